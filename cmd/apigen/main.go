@@ -0,0 +1,105 @@
+// Command apigen regenerates the OpenAPI spec of every REST endpoint from the
+// delivery packages' handlers and DTOs.
+//
+//	apigen
+//
+// Each package under internal/delivery/http that mounts routes gets a spec_gen.go
+// with its RegisterSpec, and internal/delivery/http/apispec a register_gen.go
+// calling all of them. It runs from anywhere inside the module, go generate in
+// apispec runs it; the typed client is regenerated from the result in
+// internal/apiclient.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/specgen"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "apigen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	root, modulePath, err := findModule()
+	if err != nil {
+		return err
+	}
+	deliveryDir := filepath.Join(root, "internal", "delivery", "http")
+	files, err := specgen.Generate(deliveryDir, modulePath+"/internal/delivery/http", filepath.Join(deliveryDir, "apispec"))
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	written := 0
+	for _, path := range paths {
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, files[path]) {
+			continue
+		}
+		if err := os.WriteFile(path, files[path], 0o644); err != nil {
+			return err
+		}
+		written++
+		rel, _ := filepath.Rel(root, path)
+		fmt.Fprintln(os.Stderr, "wrote", rel)
+	}
+	// Packages that stopped mounting routes lose their spec
+	stale, err := filepath.Glob(filepath.Join(deliveryDir, "*", specgen.FileName))
+	if err != nil {
+		return err
+	}
+	for _, path := range stale {
+		if _, ok := files[path]; ok {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		written++
+		rel, _ := filepath.Rel(root, path)
+		fmt.Fprintln(os.Stderr, "removed", rel)
+	}
+	fmt.Fprintf(os.Stderr, "%d packages documented, %d files changed\n", len(files)-1, written)
+	return nil
+}
+
+// findModule walks up from the working directory to go.mod
+func findModule() (dir, modulePath string, err error) {
+	dir, err = os.Getwd()
+	if err != nil {
+		return "", "", err
+	}
+	for {
+		f, err := os.Open(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			defer f.Close()
+			scanner := bufio.NewScanner(f)
+			for scanner.Scan() {
+				if path, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+					return dir, strings.TrimSpace(path), nil
+				}
+			}
+			return "", "", errors.New("go.mod has no module line")
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", errors.New("not inside a Go module")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,179 @@
+// Command audit anchors, verifies and exports the hash-chained audit log.
+//
+//	audit anchor        -anchors /mnt/audit-anchors
+//	audit verify        -anchors /mnt/audit-anchors
+//	audit export        -anchors /mnt/audit-anchors -from 1 -to 5000 -out audit-2025q2.zip
+//	audit verify-bundle -in audit-2025q2.zip -public-key <base64>
+//
+// Run anchor from a scheduler; the anchors directory should be write-once storage that the
+// database host cannot modify. Export signs with the Ed25519 seed in AUDIT_SIGNING_KEY (base64),
+// regulators verify with the matching public key. The connection string comes from DATABASE_URL,
+// the pgx driver is linked in and any other -driver must be added to the imports.
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/auditlog"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "audit:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: audit <anchor|verify|export|verify-bundle> [flags]")
+}
+
+func run(ctx context.Context, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	anchorsDir := flags.String("anchors", "", "anchor storage directory")
+	driver := flags.String("driver", "pgx", "database/sql driver name")
+	from := flags.Uint64("from", 1, "first sequence number (export)")
+	to := flags.Uint64("to", 0, "last sequence number (export)")
+	out := flags.String("out", "", "bundle file to write (export)")
+	in := flags.String("in", "", "bundle file to check (verify-bundle)")
+	publicKey := flags.String("public-key", "", "base64 Ed25519 public key (verify-bundle)")
+	flags.Parse(args)
+
+	if command == "verify-bundle" {
+		return verifyBundle(*in, *publicKey)
+	}
+
+	if *anchorsDir == "" {
+		return errors.New("-anchors is required")
+	}
+	db, err := openDB(*driver)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	repo := auditlog.NewSQLRepository(db)
+	service := app.NewService(repo, repo, auditlog.NewFileAnchorStore(*anchorsDir))
+
+	switch command {
+	case "anchor":
+		anchor, err := service.Anchor(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if anchor == nil {
+			fmt.Println("log is empty, nothing to anchor")
+			return nil
+		}
+		fmt.Printf("anchored entry %d\t%s\t%s\n", anchor.Seq, anchor.Hash, anchor.Location)
+
+	case "verify":
+		report, err := service.Verify(ctx)
+		if err != nil && report == nil {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("verification failed after %d entries: %w", report.Entries, err)
+		}
+		if report.Head == nil {
+			fmt.Println("log is empty")
+			return nil
+		}
+		fmt.Printf("%d entries ok, head %d %s, %d anchors matched\n", report.Entries, report.Head.Seq, report.Head.Hash, report.Anchors)
+
+	case "export":
+		if *out == "" {
+			return errors.New("-out is required")
+		}
+		key, err := loadSigningKey()
+		if err != nil {
+			return err
+		}
+		bundle, err := service.Export(ctx, *from, *to)
+		if err != nil {
+			return err
+		}
+		return writeBundle(*out, bundle, key)
+
+	default:
+		usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+	return nil
+}
+
+func writeBundle(path string, bundle *app.Bundle, key ed25519.PrivateKey) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	manifest, err := auditlog.WriteBundle(f, bundle, key, time.Now())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	fmt.Printf("exported entries %d-%d to %s, head %s\n", manifest.FromSeq, manifest.ToSeq, path, manifest.HeadHash)
+	return nil
+}
+
+func verifyBundle(path, encodedKey string) error {
+	if path == "" {
+		return errors.New("-in is required")
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New("-public-key must be a base64 Ed25519 public key")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	manifest, err := auditlog.VerifyBundle(f, info.Size(), ed25519.PublicKey(key))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("bundle ok: entries %d-%d, previous %s, head %s\n", manifest.FromSeq, manifest.ToSeq, manifest.PreviousHash, manifest.HeadHash)
+	return nil
+}
+
+func openDB(driver string) (*sql.DB, error) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return nil, errors.New("DATABASE_URL is not set")
+	}
+	return sql.Open(driver, dsn)
+}
+
+func loadSigningKey() (ed25519.PrivateKey, error) {
+	seed, err := base64.StdEncoding.DecodeString(os.Getenv("AUDIT_SIGNING_KEY"))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, errors.New("AUDIT_SIGNING_KEY must be a base64 Ed25519 seed")
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
@@ -0,0 +1,217 @@
+// Command backup creates, verifies, restores and prunes encrypted logical backups of the CMS database.
+//
+//	backup create  -store /mnt/backups -policy policy.json
+//	backup list    -store /mnt/backups
+//	backup verify  -store /mnt/backups -id 20250701T020000Z
+//	backup restore -store /mnt/backups -id 20250701T020000Z -confirm <database name>
+//	backup prune   -store /mnt/backups -keep-days 14 -keep-months 12
+//	backup create  -store s3://cms-backups/production -policy policy.json
+//
+// -store is a directory or an s3://bucket/prefix URL. Buckets are reached with AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, the optional AWS_SESSION_TOKEN and S3_REGION, S3_ENDPOINT points the
+// store at another S3 compatible service such as MinIO or R2.
+//
+// Archive keys come from BACKUP_KEYS ("id=base64,..." with the active key first), the PII column key
+// from BACKUP_PII_KEY and the connection string from DATABASE_URL. The pgx driver is linked in, other
+// database/sql drivers named by -driver must be added to the imports.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/backup"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "backup:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: backup <create|list|verify|restore|prune> [flags]")
+}
+
+func run(ctx context.Context, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	storeLocation := flags.String("store", "", "backup storage directory or s3://bucket/prefix")
+	driver := flags.String("driver", "pgx", "database/sql driver name")
+	policyPath := flags.String("policy", "", "backup policy file (create)")
+	id := flags.String("id", "", "backup ID (verify, restore)")
+	confirm := flags.String("confirm", "", "name of the database being overwritten (restore)")
+	keepDays := flags.Int("keep-days", 14, "days of backups to keep (prune)")
+	keepMonths := flags.Int("keep-months", 12, "months to keep one backup of (prune)")
+	flags.Parse(args)
+
+	store, err := openStore(*storeLocation)
+	if err != nil {
+		return err
+	}
+
+	keys, err := backup.ParseKeys(os.Getenv("BACKUP_KEYS"))
+	if err != nil {
+		return fmt.Errorf("BACKUP_KEYS: %w", err)
+	}
+	piiKey, err := loadPIIKey()
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "create":
+		policy, err := loadPolicy(*policyPath)
+		if err != nil {
+			return err
+		}
+		db, err := openDB(*driver)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		m, err := backup.Create(ctx, backup.NewSQLSource(db), store, policy, keys, piiKey, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created %s: %d tables, %d bytes\n", m.ID, len(m.Tables), m.ArchiveSize)
+
+	case "list":
+		manifests, err := backup.List(ctx, store, keys)
+		if err != nil {
+			return err
+		}
+		for _, m := range manifests {
+			fmt.Printf("%s\t%s\tkey=%s\tpolicy=v%d\t%d bytes\n", m.ID, m.CreatedAt.Format(time.RFC3339), m.KeyID, m.PolicyVersion, m.ArchiveSize)
+		}
+
+	case "verify":
+		if *id == "" {
+			return errors.New("-id is required")
+		}
+		m, err := backup.Restore(ctx, store, *id, keys, nil, backup.DiscardSink{})
+		if err != nil {
+			return err
+		}
+		for _, table := range m.Tables {
+			fmt.Printf("%s\t%d rows\tok\n", table, m.Stats[table].Rows)
+		}
+
+	case "restore":
+		if *id == "" {
+			return errors.New("-id is required")
+		}
+		db, err := openDB(*driver)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		var current string
+		if err := db.QueryRowContext(ctx, "SELECT current_database()").Scan(&current); err != nil {
+			return err
+		}
+		if *confirm != current {
+			return fmt.Errorf("restore overwrites database %q, pass -confirm %s to continue", current, current)
+		}
+
+		m, err := backup.Restore(ctx, store, *id, keys, piiKey, backup.NewSQLSink(db))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("restored %s into %s: %d tables\n", m.ID, current, len(m.Tables))
+
+	case "prune":
+		deleted, err := backup.Prune(ctx, store, keys, backup.Retention{KeepDays: *keepDays, KeepMonths: *keepMonths}, time.Now())
+		for _, id := range deleted {
+			fmt.Println("deleted", id)
+		}
+		return err
+
+	default:
+		usage()
+		return fmt.Errorf("unknown command %q", command)
+	}
+	return nil
+}
+
+// openStore picks the object store from the -store flag
+func openStore(location string) (backup.ObjectStore, error) {
+	if location == "" {
+		return nil, errors.New("-store is required")
+	}
+	rest, ok := strings.CutPrefix(location, "s3://")
+	if !ok {
+		return backup.NewFileStore(location), nil
+	}
+
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("-store %s has no bucket", location)
+	}
+	cfg := backup.S3Config{
+		Region:          os.Getenv("S3_REGION"),
+		Bucket:          bucket,
+		Prefix:          prefix,
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if cfg.Region == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("S3_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for an s3:// store")
+	}
+	return backup.NewS3Store(nil, os.Getenv("S3_ENDPOINT"), cfg, shared.SystemClock{}), nil
+}
+
+func openDB(driver string) (*sql.DB, error) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return nil, errors.New("DATABASE_URL is not set")
+	}
+	return sql.Open(driver, dsn)
+}
+
+func loadPolicy(path string) (*backup.Policy, error) {
+	if path == "" {
+		return nil, errors.New("-policy is required")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return backup.LoadPolicy(f)
+}
+
+// loadPIIKey returns nil when BACKUP_PII_KEY is unset, restores then keep PII columns sealed
+func loadPIIKey() ([]byte, error) {
+	raw := os.Getenv("BACKUP_PII_KEY")
+	if raw == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil || len(key) != backup.KeySize {
+		return nil, fmt.Errorf("BACKUP_PII_KEY: %w", backup.ErrInvalidKey)
+	}
+	return key, nil
+}
@@ -0,0 +1,121 @@
+// Package apiclient is a typed client of the CMS REST API for internal
+// consumers. The methods and DTOs in client_gen.go are generated from the
+// OpenAPI document the delivery packages publish, so they follow the handlers.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+//go:generate go run ../../cmd/apiclientgen
+
+// Client calls the API at a base URL, e.g. https://cms.internal
+type Client struct {
+	baseURL string
+	http    *http.Client
+
+	// Header is sent with every request, e.g. Authorization
+	Header http.Header
+}
+
+// New returns a client of the API at baseURL, http.DefaultClient when httpClient is nil
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: strings.TrimSuffix(baseURL, "/"), http: httpClient, Header: http.Header{}}
+}
+
+// APIError is an answer outside 2xx
+type APIError struct {
+	Status  int
+	Message string // The error field of the body, if any
+	Body    []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("apiclient: %d %s: %s", e.Status, http.StatusText(e.Status), e.Message)
+	}
+	return fmt.Sprintf("apiclient: %d %s", e.Status, http.StatusText(e.Status))
+}
+
+// rawBody is a request body that is not JSON
+type rawBody struct {
+	body        io.Reader
+	contentType string
+}
+
+// do sends a request, in JSON encoded unless it is nil or a rawBody, and
+// decodes a 2xx answer into out: raw into a *[]byte, JSON into anything else.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	contentType := ""
+	switch in := in.(type) {
+	case nil:
+	case rawBody:
+		body, contentType = in.body, in.contentType
+	default:
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("apiclient: encode request: %w", err)
+		}
+		body, contentType = bytes.NewReader(payload), "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.Header {
+		req.Header[key] = values
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{Status: resp.StatusCode, Body: data}
+		var payload struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &payload) == nil {
+			apiErr.Message = payload.Error
+		}
+		return apiErr
+	}
+
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*out = data
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("apiclient: decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/apispec"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/mobile"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/clientgen"
+)
+
+type stubFeedSource struct {
+	teasers []mobile.Teaser
+}
+
+func (s *stubFeedSource) ListTeasers(ctx context.Context, after *mobile.Cursor, limit int) ([]mobile.Teaser, error) {
+	if limit > len(s.teasers) {
+		return s.teasers, nil
+	}
+	return s.teasers[:limit], nil
+}
+
+func TestClient_MobileFeed(t *testing.T) {
+	source := &stubFeedSource{teasers: []mobile.Teaser{
+		{ID: "a", Slug: "a", Title: "A", PublishedAt: time.Unix(1700000000, 0)},
+		{ID: "b", Slug: "b", Title: "B", PublishedAt: time.Unix(1690000000, 0)},
+	}}
+	server := httptest.NewServer(mobile.NewRouter(source))
+	defer server.Close()
+	client := New(server.URL, server.Client())
+
+	limit := 1
+	feed, err := client.MobileFeed(context.Background(), MobileFeedParams{Limit: &limit})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(feed.Items) != 1 || feed.Items[0].ID != "a" || feed.Items[0].PublishedAt != 1700000000 || feed.NextCursor == "" {
+		t.Errorf("unexpected feed: %+v", feed)
+	}
+
+	limit = 100
+	_, err = client.MobileFeed(context.Background(), MobileFeedParams{Limit: &limit})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest || apiErr.Message != "limit must be between 1 and 30" {
+		t.Errorf("expected a 400 APIError, got %v", err)
+	}
+}
+
+func TestClient_SendsBodyAndPath(t *testing.T) {
+	var gotPath, gotType, gotAuth string
+	var got AccessibilityPolicyRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotType, gotAuth = r.URL.EscapedPath(), r.Header.Get("Content-Type"), r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"site_id":"site 1","strictness":"strict"}`))
+	}))
+	defer server.Close()
+	client := New(server.URL+"/", nil)
+	client.Header.Set("Authorization", "Bearer token")
+
+	policy, err := client.AccessibilitySetPolicy(context.Background(), AccessibilitySetPolicyParams{SiteID: "site 1"}, AccessibilityPolicyRequest{Strictness: "strict"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/admin/sites/site%201/accessibility/policy" || gotType != "application/json" || gotAuth != "Bearer token" {
+		t.Errorf("unexpected request: %s %s %s", gotPath, gotType, gotAuth)
+	}
+	if got.Strictness != "strict" || policy.SiteID != "site 1" || policy.UpdatedAt != nil {
+		t.Errorf("unexpected round trip: %+v %+v", got, policy)
+	}
+}
+
+// The client must be regenerated whenever the specs change
+func TestGeneratedClientUpToDate(t *testing.T) {
+	want, err := clientgen.Generate(apispec.Document(), "apiclient")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	current, err := os.ReadFile("client_gen.go")
+	if err != nil || !bytes.Equal(current, want) {
+		t.Errorf("client_gen.go is stale, run go generate ./internal/apiclient")
+	}
+}
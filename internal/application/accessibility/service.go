@@ -0,0 +1,117 @@
+package accessibility
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/serp"
+)
+
+// Headlines measures titles for search results and social cards, the serp service implements it
+type Headlines interface {
+	Preview(headline, seoTitle, description string) []serp.Preview
+}
+
+// Draft is the article as an editor submits it for review or publishing
+type Draft struct {
+	Title           string
+	SEOTitle        string
+	MetaDescription string
+	Language        string
+	Body            string
+}
+
+// Review is the publish checklist: the accessibility audit plus headline
+// length warnings. Only the audit can block publishing.
+type Review struct {
+	Checklist *accessibility.Checklist
+	Headlines []serp.Preview
+}
+
+// Warnings counts headline previews with at least one warning
+func (r *Review) Warnings() int {
+	n := 0
+	for _, p := range r.Headlines {
+		if len(p.Warnings) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+type Service struct {
+	policies  accessibility.PolicyRepository
+	inspector accessibility.Inspector
+	headlines Headlines
+}
+
+func NewService(policies accessibility.PolicyRepository, inspector accessibility.Inspector, headlines Headlines) *Service {
+	return &Service{policies: policies, inspector: inspector, headlines: headlines}
+}
+
+// Review audits a draft against the site's policy for the review screen
+func (s *Service) Review(ctx context.Context, siteID string, d Draft) (*Review, error) {
+	policy, err := s.Policy(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	return &Review{
+		Checklist: policy.Audit(s.inspector.Inspect(d.Title, d.Language, d.Body)),
+		Headlines: s.headlines.Preview(d.Title, d.SEOTitle, d.MetaDescription),
+	}, nil
+}
+
+// Audit runs only the accessibility checklist, for the publish checklist
+// which has no use for headline previews
+func (s *Service) Audit(ctx context.Context, siteID, title, language, body string) (*accessibility.Checklist, error) {
+	policy, err := s.Policy(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	return policy.Audit(s.inspector.Inspect(title, language, body)), nil
+}
+
+// CheckPublish is the guard the article service calls before it publishes.
+// It returns the audit together with ErrPublishBlocked when a required check
+// fails, so editors see what to fix. Headline warnings never block, so they
+// are left out.
+func (s *Service) CheckPublish(ctx context.Context, siteID, title, language, body string) (*accessibility.Checklist, error) {
+	audit, err := s.Audit(ctx, siteID, title, language, body)
+	if err != nil {
+		return nil, err
+	}
+	if blocking := audit.Blocking(); len(blocking) > 0 {
+		names := make([]string, len(blocking))
+		for i, c := range blocking {
+			names[i] = string(c)
+		}
+		return audit, fmt.Errorf("%w: %s", accessibility.ErrPublishBlocked, strings.Join(names, ", "))
+	}
+	return audit, nil
+}
+
+// Policy returns the site's policy, the default until one is saved
+func (s *Service) Policy(ctx context.Context, siteID string) (*accessibility.Policy, error) {
+	policy, err := s.policies.FindBySite(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accessibility policy: %w", err)
+	}
+	if policy == nil {
+		return accessibility.DefaultPolicy(siteID), nil
+	}
+	return policy, nil
+}
+
+func (s *Service) SetPolicy(ctx context.Context, staffID, siteID string, strictness accessibility.Strictness, at time.Time) (*accessibility.Policy, error) {
+	policy, err := accessibility.NewPolicy(siteID, strictness, staffID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policies.Save(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to save accessibility policy: %w", err)
+	}
+	return policy, nil
+}
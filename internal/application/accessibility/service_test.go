@@ -0,0 +1,119 @@
+package accessibility
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/serp"
+)
+
+type memoryPolicies struct {
+	policies map[string]*accessibility.Policy
+}
+
+func (m *memoryPolicies) Save(ctx context.Context, policy *accessibility.Policy) error {
+	m.policies[policy.SiteID] = policy
+	return nil
+}
+
+func (m *memoryPolicies) FindBySite(ctx context.Context, siteID string) (*accessibility.Policy, error) {
+	return m.policies[siteID], nil
+}
+
+// fakeInspector reads "img" and "table" markers instead of HTML
+type fakeInspector struct{}
+
+func (fakeInspector) Inspect(title, language, body string) accessibility.Content {
+	c := accessibility.Content{Title: title, Language: language}
+	for _, part := range strings.Fields(body) {
+		switch part {
+		case "img":
+			c.Images = append(c.Images, accessibility.Image{Src: "a.jpg"})
+		case "img-alt":
+			c.Images = append(c.Images, accessibility.Image{Src: "a.jpg", Alt: "Banjir", HasAlt: true})
+		case "h4":
+			c.Headings = append(c.Headings, 4)
+		}
+	}
+	return c
+}
+
+// fakeHeadlines measures the title on a single 100px surface
+type fakeHeadlines struct{}
+
+func (fakeHeadlines) Preview(headline, seoTitle, description string) []serp.Preview {
+	surface := serp.Surface{Name: serp.SurfaceGoogleTitle, Field: serp.FieldTitle, Font: serp.Font{SizePx: 20}, LineWidthPx: 100, Lines: 1}
+	return []serp.Preview{surface.Measure(headline)}
+}
+
+func createTestService(t *testing.T) *Service {
+	t.Helper()
+	return NewService(&memoryPolicies{policies: map[string]*accessibility.Policy{}}, fakeInspector{}, fakeHeadlines{})
+}
+
+func TestService_CheckPublish(t *testing.T) {
+	testCases := []struct {
+		name        string
+		strictness  accessibility.Strictness
+		body        string
+		expectedErr error
+	}{
+		{"passes", accessibility.StrictnessStandard, "img-alt", nil},
+		{"missing alt blocks by default", "", "img", accessibility.ErrPublishBlocked},
+		{"missing alt allowed when relaxed", accessibility.StrictnessRelaxed, "img", nil},
+		{"heading skip allowed at standard", accessibility.StrictnessStandard, "img-alt h4", nil},
+		{"heading skip blocks strict", accessibility.StrictnessStrict, "img-alt h4", accessibility.ErrPublishBlocked},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := createTestService(t)
+			ctx := context.Background()
+			if tc.strictness != "" {
+				if _, err := service.SetPolicy(ctx, "editor-1", "site-1", tc.strictness, time.Now()); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			audit, err := service.CheckPublish(ctx, "site-1", "Banjir", "id", tc.body)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if audit == nil {
+				t.Fatal("expected the checklist even when blocked")
+			}
+		})
+	}
+}
+
+func TestService_ReviewHeadlines(t *testing.T) {
+	service := createTestService(t)
+	draft := Draft{Title: "Banjir rendam ribuan rumah di Jakarta", Language: "id", Body: "img-alt"}
+	review, err := service.Review(context.Background(), "site-1", draft)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(review.Headlines) != 1 || !review.Headlines[0].Truncated || review.Warnings() != 1 {
+		t.Errorf("expected a truncated headline warning, got %+v", review.Headlines)
+	}
+	if _, err := service.CheckPublish(context.Background(), "site-1", draft.Title, draft.Language, draft.Body); err != nil {
+		t.Errorf("expected headline warnings not to block, got %v", err)
+	}
+}
+
+func TestService_Policy(t *testing.T) {
+	service := createTestService(t)
+	ctx := context.Background()
+
+	policy, _ := service.Policy(ctx, "site-9")
+	if policy.Strictness != accessibility.DefaultStrictness {
+		t.Errorf("expected default strictness, got %s", policy.Strictness)
+	}
+	if _, err := service.SetPolicy(ctx, "editor-1", "site-9", "lenient", time.Now()); err != accessibility.ErrInvalidStrictness {
+		t.Errorf("expected error '%v', got '%v'", accessibility.ErrInvalidStrictness, err)
+	}
+}
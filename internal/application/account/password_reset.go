@@ -0,0 +1,164 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// PasswordResetNotifier emails the reset link carrying the raw token
+type PasswordResetNotifier interface {
+	SendPasswordReset(ctx context.Context, email account.Email, token string, reset *account.PasswordReset) error
+}
+
+// SessionRevoker signs an account out everywhere (implemented by the auth module)
+type SessionRevoker interface {
+	RevokeAll(ctx context.Context, accountID string) error
+}
+
+type PasswordResetService struct {
+	accounts account.UserAccountRepository
+	resets   account.PasswordResetRepository
+	policies *PolicyService
+	hasher   account.PasswordHasher
+	sessions SessionRevoker
+	notifier PasswordResetNotifier
+	tx       shared.Transactor
+	clock    shared.Clock
+}
+
+func NewPasswordResetService(accounts account.UserAccountRepository, resets account.PasswordResetRepository, policies *PolicyService, hasher account.PasswordHasher, sessions SessionRevoker, notifier PasswordResetNotifier, tx shared.Transactor, clock shared.Clock) *PasswordResetService {
+	return &PasswordResetService{accounts: accounts, resets: resets, policies: policies, hasher: hasher, sessions: sessions, notifier: notifier, tx: tx, clock: clock}
+}
+
+// RequestPasswordReset emails a single-use reset link and revokes any earlier
+// one. Unknown and inactive addresses succeed silently, so the form cannot be
+// used to find out who has an account.
+func (s *PasswordResetService) RequestPasswordReset(ctx context.Context, rawEmail, ip string) error {
+	email, err := account.NewEmail(rawEmail)
+	if err != nil {
+		return err
+	}
+	acc, err := s.accounts.FindActiveByEmail(ctx, email.String())
+	if err != nil {
+		return fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil {
+		return nil
+	}
+
+	now := s.clock.Now()
+	if err := s.resets.RevokeOutstanding(ctx, acc.ID, now); err != nil {
+		return fmt.Errorf("failed to revoke earlier reset links: %w", err)
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset ID: %w", err)
+	}
+	token, hash, err := newResetToken()
+	if err != nil {
+		return err
+	}
+	reset, err := account.NewPasswordReset(id, acc.ID, hash, ip, now)
+	if err != nil {
+		return err
+	}
+	if err := s.resets.Create(ctx, reset); err != nil {
+		return fmt.Errorf("failed to save password reset: %w", err)
+	}
+
+	if err := s.notifier.SendPasswordReset(ctx, acc.Email, token, reset); err != nil {
+		return fmt.Errorf("failed to send password reset: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password from an emailed link. The password is
+// checked against the account type's policy before the link is used up, so a
+// rejected password can be retried with the same link. The link is redeemed with
+// a conditional update in the same transaction as the password change, so two
+// requests racing with one link cannot both succeed. Every session of the
+// account is signed out afterwards.
+func (s *PasswordResetService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	hash := hashResetToken(token)
+	reset, err := s.resets.FindByTokenHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to load password reset: %w", err)
+	}
+	if reset == nil {
+		return account.ErrInvalidResetToken
+	}
+	now := s.clock.Now()
+	if err := reset.Check(hash, now); err != nil {
+		return err
+	}
+
+	acc, err := s.accounts.FindByID(ctx, reset.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil || !acc.IsActive() {
+		return account.ErrInvalidResetToken
+	}
+	if err := s.policies.ValidatePassword(ctx, acc.Type, newPassword); err != nil {
+		return err
+	}
+
+	hashed, err := s.hasher.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	acc.SetClock(s.clock)
+	if err := acc.ResetPasswordHash(hashed, acc.ID); err != nil {
+		return err
+	}
+	if err := reset.Redeem(hash, now); err != nil {
+		return err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		redeemed, err := s.resets.MarkRedeemed(ctx, reset)
+		if err != nil {
+			return fmt.Errorf("failed to redeem password reset: %w", err)
+		}
+		if !redeemed {
+			return account.ErrResetTokenUsed
+		}
+		if err := s.accounts.Update(ctx, acc); err != nil {
+			return fmt.Errorf("failed to update account: %w", err)
+		}
+		if err := s.resets.RevokeOutstanding(ctx, acc.ID, now); err != nil {
+			return fmt.Errorf("failed to revoke other reset links: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.sessions.RevokeAll(ctx, acc.ID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+// newResetToken returns the URL-safe token to email and the hash to store
+func newResetToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate reset token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashResetToken(token), nil
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,196 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memoryAccounts struct {
+	account.UserAccountRepository
+	byID map[string]*account.UserAccount
+}
+
+func (m *memoryAccounts) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return m.byID[id], nil
+}
+
+func (m *memoryAccounts) FindActiveByEmail(ctx context.Context, email string) (*account.UserAccount, error) {
+	for _, acc := range m.byID {
+		if acc.Email.String() == email && acc.IsActive() {
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAccounts) Update(ctx context.Context, acc *account.UserAccount) error {
+	m.byID[acc.ID] = acc
+	return nil
+}
+
+// memoryResets hands out copies like a database would. stale makes reads miss
+// redemptions, as a request that loaded the link just before another used it.
+type memoryResets struct {
+	resets []*account.PasswordReset
+	stale  bool
+}
+
+func (m *memoryResets) Create(ctx context.Context, reset *account.PasswordReset) error {
+	m.resets = append(m.resets, reset)
+	return nil
+}
+
+func (m *memoryResets) MarkRedeemed(ctx context.Context, reset *account.PasswordReset) (bool, error) {
+	for _, r := range m.resets {
+		if r.ID == reset.ID {
+			if r.UsedAt != nil || r.RevokedAt != nil {
+				return false, nil
+			}
+			r.UsedAt = reset.UsedAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryResets) FindByTokenHash(ctx context.Context, tokenHash string) (*account.PasswordReset, error) {
+	for _, r := range m.resets {
+		if r.TokenHash == tokenHash {
+			found := *r
+			if m.stale {
+				found.UsedAt = nil
+			}
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryResets) RevokeOutstanding(ctx context.Context, accountID string, at time.Time) error {
+	for _, r := range m.resets {
+		if r.AccountID == accountID {
+			r.Revoke(at)
+		}
+	}
+	return nil
+}
+
+type fakeHasher struct{}
+
+func (fakeHasher) Hash(raw string) (string, error) {
+	return "hashed:" + raw, nil
+}
+
+func (fakeHasher) Compare(raw, encoded string) (bool, error) {
+	return encoded == "hashed:"+raw, nil
+}
+
+type fakeSessions struct {
+	revoked []string
+}
+
+func (f *fakeSessions) RevokeAll(ctx context.Context, accountID string) error {
+	f.revoked = append(f.revoked, accountID)
+	return nil
+}
+
+// fakeResetNotifier keeps the last emailed token
+type fakeResetNotifier struct {
+	tokens []string
+}
+
+func (f *fakeResetNotifier) SendPasswordReset(ctx context.Context, email account.Email, token string, reset *account.PasswordReset) error {
+	f.tokens = append(f.tokens, token)
+	return nil
+}
+
+func newTestResetService(t *testing.T) (*PasswordResetService, *account.UserAccount, *fakeSessions, *fakeResetNotifier, *shared.FrozenClock, *memoryResets) {
+	t.Helper()
+	member, _ := account.NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed:OldPass123!")
+	_ = member.SelfVerify()
+	member.FailedLoginAttempts = 5
+
+	clock := shared.NewFrozenClock(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	sessions := &fakeSessions{}
+	notifier := &fakeResetNotifier{}
+	policies := NewPolicyService(&memoryPolicies{policies: map[account.UserAccountType]*account.SecurityPolicy{}}, clock)
+	accounts := &memoryAccounts{byID: map[string]*account.UserAccount{member.ID: member}}
+	resets := &memoryResets{}
+	service := NewPasswordResetService(accounts, resets, policies, fakeHasher{}, sessions, notifier, shared.NoTransaction{}, clock)
+	return service, member, sessions, notifier, clock, resets
+}
+
+func TestPasswordResetService_Reset(t *testing.T) {
+	service, member, sessions, notifier, _, _ := newTestResetService(t)
+	ctx := context.Background()
+
+	if err := service.RequestPasswordReset(ctx, "Member@Example.com", "203.0.113.9"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.RequestPasswordReset(ctx, "nobody@example.com", "203.0.113.9"); err != nil || len(notifier.tokens) != 1 {
+		t.Fatalf("expected unknown addresses to succeed silently, got %d emails %v", len(notifier.tokens), err)
+	}
+	token := notifier.tokens[0]
+
+	// A rejected password does not use up the link
+	if err := service.ResetPassword(ctx, token, "short"); !errors.Is(err, account.ErrPasswordTooShort) {
+		t.Errorf("expected error '%v', got '%v'", account.ErrPasswordTooShort, err)
+	}
+	if err := service.ResetPassword(ctx, token, "NewPass456!"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member.PasswordHash.Encoded() != "hashed:NewPass456!" || member.FailedLoginAttempts != 0 || *member.LastActionBy != member.ID {
+		t.Errorf("expected new password and cleared lockout, got %+v", member)
+	}
+	if len(sessions.revoked) != 1 || sessions.revoked[0] != member.ID {
+		t.Errorf("expected every session revoked, got %v", sessions.revoked)
+	}
+
+	if err := service.ResetPassword(ctx, token, "OtherPass789!"); !errors.Is(err, account.ErrInvalidResetToken) {
+		t.Errorf("expected error '%v', got '%v'", account.ErrInvalidResetToken, err)
+	}
+}
+
+func TestPasswordResetService_ExpiredAndSuperseded(t *testing.T) {
+	service, _, _, notifier, clock, _ := newTestResetService(t)
+	ctx := context.Background()
+
+	_ = service.RequestPasswordReset(ctx, "member@example.com", "")
+	_ = service.RequestPasswordReset(ctx, "member@example.com", "")
+	if err := service.ResetPassword(ctx, notifier.tokens[0], "NewPass456!"); !errors.Is(err, account.ErrInvalidResetToken) {
+		t.Errorf("expected the earlier link revoked, got '%v'", err)
+	}
+
+	clock.Advance(account.PasswordResetTTL)
+	if err := service.ResetPassword(ctx, notifier.tokens[1], "NewPass456!"); !errors.Is(err, account.ErrInvalidResetToken) {
+		t.Errorf("expected the link expired, got '%v'", err)
+	}
+	if err := service.ResetPassword(ctx, "made-up-token", "NewPass456!"); !errors.Is(err, account.ErrInvalidResetToken) {
+		t.Errorf("expected error '%v', got '%v'", account.ErrInvalidResetToken, err)
+	}
+}
+
+func TestPasswordResetService_ConcurrentRedeem(t *testing.T) {
+	service, _, sessions, notifier, _, resets := newTestResetService(t)
+	ctx := context.Background()
+
+	_ = service.RequestPasswordReset(ctx, "member@example.com", "")
+	token := notifier.tokens[0]
+	if err := service.ResetPassword(ctx, token, "NewPass456!"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A second request that loaded the link before the first one committed
+	resets.stale = true
+	if err := service.ResetPassword(ctx, token, "OtherPass789!"); !errors.Is(err, account.ErrInvalidResetToken) {
+		t.Errorf("expected the second redemption rejected, got '%v'", err)
+	}
+	if len(sessions.revoked) != 1 {
+		t.Errorf("expected only the first reset to complete, got %d", len(sessions.revoked))
+	}
+}
@@ -0,0 +1,111 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// PolicyCacheTTL bounds how long a policy change takes to reach every instance
+const PolicyCacheTTL = 30 * time.Second
+
+type cachedPolicy struct {
+	policy    *account.SecurityPolicy
+	fetchedAt time.Time
+}
+
+// PolicyService resolves the password and lockout policy per account type from the settings store.
+// Policies are cached briefly since every login and password change reads them.
+type PolicyService struct {
+	policies account.SecurityPolicyRepository
+	clock    shared.Clock
+
+	mu    sync.Mutex
+	cache map[account.UserAccountType]cachedPolicy
+}
+
+func NewPolicyService(policies account.SecurityPolicyRepository, clock shared.Clock) *PolicyService {
+	return &PolicyService{policies: policies, clock: clock, cache: make(map[account.UserAccountType]cachedPolicy)}
+}
+
+// Resolve returns the configured policy or the default one. When the settings store is
+// down a stale cached policy is used rather than falling back to the looser default.
+func (s *PolicyService) Resolve(ctx context.Context, accountType account.UserAccountType) (*account.SecurityPolicy, error) {
+	s.mu.Lock()
+	cached, ok := s.cache[accountType]
+	s.mu.Unlock()
+	if ok && s.clock.Now().Sub(cached.fetchedAt) < PolicyCacheTTL {
+		return cached.policy, nil
+	}
+
+	policy, err := s.policies.FindByAccountType(ctx, accountType)
+	if err != nil {
+		if ok {
+			return cached.policy, nil
+		}
+		return nil, fmt.Errorf("failed to load security policy: %w", err)
+	}
+	if policy == nil {
+		policy = account.DefaultSecurityPolicy(accountType)
+	}
+
+	s.mu.Lock()
+	s.cache[accountType] = cachedPolicy{policy: policy, fetchedAt: s.clock.Now()}
+	s.mu.Unlock()
+	return policy, nil
+}
+
+// List returns the effective policy of every account type
+func (s *PolicyService) List(ctx context.Context) ([]*account.SecurityPolicy, error) {
+	var policies []*account.SecurityPolicy
+	for _, accountType := range account.AccountTypes() {
+		policy, err := s.Resolve(ctx, accountType)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// Update replaces the policy of an account type, it applies to new passwords and failed logins
+// from now on; existing passwords are not re-checked
+func (s *PolicyService) Update(ctx context.Context, adminID string, accountType account.UserAccountType, password account.PasswordPolicy, lockout account.LockoutPolicy) (*account.SecurityPolicy, error) {
+	policy, err := account.NewSecurityPolicy(accountType, password, lockout, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.policies.Save(ctx, policy); err != nil {
+		return nil, fmt.Errorf("failed to save security policy: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cache[accountType] = cachedPolicy{policy: policy, fetchedAt: s.clock.Now()}
+	s.mu.Unlock()
+	return policy, nil
+}
+
+// ValidatePassword checks a new password against the account type's policy
+func (s *PolicyService) ValidatePassword(ctx context.Context, accountType account.UserAccountType, raw string) error {
+	policy, err := s.Resolve(ctx, accountType)
+	if err != nil {
+		return err
+	}
+	return account.ValidatePassword(raw, policy.Password)
+}
+
+// RecordFailedLogin counts a failed login and locks the account per the lockout policy;
+// the caller persists the account. The lock runs on the service's clock.
+func (s *PolicyService) RecordFailedLogin(ctx context.Context, acc *account.UserAccount, ipAddress string) error {
+	policy, err := s.Resolve(ctx, acc.Type)
+	if err != nil {
+		return err
+	}
+	acc.SetClock(s.clock)
+	return acc.RecordFailedLogin(ipAddress, policy.Lockout.MaxAttempts, policy.Lockout.LockDuration)
+}
@@ -0,0 +1,96 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memoryPolicies struct {
+	policies map[account.UserAccountType]*account.SecurityPolicy
+	reads    int
+	err      error
+}
+
+func (m *memoryPolicies) Save(ctx context.Context, policy *account.SecurityPolicy) error {
+	m.policies[policy.AccountType] = policy
+	return nil
+}
+
+func (m *memoryPolicies) FindByAccountType(ctx context.Context, accountType account.UserAccountType) (*account.SecurityPolicy, error) {
+	m.reads++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.policies[accountType], nil
+}
+
+func TestPolicyService_TightenInternalPolicy(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryPolicies{policies: map[account.UserAccountType]*account.SecurityPolicy{}}
+	clock := shared.NewFrozenClock(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	service := NewPolicyService(store, clock)
+
+	if err := service.ValidatePassword(ctx, account.TypeInternal, "StaffPass123!"); err != nil {
+		t.Fatalf("expected default policy to accept, got %v", err)
+	}
+
+	strict := account.PasswordPolicy{MinLength: 16, RequireUpper: true, RequireLower: true, RequireNumber: true, RequireSpecial: true}
+	if _, err := service.Update(ctx, "admin123", account.TypeInternal, strict, account.LockoutPolicy{MaxAttempts: 3, LockDuration: time.Hour}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := service.ValidatePassword(ctx, account.TypeInternal, "StaffPass123!"); !errors.Is(err, account.ErrPasswordTooShort) {
+		t.Errorf("expected error '%v', got '%v'", account.ErrPasswordTooShort, err)
+	}
+	if err := service.ValidatePassword(ctx, account.TypeMembership, "StaffPass123!"); err != nil {
+		t.Errorf("expected other account types to keep the default, got %v", err)
+	}
+
+	staff, _ := account.NewUserAccountForTesting("s1", "editor", "editor@example.com", "StaffPass123!", account.TypeInternal, "admin123")
+	for i := 0; i < 3; i++ {
+		if err := service.RecordFailedLogin(ctx, staff, "10.0.0.1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !staff.IsLocked() || staff.LockedUntil.Sub(clock.Now()) != time.Hour {
+		t.Errorf("expected lock for an hour after 3 attempts, got %v", staff.LockedUntil)
+	}
+	clock.Advance(time.Hour)
+	if staff.IsLocked() {
+		t.Error("expected lock over after an hour")
+	}
+}
+
+func TestPolicyService_CachesAndKeepsStalePolicyOnOutage(t *testing.T) {
+	ctx := context.Background()
+	strict, _ := account.NewSecurityPolicy(account.TypeInternal, account.PasswordPolicy{MinLength: 20}, account.DefaultLockoutPolicy, "admin123")
+	store := &memoryPolicies{policies: map[account.UserAccountType]*account.SecurityPolicy{account.TypeInternal: strict}}
+	clock := shared.NewFrozenClock(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	service := NewPolicyService(store, clock)
+
+	for i := 0; i < 3; i++ {
+		if _, err := service.Resolve(ctx, account.TypeInternal); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if store.reads != 1 {
+		t.Errorf("expected one settings read, got %d", store.reads)
+	}
+
+	// Expire the cache entry and take the settings store down
+	clock.Advance(PolicyCacheTTL)
+	store.err = errors.New("settings unavailable")
+
+	policy, err := service.Resolve(ctx, account.TypeInternal)
+	if err != nil || policy.Password.MinLength != 20 {
+		t.Errorf("expected stale strict policy, got %v, %v", policy, err)
+	}
+	if _, err := service.Resolve(ctx, account.TypePartner); err == nil {
+		t.Error("expected error but got none")
+	}
+}
@@ -0,0 +1,82 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Safety limits for fuzzy search
+const (
+	// MaxSearchCandidates bounds how many rows the trigram index may return for ranking
+	MaxSearchCandidates = 200
+	SearchTimeout       = 2 * time.Second
+)
+
+// ListResult is one page of the admin account list
+type ListResult struct {
+	Items []account.SearchResult
+	Total int64 // Exact for plain listing, number of ranked matches for searches
+}
+
+type AdminListService struct {
+	accounts account.UserAccountRepository
+	search   account.AccountSearchRepository
+}
+
+func NewAdminListService(accounts account.UserAccountRepository, search account.AccountSearchRepository) *AdminListService {
+	return &AdminListService{accounts: accounts, search: search}
+}
+
+// List backs the admin account list. Without a search query it pages through the
+// repository; with one it ranks fuzzy matches, ignoring OrderBy in favour of relevance.
+func (s *AdminListService) List(ctx context.Context, filter *account.UserAccountFilter) (*ListResult, error) {
+	filter.SetDefaults()
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	if filter.SearchQuery == nil || *filter.SearchQuery == "" {
+		return s.list(ctx, filter)
+	}
+
+	query, err := account.NewSearchQuery(*filter.SearchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, SearchTimeout)
+	defer cancel()
+
+	candidates, err := s.search.FindSearchCandidates(ctx, *query, filter, MaxSearchCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search accounts: %w", err)
+	}
+
+	ranked := account.RankSearchResults(candidates, *query)
+	result := &ListResult{Total: int64(len(ranked))}
+	if filter.Offset < len(ranked) {
+		end := min(filter.Offset+filter.Limit, len(ranked))
+		result.Items = ranked[filter.Offset:end]
+	}
+	return result, nil
+}
+
+func (s *AdminListService) list(ctx context.Context, filter *account.UserAccountFilter) (*ListResult, error) {
+	accounts, err := s.accounts.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+	total, err := s.accounts.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count accounts: %w", err)
+	}
+
+	items := make([]account.SearchResult, 0, len(accounts))
+	for _, acc := range accounts {
+		items = append(items, account.SearchResult{Account: acc})
+	}
+	return &ListResult{Items: items, Total: total}, nil
+}
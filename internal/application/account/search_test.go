@@ -0,0 +1,85 @@
+package account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts []*account.UserAccount
+}
+
+func (f *fakeAccountRepo) Find(ctx context.Context, filter *account.UserAccountFilter) ([]*account.UserAccount, error) {
+	end := min(filter.Offset+filter.Limit, len(f.accounts))
+	return f.accounts[filter.Offset:end], nil
+}
+
+func (f *fakeAccountRepo) Count(ctx context.Context, filter *account.UserAccountFilter) (int64, error) {
+	return int64(len(f.accounts)), nil
+}
+
+type fakeSearchRepo struct {
+	candidates []account.SearchCandidate
+	limit      int
+}
+
+func (f *fakeSearchRepo) FindSearchCandidates(ctx context.Context, query account.SearchQuery, filter *account.UserAccountFilter, limit int) ([]account.SearchCandidate, error) {
+	f.limit = limit
+	return f.candidates, nil
+}
+
+func TestAdminListService_List(t *testing.T) {
+	accounts := []*account.UserAccount{
+		createTestAccount(t, "u1", "jonathan"),
+		createTestAccount(t, "u2", "maria"),
+		createTestAccount(t, "u3", "jonah"),
+	}
+	var candidates []account.SearchCandidate
+	for _, acc := range accounts {
+		candidates = append(candidates, account.SearchCandidate{Account: acc})
+	}
+	search := &fakeSearchRepo{candidates: candidates}
+	service := NewAdminListService(&fakeAccountRepo{accounts: accounts}, search)
+	ctx := context.Background()
+
+	plain, err := service.List(ctx, &account.UserAccountFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plain.Items) != 2 || plain.Total != 3 {
+		t.Errorf("expected page of 2 out of 3, got %d of %d", len(plain.Items), plain.Total)
+	}
+
+	query := "jona"
+	found, err := service.List(ctx, &account.UserAccountFilter{SearchQuery: &query, Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found.Total != 2 || len(found.Items) != 1 || found.Items[0].Score == 0 {
+		t.Errorf("expected 2 ranked matches paged to 1, got %+v", found)
+	}
+	if search.limit != MaxSearchCandidates {
+		t.Errorf("expected candidate limit %d, got %d", MaxSearchCandidates, search.limit)
+	}
+
+	beyond, _ := service.List(ctx, &account.UserAccountFilter{SearchQuery: &query, Offset: 10})
+	if len(beyond.Items) != 0 {
+		t.Errorf("expected empty page beyond results, got %d", len(beyond.Items))
+	}
+
+	short := "j"
+	if _, err := service.List(ctx, &account.UserAccountFilter{SearchQuery: &short}); err != account.ErrSearchQueryTooShort {
+		t.Errorf("expected error '%v', got '%v'", account.ErrSearchQueryTooShort, err)
+	}
+}
+
+func createTestAccount(t *testing.T, id, username string) *account.UserAccount {
+	acc, err := account.NewUserAccountForTesting(id, username, username+"@example.com", "TestPassword123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return acc
+}
@@ -0,0 +1,537 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// SessionTTL is the absolute lifetime of a sign-in, refreshing does not extend it
+const SessionTTL = 30 * 24 * time.Hour
+
+// MFAChallengeTTL is how long the second sign-in step may take after the password
+const MFAChallengeTTL = 5 * time.Minute
+
+var (
+	ErrAccountNotFound          = shared.NewDomainError("account.not_found", shared.KindNotFound, "user account not found")
+	ErrUsernameTaken            = shared.NewDomainError("account.username_taken", shared.KindConflict, "username is already taken")
+	ErrEmailTaken               = shared.NewDomainError("account.email_taken", shared.KindConflict, "email is already registered")
+	ErrInvalidCredentials       = shared.NewDomainError("account.invalid_credentials", shared.KindForbidden, "invalid username, email or password")
+	ErrAccountLocked            = shared.NewDomainError("account.locked", shared.KindForbidden, "too many failed sign-ins, try again later")
+	ErrLoginNotAllowed          = shared.NewDomainError("account.login_not_allowed", shared.KindForbidden, "user account cannot sign in")
+	ErrInvalidVerificationToken = shared.NewDomainError("account.invalid_verification_token", shared.KindValidation, "verification link is invalid or has expired")
+	ErrInvalidMFAChallenge      = shared.NewDomainError("account.invalid_mfa_challenge", shared.KindForbidden, "sign-in has expired, enter your password again")
+)
+
+// TokenPair is what a sign-in or a refresh hands to the client
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// TokenIssuer signs access tokens and rotates refresh tokens (implemented by the auth module)
+type TokenIssuer interface {
+	Issue(ctx context.Context, acc *account.UserAccount, session *account.Session) (*TokenPair, error)
+	Refresh(ctx context.Context, rawToken, deviceFingerprint, ipAddress string) (*TokenPair, error)
+	Revoke(ctx context.Context, rawToken string) error
+}
+
+// VerificationTokens keeps the single-use tokens of verification emails
+type VerificationTokens interface {
+	Issue(ctx context.Context, accountID string, at time.Time) (string, error)
+	// Consume redeems a token once and returns its account, "" when the token is unknown, used or expired
+	Consume(ctx context.Context, token string, at time.Time) (string, error)
+}
+
+// MFAChallenges keeps the short-lived tokens that link the password step of a
+// sign-in to its second step
+type MFAChallenges interface {
+	Issue(ctx context.Context, accountID string, expiresAt time.Time) (string, error)
+	// Find returns the challenge's account, "" when the token is unknown, used or expired
+	Find(ctx context.Context, token string, at time.Time) (string, error)
+	Consume(ctx context.Context, token string) error
+}
+
+// RegistrationEvents records account events in the caller's transaction (an
+// outbox), so no verification email goes out for a registration that rolled back
+type RegistrationEvents interface {
+	VerificationRequested(ctx context.Context, event account.VerificationRequested) error
+}
+
+// Entitlements drops cached access decisions once an account's status changes,
+// the entitlement service implements it
+type Entitlements interface {
+	Invalidate(ctx context.Context, accountID string) error
+}
+
+// RegisterInput is a self-registration, which always creates a membership account
+type RegisterInput struct {
+	Username string
+	Email    string
+	Password string
+}
+
+// LoginInput identifies the account by username or email address
+type LoginInput struct {
+	Identifier        string
+	Password          string
+	IPAddress         string
+	UserAgent         string
+	DeviceFingerprint string
+}
+
+// MFAChallenge is handed out instead of tokens when the account has two-factor
+// authentication on, the client answers it with VerifyLogin
+type MFAChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// LoginResult holds either the tokens or the two-factor challenge
+type LoginResult struct {
+	Tokens    *TokenPair
+	Challenge *MFAChallenge
+}
+
+// VerifyLoginInput is the second sign-in step, an authenticator or backup code
+type VerifyLoginInput struct {
+	Challenge         string
+	Code              string
+	IPAddress         string
+	UserAgent         string
+	DeviceFingerprint string
+}
+
+// AccountService covers registration, sign-in and the account lifecycle
+// actions staff take from the admin panel
+type AccountService struct {
+	accounts      account.UserAccountRepository
+	sessions      account.SessionRepository
+	policies      *PolicyService
+	hasher        account.PasswordHasher
+	tokens        TokenIssuer
+	verifications VerificationTokens
+	challenges    MFAChallenges
+	totp          account.TOTPVerifier
+	events        RegistrationEvents
+	trail         audit.Trail
+	entitlements  Entitlements
+	tx            shared.Transactor
+	clock         shared.Clock
+}
+
+func NewAccountService(accounts account.UserAccountRepository, sessions account.SessionRepository, policies *PolicyService, hasher account.PasswordHasher, tokens TokenIssuer, verifications VerificationTokens, challenges MFAChallenges, totp account.TOTPVerifier, events RegistrationEvents, trail audit.Trail, entitlements Entitlements, tx shared.Transactor, clock shared.Clock) *AccountService {
+	return &AccountService{
+		accounts:      accounts,
+		sessions:      sessions,
+		policies:      policies,
+		hasher:        hasher,
+		tokens:        tokens,
+		verifications: verifications,
+		challenges:    challenges,
+		totp:          totp,
+		events:        events,
+		trail:         trail,
+		entitlements:  entitlements,
+		tx:            tx,
+		clock:         clock,
+	}
+}
+
+// Register creates a membership account pending verification. The duplicate
+// checks, the account, its verification token and the VerificationRequested
+// event commit together; the notification worker emails the link from the event.
+func (s *AccountService) Register(ctx context.Context, in RegisterInput) (*account.UserAccount, error) {
+	username, err := account.NewUsername(in.Username)
+	if err != nil {
+		return nil, err
+	}
+	email, err := account.NewEmail(in.Email)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policies.ValidatePassword(ctx, account.TypeMembership, in.Password); err != nil {
+		return nil, err
+	}
+	hashed, err := s.hasher.Hash(in.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate account ID: %w", err)
+	}
+	acc, err := account.NewUserAccountForSelfRegistration(id, username.String(), email.String(), hashed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		taken, err := s.accounts.ExistsByUsername(ctx, username.String())
+		if err != nil {
+			return fmt.Errorf("failed to check username: %w", err)
+		}
+		if taken {
+			return ErrUsernameTaken
+		}
+		if taken, err = s.accounts.ExistsByEmail(ctx, email.String()); err != nil {
+			return fmt.Errorf("failed to check email: %w", err)
+		}
+		if taken {
+			return ErrEmailTaken
+		}
+		if err := s.accounts.Create(ctx, acc); err != nil {
+			return fmt.Errorf("failed to create account: %w", err)
+		}
+
+		now := s.clock.Now()
+		token, err := s.verifications.Issue(ctx, acc.ID, now)
+		if err != nil {
+			return fmt.Errorf("failed to issue verification token: %w", err)
+		}
+		event := account.VerificationRequested{
+			AccountID:  acc.ID,
+			Username:   acc.Username.String(),
+			Email:      acc.Email.String(),
+			Token:      token,
+			OccurredAt: now,
+		}
+		if err := s.events.VerificationRequested(ctx, event); err != nil {
+			return fmt.Errorf("failed to record verification request: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// VerifyEmail activates a self-registered account from its emailed link
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) (*account.UserAccount, error) {
+	accountID, err := s.verifications.Consume(ctx, token, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem verification token: %w", err)
+	}
+	if accountID == "" {
+		return nil, ErrInvalidVerificationToken
+	}
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	acc.SetClock(s.clock)
+	if err := acc.SelfVerify(); err != nil {
+		return nil, err
+	}
+	if err := s.accounts.Update(ctx, acc); err != nil {
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+	_ = s.entitlements.Invalidate(ctx, acc.ID)
+	return acc, nil
+}
+
+// Login checks the password and opens a session. Unknown accounts and wrong
+// passwords fail alike so the form cannot be used to find out who has an
+// account; a wrong password counts towards the lockout policy. Accounts with
+// two-factor authentication get a challenge instead of tokens.
+func (s *AccountService) Login(ctx context.Context, in LoginInput) (*LoginResult, error) {
+	acc, err := s.findByIdentifier(ctx, in.Identifier)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil || acc.IsSoftDeleted() {
+		return nil, ErrInvalidCredentials
+	}
+
+	acc.SetClock(s.clock)
+	if acc.IsLocked() {
+		return nil, ErrAccountLocked.With("locked_until", acc.LockedUntil.Format(time.RFC3339))
+	}
+
+	ok, err := acc.PasswordHash.Compare(in.Password, s.hasher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare password: %w", err)
+	}
+	if !ok {
+		if err := s.recordFailedLogin(ctx, acc, in.IPAddress); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+	if !acc.CanLogin() {
+		return nil, ErrLoginNotAllowed.With("status", string(acc.Status))
+	}
+
+	if acc.RequiresMFA() {
+		expiresAt := s.clock.Now().Add(MFAChallengeTTL)
+		token, err := s.challenges.Issue(ctx, acc.ID, expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to issue sign-in challenge: %w", err)
+		}
+		return &LoginResult{Challenge: &MFAChallenge{Token: token, ExpiresAt: expiresAt}}, nil
+	}
+
+	pair, err := s.openSession(ctx, acc, in.IPAddress, in.UserAgent, in.DeviceFingerprint)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Tokens: pair}, nil
+}
+
+// Authenticate checks the password without opening a session, for what an
+// account that cannot sign in may still do, such as appealing its disable.
+// Failures count towards the lockout policy like a sign-in.
+func (s *AccountService) Authenticate(ctx context.Context, identifier, password, ipAddress string) (*account.UserAccount, error) {
+	acc, err := s.findByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil || acc.IsSoftDeleted() {
+		return nil, ErrInvalidCredentials
+	}
+
+	acc.SetClock(s.clock)
+	if acc.IsLocked() {
+		return nil, ErrAccountLocked.With("locked_until", acc.LockedUntil.Format(time.RFC3339))
+	}
+
+	ok, err := acc.PasswordHash.Compare(password, s.hasher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare password: %w", err)
+	}
+	if !ok {
+		if err := s.recordFailedLogin(ctx, acc, ipAddress); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+	return acc, nil
+}
+
+// VerifyLogin finishes a two-factor sign-in. A wrong code counts towards the
+// lockout policy like a wrong password and leaves the challenge open until it
+// expires; the spent time step or backup code is saved before tokens go out.
+func (s *AccountService) VerifyLogin(ctx context.Context, in VerifyLoginInput) (*TokenPair, error) {
+	accountID, err := s.challenges.Find(ctx, in.Challenge, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sign-in challenge: %w", err)
+	}
+	if accountID == "" {
+		return nil, ErrInvalidMFAChallenge
+	}
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil || acc.IsSoftDeleted() {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	acc.SetClock(s.clock)
+	if acc.IsLocked() {
+		return nil, ErrAccountLocked.With("locked_until", acc.LockedUntil.Format(time.RFC3339))
+	}
+	if !acc.CanLogin() {
+		return nil, ErrLoginNotAllowed.With("status", string(acc.Status))
+	}
+
+	if err := acc.VerifyMFA(in.Code, s.totp); err != nil {
+		if !errors.Is(err, account.ErrInvalidMFACode) {
+			return nil, err
+		}
+		if err := s.recordFailedLogin(ctx, acc, in.IPAddress); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	if err := s.challenges.Consume(ctx, in.Challenge); err != nil {
+		return nil, fmt.Errorf("failed to consume sign-in challenge: %w", err)
+	}
+	return s.openSession(ctx, acc, in.IPAddress, in.UserAgent, in.DeviceFingerprint)
+}
+
+// Refresh exchanges a refresh token for a new pair
+func (s *AccountService) Refresh(ctx context.Context, refreshToken, deviceFingerprint, ipAddress string) (*TokenPair, error) {
+	return s.tokens.Refresh(ctx, refreshToken, deviceFingerprint, ipAddress)
+}
+
+// Logout ends the session behind the refresh token
+func (s *AccountService) Logout(ctx context.Context, refreshToken string) error {
+	return s.tokens.Revoke(ctx, refreshToken)
+}
+
+// Verify activates an account pending verification on behalf of staff
+func (s *AccountService) Verify(ctx context.Context, staffID, accountID string) (*account.UserAccount, error) {
+	return s.change(ctx, staffID, accountID, "account.verify", nil, func(acc *account.UserAccount) error {
+		return acc.Verify(staffID)
+	})
+}
+
+// Disable disables an account and signs it out everywhere
+func (s *AccountService) Disable(ctx context.Context, staffID, accountID string, disabilityType account.DisabilityType, reason string) (*account.UserAccount, error) {
+	details := map[string]string{"reason": reason}
+	acc, err := s.change(ctx, staffID, accountID, "account.disable", details, func(acc *account.UserAccount) error {
+		return acc.Disable(staffID, disabilityType, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acc, s.revokeSessions(ctx, acc)
+}
+
+// Reactivate lifts a disability, the account signs in again with its old password
+func (s *AccountService) Reactivate(ctx context.Context, staffID, accountID string) (*account.UserAccount, error) {
+	return s.change(ctx, staffID, accountID, "account.reactivate", nil, func(acc *account.UserAccount) error {
+		return acc.Reactivate(staffID)
+	})
+}
+
+// Delete soft deletes an account and signs it out everywhere
+func (s *AccountService) Delete(ctx context.Context, staffID, accountID string) (*account.UserAccount, error) {
+	acc, err := s.change(ctx, staffID, accountID, "account.delete", nil, func(acc *account.UserAccount) error {
+		return acc.Delete(staffID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acc, s.revokeSessions(ctx, acc)
+}
+
+// change applies a staff action and records it in the audit trail with the
+// fields it changed, the account is not updated when the entry fails
+func (s *AccountService) change(ctx context.Context, staffID, accountID, action string, details map[string]string, apply func(acc *account.UserAccount) error) (*account.UserAccount, error) {
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+	acc.SetClock(s.clock)
+	before := auditFields(acc)
+	if err := apply(acc); err != nil {
+		return nil, err
+	}
+
+	event := audit.Event{
+		ActorID:    staffID,
+		Action:     action,
+		TargetType: "account",
+		TargetID:   acc.ID,
+		Details:    details,
+		Changes:    audit.Diff(before, auditFields(acc)),
+	}
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.accounts.Update(ctx, acc); err != nil {
+			return fmt.Errorf("failed to update account: %w", err)
+		}
+		if err := s.trail.Emit(ctx, event, s.clock.Now()); err != nil {
+			return fmt.Errorf("failed to record audit entry: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The status is saved either way, a failed invalidation only leaves the old
+	// access cached until it expires
+	_ = s.entitlements.Invalidate(ctx, acc.ID)
+	return acc, nil
+}
+
+// auditFields are the lifecycle fields staff actions change, as the audit
+// trail diffs them
+func auditFields(acc *account.UserAccount) map[string]string {
+	fields := map[string]string{
+		"status":      string(acc.Status),
+		"is_verified": strconv.FormatBool(acc.IsVerified),
+	}
+	if acc.DisabilityType != nil {
+		fields["disability_type"] = string(*acc.DisabilityType)
+	}
+	if acc.IssuedReason != nil {
+		fields["issued_reason"] = *acc.IssuedReason
+	}
+	if acc.SuspendedUntil != nil {
+		fields["suspended_until"] = acc.SuspendedUntil.UTC().Format(time.RFC3339)
+	}
+	if acc.DeletedAt != nil {
+		fields["deleted_at"] = acc.DeletedAt.UTC().Format(time.RFC3339)
+	}
+	return fields
+}
+
+func (s *AccountService) revokeSessions(ctx context.Context, acc *account.UserAccount) error {
+	if _, err := s.sessions.RevokeAllForAccount(ctx, acc.ID, account.RevokedByAccountDisable, s.clock.Now()); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+// openSession records the sign-in, saving whatever the password or two-factor
+// step changed on the account, and issues the tokens
+func (s *AccountService) openSession(ctx context.Context, acc *account.UserAccount, ipAddress, userAgent, deviceFingerprint string) (*TokenPair, error) {
+	if err := acc.RecordSuccessfulLogin(ipAddress); err != nil {
+		return nil, err
+	}
+	if err := s.accounts.Update(ctx, acc); err != nil {
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	session, err := account.NewSession(id, acc.ID, deviceFingerprint, ipAddress, userAgent, SessionTTL, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return s.tokens.Issue(ctx, acc, session)
+}
+
+func (s *AccountService) recordFailedLogin(ctx context.Context, acc *account.UserAccount, ipAddress string) error {
+	if err := s.policies.RecordFailedLogin(ctx, acc, ipAddress); err != nil {
+		return err
+	}
+	if err := s.accounts.Update(ctx, acc); err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+	return nil
+}
+
+// findByIdentifier reads an address as an email and anything else as a username
+func (s *AccountService) findByIdentifier(ctx context.Context, identifier string) (*account.UserAccount, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return nil, nil
+	}
+
+	var acc *account.UserAccount
+	var err error
+	if strings.Contains(identifier, "@") {
+		acc, err = s.accounts.FindByEmail(ctx, strings.ToLower(identifier))
+	} else {
+		acc, err = s.accounts.FindByUsername(ctx, identifier)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	return acc, nil
+}
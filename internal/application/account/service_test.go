@@ -0,0 +1,462 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func (m *memoryAccounts) Create(ctx context.Context, acc *account.UserAccount) error {
+	m.byID[acc.ID] = acc
+	return nil
+}
+
+func (m *memoryAccounts) FindByUsername(ctx context.Context, username string) (*account.UserAccount, error) {
+	for _, acc := range m.byID {
+		if acc.Username.String() == username {
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAccounts) FindByEmail(ctx context.Context, email string) (*account.UserAccount, error) {
+	for _, acc := range m.byID {
+		if acc.Email.String() == email {
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAccounts) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	acc, _ := m.FindByUsername(ctx, username)
+	return acc != nil, nil
+}
+
+func (m *memoryAccounts) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	acc, _ := m.FindByEmail(ctx, email)
+	return acc != nil, nil
+}
+
+type memorySessions struct {
+	account.SessionRepository
+	created []*account.Session
+	revoked map[string]account.RevocationReason
+}
+
+func (m *memorySessions) Create(ctx context.Context, session *account.Session) error {
+	m.created = append(m.created, session)
+	return nil
+}
+
+func (m *memorySessions) RevokeAllForAccount(ctx context.Context, accountID string, reason account.RevocationReason, at time.Time) (int, error) {
+	m.revoked[accountID] = reason
+	return 1, nil
+}
+
+type fakeTokens struct {
+	revoked []string
+}
+
+func (f *fakeTokens) Issue(ctx context.Context, acc *account.UserAccount, session *account.Session) (*TokenPair, error) {
+	return &TokenPair{AccessToken: "access-" + acc.ID, RefreshToken: "refresh-" + session.ID}, nil
+}
+
+func (f *fakeTokens) Refresh(ctx context.Context, rawToken, deviceFingerprint, ipAddress string) (*TokenPair, error) {
+	return &TokenPair{AccessToken: "access", RefreshToken: rawToken + "-next"}, nil
+}
+
+func (f *fakeTokens) Revoke(ctx context.Context, rawToken string) error {
+	f.revoked = append(f.revoked, rawToken)
+	return nil
+}
+
+// fakeVerifications hands out the account ID as token and redeems it once
+type fakeVerifications struct {
+	issued map[string]string
+	sent   []string // Tokens of recorded VerificationRequested events
+	err    error
+}
+
+func (f *fakeVerifications) Issue(ctx context.Context, accountID string, at time.Time) (string, error) {
+	token := "verify-" + accountID
+	f.issued[token] = accountID
+	return token, nil
+}
+
+func (f *fakeVerifications) Consume(ctx context.Context, token string, at time.Time) (string, error) {
+	accountID := f.issued[token]
+	delete(f.issued, token)
+	return accountID, nil
+}
+
+func (f *fakeVerifications) VerificationRequested(ctx context.Context, event account.VerificationRequested) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, event.Token)
+	return nil
+}
+
+// memoryChallenges hands out numbered sign-in challenges
+type memoryChallenges struct {
+	issued map[string]string
+	expiry map[string]time.Time
+}
+
+func (m *memoryChallenges) Issue(ctx context.Context, accountID string, expiresAt time.Time) (string, error) {
+	token := fmt.Sprintf("challenge-%d", len(m.issued)+1)
+	m.issued[token], m.expiry[token] = accountID, expiresAt
+	return token, nil
+}
+
+func (m *memoryChallenges) Find(ctx context.Context, token string, at time.Time) (string, error) {
+	if !at.Before(m.expiry[token]) {
+		return "", nil
+	}
+	return m.issued[token], nil
+}
+
+func (m *memoryChallenges) Consume(ctx context.Context, token string) error {
+	delete(m.expiry, token)
+	return nil
+}
+
+// fakeTOTP accepts "123456" for the current time step
+type fakeTOTP struct{}
+
+func (fakeTOTP) Verify(secret, code string, at time.Time) (int64, bool, error) {
+	return at.Unix() / 30, code == "123456", nil
+}
+
+// recordingTrail keeps the emitted audit events, it fails every emit when err is set
+type recordingTrail struct {
+	events []audit.Event
+	err    error
+}
+
+func (r *recordingTrail) Emit(ctx context.Context, event audit.Event, at time.Time) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+// fakeEntitlements records the accounts whose cached access was dropped
+type fakeEntitlements struct {
+	invalidated []string
+}
+
+func (f *fakeEntitlements) Invalidate(ctx context.Context, accountID string) error {
+	f.invalidated = append(f.invalidated, accountID)
+	return nil
+}
+
+// memoryTx restores the accounts when the unit of work fails
+type memoryTx struct {
+	accounts *memoryAccounts
+}
+
+func (m *memoryTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	snapshot := maps.Clone(m.accounts.byID)
+	if err := fn(ctx); err != nil {
+		m.accounts.byID = snapshot
+		return err
+	}
+	return nil
+}
+
+func newTestAccountService(t *testing.T) (*AccountService, *memoryAccounts, *memorySessions, *fakeVerifications, *shared.FrozenClock) {
+	t.Helper()
+	clock := shared.NewFrozenClock(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	accounts := &memoryAccounts{byID: map[string]*account.UserAccount{}}
+	sessions := &memorySessions{revoked: map[string]account.RevocationReason{}}
+	verifications := &fakeVerifications{issued: map[string]string{}}
+	policies := NewPolicyService(&memoryPolicies{policies: map[account.UserAccountType]*account.SecurityPolicy{}}, clock)
+	challenges := &memoryChallenges{issued: map[string]string{}, expiry: map[string]time.Time{}}
+	service := NewAccountService(accounts, sessions, policies, fakeHasher{}, &fakeTokens{}, verifications, challenges, fakeTOTP{}, verifications, &recordingTrail{}, &fakeEntitlements{}, &memoryTx{accounts: accounts}, clock)
+	return service, accounts, sessions, verifications, clock
+}
+
+func TestAccountService_RegisterAndVerify(t *testing.T) {
+	service, _, _, verifications, _ := newTestAccountService(t)
+	ctx := context.Background()
+
+	acc, err := service.Register(ctx, RegisterInput{Username: "jhon_doe", Email: "Jhon@Example.com", Password: "Secret123!"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !acc.IsPendingVerification() || !acc.IsMembership() || acc.PasswordHash.Encoded() != "hashed:Secret123!" {
+		t.Errorf("expected a pending membership account, got %+v", acc)
+	}
+
+	tests := []struct {
+		name    string
+		input   RegisterInput
+		wantErr error
+	}{
+		{"username taken", RegisterInput{"jhon_doe", "other@example.com", "Secret123!"}, ErrUsernameTaken},
+		{"email taken", RegisterInput{"other_user", "jhon@example.com", "Secret123!"}, ErrEmailTaken},
+		{"invalid email", RegisterInput{"other_user", "not-an-email", "Secret123!"}, account.ErrInvalidEmail},
+		{"weak password", RegisterInput{"other_user", "other@example.com", "short"}, account.ErrPasswordTooShort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Register(ctx, tt.input); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error '%v', got '%v'", tt.wantErr, err)
+			}
+		})
+	}
+
+	if len(verifications.sent) != 1 {
+		t.Fatalf("expected one verification request, got %d", len(verifications.sent))
+	}
+	if _, err := service.VerifyEmail(ctx, verifications.sent[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !acc.IsActive() || !acc.IsVerified {
+		t.Errorf("expected the account active after verification, got %s", acc.Status)
+	}
+	if _, err := service.VerifyEmail(ctx, verifications.sent[0]); !errors.Is(err, ErrInvalidVerificationToken) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidVerificationToken, err)
+	}
+}
+
+func TestAccountService_RegisterRollsBack(t *testing.T) {
+	service, accounts, _, verifications, _ := newTestAccountService(t)
+	verifications.err = errors.New("outbox unavailable")
+
+	_, err := service.Register(context.Background(), RegisterInput{Username: "jhon_doe", Email: "jhon@example.com", Password: "Secret123!"})
+	if err == nil {
+		t.Fatal("expected the failed event to fail the registration")
+	}
+	if len(accounts.byID) != 0 {
+		t.Errorf("expected the account rolled back, got %d accounts", len(accounts.byID))
+	}
+
+	// The username is free again once the outbox recovers
+	verifications.err = nil
+	if _, err := service.Register(context.Background(), RegisterInput{Username: "jhon_doe", Email: "jhon@example.com", Password: "Secret123!"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAccountService_Login(t *testing.T) {
+	service, accounts, sessions, _, clock := newTestAccountService(t)
+	ctx := context.Background()
+
+	member, _ := account.NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed:Secret123!")
+	_ = member.SelfVerify()
+	pending, _ := account.NewUserAccountForSelfRegistration("m2", "pending_user", "pending@example.com", "hashed:Secret123!")
+	accounts.byID[member.ID], accounts.byID[pending.ID] = member, pending
+
+	login := func(identifier, password string) (*TokenPair, error) {
+		result, err := service.Login(ctx, LoginInput{Identifier: identifier, Password: password, IPAddress: "203.0.113.9", UserAgent: "Firefox"})
+		if err != nil {
+			return nil, err
+		}
+		return result.Tokens, nil
+	}
+
+	pair, err := login("Member@Example.com", "Secret123!")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pair.AccessToken != "access-m1" || len(sessions.created) != 1 || member.LastLoginAt == nil {
+		t.Errorf("expected a session and tokens, got %+v with %d sessions", pair, len(sessions.created))
+	}
+	if _, err := login("member_user", "Secret123!"); err != nil {
+		t.Errorf("expected sign-in by username, got '%v'", err)
+	}
+
+	if _, err := login("nobody", "Secret123!"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidCredentials, err)
+	}
+	if _, err := login("pending_user", "Secret123!"); !errors.Is(err, ErrLoginNotAllowed) {
+		t.Errorf("expected error '%v', got '%v'", ErrLoginNotAllowed, err)
+	}
+
+	policy := account.DefaultSecurityPolicy(account.TypeMembership)
+	for range policy.Lockout.MaxAttempts {
+		if _, err := login("member_user", "Wrong123!"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("expected error '%v', got '%v'", ErrInvalidCredentials, err)
+		}
+	}
+	if _, err := login("member_user", "Secret123!"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("expected error '%v', got '%v'", ErrAccountLocked, err)
+	}
+	clock.Advance(policy.Lockout.LockDuration)
+	if _, err := login("member_user", "Secret123!"); err != nil {
+		t.Errorf("expected the lock to run out, got '%v'", err)
+	}
+}
+
+func TestAccountService_LoginWithMFA(t *testing.T) {
+	service, accounts, sessions, _, clock := newTestAccountService(t)
+	ctx := context.Background()
+
+	member, _ := account.NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed:Secret123!")
+	_ = member.SelfVerify()
+	member.SetClock(clock)
+	_ = member.EnableTOTP("JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP")
+	clock.Advance(-time.Minute) // The setup code is from an earlier time step
+	backup, err := member.ConfirmTOTP("123456", fakeTOTP{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Minute)
+	accounts.byID[member.ID] = member
+
+	result, err := service.Login(ctx, LoginInput{Identifier: "member_user", Password: "Secret123!", IPAddress: "203.0.113.9"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Tokens != nil || result.Challenge == nil || len(sessions.created) != 0 {
+		t.Fatalf("expected a challenge and no session, got %+v", result)
+	}
+	challenge := result.Challenge.Token
+
+	if _, err := service.VerifyLogin(ctx, VerifyLoginInput{Challenge: challenge, Code: "000000", IPAddress: "203.0.113.9"}); !errors.Is(err, account.ErrInvalidMFACode) {
+		t.Errorf("expected error '%v', got '%v'", account.ErrInvalidMFACode, err)
+	}
+	if member.FailedLoginAttempts != 1 {
+		t.Errorf("expected the wrong code counted towards the lockout, got %d", member.FailedLoginAttempts)
+	}
+
+	pair, err := service.VerifyLogin(ctx, VerifyLoginInput{Challenge: challenge, Code: "123456", IPAddress: "203.0.113.9"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pair.AccessToken != "access-m1" || len(sessions.created) != 1 || member.TOTPLastStep != clock.Now().Unix()/30 {
+		t.Errorf("expected tokens and the time step saved, got %+v", pair)
+	}
+	if _, err := service.VerifyLogin(ctx, VerifyLoginInput{Challenge: challenge, Code: "123456", IPAddress: "203.0.113.9"}); !errors.Is(err, ErrInvalidMFAChallenge) {
+		t.Errorf("expected the challenge used up, got '%v'", err)
+	}
+
+	// The same authenticator code cannot open a second session
+	result, _ = service.Login(ctx, LoginInput{Identifier: "member_user", Password: "Secret123!", IPAddress: "203.0.113.9"})
+	if _, err := service.VerifyLogin(ctx, VerifyLoginInput{Challenge: result.Challenge.Token, Code: "123456", IPAddress: "203.0.113.9"}); !errors.Is(err, account.ErrInvalidMFACode) {
+		t.Errorf("expected the reused code rejected, got '%v'", err)
+	}
+	if _, err := service.VerifyLogin(ctx, VerifyLoginInput{Challenge: result.Challenge.Token, Code: backup[0], IPAddress: "203.0.113.9"}); err != nil {
+		t.Errorf("expected a backup code accepted, got '%v'", err)
+	}
+	if member.BackupCodes.Remaining() != account.BackupCodeCount-1 {
+		t.Errorf("expected the backup code spent, %d left", member.BackupCodes.Remaining())
+	}
+
+	result, _ = service.Login(ctx, LoginInput{Identifier: "member_user", Password: "Secret123!", IPAddress: "203.0.113.9"})
+	clock.Advance(MFAChallengeTTL)
+	if _, err := service.VerifyLogin(ctx, VerifyLoginInput{Challenge: result.Challenge.Token, Code: backup[1], IPAddress: "203.0.113.9"}); !errors.Is(err, ErrInvalidMFAChallenge) {
+		t.Errorf("expected the challenge expired, got '%v'", err)
+	}
+}
+
+func TestAccountService_Lifecycle(t *testing.T) {
+	service, accounts, sessions, _, _ := newTestAccountService(t)
+	ctx := context.Background()
+
+	acc, _ := account.NewUserAccountWithHash("a1", "staff_writer", "writer@example.com", "hashed:Secret123!", account.TypeInternal, "admin1")
+	accounts.byID[acc.ID] = acc
+
+	if _, err := service.Disable(ctx, "admin1", acc.ID, account.DisabilityTypeManual, "left"); !errors.Is(err, account.ErrAccountUnverified) {
+		t.Errorf("expected error '%v', got '%v'", account.ErrAccountUnverified, err)
+	}
+	if _, err := service.Verify(ctx, "admin1", acc.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Disable(ctx, "admin1", acc.ID, account.DisabilityTypeManual, "left the newsroom"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !acc.IsManuallyDisabled() || sessions.revoked[acc.ID] != account.RevokedByAccountDisable {
+		t.Errorf("expected a disabled account signed out everywhere, got %s %v", acc.Status, sessions.revoked)
+	}
+	if _, err := service.Reactivate(ctx, "admin1", acc.ID); err != nil || !acc.IsActive() {
+		t.Errorf("expected the account reactivated, got %s %v", acc.Status, err)
+	}
+
+	delete(sessions.revoked, acc.ID)
+	if _, err := service.Delete(ctx, "admin1", acc.ID); err != nil || !acc.IsSoftDeleted() || sessions.revoked[acc.ID] == "" {
+		t.Errorf("expected a deleted account signed out everywhere, got %s %v", acc.Status, err)
+	}
+	if _, err := service.Delete(ctx, "admin1", "missing"); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrAccountNotFound, err)
+	}
+	// Verify, disable, reactivate and delete each drop the cached access
+	if invalidated := service.entitlements.(*fakeEntitlements).invalidated; len(invalidated) != 4 {
+		t.Errorf("expected every status change to invalidate entitlements, got %v", invalidated)
+	}
+}
+
+func TestAccountService_Authenticate(t *testing.T) {
+	service, accounts, sessions, _, _ := newTestAccountService(t)
+	ctx := context.Background()
+
+	member, _ := account.NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed:Secret123!")
+	_ = member.SelfVerify()
+	_ = member.Disable("mod1", account.DisabilityTypeManual, "spam")
+	accounts.byID[member.ID] = member
+
+	acc, err := service.Authenticate(ctx, "member_user", "Secret123!", "203.0.113.9")
+	if err != nil || acc.ID != "m1" {
+		t.Fatalf("expected a disabled account authenticated, got %v, %v", acc, err)
+	}
+	if len(sessions.created) != 0 {
+		t.Errorf("expected no session opened, got %d", len(sessions.created))
+	}
+
+	policy := account.DefaultSecurityPolicy(account.TypeMembership)
+	for range policy.Lockout.MaxAttempts {
+		if _, err := service.Authenticate(ctx, "member_user", "Wrong123!", "203.0.113.9"); !errors.Is(err, ErrInvalidCredentials) {
+			t.Fatalf("expected error '%v', got '%v'", ErrInvalidCredentials, err)
+		}
+	}
+	if _, err := service.Authenticate(ctx, "member_user", "Secret123!", "203.0.113.9"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("expected error '%v', got '%v'", ErrAccountLocked, err)
+	}
+}
+
+func TestAccountService_LifecycleAudited(t *testing.T) {
+	service, accounts, _, _, _ := newTestAccountService(t)
+	trail := &recordingTrail{}
+	service.trail = trail
+	ctx := audit.WithIP(context.Background(), "203.0.113.7")
+
+	acc, _ := account.NewUserAccountWithHash("a1", "staff_writer", "writer@example.com", "hashed:Secret123!", account.TypeInternal, "admin1")
+	accounts.byID[acc.ID] = acc
+	_, _ = service.Verify(ctx, "admin1", acc.ID)
+	_, _ = service.Disable(ctx, "admin2", acc.ID, account.DisabilityTypeManual, "left the newsroom")
+
+	if len(trail.events) != 2 {
+		t.Fatalf("expected two audit events, got %d", len(trail.events))
+	}
+	disable := trail.events[1]
+	if disable.ActorID != "admin2" || disable.Action != "account.disable" || disable.TargetID != acc.ID || disable.Details["reason"] != "left the newsroom" {
+		t.Errorf("unexpected event %+v", disable)
+	}
+	changed := map[string]audit.Change{}
+	for _, c := range disable.Changes {
+		changed[c.Field] = c
+	}
+	if status := changed["status"]; status.Before != string(account.StatusActive) || status.After != string(account.StatusDisabled) {
+		t.Errorf("expected the status change recorded, got %+v", disable.Changes)
+	}
+	if _, ok := changed["is_verified"]; ok {
+		t.Errorf("expected unchanged fields left out, got %+v", disable.Changes)
+	}
+
+	trail.err = errors.New("audit log unavailable")
+	if _, err := service.Reactivate(ctx, "admin1", acc.ID); err == nil {
+		t.Error("expected the action refused when it cannot be audited")
+	}
+}
@@ -0,0 +1,104 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+// Query limits for the analytics APIs
+const (
+	DefaultTrendingWindow = 6 * time.Hour
+	MaxTrendingWindow     = 7 * 24 * time.Hour
+	DefaultTrendingLimit  = 10
+	MaxTrendingLimit      = 50
+	DefaultTopArticles    = 5
+)
+
+// TrackInput describes one reader interaction
+type TrackInput struct {
+	Type       analytics.EventType
+	ArticleID  string
+	AuthorID   string
+	Region     string
+	Device     string
+	OccurredAt time.Time
+}
+
+type Service struct {
+	writer  analytics.EventWriter
+	store   analytics.Store
+	consent consent.Checker
+}
+
+// NewService takes the writer separately so ingestion can go through a batching writer
+func NewService(writer analytics.EventWriter, store analytics.Store, checker consent.Checker) *Service {
+	return &Service{writer: writer, store: store, consent: checker}
+}
+
+// Track records an event if the reader consented to analytics, reports whether it was recorded
+func (s *Service) Track(ctx context.Context, subject consent.Subject, input TrackInput) (bool, error) {
+	allowed, err := s.consent.Allows(ctx, subject, consent.PurposeAnalytics)
+	if err != nil {
+		return false, fmt.Errorf("failed to check consent: %w", err)
+	}
+	if !allowed {
+		return false, nil
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return false, err
+	}
+
+	occurredAt := input.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	event, err := analytics.NewEvent(id, input.Type, input.ArticleID, input.AuthorID, subject.String(), occurredAt)
+	if err != nil {
+		return false, err
+	}
+	event.Region = input.Region
+	event.Device = input.Device
+
+	if err := s.writer.WriteEvents(ctx, []*analytics.Event{event}); err != nil {
+		return false, fmt.Errorf("failed to write event: %w", err)
+	}
+	return true, nil
+}
+
+// Trending returns the most viewed articles in the window ending now
+func (s *Service) Trending(ctx context.Context, window time.Duration, limit int) ([]analytics.ArticleMetric, error) {
+	if window <= 0 || window > MaxTrendingWindow {
+		window = DefaultTrendingWindow
+	}
+	if limit < 1 || limit > MaxTrendingLimit {
+		limit = DefaultTrendingLimit
+	}
+	return s.store.Trending(ctx, window, time.Now(), limit)
+}
+
+func (s *Service) ArticleSeries(ctx context.Context, articleID string, from, to time.Time, granularity analytics.Granularity) ([]analytics.Bucket, error) {
+	if err := analytics.ValidateGranularity(granularity); err != nil {
+		return nil, err
+	}
+	r, err := analytics.NewTimeRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ArticleSeries(ctx, articleID, *r, granularity)
+}
+
+func (s *Service) AuthorSummary(ctx context.Context, authorID string, from, to time.Time) (*analytics.AuthorSummary, error) {
+	r, err := analytics.NewTimeRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.AuthorSummary(ctx, authorID, *r, DefaultTopArticles)
+}
@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+type fakeStore struct {
+	events        []*analytics.Event
+	trendWindow   time.Duration
+	trendLimit    int
+	seriesQueried bool
+}
+
+func (f *fakeStore) WriteEvents(ctx context.Context, events []*analytics.Event) error {
+	f.events = append(f.events, events...)
+	return nil
+}
+
+func (f *fakeStore) Trending(ctx context.Context, window time.Duration, at time.Time, limit int) ([]analytics.ArticleMetric, error) {
+	f.trendWindow, f.trendLimit = window, limit
+	return nil, nil
+}
+
+func (f *fakeStore) ArticleSeries(ctx context.Context, articleID string, r analytics.TimeRange, granularity analytics.Granularity) ([]analytics.Bucket, error) {
+	f.seriesQueried = true
+	return nil, nil
+}
+
+func (f *fakeStore) AuthorSummary(ctx context.Context, authorID string, r analytics.TimeRange, topLimit int) (*analytics.AuthorSummary, error) {
+	return &analytics.AuthorSummary{AuthorID: authorID}, nil
+}
+
+type fakeChecker struct {
+	allowed map[string]bool
+}
+
+func (f *fakeChecker) Allows(ctx context.Context, subject consent.Subject, purpose consent.Purpose) (bool, error) {
+	return purpose == consent.PurposeAnalytics && f.allowed[subject.String()], nil
+}
+
+func TestService_TrackRespectsConsent(t *testing.T) {
+	store := &fakeStore{}
+	optedIn, _ := consent.NewAccountSubject("user-1")
+	optedOut, _ := consent.NewAccountSubject("user-2")
+	service := NewService(store, store, &fakeChecker{allowed: map[string]bool{optedIn.String(): true}})
+	ctx := context.Background()
+	input := TrackInput{Type: analytics.EventView, ArticleID: "a1", AuthorID: "author-1"}
+
+	recorded, err := service.Track(ctx, *optedIn, input)
+	if err != nil || !recorded {
+		t.Fatalf("expected event to be recorded, got %v, %v", recorded, err)
+	}
+	recorded, err = service.Track(ctx, *optedOut, input)
+	if err != nil || recorded {
+		t.Fatalf("expected event to be dropped without consent, got %v, %v", recorded, err)
+	}
+
+	if len(store.events) != 1 || store.events[0].VisitorKey != "account:user-1" {
+		t.Errorf("expected one event for user-1, got %v", store.events)
+	}
+
+	if _, err := service.Track(ctx, *optedIn, TrackInput{Type: "click", ArticleID: "a1", AuthorID: "author-1"}); err != analytics.ErrInvalidEventType {
+		t.Errorf("expected error '%v', got '%v'", analytics.ErrInvalidEventType, err)
+	}
+}
+
+func TestService_QueryDefaults(t *testing.T) {
+	store := &fakeStore{}
+	service := NewService(store, store, &fakeChecker{})
+	ctx := context.Background()
+
+	_, _ = service.Trending(ctx, 30*24*time.Hour, 500)
+	if store.trendWindow != DefaultTrendingWindow || store.trendLimit != DefaultTrendingLimit {
+		t.Errorf("expected defaults, got window %v limit %d", store.trendWindow, store.trendLimit)
+	}
+
+	now := time.Now()
+	if _, err := service.ArticleSeries(ctx, "a1", now, now.Add(-time.Hour), analytics.GranularityHour); err != analytics.ErrInvalidRange {
+		t.Errorf("expected error '%v', got '%v'", analytics.ErrInvalidRange, err)
+	}
+	if _, err := service.ArticleSeries(ctx, "a1", now.Add(-time.Hour), now, "minute"); err != analytics.ErrInvalidGranularity {
+		t.Errorf("expected error '%v', got '%v'", analytics.ErrInvalidGranularity, err)
+	}
+	if _, err := service.ArticleSeries(ctx, "a1", now.Add(-time.Hour), now, analytics.GranularityHour); err != nil || !store.seriesQueried {
+		t.Errorf("expected series query, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package appeal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/appeal"
+)
+
+var (
+	ErrAccountNotFound     = shared.NewDomainError("appeal.account_not_found", shared.KindNotFound, "account not found")
+	ErrAppealNotFound      = shared.NewDomainError("appeal.not_found", shared.KindNotFound, "appeal not found")
+	ErrAppealAlreadyExists = shared.NewDomainError("appeal.already_pending", shared.KindConflict, "an appeal for this disable is already pending")
+	ErrDisableChanged      = shared.NewDomainError("appeal.disable_changed", shared.KindState, "account is no longer disabled by the appealed action")
+)
+
+// DecisionNotifier tells the reader the outcome of their appeal
+type DecisionNotifier interface {
+	NotifyAppealDecision(ctx context.Context, recipient account.Email, a *appeal.Appeal) error
+}
+
+// Entitlements drops a reactivated account's cached access, the entitlement service implements it
+type Entitlements interface {
+	Invalidate(ctx context.Context, accountID string) error
+}
+
+type Service struct {
+	appeals      appeal.AppealRepository
+	accounts     account.UserAccountRepository
+	entitlements Entitlements
+	notifier     DecisionNotifier
+}
+
+func NewService(appeals appeal.AppealRepository, accounts account.UserAccountRepository, entitlements Entitlements, notifier DecisionNotifier) *Service {
+	return &Service{appeals: appeals, accounts: accounts, entitlements: entitlements, notifier: notifier}
+}
+
+// Submit files an appeal against the account's current disable
+func (s *Service) Submit(ctx context.Context, accountID, statement string, ticketReference *string) (*appeal.Appeal, error) {
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	if acc.DisabledAt != nil {
+		exists, err := s.appeals.ExistsPendingForDisable(ctx, acc.ID, *acc.DisabledAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check pending appeals: %w", err)
+		}
+		if exists {
+			return nil, ErrAppealAlreadyExists
+		}
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := appeal.NewAppeal(id, acc, statement, ticketReference)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.appeals.Create(ctx, a); err != nil {
+		return nil, fmt.Errorf("failed to save appeal: %w", err)
+	}
+	return a, nil
+}
+
+// StartReview assigns an appeal from the queue to a reviewer
+func (s *Service) StartReview(ctx context.Context, appealID, reviewerID string) (*appeal.Appeal, error) {
+	a, err := s.find(ctx, appealID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.StartReview(reviewerID); err != nil {
+		return nil, err
+	}
+	if err := s.appeals.Update(ctx, a); err != nil {
+		return nil, fmt.Errorf("failed to save appeal: %w", err)
+	}
+	return a, nil
+}
+
+// Decide records the outcome, reactivates the account when the disable is reversed
+// and notifies the reader. A failed notification is left for a retry and does not
+// undo the decision.
+func (s *Service) Decide(ctx context.Context, appealID, reviewerID string, outcome appeal.Outcome, note string) (*appeal.Appeal, error) {
+	a, err := s.find(ctx, appealID)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := s.accounts.FindByID(ctx, a.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+
+	if outcome == appeal.OutcomeReverse && !a.AppliesTo(acc) {
+		return nil, ErrDisableChanged
+	}
+
+	if err := a.Decide(reviewerID, outcome, note); err != nil {
+		return nil, err
+	}
+
+	if a.IsReversed() {
+		if err := acc.Reactivate(reviewerID); err != nil {
+			return nil, err
+		}
+		if err := s.accounts.Update(ctx, acc); err != nil {
+			return nil, fmt.Errorf("failed to reactivate account: %w", err)
+		}
+		_ = s.entitlements.Invalidate(ctx, acc.ID)
+	}
+
+	if err := s.appeals.Update(ctx, a); err != nil {
+		return nil, fmt.Errorf("failed to save appeal: %w", err)
+	}
+
+	if err := s.notifier.NotifyAppealDecision(ctx, acc.Email, a); err == nil {
+		if err := a.MarkNotified(); err == nil {
+			_ = s.appeals.Update(ctx, a)
+		}
+	}
+	return a, nil
+}
+
+// ReviewQueue lists pending appeals oldest first
+func (s *Service) ReviewQueue(ctx context.Context, limit, offset int) ([]*appeal.Appeal, error) {
+	filter := &appeal.AppealFilter{Limit: limit, Offset: offset}
+	filter.SetDefaults()
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.appeals.FindReviewQueue(ctx, filter.Limit, filter.Offset)
+}
+
+func (s *Service) find(ctx context.Context, appealID string) (*appeal.Appeal, error) {
+	a, err := s.appeals.FindByID(ctx, appealID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load appeal: %w", err)
+	}
+	if a == nil {
+		return nil, ErrAppealNotFound
+	}
+	return a, nil
+}
@@ -0,0 +1,202 @@
+package appeal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/appeal"
+)
+
+type fakeAppealRepo struct {
+	appeals map[string]*appeal.Appeal
+}
+
+func newFakeAppealRepo() *fakeAppealRepo {
+	return &fakeAppealRepo{appeals: map[string]*appeal.Appeal{}}
+}
+
+func (f *fakeAppealRepo) Create(ctx context.Context, a *appeal.Appeal) error {
+	f.appeals[a.ID] = a
+	return nil
+}
+
+func (f *fakeAppealRepo) Update(ctx context.Context, a *appeal.Appeal) error {
+	f.appeals[a.ID] = a
+	return nil
+}
+
+func (f *fakeAppealRepo) FindByID(ctx context.Context, id string) (*appeal.Appeal, error) {
+	return f.appeals[id], nil
+}
+
+func (f *fakeAppealRepo) Find(ctx context.Context, filter *appeal.AppealFilter) ([]*appeal.Appeal, error) {
+	return nil, nil
+}
+
+func (f *fakeAppealRepo) FindByAccountID(ctx context.Context, accountID string) ([]*appeal.Appeal, error) {
+	return nil, nil
+}
+
+func (f *fakeAppealRepo) FindReviewQueue(ctx context.Context, limit, offset int) ([]*appeal.Appeal, error) {
+	var queue []*appeal.Appeal
+	for _, a := range f.appeals {
+		if a.IsPending() {
+			queue = append(queue, a)
+		}
+	}
+	return queue, nil
+}
+
+func (f *fakeAppealRepo) ExistsPendingForDisable(ctx context.Context, accountID string, disabledAt time.Time) (bool, error) {
+	for _, a := range f.appeals {
+		if a.AccountID == accountID && a.DisabledAt.Equal(disabledAt) && a.IsPending() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+	updated  int
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+func (f *fakeAccountRepo) Update(ctx context.Context, acc *account.UserAccount) error {
+	f.updated++
+	return nil
+}
+
+type fakeNotifier struct {
+	notified []string
+}
+
+func (f *fakeNotifier) NotifyAppealDecision(ctx context.Context, recipient account.Email, a *appeal.Appeal) error {
+	f.notified = append(f.notified, recipient.String())
+	return nil
+}
+
+// fakeEntitlements records the accounts whose cached access was dropped
+type fakeEntitlements struct {
+	invalidated []string
+}
+
+func (f *fakeEntitlements) Invalidate(ctx context.Context, accountID string) error {
+	f.invalidated = append(f.invalidated, accountID)
+	return nil
+}
+
+func TestService_SubmitAndReverse(t *testing.T) {
+	member := createDisabledMember(t)
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{member.ID: member}}
+	appeals := newFakeAppealRepo()
+	notifier := &fakeNotifier{}
+	entitlements := &fakeEntitlements{}
+	service := NewService(appeals, accounts, entitlements, notifier)
+	ctx := context.Background()
+
+	a, err := service.Submit(ctx, member.ID, "My comment quoted the article, it was not abuse.", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.ID == "" {
+		t.Error("expected generated appeal ID")
+	}
+
+	if _, err := service.Submit(ctx, member.ID, "Second appeal for the same disable action.", nil); err != ErrAppealAlreadyExists {
+		t.Errorf("expected error '%v', got '%v'", ErrAppealAlreadyExists, err)
+	}
+
+	queue, _ := service.ReviewQueue(ctx, 0, 0)
+	if len(queue) != 1 {
+		t.Errorf("expected 1 appeal in queue, got %d", len(queue))
+	}
+
+	if _, err := service.StartReview(ctx, a.ID, "mod1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decided, err := service.Decide(ctx, a.ID, "mod1", appeal.OutcomeReverse, "Quote taken out of context")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !member.IsActive() {
+		t.Error("expected account to be reactivated")
+	}
+	if accounts.updated != 1 {
+		t.Errorf("expected account to be saved once, got %d", accounts.updated)
+	}
+	if len(entitlements.invalidated) != 1 || entitlements.invalidated[0] != member.ID {
+		t.Errorf("expected the reactivation to invalidate entitlements, got %v", entitlements.invalidated)
+	}
+	if len(notifier.notified) != 1 || notifier.notified[0] != "member@example.com" {
+		t.Errorf("expected decision notification, got %v", notifier.notified)
+	}
+	if decided.NotifiedAt == nil {
+		t.Error("expected appeal to be marked notified")
+	}
+}
+
+func TestService_DecideUphold(t *testing.T) {
+	member := createDisabledMember(t)
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{member.ID: member}}
+	service := NewService(newFakeAppealRepo(), accounts, &fakeEntitlements{}, &fakeNotifier{})
+	ctx := context.Background()
+
+	a, _ := service.Submit(ctx, member.ID, "My comment quoted the article, it was not abuse.", nil)
+	_, _ = service.StartReview(ctx, a.ID, "mod1")
+	if _, err := service.Decide(ctx, a.ID, "mod1", appeal.OutcomeUphold, "Clear violation"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !member.IsDisabled() || accounts.updated != 0 {
+		t.Error("expected account to stay disabled")
+	}
+}
+
+func TestService_DecideReverse_DisableChanged(t *testing.T) {
+	member := createDisabledMember(t)
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{member.ID: member}}
+	service := NewService(newFakeAppealRepo(), accounts, &fakeEntitlements{}, &fakeNotifier{})
+	ctx := context.Background()
+
+	a, _ := service.Submit(ctx, member.ID, "My comment quoted the article, it was not abuse.", nil)
+	_, _ = service.StartReview(ctx, a.ID, "mod1")
+
+	// Staff reactivated the account by other means in the meantime
+	_ = member.Reactivate("admin1")
+
+	if _, err := service.Decide(ctx, a.ID, "mod1", appeal.OutcomeReverse, "ok"); err != ErrDisableChanged {
+		t.Errorf("expected error '%v', got '%v'", ErrDisableChanged, err)
+	}
+}
+
+func TestService_NotFound(t *testing.T) {
+	service := NewService(newFakeAppealRepo(), &fakeAccountRepo{accounts: map[string]*account.UserAccount{}}, &fakeEntitlements{}, &fakeNotifier{})
+
+	if _, err := service.Submit(context.Background(), "missing", "statement long enough to pass", nil); err != ErrAccountNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrAccountNotFound, err)
+	}
+	if _, err := service.StartReview(context.Background(), "missing", "mod1"); err != ErrAppealNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrAppealNotFound, err)
+	}
+}
+
+func createDisabledMember(t *testing.T) *account.UserAccount {
+	acc, err := account.NewUserAccountForSelfRegistration("member123", "member_user", "member@example.com", "hashed_password")
+	if err != nil {
+		t.Fatalf("failed to create member: %v", err)
+	}
+	if err := acc.SelfVerify(); err != nil {
+		t.Fatalf("failed to verify member: %v", err)
+	}
+	if err := acc.SetViolation("mod1", "abusive comment"); err != nil {
+		t.Fatalf("failed to disable member: %v", err)
+	}
+	return acc
+}
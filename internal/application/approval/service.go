@@ -0,0 +1,251 @@
+package approval
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/approval"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var (
+	ErrGrantNotFound   = errors.New("approval link not found")
+	ErrArticleNotFound = errors.New("article not found")
+	ErrNotInReview     = errors.New("article is no longer waiting for review")
+	ErrEditorInactive  = errors.New("editor account is not active")
+)
+
+// PushSender delivers an approval request to an editor's registered devices
+type PushSender interface {
+	SendApprovalRequest(ctx context.Context, editorID string, push ApprovalPush) error
+}
+
+// ApprovalPush is the notification payload, each link carries a token for exactly one action
+type ApprovalPush struct {
+	ArticleID  string
+	Title      string
+	Summary    string
+	ApproveURL string
+	RejectURL  string
+	ExpiresAt  time.Time
+}
+
+// RequestResult counts the pushes of one approval request
+type RequestResult struct {
+	Sent   int
+	Failed int // Editors whose push could not be delivered, they can still review at the desk
+}
+
+// Pending is what the app shows before the editor confirms
+type Pending struct {
+	Article   *article.Article
+	Action    approval.Action
+	ExpiresAt time.Time
+}
+
+// Service lets editors approve or reject articles in review from a push
+// notification. Decisions go through the article workflow like desk decisions,
+// so the same rules apply, e.g. no approving your own article.
+type Service struct {
+	grants       approval.GrantRepository
+	articles     article.ArticleRepository
+	accounts     account.UserAccountRepository
+	push         PushSender
+	tx           shared.Transactor
+	deepLinkBase string // e.g. "newsportal://approvals"
+}
+
+func NewService(grants approval.GrantRepository, articles article.ArticleRepository, accounts account.UserAccountRepository, push PushSender, tx shared.Transactor, deepLinkBase string) *Service {
+	return &Service{grants: grants, articles: articles, accounts: accounts, push: push, tx: tx, deepLinkBase: deepLinkBase}
+}
+
+// RequestApproval pushes approve and reject links for an article in review to each
+// editor. The author is skipped since they cannot approve their own work.
+func (s *Service) RequestApproval(ctx context.Context, articleID string, editorIDs []string, at time.Time) (*RequestResult, error) {
+	a, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status != article.StatusInReview {
+		return nil, ErrNotInReview
+	}
+
+	result := &RequestResult{}
+	for _, editorID := range editorIDs {
+		if editorID == a.AuthorID {
+			continue
+		}
+		push := ApprovalPush{ArticleID: a.ID, Title: a.Title, Summary: a.Summary, ExpiresAt: at.Add(approval.DefaultTTL)}
+		grants := make([]*approval.Grant, 0, 2)
+		for _, action := range []approval.Action{approval.ActionApprove, approval.ActionReject} {
+			grant, link, err := s.issue(editorID, a.ID, action, at)
+			if err != nil {
+				return result, err
+			}
+			grants = append(grants, grant)
+			if action == approval.ActionApprove {
+				push.ApproveURL = link
+			} else {
+				push.RejectURL = link
+			}
+		}
+		if err := s.grants.Create(ctx, grants...); err != nil {
+			return result, fmt.Errorf("failed to save approval links: %w", err)
+		}
+
+		if err := s.push.SendApprovalRequest(ctx, editorID, push); err != nil {
+			result.Failed++
+			continue
+		}
+		result.Sent++
+	}
+	return result, nil
+}
+
+// Preview shows what a link would do without using it up
+func (s *Service) Preview(ctx context.Context, token string, at time.Time) (*Pending, error) {
+	grant, err := s.findGrant(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := grant.CheckUsable(at); err != nil {
+		return nil, err
+	}
+	a, err := s.findArticle(ctx, grant.ArticleID)
+	if err != nil {
+		return nil, err
+	}
+	return &Pending{Article: a, Action: grant.Action, ExpiresAt: grant.ExpiresAt}, nil
+}
+
+// Decide takes the link's action on the article as its editor. The link is used
+// up and the article's other links are revoked in the same transaction, so a
+// second editor tapping an older push gets a clear answer instead of a stale decision.
+// A rejection needs a note for the author, like at the desk.
+func (s *Service) Decide(ctx context.Context, token, note string, at time.Time) (*article.Article, error) {
+	var decided *article.Article
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		grant, err := s.findGrant(ctx, token)
+		if err != nil {
+			return err
+		}
+		if err := grant.CheckUsable(at); err != nil {
+			return err
+		}
+
+		editor, err := s.accounts.FindByID(ctx, grant.EditorID)
+		if err != nil {
+			return fmt.Errorf("failed to load editor: %w", err)
+		}
+		if editor == nil || !editor.IsActive() || !editor.IsInternal() {
+			return ErrEditorInactive
+		}
+
+		a, err := s.findArticle(ctx, grant.ArticleID)
+		if err != nil {
+			return err
+		}
+		if a.Status != article.StatusInReview {
+			return ErrNotInReview
+		}
+		switch grant.Action {
+		case approval.ActionApprove:
+			err = a.Approve(grant.EditorID)
+		case approval.ActionReject:
+			err = a.Reject(grant.EditorID, note)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := grant.Redeem(at); err != nil {
+			return err
+		}
+		if err := s.articles.Update(ctx, a); err != nil {
+			return fmt.Errorf("failed to update article: %w", err)
+		}
+		if err := s.grants.Update(ctx, grant); err != nil {
+			return fmt.Errorf("failed to update approval link: %w", err)
+		}
+		if _, err := s.grants.RevokeForArticle(ctx, a.ID, at); err != nil {
+			return fmt.Errorf("failed to revoke approval links: %w", err)
+		}
+		decided = a
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decided, nil
+}
+
+// Withdraw revokes an article's outstanding links, call it when the desk decides first
+func (s *Service) Withdraw(ctx context.Context, articleID string, at time.Time) error {
+	if _, err := s.grants.RevokeForArticle(ctx, articleID, at); err != nil {
+		return fmt.Errorf("failed to revoke approval links: %w", err)
+	}
+	return nil
+}
+
+// issue creates a grant and its deep link
+func (s *Service) issue(editorID, articleID string, action approval.Action, at time.Time) (*approval.Grant, string, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, "", err
+	}
+	token, tokenHash, err := newGrantToken()
+	if err != nil {
+		return nil, "", err
+	}
+	grant, err := approval.NewGrant(id, tokenHash, editorID, articleID, action, approval.DefaultTTL, at)
+	if err != nil {
+		return nil, "", err
+	}
+	return grant, s.deepLinkBase + "?token=" + url.QueryEscape(token), nil
+}
+
+func (s *Service) findGrant(ctx context.Context, token string) (*approval.Grant, error) {
+	grant, err := s.grants.FindByTokenHash(ctx, hashGrantToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load approval link: %w", err)
+	}
+	if grant == nil {
+		return nil, ErrGrantNotFound
+	}
+	return grant, nil
+}
+
+func (s *Service) findArticle(ctx context.Context, articleID string) (*article.Article, error) {
+	a, err := s.articles.FindByID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article: %w", err)
+	}
+	if a == nil {
+		return nil, ErrArticleNotFound
+	}
+	return a, nil
+}
+
+// newGrantToken returns the URL-safe token for the deep link and the hash to store
+func newGrantToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate approval token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashGrantToken(token), nil
+}
+
+func hashGrantToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,217 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/approval"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memoryGrants struct {
+	grants []*approval.Grant
+}
+
+func (m *memoryGrants) Create(ctx context.Context, grants ...*approval.Grant) error {
+	m.grants = append(m.grants, grants...)
+	return nil
+}
+
+func (m *memoryGrants) Update(ctx context.Context, grant *approval.Grant) error {
+	return nil
+}
+
+func (m *memoryGrants) RevokeForArticle(ctx context.Context, articleID string, at time.Time) (int64, error) {
+	var n int64
+	for _, g := range m.grants {
+		if g.ArticleID == articleID && g.UsedAt == nil && g.RevokedAt == nil {
+			g.Revoke(at)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *memoryGrants) FindByTokenHash(ctx context.Context, tokenHash string) (*approval.Grant, error) {
+	for _, g := range m.grants {
+		if g.TokenHash == tokenHash {
+			return g, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeArticles implements only the lookups approvals use
+type fakeArticles struct {
+	article.ArticleRepository
+	articles map[string]*article.Article
+	updated  int
+}
+
+func (f *fakeArticles) FindByID(ctx context.Context, id string) (*article.Article, error) {
+	return f.articles[id], nil
+}
+
+func (f *fakeArticles) Update(ctx context.Context, a *article.Article) error {
+	f.updated++
+	return nil
+}
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+type fakePush struct {
+	sent map[string]ApprovalPush
+	fail map[string]bool
+}
+
+func (f *fakePush) SendApprovalRequest(ctx context.Context, editorID string, push ApprovalPush) error {
+	if f.fail[editorID] {
+		return errors.New("device unregistered")
+	}
+	f.sent[editorID] = push
+	return nil
+}
+
+func createReviewArticle(t *testing.T, id, authorID string) *article.Article {
+	t.Helper()
+	slug, _ := article.NewSlug("budget-vote-delayed")
+	a, err := article.NewArticle(id, *slug, "Budget vote delayed", "The vote moves to Friday", "Body", authorID)
+	if err != nil {
+		t.Fatalf("failed to create article: %v", err)
+	}
+	if err := a.SubmitForReview(authorID); err != nil {
+		t.Fatalf("failed to submit article: %v", err)
+	}
+	return a
+}
+
+func createEditor(t *testing.T, id string) *account.UserAccount {
+	t.Helper()
+	acc, err := account.NewUserAccountForTesting(id, strings.ReplaceAll(id, "-", "_"), id+"@newsroom.example.com", "EditorPass123!", account.TypeInternal, "admin123")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+	return acc
+}
+
+func createTestService(t *testing.T) (*Service, *memoryGrants, *fakeArticles, *fakePush) {
+	t.Helper()
+	grants := &memoryGrants{}
+	articles := &fakeArticles{articles: map[string]*article.Article{"article-1": createReviewArticle(t, "article-1", "reporter-1")}}
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{"editor-1": createEditor(t, "editor-1"), "editor-2": createEditor(t, "editor-2")}}
+	push := &fakePush{sent: map[string]ApprovalPush{}, fail: map[string]bool{"editor-3": true}}
+	return NewService(grants, articles, accounts, push, shared.NoTransaction{}, "newsportal://approvals"), grants, articles, push
+}
+
+func tokenOf(t *testing.T, link string) string {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("invalid link %s: %v", link, err)
+	}
+	return u.Query().Get("token")
+}
+
+func TestService_RequestApproval(t *testing.T) {
+	ctx := context.Background()
+	service, grants, _, push := createTestService(t)
+	at := time.Now()
+
+	result, err := service.RequestApproval(ctx, "article-1", []string{"editor-1", "reporter-1", "editor-3"}, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Sent != 1 || result.Failed != 1 {
+		t.Errorf("expected one push sent, one failed and the author skipped, got %+v", result)
+	}
+	sent := push.sent["editor-1"]
+	if !strings.HasPrefix(sent.ApproveURL, "newsportal://approvals?token=") || tokenOf(t, sent.ApproveURL) == tokenOf(t, sent.RejectURL) {
+		t.Errorf("expected separate approve and reject links, got %+v", sent)
+	}
+	if len(grants.grants) != 4 || strings.Contains(sent.ApproveURL, grants.grants[0].TokenHash) {
+		t.Errorf("expected two hashed grants per editor, got %d", len(grants.grants))
+	}
+
+	if _, err := service.RequestApproval(ctx, "missing", []string{"editor-1"}, at); err != ErrArticleNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrArticleNotFound, err)
+	}
+}
+
+func TestService_Decide(t *testing.T) {
+	ctx := context.Background()
+	service, grants, articles, push := createTestService(t)
+	at := time.Now()
+	_, _ = service.RequestApproval(ctx, "article-1", []string{"editor-1", "editor-2"}, at)
+	first, second := push.sent["editor-1"], push.sent["editor-2"]
+
+	pending, err := service.Preview(ctx, tokenOf(t, first.RejectURL), at.Add(time.Minute))
+	if err != nil || pending.Action != approval.ActionReject || pending.Article.ID != "article-1" {
+		t.Fatalf("expected a reject preview, got %+v, %v", pending, err)
+	}
+
+	// Rejecting needs a note, and a failed attempt leaves the link usable
+	if _, err := service.Decide(ctx, tokenOf(t, first.RejectURL), " ", at.Add(time.Minute)); !errors.Is(err, article.ErrEmptyReviewNote) {
+		t.Errorf("expected error '%v', got '%v'", article.ErrEmptyReviewNote, err)
+	}
+
+	if _, err := service.Decide(ctx, tokenOf(t, first.ApproveURL), "", at.Add(approval.DefaultTTL)); !errors.Is(err, approval.ErrExpired) {
+		t.Errorf("expected error '%v', got '%v'", approval.ErrExpired, err)
+	}
+
+	decided, err := service.Decide(ctx, tokenOf(t, first.ApproveURL), "", at.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decided.Status != article.StatusApproved || *decided.EditorID != "editor-1" || articles.updated != 1 {
+		t.Errorf("expected the article approved by editor-1, got %s", decided.Status)
+	}
+
+	// The other links are revoked, and the used one cannot be replayed
+	if _, err := service.Decide(ctx, tokenOf(t, second.RejectURL), "Needs a source", at.Add(3*time.Minute)); !errors.Is(err, approval.ErrRevoked) {
+		t.Errorf("expected error '%v', got '%v'", approval.ErrRevoked, err)
+	}
+	if _, err := service.Decide(ctx, tokenOf(t, first.ApproveURL), "", at.Add(3*time.Minute)); !errors.Is(err, approval.ErrUsed) {
+		t.Errorf("expected error '%v', got '%v'", approval.ErrUsed, err)
+	}
+	if _, err := service.Decide(ctx, "forged", "", at); err != ErrGrantNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrGrantNotFound, err)
+	}
+
+	used := 0
+	for _, g := range grants.grants {
+		if g.UsedAt != nil {
+			used++
+		}
+	}
+	if used != 1 {
+		t.Errorf("expected exactly one link used, got %d", used)
+	}
+}
+
+func TestService_DecideRequiresActiveEditor(t *testing.T) {
+	ctx := context.Background()
+	service, _, _, push := createTestService(t)
+	at := time.Now()
+	_, _ = service.RequestApproval(ctx, "article-1", []string{"editor-1"}, at)
+	service.accounts.(*fakeAccountRepo).accounts["editor-1"] = nil
+
+	if _, err := service.Decide(ctx, tokenOf(t, push.sent["editor-1"].ApproveURL), "", at); err != ErrEditorInactive {
+		t.Errorf("expected error '%v', got '%v'", ErrEditorInactive, err)
+	}
+}
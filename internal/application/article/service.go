@@ -0,0 +1,179 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/publishguard"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var ErrArticleNotFound = errors.New("article not found")
+
+// Checklist runs the desk's publish checklist variant, the checklist service implements it
+type Checklist interface {
+	Guard(ctx context.Context, articleID string) (*checklist.Result, error)
+}
+
+// Accessibility audits the article against its site's policy, the accessibility service implements it
+type Accessibility interface {
+	CheckPublish(ctx context.Context, siteID, title, language, body string) (*accessibility.Checklist, error)
+}
+
+// Guard watches staff actions for a hijacked session, the publishguard service implements it
+type Guard interface {
+	Guard(ctx context.Context, action publishguard.Action) (*publishguard.Decision, error)
+}
+
+// Actor is the staff member taking an action and the session they act from
+type Actor struct {
+	StaffID   string
+	SessionID string
+	IP        string
+	StepUpAt  *time.Time // Last re-authentication of the session
+}
+
+// Service moves articles through the publishing steps of the workflow. Every
+// publish, unpublish and edit goes through here, so the guards run whoever
+// pushes the button.
+type Service struct {
+	articles      article.ArticleRepository
+	revisions     article.RevisionRepository
+	drafts        checklist.ArticleSource
+	checklist     Checklist
+	accessibility Accessibility
+	guard         Guard
+	tx            shared.Transactor
+}
+
+func NewService(articles article.ArticleRepository, revisions article.RevisionRepository, drafts checklist.ArticleSource, checklist Checklist, accessibility Accessibility, guard Guard, tx shared.Transactor) *Service {
+	return &Service{articles: articles, revisions: revisions, drafts: drafts, checklist: checklist, accessibility: accessibility, guard: guard, tx: tx}
+}
+
+// Publish puts an approved article live once its desk's checklist and its
+// site's accessibility policy pass. A blocked publish returns
+// checklist.ErrPublishBlocked or accessibility.ErrPublishBlocked and leaves
+// the article as it was.
+func (s *Service) Publish(ctx context.Context, articleID string, by Actor, at time.Time) (*article.Article, error) {
+	a, err := s.article(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checklist.Guard(ctx, a.ID); err != nil {
+		return nil, err
+	}
+	draft, err := s.drafts.FindForChecklist(ctx, a.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article draft: %w", err)
+	}
+	if draft == nil {
+		return nil, ErrArticleNotFound
+	}
+	if _, err := s.accessibility.CheckPublish(ctx, draft.SiteID, draft.Title, draft.Language, draft.Body); err != nil {
+		return nil, err
+	}
+	if err := s.screen(ctx, publishguard.ActionPublish, a, by, at); err != nil {
+		return nil, err
+	}
+	if err := a.Publish(by.StaffID); err != nil {
+		return nil, err
+	}
+	if err := s.articles.Update(ctx, a); err != nil {
+		return nil, fmt.Errorf("failed to update article: %w", err)
+	}
+	return a, nil
+}
+
+// Unpublish takes a published article off the site by archiving it
+func (s *Service) Unpublish(ctx context.Context, articleID string, by Actor, at time.Time) (*article.Article, error) {
+	a, err := s.article(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.screen(ctx, publishguard.ActionUnpublish, a, by, at); err != nil {
+		return nil, err
+	}
+	if err := a.Archive(by.StaffID); err != nil {
+		return nil, err
+	}
+	if err := s.articles.Update(ctx, a); err != nil {
+		return nil, fmt.Errorf("failed to update article: %w", err)
+	}
+	return a, nil
+}
+
+// Edit changes the copy and saves the new version's revision with it
+func (s *Service) Edit(ctx context.Context, articleID, title, summary, body string, by Actor, at time.Time) (*article.Article, error) {
+	a, err := s.article(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.screen(ctx, publishguard.ActionEdit, a, by, at); err != nil {
+		return nil, err
+	}
+	if err := a.UpdateContent(title, summary, body, by.StaffID); err != nil {
+		return nil, err
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate revision ID: %w", err)
+	}
+	revision, err := article.NewRevision(id, a)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.articles.Update(ctx, a); err != nil {
+			return fmt.Errorf("failed to update article: %w", err)
+		}
+		if err := s.revisions.Create(ctx, revision); err != nil {
+			return fmt.Errorf("failed to save revision: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// screen asks the publish guard about the action. Unless it allows it the
+// action stops with the guard's error, ErrStepUpRequired or
+// ErrSessionSuspended, which the handler turns into a re-authentication
+// prompt or a signed-out session.
+func (s *Service) screen(ctx context.Context, kind publishguard.ActionKind, a *article.Article, by Actor, at time.Time) error {
+	decision, err := s.guard.Guard(ctx, publishguard.Action{
+		StaffID:            by.StaffID,
+		SessionID:          by.SessionID,
+		Kind:               kind,
+		ArticleID:          a.ID,
+		ArticlePublishedAt: a.PublishedAt,
+		IP:                 by.IP,
+		At:                 at,
+		StepUpAt:           by.StepUpAt,
+	})
+	switch {
+	case decision != nil && decision.Response != publishguard.ResponseAllow:
+		return err
+	case err != nil:
+		return fmt.Errorf("failed to run publish guard: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) article(ctx context.Context, id string) (*article.Article, error) {
+	a, err := s.articles.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article: %w", err)
+	}
+	if a == nil {
+		return nil, ErrArticleNotFound
+	}
+	return a, nil
+}
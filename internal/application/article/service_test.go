@@ -0,0 +1,248 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/publishguard"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// fakeArticles implements only the lookups publishing uses
+type fakeArticles struct {
+	article.ArticleRepository
+	articles map[string]*article.Article
+	updated  int
+}
+
+func (f *fakeArticles) FindByID(ctx context.Context, id string) (*article.Article, error) {
+	return f.articles[id], nil
+}
+
+func (f *fakeArticles) Update(ctx context.Context, a *article.Article) error {
+	f.updated++
+	return nil
+}
+
+type memoryRevisions struct {
+	article.RevisionRepository
+	revisions []*article.Revision
+}
+
+func (m *memoryRevisions) Create(ctx context.Context, revision *article.Revision) error {
+	m.revisions = append(m.revisions, revision)
+	return nil
+}
+
+// errStepUp stands in for the publishguard service's ErrStepUpRequired
+var errStepUp = errors.New("re-authentication required")
+
+// fakeGuard asks the sessions listed in stepUp to re-authenticate
+type fakeGuard struct {
+	stepUp  map[string]bool
+	actions []publishguard.Action
+}
+
+func (f *fakeGuard) Guard(ctx context.Context, action publishguard.Action) (*publishguard.Decision, error) {
+	f.actions = append(f.actions, action)
+	if f.stepUp[action.SessionID] {
+		return &publishguard.Decision{Response: publishguard.ResponseStepUp}, errStepUp
+	}
+	return &publishguard.Decision{Response: publishguard.ResponseAllow}, nil
+}
+
+// fakeChecklist blocks the articles listed in blocked
+type fakeChecklist struct {
+	blocked map[string]bool
+	guarded []string
+}
+
+func (f *fakeChecklist) Guard(ctx context.Context, articleID string) (*checklist.Result, error) {
+	f.guarded = append(f.guarded, articleID)
+	if f.blocked[articleID] {
+		return &checklist.Result{}, checklist.ErrPublishBlocked
+	}
+	return &checklist.Result{}, nil
+}
+
+// fakeDrafts reads the checklist view straight off the stored article, on one site
+type fakeDrafts struct {
+	articles *fakeArticles
+}
+
+func (f *fakeDrafts) FindForChecklist(ctx context.Context, articleID string) (*checklist.Article, error) {
+	a := f.articles.articles[articleID]
+	if a == nil {
+		return nil, nil
+	}
+	return &checklist.Article{ID: a.ID, SiteID: "site-1", Desk: "news", Title: a.Title, Language: "id", Body: a.Body}, nil
+}
+
+// fakeAccessibility blocks bodies with an image lacking alt text
+type fakeAccessibility struct{}
+
+func (fakeAccessibility) CheckPublish(ctx context.Context, siteID, title, language, body string) (*accessibility.Checklist, error) {
+	if body == "<img>" {
+		return &accessibility.Checklist{}, accessibility.ErrPublishBlocked
+	}
+	return &accessibility.Checklist{}, nil
+}
+
+func createApprovedArticle(t *testing.T, id string) *article.Article {
+	t.Helper()
+	slug, _ := article.NewSlug("budget-vote-delayed-" + id)
+	a, err := article.NewArticle(id, *slug, "Budget vote delayed", "The vote moves to Friday", "Body", "reporter-1")
+	if err != nil {
+		t.Fatalf("failed to create article: %v", err)
+	}
+	if err := a.SubmitForReview("reporter-1"); err != nil {
+		t.Fatalf("failed to submit article: %v", err)
+	}
+	if err := a.Approve("editor-1"); err != nil {
+		t.Fatalf("failed to approve article: %v", err)
+	}
+	return a
+}
+
+type testEnv struct {
+	service   *Service
+	articles  *fakeArticles
+	revisions *memoryRevisions
+	guard     *fakeGuard
+}
+
+var (
+	editor    = Actor{StaffID: "editor-1", SessionID: "sess-1", IP: "198.51.100.7"}
+	hijacked  = Actor{StaffID: "editor-1", SessionID: "sess-2", IP: "203.0.113.9"}
+	reporter  = Actor{StaffID: "reporter-1", SessionID: "sess-3", IP: "198.51.100.8"}
+	guardTime = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+)
+
+func createTestService(t *testing.T) *testEnv {
+	t.Helper()
+	articles := &fakeArticles{articles: map[string]*article.Article{
+		"article-1": createApprovedArticle(t, "article-1"),
+		"article-2": createApprovedArticle(t, "article-2"),
+		"article-3": createApprovedArticle(t, "article-3"),
+	}}
+	articles.articles["article-3"].Body = "<img>"
+	checklists := &fakeChecklist{blocked: map[string]bool{"article-2": true}}
+	env := &testEnv{articles: articles, revisions: &memoryRevisions{}, guard: &fakeGuard{stepUp: map[string]bool{"sess-2": true}}}
+	env.service = NewService(articles, env.revisions, &fakeDrafts{articles: articles}, checklists, fakeAccessibility{}, env.guard, shared.NoTransaction{})
+	return env
+}
+
+func TestService_Publish(t *testing.T) {
+	testCases := []struct {
+		name        string
+		articleID   string
+		expectedErr error
+	}{
+		{"checklist passes", "article-1", nil},
+		{"checklist blocks", "article-2", checklist.ErrPublishBlocked},
+		{"accessibility blocks", "article-3", accessibility.ErrPublishBlocked},
+		{"unknown article", "article-9", ErrArticleNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := createTestService(t)
+			articles := env.articles
+
+			a, err := env.service.Publish(context.Background(), tc.articleID, editor, guardTime)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err != nil {
+				if stored := articles.articles[tc.articleID]; stored != nil && stored.Status != article.StatusApproved {
+					t.Errorf("expected a blocked article to stay approved, got %s", stored.Status)
+				}
+				if articles.updated != 0 {
+					t.Errorf("expected nothing saved, got %d updates", articles.updated)
+				}
+				return
+			}
+			if a.Status != article.StatusPublished || articles.updated != 1 {
+				t.Errorf("expected the article published and saved, got %s with %d updates", a.Status, articles.updated)
+			}
+		})
+	}
+}
+
+func TestService_Guard(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		kind   publishguard.ActionKind
+		status article.Status // Status the article is in before the action
+		act    func(s *Service, by Actor) (*article.Article, error)
+	}{
+		{"publish", publishguard.ActionPublish, article.StatusApproved, func(s *Service, by Actor) (*article.Article, error) {
+			return s.Publish(ctx, "article-1", by, guardTime)
+		}},
+		{"unpublish", publishguard.ActionUnpublish, article.StatusPublished, func(s *Service, by Actor) (*article.Article, error) {
+			return s.Unpublish(ctx, "article-1", by, guardTime)
+		}},
+		{"edit", publishguard.ActionEdit, article.StatusDraft, func(s *Service, by Actor) (*article.Article, error) {
+			return s.Edit(ctx, "article-1", "Budget vote moved to Friday", "", "New body", by, guardTime)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env := createTestService(t)
+			a := env.articles.articles["article-1"]
+			by := editor
+			switch tc.status {
+			case article.StatusPublished:
+				if err := a.Publish("editor-1"); err != nil {
+					t.Fatalf("failed to publish article: %v", err)
+				}
+			case article.StatusDraft:
+				if err := a.Reject("editor-1", "Needs a second source"); err != nil {
+					t.Fatalf("failed to send article back: %v", err)
+				}
+				by = reporter
+			}
+
+			// A session the guard distrusts is stopped before anything changes
+			if _, err := tc.act(env.service, hijacked); !errors.Is(err, errStepUp) {
+				t.Fatalf("expected error '%v', got '%v'", errStepUp, err)
+			}
+			if a.Status != tc.status || env.articles.updated != 0 || len(env.revisions.revisions) != 0 {
+				t.Fatalf("expected the article untouched, got %s with %d updates", a.Status, env.articles.updated)
+			}
+
+			if _, err := tc.act(env.service, by); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if env.articles.updated != 1 {
+				t.Errorf("expected the article saved once, got %d updates", env.articles.updated)
+			}
+			last := env.guard.actions[len(env.guard.actions)-1]
+			if len(env.guard.actions) != 2 || last.Kind != tc.kind || last.ArticleID != "article-1" || last.StaffID != by.StaffID || !last.At.Equal(guardTime) {
+				t.Errorf("expected both attempts guarded as %s, got %+v", tc.kind, env.guard.actions)
+			}
+		})
+	}
+}
+
+func TestService_EditSavesRevision(t *testing.T) {
+	env := createTestService(t)
+	a := env.articles.articles["article-1"]
+	_ = a.Reject("editor-1", "Needs a second source")
+
+	edited, err := env.service.Edit(context.Background(), "article-1", "Budget vote moved to Friday", "", "New body", reporter, guardTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(env.revisions.revisions) != 1 || env.revisions.revisions[0].Version != edited.Version || env.revisions.revisions[0].Body != "New body" {
+		t.Errorf("expected the new version saved as a revision, got %+v", env.revisions.revisions)
+	}
+}
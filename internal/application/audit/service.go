@@ -0,0 +1,265 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// VerifyBatch is how many entries one verification read loads
+const VerifyBatch = 1000
+
+// appendAttempts bounds retries when concurrent writers race for the next sequence number
+const appendAttempts = 5
+
+// History page sizes
+const (
+	DefaultHistoryLimit = 50
+	MaxHistoryLimit     = 200
+)
+
+var (
+	ErrInvalidRange   = errors.New("export range must start at 1 or later and end at or after its start")
+	ErrEntryNotFound  = errors.New("audit entry not found")
+	ErrInvalidHistory = errors.New("history needs a target type and ID or an actor")
+)
+
+// Report is the outcome of a full verification
+type Report struct {
+	Entries int
+	Head    *audit.Entry
+	Anchors int
+}
+
+// Bundle is a verified range of the log for export, with the entry before
+// the range so a recipient can check its first link
+type Bundle struct {
+	Previous *audit.Entry
+	Entries  []*audit.Entry
+	Anchors  []*audit.Anchor
+}
+
+type Service struct {
+	entries audit.EntryRepository
+	anchors audit.AnchorRepository
+	store   audit.AnchorStore
+}
+
+func NewService(entries audit.EntryRepository, anchors audit.AnchorRepository, store audit.AnchorStore) *Service {
+	return &Service{entries: entries, anchors: anchors, store: store}
+}
+
+var _ audit.Trail = (*Service)(nil)
+
+// Record appends an event to the chain, without an IP it takes the one the
+// request put in the context
+func (s *Service) Record(ctx context.Context, event audit.Event, at time.Time) (*audit.Entry, error) {
+	if event.IP == "" {
+		event.IP = audit.IPFromContext(ctx)
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate audit entry ID: %w", err)
+	}
+
+	for attempt := 0; attempt < appendAttempts; attempt++ {
+		head, err := s.entries.Head(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load audit head: %w", err)
+		}
+		entry, err := audit.NewEntry(head, id, event.ActorID, event.Action, event.TargetType, event.TargetID, event.IP, event.Details, event.Changes, at)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.entries.Append(ctx, entry)
+		if err == nil {
+			return entry, nil
+		}
+		if !errors.Is(err, audit.ErrSequenceTaken) {
+			return nil, fmt.Errorf("failed to append audit entry: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("failed to append audit entry: %w", audit.ErrSequenceTaken)
+}
+
+// Emit records an event for the services that audit their actions, in the
+// caller's transaction when there is one
+func (s *Service) Emit(ctx context.Context, event audit.Event, at time.Time) error {
+	_, err := s.Record(ctx, event, at)
+	return err
+}
+
+// History pages through the entries about a target, or by an actor when no
+// target is given, newest first. Pass the Seq of the last entry of a page as
+// beforeSeq for the next one.
+func (s *Service) History(ctx context.Context, targetType, targetID, actorID string, beforeSeq uint64, limit int) ([]*audit.Entry, error) {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	limit = min(limit, MaxHistoryLimit)
+
+	var entries []*audit.Entry
+	var err error
+	switch {
+	case targetType != "" && targetID != "":
+		entries, err = s.entries.FindByTarget(ctx, targetType, targetID, beforeSeq, limit)
+	case targetType == "" && targetID == "" && actorID != "":
+		entries, err = s.entries.FindByActor(ctx, actorID, beforeSeq, limit)
+	default:
+		return nil, ErrInvalidHistory
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit history: %w", err)
+	}
+	return entries, nil
+}
+
+// Anchor copies the current head to external storage, it is a no-op when
+// the head is already anchored
+func (s *Service) Anchor(ctx context.Context, at time.Time) (*audit.Anchor, error) {
+	head, err := s.entries.Head(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit head: %w", err)
+	}
+	if head == nil {
+		return nil, nil
+	}
+	anchors, err := s.anchors.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load anchors: %w", err)
+	}
+	for _, a := range anchors {
+		if a.Seq == head.Seq {
+			return a, nil
+		}
+	}
+
+	anchor := &audit.Anchor{Seq: head.Seq, Hash: head.Hash, AnchoredAt: at}
+	if anchor.Location, err = s.store.Put(ctx, anchor); err != nil {
+		return nil, fmt.Errorf("failed to store anchor: %w", err)
+	}
+	if err := s.anchors.Save(ctx, anchor); err != nil {
+		return nil, fmt.Errorf("failed to save anchor: %w", err)
+	}
+	return anchor, nil
+}
+
+// Verify walks the whole chain. Local anchor records are first compared with
+// their external copies, so an intruder who rewrote the chain and the anchor
+// table together is still caught. Once retention has purged old entries the
+// chain starts after the anchor they were purged up to.
+func (s *Service) Verify(ctx context.Context) (*Report, error) {
+	anchors, err := s.trustedAnchors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := audit.NewVerifier(anchors)
+	report := &Report{Anchors: len(anchors)}
+	for from := uint64(1); ; {
+		batch, err := s.entries.Range(ctx, from, VerifyBatch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load audit entries: %w", err)
+		}
+		if from == 1 && len(batch) > 0 && batch[0].Seq > 1 {
+			start := anchorAt(anchors, batch[0].Seq-1)
+			if start == nil {
+				return report, fmt.Errorf("%w: log starts at entry %d without an anchor before it", audit.ErrGap, batch[0].Seq)
+			}
+			verifier = audit.NewVerifierAfter(&audit.Entry{Seq: start.Seq, Hash: start.Hash}, anchors)
+		}
+		for _, e := range batch {
+			if err := verifier.Check(e); err != nil {
+				return report, err
+			}
+			report.Head = e
+			report.Entries = verifier.Checked()
+		}
+		if len(batch) < VerifyBatch {
+			break
+		}
+		from = batch[len(batch)-1].Seq + 1
+	}
+	return report, verifier.Finish()
+}
+
+func anchorAt(anchors []audit.Anchor, seq uint64) *audit.Anchor {
+	for i := range anchors {
+		if anchors[i].Seq == seq {
+			return &anchors[i]
+		}
+	}
+	return nil
+}
+
+func (s *Service) trustedAnchors(ctx context.Context) ([]audit.Anchor, error) {
+	records, err := s.anchors.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load anchors: %w", err)
+	}
+	anchors := make([]audit.Anchor, 0, len(records))
+	for _, record := range records {
+		external, err := s.store.Get(ctx, record.Location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load anchor %d: %w", record.Seq, err)
+		}
+		if external.Seq != record.Seq || external.Hash != record.Hash {
+			return nil, fmt.Errorf("%w: anchor record %d differs from its external copy", audit.ErrAnchorMismatch, record.Seq)
+		}
+		anchors = append(anchors, *external)
+	}
+	sort.Slice(anchors, func(i, j int) bool { return anchors[i].Seq < anchors[j].Seq })
+	return anchors, nil
+}
+
+// Export loads and verifies the entries fromSeq..toSeq for a signed bundle
+func (s *Service) Export(ctx context.Context, fromSeq, toSeq uint64) (*Bundle, error) {
+	if fromSeq < 1 || toSeq < fromSeq {
+		return nil, ErrInvalidRange
+	}
+	anchors, err := s.trustedAnchors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{}
+	if fromSeq > 1 {
+		if bundle.Previous, err = s.entries.FindBySeq(ctx, fromSeq-1); err != nil {
+			return nil, fmt.Errorf("failed to load audit entry: %w", err)
+		}
+		if bundle.Previous == nil {
+			return nil, ErrEntryNotFound
+		}
+	}
+
+	verifier := audit.NewVerifierAfter(bundle.Previous, anchors)
+	for from := fromSeq; from <= toSeq; {
+		batch, err := s.entries.Range(ctx, from, int(min(toSeq-from+1, VerifyBatch)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load audit entries: %w", err)
+		}
+		if len(batch) == 0 {
+			return nil, ErrEntryNotFound
+		}
+		for _, e := range batch {
+			if err := verifier.Check(e); err != nil {
+				return nil, err
+			}
+		}
+		bundle.Entries = append(bundle.Entries, batch...)
+		from = batch[len(batch)-1].Seq + 1
+	}
+
+	for i := range anchors {
+		if anchors[i].Seq >= fromSeq && anchors[i].Seq <= toSeq {
+			bundle.Anchors = append(bundle.Anchors, &anchors[i])
+		}
+	}
+	return bundle, nil
+}
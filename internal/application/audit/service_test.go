@@ -0,0 +1,262 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+)
+
+type memoryEntries struct {
+	entries []*audit.Entry
+	races   int // Append fails this many times as if another writer got there first
+}
+
+func (m *memoryEntries) Append(ctx context.Context, entry *audit.Entry) error {
+	if m.races > 0 {
+		m.races--
+		return audit.ErrSequenceTaken
+	}
+	if entry.Seq != uint64(len(m.entries))+1 {
+		return audit.ErrSequenceTaken
+	}
+	copied := *entry
+	m.entries = append(m.entries, &copied)
+	return nil
+}
+
+func (m *memoryEntries) Head(ctx context.Context) (*audit.Entry, error) {
+	if len(m.entries) == 0 {
+		return nil, nil
+	}
+	return m.entries[len(m.entries)-1], nil
+}
+
+func (m *memoryEntries) FindBySeq(ctx context.Context, seq uint64) (*audit.Entry, error) {
+	if seq == 0 || seq > uint64(len(m.entries)) {
+		return nil, nil
+	}
+	return m.entries[seq-1], nil
+}
+
+func (m *memoryEntries) Range(ctx context.Context, fromSeq uint64, limit int) ([]*audit.Entry, error) {
+	var found []*audit.Entry
+	for _, e := range m.entries {
+		if e.Seq >= fromSeq && len(found) < limit {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryEntries) FindByTarget(ctx context.Context, targetType, targetID string, beforeSeq uint64, limit int) ([]*audit.Entry, error) {
+	return m.newestFirst(func(e *audit.Entry) bool { return e.TargetType == targetType && e.TargetID == targetID }, beforeSeq, limit), nil
+}
+
+func (m *memoryEntries) FindByActor(ctx context.Context, actorID string, beforeSeq uint64, limit int) ([]*audit.Entry, error) {
+	return m.newestFirst(func(e *audit.Entry) bool { return e.ActorID == actorID }, beforeSeq, limit), nil
+}
+
+func (m *memoryEntries) newestFirst(match func(*audit.Entry) bool, beforeSeq uint64, limit int) []*audit.Entry {
+	var found []*audit.Entry
+	for i := len(m.entries) - 1; i >= 0 && len(found) < limit; i-- {
+		if e := m.entries[i]; match(e) && (beforeSeq == 0 || e.Seq < beforeSeq) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+type memoryAnchors struct {
+	anchors []*audit.Anchor
+}
+
+func (m *memoryAnchors) Save(ctx context.Context, anchor *audit.Anchor) error {
+	m.anchors = append(m.anchors, anchor)
+	return nil
+}
+
+func (m *memoryAnchors) FindAll(ctx context.Context) ([]*audit.Anchor, error) {
+	return m.anchors, nil
+}
+
+type memoryStore struct {
+	objects map[string]audit.Anchor
+}
+
+func (m *memoryStore) Put(ctx context.Context, anchor *audit.Anchor) (string, error) {
+	location := fmt.Sprintf("anchors/%d", anchor.Seq)
+	m.objects[location] = *anchor
+	return location, nil
+}
+
+func (m *memoryStore) Get(ctx context.Context, location string) (*audit.Anchor, error) {
+	anchor, ok := m.objects[location]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+	return &anchor, nil
+}
+
+func newTestService(t *testing.T, n int) (*Service, *memoryEntries, *memoryAnchors) {
+	t.Helper()
+	entries := &memoryEntries{}
+	anchors := &memoryAnchors{}
+	service := NewService(entries, anchors, &memoryStore{objects: map[string]audit.Anchor{}})
+	for i := 0; i < n; i++ {
+		if _, err := service.Record(context.Background(), audit.Event{ActorID: "editor-1", Action: "article.publish", TargetType: "article", TargetID: fmt.Sprintf("a%d", i)}, time.Now()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return service, entries, anchors
+}
+
+func TestService_RecordRetriesRace(t *testing.T) {
+	service, entries, _ := newTestService(t, 1)
+	entries.races = 2
+
+	entry, err := service.Record(context.Background(), audit.Event{ActorID: "editor-1", Action: "article.unpublish"}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Seq != 2 || entry.PrevHash != entries.entries[0].Hash {
+		t.Errorf("expected entry 2 linked to entry 1, got %+v", entry)
+	}
+
+	entries.races = appendAttempts
+	if _, err := service.Record(context.Background(), audit.Event{ActorID: "editor-1", Action: "article.unpublish"}, time.Now()); !errors.Is(err, audit.ErrSequenceTaken) {
+		t.Errorf("expected error '%v', got '%v'", audit.ErrSequenceTaken, err)
+	}
+}
+
+func TestService_AnchorAndVerify(t *testing.T) {
+	service, entries, anchors := newTestService(t, 5)
+	ctx := context.Background()
+
+	anchor, err := service.Anchor(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again, _ := service.Anchor(ctx, time.Now()); again != anchor || len(anchors.anchors) != 1 {
+		t.Errorf("expected an anchored head not anchored twice, got %d anchors", len(anchors.anchors))
+	}
+
+	report, err := service.Verify(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Entries != 5 || report.Head.Seq != 5 || report.Anchors != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	// Rewriting the chain and the local anchor record together is caught by the external copy
+	entries.entries = entries.entries[:3]
+	anchors.anchors[0].Seq, anchors.anchors[0].Hash = 3, entries.entries[2].Hash
+	if _, err := service.Verify(ctx); !errors.Is(err, audit.ErrAnchorMismatch) {
+		t.Errorf("expected error '%v', got '%v'", audit.ErrAnchorMismatch, err)
+	}
+}
+
+func TestService_VerifyDetectsTampering(t *testing.T) {
+	service, entries, _ := newTestService(t, 3)
+	entries.entries[1].ActorID = "intruder"
+
+	report, err := service.Verify(context.Background())
+	if !errors.Is(err, audit.ErrTampered) || report.Entries != 1 {
+		t.Errorf("expected tampering found after 1 good entry, got %v %+v", err, report)
+	}
+}
+
+func TestService_VerifyAfterPurge(t *testing.T) {
+	service, entries, _ := newTestService(t, 5)
+	ctx := context.Background()
+	entries.entries = entries.entries[2:]
+
+	if _, err := service.Verify(ctx); !errors.Is(err, audit.ErrGap) {
+		t.Errorf("expected error '%v', got '%v'", audit.ErrGap, err)
+	}
+
+	// Retention purges up to an anchored entry, the rest of the chain hangs off its hash
+	service, entries, _ = newTestService(t, 3)
+	_, _ = service.Anchor(ctx, time.Now())
+	for i := 0; i < 2; i++ {
+		_, _ = service.Record(ctx, audit.Event{ActorID: "editor-1", Action: "article.publish"}, time.Now())
+	}
+	entries.entries = entries.entries[3:]
+
+	report, err := service.Verify(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Entries != 2 || report.Head.Seq != 5 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestService_Export(t *testing.T) {
+	service, _, _ := newTestService(t, 5)
+	ctx := context.Background()
+	_, _ = service.Anchor(ctx, time.Now())
+
+	bundle, err := service.Export(ctx, 3, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bundle.Previous.Seq != 2 || len(bundle.Entries) != 3 || len(bundle.Anchors) != 1 {
+		t.Errorf("unexpected bundle: previous %d, %d entries, %d anchors", bundle.Previous.Seq, len(bundle.Entries), len(bundle.Anchors))
+	}
+
+	if _, err := service.Export(ctx, 4, 9); err != ErrEntryNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrEntryNotFound, err)
+	}
+	if _, err := service.Export(ctx, 0, 2); err != ErrInvalidRange {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidRange, err)
+	}
+}
+
+func TestService_RecordTakesIPFromContext(t *testing.T) {
+	service, _, _ := newTestService(t, 0)
+	ctx := audit.WithIP(context.Background(), "203.0.113.7")
+
+	entry, err := service.Record(ctx, audit.Event{ActorID: "admin-1", Action: "account.disable", TargetType: "account", TargetID: "u1"}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.IP != "203.0.113.7" {
+		t.Errorf("expected the request IP, got %q", entry.IP)
+	}
+	entry, _ = service.Record(ctx, audit.Event{ActorID: "admin-1", Action: "account.verify", IP: "198.51.100.1"}, time.Now())
+	if entry.IP != "198.51.100.1" {
+		t.Errorf("expected an explicit IP kept, got %q", entry.IP)
+	}
+}
+
+func TestService_History(t *testing.T) {
+	service, _, _ := newTestService(t, 0)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		_ = service.Emit(ctx, audit.Event{ActorID: "admin-1", Action: "account.verify", TargetType: "account", TargetID: "u1"}, time.Now())
+		_ = service.Emit(ctx, audit.Event{ActorID: "admin-2", Action: "account.disable", TargetType: "account", TargetID: "u2"}, time.Now())
+	}
+
+	page, err := service.History(ctx, "account", "u1", "", 0, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page) != 3 || page[0].Seq != 9 || page[2].Seq != 5 {
+		t.Fatalf("expected the newest three entries about u1, got %d", len(page))
+	}
+	next, _ := service.History(ctx, "account", "u1", "", page[2].Seq, 3)
+	if len(next) != 2 || next[1].Seq != 1 {
+		t.Errorf("expected the remaining two entries, got %d", len(next))
+	}
+	if byActor, _ := service.History(ctx, "", "", "admin-2", 0, 0); len(byActor) != 5 {
+		t.Errorf("expected five entries by admin-2, got %d", len(byActor))
+	}
+	if _, err := service.History(ctx, "account", "", "admin-1", 0, 0); err != ErrInvalidHistory {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidHistory, err)
+	}
+}
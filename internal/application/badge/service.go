@@ -0,0 +1,146 @@
+package badge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/badge"
+)
+
+var (
+	ErrAccountNotFound = errors.New("account not found")
+	ErrAlreadyBadged   = errors.New("account already has a badge, revoke it first")
+	ErrNotBadged       = errors.New("account has no badge")
+)
+
+// Profile is the public view of an account
+type Profile struct {
+	Account *account.UserAccount
+	Badge   *badge.Badge // nil when not verified
+}
+
+type Service struct {
+	badges   badge.BadgeRepository
+	audit    badge.AuditRepository
+	accounts account.UserAccountRepository
+}
+
+func NewService(badges badge.BadgeRepository, audit badge.AuditRepository, accounts account.UserAccountRepository) *Service {
+	return &Service{badges: badges, audit: audit, accounts: accounts}
+}
+
+// Grant gives an account a verified badge. A username that already looks like
+// another verified account is refused, so a look-alike cannot be verified by mistake.
+func (s *Service) Grant(ctx context.Context, staffID, accountID string, kind badge.Kind, reason string, at time.Time) (*badge.Badge, error) {
+	acc, err := s.account(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.badges.FindActiveByAccountID(ctx, acc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load badge: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrAlreadyBadged
+	}
+	if err := s.CheckUsername(ctx, acc.ID, acc.Username.String()); err != nil {
+		return nil, err
+	}
+
+	b, err := badge.NewBadge(acc.ID, acc.Username.String(), kind, staffID, reason, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.badges.Save(ctx, b); err != nil {
+		return nil, fmt.Errorf("failed to save badge: %w", err)
+	}
+	if err := s.record(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, staffID, accountID, reason string, at time.Time) (*badge.Badge, error) {
+	b, err := s.badges.FindActiveByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load badge: %w", err)
+	}
+	if b == nil {
+		return nil, ErrNotBadged
+	}
+	if err := b.Revoke(staffID, reason, at); err != nil {
+		return nil, err
+	}
+	if err := s.badges.Save(ctx, b); err != nil {
+		return nil, fmt.Errorf("failed to save badge: %w", err)
+	}
+	if err := s.record(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *Service) History(ctx context.Context, accountID string) ([]badge.AuditEntry, error) {
+	entries, err := s.audit.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load badge history: %w", err)
+	}
+	return entries, nil
+}
+
+// CheckUsername returns badge.ErrImpersonation when the username looks like a
+// verified account's. Registration and username changes call it before saving.
+func (s *Service) CheckUsername(ctx context.Context, accountID, username string) error {
+	holders, err := s.badges.FindActiveBySkeleton(ctx, badge.Skeleton(username))
+	if err != nil {
+		return fmt.Errorf("failed to check verified usernames: %w", err)
+	}
+	for _, b := range holders {
+		if b.Impersonates(accountID, username) {
+			return badge.ErrImpersonation
+		}
+	}
+	return nil
+}
+
+// Profile returns an account's public profile with its badge
+func (s *Service) Profile(ctx context.Context, username string) (*Profile, error) {
+	acc, err := s.accounts.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil || !acc.IsActive() {
+		return nil, ErrAccountNotFound
+	}
+	b, err := s.badges.FindActiveByAccountID(ctx, acc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load badge: %w", err)
+	}
+	return &Profile{Account: acc, Badge: b}, nil
+}
+
+func (s *Service) account(ctx context.Context, accountID string) (*account.UserAccount, error) {
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil {
+		return nil, ErrAccountNotFound
+	}
+	return acc, nil
+}
+
+func (s *Service) record(ctx context.Context, b *badge.Badge) error {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	if err := s.audit.Create(ctx, b.Audit(id)); err != nil {
+		return fmt.Errorf("failed to record badge audit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package badge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/badge"
+)
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+func (f *fakeAccountRepo) FindByUsername(ctx context.Context, username string) (*account.UserAccount, error) {
+	for _, acc := range f.accounts {
+		if acc.Username.String() == username {
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+type memoryBadges struct {
+	badges map[string]*badge.Badge
+}
+
+func (m *memoryBadges) Save(ctx context.Context, b *badge.Badge) error {
+	m.badges[b.AccountID] = b
+	return nil
+}
+
+func (m *memoryBadges) FindActiveByAccountID(ctx context.Context, accountID string) (*badge.Badge, error) {
+	if b, ok := m.badges[accountID]; ok && b.IsActive() {
+		return b, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryBadges) FindActiveByAccountIDs(ctx context.Context, accountIDs []string) (map[string]*badge.Badge, error) {
+	found := map[string]*badge.Badge{}
+	for _, id := range accountIDs {
+		if b, ok := m.badges[id]; ok && b.IsActive() {
+			found[id] = b
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryBadges) FindActiveBySkeleton(ctx context.Context, skeleton string) ([]*badge.Badge, error) {
+	var found []*badge.Badge
+	for _, b := range m.badges {
+		if b.IsActive() && b.Skeleton == skeleton {
+			found = append(found, b)
+		}
+	}
+	return found, nil
+}
+
+type memoryAudit struct {
+	entries []badge.AuditEntry
+}
+
+func (m *memoryAudit) Create(ctx context.Context, entry badge.AuditEntry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *memoryAudit) FindByAccountID(ctx context.Context, accountID string) ([]badge.AuditEntry, error) {
+	var found []badge.AuditEntry
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		if m.entries[i].AccountID == accountID {
+			found = append(found, m.entries[i])
+		}
+	}
+	return found, nil
+}
+
+func createTestMember(t *testing.T, id, username string) *account.UserAccount {
+	t.Helper()
+	acc, err := account.NewUserAccountForTesting(id, username, username+"@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+	return acc
+}
+
+func createTestService(t *testing.T) (*Service, *memoryAudit) {
+	t.Helper()
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{
+		"acc-1": createTestMember(t, "acc-1", "jokosaputro"),
+		"acc-2": createTestMember(t, "acc-2", "j0ko_saputr0"),
+	}}
+	audit := &memoryAudit{}
+	return NewService(&memoryBadges{badges: map[string]*badge.Badge{}}, audit, accounts), audit
+}
+
+func TestService_GrantAndRevoke(t *testing.T) {
+	s, audit := createTestService(t)
+	ctx := context.Background()
+
+	if _, err := s.Grant(ctx, "staff-1", "acc-1", badge.KindAuthor, "Senior columnist", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Grant(ctx, "staff-1", "acc-1", badge.KindCommenter, "Twice", time.Now()); !errors.Is(err, ErrAlreadyBadged) {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyBadged, err)
+	}
+	if _, err := s.Grant(ctx, "staff-1", "acc-2", badge.KindAuthor, "Looks legit", time.Now()); !errors.Is(err, badge.ErrImpersonation) {
+		t.Errorf("expected error '%v', got '%v'", badge.ErrImpersonation, err)
+	}
+
+	profile, err := s.Profile(ctx, "jokosaputro")
+	if err != nil || profile.Badge == nil || profile.Badge.Kind != badge.KindAuthor {
+		t.Fatalf("expected profile with author badge, got %+v, %v", profile, err)
+	}
+
+	if _, err := s.Revoke(ctx, "staff-2", "acc-1", "Left the newsroom", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Revoke(ctx, "staff-2", "acc-1", "Again", time.Now()); !errors.Is(err, ErrNotBadged) {
+		t.Errorf("expected error '%v', got '%v'", ErrNotBadged, err)
+	}
+
+	history, _ := s.History(ctx, "acc-1")
+	if len(history) != 2 || history[0].Action != badge.ActionRevoke || history[1].Action != badge.ActionGrant {
+		t.Errorf("expected revoke then grant in history, got %+v", history)
+	}
+	if len(audit.entries) != 2 {
+		t.Errorf("expected 2 audit entries, got %d", len(audit.entries))
+	}
+}
+
+func TestService_CheckUsername(t *testing.T) {
+	s, _ := createTestService(t)
+	ctx := context.Background()
+	if _, err := s.Grant(ctx, "staff-1", "acc-1", badge.KindAuthor, "Senior columnist", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		accountID     string
+		username      string
+		expectedError error
+	}{
+		{"new look-alike", "", "jokosaputr0", badge.ErrImpersonation},
+		{"rename to look-alike", "acc-3", "Joko_Saputro", badge.ErrImpersonation},
+		{"holder renames", "acc-1", "jokosaputro_", nil},
+		{"unrelated", "", "sitinurhaliza", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.CheckUsername(ctx, tc.accountID, tc.username)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package bruteforce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/bruteforce"
+)
+
+var (
+	ErrInvalidIP   = errors.New("invalid client IP address")
+	ErrBanNotFound = errors.New("no active ban for this subject")
+)
+
+// Service throttles failed logins per IP and per ASN. It complements the
+// per-account lockout, which cannot see one client trying many accounts.
+type Service struct {
+	counter bruteforce.FailureCounter
+	bans    bruteforce.BanRepository
+	asns    bruteforce.ASNResolver
+	policy  bruteforce.Policy
+}
+
+func NewService(counter bruteforce.FailureCounter, bans bruteforce.BanRepository, asns bruteforce.ASNResolver, policy bruteforce.Policy) *Service {
+	return &Service{counter: counter, bans: bans, asns: asns, policy: policy}
+}
+
+// Check returns the ban in force for the client's address or network, nil
+// when the login may proceed
+func (s *Service) Check(ctx context.Context, clientIP string, at time.Time) (*bruteforce.Ban, error) {
+	ip, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return nil, ErrInvalidIP
+	}
+	if s.policy.Allowlist.Contains(ip) {
+		return nil, nil
+	}
+
+	for _, subject := range s.subjects(ctx, ip) {
+		ban, err := s.bans.FindLatest(ctx, subject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ban: %w", err)
+		}
+		if ban != nil && ban.IsActive(at) {
+			return ban, nil
+		}
+	}
+	return nil, nil
+}
+
+// RecordFailure counts a failed login from the client at both levels and
+// returns the ban it triggered, if any
+func (s *Service) RecordFailure(ctx context.Context, clientIP string, at time.Time) (*bruteforce.Ban, error) {
+	ip, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return nil, ErrInvalidIP
+	}
+	if s.policy.Allowlist.Contains(ip) {
+		return nil, nil
+	}
+
+	var triggered *bruteforce.Ban
+	for _, subject := range s.subjects(ctx, ip) {
+		threshold := s.policy.Threshold(subject.Scope)
+		failures, err := s.counter.Increment(ctx, subject, threshold.Window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count login failure: %w", err)
+		}
+		if failures < threshold.Failures {
+			continue
+		}
+		ban, err := s.ban(ctx, subject, failures, at)
+		if err != nil {
+			return nil, err
+		}
+		if triggered == nil {
+			triggered = ban
+		}
+	}
+	return triggered, nil
+}
+
+func (s *Service) ban(ctx context.Context, subject bruteforce.Subject, failures int, at time.Time) (*bruteforce.Ban, error) {
+	strike := 1
+	previous, err := s.bans.FindLatest(ctx, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ban: %w", err)
+	}
+	if previous != nil {
+		if previous.IsActive(at) {
+			// Failures from a banned client only arrive while the ban is being stored
+			return previous, nil
+		}
+		strike = previous.NextStrike(s.policy, at)
+	}
+
+	ban, err := bruteforce.NewBan(subject, failures, strike, s.policy.BanDuration(subject.Scope, strike), at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.bans.Save(ctx, ban, ban.ExpiresAt.Sub(at)+s.policy.StrikeMemory); err != nil {
+		return nil, fmt.Errorf("failed to save ban: %w", err)
+	}
+	// The counter starts over so the ban is not extended by failures already counted
+	if err := s.counter.Reset(ctx, subject); err != nil {
+		return nil, fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return ban, nil
+}
+
+// subjects is the client's address and, when known, its autonomous system.
+// A failed ASN lookup leaves only the address, which still gets throttled.
+func (s *Service) subjects(ctx context.Context, ip netip.Addr) []bruteforce.Subject {
+	subjects := []bruteforce.Subject{{Scope: bruteforce.ScopeIP, Value: ip.Unmap().String()}}
+	if asn, err := s.asns.Lookup(ctx, ip); err == nil && asn != 0 {
+		subjects = append(subjects, bruteforce.Subject{Scope: bruteforce.ScopeASN, Value: strconv.FormatUint(uint64(asn), 10)})
+	}
+	return subjects
+}
+
+// Bans lists the bans in force for the admin screen
+func (s *Service) Bans(ctx context.Context, at time.Time) ([]*bruteforce.Ban, error) {
+	bans, err := s.bans.FindActive(ctx, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bans: %w", err)
+	}
+	return bans, nil
+}
+
+// Lift ends a ban early and clears the subject's failure count
+func (s *Service) Lift(ctx context.Context, staffID string, scope bruteforce.Scope, value string, at time.Time) (*bruteforce.Ban, error) {
+	subject, err := bruteforce.NewSubject(scope, value)
+	if err != nil {
+		return nil, err
+	}
+	ban, err := s.bans.FindLatest(ctx, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ban: %w", err)
+	}
+	if ban == nil || !ban.IsActive(at) {
+		return nil, ErrBanNotFound
+	}
+
+	if err := ban.Lift(staffID, at); err != nil {
+		return nil, err
+	}
+	if err := s.bans.Save(ctx, ban, s.policy.StrikeMemory); err != nil {
+		return nil, fmt.Errorf("failed to save ban: %w", err)
+	}
+	if err := s.counter.Reset(ctx, subject); err != nil {
+		return nil, fmt.Errorf("failed to reset login failures: %w", err)
+	}
+	return ban, nil
+}
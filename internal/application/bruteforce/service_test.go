@@ -0,0 +1,167 @@
+package bruteforce
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/bruteforce"
+)
+
+type memoryCounter struct {
+	counts map[string]int
+}
+
+func (m *memoryCounter) Increment(ctx context.Context, subject bruteforce.Subject, window time.Duration) (int, error) {
+	m.counts[subject.Key()]++
+	return m.counts[subject.Key()], nil
+}
+
+func (m *memoryCounter) Reset(ctx context.Context, subject bruteforce.Subject) error {
+	delete(m.counts, subject.Key())
+	return nil
+}
+
+type memoryBans struct {
+	bans map[string]*bruteforce.Ban
+}
+
+func (m *memoryBans) Save(ctx context.Context, ban *bruteforce.Ban, ttl time.Duration) error {
+	m.bans[ban.Subject.Key()] = ban
+	return nil
+}
+
+func (m *memoryBans) FindLatest(ctx context.Context, subject bruteforce.Subject) (*bruteforce.Ban, error) {
+	return m.bans[subject.Key()], nil
+}
+
+func (m *memoryBans) FindActive(ctx context.Context, at time.Time) ([]*bruteforce.Ban, error) {
+	var active []*bruteforce.Ban
+	for _, b := range m.bans {
+		if b.IsActive(at) {
+			active = append(active, b)
+		}
+	}
+	return active, nil
+}
+
+// fakeASNs puts 203.0.113.0/24 in AS64500 and fails lookups for 192.0.2.0/24
+type fakeASNs struct{}
+
+func (fakeASNs) Lookup(ctx context.Context, ip netip.Addr) (uint32, error) {
+	switch {
+	case netip.MustParsePrefix("203.0.113.0/24").Contains(ip):
+		return 64500, nil
+	case netip.MustParsePrefix("192.0.2.0/24").Contains(ip):
+		return 0, errors.New("database not loaded")
+	}
+	return 0, nil
+}
+
+func newTestService(t *testing.T) (*Service, *memoryCounter, *memoryBans) {
+	t.Helper()
+	allowlist, _ := bruteforce.NewAllowlist([]string{"198.51.100.0/24"})
+	policy, err := bruteforce.NewPolicy(
+		bruteforce.Threshold{Failures: 3, Window: time.Minute, BanFor: 10 * time.Minute},
+		bruteforce.Threshold{Failures: 5, Window: time.Minute, BanFor: 10 * time.Minute},
+		time.Hour, 24*time.Hour, allowlist,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	counter := &memoryCounter{counts: map[string]int{}}
+	bans := &memoryBans{bans: map[string]*bruteforce.Ban{}}
+	return NewService(counter, bans, fakeASNs{}, *policy), counter, bans
+}
+
+func fail(t *testing.T, s *Service, ip string, times int, at time.Time) *bruteforce.Ban {
+	t.Helper()
+	var ban *bruteforce.Ban
+	for i := 0; i < times; i++ {
+		b, err := s.RecordFailure(context.Background(), ip, at)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if b != nil {
+			ban = b
+		}
+	}
+	return ban
+}
+
+func TestService_IPBanEscalates(t *testing.T) {
+	service, counter, _ := newTestService(t)
+	ctx := context.Background()
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	if ban := fail(t, service, "192.0.2.10", 2, at); ban != nil {
+		t.Fatalf("expected no ban below the threshold, got %+v", ban)
+	}
+	ban := fail(t, service, "192.0.2.10", 1, at)
+	if ban == nil || ban.Subject.Key() != "ip:192.0.2.10" || ban.ExpiresAt != at.Add(10*time.Minute) {
+		t.Fatalf("expected a 10 minute IP ban, got %+v", ban)
+	}
+	if counter.counts["ip:192.0.2.10"] != 0 {
+		t.Errorf("expected counter reset after the ban, got %d", counter.counts["ip:192.0.2.10"])
+	}
+	if found, _ := service.Check(ctx, "192.0.2.10", at.Add(time.Minute)); found == nil {
+		t.Error("expected the client banned")
+	}
+	if found, _ := service.Check(ctx, "192.0.2.11", at.Add(time.Minute)); found != nil {
+		t.Errorf("expected the neighbour unaffected, got %+v", found)
+	}
+
+	later := at.Add(30 * time.Minute)
+	ban = fail(t, service, "192.0.2.10", 3, later)
+	if ban == nil || ban.Strike != 2 || ban.ExpiresAt != later.Add(20*time.Minute) {
+		t.Errorf("expected a doubled second ban, got %+v", ban)
+	}
+}
+
+func TestService_ASNBan(t *testing.T) {
+	service, _, _ := newTestService(t)
+	at := time.Now()
+
+	// A botnet rotating addresses inside one provider never trips the IP threshold
+	for _, ip := range []string{"203.0.113.1", "203.0.113.2", "203.0.113.3", "203.0.113.4"} {
+		fail(t, service, ip, 1, at)
+	}
+	ban := fail(t, service, "203.0.113.5", 1, at)
+	if ban == nil || ban.Subject.Key() != "asn:64500" {
+		t.Fatalf("expected the AS banned, got %+v", ban)
+	}
+	if found, _ := service.Check(context.Background(), "203.0.113.99", at); found == nil || found.Subject.Scope != bruteforce.ScopeASN {
+		t.Errorf("expected another address of the AS banned, got %+v", found)
+	}
+}
+
+func TestService_AllowlistAndLift(t *testing.T) {
+	service, _, bans := newTestService(t)
+	ctx := context.Background()
+	at := time.Now()
+
+	if ban := fail(t, service, "198.51.100.7", 10, at); ban != nil || len(bans.bans) != 0 {
+		t.Errorf("expected the office range never banned, got %+v", ban)
+	}
+
+	fail(t, service, "192.0.2.10", 3, at)
+	if _, err := service.Lift(ctx, "admin-1", bruteforce.ScopeIP, "192.0.2.11", at); err != ErrBanNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrBanNotFound, err)
+	}
+	ban, err := service.Lift(ctx, "admin-1", bruteforce.ScopeIP, "192.0.2.10", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *ban.LiftedBy != "admin-1" {
+		t.Errorf("expected lifted by admin-1, got %+v", ban)
+	}
+	if found, _ := service.Check(ctx, "192.0.2.10", at); found != nil {
+		t.Errorf("expected logins allowed after lifting, got %+v", found)
+	}
+
+	if _, err := service.Check(ctx, "not-an-ip", at); err != ErrInvalidIP {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidIP, err)
+	}
+}
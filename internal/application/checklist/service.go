@@ -0,0 +1,223 @@
+package checklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sensitivity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+var (
+	ErrArticleNotFound  = errors.New("article not found")
+	ErrTemplateNotFound = errors.New("desk has no checklist variant")
+	ErrNotLegalReviewer = errors.New("only the legal desk can approve articles")
+)
+
+// Accessibility audits article bodies, the accessibility service implements it
+type Accessibility interface {
+	Audit(ctx context.Context, siteID, title, language, body string) (*accessibility.Checklist, error)
+}
+
+// Sensitivity loads the article's sensitive-content classification, the sensitivity service implements it
+type Sensitivity interface {
+	Classification(ctx context.Context, articleID string) (*sensitivity.Classification, error)
+}
+
+// Permissions checks staff scopes, the entitlement service implements it
+type Permissions interface {
+	CanUseAPI(ctx context.Context, accountID string, scope entitlement.Scope, at time.Time) (entitlement.Decision, error)
+}
+
+// ItemInput is a checklist item as submitted by an editor
+type ItemInput struct {
+	Kind          string
+	Required      bool
+	MinTags       int
+	SensitiveTags []string
+}
+
+type Service struct {
+	templates     checklist.TemplateRepository
+	approvals     checklist.ApprovalRepository
+	articles      checklist.ArticleSource
+	accessibility Accessibility
+	sensitivity   Sensitivity
+	permissions   Permissions
+}
+
+func NewService(templates checklist.TemplateRepository, approvals checklist.ApprovalRepository, articles checklist.ArticleSource, accessibility Accessibility, sensitivity Sensitivity, permissions Permissions) *Service {
+	return &Service{templates: templates, approvals: approvals, articles: articles, accessibility: accessibility, sensitivity: sensitivity, permissions: permissions}
+}
+
+// Evaluate runs the article's desk checklist for the editor screen
+func (s *Service) Evaluate(ctx context.Context, articleID string) (*checklist.Result, error) {
+	a, err := s.article(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := s.Template(ctx, a.Desk)
+	if err != nil {
+		return nil, err
+	}
+
+	var evidence checklist.Evidence
+	for _, item := range template.Items {
+		switch item.Kind {
+		case checklist.ItemAccessibility:
+			audit, err := s.accessibility.Audit(ctx, a.SiteID, a.Title, a.Language, a.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to audit accessibility: %w", err)
+			}
+			for _, c := range audit.Blocking() {
+				evidence.AccessibilityBlocking = append(evidence.AccessibilityBlocking, string(c))
+			}
+		case checklist.ItemLegalReview:
+			if evidence.Sensitive, err = s.sensitive(ctx, a.ID); err != nil {
+				return nil, err
+			}
+			if evidence.Approval, err = s.approvals.FindLatest(ctx, a.ID); err != nil {
+				return nil, fmt.Errorf("failed to load legal approval: %w", err)
+			}
+		}
+	}
+	return template.Evaluate(*a, evidence), nil
+}
+
+// Guard is called by the article service before it publishes. It returns
+// the result with ErrPublishBlocked while a required item fails.
+func (s *Service) Guard(ctx context.Context, articleID string) (*checklist.Result, error) {
+	result, err := s.Evaluate(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if blocking := result.Blocking(); len(blocking) > 0 {
+		names := make([]string, len(blocking))
+		for i, kind := range blocking {
+			names[i] = string(kind)
+		}
+		return result, fmt.Errorf("%w: %s", checklist.ErrPublishBlocked, strings.Join(names, ", "))
+	}
+	return result, nil
+}
+
+// ApproveLegal signs off the article's current revision. A later edit
+// needs a new approval.
+func (s *Service) ApproveLegal(ctx context.Context, staffID, articleID, note string, at time.Time) (*checklist.Approval, error) {
+	decision, err := s.permissions.CanUseAPI(ctx, staffID, checklist.ScopeLegalReview, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check permissions: %w", err)
+	}
+	if !decision.Allowed {
+		return nil, ErrNotLegalReviewer
+	}
+	a, err := s.article(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := s.Template(ctx, a.Desk)
+	if err != nil {
+		return nil, err
+	}
+	sensitive, err := s.sensitive(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !template.NeedsLegalReview(*a, checklist.Evidence{Sensitive: sensitive}) {
+		return nil, checklist.ErrNothingToApprove
+	}
+
+	approval := checklist.Approval{ArticleID: a.ID, Revision: a.Revision, ReviewerID: staffID, Note: strings.TrimSpace(note), ApprovedAt: at}
+	if err := s.approvals.Save(ctx, approval); err != nil {
+		return nil, fmt.Errorf("failed to save legal approval: %w", err)
+	}
+	return &approval, nil
+}
+
+// Template returns the desk's variant, falling back to the default desk and
+// then to the built-in checklist
+func (s *Service) Template(ctx context.Context, desk string) (*checklist.Template, error) {
+	for _, candidate := range []string{desk, checklist.DefaultDesk} {
+		if candidate == "" {
+			continue
+		}
+		template, err := s.templates.FindByDesk(ctx, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load checklist: %w", err)
+		}
+		if template != nil {
+			return template, nil
+		}
+	}
+	return checklist.DefaultTemplate(), nil
+}
+
+func (s *Service) Templates(ctx context.Context) ([]*checklist.Template, error) {
+	templates, err := s.templates.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checklists: %w", err)
+	}
+	return templates, nil
+}
+
+func (s *Service) SaveTemplate(ctx context.Context, staffID, desk string, inputs []ItemInput, at time.Time) (*checklist.Template, error) {
+	items := make([]checklist.Item, 0, len(inputs))
+	for i, in := range inputs {
+		item, err := checklist.NewItem(checklist.ItemKind(in.Kind), in.Required, in.MinTags, in.SensitiveTags)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		items = append(items, item)
+	}
+	template, err := checklist.NewTemplate(desk, items, staffID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templates.Save(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to save checklist: %w", err)
+	}
+	return template, nil
+}
+
+// DeleteTemplate drops a desk variant, the desk falls back to the default
+func (s *Service) DeleteTemplate(ctx context.Context, desk string) error {
+	template, err := s.templates.FindByDesk(ctx, desk)
+	if err != nil {
+		return fmt.Errorf("failed to load checklist: %w", err)
+	}
+	if template == nil {
+		return ErrTemplateNotFound
+	}
+	if err := s.templates.Delete(ctx, desk); err != nil {
+		return fmt.Errorf("failed to delete checklist: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) article(ctx context.Context, articleID string) (*checklist.Article, error) {
+	a, err := s.articles.FindForChecklist(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article: %w", err)
+	}
+	if a == nil {
+		return nil, ErrArticleNotFound
+	}
+	return a, nil
+}
+
+func (s *Service) sensitive(ctx context.Context, articleID string) ([]string, error) {
+	classification, err := s.sensitivity.Classification(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sensitivity classification: %w", err)
+	}
+	categories := make([]string, 0, len(classification.Categories))
+	for _, c := range classification.Categories {
+		categories = append(categories, string(c))
+	}
+	return categories, nil
+}
@@ -0,0 +1,207 @@
+package checklist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sensitivity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+type memoryTemplates struct {
+	templates map[string]*checklist.Template
+}
+
+func (m *memoryTemplates) Save(ctx context.Context, t *checklist.Template) error {
+	m.templates[t.Desk] = t
+	return nil
+}
+
+func (m *memoryTemplates) Delete(ctx context.Context, desk string) error {
+	delete(m.templates, desk)
+	return nil
+}
+
+func (m *memoryTemplates) FindByDesk(ctx context.Context, desk string) (*checklist.Template, error) {
+	return m.templates[desk], nil
+}
+
+func (m *memoryTemplates) FindAll(ctx context.Context) ([]*checklist.Template, error) {
+	var all []*checklist.Template
+	for _, t := range m.templates {
+		all = append(all, t)
+	}
+	return all, nil
+}
+
+type memoryApprovals struct {
+	approvals map[string]checklist.Approval
+}
+
+func (m *memoryApprovals) Save(ctx context.Context, a checklist.Approval) error {
+	m.approvals[a.ArticleID] = a
+	return nil
+}
+
+func (m *memoryApprovals) FindLatest(ctx context.Context, articleID string) (*checklist.Approval, error) {
+	if a, ok := m.approvals[articleID]; ok {
+		return &a, nil
+	}
+	return nil, nil
+}
+
+type fakeArticles struct {
+	articles map[string]*checklist.Article
+}
+
+func (f *fakeArticles) FindForChecklist(ctx context.Context, articleID string) (*checklist.Article, error) {
+	if a, ok := f.articles[articleID]; ok {
+		copied := *a
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// fakeAccessibility fails image_alt when the body is "no-alt"
+type fakeAccessibility struct {
+	calls int
+}
+
+func (f *fakeAccessibility) Audit(ctx context.Context, siteID, title, language, body string) (*accessibility.Checklist, error) {
+	f.calls++
+	content := accessibility.Content{Language: "id"}
+	if body == "no-alt" {
+		content.Images = []accessibility.Image{{Src: "a.jpg"}}
+	}
+	return accessibility.DefaultPolicy(siteID).Audit(content), nil
+}
+
+type fakeSensitivity struct {
+	categories map[string][]sensitivity.Category
+}
+
+func (f *fakeSensitivity) Classification(ctx context.Context, articleID string) (*sensitivity.Classification, error) {
+	return &sensitivity.Classification{ArticleID: articleID, Categories: f.categories[articleID]}, nil
+}
+
+// fakePermissions grants legal review to legal-1 only
+type fakePermissions struct{}
+
+func (fakePermissions) CanUseAPI(ctx context.Context, accountID string, scope entitlement.Scope, at time.Time) (entitlement.Decision, error) {
+	return entitlement.Decision{Allowed: accountID == "legal-1" && scope == checklist.ScopeLegalReview}, nil
+}
+
+func createTestService(t *testing.T) (*Service, *fakeArticles, *fakeAccessibility, *fakeSensitivity) {
+	t.Helper()
+	articles := &fakeArticles{articles: map[string]*checklist.Article{
+		"a1": {
+			ID: "a1", Revision: "r1", SiteID: "site-1", Desk: "politics", Body: "ok",
+			SEOTitle: "Sidang", MetaDescription: "Putusan", CategoryID: "cat-1", Tags: []string{"court-case"},
+			Hero: &checklist.HeroImage{URL: "https://cdn.example.com/a1.jpg", Credit: "Antara"},
+		},
+	}}
+	access := &fakeAccessibility{}
+	templates := &memoryTemplates{templates: map[string]*checklist.Template{}}
+	classified := &fakeSensitivity{categories: map[string][]sensitivity.Category{}}
+	service := NewService(templates, &memoryApprovals{approvals: map[string]checklist.Approval{}}, articles, access, classified, fakePermissions{})
+	return service, articles, access, classified
+}
+
+func TestService_Guard(t *testing.T) {
+	service, articles, _, _ := createTestService(t)
+	ctx := context.Background()
+
+	if _, err := service.Guard(ctx, "a1"); err != nil {
+		t.Fatalf("expected the built-in checklist to pass without sensitive tags, got %v", err)
+	}
+
+	articles.articles["a1"].Body = "no-alt"
+	result, err := service.Guard(ctx, "a1")
+	if !errors.Is(err, checklist.ErrPublishBlocked) || result == nil {
+		t.Fatalf("expected error '%v' with the result, got '%v'", checklist.ErrPublishBlocked, err)
+	}
+	if blocking := result.Blocking(); len(blocking) != 1 || blocking[0] != checklist.ItemAccessibility {
+		t.Errorf("expected accessibility to block, got %v", blocking)
+	}
+
+	if _, err := service.Guard(ctx, "zz"); err != ErrArticleNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrArticleNotFound, err)
+	}
+}
+
+func TestService_DeskVariant(t *testing.T) {
+	service, _, access, _ := createTestService(t)
+	ctx := context.Background()
+
+	if _, err := service.SaveTemplate(ctx, "chief-1", "politics", []ItemInput{
+		{Kind: "category", Required: true},
+		{Kind: "legal_review", Required: true, SensitiveTags: []string{"court-case"}},
+	}, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := service.Guard(ctx, "a1")
+	if !errors.Is(err, checklist.ErrPublishBlocked) || len(result.Items) != 2 || result.Desk != "politics" {
+		t.Fatalf("expected the politics variant to require legal review, got %v %+v", err, result)
+	}
+	if access.calls != 0 {
+		t.Errorf("expected no accessibility audit when the variant leaves it out")
+	}
+
+	if _, err := service.ApproveLegal(ctx, "reporter-1", "a1", "", time.Now()); err != ErrNotLegalReviewer {
+		t.Errorf("expected error '%v', got '%v'", ErrNotLegalReviewer, err)
+	}
+	if _, err := service.ApproveLegal(ctx, "legal-1", "a1", "Sumber terverifikasi", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Guard(ctx, "a1"); err != nil {
+		t.Errorf("expected approval to unblock publishing, got %v", err)
+	}
+
+	if err := service.DeleteTemplate(ctx, "politics"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.DeleteTemplate(ctx, "politics"); err != ErrTemplateNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrTemplateNotFound, err)
+	}
+}
+
+func TestService_ApproveLegalWithoutSensitiveTags(t *testing.T) {
+	service, _, _, _ := createTestService(t)
+	if _, err := service.ApproveLegal(context.Background(), "legal-1", "a1", "", time.Now()); err != checklist.ErrNothingToApprove {
+		t.Errorf("expected error '%v', got '%v'", checklist.ErrNothingToApprove, err)
+	}
+}
+
+func TestService_SensitiveClassification(t *testing.T) {
+	service, _, _, classified := createTestService(t)
+	ctx := context.Background()
+	classified.categories["a1"] = []sensitivity.Category{sensitivity.CategoryMinors}
+
+	result, err := service.Guard(ctx, "a1")
+	if !errors.Is(err, checklist.ErrPublishBlocked) {
+		t.Fatalf("expected error '%v', got '%v'", checklist.ErrPublishBlocked, err)
+	}
+	if blocking := result.Blocking(); len(blocking) != 1 || blocking[0] != checklist.ItemLegalReview {
+		t.Errorf("expected legal review to block, got %v", blocking)
+	}
+
+	if _, err := service.ApproveLegal(ctx, "legal-1", "a1", "Identitas anak disamarkan", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Guard(ctx, "a1"); err != nil {
+		t.Errorf("expected approval to unblock publishing, got %v", err)
+	}
+}
+
+func TestService_SaveTemplate(t *testing.T) {
+	service, _, _, _ := createTestService(t)
+	_, err := service.SaveTemplate(context.Background(), "chief-1", "politics", []ItemInput{{Kind: "tags", Required: true}}, time.Now())
+	if !errors.Is(err, checklist.ErrInvalidMinTags) {
+		t.Errorf("expected error '%v', got '%v'", checklist.ErrInvalidMinTags, err)
+	}
+}
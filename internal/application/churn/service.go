@@ -0,0 +1,141 @@
+package churn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/churn"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// ScoreBatchSize is how many members the job loads and scores at a time
+const ScoreBatchSize = 500
+
+// Limits for the history and CRM export endpoints
+const (
+	DefaultPageSize = 100
+	MaxPageSize     = 1000
+)
+
+// RunResult summarizes one scoring job run
+type RunResult struct {
+	Scored   int
+	High     int
+	WinBacks int
+	Failed   int // Members that could not be scored or notified; picked up again next run
+}
+
+type Service struct {
+	members     churn.MemberSource
+	signals     churn.SignalSource
+	assessments churn.AssessmentRepository
+	winBacks    churn.WinBackRepository
+	notifier    churn.WinBackNotifier
+}
+
+func NewService(members churn.MemberSource, signals churn.SignalSource, assessments churn.AssessmentRepository, winBacks churn.WinBackRepository, notifier churn.WinBackNotifier) *Service {
+	return &Service{members: members, signals: signals, assessments: assessments, winBacks: winBacks, notifier: notifier}
+}
+
+// Run is the scheduled scoring job. It scores every member, stores the
+// assessment and starts a win-back workflow for high-risk members outside
+// the cooldown. One failing member does not stop the others.
+func (s *Service) Run(ctx context.Context, at time.Time) (*RunResult, error) {
+	result := &RunResult{}
+	var errs []error
+	after := ""
+	for {
+		members, err := s.members.ScanMembers(ctx, after, ScoreBatchSize)
+		if err != nil {
+			return result, errors.Join(append(errs, fmt.Errorf("failed to load members: %w", err))...)
+		}
+		if len(members) == 0 {
+			break
+		}
+		after = members[len(members)-1].AccountID
+
+		ids := make([]string, 0, len(members))
+		for _, m := range members {
+			ids = append(ids, m.AccountID)
+		}
+		signals, err := s.signals.Signals(ctx, ids, at.Add(-churn.Window), at)
+		if err != nil {
+			return result, errors.Join(append(errs, fmt.Errorf("failed to load signals: %w", err))...)
+		}
+
+		for _, m := range members {
+			if err := s.score(ctx, m, signals[m.AccountID], at, result); err != nil {
+				result.Failed++
+				errs = append(errs, fmt.Errorf("account %s: %w", m.AccountID, err))
+			}
+		}
+		if len(members) < ScoreBatchSize {
+			break
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+func (s *Service) score(ctx context.Context, m churn.Member, signals churn.Signals, at time.Time, result *RunResult) error {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	assessment, err := churn.NewAssessment(id, m, signals, at)
+	if err != nil {
+		return err
+	}
+	if err := s.assessments.Create(ctx, assessment); err != nil {
+		return fmt.Errorf("failed to save assessment: %w", err)
+	}
+	result.Scored++
+	if !assessment.IsHigh() {
+		return nil
+	}
+	result.High++
+
+	last, err := s.winBacks.FindLatest(ctx, m.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to load win-back: %w", err)
+	}
+	if !churn.DueForWinBack(assessment, last, at) {
+		return nil
+	}
+
+	winBackID, err := shared.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	winBack, err := churn.NewWinBack(winBackID, assessment, at)
+	if err != nil {
+		return err
+	}
+	// Only recorded once the workflow started, so a failed start is retried next run
+	if err := s.notifier.StartWinBack(ctx, assessment); err != nil {
+		return fmt.Errorf("failed to start win-back: %w", err)
+	}
+	if err := s.winBacks.Create(ctx, winBack); err != nil {
+		return fmt.Errorf("failed to save win-back: %w", err)
+	}
+	result.WinBacks++
+	return nil
+}
+
+// History returns a member's assessments, newest first
+func (s *Service) History(ctx context.Context, accountID string, limit int) ([]*churn.Assessment, error) {
+	if limit < 1 || limit > MaxPageSize {
+		limit = DefaultPageSize
+	}
+	return s.assessments.FindHistory(ctx, accountID, limit)
+}
+
+// Export pages the latest score of every member for the CRM sync, pass the
+// last account ID of a page as after to get the next one
+func (s *Service) Export(ctx context.Context, after string, limit int) ([]*churn.Assessment, error) {
+	if limit < 1 || limit > MaxPageSize {
+		limit = DefaultPageSize
+	}
+	return s.assessments.ScanLatest(ctx, after, limit)
+}
@@ -0,0 +1,186 @@
+package churn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/churn"
+)
+
+type fakeMembers struct {
+	members []churn.Member
+}
+
+func (f *fakeMembers) ScanMembers(ctx context.Context, after string, limit int) ([]churn.Member, error) {
+	var page []churn.Member
+	for _, m := range f.members {
+		if m.AccountID > after && len(page) < limit {
+			page = append(page, m)
+		}
+	}
+	return page, nil
+}
+
+type fakeSignals struct {
+	signals map[string]churn.Signals
+	calls   int
+}
+
+func (f *fakeSignals) Signals(ctx context.Context, ids []string, from, to time.Time) (map[string]churn.Signals, error) {
+	f.calls++
+	if to.Sub(from) != churn.Window {
+		return nil, errors.New("unexpected window")
+	}
+	result := make(map[string]churn.Signals)
+	for _, id := range ids {
+		if s, ok := f.signals[id]; ok {
+			result[id] = s
+		}
+	}
+	return result, nil
+}
+
+type memoryAssessments struct {
+	byAccount map[string][]*churn.Assessment
+}
+
+func (m *memoryAssessments) Create(ctx context.Context, a *churn.Assessment) error {
+	m.byAccount[a.AccountID] = append([]*churn.Assessment{a}, m.byAccount[a.AccountID]...)
+	return nil
+}
+
+func (m *memoryAssessments) FindLatest(ctx context.Context, accountID string) (*churn.Assessment, error) {
+	if list := m.byAccount[accountID]; len(list) > 0 {
+		return list[0], nil
+	}
+	return nil, nil
+}
+
+func (m *memoryAssessments) FindHistory(ctx context.Context, accountID string, limit int) ([]*churn.Assessment, error) {
+	list := m.byAccount[accountID]
+	return list[:min(limit, len(list))], nil
+}
+
+func (m *memoryAssessments) ScanLatest(ctx context.Context, after string, limit int) ([]*churn.Assessment, error) {
+	ids := make([]string, 0, len(m.byAccount))
+	for id := range m.byAccount {
+		if id > after {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	var page []*churn.Assessment
+	for _, id := range ids[:min(limit, len(ids))] {
+		page = append(page, m.byAccount[id][0])
+	}
+	return page, nil
+}
+
+type memoryWinBacks struct {
+	latest map[string]*churn.WinBack
+}
+
+func (m *memoryWinBacks) Create(ctx context.Context, w *churn.WinBack) error {
+	m.latest[w.AccountID] = w
+	return nil
+}
+
+func (m *memoryWinBacks) FindLatest(ctx context.Context, accountID string) (*churn.WinBack, error) {
+	return m.latest[accountID], nil
+}
+
+type fakeNotifier struct {
+	started []string
+	failFor string
+}
+
+func (f *fakeNotifier) StartWinBack(ctx context.Context, a *churn.Assessment) error {
+	if a.AccountID == f.failFor {
+		return errors.New("email provider unavailable")
+	}
+	f.started = append(f.started, a.AccountID)
+	return nil
+}
+
+func TestService_Run(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC)
+	recent := at.AddDate(0, 0, -1)
+
+	members := &fakeMembers{}
+	signals := &fakeSignals{signals: map[string]churn.Signals{}}
+	for i := range ScoreBatchSize + 2 {
+		id := fmt.Sprintf("account-%04d", i)
+		members.members = append(members.members, churn.Member{AccountID: id, SubscriptionID: "sub-" + id})
+		// Everyone is engaged except the first three, who have no signals at all
+		if i >= 3 {
+			signals.signals[id] = churn.Signals{Reads: 12, PriorReads: 10, Logins: 8, LastLoginAt: &recent}
+		}
+	}
+	assessments := &memoryAssessments{byAccount: map[string][]*churn.Assessment{}}
+	winBacks := &memoryWinBacks{latest: map[string]*churn.WinBack{
+		"account-0001": {ID: "w0", AccountID: "account-0001", StartedAt: at.AddDate(0, 0, -10)},
+	}}
+	notifier := &fakeNotifier{failFor: "account-0002"}
+	service := NewService(members, signals, assessments, winBacks, notifier)
+
+	result, err := service.Run(ctx, at)
+	if err == nil {
+		t.Fatal("expected the failed win-back to be reported")
+	}
+	if result.Scored != ScoreBatchSize+2 || result.High != 3 || result.WinBacks != 1 || result.Failed != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if signals.calls != 2 {
+		t.Errorf("expected members to be scored in two batches, got %d", signals.calls)
+	}
+	if len(notifier.started) != 1 || notifier.started[0] != "account-0000" {
+		t.Errorf("expected only account-0000 to get a win-back, got %v", notifier.started)
+	}
+	if winBacks.latest["account-0002"] != nil {
+		t.Error("expected a failed start not to be recorded")
+	}
+
+	// The next run retries the failed start and keeps the history
+	notifier.failFor = ""
+	result, err = service.Run(ctx, at.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.WinBacks != 1 || notifier.started[1] != "account-0002" {
+		t.Errorf("expected only the failed start to be retried, got %+v %v", result, notifier.started)
+	}
+	history, _ := service.History(ctx, "account-0000", 0)
+	if len(history) != 2 || !history[0].ScoredAt.After(history[1].ScoredAt) {
+		t.Errorf("expected two assessments newest first, got %d", len(history))
+	}
+}
+
+func TestService_Export(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC)
+	assessments := &memoryAssessments{byAccount: map[string][]*churn.Assessment{}}
+	for _, id := range []string{"a", "b", "c"} {
+		old, _ := churn.NewAssessment(id+"-old", churn.Member{AccountID: id}, churn.Signals{}, at.AddDate(0, 0, -1))
+		latest, _ := churn.NewAssessment(id+"-new", churn.Member{AccountID: id}, churn.Signals{Reads: 10, LastLoginAt: &at}, at)
+		_ = assessments.Create(ctx, old)
+		_ = assessments.Create(ctx, latest)
+	}
+	service := NewService(&fakeMembers{}, &fakeSignals{}, assessments, &memoryWinBacks{}, &fakeNotifier{})
+
+	page, err := service.Export(ctx, "", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != "a-new" || page[1].ID != "b-new" {
+		t.Fatalf("expected the latest assessment per member, got %+v", page)
+	}
+	page, _ = service.Export(ctx, page[1].AccountID, 2)
+	if len(page) != 1 || page[0].AccountID != "c" {
+		t.Errorf("expected the last page to hold c, got %+v", page)
+	}
+}
@@ -0,0 +1,443 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Import limits
+const (
+	MaxArchiveComments = 10000  // One member's archive
+	MaxLegacyPosts     = 500000 // One legacy export, split bigger ones by date
+	exportPageSize     = 500
+)
+
+var ErrImportTooLarge = errors.New("import has too many comments")
+
+// Reasons an imported item was skipped, reported back item by item
+const (
+	SkipRemoved         = "removed before the export"
+	SkipAlreadyImported = "already imported"
+	SkipArticleNotFound = "article not found"
+	SkipParentNotFound  = "parent comment not found"
+	SkipCommentNotFound = "comment not found"
+	SkipAlreadyReacted  = "already reacted"
+	SkipSpam            = "marked as spam"
+	SkipThreadUnmatched = "thread not matched to an article"
+)
+
+type Skip struct {
+	ID     string
+	Reason string
+}
+
+// ImportReport summarizes a member's archive import
+type ImportReport struct {
+	Comments  int // Imported and queued for screening
+	Reactions int
+	Skipped   []Skip
+}
+
+// LegacyReport summarizes a bulk import from a legacy platform
+type LegacyReport struct {
+	Threads          int
+	UnmatchedThreads []string // Links of threads no article matched, their posts are skipped
+	Imported         int
+	AlreadyImported  int
+	Matches          map[comment.MatchKind]int
+	Skipped          []Skip
+}
+
+// PortabilityService moves comments in and out: members export and re-import
+// their own comments and reactions, staff migrate a legacy platform's threads
+type PortabilityService struct {
+	comments  comment.CommentRepository
+	reactions comment.ReactionRepository
+	articles  comment.ArticleLocator
+	accounts  account.UserAccountRepository
+	screener  Screener
+	cache     comment.ThreadSummaryCache
+	clock     shared.Clock
+}
+
+func NewPortabilityService(comments comment.CommentRepository, reactions comment.ReactionRepository, articles comment.ArticleLocator, accounts account.UserAccountRepository, screener Screener, cache comment.ThreadSummaryCache, clock shared.Clock) *PortabilityService {
+	return &PortabilityService{comments: comments, reactions: reactions, articles: articles, accounts: accounts, screener: screener, cache: cache, clock: clock}
+}
+
+// Export returns every comment and reaction of the account
+func (s *PortabilityService) Export(ctx context.Context, accountID string) (*comment.Archive, error) {
+	var comments []*comment.Comment
+	for offset := 0; ; offset += exportPageSize {
+		page, err := s.comments.FindByAuthor(ctx, accountID, exportPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load comments: %w", err)
+		}
+		comments = append(comments, page...)
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+	reactions, err := s.reactions.FindByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reactions: %w", err)
+	}
+	return comment.NewArchive(accountID, comments, reactions, s.clock.Now()), nil
+}
+
+// ImportArchive brings an archive's comments and reactions back under the
+// signed-in account, whoever the archive was exported for. Comments keep their
+// original time but are screened like new ones. Replies need their parent
+// here, in the archive or already on the site. Importing twice adds nothing.
+func (s *PortabilityService) ImportArchive(ctx context.Context, accountID string, archive *comment.Archive) (*ImportReport, error) {
+	if len(archive.Comments) > MaxArchiveComments {
+		return nil, ErrImportTooLarge
+	}
+	report := &ImportReport{}
+	touched := make(map[string]bool)
+	imported := make(map[string]*comment.Comment) // Archive ID -> comment on this site
+
+	archived := append([]comment.ArchivedComment(nil), archive.Comments...)
+	sort.SliceStable(archived, func(i, j int) bool { return archived[i].CreatedAt.Before(archived[j].CreatedAt) })
+
+	for _, ac := range archived {
+		if !ac.Importable() {
+			report.Skipped = append(report.Skipped, Skip{ID: ac.ID, Reason: SkipRemoved})
+			continue
+		}
+		existing, err := s.findImported(ctx, comment.RefArchive+ac.ID, ac.ID, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			imported[ac.ID] = existing
+			report.Skipped = append(report.Skipped, Skip{ID: ac.ID, Reason: SkipAlreadyImported})
+			continue
+		}
+
+		ok, err := s.articles.Exists(ctx, ac.ArticleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up article: %w", err)
+		}
+		if !ok {
+			report.Skipped = append(report.Skipped, Skip{ID: ac.ID, Reason: SkipArticleNotFound})
+			continue
+		}
+		var parent *comment.Comment
+		if ac.ParentID != nil {
+			parent, err = s.archiveParent(ctx, imported, *ac.ParentID, ac.ArticleID)
+			if err != nil {
+				return nil, err
+			}
+			if parent == nil {
+				report.Skipped = append(report.Skipped, Skip{ID: ac.ID, Reason: SkipParentNotFound})
+				continue
+			}
+		}
+
+		id, seq, err := s.reserve(ctx, ac.ArticleID, parent)
+		if err != nil {
+			return nil, err
+		}
+		c, err := comment.NewImportedComment(id, ac.ArticleID, comment.AccountAuthor(accountID), ac.Body, parent, seq, comment.RefArchive+ac.ID, ac.CreatedAt)
+		if err != nil {
+			report.Skipped = append(report.Skipped, Skip{ID: ac.ID, Reason: err.Error()})
+			continue
+		}
+		if err := s.save(ctx, c); err != nil {
+			return nil, err
+		}
+		// Restored history is screened like new comments but not pre-moderated again
+		if _, err := s.screener.Screen(ctx, c, false, s.clock.Now()); err != nil {
+			return nil, fmt.Errorf("failed to screen comment: %w", err)
+		}
+		imported[ac.ID] = c
+		touched[c.ArticleID] = true
+		report.Comments++
+	}
+
+	for _, ar := range archive.Reactions {
+		skipped, err := s.importReaction(ctx, accountID, ar, imported)
+		if err != nil {
+			return nil, err
+		}
+		if skipped != "" {
+			report.Skipped = append(report.Skipped, Skip{ID: ar.CommentID, Reason: skipped})
+			continue
+		}
+		report.Reactions++
+	}
+
+	return report, s.invalidate(ctx, touched)
+}
+
+// ImportLegacy migrates a legacy platform's export. Threads are matched to
+// articles by link, authors by MatchAuthor. Posts that were public there are
+// approved here without screening, spam and deleted posts are left behind and
+// their replies move up to the nearest imported ancestor. Importing the same
+// export again adds only what is new.
+func (s *PortabilityService) ImportLegacy(ctx context.Context, export *comment.LegacyExport) (*LegacyReport, error) {
+	if len(export.Posts) > MaxLegacyPosts {
+		return nil, ErrImportTooLarge
+	}
+	report := &LegacyReport{Threads: len(export.Threads), Matches: make(map[comment.MatchKind]int)}
+	touched := make(map[string]bool)
+
+	articleOf := make(map[string]string, len(export.Threads))
+	for _, t := range export.Threads {
+		articleID, err := s.articles.FindByURL(ctx, t.Link)
+		if err != nil {
+			return nil, fmt.Errorf("failed to match thread %s: %w", t.ID, err)
+		}
+		if articleID == "" {
+			report.UnmatchedThreads = append(report.UnmatchedThreads, t.Link)
+			continue
+		}
+		articleOf[t.ID] = articleID
+	}
+
+	posts := append([]comment.LegacyPost(nil), export.Posts...)
+	sort.SliceStable(posts, func(i, j int) bool { return posts[i].CreatedAt.Before(posts[j].CreatedAt) })
+	parentOf := make(map[string]string, len(posts))
+	for _, p := range posts {
+		parentOf[p.ID] = p.ParentID
+	}
+
+	imported := make(map[string]*comment.Comment) // Legacy post ID -> comment on this site
+	authors := newAuthorMatcher(s.accounts)
+	for _, p := range posts {
+		articleID, ok := articleOf[p.ThreadID]
+		switch {
+		case p.Spam:
+			report.Skipped = append(report.Skipped, Skip{ID: p.ID, Reason: SkipSpam})
+			continue
+		case p.Deleted:
+			report.Skipped = append(report.Skipped, Skip{ID: p.ID, Reason: SkipRemoved})
+			continue
+		case !ok:
+			report.Skipped = append(report.Skipped, Skip{ID: p.ID, Reason: SkipThreadUnmatched})
+			continue
+		}
+
+		ref := comment.RefDisqus + p.ID
+		existing, err := s.comments.FindByImportRef(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up imported comment: %w", err)
+		}
+		if existing != nil {
+			imported[p.ID] = existing
+			report.AlreadyImported++
+			continue
+		}
+
+		parent, err := s.legacyParent(ctx, imported, parentOf, p.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		author, kind, err := authors.match(ctx, p.Author)
+		if err != nil {
+			return nil, err
+		}
+
+		id, seq, err := s.reserve(ctx, articleID, parent)
+		if err != nil {
+			return nil, err
+		}
+		c, err := comment.NewImportedComment(id, articleID, author, p.Body, parent, seq, ref, p.CreatedAt)
+		if err != nil {
+			report.Skipped = append(report.Skipped, Skip{ID: p.ID, Reason: err.Error()})
+			continue
+		}
+		if err := c.Approve(comment.ImportModeratorID, s.clock.Now()); err != nil {
+			return nil, err
+		}
+		if err := s.save(ctx, c); err != nil {
+			return nil, err
+		}
+		imported[p.ID] = c
+		touched[articleID] = true
+		report.Imported++
+		report.Matches[kind]++
+	}
+
+	return report, s.invalidate(ctx, touched)
+}
+
+// reserve picks the new comment's ID and place in the thread, a comment the
+// domain then rejects only leaves a gap
+func (s *PortabilityService) reserve(ctx context.Context, articleID string, parent *comment.Comment) (string, uint32, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return "", 0, err
+	}
+	var parentID *string
+	if parent != nil {
+		parentID = &parent.ID
+	}
+	seq, err := s.comments.NextSequence(ctx, articleID, parentID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to reserve comment sequence: %w", err)
+	}
+	return id, seq, nil
+}
+
+func (s *PortabilityService) save(ctx context.Context, c *comment.Comment) error {
+	if err := s.comments.Create(ctx, c); err != nil {
+		return fmt.Errorf("failed to save comment: %w", err)
+	}
+	if !c.IsTopLevel() {
+		if err := s.comments.IncrementCounts(ctx, c.ArticleID, c.Path); err != nil {
+			return fmt.Errorf("failed to update reply counts: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *PortabilityService) importReaction(ctx context.Context, accountID string, ar comment.ArchivedReaction, imported map[string]*comment.Comment) (string, error) {
+	target := imported[ar.CommentID]
+	if target == nil {
+		c, err := s.comments.FindByID(ctx, ar.CommentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to load comment: %w", err)
+		}
+		target = c
+	}
+	if target == nil || target.IsRemoved() {
+		return SkipCommentNotFound, nil
+	}
+	exists, err := s.reactions.Exists(ctx, target.ID, accountID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load reactions: %w", err)
+	}
+	if exists {
+		return SkipAlreadyReacted, nil
+	}
+
+	r, err := comment.NewReaction(target, accountID, ar.Kind, ar.CreatedAt)
+	if err != nil {
+		return err.Error(), nil
+	}
+	if err := s.reactions.Create(ctx, r); err != nil {
+		return "", fmt.Errorf("failed to save reaction: %w", err)
+	}
+	if err := target.Vote(r.Deltas()); err != nil {
+		return "", err
+	}
+	if err := s.comments.Update(ctx, target); err != nil {
+		return "", fmt.Errorf("failed to save comment: %w", err)
+	}
+	return "", nil
+}
+
+// findImported returns the comment an archive entry already became: imported
+// before, or never gone because the archive came from this site
+func (s *PortabilityService) findImported(ctx context.Context, ref, originalID, accountID string) (*comment.Comment, error) {
+	c, err := s.comments.FindByImportRef(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up imported comment: %w", err)
+	}
+	if c != nil {
+		return c, nil
+	}
+	c, err = s.comments.FindByID(ctx, originalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comment: %w", err)
+	}
+	if c != nil && c.IsAuthoredBy(accountID) {
+		return c, nil
+	}
+	return nil, nil
+}
+
+// archiveParent finds the parent among the imported comments or on the site
+func (s *PortabilityService) archiveParent(ctx context.Context, imported map[string]*comment.Comment, parentID, articleID string) (*comment.Comment, error) {
+	parent := imported[parentID]
+	if parent == nil {
+		p, err := s.comments.FindByID(ctx, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load parent comment: %w", err)
+		}
+		parent = p
+	}
+	if parent == nil || parent.ArticleID != articleID || parent.IsRemoved() {
+		return nil, nil
+	}
+	return s.withinDepth(ctx, parent)
+}
+
+// legacyParent walks up past posts that were not imported, nil makes the post top-level
+func (s *PortabilityService) legacyParent(ctx context.Context, imported map[string]*comment.Comment, parentOf map[string]string, parentID string) (*comment.Comment, error) {
+	for seen := 0; parentID != "" && seen < len(parentOf); seen++ {
+		if parent := imported[parentID]; parent != nil {
+			return s.withinDepth(ctx, parent)
+		}
+		parentID = parentOf[parentID]
+	}
+	return nil, nil
+}
+
+// withinDepth moves a reply up to the deepest ancestor that can still take
+// one, legacy platforms nest deeper than MaxDepth
+func (s *PortabilityService) withinDepth(ctx context.Context, parent *comment.Comment) (*comment.Comment, error) {
+	for parent.Path.Depth()+1 > comment.MaxDepth && parent.ParentID != nil {
+		p, err := s.comments.FindByID(ctx, *parent.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load parent comment: %w", err)
+		}
+		if p == nil {
+			return nil, nil
+		}
+		parent = p
+	}
+	return parent, nil
+}
+
+func (s *PortabilityService) invalidate(ctx context.Context, articles map[string]bool) error {
+	for articleID := range articles {
+		if err := s.cache.Invalidate(ctx, articleID); err != nil {
+			return fmt.Errorf("failed to invalidate thread summary: %w", err)
+		}
+	}
+	return nil
+}
+
+// authorMatcher caches account lookups, legacy authors post many times
+type authorMatcher struct {
+	accounts   account.UserAccountRepository
+	byEmail    map[string]*account.UserAccount
+	byUsername map[string]*account.UserAccount
+}
+
+func newAuthorMatcher(accounts account.UserAccountRepository) *authorMatcher {
+	return &authorMatcher{accounts: accounts, byEmail: map[string]*account.UserAccount{}, byUsername: map[string]*account.UserAccount{}}
+}
+
+func (m *authorMatcher) match(ctx context.Context, a comment.LegacyAuthor) (comment.Author, comment.MatchKind, error) {
+	email := strings.ToLower(strings.TrimSpace(a.Email))
+	username := strings.ToLower(strings.TrimSpace(a.Username))
+
+	byEmail, ok := m.byEmail[email]
+	if !ok && email != "" {
+		acc, err := m.accounts.FindByEmail(ctx, email)
+		if err != nil {
+			return comment.Author{}, "", fmt.Errorf("failed to match author: %w", err)
+		}
+		m.byEmail[email], byEmail = acc, acc
+	}
+	byUsername, ok := m.byUsername[username]
+	if !ok && username != "" {
+		acc, err := m.accounts.FindByUsername(ctx, username)
+		if err != nil {
+			return comment.Author{}, "", fmt.Errorf("failed to match author: %w", err)
+		}
+		m.byUsername[username], byUsername = acc, acc
+	}
+	author, kind := comment.MatchAuthor(a, byEmail, byUsername)
+	return author, kind, nil
+}
@@ -0,0 +1,304 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memoryReactions struct {
+	reactions []*comment.Reaction
+}
+
+func (m *memoryReactions) Create(ctx context.Context, r *comment.Reaction) error {
+	m.reactions = append(m.reactions, r)
+	return nil
+}
+
+func (m *memoryReactions) Exists(ctx context.Context, commentID, accountID string) (bool, error) {
+	for _, r := range m.reactions {
+		if r.CommentID == commentID && r.AccountID == accountID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryReactions) FindByAccount(ctx context.Context, accountID string) ([]*comment.Reaction, error) {
+	var found []*comment.Reaction
+	for _, r := range m.reactions {
+		if r.AccountID == accountID {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+type fakeArticles struct {
+	links map[string]string // Legacy link -> article ID
+}
+
+func (f *fakeArticles) Exists(ctx context.Context, articleID string) (bool, error) {
+	for _, id := range f.links {
+		if id == articleID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeArticles) FindByURL(ctx context.Context, link string) (string, error) {
+	return f.links[link], nil
+}
+
+type fakeAccounts struct {
+	account.UserAccountRepository
+	accounts []*account.UserAccount
+	lookups  int
+}
+
+func (f *fakeAccounts) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	for _, acc := range f.accounts {
+		if acc.ID == id {
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeAccounts) FindByEmail(ctx context.Context, email string) (*account.UserAccount, error) {
+	f.lookups++
+	for _, acc := range f.accounts {
+		if acc.Email.String() == email {
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeAccounts) FindByUsername(ctx context.Context, username string) (*account.UserAccount, error) {
+	f.lookups++
+	for _, acc := range f.accounts {
+		if acc.Username.String() == username {
+			return acc, nil
+		}
+	}
+	return nil, nil
+}
+
+type portabilityEnv struct {
+	service   *PortabilityService
+	repo      *memoryRepo
+	reactions *memoryReactions
+	accounts  *fakeAccounts
+	screener  *fakeScreener
+	cache     *fakeCache
+}
+
+func newPortabilityEnv(t *testing.T) *portabilityEnv {
+	t.Helper()
+	sari, err := account.NewUserAccountForTesting("u1", "sari", "sari@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	budi, _ := account.NewUserAccountForTesting("u2", "budi", "budi@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	for _, acc := range []*account.UserAccount{sari, budi} {
+		acc.Status, acc.IsVerified = account.StatusActive, true
+	}
+
+	repo := newMemoryRepo()
+	env := &portabilityEnv{
+		repo:      repo,
+		reactions: &memoryReactions{},
+		accounts:  &fakeAccounts{accounts: []*account.UserAccount{sari, budi}},
+		screener:  &fakeScreener{repo: repo},
+		cache:     &fakeCache{summaries: map[string]comment.ThreadSummary{}},
+	}
+	articles := &fakeArticles{links: map[string]string{
+		"https://news.example.com/2019/05/budget": "a1",
+		"https://news.example.com/2019/06/floods": "a2",
+	}}
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC))
+	env.service = NewPortabilityService(repo, env.reactions, articles, env.accounts, env.screener, env.cache, clock)
+	return env
+}
+
+func TestPortabilityService_ExportAndImport(t *testing.T) {
+	env := newPortabilityEnv(t)
+	ctx := context.Background()
+	posts := NewService(env.repo, newMemoryArticles("a1", "a2"), env.accounts, env.cache, &memorySource{repo: env.repo}, &fakeBadges{}, env.screener)
+
+	root, _ := posts.Post(ctx, "a1", comment.AccountAuthor("u1"), nil, "Sharp analysis")
+	reply, _ := posts.Post(ctx, "a1", comment.AccountAuthor("u1"), &root.ID, "Adding a source")
+	removed, _ := posts.Post(ctx, "a1", comment.AccountAuthor("u1"), nil, "Typo")
+	_ = removed.Delete("u1")
+	other, _ := posts.Post(ctx, "a2", comment.AccountAuthor("u2"), nil, "Thanks for covering this")
+	_ = env.reactions.Create(ctx, &comment.Reaction{CommentID: other.ID, ArticleID: "a2", AccountID: "u1", Kind: comment.ReactionUp})
+
+	archive, err := env.service.Export(ctx, "u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(archive.Comments) != 3 || len(archive.Reactions) != 1 {
+		t.Fatalf("expected three comments and one reaction, got %+v", archive)
+	}
+
+	// Re-importing on the same site finds everything already here
+	report, err := env.service.ImportArchive(ctx, "u1", archive)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Comments != 0 || report.Reactions != 0 || len(report.Skipped) != 4 {
+		t.Errorf("expected nothing new, got %+v", report)
+	}
+
+	// Budi imports Sari's archive structure into his own account, e.g. after a merge
+	report, err = env.service.ImportArchive(ctx, "u2", archive)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Comments != 2 || report.Reactions != 0 {
+		t.Errorf("expected both comments imported and the own-comment reaction skipped, got %+v", report)
+	}
+	imported, _ := env.repo.FindByImportRef(ctx, comment.RefArchive+reply.ID)
+	if imported == nil || imported.Author.AccountID != "u2" || !imported.CreatedAt.Equal(reply.CreatedAt) {
+		t.Fatalf("expected the reply imported with its original time, got %+v", imported)
+	}
+	parent, _ := env.repo.FindByImportRef(ctx, comment.RefArchive+root.ID)
+	if *imported.ParentID != parent.ID || parent.ReplyCount != 1 {
+		t.Errorf("expected the reply under the imported root, got parent %s", *imported.ParentID)
+	}
+	if !contains(env.screener.screened, imported.ID) {
+		t.Error("expected imported comments to be screened")
+	}
+
+	// Running it again adds nothing
+	again, _ := env.service.ImportArchive(ctx, "u2", archive)
+	if again.Comments != 0 {
+		t.Errorf("expected the second import to add nothing, got %+v", again)
+	}
+}
+
+func TestPortabilityService_ImportArchiveSkips(t *testing.T) {
+	env := newPortabilityEnv(t)
+	ctx := context.Background()
+	at := time.Date(2025, time.January, 5, 10, 0, 0, 0, time.UTC)
+	missing := "gone"
+	target, _ := comment.NewComment("c9", "a2", comment.AccountAuthor("u2"), "Original reporting", 1)
+	_ = env.repo.Create(ctx, target)
+
+	archive := &comment.Archive{
+		Comments: []comment.ArchivedComment{
+			{ID: "x1", ArticleID: "a9", Body: "Elsewhere", Status: comment.StatusApproved, CreatedAt: at},
+			{ID: "x2", ArticleID: "a1", ParentID: &missing, Body: "Orphan", Status: comment.StatusApproved, CreatedAt: at},
+			{ID: "x3", ArticleID: "a1", Body: "Rejected", Status: comment.StatusRejected, CreatedAt: at},
+			{ID: "x4", ArticleID: "a1", Body: " ", Status: comment.StatusApproved, CreatedAt: at},
+		},
+		Reactions: []comment.ArchivedReaction{
+			{CommentID: "c9", Kind: comment.ReactionDown, CreatedAt: at},
+			{CommentID: "c9", Kind: comment.ReactionUp, CreatedAt: at},
+			{CommentID: "nope", Kind: comment.ReactionUp, CreatedAt: at},
+		},
+	}
+
+	report, err := env.service.ImportArchive(ctx, "u1", archive)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reasons := map[string]string{}
+	for _, s := range report.Skipped {
+		reasons[s.ID] = s.Reason
+	}
+	expected := map[string]string{
+		"x1":   SkipArticleNotFound,
+		"x2":   SkipParentNotFound,
+		"x3":   SkipRemoved,
+		"x4":   comment.ErrBodyEmpty.Error(),
+		"c9":   SkipAlreadyReacted,
+		"nope": SkipCommentNotFound,
+	}
+	for id, reason := range expected {
+		if reasons[id] != reason {
+			t.Errorf("%s: expected %q, got %q", id, reason, reasons[id])
+		}
+	}
+	if report.Reactions != 1 || target.Downvotes != 1 || target.Upvotes != 0 {
+		t.Errorf("expected the first reaction counted once, got %+v and %d/%d", report, target.Upvotes, target.Downvotes)
+	}
+
+	big := &comment.Archive{Comments: make([]comment.ArchivedComment, MaxArchiveComments+1)}
+	if _, err := env.service.ImportArchive(ctx, "u1", big); !errors.Is(err, ErrImportTooLarge) {
+		t.Errorf("expected ErrImportTooLarge, got %v", err)
+	}
+}
+
+func TestPortabilityService_ImportLegacy(t *testing.T) {
+	env := newPortabilityEnv(t)
+	ctx := context.Background()
+	at := time.Date(2019, time.May, 2, 8, 0, 0, 0, time.UTC)
+
+	export := &comment.LegacyExport{
+		Threads: []comment.LegacyThread{
+			{ID: "t1", Link: "https://news.example.com/2019/05/budget"},
+			{ID: "t2", Link: "https://news.example.com/2018/01/removed-story"},
+		},
+		Posts: []comment.LegacyPost{
+			// Out of order on purpose, the export is not sorted
+			{ID: "p3", ThreadID: "t1", ParentID: "p2", Body: "Reply to a deleted post", CreatedAt: at.Add(2 * time.Hour), Author: comment.LegacyAuthor{Name: "Reader", Username: "budi"}},
+			{ID: "p1", ThreadID: "t1", Body: "First", CreatedAt: at, Author: comment.LegacyAuthor{Name: "Sari W", Email: "SARI@example.com", Username: "sariw"}},
+			{ID: "p2", ThreadID: "t1", ParentID: "p1", Body: "", CreatedAt: at.Add(time.Hour), Deleted: true},
+			{ID: "p4", ThreadID: "t1", Body: "Buy now", CreatedAt: at, Spam: true},
+			{ID: "p5", ThreadID: "t2", Body: "Lost thread", CreatedAt: at},
+			{ID: "p6", ThreadID: "t1", Body: "Anonymous take", CreatedAt: at.Add(3 * time.Hour), Author: comment.LegacyAuthor{Name: "Guest", Anonymous: true}},
+			{ID: "p7", ThreadID: "t1", Body: "Another from Sari", CreatedAt: at.Add(4 * time.Hour), Author: comment.LegacyAuthor{Name: "Sari W", Email: "sari@example.com", Username: "sariw"}},
+		},
+	}
+
+	report, err := env.service.ImportLegacy(ctx, export)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Imported != 4 || len(report.Skipped) != 3 || len(report.UnmatchedThreads) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if report.Matches[comment.MatchEmail] != 2 || report.Matches[comment.MatchUsername] != 1 || report.Matches[comment.MatchGuest] != 1 {
+		t.Errorf("unexpected matches %+v", report.Matches)
+	}
+	if env.accounts.lookups != 3 {
+		t.Errorf("expected repeated authors to be looked up once, got %d lookups", env.accounts.lookups)
+	}
+
+	first, _ := env.repo.FindByImportRef(ctx, comment.RefDisqus+"p1")
+	moved, _ := env.repo.FindByImportRef(ctx, comment.RefDisqus+"p3")
+	if first == nil || moved == nil || *moved.ParentID != first.ID {
+		t.Fatalf("expected the reply to a deleted post moved under its grandparent, got %+v", moved)
+	}
+	if first.Status != comment.StatusApproved || *first.ModeratedBy != comment.ImportModeratorID || !first.CreatedAt.Equal(at) {
+		t.Errorf("expected an approved comment with its original time, got %+v", first)
+	}
+	if len(env.screener.screened) != 0 {
+		t.Error("expected legacy comments not to be screened")
+	}
+
+	again, err := env.service.ImportLegacy(ctx, export)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.Imported != 0 || again.AlreadyImported != 4 {
+		t.Errorf("expected a second run to import nothing, got %+v", again)
+	}
+}
+
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
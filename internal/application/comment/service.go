@@ -0,0 +1,256 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/moderation"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/badge"
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrParentMismatch  = errors.New("parent comment belongs to another article")
+	ErrRepliesClosed   = errors.New("comment cannot receive replies")
+)
+
+// DefaultReplyPreview is how many replies are shown under each top-level comment before "expand"
+const DefaultReplyPreview = 3
+
+// Screener queues new comments for moderation and may hold them, the
+// moderation service implements it. A pre-moderated comment is always held.
+type Screener interface {
+	Screen(ctx context.Context, c *comment.Comment, preModeration bool, at time.Time) (*moderation.Review, error)
+}
+
+// Articles reads the article a comment is posted on, article.ArticleRepository implements it
+type Articles interface {
+	FindByID(ctx context.Context, id string) (*article.Article, error)
+}
+
+type Service struct {
+	comments comment.CommentRepository
+	articles Articles
+	accounts account.UserAccountRepository
+	cache    comment.ThreadSummaryCache
+	source   comment.ThreadSummarySource
+	badges   badge.Lookup
+	screener Screener
+}
+
+func NewService(comments comment.CommentRepository, articles Articles, accounts account.UserAccountRepository, cache comment.ThreadSummaryCache, source comment.ThreadSummarySource, badges badge.Lookup, screener Screener) *Service {
+	return &Service{comments: comments, articles: articles, accounts: accounts, cache: cache, source: source, badges: badges, screener: screener}
+}
+
+// Post adds a top-level comment, or a reply when parentID is set. The article's
+// comment settings decide whether the author may comment at all. The comment
+// starts pending, screening approves it or holds it for a moderator, and
+// pre-moderated articles always hold it.
+func (s *Service) Post(ctx context.Context, articleID string, author comment.Author, parentID *string, body string) (*comment.Comment, error) {
+	now := time.Now()
+	a, err := s.articles.FindByID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article: %w", err)
+	}
+	if a == nil {
+		return nil, ErrArticleNotFound
+	}
+	commenter, err := s.commenter(ctx, author)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.Comments.CheckCommenter(a.PublishedAt, commenter, now); err != nil {
+		return nil, err
+	}
+
+	var parent *comment.Comment
+	if parentID != nil {
+		p, err := s.comments.FindByID(ctx, *parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load parent comment: %w", err)
+		}
+		if p == nil {
+			return nil, ErrCommentNotFound
+		}
+		if p.ArticleID != articleID {
+			return nil, ErrParentMismatch
+		}
+		if !p.CanReceiveReplies() {
+			return nil, ErrRepliesClosed
+		}
+		parent = p
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	seq, err := s.comments.NextSequence(ctx, articleID, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve comment sequence: %w", err)
+	}
+
+	var c *comment.Comment
+	if parent == nil {
+		c, err = comment.NewComment(id, articleID, author, body, seq)
+	} else {
+		c, err = comment.NewReply(id, parent, author, body, seq)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.comments.Create(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to save comment: %w", err)
+	}
+	// Screening may hold the comment, the returned status tells the author
+	if _, err := s.screener.Screen(ctx, c, a.Comments.RequiresPreModeration(), now); err != nil {
+		return nil, fmt.Errorf("failed to screen comment: %w", err)
+	}
+	if parent != nil {
+		if err := s.comments.IncrementCounts(ctx, articleID, c.Path); err != nil {
+			return nil, fmt.Errorf("failed to update reply counts: %w", err)
+		}
+	}
+	if err := s.cache.Invalidate(ctx, articleID); err != nil {
+		return nil, fmt.Errorf("failed to invalidate thread summary: %w", err)
+	}
+	return c, nil
+}
+
+// commenter loads the member posting, nil for guests. An account that no
+// longer exists cannot comment.
+func (s *Service) commenter(ctx context.Context, author comment.Author) (*account.UserAccount, error) {
+	if author.IsGuest() {
+		return nil, nil
+	}
+	acc, err := s.accounts.FindByID(ctx, author.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load commenter: %w", err)
+	}
+	if acc == nil {
+		return nil, article.ErrCommenterNotActive
+	}
+	return acc, nil
+}
+
+// TopLevelPage returns a page of top-level comments, each with a preview of its oldest replies
+func (s *Service) TopLevelPage(ctx context.Context, articleID string, sort comment.SortMode, limit, offset int) ([]comment.Node, error) {
+	return s.page(ctx, comment.PageQuery{ArticleID: articleID, Sort: sort, Limit: limit, Offset: offset}, DefaultReplyPreview)
+}
+
+// Replies expands one comment, returning a page of its direct replies with their own previews
+func (s *Service) Replies(ctx context.Context, parentID string, sort comment.SortMode, limit, offset int) ([]comment.Node, error) {
+	parent, err := s.comments.FindByID(ctx, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comment: %w", err)
+	}
+	if parent == nil {
+		return nil, ErrCommentNotFound
+	}
+	return s.page(ctx, comment.PageQuery{ArticleID: parent.ArticleID, ParentID: &parent.ID, Sort: sort, Limit: limit, Offset: offset}, DefaultReplyPreview)
+}
+
+// Summary returns the thread header, from cache when warm
+func (s *Service) Summary(ctx context.Context, articleID string) (*comment.ThreadSummary, error) {
+	cached, err := s.cache.Get(ctx, articleID)
+	if err == nil && cached != nil {
+		return cached, nil
+	}
+
+	summary, err := s.source.Summary(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute thread summary: %w", err)
+	}
+	// A failed cache write only costs the next reader a recompute
+	_ = s.cache.Set(ctx, *summary)
+	return summary, nil
+}
+
+func (s *Service) page(ctx context.Context, query comment.PageQuery, preview int) ([]comment.Node, error) {
+	query.SetDefaults()
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.comments.FindPage(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comments: %w", err)
+	}
+
+	// One batched query for all previews instead of one per comment
+	var withReplies []string
+	for _, c := range comments {
+		if c.ReplyCount > 0 {
+			withReplies = append(withReplies, c.ID)
+		}
+	}
+	previews := map[string][]*comment.Comment{}
+	if len(withReplies) > 0 {
+		if previews, err = s.comments.FindReplyPreviews(ctx, withReplies, preview); err != nil {
+			return nil, fmt.Errorf("failed to load reply previews: %w", err)
+		}
+	}
+
+	badges := s.authorBadges(ctx, comments, previews)
+
+	nodes := make([]comment.Node, 0, len(comments))
+	for _, c := range comments {
+		replies := previews[c.ID]
+		node := comment.Node{
+			Comment:     c,
+			Replies:     replies,
+			MoreReplies: max(c.ReplyCount-len(replies), 0),
+		}
+		for _, author := range append([]*comment.Comment{c}, replies...) {
+			if author.Author.IsGuest() {
+				continue
+			}
+			if b, ok := badges[author.Author.AccountID]; ok {
+				if node.Badges == nil {
+					node.Badges = map[string]badge.Kind{}
+				}
+				node.Badges[author.Author.AccountID] = b.Kind
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// authorBadges looks up the badges of every signed-in author on the page in one query
+func (s *Service) authorBadges(ctx context.Context, comments []*comment.Comment, previews map[string][]*comment.Comment) map[string]*badge.Badge {
+	seen := map[string]struct{}{}
+	var authors []string
+	add := func(c *comment.Comment) {
+		if c.Author.IsGuest() {
+			return
+		}
+		if _, ok := seen[c.Author.AccountID]; !ok {
+			seen[c.Author.AccountID] = struct{}{}
+			authors = append(authors, c.Author.AccountID)
+		}
+	}
+	for _, c := range comments {
+		add(c)
+		for _, reply := range previews[c.ID] {
+			add(reply)
+		}
+	}
+	if len(authors) == 0 {
+		return nil
+	}
+
+	badges, err := s.badges.FindActiveByAccountIDs(ctx, authors)
+	if err != nil {
+		// Badges are decoration, the thread still renders without them
+		return nil
+	}
+	return badges
+}
@@ -0,0 +1,467 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/moderation"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/badge"
+)
+
+// memoryRepo keeps comments indexed by parent the way the SQL indexes would
+type memoryRepo struct {
+	byID     map[string]*comment.Comment
+	children map[string][]*comment.Comment // "" holds top-level comments
+	seq      map[string]uint32
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{
+		byID:     map[string]*comment.Comment{},
+		children: map[string][]*comment.Comment{},
+		seq:      map[string]uint32{},
+	}
+}
+
+func parentKey(parentID *string) string {
+	if parentID == nil {
+		return ""
+	}
+	return *parentID
+}
+
+func (m *memoryRepo) Create(ctx context.Context, c *comment.Comment) error {
+	m.byID[c.ID] = c
+	key := parentKey(c.ParentID)
+	m.children[key] = append(m.children[key], c)
+	return nil
+}
+
+func (m *memoryRepo) Update(ctx context.Context, c *comment.Comment) error {
+	m.byID[c.ID] = c
+	return nil
+}
+
+func (m *memoryRepo) NextSequence(ctx context.Context, articleID string, parentID *string) (uint32, error) {
+	key := articleID + "/" + parentKey(parentID)
+	m.seq[key]++
+	return m.seq[key], nil
+}
+
+func (m *memoryRepo) IncrementCounts(ctx context.Context, articleID string, replyPath comment.Path) error {
+	for _, c := range m.byID {
+		if c.ArticleID != articleID || !c.Path.IsAncestorOf(replyPath) {
+			continue
+		}
+		c.DescendantCount++
+		if replyPath.Parent().Equals(c.Path) {
+			c.ReplyCount++
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepo) FindByID(ctx context.Context, id string) (*comment.Comment, error) {
+	return m.byID[id], nil
+}
+
+func (m *memoryRepo) FindPage(ctx context.Context, q comment.PageQuery) ([]*comment.Comment, error) {
+	siblings := append([]*comment.Comment(nil), m.children[parentKey(q.ParentID)]...)
+	comment.SortComments(siblings, q.Sort)
+	if q.Offset >= len(siblings) {
+		return nil, nil
+	}
+	end := min(q.Offset+q.Limit, len(siblings))
+	return siblings[q.Offset:end], nil
+}
+
+func (m *memoryRepo) FindReplyPreviews(ctx context.Context, parentIDs []string, perParent int) (map[string][]*comment.Comment, error) {
+	result := make(map[string][]*comment.Comment, len(parentIDs))
+	for _, id := range parentIDs {
+		replies := m.children[id]
+		result[id] = replies[:min(perParent, len(replies))]
+	}
+	return result, nil
+}
+
+func (m *memoryRepo) FindSubtree(ctx context.Context, articleID string, root comment.Path, limit int) ([]*comment.Comment, error) {
+	return nil, nil
+}
+
+func (m *memoryRepo) CountTopLevel(ctx context.Context, articleID string) (int, error) {
+	return len(m.children[""]), nil
+}
+
+func (m *memoryRepo) FindByAuthor(ctx context.Context, accountID string, limit, offset int) ([]*comment.Comment, error) {
+	var found []*comment.Comment
+	for _, c := range m.byID {
+		if c.IsAuthoredBy(accountID) {
+			found = append(found, c)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].CreatedAt.Before(found[j].CreatedAt) })
+	if offset >= len(found) {
+		return nil, nil
+	}
+	return found[offset:min(offset+limit, len(found))], nil
+}
+
+func (m *memoryRepo) FindByImportRef(ctx context.Context, ref string) (*comment.Comment, error) {
+	for _, c := range m.byID {
+		if c.ImportRef != nil && *c.ImportRef == ref {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryRepo) FindApprovedSince(ctx context.Context, articleID string, root *comment.Path, since time.Time, limit int) ([]*comment.Comment, error) {
+	var found []*comment.Comment
+	for _, c := range m.byID {
+		if c.ArticleID != articleID || !c.IsVisible() || !c.ModeratedAt.After(since) || (root != nil && !root.IsAncestorOf(c.Path)) {
+			continue
+		}
+		found = append(found, c)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ModeratedAt.Before(*found[j].ModeratedAt) })
+	return found[:min(limit, len(found))], nil
+}
+
+func (m *memoryRepo) FindQueue(ctx context.Context, q comment.QueueQuery) ([]*comment.Comment, error) {
+	return nil, nil
+}
+
+func (m *memoryRepo) CountByStatus(ctx context.Context, articleID *string) (map[comment.Status]int, error) {
+	return nil, nil
+}
+
+type fakeCache struct {
+	summaries   map[string]comment.ThreadSummary
+	invalidated int
+}
+
+func (f *fakeCache) Get(ctx context.Context, articleID string) (*comment.ThreadSummary, error) {
+	if s, ok := f.summaries[articleID]; ok {
+		return &s, nil
+	}
+	return nil, nil
+}
+
+func (f *fakeCache) Set(ctx context.Context, s comment.ThreadSummary) error {
+	f.summaries[s.ArticleID] = s
+	return nil
+}
+
+func (f *fakeCache) Invalidate(ctx context.Context, articleID string) error {
+	delete(f.summaries, articleID)
+	f.invalidated++
+	return nil
+}
+
+type memorySource struct {
+	repo     *memoryRepo
+	computed int
+}
+
+func (s *memorySource) Summary(ctx context.Context, articleID string) (*comment.ThreadSummary, error) {
+	s.computed++
+	all := make([]*comment.Comment, 0, len(s.repo.byID))
+	for _, c := range s.repo.byID {
+		all = append(all, c)
+	}
+	summary := comment.Summarize(articleID, all)
+	return &summary, nil
+}
+
+type fakeBadges struct {
+	kinds map[string]badge.Kind
+}
+
+func (f *fakeBadges) FindActiveByAccountIDs(ctx context.Context, accountIDs []string) (map[string]*badge.Badge, error) {
+	found := map[string]*badge.Badge{}
+	for _, id := range accountIDs {
+		if kind, ok := f.kinds[id]; ok {
+			found[id] = &badge.Badge{AccountID: id, Kind: kind}
+		}
+	}
+	return found, nil
+}
+
+// fakeScreener holds pre-moderated comments and those containing "spam", and publishes the rest
+type fakeScreener struct {
+	repo     *memoryRepo
+	screened []string
+}
+
+func (f *fakeScreener) Screen(ctx context.Context, c *comment.Comment, preModeration bool, at time.Time) (*moderation.Review, error) {
+	f.screened = append(f.screened, c.ID)
+	if preModeration || strings.Contains(c.Body, "spam") {
+		return &moderation.Review{CommentID: c.ID, Held: true}, nil
+	}
+	if err := c.Approve(moderation.AutoModeratorID, at); err != nil {
+		return nil, err
+	}
+	return &moderation.Review{CommentID: c.ID}, f.repo.Update(ctx, c)
+}
+
+// memoryArticles holds published articles with the default comment settings
+type memoryArticles struct {
+	byID map[string]*article.Article
+}
+
+func newMemoryArticles(ids ...string) *memoryArticles {
+	m := &memoryArticles{byID: map[string]*article.Article{}}
+	published := time.Now().Add(-time.Hour)
+	for _, id := range ids {
+		slug, _ := article.NewSlug("article-" + id)
+		a, _ := article.NewArticle(id, *slug, "Title", "", "<p>Body</p>", "u9")
+		a.Status, a.PublishedAt = article.StatusPublished, &published
+		m.byID[id] = a
+	}
+	return m
+}
+
+func (m *memoryArticles) FindByID(ctx context.Context, id string) (*article.Article, error) {
+	return m.byID[id], nil
+}
+
+// newTestAccounts returns active members u1 to u3
+func newTestAccounts() *fakeAccounts {
+	accounts := &fakeAccounts{}
+	for _, id := range []string{"u1", "u2", "u3"} {
+		acc, _ := account.NewUserAccountForTesting(id, "member"+id, id+"@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+		acc.Status, acc.IsVerified = account.StatusActive, true
+		accounts.accounts = append(accounts.accounts, acc)
+	}
+	return accounts
+}
+
+func newTestService() (*Service, *memoryRepo, *fakeCache, *memorySource) {
+	service, repo, cache, source, _ := newTestServiceWithArticles()
+	return service, repo, cache, source
+}
+
+func newTestServiceWithArticles() (*Service, *memoryRepo, *fakeCache, *memorySource, *memoryArticles) {
+	repo := newMemoryRepo()
+	articles := newMemoryArticles("a1", "a2")
+	cache := &fakeCache{summaries: map[string]comment.ThreadSummary{}}
+	source := &memorySource{repo: repo}
+	badges := &fakeBadges{kinds: map[string]badge.Kind{"u2": badge.KindAuthor}}
+	return NewService(repo, articles, newTestAccounts(), cache, source, badges, &fakeScreener{repo: repo}), repo, cache, source, articles
+}
+
+func TestService_PostAndPage(t *testing.T) {
+	service, _, cache, _ := newTestService()
+	ctx := context.Background()
+
+	root, err := service.Post(ctx, "a1", comment.AccountAuthor("u1"), nil, "Top-level comment")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var lastReply *comment.Comment
+	for i := 0; i < 5; i++ {
+		if lastReply, err = service.Post(ctx, "a1", comment.AccountAuthor("u2"), &root.ID, fmt.Sprintf("reply %d", i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := service.Post(ctx, "a1", comment.AccountAuthor("u3"), &lastReply.ID, "nested reply"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if root.ReplyCount != 5 || root.DescendantCount != 6 || lastReply.ReplyCount != 1 {
+		t.Errorf("unexpected counts: root %d/%d, reply %d", root.ReplyCount, root.DescendantCount, lastReply.ReplyCount)
+	}
+	if cache.invalidated != 7 {
+		t.Errorf("expected summary invalidated on every post, got %d", cache.invalidated)
+	}
+
+	nodes, err := service.TopLevelPage(ctx, "a1", "", 0, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 1 || len(nodes[0].Replies) != DefaultReplyPreview || nodes[0].MoreReplies != 2 {
+		t.Errorf("expected 3 previewed and 2 more replies, got %+v", nodes)
+	}
+	if len(nodes[0].Badges) != 1 || nodes[0].Badges["u2"] != badge.KindAuthor {
+		t.Errorf("expected badge for the replying author only, got %v", nodes[0].Badges)
+	}
+
+	replies, err := service.Replies(ctx, root.ID, comment.SortNewest, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(replies) != 5 || replies[0].Comment.ID != lastReply.ID || len(replies[0].Replies) != 1 {
+		t.Errorf("expected newest reply first with its nested preview, got %+v", replies[0])
+	}
+}
+
+func TestService_PostErrors(t *testing.T) {
+	service, _, _, _ := newTestService()
+	ctx := context.Background()
+	root, _ := service.Post(ctx, "a1", comment.AccountAuthor("u1"), nil, "Top-level comment")
+
+	missing := "missing"
+	if _, err := service.Post(ctx, "a1", comment.AccountAuthor("u1"), &missing, "reply"); err != ErrCommentNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrCommentNotFound, err)
+	}
+	if _, err := service.Post(ctx, "a2", comment.AccountAuthor("u1"), &root.ID, "reply"); err != ErrParentMismatch {
+		t.Errorf("expected error '%v', got '%v'", ErrParentMismatch, err)
+	}
+
+	_ = root.Reject("mod-1", time.Now())
+	if _, err := service.Post(ctx, "a1", comment.AccountAuthor("u1"), &root.ID, "reply"); err != ErrRepliesClosed {
+		t.Errorf("expected error '%v', got '%v'", ErrRepliesClosed, err)
+	}
+
+	if _, err := service.TopLevelPage(ctx, "a1", "hot", 10, 0); err != comment.ErrInvalidSort {
+		t.Errorf("expected error '%v', got '%v'", comment.ErrInvalidSort, err)
+	}
+}
+
+func TestService_SummaryCacheAside(t *testing.T) {
+	service, _, _, source := newTestService()
+	ctx := context.Background()
+	_, _ = service.Post(ctx, "a1", comment.AccountAuthor("u1"), nil, "Top-level comment")
+
+	for i := 0; i < 3; i++ {
+		summary, err := service.Summary(ctx, "a1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if summary.Total != 1 {
+			t.Errorf("expected 1 comment, got %d", summary.Total)
+		}
+	}
+	if source.computed != 1 {
+		t.Errorf("expected summary computed once, got %d", source.computed)
+	}
+
+	_, _ = service.Post(ctx, "a1", comment.AccountAuthor("u2"), nil, "Another comment")
+	summary, _ := service.Summary(ctx, "a1")
+	if summary.Total != 2 || source.computed != 2 {
+		t.Errorf("expected recompute after new comment, got total %d computed %d", summary.Total, source.computed)
+	}
+}
+
+func BenchmarkService_TopLevelPage(b *testing.B) {
+	service, repo, _, _ := newTestService()
+	ctx := context.Background()
+
+	// 20k top-level comments with 3 replies each, 80k comments in total
+	for i := 0; i < 20000; i++ {
+		root, err := service.Post(ctx, "a1", comment.AccountAuthor("u1"), nil, strings.Repeat("x", 50))
+		if err != nil {
+			b.Fatalf("failed to post: %v", err)
+		}
+		for j := 0; j < 3; j++ {
+			reply, _ := comment.NewReply(fmt.Sprintf("%s-%d", root.ID, j), root, comment.AccountAuthor("u2"), "reply", uint32(j+1))
+			_ = repo.Create(ctx, reply)
+			root.ReplyCount++
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := service.TopLevelPage(ctx, "a1", comment.SortOldest, 20, (i%100)*20); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestService_PostScreened(t *testing.T) {
+	service, repo, _, _ := newTestService()
+
+	c, err := service.Post(context.Background(), "a1", comment.AccountAuthor("u1"), nil, "cheap spam here")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.IsVisible() || repo.byID[c.ID].Status != comment.StatusPending {
+		t.Errorf("expected the held comment to stay pending, got %s", c.Status)
+	}
+}
+
+func TestService_PostAsGuest(t *testing.T) {
+	service, _, _, _ := newTestService()
+	ctx := context.Background()
+
+	guest, err := comment.NewGuestAuthor("Sari", "sari@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	root, err := service.Post(ctx, "a1", *guest, nil, "Posting without an account")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !root.Author.IsGuest() || !root.IsVisible() {
+		t.Errorf("expected a published guest comment, got %+v", root)
+	}
+	if _, err := service.Post(ctx, "a1", comment.AccountAuthor("u2"), &root.ID, "Welcome"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	nodes, _ := service.TopLevelPage(ctx, "a1", "", 0, 0)
+	if len(nodes) != 1 || len(nodes[0].Badges) != 1 || nodes[0].Badges["u2"] != badge.KindAuthor {
+		t.Errorf("expected only the signed-in author's badge, got %+v", nodes)
+	}
+}
+
+func TestService_PostCommentSettings(t *testing.T) {
+	guest, _ := comment.NewGuestAuthor("Sari", "sari@example.com")
+	disabled, _ := article.NewCommentSettings(false, false, 0, false)
+	membersOnly, _ := article.NewCommentSettings(true, true, 0, false)
+	closesAfterADay, _ := article.NewCommentSettings(true, false, 1, false)
+	preModerated, _ := article.NewCommentSettings(true, false, 0, true)
+
+	testCases := []struct {
+		name        string
+		settings    *article.CommentSettings
+		published   time.Duration // Before now
+		author      comment.Author
+		articleID   string
+		expectedErr error
+		pending     bool
+	}{
+		{"open", nil, time.Hour, comment.AccountAuthor("u1"), "a1", nil, false},
+		{"unknown article", nil, time.Hour, comment.AccountAuthor("u1"), "a9", ErrArticleNotFound, false},
+		{"unknown member", nil, time.Hour, comment.AccountAuthor("u9"), "a1", article.ErrCommenterNotActive, false},
+		{"disabled", disabled, time.Hour, comment.AccountAuthor("u1"), "a1", article.ErrCommentsDisabled, false},
+		{"members only, guest", membersOnly, time.Hour, *guest, "a1", article.ErrCommentsMembersOnly, false},
+		{"members only, member", membersOnly, time.Hour, comment.AccountAuthor("u1"), "a1", nil, false},
+		{"auto-closed", closesAfterADay, 48 * time.Hour, comment.AccountAuthor("u1"), "a1", article.ErrCommentsClosed, false},
+		{"pre-moderated", preModerated, time.Hour, comment.AccountAuthor("u1"), "a1", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, repo, _, _, articles := newTestServiceWithArticles()
+			a := articles.byID["a1"]
+			if tc.settings != nil {
+				a.Comments = *tc.settings
+			}
+			published := time.Now().Add(-tc.published)
+			a.PublishedAt = &published
+
+			c, err := service.Post(context.Background(), tc.articleID, tc.author, nil, "A comment")
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err != nil {
+				if len(repo.byID) != 0 {
+					t.Errorf("expected nothing saved, got %d comments", len(repo.byID))
+				}
+				return
+			}
+			if pending := repo.byID[c.ID].Status == comment.StatusPending; pending != tc.pending {
+				t.Errorf("expected pending %v, got status %s", tc.pending, c.Status)
+			}
+		})
+	}
+}
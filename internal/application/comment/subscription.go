@@ -0,0 +1,175 @@
+package comment
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrArticleNotFound      = errors.New("article not found")
+	ErrSubscriptionNotFound = errors.New("comment subscription not found")
+)
+
+// SubscriptionService lets members follow an article's discussion or a single
+// thread, the notification job emails them the new approved comments
+type SubscriptionService struct {
+	subscriptions comment.SubscriptionRepository
+	comments      comment.CommentRepository
+	articles      comment.ArticleLocator
+	clock         shared.Clock
+}
+
+func NewSubscriptionService(subscriptions comment.SubscriptionRepository, comments comment.CommentRepository, articles comment.ArticleLocator, clock shared.Clock) *SubscriptionService {
+	return &SubscriptionService{subscriptions: subscriptions, comments: comments, articles: articles, clock: clock}
+}
+
+// Subscribe follows the article's discussion, or the thread under commentID when
+// it is set. Subscribing again only changes the frequency.
+func (s *SubscriptionService) Subscribe(ctx context.Context, accountID, articleID string, commentID *string, frequency comment.Frequency) (*comment.Subscription, error) {
+	var root *comment.Comment
+	if commentID != nil {
+		c, err := s.comments.FindByID(ctx, *commentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load comment: %w", err)
+		}
+		if c == nil {
+			return nil, ErrCommentNotFound
+		}
+		if c.ArticleID != articleID {
+			return nil, ErrParentMismatch
+		}
+		root = c
+	} else {
+		exists, err := s.articles.Exists(ctx, articleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load article: %w", err)
+		}
+		if !exists {
+			return nil, ErrArticleNotFound
+		}
+	}
+
+	now := s.clock.Now()
+	existing, err := s.subscriptions.Find(ctx, accountID, articleID, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comment subscription: %w", err)
+	}
+	if existing != nil {
+		if err := existing.ChangeFrequency(frequency, now); err != nil {
+			return nil, err
+		}
+		if err := s.subscriptions.Update(ctx, existing); err != nil {
+			return nil, fmt.Errorf("failed to update comment subscription: %w", err)
+		}
+		return existing, nil
+	}
+
+	count, err := s.subscriptions.CountByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count comment subscriptions: %w", err)
+	}
+	if count >= comment.MaxSubscriptionsPerAccount {
+		return nil, comment.ErrTooManySubscriptions
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	token, err := newUnsubscribeToken()
+	if err != nil {
+		return nil, err
+	}
+	var sub *comment.Subscription
+	if root != nil {
+		sub, err = comment.NewThreadSubscription(id, accountID, root, frequency, token, now)
+	} else {
+		sub, err = comment.NewArticleSubscription(id, accountID, articleID, frequency, token, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.subscriptions.Create(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to save comment subscription: %w", err)
+	}
+	return sub, nil
+}
+
+func (s *SubscriptionService) List(ctx context.Context, accountID string) ([]*comment.Subscription, error) {
+	subs, err := s.subscriptions.FindByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comment subscriptions: %w", err)
+	}
+	return subs, nil
+}
+
+func (s *SubscriptionService) ChangeFrequency(ctx context.Context, accountID, id string, frequency comment.Frequency) (*comment.Subscription, error) {
+	sub, err := s.owned(ctx, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := sub.ChangeFrequency(frequency, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.subscriptions.Update(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to update comment subscription: %w", err)
+	}
+	return sub, nil
+}
+
+func (s *SubscriptionService) Unsubscribe(ctx context.Context, accountID, id string) error {
+	sub, err := s.owned(ctx, accountID, id)
+	if err != nil {
+		return err
+	}
+	return s.delete(ctx, sub)
+}
+
+// UnsubscribeByToken backs the link in the emails, it needs no session
+func (s *SubscriptionService) UnsubscribeByToken(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrSubscriptionNotFound
+	}
+	sub, err := s.subscriptions.FindByUnsubscribeToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("failed to load comment subscription: %w", err)
+	}
+	if sub == nil {
+		return ErrSubscriptionNotFound
+	}
+	return s.delete(ctx, sub)
+}
+
+// owned loads a member's subscription, someone else's is reported as not found
+func (s *SubscriptionService) owned(ctx context.Context, accountID, id string) (*comment.Subscription, error) {
+	sub, err := s.subscriptions.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comment subscription: %w", err)
+	}
+	if sub == nil || sub.AccountID != accountID {
+		return nil, ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+func (s *SubscriptionService) delete(ctx context.Context, sub *comment.Subscription) error {
+	if err := s.subscriptions.Delete(ctx, sub.ID); err != nil {
+		return fmt.Errorf("failed to delete comment subscription: %w", err)
+	}
+	return nil
+}
+
+// newUnsubscribeToken returns the URL-safe secret of a subscription's unsubscribe link
+func newUnsubscribeToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate unsubscribe token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
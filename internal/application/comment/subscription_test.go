@@ -0,0 +1,162 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type memorySubscriptions struct {
+	byID map[string]*comment.Subscription
+}
+
+func (m *memorySubscriptions) Create(ctx context.Context, s *comment.Subscription) error {
+	m.byID[s.ID] = s
+	return nil
+}
+
+func (m *memorySubscriptions) Update(ctx context.Context, s *comment.Subscription) error {
+	m.byID[s.ID] = s
+	return nil
+}
+
+func (m *memorySubscriptions) Delete(ctx context.Context, id string) error {
+	delete(m.byID, id)
+	return nil
+}
+
+func (m *memorySubscriptions) FindByID(ctx context.Context, id string) (*comment.Subscription, error) {
+	return m.byID[id], nil
+}
+
+func (m *memorySubscriptions) FindByUnsubscribeToken(ctx context.Context, token string) (*comment.Subscription, error) {
+	for _, s := range m.byID {
+		if s.UnsubscribeToken == token {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySubscriptions) Find(ctx context.Context, accountID, articleID string, commentID *string) (*comment.Subscription, error) {
+	for _, s := range m.byID {
+		if s.AccountID == accountID && s.ArticleID == articleID && parentKey(s.CommentID) == parentKey(commentID) {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySubscriptions) FindByAccount(ctx context.Context, accountID string) ([]*comment.Subscription, error) {
+	var found []*comment.Subscription
+	for _, s := range m.byID {
+		if s.AccountID == accountID {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+func (m *memorySubscriptions) CountByAccount(ctx context.Context, accountID string) (int, error) {
+	found, _ := m.FindByAccount(ctx, accountID)
+	return len(found), nil
+}
+
+func (m *memorySubscriptions) FindPendingAccounts(ctx context.Context, after string, limit int) ([]string, error) {
+	return nil, nil
+}
+
+func newTestSubscriptionService(t *testing.T) (*SubscriptionService, *memoryRepo, *memorySubscriptions) {
+	t.Helper()
+	repo := newMemoryRepo()
+	subscriptions := &memorySubscriptions{byID: map[string]*comment.Subscription{}}
+	articles := &fakeArticles{links: map[string]string{"https://old.example.com/a1": "a1", "https://old.example.com/a2": "a2"}}
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC))
+	return NewSubscriptionService(subscriptions, repo, articles, clock), repo, subscriptions
+}
+
+func TestSubscriptionService_Subscribe(t *testing.T) {
+	service, repo, subscriptions := newTestSubscriptionService(t)
+	ctx := context.Background()
+	root, _ := comment.NewComment("c1", "a1", comment.AccountAuthor("u1"), "Sharp analysis", 1)
+	_ = root.Approve("mod-1", time.Now())
+	_ = repo.Create(ctx, root)
+	pending, _ := comment.NewComment("c2", "a1", comment.AccountAuthor("u1"), "Held", 2)
+	_ = repo.Create(ctx, pending)
+	rootID, pendingID, missingID := root.ID, pending.ID, "c9"
+
+	testCases := []struct {
+		name          string
+		articleID     string
+		commentID     *string
+		frequency     comment.Frequency
+		expectedError error
+	}{
+		{"discussion", "a1", nil, comment.FrequencyDaily, nil},
+		{"thread", "a1", &rootID, comment.FrequencyInstant, nil},
+		{"unknown article", "a9", nil, comment.FrequencyDaily, ErrArticleNotFound},
+		{"unknown comment", "a1", &missingID, comment.FrequencyDaily, ErrCommentNotFound},
+		{"comment of another article", "a2", &rootID, comment.FrequencyDaily, ErrParentMismatch},
+		{"pending comment", "a1", &pendingID, comment.FrequencyDaily, comment.ErrNotSubscribable},
+		{"unknown frequency", "a2", nil, "weekly", comment.ErrInvalidFrequency},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.Subscribe(ctx, "u2", tc.articleID, tc.commentID, tc.frequency)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+
+	again, err := service.Subscribe(ctx, "u2", "a1", nil, comment.FrequencyInstant)
+	if err != nil || again.Frequency != comment.FrequencyInstant || len(subscriptions.byID) != 2 {
+		t.Errorf("expected subscribing again to change the frequency only, got %v with %d subscriptions", err, len(subscriptions.byID))
+	}
+}
+
+func TestSubscriptionService_Cap(t *testing.T) {
+	service, _, subscriptions := newTestSubscriptionService(t)
+	ctx := context.Background()
+	for i := 0; i < comment.MaxSubscriptionsPerAccount; i++ {
+		id := fmt.Sprintf("s%d", i)
+		subscriptions.byID[id] = &comment.Subscription{ID: id, AccountID: "u2", ArticleID: fmt.Sprintf("a%d", i+10)}
+	}
+	if _, err := service.Subscribe(ctx, "u2", "a1", nil, comment.FrequencyDaily); err != comment.ErrTooManySubscriptions {
+		t.Errorf("expected error '%v', got '%v'", comment.ErrTooManySubscriptions, err)
+	}
+}
+
+func TestSubscriptionService_Unsubscribe(t *testing.T) {
+	service, _, subscriptions := newTestSubscriptionService(t)
+	ctx := context.Background()
+	first, _ := service.Subscribe(ctx, "u2", "a1", nil, comment.FrequencyDaily)
+	second, _ := service.Subscribe(ctx, "u2", "a2", nil, comment.FrequencyDaily)
+	if first.UnsubscribeToken == "" || first.UnsubscribeToken == second.UnsubscribeToken {
+		t.Fatal("expected a distinct unsubscribe token per subscription")
+	}
+
+	if err := service.Unsubscribe(ctx, "u3", first.ID); err != ErrSubscriptionNotFound {
+		t.Errorf("expected someone else's subscription reported missing, got %v", err)
+	}
+	if _, err := service.ChangeFrequency(ctx, "u2", first.ID, comment.FrequencyInstant); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.Unsubscribe(ctx, "u2", first.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.UnsubscribeByToken(ctx, second.UnsubscribeToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.UnsubscribeByToken(ctx, second.UnsubscribeToken); err != ErrSubscriptionNotFound {
+		t.Errorf("expected a used link reported missing, got %v", err)
+	}
+	if len(subscriptions.byID) != 0 {
+		t.Errorf("expected no subscriptions left, got %d", len(subscriptions.byID))
+	}
+}
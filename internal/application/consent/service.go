@@ -0,0 +1,112 @@
+package consent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+type Service struct {
+	records consent.RecordRepository
+	audit   consent.AuditRepository
+}
+
+func NewService(records consent.RecordRepository, audit consent.AuditRepository) *Service {
+	return &Service{records: records, audit: audit}
+}
+
+// Ensure Service can be used as the enforcement hook
+var (
+	_ consent.Checker      = (*Service)(nil)
+	_ consent.BatchChecker = (*Service)(nil)
+)
+
+// Get returns the subject's record, an undecided one if nothing was stored yet
+func (s *Service) Get(ctx context.Context, subject consent.Subject) (*consent.Record, error) {
+	record, err := s.records.FindBySubject(ctx, subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load consent: %w", err)
+	}
+	if record == nil {
+		return consent.NewRecord(subject), nil
+	}
+	return record, nil
+}
+
+// Update applies the subject's choices and writes an audit entry for each change
+func (s *Service) Update(ctx context.Context, subject consent.Subject, choices map[consent.Purpose]bool, source consent.Source, ipAddress string) (*consent.Record, error) {
+	record, err := s.Get(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := record.Apply(choices, source, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return record, nil
+	}
+
+	for _, change := range changes {
+		id, err := shared.GenerateUUID()
+		if err != nil {
+			return nil, err
+		}
+		change.ID = id
+	}
+
+	if err := s.records.Save(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to save consent: %w", err)
+	}
+	if err := s.audit.Append(ctx, changes); err != nil {
+		return nil, fmt.Errorf("failed to write consent audit: %w", err)
+	}
+	return record, nil
+}
+
+// History returns the subject's audit trail, newest first
+func (s *Service) History(ctx context.Context, subject consent.Subject, limit, offset int) ([]*consent.Change, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.audit.FindBySubject(ctx, subject, limit, offset)
+}
+
+// Allows checks a single purpose, analytics calls this before recording an event
+func (s *Service) Allows(ctx context.Context, subject consent.Subject, purpose consent.Purpose) (bool, error) {
+	record, err := s.Get(ctx, subject)
+	if err != nil {
+		return false, err
+	}
+	return record.Allows(purpose), nil
+}
+
+// FilterAllowed keeps only subjects that granted the purpose, newsletter sends
+// pass their recipient list through this with PurposeMarketingEmail
+func (s *Service) FilterAllowed(ctx context.Context, purpose consent.Purpose, subjects []consent.Subject) ([]consent.Subject, error) {
+	if len(subjects) == 0 {
+		return nil, nil
+	}
+	records, err := s.records.FindBySubjects(ctx, subjects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load consent: %w", err)
+	}
+	granted := make(map[string]bool, len(records))
+	for _, record := range records {
+		granted[record.Subject.String()] = record.Allows(purpose)
+	}
+
+	allowed := make([]consent.Subject, 0, len(subjects))
+	for _, subject := range subjects {
+		if granted[subject.String()] {
+			allowed = append(allowed, subject)
+		}
+	}
+	return allowed, nil
+}
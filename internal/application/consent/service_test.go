@@ -0,0 +1,137 @@
+package consent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+type fakeRecordRepo struct {
+	records map[string]*consent.Record
+	saved   int
+	batches int
+}
+
+func (f *fakeRecordRepo) Save(ctx context.Context, record *consent.Record) error {
+	f.records[record.Subject.String()] = record
+	f.saved++
+	return nil
+}
+
+func (f *fakeRecordRepo) FindBySubject(ctx context.Context, subject consent.Subject) (*consent.Record, error) {
+	return f.records[subject.String()], nil
+}
+
+func (f *fakeRecordRepo) FindBySubjects(ctx context.Context, subjects []consent.Subject) ([]*consent.Record, error) {
+	f.batches++
+	var result []*consent.Record
+	for _, subject := range subjects {
+		if record, ok := f.records[subject.String()]; ok {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
+type fakeAuditRepo struct {
+	changes []*consent.Change
+}
+
+func (f *fakeAuditRepo) Append(ctx context.Context, changes []*consent.Change) error {
+	f.changes = append(f.changes, changes...)
+	return nil
+}
+
+func (f *fakeAuditRepo) FindBySubject(ctx context.Context, subject consent.Subject, limit, offset int) ([]*consent.Change, error) {
+	var result []*consent.Change
+	for i := len(f.changes) - 1; i >= 0; i-- {
+		if f.changes[i].Subject.Equals(subject) {
+			result = append(result, f.changes[i])
+		}
+	}
+	return result, nil
+}
+
+func TestService_UpdateAndAudit(t *testing.T) {
+	records := &fakeRecordRepo{records: map[string]*consent.Record{}}
+	audit := &fakeAuditRepo{}
+	service := NewService(records, audit)
+	ctx := context.Background()
+	subject := createTestSubject(t, "user-1")
+
+	record, err := service.Get(ctx, subject)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.IsDecided() {
+		t.Error("expected undecided record for unknown subject")
+	}
+
+	if _, err := service.Update(ctx, subject, map[consent.Purpose]bool{consent.PurposeAnalytics: true}, consent.SourceBanner, "203.0.113.7"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Unchanged values are neither saved nor audited
+	if _, err := service.Update(ctx, subject, map[consent.Purpose]bool{consent.PurposeAnalytics: true}, consent.SourceBanner, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if records.saved != 1 {
+		t.Errorf("expected record to be saved once, got %d", records.saved)
+	}
+
+	history, _ := service.History(ctx, subject, 0, 0)
+	if len(history) != 1 || history[0].ID == "" {
+		t.Fatalf("expected one audit entry with an ID, got %+v", history)
+	}
+
+	allowed, _ := service.Allows(ctx, subject, consent.PurposeAnalytics)
+	if !allowed {
+		t.Error("expected analytics to be allowed")
+	}
+
+	// Denying everything on the banner is a decision too
+	declined := createTestSubject(t, "user-2")
+	record, err = service.Update(ctx, declined, map[consent.Purpose]bool{consent.PurposeAnalytics: false, consent.PurposeThirdPartyAds: false}, consent.SourceBanner, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored := records.records[declined.String()]; stored == nil || !stored.IsDecided() || !record.IsDecided() {
+		t.Error("expected a deny-all record to be saved as decided")
+	}
+
+	if _, err := service.Update(ctx, subject, map[consent.Purpose]bool{"fingerprinting": true}, consent.SourceAPI, ""); err != consent.ErrInvalidPurpose {
+		t.Errorf("expected error '%v', got '%v'", consent.ErrInvalidPurpose, err)
+	}
+}
+
+func TestService_FilterAllowed(t *testing.T) {
+	records := &fakeRecordRepo{records: map[string]*consent.Record{}}
+	service := NewService(records, &fakeAuditRepo{})
+	ctx := context.Background()
+
+	optedIn := createTestSubject(t, "user-1")
+	optedOut := createTestSubject(t, "user-2")
+	undecided := createTestSubject(t, "user-3")
+
+	_, _ = service.Update(ctx, optedIn, map[consent.Purpose]bool{consent.PurposeMarketingEmail: true}, consent.SourcePreferences, "")
+	_, _ = service.Update(ctx, optedOut, map[consent.Purpose]bool{consent.PurposeMarketingEmail: false}, consent.SourcePreferences, "")
+
+	allowed, err := service.FilterAllowed(ctx, consent.PurposeMarketingEmail, []consent.Subject{optedIn, optedOut, undecided})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(allowed) != 1 || !allowed[0].Equals(optedIn) {
+		t.Errorf("expected only opted-in subject, got %v", allowed)
+	}
+	if records.batches != 1 {
+		t.Errorf("expected the records loaded in one query, got %d", records.batches)
+	}
+}
+
+func createTestSubject(t *testing.T, accountID string) consent.Subject {
+	s, err := consent.NewAccountSubject(accountID)
+	if err != nil {
+		t.Fatalf("failed to create subject: %v", err)
+	}
+	return *s
+}
@@ -0,0 +1,348 @@
+package crm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/crm"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Job sizes
+const (
+	SyncBatchSize     = 1000 // Changes one sync run picks up
+	ReconcilePageSize = 200  // Members compared per page
+)
+
+// MaxRateLimitWait is the longest the jobs wait in place when the CRM asks
+// them to slow down, longer delays defer the work to a later run
+const MaxRateLimitWait = 30 * time.Second
+
+// maxRateLimitRetries bounds how often one call waits and tries again
+const maxRateLimitRetries = 3
+
+// SyncResult summarizes one sync run
+type SyncResult struct {
+	Upserted int // Accounts written to the CRM
+	Deleted  int // Accounts removed from the CRM
+	Failed   int // Accounts the CRM rejected, retried with backoff
+	Deferred int // Accounts put back because of the rate limit
+}
+
+type Service struct {
+	provider crm.Provider
+	mapping  *crm.Mapping
+	changes  crm.ChangeRepository
+	records  crm.RecordSource
+	reports  crm.ReportRepository
+	clock    shared.Clock
+	wait     func(ctx context.Context, d time.Duration) error
+}
+
+// NewService takes the field mapping from crm.LoadMapping or the provider's default
+func NewService(provider crm.Provider, mapping *crm.Mapping, changes crm.ChangeRepository, records crm.RecordSource, reports crm.ReportRepository, clock shared.Clock) *Service {
+	return &Service{provider: provider, mapping: mapping, changes: changes, records: records, reports: reports, clock: clock, wait: sleep}
+}
+
+func (s *Service) Mapping() *crm.Mapping {
+	return s.mapping
+}
+
+// Enqueue records a lifecycle event, the account and subscription flows call
+// it and the sync job delivers it
+func (s *Service) Enqueue(ctx context.Context, accountID string, event crm.Event) error {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	change, err := crm.NewChange(id, accountID, event, s.clock.Now())
+	if err != nil {
+		return err
+	}
+	if err := s.changes.Create(ctx, change); err != nil {
+		return fmt.Errorf("failed to queue CRM change: %w", err)
+	}
+	return nil
+}
+
+// Sync is the scheduled job. It sends the current record of every account
+// with due changes, in batches the provider accepts, and deletes the contacts
+// of accounts that no longer exist.
+func (s *Service) Sync(ctx context.Context) (*SyncResult, error) {
+	at := s.clock.Now()
+	due, err := s.changes.FindDue(ctx, at, SyncBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CRM changes: %w", err)
+	}
+	result := &SyncResult{}
+	if len(due) == 0 {
+		return result, nil
+	}
+
+	byAccount := make(map[string][]*crm.Change)
+	var accountIDs []string
+	for _, c := range due {
+		if _, ok := byAccount[c.AccountID]; !ok {
+			accountIDs = append(accountIDs, c.AccountID)
+		}
+		byAccount[c.AccountID] = append(byAccount[c.AccountID], c)
+	}
+	records, err := s.records.FindRecords(ctx, accountIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load members: %w", err)
+	}
+
+	var contacts []crm.Contact
+	var gone []string
+	for _, id := range accountIDs {
+		if record, ok := records[id]; ok {
+			contacts = append(contacts, s.mapping.Contact(record))
+		} else {
+			gone = append(gone, id)
+		}
+	}
+
+	var errs []error
+	deferUntil := time.Time{}
+	finish := func(accountID string, callErr error) {
+		for _, c := range byAccount[accountID] {
+			switch {
+			case !deferUntil.IsZero():
+				c.Defer(deferUntil)
+			case callErr != nil:
+				c.MarkFailed(callErr.Error(), at)
+			default:
+				c.MarkSynced(at)
+			}
+			if err := s.changes.Update(ctx, c); err != nil {
+				errs = append(errs, fmt.Errorf("failed to save CRM change %s: %w", c.ID, err))
+			}
+		}
+	}
+	// rateLimited switches the rest of the run to deferring once the CRM wants a long pause
+	rateLimited := func(err error) bool {
+		var limited *crm.RateLimitError
+		if errors.As(err, &limited) && deferUntil.IsZero() {
+			deferUntil = at.Add(limited.RetryAfter)
+			errs = append(errs, err)
+		}
+		return !deferUntil.IsZero()
+	}
+
+	for _, batch := range chunk(contacts, s.provider.MaxBatch()) {
+		var rejected map[string]error
+		var callErr error
+		if deferUntil.IsZero() {
+			callErr = s.call(ctx, func() error {
+				var err error
+				rejected, err = s.provider.Upsert(ctx, batch)
+				return err
+			})
+			if callErr != nil && !rateLimited(callErr) {
+				errs = append(errs, fmt.Errorf("failed to upsert contacts: %w", callErr))
+			}
+		}
+		for _, contact := range batch {
+			err := callErr
+			if err == nil {
+				err = rejected[contact.Key]
+			}
+			switch {
+			case !deferUntil.IsZero():
+				result.Deferred++
+			case err != nil:
+				result.Failed++
+			default:
+				result.Upserted++
+			}
+			finish(contact.Key, err)
+		}
+	}
+
+	for _, batch := range chunk(gone, s.provider.MaxBatch()) {
+		var callErr error
+		if deferUntil.IsZero() {
+			callErr = s.call(ctx, func() error { return s.provider.Delete(ctx, batch) })
+			if callErr != nil && !rateLimited(callErr) {
+				errs = append(errs, fmt.Errorf("failed to delete contacts: %w", callErr))
+			}
+		}
+		for _, key := range batch {
+			switch {
+			case !deferUntil.IsZero():
+				result.Deferred++
+			case callErr != nil:
+				result.Failed++
+			default:
+				result.Deleted++
+			}
+			finish(key, callErr)
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// Reconcile compares every member with the CRM contact, queues a change for
+// each difference so the CMS version wins on the next sync, and saves the report
+func (s *Service) Reconcile(ctx context.Context) (*crm.Report, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	report, err := crm.NewReport(id, s.provider.Name(), s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reconcile(ctx, report); err != nil {
+		report.Fail(err, s.clock.Now())
+		if saveErr := s.reports.Save(ctx, report); saveErr != nil {
+			return report, errors.Join(err, fmt.Errorf("failed to save reconciliation report: %w", saveErr))
+		}
+		return report, err
+	}
+	report.Finish(s.clock.Now())
+	if err := s.reports.Save(ctx, report); err != nil {
+		return report, fmt.Errorf("failed to save reconciliation report: %w", err)
+	}
+	return report, nil
+}
+
+func (s *Service) reconcile(ctx context.Context, report *crm.Report) error {
+	members := make(map[string]bool)
+	after := ""
+	for {
+		records, err := s.records.ScanRecords(ctx, after, ReconcilePageSize)
+		if err != nil {
+			return fmt.Errorf("failed to load members: %w", err)
+		}
+		if len(records) == 0 {
+			break
+		}
+		after = records[len(records)-1].AccountID
+
+		keys := make([]string, 0, len(records))
+		for _, r := range records {
+			keys = append(keys, r.AccountID)
+			members[r.AccountID] = true
+		}
+		contacts, err := s.fetch(ctx, keys)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range records {
+			report.Checked++
+			have, ok := contacts[r.AccountID]
+			if !ok {
+				report.MissingInCRM = append(report.MissingInCRM, r.AccountID)
+			} else if drifts := crm.Compare(s.mapping.Contact(r), have); len(drifts) > 0 {
+				report.Drifted = append(report.Drifted, crm.AccountDrift{AccountID: r.AccountID, Drifts: drifts})
+			} else {
+				report.InSync++
+				continue
+			}
+			if err := s.Enqueue(ctx, r.AccountID, crm.EventReconciled); err != nil {
+				return err
+			}
+			report.Queued++
+		}
+		if len(records) < ReconcilePageSize {
+			break
+		}
+	}
+
+	cursor := ""
+	for {
+		var contacts []crm.Contact
+		err := s.call(ctx, func() error {
+			var err error
+			contacts, cursor, err = s.provider.Scan(ctx, cursor, ReconcilePageSize)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to scan CRM contacts: %w", err)
+		}
+		for _, c := range contacts {
+			if members[c.Key] {
+				continue
+			}
+			// The sync deletes contacts whose account it cannot find
+			report.UnknownInCRM = append(report.UnknownInCRM, c.Key)
+			if err := s.Enqueue(ctx, c.Key, crm.EventAccountDeleted); err != nil {
+				return err
+			}
+			report.Queued++
+		}
+		if cursor == "" {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *Service) fetch(ctx context.Context, keys []string) (map[string]crm.Contact, error) {
+	contacts := make(map[string]crm.Contact, len(keys))
+	for _, batch := range chunk(keys, s.provider.MaxBatch()) {
+		var found map[string]crm.Contact
+		err := s.call(ctx, func() error {
+			var err error
+			found, err = s.provider.Fetch(ctx, batch)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch CRM contacts: %w", err)
+		}
+		for key, c := range found {
+			contacts[key] = c
+		}
+	}
+	return contacts, nil
+}
+
+func (s *Service) Reports(ctx context.Context, limit int) ([]*crm.Report, error) {
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	return s.reports.FindRecent(ctx, limit)
+}
+
+// call runs one provider request, waiting out short rate limits in place
+func (s *Service) call(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		var limited *crm.RateLimitError
+		if !errors.As(err, &limited) || limited.RetryAfter > MaxRateLimitWait || attempt == maxRateLimitRetries {
+			return err
+		}
+		if err := s.wait(ctx, limited.RetryAfter); err != nil {
+			return err
+		}
+	}
+}
+
+func chunk[T any](items []T, size int) [][]T {
+	if size < 1 {
+		size = len(items)
+	}
+	var batches [][]T
+	for len(items) > 0 {
+		n := min(size, len(items))
+		batches = append(batches, items[:n])
+		items = items[n:]
+	}
+	return batches
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,334 @@
+package crm
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/crm"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type fakeProvider struct {
+	contacts  map[string]crm.Contact
+	reject    map[string]error
+	limits    []time.Duration // Rate limits returned by the next calls, in order
+	batches   [][]string
+	deleted   []string
+	scanPages int
+}
+
+func (f *fakeProvider) Name() string  { return "fake" }
+func (f *fakeProvider) MaxBatch() int { return 2 }
+
+func (f *fakeProvider) limited() error {
+	if len(f.limits) == 0 {
+		return nil
+	}
+	d := f.limits[0]
+	f.limits = f.limits[1:]
+	return &crm.RateLimitError{RetryAfter: d}
+}
+
+func (f *fakeProvider) Upsert(ctx context.Context, contacts []crm.Contact) (map[string]error, error) {
+	if err := f.limited(); err != nil {
+		return nil, err
+	}
+	var keys []string
+	rejected := map[string]error{}
+	for _, c := range contacts {
+		keys = append(keys, c.Key)
+		if err := f.reject[c.Key]; err != nil {
+			rejected[c.Key] = err
+			continue
+		}
+		f.contacts[c.Key] = c
+	}
+	f.batches = append(f.batches, keys)
+	return rejected, nil
+}
+
+func (f *fakeProvider) Delete(ctx context.Context, keys []string) error {
+	if err := f.limited(); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		delete(f.contacts, k)
+	}
+	f.deleted = append(f.deleted, keys...)
+	return nil
+}
+
+func (f *fakeProvider) Fetch(ctx context.Context, keys []string) (map[string]crm.Contact, error) {
+	if err := f.limited(); err != nil {
+		return nil, err
+	}
+	found := map[string]crm.Contact{}
+	for _, k := range keys {
+		if c, ok := f.contacts[k]; ok {
+			found[k] = c
+		}
+	}
+	return found, nil
+}
+
+// Scan returns one contact per page to exercise the cursor
+func (f *fakeProvider) Scan(ctx context.Context, cursor string, limit int) ([]crm.Contact, string, error) {
+	f.scanPages++
+	keys := make([]string, 0, len(f.contacts))
+	for k := range f.contacts {
+		if k > cursor {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) == 0 {
+		return nil, "", nil
+	}
+	next := ""
+	if len(keys) > 1 {
+		next = keys[0]
+	}
+	return []crm.Contact{f.contacts[keys[0]]}, next, nil
+}
+
+type memoryChanges struct {
+	changes []*crm.Change
+}
+
+func (m *memoryChanges) Create(ctx context.Context, c *crm.Change) error {
+	m.changes = append(m.changes, c)
+	return nil
+}
+
+func (m *memoryChanges) Update(ctx context.Context, c *crm.Change) error {
+	return nil
+}
+
+func (m *memoryChanges) FindDue(ctx context.Context, at time.Time, limit int) ([]*crm.Change, error) {
+	var due []*crm.Change
+	for _, c := range m.changes {
+		if c.Due(at) && len(due) < limit {
+			due = append(due, c)
+		}
+	}
+	return due, nil
+}
+
+type memoryRecords struct {
+	records map[string]crm.Record
+}
+
+func (m *memoryRecords) FindRecords(ctx context.Context, ids []string) (map[string]crm.Record, error) {
+	found := map[string]crm.Record{}
+	for _, id := range ids {
+		if r, ok := m.records[id]; ok {
+			found[id] = r
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryRecords) ScanRecords(ctx context.Context, after string, limit int) ([]crm.Record, error) {
+	ids := make([]string, 0, len(m.records))
+	for id := range m.records {
+		if id > after {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	var page []crm.Record
+	for _, id := range ids[:min(limit, len(ids))] {
+		page = append(page, m.records[id])
+	}
+	return page, nil
+}
+
+type memoryReports struct {
+	reports []*crm.Report
+}
+
+func (m *memoryReports) Save(ctx context.Context, r *crm.Report) error {
+	m.reports = append(m.reports, r)
+	return nil
+}
+
+func (m *memoryReports) FindRecent(ctx context.Context, limit int) ([]*crm.Report, error) {
+	return m.reports, nil
+}
+
+type testEnv struct {
+	service  *Service
+	provider *fakeProvider
+	changes  *memoryChanges
+	records  *memoryRecords
+	reports  *memoryReports
+	clock    *shared.FrozenClock
+	waited   []time.Duration
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := &testEnv{
+		provider: &fakeProvider{contacts: map[string]crm.Contact{}, reject: map[string]error{}},
+		changes:  &memoryChanges{},
+		records:  &memoryRecords{records: map[string]crm.Record{}},
+		reports:  &memoryReports{},
+		clock:    shared.NewFrozenClock(time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC)),
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		env.records.records[id] = crm.Record{AccountID: id, Email: id + "@example.com", AccountStatus: "active", Plan: "premium"}
+	}
+	env.service = NewService(env.provider, crm.DefaultHubSpotMapping(), env.changes, env.records, env.reports, env.clock)
+	env.service.wait = func(ctx context.Context, d time.Duration) error {
+		env.waited = append(env.waited, d)
+		return nil
+	}
+	return env
+}
+
+func (e *testEnv) enqueue(t *testing.T, accountID string, event crm.Event) {
+	t.Helper()
+	if err := e.service.Enqueue(context.Background(), accountID, event); err != nil {
+		t.Fatalf("failed to enqueue: %v", err)
+	}
+}
+
+func TestService_Sync(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.enqueue(t, "a", crm.EventAccountRegistered)
+	env.enqueue(t, "a", crm.EventSubscriptionStarted)
+	env.enqueue(t, "b", crm.EventAccountUpdated)
+	env.enqueue(t, "c", crm.EventSubscriptionChanged)
+	env.enqueue(t, "gone", crm.EventAccountDeleted)
+	env.provider.reject["b"] = errors.New("email is invalid")
+
+	result, err := env.service.Sync(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *result != (SyncResult{Upserted: 2, Deleted: 1, Failed: 1}) {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(env.provider.batches) != 2 || len(env.provider.batches[0]) != 2 {
+		t.Errorf("expected batches of at most two contacts, got %v", env.provider.batches)
+	}
+	if a := env.provider.contacts["a"]; a.Properties[crm.KeyProperty] != "a" || a.Properties["cms_plan"] != "premium" {
+		t.Errorf("expected the mapped record, got %+v", a)
+	}
+	if len(env.provider.deleted) != 1 || env.provider.deleted[0] != "gone" {
+		t.Errorf("expected the deleted account removed, got %v", env.provider.deleted)
+	}
+
+	for _, c := range env.changes.changes {
+		switch c.AccountID {
+		case "b":
+			if c.Status != crm.ChangePending || c.Attempts != 1 || *c.LastError != "email is invalid" {
+				t.Errorf("expected the rejected change to back off, got %+v", c)
+			}
+		default:
+			if c.Status != crm.ChangeSynced {
+				t.Errorf("expected %s synced, got %s", c.AccountID, c.Status)
+			}
+		}
+	}
+
+	// Nothing is due until the backoff passes
+	if result, _ := env.service.Sync(ctx); *result != (SyncResult{}) {
+		t.Errorf("expected nothing to sync, got %+v", result)
+	}
+}
+
+func TestService_SyncRateLimit(t *testing.T) {
+	t.Run("short limits are waited out", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.enqueue(t, "a", crm.EventAccountUpdated)
+		env.provider.limits = []time.Duration{2 * time.Second, 3 * time.Second}
+
+		result, err := env.service.Sync(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Upserted != 1 || len(env.waited) != 2 || env.waited[1] != 3*time.Second {
+			t.Errorf("expected two waits before the upsert, got %+v %v", result, env.waited)
+		}
+	})
+
+	t.Run("long limits defer the rest of the run", func(t *testing.T) {
+		env := newTestEnv(t)
+		for _, id := range []string{"a", "b", "c", "gone"} {
+			env.enqueue(t, id, crm.EventAccountUpdated)
+		}
+		env.provider.limits = []time.Duration{10 * time.Minute}
+
+		result, err := env.service.Sync(context.Background())
+		if !errors.Is(err, crm.ErrRateLimited) {
+			t.Fatalf("expected ErrRateLimited, got %v", err)
+		}
+		if *result != (SyncResult{Deferred: 4}) || len(env.waited) != 0 {
+			t.Errorf("expected every account deferred without waiting, got %+v", result)
+		}
+		for _, c := range env.changes.changes {
+			if c.Attempts != 0 || !c.NextAttemptAt.Equal(env.clock.Now().Add(10*time.Minute)) {
+				t.Errorf("expected %s deferred without an attempt, got %+v", c.AccountID, c)
+			}
+		}
+	})
+}
+
+func TestService_Reconcile(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	mapping := crm.DefaultHubSpotMapping()
+
+	env.provider.contacts["a"] = mapping.Contact(env.records.records["a"])
+	drifted := mapping.Contact(env.records.records["b"])
+	drifted.Properties["cms_plan"] = "basic"
+	drifted.Properties["lifecyclestage"] = "customer"
+	env.provider.contacts["b"] = drifted
+	env.provider.contacts["z"] = crm.Contact{Key: "z", Properties: map[string]string{crm.KeyProperty: "z"}}
+
+	report, err := env.service.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Provider != "fake" || report.Checked != 3 || report.InSync != 1 || report.Queued != 3 || report.Error != nil {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if len(report.Drifted) != 1 || report.Drifted[0].AccountID != "b" || len(report.Drifted[0].Drifts) != 1 || report.Drifted[0].Drifts[0].CRM != "basic" {
+		t.Errorf("expected only b's plan to drift, got %+v", report.Drifted)
+	}
+	if len(report.MissingInCRM) != 1 || report.MissingInCRM[0] != "c" || len(report.UnknownInCRM) != 1 || report.UnknownInCRM[0] != "z" {
+		t.Errorf("unexpected missing %v and unknown %v", report.MissingInCRM, report.UnknownInCRM)
+	}
+	if env.provider.scanPages != 3 || len(env.reports.reports) != 1 {
+		t.Errorf("expected the whole CRM scanned and the report saved, got %d pages", env.provider.scanPages)
+	}
+
+	// The queued repairs make the CMS win
+	if _, err := env.service.Sync(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.provider.contacts["b"].Properties["cms_plan"] != "premium" || env.provider.contacts["c"].Key != "c" {
+		t.Errorf("expected b repaired and c created, got %+v", env.provider.contacts)
+	}
+	if _, ok := env.provider.contacts["z"]; ok {
+		t.Error("expected the unknown contact deleted")
+	}
+}
+
+func TestService_ReconcileFailure(t *testing.T) {
+	env := newTestEnv(t)
+	env.provider.limits = []time.Duration{time.Hour}
+
+	report, err := env.service.Reconcile(context.Background())
+	if !errors.Is(err, crm.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if report.Error == nil || len(env.reports.reports) != 1 {
+		t.Errorf("expected the failed report to be saved, got %+v", report)
+	}
+}
@@ -0,0 +1,241 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/election"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrRaceNotFound   = errors.New("race not found")
+	ErrRegionNotFound = errors.New("region has no results yet")
+	ErrUnknownSource  = errors.New("no results feed adapter with that name")
+)
+
+// CandidateInput is a candidate as submitted by an editor
+type CandidateInput struct {
+	ID    string
+	Name  string
+	Party string
+}
+
+// IngestResult says what one feed poll changed
+type IngestResult struct {
+	Changed  int   // Regions whose tally moved
+	Rejected error // Reports that failed validation, joined; the rest are still applied
+}
+
+type Service struct {
+	races   election.RaceRepository
+	tallies election.TallyRepository
+	sources map[string]election.Source
+}
+
+// NewService takes the feed adapters keyed by the name races refer to in Source
+func NewService(races election.RaceRepository, tallies election.TallyRepository, sources map[string]election.Source) *Service {
+	return &Service{races: races, tallies: tallies, sources: sources}
+}
+
+func (s *Service) CreateRace(ctx context.Context, editorID, name, source, feedRef string, inputs []CandidateInput, at time.Time) (*election.Race, error) {
+	if _, ok := s.sources[source]; !ok {
+		return nil, ErrUnknownSource
+	}
+	candidates := make([]election.Candidate, 0, len(inputs))
+	for _, in := range inputs {
+		candidates = append(candidates, election.Candidate{ID: in.ID, Name: in.Name, Party: in.Party})
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	race, err := election.NewRace(id, name, source, feedRef, candidates, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.races.Create(ctx, race); err != nil {
+		return nil, fmt.Errorf("failed to create race: %w", err)
+	}
+	return race, nil
+}
+
+// CloseRace stops ingestion and overrides once results are certified
+func (s *Service) CloseRace(ctx context.Context, editorID, raceID string, at time.Time) (*election.Race, error) {
+	race, err := s.Race(ctx, raceID)
+	if err != nil {
+		return nil, err
+	}
+	race.Close(editorID, at)
+	if err := s.races.Update(ctx, race); err != nil {
+		return nil, fmt.Errorf("failed to update race: %w", err)
+	}
+	return race, nil
+}
+
+func (s *Service) Race(ctx context.Context, raceID string) (*election.Race, error) {
+	race, err := s.races.FindByID(ctx, raceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load race: %w", err)
+	}
+	if race == nil {
+		return nil, ErrRaceNotFound
+	}
+	return race, nil
+}
+
+func (s *Service) Races(ctx context.Context) ([]*election.Race, error) {
+	races, err := s.races.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list races: %w", err)
+	}
+	return races, nil
+}
+
+// Ingest polls the race's feed and stores the regions that changed. It is
+// run by the scheduler every minute or so on election night.
+func (s *Service) Ingest(ctx context.Context, raceID string, at time.Time) (*IngestResult, error) {
+	race, err := s.Race(ctx, raceID)
+	if err != nil {
+		return nil, err
+	}
+	if race.Closed {
+		return nil, election.ErrRaceClosed
+	}
+	source, ok := s.sources[race.Source]
+	if !ok {
+		return nil, ErrUnknownSource
+	}
+	reports, err := source.Fetch(ctx, race)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch results: %w", err)
+	}
+
+	result := &IngestResult{}
+	var rejected []error
+	for _, report := range reports {
+		if err := race.CheckReport(report); err != nil {
+			rejected = append(rejected, fmt.Errorf("region %q: %w", report.Region, err))
+			continue
+		}
+		region, _ := election.NormalizeRegion(report.Region)
+		tally, err := s.tally(ctx, race.ID, region)
+		if err != nil {
+			return nil, err
+		}
+		if tally == nil {
+			tally = election.NewTally(race.ID, region)
+		}
+		revision := tally.ApplyFeed(report, at)
+		if revision == nil {
+			continue
+		}
+		if err := s.tallies.Save(ctx, tally, revision); err != nil {
+			return nil, fmt.Errorf("failed to save tally: %w", err)
+		}
+		result.Changed++
+	}
+	result.Rejected = errors.Join(rejected...)
+	return result, nil
+}
+
+// Results is the live count for coverage pages. With a non-zero since only
+// regions changed after it are listed, for clients that poll.
+func (s *Service) Results(ctx context.Context, raceID string, since time.Time) (*election.Results, error) {
+	race, err := s.Race(ctx, raceID)
+	if err != nil {
+		return nil, err
+	}
+	tallies, err := s.tallies.FindByRace(ctx, race.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tallies: %w", err)
+	}
+	return election.Summarize(race, tallies, since), nil
+}
+
+// Override corrects a region's votes, e.g. when the feed lags behind the
+// official forms. The reason is kept in the region's history.
+func (s *Service) Override(ctx context.Context, editorID, raceID, region string, votes election.Counts, reason string, at time.Time) (*election.Tally, error) {
+	race, tally, err := s.editable(ctx, raceID, region)
+	if err != nil {
+		return nil, err
+	}
+	if err := race.CheckVotes(votes); err != nil {
+		return nil, err
+	}
+	if tally == nil {
+		tally = election.NewTally(race.ID, region)
+	}
+	revision, err := tally.SetOverride(votes, editorID, reason, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tallies.Save(ctx, tally, revision); err != nil {
+		return nil, fmt.Errorf("failed to save tally: %w", err)
+	}
+	return tally, nil
+}
+
+// ClearOverride shows the feed's numbers again
+func (s *Service) ClearOverride(ctx context.Context, editorID, raceID, region, reason string, at time.Time) (*election.Tally, error) {
+	_, tally, err := s.editable(ctx, raceID, region)
+	if err != nil {
+		return nil, err
+	}
+	if tally == nil {
+		return nil, ErrRegionNotFound
+	}
+	revision, err := tally.ClearOverride(editorID, reason, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tallies.Save(ctx, tally, revision); err != nil {
+		return nil, fmt.Errorf("failed to save tally: %w", err)
+	}
+	return tally, nil
+}
+
+// History returns the region's revisions, newest first
+func (s *Service) History(ctx context.Context, raceID, region string) ([]*election.Revision, error) {
+	if _, err := s.Race(ctx, raceID); err != nil {
+		return nil, err
+	}
+	region, err := election.NormalizeRegion(region)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := s.tallies.FindRevisions(ctx, raceID, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (s *Service) editable(ctx context.Context, raceID, region string) (*election.Race, *election.Tally, error) {
+	race, err := s.Race(ctx, raceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if race.Closed {
+		return nil, nil, election.ErrRaceClosed
+	}
+	region, err = election.NormalizeRegion(region)
+	if err != nil {
+		return nil, nil, err
+	}
+	tally, err := s.tally(ctx, race.ID, region)
+	if err != nil {
+		return nil, nil, err
+	}
+	return race, tally, nil
+}
+
+func (s *Service) tally(ctx context.Context, raceID, region string) (*election.Tally, error) {
+	tally, err := s.tallies.FindByRegion(ctx, raceID, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tally: %w", err)
+	}
+	return tally, nil
+}
@@ -0,0 +1,179 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/election"
+)
+
+type memoryRaces struct {
+	races map[string]*election.Race
+}
+
+func (m *memoryRaces) Create(ctx context.Context, r *election.Race) error {
+	m.races[r.ID] = r
+	return nil
+}
+
+func (m *memoryRaces) Update(ctx context.Context, r *election.Race) error {
+	m.races[r.ID] = r
+	return nil
+}
+
+func (m *memoryRaces) FindByID(ctx context.Context, id string) (*election.Race, error) {
+	return m.races[id], nil
+}
+
+func (m *memoryRaces) FindAll(ctx context.Context) ([]*election.Race, error) {
+	var all []*election.Race
+	for _, r := range m.races {
+		all = append(all, r)
+	}
+	return all, nil
+}
+
+type memoryTallies struct {
+	tallies   map[string]election.Tally
+	revisions []*election.Revision
+}
+
+func (m *memoryTallies) Save(ctx context.Context, t *election.Tally, rev *election.Revision) error {
+	m.tallies[t.RaceID+"/"+t.Region] = *t
+	m.revisions = append([]*election.Revision{rev}, m.revisions...)
+	return nil
+}
+
+func (m *memoryTallies) FindByRegion(ctx context.Context, raceID, region string) (*election.Tally, error) {
+	if t, ok := m.tallies[raceID+"/"+region]; ok {
+		return &t, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryTallies) FindByRace(ctx context.Context, raceID string) ([]*election.Tally, error) {
+	var all []*election.Tally
+	for _, t := range m.tallies {
+		if t.RaceID == raceID {
+			copied := t
+			all = append(all, &copied)
+		}
+	}
+	return all, nil
+}
+
+func (m *memoryTallies) FindRevisions(ctx context.Context, raceID, region string) ([]*election.Revision, error) {
+	var found []*election.Revision
+	for _, r := range m.revisions {
+		if r.RaceID == raceID && r.Region == region {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+type fakeSource struct {
+	reports []election.Report
+	err     error
+}
+
+func (f *fakeSource) Fetch(ctx context.Context, race *election.Race) ([]election.Report, error) {
+	return f.reports, f.err
+}
+
+func createTestService(t *testing.T) (*Service, *fakeSource, *election.Race) {
+	t.Helper()
+	source := &fakeSource{}
+	service := NewService(&memoryRaces{races: map[string]*election.Race{}}, &memoryTallies{tallies: map[string]election.Tally{}}, map[string]election.Source{"json": source})
+	race, err := service.CreateRace(context.Background(), "editor-1", "Pilkada DKI", "json", "dki-2029", []CandidateInput{{ID: "01", Name: "Calon A"}, {ID: "02", Name: "Calon B"}}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return service, source, race
+}
+
+func TestService_CreateRaceUnknownSource(t *testing.T) {
+	service, _, _ := createTestService(t)
+	_, err := service.CreateRace(context.Background(), "editor-1", "Pilpres", "rss", "", []CandidateInput{{ID: "01", Name: "A"}}, time.Now())
+	if err != ErrUnknownSource {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownSource, err)
+	}
+}
+
+func TestService_Ingest(t *testing.T) {
+	service, source, race := createTestService(t)
+	ctx := context.Background()
+	at := time.Date(2029, 2, 14, 15, 0, 0, 0, time.UTC)
+
+	source.reports = []election.Report{
+		{Region: "JAKSEL", Votes: election.Counts{"01": 10, "02": 7}, StationsReported: 3, StationsTotal: 5},
+		{Region: "jakut", Votes: election.Counts{"09": 1}, StationsTotal: 4},
+	}
+	result, err := service.Ingest(ctx, race.ID, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Changed != 1 || !errors.Is(result.Rejected, election.ErrUnknownCandidate) {
+		t.Errorf("expected one region applied and one rejected, got %+v", result)
+	}
+
+	result, _ = service.Ingest(ctx, race.ID, at.Add(time.Minute))
+	if result.Changed != 0 {
+		t.Errorf("expected an unchanged feed to change nothing, got %d", result.Changed)
+	}
+
+	results, err := service.Results(ctx, race.ID, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.Totals["01"] != 10 || len(results.Regions) != 1 || results.Regions[0].Region != "jaksel" || !results.UpdatedAt.Equal(at) {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	source.err = errors.New("feed unavailable")
+	if _, err := service.Ingest(ctx, race.ID, at); err == nil {
+		t.Error("expected feed error")
+	}
+}
+
+func TestService_Override(t *testing.T) {
+	service, source, race := createTestService(t)
+	ctx := context.Background()
+	at := time.Date(2029, 2, 14, 15, 0, 0, 0, time.UTC)
+	source.reports = []election.Report{{Region: "jaksel", Votes: election.Counts{"01": 10, "02": 7}, StationsReported: 3, StationsTotal: 5}}
+	_, _ = service.Ingest(ctx, race.ID, at)
+
+	if _, err := service.Override(ctx, "editor-1", race.ID, "jaksel", election.Counts{"03": 1}, "Koreksi", at); err != election.ErrUnknownCandidate {
+		t.Errorf("expected error '%v', got '%v'", election.ErrUnknownCandidate, err)
+	}
+	tally, err := service.Override(ctx, "editor-1", race.ID, "jaksel", election.Counts{"01": 12, "02": 7}, "Koreksi C1 TPS 004", at.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tally.Votes()["01"] != 12 || tally.Version != 2 {
+		t.Errorf("expected overridden tally at version 2, got %+v", tally)
+	}
+
+	if _, err := service.ClearOverride(ctx, "editor-1", race.ID, "jaksel", "Feed terkoreksi", at.Add(2*time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	history, err := service.History(ctx, race.ID, "jaksel")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(history) != 3 || history[0].Origin != election.OriginOverrideCleared || history[1].Reason != "Koreksi C1 TPS 004" {
+		t.Errorf("expected audited history newest first, got %+v", history)
+	}
+
+	if _, err := service.CloseRace(ctx, "editor-1", race.ID, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Override(ctx, "editor-1", race.ID, "jaksel", election.Counts{"01": 1}, "late", at); err != election.ErrRaceClosed {
+		t.Errorf("expected error '%v', got '%v'", election.ErrRaceClosed, err)
+	}
+	if _, err := service.Ingest(ctx, race.ID, at); err != election.ErrRaceClosed {
+		t.Errorf("expected error '%v', got '%v'", election.ErrRaceClosed, err)
+	}
+}
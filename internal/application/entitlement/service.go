@@ -0,0 +1,155 @@
+package entitlement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+// SubjectTTL bounds how stale a cached role, status or subscription change can be.
+// The account, sanction, appeal and subscription services call Invalidate after
+// a change so the next request sees it. Roles are assigned outside the app and
+// show up once the TTL runs out.
+const SubjectTTL = time.Minute
+
+// Service is the single place access decisions are made: account status,
+// roles, subscription state, single-article purchases, institution licences, the metered paywall and sanctions
+type Service struct {
+	accounts      account.UserAccountRepository
+	roles         entitlement.RoleRepository
+	subscriptions subscription.SubscriptionRepository
+	meter         entitlement.MeterRepository
+	purchases     entitlement.PurchaseRepository
+	cache         entitlement.SubjectCache
+	freeArticles  int
+}
+
+func NewService(accounts account.UserAccountRepository, roles entitlement.RoleRepository, subscriptions subscription.SubscriptionRepository, meter entitlement.MeterRepository, purchases entitlement.PurchaseRepository, cache entitlement.SubjectCache) *Service {
+	return &Service{
+		accounts:      accounts,
+		roles:         roles,
+		subscriptions: subscriptions,
+		meter:         meter,
+		purchases:     purchases,
+		cache:         cache,
+		freeArticles:  entitlement.FreeArticlesPerMonth,
+	}
+}
+
+// CanReadArticle decides access to the full article, accountID is empty for guests.
+// A read allowed by the meter uses up one of the month's free articles.
+func (s *Service) CanReadArticle(ctx context.Context, accountID string, a entitlement.Article, at time.Time) (entitlement.Decision, error) {
+	return s.CanReadArticleVia(ctx, accountID, "", a, at)
+}
+
+// CanReadArticleVia is CanReadArticle for a request that came from a licensed
+// institution's range or proxy, institutionID is empty when it did not
+func (s *Service) CanReadArticleVia(ctx context.Context, accountID, institutionID string, a entitlement.Article, at time.Time) (entitlement.Decision, error) {
+	subject, err := s.subject(ctx, accountID)
+	if err != nil {
+		return entitlement.Decision{}, err
+	}
+	if institutionID != "" {
+		// The cached subject is shared, the institution belongs to this request only
+		via := *subject
+		via.Institution = institutionID
+		subject = &via
+	}
+
+	var usage entitlement.MeterUsage
+	if subject.Account != nil && subject.Institution == "" && a.Holdout == "" && a.Tier == entitlement.TierMetered {
+		usage.Limit = s.freeArticles
+		usage.Used, usage.AlreadyRead, err = s.meter.FindUsage(ctx, accountID, a.ID, monthStart(at))
+		if err != nil {
+			return entitlement.Decision{}, fmt.Errorf("failed to load meter usage: %w", err)
+		}
+	}
+
+	// Subscribers never need the lookup, their access does not depend on it
+	purchased := false
+	if subject.Account != nil && a.Tier != entitlement.TierFree && a.Holdout == "" && !subject.Premium {
+		purchased, err = s.purchases.HasPurchased(ctx, accountID, a.ID)
+		if err != nil {
+			return entitlement.Decision{}, fmt.Errorf("failed to load purchases: %w", err)
+		}
+	}
+
+	decision := subject.ReadArticle(a, usage, purchased, at)
+	if decision.Allowed && decision.Reason == entitlement.ReasonMeter && !usage.AlreadyRead {
+		if err := s.meter.RecordRead(ctx, accountID, a.ID, at); err != nil {
+			return entitlement.Decision{}, fmt.Errorf("failed to record metered read: %w", err)
+		}
+	}
+	return decision, nil
+}
+
+func (s *Service) CanComment(ctx context.Context, accountID string, a entitlement.Article, at time.Time) (entitlement.Decision, error) {
+	subject, err := s.subject(ctx, accountID)
+	if err != nil {
+		return entitlement.Decision{}, err
+	}
+	return subject.Comment(a, at), nil
+}
+
+func (s *Service) CanUseAPI(ctx context.Context, accountID string, scope entitlement.Scope, at time.Time) (entitlement.Decision, error) {
+	subject, err := s.subject(ctx, accountID)
+	if err != nil {
+		return entitlement.Decision{}, err
+	}
+	return subject.UseAPI(scope, at), nil
+}
+
+// Invalidate drops the cached subject after a role, status or subscription change
+func (s *Service) Invalidate(ctx context.Context, accountID string) error {
+	if err := s.cache.Invalidate(ctx, accountID); err != nil {
+		return fmt.Errorf("failed to invalidate entitlements: %w", err)
+	}
+	return nil
+}
+
+// subject loads who is asking, through the cache. An unknown account is treated as a guest.
+func (s *Service) subject(ctx context.Context, accountID string) (*entitlement.Subject, error) {
+	if accountID == "" {
+		return &entitlement.Subject{}, nil
+	}
+	if cached, err := s.cache.Get(ctx, accountID); err == nil && cached != nil {
+		return cached, nil
+	}
+
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil {
+		return &entitlement.Subject{}, nil
+	}
+	roles, err := s.roles.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load roles: %w", err)
+	}
+	subs, err := s.subscriptions.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscriptions: %w", err)
+	}
+
+	subject := &entitlement.Subject{AccountID: accountID, Account: acc, Roles: roles}
+	for _, sub := range subs {
+		if sub.HasPremiumAccess() {
+			subject.Premium = true
+			break
+		}
+	}
+
+	// A cache outage only costs latency, the decision is still correct
+	_ = s.cache.Set(ctx, subject, SubjectTTL)
+	return subject, nil
+}
+
+func monthStart(at time.Time) time.Time {
+	at = at.UTC()
+	return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
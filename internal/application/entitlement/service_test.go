@@ -0,0 +1,280 @@
+package entitlement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+	lookups  int
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	f.lookups++
+	return f.accounts[id], nil
+}
+
+type fakeRoles struct {
+	roles map[string][]entitlement.Role
+}
+
+func (f *fakeRoles) FindByAccountID(ctx context.Context, accountID string) ([]entitlement.Role, error) {
+	return f.roles[accountID], nil
+}
+
+type fakeSubscriptions struct {
+	subscription.SubscriptionRepository
+	subscriptions []*subscription.Subscription
+}
+
+func (f *fakeSubscriptions) FindByAccountID(ctx context.Context, accountID string) ([]*subscription.Subscription, error) {
+	var found []*subscription.Subscription
+	for _, s := range f.subscriptions {
+		if s.AccountID == accountID {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+type memoryMeter struct {
+	reads map[string][]string // account ID -> article IDs read this month
+}
+
+func (m *memoryMeter) FindUsage(ctx context.Context, accountID, articleID string, since time.Time) (int, bool, error) {
+	for _, id := range m.reads[accountID] {
+		if id == articleID {
+			return len(m.reads[accountID]), true, nil
+		}
+	}
+	return len(m.reads[accountID]), false, nil
+}
+
+func (m *memoryMeter) RecordRead(ctx context.Context, accountID, articleID string, at time.Time) error {
+	m.reads[accountID] = append(m.reads[accountID], articleID)
+	return nil
+}
+
+type memoryPurchases struct {
+	owned map[string]bool // account ID + article ID
+}
+
+func (m *memoryPurchases) HasPurchased(ctx context.Context, accountID, articleID string) (bool, error) {
+	return m.owned[accountID+"/"+articleID], nil
+}
+
+type memoryCache struct {
+	subjects map[string]*entitlement.Subject
+	err      error
+}
+
+func (m *memoryCache) Get(ctx context.Context, accountID string) (*entitlement.Subject, error) {
+	return m.subjects[accountID], m.err
+}
+
+func (m *memoryCache) Set(ctx context.Context, subject *entitlement.Subject, ttl time.Duration) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.subjects[subject.AccountID] = subject
+	return nil
+}
+
+func (m *memoryCache) Invalidate(ctx context.Context, accountID string) error {
+	delete(m.subjects, accountID)
+	return m.err
+}
+
+type testService struct {
+	*Service
+	accounts      *fakeAccountRepo
+	subscriptions *fakeSubscriptions
+	meter         *memoryMeter
+	purchases     *memoryPurchases
+	cache         *memoryCache
+}
+
+func createTestService(t *testing.T) *testService {
+	t.Helper()
+	acc, err := account.NewUserAccountForTesting("member-1", "member1", "member1@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+	partner, _ := entitlement.NewRole("partner", []entitlement.Scope{"api:search"})
+
+	ts := &testService{
+		accounts:      &fakeAccountRepo{accounts: map[string]*account.UserAccount{"member-1": acc}},
+		subscriptions: &fakeSubscriptions{},
+		meter:         &memoryMeter{reads: map[string][]string{}},
+		purchases:     &memoryPurchases{owned: map[string]bool{}},
+		cache:         &memoryCache{subjects: map[string]*entitlement.Subject{}},
+	}
+	ts.Service = NewService(ts.accounts, &fakeRoles{roles: map[string][]entitlement.Role{"member-1": {*partner}}}, ts.subscriptions, ts.meter, ts.purchases, ts.cache)
+	return ts
+}
+
+func metered(id string) entitlement.Article {
+	return entitlement.Article{ID: id, Tier: entitlement.TierMetered, Comments: article.DefaultCommentSettings()}
+}
+
+func TestService_CanReadArticle_Meter(t *testing.T) {
+	s := createTestService(t)
+	ctx := context.Background()
+	now := time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < entitlement.FreeArticlesPerMonth; i++ {
+		d, err := s.CanReadArticle(ctx, "member-1", metered(string(rune('a'+i))), now)
+		if err != nil || !d.Allowed {
+			t.Fatalf("expected free article %d to be allowed, got %s, %v", i+1, d, err)
+		}
+	}
+
+	d, _ := s.CanReadArticle(ctx, "member-1", metered("z"), now)
+	if d.Allowed || d.Reason != entitlement.ReasonMeterExhausted {
+		t.Errorf("expected meter exhausted, got %s", d)
+	}
+	d, _ = s.CanReadArticle(ctx, "member-1", metered("a"), now)
+	if !d.Allowed {
+		t.Errorf("expected reread to be allowed, got %s", d)
+	}
+	if len(s.meter.reads["member-1"]) != entitlement.FreeArticlesPerMonth {
+		t.Errorf("expected %d recorded reads, got %d", entitlement.FreeArticlesPerMonth, len(s.meter.reads["member-1"]))
+	}
+
+	d, _ = s.CanReadArticle(ctx, "", metered("a"), now)
+	if d.Reason != entitlement.ReasonSignInRequired {
+		t.Errorf("expected guest to be asked to sign in, got %s", d)
+	}
+}
+
+func TestService_CanReadArticle_Subscription(t *testing.T) {
+	s := createTestService(t)
+	ctx := context.Background()
+	premium := entitlement.Article{ID: "p", Tier: entitlement.TierPremium}
+
+	d, _ := s.CanReadArticle(ctx, "member-1", premium, time.Now())
+	if d.Allowed {
+		t.Fatalf("expected premium article to be denied without subscription, got %s", d)
+	}
+
+	price, _ := payment.NewMoney(4900, "USD")
+	sub, _ := subscription.NewSubscription("sub-1", "member-1", "premium", subscription.IntervalMonthly, *price, time.Now().AddDate(0, 1, 0))
+	s.subscriptions.subscriptions = append(s.subscriptions.subscriptions, sub)
+
+	d, _ = s.CanReadArticle(ctx, "member-1", premium, time.Now())
+	if d.Allowed {
+		t.Errorf("expected cached subject to still be without subscription, got %s", d)
+	}
+
+	if err := s.Invalidate(ctx, "member-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d, _ = s.CanReadArticle(ctx, "member-1", premium, time.Now())
+	if !d.Allowed || d.Reason != entitlement.ReasonSubscription {
+		t.Errorf("expected subscription access after invalidation, got %s", d)
+	}
+}
+
+func TestService_CanReadArticle_Purchase(t *testing.T) {
+	s := createTestService(t)
+	ctx := context.Background()
+	premium := entitlement.Article{ID: "p", Tier: entitlement.TierPremium}
+
+	d, _ := s.CanReadArticle(ctx, "member-1", premium, time.Now())
+	if d.Allowed {
+		t.Fatalf("expected premium article to be denied before the purchase, got %s", d)
+	}
+
+	// Not cached: the purchase unlocks the article without an invalidation
+	s.purchases.owned["member-1/p"] = true
+	d, _ = s.CanReadArticle(ctx, "member-1", premium, time.Now())
+	if !d.Allowed || d.Reason != entitlement.ReasonPurchase {
+		t.Errorf("expected purchase access, got %s", d)
+	}
+	d, _ = s.CanReadArticle(ctx, "member-1", entitlement.Article{ID: "other", Tier: entitlement.TierPremium}, time.Now())
+	if d.Allowed {
+		t.Errorf("expected other premium articles to stay locked, got %s", d)
+	}
+}
+
+func TestService_CanReadArticleVia(t *testing.T) {
+	s := createTestService(t)
+	ctx := context.Background()
+	premium := entitlement.Article{ID: "p", Tier: entitlement.TierPremium}
+
+	d, _ := s.CanReadArticleVia(ctx, "", "inst-1", premium, time.Now())
+	if !d.Allowed || d.Reason != entitlement.ReasonInstitution {
+		t.Errorf("expected a guest on campus to read, got %s", d)
+	}
+
+	d, _ = s.CanReadArticleVia(ctx, "member-1", "inst-1", metered("a"), time.Now())
+	if !d.Allowed || d.Reason != entitlement.ReasonInstitution || len(s.meter.reads["member-1"]) != 0 {
+		t.Errorf("expected institutional access without using the meter, got %s", d)
+	}
+
+	// The institution must not stick to the cached subject
+	d, _ = s.CanReadArticle(ctx, "member-1", premium, time.Now())
+	if d.Allowed {
+		t.Errorf("expected the member off campus to be denied, got %s", d)
+	}
+}
+
+func TestService_CanUseAPI(t *testing.T) {
+	s := createTestService(t)
+	ctx := context.Background()
+
+	testCases := []struct {
+		name            string
+		accountID       string
+		scope           entitlement.Scope
+		expectedAllowed bool
+		expectedReason  entitlement.Reason
+	}{
+		{"granted scope", "member-1", "api:search", true, entitlement.ReasonRole},
+		{"missing scope", "member-1", "api:export", false, entitlement.ReasonScopeMissing},
+		{"unknown account", "ghost", "api:search", false, entitlement.ReasonSignInRequired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := s.CanUseAPI(ctx, tc.accountID, tc.scope, time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.Allowed != tc.expectedAllowed || d.Reason != tc.expectedReason {
+				t.Errorf("expected allowed=%v (%s), got %s", tc.expectedAllowed, tc.expectedReason, d)
+			}
+		})
+	}
+}
+
+func TestService_CacheOutage(t *testing.T) {
+	s := createTestService(t)
+	s.cache.err = errors.New("connection refused")
+
+	for i := 0; i < 2; i++ {
+		d, err := s.CanComment(context.Background(), "member-1", entitlement.Article{ID: "a", Tier: entitlement.TierFree}, time.Now())
+		if err != nil {
+			t.Fatalf("expected cache outage to be tolerated, got %v", err)
+		}
+		if d.Reason != entitlement.ReasonCommentsClosed {
+			t.Errorf("expected article without comment settings to be closed for comments, got %s", d)
+		}
+	}
+	if s.accounts.lookups != 2 {
+		t.Errorf("expected every request to hit the store during the outage, got %d lookups", s.accounts.lookups)
+	}
+}
@@ -0,0 +1,382 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/entity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrEntityNotFound    = errors.New("entity not found")
+	ErrSlugTaken         = errors.New("another entity already uses this slug")
+	ErrAmbiguityNotFound = errors.New("ambiguous mention not found")
+)
+
+// Paths builds canonical article paths, the permalink router implements it
+type Paths interface {
+	Path(ref permalink.ArticleRef) (string, error)
+}
+
+// ExtractResult summarizes one extraction run
+type ExtractResult struct {
+	Linked    int
+	Ambiguous int // Waiting for an editor to pick the right namesake
+	Unknown   int // Names no entity claims
+}
+
+// Entry is an article on an entity page
+type Entry struct {
+	Summary entity.ArticleSummary
+	Path    string
+}
+
+// Page is an entity page: everything the newsroom published about it
+type Page struct {
+	Entity   *entity.Entity
+	Articles []Entry
+	Total    int64
+}
+
+type Service struct {
+	entities    entity.EntityRepository
+	links       entity.LinkRepository
+	ambiguities entity.AmbiguityRepository
+	extractor   entity.Extractor
+	articles    entity.ArticleSource
+	paths       Paths
+}
+
+func NewService(entities entity.EntityRepository, links entity.LinkRepository, ambiguities entity.AmbiguityRepository, extractor entity.Extractor, articles entity.ArticleSource, paths Paths) *Service {
+	return &Service{entities: entities, links: links, ambiguities: ambiguities, extractor: extractor, articles: articles, paths: paths}
+}
+
+// Extract runs the NLP provider over an article and replaces its extracted links.
+// A name matching one entity is linked, a name shared by namesakes is queued for
+// editors unless an editor already linked one of them to this article.
+func (s *Service) Extract(ctx context.Context, articleID string, at time.Time) (*ExtractResult, error) {
+	text, err := s.articles.FindText(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article text: %w", err)
+	}
+	mentions, err := s.extractor.Extract(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract entities: %w", err)
+	}
+	existing, err := s.links.FindByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article links: %w", err)
+	}
+	edited := map[string]bool{}
+	for _, link := range existing {
+		if link.Source == entity.SourceEditor {
+			edited[link.EntityID] = true
+		}
+	}
+
+	type mentionKey struct {
+		kind  entity.Kind
+		alias string
+	}
+	counts := map[mentionKey]int{}
+	var order []mentionKey
+	for _, m := range mentions {
+		key := mentionKey{kind: m.Kind, alias: entity.NormalizeAlias(m.Surface)}
+		if counts[key] == 0 {
+			order = append(order, key)
+		}
+		counts[key]++
+	}
+
+	result := &ExtractResult{}
+	linked := map[string]int{}
+	var ambiguities []entity.Ambiguity
+	for _, key := range order {
+		candidates, err := s.entities.FindByAlias(ctx, key.kind, key.alias)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve mention: %w", err)
+		}
+		switch {
+		case len(candidates) == 0:
+			result.Unknown++
+		case len(candidates) == 1:
+			linked[candidates[0].ID] += counts[key]
+		default:
+			if chosen := editedCandidate(candidates, edited); chosen != "" {
+				linked[chosen] += counts[key]
+				continue
+			}
+			ids := make([]string, len(candidates))
+			for i, c := range candidates {
+				ids[i] = c.ID
+			}
+			ambiguities = append(ambiguities, entity.Ambiguity{ArticleID: articleID, Surface: key.alias, Kind: key.kind, CandidateIDs: ids, Mentions: counts[key], CreatedAt: at})
+		}
+	}
+
+	links := make([]entity.Link, 0, len(linked))
+	for id, n := range linked {
+		if edited[id] {
+			continue
+		}
+		links = append(links, entity.Link{ArticleID: articleID, EntityID: id, Mentions: n, Source: entity.SourceExtracted, CreatedAt: at})
+	}
+	if err := s.links.ReplaceExtracted(ctx, articleID, links); err != nil {
+		return nil, fmt.Errorf("failed to save entity links: %w", err)
+	}
+	if err := s.ambiguities.ReplaceForArticle(ctx, articleID, ambiguities); err != nil {
+		return nil, fmt.Errorf("failed to save ambiguous mentions: %w", err)
+	}
+
+	result.Linked = len(linked)
+	result.Ambiguous = len(ambiguities)
+	return result, nil
+}
+
+// Keys returns the keys of the entities an article covers, e.g. "org:kpk", which topic rules match on
+func (s *Service) Keys(ctx context.Context, articleID string) ([]string, error) {
+	links, err := s.links.FindByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article links: %w", err)
+	}
+	keys := make([]string, 0, len(links))
+	for _, link := range links {
+		e, err := s.entities.FindByID(ctx, link.EntityID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load entity: %w", err)
+		}
+		if e != nil && !e.IsMerged() {
+			keys = append(keys, e.Key())
+		}
+	}
+	return keys, nil
+}
+
+// Page loads an entity page, a merged entity's slug resolves to the survivor
+func (s *Service) Page(ctx context.Context, slug string, limit, offset int) (*Page, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	found, err := s.entities.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entity: %w", err)
+	}
+	if found == nil {
+		return nil, ErrEntityNotFound
+	}
+	if found.IsMerged() {
+		if found, err = s.find(ctx, *found.MergedInto); err != nil {
+			return nil, err
+		}
+	}
+
+	ids, err := s.links.FindArticleIDs(ctx, found.ID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load coverage: %w", err)
+	}
+	total, err := s.links.CountArticles(ctx, found.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count coverage: %w", err)
+	}
+	summaries, err := s.articles.FindPublished(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load articles: %w", err)
+	}
+
+	page := &Page{Entity: found, Total: total}
+	for _, id := range ids {
+		summary, ok := summaries[id]
+		if !ok {
+			continue
+		}
+		path, err := s.paths.Path(summary.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build path for %s: %w", id, err)
+		}
+		page.Articles = append(page.Articles, Entry{Summary: summary, Path: path})
+	}
+	return page, nil
+}
+
+func (s *Service) Create(ctx context.Context, staffID, kind, slug, name, description string, aliases []string) (*entity.Entity, error) {
+	kindValue, err := entity.ParseKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	slugObj, err := entity.NewSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.entities.FindBySlug(ctx, slugObj.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slug: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrSlugTaken
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := entity.NewEntity(id, kindValue, *slugObj, name, description, aliases, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.entities.Create(ctx, created); err != nil {
+		return nil, fmt.Errorf("failed to save entity: %w", err)
+	}
+	return created, nil
+}
+
+// Update edits the name, disambiguation note and aliases. Existing links are
+// not touched, re-run extraction on affected articles to pick up new aliases.
+func (s *Service) Update(ctx context.Context, staffID, id, name, description string, aliases []string) (*entity.Entity, error) {
+	existing, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := existing.UpdateDetails(name, description, aliases, staffID); err != nil {
+		return nil, err
+	}
+	if err := s.entities.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to save entity: %w", err)
+	}
+	return existing, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*entity.Entity, error) {
+	return s.find(ctx, id)
+}
+
+func (s *Service) Search(ctx context.Context, query string, limit int) ([]*entity.Entity, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	found, err := s.entities.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search entities: %w", err)
+	}
+	return found, nil
+}
+
+// Merge folds a duplicate into the surviving entity and moves its coverage there
+func (s *Service) Merge(ctx context.Context, staffID, sourceID, targetID string) (*entity.Entity, error) {
+	source, err := s.find(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.find(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.IsMerged() {
+		return nil, entity.ErrAlreadyMerged
+	}
+	if err := source.MergeInto(target.ID, staffID); err != nil {
+		return nil, err
+	}
+
+	if err := s.links.MoveAll(ctx, source.ID, target.ID); err != nil {
+		return nil, fmt.Errorf("failed to move entity links: %w", err)
+	}
+	if err := s.entities.Update(ctx, source); err != nil {
+		return nil, fmt.Errorf("failed to save entity: %w", err)
+	}
+	return target, nil
+}
+
+// Ambiguities is the editor queue of names matching several namesakes
+func (s *Service) Ambiguities(ctx context.Context, limit, offset int) ([]entity.Ambiguity, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	found, total, err := s.ambiguities.FindPending(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load ambiguous mentions: %w", err)
+	}
+	return found, total, nil
+}
+
+// Resolve links the article to the namesake the editor picked. The editor may
+// pick an entity outside the candidates, e.g. one created for the occasion.
+func (s *Service) Resolve(ctx context.Context, articleID, surface, entityID string, at time.Time) error {
+	pending, err := s.ambiguity(ctx, articleID, surface)
+	if err != nil {
+		return err
+	}
+	chosen, err := s.find(ctx, entityID)
+	if err != nil {
+		return err
+	}
+	if chosen.IsMerged() {
+		return entity.ErrAlreadyMerged
+	}
+	if chosen.Kind != pending.Kind {
+		return entity.ErrNotCandidate
+	}
+
+	if err := s.links.Save(ctx, entity.Link{ArticleID: articleID, EntityID: chosen.ID, Mentions: pending.Mentions, Source: entity.SourceEditor, CreatedAt: at}); err != nil {
+		return fmt.Errorf("failed to save entity link: %w", err)
+	}
+	if err := s.ambiguities.Delete(ctx, articleID, pending.Surface); err != nil {
+		return fmt.Errorf("failed to clear ambiguous mention: %w", err)
+	}
+	return nil
+}
+
+// Dismiss drops a mention that refers to none of the candidates
+func (s *Service) Dismiss(ctx context.Context, articleID, surface string) error {
+	pending, err := s.ambiguity(ctx, articleID, surface)
+	if err != nil {
+		return err
+	}
+	if err := s.ambiguities.Delete(ctx, articleID, pending.Surface); err != nil {
+		return fmt.Errorf("failed to clear ambiguous mention: %w", err)
+	}
+	return nil
+}
+
+// Unlink removes a wrong link from an article, extracted or not
+func (s *Service) Unlink(ctx context.Context, articleID, entityID string) error {
+	if err := s.links.Delete(ctx, articleID, entityID); err != nil {
+		return fmt.Errorf("failed to delete entity link: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) ambiguity(ctx context.Context, articleID, surface string) (*entity.Ambiguity, error) {
+	found, err := s.ambiguities.Find(ctx, articleID, entity.NormalizeAlias(surface))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ambiguous mention: %w", err)
+	}
+	if found == nil {
+		return nil, ErrAmbiguityNotFound
+	}
+	return found, nil
+}
+
+func (s *Service) find(ctx context.Context, id string) (*entity.Entity, error) {
+	found, err := s.entities.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entity: %w", err)
+	}
+	if found == nil {
+		return nil, ErrEntityNotFound
+	}
+	return found, nil
+}
+
+// editedCandidate returns the namesake an editor already linked to the article, if any
+func editedCandidate(candidates []*entity.Entity, edited map[string]bool) string {
+	for _, c := range candidates {
+		if edited[c.ID] {
+			return c.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,353 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/entity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+type memoryEntities struct {
+	entities map[string]*entity.Entity
+}
+
+func (m *memoryEntities) Create(ctx context.Context, e *entity.Entity) error {
+	m.entities[e.ID] = e
+	return nil
+}
+
+func (m *memoryEntities) Update(ctx context.Context, e *entity.Entity) error {
+	m.entities[e.ID] = e
+	return nil
+}
+
+func (m *memoryEntities) FindByID(ctx context.Context, id string) (*entity.Entity, error) {
+	return m.entities[id], nil
+}
+
+func (m *memoryEntities) FindBySlug(ctx context.Context, slug string) (*entity.Entity, error) {
+	for _, e := range m.entities {
+		if e.Slug.Value() == slug {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryEntities) FindByAlias(ctx context.Context, kind entity.Kind, alias string) ([]*entity.Entity, error) {
+	var found []*entity.Entity
+	for _, e := range m.entities {
+		if e.IsMerged() || e.Kind != kind {
+			continue
+		}
+		for _, name := range e.Names() {
+			if name == alias {
+				found = append(found, e)
+				break
+			}
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+	return found, nil
+}
+
+func (m *memoryEntities) FindAll(ctx context.Context) ([]*entity.Entity, error) {
+	var all []*entity.Entity
+	for _, e := range m.entities {
+		if !e.IsMerged() {
+			all = append(all, e)
+		}
+	}
+	return all, nil
+}
+
+func (m *memoryEntities) Search(ctx context.Context, query string, limit int) ([]*entity.Entity, error) {
+	var found []*entity.Entity
+	for _, e := range m.entities {
+		if strings.Contains(strings.ToLower(e.Name), strings.ToLower(query)) {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+type memoryLinks struct {
+	links []entity.Link
+}
+
+func (m *memoryLinks) ReplaceExtracted(ctx context.Context, articleID string, links []entity.Link) error {
+	kept := m.links[:0]
+	for _, l := range m.links {
+		if l.ArticleID != articleID || l.Source == entity.SourceEditor {
+			kept = append(kept, l)
+		}
+	}
+	m.links = append(kept, links...)
+	return nil
+}
+
+func (m *memoryLinks) Save(ctx context.Context, link entity.Link) error {
+	_ = m.Delete(ctx, link.ArticleID, link.EntityID)
+	m.links = append(m.links, link)
+	return nil
+}
+
+func (m *memoryLinks) Delete(ctx context.Context, articleID, entityID string) error {
+	kept := m.links[:0]
+	for _, l := range m.links {
+		if l.ArticleID != articleID || l.EntityID != entityID {
+			kept = append(kept, l)
+		}
+	}
+	m.links = kept
+	return nil
+}
+
+func (m *memoryLinks) MoveAll(ctx context.Context, fromEntityID, toEntityID string) error {
+	for i := range m.links {
+		if m.links[i].EntityID == fromEntityID {
+			m.links[i].EntityID = toEntityID
+		}
+	}
+	return nil
+}
+
+func (m *memoryLinks) FindByArticle(ctx context.Context, articleID string) ([]entity.Link, error) {
+	var found []entity.Link
+	for _, l := range m.links {
+		if l.ArticleID == articleID {
+			found = append(found, l)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryLinks) FindArticleIDs(ctx context.Context, entityID string, limit, offset int) ([]string, error) {
+	var ids []string
+	for _, l := range m.links {
+		if l.EntityID == entityID {
+			ids = append(ids, l.ArticleID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (m *memoryLinks) CountArticles(ctx context.Context, entityID string) (int64, error) {
+	ids, _ := m.FindArticleIDs(ctx, entityID, 0, 0)
+	return int64(len(ids)), nil
+}
+
+type memoryAmbiguities struct {
+	pending []entity.Ambiguity
+}
+
+func (m *memoryAmbiguities) ReplaceForArticle(ctx context.Context, articleID string, ambiguities []entity.Ambiguity) error {
+	kept := m.pending[:0]
+	for _, a := range m.pending {
+		if a.ArticleID != articleID {
+			kept = append(kept, a)
+		}
+	}
+	m.pending = append(kept, ambiguities...)
+	return nil
+}
+
+func (m *memoryAmbiguities) Delete(ctx context.Context, articleID, surface string) error {
+	kept := m.pending[:0]
+	for _, a := range m.pending {
+		if a.ArticleID != articleID || a.Surface != surface {
+			kept = append(kept, a)
+		}
+	}
+	m.pending = kept
+	return nil
+}
+
+func (m *memoryAmbiguities) Find(ctx context.Context, articleID, surface string) (*entity.Ambiguity, error) {
+	for _, a := range m.pending {
+		if a.ArticleID == articleID && a.Surface == surface {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAmbiguities) FindPending(ctx context.Context, limit, offset int) ([]entity.Ambiguity, int64, error) {
+	return m.pending, int64(len(m.pending)), nil
+}
+
+type fakeExtractor struct {
+	mentions []entity.Mention
+}
+
+func (f *fakeExtractor) Extract(ctx context.Context, text string) ([]entity.Mention, error) {
+	return f.mentions, nil
+}
+
+type fakeArticles struct {
+	published map[string]entity.ArticleSummary
+}
+
+func (f *fakeArticles) FindText(ctx context.Context, articleID string) (string, error) {
+	return "text of " + articleID, nil
+}
+
+func (f *fakeArticles) FindPublished(ctx context.Context, articleIDs []string) (map[string]entity.ArticleSummary, error) {
+	found := map[string]entity.ArticleSummary{}
+	for _, id := range articleIDs {
+		if summary, ok := f.published[id]; ok {
+			found[id] = summary
+		}
+	}
+	return found, nil
+}
+
+type fakePaths struct{}
+
+func (fakePaths) Path(ref permalink.ArticleRef) (string, error) {
+	return "/" + ref.CategorySlug + "/" + ref.Slug, nil
+}
+
+type testFixture struct {
+	service     *Service
+	links       *memoryLinks
+	ambiguities *memoryAmbiguities
+	extractor   *fakeExtractor
+	minister    *entity.Entity
+	footballer  *entity.Entity
+	kpk         *entity.Entity
+}
+
+func createTestService(t *testing.T) *testFixture {
+	t.Helper()
+	f := &testFixture{links: &memoryLinks{}, ambiguities: &memoryAmbiguities{}, extractor: &fakeExtractor{}}
+	articles := &fakeArticles{published: map[string]entity.ArticleSummary{
+		"a1": {Ref: permalink.ArticleRef{ID: "a1", CategorySlug: "politics", Slug: "budget"}, Title: "Budget"},
+	}}
+	f.service = NewService(&memoryEntities{entities: map[string]*entity.Entity{}}, f.links, f.ambiguities, f.extractor, articles, fakePaths{})
+
+	ctx := context.Background()
+	var err error
+	if f.minister, err = f.service.Create(ctx, "editor-1", "person", "budi-santoso-health", "Budi Santoso", "Minister of Health", nil); err != nil {
+		t.Fatalf("failed to create entity: %v", err)
+	}
+	f.footballer, _ = f.service.Create(ctx, "editor-1", "person", "budi-santoso-persib", "Budi Santoso", "Persib midfielder", nil)
+	f.kpk, _ = f.service.Create(ctx, "editor-1", "org", "kpk", "Corruption Eradication Commission", "", []string{"KPK"})
+	return f
+}
+
+func TestService_Create(t *testing.T) {
+	f := createTestService(t)
+
+	if _, err := f.service.Create(context.Background(), "editor-1", "org", "kpk", "KPK", "", nil); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected error '%v', got '%v'", ErrSlugTaken, err)
+	}
+	if _, err := f.service.Create(context.Background(), "editor-1", "company", "goto", "GoTo", "", nil); !errors.Is(err, entity.ErrInvalidKind) {
+		t.Errorf("expected error '%v', got '%v'", entity.ErrInvalidKind, err)
+	}
+}
+
+func TestService_Extract(t *testing.T) {
+	f := createTestService(t)
+	ctx := context.Background()
+	f.extractor.mentions = []entity.Mention{
+		{Surface: "KPK", Kind: entity.KindOrganization},
+		{Surface: "Budi Santoso", Kind: entity.KindPerson},
+		{Surface: "kpk", Kind: entity.KindOrganization},
+		{Surface: "Jakarta", Kind: entity.KindPlace},
+	}
+
+	result, err := f.service.Extract(ctx, "a1", time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if result.Linked != 1 || result.Ambiguous != 1 || result.Unknown != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(f.links.links) != 1 || f.links.links[0].EntityID != f.kpk.ID || f.links.links[0].Mentions != 2 {
+		t.Errorf("expected KPK linked with 2 mentions, got %+v", f.links.links)
+	}
+	if len(f.ambiguities.pending) != 1 || len(f.ambiguities.pending[0].CandidateIDs) != 2 {
+		t.Fatalf("expected Budi Santoso queued with 2 candidates, got %+v", f.ambiguities.pending)
+	}
+
+	// The editor picks the minister, re-extraction keeps that choice
+	if err := f.service.Resolve(ctx, "a1", "Budi Santoso", f.minister.ID, time.Now()); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	result, _ = f.service.Extract(ctx, "a1", time.Now())
+	if result.Ambiguous != 0 || len(f.ambiguities.pending) != 0 {
+		t.Errorf("expected the editor's choice to settle the mention, got %+v", result)
+	}
+	keys, _ := f.service.Keys(ctx, "a1")
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "org:kpk" || keys[1] != "person:budi-santoso-health" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	f := createTestService(t)
+	ctx := context.Background()
+	f.extractor.mentions = []entity.Mention{{Surface: "Budi Santoso", Kind: entity.KindPerson}}
+	_, _ = f.service.Extract(ctx, "a1", time.Now())
+
+	testCases := []struct {
+		name        string
+		surface     string
+		entityID    string
+		expectedErr error
+	}{
+		{"unknown mention", "Budi", f.minister.ID, ErrAmbiguityNotFound},
+		{"unknown entity", "Budi Santoso", "missing", ErrEntityNotFound},
+		{"wrong kind", "budi santoso", f.kpk.ID, entity.ErrNotCandidate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := f.service.Resolve(ctx, "a1", tc.surface, tc.entityID, time.Now())
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+
+	if err := f.service.Dismiss(ctx, "a1", "Budi Santoso"); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(f.ambiguities.pending) != 0 {
+		t.Errorf("expected the queue empty after dismissing, got %+v", f.ambiguities.pending)
+	}
+}
+
+func TestService_Merge(t *testing.T) {
+	f := createTestService(t)
+	ctx := context.Background()
+	duplicate, _ := f.service.Create(ctx, "editor-1", "org", "komisi-pemberantasan-korupsi", "Komisi Pemberantasan Korupsi", "", nil)
+	_ = f.links.Save(ctx, entity.Link{ArticleID: "a1", EntityID: duplicate.ID, Source: entity.SourceExtracted})
+
+	if _, err := f.service.Merge(ctx, "editor-2", duplicate.ID, f.kpk.ID); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+
+	page, err := f.service.Page(ctx, "komisi-pemberantasan-korupsi", 20, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if page.Entity.ID != f.kpk.ID || page.Total != 1 || len(page.Articles) != 1 || page.Articles[0].Path != "/politics/budget" {
+		t.Errorf("expected merged coverage on the KPK page, got %+v", page)
+	}
+
+	if _, err := f.service.Merge(ctx, "editor-2", f.kpk.ID, duplicate.ID); !errors.Is(err, entity.ErrAlreadyMerged) {
+		t.Errorf("expected error '%v', got '%v'", entity.ErrAlreadyMerged, err)
+	}
+	if _, err := f.service.Page(ctx, "missing", 20, 0); !errors.Is(err, ErrEntityNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrEntityNotFound, err)
+	}
+}
@@ -0,0 +1,229 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/experiment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+var ErrExperimentNotFound = shared.NewDomainError("experiment.not_found", shared.KindNotFound, "experiment not found")
+
+// Assignment is the experiment and group a visitor was placed in
+type Assignment struct {
+	ExperimentID string
+	Group        experiment.Group
+}
+
+// Service runs paywall holdout experiments. The reading path calls Apply
+// before asking the entitlement service, the subscription flow calls
+// RecordConversion and a scheduled job calls EndDue.
+type Service struct {
+	experiments experiment.ExperimentRepository
+	exposures   experiment.ExposureRepository
+	conversions experiment.ConversionRepository
+	clock       shared.Clock
+}
+
+func NewService(experiments experiment.ExperimentRepository, exposures experiment.ExposureRepository, conversions experiment.ConversionRepository, clock shared.Clock) *Service {
+	return &Service{experiments: experiments, exposures: exposures, conversions: conversions, clock: clock}
+}
+
+func (s *Service) Create(ctx context.Context, staffID, name, hypothesis string, targets experiment.Targets, holdoutPercent int, endsAt time.Time) (*experiment.Experiment, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	e, err := experiment.NewExperiment(id, name, hypothesis, targets, holdoutPercent, endsAt, staffID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.experiments.Create(ctx, e); err != nil {
+		return nil, fmt.Errorf("failed to save experiment: %w", err)
+	}
+	return e, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*experiment.Experiment, error) {
+	e, err := s.experiments.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load experiment: %w", err)
+	}
+	if e == nil {
+		return nil, ErrExperimentNotFound
+	}
+	return e, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]*experiment.Experiment, error) {
+	all, err := s.experiments.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load experiments: %w", err)
+	}
+	return all, nil
+}
+
+// Start refuses an experiment sharing an article or category with a running
+// one, a reader can only be in one holdout per article
+func (s *Service) Start(ctx context.Context, id string) (*experiment.Experiment, error) {
+	e, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	running, err := s.experiments.FindRunning(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load running experiments: %w", err)
+	}
+	for _, other := range running {
+		if other.ID != e.ID && other.Targets.Overlaps(e.Targets) {
+			return nil, fmt.Errorf("%w: %s", experiment.ErrTargetTaken, other.Name)
+		}
+	}
+	if err := e.Start(s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.experiments.Update(ctx, e); err != nil {
+		return nil, fmt.Errorf("failed to save experiment: %w", err)
+	}
+	return e, nil
+}
+
+// Stop ends an experiment before its end date
+func (s *Service) Stop(ctx context.Context, id string) (*experiment.Experiment, error) {
+	e, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.End(experiment.EndStopped, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.experiments.Update(ctx, e); err != nil {
+		return nil, fmt.Errorf("failed to save experiment: %w", err)
+	}
+	return e, nil
+}
+
+// EndDue is the scheduled job ending experiments past their end date, it
+// returns how many it ended
+func (s *Service) EndDue(ctx context.Context) (int, error) {
+	running, err := s.experiments.FindRunning(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load running experiments: %w", err)
+	}
+	now := s.clock.Now()
+	ended := 0
+	var errs []error
+	for _, e := range running {
+		if !e.DueToEnd(now) {
+			continue
+		}
+		if err := e.End(experiment.EndScheduled, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.experiments.Update(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save experiment %s: %w", e.ID, err))
+			continue
+		}
+		ended++
+	}
+	return ended, errors.Join(errs...)
+}
+
+// Assign places the visitor in the active experiment targeting the article
+// and records the exposure, nil when no experiment targets it. Visitors
+// without a visitor ID, e.g. with cookies blocked, are never assigned.
+func (s *Service) Assign(ctx context.Context, visitorID, accountID string, target experiment.Target) (*Assignment, error) {
+	if visitorID == "" {
+		return nil, nil
+	}
+	running, err := s.experiments.FindRunning(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load running experiments: %w", err)
+	}
+	now := s.clock.Now()
+	for _, e := range running {
+		if !e.Active(now) || !e.Targets.Matches(target) {
+			continue
+		}
+		group := e.Assign(visitorID)
+		exposure := experiment.Exposure{
+			ExperimentID: e.ID,
+			VisitorID:    visitorID,
+			AccountID:    accountID,
+			Group:        group,
+			ArticleID:    target.ArticleID,
+			At:           now,
+		}
+		if err := s.exposures.Record(ctx, exposure); err != nil {
+			return nil, fmt.Errorf("failed to record exposure: %w", err)
+		}
+		return &Assignment{ExperimentID: e.ID, Group: group}, nil
+	}
+	return nil, nil
+}
+
+// Apply assigns the visitor and marks the article free when they are held out,
+// the entitlement service then allows the read with ReasonHoldout
+func (s *Service) Apply(ctx context.Context, visitorID, accountID string, target experiment.Target, access *entitlement.Article) error {
+	if access.Tier == entitlement.TierFree {
+		return nil
+	}
+	assignment, err := s.Assign(ctx, visitorID, accountID, target)
+	if err != nil {
+		return err
+	}
+	if assignment != nil && assignment.Group == experiment.GroupHoldout {
+		access.Holdout = assignment.ExperimentID
+	}
+	return nil
+}
+
+// RecordConversion credits a new subscription to every experiment the reader
+// was exposed to within the ConversionWindow, in the group they saw. The
+// visitor ID links exposures from before the reader signed up.
+func (s *Service) RecordConversion(ctx context.Context, visitorID, accountID, subscriptionID string) (int, error) {
+	now := s.clock.Now()
+	exposures, err := s.exposures.FindForReader(ctx, visitorID, accountID, now.Add(-experiment.ConversionWindow))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load exposures: %w", err)
+	}
+	credited := make(map[string]bool)
+	for _, x := range exposures {
+		if credited[x.ExperimentID] {
+			continue
+		}
+		conversion, ok := x.Convert(subscriptionID, accountID, now)
+		if !ok {
+			continue
+		}
+		if err := s.conversions.Create(ctx, conversion); err != nil {
+			return len(credited), fmt.Errorf("failed to record conversion: %w", err)
+		}
+		credited[x.ExperimentID] = true
+	}
+	return len(credited), nil
+}
+
+func (s *Service) Results(ctx context.Context, id string) (*experiment.Results, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	visitors, err := s.exposures.CountVisitors(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count exposures: %w", err)
+	}
+	conversions, err := s.conversions.CountConversions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count conversions: %w", err)
+	}
+	return &experiment.Results{
+		ExperimentID: id,
+		Control:      experiment.GroupResult{Group: experiment.GroupControl, Visitors: visitors[experiment.GroupControl], Conversions: conversions[experiment.GroupControl]},
+		Holdout:      experiment.GroupResult{Group: experiment.GroupHoldout, Visitors: visitors[experiment.GroupHoldout], Conversions: conversions[experiment.GroupHoldout]},
+	}, nil
+}
@@ -0,0 +1,219 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/experiment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+type memoryExperiments struct {
+	byID map[string]*experiment.Experiment
+}
+
+func (m *memoryExperiments) Create(ctx context.Context, e *experiment.Experiment) error {
+	m.byID[e.ID] = e
+	return nil
+}
+
+func (m *memoryExperiments) Update(ctx context.Context, e *experiment.Experiment) error {
+	m.byID[e.ID] = e
+	return nil
+}
+
+func (m *memoryExperiments) FindByID(ctx context.Context, id string) (*experiment.Experiment, error) {
+	return m.byID[id], nil
+}
+
+func (m *memoryExperiments) FindAll(ctx context.Context) ([]*experiment.Experiment, error) {
+	var all []*experiment.Experiment
+	for _, e := range m.byID {
+		all = append(all, e)
+	}
+	return all, nil
+}
+
+func (m *memoryExperiments) FindRunning(ctx context.Context) ([]*experiment.Experiment, error) {
+	var running []*experiment.Experiment
+	for _, e := range m.byID {
+		if e.Status == experiment.StatusRunning {
+			running = append(running, e)
+		}
+	}
+	return running, nil
+}
+
+type memoryExposures struct {
+	exposures []experiment.Exposure
+}
+
+func (m *memoryExposures) Record(ctx context.Context, x experiment.Exposure) error {
+	for i, existing := range m.exposures {
+		if existing.ExperimentID == x.ExperimentID && existing.VisitorID == x.VisitorID {
+			if existing.AccountID == "" {
+				m.exposures[i].AccountID = x.AccountID
+			}
+			return nil
+		}
+	}
+	m.exposures = append(m.exposures, x)
+	return nil
+}
+
+func (m *memoryExposures) FindForReader(ctx context.Context, visitorID, accountID string, since time.Time) ([]experiment.Exposure, error) {
+	var found []experiment.Exposure
+	for _, x := range m.exposures {
+		if (x.VisitorID == visitorID || (accountID != "" && x.AccountID == accountID)) && !x.At.Before(since) {
+			found = append(found, x)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryExposures) CountVisitors(ctx context.Context, experimentID string) (map[experiment.Group]int, error) {
+	counts := map[experiment.Group]int{}
+	for _, x := range m.exposures {
+		if x.ExperimentID == experimentID {
+			counts[x.Group]++
+		}
+	}
+	return counts, nil
+}
+
+type memoryConversions struct {
+	conversions []experiment.Conversion
+}
+
+func (m *memoryConversions) Create(ctx context.Context, c experiment.Conversion) error {
+	m.conversions = append(m.conversions, c)
+	return nil
+}
+
+func (m *memoryConversions) CountConversions(ctx context.Context, experimentID string) (map[experiment.Group]int, error) {
+	counts := map[experiment.Group]int{}
+	for _, c := range m.conversions {
+		if c.ExperimentID == experimentID {
+			counts[c.Group]++
+		}
+	}
+	return counts, nil
+}
+
+var testNow = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+func newTestService() (*Service, *memoryExperiments, *memoryExposures, *memoryConversions, *shared.FrozenClock) {
+	experiments := &memoryExperiments{byID: map[string]*experiment.Experiment{}}
+	exposures := &memoryExposures{}
+	conversions := &memoryConversions{}
+	clock := shared.NewFrozenClock(testNow)
+	return NewService(experiments, exposures, conversions, clock), experiments, exposures, conversions, clock
+}
+
+// visitorIn finds a visitor the experiment puts in the group
+func visitorIn(e *experiment.Experiment, group experiment.Group) string {
+	for i := 0; ; i++ {
+		if v := fmt.Sprintf("visitor-%d", i); e.Assign(v) == group {
+			return v
+		}
+	}
+}
+
+func TestService_StartRefusesOverlap(t *testing.T) {
+	service, _, _, _, _ := newTestService()
+	ctx := context.Background()
+	opinion, _ := experiment.NewTargets(nil, []string{"opini"})
+
+	first, _ := service.Create(ctx, "pm-1", "Free opinion", "", opinion, 10, testNow.AddDate(0, 0, 14))
+	if _, err := service.Start(ctx, first.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := service.Create(ctx, "pm-1", "Free opinion, larger", "", opinion, 30, testNow.AddDate(0, 0, 14))
+	if _, err := service.Start(ctx, second.ID); !errors.Is(err, experiment.ErrTargetTaken) {
+		t.Errorf("expected ErrTargetTaken, got %v", err)
+	}
+	if _, err := service.Start(ctx, "missing"); err != ErrExperimentNotFound {
+		t.Errorf("expected ErrExperimentNotFound, got %v", err)
+	}
+}
+
+func TestService_ApplyAndConvert(t *testing.T) {
+	service, _, exposures, _, clock := newTestService()
+	ctx := context.Background()
+	opinion, _ := experiment.NewTargets(nil, []string{"opini"})
+	e, _ := service.Create(ctx, "pm-1", "Free opinion", "", opinion, 10, testNow.AddDate(0, 0, 14))
+	_, _ = service.Start(ctx, e.ID)
+
+	held, control := visitorIn(e, experiment.GroupHoldout), visitorIn(e, experiment.GroupControl)
+	column := experiment.Target{ArticleID: "a1", CategoryIDs: []string{"opini"}}
+
+	heldAccess := entitlement.Article{ID: "a1", Tier: entitlement.TierPremium}
+	if err := service.Apply(ctx, held, "", column, &heldAccess); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if heldAccess.Holdout != e.ID {
+		t.Errorf("expected the held out guest to read for free, got %+v", heldAccess)
+	}
+	controlAccess := entitlement.Article{ID: "a1", Tier: entitlement.TierPremium}
+	_ = service.Apply(ctx, control, "member-2", column, &controlAccess)
+	if controlAccess.Holdout != "" {
+		t.Error("expected the control visitor to meet the paywall")
+	}
+	other := entitlement.Article{ID: "a9", Tier: entitlement.TierPremium}
+	_ = service.Apply(ctx, held, "", experiment.Target{ArticleID: "a9", CategoryIDs: []string{"nasional"}}, &other)
+	if other.Holdout != "" || len(exposures.exposures) != 2 {
+		t.Errorf("expected untargeted articles left alone, got %d exposures", len(exposures.exposures))
+	}
+
+	// The guest signs up and subscribes a week later, the visitor ID links them
+	clock.Set(testNow.AddDate(0, 0, 7))
+	if n, err := service.RecordConversion(ctx, held, "member-1", "sub-1"); err != nil || n != 1 {
+		t.Fatalf("expected one conversion, got %d, %v", n, err)
+	}
+	if n, _ := service.RecordConversion(ctx, "new-device", "member-2", "sub-2"); n != 1 {
+		t.Errorf("expected the control member matched by account, got %d", n)
+	}
+
+	results, err := service.Results(ctx, e.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.Holdout.Visitors != 1 || results.Holdout.Conversions != 1 || results.Control.Conversions != 1 {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	// Past the end date nobody is held out, the job then ends the experiment
+	clock.Set(e.EndsAt)
+	late := entitlement.Article{ID: "a1", Tier: entitlement.TierPremium}
+	_ = service.Apply(ctx, held, "", column, &late)
+	if late.Holdout != "" {
+		t.Error("expected the holdout to stop at the end date")
+	}
+	if n, err := service.EndDue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected one experiment ended, got %d, %v", n, err)
+	}
+	ended, _ := service.Get(ctx, e.ID)
+	if ended.Status != experiment.StatusEnded || *ended.EndReason != experiment.EndScheduled {
+		t.Errorf("expected a scheduled end, got %+v", ended)
+	}
+}
+
+func TestService_ApplySkips(t *testing.T) {
+	service, _, exposures, _, _ := newTestService()
+	ctx := context.Background()
+	targets, _ := experiment.NewTargets([]string{"a1"}, nil)
+	e, _ := service.Create(ctx, "pm-1", "Free a1", "", targets, 50, testNow.AddDate(0, 0, 14))
+	_, _ = service.Start(ctx, e.ID)
+
+	free := entitlement.Article{ID: "a1", Tier: entitlement.TierFree}
+	_ = service.Apply(ctx, "visitor-1", "", experiment.Target{ArticleID: "a1"}, &free)
+	anonymous := entitlement.Article{ID: "a1", Tier: entitlement.TierMetered}
+	_ = service.Apply(ctx, "", "", experiment.Target{ArticleID: "a1"}, &anonymous)
+	if len(exposures.exposures) != 0 || anonymous.Holdout != "" {
+		t.Error("expected free articles and visitors without an ID left out of the experiment")
+	}
+}
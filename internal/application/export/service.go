@@ -0,0 +1,135 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/export"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+var (
+	ErrArticleNotFound  = errors.New("article not found")
+	ErrExportNotAllowed = errors.New("article cannot be read in full without a subscription")
+	ErrFormatNotEnabled = errors.New("export format is not enabled")
+)
+
+// Access decides who may read an article in full, the entitlement service implements it
+type Access interface {
+	CanReadArticle(ctx context.Context, accountID string, a entitlement.Article, at time.Time) (entitlement.Decision, error)
+}
+
+type Service struct {
+	articles  export.ArticleSource
+	cache     export.DocumentCache
+	images    export.ImageOptimizer
+	access    Access
+	renderers map[export.Format]export.Renderer
+}
+
+func NewService(articles export.ArticleSource, cache export.DocumentCache, images export.ImageOptimizer, access Access, renderers map[export.Format]export.Renderer) *Service {
+	return &Service{articles: articles, cache: cache, images: images, access: access, renderers: renderers}
+}
+
+// Download exports an article for a reader, guests pass an empty account ID.
+// The reader must be able to read the article in full, so premium and
+// exhausted metered articles are refused.
+func (s *Service) Download(ctx context.Context, accountID, articleID string, format export.Format, at time.Time) (*export.Document, error) {
+	a, err := s.find(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	decision, err := s.access.CanReadArticle(ctx, accountID, a.Access, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check access: %w", err)
+	}
+	if !decision.Allowed {
+		return nil, ErrExportNotAllowed
+	}
+	return s.render(ctx, a, format, at)
+}
+
+// Archive exports an article for the newsroom archive without an access check
+func (s *Service) Archive(ctx context.Context, articleID string, format export.Format, at time.Time) (*export.Document, error) {
+	a, err := s.find(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	return s.render(ctx, a, format, at)
+}
+
+func (s *Service) find(ctx context.Context, articleID string) (*export.Article, error) {
+	a, err := s.articles.FindForExport(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article: %w", err)
+	}
+	if a == nil {
+		return nil, ErrArticleNotFound
+	}
+	return a, nil
+}
+
+// render serves the cached rendering of the current revision or produces it
+func (s *Service) render(ctx context.Context, a *export.Article, format export.Format, at time.Time) (*export.Document, error) {
+	renderer, ok := s.renderers[format]
+	if !ok {
+		return nil, ErrFormatNotEnabled
+	}
+	cached, err := s.cache.Get(ctx, export.CacheKey(a.ID, a.Revision, format))
+	if err == nil && cached != nil {
+		return cached, nil
+	}
+
+	source := *a
+	if format == export.FormatEPUB {
+		source = s.bundleImages(ctx, source)
+	}
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, &source); err != nil {
+		return nil, fmt.Errorf("failed to render %s export: %w", format, err)
+	}
+	doc, err := export.NewDocument(a, format, buf.Bytes(), at)
+	if err != nil {
+		return nil, err
+	}
+	// A failed cache write only costs the next reader a re-render
+	_ = s.cache.Set(ctx, doc, export.CacheTTL)
+	return doc, nil
+}
+
+// bundleImages embeds renditions in the book. An image the optimizer cannot
+// produce keeps its online URL and simply needs a connection.
+func (s *Service) bundleImages(ctx context.Context, a export.Article) export.Article {
+	bundled := make([]export.Image, 0, len(a.Images))
+	for i, img := range a.Images {
+		data, contentType, err := s.images.Optimize(ctx, img.SourceURL, export.ImageMaxWidth)
+		if err != nil {
+			continue
+		}
+		img.Name = fmt.Sprintf("%s-%d%s", a.ID, i, extension(contentType))
+		img.ContentType = contentType
+		img.Data = data
+		a.Body = strings.ReplaceAll(a.Body, `"`+img.SourceURL+`"`, `"images/`+img.Name+`"`)
+		bundled = append(bundled, img)
+	}
+	a.Images = bundled
+	return a
+}
+
+func extension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	}
+	return ""
+}
@@ -0,0 +1,158 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/export"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+type fakeArticles struct {
+	articles map[string]*export.Article
+}
+
+func (f *fakeArticles) FindForExport(ctx context.Context, articleID string) (*export.Article, error) {
+	return f.articles[articleID], nil
+}
+
+type memoryCache struct {
+	docs map[string]*export.Document
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string) (*export.Document, error) {
+	return m.docs[key], nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, doc *export.Document, ttl time.Duration) error {
+	m.docs[doc.Key()] = doc
+	return nil
+}
+
+type fakeImages struct{}
+
+func (fakeImages) Optimize(ctx context.Context, sourceURL string, maxWidth int) ([]byte, string, error) {
+	if strings.Contains(sourceURL, "broken") {
+		return nil, "", errors.New("unsupported image")
+	}
+	return []byte("jpeg bytes"), "image/jpeg", nil
+}
+
+// fakeAccess lets everyone read everything but premium articles
+type fakeAccess struct{}
+
+func (fakeAccess) CanReadArticle(ctx context.Context, accountID string, a entitlement.Article, at time.Time) (entitlement.Decision, error) {
+	if a.Tier == entitlement.TierPremium {
+		return entitlement.Decision{Allowed: false, Reason: entitlement.ReasonPremiumRequired}, nil
+	}
+	return entitlement.Decision{Allowed: true, Reason: entitlement.ReasonFreeArticle}, nil
+}
+
+// countingRenderer records what it was asked to render
+type countingRenderer struct {
+	calls int
+	last  export.Article
+}
+
+func (r *countingRenderer) Render(w io.Writer, a *export.Article) error {
+	r.calls++
+	r.last = *a
+	_, err := fmt.Fprintf(w, "%s@%s", a.ID, a.Revision)
+	return err
+}
+
+func createTestService(t *testing.T) (*Service, *fakeArticles, map[export.Format]*countingRenderer) {
+	t.Helper()
+	articles := &fakeArticles{articles: map[string]*export.Article{
+		"a1": {
+			ID: "a1", Revision: "r1", Slug: "banjir-jakarta", Title: "Banjir",
+			Body:   `<p>Text</p><img src="https://cdn.example.com/a1.jpg"><img src="https://cdn.example.com/broken.jpg">`,
+			Images: []export.Image{{SourceURL: "https://cdn.example.com/a1.jpg"}, {SourceURL: "https://cdn.example.com/broken.jpg"}},
+			Access: entitlement.Article{ID: "a1", Tier: entitlement.TierFree},
+		},
+		"p1": {ID: "p1", Revision: "r1", Access: entitlement.Article{ID: "p1", Tier: entitlement.TierPremium}},
+	}}
+	renderers := map[export.Format]*countingRenderer{export.FormatEPUB: {}, export.FormatText: {}}
+	registered := map[export.Format]export.Renderer{}
+	for f, r := range renderers {
+		registered[f] = r
+	}
+	service := NewService(articles, &memoryCache{docs: map[string]*export.Document{}}, fakeImages{}, fakeAccess{}, registered)
+	return service, articles, renderers
+}
+
+func TestService_Download(t *testing.T) {
+	at := time.Date(2025, time.June, 3, 6, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		articleID   string
+		format      export.Format
+		expectedErr error
+	}{
+		{"text", "a1", export.FormatText, nil},
+		{"epub", "a1", export.FormatEPUB, nil},
+		{"format not enabled", "a1", export.FormatHTML, ErrFormatNotEnabled},
+		{"premium", "p1", export.FormatText, ErrExportNotAllowed},
+		{"missing article", "zz", export.FormatText, ErrArticleNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, _, _ := createTestService(t)
+			doc, err := service.Download(context.Background(), "", tc.articleID, tc.format, at)
+			if err != tc.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err == nil && (doc.Filename != "banjir-jakarta"+tc.format.Extension() || string(doc.Data) != "a1@r1") {
+				t.Errorf("unexpected document %s %q", doc.Filename, doc.Data)
+			}
+		})
+	}
+}
+
+func TestService_Archive(t *testing.T) {
+	service, _, _ := createTestService(t)
+	if _, err := service.Archive(context.Background(), "p1", export.FormatText, time.Now()); err != nil {
+		t.Errorf("expected archival export without an access check, got %v", err)
+	}
+}
+
+func TestService_Caching(t *testing.T) {
+	service, articles, renderers := createTestService(t)
+	ctx := context.Background()
+
+	_, _ = service.Download(ctx, "", "a1", export.FormatText, time.Now())
+	_, _ = service.Archive(ctx, "a1", export.FormatText, time.Now())
+	if renderers[export.FormatText].calls != 1 {
+		t.Errorf("expected one render for the same revision, got %d", renderers[export.FormatText].calls)
+	}
+
+	articles.articles["a1"].Revision = "r2"
+	doc, _ := service.Download(ctx, "", "a1", export.FormatText, time.Now())
+	if renderers[export.FormatText].calls != 2 || string(doc.Data) != "a1@r2" {
+		t.Errorf("expected a new revision to render again, got %d calls and %q", renderers[export.FormatText].calls, doc.Data)
+	}
+}
+
+func TestService_EPUBBundlesImages(t *testing.T) {
+	service, articles, renderers := createTestService(t)
+	if _, err := service.Download(context.Background(), "", "a1", export.FormatEPUB, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rendered := renderers[export.FormatEPUB].last
+	if len(rendered.Images) != 1 || rendered.Images[0].Name != "a1-0.jpg" {
+		t.Fatalf("expected one bundled image, got %+v", rendered.Images)
+	}
+	if !strings.Contains(rendered.Body, `src="images/a1-0.jpg"`) || !strings.Contains(rendered.Body, "https://cdn.example.com/broken.jpg") {
+		t.Errorf("expected bundled image rewritten and broken one left online, got %s", rendered.Body)
+	}
+	if strings.Contains(articles.articles["a1"].Body, "images/") {
+		t.Errorf("expected the source article untouched")
+	}
+}
@@ -0,0 +1,321 @@
+package freshness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/freshness"
+)
+
+// RealertAfter is how often a stale rail alert repeats while the rail stays stale
+const RealertAfter = 3 * time.Hour
+
+var (
+	ErrTargetNotFound = errors.New("desk target not found")
+	ErrRailNotFound   = errors.New("rail not found")
+	ErrRailExists     = errors.New("a rail with this slug already exists")
+)
+
+// ProducerAlerts notifies the producers of a desk, wired to the on-call alert
+// channel with the desk as the routing key
+type ProducerAlerts interface {
+	// AlertStaleRail fires when the rail's newest article is older than its max age, latest is nil for an empty rail
+	AlertStaleRail(ctx context.Context, rail *freshness.Rail, latest *time.Time, at time.Time) error
+	// AlertRailRecovered fires once a rail that was alerted is fresh again, usually after new content
+	AlertRailRecovered(ctx context.Context, rail *freshness.Rail, latest *time.Time, at time.Time) error
+	// AlertMissedTarget fires once for a finished day the desk published less than its target on
+	AlertMissedTarget(ctx context.Context, day freshness.DeskDay) error
+}
+
+// CheckResult summarizes one run of the monitoring job
+type CheckResult struct {
+	Stale         int // Stale alerts sent, repeats included
+	Recovered     int
+	MissedTargets int
+	Failed        int
+}
+
+// RailStatus is one row of the freshness report
+type RailStatus struct {
+	Rail     *freshness.Rail
+	LatestAt *time.Time
+	StaleAt  time.Time
+	Stale    bool
+}
+
+type Service struct {
+	targets   freshness.DeskTargetRepository
+	rails     freshness.RailRepository
+	publishes freshness.PublishLog
+	alerts    ProducerAlerts
+	rules     freshness.Rules
+}
+
+func NewService(targets freshness.DeskTargetRepository, rails freshness.RailRepository, publishes freshness.PublishLog, alerts ProducerAlerts, rules freshness.Rules) *Service {
+	return &Service{targets: targets, rails: rails, publishes: publishes, alerts: alerts, rules: rules}
+}
+
+// SetTarget creates or changes a desk's daily publishing target
+func (s *Service) SetTarget(ctx context.Context, staffID, desk string, dailyTarget int, at time.Time) (*freshness.DeskTarget, error) {
+	target, err := s.targets.FindByDesk(ctx, desk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load desk target: %w", err)
+	}
+
+	if target == nil {
+		target, err = freshness.NewDeskTarget(desk, dailyTarget, staffID, at)
+	} else {
+		err = target.SetTarget(dailyTarget, staffID, at)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.targets.Save(ctx, target); err != nil {
+		return nil, fmt.Errorf("failed to save desk target: %w", err)
+	}
+	return target, nil
+}
+
+func (s *Service) RemoveTarget(ctx context.Context, desk string) error {
+	target, err := s.targets.FindByDesk(ctx, desk)
+	if err != nil {
+		return fmt.Errorf("failed to load desk target: %w", err)
+	}
+	if target == nil {
+		return ErrTargetNotFound
+	}
+	if err := s.targets.Delete(ctx, desk); err != nil {
+		return fmt.Errorf("failed to delete desk target: %w", err)
+	}
+	return nil
+}
+
+// Velocity compares each desk's publishes on a newsroom day with its target.
+// An empty date is today. Desks that published without a target are listed
+// with a zero target, desks below pace come first.
+func (s *Service) Velocity(ctx context.Context, date string, at time.Time) ([]freshness.DeskDay, error) {
+	day := s.rules.DayOf(at)
+	if date != "" {
+		var err error
+		if day, err = s.rules.ParseDay(date); err != nil {
+			return nil, err
+		}
+	}
+
+	targets, err := s.targets.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load desk targets: %w", err)
+	}
+	days, err := s.deskDays(ctx, day, targets)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(days, func(i, j int) bool {
+		pi, pj := days[i].OnPace(at), days[j].OnPace(at)
+		if pi != pj {
+			return !pi
+		}
+		return days[i].Desk < days[j].Desk
+	})
+	return days, nil
+}
+
+func (s *Service) CreateRail(ctx context.Context, slug, name, desk string, categoryIDs []string, maxAge time.Duration, at time.Time) (*freshness.Rail, error) {
+	rail, err := freshness.NewRail(slug, name, desk, categoryIDs, maxAge, at)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.rails.FindBySlug(ctx, rail.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rail: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrRailExists
+	}
+
+	if err := s.rails.Create(ctx, rail); err != nil {
+		return nil, fmt.Errorf("failed to save rail: %w", err)
+	}
+	return rail, nil
+}
+
+func (s *Service) UpdateRail(ctx context.Context, slug, name, desk string, categoryIDs []string, maxAge time.Duration, at time.Time) (*freshness.Rail, error) {
+	rail, err := s.findRail(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := rail.Update(name, desk, categoryIDs, maxAge, at); err != nil {
+		return nil, err
+	}
+	if err := s.rails.Update(ctx, rail); err != nil {
+		return nil, fmt.Errorf("failed to save rail: %w", err)
+	}
+	return rail, nil
+}
+
+func (s *Service) DeleteRail(ctx context.Context, slug string) error {
+	if _, err := s.findRail(ctx, slug); err != nil {
+		return err
+	}
+	if err := s.rails.Delete(ctx, slug); err != nil {
+		return fmt.Errorf("failed to delete rail: %w", err)
+	}
+	return nil
+}
+
+// Freshness lists every rail with its newest article, stale ones first
+func (s *Service) Freshness(ctx context.Context, at time.Time) ([]RailStatus, error) {
+	rails, err := s.rails.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rails: %w", err)
+	}
+
+	statuses := make([]RailStatus, 0, len(rails))
+	for _, rail := range rails {
+		latest, err := s.publishes.LatestInCategories(ctx, rail.CategoryIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load newest article of %s: %w", rail.Slug, err)
+		}
+		statuses = append(statuses, RailStatus{Rail: rail, LatestAt: latest, StaleAt: rail.StaleAt(latest), Stale: rail.IsStale(latest, at)})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Stale != statuses[j].Stale {
+			return statuses[i].Stale
+		}
+		return statuses[i].Rail.Slug < statuses[j].Rail.Slug
+	})
+	return statuses, nil
+}
+
+// Check alerts producers about stale rails and about desks that missed
+// yesterday's target. Stale rails are held during quiet hours, recoveries are
+// always announced. Failed alerts are retried on the next run.
+func (s *Service) Check(ctx context.Context, at time.Time) (*CheckResult, error) {
+	result := &CheckResult{}
+	var errs []error
+	if err := s.checkRails(ctx, at, result); err != nil {
+		errs = append(errs, err)
+	}
+	if err := s.checkTargets(ctx, at, result); err != nil {
+		errs = append(errs, err)
+	}
+	return result, errors.Join(errs...)
+}
+
+func (s *Service) checkRails(ctx context.Context, at time.Time, result *CheckResult) error {
+	rails, err := s.rails.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load rails: %w", err)
+	}
+
+	quiet := s.rules.Quiet(at)
+	var errs []error
+	for _, rail := range rails {
+		latest, err := s.publishes.LatestInCategories(ctx, rail.CategoryIDs)
+		if err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("failed to load newest article of %s: %w", rail.Slug, err))
+			continue
+		}
+
+		switch {
+		case !rail.IsStale(latest, at) && rail.AlertedAt != nil:
+			if err := s.alerts.AlertRailRecovered(ctx, rail, latest, at); err != nil {
+				result.Failed++
+				errs = append(errs, fmt.Errorf("failed to alert on %s: %w", rail.Slug, err))
+				continue
+			}
+			rail.RecordFresh()
+			result.Recovered++
+		case !quiet && rail.NeedsAlert(latest, at, RealertAfter):
+			if err := s.alerts.AlertStaleRail(ctx, rail, latest, at); err != nil {
+				result.Failed++
+				errs = append(errs, fmt.Errorf("failed to alert on %s: %w", rail.Slug, err))
+				continue
+			}
+			rail.MarkAlerted(at)
+			result.Stale++
+		default:
+			continue
+		}
+
+		if err := s.rails.Update(ctx, rail); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save rail %s: %w", rail.Slug, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Service) checkTargets(ctx context.Context, at time.Time, result *CheckResult) error {
+	targets, err := s.targets.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load desk targets: %w", err)
+	}
+	yesterday := s.rules.DayOf(at).AddDate(0, 0, -1)
+	days, err := s.deskDays(ctx, yesterday, targets)
+	if err != nil {
+		return err
+	}
+	byDesk := make(map[string]*freshness.DeskTarget, len(targets))
+	for _, t := range targets {
+		byDesk[t.Desk] = t
+	}
+
+	var errs []error
+	for _, day := range days {
+		target := byDesk[day.Desk]
+		if !day.Missed() || target == nil || !target.NeedsAlert(yesterday) {
+			continue
+		}
+		if err := s.alerts.AlertMissedTarget(ctx, day); err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("failed to alert on desk %s: %w", day.Desk, err))
+			continue
+		}
+		target.MarkAlerted(yesterday)
+		if err := s.targets.Save(ctx, target); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save desk target %s: %w", day.Desk, err))
+			continue
+		}
+		result.MissedTargets++
+	}
+	return errors.Join(errs...)
+}
+
+// deskDays joins the day's publish counts with the targets
+func (s *Service) deskDays(ctx context.Context, day time.Time, targets []*freshness.DeskTarget) ([]freshness.DeskDay, error) {
+	counts, err := s.publishes.CountByDesk(ctx, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count publishes: %w", err)
+	}
+
+	days := make([]freshness.DeskDay, 0, len(targets)+len(counts))
+	seen := map[string]bool{}
+	for _, t := range targets {
+		seen[t.Desk] = true
+		days = append(days, freshness.DeskDay{Desk: t.Desk, Day: day, Published: counts[t.Desk], Target: t.DailyTarget})
+	}
+	for desk, n := range counts {
+		if !seen[desk] {
+			days = append(days, freshness.DeskDay{Desk: desk, Day: day, Published: n})
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Desk < days[j].Desk })
+	return days, nil
+}
+
+func (s *Service) findRail(ctx context.Context, slug string) (*freshness.Rail, error) {
+	rail, err := s.rails.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rail: %w", err)
+	}
+	if rail == nil {
+		return nil, ErrRailNotFound
+	}
+	return rail, nil
+}
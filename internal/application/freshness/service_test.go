@@ -0,0 +1,275 @@
+package freshness
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/freshness"
+)
+
+type memoryTargets struct {
+	targets map[string]*freshness.DeskTarget
+}
+
+func (m *memoryTargets) Save(ctx context.Context, target *freshness.DeskTarget) error {
+	m.targets[target.Desk] = target
+	return nil
+}
+
+func (m *memoryTargets) Delete(ctx context.Context, desk string) error {
+	delete(m.targets, desk)
+	return nil
+}
+
+func (m *memoryTargets) FindByDesk(ctx context.Context, desk string) (*freshness.DeskTarget, error) {
+	return m.targets[desk], nil
+}
+
+func (m *memoryTargets) FindAll(ctx context.Context) ([]*freshness.DeskTarget, error) {
+	var all []*freshness.DeskTarget
+	for _, t := range m.targets {
+		all = append(all, t)
+	}
+	return all, nil
+}
+
+type memoryRails struct {
+	rails map[string]*freshness.Rail
+}
+
+func (m *memoryRails) Create(ctx context.Context, rail *freshness.Rail) error {
+	m.rails[rail.Slug] = rail
+	return nil
+}
+
+func (m *memoryRails) Update(ctx context.Context, rail *freshness.Rail) error {
+	m.rails[rail.Slug] = rail
+	return nil
+}
+
+func (m *memoryRails) Delete(ctx context.Context, slug string) error {
+	delete(m.rails, slug)
+	return nil
+}
+
+func (m *memoryRails) FindBySlug(ctx context.Context, slug string) (*freshness.Rail, error) {
+	return m.rails[slug], nil
+}
+
+func (m *memoryRails) FindAll(ctx context.Context) ([]*freshness.Rail, error) {
+	var all []*freshness.Rail
+	for _, r := range m.rails {
+		all = append(all, r)
+	}
+	return all, nil
+}
+
+// memoryPublishes records first publications per desk and category
+type memoryPublishes struct {
+	published []publish
+}
+
+type publish struct {
+	desk, categoryID string
+	at               time.Time
+}
+
+func (m *memoryPublishes) CountByDesk(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, p := range m.published {
+		if !p.at.Before(from) && p.at.Before(to) {
+			counts[p.desk]++
+		}
+	}
+	return counts, nil
+}
+
+func (m *memoryPublishes) LatestInCategories(ctx context.Context, categoryIDs []string) (*time.Time, error) {
+	var latest *time.Time
+	for _, p := range m.published {
+		for _, id := range categoryIDs {
+			if p.categoryID == id && (latest == nil || p.at.After(*latest)) {
+				at := p.at
+				latest = &at
+			}
+		}
+	}
+	return latest, nil
+}
+
+type recordingAlerts struct {
+	stale     []string
+	recovered []string
+	missed    []freshness.DeskDay
+	err       error
+}
+
+func (r *recordingAlerts) AlertStaleRail(ctx context.Context, rail *freshness.Rail, latest *time.Time, at time.Time) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.stale = append(r.stale, rail.Slug)
+	return nil
+}
+
+func (r *recordingAlerts) AlertRailRecovered(ctx context.Context, rail *freshness.Rail, latest *time.Time, at time.Time) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.recovered = append(r.recovered, rail.Slug)
+	return nil
+}
+
+func (r *recordingAlerts) AlertMissedTarget(ctx context.Context, day freshness.DeskDay) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.missed = append(r.missed, day)
+	return nil
+}
+
+type fixture struct {
+	service   *Service
+	targets   *memoryTargets
+	rails     *memoryRails
+	publishes *memoryPublishes
+	alerts    *recordingAlerts
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		targets:   &memoryTargets{targets: map[string]*freshness.DeskTarget{}},
+		rails:     &memoryRails{rails: map[string]*freshness.Rail{}},
+		publishes: &memoryPublishes{},
+		alerts:    &recordingAlerts{},
+	}
+	f.service = NewService(f.targets, f.rails, f.publishes, f.alerts, freshness.DefaultRules)
+	return f
+}
+
+// wib returns a time on the day of March 2026 at the hour in Western Indonesia
+func wib(day, hour int) time.Time {
+	return time.Date(2026, 3, day, hour, 0, 0, 0, freshness.DefaultRules.Location)
+}
+
+func TestService_Velocity(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	if _, err := f.service.SetTarget(ctx, "s1", "metro", 10, wib(9, 8)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.service.SetTarget(ctx, "s1", "sports", 4, wib(9, 8)); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		f.publishes.published = append(f.publishes.published, publish{desk: "metro", categoryID: "c1", at: wib(10, 7+i)})
+	}
+	f.publishes.published = append(f.publishes.published,
+		publish{desk: "sports", categoryID: "c2", at: wib(10, 8)},
+		publish{desk: "opinion", categoryID: "c3", at: wib(10, 9)},
+		publish{desk: "metro", categoryID: "c1", at: wib(9, 20)},
+	)
+
+	days, err := f.service.Velocity(ctx, "", wib(10, 12))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 3 {
+		t.Fatalf("expected 3 desks, got %d", len(days))
+	}
+	// At noon metro should have 5 of 10 out, sports 2 of 4
+	if days[0].Desk != "metro" || days[0].Published != 3 || days[1].Desk != "sports" {
+		t.Errorf("expected desks below pace first, got %+v", days)
+	}
+	if days[2].Desk != "opinion" || days[2].Target != 0 || days[2].Published != 1 {
+		t.Errorf("expected desks without a target listed, got %+v", days[2])
+	}
+
+	days, _ = f.service.Velocity(ctx, "2026-03-09", wib(10, 12))
+	if len(days) != 2 || days[0].Desk != "metro" || days[0].Published != 1 {
+		t.Errorf("expected the requested day counted, got %+v", days)
+	}
+
+	if _, err := f.service.Velocity(ctx, "yesterday", wib(10, 12)); !errors.Is(err, freshness.ErrInvalidDay) {
+		t.Errorf("expected ErrInvalidDay, got %v", err)
+	}
+}
+
+func TestService_CheckRails(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	if _, err := f.service.CreateRail(ctx, "metro", "Metro", "metro", []string{"c1"}, 3*time.Hour, wib(9, 8)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.service.CreateRail(ctx, "metro", "Metro", "metro", []string{"c1"}, 3*time.Hour, wib(9, 8)); !errors.Is(err, ErrRailExists) {
+		t.Errorf("expected ErrRailExists, got %v", err)
+	}
+	f.publishes.published = append(f.publishes.published, publish{desk: "metro", categoryID: "c1", at: wib(10, 7)})
+
+	result, err := f.service.Check(ctx, wib(10, 9))
+	if err != nil || result.Stale != 0 {
+		t.Errorf("expected a fresh rail left alone, got %+v, %v", result, err)
+	}
+
+	result, _ = f.service.Check(ctx, wib(10, 10))
+	if result.Stale != 1 || len(f.alerts.stale) != 1 {
+		t.Errorf("expected the stale rail alerted, got %+v", result)
+	}
+	result, _ = f.service.Check(ctx, wib(10, 11))
+	if result.Stale != 0 {
+		t.Errorf("expected no repeat before RealertAfter, got %+v", result)
+	}
+
+	f.publishes.published = append(f.publishes.published, publish{desk: "metro", categoryID: "c1", at: wib(10, 12)})
+	result, _ = f.service.Check(ctx, wib(10, 12))
+	if result.Recovered != 1 || f.rails.rails["metro"].AlertedAt != nil {
+		t.Errorf("expected the recovery announced, got %+v", result)
+	}
+
+	// Stale again overnight, held until the quiet hours end
+	result, _ = f.service.Check(ctx, wib(11, 2))
+	if result.Stale != 0 {
+		t.Errorf("expected no alert during quiet hours, got %+v", result)
+	}
+	result, _ = f.service.Check(ctx, wib(11, 6))
+	if result.Stale != 1 {
+		t.Errorf("expected the alert once quiet hours end, got %+v", result)
+	}
+}
+
+func TestService_CheckTargets(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	_, _ = f.service.SetTarget(ctx, "s1", "metro", 3, wib(8, 8))
+	_, _ = f.service.SetTarget(ctx, "s1", "sports", 1, wib(8, 8))
+	f.publishes.published = append(f.publishes.published,
+		publish{desk: "metro", categoryID: "c1", at: wib(9, 10)},
+		publish{desk: "sports", categoryID: "c2", at: wib(9, 11)},
+	)
+
+	f.alerts.err = errors.New("webhook down")
+	result, err := f.service.Check(ctx, wib(10, 7))
+	if err == nil || result.Failed != 1 {
+		t.Errorf("expected the failed alert reported, got %+v, %v", result, err)
+	}
+
+	f.alerts.err = nil
+	result, err = f.service.Check(ctx, wib(10, 8))
+	if err != nil || result.MissedTargets != 1 || len(f.alerts.missed) != 1 || f.alerts.missed[0].Desk != "metro" {
+		t.Errorf("expected metro's missed target alerted, got %+v, %v", result, err)
+	}
+
+	result, _ = f.service.Check(ctx, wib(10, 9))
+	if result.MissedTargets != 0 {
+		t.Errorf("expected each day alerted once, got %+v", result)
+	}
+
+	if err := f.service.RemoveTarget(ctx, "metro"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.service.RemoveTarget(ctx, "metro"); !errors.Is(err, ErrTargetNotFound) {
+		t.Errorf("expected ErrTargetNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/geo"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+var ErrLocationNotFound = errors.New("article has no location")
+
+// MaxPins bounds one map query, beyond it the frontend switches to clusters
+const MaxPins = 200
+
+// Paths builds canonical article paths, the permalink router implements it
+type Paths interface {
+	Path(ref permalink.ArticleRef) (string, error)
+}
+
+// Pin is a published article on the map
+type Pin struct {
+	Location   *geo.ArticleLocation
+	Summary    geo.ArticleSummary
+	Path       string
+	DistanceKm *float64 // Only for radius queries
+}
+
+type Service struct {
+	locations geo.LocationRepository
+	articles  geo.ArticleSource
+	paths     Paths
+}
+
+func NewService(locations geo.LocationRepository, articles geo.ArticleSource, paths Paths) *Service {
+	return &Service{locations: locations, articles: articles, paths: paths}
+}
+
+// SetLocation tags an article, or moves an existing tag
+func (s *Service) SetLocation(ctx context.Context, staffID, articleID string, location geo.Location) (*geo.ArticleLocation, error) {
+	existing, err := s.locations.FindByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location: %w", err)
+	}
+	if existing == nil {
+		if existing, err = geo.NewArticleLocation(articleID, location, staffID); err != nil {
+			return nil, err
+		}
+	} else {
+		existing.Move(location, staffID)
+	}
+
+	if err := s.locations.Save(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to save location: %w", err)
+	}
+	return existing, nil
+}
+
+func (s *Service) RemoveLocation(ctx context.Context, articleID string) error {
+	if _, err := s.Location(ctx, articleID); err != nil {
+		return err
+	}
+	if err := s.locations.Delete(ctx, articleID); err != nil {
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Location(ctx context.Context, articleID string) (*geo.ArticleLocation, error) {
+	found, err := s.locations.FindByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location: %w", err)
+	}
+	if found == nil {
+		return nil, ErrLocationNotFound
+	}
+	return found, nil
+}
+
+// ArticlePublished puts a tagged article on the map, the publish hook calls it
+func (s *Service) ArticlePublished(ctx context.Context, articleID string, at time.Time) error {
+	if err := s.locations.SetPublished(ctx, articleID, &at); err != nil {
+		return fmt.Errorf("failed to publish location: %w", err)
+	}
+	return nil
+}
+
+// ArticleUnpublished takes an article off the map, the tag itself is kept
+func (s *Service) ArticleUnpublished(ctx context.Context, articleID string) error {
+	if err := s.locations.SetPublished(ctx, articleID, nil); err != nil {
+		return fmt.Errorf("failed to unpublish location: %w", err)
+	}
+	return nil
+}
+
+// Within lists published articles in the visible part of the map, newest first
+func (s *Service) Within(ctx context.Context, box geo.BoundingBox, since time.Time, limit int) ([]Pin, error) {
+	locations, err := s.locations.FindWithin(ctx, box, since, clampLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find articles in area: %w", err)
+	}
+	nearby := make([]geo.Nearby, len(locations))
+	for i, l := range locations {
+		nearby[i] = geo.Nearby{Location: l}
+	}
+	return s.pins(ctx, nearby, false)
+}
+
+// Near lists published articles within radiusKm of a point, nearest first
+func (s *Service) Near(ctx context.Context, center geo.Point, radiusKm float64, since time.Time, limit int) ([]Pin, error) {
+	if radiusKm <= 0 || radiusKm > geo.MaxRadiusKm {
+		return nil, geo.ErrInvalidRadius
+	}
+	nearby, err := s.locations.FindNear(ctx, center, radiusKm, since, clampLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find articles nearby: %w", err)
+	}
+	return s.pins(ctx, nearby, true)
+}
+
+// Clusters groups published articles in the box for the given map zoom level
+func (s *Service) Clusters(ctx context.Context, box geo.BoundingBox, zoom int, since time.Time) ([]geo.Cluster, error) {
+	gridSize, err := geo.GridSize(zoom)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := s.locations.Clusters(ctx, box, gridSize, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cluster articles: %w", err)
+	}
+	return clusters, nil
+}
+
+func (s *Service) pins(ctx context.Context, nearby []geo.Nearby, withDistance bool) ([]Pin, error) {
+	ids := make([]string, len(nearby))
+	for i, n := range nearby {
+		ids[i] = n.Location.ArticleID
+	}
+	summaries, err := s.articles.FindPublished(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load articles: %w", err)
+	}
+
+	pins := make([]Pin, 0, len(nearby))
+	for _, n := range nearby {
+		summary, ok := summaries[n.Location.ArticleID]
+		if !ok {
+			continue
+		}
+		path, err := s.paths.Path(summary.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build path for %s: %w", summary.Ref.ID, err)
+		}
+		pin := Pin{Location: n.Location, Summary: summary, Path: path}
+		if withDistance {
+			distance := n.DistanceKm
+			pin.DistanceKm = &distance
+		}
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 || limit > MaxPins {
+		return MaxPins
+	}
+	return limit
+}
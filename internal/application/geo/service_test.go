@@ -0,0 +1,182 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/geo"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+type memoryLocations struct {
+	locations map[string]*geo.ArticleLocation
+	gridSize  float64
+}
+
+func (m *memoryLocations) Save(ctx context.Context, l *geo.ArticleLocation) error {
+	m.locations[l.ArticleID] = l
+	return nil
+}
+
+func (m *memoryLocations) Delete(ctx context.Context, articleID string) error {
+	delete(m.locations, articleID)
+	return nil
+}
+
+func (m *memoryLocations) SetPublished(ctx context.Context, articleID string, at *time.Time) error {
+	if l, ok := m.locations[articleID]; ok {
+		l.PublishedAt = at
+	}
+	return nil
+}
+
+func (m *memoryLocations) FindByArticle(ctx context.Context, articleID string) (*geo.ArticleLocation, error) {
+	return m.locations[articleID], nil
+}
+
+func (m *memoryLocations) FindWithin(ctx context.Context, box geo.BoundingBox, since time.Time, limit int) ([]*geo.ArticleLocation, error) {
+	var found []*geo.ArticleLocation
+	for _, l := range m.locations {
+		if l.IsOnMap() && !l.PublishedAt.Before(since) && box.Contains(l.Location.Center) {
+			found = append(found, l)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].PublishedAt.After(*found[j].PublishedAt) })
+	return found, nil
+}
+
+func (m *memoryLocations) FindNear(ctx context.Context, center geo.Point, radiusKm float64, since time.Time, limit int) ([]geo.Nearby, error) {
+	var found []geo.Nearby
+	for _, l := range m.locations {
+		if d := center.DistanceKm(l.Location.Center); l.IsOnMap() && d <= radiusKm {
+			found = append(found, geo.Nearby{Location: l, DistanceKm: d})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].DistanceKm < found[j].DistanceKm })
+	return found, nil
+}
+
+func (m *memoryLocations) Clusters(ctx context.Context, box geo.BoundingBox, gridSize float64, since time.Time) ([]geo.Cluster, error) {
+	m.gridSize = gridSize
+	return nil, nil
+}
+
+type fakeArticles struct{}
+
+func (fakeArticles) FindPublished(ctx context.Context, articleIDs []string) (map[string]geo.ArticleSummary, error) {
+	found := map[string]geo.ArticleSummary{}
+	for _, id := range articleIDs {
+		if id != "retracted" {
+			found[id] = geo.ArticleSummary{Ref: permalink.ArticleRef{ID: id, CategorySlug: "metro", Slug: id}, Title: "Article " + id}
+		}
+	}
+	return found, nil
+}
+
+type fakePaths struct{}
+
+func (fakePaths) Path(ref permalink.ArticleRef) (string, error) {
+	return "/" + ref.CategorySlug + "/" + ref.Slug, nil
+}
+
+func createTestService(t *testing.T) (*Service, *memoryLocations) {
+	t.Helper()
+	locations := &memoryLocations{locations: map[string]*geo.ArticleLocation{}}
+	service := NewService(locations, fakeArticles{}, fakePaths{})
+
+	ctx := context.Background()
+	published := time.Date(2025, time.June, 1, 8, 0, 0, 0, time.UTC)
+	for i, tc := range []struct {
+		id       string
+		lat, lng float64
+	}{
+		{"flood", -6.2241, 106.8650}, // East Jakarta
+		{"retracted", -6.2, 106.85},
+		{"quake", -6.9175, 107.6191}, // Bandung
+		{"draft", -6.21, 106.86},
+	} {
+		point, _ := geo.NewPointLocation(geo.Point{Lat: tc.lat, Lng: tc.lng}, "")
+		if _, err := service.SetLocation(ctx, "editor-1", tc.id, *point); err != nil {
+			t.Fatalf("failed to set location: %v", err)
+		}
+		if tc.id != "draft" {
+			_ = service.ArticlePublished(ctx, tc.id, published.Add(time.Duration(i)*time.Hour))
+		}
+	}
+	return service, locations
+}
+
+func TestService_Within(t *testing.T) {
+	service, _ := createTestService(t)
+	jakarta, _ := geo.NewBoundingBox(-6.4, 106.6, -6.0, 107.0)
+
+	pins, err := service.Within(context.Background(), *jakarta, time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(pins) != 1 || pins[0].Summary.Ref.ID != "flood" || pins[0].Path != "/metro/flood" || pins[0].DistanceKm != nil {
+		t.Errorf("expected only the published Jakarta flood story, got %+v", pins)
+	}
+}
+
+func TestService_Near(t *testing.T) {
+	service, _ := createTestService(t)
+	ctx := context.Background()
+	monas := geo.Point{Lat: -6.1754, Lng: 106.8272}
+
+	pins, err := service.Near(ctx, monas, 150, time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(pins) != 2 || pins[0].Summary.Ref.ID != "flood" || *pins[0].DistanceKm > *pins[1].DistanceKm {
+		t.Errorf("expected flood then quake by distance, got %+v", pins)
+	}
+
+	if _, err := service.Near(ctx, monas, 500, time.Time{}, 10); !errors.Is(err, geo.ErrInvalidRadius) {
+		t.Errorf("expected error '%v', got '%v'", geo.ErrInvalidRadius, err)
+	}
+}
+
+func TestService_SetLocation_MovesAndUnpublishes(t *testing.T) {
+	service, locations := createTestService(t)
+	ctx := context.Background()
+
+	region, _ := geo.NewRegionLocation([]geo.Point{{Lat: -6.3, Lng: 106.8}, {Lat: -6.3, Lng: 106.9}, {Lat: -6.2, Lng: 106.9}}, "Kampung Pulo")
+	moved, err := service.SetLocation(ctx, "editor-2", "flood", *region)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if moved.Location.Kind != geo.KindRegion || *moved.LastActionBy != "editor-2" || !moved.IsOnMap() {
+		t.Errorf("expected the published tag moved to a region, got %+v", moved)
+	}
+
+	_ = service.ArticleUnpublished(ctx, "flood")
+	if locations.locations["flood"].IsOnMap() {
+		t.Errorf("expected the unpublished article off the map")
+	}
+
+	if err := service.RemoveLocation(ctx, "flood"); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if _, err := service.Location(ctx, "flood"); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrLocationNotFound, err)
+	}
+}
+
+func TestService_Clusters(t *testing.T) {
+	service, locations := createTestService(t)
+	box, _ := geo.NewBoundingBox(-11, 95, 6, 141)
+
+	if _, err := service.Clusters(context.Background(), *box, 2, time.Time{}); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if locations.gridSize != 22.5 {
+		t.Errorf("expected 22.5 degree cells at zoom 2, got %v", locations.gridSize)
+	}
+	if _, err := service.Clusters(context.Background(), *box, -1, time.Time{}); !errors.Is(err, geo.ErrInvalidZoom) {
+		t.Errorf("expected error '%v', got '%v'", geo.ErrInvalidZoom, err)
+	}
+}
@@ -0,0 +1,337 @@
+package institution
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/institution"
+)
+
+// MaxUsageRange bounds one usage report, a contract year plus a leap day
+const MaxUsageRange = 366 * 24 * time.Hour
+
+var (
+	ErrInstitutionNotFound = shared.NewDomainError("institution.not_found", shared.KindNotFound, "institution not found")
+	ErrAlertNotFound       = shared.NewDomainError("institution.alert_not_found", shared.KindNotFound, "alert not found")
+	ErrRangeTaken          = shared.NewDomainError("institution.range_taken", shared.KindConflict, "range overlaps a range of another institution")
+	ErrInvalidUsageRange   = shared.NewDomainError("institution.invalid_usage_range", shared.KindValidation, "usage range must end after it starts and span at most a year")
+)
+
+// Access is the institution a request was matched to
+type Access struct {
+	InstitutionID string
+	Name          string
+	Source        institution.Source
+}
+
+// Service manages institution licences and resolves requests to them. The
+// entitlement service makes the decision, see CanReadArticleVia.
+type Service struct {
+	institutions institution.InstitutionRepository
+	usage        institution.UsageRepository
+	volumes      institution.VolumeRepository
+	alerts       institution.AlertRepository
+	policy       institution.AnomalyPolicy
+	clock        shared.Clock
+}
+
+func NewService(institutions institution.InstitutionRepository, usage institution.UsageRepository, volumes institution.VolumeRepository, alerts institution.AlertRepository, policy institution.AnomalyPolicy, clock shared.Clock) *Service {
+	return &Service{institutions: institutions, usage: usage, volumes: volumes, alerts: alerts, policy: policy, clock: clock}
+}
+
+func (s *Service) Create(ctx context.Context, staffID, name string, contract institution.Contract, ranges []string) (*institution.Institution, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	i, err := institution.NewInstitution(id, name, contract, staffID, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.SetRanges(ranges, now); err != nil {
+		return nil, err
+	}
+	if err := s.checkRanges(ctx, i); err != nil {
+		return nil, err
+	}
+	if err := s.institutions.Create(ctx, i); err != nil {
+		return nil, fmt.Errorf("failed to save institution: %w", err)
+	}
+	return i, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*institution.Institution, error) {
+	i, err := s.institutions.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load institution: %w", err)
+	}
+	if i == nil {
+		return nil, ErrInstitutionNotFound
+	}
+	return i, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]*institution.Institution, error) {
+	return s.institutions.FindAll(ctx)
+}
+
+func (s *Service) SetRanges(ctx context.Context, id string, ranges []string) (*institution.Institution, error) {
+	return s.update(ctx, id, func(i *institution.Institution, at time.Time) error {
+		if err := i.SetRanges(ranges, at); err != nil {
+			return err
+		}
+		return s.checkRanges(ctx, i)
+	})
+}
+
+// Renew replaces the contract, e.g. after the yearly renewal is signed
+func (s *Service) Renew(ctx context.Context, id string, contract institution.Contract) (*institution.Institution, error) {
+	return s.update(ctx, id, func(i *institution.Institution, at time.Time) error {
+		return i.Renew(contract, at)
+	})
+}
+
+func (s *Service) Suspend(ctx context.Context, id, reason string) (*institution.Institution, error) {
+	return s.update(ctx, id, func(i *institution.Institution, at time.Time) error {
+		return i.Suspend(reason, at)
+	})
+}
+
+func (s *Service) Reinstate(ctx context.Context, id string) (*institution.Institution, error) {
+	return s.update(ctx, id, func(i *institution.Institution, at time.Time) error {
+		return i.Reinstate(at)
+	})
+}
+
+// IssueProxyToken returns the raw token, it is not stored and cannot be shown again
+func (s *Service) IssueProxyToken(ctx context.Context, id, label string) (string, *institution.ProxyToken, error) {
+	tokenID, err := shared.GenerateUUID()
+	if err != nil {
+		return "", nil, err
+	}
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to generate proxy token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	var issued *institution.ProxyToken
+	_, err = s.update(ctx, id, func(i *institution.Institution, at time.Time) error {
+		var err error
+		issued, err = i.IssueProxyToken(tokenID, label, hashToken(token), at)
+		return err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return token, issued, nil
+}
+
+func (s *Service) RevokeProxyToken(ctx context.Context, id, tokenID string) (*institution.Institution, error) {
+	return s.update(ctx, id, func(i *institution.Institution, at time.Time) error {
+		return i.RevokeProxyToken(tokenID, at)
+	})
+}
+
+// Resolve matches a request to an institution by its proxy token, then by the
+// client address. It returns nil when the request is not covered or the
+// licence does not grant access now, e.g. because the contract ended.
+func (s *Service) Resolve(ctx context.Context, clientIP, proxyToken string) (*Access, error) {
+	at := s.clock.Now()
+	var i *institution.Institution
+	var source institution.Source
+
+	if proxyToken != "" {
+		hash := hashToken(proxyToken)
+		found, err := s.institutions.FindByTokenHash(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load institution: %w", err)
+		}
+		if found != nil {
+			if token, ok := found.MatchToken(hash); ok {
+				i, source = found, institution.Source{InstitutionID: found.ID, Kind: institution.SourceProxy, Value: token.ID}
+			}
+		}
+	}
+	if i == nil {
+		ip, err := netip.ParseAddr(clientIP)
+		if err != nil {
+			return nil, nil
+		}
+		found, err := s.institutions.FindByIP(ctx, ip)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load institution: %w", err)
+		}
+		if found != nil {
+			if prefix, ok := found.MatchIP(ip); ok {
+				i, source = found, institution.Source{InstitutionID: found.ID, Kind: institution.SourceRange, Value: prefix.String()}
+			}
+		}
+	}
+	if i == nil || !i.GrantsAccess(at) {
+		return nil, nil
+	}
+
+	// Losing a count only weakens abuse detection, the reader still gets in
+	_ = s.volumes.Increment(ctx, source, institution.Hour(at))
+	return &Access{InstitutionID: i.ID, Name: i.Name, Source: source}, nil
+}
+
+// RecordRead counts an article read under the licence, call it when the
+// entitlement decision allowed the read for ReasonInstitution
+func (s *Service) RecordRead(ctx context.Context, institutionID, articleID string) error {
+	if err := s.usage.RecordRead(ctx, institutionID, articleID, s.clock.Now()); err != nil {
+		return fmt.Errorf("failed to record institutional read: %w", err)
+	}
+	return nil
+}
+
+// Usage returns the daily statistics for [from, to), days are UTC
+func (s *Service) Usage(ctx context.Context, id string, from, to time.Time) ([]institution.DailyUsage, error) {
+	from, to = institution.Day(from), institution.Day(to)
+	if !to.After(from) || to.Sub(from) > MaxUsageRange {
+		return nil, ErrInvalidUsageRange
+	}
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	usage, err := s.usage.FindDaily(ctx, id, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage: %w", err)
+	}
+	return usage, nil
+}
+
+// DetectAbuse is the hourly job. It compares every source's volume in the last
+// complete hour with the same hour on the baseline days and raises an alert
+// for each anomalous one. Running it twice for an hour raises nothing new.
+func (s *Service) DetectAbuse(ctx context.Context) ([]*institution.Alert, error) {
+	at := s.clock.Now()
+	hour := institution.Hour(at).Add(-time.Hour)
+
+	volumes, err := s.volumes.FindHour(ctx, hour)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load volumes: %w", err)
+	}
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+
+	baselines := make(map[institution.Source][]int)
+	days := s.policy.BaselineHours(hour)
+	for n, h := range days {
+		past, err := s.volumes.FindHour(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load baseline volumes: %w", err)
+		}
+		for _, v := range past {
+			if baselines[v.Source] == nil {
+				baselines[v.Source] = make([]int, len(days))
+			}
+			baselines[v.Source][n] = v.Requests
+		}
+	}
+
+	var raised []*institution.Alert
+	for _, v := range volumes {
+		baseline := baselines[v.Source]
+		if baseline == nil {
+			baseline = make([]int, len(days))
+		}
+		anomalous, mean := s.policy.Anomalous(v.Requests, baseline)
+		if !anomalous {
+			continue
+		}
+		existing, err := s.alerts.FindBySource(ctx, v.Source, hour)
+		if err != nil {
+			return raised, fmt.Errorf("failed to load alert: %w", err)
+		}
+		if existing != nil {
+			continue
+		}
+
+		id, err := shared.GenerateUUID()
+		if err != nil {
+			return raised, err
+		}
+		alert, err := institution.NewAlert(id, v, mean, at)
+		if err != nil {
+			return raised, err
+		}
+		if err := s.alerts.Create(ctx, alert); err != nil {
+			return raised, fmt.Errorf("failed to save alert: %w", err)
+		}
+		raised = append(raised, alert)
+	}
+	return raised, nil
+}
+
+func (s *Service) Alerts(ctx context.Context) ([]*institution.Alert, error) {
+	return s.alerts.FindOpen(ctx)
+}
+
+// ResolveAlert closes an alert, actioned records that staff suspended the
+// institution or revoked the token, which they do separately
+func (s *Service) ResolveAlert(ctx context.Context, staffID, alertID string, actioned bool) (*institution.Alert, error) {
+	alert, err := s.alerts.FindByID(ctx, alertID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load alert: %w", err)
+	}
+	if alert == nil {
+		return nil, ErrAlertNotFound
+	}
+	if err := alert.Resolve(staffID, actioned, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.alerts.Update(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to save alert: %w", err)
+	}
+	return alert, nil
+}
+
+func (s *Service) update(ctx context.Context, id string, change func(i *institution.Institution, at time.Time) error) (*institution.Institution, error) {
+	i, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(i, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.institutions.Update(ctx, i); err != nil {
+		return nil, fmt.Errorf("failed to save institution: %w", err)
+	}
+	return i, nil
+}
+
+// checkRanges rejects ranges another institution already licenses, an address
+// must resolve to a single institution
+func (s *Service) checkRanges(ctx context.Context, i *institution.Institution) error {
+	if len(i.Ranges) == 0 {
+		return nil
+	}
+	all, err := s.institutions.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load institutions: %w", err)
+	}
+	for _, other := range all {
+		if other.ID == i.ID {
+			continue
+		}
+		if prefix, ok := other.Overlaps(i.Ranges); ok {
+			return fmt.Errorf("%w: %s belongs to %s", ErrRangeTaken, prefix, other.Name)
+		}
+	}
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,328 @@
+package institution
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/institution"
+)
+
+type memoryInstitutions struct {
+	institutions map[string]*institution.Institution
+}
+
+func (m *memoryInstitutions) Create(ctx context.Context, i *institution.Institution) error {
+	m.institutions[i.ID] = i
+	return nil
+}
+
+func (m *memoryInstitutions) Update(ctx context.Context, i *institution.Institution) error {
+	m.institutions[i.ID] = i
+	return nil
+}
+
+func (m *memoryInstitutions) FindByID(ctx context.Context, id string) (*institution.Institution, error) {
+	return m.institutions[id], nil
+}
+
+func (m *memoryInstitutions) FindAll(ctx context.Context) ([]*institution.Institution, error) {
+	var all []*institution.Institution
+	for _, i := range m.institutions {
+		all = append(all, i)
+	}
+	return all, nil
+}
+
+func (m *memoryInstitutions) FindByIP(ctx context.Context, ip netip.Addr) (*institution.Institution, error) {
+	for _, i := range m.institutions {
+		if _, ok := i.MatchIP(ip); ok {
+			return i, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryInstitutions) FindByTokenHash(ctx context.Context, tokenHash string) (*institution.Institution, error) {
+	for _, i := range m.institutions {
+		if _, ok := i.MatchToken(tokenHash); ok {
+			return i, nil
+		}
+	}
+	return nil, nil
+}
+
+type memoryUsage struct {
+	reads map[string][]string // institution ID -> article IDs
+}
+
+func (m *memoryUsage) RecordRead(ctx context.Context, institutionID, articleID string, at time.Time) error {
+	m.reads[institutionID] = append(m.reads[institutionID], articleID)
+	return nil
+}
+
+func (m *memoryUsage) FindDaily(ctx context.Context, institutionID string, from, to time.Time) ([]institution.DailyUsage, error) {
+	unique := map[string]bool{}
+	for _, id := range m.reads[institutionID] {
+		unique[id] = true
+	}
+	return []institution.DailyUsage{{Day: from, Reads: len(m.reads[institutionID]), UniqueArticles: len(unique)}}, nil
+}
+
+type memoryVolumes struct {
+	counts map[time.Time]map[institution.Source]int
+}
+
+func (m *memoryVolumes) Increment(ctx context.Context, source institution.Source, hour time.Time) error {
+	if m.counts[hour] == nil {
+		m.counts[hour] = map[institution.Source]int{}
+	}
+	m.counts[hour][source]++
+	return nil
+}
+
+func (m *memoryVolumes) FindHour(ctx context.Context, hour time.Time) ([]institution.Volume, error) {
+	var volumes []institution.Volume
+	for source, n := range m.counts[hour] {
+		volumes = append(volumes, institution.Volume{Source: source, Hour: hour, Requests: n})
+	}
+	return volumes, nil
+}
+
+type memoryAlerts struct {
+	alerts []*institution.Alert
+}
+
+func (m *memoryAlerts) Create(ctx context.Context, a *institution.Alert) error {
+	m.alerts = append(m.alerts, a)
+	return nil
+}
+
+func (m *memoryAlerts) Update(ctx context.Context, a *institution.Alert) error {
+	return nil
+}
+
+func (m *memoryAlerts) FindByID(ctx context.Context, id string) (*institution.Alert, error) {
+	for _, a := range m.alerts {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAlerts) FindBySource(ctx context.Context, source institution.Source, hour time.Time) (*institution.Alert, error) {
+	for _, a := range m.alerts {
+		if a.Source == source && a.Hour.Equal(hour) {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAlerts) FindOpen(ctx context.Context) ([]*institution.Alert, error) {
+	var open []*institution.Alert
+	for _, a := range m.alerts {
+		if a.Status == institution.AlertOpen {
+			open = append(open, a)
+		}
+	}
+	return open, nil
+}
+
+type testEnv struct {
+	service      *Service
+	institutions *memoryInstitutions
+	usage        *memoryUsage
+	volumes      *memoryVolumes
+	alerts       *memoryAlerts
+	clock        *shared.FrozenClock
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := &testEnv{
+		institutions: &memoryInstitutions{institutions: map[string]*institution.Institution{}},
+		usage:        &memoryUsage{reads: map[string][]string{}},
+		volumes:      &memoryVolumes{counts: map[time.Time]map[institution.Source]int{}},
+		alerts:       &memoryAlerts{},
+		clock:        shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 30, 0, 0, time.UTC)),
+	}
+	env.service = NewService(env.institutions, env.usage, env.volumes, env.alerts, institution.DefaultAnomalyPolicy(), env.clock)
+	return env
+}
+
+func (e *testEnv) create(t *testing.T, name string, ranges ...string) *institution.Institution {
+	t.Helper()
+	now := e.clock.Now()
+	contract, _ := institution.NewContract(name+"-2026", now.AddDate(0, -1, 0), now.AddDate(0, 11, 0))
+	i, err := e.service.Create(context.Background(), "admin-1", name, contract, ranges)
+	if err != nil {
+		t.Fatalf("failed to create institution: %v", err)
+	}
+	return i
+}
+
+func TestService_Create(t *testing.T) {
+	env := newTestEnv(t)
+	env.create(t, "UI", "152.118.0.0/16")
+
+	contract, _ := institution.NewContract("ITB-2026", env.clock.Now(), env.clock.Now().AddDate(1, 0, 0))
+	_, err := env.service.Create(context.Background(), "admin-1", "ITB", contract, []string{"152.118.24.0/24"})
+	if !errors.Is(err, ErrRangeTaken) {
+		t.Errorf("expected ErrRangeTaken, got %v", err)
+	}
+	if len(env.institutions.institutions) != 1 {
+		t.Error("expected the overlapping institution not to be saved")
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	ui := env.create(t, "UI", "152.118.0.0/16")
+	token, proxy, err := env.service.IssueProxyToken(ctx, ui.ID, "EZproxy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if proxy.TokenHash == token {
+		t.Error("expected only the hash to be stored")
+	}
+
+	access, err := env.service.Resolve(ctx, "152.118.24.7", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if access == nil || access.InstitutionID != ui.ID || access.Source.Kind != institution.SourceRange || access.Source.Value != "152.118.0.0/16" {
+		t.Errorf("expected the campus range to match, got %+v", access)
+	}
+
+	access, _ = env.service.Resolve(ctx, "203.0.113.7", token)
+	if access == nil || access.Source.Kind != institution.SourceProxy || access.Source.Value != proxy.ID {
+		t.Errorf("expected the proxy token to match, got %+v", access)
+	}
+
+	for _, tc := range []struct{ name, ip, token string }{
+		{"outside address", "203.0.113.7", ""},
+		{"unknown token", "203.0.113.7", "forged"},
+		{"invalid address", "not-an-ip", ""},
+	} {
+		if access, _ := env.service.Resolve(ctx, tc.ip, tc.token); access != nil {
+			t.Errorf("%s: expected no access, got %+v", tc.name, access)
+		}
+	}
+
+	hour := institution.Hour(env.clock.Now())
+	if volumes, _ := env.volumes.FindHour(ctx, hour); len(volumes) != 2 {
+		t.Errorf("expected one volume per source, got %+v", volumes)
+	}
+
+	// Access ends with the contract
+	env.clock.Advance(365 * 24 * time.Hour)
+	if access, _ := env.service.Resolve(ctx, "152.118.24.7", ""); access != nil {
+		t.Errorf("expected no access after the contract, got %+v", access)
+	}
+}
+
+func TestService_SuspendAndRevoke(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	ui := env.create(t, "UI", "152.118.0.0/16")
+	token, proxy, _ := env.service.IssueProxyToken(ctx, ui.ID, "EZproxy")
+
+	if _, err := env.service.RevokeProxyToken(ctx, ui.ID, proxy.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if access, _ := env.service.Resolve(ctx, "203.0.113.7", token); access != nil {
+		t.Errorf("expected a revoked token to grant nothing, got %+v", access)
+	}
+
+	if _, err := env.service.Suspend(ctx, ui.ID, "scraping"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if access, _ := env.service.Resolve(ctx, "152.118.24.7", ""); access != nil {
+		t.Errorf("expected a suspended institution to grant nothing, got %+v", access)
+	}
+	if _, err := env.service.Reinstate(ctx, ui.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := env.service.Suspend(ctx, "missing", "scraping"); err != ErrInstitutionNotFound {
+		t.Errorf("expected ErrInstitutionNotFound, got %v", err)
+	}
+}
+
+func TestService_Usage(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	ui := env.create(t, "UI", "152.118.0.0/16")
+	for _, id := range []string{"a", "b", "a"} {
+		if err := env.service.RecordRead(ctx, ui.ID, id); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	now := env.clock.Now()
+	usage, err := env.service.Usage(ctx, ui.ID, now.AddDate(0, 0, -7), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(usage) != 1 || usage[0].Reads != 3 || usage[0].UniqueArticles != 2 {
+		t.Errorf("unexpected usage %+v", usage)
+	}
+
+	if _, err := env.service.Usage(ctx, ui.ID, now, now.AddDate(0, 0, -1)); err != ErrInvalidUsageRange {
+		t.Errorf("expected ErrInvalidUsageRange for a reversed range, got %v", err)
+	}
+	if _, err := env.service.Usage(ctx, ui.ID, now.AddDate(-2, 0, 0), now); err != ErrInvalidUsageRange {
+		t.Errorf("expected ErrInvalidUsageRange for two years, got %v", err)
+	}
+}
+
+func TestService_DetectAbuse(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	hour := institution.Hour(env.clock.Now()).Add(-time.Hour)
+
+	busy := institution.Source{InstitutionID: "inst-1", Kind: institution.SourceRange, Value: "152.118.0.0/16"}
+	quiet := institution.Source{InstitutionID: "inst-1", Kind: institution.SourceProxy, Value: "tok-1"}
+	record := func(source institution.Source, at time.Time, n int) {
+		for range n {
+			_ = env.volumes.Increment(ctx, source, at)
+		}
+	}
+	for day := 1; day <= 7; day++ {
+		record(busy, hour.AddDate(0, 0, -day), 2000)
+		record(quiet, hour.AddDate(0, 0, -day), 20)
+	}
+	record(busy, hour, 2500)  // Exam week, within the usual peak
+	record(quiet, hour, 3000) // The proxy is being scraped
+
+	alerts, err := env.service.DetectAbuse(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 1 || alerts[0].Source != quiet || alerts[0].Requests != 3000 || alerts[0].Baseline != 20 {
+		t.Fatalf("expected one alert for the proxy, got %+v", alerts)
+	}
+
+	if again, _ := env.service.DetectAbuse(ctx); len(again) != 0 {
+		t.Errorf("expected a second run to raise nothing, got %+v", again)
+	}
+
+	resolved, err := env.service.ResolveAlert(ctx, "admin-1", alerts[0].ID, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.Status != institution.AlertActioned {
+		t.Errorf("expected an actioned alert, got %s", resolved.Status)
+	}
+	if open, _ := env.service.Alerts(ctx); len(open) != 0 {
+		t.Errorf("expected no open alerts, got %+v", open)
+	}
+	if _, err := env.service.ResolveAlert(ctx, "admin-1", "missing", false); err != ErrAlertNotFound {
+		t.Errorf("expected ErrAlertNotFound, got %v", err)
+	}
+}
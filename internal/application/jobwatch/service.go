@@ -0,0 +1,236 @@
+package jobwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/jobwatch"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// RealertAfter is how often an alert repeats while a job stays overdue
+const RealertAfter = 6 * time.Hour
+
+var ErrJobNotRegistered = errors.New("job is not registered")
+
+// Schedule is the expected cadence of one job
+type Schedule struct {
+	Name     string
+	Interval time.Duration
+	Grace    time.Duration
+}
+
+// DefaultSchedules are the critical jobs registered at startup
+var DefaultSchedules = []Schedule{
+	{Name: "sitemap-rebuild", Interval: time.Hour, Grace: 15 * time.Minute},
+	{Name: "cache-purge", Interval: 15 * time.Minute, Grace: 5 * time.Minute},
+	{Name: "newsletter-send", Interval: 24 * time.Hour, Grace: time.Hour},
+	{Name: "activity-digest", Interval: time.Hour, Grace: 15 * time.Minute},
+	{Name: "reader-digest", Interval: time.Hour, Grace: 15 * time.Minute},
+	{Name: "comment-replies", Interval: 10 * time.Minute, Grace: 10 * time.Minute},
+	{Name: "suspension-expiry", Interval: 10 * time.Minute, Grace: 5 * time.Minute},
+	{Name: "document-scan", Interval: 5 * time.Minute, Grace: 10 * time.Minute},
+	{Name: "quota-alerts", Interval: 15 * time.Minute, Grace: 15 * time.Minute},
+	{Name: "dunning-retry", Interval: time.Hour, Grace: 30 * time.Minute},
+	{Name: "retention-purge", Interval: 24 * time.Hour, Grace: 2 * time.Hour},
+	{Name: "revenue-attribution", Interval: 24 * time.Hour, Grace: 2 * time.Hour},
+	{Name: "churn-scoring", Interval: 24 * time.Hour, Grace: 2 * time.Hour},
+	{Name: "crm-sync", Interval: 5 * time.Minute, Grace: 10 * time.Minute},
+	{Name: "crm-reconcile", Interval: 24 * time.Hour, Grace: 2 * time.Hour},
+	{Name: "institution-abuse", Interval: time.Hour, Grace: 30 * time.Minute},
+	{Name: "content-freshness", Interval: 15 * time.Minute, Grace: 15 * time.Minute},
+}
+
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityResolved Severity = "resolved"
+)
+
+// Alert is one message to the on-call channel
+type Alert struct {
+	Severity Severity
+	Source   string
+	Title    string
+	Message  string
+	At       time.Time
+}
+
+// AlertChannel delivers alerts to on-call, e.g. a chat webhook or a paging service
+type AlertChannel interface {
+	SendAlert(ctx context.Context, alert Alert) error
+}
+
+type Service struct {
+	jobs    jobwatch.JobRepository
+	channel AlertChannel
+	clock   shared.Clock
+}
+
+func NewService(jobs jobwatch.JobRepository, channel AlertChannel, clock shared.Clock) *Service {
+	return &Service{jobs: jobs, channel: channel, clock: clock}
+}
+
+// Register records the expected schedule, re-registering updates it and keeps the run history
+func (s *Service) Register(ctx context.Context, schedule Schedule, at time.Time) (*jobwatch.Job, error) {
+	job, err := s.jobs.FindByName(ctx, schedule.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job: %w", err)
+	}
+
+	if job == nil {
+		job, err = jobwatch.NewJob(schedule.Name, schedule.Interval, schedule.Grace, at)
+	} else {
+		err = job.Reschedule(schedule.Interval, schedule.Grace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.jobs.Save(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+	return job, nil
+}
+
+// ReportSuccess is the heartbeat of a finished run, a recovery is announced on the alert channel
+func (s *Service) ReportSuccess(ctx context.Context, name string, at time.Time, duration time.Duration) error {
+	job, err := s.find(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	recovered := job.RecordSuccess(at, duration)
+	if err := s.jobs.Save(ctx, job); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+
+	if recovered {
+		return s.channel.SendAlert(ctx, Alert{
+			Severity: SeverityResolved,
+			Source:   job.Name,
+			Title:    fmt.Sprintf("%s has recovered", job.Name),
+			Message:  fmt.Sprintf("Succeeded at %s after %s.", at.UTC().Format(time.RFC3339), duration.Round(time.Second)),
+			At:       at,
+		})
+	}
+	return nil
+}
+
+// ReportFailure records a failed run; the alert only fires once the success window is missed
+func (s *Service) ReportFailure(ctx context.Context, name string, at time.Time, message string) error {
+	job, err := s.find(ctx, name)
+	if err != nil {
+		return err
+	}
+	job.RecordFailure(at, message)
+	if err := s.jobs.Save(ctx, job); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+	return nil
+}
+
+// Track runs fn and reports its outcome, for jobs running inside this process
+func (s *Service) Track(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	started := s.clock.Now()
+	runErr := fn(ctx)
+	finished := s.clock.Now()
+
+	var reportErr error
+	if runErr != nil {
+		reportErr = s.ReportFailure(ctx, name, finished, runErr.Error())
+	} else {
+		reportErr = s.ReportSuccess(ctx, name, finished, finished.Sub(started))
+	}
+	return errors.Join(runErr, reportErr)
+}
+
+// Check alerts on every overdue job. It is the switch itself and must run from a different
+// scheduler than the jobs it watches.
+func (s *Service) Check(ctx context.Context, at time.Time) (int, error) {
+	jobs, err := s.jobs.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load jobs: %w", err)
+	}
+
+	alerted := 0
+	var errs []error
+	for _, job := range jobs {
+		if !job.NeedsAlert(at, RealertAfter) {
+			continue
+		}
+
+		if err := s.channel.SendAlert(ctx, overdueAlert(job, at)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to alert on %s: %w", job.Name, err))
+			continue
+		}
+		job.MarkAlerted(at)
+		if err := s.jobs.Save(ctx, job); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save job %s: %w", job.Name, err))
+			continue
+		}
+		alerted++
+	}
+	return alerted, errors.Join(errs...)
+}
+
+// JobStatus is one row of the status endpoint
+type JobStatus struct {
+	Job    *jobwatch.Job
+	Status jobwatch.Status
+}
+
+// Statuses lists every registered job, overdue ones first
+func (s *Service) Statuses(ctx context.Context, at time.Time) ([]JobStatus, error) {
+	jobs, err := s.jobs.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jobs: %w", err)
+	}
+
+	statuses := make([]JobStatus, 0, len(jobs))
+	for _, job := range jobs {
+		statuses = append(statuses, JobStatus{Job: job, Status: job.StatusAt(at)})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		oi, oj := statuses[i].Status == jobwatch.StatusOverdue, statuses[j].Status == jobwatch.StatusOverdue
+		if oi != oj {
+			return oi
+		}
+		return statuses[i].Job.Name < statuses[j].Job.Name
+	})
+	return statuses, nil
+}
+
+func (s *Service) find(ctx context.Context, name string) (*jobwatch.Job, error) {
+	job, err := s.jobs.FindByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job: %w", err)
+	}
+	if job == nil {
+		return nil, ErrJobNotRegistered
+	}
+	return job, nil
+}
+
+func overdueAlert(job *jobwatch.Job, at time.Time) Alert {
+	last := "it has never succeeded"
+	if job.LastSuccessAt != nil {
+		last = "last success was " + job.LastSuccessAt.UTC().Format(time.RFC3339)
+	}
+	message := fmt.Sprintf("Expected every %s (+%s grace), %s.", job.Interval, job.Grace, last)
+	if job.LastError != nil && job.LastFailureAt != nil && (job.LastSuccessAt == nil || job.LastFailureAt.After(*job.LastSuccessAt)) {
+		message += " Last error: " + *job.LastError
+	}
+
+	return Alert{
+		Severity: SeverityCritical,
+		Source:   job.Name,
+		Title:    fmt.Sprintf("%s is overdue", job.Name),
+		Message:  message,
+		At:       at,
+	}
+}
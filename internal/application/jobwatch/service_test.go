@@ -0,0 +1,153 @@
+package jobwatch
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/jobwatch"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type memoryJobs struct {
+	jobs map[string]*jobwatch.Job
+}
+
+func (m *memoryJobs) Save(ctx context.Context, job *jobwatch.Job) error {
+	m.jobs[job.Name] = job
+	return nil
+}
+
+func (m *memoryJobs) FindByName(ctx context.Context, name string) (*jobwatch.Job, error) {
+	return m.jobs[name], nil
+}
+
+func (m *memoryJobs) FindAll(ctx context.Context) ([]*jobwatch.Job, error) {
+	var all []*jobwatch.Job
+	for _, job := range m.jobs {
+		all = append(all, job)
+	}
+	return all, nil
+}
+
+type fakeChannel struct {
+	alerts []Alert
+	err    error
+}
+
+func (f *fakeChannel) SendAlert(ctx context.Context, alert Alert) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.alerts = append(f.alerts, alert)
+	return nil
+}
+
+func TestService_DeadMansSwitch(t *testing.T) {
+	ctx := context.Background()
+	jobs := &memoryJobs{jobs: map[string]*jobwatch.Job{}}
+	channel := &fakeChannel{}
+	service := NewService(jobs, channel, shared.SystemClock{})
+	start := time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, schedule := range DefaultSchedules {
+		if _, err := service.Register(ctx, schedule, start); err != nil {
+			t.Fatalf("failed to register %s: %v", schedule.Name, err)
+		}
+	}
+
+	// Everything except the sitemap keeps reporting
+	at := start
+	for ; at.Before(start.Add(80 * time.Minute)); at = at.Add(5 * time.Minute) {
+		for _, schedule := range DefaultSchedules {
+			if schedule.Name != "sitemap-rebuild" {
+				service.ReportSuccess(ctx, schedule.Name, at, time.Second)
+			}
+		}
+	}
+	service.ReportFailure(ctx, "sitemap-rebuild", start.Add(time.Hour), "storage: bucket not found")
+
+	n, err := service.Check(ctx, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 || channel.alerts[0].Source != "sitemap-rebuild" || channel.alerts[0].Severity != SeverityCritical {
+		t.Fatalf("expected one critical sitemap alert, got %+v", channel.alerts)
+	}
+	if !strings.Contains(channel.alerts[0].Message, "never succeeded") || !strings.Contains(channel.alerts[0].Message, "bucket not found") {
+		t.Errorf("expected alert to explain the failure, got %q", channel.alerts[0].Message)
+	}
+
+	// No duplicate until the re-alert interval passes
+	if n, _ := service.Check(ctx, at.Add(time.Minute)); n != 0 {
+		t.Errorf("expected no repeat alert yet, got %d", n)
+	}
+
+	statuses, _ := service.Statuses(ctx, at)
+	if statuses[0].Job.Name != "sitemap-rebuild" || statuses[0].Status != jobwatch.StatusOverdue {
+		t.Errorf("expected overdue sitemap first, got %s %s", statuses[0].Job.Name, statuses[0].Status)
+	}
+
+	if err := service.ReportSuccess(ctx, "sitemap-rebuild", at.Add(2*time.Hour), 3*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := channel.alerts[len(channel.alerts)-1]
+	if last.Severity != SeverityResolved || last.Source != "sitemap-rebuild" {
+		t.Errorf("expected recovery alert, got %+v", last)
+	}
+
+	if err := service.ReportSuccess(ctx, "unknown-job", at, time.Second); err != ErrJobNotRegistered {
+		t.Errorf("expected error '%v', got '%v'", ErrJobNotRegistered, err)
+	}
+}
+
+func TestService_CheckKeepsAlertingWhenChannelFails(t *testing.T) {
+	ctx := context.Background()
+	jobs := &memoryJobs{jobs: map[string]*jobwatch.Job{}}
+	channel := &fakeChannel{err: errors.New("webhook 502")}
+	service := NewService(jobs, channel, shared.SystemClock{})
+	start := time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC)
+
+	service.Register(ctx, Schedule{Name: "cache-purge", Interval: 15 * time.Minute}, start)
+	if _, err := service.Check(ctx, start.Add(time.Hour)); err == nil {
+		t.Error("expected error but got none")
+	}
+	if jobs.jobs["cache-purge"].AlertedAt != nil {
+		t.Error("expected alert to stay outstanding after a failed delivery")
+	}
+
+	channel.err = nil
+	if n, _ := service.Check(ctx, start.Add(time.Hour)); n != 1 {
+		t.Errorf("expected alert on the next check, got %d", n)
+	}
+}
+
+func TestService_Track(t *testing.T) {
+	ctx := context.Background()
+	jobs := &memoryJobs{jobs: map[string]*jobwatch.Job{}}
+	clock := shared.NewFrozenClock(time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC))
+	service := NewService(jobs, &fakeChannel{}, clock)
+	service.Register(ctx, Schedule{Name: "cache-purge", Interval: 15 * time.Minute}, clock.Now())
+
+	runErr := errors.New("cdn: 429")
+	if err := service.Track(ctx, "cache-purge", func(ctx context.Context) error { return runErr }); !errors.Is(err, runErr) {
+		t.Errorf("expected error '%v', got '%v'", runErr, err)
+	}
+	if jobs.jobs["cache-purge"].LastError == nil {
+		t.Error("expected failure to be recorded")
+	}
+
+	slowRun := func(ctx context.Context) error {
+		clock.Advance(3 * time.Minute)
+		return nil
+	}
+	if err := service.Track(ctx, "cache-purge", slowRun); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job := jobs.jobs["cache-purge"]
+	if job.LastSuccessAt == nil || !job.LastSuccessAt.Equal(clock.Now()) || job.LastDuration != 3*time.Minute {
+		t.Errorf("expected success recorded at the clock's time after 3m, got %v after %s", job.LastSuccessAt, job.LastDuration)
+	}
+}
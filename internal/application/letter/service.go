@@ -0,0 +1,191 @@
+package letter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var (
+	ErrAccountNotFound = errors.New("account not found")
+	ErrLetterNotFound  = errors.New("letter not found")
+	ErrInvalidPage     = shared.NewDomainError("letter.invalid_page", shared.KindValidation, "invalid page")
+)
+
+// ArticleDrafter hands an accepted letter to the article workflow as a draft on
+// the letters page and returns the new article's ID
+type ArticleDrafter interface {
+	CreateLetterDraft(ctx context.Context, editorID string, draft *letter.ArticleDraft) (string, error)
+}
+
+type Service struct {
+	letters  letter.LetterSubmissionRepository
+	accounts account.UserAccountRepository
+	notifier letter.AcknowledgmentNotifier
+	articles ArticleDrafter
+}
+
+func NewService(letters letter.LetterSubmissionRepository, accounts account.UserAccountRepository, notifier letter.AcknowledgmentNotifier, articles ArticleDrafter) *Service {
+	return &Service{letters: letters, accounts: accounts, notifier: notifier, articles: articles}
+}
+
+// SubmitInput is the public submission form, Email is ignored for signed-in readers
+type SubmitInput struct {
+	AccountID *string
+	Name      string
+	Email     string
+	City      string
+	Subject   string
+	Body      string
+}
+
+// Submit files a letter in the editorial queue and acknowledges it right away.
+// A signed-in reader's account email replaces the one typed in. A failed
+// acknowledgment does not fail the submission, AcknowledgePending retries it.
+func (s *Service) Submit(ctx context.Context, input SubmitInput) (*letter.LetterSubmission, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	var l *letter.LetterSubmission
+	if input.AccountID != nil {
+		acc, err := s.accounts.FindByID(ctx, *input.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load account: %w", err)
+		}
+		if acc == nil {
+			return nil, ErrAccountNotFound
+		}
+		l, err = letter.NewLetterSubmissionFromAccount(id, acc, input.Name, input.City, input.Subject, input.Body)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		author, err := letter.NewAuthor(input.Name, input.Email, input.City)
+		if err != nil {
+			return nil, err
+		}
+		if l, err = letter.NewLetterSubmission(id, *author, input.Subject, input.Body, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.letters.Create(ctx, l); err != nil {
+		return nil, fmt.Errorf("failed to save letter: %w", err)
+	}
+	_ = s.acknowledge(ctx, l)
+	return l, nil
+}
+
+// AcknowledgePending sends the acknowledgments that failed at submission time,
+// it returns how many were sent and every failure
+func (s *Service) AcknowledgePending(ctx context.Context, limit int) (int, error) {
+	pending, err := s.letters.FindUnacknowledged(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load unacknowledged letters: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for _, l := range pending {
+		if err := s.acknowledge(ctx, l); err != nil {
+			errs = append(errs, fmt.Errorf("failed to acknowledge letter %s: %w", l.ID, err))
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+// EditorialQueue lists received and shortlisted letters oldest first
+func (s *Service) EditorialQueue(ctx context.Context, limit, offset int) ([]*letter.LetterSubmission, error) {
+	filter := &letter.LetterFilter{Limit: limit, Offset: offset}
+	filter.SetDefaults()
+	if err := filter.Validate(); err != nil {
+		return nil, ErrInvalidPage.WithMessage(err.Error())
+	}
+	return s.letters.FindEditorialQueue(ctx, filter.Limit, filter.Offset)
+}
+
+func (s *Service) Get(ctx context.Context, letterID string) (*letter.LetterSubmission, error) {
+	return s.find(ctx, letterID)
+}
+
+func (s *Service) Shortlist(ctx context.Context, letterID, editorID string) (*letter.LetterSubmission, error) {
+	return s.update(ctx, letterID, func(l *letter.LetterSubmission) error {
+		return l.Shortlist(editorID)
+	})
+}
+
+func (s *Service) Decline(ctx context.Context, letterID, editorID, reason string) (*letter.LetterSubmission, error) {
+	return s.update(ctx, letterID, func(l *letter.LetterSubmission) error {
+		return l.Decline(editorID, reason)
+	})
+}
+
+// Publish converts a shortlisted letter into an article draft attributed to its author
+func (s *Service) Publish(ctx context.Context, letterID, editorID string) (*letter.LetterSubmission, error) {
+	l, err := s.find(ctx, letterID)
+	if err != nil {
+		return nil, err
+	}
+	draft, err := l.ToArticleDraft()
+	if err != nil {
+		return nil, err
+	}
+
+	articleID, err := s.articles.CreateLetterDraft(ctx, editorID, draft)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create article from letter: %w", err)
+	}
+	if err := l.Publish(editorID, articleID); err != nil {
+		return nil, err
+	}
+	if err := s.letters.Update(ctx, l); err != nil {
+		return nil, fmt.Errorf("failed to save letter: %w", err)
+	}
+	return l, nil
+}
+
+func (s *Service) acknowledge(ctx context.Context, l *letter.LetterSubmission) error {
+	if err := s.notifier.SendAcknowledgment(ctx, l); err != nil {
+		return err
+	}
+	if err := l.MarkAcknowledged(); err != nil {
+		return err
+	}
+	if err := s.letters.Update(ctx, l); err != nil {
+		return fmt.Errorf("failed to save letter: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) update(ctx context.Context, letterID string, change func(l *letter.LetterSubmission) error) (*letter.LetterSubmission, error) {
+	l, err := s.find(ctx, letterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(l); err != nil {
+		return nil, err
+	}
+	if err := s.letters.Update(ctx, l); err != nil {
+		return nil, fmt.Errorf("failed to save letter: %w", err)
+	}
+	return l, nil
+}
+
+func (s *Service) find(ctx context.Context, letterID string) (*letter.LetterSubmission, error) {
+	l, err := s.letters.FindByID(ctx, letterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load letter: %w", err)
+	}
+	if l == nil {
+		return nil, ErrLetterNotFound
+	}
+	return l, nil
+}
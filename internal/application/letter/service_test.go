@@ -0,0 +1,195 @@
+package letter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakeLetterRepo struct {
+	letter.LetterSubmissionRepository
+	letters map[string]*letter.LetterSubmission
+}
+
+func newFakeLetterRepo() *fakeLetterRepo {
+	return &fakeLetterRepo{letters: map[string]*letter.LetterSubmission{}}
+}
+
+func (f *fakeLetterRepo) Create(ctx context.Context, l *letter.LetterSubmission) error {
+	f.letters[l.ID] = l
+	return nil
+}
+
+func (f *fakeLetterRepo) Update(ctx context.Context, l *letter.LetterSubmission) error {
+	f.letters[l.ID] = l
+	return nil
+}
+
+func (f *fakeLetterRepo) FindByID(ctx context.Context, id string) (*letter.LetterSubmission, error) {
+	return f.letters[id], nil
+}
+
+func (f *fakeLetterRepo) FindEditorialQueue(ctx context.Context, limit, offset int) ([]*letter.LetterSubmission, error) {
+	var queue []*letter.LetterSubmission
+	for _, l := range f.letters {
+		if l.IsReceived() || l.IsShortlisted() {
+			queue = append(queue, l)
+		}
+	}
+	return queue, nil
+}
+
+func (f *fakeLetterRepo) FindUnacknowledged(ctx context.Context, limit int) ([]*letter.LetterSubmission, error) {
+	var pending []*letter.LetterSubmission
+	for _, l := range f.letters {
+		if !l.IsAcknowledged() {
+			pending = append(pending, l)
+		}
+	}
+	return pending, nil
+}
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+type fakeNotifier struct {
+	err  error
+	sent []string
+}
+
+func (f *fakeNotifier) SendAcknowledgment(ctx context.Context, l *letter.LetterSubmission) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, l.Author.Email().String())
+	return nil
+}
+
+type fakeDrafter struct {
+	drafts []*letter.ArticleDraft
+}
+
+func (f *fakeDrafter) CreateLetterDraft(ctx context.Context, editorID string, draft *letter.ArticleDraft) (string, error) {
+	f.drafts = append(f.drafts, draft)
+	return "art-1", nil
+}
+
+func newTestService(t *testing.T) (*Service, *fakeLetterRepo, *fakeNotifier, *fakeDrafter) {
+	t.Helper()
+	member, err := account.NewUserAccountForSelfRegistration("member123", "member_user", "Member@Example.com", "hashed_password")
+	if err != nil {
+		t.Fatalf("failed to create member: %v", err)
+	}
+	if err := member.SelfVerify(); err != nil {
+		t.Fatalf("failed to verify member: %v", err)
+	}
+	letters, notifier, drafter := newFakeLetterRepo(), &fakeNotifier{}, &fakeDrafter{}
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{member.ID: member}}
+	return NewService(letters, accounts, notifier, drafter), letters, notifier, drafter
+}
+
+func TestService_SubmitAcknowledges(t *testing.T) {
+	service, _, notifier, _ := newTestService(t)
+	ctx := context.Background()
+
+	l, err := service.Submit(ctx, SubmitInput{Name: "Jane Doe", Email: "jane@example.com", City: "Bandung", Subject: "Flooding in Dayeuhkolot", Body: "The drains were never cleared."})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.ID == "" || !l.IsReceived() || !l.IsAcknowledged() {
+		t.Errorf("expected a received and acknowledged letter, got %+v", l)
+	}
+
+	accountID := "member123"
+	l, err = service.Submit(ctx, SubmitInput{AccountID: &accountID, Name: "Member", Email: "typed@example.com", Subject: "Subject", Body: "Body"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.Author.Email().String() != "member@example.com" {
+		t.Errorf("expected the account email used, got %s", l.Author.Email())
+	}
+	if len(notifier.sent) != 2 || notifier.sent[1] != "member@example.com" {
+		t.Errorf("expected both letters acknowledged, got %v", notifier.sent)
+	}
+
+	if _, err := service.Submit(ctx, SubmitInput{Name: "Jane Doe", Email: "jane@example.com", Body: "Body"}); !errors.Is(err, letter.ErrEmptySubject) {
+		t.Errorf("expected ErrEmptySubject, got %v", err)
+	}
+	missing := "missing"
+	if _, err := service.Submit(ctx, SubmitInput{AccountID: &missing, Name: "Jane", Subject: "Subject", Body: "Body"}); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("expected ErrAccountNotFound, got %v", err)
+	}
+}
+
+func TestService_AcknowledgePending(t *testing.T) {
+	service, letters, notifier, _ := newTestService(t)
+	ctx := context.Background()
+
+	notifier.err = errors.New("smtp down")
+	l, err := service.Submit(ctx, SubmitInput{Name: "Jane Doe", Email: "jane@example.com", Subject: "Subject", Body: "Body"})
+	if err != nil {
+		t.Fatalf("expected the letter kept when the acknowledgment fails, got %v", err)
+	}
+	if l.IsAcknowledged() {
+		t.Error("expected the letter left unacknowledged")
+	}
+	if sent, err := service.AcknowledgePending(ctx, 50); sent != 0 || err == nil {
+		t.Errorf("expected the failure reported, got %d, %v", sent, err)
+	}
+
+	notifier.err = nil
+	if sent, err := service.AcknowledgePending(ctx, 50); sent != 1 || err != nil {
+		t.Errorf("expected the retry sent, got %d, %v", sent, err)
+	}
+	if !letters.letters[l.ID].IsAcknowledged() {
+		t.Error("expected the letter marked acknowledged")
+	}
+}
+
+func TestService_EditorialWorkflow(t *testing.T) {
+	service, _, _, drafter := newTestService(t)
+	ctx := context.Background()
+
+	first, _ := service.Submit(ctx, SubmitInput{Name: "Jane Doe", Email: "jane@example.com", City: "Bandung", Subject: "Flooding", Body: "Body"})
+	second, _ := service.Submit(ctx, SubmitInput{Name: "Budi", Email: "budi@example.com", Subject: "Parking", Body: "Body"})
+
+	if queue, _ := service.EditorialQueue(ctx, 0, 0); len(queue) != 2 {
+		t.Errorf("expected 2 letters in the queue, got %d", len(queue))
+	}
+
+	if _, err := service.Publish(ctx, first.ID, "editor1"); !errors.Is(err, letter.ErrNotShortlisted) {
+		t.Errorf("expected ErrNotShortlisted, got %v", err)
+	}
+	if _, err := service.Shortlist(ctx, first.ID, "editor1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	published, err := service.Publish(ctx, first.ID, "editor1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !published.IsPublished() || *published.ArticleID != "art-1" {
+		t.Errorf("expected the letter published as art-1, got %+v", published)
+	}
+	if len(drafter.drafts) != 1 || drafter.drafts[0].Byline != "Jane Doe, Bandung" {
+		t.Errorf("expected an attributed draft, got %+v", drafter.drafts)
+	}
+
+	if _, err := service.Decline(ctx, second.ID, "editor1", "Off topic"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if queue, _ := service.EditorialQueue(ctx, 0, 0); len(queue) != 0 {
+		t.Errorf("expected an empty queue, got %d", len(queue))
+	}
+	if _, err := service.Shortlist(ctx, "missing", "editor1"); !errors.Is(err, ErrLetterNotFound) {
+		t.Errorf("expected ErrLetterNotFound, got %v", err)
+	}
+}
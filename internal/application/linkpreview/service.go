@@ -0,0 +1,63 @@
+package linkpreview
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/linkpreview"
+)
+
+// Service builds the link cards the editor inserts into the block model
+type Service struct {
+	cache     linkpreview.Cache
+	fetcher   linkpreview.Fetcher
+	articles  linkpreview.ArticleCards
+	siteHosts map[string]bool
+}
+
+// NewService takes the portal's own hostnames, links to them are answered
+// from the article store instead of over the network
+func NewService(cache linkpreview.Cache, fetcher linkpreview.Fetcher, articles linkpreview.ArticleCards, siteHosts []string) *Service {
+	hosts := make(map[string]bool, len(siteHosts))
+	for _, h := range siteHosts {
+		hosts[strings.ToLower(h)] = true
+	}
+	return &Service{cache: cache, fetcher: fetcher, articles: articles, siteHosts: hosts}
+}
+
+// Preview returns the card for a pasted URL
+func (s *Service) Preview(ctx context.Context, rawURL string, at time.Time) (*linkpreview.Card, error) {
+	u, err := linkpreview.NormalizeURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.siteHosts[u.Hostname()] {
+		card, err := s.articles.CardForPath(ctx, u.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load article card: %w", err)
+		}
+		if card != nil {
+			return card, nil
+		}
+		// Section and tag pages are not articles, they get a regular card
+	}
+
+	key := u.String()
+	cached, err := s.cache.Get(ctx, key)
+	if err == nil && cached != nil {
+		return cached, nil
+	}
+
+	page, err := s.fetcher.Fetch(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	card := linkpreview.Parse(page.Body, page.URL, at)
+
+	// A failed cache write only costs the next editor a fetch
+	_ = s.cache.Set(ctx, key, card, linkpreview.CacheTTL)
+	return card, nil
+}
@@ -0,0 +1,104 @@
+package linkpreview
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/linkpreview"
+)
+
+type memoryCache struct {
+	cards map[string]*linkpreview.Card
+}
+
+func (m *memoryCache) Get(ctx context.Context, key string) (*linkpreview.Card, error) {
+	return m.cards[key], nil
+}
+
+func (m *memoryCache) Set(ctx context.Context, key string, card *linkpreview.Card, ttl time.Duration) error {
+	m.cards[key] = card
+	return nil
+}
+
+type fakeFetcher struct {
+	fetched []string
+	err     error
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, u *url.URL) (*linkpreview.Page, error) {
+	f.fetched = append(f.fetched, u.String())
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &linkpreview.Page{URL: u, Body: []byte(`<meta property="og:title" content="Remote page">`)}, nil
+}
+
+type fakeArticles struct{}
+
+func (fakeArticles) CardForPath(ctx context.Context, path string) (*linkpreview.Card, error) {
+	if path != "/news/flood-a1" {
+		return nil, nil
+	}
+	id := "a1"
+	return &linkpreview.Card{URL: "https://news.example.com/news/flood-a1", Title: "Flood", ArticleID: &id}, nil
+}
+
+func newTestService() (*Service, *memoryCache, *fakeFetcher) {
+	cache := &memoryCache{cards: map[string]*linkpreview.Card{}}
+	fetcher := &fakeFetcher{}
+	return NewService(cache, fetcher, fakeArticles{}, []string{"News.Example.com"}), cache, fetcher
+}
+
+func TestService_PreviewExternal(t *testing.T) {
+	service, cache, fetcher := newTestService()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		card, err := service.Preview(ctx, "https://other.example.org/story?utm_source=x#comments", time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if card.Title != "Remote page" || card.Internal() {
+			t.Errorf("unexpected card %+v", card)
+		}
+	}
+	if len(fetcher.fetched) != 1 || fetcher.fetched[0] != "https://other.example.org/story" {
+		t.Errorf("expected one fetch of the normalized URL, got %v", fetcher.fetched)
+	}
+	if cache.cards["https://other.example.org/story"] == nil {
+		t.Error("expected the card cached under the normalized URL")
+	}
+}
+
+func TestService_PreviewInternal(t *testing.T) {
+	service, _, fetcher := newTestService()
+
+	card, err := service.Preview(context.Background(), "https://news.example.com/news/flood-a1", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !card.Internal() || *card.ArticleID != "a1" || len(fetcher.fetched) != 0 {
+		t.Errorf("expected article card without a fetch, got %+v", card)
+	}
+
+	if _, err := service.Preview(context.Background(), "https://news.example.com/sport", time.Now()); err != nil || len(fetcher.fetched) != 1 {
+		t.Errorf("expected a section page fetched like any other page, got %v", err)
+	}
+}
+
+func TestService_PreviewErrors(t *testing.T) {
+	service, cache, fetcher := newTestService()
+	fetcher.err = linkpreview.ErrBlockedHost
+
+	if _, err := service.Preview(context.Background(), "file:///etc/passwd", time.Now()); err != linkpreview.ErrInvalidURL {
+		t.Errorf("expected error '%v', got '%v'", linkpreview.ErrInvalidURL, err)
+	}
+	if _, err := service.Preview(context.Background(), "http://internal.example.org/", time.Now()); err != linkpreview.ErrBlockedHost {
+		t.Errorf("expected error '%v', got '%v'", linkpreview.ErrBlockedHost, err)
+	}
+	if len(cache.cards) != 0 {
+		t.Errorf("expected failures not cached, got %v", cache.cards)
+	}
+}
@@ -0,0 +1,117 @@
+package localization
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/localization"
+)
+
+type Service struct {
+	rates       localization.RateRepository
+	settings    localization.ArticleSettingsRepository
+	preferences localization.PreferenceRepository
+	provider    localization.RateProvider
+}
+
+func NewService(rates localization.RateRepository, settings localization.ArticleSettingsRepository, preferences localization.PreferenceRepository, provider localization.RateProvider) *Service {
+	return &Service{rates: rates, settings: settings, preferences: preferences, provider: provider}
+}
+
+// RefreshRates is the scheduled job, it stores the latest USD/IDR rate.
+// A failed fetch keeps the previous rate, which stays in use until it is
+// older than localization.MaxRateAge.
+func (s *Service) RefreshRates(ctx context.Context) (*localization.Rate, error) {
+	rate, err := s.provider.Latest(ctx, localization.CurrencyUSD, localization.CurrencyIDR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exchange rate: %w", err)
+	}
+	if err := s.rates.Save(ctx, rate); err != nil {
+		return nil, fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+// Render adds conversions to an article body for the reader, accountID is
+// empty for guests. Articles the editor opted out are returned unchanged.
+func (s *Service) Render(ctx context.Context, articleID, accountID, body, language string, at time.Time) (string, error) {
+	settings, err := s.ArticleSettings(ctx, articleID)
+	if err != nil {
+		return "", err
+	}
+	if settings.Disabled {
+		return body, nil
+	}
+	pref := localization.DefaultPreference()
+	if accountID != "" {
+		saved, err := s.Preference(ctx, accountID)
+		if err != nil {
+			return "", err
+		}
+		pref = saved.Preference
+	}
+	return s.Preview(ctx, body, language, pref, at)
+}
+
+// Preview renders a body with the given preference, for editors checking
+// how conversions read before publishing
+func (s *Service) Preview(ctx context.Context, body, language string, pref localization.Preference, at time.Time) (string, error) {
+	rate, err := s.rates.FindLatest(ctx, localization.CurrencyUSD, localization.CurrencyIDR)
+	if err != nil {
+		return "", fmt.Errorf("failed to load exchange rate: %w", err)
+	}
+	if rate != nil && !rate.Fresh(at) {
+		// Measurements are still converted, a stale rate would mislead
+		rate = nil
+	}
+	rendered, _ := localization.Localize(body, language, pref, rate)
+	return rendered, nil
+}
+
+// ArticleSettings returns the article's settings, conversions are on until an editor opts out
+func (s *Service) ArticleSettings(ctx context.Context, articleID string) (*localization.ArticleSettings, error) {
+	settings, err := s.settings.FindByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load localization settings: %w", err)
+	}
+	if settings == nil {
+		return &localization.ArticleSettings{ArticleID: articleID}, nil
+	}
+	return settings, nil
+}
+
+func (s *Service) SetOptOut(ctx context.Context, editorID, articleID string, disabled bool, at time.Time) (*localization.ArticleSettings, error) {
+	settings, err := localization.NewArticleSettings(articleID, disabled, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.settings.Save(ctx, settings); err != nil {
+		return nil, fmt.Errorf("failed to save localization settings: %w", err)
+	}
+	return settings, nil
+}
+
+// Preference returns the member's saved preference, the default until one is saved
+func (s *Service) Preference(ctx context.Context, accountID string) (*localization.ReaderPreference, error) {
+	pref, err := s.preferences.FindByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reader preference: %w", err)
+	}
+	if pref == nil {
+		return &localization.ReaderPreference{AccountID: accountID, Preference: localization.DefaultPreference()}, nil
+	}
+	return pref, nil
+}
+
+func (s *Service) SavePreference(ctx context.Context, accountID string, currency localization.Currency, units localization.UnitSystem, at time.Time) (*localization.ReaderPreference, error) {
+	pref, err := localization.NewPreference(currency, units)
+	if err != nil {
+		return nil, err
+	}
+	saved := &localization.ReaderPreference{AccountID: accountID, Preference: pref, UpdatedAt: at}
+	if err := s.preferences.Save(ctx, saved); err != nil {
+		return nil, fmt.Errorf("failed to save reader preference: %w", err)
+	}
+	return saved, nil
+}
@@ -0,0 +1,121 @@
+package localization
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/localization"
+)
+
+type memoryRates struct {
+	latest *localization.Rate
+}
+
+func (m *memoryRates) Save(ctx context.Context, rate *localization.Rate) error {
+	m.latest = rate
+	return nil
+}
+
+func (m *memoryRates) FindLatest(ctx context.Context, base, quote localization.Currency) (*localization.Rate, error) {
+	return m.latest, nil
+}
+
+type memorySettings struct {
+	settings map[string]*localization.ArticleSettings
+}
+
+func (m *memorySettings) Save(ctx context.Context, settings *localization.ArticleSettings) error {
+	m.settings[settings.ArticleID] = settings
+	return nil
+}
+
+func (m *memorySettings) FindByArticle(ctx context.Context, articleID string) (*localization.ArticleSettings, error) {
+	return m.settings[articleID], nil
+}
+
+type memoryPreferences struct {
+	preferences map[string]*localization.ReaderPreference
+}
+
+func (m *memoryPreferences) Save(ctx context.Context, pref *localization.ReaderPreference) error {
+	m.preferences[pref.AccountID] = pref
+	return nil
+}
+
+func (m *memoryPreferences) FindByAccount(ctx context.Context, accountID string) (*localization.ReaderPreference, error) {
+	return m.preferences[accountID], nil
+}
+
+type fakeProvider struct {
+	rate *localization.Rate
+}
+
+func (f *fakeProvider) Latest(ctx context.Context, base, quote localization.Currency) (*localization.Rate, error) {
+	return f.rate, nil
+}
+
+func createTestService(fetchedAt time.Time) (*Service, *memoryRates) {
+	rates := &memoryRates{}
+	provider := &fakeProvider{rate: &localization.Rate{Base: localization.CurrencyUSD, Quote: localization.CurrencyIDR, Value: 16000, FetchedAt: fetchedAt}}
+	service := NewService(rates, &memorySettings{settings: map[string]*localization.ArticleSettings{}}, &memoryPreferences{preferences: map[string]*localization.ReaderPreference{}}, provider)
+	return service, rates
+}
+
+func TestService_Render(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	service, _ := createTestService(at)
+	if _, err := service.RefreshRates(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.SavePreference(ctx, "member-1", localization.CurrencyUSD, localization.UnitsImperial, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body := "<p>Anggaran Rp1,6 triliun untuk jalan 10 km.</p>"
+
+	got, err := service.Render(ctx, "a1", "member-1", body, "id", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(got, `class="conversion"`) != 2 {
+		t.Errorf("expected the amount and the distance converted, got %s", got)
+	}
+
+	if got, _ := service.Render(ctx, "a1", "", body, "id", at); got != body {
+		t.Errorf("expected guests on the default preference to see the body unchanged, got %s", got)
+	}
+
+	if _, err := service.SetOptOut(ctx, "editor-1", "a1", true, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := service.Render(ctx, "a1", "member-1", body, "id", at); got != body {
+		t.Errorf("expected an opted out article unchanged, got %s", got)
+	}
+}
+
+func TestService_Preview_StaleRate(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	service, _ := createTestService(at.Add(-localization.MaxRateAge - time.Hour))
+	if _, err := service.RefreshRates(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pref := localization.Preference{Currency: localization.CurrencyUSD, Units: localization.UnitsImperial}
+
+	got, err := service.Preview(ctx, "<p>Anggaran Rp1,6 triliun untuk jalan 10 km.</p>", "id", pref, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(got, `class="conversion"`) != 1 || strings.Contains(got, "US$") {
+		t.Errorf("expected only the distance converted with a stale rate, got %s", got)
+	}
+}
+
+func TestService_SavePreference_Invalid(t *testing.T) {
+	service, _ := createTestService(time.Now())
+	if _, err := service.SavePreference(context.Background(), "member-1", "EUR", localization.UnitsMetric, time.Now()); err != localization.ErrInvalidCurrency {
+		t.Errorf("expected error '%v', got '%v'", localization.ErrInvalidCurrency, err)
+	}
+}
@@ -0,0 +1,179 @@
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// DefaultScanBatch is how many pending documents one job run scans
+const DefaultScanBatch = 50
+
+var ErrDocumentNotFound = errors.New("document not found")
+
+// DetectionAlerter notifies the security desk when a scanner finds a threat
+type DetectionAlerter interface {
+	AlertDetection(ctx context.Context, document *media.Document) error
+}
+
+// DocumentService runs uploaded documents through the virus scanner before they can be downloaded
+type DocumentService struct {
+	documents media.DocumentRepository
+	blobs     media.BlobStore
+	scanner   media.Scanner
+	alerter   DetectionAlerter
+}
+
+func NewDocumentService(documents media.DocumentRepository, blobs media.BlobStore, scanner media.Scanner, alerter DetectionAlerter) *DocumentService {
+	return &DocumentService{documents: documents, blobs: blobs, scanner: scanner, alerter: alerter}
+}
+
+// Upload stores the document as pending, it is scanned by the next RunPendingScans
+func (s *DocumentService) Upload(ctx context.Context, filename, contentType string, size int64, content io.Reader, uploadedBy string) (*media.Document, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := media.NewDocument(id, filename, contentType, size, "", "documents/"+id, uploadedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	if err := s.blobs.Put(ctx, d.StorageKey, io.TeeReader(io.LimitReader(content, size), hash)); err != nil {
+		return nil, fmt.Errorf("failed to store document: %w", err)
+	}
+	d.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if err := s.documents.Create(ctx, d); err != nil {
+		return nil, fmt.Errorf("failed to save document: %w", err)
+	}
+	return d, nil
+}
+
+// ScanRunResult summarizes one run of the scan job
+type ScanRunResult struct {
+	Clean        int
+	Quarantined  int
+	Errors       int // Retried next run until MaxScanAttempts
+	AlertsFailed int
+}
+
+// RunPendingScans scans a batch of pending documents
+func (s *DocumentService) RunPendingScans(ctx context.Context, at time.Time) (*ScanRunResult, error) {
+	pending, err := s.documents.FindPendingScan(ctx, DefaultScanBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pending documents: %w", err)
+	}
+
+	result := &ScanRunResult{}
+	for _, d := range pending {
+		verdict, scanErr := s.scan(ctx, d)
+		if scanErr != nil {
+			result.Errors++
+			if err := d.RecordScanError(scanErr.Error(), at); err != nil {
+				return result, err
+			}
+			if err := s.documents.Update(ctx, d); err != nil {
+				return result, fmt.Errorf("failed to update document %s: %w", d.ID, err)
+			}
+			continue
+		}
+
+		// Move the blob before recording the verdict so a crash never leaves an infected file servable
+		if !verdict.IsClean() && !d.IsQuarantined() {
+			if err := s.blobs.Quarantine(ctx, d.StorageKey); err != nil {
+				return result, fmt.Errorf("failed to quarantine document %s: %w", d.ID, err)
+			}
+		}
+		if verdict.IsClean() && d.IsQuarantined() {
+			if err := s.blobs.Release(ctx, d.StorageKey); err != nil {
+				return result, fmt.Errorf("failed to release document %s: %w", d.ID, err)
+			}
+		}
+
+		if err := d.RecordScan(verdict, at); err != nil {
+			return result, err
+		}
+		if err := s.documents.Update(ctx, d); err != nil {
+			return result, fmt.Errorf("failed to update document %s: %w", d.ID, err)
+		}
+
+		if verdict.IsClean() {
+			result.Clean++
+			continue
+		}
+		result.Quarantined++
+		if err := s.alerter.AlertDetection(ctx, d); err != nil {
+			result.AlertsFailed++
+		}
+	}
+	return result, nil
+}
+
+// Rescan queues one document for another scan
+func (s *DocumentService) Rescan(ctx context.Context, documentID string) (*media.Document, error) {
+	d, err := s.find(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	d.RequestRescan()
+	if err := s.documents.Update(ctx, d); err != nil {
+		return nil, fmt.Errorf("failed to update document: %w", err)
+	}
+	return d, nil
+}
+
+// RescanSince queues every document uploaded since the given time, e.g. after a signature update
+func (s *DocumentService) RescanSince(ctx context.Context, uploadedSince time.Time) (int64, error) {
+	n, err := s.documents.MarkRescan(ctx, uploadedSince)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue re-scan: %w", err)
+	}
+	return n, nil
+}
+
+// Open returns the document content, only once it has been scanned clean
+func (s *DocumentService) Open(ctx context.Context, documentID string) (*media.Document, io.ReadCloser, error) {
+	d, err := s.find(ctx, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !d.IsDownloadable() {
+		return d, nil, media.ErrNotDownloadable
+	}
+
+	content, err := s.blobs.Open(ctx, d.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open document: %w", err)
+	}
+	return d, content, nil
+}
+
+func (s *DocumentService) scan(ctx context.Context, d *media.Document) (media.ScanResult, error) {
+	content, err := s.blobs.Open(ctx, d.StorageKey)
+	if err != nil {
+		return media.ScanResult{}, err
+	}
+	defer content.Close()
+	return s.scanner.Scan(ctx, content)
+}
+
+func (s *DocumentService) find(ctx context.Context, documentID string) (*media.Document, error) {
+	d, err := s.documents.FindByID(ctx, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load document: %w", err)
+	}
+	if d == nil {
+		return nil, ErrDocumentNotFound
+	}
+	return d, nil
+}
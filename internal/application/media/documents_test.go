@@ -0,0 +1,181 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+)
+
+type memoryDocuments struct {
+	items map[string]*media.Document
+}
+
+func (m *memoryDocuments) Create(ctx context.Context, d *media.Document) error {
+	m.items[d.ID] = d
+	return nil
+}
+
+func (m *memoryDocuments) Update(ctx context.Context, d *media.Document) error {
+	m.items[d.ID] = d
+	return nil
+}
+
+func (m *memoryDocuments) FindByID(ctx context.Context, id string) (*media.Document, error) {
+	return m.items[id], nil
+}
+
+func (m *memoryDocuments) FindPendingScan(ctx context.Context, limit int) ([]*media.Document, error) {
+	var pending []*media.Document
+	for _, d := range m.items {
+		if d.ScanStatus == media.ScanPending && len(pending) < limit {
+			pending = append(pending, d)
+		}
+	}
+	return pending, nil
+}
+
+func (m *memoryDocuments) MarkRescan(ctx context.Context, uploadedSince time.Time) (int64, error) {
+	var n int64
+	for _, d := range m.items {
+		if !d.CreatedAt.Before(uploadedSince) {
+			d.RequestRescan()
+			n++
+		}
+	}
+	return n, nil
+}
+
+type memoryBlobs struct {
+	blobs       map[string][]byte
+	quarantined map[string]bool
+}
+
+func (m *memoryBlobs) Put(ctx context.Context, key string, content io.Reader) error {
+	b, err := io.ReadAll(content)
+	m.blobs[key] = b
+	return err
+}
+
+func (m *memoryBlobs) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m.blobs[key])), nil
+}
+
+func (m *memoryBlobs) Quarantine(ctx context.Context, key string) error {
+	m.quarantined[key] = true
+	return nil
+}
+
+func (m *memoryBlobs) Release(ctx context.Context, key string) error {
+	delete(m.quarantined, key)
+	return nil
+}
+
+// fakeScanner flags content containing "EICAR"
+type fakeScanner struct {
+	err error
+}
+
+func (f *fakeScanner) Name() string {
+	return "fake"
+}
+
+func (f *fakeScanner) Scan(ctx context.Context, content io.Reader) (media.ScanResult, error) {
+	if f.err != nil {
+		return media.ScanResult{}, f.err
+	}
+	b, _ := io.ReadAll(content)
+	if strings.Contains(string(b), "EICAR") {
+		result, _ := media.NewInfectedResult("fake", "Eicar-Signature")
+		return *result, nil
+	}
+	return media.NewCleanResult("fake"), nil
+}
+
+type fakeAlerter struct {
+	alerted []string
+}
+
+func (f *fakeAlerter) AlertDetection(ctx context.Context, d *media.Document) error {
+	f.alerted = append(f.alerted, d.ID)
+	return nil
+}
+
+func TestDocumentService(t *testing.T) {
+	ctx := context.Background()
+	documents := &memoryDocuments{items: map[string]*media.Document{}}
+	blobs := &memoryBlobs{blobs: map[string][]byte{}, quarantined: map[string]bool{}}
+	scanner := &fakeScanner{}
+	alerter := &fakeAlerter{}
+	service := NewDocumentService(documents, blobs, scanner, alerter)
+
+	cleanBody := "quarterly report"
+	clean, err := service.Upload(ctx, "report.txt", "text/plain", int64(len(cleanBody)), strings.NewReader(cleanBody), "editor-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clean.Checksum) != 64 {
+		t.Errorf("expected sha256 checksum, got %q", clean.Checksum)
+	}
+	infectedBody := "X5O!P%@AP EICAR test"
+	infected, _ := service.Upload(ctx, "invoice.pdf", "application/pdf", int64(len(infectedBody)), strings.NewReader(infectedBody), "editor-1")
+
+	if _, _, err := service.Open(ctx, clean.ID); err != media.ErrNotDownloadable {
+		t.Errorf("expected error '%v', got '%v'", media.ErrNotDownloadable, err)
+	}
+
+	at := time.Now()
+	scanner.err = errors.New("clamd: connection refused")
+	result, err := service.RunPendingScans(ctx, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Errors != 2 || clean.ScanStatus != media.ScanPending {
+		t.Errorf("expected scanner errors to keep documents pending, got %+v", result)
+	}
+
+	scanner.err = nil
+	result, _ = service.RunPendingScans(ctx, at)
+	if result.Clean != 1 || result.Quarantined != 1 {
+		t.Errorf("expected one clean and one quarantined, got %+v", result)
+	}
+	if !blobs.quarantined[infected.StorageKey] || len(alerter.alerted) != 1 || alerter.alerted[0] != infected.ID {
+		t.Error("expected infected blob quarantined and alerted")
+	}
+
+	_, content, err := service.Open(ctx, clean.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(content)
+	if string(body) != cleanBody {
+		t.Errorf("expected %q, got %q", cleanBody, body)
+	}
+	if _, _, err := service.Open(ctx, infected.ID); err != media.ErrNotDownloadable {
+		t.Errorf("expected error '%v', got '%v'", media.ErrNotDownloadable, err)
+	}
+
+	// False positive: the blob is cleaned up and re-scanned
+	blobs.blobs[infected.StorageKey] = []byte("fixed")
+	if _, err := service.Rescan(ctx, infected.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	service.RunPendingScans(ctx, at)
+	if !infected.IsDownloadable() || blobs.quarantined[infected.StorageKey] {
+		t.Error("expected clean re-scan to release the document")
+	}
+
+	n, _ := service.RescanSince(ctx, at.Add(-time.Hour))
+	if n != 2 || clean.IsDownloadable() {
+		t.Errorf("expected both documents queued for re-scan, got %d", n)
+	}
+
+	if _, err := service.Rescan(ctx, "missing"); err != ErrDocumentNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrDocumentNotFound, err)
+	}
+}
@@ -0,0 +1,333 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/taxonomy"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/migration"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Legacy status values, the mapping's query translates the legacy system's own
+// codes into these, e.g. with a CASE expression
+const (
+	legacyActive    = "active"
+	legacyDisabled  = "disabled"
+	legacyDraft     = "draft"
+	legacyPublished = "published"
+	legacyArchived  = "archived"
+)
+
+// planUser keeps the legacy password hash. Accounts whose hash format the
+// PasswordHasher cannot verify sign in through a password reset. A legacy
+// user whose email already has an account, e.g. staff set up before the
+// cutover, is mapped to that account.
+func (s *Service) planUser(ctx context.Context, e *execution, rec migration.Record) (*step, error) {
+	email := strings.ToLower(rec.Value(migration.FieldEmail))
+	if !e.claim(migration.KindUser, migration.FieldEmail, email) {
+		return nil, rejectf("email %s is used by an earlier legacy user", email)
+	}
+	existing, err := s.targets.Accounts.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if existing != nil {
+		return &step{newID: existing.ID, matched: true}, nil
+	}
+
+	username := rec.Value(migration.FieldUsername)
+	if !e.claim(migration.KindUser, migration.FieldUsername, username) {
+		return nil, rejectf("username %s is used by an earlier legacy user", username)
+	}
+	taken, err := s.targets.Accounts.ExistsByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check username: %w", err)
+	}
+	if taken {
+		return nil, rejectf("username %s is taken by another account", username)
+	}
+
+	accountType := account.TypeMembership
+	if v := rec.Value(migration.FieldAccountType); v != "" {
+		accountType = account.UserAccountType(v)
+	}
+	createdAt, err := rec.Time(migration.FieldCreatedAt)
+	if err != nil {
+		return nil, reject(err)
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	actor := e.run.StartedBy
+	ua, err := account.NewUserAccountWithHash(id, username, email, rec.Value(migration.FieldPassword), accountType, actor)
+	if err != nil {
+		return nil, reject(err)
+	}
+	ua.SetClock(s.clock)
+	if err := ua.Verify(actor); err != nil {
+		return nil, reject(err)
+	}
+	switch status := rec.Value(migration.FieldStatus); status {
+	case "", legacyActive:
+	case legacyDisabled:
+		if err := ua.DisableManually(actor, "disabled in the legacy CMS"); err != nil {
+			return nil, reject(err)
+		}
+	default:
+		return nil, rejectf("%w: status %q", migration.ErrInvalidValue, status)
+	}
+	if createdAt != nil {
+		ua.CreatedAt = *createdAt
+	}
+
+	return &step{newID: id, save: func(ctx context.Context) error {
+		return s.targets.Accounts.Create(ctx, ua)
+	}}, nil
+}
+
+// planCategory needs the parent migrated first, the mapping's query should
+// list parents before their children, e.g. by ordering on depth
+func (s *Service) planCategory(ctx context.Context, e *execution, rec migration.Record) (*step, error) {
+	slug, err := taxonomy.NewSlug(rec.Value(migration.FieldSlug))
+	if err != nil {
+		return nil, reject(err)
+	}
+	existing, err := s.targets.Categories.FindBySlug(ctx, slug.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load category: %w", err)
+	}
+	if existing != nil {
+		e.categories[existing.ID] = existing
+		return &step{newID: existing.ID, matched: true}, nil
+	}
+	if !e.claim(migration.KindCategory, migration.FieldSlug, slug.Value()) {
+		return nil, rejectf("slug %s is used by an earlier legacy category", slug.Value())
+	}
+
+	var parent *taxonomy.Category
+	if legacyParent := rec.Value(migration.FieldParentID); legacyParent != "" && legacyParent != "0" {
+		parentID, err := s.resolve(ctx, e, migration.KindCategory, legacyParent)
+		if err != nil {
+			return nil, err
+		}
+		if parentID == "" {
+			return nil, rejectf("parent category %s is not migrated", legacyParent)
+		}
+		if parent = e.categories[parentID]; parent == nil {
+			if parent, err = s.targets.Categories.FindByID(ctx, parentID); err != nil {
+				return nil, fmt.Errorf("failed to load category: %w", err)
+			}
+		}
+		if parent == nil {
+			return nil, rejectf("parent category %s no longer exists", legacyParent)
+		}
+	}
+	position, err := optionalInt(rec, migration.FieldPosition)
+	if err != nil {
+		return nil, reject(err)
+	}
+	createdAt, err := rec.Time(migration.FieldCreatedAt)
+	if err != nil {
+		return nil, reject(err)
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	c, err := taxonomy.NewCategory(id, *slug, rec.Value(migration.FieldName), rec.Value(migration.FieldDescription), parent, position, e.run.StartedBy)
+	if err != nil {
+		return nil, reject(err)
+	}
+	if createdAt != nil {
+		c.CreatedAt = *createdAt
+	}
+	e.categories[id] = c
+
+	return &step{newID: id, save: func(ctx context.Context) error {
+		return s.targets.Categories.Create(ctx, c)
+	}}, nil
+}
+
+func (s *Service) planTag(ctx context.Context, e *execution, rec migration.Record) (*step, error) {
+	slug, err := taxonomy.NewSlug(rec.Value(migration.FieldSlug))
+	if err != nil {
+		return nil, reject(err)
+	}
+	existing, err := s.targets.Tags.FindBySlug(ctx, slug.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tag: %w", err)
+	}
+	if existing != nil {
+		return &step{newID: existing.ID, matched: true}, nil
+	}
+	if !e.claim(migration.KindTag, migration.FieldSlug, slug.Value()) {
+		return nil, rejectf("slug %s is used by an earlier legacy tag", slug.Value())
+	}
+	createdAt, err := rec.Time(migration.FieldCreatedAt)
+	if err != nil {
+		return nil, reject(err)
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	t, err := taxonomy.NewTag(id, *slug, rec.Value(migration.FieldName), rec.Value(migration.FieldDescription), e.run.StartedBy)
+	if err != nil {
+		return nil, reject(err)
+	}
+	if createdAt != nil {
+		t.CreatedAt = *createdAt
+	}
+
+	return &step{newID: id, save: func(ctx context.Context) error {
+		return s.targets.Tags.Create(ctx, t)
+	}}, nil
+}
+
+// planMedia copies the file into the blob store when the step is saved, a
+// dry run still reads it to check it exists and fits the document limits.
+// Files from legacy users that were not migrated are credited to whoever
+// started the run. Migrated documents wait for the virus scanner like uploads.
+func (s *Service) planMedia(ctx context.Context, e *execution, rec migration.Record) (*step, error) {
+	uploadedBy, err := s.resolve(ctx, e, migration.KindUser, rec.Value(migration.FieldUploadedBy))
+	if err != nil {
+		return nil, err
+	}
+	if uploadedBy == "" {
+		uploadedBy = e.run.StartedBy
+	}
+	createdAt, err := rec.Time(migration.FieldCreatedAt)
+	if err != nil {
+		return nil, reject(err)
+	}
+
+	location := rec.Value(migration.FieldLocation)
+	file, err := s.files.Open(ctx, location)
+	if err != nil {
+		return nil, rejectf("failed to open %s: %w", location, err)
+	}
+	data, err := io.ReadAll(io.LimitReader(file, media.MaxDocumentSize+1))
+	file.Close()
+	if err != nil {
+		return nil, rejectf("failed to read %s: %w", location, err)
+	}
+	sum := sha256.Sum256(data)
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	d, err := media.NewDocument(id, rec.Value(migration.FieldFilename), rec.Value(migration.FieldContentType), int64(len(data)), hex.EncodeToString(sum[:]), "documents/"+id, uploadedBy)
+	if err != nil {
+		return nil, reject(err)
+	}
+	if createdAt != nil {
+		d.CreatedAt = *createdAt
+	}
+
+	return &step{newID: id, save: func(ctx context.Context) error {
+		if err := s.targets.Blobs.Put(ctx, d.StorageKey, bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("failed to store document: %w", err)
+		}
+		return s.targets.Documents.Create(ctx, d)
+	}}, nil
+}
+
+// planArticle brings published and archived stories over in their final
+// state, without a second pass through review. Slugs are kept so permalinks
+// keep working.
+func (s *Service) planArticle(ctx context.Context, e *execution, rec migration.Record) (*step, error) {
+	slug, err := article.NewSlug(rec.Value(migration.FieldSlug))
+	if err != nil {
+		return nil, reject(err)
+	}
+	existing, err := s.targets.Articles.FindBySlug(ctx, slug.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article: %w", err)
+	}
+	if existing != nil || !e.claim(migration.KindArticle, migration.FieldSlug, slug.Value()) {
+		return nil, rejectf("slug %s is already used by another article", slug.Value())
+	}
+
+	legacyAuthor := rec.Value(migration.FieldAuthorID)
+	authorID, err := s.resolve(ctx, e, migration.KindUser, legacyAuthor)
+	if err != nil {
+		return nil, err
+	}
+	if authorID == "" {
+		return nil, rejectf("author %s is not migrated", legacyAuthor)
+	}
+	createdAt, err := rec.Time(migration.FieldCreatedAt)
+	if err != nil {
+		return nil, reject(err)
+	}
+	publishedAt, err := rec.Time(migration.FieldPublishedAt)
+	if err != nil {
+		return nil, reject(err)
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	a, err := article.NewArticle(id, *slug, rec.Value(migration.FieldTitle), rec.Value(migration.FieldSummary), rec.Value(migration.FieldBody), authorID)
+	if err != nil {
+		return nil, reject(err)
+	}
+	if a.Body == "" {
+		return nil, reject(article.ErrBodyEmpty)
+	}
+	if createdAt != nil {
+		a.CreatedAt = *createdAt
+		a.UpdatedAt = *createdAt
+	}
+
+	now := s.clock.Now()
+	switch status := rec.Value(migration.FieldStatus); status {
+	case "", legacyDraft:
+	case legacyPublished, legacyArchived:
+		if publishedAt == nil {
+			publishedAt = &a.CreatedAt
+		}
+		a.Status = article.StatusPublished
+		a.PublishedAt = publishedAt
+		if status == legacyArchived {
+			a.Status = article.StatusArchived
+			a.ArchivedAt = &now
+		}
+	default:
+		return nil, rejectf("%w: status %q", migration.ErrInvalidValue, status)
+	}
+	actor := e.run.StartedBy
+	a.LastActionBy = &actor
+
+	return &step{newID: id, save: func(ctx context.Context) error {
+		return s.targets.Articles.Create(ctx, a)
+	}}, nil
+}
+
+func optionalInt(rec migration.Record, f migration.Field) (int, error) {
+	v := rec.Value(f)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s %q is not a number", migration.ErrInvalidValue, f, v)
+	}
+	return n, nil
+}
@@ -0,0 +1,326 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/taxonomy"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/migration"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// The migration lock keeps a second Start or Resume from writing the same
+// records while a run is going, on this replica or another
+const (
+	LockKey = "migration"
+	LockTTL = time.Minute
+)
+
+// Targets are the repositories migrated records are written to
+type Targets struct {
+	Accounts   account.UserAccountRepository
+	Categories taxonomy.CategoryRepository
+	Tags       taxonomy.TagRepository
+	Documents  media.DocumentRepository
+	Blobs      media.BlobStore
+	Articles   article.ArticleRepository
+}
+
+// Service moves a legacy CMS into this one. Each kind is read in batches in
+// migration order, every record is validated with the same constructors the
+// editors' forms use and saved together with its ID mapping, so a run that
+// stops halfway resumes without creating anything twice.
+type Service struct {
+	config    *migration.Config
+	source    migration.RecordSource
+	files     migration.FileSource
+	mappings  migration.IDMappingRepository
+	runs      migration.RunRepository
+	targets   Targets
+	tx        shared.Transactor
+	locker    shared.Locker
+	clock     shared.Clock
+	batchSize int
+}
+
+func NewService(config *migration.Config, source migration.RecordSource, files migration.FileSource, mappings migration.IDMappingRepository, runs migration.RunRepository, targets Targets, tx shared.Transactor, locker shared.Locker, clock shared.Clock) *Service {
+	return &Service{
+		config:    config,
+		source:    source,
+		files:     files,
+		mappings:  mappings,
+		runs:      runs,
+		targets:   targets,
+		tx:        tx,
+		locker:    locker,
+		clock:     clock,
+		batchSize: migration.DefaultBatchSize,
+	}
+}
+
+// SetBatchSize changes how many records are read and checkpointed at a time, clamped to MaxBatchSize
+func (s *Service) SetBatchSize(n int) {
+	s.batchSize = min(max(n, 1), migration.MaxBatchSize)
+}
+
+// Start begins a run. A dry run validates every record and resolves every
+// reference without writing anything but the run, whose issues are the
+// validation report. It returns shared.ErrLocked while another run is going.
+func (s *Service) Start(ctx context.Context, actorID string, dryRun bool) (*migration.Run, error) {
+	return s.locked(ctx, func(ctx context.Context) (*migration.Run, error) {
+		id, err := shared.GenerateUUID()
+		if err != nil {
+			return nil, err
+		}
+		run, err := migration.NewRun(id, actorID, dryRun, s.clock.Now())
+		if err != nil {
+			return nil, err
+		}
+		if err := s.runs.Create(ctx, run); err != nil {
+			return nil, fmt.Errorf("failed to save migration run: %w", err)
+		}
+		return s.execute(ctx, run)
+	})
+}
+
+// Resume continues a failed or interrupted run from its cursors
+func (s *Service) Resume(ctx context.Context, runID string) (*migration.Run, error) {
+	return s.locked(ctx, func(ctx context.Context) (*migration.Run, error) {
+		run, err := s.runs.FindByID(ctx, runID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load migration run: %w", err)
+		}
+		if run == nil {
+			return nil, migration.ErrRunNotFound
+		}
+		if err := run.Resume(s.clock.Now()); err != nil {
+			return nil, err
+		}
+		if err := s.runs.Update(ctx, run); err != nil {
+			return nil, fmt.Errorf("failed to save migration run: %w", err)
+		}
+		return s.execute(ctx, run)
+	})
+}
+
+// locked runs a Start or Resume under the migration lock. A lost lease
+// cancels the context, the run then stops as failed and can be resumed.
+func (s *Service) locked(ctx context.Context, fn func(ctx context.Context) (*migration.Run, error)) (*migration.Run, error) {
+	var run *migration.Run
+	err := s.locker.Run(ctx, LockKey, LockTTL, func(ctx context.Context) error {
+		var err error
+		run, err = fn(ctx)
+		return err
+	})
+	return run, err
+}
+
+// execution is the state of one Start or Resume call
+type execution struct {
+	run *migration.Run
+	// planned holds the IDs a dry run would have mapped, so later kinds resolve references to them
+	planned map[migration.Kind]map[string]string
+	// claimed holds the slugs, usernames and emails taken during the call, to catch legacy duplicates
+	claimed map[string]bool
+	// categories keeps the categories of the call, children need their parent's ancestors
+	categories map[string]*taxonomy.Category
+}
+
+func (e *execution) claim(kind migration.Kind, field migration.Field, value string) bool {
+	key := string(kind) + "/" + string(field) + "/" + value
+	if e.claimed[key] {
+		return false
+	}
+	e.claimed[key] = true
+	return true
+}
+
+// step is a validated record, ready to save
+type step struct {
+	newID   string
+	matched bool // Mapped to an existing entity, nothing to save
+	save    func(ctx context.Context) error
+}
+
+// rejected marks a legacy record as invalid, the run records it and moves on
+type rejected struct {
+	err error
+}
+
+func (r rejected) Error() string { return r.err.Error() }
+func (r rejected) Unwrap() error { return r.err }
+
+func reject(err error) error {
+	return rejected{err: err}
+}
+
+func rejectf(format string, args ...any) error {
+	return rejected{err: fmt.Errorf(format, args...)}
+}
+
+func (s *Service) execute(ctx context.Context, run *migration.Run) (*migration.Run, error) {
+	e := &execution{
+		run:        run,
+		planned:    make(map[migration.Kind]map[string]string),
+		claimed:    make(map[string]bool),
+		categories: make(map[string]*taxonomy.Category),
+	}
+	for _, kind := range s.config.Kinds() {
+		for {
+			records, err := s.source.Fetch(ctx, kind, run.Cursor(kind), s.batchSize)
+			if err != nil {
+				return s.fail(ctx, run, fmt.Errorf("failed to read legacy %s: %w", kind, err))
+			}
+			for _, rec := range records {
+				if err := s.migrate(ctx, e, rec); err != nil {
+					return s.fail(ctx, run, fmt.Errorf("failed to migrate %s %s: %w", kind, rec.LegacyID, err))
+				}
+			}
+			if len(records) > 0 {
+				run.Advance(kind, records[len(records)-1].LegacyID, s.clock.Now())
+				if err := s.runs.Update(ctx, run); err != nil {
+					return nil, fmt.Errorf("failed to save migration run: %w", err)
+				}
+			}
+			if len(records) < s.batchSize {
+				break
+			}
+		}
+	}
+
+	if err := run.Complete(s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.runs.Update(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to save migration run: %w", err)
+	}
+	return run, nil
+}
+
+// fail saves the stopped run with a fresh context, the caller's may be the reason it stopped
+func (s *Service) fail(ctx context.Context, run *migration.Run, cause error) (*migration.Run, error) {
+	run.Fail(cause, s.clock.Now())
+	if err := s.runs.Update(context.WithoutCancel(ctx), run); err != nil {
+		return run, errors.Join(cause, fmt.Errorf("failed to save migration run: %w", err))
+	}
+	return run, cause
+}
+
+func (s *Service) migrate(ctx context.Context, e *execution, rec migration.Record) error {
+	stats := e.run.Stat(rec.Kind)
+	stats.Fetched++
+
+	existing, err := s.mappings.FindNewID(ctx, rec.Kind, rec.LegacyID)
+	if err != nil {
+		return fmt.Errorf("failed to load ID mapping: %w", err)
+	}
+	if existing != "" {
+		stats.Skipped++
+		return nil
+	}
+
+	var st *step
+	switch rec.Kind {
+	case migration.KindUser:
+		st, err = s.planUser(ctx, e, rec)
+	case migration.KindCategory:
+		st, err = s.planCategory(ctx, e, rec)
+	case migration.KindTag:
+		st, err = s.planTag(ctx, e, rec)
+	case migration.KindMedia:
+		st, err = s.planMedia(ctx, e, rec)
+	case migration.KindArticle:
+		st, err = s.planArticle(ctx, e, rec)
+	default:
+		err = reject(migration.ErrUnknownKind)
+	}
+	if r := (rejected{}); errors.As(err, &r) {
+		e.run.Reject(rec.Kind, rec.LegacyID, r.err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if e.run.DryRun {
+		if e.planned[rec.Kind] == nil {
+			e.planned[rec.Kind] = make(map[string]string)
+		}
+		e.planned[rec.Kind][rec.LegacyID] = st.newID
+	} else {
+		mapping, err := migration.NewIDMapping(rec.Kind, rec.LegacyID, st.newID, e.run.ID, st.matched, s.clock.Now())
+		if err != nil {
+			return err
+		}
+		err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if st.save != nil {
+				if err := st.save(ctx); err != nil {
+					return err
+				}
+			}
+			return s.mappings.Create(ctx, mapping)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if st.matched {
+		stats.Matched++
+	} else {
+		stats.Migrated++
+	}
+	return nil
+}
+
+// resolve translates a legacy reference into the CMS ID, "" when the
+// referenced record is not migrated
+func (s *Service) resolve(ctx context.Context, e *execution, kind migration.Kind, legacyID string) (string, error) {
+	if legacyID == "" {
+		return "", nil
+	}
+	if id, ok := e.planned[kind][legacyID]; ok {
+		return id, nil
+	}
+	id, err := s.mappings.FindNewID(ctx, kind, legacyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load ID mapping: %w", err)
+	}
+	return id, nil
+}
+
+// CutoverReport compares every configured kind between the legacy database
+// and the ID mappings, with the issues of the latest real run
+func (s *Service) CutoverReport(ctx context.Context) (*migration.CutoverReport, error) {
+	latest, err := s.runs.FindLatest(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migration run: %w", err)
+	}
+
+	report := &migration.CutoverReport{GeneratedAt: s.clock.Now()}
+	if latest != nil {
+		report.RunID = latest.ID
+		report.Issues = latest.Issues
+	}
+	for _, kind := range s.config.Kinds() {
+		sourceCount, err := s.source.Count(ctx, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count legacy %s: %w", kind, err)
+		}
+		mapped, err := s.mappings.Count(ctx, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s mappings: %w", kind, err)
+		}
+		k := migration.KindReport{Kind: kind, SourceCount: sourceCount, Mapped: mapped}
+		if latest != nil {
+			k.Invalid = latest.Stat(kind).Invalid
+		}
+		report.Kinds = append(report.Kinds, k)
+	}
+	return report, nil
+}
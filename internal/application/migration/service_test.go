@@ -0,0 +1,508 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/taxonomy"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/migration"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// fakeSource serves records sorted by legacy ID, failing once at failAt
+type fakeSource struct {
+	records map[migration.Kind][]migration.Record
+	failAt  string
+}
+
+func (s *fakeSource) add(kind migration.Kind, fields map[migration.Field]string) {
+	if s.records == nil {
+		s.records = make(map[migration.Kind][]migration.Record)
+	}
+	s.records[kind] = append(s.records[kind], migration.Record{Kind: kind, LegacyID: fields[migration.FieldID], Fields: fields})
+	sort.Slice(s.records[kind], func(i, j int) bool { return s.records[kind][i].LegacyID < s.records[kind][j].LegacyID })
+}
+
+func (s *fakeSource) Count(ctx context.Context, kind migration.Kind) (int64, error) {
+	return int64(len(s.records[kind])), nil
+}
+
+func (s *fakeSource) Fetch(ctx context.Context, kind migration.Kind, afterID string, limit int) ([]migration.Record, error) {
+	var page []migration.Record
+	for _, r := range s.records[kind] {
+		if r.LegacyID <= afterID {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		if s.failAt != "" && r.LegacyID == s.failAt {
+			s.failAt = ""
+			return nil, errors.New("connection reset")
+		}
+		page = append(page, r)
+	}
+	return page, nil
+}
+
+type fakeFiles map[string]string
+
+func (f fakeFiles) Open(ctx context.Context, location string) (io.ReadCloser, error) {
+	content, ok := f[location]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+type memoryMappings struct {
+	byKey map[string]*migration.IDMapping
+}
+
+func (m *memoryMappings) Create(ctx context.Context, mapping *migration.IDMapping) error {
+	if m.byKey == nil {
+		m.byKey = make(map[string]*migration.IDMapping)
+	}
+	m.byKey[string(mapping.Kind)+"/"+mapping.LegacyID] = mapping
+	return nil
+}
+
+func (m *memoryMappings) FindNewID(ctx context.Context, kind migration.Kind, legacyID string) (string, error) {
+	if mapping, ok := m.byKey[string(kind)+"/"+legacyID]; ok {
+		return mapping.NewID, nil
+	}
+	return "", nil
+}
+
+func (m *memoryMappings) Count(ctx context.Context, kind migration.Kind) (int64, error) {
+	var n int64
+	for _, mapping := range m.byKey {
+		if mapping.Kind == kind {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type memoryRuns struct {
+	runs []*migration.Run
+}
+
+func (m *memoryRuns) Create(ctx context.Context, run *migration.Run) error {
+	m.runs = append(m.runs, run)
+	return nil
+}
+
+func (m *memoryRuns) Update(ctx context.Context, run *migration.Run) error { return nil }
+
+func (m *memoryRuns) FindByID(ctx context.Context, id string) (*migration.Run, error) {
+	for _, r := range m.runs {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryRuns) FindLatest(ctx context.Context, dryRun bool) (*migration.Run, error) {
+	for i := len(m.runs) - 1; i >= 0; i-- {
+		if m.runs[i].DryRun == dryRun {
+			return m.runs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+type memoryAccounts struct {
+	account.UserAccountRepository
+	byID map[string]*account.UserAccount
+}
+
+func (m *memoryAccounts) Create(ctx context.Context, ua *account.UserAccount) error {
+	m.byID[ua.ID] = ua
+	return nil
+}
+
+func (m *memoryAccounts) FindByEmail(ctx context.Context, email string) (*account.UserAccount, error) {
+	for _, ua := range m.byID {
+		if ua.Email.String() == strings.ToLower(email) {
+			return ua, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAccounts) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	for _, ua := range m.byID {
+		if ua.Username.String() == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type memoryCategories struct {
+	taxonomy.CategoryRepository
+	byID map[string]*taxonomy.Category
+}
+
+func (m *memoryCategories) Create(ctx context.Context, c *taxonomy.Category) error {
+	m.byID[c.ID] = c
+	return nil
+}
+
+func (m *memoryCategories) FindByID(ctx context.Context, id string) (*taxonomy.Category, error) {
+	return m.byID[id], nil
+}
+
+func (m *memoryCategories) FindBySlug(ctx context.Context, slug string) (*taxonomy.Category, error) {
+	for _, c := range m.byID {
+		if c.Slug.Value() == slug {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+type memoryTags struct {
+	taxonomy.TagRepository
+	byID map[string]*taxonomy.Tag
+}
+
+func (m *memoryTags) Create(ctx context.Context, t *taxonomy.Tag) error {
+	m.byID[t.ID] = t
+	return nil
+}
+
+func (m *memoryTags) FindBySlug(ctx context.Context, slug string) (*taxonomy.Tag, error) {
+	for _, t := range m.byID {
+		if t.Slug.Value() == slug {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+type memoryDocuments struct {
+	media.DocumentRepository
+	byID map[string]*media.Document
+}
+
+func (m *memoryDocuments) Create(ctx context.Context, d *media.Document) error {
+	m.byID[d.ID] = d
+	return nil
+}
+
+type memoryBlobs struct {
+	media.BlobStore
+	blobs map[string][]byte
+}
+
+func (m *memoryBlobs) Put(ctx context.Context, key string, content io.Reader) error {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, content); err != nil {
+		return err
+	}
+	m.blobs[key] = buf.Bytes()
+	return nil
+}
+
+type memoryArticles struct {
+	article.ArticleRepository
+	byID map[string]*article.Article
+}
+
+func (m *memoryArticles) Create(ctx context.Context, a *article.Article) error {
+	m.byID[a.ID] = a
+	return nil
+}
+
+func (m *memoryArticles) FindBySlug(ctx context.Context, slug string) (*article.Article, error) {
+	for _, a := range m.byID {
+		if a.Slug.Value() == slug {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+type fixture struct {
+	source     *fakeSource
+	mappings   *memoryMappings
+	runs       *memoryRuns
+	accounts   *memoryAccounts
+	categories *memoryCategories
+	tags       *memoryTags
+	documents  *memoryDocuments
+	blobs      *memoryBlobs
+	articles   *memoryArticles
+	service    *Service
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	columns := func(fields ...migration.Field) map[migration.Field]string {
+		m := make(map[migration.Field]string, len(fields))
+		for _, f := range fields {
+			m[f] = string(f)
+		}
+		return m
+	}
+	config, err := migration.NewConfig(map[migration.Kind]migration.Source{
+		migration.KindUser:     {Query: "SELECT * FROM users", Columns: columns(migration.KindUser.Fields()...)},
+		migration.KindCategory: {Query: "SELECT * FROM sections", Columns: columns(migration.KindCategory.Fields()...)},
+		migration.KindTag:      {Query: "SELECT * FROM tags", Columns: columns(migration.KindTag.Fields()...)},
+		migration.KindMedia:    {Query: "SELECT * FROM files", Columns: columns(migration.KindMedia.Fields()...)},
+		migration.KindArticle:  {Query: "SELECT * FROM stories", Columns: columns(migration.KindArticle.Fields()...)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	source := &fakeSource{}
+	source.add(migration.KindUser, map[migration.Field]string{
+		"id": "u1", "username": "sari", "email": "sari@example.com", "password_hash": "$P$Bphpass", "created_at": "2015-06-01 08:00:00",
+	})
+	source.add(migration.KindUser, map[migration.Field]string{
+		"id": "u2", "username": "budi", "email": "Budi@Example.com", "password_hash": "$P$Bother", "status": "disabled",
+	})
+	source.add(migration.KindUser, map[migration.Field]string{
+		"id": "u3", "username": "budi_lagi", "email": "budi@example.com", "password_hash": "$P$Bthird",
+	})
+	source.add(migration.KindCategory, map[migration.Field]string{"id": "c1", "slug": "nasional", "name": "Nasional", "parent_id": "0"})
+	source.add(migration.KindCategory, map[migration.Field]string{"id": "c2", "slug": "politik", "name": "Politik", "parent_id": "c1", "position": "2"})
+	source.add(migration.KindCategory, map[migration.Field]string{"id": "c3", "slug": "hukum", "name": "Hukum", "parent_id": "c9"})
+	source.add(migration.KindTag, map[migration.Field]string{"id": "t1", "slug": "banjir", "name": "Banjir"})
+	source.add(migration.KindTag, map[migration.Field]string{"id": "t2", "slug": "Not A Slug!", "name": "Broken"})
+	source.add(migration.KindMedia, map[migration.Field]string{
+		"id": "m1", "filename": "laporan.pdf", "content_type": "application/pdf", "location": "2019/laporan.pdf", "uploaded_by": "u1",
+	})
+	source.add(migration.KindMedia, map[migration.Field]string{
+		"id": "m2", "filename": "gone.pdf", "content_type": "application/pdf", "location": "2019/gone.pdf", "uploaded_by": "u1",
+	})
+	source.add(migration.KindArticle, map[migration.Field]string{
+		"id": "a1", "slug": "banjir-jakarta", "title": "Banjir Jakarta", "body": "<p>Air naik</p>", "author_id": "u1",
+		"status": "published", "published_at": "2019-04-02 08:15:00", "created_at": "2019-04-01 20:00:00",
+	})
+	source.add(migration.KindArticle, map[migration.Field]string{
+		"id": "a2", "slug": "draf", "title": "Draf", "body": "Belum selesai", "author_id": "u2",
+	})
+	source.add(migration.KindArticle, map[migration.Field]string{
+		"id": "a3", "slug": "yatim", "title": "Yatim", "body": "Tanpa penulis", "author_id": "u404",
+	})
+
+	f := &fixture{
+		source:     source,
+		mappings:   &memoryMappings{},
+		runs:       &memoryRuns{},
+		accounts:   &memoryAccounts{byID: map[string]*account.UserAccount{}},
+		categories: &memoryCategories{byID: map[string]*taxonomy.Category{}},
+		tags:       &memoryTags{byID: map[string]*taxonomy.Tag{}},
+		documents:  &memoryDocuments{byID: map[string]*media.Document{}},
+		blobs:      &memoryBlobs{blobs: map[string][]byte{}},
+		articles:   &memoryArticles{byID: map[string]*article.Article{}},
+	}
+	files := fakeFiles{"2019/laporan.pdf": "%PDF-1.4 laporan"}
+	targets := Targets{Accounts: f.accounts, Categories: f.categories, Tags: f.tags, Documents: f.documents, Blobs: f.blobs, Articles: f.articles}
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC))
+	f.service = NewService(config, source, files, f.mappings, f.runs, targets, shared.NoTransaction{}, shared.NoLock{}, clock)
+	f.service.SetBatchSize(2)
+	return f
+}
+
+func reasons(run *migration.Run) map[string]string {
+	found := make(map[string]string, len(run.Issues))
+	for _, issue := range run.Issues {
+		found[issue.LegacyID] = issue.Reason
+	}
+	return found
+}
+
+func TestService_DryRun(t *testing.T) {
+	f := newFixture(t)
+	run, err := f.service.Start(context.Background(), "admin-1", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Status != migration.RunCompleted {
+		t.Fatalf("expected a completed run, got %+v", run)
+	}
+	if len(f.mappings.byKey)+len(f.accounts.byID)+len(f.articles.byID)+len(f.blobs.blobs) != 0 {
+		t.Error("expected a dry run to write nothing")
+	}
+
+	// References to records the dry run would create resolve, bad ones are reported
+	found := reasons(run)
+	for _, id := range []string{"u3", "c3", "t2", "m2", "a3"} {
+		if found[id] == "" {
+			t.Errorf("expected an issue for %s, got %v", id, found)
+		}
+	}
+	if len(found) != 5 {
+		t.Errorf("expected 5 issues, got %v", found)
+	}
+	if s := run.Stat(migration.KindArticle); s.Migrated != 2 || s.Invalid != 1 || s.Fetched != 3 {
+		t.Errorf("unexpected article stats %+v", s)
+	}
+	if run.Cursor(migration.KindArticle) != "a3" {
+		t.Errorf("expected the cursor at the last article, got %q", run.Cursor(migration.KindArticle))
+	}
+}
+
+func TestService_Migrate(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	run, err := f.service.Start(ctx, "admin-1", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(f.accounts.byID) != 2 || len(f.categories.byID) != 2 || len(f.tags.byID) != 1 || len(f.documents.byID) != 1 || len(f.articles.byID) != 2 {
+		t.Fatalf("unexpected migrated entities, issues %v", reasons(run))
+	}
+
+	sariID, _ := f.mappings.FindNewID(ctx, migration.KindUser, "u1")
+	sari := f.accounts.byID[sariID]
+	if !sari.IsActive() || sari.PasswordHash.Encoded() != "$P$Bphpass" || sari.CreatedAt.Year() != 2015 {
+		t.Errorf("expected sari active with the legacy hash, got %+v", sari)
+	}
+	budiID, _ := f.mappings.FindNewID(ctx, migration.KindUser, "u2")
+	if !f.accounts.byID[budiID].IsDisabled() {
+		t.Error("expected budi disabled like in the legacy CMS")
+	}
+
+	nasionalID, _ := f.mappings.FindNewID(ctx, migration.KindCategory, "c1")
+	politikID, _ := f.mappings.FindNewID(ctx, migration.KindCategory, "c2")
+	if politik := f.categories.byID[politikID]; politik.ParentID == nil || *politik.ParentID != nasionalID || politik.Position != 2 {
+		t.Errorf("expected politik under nasional, got %+v", politik)
+	}
+
+	documentID, _ := f.mappings.FindNewID(ctx, migration.KindMedia, "m1")
+	document := f.documents.byID[documentID]
+	if document.UploadedBy != sariID || document.ScanStatus != media.ScanPending || string(f.blobs.blobs[document.StorageKey]) != "%PDF-1.4 laporan" {
+		t.Errorf("unexpected document %+v", document)
+	}
+
+	articleID, _ := f.mappings.FindNewID(ctx, migration.KindArticle, "a1")
+	story := f.articles.byID[articleID]
+	if story.Status != article.StatusPublished || story.AuthorID != sariID || story.PublishedAt == nil || story.PublishedAt.Day() != 2 {
+		t.Errorf("expected a1 published by sari on its legacy date, got %+v", story)
+	}
+	draftID, _ := f.mappings.FindNewID(ctx, migration.KindArticle, "a2")
+	if f.articles.byID[draftID].Status != article.StatusDraft {
+		t.Error("expected a2 kept as a draft")
+	}
+
+	report, err := f.service.CutoverReport(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Ready() || report.RunID != run.ID || len(report.Issues) != 5 {
+		t.Errorf("expected the rejected records to block the cutover, got %+v", report)
+	}
+
+	// A second run finds everything mapped, records added since are picked up
+	f.source.add(migration.KindTag, map[migration.Field]string{"id": "t3", "slug": "pemilu", "name": "Pemilu"})
+	again, err := f.service.Start(ctx, "admin-1", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := again.Stat(migration.KindTag); s.Skipped != 1 || s.Migrated != 1 {
+		t.Errorf("expected t1 skipped and t3 migrated, got %+v", s)
+	}
+	if len(f.accounts.byID) != 2 || len(f.articles.byID) != 2 {
+		t.Error("expected nothing migrated twice")
+	}
+}
+
+func TestService_MatchesExisting(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	staff, _ := account.NewUserAccountWithHash("staff-1", "sari_redaksi", "sari@example.com", "bcrypt-hash", account.TypeInternal, "admin-1")
+	f.accounts.byID[staff.ID] = staff
+	slug, _ := taxonomy.NewSlug("nasional")
+	existing, _ := taxonomy.NewCategory("cat-1", *slug, "Nasional", "", nil, 0, "admin-1")
+	f.categories.byID[existing.ID] = existing
+
+	run, err := f.service.Start(ctx, "admin-1", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id, _ := f.mappings.FindNewID(ctx, migration.KindUser, "u1"); id != "staff-1" || run.Stat(migration.KindUser).Matched != 1 {
+		t.Errorf("expected u1 matched to the staff account, got %q", id)
+	}
+	politikID, _ := f.mappings.FindNewID(ctx, migration.KindCategory, "c2")
+	if politik := f.categories.byID[politikID]; politik.ParentID == nil || *politik.ParentID != "cat-1" {
+		t.Errorf("expected politik under the existing category, got %+v", politik)
+	}
+	if f.articlesBy("staff-1") != 1 {
+		t.Error("expected a1 credited to the staff account")
+	}
+}
+
+func (f *fixture) articlesBy(authorID string) int {
+	n := 0
+	for _, a := range f.articles.byID {
+		if a.AuthorID == authorID {
+			n++
+		}
+	}
+	return n
+}
+
+func TestService_Resume(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	f.source.failAt = "c3"
+
+	run, err := f.service.Start(ctx, "admin-1", false)
+	if err == nil || run.Status != migration.RunFailed {
+		t.Fatalf("expected the run to fail on the legacy read, got %v", err)
+	}
+	if run.Cursor(migration.KindCategory) != "c2" || len(f.categories.byID) != 2 || len(f.articles.byID) != 0 {
+		t.Fatalf("expected the run stopped after the first category batch, got cursor %q", run.Cursor(migration.KindCategory))
+	}
+
+	resumed, err := f.service.Resume(ctx, run.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resumed.Status != migration.RunCompleted || len(f.categories.byID) != 2 || len(f.articles.byID) != 2 {
+		t.Errorf("expected the run finished without duplicates, got %+v", resumed)
+	}
+	if s := resumed.Stat(migration.KindUser); s.Fetched != 3 || s.Migrated != 2 {
+		t.Errorf("expected users handled once, got %+v", s)
+	}
+
+	if _, err := f.service.Resume(ctx, "missing"); err != migration.ErrRunNotFound {
+		t.Errorf("expected ErrRunNotFound, got %v", err)
+	}
+}
+
+// heldLocker is a lock another replica holds
+type heldLocker struct{}
+
+func (heldLocker) Run(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	return shared.ErrLocked
+}
+
+func TestService_Locked(t *testing.T) {
+	f := newFixture(t)
+	f.service.locker = heldLocker{}
+
+	if _, err := f.service.Start(context.Background(), "admin-1", false); !errors.Is(err, shared.ErrLocked) {
+		t.Fatalf("expected error '%v', got '%v'", shared.ErrLocked, err)
+	}
+	if len(f.runs.runs) != 0 || len(f.categories.byID) != 0 {
+		t.Errorf("expected nothing written while another run holds the lock, got %d runs", len(f.runs.runs))
+	}
+}
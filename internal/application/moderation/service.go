@@ -0,0 +1,257 @@
+package moderation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/moderation"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrReviewNotFound  = errors.New("review not found")
+	ErrCommentNotFound = errors.New("comment not found")
+)
+
+// HeatWindow is how far back the heated discussion signal looks
+const HeatWindow = 3 * time.Hour
+
+// Comments is the part of comment storage moderation needs, comment.CommentRepository implements it
+type Comments interface {
+	FindByID(ctx context.Context, id string) (*comment.Comment, error)
+	Update(ctx context.Context, comment *comment.Comment) error
+}
+
+type Service struct {
+	reviews      moderation.ReviewRepository
+	profiles     moderation.ProfileRepository
+	dictionaries moderation.DictionaryRepository
+	comments     Comments
+	scorer       moderation.ToxicityScorer
+	thresholds   moderation.Thresholds
+}
+
+func NewService(reviews moderation.ReviewRepository, profiles moderation.ProfileRepository, dictionaries moderation.DictionaryRepository, comments Comments, scorer moderation.ToxicityScorer, thresholds moderation.Thresholds) *Service {
+	return &Service{reviews: reviews, profiles: profiles, dictionaries: dictionaries, comments: comments, scorer: scorer, thresholds: thresholds}
+}
+
+// Screen queues a new comment for review: it detects the language, scores its
+// toxicity, holds the comment when it matches that language's banned words or
+// scores over the hold threshold, approves it when the provider found it
+// harmless, and otherwise assigns it to a moderator who reads the language.
+// Comments that are not held are published right away and reviewed afterwards,
+// except on articles with preModeration, where every comment waits for a moderator.
+func (s *Service) Screen(ctx context.Context, c *comment.Comment, preModeration bool, at time.Time) (*moderation.Review, error) {
+	language := moderation.Detect(c.Body)
+	matches, err := s.bannedWords(ctx, language, c.Body)
+	if err != nil {
+		return nil, err
+	}
+	scores := s.score(ctx, c.Body, language, matches)
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	review, err := moderation.NewReview(id, c.ID, c.ArticleID, language, matches, at)
+	if err != nil {
+		return nil, err
+	}
+	review.Held = review.Held || preModeration
+	review.ApplyScores(scores, s.thresholds, at)
+	if !review.Held {
+		if err := c.Approve(moderation.AutoModeratorID, at); err != nil {
+			return nil, err
+		}
+		if err := s.comments.Update(ctx, c); err != nil {
+			return nil, fmt.Errorf("failed to publish comment: %w", err)
+		}
+	}
+
+	if review.Status == moderation.ReviewPending && language != moderation.LanguageUnknown {
+		profiles, err := s.profiles.FindByLanguage(ctx, language)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load moderator profiles: %w", err)
+		}
+		if len(profiles) > 0 {
+			staffIDs := make([]string, len(profiles))
+			for i, p := range profiles {
+				staffIDs[i] = p.StaffID
+			}
+			open, err := s.reviews.CountPending(ctx, staffIDs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count open reviews: %w", err)
+			}
+			if picked := moderation.Route(language, profiles, open); picked != nil {
+				if err := review.Assign(picked.StaffID, at); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if err := s.reviews.Create(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+	return review, nil
+}
+
+// score asks the provider, falling back to the heuristic when it fails. Only the
+// toxicity comes from the provider; sentiment always comes from the lexicon.
+func (s *Service) score(ctx context.Context, body string, language moderation.Language, matches []string) moderation.Scores {
+	fallback := moderation.HeuristicScore(body, matches)
+	toxicity, err := s.scorer.Toxicity(ctx, body, language)
+	if err != nil {
+		return fallback
+	}
+	return moderation.Scores{Toxicity: toxicity, Sentiment: fallback.Sentiment, Source: moderation.SourceProvider}
+}
+
+// bannedWords checks the detected language's dictionary, or every dictionary
+// when the language is unknown so short insults are not waved through
+func (s *Service) bannedWords(ctx context.Context, language moderation.Language, body string) ([]string, error) {
+	languages := []moderation.Language{language}
+	if language == moderation.LanguageUnknown {
+		languages = moderation.Languages
+	}
+	var matches []string
+	for _, l := range languages {
+		dictionary, err := s.dictionaries.FindByLanguage(ctx, l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load banned words: %w", err)
+		}
+		if dictionary != nil {
+			matches = append(matches, dictionary.Match(body)...)
+		}
+	}
+	return matches, nil
+}
+
+// Queue returns the staff member's pending reviews, a nil staffID returns the general queue
+func (s *Service) Queue(ctx context.Context, staffID *string, limit, offset int) ([]*moderation.Review, error) {
+	if limit < 1 || limit > 100 {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+	reviews, err := s.reviews.FindPending(ctx, staffID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load moderation queue: %w", err)
+	}
+	return reviews, nil
+}
+
+// Resolve approves or removes the comment under review. Approving a held
+// comment publishes it, removing rejects it.
+func (s *Service) Resolve(ctx context.Context, staffID, reviewID string, approve bool, at time.Time) (*moderation.Review, error) {
+	review, err := s.reviews.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load review: %w", err)
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+	c, err := s.comments.FindByID(ctx, review.CommentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comment: %w", err)
+	}
+	if c == nil {
+		return nil, ErrCommentNotFound
+	}
+	if err := review.Resolve(staffID, approve, at); err != nil {
+		return nil, err
+	}
+
+	changed := false
+	switch {
+	case approve && (c.Status == comment.StatusPending || c.Status == comment.StatusRejected):
+		changed = c.Approve(staffID, at) == nil
+	case !approve && (c.Status == comment.StatusPending || c.Status == comment.StatusApproved):
+		changed = c.Reject(staffID, at) == nil
+	}
+	if changed {
+		if err := s.comments.Update(ctx, c); err != nil {
+			return nil, fmt.Errorf("failed to update comment: %w", err)
+		}
+	}
+	if err := s.reviews.Update(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+	return review, nil
+}
+
+// HeatedDiscussions returns the articles whose comments turned heated in the
+// last HeatWindow, the most toxic first
+func (s *Service) HeatedDiscussions(ctx context.Context, at time.Time) ([]moderation.DiscussionHeat, error) {
+	scores, err := s.reviews.FindScoresSince(ctx, at.Add(-HeatWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comment scores: %w", err)
+	}
+	var heated []moderation.DiscussionHeat
+	for articleID, articleScores := range scores {
+		if heat := moderation.AssessHeat(articleID, articleScores); heat.Heated {
+			heated = append(heated, heat)
+		}
+	}
+	slices.SortFunc(heated, func(a, b moderation.DiscussionHeat) int {
+		if c := cmp.Compare(b.MeanToxicity, a.MeanToxicity); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ArticleID, b.ArticleID)
+	})
+	return heated, nil
+}
+
+func (s *Service) Profiles(ctx context.Context) ([]*moderation.Profile, error) {
+	profiles, err := s.profiles.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load moderator profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+// SetLanguages records the languages a moderator reads on their staff profile
+func (s *Service) SetLanguages(ctx context.Context, editorID, staffID string, languages []string, at time.Time) (*moderation.Profile, error) {
+	profile, err := moderation.NewProfile(staffID, languages, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.profiles.Save(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to save moderator profile: %w", err)
+	}
+	return profile, nil
+}
+
+// Dictionary returns a language's banned words, empty until some are saved
+func (s *Service) Dictionary(ctx context.Context, language string) (*moderation.Dictionary, error) {
+	l, err := moderation.ParseLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+	dictionary, err := s.dictionaries.FindByLanguage(ctx, l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load banned words: %w", err)
+	}
+	if dictionary == nil {
+		return &moderation.Dictionary{Language: l}, nil
+	}
+	return dictionary, nil
+}
+
+func (s *Service) SetDictionary(ctx context.Context, editorID, language string, words []string, at time.Time) (*moderation.Dictionary, error) {
+	l, err := moderation.ParseLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+	dictionary, err := moderation.NewDictionary(l, words, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.dictionaries.Save(ctx, dictionary); err != nil {
+		return nil, fmt.Errorf("failed to save banned words: %w", err)
+	}
+	return dictionary, nil
+}
@@ -0,0 +1,337 @@
+package moderation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/moderation"
+)
+
+type memoryReviews struct {
+	reviews map[string]*moderation.Review
+}
+
+func (m *memoryReviews) Create(ctx context.Context, r *moderation.Review) error {
+	m.reviews[r.ID] = r
+	return nil
+}
+
+func (m *memoryReviews) Update(ctx context.Context, r *moderation.Review) error {
+	m.reviews[r.ID] = r
+	return nil
+}
+
+func (m *memoryReviews) FindByID(ctx context.Context, id string) (*moderation.Review, error) {
+	return m.reviews[id], nil
+}
+
+func (m *memoryReviews) FindPending(ctx context.Context, assigneeID *string, limit, offset int) ([]*moderation.Review, error) {
+	var found []*moderation.Review
+	for _, r := range m.reviews {
+		if r.Status != moderation.ReviewPending || (r.AssigneeID == nil) != (assigneeID == nil) {
+			continue
+		}
+		if assigneeID == nil || *r.AssigneeID == *assigneeID {
+			found = append(found, r)
+		}
+	}
+	slices.SortFunc(found, func(a, b *moderation.Review) int {
+		if c := cmp.Compare(b.Scores.Toxicity, a.Scores.Toxicity); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return found, nil
+}
+
+func (m *memoryReviews) CountPending(ctx context.Context, staffIDs []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, r := range m.reviews {
+		if r.Status == moderation.ReviewPending && r.AssigneeID != nil {
+			counts[*r.AssigneeID]++
+		}
+	}
+	return counts, nil
+}
+
+func (m *memoryReviews) FindScoresSince(ctx context.Context, since time.Time) (map[string][]moderation.Scores, error) {
+	scores := map[string][]moderation.Scores{}
+	for _, r := range m.reviews {
+		if !r.CreatedAt.Before(since) {
+			scores[r.ArticleID] = append(scores[r.ArticleID], r.Scores)
+		}
+	}
+	return scores, nil
+}
+
+// fakeScorer knows the toxicity of some bodies, the rest fail as an unsupported language would
+type fakeScorer map[string]float64
+
+func (f fakeScorer) Toxicity(ctx context.Context, text string, language moderation.Language) (float64, error) {
+	toxicity, ok := f[text]
+	if !ok {
+		return 0, errors.New("language not supported")
+	}
+	return toxicity, nil
+}
+
+type memoryProfiles struct {
+	profiles []*moderation.Profile
+}
+
+func (m *memoryProfiles) Save(ctx context.Context, p *moderation.Profile) error {
+	m.profiles = append(m.profiles, p)
+	return nil
+}
+
+func (m *memoryProfiles) FindByStaff(ctx context.Context, staffID string) (*moderation.Profile, error) {
+	for _, p := range m.profiles {
+		if p.StaffID == staffID {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryProfiles) FindByLanguage(ctx context.Context, language moderation.Language) ([]*moderation.Profile, error) {
+	var found []*moderation.Profile
+	for _, p := range m.profiles {
+		if p.Speaks(language) {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryProfiles) FindAll(ctx context.Context) ([]*moderation.Profile, error) {
+	return m.profiles, nil
+}
+
+type memoryDictionaries struct {
+	dictionaries map[moderation.Language]*moderation.Dictionary
+}
+
+func (m *memoryDictionaries) Save(ctx context.Context, d *moderation.Dictionary) error {
+	m.dictionaries[d.Language] = d
+	return nil
+}
+
+func (m *memoryDictionaries) FindByLanguage(ctx context.Context, language moderation.Language) (*moderation.Dictionary, error) {
+	return m.dictionaries[language], nil
+}
+
+type memoryComments struct {
+	comments map[string]*comment.Comment
+}
+
+func (m *memoryComments) FindByID(ctx context.Context, id string) (*comment.Comment, error) {
+	return m.comments[id], nil
+}
+
+func (m *memoryComments) Update(ctx context.Context, c *comment.Comment) error {
+	m.comments[c.ID] = c
+	return nil
+}
+
+func createTestService(t *testing.T) (*Service, *memoryComments) {
+	t.Helper()
+	return createScoredTestService(t, fakeScorer{})
+}
+
+func createScoredTestService(t *testing.T, scorer fakeScorer) (*Service, *memoryComments) {
+	t.Helper()
+	comments := &memoryComments{comments: map[string]*comment.Comment{}}
+	service := NewService(&memoryReviews{reviews: map[string]*moderation.Review{}}, &memoryProfiles{}, &memoryDictionaries{dictionaries: map[moderation.Language]*moderation.Dictionary{}}, comments, scorer, moderation.DefaultThresholds)
+
+	ctx := context.Background()
+	at := time.Now()
+	for staffID, languages := range map[string][]string{"ana": {"id", "jv"}, "budi": {"id", "en"}} {
+		if _, err := service.SetLanguages(ctx, "lead-1", staffID, languages, at); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := service.SetDictionary(ctx, "lead-1", "id", []string{"bodoh"}, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return service, comments
+}
+
+func newComment(t *testing.T, comments *memoryComments, id, body string) *comment.Comment {
+	t.Helper()
+	c, err := comment.NewComment(id, "a1", comment.AccountAuthor("reader-1"), body, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	comments.comments[id] = c
+	return c
+}
+
+func TestService_Screen(t *testing.T) {
+	service, comments := createTestService(t)
+	ctx := context.Background()
+	at := time.Now()
+
+	javanese, err := service.Screen(ctx, newComment(t, comments, "c1", "Aku ora setuju karo kebijakan iku"), false, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if javanese.Language != moderation.LanguageJavanese || javanese.AssigneeID == nil || *javanese.AssigneeID != "ana" || javanese.Held {
+		t.Errorf("expected an unheld review routed to ana, got %+v", javanese)
+	}
+	if c1 := comments.comments["c1"]; !c1.IsVisible() || *c1.ModeratedBy != moderation.AutoModeratorID {
+		t.Errorf("expected an unheld comment published by screening, got %+v", c1)
+	}
+
+	// ana now has one open review, so the next Indonesian comment goes to budi
+	held := newComment(t, comments, "c2", "Dasar bodoh, saya tidak percaya dengan berita ini")
+	review, err := service.Screen(ctx, held, false, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !review.Held || held.IsVisible() || review.AssigneeID == nil || *review.AssigneeID != "budi" {
+		t.Errorf("expected a held comment routed to budi, got %+v", review)
+	}
+
+	unknown, err := service.Screen(ctx, newComment(t, comments, "c3", "Bodoh!"), false, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unknown.Language != moderation.LanguageUnknown || unknown.AssigneeID != nil || !unknown.Held {
+		t.Errorf("expected a held comment in the general queue, got %+v", unknown)
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	service, comments := createTestService(t)
+	ctx := context.Background()
+	at := time.Now()
+
+	held := newComment(t, comments, "c1", "Dasar bodoh, saya tidak percaya dengan berita ini")
+	review, _ := service.Screen(ctx, held, false, at)
+
+	if _, err := service.Resolve(ctx, "budi", review.ID, true, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c1 := comments.comments["c1"]; !c1.IsVisible() || *c1.ModeratedBy != "budi" {
+		t.Error("expected an approved held comment published")
+	}
+	if _, err := service.Resolve(ctx, "budi", review.ID, false, at); err != moderation.ErrAlreadyResolved {
+		t.Errorf("expected error '%v', got '%v'", moderation.ErrAlreadyResolved, err)
+	}
+	if _, err := service.Resolve(ctx, "budi", "missing", false, at); err != ErrReviewNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrReviewNotFound, err)
+	}
+
+	published := newComment(t, comments, "c2", "Aku ora setuju karo kebijakan iku")
+	review, _ = service.Screen(ctx, published, false, at)
+	if _, err := service.Resolve(ctx, "ana", review.ID, false, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published.Status != comment.StatusRejected || *published.ModeratedBy != "ana" {
+		t.Errorf("expected a removed comment rejected by ana, got %+v", published)
+	}
+
+	queue, err := service.Queue(ctx, nil, 20, 0)
+	if err != nil || len(queue) != 0 {
+		t.Errorf("expected an empty general queue, got %v, %v", queue, err)
+	}
+}
+
+func TestService_ScreenToxicity(t *testing.T) {
+	polite := "Terima kasih atas liputannya, sangat membantu dan jelas"
+	hostile := "Penulis ini tidak tahu apa yang dia tulis, pergi saja dari sini"
+	service, comments := createScoredTestService(t, fakeScorer{polite: 0.05, hostile: 0.92})
+	ctx := context.Background()
+	at := time.Now()
+
+	approved, err := service.Screen(ctx, newComment(t, comments, "c1", polite), false, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if approved.Status != moderation.ReviewApproved || approved.AssigneeID != nil || *approved.ResolvedBy != moderation.AutoModeratorID {
+		t.Errorf("expected a harmless comment auto-approved without an assignee, got %+v", approved)
+	}
+
+	held := newComment(t, comments, "c2", hostile)
+	review, err := service.Screen(ctx, held, false, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !review.Held || held.IsVisible() || review.Scores.Source != moderation.SourceProvider {
+		t.Errorf("expected a toxic comment held on the provider's score, got %+v", review)
+	}
+
+	// The provider lacks Javanese, the heuristic never auto-approves
+	javanese, _ := service.Screen(ctx, newComment(t, comments, "c3", "Aku setuju karo kowe, iku apik tenan"), false, at.Add(-time.Minute))
+	if javanese.Status != moderation.ReviewPending || javanese.Scores.Source != moderation.SourceHeuristic {
+		t.Errorf("expected a pending review on heuristic scores, got %+v", javanese)
+	}
+
+	// Both went to ana, the later but more toxic comment comes first
+	queue, _ := service.Queue(ctx, stringPtr("ana"), 20, 0)
+	if ids := reviewCommentIDs(queue); !slices.Equal(ids, []string{"c2", "c3"}) {
+		t.Errorf("expected the queue ordered by toxicity, got %v", ids)
+	}
+}
+
+func TestService_ScreenPreModeration(t *testing.T) {
+	polite := "Terima kasih atas liputannya, sangat membantu dan jelas"
+	service, comments := createScoredTestService(t, fakeScorer{polite: 0.05})
+
+	// Harmless comments wait for a moderator too on a pre-moderated article
+	c := newComment(t, comments, "c1", polite)
+	review, err := service.Screen(context.Background(), c, true, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !review.Held || review.Status != moderation.ReviewPending || c.IsVisible() {
+		t.Errorf("expected a pre-moderated comment held for review, got %+v", review)
+	}
+}
+
+func TestService_HeatedDiscussions(t *testing.T) {
+	service, comments := createTestService(t)
+	ctx := context.Background()
+	at := time.Now()
+
+	for i := range moderation.MinHeatedComments {
+		c, _ := comment.NewComment(fmt.Sprintf("h%d", i), "a-heated", comment.AccountAuthor("reader-1"), "Berita bohong, penulisnya goblok dan parah!!!", uint32(i+1))
+		comments.comments[c.ID] = c
+		_, _ = service.Screen(ctx, c, false, at.Add(-time.Hour))
+	}
+	for i := range moderation.MinHeatedComments {
+		c, _ := comment.NewComment(fmt.Sprintf("q%d", i), "a-calm", comment.AccountAuthor("reader-2"), "Liputan yang bagus, terima kasih", uint32(i+1))
+		comments.comments[c.ID] = c
+		_, _ = service.Screen(ctx, c, false, at.Add(-time.Hour))
+	}
+
+	heated, err := service.HeatedDiscussions(ctx, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(heated) != 1 || heated[0].ArticleID != "a-heated" || heated[0].Comments != moderation.MinHeatedComments {
+		t.Errorf("expected only a-heated flagged, got %+v", heated)
+	}
+
+	if heated, _ := service.HeatedDiscussions(ctx, at.Add(HeatWindow)); len(heated) != 0 {
+		t.Errorf("expected the signal to fade after the window, got %+v", heated)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func reviewCommentIDs(reviews []*moderation.Review) []string {
+	ids := make([]string, 0, len(reviews))
+	for _, r := range reviews {
+		ids = append(ids, r.CommentID)
+	}
+	return ids
+}
@@ -0,0 +1,222 @@
+package newsletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+// Send-time scanning
+const (
+	ScanPageSize      = 1000
+	PreviewSampleSize = 10
+)
+
+var ErrSegmentNotFound = errors.New("segment not found")
+
+// Preview is how many subscribers a segment reaches right now, with a few examples
+type Preview struct {
+	Count  int64
+	Sample []newsletter.Profile
+}
+
+type SegmentService struct {
+	segments   newsletter.SegmentRepository
+	profiles   newsletter.ProfileSource
+	consent    consent.BatchChecker
+	suppressed suppression.Checker
+}
+
+func NewSegmentService(segments newsletter.SegmentRepository, profiles newsletter.ProfileSource, checker consent.BatchChecker, suppressed suppression.Checker) *SegmentService {
+	return &SegmentService{segments: segments, profiles: profiles, consent: checker, suppressed: suppressed}
+}
+
+func (s *SegmentService) Create(ctx context.Context, staffID, name, description string, rules newsletter.Group) (*newsletter.Segment, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	segment, err := newsletter.NewSegment(id, name, description, rules, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.segments.Create(ctx, segment); err != nil {
+		return nil, fmt.Errorf("failed to create segment: %w", err)
+	}
+	return segment, nil
+}
+
+func (s *SegmentService) Update(ctx context.Context, staffID, id, name, description string, rules newsletter.Group) (*newsletter.Segment, error) {
+	segment, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := segment.Redefine(name, description, rules, staffID); err != nil {
+		return nil, err
+	}
+	if err := s.segments.Update(ctx, segment); err != nil {
+		return nil, fmt.Errorf("failed to update segment: %w", err)
+	}
+	return segment, nil
+}
+
+func (s *SegmentService) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.segments.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete segment: %w", err)
+	}
+	return nil
+}
+
+func (s *SegmentService) Get(ctx context.Context, id string) (*newsletter.Segment, error) {
+	segment, err := s.segments.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load segment: %w", err)
+	}
+	if segment == nil {
+		return nil, ErrSegmentNotFound
+	}
+	return segment, nil
+}
+
+func (s *SegmentService) List(ctx context.Context) ([]*newsletter.Segment, error) {
+	segments, err := s.segments.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list segments: %w", err)
+	}
+	return segments, nil
+}
+
+// Preview counts the subscribers unsaved rules would reach, for the segment builder
+func (s *SegmentService) Preview(ctx context.Context, rules newsletter.Group, at time.Time) (*Preview, error) {
+	preview := &Preview{}
+	err := s.scan(ctx, rules, at, func(batch []newsletter.Profile) error {
+		preview.Count += int64(len(batch))
+		if room := PreviewSampleSize - len(preview.Sample); room > 0 {
+			preview.Sample = append(preview.Sample, batch[:min(room, len(batch))]...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// Count refreshes a saved segment's count
+func (s *SegmentService) Count(ctx context.Context, id string, at time.Time) (*newsletter.Segment, error) {
+	segment, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	preview, err := s.Preview(ctx, segment.Rules, at)
+	if err != nil {
+		return nil, err
+	}
+
+	segment.RecordCount(preview.Count, at)
+	if err := s.segments.Update(ctx, segment); err != nil {
+		return nil, fmt.Errorf("failed to update segment: %w", err)
+	}
+	return segment, nil
+}
+
+// ForEachRecipient streams the segment's recipients at send time in batches of at most
+// ScanPageSize. Rules are evaluated against fresh profiles, not the last preview.
+func (s *SegmentService) ForEachRecipient(ctx context.Context, id string, at time.Time, send func(batch []newsletter.Profile) error) error {
+	segment, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.scan(ctx, segment.Rules, at, send)
+}
+
+// scan pages through subscriber profiles, keeping those that match, allow marketing email and are not suppressed
+func (s *SegmentService) scan(ctx context.Context, rules newsletter.Group, at time.Time, fn func(batch []newsletter.Profile) error) error {
+	match, err := rules.Compile(at)
+	if err != nil {
+		return err
+	}
+	hints := rules.Hints()
+
+	after := ""
+	for {
+		page, err := s.profiles.ScanProfiles(ctx, hints, after, ScanPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to scan subscriber profiles: %w", err)
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		after = page[len(page)-1].AccountID
+
+		matched := make([]newsletter.Profile, 0, len(page))
+		for i := range page {
+			if match(&page[i]) {
+				matched = append(matched, page[i])
+			}
+		}
+		if matched, err = s.reachable(ctx, matched); err != nil {
+			return err
+		}
+		if len(matched) > 0 {
+			if err := fn(matched); err != nil {
+				return err
+			}
+		}
+
+		if len(page) < ScanPageSize {
+			return nil
+		}
+	}
+}
+
+// reachable drops profiles without marketing email consent, checked for the whole page at once,
+// and suppressed addresses
+func (s *SegmentService) reachable(ctx context.Context, profiles []newsletter.Profile) ([]newsletter.Profile, error) {
+	if len(profiles) == 0 {
+		return profiles, nil
+	}
+	subjects := make([]consent.Subject, 0, len(profiles))
+	for _, p := range profiles {
+		if subject, err := consent.NewAccountSubject(p.AccountID); err == nil {
+			subjects = append(subjects, *subject)
+		}
+	}
+	allowed, err := s.consent.FilterAllowed(ctx, consent.PurposeMarketingEmail, subjects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check consent: %w", err)
+	}
+	granted := make(map[string]bool, len(allowed))
+	for _, subject := range allowed {
+		granted[subject.ID()] = true
+	}
+
+	kept := profiles[:0]
+	for _, p := range profiles {
+		if !granted[p.AccountID] {
+			continue
+		}
+		email, err := account.NewEmail(p.Email)
+		if err != nil {
+			continue
+		}
+		suppressed, err := s.suppressed.IsSuppressed(ctx, *email)
+		if err != nil {
+			return nil, err
+		}
+		if !suppressed {
+			kept = append(kept, p)
+		}
+	}
+	return kept, nil
+}
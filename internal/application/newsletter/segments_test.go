@@ -0,0 +1,182 @@
+package newsletter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+type memorySegments struct {
+	segments map[string]*newsletter.Segment
+}
+
+func (m *memorySegments) Create(ctx context.Context, s *newsletter.Segment) error {
+	m.segments[s.ID] = s
+	return nil
+}
+
+func (m *memorySegments) Update(ctx context.Context, s *newsletter.Segment) error {
+	m.segments[s.ID] = s
+	return nil
+}
+
+func (m *memorySegments) Delete(ctx context.Context, id string) error {
+	delete(m.segments, id)
+	return nil
+}
+
+func (m *memorySegments) FindByID(ctx context.Context, id string) (*newsletter.Segment, error) {
+	return m.segments[id], nil
+}
+
+func (m *memorySegments) FindAll(ctx context.Context) ([]*newsletter.Segment, error) {
+	var all []*newsletter.Segment
+	for _, s := range m.segments {
+		all = append(all, s)
+	}
+	return all, nil
+}
+
+// memoryProfiles pages by account ID and records the hints it was given
+type memoryProfiles struct {
+	profiles []newsletter.Profile
+	hints    newsletter.ScanHints
+	pages    int
+}
+
+func (m *memoryProfiles) ScanProfiles(ctx context.Context, hints newsletter.ScanHints, after string, limit int) ([]newsletter.Profile, error) {
+	m.hints = hints
+	m.pages++
+	var page []newsletter.Profile
+	for _, p := range m.profiles {
+		if p.AccountID > after && len(page) < limit {
+			page = append(page, p)
+		}
+	}
+	return page, nil
+}
+
+type fakeConsent struct {
+	optedOut map[string]bool
+	batches  int
+}
+
+func (f *fakeConsent) FilterAllowed(ctx context.Context, purpose consent.Purpose, subjects []consent.Subject) ([]consent.Subject, error) {
+	f.batches++
+	var allowed []consent.Subject
+	for _, subject := range subjects {
+		if !f.optedOut[subject.ID()] {
+			allowed = append(allowed, subject)
+		}
+	}
+	return allowed, nil
+}
+
+type fakeSuppressions struct {
+	emails map[string]bool
+}
+
+func (f *fakeSuppressions) IsSuppressed(ctx context.Context, email account.Email) (bool, error) {
+	return f.emails[email.String()], nil
+}
+
+func createSegmentService(t *testing.T, n int) (*SegmentService, *memoryProfiles) {
+	profiles := &memoryProfiles{}
+	for i := 0; i < n; i++ {
+		profiles.profiles = append(profiles.profiles, newsletter.Profile{
+			AccountID:      fmt.Sprintf("acc-%05d", i),
+			Email:          fmt.Sprintf("reader%d@example.com", i),
+			AccountType:    account.TypeMembership,
+			Premium:        i%2 == 0,
+			Follows:        []string{[]string{"tech", "sport", "politics"}[i%3]},
+			ArticlesRead7d: i % 5,
+		})
+	}
+	sort.Slice(profiles.profiles, func(i, j int) bool { return profiles.profiles[i].AccountID < profiles.profiles[j].AccountID })
+
+	checker := &fakeConsent{optedOut: map[string]bool{"acc-00000": true}}
+	suppressed := &fakeSuppressions{emails: map[string]bool{"reader1@example.com": true}}
+	return NewSegmentService(&memorySegments{segments: map[string]*newsletter.Segment{}}, profiles, checker, suppressed), profiles
+}
+
+var engagedTech = newsletter.Group{
+	Match: newsletter.MatchAll,
+	Rules: []newsletter.Rule{
+		{Field: newsletter.FieldFollows, Operator: newsletter.OpContains, Values: []string{"tech"}},
+		{Field: newsletter.FieldArticlesRead7d, Operator: newsletter.OpAtLeast, Values: []string{"3"}},
+		{Field: newsletter.FieldPremium, Operator: newsletter.OpEquals, Values: []string{"true"}},
+	},
+}
+
+func TestSegmentService_PreviewAndCount(t *testing.T) {
+	ctx := context.Background()
+	service, profiles := createSegmentService(t, 2500)
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+
+	// Expected: i%2==0, i%3==0, i%5 in {3,4}, minus the opted-out acc-00000 which reads 0
+	var expected int64
+	for i := 0; i < 2500; i++ {
+		if i%6 == 0 && i%5 >= 3 {
+			expected++
+		}
+	}
+
+	preview, err := service.Preview(ctx, engagedTech, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.Count != expected || len(preview.Sample) != PreviewSampleSize {
+		t.Errorf("expected %d with a sample of %d, got %d with %d", expected, PreviewSampleSize, preview.Count, len(preview.Sample))
+	}
+	if profiles.pages != 3 || !profiles.hints.WithBehavior || profiles.hints.Premium == nil {
+		t.Errorf("expected 3 pages with behavior and premium hints, got %d pages %+v", profiles.pages, profiles.hints)
+	}
+	if checker := service.consent.(*fakeConsent); checker.batches != 3 {
+		t.Errorf("expected consent checked once per page, got %d lookups", checker.batches)
+	}
+
+	segment, err := service.Create(ctx, "staff1", "Engaged tech readers", "", engagedTech)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	counted, err := service.Count(ctx, segment.ID, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counted.LastCount == nil || *counted.LastCount != expected {
+		t.Errorf("expected saved count %d, got %v", expected, counted.LastCount)
+	}
+}
+
+func TestSegmentService_ForEachRecipientSkipsUnreachable(t *testing.T) {
+	ctx := context.Background()
+	service, _ := createSegmentService(t, 10)
+	everyone := newsletter.Group{Match: newsletter.MatchAll, Rules: []newsletter.Rule{
+		{Field: newsletter.FieldAccountType, Operator: newsletter.OpEquals, Values: []string{"membership"}},
+	}}
+	segment, _ := service.Create(ctx, "staff1", "All members", "", everyone)
+
+	var recipients []string
+	err := service.ForEachRecipient(ctx, segment.ID, time.Now(), func(batch []newsletter.Profile) error {
+		for _, p := range batch {
+			recipients = append(recipients, p.AccountID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recipients) != 8 || recipients[0] != "acc-00002" {
+		t.Errorf("expected 8 recipients without the opted-out and suppressed readers, got %v", recipients)
+	}
+
+	if err := service.ForEachRecipient(ctx, "missing", time.Now(), nil); err != ErrSegmentNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrSegmentNotFound, err)
+	}
+}
@@ -0,0 +1,163 @@
+package newsletter
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var ErrSubscriberNotFound = errors.New("subscriber not found")
+
+// ConfirmationSender emails the double opt-in link carrying the raw token
+type ConfirmationSender interface {
+	SendConfirmation(ctx context.Context, email account.Email, token string, expiresAt time.Time) error
+}
+
+// OptInProof is the compliance record for one address
+type OptInProof struct {
+	Subscriber  *newsletter.Subscriber
+	Suppression *suppression.Entry // Set when the address is on the suppression list
+}
+
+// SubscriptionService runs double opt-in for newsletter signups
+type SubscriptionService struct {
+	subscribers  newsletter.SubscriberRepository
+	suppressions suppression.Repository
+	sender       ConfirmationSender
+}
+
+func NewSubscriptionService(subscribers newsletter.SubscriberRepository, suppressions suppression.Repository, sender ConfirmationSender) *SubscriptionService {
+	return &SubscriptionService{subscribers: subscribers, suppressions: suppressions, sender: sender}
+}
+
+// Subscribe starts double opt-in. It reports success for confirmed and suppressed
+// addresses too, so the signup form cannot be used to probe the list.
+func (s *SubscriptionService) Subscribe(ctx context.Context, rawEmail, source, ip string, at time.Time) error {
+	email, err := account.NewEmail(rawEmail)
+	if err != nil {
+		return err
+	}
+
+	entry, err := s.suppressions.FindByEmail(ctx, *email)
+	if err != nil {
+		return fmt.Errorf("failed to check suppression list: %w", err)
+	}
+	if entry != nil {
+		return nil
+	}
+
+	subscriber, err := s.subscribers.FindByEmail(ctx, *email)
+	if err != nil {
+		return fmt.Errorf("failed to load subscriber: %w", err)
+	}
+	if subscriber != nil && subscriber.Status == newsletter.SubscriberConfirmed {
+		return nil
+	}
+
+	token, hash, err := newConfirmationToken()
+	if err != nil {
+		return err
+	}
+
+	if subscriber == nil {
+		id, err := shared.GenerateUUID()
+		if err != nil {
+			return err
+		}
+		if subscriber, err = newsletter.NewSubscriber(id, *email, source, ip, hash, at); err != nil {
+			return err
+		}
+		if err := s.subscribers.Create(ctx, subscriber); err != nil {
+			return fmt.Errorf("failed to create subscriber: %w", err)
+		}
+	} else {
+		if err := subscriber.Resubscribe(source, ip, hash, at); err != nil {
+			return err
+		}
+		if err := s.subscribers.Update(ctx, subscriber); err != nil {
+			return fmt.Errorf("failed to update subscriber: %w", err)
+		}
+	}
+
+	if err := s.sender.SendConfirmation(ctx, *email, token, *subscriber.TokenExpiresAt); err != nil {
+		return fmt.Errorf("failed to send confirmation: %w", err)
+	}
+	return nil
+}
+
+// Confirm completes double opt-in from the emailed link
+func (s *SubscriptionService) Confirm(ctx context.Context, token, ip string, at time.Time) (*newsletter.Subscriber, error) {
+	hash := hashToken(token)
+	subscriber, err := s.subscribers.FindByTokenHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscriber: %w", err)
+	}
+	if subscriber == nil {
+		return nil, newsletter.ErrInvalidConfirmation
+	}
+
+	if err := subscriber.Confirm(hash, ip, at); err != nil {
+		return nil, err
+	}
+	if err := s.subscribers.Update(ctx, subscriber); err != nil {
+		return nil, fmt.Errorf("failed to update subscriber: %w", err)
+	}
+	return subscriber, nil
+}
+
+// Proof returns the opt-in evidence for one address
+func (s *SubscriptionService) Proof(ctx context.Context, rawEmail string) (*OptInProof, error) {
+	email, err := account.NewEmail(rawEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriber, err := s.subscribers.FindByEmail(ctx, *email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscriber: %w", err)
+	}
+	if subscriber == nil {
+		return nil, ErrSubscriberNotFound
+	}
+	entry, err := s.suppressions.FindByEmail(ctx, *email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check suppression list: %w", err)
+	}
+	return &OptInProof{Subscriber: subscriber, Suppression: entry}, nil
+}
+
+// Subscribers lists opt-in records for the compliance report
+func (s *SubscriptionService) Subscribers(ctx context.Context, status *newsletter.SubscriberStatus, limit, offset int) ([]*newsletter.Subscriber, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.subscribers.FindAll(ctx, status, limit, offset)
+}
+
+// newConfirmationToken returns the URL-safe token to email and the hash to store
+func newConfirmationToken() (string, string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
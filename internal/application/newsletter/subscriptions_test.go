@@ -0,0 +1,127 @@
+package newsletter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memorySubscribers struct {
+	subscribers map[string]*newsletter.Subscriber
+}
+
+func (m *memorySubscribers) Create(ctx context.Context, s *newsletter.Subscriber) error {
+	m.subscribers[s.Email.String()] = s
+	return nil
+}
+
+func (m *memorySubscribers) Update(ctx context.Context, s *newsletter.Subscriber) error {
+	m.subscribers[s.Email.String()] = s
+	return nil
+}
+
+func (m *memorySubscribers) FindByEmail(ctx context.Context, email account.Email) (*newsletter.Subscriber, error) {
+	return m.subscribers[email.String()], nil
+}
+
+func (m *memorySubscribers) FindByTokenHash(ctx context.Context, tokenHash string) (*newsletter.Subscriber, error) {
+	for _, s := range m.subscribers {
+		if s.TokenHash == tokenHash {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySubscribers) FindAll(ctx context.Context, status *newsletter.SubscriberStatus, limit, offset int) ([]*newsletter.Subscriber, int64, error) {
+	var all []*newsletter.Subscriber
+	for _, s := range m.subscribers {
+		all = append(all, s)
+	}
+	return all, int64(len(all)), nil
+}
+
+type memorySuppressionList struct {
+	suppression.Repository
+	entries map[string]*suppression.Entry
+}
+
+func (m *memorySuppressionList) FindByEmail(ctx context.Context, email account.Email) (*suppression.Entry, error) {
+	return m.entries[email.String()], nil
+}
+
+type fakeConfirmations struct {
+	tokens map[string]string
+}
+
+func (f *fakeConfirmations) SendConfirmation(ctx context.Context, email account.Email, token string, expiresAt time.Time) error {
+	f.tokens[email.String()] = token
+	return nil
+}
+
+func createSubscriptionService(t *testing.T) (*SubscriptionService, *fakeConfirmations) {
+	bounced, _ := account.NewEmail("gone@example.com")
+	entry, _ := suppression.NewEntry(*bounced, suppression.ReasonHardBounce, "postmark", "550 no such user", time.Now())
+	suppressions := &memorySuppressionList{entries: map[string]*suppression.Entry{"gone@example.com": entry}}
+
+	sender := &fakeConfirmations{tokens: map[string]string{}}
+	return NewSubscriptionService(&memorySubscribers{subscribers: map[string]*newsletter.Subscriber{}}, suppressions, sender), sender
+}
+
+func TestSubscriptionService_DoubleOptIn(t *testing.T) {
+	ctx := context.Background()
+	service, sender := createSubscriptionService(t)
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+
+	if err := service.Subscribe(ctx, "Reader@Example.com", "footer", "203.0.113.7", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token := sender.tokens["reader@example.com"]
+	if token == "" {
+		t.Fatal("expected a confirmation email")
+	}
+
+	proof, _ := service.Proof(ctx, "reader@example.com")
+	if proof.Subscriber.IsSendable() || proof.Subscriber.TokenHash == token {
+		t.Error("expected a pending subscriber storing only the token hash")
+	}
+
+	if _, err := service.Confirm(ctx, "forged", "", at.Add(time.Hour)); err != newsletter.ErrInvalidConfirmation {
+		t.Errorf("expected error '%v', got '%v'", newsletter.ErrInvalidConfirmation, err)
+	}
+	subscriber, err := service.Confirm(ctx, token, "198.51.100.2", at.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !subscriber.IsSendable() || *subscriber.OptIn.RequestIP != "203.0.113.7" || *subscriber.OptIn.ConfirmIP != "198.51.100.2" {
+		t.Errorf("expected confirmed subscriber with opt-in proof, got %+v", subscriber.OptIn)
+	}
+
+	// A second signup for a confirmed address sends nothing
+	delete(sender.tokens, "reader@example.com")
+	if err := service.Subscribe(ctx, "reader@example.com", "footer", "", at.Add(2*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := sender.tokens["reader@example.com"]; ok {
+		t.Error("expected no confirmation for an already confirmed address")
+	}
+}
+
+func TestSubscriptionService_SuppressedAddress(t *testing.T) {
+	ctx := context.Background()
+	service, sender := createSubscriptionService(t)
+
+	if err := service.Subscribe(ctx, "gone@example.com", "footer", "", time.Now()); err != nil {
+		t.Fatalf("expected suppressed signup to look successful, got %v", err)
+	}
+	if len(sender.tokens) != 0 {
+		t.Errorf("expected no confirmation to a suppressed address, got %v", sender.tokens)
+	}
+	if _, err := service.Proof(ctx, "gone@example.com"); err != ErrSubscriberNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrSubscriberNotFound, err)
+	}
+}
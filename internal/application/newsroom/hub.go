@@ -0,0 +1,198 @@
+package newsroom
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Hub defaults
+const (
+	DefaultHistorySize      = 1000 // Updates kept for replay on reconnect
+	DefaultRecentActivity   = 50
+	DefaultSubscriberBuffer = 64
+)
+
+// Hub keeps the live dashboard state fed by the analytics pipeline and fans
+// updates out to subscribed editors
+type Hub struct {
+	mu          sync.Mutex
+	seq         uint64
+	readers     map[string]ArticleReaders
+	activity    []PublishingEvent
+	history     []Update
+	historySize int
+	spikes      *SpikeDetector
+	subscribers map[*Subscription]struct{}
+}
+
+func NewHub(spikes *SpikeDetector) *Hub {
+	if spikes == nil {
+		spikes = NewSpikeDetector(3, 50)
+	}
+	return &Hub{
+		readers:     make(map[string]ArticleReaders),
+		historySize: DefaultHistorySize,
+		spikes:      spikes,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription receives the updates for its desks. The channel is closed when the
+// subscriber falls behind; the client should reconnect with its last seq.
+type Subscription struct {
+	desks   map[string]bool // Empty means every desk
+	updates chan Update
+	closed  bool
+}
+
+func (s *Subscription) Updates() <-chan Update {
+	return s.updates
+}
+
+func (s *Subscription) wants(desk string) bool {
+	return len(s.desks) == 0 || desk == "" || s.desks[desk]
+}
+
+// Subscribe registers an editor. With lastSeq > 0 the missed updates are replayed when
+// still in history, otherwise the subscription starts with a snapshot.
+func (h *Hub) Subscribe(desks []string, lastSeq uint64) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub := &Subscription{
+		desks:   make(map[string]bool, len(desks)),
+		updates: make(chan Update, DefaultSubscriberBuffer+h.historySize),
+	}
+	for _, d := range desks {
+		if d != "" {
+			sub.desks[d] = true
+		}
+	}
+
+	if missed, ok := h.replayLocked(lastSeq); ok {
+		for _, u := range missed {
+			if sub.wants(u.Desk) {
+				sub.updates <- u
+			}
+		}
+	} else {
+		sub.updates <- h.snapshotLocked(sub)
+	}
+
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closeLocked(sub)
+}
+
+// UpdateReaders records the current concurrent readers of an article, zero removes it
+func (h *Hub) UpdateReaders(r ArticleReaders, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if r.Readers <= 0 {
+		delete(h.readers, r.ArticleID)
+		h.spikes.Forget(r.ArticleID)
+	} else {
+		h.readers[r.ArticleID] = r
+	}
+	h.broadcastLocked(Update{Type: UpdateReaders, Desk: r.Desk, At: at, Readers: &r})
+
+	if r.Readers <= 0 {
+		return
+	}
+	if baseline, started := h.spikes.Observe(r.ArticleID, r.Readers); started {
+		spike := TrafficSpike{ArticleID: r.ArticleID, Title: r.Title, Desk: r.Desk, Readers: r.Readers, Baseline: baseline}
+		h.broadcastLocked(Update{Type: UpdateSpike, Desk: r.Desk, At: at, Spike: &spike})
+	}
+}
+
+// RecordPublishing adds an entry to the publishing activity feed
+func (h *Hub) RecordPublishing(e PublishingEvent, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.activity = append(h.activity, e)
+	if len(h.activity) > DefaultRecentActivity {
+		h.activity = h.activity[len(h.activity)-DefaultRecentActivity:]
+	}
+	h.broadcastLocked(Update{Type: UpdatePublishing, Desk: e.Desk, At: at, Publishing: &e})
+}
+
+func (h *Hub) broadcastLocked(u Update) {
+	h.seq++
+	u.Seq = h.seq
+
+	h.history = append(h.history, u)
+	if len(h.history) > h.historySize {
+		h.history = h.history[len(h.history)-h.historySize:]
+	}
+
+	for sub := range h.subscribers {
+		if !sub.wants(u.Desk) {
+			continue
+		}
+		select {
+		case sub.updates <- u:
+		default:
+			// Too slow, drop it rather than block the pipeline
+			h.closeLocked(sub)
+		}
+	}
+}
+
+// replayLocked returns the updates after lastSeq if none of them has left the history
+func (h *Hub) replayLocked(lastSeq uint64) ([]Update, bool) {
+	if lastSeq == 0 || lastSeq > h.seq {
+		return nil, false
+	}
+	if lastSeq == h.seq {
+		return nil, true
+	}
+	if len(h.history) == 0 || h.history[0].Seq > lastSeq+1 {
+		return nil, false
+	}
+	start := int(lastSeq + 1 - h.history[0].Seq)
+	return append([]Update(nil), h.history[start:]...), true
+}
+
+func (h *Hub) snapshotLocked(sub *Subscription) Update {
+	snapshot := &DashboardSnapshot{
+		Readers:        make([]ArticleReaders, 0, len(h.readers)),
+		RecentActivity: make([]PublishingEvent, 0, len(h.activity)),
+	}
+	for _, r := range h.readers {
+		if sub.wants(r.Desk) {
+			snapshot.Readers = append(snapshot.Readers, r)
+		}
+	}
+	sort.Slice(snapshot.Readers, func(i, j int) bool {
+		if snapshot.Readers[i].Readers != snapshot.Readers[j].Readers {
+			return snapshot.Readers[i].Readers > snapshot.Readers[j].Readers
+		}
+		return snapshot.Readers[i].ArticleID < snapshot.Readers[j].ArticleID
+	})
+	// Newest activity first
+	for i := len(h.activity) - 1; i >= 0; i-- {
+		if sub.wants(h.activity[i].Desk) {
+			snapshot.RecentActivity = append(snapshot.RecentActivity, h.activity[i])
+		}
+	}
+
+	// A snapshot carries the current seq so the client can resume from it
+	return Update{Seq: h.seq, Type: UpdateSnapshot, At: time.Now(), Snapshot: snapshot}
+}
+
+func (h *Hub) closeLocked(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(h.subscribers, sub)
+	close(sub.updates)
+}
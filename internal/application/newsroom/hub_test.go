@@ -0,0 +1,129 @@
+package newsroom
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHub_SnapshotAndDeskFilter(t *testing.T) {
+	hub := NewHub(nil)
+	now := time.Now()
+
+	hub.UpdateReaders(ArticleReaders{ArticleID: "a1", Desk: "politics", Readers: 120}, now)
+	hub.UpdateReaders(ArticleReaders{ArticleID: "a2", Desk: "sport", Readers: 300}, now)
+	hub.RecordPublishing(PublishingEvent{ArticleID: "a3", Desk: "sport", Action: ActionPublished}, now)
+
+	sub := hub.Subscribe([]string{"sport"}, 0)
+	first := <-sub.Updates()
+	if first.Type != UpdateSnapshot || first.Seq != 3 {
+		t.Fatalf("expected snapshot at seq 3, got %s at %d", first.Type, first.Seq)
+	}
+	if len(first.Snapshot.Readers) != 1 || first.Snapshot.Readers[0].ArticleID != "a2" {
+		t.Errorf("expected only sport readers, got %+v", first.Snapshot.Readers)
+	}
+	if len(first.Snapshot.RecentActivity) != 1 {
+		t.Errorf("expected sport activity, got %+v", first.Snapshot.RecentActivity)
+	}
+
+	hub.UpdateReaders(ArticleReaders{ArticleID: "a1", Desk: "politics", Readers: 130}, now)
+	hub.UpdateReaders(ArticleReaders{ArticleID: "a2", Desk: "sport", Readers: 310}, now)
+
+	update := <-sub.Updates()
+	if update.Desk != "sport" || update.Seq != 5 {
+		t.Errorf("expected sport update at seq 5, got %s at %d", update.Desk, update.Seq)
+	}
+}
+
+func TestHub_ReconnectReplaysMissedUpdates(t *testing.T) {
+	hub := NewHub(nil)
+	now := time.Now()
+
+	for i := 1; i <= 5; i++ {
+		hub.UpdateReaders(ArticleReaders{ArticleID: "a1", Desk: "politics", Readers: 100 + i}, now)
+	}
+
+	sub := hub.Subscribe(nil, 3)
+	for _, want := range []uint64{4, 5} {
+		u := <-sub.Updates()
+		if u.Type != UpdateReaders || u.Seq != want {
+			t.Errorf("expected replayed seq %d, got %s at %d", want, u.Type, u.Seq)
+		}
+	}
+
+	// Unknown future seq, e.g. after a server restart, falls back to a snapshot
+	restarted := hub.Subscribe(nil, 99)
+	if u := <-restarted.Updates(); u.Type != UpdateSnapshot {
+		t.Errorf("expected snapshot, got %s", u.Type)
+	}
+}
+
+func TestHub_ReconnectAfterHistoryTrimmedGetsSnapshot(t *testing.T) {
+	hub := NewHub(nil)
+	hub.historySize = 3
+	now := time.Now()
+
+	for i := 1; i <= 6; i++ {
+		hub.UpdateReaders(ArticleReaders{ArticleID: "a1", Readers: 100}, now)
+	}
+
+	sub := hub.Subscribe(nil, 1)
+	if u := <-sub.Updates(); u.Type != UpdateSnapshot {
+		t.Errorf("expected snapshot when history no longer covers the gap, got %s", u.Type)
+	}
+}
+
+func TestHub_SlowSubscriberIsClosed(t *testing.T) {
+	hub := NewHub(nil)
+	hub.historySize = 1
+	sub := hub.Subscribe(nil, 0)
+	now := time.Now()
+
+	for i := 0; i < DefaultSubscriberBuffer+5; i++ {
+		hub.UpdateReaders(ArticleReaders{ArticleID: "a1", Readers: 10}, now)
+	}
+
+	count := 0
+	for range sub.Updates() {
+		count++
+	}
+	if count != DefaultSubscriberBuffer+1 {
+		t.Errorf("expected channel closed after filling buffer, got %d updates", count)
+	}
+
+	// Unsubscribing an already closed subscription is a no-op
+	hub.Unsubscribe(sub)
+}
+
+func TestHub_RemovesArticlesWithoutReaders(t *testing.T) {
+	hub := NewHub(nil)
+	now := time.Now()
+	hub.UpdateReaders(ArticleReaders{ArticleID: "a1", Readers: 10}, now)
+	hub.UpdateReaders(ArticleReaders{ArticleID: "a1", Readers: 0}, now)
+
+	u := <-hub.Subscribe(nil, 0).Updates()
+	if len(u.Snapshot.Readers) != 0 {
+		t.Errorf("expected no readers, got %+v", u.Snapshot.Readers)
+	}
+}
+
+func TestSpikeDetector(t *testing.T) {
+	detector := NewSpikeDetector(3, 50)
+
+	samples := []struct {
+		readers int
+		spike   bool
+	}{
+		{readers: 40},
+		{readers: 45},
+		{readers: 160, spike: true},
+		{readers: 170}, // Still spiking, reported once
+		{readers: 20},  // Back to normal
+		{readers: 30},  // Below minimum audience
+	}
+
+	for i, s := range samples {
+		if _, started := detector.Observe("a1", s.readers); started != s.spike {
+			t.Errorf("sample %d: expected spike %v, got %v", i, s.spike, started)
+		}
+	}
+}
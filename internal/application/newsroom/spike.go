@@ -0,0 +1,47 @@
+package newsroom
+
+// SpikeDetector compares each reader count against an exponentially weighted
+// moving average of the article's previous counts
+type SpikeDetector struct {
+	factor     float64 // Spike when readers >= factor * baseline
+	minReaders int     // Ignore spikes on tiny audiences
+	smoothing  float64 // Weight of the newest sample in the baseline
+	baselines  map[string]float64
+	spiking    map[string]bool
+}
+
+func NewSpikeDetector(factor float64, minReaders int) *SpikeDetector {
+	if factor <= 1 {
+		factor = 3
+	}
+	return &SpikeDetector{
+		factor:     factor,
+		minReaders: minReaders,
+		smoothing:  0.2,
+		baselines:  make(map[string]float64),
+		spiking:    make(map[string]bool),
+	}
+}
+
+// Observe records a sample and reports the baseline it was compared to and whether
+// a new spike started. A spike is reported once until readers fall back under the threshold.
+func (d *SpikeDetector) Observe(articleID string, readers int) (float64, bool) {
+	baseline, seen := d.baselines[articleID]
+	if !seen {
+		d.baselines[articleID] = float64(readers)
+		return float64(readers), false
+	}
+
+	over := readers >= d.minReaders && float64(readers) >= d.factor*baseline
+	started := over && !d.spiking[articleID]
+	d.spiking[articleID] = over
+
+	d.baselines[articleID] = baseline + d.smoothing*(float64(readers)-baseline)
+	return baseline, started
+}
+
+// Forget drops an article that no longer has readers
+func (d *SpikeDetector) Forget(articleID string) {
+	delete(d.baselines, articleID)
+	delete(d.spiking, articleID)
+}
@@ -0,0 +1,64 @@
+package newsroom
+
+import "time"
+
+type UpdateType string
+
+const (
+	UpdateSnapshot   UpdateType = "snapshot"
+	UpdateReaders    UpdateType = "readers"
+	UpdatePublishing UpdateType = "publishing"
+	UpdateSpike      UpdateType = "spike"
+)
+
+// Update is one message on the dashboard feed. Seq increases by one per update,
+// so a client can tell from a gap that it missed something.
+type Update struct {
+	Seq        uint64             `json:"seq"`
+	Type       UpdateType         `json:"type"`
+	Desk       string             `json:"desk,omitempty"`
+	At         time.Time          `json:"at"`
+	Readers    *ArticleReaders    `json:"readers,omitempty"`
+	Publishing *PublishingEvent   `json:"publishing,omitempty"`
+	Spike      *TrafficSpike      `json:"spike,omitempty"`
+	Snapshot   *DashboardSnapshot `json:"snapshot,omitempty"`
+}
+
+// ArticleReaders is the concurrent reader count of one article
+type ArticleReaders struct {
+	ArticleID string `json:"article_id"`
+	Title     string `json:"title"`
+	Desk      string `json:"desk"`
+	Readers   int    `json:"readers"`
+}
+
+type PublishingAction string
+
+const (
+	ActionPublished   PublishingAction = "published"
+	ActionUpdated     PublishingAction = "updated"
+	ActionUnpublished PublishingAction = "unpublished"
+)
+
+type PublishingEvent struct {
+	ArticleID string           `json:"article_id"`
+	Title     string           `json:"title"`
+	Desk      string           `json:"desk"`
+	Action    PublishingAction `json:"action"`
+	EditorID  string           `json:"editor_id"`
+}
+
+// TrafficSpike is raised when an article's readers jump well above its recent baseline
+type TrafficSpike struct {
+	ArticleID string  `json:"article_id"`
+	Title     string  `json:"title"`
+	Desk      string  `json:"desk"`
+	Readers   int     `json:"readers"`
+	Baseline  float64 `json:"baseline"`
+}
+
+// DashboardSnapshot is the full state a client starts from
+type DashboardSnapshot struct {
+	Readers        []ArticleReaders  `json:"readers"`
+	RecentActivity []PublishingEvent `json:"recent_activity"`
+}
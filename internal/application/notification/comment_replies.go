@@ -0,0 +1,274 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// CommentRepliesTemplate is the email template key for "new comments in a discussion you follow"
+const CommentRepliesTemplate = "comment_replies"
+
+const (
+	replyExcerptLength  = 200
+	replyAccountsBatch  = 500
+	unsubscribeLinkPath = "/comments/subscriptions/unsubscribe"
+)
+
+// CommentReplyService is the scheduled job behind comment subscription emails.
+// Each run sends a member one email covering every due subscription, capped at
+// comment.MaxNotificationsPerDay. Wrap the sender in NewSuppressingSender so
+// bounced and unsubscribed addresses are skipped.
+type CommentReplyService struct {
+	subscriptions comment.SubscriptionRepository
+	comments      comment.CommentRepository
+	deliveries    comment.DeliveryLog
+	accounts      account.UserAccountRepository
+	published     topic.ArticleStream
+	templates     *TemplateService
+	sender        EmailSender
+	locker        shared.Locker
+	paths         ArticlePaths
+	baseURL       string
+}
+
+func NewCommentReplyService(subscriptions comment.SubscriptionRepository, comments comment.CommentRepository, deliveries comment.DeliveryLog, accounts account.UserAccountRepository, published topic.ArticleStream, templates *TemplateService, sender EmailSender, locker shared.Locker, paths ArticlePaths, baseURL string) *CommentReplyService {
+	return &CommentReplyService{
+		subscriptions: subscriptions,
+		comments:      comments,
+		deliveries:    deliveries,
+		accounts:      accounts,
+		published:     published,
+		templates:     templates,
+		sender:        sender,
+		locker:        locker,
+		paths:         paths,
+		baseURL:       baseURL,
+	}
+}
+
+// threadUpdate is what one subscription has to report in a run
+type threadUpdate struct {
+	subscription *comment.Subscription
+	through      time.Time // Approval time of the last comment read for it
+	replies      []*comment.Comment
+}
+
+// RunDue emails every member with new approved comments on due subscriptions.
+// Failures for one member do not stop the others; they are retried next run.
+// It returns shared.ErrLocked while another replica runs the job.
+func (s *CommentReplyService) RunDue(ctx context.Context, at time.Time) (*DigestRunResult, error) {
+	return runLocked(ctx, s.locker, CommentReplyLockKey, func(ctx context.Context) (*DigestRunResult, error) {
+		return s.runDue(ctx, at)
+	})
+}
+
+func (s *CommentReplyService) runDue(ctx context.Context, at time.Time) (*DigestRunResult, error) {
+	result := &DigestRunResult{}
+	after := ""
+	for {
+		accountIDs, err := s.subscriptions.FindPendingAccounts(ctx, after, replyAccountsBatch)
+		if err != nil {
+			return result, fmt.Errorf("failed to load comment subscribers: %w", err)
+		}
+		for _, accountID := range accountIDs {
+			if err := s.notify(ctx, accountID, at, result); err != nil {
+				return result, err
+			}
+		}
+		if len(accountIDs) < replyAccountsBatch {
+			return result, nil
+		}
+		after = accountIDs[len(accountIDs)-1]
+	}
+}
+
+// notify counts the outcome for one member, only a failed save stops the run
+func (s *CommentReplyService) notify(ctx context.Context, accountID string, at time.Time, result *DigestRunResult) error {
+	subs, err := s.subscriptions.FindByAccount(ctx, accountID)
+	if err != nil {
+		result.Failed++
+		return nil
+	}
+	var due []*comment.Subscription
+	for _, sub := range subs {
+		if sub.IsDue(at) {
+			due = append(due, sub)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	member, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		result.Failed++
+		return nil
+	}
+	if member == nil || !member.IsActive() {
+		// Nobody to tell, move past the comments so the account stops coming up
+		result.Skipped++
+		return s.skipTo(ctx, due, at)
+	}
+	sent, err := s.deliveries.CountSince(ctx, accountID, at.Add(-24*time.Hour))
+	if err != nil {
+		result.Failed++
+		return nil
+	}
+	if sent >= comment.MaxNotificationsPerDay {
+		// The replies stay pending and go out together once the day's cap frees up
+		result.Skipped++
+		return nil
+	}
+
+	updates, err := s.collect(ctx, due)
+	if err != nil {
+		result.Failed++
+		return nil
+	}
+	data, ok, err := s.templateData(ctx, member, updates)
+	if err != nil {
+		result.Failed++
+		return nil
+	}
+	if !ok {
+		result.Skipped++
+		return s.markUpdates(ctx, updates, false, at)
+	}
+
+	rendered, err := s.templates.Render(ctx, CommentRepliesTemplate, data)
+	if err != nil {
+		result.Failed++
+		return nil
+	}
+	message := EmailMessage{To: member.Email, Subject: rendered.Subject, HTMLBody: rendered.HTMLBody, TextBody: rendered.TextBody}
+	if err := s.sender.SendEmail(ctx, message); err != nil {
+		if errors.Is(err, ErrRecipientSuppressed) {
+			result.Skipped++
+			return s.markUpdates(ctx, updates, false, at)
+		}
+		result.Failed++
+		return nil
+	}
+	result.Sent++
+	if err := s.deliveries.Record(ctx, accountID, at); err != nil {
+		return fmt.Errorf("failed to record reply email to %s: %w", accountID, err)
+	}
+	return s.markUpdates(ctx, updates, true, at)
+}
+
+// collect reads the comments approved since each subscription was last notified,
+// keeping the ones the subscriber should hear about
+func (s *CommentReplyService) collect(ctx context.Context, subs []*comment.Subscription) ([]threadUpdate, error) {
+	updates := make([]threadUpdate, 0, len(subs))
+	for _, sub := range subs {
+		approved, err := s.comments.FindApprovedSince(ctx, sub.ArticleID, sub.RootPath, sub.NotifiedThrough, comment.MaxRepliesPerThread)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load new comments: %w", err)
+		}
+		update := threadUpdate{subscription: sub, through: sub.NotifiedThrough}
+		for _, c := range approved {
+			if c.ModeratedAt != nil && c.ModeratedAt.After(update.through) {
+				update.through = *c.ModeratedAt
+			}
+			if sub.Covers(c) {
+				update.replies = append(update.replies, c)
+			}
+		}
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// templateData lists the threads with replies on published articles, false
+// when none are left to report
+func (s *CommentReplyService) templateData(ctx context.Context, member *account.UserAccount, updates []threadUpdate) (map[string]any, bool, error) {
+	var articleIDs []string
+	for _, u := range updates {
+		if len(u.replies) > 0 {
+			articleIDs = append(articleIDs, u.subscription.ArticleID)
+		}
+	}
+	if len(articleIDs) == 0 {
+		return nil, false, nil
+	}
+	articles, err := s.published.FindPublished(ctx, articleIDs)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load articles: %w", err)
+	}
+
+	threads := make([]map[string]any, 0, len(updates))
+	total := 0
+	for _, u := range updates {
+		article, ok := articles[u.subscription.ArticleID]
+		if !ok || len(u.replies) == 0 {
+			continue
+		}
+		path, err := s.paths.Path(article.Ref)
+		if err != nil {
+			continue
+		}
+		replies := make([]map[string]any, 0, len(u.replies))
+		for _, c := range u.replies {
+			replies = append(replies, map[string]any{
+				"excerpt": excerpt(c.Body, replyExcerptLength),
+				"url":     s.baseURL + path + "#comment-" + c.ID,
+			})
+		}
+		total += len(replies)
+		threads = append(threads, map[string]any{
+			"article_title":   article.Title,
+			"url":             s.baseURL + path,
+			"is_thread":       u.subscription.IsThread(),
+			"replies":         replies,
+			"unsubscribe_url": s.baseURL + unsubscribeLinkPath + "?token=" + url.QueryEscape(u.subscription.UnsubscribeToken),
+		})
+	}
+	if len(threads) == 0 {
+		return nil, false, nil
+	}
+	return map[string]any{
+		"username":    member.Username.String(),
+		"threads":     threads,
+		"reply_count": total,
+	}, true, nil
+}
+
+func (s *CommentReplyService) markUpdates(ctx context.Context, updates []threadUpdate, sent bool, at time.Time) error {
+	for _, u := range updates {
+		u.subscription.MarkNotified(u.through, sent && len(u.replies) > 0, at)
+		if err := s.subscriptions.Update(ctx, u.subscription); err != nil {
+			return fmt.Errorf("failed to update comment subscription %s: %w", u.subscription.ID, err)
+		}
+	}
+	return nil
+}
+
+// skipTo moves the subscriptions past every comment approved up to the given time without sending
+func (s *CommentReplyService) skipTo(ctx context.Context, subs []*comment.Subscription, at time.Time) error {
+	updates := make([]threadUpdate, 0, len(subs))
+	for _, sub := range subs {
+		updates = append(updates, threadUpdate{subscription: sub, through: at})
+	}
+	return s.markUpdates(ctx, updates, false, at)
+}
+
+// excerpt cuts text to at most n runes at a word boundary
+func excerpt(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	cut := n
+	for cut > n/2 && runes[cut] != ' ' {
+		cut--
+	}
+	return string(runes[:cut]) + "…"
+}
@@ -0,0 +1,169 @@
+package notification
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// fakeCommentSubscriptions implements only the lookups the reply job uses
+type fakeCommentSubscriptions struct {
+	comment.SubscriptionRepository
+	subs    []*comment.Subscription
+	updated int
+}
+
+func (f *fakeCommentSubscriptions) FindPendingAccounts(ctx context.Context, after string, limit int) ([]string, error) {
+	seen := map[string]bool{}
+	var accountIDs []string
+	for _, s := range f.subs {
+		if s.AccountID > after && !seen[s.AccountID] {
+			seen[s.AccountID] = true
+			accountIDs = append(accountIDs, s.AccountID)
+		}
+	}
+	return accountIDs, nil
+}
+
+func (f *fakeCommentSubscriptions) FindByAccount(ctx context.Context, accountID string) ([]*comment.Subscription, error) {
+	var found []*comment.Subscription
+	for _, s := range f.subs {
+		if s.AccountID == accountID {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeCommentSubscriptions) Update(ctx context.Context, s *comment.Subscription) error {
+	f.updated++
+	return nil
+}
+
+// fakeApprovedComments implements only the lookup the reply job uses
+type fakeApprovedComments struct {
+	comment.CommentRepository
+	comments []*comment.Comment // Oldest approval first
+}
+
+func (f *fakeApprovedComments) FindApprovedSince(ctx context.Context, articleID string, root *comment.Path, since time.Time, limit int) ([]*comment.Comment, error) {
+	var found []*comment.Comment
+	for _, c := range f.comments {
+		if c.ArticleID == articleID && c.ModeratedAt.After(since) && (root == nil || root.IsAncestorOf(c.Path)) && len(found) < limit {
+			found = append(found, c)
+		}
+	}
+	return found, nil
+}
+
+type memoryDeliveries map[string][]time.Time
+
+func (m memoryDeliveries) Record(ctx context.Context, accountID string, at time.Time) error {
+	m[accountID] = append(m[accountID], at)
+	return nil
+}
+
+func (m memoryDeliveries) CountSince(ctx context.Context, accountID string, since time.Time) (int, error) {
+	n := 0
+	for _, at := range m[accountID] {
+		if at.After(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func approvedAt(t *testing.T, id string, parent *comment.Comment, accountID, body string, seq uint32, at time.Time) *comment.Comment {
+	t.Helper()
+	var c *comment.Comment
+	var err error
+	if parent == nil {
+		c, err = comment.NewComment(id, "a1", comment.AccountAuthor(accountID), body, seq)
+	} else {
+		c, err = comment.NewReply(id, parent, comment.AccountAuthor(accountID), body, seq)
+	}
+	if err != nil {
+		t.Fatalf("failed to create comment: %v", err)
+	}
+	if err := c.Approve("mod-1", at); err != nil {
+		t.Fatalf("failed to approve comment: %v", err)
+	}
+	return c
+}
+
+func TestCommentReplyService_RunDue(t *testing.T) {
+	ctx := context.Background()
+	templates, _ := createTemplateService(t)
+	activateTemplate(t, templates, CommentRepliesTemplate, emailtemplate.Content{
+		Subject:  "{{.reply_count}} new comments for {{.username}}",
+		HTMLBody: `{{range .threads}}<a href="{{.url}}">{{.article_title}}</a>{{end}}`,
+		TextBody: `{{range .threads}}{{.article_title}}{{range .replies}} | {{.excerpt}} {{.url}}{{end}} | {{.unsubscribe_url}}` + "\n" + `{{end}}`,
+	}, map[string]any{"username": "member", "reply_count": 1, "threads": []map[string]any{}})
+
+	start := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	root := approvedAt(t, "c1", nil, "member1", "Sharp analysis", 1, start)
+	thread, _ := comment.NewThreadSubscription("s1", "member1", root, comment.FrequencyInstant, "token-1", start)
+	daily, _ := comment.NewArticleSubscription("s2", "member2", "a1", comment.FrequencyDaily, "token-2", start)
+	capped, _ := comment.NewArticleSubscription("s3", "member3", "a1", comment.FrequencyInstant, "token-3", start)
+	subscriptions := &fakeCommentSubscriptions{subs: []*comment.Subscription{thread, daily, capped}}
+
+	comments := &fakeApprovedComments{comments: []*comment.Comment{
+		approvedAt(t, "c2", root, "member2", "Agreed, the figures hold up", 1, start.Add(time.Minute)),
+		approvedAt(t, "c3", root, "member1", "Thanks", 2, start.Add(2*time.Minute)),
+	}}
+	deliveries := memoryDeliveries{}
+	for i := 0; i < comment.MaxNotificationsPerDay; i++ {
+		_ = deliveries.Record(ctx, "member3", start.Add(-time.Hour))
+	}
+
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{
+		"member1": createActiveMember(t, "member1", "reader", "reader@example.com"),
+		"member2": createActiveMember(t, "member2", "daily", "daily@example.com"),
+		"member3": createActiveMember(t, "member3", "chatty", "chatty@example.com"),
+	}}
+	stream := &fakeArticleStream{published: map[string]topic.ArticleSummary{
+		"a1": {Ref: permalink.ArticleRef{ID: "a1", CategorySlug: "news", Slug: "a1"}, Title: "Floods in Jakarta"},
+	}}
+	sender := &fakeEmailSender{}
+	service := NewCommentReplyService(subscriptions, comments, deliveries, accounts, stream, templates, sender, shared.NoLock{}, fakeArticlePaths{}, "https://news.example.com")
+
+	at := start.Add(10 * time.Minute)
+	result, err := service.RunDue(ctx, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The daily subscriber waits for their day, the capped one for the cap to free up
+	if result.Sent != 1 || result.Skipped != 1 || result.Failed != 0 {
+		t.Fatalf("expected 1 sent and 1 capped, got %+v", result)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].Subject != "1 new comments for reader" {
+		t.Fatalf("unexpected emails %+v", sender.sent)
+	}
+	want := "Floods in Jakarta | Agreed, the figures hold up https://news.example.com/news/a1#comment-c2 | https://news.example.com/comments/subscriptions/unsubscribe?token=token-1\n"
+	if sender.sent[0].TextBody != want {
+		t.Errorf("expected body\n%s\ngot\n%s", want, sender.sent[0].TextBody)
+	}
+	if !thread.NotifiedThrough.Equal(start.Add(2*time.Minute)) || len(deliveries["member1"]) != 1 {
+		t.Errorf("expected the thread moved past both replies, got %v", thread.NotifiedThrough)
+	}
+	if !capped.NotifiedThrough.Equal(start) {
+		t.Errorf("expected capped replies left pending, got %v", capped.NotifiedThrough)
+	}
+
+	if result, _ := service.RunDue(ctx, at.Add(time.Minute)); result.Sent != 0 {
+		t.Errorf("expected nothing new a minute later, got %+v", result)
+	}
+
+	result, _ = service.RunDue(ctx, start.Add(comment.DailyInterval))
+	if result.Sent != 2 || !strings.Contains(sender.sent[1].TextBody, "Thanks") {
+		t.Errorf("expected the daily and the uncapped emails a day later, got %+v", result)
+	}
+}
@@ -0,0 +1,187 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/digest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// DigestSectionSource contributes one section to the activity digest
+type DigestSectionSource interface {
+	Section(ctx context.Context, since, until time.Time) (*digest.Section, error)
+}
+
+// DigestSender delivers a rendered digest to a staff member
+type DigestSender interface {
+	SendDigest(ctx context.Context, recipient account.Email, d *digest.Digest) error
+}
+
+// DigestService is the scheduled job behind the admin activity digest emails
+type DigestService struct {
+	subscriptions digest.SubscriptionRepository
+	accounts      account.UserAccountRepository
+	sources       []DigestSectionSource
+	sender        DigestSender
+	locker        shared.Locker
+}
+
+func NewDigestService(subscriptions digest.SubscriptionRepository, accounts account.UserAccountRepository, sender DigestSender, locker shared.Locker, sources ...DigestSectionSource) *DigestService {
+	return &DigestService{
+		subscriptions: subscriptions,
+		accounts:      accounts,
+		sources:       sources,
+		sender:        sender,
+		locker:        locker,
+	}
+}
+
+// Scheduled jobs hold a lock while they run, so replicas that share a schedule
+// never send the same email twice. The lease is extended while a run goes on.
+const (
+	DigestLockKey       = "notification.digest"
+	CommentReplyLockKey = "notification.comment_replies"
+	ReaderDigestLockKey = "notification.reader_digest"
+	JobLockTTL          = time.Minute
+)
+
+// DigestRunResult summarizes one job run
+type DigestRunResult struct {
+	Sent    int
+	Skipped int // Due but nothing to report, recipient no longer eligible or suppressed
+	Failed  int
+}
+
+// RunDue sends every digest whose schedule has elapsed at the given time.
+// Failures for one recipient do not stop the others; they are retried next run.
+// It returns shared.ErrLocked while another replica runs the job.
+func (s *DigestService) RunDue(ctx context.Context, at time.Time) (*DigestRunResult, error) {
+	return runLocked(ctx, s.locker, DigestLockKey, func(ctx context.Context) (*DigestRunResult, error) {
+		return s.runDue(ctx, at)
+	})
+}
+
+func (s *DigestService) runDue(ctx context.Context, at time.Time) (*DigestRunResult, error) {
+	subs, err := s.subscriptions.FindEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load digest subscriptions: %w", err)
+	}
+
+	result := &DigestRunResult{}
+	for _, sub := range subs {
+		if !sub.IsDue(at) {
+			continue
+		}
+
+		staff, err := s.accounts.FindByID(ctx, sub.AccountID)
+		if err != nil {
+			result.Failed++
+			continue
+		}
+		if staff == nil || !staff.IsActive() || !staff.IsInternal() {
+			result.Skipped++
+			continue
+		}
+
+		d, err := s.Build(ctx, sub, at)
+		if err != nil {
+			result.Failed++
+			continue
+		}
+
+		if !d.IsEmpty() {
+			if err := s.sender.SendDigest(ctx, staff.Email, d); errors.Is(err, ErrRecipientSuppressed) {
+				result.Skipped++
+			} else if err != nil {
+				result.Failed++
+				continue
+			} else {
+				result.Sent++
+			}
+		} else {
+			result.Skipped++
+		}
+
+		sub.MarkSent(at)
+		if err := s.subscriptions.Update(ctx, sub); err != nil {
+			return result, fmt.Errorf("failed to update digest subscription %s: %w", sub.ID, err)
+		}
+	}
+	return result, nil
+}
+
+// Build assembles the digest for a subscription without sending it
+func (s *DigestService) Build(ctx context.Context, sub *digest.Subscription, at time.Time) (*digest.Digest, error) {
+	d := &digest.Digest{
+		AccountID:   sub.AccountID,
+		PeriodStart: sub.PeriodStart(at),
+		PeriodEnd:   at,
+	}
+	for _, source := range s.sources {
+		section, err := source.Section(ctx, d.PeriodStart, d.PeriodEnd)
+		if err != nil {
+			return nil, err
+		}
+		if section != nil {
+			d.Sections = append(d.Sections, *section)
+		}
+	}
+	return d, nil
+}
+
+// PendingVerificationSource reports registrations still awaiting verification
+type PendingVerificationSource struct {
+	accounts account.UserAccountRepository
+}
+
+func NewPendingVerificationSource(accounts account.UserAccountRepository) *PendingVerificationSource {
+	return &PendingVerificationSource{accounts: accounts}
+}
+
+func (p *PendingVerificationSource) Section(ctx context.Context, since, until time.Time) (*digest.Section, error) {
+	status := account.StatusPendingVerification
+	filter := &account.UserAccountFilter{Status: &status, CreatedBefore: &until}
+	filter.SetDefaults()
+
+	count, err := p.accounts.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pending verifications: %w", err)
+	}
+
+	return &digest.Section{
+		Key:   digest.SectionPendingVerifications,
+		Title: "Registrations awaiting verification",
+		Count: count,
+	}, nil
+}
+
+// CountSource adapts a plain counting function (articles awaiting review,
+// flagged comments, publish failures) into a digest section
+type CountSource struct {
+	Key   string
+	Title string
+	Count func(ctx context.Context, since, until time.Time) (int64, error)
+}
+
+func (c CountSource) Section(ctx context.Context, since, until time.Time) (*digest.Section, error) {
+	count, err := c.Count(ctx, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count %s: %w", c.Key, err)
+	}
+	return &digest.Section{Key: c.Key, Title: c.Title, Count: count}, nil
+}
+
+// runLocked runs one job under its lock
+func runLocked(ctx context.Context, locker shared.Locker, key string, run func(ctx context.Context) (*DigestRunResult, error)) (*DigestRunResult, error) {
+	var result *DigestRunResult
+	err := locker.Run(ctx, key, JobLockTTL, func(ctx context.Context) error {
+		var err error
+		result, err = run(ctx)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,195 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/digest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakeSubscriptionRepo struct {
+	subs    []*digest.Subscription
+	updated []string
+}
+
+func (f *fakeSubscriptionRepo) Create(ctx context.Context, s *digest.Subscription) error {
+	f.subs = append(f.subs, s)
+	return nil
+}
+
+func (f *fakeSubscriptionRepo) Update(ctx context.Context, s *digest.Subscription) error {
+	f.updated = append(f.updated, s.ID)
+	return nil
+}
+
+func (f *fakeSubscriptionRepo) FindByAccountID(ctx context.Context, accountID string) (*digest.Subscription, error) {
+	for _, s := range f.subs {
+		if s.AccountID == accountID {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSubscriptionRepo) FindEnabled(ctx context.Context) ([]*digest.Subscription, error) {
+	return f.subs, nil
+}
+
+// fakeAccountRepo implements only the lookups the digest job uses
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts     map[string]*account.UserAccount
+	pendingCount int64
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+func (f *fakeAccountRepo) Count(ctx context.Context, filter *account.UserAccountFilter) (int64, error) {
+	return f.pendingCount, nil
+}
+
+type fakeDigestSender struct {
+	sent []string
+	err  error
+}
+
+func (f *fakeDigestSender) SendDigest(ctx context.Context, recipient account.Email, d *digest.Digest) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, recipient.String())
+	return nil
+}
+
+func TestDigestService_RunDue(t *testing.T) {
+	at := time.Date(2026, 1, 7, 8, 0, 0, 0, time.UTC)
+
+	editor := createActiveStaff(t, "editor1", "editor_one", "editor@example.com")
+	quiet := createActiveStaff(t, "editor2", "editor_two", "quiet@example.com")
+	gone := createActiveStaff(t, "editor3", "editor_three", "gone@example.com")
+	_ = gone.Delete("admin1")
+	sentYesterday := at.Add(-23 * time.Hour)
+
+	subs := &fakeSubscriptionRepo{subs: []*digest.Subscription{
+		{ID: "s1", AccountID: "editor1", Frequency: digest.FrequencyDaily, SendHour: 7, Timezone: "UTC"},
+		{ID: "s2", AccountID: "editor3", Frequency: digest.FrequencyDaily, SendHour: 7, Timezone: "UTC"},
+		{ID: "s3", AccountID: "editor2", Frequency: digest.FrequencyDaily, SendHour: 9, Timezone: "UTC", LastSentAt: &sentYesterday},
+	}}
+	accounts := &fakeAccountRepo{
+		accounts:     map[string]*account.UserAccount{"editor1": editor, "editor2": quiet, "editor3": gone},
+		pendingCount: 4,
+	}
+	sender := &fakeDigestSender{}
+	flagged := CountSource{
+		Key:   digest.SectionFlaggedComments,
+		Title: "Flagged comments",
+		Count: func(ctx context.Context, since, until time.Time) (int64, error) { return 2, nil },
+	}
+
+	service := NewDigestService(subs, accounts, sender, shared.NoLock{}, NewPendingVerificationSource(accounts), flagged)
+	result, err := service.RunDue(context.Background(), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Sent != 1 || result.Skipped != 1 || result.Failed != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(sender.sent) != 1 || sender.sent[0] != "editor@example.com" {
+		t.Errorf("expected digest to be sent to editor@example.com, got %v", sender.sent)
+	}
+	if subs.subs[0].LastSentAt == nil || !subs.subs[0].LastSentAt.Equal(at) {
+		t.Error("expected subscription to be marked as sent")
+	}
+	if !subs.subs[2].LastSentAt.Equal(sentYesterday) {
+		t.Error("expected subscription not yet due to be left alone")
+	}
+}
+
+func TestDigestService_RunDue_SendFailure(t *testing.T) {
+	at := time.Date(2026, 1, 7, 8, 0, 0, 0, time.UTC)
+	editor := createActiveStaff(t, "editor1", "editor_one", "editor@example.com")
+
+	subs := &fakeSubscriptionRepo{subs: []*digest.Subscription{
+		{ID: "s1", AccountID: "editor1", Frequency: digest.FrequencyDaily, SendHour: 7, Timezone: "UTC"},
+	}}
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{"editor1": editor}, pendingCount: 1}
+	sender := &fakeDigestSender{err: errors.New("smtp down")}
+
+	service := NewDigestService(subs, accounts, sender, shared.NoLock{}, NewPendingVerificationSource(accounts))
+	result, err := service.RunDue(context.Background(), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Failed != 1 {
+		t.Errorf("expected 1 failure, got %+v", result)
+	}
+	if subs.subs[0].LastSentAt != nil {
+		t.Error("expected failed digest to stay due for the next run")
+	}
+}
+
+// heldLocker is a job lock another replica holds
+type heldLocker struct{}
+
+func (heldLocker) Run(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	return shared.ErrLocked
+}
+
+func TestDigestService_RunDue_Locked(t *testing.T) {
+	at := time.Date(2026, 1, 7, 8, 0, 0, 0, time.UTC)
+	editor := createActiveStaff(t, "editor1", "editor_one", "editor@example.com")
+	subs := &fakeSubscriptionRepo{subs: []*digest.Subscription{
+		{ID: "s1", AccountID: "editor1", Frequency: digest.FrequencyDaily, SendHour: 7, Timezone: "UTC"},
+	}}
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{"editor1": editor}, pendingCount: 1}
+	sender := &fakeDigestSender{}
+
+	service := NewDigestService(subs, accounts, sender, heldLocker{}, NewPendingVerificationSource(accounts))
+	if _, err := service.RunDue(context.Background(), at); !errors.Is(err, shared.ErrLocked) {
+		t.Fatalf("expected error '%v', got '%v'", shared.ErrLocked, err)
+	}
+	if len(sender.sent) != 0 || subs.subs[0].LastSentAt != nil {
+		t.Errorf("expected nothing sent while another replica runs the job, got %v", sender.sent)
+	}
+}
+
+func TestDigestService_RunDue_Suppressed(t *testing.T) {
+	at := time.Date(2026, 1, 7, 8, 0, 0, 0, time.UTC)
+	editor := createActiveStaff(t, "editor1", "editor_one", "editor@example.com")
+
+	subs := &fakeSubscriptionRepo{subs: []*digest.Subscription{
+		{ID: "s1", AccountID: "editor1", Frequency: digest.FrequencyDaily, SendHour: 7, Timezone: "UTC"},
+	}}
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{"editor1": editor}, pendingCount: 1}
+	sender := &fakeDigestSender{err: ErrRecipientSuppressed}
+
+	service := NewDigestService(subs, accounts, sender, shared.NoLock{}, NewPendingVerificationSource(accounts))
+	result, err := service.RunDue(context.Background(), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Skipped != 1 || result.Failed != 0 {
+		t.Errorf("expected the suppressed recipient skipped, got %+v", result)
+	}
+	if subs.subs[0].LastSentAt == nil {
+		t.Error("expected the suppressed digest marked sent so it is not retried")
+	}
+}
+
+func createActiveStaff(t *testing.T, id, username, email string) *account.UserAccount {
+	acc, err := account.NewUserAccountForTesting(id, username, email, "StaffPass123!", account.TypeInternal, "admin123")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+	return acc
+}
@@ -0,0 +1,153 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// PasswordResetTemplate is the email template key for the password reset link
+const PasswordResetTemplate = "password_reset"
+
+// LetterAcknowledgmentTemplate is the email template key confirming a letter to the editor arrived
+const LetterAcknowledgmentTemplate = "letter_acknowledgment"
+
+// MaxAttachmentBytes caps the attachments of one email, providers reject
+// messages past 10 MB and base64 adds a third
+const MaxAttachmentBytes = 7 << 20
+
+var (
+	ErrNoRecipients        = errors.New("email has no recipients")
+	ErrInvalidAttachment   = errors.New("attachment needs a file name and content")
+	ErrAttachmentsTooLarge = errors.New("attachments exceed 7 MB")
+)
+
+// Attachment is a file sent along with an email
+type Attachment struct {
+	Filename    string
+	ContentType string // Guessed from the file name when empty
+	Data        []byte
+}
+
+// Mail is a templated email to one or more recipients
+type Mail struct {
+	Template    string
+	Data        map[string]any
+	To          []account.Email
+	Attachments []Attachment
+}
+
+// Mailer sends templated email, the port verification, password reset and
+// editorial notifications go through
+type Mailer interface {
+	Send(ctx context.Context, mail Mail) error
+}
+
+// TemplateMailer renders the template's live version once and sends every
+// recipient their own copy, so addresses are never disclosed to each other.
+// Wrap the sender in NewSuppressingSender so bounced addresses are skipped.
+type TemplateMailer struct {
+	templates *TemplateService
+	sender    EmailSender
+}
+
+func NewTemplateMailer(templates *TemplateService, sender EmailSender) *TemplateMailer {
+	return &TemplateMailer{templates: templates, sender: sender}
+}
+
+var _ Mailer = (*TemplateMailer)(nil)
+
+// Send keeps going when one recipient fails and reports every failure.
+// Suppressed recipients are skipped silently.
+func (m *TemplateMailer) Send(ctx context.Context, mail Mail) error {
+	if len(mail.To) == 0 {
+		return ErrNoRecipients
+	}
+	if err := validateAttachments(mail.Attachments); err != nil {
+		return err
+	}
+	rendered, err := m.templates.Render(ctx, mail.Template, mail.Data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s email: %w", mail.Template, err)
+	}
+
+	var errs []error
+	for _, to := range mail.To {
+		message := EmailMessage{
+			To:          to,
+			Subject:     rendered.Subject,
+			HTMLBody:    rendered.HTMLBody,
+			TextBody:    rendered.TextBody,
+			Attachments: mail.Attachments,
+		}
+		if err := m.sender.SendEmail(ctx, message); err != nil && !errors.Is(err, ErrRecipientSuppressed) {
+			errs = append(errs, fmt.Errorf("failed to send %s email to %s: %w", mail.Template, to, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateAttachments(attachments []Attachment) error {
+	total := 0
+	for _, a := range attachments {
+		if strings.TrimSpace(a.Filename) == "" || len(a.Data) == 0 {
+			return ErrInvalidAttachment
+		}
+		total += len(a.Data)
+	}
+	if total > MaxAttachmentBytes {
+		return ErrAttachmentsTooLarge
+	}
+	return nil
+}
+
+// PasswordResetMailer emails the reset link, it is the account service's PasswordResetNotifier
+type PasswordResetMailer struct {
+	mailer  Mailer
+	baseURL string
+}
+
+func NewPasswordResetMailer(mailer Mailer, baseURL string) *PasswordResetMailer {
+	return &PasswordResetMailer{mailer: mailer, baseURL: baseURL}
+}
+
+func (m *PasswordResetMailer) SendPasswordReset(ctx context.Context, email account.Email, token string, reset *account.PasswordReset) error {
+	return m.mailer.Send(ctx, Mail{
+		Template: PasswordResetTemplate,
+		Data: map[string]any{
+			"reset_url":  m.baseURL + "/reset-password?token=" + url.QueryEscape(token),
+			"expires_at": reset.ExpiresAt.Format(time.RFC3339),
+		},
+		To: []account.Email{email},
+	})
+}
+
+// LetterAcknowledgmentMailer confirms a letter to the editor arrived, it is the
+// letter service's AcknowledgmentNotifier
+type LetterAcknowledgmentMailer struct {
+	mailer Mailer
+}
+
+func NewLetterAcknowledgmentMailer(mailer Mailer) *LetterAcknowledgmentMailer {
+	return &LetterAcknowledgmentMailer{mailer: mailer}
+}
+
+var _ letter.AcknowledgmentNotifier = (*LetterAcknowledgmentMailer)(nil)
+
+func (m *LetterAcknowledgmentMailer) SendAcknowledgment(ctx context.Context, l *letter.LetterSubmission) error {
+	return m.mailer.Send(ctx, Mail{
+		Template: LetterAcknowledgmentTemplate,
+		Data: map[string]any{
+			"name":        l.Author.Name(),
+			"subject":     l.Subject,
+			"received_at": l.CreatedAt.Format(time.RFC3339),
+		},
+		To: []account.Email{l.Author.Email()},
+	})
+}
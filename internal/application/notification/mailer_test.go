@@ -0,0 +1,149 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type failingSender struct {
+	fail map[string]bool
+	sent []EmailMessage
+}
+
+func (f *failingSender) SendEmail(ctx context.Context, message EmailMessage) error {
+	if f.fail[message.To.String()] {
+		return errors.New("mailbox unavailable")
+	}
+	f.sent = append(f.sent, message)
+	return nil
+}
+
+func activateTemplate(t *testing.T, templates *TemplateService, key string, content emailtemplate.Content, sample map[string]any) {
+	t.Helper()
+	ctx := context.Background()
+	_, _ = templates.CreateTemplate(ctx, "staff1", key, key, sample)
+	v, _ := templates.Draft(ctx, "staff1", key, content, "")
+	if _, err := templates.Activate(ctx, "staff1", key, v.Number); err != nil {
+		t.Fatalf("failed to activate template: %v", err)
+	}
+}
+
+func emails(t *testing.T, addresses ...string) []account.Email {
+	t.Helper()
+	var list []account.Email
+	for _, a := range addresses {
+		e, err := account.NewEmail(a)
+		if err != nil {
+			t.Fatalf("invalid email %s: %v", a, err)
+		}
+		list = append(list, *e)
+	}
+	return list
+}
+
+func TestTemplateMailer_Send(t *testing.T) {
+	ctx := context.Background()
+	templates, _ := createTemplateService(t)
+	activateTemplate(t, templates, "embargo_lifted", emailtemplate.Content{
+		Subject:  "Embargo lifted: {{.headline}}",
+		HTMLBody: "<p>{{.headline}} is live</p>",
+	}, map[string]any{"headline": "Budget"})
+
+	sender := &failingSender{fail: map[string]bool{"desk@example.com": true}}
+	mailer := NewTemplateMailer(templates, NewSuppressingSender(fakeSuppressions{"bounced@example.com": true}, sender))
+	report := Attachment{Filename: "embargo.pdf", ContentType: "application/pdf", Data: []byte("%PDF-")}
+
+	err := mailer.Send(ctx, Mail{
+		Template:    "embargo_lifted",
+		Data:        map[string]any{"headline": "Budget 2027"},
+		To:          emails(t, "editor@example.com", "bounced@example.com", "desk@example.com", "legal@example.com"),
+		Attachments: []Attachment{report},
+	})
+	if err == nil || !strings.Contains(err.Error(), "desk@example.com") {
+		t.Errorf("expected the failed recipient reported, got %v", err)
+	}
+	if len(sender.sent) != 2 || sender.sent[0].To.String() != "editor@example.com" || sender.sent[1].To.String() != "legal@example.com" {
+		t.Fatalf("expected one copy per deliverable recipient, got %+v", sender.sent)
+	}
+	if sender.sent[0].Subject != "Embargo lifted: Budget 2027" || len(sender.sent[1].Attachments) != 1 {
+		t.Errorf("unexpected message %+v", sender.sent[0])
+	}
+}
+
+func TestTemplateMailer_SendRejects(t *testing.T) {
+	ctx := context.Background()
+	templates, sender := createTemplateService(t)
+	mailer := NewTemplateMailer(templates, sender)
+	to := emails(t, "editor@example.com")
+
+	testCases := []struct {
+		name     string
+		mail     Mail
+		expected error
+	}{
+		{"no recipients", Mail{Template: "embargo_lifted"}, ErrNoRecipients},
+		{"unnamed attachment", Mail{Template: "embargo_lifted", To: to, Attachments: []Attachment{{Data: []byte("x")}}}, ErrInvalidAttachment},
+		{"empty attachment", Mail{Template: "embargo_lifted", To: to, Attachments: []Attachment{{Filename: "a.txt"}}}, ErrInvalidAttachment},
+		{"too large", Mail{Template: "embargo_lifted", To: to, Attachments: []Attachment{{Filename: "a.bin", Data: make([]byte, MaxAttachmentBytes+1)}}}, ErrAttachmentsTooLarge},
+		{"unknown template", Mail{Template: "embargo_lifted", To: to}, ErrTemplateNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := mailer.Send(ctx, tc.mail); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+	if len(sender.sent) != 0 {
+		t.Errorf("expected nothing sent, got %d", len(sender.sent))
+	}
+}
+
+func TestPasswordResetMailer(t *testing.T) {
+	ctx := context.Background()
+	templates, sender := createTemplateService(t)
+	activateTemplate(t, templates, PasswordResetTemplate, emailtemplate.Content{
+		Subject:  "Reset your password",
+		HTMLBody: `<a href="{{.reset_url}}">Reset</a> before {{.expires_at}}`,
+	}, map[string]any{"reset_url": "", "expires_at": ""})
+
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	reset, _ := account.NewPasswordReset("r1", "m1", "hash", "203.0.113.9", at)
+	mailer := NewPasswordResetMailer(NewTemplateMailer(templates, sender), "https://news.example.com")
+
+	if err := mailer.SendPasswordReset(ctx, emails(t, "sari@example.com")[0], "a+b/c", reset); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `<a href="https://news.example.com/reset-password?token=a%2Bb%2Fc">Reset</a> before 2026-03-10T10:00:00Z`
+	if len(sender.sent) != 1 || sender.sent[0].HTMLBody != expected {
+		t.Errorf("unexpected emails %+v", sender.sent)
+	}
+}
+
+func TestLetterAcknowledgmentMailer(t *testing.T) {
+	ctx := context.Background()
+	templates, sender := createTemplateService(t)
+	activateTemplate(t, templates, LetterAcknowledgmentTemplate, emailtemplate.Content{
+		Subject:  "We received your letter",
+		HTMLBody: `Dear {{.name}}, thank you for "{{.subject}}"`,
+	}, map[string]any{"name": "", "subject": "", "received_at": ""})
+
+	author, _ := letter.NewAuthor("Jane Doe", "jane@example.com", "Bandung")
+	l, _ := letter.NewLetterSubmission("ltr-1", *author, "Flooding", "The drains were never cleared.", nil)
+	mailer := NewLetterAcknowledgmentMailer(NewTemplateMailer(templates, sender))
+
+	if err := mailer.SendAcknowledgment(ctx, l); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To.String() != "jane@example.com" || sender.sent[0].HTMLBody != `Dear Jane Doe, thank you for "Flooding"` {
+		t.Errorf("unexpected emails %+v", sender.sent)
+	}
+}
@@ -0,0 +1,348 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/digest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+// ReaderDigestTemplate is the email template key for the member "weekly digest for you"
+const ReaderDigestTemplate = "reader_digest"
+
+const (
+	ReaderDigestPicks    = 8
+	ReaderHistoryWindow  = 30 * 24 * time.Hour // Reads that shape "because you read" and are never recommended again
+	ReaderTrendingWindow = 7 * 24 * time.Hour
+	readerScheduleBatch  = 500
+)
+
+var ErrMemberNotFound = errors.New("member not found")
+
+// ReaderArticles finds recommendation candidates (implemented by the article and analytics modules)
+type ReaderArticles interface {
+	// ReadBy returns the articles the member opened since the given time, most recent first
+	ReadBy(ctx context.Context, accountID string, since time.Time) ([]string, error)
+	// InTopics returns articles published since the given time in any of the topics,
+	// newest first, with the topic's name as Context
+	InTopics(ctx context.Context, topicIDs []string, since time.Time, limit int) ([]digest.Pick, error)
+	// SimilarTo returns articles published since the given time sharing tags or entities
+	// with the given ones, best match first, with the read article's title as Context
+	SimilarTo(ctx context.Context, articleIDs []string, since time.Time, limit int) ([]digest.Pick, error)
+}
+
+// ReaderDigestService is the scheduled job behind the member "weekly digest for you"
+// emails. Wrap the sender in NewSuppressingSender so bounced and unsubscribed
+// addresses are skipped. Members who have not consented to marketing email and
+// personalization are skipped as well.
+type ReaderDigestService struct {
+	schedules digest.ReaderScheduleRepository
+	accounts  account.UserAccountRepository
+	consent   consent.Checker
+	follows   topic.FollowRepository
+	articles  ReaderArticles
+	trending  analytics.TrendingQuery
+	published topic.ArticleStream
+	templates *TemplateService
+	sender    EmailSender
+	locker    shared.Locker
+	paths     ArticlePaths
+	baseURL   string
+}
+
+func NewReaderDigestService(schedules digest.ReaderScheduleRepository, accounts account.UserAccountRepository, checker consent.Checker, follows topic.FollowRepository, articles ReaderArticles, trending analytics.TrendingQuery, published topic.ArticleStream, templates *TemplateService, sender EmailSender, locker shared.Locker, paths ArticlePaths, baseURL string) *ReaderDigestService {
+	return &ReaderDigestService{
+		schedules: schedules,
+		accounts:  accounts,
+		consent:   checker,
+		follows:   follows,
+		articles:  articles,
+		trending:  trending,
+		published: published,
+		templates: templates,
+		sender:    sender,
+		locker:    locker,
+		paths:     paths,
+		baseURL:   baseURL,
+	}
+}
+
+// OptIn turns on the member's weekly digest, creating their schedule in the
+// given timezone the first time
+func (s *ReaderDigestService) OptIn(ctx context.Context, accountID, timezone string) (*digest.ReaderSchedule, error) {
+	schedule, err := s.schedules.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reader schedule: %w", err)
+	}
+	if schedule != nil {
+		schedule.Enable()
+		if err := s.schedules.Update(ctx, schedule); err != nil {
+			return nil, fmt.Errorf("failed to update reader schedule: %w", err)
+		}
+		return schedule, nil
+	}
+
+	member, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if member == nil {
+		return nil, ErrMemberNotFound
+	}
+	schedule, err = digest.NewReaderSchedule(member, timezone)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Enable()
+	if err := s.schedules.Create(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to create reader schedule: %w", err)
+	}
+	return schedule, nil
+}
+
+// OptOut turns the member's weekly digest off, keeping their learned send hour
+func (s *ReaderDigestService) OptOut(ctx context.Context, accountID string) error {
+	schedule, err := s.schedules.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to load reader schedule: %w", err)
+	}
+	if schedule == nil || !schedule.Enabled {
+		return nil
+	}
+	schedule.Disable()
+	if err := s.schedules.Update(ctx, schedule); err != nil {
+		return fmt.Errorf("failed to update reader schedule: %w", err)
+	}
+	return nil
+}
+
+// RecordOpen feeds a digest open, reported by the tracking pixel, into the member's send hour
+func (s *ReaderDigestService) RecordOpen(ctx context.Context, accountID string, at time.Time) error {
+	schedule, err := s.schedules.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to load reader schedule: %w", err)
+	}
+	if schedule == nil {
+		return nil
+	}
+	schedule.RecordOpen(at)
+	if err := s.schedules.Update(ctx, schedule); err != nil {
+		return fmt.Errorf("failed to update reader schedule: %w", err)
+	}
+	return nil
+}
+
+// RunDue sends every reader digest whose send time has passed at the given time.
+// Trending articles are ranked once per run. Failures for one member do not stop
+// the others; they are retried next run. It returns shared.ErrLocked while
+// another replica runs the job.
+func (s *ReaderDigestService) RunDue(ctx context.Context, at time.Time) (*DigestRunResult, error) {
+	return runLocked(ctx, s.locker, ReaderDigestLockKey, func(ctx context.Context) (*DigestRunResult, error) {
+		return s.runDue(ctx, at)
+	})
+}
+
+func (s *ReaderDigestService) runDue(ctx context.Context, at time.Time) (*DigestRunResult, error) {
+	trending, err := s.trendingPicks(ctx, at)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DigestRunResult{}
+	after := ""
+	for {
+		schedules, err := s.schedules.FindEnabled(ctx, after, readerScheduleBatch)
+		if err != nil {
+			return result, fmt.Errorf("failed to load reader schedules: %w", err)
+		}
+		for _, schedule := range schedules {
+			if !schedule.IsDue(at) {
+				continue
+			}
+			if !s.send(ctx, schedule, trending, at, result) {
+				continue
+			}
+			schedule.MarkSent(at)
+			if err := s.schedules.Update(ctx, schedule); err != nil {
+				return result, fmt.Errorf("failed to update reader schedule %s: %w", schedule.AccountID, err)
+			}
+		}
+		if len(schedules) < readerScheduleBatch {
+			return result, nil
+		}
+		after = schedules[len(schedules)-1].AccountID
+	}
+}
+
+// send counts the outcome for one member and reports whether their week is done
+func (s *ReaderDigestService) send(ctx context.Context, schedule *digest.ReaderSchedule, trending []digest.Pick, at time.Time, result *DigestRunResult) bool {
+	member, err := s.accounts.FindByID(ctx, schedule.AccountID)
+	if err != nil {
+		result.Failed++
+		return false
+	}
+	if member == nil || !member.IsActive() {
+		result.Skipped++
+		return true
+	}
+	allowed, err := s.consents(ctx, member.ID)
+	if err != nil {
+		result.Failed++
+		return false
+	}
+	if !allowed {
+		result.Skipped++
+		return true
+	}
+
+	d, err := s.Build(ctx, schedule, trending, at)
+	if err != nil {
+		result.Failed++
+		return false
+	}
+	if d.IsEmpty() {
+		result.Skipped++
+		return true
+	}
+
+	rendered, err := s.templates.Render(ctx, ReaderDigestTemplate, s.templateData(member, d))
+	if err != nil {
+		result.Failed++
+		return false
+	}
+	message := EmailMessage{To: member.Email, Subject: rendered.Subject, HTMLBody: rendered.HTMLBody, TextBody: rendered.TextBody}
+	if err := s.sender.SendEmail(ctx, message); err != nil {
+		if errors.Is(err, ErrRecipientSuppressed) {
+			result.Skipped++
+			return true
+		}
+		result.Failed++
+		return false
+	}
+	result.Sent++
+	return true
+}
+
+// consents reports whether the member agreed to both marketing email and the
+// personalization the picks are built from
+func (s *ReaderDigestService) consents(ctx context.Context, accountID string) (bool, error) {
+	subject, err := consent.NewAccountSubject(accountID)
+	if err != nil {
+		return false, err
+	}
+	for _, purpose := range []consent.Purpose{consent.PurposeMarketingEmail, consent.PurposePersonalization} {
+		allowed, err := s.consent.Allows(ctx, *subject, purpose)
+		if err != nil {
+			return false, fmt.Errorf("failed to check consent: %w", err)
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Build assembles a member's digest without sending it: articles from followed topics,
+// ones like what they read and trending ones, taken in turn and never already read
+func (s *ReaderDigestService) Build(ctx context.Context, schedule *digest.ReaderSchedule, trending []digest.Pick, at time.Time) (*digest.ReaderDigest, error) {
+	d := &digest.ReaderDigest{
+		AccountID:   schedule.AccountID,
+		PeriodStart: schedule.PeriodStart(at),
+		PeriodEnd:   at,
+	}
+
+	topicIDs, err := s.follows.FindTopicIDsByAccount(ctx, schedule.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load followed topics: %w", err)
+	}
+	var followed []digest.Pick
+	if len(topicIDs) > 0 {
+		if followed, err = s.articles.InTopics(ctx, topicIDs, d.PeriodStart, ReaderDigestPicks); err != nil {
+			return nil, fmt.Errorf("failed to load followed topic articles: %w", err)
+		}
+	}
+
+	readIDs, err := s.articles.ReadBy(ctx, schedule.AccountID, at.Add(-ReaderHistoryWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reading history: %w", err)
+	}
+	var similar []digest.Pick
+	if len(readIDs) > 0 {
+		if similar, err = s.articles.SimilarTo(ctx, readIDs, d.PeriodStart, ReaderDigestPicks); err != nil {
+			return nil, fmt.Errorf("failed to load similar articles: %w", err)
+		}
+	}
+
+	read := make(map[string]bool, len(readIDs))
+	for _, id := range readIDs {
+		read[id] = true
+	}
+	d.Picks = digest.SelectPicks(read, ReaderDigestPicks,
+		withReason(followed, digest.ReasonFollowedTopic),
+		withReason(similar, digest.ReasonReadingHistory),
+		trending,
+	)
+	return d, nil
+}
+
+// trendingPicks resolves the week's most viewed articles that are still published
+func (s *ReaderDigestService) trendingPicks(ctx context.Context, at time.Time) ([]digest.Pick, error) {
+	metrics, err := s.trending.Trending(ctx, ReaderTrendingWindow, at, ReaderDigestPicks*2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trending articles: %w", err)
+	}
+	if len(metrics) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		ids = append(ids, m.ArticleID)
+	}
+	summaries, err := s.published.FindPublished(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trending articles: %w", err)
+	}
+
+	picks := make([]digest.Pick, 0, len(summaries))
+	for _, id := range ids {
+		if summary, ok := summaries[id]; ok {
+			picks = append(picks, digest.Pick{Ref: summary.Ref, Title: summary.Title, Excerpt: summary.Excerpt, Reason: digest.ReasonTrending})
+		}
+	}
+	return picks, nil
+}
+
+func (s *ReaderDigestService) templateData(member *account.UserAccount, d *digest.ReaderDigest) map[string]any {
+	picks := make([]map[string]any, 0, len(d.Picks))
+	for _, p := range d.Picks {
+		path, err := s.paths.Path(p.Ref)
+		if err != nil {
+			continue
+		}
+		picks = append(picks, map[string]any{
+			"title":   p.Title,
+			"excerpt": p.Excerpt,
+			"url":     s.baseURL + path,
+			"reason":  string(p.Reason),
+			"context": p.Context,
+		})
+	}
+	return map[string]any{
+		"username": member.Username.String(),
+		"picks":    picks,
+	}
+}
+
+func withReason(picks []digest.Pick, reason digest.PickReason) []digest.Pick {
+	for i := range picks {
+		picks[i].Reason = reason
+	}
+	return picks
+}
@@ -0,0 +1,268 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/digest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+type memoryReaderSchedules struct {
+	schedules []*digest.ReaderSchedule
+	updated   []string
+}
+
+func (m *memoryReaderSchedules) Create(ctx context.Context, s *digest.ReaderSchedule) error {
+	m.schedules = append(m.schedules, s)
+	return nil
+}
+
+func (m *memoryReaderSchedules) Update(ctx context.Context, s *digest.ReaderSchedule) error {
+	m.updated = append(m.updated, s.AccountID)
+	return nil
+}
+
+func (m *memoryReaderSchedules) FindByAccountID(ctx context.Context, accountID string) (*digest.ReaderSchedule, error) {
+	for _, s := range m.schedules {
+		if s.AccountID == accountID {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryReaderSchedules) FindEnabled(ctx context.Context, after string, limit int) ([]*digest.ReaderSchedule, error) {
+	var page []*digest.ReaderSchedule
+	for _, s := range m.schedules {
+		if s.Enabled && s.AccountID > after && len(page) < limit {
+			page = append(page, s)
+		}
+	}
+	return page, nil
+}
+
+// fakeFollows implements only the lookup the reader digest uses
+type fakeFollows struct {
+	topic.FollowRepository
+	topicIDs map[string][]string
+}
+
+func (f *fakeFollows) FindTopicIDsByAccount(ctx context.Context, accountID string) ([]string, error) {
+	return f.topicIDs[accountID], nil
+}
+
+type fakeReaderArticles struct {
+	read    map[string][]string
+	topics  map[string][]digest.Pick
+	similar []digest.Pick
+}
+
+func (f *fakeReaderArticles) ReadBy(ctx context.Context, accountID string, since time.Time) ([]string, error) {
+	return f.read[accountID], nil
+}
+
+func (f *fakeReaderArticles) InTopics(ctx context.Context, topicIDs []string, since time.Time, limit int) ([]digest.Pick, error) {
+	var picks []digest.Pick
+	for _, id := range topicIDs {
+		picks = append(picks, f.topics[id]...)
+	}
+	return picks, nil
+}
+
+func (f *fakeReaderArticles) SimilarTo(ctx context.Context, articleIDs []string, since time.Time, limit int) ([]digest.Pick, error) {
+	return f.similar, nil
+}
+
+type fakeTrending struct {
+	metrics []analytics.ArticleMetric
+	calls   int
+}
+
+func (f *fakeTrending) Trending(ctx context.Context, window time.Duration, at time.Time, limit int) ([]analytics.ArticleMetric, error) {
+	f.calls++
+	return f.metrics, nil
+}
+
+// fakeArticleStream implements only the lookup the reader digest uses
+type fakeArticleStream struct {
+	topic.ArticleStream
+	published map[string]topic.ArticleSummary
+}
+
+func (f *fakeArticleStream) FindPublished(ctx context.Context, articleIDs []string) (map[string]topic.ArticleSummary, error) {
+	found := make(map[string]topic.ArticleSummary)
+	for _, id := range articleIDs {
+		if summary, ok := f.published[id]; ok {
+			found[id] = summary
+		}
+	}
+	return found, nil
+}
+
+type fakeSuppressions map[string]bool
+
+func (f fakeSuppressions) IsSuppressed(ctx context.Context, email account.Email) (bool, error) {
+	return f[email.String()], nil
+}
+
+var _ suppression.Checker = fakeSuppressions(nil)
+
+// fakeConsent grants the listed purposes to the listed subjects
+type fakeConsent map[string][]consent.Purpose
+
+func (f fakeConsent) Allows(ctx context.Context, subject consent.Subject, purpose consent.Purpose) (bool, error) {
+	return slices.Contains(f[subject.ID()], purpose), nil
+}
+
+var _ consent.Checker = fakeConsent(nil)
+
+func articlePick(id, title, context string) digest.Pick {
+	return digest.Pick{Ref: permalink.ArticleRef{ID: id, CategorySlug: "news", Slug: id}, Title: title, Context: context}
+}
+
+func TestReaderDigestService_RunDue(t *testing.T) {
+	ctx := context.Background()
+	templates, _ := createTemplateService(t)
+	sample := map[string]any{"username": "member", "picks": []map[string]any{{"title": "", "excerpt": "", "url": "", "reason": "", "context": ""}}}
+	_, _ = templates.CreateTemplate(ctx, "staff1", ReaderDigestTemplate, "Weekly digest for you", sample)
+	v1, _ := templates.Draft(ctx, "staff1", ReaderDigestTemplate, emailtemplate.Content{
+		Subject:  "This week for {{.username}}",
+		HTMLBody: `{{range .picks}}<a href="{{.url}}">{{.title}}</a>{{end}}`,
+		TextBody: `{{range .picks}}{{.reason}} {{.context}}: {{.title}} {{.url}}` + "\n" + `{{end}}`,
+	}, "")
+	if _, err := templates.Activate(ctx, "staff1", ReaderDigestTemplate, v1.Number); err != nil {
+		t.Fatalf("failed to activate template: %v", err)
+	}
+
+	reader := createActiveMember(t, "member1", "reader", "reader@example.com")
+	quiet := createActiveMember(t, "member2", "quiet", "quiet@example.com")
+	bounced := createActiveMember(t, "member3", "bounced", "bounced@example.com")
+	private := createActiveMember(t, "member5", "private", "private@example.com")
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{"member1": reader, "member2": quiet, "member3": bounced, "member5": private}}
+	both := []consent.Purpose{consent.PurposeMarketingEmail, consent.PurposePersonalization}
+	consents := fakeConsent{"member1": both, "member2": both, "member3": both, "member5": {consent.PurposeMarketingEmail}}
+
+	// Saturday 2026-01-10 08:00 in Jakarta
+	at := time.Date(2026, 1, 10, 1, 0, 0, 0, time.UTC)
+	lastWeek := at.AddDate(0, 0, -7)
+	lastWeekNewYork := time.Date(2026, 1, 3, 13, 0, 0, 0, time.UTC)
+	schedules := &memoryReaderSchedules{schedules: []*digest.ReaderSchedule{
+		{AccountID: "member1", Enabled: true, Timezone: "Asia/Jakarta", LastSentAt: &lastWeek},
+		{AccountID: "member2", Enabled: true, Timezone: "Asia/Jakarta", LastSentAt: &lastWeek},
+		{AccountID: "member3", Enabled: true, Timezone: "Asia/Jakarta", LastSentAt: &lastWeek},
+		{AccountID: "member4", Enabled: true, Timezone: "America/New_York", LastSentAt: &lastWeekNewYork}, // Still Friday evening there
+		{AccountID: "member5", Enabled: true, Timezone: "Asia/Jakarta", LastSentAt: &lastWeek},            // No personalization consent
+	}}
+
+	follows := &fakeFollows{topicIDs: map[string][]string{"member1": {"topic-climate"}}}
+	articles := &fakeReaderArticles{
+		read:    map[string][]string{"member1": {"a9", "a2"}},
+		topics:  map[string][]digest.Pick{"topic-climate": {articlePick("a1", "Floods in Jakarta", "Climate"), articlePick("a2", "Already read", "Climate")}},
+		similar: []digest.Pick{articlePick("a3", "Dam repairs delayed", "Floods last week")},
+	}
+	trending := &fakeTrending{metrics: []analytics.ArticleMetric{{ArticleID: "a1"}, {ArticleID: "a4"}, {ArticleID: "unpublished"}}}
+	stream := &fakeArticleStream{published: map[string]topic.ArticleSummary{
+		"a1": {Ref: permalink.ArticleRef{ID: "a1", CategorySlug: "news", Slug: "a1"}, Title: "Floods in Jakarta"},
+		"a4": {Ref: permalink.ArticleRef{ID: "a4", CategorySlug: "news", Slug: "a4"}, Title: "Election results"},
+	}}
+	sender := &fakeEmailSender{}
+	service := NewReaderDigestService(schedules, accounts, consents, follows, articles, trending, stream, templates,
+		NewSuppressingSender(fakeSuppressions{"bounced@example.com": true}, sender), shared.NoLock{}, fakeArticlePaths{}, "https://news.example.com")
+
+	result, err := service.RunDue(ctx, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The bounced address and the member without consent are skipped but their week still counts as done
+	if result.Sent != 2 || result.Skipped != 2 || result.Failed != 0 || trending.calls != 1 {
+		t.Fatalf("expected 2 sent and 2 skipped from one trending query, got %+v after %d queries", result, trending.calls)
+	}
+	if len(sender.sent) != 2 || sender.sent[0].Subject != "This week for reader" {
+		t.Fatalf("unexpected emails %+v", sender.sent)
+	}
+	want := "followed_topic Climate: Floods in Jakarta https://news.example.com/news/a1\n" +
+		"reading_history Floods last week: Dam repairs delayed https://news.example.com/news/a3\n" +
+		"trending : Election results https://news.example.com/news/a4\n"
+	if sender.sent[0].TextBody != want {
+		t.Errorf("expected body\n%s\ngot\n%s", want, sender.sent[0].TextBody)
+	}
+	if !strings.Contains(sender.sent[1].TextBody, "trending : Floods in Jakarta") {
+		t.Errorf("expected a member without follows or history to get trending picks, got %s", sender.sent[1].TextBody)
+	}
+	if !slices.Equal(schedules.updated, []string{"member1", "member2", "member3", "member5"}) {
+		t.Errorf("expected sent and skipped members marked, got %v", schedules.updated)
+	}
+
+	if result, _ := service.RunDue(ctx, at.Add(time.Hour)); result.Sent != 0 {
+		t.Errorf("expected nothing due an hour later, got %+v", result)
+	}
+}
+
+func TestReaderDigestService_RecordOpen(t *testing.T) {
+	schedule := &digest.ReaderSchedule{AccountID: "member1", Enabled: true, Timezone: "UTC"}
+	schedules := &memoryReaderSchedules{schedules: []*digest.ReaderSchedule{schedule}}
+	service := NewReaderDigestService(schedules, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "")
+
+	for i := 0; i < digest.MinOpensToOptimize; i++ {
+		if err := service.RecordOpen(context.Background(), "member1", time.Date(2026, 1, 3+i, 19, 30, 0, 0, time.UTC)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := service.RecordOpen(context.Background(), "unknown", time.Now()); err != nil {
+		t.Errorf("expected opens without a schedule ignored, got %v", err)
+	}
+	if schedule.SendHour() != 19 || len(schedules.updated) != digest.MinOpensToOptimize {
+		t.Errorf("expected send hour 19 after %d saved opens, got %d after %d", digest.MinOpensToOptimize, schedule.SendHour(), len(schedules.updated))
+	}
+}
+
+func TestReaderDigestService_OptIn(t *testing.T) {
+	ctx := context.Background()
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{"member1": createActiveMember(t, "member1", "reader", "reader@example.com")}}
+	schedules := &memoryReaderSchedules{}
+	service := NewReaderDigestService(schedules, accounts, nil, nil, nil, nil, nil, nil, nil, nil, nil, "")
+
+	if _, err := service.OptIn(ctx, "unknown", "Asia/Jakarta"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrMemberNotFound, err)
+	}
+	schedule, err := service.OptIn(ctx, "member1", "Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !schedule.Enabled || len(schedules.schedules) != 1 {
+		t.Fatalf("expected an enabled schedule created, got %+v", schedules.schedules)
+	}
+
+	if err := service.OptOut(ctx, "member1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page, _ := schedules.FindEnabled(ctx, "", 10); len(page) != 0 {
+		t.Errorf("expected no enabled schedules after opting out, got %d", len(page))
+	}
+	if _, err := service.OptIn(ctx, "member1", "Asia/Jakarta"); err != nil || !schedule.Enabled || len(schedules.schedules) != 1 {
+		t.Errorf("expected the existing schedule enabled again, got %v with %d schedules", err, len(schedules.schedules))
+	}
+}
+
+func createActiveMember(t *testing.T, id, username, email string) *account.UserAccount {
+	acc, err := account.NewUserAccountForTesting(id, username, email, "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+	return acc
+}
@@ -0,0 +1,267 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/digest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/appeal"
+)
+
+var (
+	ErrRecipientSuppressed = suppression.ErrRecipientSuppressed
+	ErrNotSuppressed       = errors.New("address is not suppressed")
+)
+
+type ProviderEventType string
+
+const (
+	EventBounce    ProviderEventType = "bounce"
+	EventComplaint ProviderEventType = "complaint"
+)
+
+// ProviderEvent is a bounce or complaint callback, normalized from the provider's payload
+type ProviderEvent struct {
+	Type       ProviderEventType
+	Permanent  bool // Hard bounce; soft bounces are retried by the provider and never suppress
+	Email      string
+	Detail     string
+	OccurredAt time.Time
+}
+
+// SuppressionService owns the global suppression list
+type SuppressionService struct {
+	entries suppression.Repository
+}
+
+func NewSuppressionService(entries suppression.Repository) *SuppressionService {
+	return &SuppressionService{entries: entries}
+}
+
+// Ensure SuppressionService can be used as the send-path hook
+var _ suppression.Checker = (*SuppressionService)(nil)
+
+func (s *SuppressionService) IsSuppressed(ctx context.Context, email account.Email) (bool, error) {
+	entry, err := s.entries.FindByEmail(ctx, email)
+	if err != nil {
+		return false, fmt.Errorf("failed to check suppression list: %w", err)
+	}
+	return entry != nil, nil
+}
+
+// Suppress adds the address, or escalates the reason of an existing entry
+func (s *SuppressionService) Suppress(ctx context.Context, email account.Email, reason suppression.Reason, source, detail string, at time.Time) (*suppression.Entry, error) {
+	entry, err := s.entries.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load suppression: %w", err)
+	}
+
+	if entry == nil {
+		if entry, err = suppression.NewEntry(email, reason, source, detail, at); err != nil {
+			return nil, err
+		}
+	} else {
+		changed, err := entry.Escalate(reason, source, detail, at)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			return entry, nil
+		}
+	}
+
+	if err := s.entries.Save(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to save suppression: %w", err)
+	}
+	return entry, nil
+}
+
+// Lift removes a manual or bounce entry, e.g. after the reader fixed their mailbox
+func (s *SuppressionService) Lift(ctx context.Context, email account.Email) error {
+	entry, err := s.entries.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to load suppression: %w", err)
+	}
+	if entry == nil {
+		return ErrNotSuppressed
+	}
+	if !entry.CanLift() {
+		return suppression.ErrCannotLift
+	}
+	if err := s.entries.Delete(ctx, email); err != nil {
+		return fmt.Errorf("failed to delete suppression: %w", err)
+	}
+	return nil
+}
+
+func (s *SuppressionService) List(ctx context.Context, reason *suppression.Reason, limit, offset int) ([]*suppression.Entry, int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.entries.FindAll(ctx, reason, limit, offset)
+}
+
+// HandleProviderEvents applies a webhook batch and returns how many addresses were suppressed.
+// Events with an unparseable address are skipped so one bad entry does not fail the batch.
+func (s *SuppressionService) HandleProviderEvents(ctx context.Context, provider string, events []ProviderEvent) (int, error) {
+	suppressed := 0
+	for _, event := range events {
+		var reason suppression.Reason
+		switch {
+		case event.Type == EventComplaint:
+			reason = suppression.ReasonComplaint
+		case event.Type == EventBounce && event.Permanent:
+			reason = suppression.ReasonHardBounce
+		default:
+			continue
+		}
+
+		email, err := account.NewEmail(event.Email)
+		if err != nil {
+			continue
+		}
+		if _, err := s.Suppress(ctx, *email, reason, provider, event.Detail, event.OccurredAt); err != nil {
+			return suppressed, err
+		}
+		suppressed++
+	}
+	return suppressed, nil
+}
+
+// suppressingSender refuses to hand suppressed recipients to the provider
+type suppressingSender struct {
+	checker suppression.Checker
+	next    EmailSender
+}
+
+// NewSuppressingSender wraps the provider sender, every send path must go through it
+func NewSuppressingSender(checker suppression.Checker, next EmailSender) EmailSender {
+	return &suppressingSender{checker: checker, next: next}
+}
+
+func (s *suppressingSender) SendEmail(ctx context.Context, message EmailMessage) error {
+	if err := checkRecipient(ctx, s.checker, message.To); err != nil {
+		return err
+	}
+	return s.next.SendEmail(ctx, message)
+}
+
+type suppressingDigestSender struct {
+	checker suppression.Checker
+	next    DigestSender
+}
+
+// NewSuppressingDigestSender is NewSuppressingSender for the activity digest path
+func NewSuppressingDigestSender(checker suppression.Checker, next DigestSender) DigestSender {
+	return &suppressingDigestSender{checker: checker, next: next}
+}
+
+func (s *suppressingDigestSender) SendDigest(ctx context.Context, recipient account.Email, d *digest.Digest) error {
+	if err := checkRecipient(ctx, s.checker, recipient); err != nil {
+		return err
+	}
+	return s.next.SendDigest(ctx, recipient, d)
+}
+
+// ConfirmationSender has the method set of newsletter.ConfirmationSender
+type ConfirmationSender interface {
+	SendConfirmation(ctx context.Context, email account.Email, token string, expiresAt time.Time) error
+}
+
+type suppressingConfirmationSender struct {
+	checker suppression.Checker
+	next    ConfirmationSender
+}
+
+// NewSuppressingConfirmationSender guards the newsletter double opt-in email
+func NewSuppressingConfirmationSender(checker suppression.Checker, next ConfirmationSender) ConfirmationSender {
+	return &suppressingConfirmationSender{checker: checker, next: next}
+}
+
+func (s *suppressingConfirmationSender) SendConfirmation(ctx context.Context, email account.Email, token string, expiresAt time.Time) error {
+	if err := checkRecipient(ctx, s.checker, email); err != nil {
+		return err
+	}
+	return s.next.SendConfirmation(ctx, email, token, expiresAt)
+}
+
+// UsageNotifier has the method set of quota.UsageNotifier
+type UsageNotifier interface {
+	NotifyQuotaUsage(ctx context.Context, recipient account.Email, alert *quota.Alert) error
+}
+
+type suppressingUsageNotifier struct {
+	checker suppression.Checker
+	next    UsageNotifier
+}
+
+// NewSuppressingUsageNotifier guards the partner quota threshold email
+func NewSuppressingUsageNotifier(checker suppression.Checker, next UsageNotifier) UsageNotifier {
+	return &suppressingUsageNotifier{checker: checker, next: next}
+}
+
+func (s *suppressingUsageNotifier) NotifyQuotaUsage(ctx context.Context, recipient account.Email, alert *quota.Alert) error {
+	if err := checkRecipient(ctx, s.checker, recipient); err != nil {
+		return err
+	}
+	return s.next.NotifyQuotaUsage(ctx, recipient, alert)
+}
+
+// DecisionNotifier has the method set of appeal.DecisionNotifier
+type DecisionNotifier interface {
+	NotifyAppealDecision(ctx context.Context, recipient account.Email, a *appeal.Appeal) error
+}
+
+type suppressingDecisionNotifier struct {
+	checker suppression.Checker
+	next    DecisionNotifier
+}
+
+// NewSuppressingDecisionNotifier guards the appeal outcome email
+func NewSuppressingDecisionNotifier(checker suppression.Checker, next DecisionNotifier) DecisionNotifier {
+	return &suppressingDecisionNotifier{checker: checker, next: next}
+}
+
+func (s *suppressingDecisionNotifier) NotifyAppealDecision(ctx context.Context, recipient account.Email, a *appeal.Appeal) error {
+	if err := checkRecipient(ctx, s.checker, recipient); err != nil {
+		return err
+	}
+	return s.next.NotifyAppealDecision(ctx, recipient, a)
+}
+
+type suppressingAcknowledgmentNotifier struct {
+	checker suppression.Checker
+	next    letter.AcknowledgmentNotifier
+}
+
+// NewSuppressingAcknowledgmentNotifier guards the letter to the editor receipt
+func NewSuppressingAcknowledgmentNotifier(checker suppression.Checker, next letter.AcknowledgmentNotifier) letter.AcknowledgmentNotifier {
+	return &suppressingAcknowledgmentNotifier{checker: checker, next: next}
+}
+
+func (s *suppressingAcknowledgmentNotifier) SendAcknowledgment(ctx context.Context, l *letter.LetterSubmission) error {
+	if err := checkRecipient(ctx, s.checker, l.Author.Email()); err != nil {
+		return err
+	}
+	return s.next.SendAcknowledgment(ctx, l)
+}
+
+func checkRecipient(ctx context.Context, checker suppression.Checker, email account.Email) error {
+	suppressed, err := checker.IsSuppressed(ctx, email)
+	if err != nil {
+		return err
+	}
+	if suppressed {
+		return ErrRecipientSuppressed
+	}
+	return nil
+}
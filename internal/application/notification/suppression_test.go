@@ -0,0 +1,141 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memorySuppressions struct {
+	entries map[string]*suppression.Entry
+}
+
+func (m *memorySuppressions) Save(ctx context.Context, entry *suppression.Entry) error {
+	m.entries[entry.Email.String()] = entry
+	return nil
+}
+
+func (m *memorySuppressions) Delete(ctx context.Context, email account.Email) error {
+	delete(m.entries, email.String())
+	return nil
+}
+
+func (m *memorySuppressions) FindByEmail(ctx context.Context, email account.Email) (*suppression.Entry, error) {
+	return m.entries[email.String()], nil
+}
+
+func (m *memorySuppressions) FindAll(ctx context.Context, reason *suppression.Reason, limit, offset int) ([]*suppression.Entry, int64, error) {
+	var all []*suppression.Entry
+	for _, e := range m.entries {
+		if reason == nil || e.Reason == *reason {
+			all = append(all, e)
+		}
+	}
+	return all, int64(len(all)), nil
+}
+
+func createTestEmail(t *testing.T, value string) account.Email {
+	email, err := account.NewEmail(value)
+	if err != nil {
+		t.Fatalf("failed to create email: %v", err)
+	}
+	return *email
+}
+
+func TestSuppressionService_HandleProviderEvents(t *testing.T) {
+	ctx := context.Background()
+	service := NewSuppressionService(&memorySuppressions{entries: map[string]*suppression.Entry{}})
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+
+	n, err := service.HandleProviderEvents(ctx, "postmark", []ProviderEvent{
+		{Type: EventBounce, Permanent: true, Email: "Gone@Example.com", Detail: "550 no such user", OccurredAt: at},
+		{Type: EventBounce, Permanent: false, Email: "full@example.com", Detail: "452 mailbox full", OccurredAt: at},
+		{Type: EventComplaint, Email: "angry@example.com", OccurredAt: at},
+		{Type: EventComplaint, Email: "not-an-address", OccurredAt: at},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 suppressions, got %d", n)
+	}
+
+	testCases := []struct {
+		email      string
+		suppressed bool
+	}{
+		{"gone@example.com", true},
+		{"full@example.com", false},
+		{"angry@example.com", true},
+	}
+	for _, tc := range testCases {
+		suppressed, _ := service.IsSuppressed(ctx, createTestEmail(t, tc.email))
+		if suppressed != tc.suppressed {
+			t.Errorf("expected %s suppressed=%v, got %v", tc.email, tc.suppressed, suppressed)
+		}
+	}
+
+	if err := service.Lift(ctx, createTestEmail(t, "angry@example.com")); err != suppression.ErrCannotLift {
+		t.Errorf("expected error '%v', got '%v'", suppression.ErrCannotLift, err)
+	}
+	if err := service.Lift(ctx, createTestEmail(t, "gone@example.com")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := service.Lift(ctx, createTestEmail(t, "gone@example.com")); err != ErrNotSuppressed {
+		t.Errorf("expected error '%v', got '%v'", ErrNotSuppressed, err)
+	}
+}
+
+func TestSuppressingSender(t *testing.T) {
+	ctx := context.Background()
+	service := NewSuppressionService(&memorySuppressions{entries: map[string]*suppression.Entry{}})
+	_, _ = service.Suppress(ctx, createTestEmail(t, "gone@example.com"), suppression.ReasonManual, "staff1", "removal request", time.Now())
+
+	provider := &fakeEmailSender{}
+	sender := NewSuppressingSender(service, provider)
+	if err := sender.SendEmail(ctx, EmailMessage{To: createTestEmail(t, "gone@example.com")}); err != ErrRecipientSuppressed {
+		t.Errorf("expected error '%v', got '%v'", ErrRecipientSuppressed, err)
+	}
+	if err := sender.SendEmail(ctx, EmailMessage{To: createTestEmail(t, "reader@example.com")}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(provider.sent) != 1 || provider.sent[0].To.String() != "reader@example.com" {
+		t.Errorf("expected only the unsuppressed message to reach the provider, got %+v", provider.sent)
+	}
+
+	digests := &fakeDigestSender{}
+	if err := NewSuppressingDigestSender(service, digests).SendDigest(ctx, createTestEmail(t, "gone@example.com"), nil); err != ErrRecipientSuppressed {
+		t.Errorf("expected error '%v', got '%v'", ErrRecipientSuppressed, err)
+	}
+}
+
+type fakeConfirmationSender struct {
+	sent []string
+}
+
+func (f *fakeConfirmationSender) SendConfirmation(ctx context.Context, email account.Email, token string, expiresAt time.Time) error {
+	f.sent = append(f.sent, email.String())
+	return nil
+}
+
+func TestSuppressingConfirmationSender(t *testing.T) {
+	ctx := context.Background()
+	service := NewSuppressionService(&memorySuppressions{entries: map[string]*suppression.Entry{}})
+	_, _ = service.Suppress(ctx, createTestEmail(t, "gone@example.com"), suppression.ReasonComplaint, "ses", "", time.Now())
+
+	provider := &fakeConfirmationSender{}
+	sender := NewSuppressingConfirmationSender(service, provider)
+	if err := sender.SendConfirmation(ctx, createTestEmail(t, "gone@example.com"), "token", time.Now()); !errors.Is(err, suppression.ErrRecipientSuppressed) {
+		t.Errorf("expected error '%v', got '%v'", suppression.ErrRecipientSuppressed, err)
+	}
+	if err := sender.SendConfirmation(ctx, createTestEmail(t, "reader@example.com"), "token", time.Now()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(provider.sent) != 1 || provider.sent[0] != "reader@example.com" {
+		t.Errorf("expected only the unsuppressed confirmation sent, got %v", provider.sent)
+	}
+}
@@ -0,0 +1,252 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// TestSubjectPrefix marks test sends so they are never mistaken for a live campaign
+const TestSubjectPrefix = "[TEST] "
+
+var (
+	ErrTemplateNotFound   = errors.New("email template not found")
+	ErrTemplateExists     = errors.New("email template already exists")
+	ErrTemplateNotLive    = errors.New("email template has no active version")
+	ErrTestSendNotAllowed = errors.New("test emails can only be sent to active staff accounts")
+)
+
+// EmailMessage is one outgoing email
+type EmailMessage struct {
+	To          account.Email
+	Subject     string
+	HTMLBody    string
+	TextBody    string
+	Attachments []Attachment
+}
+
+// EmailSender delivers a single email through the provider
+type EmailSender interface {
+	SendEmail(ctx context.Context, message EmailMessage) error
+}
+
+// TemplateService manages versioned email templates and renders them for preview, test and live sends
+type TemplateService struct {
+	templates emailtemplate.TemplateRepository
+	versions  emailtemplate.VersionRepository
+	accounts  account.UserAccountRepository
+	sender    EmailSender
+}
+
+func NewTemplateService(templates emailtemplate.TemplateRepository, versions emailtemplate.VersionRepository, accounts account.UserAccountRepository, sender EmailSender) *TemplateService {
+	return &TemplateService{templates: templates, versions: versions, accounts: accounts, sender: sender}
+}
+
+func (s *TemplateService) CreateTemplate(ctx context.Context, staffID, key, description string, sampleData map[string]any) (*emailtemplate.Template, error) {
+	existing, err := s.templates.FindByKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrTemplateExists
+	}
+
+	tmpl, err := emailtemplate.NewTemplate(key, description, sampleData, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templates.Create(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func (s *TemplateService) UpdateSampleData(ctx context.Context, key string, sampleData map[string]any) (*emailtemplate.Template, error) {
+	tmpl, err := s.find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.UpdateSampleData(sampleData)
+	if err := s.templates.Update(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func (s *TemplateService) List(ctx context.Context) ([]*emailtemplate.Template, error) {
+	templates, err := s.templates.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return templates, nil
+}
+
+func (s *TemplateService) Versions(ctx context.Context, key string) (*emailtemplate.Template, []*emailtemplate.Version, error) {
+	tmpl, err := s.find(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	versions, err := s.versions.FindByTemplate(ctx, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	return tmpl, versions, nil
+}
+
+// Draft saves new content as the next version without affecting live sends
+func (s *TemplateService) Draft(ctx context.Context, staffID, key string, content emailtemplate.Content, note string) (*emailtemplate.Version, error) {
+	tmpl, err := s.find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := tmpl.Draft(content, staffID, note)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.versions.Create(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to save version: %w", err)
+	}
+	if err := s.templates.Update(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+	return version, nil
+}
+
+// Activate makes a version live. It must render with the sample data first, so a version
+// referencing a variable the sending code does not provide never reaches readers.
+func (s *TemplateService) Activate(ctx context.Context, staffID, key string, number int) (*emailtemplate.Template, error) {
+	tmpl, err := s.find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.version(ctx, key, number)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := version.Content.Render(tmpl.SampleData); err != nil {
+		return nil, err
+	}
+
+	if err := tmpl.Activate(number, staffID); err != nil {
+		return nil, err
+	}
+	if err := s.templates.Update(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Rollback puts the previously live version back
+func (s *TemplateService) Rollback(ctx context.Context, staffID, key string) (*emailtemplate.Template, error) {
+	tmpl, err := s.find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Rollback(staffID); err != nil {
+		return nil, err
+	}
+	if err := s.templates.Update(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Preview renders a version, the live one when number is nil, with the template's sample
+// data overlaid by data
+func (s *TemplateService) Preview(ctx context.Context, key string, number *int, data map[string]any) (*emailtemplate.Rendered, error) {
+	tmpl, err := s.find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	version, err := s.resolveVersion(ctx, tmpl, number)
+	if err != nil {
+		return nil, err
+	}
+	return version.Content.Render(mergeData(tmpl.SampleData, data))
+}
+
+// SendTest renders a version with sample data and emails it to the requesting staff member only
+func (s *TemplateService) SendTest(ctx context.Context, staffID, key string, number *int, data map[string]any) error {
+	staff, err := s.accounts.FindByID(ctx, staffID)
+	if err != nil {
+		return fmt.Errorf("failed to load staff account: %w", err)
+	}
+	if staff == nil || !staff.IsActive() || !staff.IsInternal() {
+		return ErrTestSendNotAllowed
+	}
+
+	rendered, err := s.Preview(ctx, key, number, data)
+	if err != nil {
+		return err
+	}
+
+	return s.sender.SendEmail(ctx, EmailMessage{
+		To:       staff.Email,
+		Subject:  TestSubjectPrefix + rendered.Subject,
+		HTMLBody: rendered.HTMLBody,
+		TextBody: rendered.TextBody,
+	})
+}
+
+// Render fills in the live version for a real send
+func (s *TemplateService) Render(ctx context.Context, key string, data map[string]any) (*emailtemplate.Rendered, error) {
+	tmpl, err := s.find(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !tmpl.IsLive() {
+		return nil, ErrTemplateNotLive
+	}
+	version, err := s.version(ctx, key, *tmpl.ActiveVersion)
+	if err != nil {
+		return nil, err
+	}
+	return version.Content.Render(data)
+}
+
+func (s *TemplateService) resolveVersion(ctx context.Context, tmpl *emailtemplate.Template, number *int) (*emailtemplate.Version, error) {
+	if number != nil {
+		return s.version(ctx, tmpl.Key, *number)
+	}
+	if !tmpl.IsLive() {
+		return nil, ErrTemplateNotLive
+	}
+	return s.version(ctx, tmpl.Key, *tmpl.ActiveVersion)
+}
+
+func (s *TemplateService) find(ctx context.Context, key string) (*emailtemplate.Template, error) {
+	tmpl, err := s.templates.FindByKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template: %w", err)
+	}
+	if tmpl == nil {
+		return nil, ErrTemplateNotFound
+	}
+	return tmpl, nil
+}
+
+func (s *TemplateService) version(ctx context.Context, key string, number int) (*emailtemplate.Version, error) {
+	version, err := s.versions.FindByNumber(ctx, key, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load version: %w", err)
+	}
+	if version == nil {
+		return nil, emailtemplate.ErrVersionNotFound
+	}
+	return version, nil
+}
+
+func mergeData(sample, override map[string]any) map[string]any {
+	merged := make(map[string]any, len(sample)+len(override))
+	for k, v := range sample {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
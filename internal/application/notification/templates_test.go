@@ -0,0 +1,156 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memoryTemplates struct {
+	templates map[string]*emailtemplate.Template
+}
+
+func (m *memoryTemplates) Create(ctx context.Context, tmpl *emailtemplate.Template) error {
+	m.templates[tmpl.Key] = tmpl
+	return nil
+}
+
+func (m *memoryTemplates) Update(ctx context.Context, tmpl *emailtemplate.Template) error {
+	m.templates[tmpl.Key] = tmpl
+	return nil
+}
+
+func (m *memoryTemplates) FindByKey(ctx context.Context, key string) (*emailtemplate.Template, error) {
+	return m.templates[key], nil
+}
+
+func (m *memoryTemplates) FindAll(ctx context.Context) ([]*emailtemplate.Template, error) {
+	var all []*emailtemplate.Template
+	for _, tmpl := range m.templates {
+		all = append(all, tmpl)
+	}
+	return all, nil
+}
+
+type memoryVersions struct {
+	versions []*emailtemplate.Version
+}
+
+func (m *memoryVersions) Create(ctx context.Context, version *emailtemplate.Version) error {
+	m.versions = append(m.versions, version)
+	return nil
+}
+
+func (m *memoryVersions) FindByNumber(ctx context.Context, key string, number int) (*emailtemplate.Version, error) {
+	for _, v := range m.versions {
+		if v.TemplateKey == key && v.Number == number {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryVersions) FindByTemplate(ctx context.Context, key string) ([]*emailtemplate.Version, error) {
+	var found []*emailtemplate.Version
+	for i := len(m.versions) - 1; i >= 0; i-- {
+		if m.versions[i].TemplateKey == key {
+			found = append(found, m.versions[i])
+		}
+	}
+	return found, nil
+}
+
+type fakeEmailSender struct {
+	sent []EmailMessage
+}
+
+func (f *fakeEmailSender) SendEmail(ctx context.Context, message EmailMessage) error {
+	f.sent = append(f.sent, message)
+	return nil
+}
+
+func createTemplateService(t *testing.T) (*TemplateService, *fakeEmailSender) {
+	staff := createActiveStaff(t, "staff1", "marketing", "marketing@example.com")
+	reader, _ := account.NewUserAccountForTesting("reader1", "reader", "reader@example.com", "TestPassword123!", account.TypeMembership, account.SelfRegistration)
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{"staff1": staff, "reader1": reader}}
+	sender := &fakeEmailSender{}
+	service := NewTemplateService(&memoryTemplates{templates: map[string]*emailtemplate.Template{}}, &memoryVersions{}, accounts, sender)
+
+	if _, err := service.CreateTemplate(context.Background(), "staff1", "welcome", "Sent after sign-up", map[string]any{"Name": "Sari"}); err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+	return service, sender
+}
+
+func TestTemplateService_DraftPreviewActivateRollback(t *testing.T) {
+	ctx := context.Background()
+	service, _ := createTemplateService(t)
+
+	v1, err := service.Draft(ctx, "staff1", "welcome", emailtemplate.Content{Subject: "Welcome, {{.Name}}", HTMLBody: "<p>Hi {{.Name}}</p>"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Preview(ctx, "welcome", nil, nil); err != ErrTemplateNotLive {
+		t.Errorf("expected error '%v', got '%v'", ErrTemplateNotLive, err)
+	}
+
+	preview, err := service.Preview(ctx, "welcome", &v1.Number, map[string]any{"Name": "Budi"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preview.Subject != "Welcome, Budi" {
+		t.Errorf("expected override data in preview, got %q", preview.Subject)
+	}
+
+	if _, err := service.Activate(ctx, "staff1", "welcome", v1.Number); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A version using a variable the sample data lacks cannot go live
+	v2, _ := service.Draft(ctx, "staff1", "welcome", emailtemplate.Content{Subject: "{{.FirstName}}, welcome", HTMLBody: "<p>x</p>"}, "personalized subject")
+	if _, err := service.Activate(ctx, "staff1", "welcome", v2.Number); !errors.Is(err, emailtemplate.ErrRenderFailed) {
+		t.Errorf("expected error '%v', got '%v'", emailtemplate.ErrRenderFailed, err)
+	}
+
+	v3, _ := service.Draft(ctx, "staff1", "welcome", emailtemplate.Content{Subject: "Hello {{.Name}}", HTMLBody: "<p>x</p>"}, "")
+	service.Activate(ctx, "staff1", "welcome", v3.Number)
+	if live, _ := service.Render(ctx, "welcome", map[string]any{"Name": "Ayu"}); live.Subject != "Hello Ayu" {
+		t.Errorf("expected version 3 live, got %q", live.Subject)
+	}
+
+	if _, err := service.Rollback(ctx, "staff1", "welcome"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if live, _ := service.Render(ctx, "welcome", map[string]any{"Name": "Ayu"}); live.Subject != "Welcome, Ayu" {
+		t.Errorf("expected version 1 live after rollback, got %q", live.Subject)
+	}
+
+	_, versions, _ := service.Versions(ctx, "welcome")
+	if len(versions) != 3 || versions[0].Number != 3 {
+		t.Errorf("expected 3 versions newest first, got %d", len(versions))
+	}
+}
+
+func TestTemplateService_SendTest(t *testing.T) {
+	ctx := context.Background()
+	service, sender := createTemplateService(t)
+	v1, _ := service.Draft(ctx, "staff1", "welcome", emailtemplate.Content{Subject: "Welcome, {{.Name}}", HTMLBody: "<p>Hi {{.Name}}</p>"}, "")
+
+	if err := service.SendTest(ctx, "staff1", "welcome", &v1.Number, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To.String() != "marketing@example.com" || !strings.HasPrefix(sender.sent[0].Subject, TestSubjectPrefix) {
+		t.Errorf("expected test email to the requesting staff member, got %+v", sender.sent)
+	}
+
+	if err := service.SendTest(ctx, "reader1", "welcome", &v1.Number, nil); err != ErrTestSendNotAllowed {
+		t.Errorf("expected error '%v', got '%v'", ErrTestSendNotAllowed, err)
+	}
+	if err := service.SendTest(ctx, "staff1", "missing", nil, nil); err != ErrTemplateNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrTemplateNotFound, err)
+	}
+}
@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// TopicArticleTemplate is the email template key for "new in a topic you follow"
+const TopicArticleTemplate = "topic_new_article"
+
+// ArticlePaths builds canonical article paths, the permalink router implements it
+type ArticlePaths interface {
+	Path(ref permalink.ArticleRef) (string, error)
+}
+
+// TopicNotifier emails topic followers about new articles. Wrap the sender in
+// NewSuppressingSender so bounced and unsubscribed addresses are skipped.
+type TopicNotifier struct {
+	templates *TemplateService
+	accounts  account.UserAccountRepository
+	sender    EmailSender
+	paths     ArticlePaths
+	baseURL   string
+}
+
+func NewTopicNotifier(templates *TemplateService, accounts account.UserAccountRepository, sender EmailSender, paths ArticlePaths, baseURL string) *TopicNotifier {
+	return &TopicNotifier{templates: templates, accounts: accounts, sender: sender, paths: paths, baseURL: baseURL}
+}
+
+var _ topic.FollowerNotifier = (*TopicNotifier)(nil)
+
+// NotifyNewArticle renders the template once and sends it to every active follower.
+// A failed send for one member does not stop the others.
+func (n *TopicNotifier) NotifyNewArticle(ctx context.Context, accountIDs []string, t *topic.Topic, article topic.ArticleSummary, at time.Time) error {
+	path, err := n.paths.Path(article.Ref)
+	if err != nil {
+		return fmt.Errorf("failed to build article path: %w", err)
+	}
+	rendered, err := n.templates.Render(ctx, TopicArticleTemplate, map[string]any{
+		"topic_name":      t.Name,
+		"topic_url":       n.baseURL + "/topics/" + t.Slug.Value(),
+		"article_title":   article.Title,
+		"article_excerpt": article.Excerpt,
+		"article_url":     n.baseURL + path,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render topic email: %w", err)
+	}
+
+	var failed int
+	for _, id := range accountIDs {
+		member, err := n.accounts.FindByID(ctx, id)
+		if err != nil {
+			failed++
+			continue
+		}
+		if member == nil || !member.IsActive() {
+			continue
+		}
+		message := EmailMessage{To: member.Email, Subject: rendered.Subject, HTMLBody: rendered.HTMLBody, TextBody: rendered.TextBody}
+		if err := n.sender.SendEmail(ctx, message); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to notify %d of %d followers", failed, len(accountIDs))
+	}
+	return nil
+}
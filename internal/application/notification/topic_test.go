@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakeArticlePaths struct{}
+
+func (fakeArticlePaths) Path(ref permalink.ArticleRef) (string, error) {
+	return "/" + ref.CategorySlug + "/" + ref.Slug, nil
+}
+
+func TestTopicNotifier_NotifyNewArticle(t *testing.T) {
+	ctx := context.Background()
+	templates, _ := createTemplateService(t)
+	sample := map[string]any{"topic_name": "Climate", "topic_url": "", "article_title": "Floods", "article_excerpt": "", "article_url": ""}
+	_, _ = templates.CreateTemplate(ctx, "staff1", TopicArticleTemplate, "New article in a followed topic", sample)
+	v1, _ := templates.Draft(ctx, "staff1", TopicArticleTemplate, emailtemplate.Content{
+		Subject:  "New in {{.topic_name}}: {{.article_title}}",
+		HTMLBody: `<a href="{{.article_url}}">{{.article_title}}</a>`,
+	}, "")
+	if _, err := templates.Activate(ctx, "staff1", TopicArticleTemplate, v1.Number); err != nil {
+		t.Fatalf("failed to activate template: %v", err)
+	}
+
+	active, _ := account.NewUserAccountForTesting("member1", "member", "member@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	_ = active.Verify("admin123")
+	pending, _ := account.NewUserAccountForTesting("member2", "pending", "pending@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{"member1": active, "member2": pending}}
+	sender := &fakeEmailSender{}
+	notifier := NewTopicNotifier(templates, accounts, sender, fakeArticlePaths{}, "https://news.example.com")
+
+	slug, _ := topic.NewSlug("climate")
+	rule, _ := topic.NewRule([]string{"climate"}, nil)
+	climate, _ := topic.NewTopic("topic-1", *slug, "Climate", "", *rule, "editor-1")
+	article := topic.ArticleSummary{Ref: permalink.ArticleRef{ID: "a1", CategorySlug: "news", Slug: "floods"}, Title: "Floods"}
+
+	if err := notifier.NotifyNewArticle(ctx, []string{"member1", "member2", "gone"}, climate, article, time.Now()); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To.String() != "member@example.com" {
+		t.Fatalf("expected only the active member emailed, got %+v", sender.sent)
+	}
+	if sender.sent[0].Subject != "New in Climate: Floods" || sender.sent[0].HTMLBody != `<a href="https://news.example.com/news/floods">Floods</a>` {
+		t.Errorf("unexpected email %+v", sender.sent[0])
+	}
+}
@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// VerificationTemplate is the email template key for the registration verification link
+const VerificationTemplate = "account_verification"
+
+// VerificationMailer emails the link for each account.VerificationRequested event.
+// Wrap the sender in NewSuppressingSender so bounced addresses are skipped.
+type VerificationMailer struct {
+	templates *TemplateService
+	sender    EmailSender
+	baseURL   string
+}
+
+func NewVerificationMailer(templates *TemplateService, sender EmailSender, baseURL string) *VerificationMailer {
+	return &VerificationMailer{templates: templates, sender: sender, baseURL: baseURL}
+}
+
+// Handle sends one verification email. Events are delivered at least once, a
+// redelivery sends the same link again, which is harmless.
+func (m *VerificationMailer) Handle(ctx context.Context, event account.VerificationRequested) error {
+	email, err := account.NewEmail(event.Email)
+	if err != nil {
+		return err
+	}
+	rendered, err := m.templates.Render(ctx, VerificationTemplate, map[string]any{
+		"username":   event.Username,
+		"verify_url": m.baseURL + "/verify-email?token=" + url.QueryEscape(event.Token),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render verification email: %w", err)
+	}
+
+	message := EmailMessage{To: *email, Subject: rendered.Subject, HTMLBody: rendered.HTMLBody, TextBody: rendered.TextBody}
+	if err := m.sender.SendEmail(ctx, message); err != nil && !errors.Is(err, ErrRecipientSuppressed) {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestVerificationMailer_Handle(t *testing.T) {
+	ctx := context.Background()
+	templates, _ := createTemplateService(t)
+	_, _ = templates.CreateTemplate(ctx, "staff1", VerificationTemplate, "Confirm your email address", map[string]any{"username": "jhon_doe", "verify_url": ""})
+	v1, _ := templates.Draft(ctx, "staff1", VerificationTemplate, emailtemplate.Content{
+		Subject:  "Confirm your email, {{.username}}",
+		HTMLBody: `<a href="{{.verify_url}}">Confirm</a>`,
+	}, "")
+	if _, err := templates.Activate(ctx, "staff1", VerificationTemplate, v1.Number); err != nil {
+		t.Fatalf("failed to activate template: %v", err)
+	}
+
+	sender := &fakeEmailSender{}
+	mailer := NewVerificationMailer(templates, NewSuppressingSender(fakeSuppressions{"bounced@example.com": true}, sender), "https://news.example.com")
+	event := account.VerificationRequested{AccountID: "m1", Username: "jhon_doe", Email: "jhon@example.com", Token: "a+b/c", OccurredAt: time.Now()}
+
+	if err := mailer.Handle(ctx, event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].Subject != "Confirm your email, jhon_doe" ||
+		sender.sent[0].HTMLBody != `<a href="https://news.example.com/verify-email?token=a%2Bb%2Fc">Confirm</a>` {
+		t.Fatalf("unexpected emails %+v", sender.sent)
+	}
+
+	// A suppressed address is not retried
+	event.Email = "bounced@example.com"
+	if err := mailer.Handle(ctx, event); err != nil || len(sender.sent) != 1 {
+		t.Errorf("expected the suppressed address skipped without error, got %v", err)
+	}
+}
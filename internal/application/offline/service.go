@@ -0,0 +1,132 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/offline"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+// Access decides who may read an article in full, the entitlement service implements it
+type Access interface {
+	CanReadArticle(ctx context.Context, accountID string, a entitlement.Article, at time.Time) (entitlement.Decision, error)
+}
+
+type Service struct {
+	queue    offline.ReadingQueue
+	articles offline.ArticleSource
+	images   offline.ImageOptimizer
+	access   Access
+	books    offline.BookEncoder
+}
+
+func NewService(queue offline.ReadingQueue, articles offline.ArticleSource, images offline.ImageOptimizer, access Access, books offline.BookEncoder) *Service {
+	return &Service{queue: queue, articles: articles, images: images, access: access, books: books}
+}
+
+// Build packages the member's reading queue. With a manifest from the app
+// (have != nil) only new and changed articles are bundled. Access is checked
+// for every queued article, so an article the member can no longer read is
+// locked and, if the app holds it, removed.
+func (s *Service) Build(ctx context.Context, accountID string, format offline.Format, have offline.Manifest, at time.Time) (*offline.Package, error) {
+	pkg, err := offline.NewPackage(accountID, format, have != nil, at)
+	if err != nil {
+		return nil, err
+	}
+	queue, err := s.queue.FindQueue(ctx, accountID, offline.MaxArticles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reading queue: %w", err)
+	}
+	plan := offline.Plan(have, queue)
+	pkg.Removed = plan.Remove
+
+	ids := make([]string, len(queue))
+	for i, entry := range queue {
+		ids[i] = entry.ArticleID
+	}
+	found, err := s.articles.FindForOffline(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load articles: %w", err)
+	}
+	byID := make(map[string]offline.Article, len(found))
+	for _, a := range found {
+		byID[a.ID] = a
+	}
+	fetch := make(map[string]struct{}, len(plan.Fetch))
+	for _, id := range plan.Fetch {
+		fetch[id] = struct{}{}
+	}
+
+	for _, entry := range queue {
+		a, ok := byID[entry.ArticleID]
+		if !ok {
+			// Unpublished since it was saved
+			if _, held := have[entry.ArticleID]; held {
+				pkg.Removed = append(pkg.Removed, entry.ArticleID)
+			}
+			continue
+		}
+		decision, err := s.access.CanReadArticle(ctx, accountID, a.Access, at)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check access to %s: %w", a.ID, err)
+		}
+		if !decision.Allowed {
+			pkg.Lock(a.ID)
+			if _, held := have[a.ID]; held {
+				pkg.Removed = append(pkg.Removed, a.ID)
+			}
+			continue
+		}
+		if _, changed := fetch[a.ID]; !changed {
+			pkg.Keep(a.ID, entry.Revision)
+			continue
+		}
+		pkg.Add(s.bundleImages(ctx, a))
+	}
+	return pkg, nil
+}
+
+// WriteBook encodes a package as an e-book
+func (s *Service) WriteBook(w io.Writer, pkg *offline.Package) error {
+	if err := s.books.EncodePackage(w, pkg); err != nil {
+		return fmt.Errorf("failed to write book: %w", err)
+	}
+	return nil
+}
+
+// bundleImages fetches renditions and points the body at them. An image the
+// optimizer cannot produce keeps its online URL and simply needs a connection.
+func (s *Service) bundleImages(ctx context.Context, a offline.Article) offline.Article {
+	bundled := make([]offline.Image, 0, len(a.Images))
+	for i, img := range a.Images {
+		data, contentType, err := s.images.Optimize(ctx, img.SourceURL, offline.ImageMaxWidth)
+		if err != nil {
+			continue
+		}
+		img.Name = fmt.Sprintf("%s-%d%s", a.ID, i, extension(contentType))
+		img.ContentType = contentType
+		img.Data = data
+		a.Body = strings.ReplaceAll(a.Body, `"`+img.SourceURL+`"`, `"images/`+img.Name+`"`)
+		bundled = append(bundled, img)
+	}
+	a.Images = bundled
+	return a
+}
+
+func extension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	}
+	return ""
+}
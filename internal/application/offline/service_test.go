@@ -0,0 +1,154 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/offline"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+type fakeQueue struct {
+	entries []offline.QueueEntry
+}
+
+func (f *fakeQueue) FindQueue(ctx context.Context, accountID string, limit int) ([]offline.QueueEntry, error) {
+	return f.entries, nil
+}
+
+type fakeArticles struct {
+	articles map[string]offline.Article
+}
+
+func (f *fakeArticles) FindForOffline(ctx context.Context, articleIDs []string) ([]offline.Article, error) {
+	var found []offline.Article
+	for _, id := range articleIDs {
+		if a, ok := f.articles[id]; ok {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+type fakeImages struct {
+	requested []string
+}
+
+func (f *fakeImages) Optimize(ctx context.Context, sourceURL string, maxWidth int) ([]byte, string, error) {
+	f.requested = append(f.requested, sourceURL)
+	if strings.Contains(sourceURL, "broken") {
+		return nil, "", errors.New("unsupported image")
+	}
+	return []byte("jpeg bytes"), "image/jpeg", nil
+}
+
+// fakeAccess lets the member read everything but premium articles
+type fakeAccess struct{}
+
+func (fakeAccess) CanReadArticle(ctx context.Context, accountID string, a entitlement.Article, at time.Time) (entitlement.Decision, error) {
+	if a.Tier == entitlement.TierPremium {
+		return entitlement.Decision{Allowed: false, Reason: entitlement.ReasonPremiumRequired}, nil
+	}
+	return entitlement.Decision{Allowed: true, Reason: entitlement.ReasonFreeArticle}, nil
+}
+
+type fakeBooks struct{}
+
+func (fakeBooks) EncodePackage(w io.Writer, p *offline.Package) error {
+	_, err := io.WriteString(w, "book")
+	return err
+}
+
+func testArticle(id, revision string, tier entitlement.Tier) offline.Article {
+	return offline.Article{
+		ID:       id,
+		Revision: revision,
+		Title:    "Article " + id,
+		Body:     `<p>Text</p><img src="https://cdn.example.com/` + id + `.jpg" alt="Photo">`,
+		Images:   []offline.Image{{SourceURL: "https://cdn.example.com/" + id + ".jpg", Alt: "Photo"}},
+		Access:   entitlement.Article{ID: id, Tier: tier},
+	}
+}
+
+func createTestService(t *testing.T) (*Service, *fakeImages) {
+	t.Helper()
+	queue := &fakeQueue{entries: []offline.QueueEntry{
+		{ArticleID: "a1", Revision: "r1"},
+		{ArticleID: "a2", Revision: "r2"},
+		{ArticleID: "a3", Revision: "r1"},
+		{ArticleID: "gone", Revision: "r1"},
+	}}
+	articles := &fakeArticles{articles: map[string]offline.Article{
+		"a1": testArticle("a1", "r1", entitlement.TierFree),
+		"a2": testArticle("a2", "r2", entitlement.TierMetered),
+		"a3": testArticle("a3", "r1", entitlement.TierPremium),
+	}}
+	images := &fakeImages{}
+	return NewService(queue, articles, images, fakeAccess{}, fakeBooks{}), images
+}
+
+func TestService_Build_Full(t *testing.T) {
+	service, _ := createTestService(t)
+
+	pkg, err := service.Build(context.Background(), "member-1", offline.FormatJSON, nil, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if pkg.Delta || len(pkg.Articles) != 2 || len(pkg.Locked) != 1 || pkg.Locked[0] != "a3" {
+		t.Errorf("expected a1 and a2 bundled and a3 locked, got %+v", pkg)
+	}
+	a1 := pkg.Articles[0]
+	if len(a1.Images) != 1 || a1.Images[0].Name != "a1-0.jpg" || !strings.Contains(a1.Body, `src="images/a1-0.jpg"`) {
+		t.Errorf("expected the image bundled and the body rewritten, got %+v", a1)
+	}
+	if len(pkg.Manifest) != 2 {
+		t.Errorf("expected the manifest to list bundled articles only, got %v", pkg.Manifest)
+	}
+}
+
+func TestService_Build_Delta(t *testing.T) {
+	service, images := createTestService(t)
+	have := offline.Manifest{"a1": "r1", "a2": "r1", "a3": "r1", "gone": "r1", "unsaved": "r4"}
+
+	pkg, err := service.Build(context.Background(), "member-1", offline.FormatJSON, have, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(pkg.Articles) != 1 || pkg.Articles[0].ID != "a2" {
+		t.Errorf("expected only the changed article bundled, got %+v", pkg.Articles)
+	}
+	if len(images.requested) != 1 {
+		t.Errorf("expected images fetched for the changed article only, got %v", images.requested)
+	}
+	removed := strings.Join(pkg.Removed, ",")
+	if removed != "unsaved,a3,gone" {
+		t.Errorf("expected unsaved, locked and unpublished articles removed, got %s", removed)
+	}
+	if pkg.Manifest["a1"] != "r1" || pkg.Manifest["a2"] != "r2" || len(pkg.Manifest) != 2 {
+		t.Errorf("unexpected manifest %v", pkg.Manifest)
+	}
+
+	if _, err := service.Build(context.Background(), "member-1", offline.FormatEPUB, have, time.Now()); !errors.Is(err, offline.ErrDeltaNeedsJSON) {
+		t.Errorf("expected error '%v', got '%v'", offline.ErrDeltaNeedsJSON, err)
+	}
+}
+
+func TestService_Build_BrokenImage(t *testing.T) {
+	service, _ := createTestService(t)
+	broken := testArticle("a1", "r1", entitlement.TierFree)
+	broken.Images[0].SourceURL = "https://cdn.example.com/broken.tiff"
+	broken.Body = `<img src="https://cdn.example.com/broken.tiff">`
+	service.articles.(*fakeArticles).articles["a1"] = broken
+
+	pkg, err := service.Build(context.Background(), "member-1", offline.FormatJSON, nil, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(pkg.Articles[0].Images) != 0 || !strings.Contains(pkg.Articles[0].Body, "https://cdn.example.com/broken.tiff") {
+		t.Errorf("expected the broken image left online, got %+v", pkg.Articles[0])
+	}
+}
@@ -0,0 +1,170 @@
+package publishguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/publishguard"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrIncidentNotFound = errors.New("incident not found")
+	ErrStepUpRequired   = errors.New("re-authentication required")
+	ErrSessionSuspended = errors.New("session suspended pending security review")
+)
+
+// SecurityAlerts notifies security staff, e.g. a chat channel or a paging service
+type SecurityAlerts interface {
+	SendSecurityAlert(ctx context.Context, incident *publishguard.Incident) error
+}
+
+type Service struct {
+	actions   publishguard.ActionRepository
+	baselines publishguard.BaselineRepository
+	incidents publishguard.IncidentRepository
+	sessions  publishguard.SessionControl
+	alerts    SecurityAlerts
+	rules     publishguard.Rules
+}
+
+func NewService(actions publishguard.ActionRepository, baselines publishguard.BaselineRepository, incidents publishguard.IncidentRepository, sessions publishguard.SessionControl, alerts SecurityAlerts, rules publishguard.Rules) *Service {
+	return &Service{actions: actions, baselines: baselines, incidents: incidents, sessions: sessions, alerts: alerts, rules: rules}
+}
+
+// Guard runs before a publish, unpublish, edit or delete goes through. It
+// returns ErrStepUpRequired or ErrSessionSuspended together with the
+// decision when the action must not proceed.
+func (s *Service) Guard(ctx context.Context, action publishguard.Action) (*publishguard.Decision, error) {
+	if !action.Kind.Valid() {
+		return nil, publishguard.ErrInvalidActionKind
+	}
+
+	window := max(s.rules.MassUnpublish.Window, s.rules.BulkOldEdits.Window)
+	recent, err := s.actions.FindRecent(ctx, action.StaffID, action.At.Add(-window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recent actions: %w", err)
+	}
+	baseline, err := s.baselines.FindByStaff(ctx, action.StaffID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load baseline: %w", err)
+	}
+	if baseline == nil {
+		baseline = publishguard.NewBaseline(action.StaffID)
+	}
+
+	signals := publishguard.Evaluate(action, recent, baseline, s.rules)
+	response := publishguard.ResponseFor(signals)
+	if response == publishguard.ResponseStepUp && action.StepUpAt != nil && action.At.Sub(*action.StepUpAt) <= s.rules.StepUpValidity {
+		// Re-authenticated after the last challenge, the staff member vouched for the action
+		response, signals = publishguard.ResponseAllow, nil
+	}
+
+	if err := s.actions.Record(ctx, action); err != nil {
+		return nil, fmt.Errorf("failed to record action: %w", err)
+	}
+	if response == publishguard.ResponseAllow {
+		baseline.Learn(action, s.rules)
+		if err := s.baselines.Save(ctx, baseline); err != nil {
+			return nil, fmt.Errorf("failed to save baseline: %w", err)
+		}
+		return &publishguard.Decision{Response: response}, nil
+	}
+
+	incident, err := s.raise(ctx, action, recent, signals)
+	if err != nil {
+		return nil, err
+	}
+	decision := &publishguard.Decision{Response: response, Incident: incident}
+	if response == publishguard.ResponseSuspend {
+		return decision, ErrSessionSuspended
+	}
+	return decision, ErrStepUpRequired
+}
+
+func (s *Service) raise(ctx context.Context, action publishguard.Action, recent []publishguard.Action, signals []publishguard.Signal) (*publishguard.Incident, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate incident ID: %w", err)
+	}
+	incident, err := publishguard.NewIncident(id, action, recent, signals, action.At)
+	if err != nil {
+		return nil, err
+	}
+
+	// Contain first: a compromised session must not get further while the incident is written
+	switch incident.Response {
+	case publishguard.ResponseSuspend:
+		err = s.sessions.Suspend(ctx, action.SessionID, action.At.Add(s.rules.SuspendFor))
+	case publishguard.ResponseStepUp:
+		err = s.sessions.RequireStepUp(ctx, action.SessionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to restrict session: %w", err)
+	}
+
+	if err := s.incidents.Create(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to create incident: %w", err)
+	}
+	// The incident is stored and visible in the admin queue even when the alert fails
+	_ = s.alerts.SendSecurityAlert(ctx, incident)
+	return incident, nil
+}
+
+func (s *Service) OpenIncidents(ctx context.Context) ([]*publishguard.Incident, error) {
+	incidents, err := s.incidents.FindOpen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load incidents: %w", err)
+	}
+	return incidents, nil
+}
+
+func (s *Service) Incident(ctx context.Context, id string) (*publishguard.Incident, error) {
+	incident, err := s.incidents.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load incident: %w", err)
+	}
+	if incident == nil {
+		return nil, ErrIncidentNotFound
+	}
+	return incident, nil
+}
+
+// Resolve closes an incident. A legitimate one restores a suspended session
+// and teaches the baseline the triggering action, so the same address and
+// hour pass next time. A compromised session stays suspended.
+func (s *Service) Resolve(ctx context.Context, staffID, id string, compromised bool, note string, at time.Time) (*publishguard.Incident, error) {
+	incident, err := s.Incident(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := incident.Resolve(staffID, compromised, note, at); err != nil {
+		return nil, err
+	}
+
+	if !compromised {
+		baseline, err := s.baselines.FindByStaff(ctx, incident.StaffID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load baseline: %w", err)
+		}
+		if baseline == nil {
+			baseline = publishguard.NewBaseline(incident.StaffID)
+		}
+		baseline.Learn(incident.Trigger, s.rules)
+		if err := s.baselines.Save(ctx, baseline); err != nil {
+			return nil, fmt.Errorf("failed to save baseline: %w", err)
+		}
+		if incident.Response == publishguard.ResponseSuspend {
+			if err := s.sessions.Restore(ctx, incident.SessionID); err != nil {
+				return nil, fmt.Errorf("failed to restore session: %w", err)
+			}
+		}
+	}
+
+	if err := s.incidents.Update(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+	return incident, nil
+}
@@ -0,0 +1,181 @@
+package publishguard
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/publishguard"
+)
+
+type memoryActions struct {
+	actions []publishguard.Action
+}
+
+func (m *memoryActions) Record(ctx context.Context, action publishguard.Action) error {
+	m.actions = append(m.actions, action)
+	return nil
+}
+
+func (m *memoryActions) FindRecent(ctx context.Context, staffID string, since time.Time) ([]publishguard.Action, error) {
+	var recent []publishguard.Action
+	for _, a := range m.actions {
+		if a.StaffID == staffID && !a.At.Before(since) {
+			recent = append(recent, a)
+		}
+	}
+	return recent, nil
+}
+
+type memoryBaselines struct {
+	baselines map[string]*publishguard.Baseline
+}
+
+func (m *memoryBaselines) Save(ctx context.Context, baseline *publishguard.Baseline) error {
+	m.baselines[baseline.StaffID] = baseline
+	return nil
+}
+
+func (m *memoryBaselines) FindByStaff(ctx context.Context, staffID string) (*publishguard.Baseline, error) {
+	return m.baselines[staffID], nil
+}
+
+type memoryIncidents struct {
+	incidents map[string]*publishguard.Incident
+}
+
+func (m *memoryIncidents) Create(ctx context.Context, incident *publishguard.Incident) error {
+	m.incidents[incident.ID] = incident
+	return nil
+}
+
+func (m *memoryIncidents) Update(ctx context.Context, incident *publishguard.Incident) error {
+	m.incidents[incident.ID] = incident
+	return nil
+}
+
+func (m *memoryIncidents) FindByID(ctx context.Context, id string) (*publishguard.Incident, error) {
+	return m.incidents[id], nil
+}
+
+func (m *memoryIncidents) FindOpen(ctx context.Context) ([]*publishguard.Incident, error) {
+	var open []*publishguard.Incident
+	for _, i := range m.incidents {
+		if i.Status == publishguard.IncidentOpen {
+			open = append(open, i)
+		}
+	}
+	return open, nil
+}
+
+type fakeSessions struct {
+	stepUp    []string
+	suspended map[string]bool
+}
+
+func (f *fakeSessions) RequireStepUp(ctx context.Context, sessionID string) error {
+	f.stepUp = append(f.stepUp, sessionID)
+	return nil
+}
+
+func (f *fakeSessions) Suspend(ctx context.Context, sessionID string, until time.Time) error {
+	f.suspended[sessionID] = true
+	return nil
+}
+
+func (f *fakeSessions) Restore(ctx context.Context, sessionID string) error {
+	delete(f.suspended, sessionID)
+	return nil
+}
+
+type fakeAlerts struct {
+	sent []*publishguard.Incident
+}
+
+func (f *fakeAlerts) SendSecurityAlert(ctx context.Context, incident *publishguard.Incident) error {
+	f.sent = append(f.sent, incident)
+	return nil
+}
+
+type testEnv struct {
+	service   *Service
+	baselines *memoryBaselines
+	sessions  *fakeSessions
+	alerts    *fakeAlerts
+}
+
+func newTestEnv() testEnv {
+	env := testEnv{
+		baselines: &memoryBaselines{baselines: map[string]*publishguard.Baseline{}},
+		sessions:  &fakeSessions{suspended: map[string]bool{}},
+		alerts:    &fakeAlerts{},
+	}
+	env.service = NewService(&memoryActions{}, env.baselines, &memoryIncidents{incidents: map[string]*publishguard.Incident{}}, env.sessions, env.alerts, publishguard.DefaultRules)
+	return env
+}
+
+func wib(hour int) time.Time {
+	return time.Date(2025, 3, 1, hour, 0, 0, 0, publishguard.DefaultRules.Location)
+}
+
+func TestService_GuardMassUnpublish(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+
+	var decision *publishguard.Decision
+	var err error
+	for i := 0; i < 10; i++ {
+		decision, err = env.service.Guard(ctx, publishguard.Action{
+			StaffID: "s1", SessionID: "sess-1", Kind: publishguard.ActionUnpublish,
+			ArticleID: fmt.Sprintf("a%d", i), IP: "198.51.100.7", At: wib(14).Add(time.Duration(i) * time.Second),
+		})
+	}
+	if err != ErrSessionSuspended || decision.Response != publishguard.ResponseSuspend {
+		t.Fatalf("expected the tenth takedown to suspend the session, got %v %+v", err, decision)
+	}
+	if !env.sessions.suspended["sess-1"] || len(env.alerts.sent) != 1 || len(decision.Incident.Evidence) != 9 {
+		t.Errorf("expected suspension, alert and the earlier takedowns as evidence, got %+v", decision.Incident)
+	}
+
+	incident, err := env.service.Resolve(ctx, "sec-1", decision.Incident.ID, false, "archive cleanup", wib(15))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if incident.Status != publishguard.IncidentLegitimate || env.sessions.suspended["sess-1"] {
+		t.Errorf("expected the session restored, got %+v", incident)
+	}
+}
+
+func TestService_GuardStepUp(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	action := publishguard.Action{StaffID: "s1", SessionID: "sess-1", Kind: publishguard.ActionPublish, ArticleID: "a1", IP: "203.0.113.9", At: wib(3)}
+
+	decision, err := env.service.Guard(ctx, action)
+	if err != ErrStepUpRequired || len(env.sessions.stepUp) != 1 {
+		t.Fatalf("expected step-up for a 3am publish from a new address, got %v %+v", err, decision)
+	}
+	if env.baselines.baselines["s1"] != nil {
+		t.Error("expected the flagged action not learned")
+	}
+
+	steppedUp := action.At.Add(time.Minute)
+	action.StepUpAt, action.At = &steppedUp, action.At.Add(2*time.Minute)
+	if decision, err = env.service.Guard(ctx, action); err != nil || decision.Response != publishguard.ResponseAllow {
+		t.Errorf("expected the action allowed after re-authentication, got %v %+v", err, decision)
+	}
+	if len(env.alerts.sent) != 1 {
+		t.Errorf("expected a single alert, got %d", len(env.alerts.sent))
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	env := newTestEnv()
+	if _, err := env.service.Resolve(context.Background(), "sec-1", "missing", true, "", time.Now()); err != ErrIncidentNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrIncidentNotFound, err)
+	}
+	if _, err := env.service.Guard(context.Background(), publishguard.Action{Kind: "approve"}); err != publishguard.ErrInvalidActionKind {
+		t.Errorf("expected error '%v', got '%v'", publishguard.ErrInvalidActionKind, err)
+	}
+}
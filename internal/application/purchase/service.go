@@ -0,0 +1,219 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/purchase"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// History page sizes
+const (
+	DefaultHistoryLimit = 20
+	MaxHistoryLimit     = 100
+)
+
+var (
+	ErrArticleNotFound  = shared.NewDomainError("purchase.article_not_found", shared.KindNotFound, "article not found")
+	ErrPurchaseNotFound = shared.NewDomainError("purchase.not_found", shared.KindNotFound, "purchase not found")
+)
+
+// Checkout is a purchase waiting for the member to pay, the client secret
+// is handed to the payment form
+type Checkout struct {
+	Purchase     *purchase.Purchase
+	ClientSecret string
+}
+
+// Service sells premium articles one at a time. A completed purchase is read
+// by the entitlement service, which lets the buyer past the paywall for good.
+type Service struct {
+	prices    purchase.PriceRepository
+	purchases purchase.PurchaseRepository
+	articles  purchase.ArticleSource
+	payments  payment.PaymentProvider
+	clock     shared.Clock
+}
+
+func NewService(prices purchase.PriceRepository, purchases purchase.PurchaseRepository, articles purchase.ArticleSource, payments payment.PaymentProvider, clock shared.Clock) *Service {
+	return &Service{prices: prices, purchases: purchases, articles: articles, payments: payments, clock: clock}
+}
+
+// SetPrice puts a premium article up for sale or changes its price
+func (s *Service) SetPrice(ctx context.Context, editorID, articleID string, amount payment.Money) (*purchase.Price, error) {
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if !article.Premium {
+		return nil, purchase.ErrNotPremium
+	}
+	price, err := purchase.NewPrice(article.ID, amount, editorID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.prices.Save(ctx, price); err != nil {
+		return nil, fmt.Errorf("failed to save price: %w", err)
+	}
+	return price, nil
+}
+
+// RemovePrice takes the article off sale, earlier buyers keep their access
+func (s *Service) RemovePrice(ctx context.Context, articleID string) error {
+	if _, err := s.Price(ctx, articleID); err != nil {
+		return err
+	}
+	if err := s.prices.Delete(ctx, articleID); err != nil {
+		return fmt.Errorf("failed to remove price: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Price(ctx context.Context, articleID string) (*purchase.Price, error) {
+	price, err := s.prices.FindByArticleID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load price: %w", err)
+	}
+	if price == nil {
+		return nil, purchase.ErrNotForSale
+	}
+	return price, nil
+}
+
+// Buy starts a purchase and its payment intent. Asking again while the
+// payment is open returns the same checkout instead of charging twice.
+func (s *Service) Buy(ctx context.Context, accountID, articleID string) (*Checkout, error) {
+	open, err := s.purchases.FindOpen(ctx, accountID, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load purchases: %w", err)
+	}
+	if open != nil && open.IsCompleted() {
+		return nil, purchase.ErrAlreadyPurchased
+	}
+	if open != nil && open.PaymentIntentID != nil {
+		intent, err := s.payments.GetIntent(ctx, *open.PaymentIntentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load payment intent: %w", err)
+		}
+		return &Checkout{Purchase: open, ClientSecret: intent.ClientSecret}, nil
+	}
+
+	article, err := s.findArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	price, err := s.Price(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	p, err := purchase.NewPurchase(id, accountID, *article, *price, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.purchases.Create(ctx, p); err != nil {
+		return nil, fmt.Errorf("failed to save purchase: %w", err)
+	}
+
+	intent, err := s.payments.CreateIntent(ctx, p.ID, p.Amount, "Article: "+article.Title)
+	if err != nil {
+		return nil, s.abandon(ctx, p, fmt.Errorf("failed to create payment intent: %w", err))
+	}
+	if err := p.AttachPaymentIntent(intent.ID); err != nil {
+		return nil, err
+	}
+	if err := s.purchases.Update(ctx, p); err != nil {
+		return nil, fmt.Errorf("failed to update purchase: %w", err)
+	}
+	return &Checkout{Purchase: p, ClientSecret: intent.ClientSecret}, nil
+}
+
+// Confirm is called after the payment form finishes. A failed or canceled
+// payment closes the purchase so the member can try again.
+func (s *Service) Confirm(ctx context.Context, accountID, purchaseID string) (*purchase.Purchase, error) {
+	p, err := s.find(ctx, accountID, purchaseID)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsCompleted() {
+		return p, nil
+	}
+	if !p.IsPending() || p.PaymentIntentID == nil {
+		return nil, purchase.ErrNotPending
+	}
+
+	intent, err := s.payments.GetIntent(ctx, *p.PaymentIntentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payment intent: %w", err)
+	}
+	switch intent.Status {
+	case payment.IntentStatusFailed, payment.IntentStatusCanceled:
+		return nil, s.abandon(ctx, p, purchase.ErrPaymentNotSucceeded)
+	}
+	if err := p.Complete(*intent, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.purchases.Update(ctx, p); err != nil {
+		return nil, fmt.Errorf("failed to update purchase: %w", err)
+	}
+	return p, nil
+}
+
+// History lists the member's completed purchases, newest first
+func (s *Service) History(ctx context.Context, accountID string, limit, offset int) ([]*purchase.Purchase, error) {
+	if limit <= 0 || limit > MaxHistoryLimit {
+		limit = DefaultHistoryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	purchases, err := s.purchases.FindCompleted(ctx, accountID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load purchases: %w", err)
+	}
+	return purchases, nil
+}
+
+// Get returns one of the member's purchases, others' purchases look like they do not exist
+func (s *Service) Get(ctx context.Context, accountID, purchaseID string) (*purchase.Purchase, error) {
+	return s.find(ctx, accountID, purchaseID)
+}
+
+// abandon cancels a purchase that cannot be paid and returns the cause
+func (s *Service) abandon(ctx context.Context, p *purchase.Purchase, cause error) error {
+	if err := p.Cancel(s.clock.Now()); err != nil {
+		return errors.Join(cause, err)
+	}
+	if err := s.purchases.Update(ctx, p); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to update purchase: %w", err))
+	}
+	return cause
+}
+
+func (s *Service) find(ctx context.Context, accountID, purchaseID string) (*purchase.Purchase, error) {
+	p, err := s.purchases.FindByID(ctx, purchaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load purchase: %w", err)
+	}
+	if p == nil || p.AccountID != accountID {
+		return nil, ErrPurchaseNotFound
+	}
+	return p, nil
+}
+
+func (s *Service) findArticle(ctx context.Context, articleID string) (*purchase.Article, error) {
+	article, err := s.articles.FindArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article: %w", err)
+	}
+	if article == nil {
+		return nil, ErrArticleNotFound
+	}
+	return article, nil
+}
@@ -0,0 +1,236 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/purchase"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type memoryPrices struct {
+	prices map[string]*purchase.Price
+}
+
+func (m *memoryPrices) Save(ctx context.Context, p *purchase.Price) error {
+	m.prices[p.ArticleID] = p
+	return nil
+}
+
+func (m *memoryPrices) Delete(ctx context.Context, articleID string) error {
+	delete(m.prices, articleID)
+	return nil
+}
+
+func (m *memoryPrices) FindByArticleID(ctx context.Context, articleID string) (*purchase.Price, error) {
+	return m.prices[articleID], nil
+}
+
+type memoryPurchases struct {
+	purchases []*purchase.Purchase
+}
+
+func (m *memoryPurchases) Create(ctx context.Context, p *purchase.Purchase) error {
+	m.purchases = append(m.purchases, p)
+	return nil
+}
+
+func (m *memoryPurchases) Update(ctx context.Context, p *purchase.Purchase) error {
+	return nil
+}
+
+func (m *memoryPurchases) FindByID(ctx context.Context, id string) (*purchase.Purchase, error) {
+	for _, p := range m.purchases {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryPurchases) FindOpen(ctx context.Context, accountID, articleID string) (*purchase.Purchase, error) {
+	for _, p := range m.purchases {
+		if p.AccountID == accountID && p.ArticleID == articleID && p.Status != purchase.StatusCanceled {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryPurchases) HasPurchased(ctx context.Context, accountID, articleID string) (bool, error) {
+	p, _ := m.FindOpen(ctx, accountID, articleID)
+	return p != nil && p.IsCompleted(), nil
+}
+
+func (m *memoryPurchases) FindCompleted(ctx context.Context, accountID string, limit, offset int) ([]*purchase.Purchase, error) {
+	var found []*purchase.Purchase
+	for i := len(m.purchases) - 1; i >= 0; i-- {
+		if p := m.purchases[i]; p.AccountID == accountID && p.IsCompleted() {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+type fakeArticles struct{}
+
+func (fakeArticles) FindArticle(ctx context.Context, articleID string) (*purchase.Article, error) {
+	switch articleID {
+	case "premium-1":
+		return &purchase.Article{ID: articleID, Title: "Inside the budget", Premium: true}, nil
+	case "metered-1":
+		return &purchase.Article{ID: articleID, Title: "Weekend weather"}, nil
+	}
+	return nil, nil
+}
+
+type fakePayments struct {
+	intents map[string]*payment.PaymentIntent
+	err     error
+	created int
+}
+
+func (f *fakePayments) CreateIntent(ctx context.Context, reference string, amount payment.Money, description string) (*payment.PaymentIntent, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.created++
+	intent := &payment.PaymentIntent{ID: "pi_" + reference, Reference: reference, Amount: amount, Status: payment.IntentStatusRequiresPayment, ClientSecret: "secret_" + reference}
+	f.intents[intent.ID] = intent
+	return intent, nil
+}
+
+func (f *fakePayments) GetIntent(ctx context.Context, intentID string) (*payment.PaymentIntent, error) {
+	return f.intents[intentID], nil
+}
+
+func (f *fakePayments) CancelIntent(ctx context.Context, intentID string) error {
+	return nil
+}
+
+type testService struct {
+	*Service
+	prices    *memoryPrices
+	purchases *memoryPurchases
+	payments  *fakePayments
+}
+
+func createTestService(t *testing.T) *testService {
+	t.Helper()
+	ts := &testService{
+		prices:    &memoryPrices{prices: map[string]*purchase.Price{}},
+		purchases: &memoryPurchases{},
+		payments:  &fakePayments{intents: map[string]*payment.PaymentIntent{}},
+	}
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC))
+	ts.Service = NewService(ts.prices, ts.purchases, fakeArticles{}, ts.payments, clock)
+
+	amount, _ := payment.NewMoney(5000, "IDR")
+	if _, err := ts.SetPrice(context.Background(), "editor-1", "premium-1", *amount); err != nil {
+		t.Fatalf("failed to set price: %v", err)
+	}
+	return ts
+}
+
+func TestService_SetPrice(t *testing.T) {
+	s := createTestService(t)
+	ctx := context.Background()
+	amount, _ := payment.NewMoney(5000, "IDR")
+
+	if _, err := s.SetPrice(ctx, "editor-1", "metered-1", *amount); err != purchase.ErrNotPremium {
+		t.Errorf("expected ErrNotPremium, got %v", err)
+	}
+	if _, err := s.SetPrice(ctx, "editor-1", "missing", *amount); err != ErrArticleNotFound {
+		t.Errorf("expected ErrArticleNotFound, got %v", err)
+	}
+
+	if err := s.RemovePrice(ctx, "premium-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Price(ctx, "premium-1"); err != purchase.ErrNotForSale {
+		t.Errorf("expected ErrNotForSale, got %v", err)
+	}
+	if _, err := s.Buy(ctx, "member-1", "premium-1"); err != purchase.ErrNotForSale {
+		t.Errorf("expected ErrNotForSale, got %v", err)
+	}
+}
+
+func TestService_BuyAndConfirm(t *testing.T) {
+	s := createTestService(t)
+	ctx := context.Background()
+
+	checkout, err := s.Buy(ctx, "member-1", "premium-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p := checkout.Purchase
+	if !p.IsPending() || p.PaymentIntentID == nil || checkout.ClientSecret != "secret_"+p.ID {
+		t.Fatalf("unexpected checkout %+v", checkout)
+	}
+
+	again, err := s.Buy(ctx, "member-1", "premium-1")
+	if err != nil || again.Purchase.ID != p.ID || s.payments.created != 1 {
+		t.Errorf("expected the open checkout back without a new charge, got %+v, %v", again, err)
+	}
+
+	if _, err := s.Confirm(ctx, "member-1", p.ID); err != purchase.ErrPaymentNotSucceeded {
+		t.Errorf("expected ErrPaymentNotSucceeded before paying, got %v", err)
+	}
+	if !p.IsPending() {
+		t.Fatalf("expected an unpaid purchase to stay open, got %s", p.Status)
+	}
+
+	s.payments.intents[*p.PaymentIntentID].Status = payment.IntentStatusSucceeded
+	if _, err := s.Confirm(ctx, "member-2", p.ID); err != ErrPurchaseNotFound {
+		t.Errorf("expected another member's purchase to be hidden, got %v", err)
+	}
+	confirmed, err := s.Confirm(ctx, "member-1", p.ID)
+	if err != nil || !confirmed.IsCompleted() {
+		t.Fatalf("expected a completed purchase, got %+v, %v", confirmed, err)
+	}
+	if owned, _ := s.purchases.HasPurchased(ctx, "member-1", "premium-1"); !owned {
+		t.Error("expected the purchase to unlock the article")
+	}
+
+	if _, err := s.Buy(ctx, "member-1", "premium-1"); err != purchase.ErrAlreadyPurchased {
+		t.Errorf("expected ErrAlreadyPurchased, got %v", err)
+	}
+	history, _ := s.History(ctx, "member-1", 0, 0)
+	if len(history) != 1 || history[0].ArticleTitle != "Inside the budget" {
+		t.Errorf("unexpected history %+v", history)
+	}
+}
+
+func TestService_FailedPayment(t *testing.T) {
+	s := createTestService(t)
+	ctx := context.Background()
+
+	checkout, _ := s.Buy(ctx, "member-1", "premium-1")
+	s.payments.intents[*checkout.Purchase.PaymentIntentID].Status = payment.IntentStatusFailed
+	if _, err := s.Confirm(ctx, "member-1", checkout.Purchase.ID); err != purchase.ErrPaymentNotSucceeded {
+		t.Fatalf("expected ErrPaymentNotSucceeded, got %v", err)
+	}
+	if checkout.Purchase.Status != purchase.StatusCanceled {
+		t.Errorf("expected the failed purchase closed, got %s", checkout.Purchase.Status)
+	}
+
+	retry, err := s.Buy(ctx, "member-1", "premium-1")
+	if err != nil || retry.Purchase.ID == checkout.Purchase.ID {
+		t.Errorf("expected a fresh purchase after the failure, got %+v, %v", retry, err)
+	}
+}
+
+func TestService_BuyProviderFailure(t *testing.T) {
+	s := createTestService(t)
+	s.payments.err = errors.New("gateway unavailable")
+
+	if _, err := s.Buy(context.Background(), "member-1", "premium-1"); err == nil {
+		t.Fatal("expected the provider error")
+	}
+	if len(s.purchases.purchases) != 1 || s.purchases.purchases[0].Status != purchase.StatusCanceled {
+		t.Errorf("expected the purchase canceled, got %+v", s.purchases.purchases)
+	}
+}
@@ -0,0 +1,244 @@
+package puzzle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/puzzle"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var (
+	ErrPuzzleNotFound   = errors.New("puzzle not found")
+	ErrNoDailyPuzzle    = errors.New("no puzzle scheduled for today")
+	ErrDayTaken         = errors.New("another puzzle is already scheduled for that day")
+	ErrMemberNotAllowed = errors.New("only active members can save progress")
+)
+
+// Leaderboard limits
+const (
+	DefaultLeaderboardSize = 20
+	MaxLeaderboardSize     = 100
+)
+
+// SubmitResult is what the player sees after checking their grid
+type SubmitResult struct {
+	Solved   bool
+	Progress *puzzle.Progress
+	Streak   *puzzle.Streak // Set when the solve counted towards the daily streak
+}
+
+type LeaderboardEntry struct {
+	Rank      int
+	AccountID string
+	Username  string
+	Elapsed   time.Duration
+}
+
+type Service struct {
+	puzzles  puzzle.PuzzleRepository
+	progress puzzle.ProgressRepository
+	streaks  puzzle.StreakRepository
+	accounts account.UserAccountRepository
+}
+
+func NewService(puzzles puzzle.PuzzleRepository, progress puzzle.ProgressRepository, streaks puzzle.StreakRepository, accounts account.UserAccountRepository) *Service {
+	return &Service{puzzles: puzzles, progress: progress, streaks: streaks, accounts: accounts}
+}
+
+// Schedule makes a puzzle the daily puzzle of a day, one puzzle per kind per day
+func (s *Service) Schedule(ctx context.Context, puzzleID string, day time.Time) (*puzzle.Puzzle, error) {
+	p, err := s.findPuzzle(ctx, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.puzzles.FindDaily(ctx, p.Kind, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schedule: %w", err)
+	}
+	if existing != nil && existing.ID != p.ID {
+		return nil, ErrDayTaken
+	}
+
+	if err := p.Schedule(day); err != nil {
+		return nil, err
+	}
+	if err := s.puzzles.Update(ctx, p); err != nil {
+		return nil, fmt.Errorf("failed to update puzzle: %w", err)
+	}
+	return p, nil
+}
+
+// Daily returns today's puzzle of a kind
+func (s *Service) Daily(ctx context.Context, kind puzzle.Kind, at time.Time) (*puzzle.Puzzle, error) {
+	p, err := s.puzzles.FindDaily(ctx, kind, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load daily puzzle: %w", err)
+	}
+	if p == nil || !p.IsPlayable(at) {
+		return nil, ErrNoDailyPuzzle
+	}
+	return p, nil
+}
+
+// Start returns the member's progress on a puzzle, starting the clock on first open
+func (s *Service) Start(ctx context.Context, accountID, puzzleID string, at time.Time) (*puzzle.Progress, error) {
+	_, progress, created, err := s.loadProgress(ctx, accountID, puzzleID, at)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		if err := s.progress.Save(ctx, progress); err != nil {
+			return nil, fmt.Errorf("failed to save progress: %w", err)
+		}
+	}
+	return progress, nil
+}
+
+// SaveProgress stores a member's entries, starting their progress on first save
+func (s *Service) SaveProgress(ctx context.Context, accountID, puzzleID string, cells []string, elapsed time.Duration, at time.Time) (*puzzle.Progress, error) {
+	p, progress, entries, err := s.load(ctx, accountID, puzzleID, cells, at)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := progress.Save(p, *entries, elapsed, at); err != nil {
+		return nil, err
+	}
+	if err := s.progress.Save(ctx, progress); err != nil {
+		return nil, fmt.Errorf("failed to save progress: %w", err)
+	}
+	return progress, nil
+}
+
+// Submit checks a member's grid; solving the daily puzzle on its day extends the streak
+func (s *Service) Submit(ctx context.Context, accountID, puzzleID string, cells []string, elapsed time.Duration, at time.Time) (*SubmitResult, error) {
+	p, progress, entries, err := s.load(ctx, accountID, puzzleID, cells, at)
+	if err != nil {
+		return nil, err
+	}
+
+	solved, err := progress.Submit(p, *entries, elapsed, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.progress.Save(ctx, progress); err != nil {
+		return nil, fmt.Errorf("failed to save progress: %w", err)
+	}
+
+	result := &SubmitResult{Solved: solved, Progress: progress}
+	if !solved || !p.IsDailyOn(at) {
+		return result, nil
+	}
+
+	streak, err := s.streaks.Find(ctx, accountID, p.Kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load streak: %w", err)
+	}
+	if streak == nil {
+		if streak, err = puzzle.NewStreak(accountID, p.Kind); err != nil {
+			return nil, err
+		}
+	}
+	streak.RecordSolve(at)
+	if err := s.streaks.Save(ctx, streak); err != nil {
+		return nil, fmt.Errorf("failed to save streak: %w", err)
+	}
+	result.Streak = streak
+	return result, nil
+}
+
+// Leaderboard ranks the fastest unflagged solves of a puzzle
+func (s *Service) Leaderboard(ctx context.Context, puzzleID string, limit int) ([]LeaderboardEntry, error) {
+	if limit < 1 || limit > MaxLeaderboardSize {
+		limit = DefaultLeaderboardSize
+	}
+
+	ranked, err := s.progress.FindLeaderboard(ctx, puzzleID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load leaderboard: %w", err)
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(ranked))
+	for _, pr := range ranked {
+		if !pr.IsRanked() {
+			continue
+		}
+		acc, err := s.accounts.FindByID(ctx, pr.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load account: %w", err)
+		}
+		// Deleted or disabled members drop off the board
+		if acc == nil || !acc.IsActive() {
+			continue
+		}
+		entries = append(entries, LeaderboardEntry{
+			Rank:      len(entries) + 1,
+			AccountID: acc.ID,
+			Username:  acc.Username.String(),
+			Elapsed:   pr.Elapsed,
+		})
+	}
+	return entries, nil
+}
+
+func (s *Service) load(ctx context.Context, accountID, puzzleID string, cells []string, at time.Time) (*puzzle.Puzzle, *puzzle.Progress, *puzzle.Grid, error) {
+	p, progress, _, err := s.loadProgress(ctx, accountID, puzzleID, at)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	entries, err := puzzle.NewGrid(p.Solution.Rows(), p.Solution.Cols(), cells)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return p, progress, entries, nil
+}
+
+// loadProgress returns the member's progress, a new unsaved one (created) when they never opened the puzzle
+func (s *Service) loadProgress(ctx context.Context, accountID, puzzleID string, at time.Time) (*puzzle.Puzzle, *puzzle.Progress, bool, error) {
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil || !acc.IsActive() {
+		return nil, nil, false, ErrMemberNotAllowed
+	}
+
+	p, err := s.findPuzzle(ctx, puzzleID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	progress, err := s.progress.FindByAccountAndPuzzle(ctx, accountID, puzzleID)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to load progress: %w", err)
+	}
+	if progress != nil {
+		return p, progress, false, nil
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if progress, err = puzzle.NewProgress(id, p, accountID, at); err != nil {
+		return nil, nil, false, err
+	}
+	return p, progress, true, nil
+}
+
+func (s *Service) findPuzzle(ctx context.Context, puzzleID string) (*puzzle.Puzzle, error) {
+	p, err := s.puzzles.FindByID(ctx, puzzleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load puzzle: %w", err)
+	}
+	if p == nil {
+		return nil, ErrPuzzleNotFound
+	}
+	return p, nil
+}
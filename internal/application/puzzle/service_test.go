@@ -0,0 +1,206 @@
+package puzzle
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/puzzle"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakePuzzleRepo struct {
+	puzzles map[string]*puzzle.Puzzle
+}
+
+func (f *fakePuzzleRepo) Create(ctx context.Context, p *puzzle.Puzzle) error {
+	f.puzzles[p.ID] = p
+	return nil
+}
+
+func (f *fakePuzzleRepo) Update(ctx context.Context, p *puzzle.Puzzle) error {
+	f.puzzles[p.ID] = p
+	return nil
+}
+
+func (f *fakePuzzleRepo) FindByID(ctx context.Context, id string) (*puzzle.Puzzle, error) {
+	return f.puzzles[id], nil
+}
+
+func (f *fakePuzzleRepo) FindDaily(ctx context.Context, kind puzzle.Kind, day time.Time) (*puzzle.Puzzle, error) {
+	for _, p := range f.puzzles {
+		if p.Kind == kind && p.IsDailyOn(day) {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakePuzzleRepo) FindArchive(ctx context.Context, kind puzzle.Kind, before time.Time, limit, offset int) ([]*puzzle.Puzzle, error) {
+	return nil, nil
+}
+
+type fakeProgressRepo struct {
+	progress map[string]*puzzle.Progress
+}
+
+func (f *fakeProgressRepo) Save(ctx context.Context, p *puzzle.Progress) error {
+	f.progress[p.AccountID+"/"+p.PuzzleID] = p
+	return nil
+}
+
+func (f *fakeProgressRepo) FindByAccountAndPuzzle(ctx context.Context, accountID, puzzleID string) (*puzzle.Progress, error) {
+	return f.progress[accountID+"/"+puzzleID], nil
+}
+
+func (f *fakeProgressRepo) FindLeaderboard(ctx context.Context, puzzleID string, limit int) ([]*puzzle.Progress, error) {
+	var result []*puzzle.Progress
+	for _, p := range f.progress {
+		if p.PuzzleID == puzzleID && p.IsRanked() {
+			result = append(result, p)
+		}
+	}
+	// Fastest first
+	for i := 1; i < len(result); i++ {
+		for j := i; j > 0 && result[j].Elapsed < result[j-1].Elapsed; j-- {
+			result[j], result[j-1] = result[j-1], result[j]
+		}
+	}
+	return result, nil
+}
+
+type fakeStreakRepo struct {
+	streaks map[string]*puzzle.Streak
+}
+
+func (f *fakeStreakRepo) Save(ctx context.Context, s *puzzle.Streak) error {
+	f.streaks[s.AccountID+"/"+string(s.Kind)] = s
+	return nil
+}
+
+func (f *fakeStreakRepo) Find(ctx context.Context, accountID string, kind puzzle.Kind) (*puzzle.Streak, error) {
+	return f.streaks[accountID+"/"+string(kind)], nil
+}
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+type fixture struct {
+	service  *Service
+	puzzles  *fakePuzzleRepo
+	progress *fakeProgressRepo
+	puzzle   *puzzle.Puzzle
+}
+
+func newFixture(t *testing.T) *fixture {
+	solution, _ := puzzle.NewGrid(5, 5, strings.Split("C,A,T,#,S,A,#,O,A,K,R,A,T,E,S,E,#,O,N,E,S,L,O,T,S", ","))
+	p, err := puzzle.NewCrossword("p1", "editor1", "Mini", puzzle.DifficultyEasy, *solution, []puzzle.Clue{
+		{Number: 1, Direction: puzzle.DirectionAcross, Row: 0, Col: 0, Text: "Feline pet"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create puzzle: %v", err)
+	}
+
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{}}
+	for _, id := range []string{"member1", "member2", "member3"} {
+		acc, err := account.NewUserAccountForTesting(id, "player_"+id, id+"@example.com", "TestPassword123!", account.TypeMembership, "admin123")
+		if err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+		if id != "member3" {
+			_ = acc.Verify("admin123")
+		}
+		accounts.accounts[id] = acc
+	}
+
+	f := &fixture{
+		puzzles:  &fakePuzzleRepo{puzzles: map[string]*puzzle.Puzzle{p.ID: p}},
+		progress: &fakeProgressRepo{progress: map[string]*puzzle.Progress{}},
+		puzzle:   p,
+	}
+	f.service = NewService(f.puzzles, f.progress, &fakeStreakRepo{streaks: map[string]*puzzle.Streak{}}, accounts)
+	return f
+}
+
+func TestService_ScheduleOnePerDay(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1)
+
+	if _, err := f.service.Schedule(ctx, "p1", tomorrow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	other := *f.puzzle
+	other.ID = "p2"
+	other.Status = puzzle.StatusDraft
+	other.PlayDate = nil
+	f.puzzles.puzzles["p2"] = &other
+
+	if _, err := f.service.Schedule(ctx, "p2", tomorrow); err != ErrDayTaken {
+		t.Errorf("expected error '%v', got '%v'", ErrDayTaken, err)
+	}
+	if _, err := f.service.Daily(ctx, puzzle.KindCrossword, time.Now()); err != ErrNoDailyPuzzle {
+		t.Errorf("expected error '%v', got '%v'", ErrNoDailyPuzzle, err)
+	}
+	if _, err := f.service.Schedule(ctx, "missing", tomorrow); err != ErrPuzzleNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrPuzzleNotFound, err)
+	}
+}
+
+func TestService_PlayDailyPuzzle(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	start := time.Now()
+
+	if _, err := f.service.Schedule(ctx, "p1", start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	daily, err := f.service.Daily(ctx, puzzle.KindCrossword, start)
+	if err != nil || daily.ID != "p1" {
+		t.Fatalf("expected daily puzzle p1, got %v", err)
+	}
+
+	if _, err := f.service.Start(ctx, "member1", "p1", start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.service.Start(ctx, "member3", "p1", start); err != ErrMemberNotAllowed {
+		t.Errorf("expected error '%v', got '%v'", ErrMemberNotAllowed, err)
+	}
+
+	partial := daily.Board().Cells()
+	partial[0] = "C"
+	if _, err := f.service.SaveProgress(ctx, "member1", "p1", partial, 20*time.Second, start.Add(20*time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := f.service.Submit(ctx, "member1", "p1", daily.Solution.Cells(), 2*time.Minute, start.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Solved || result.Streak == nil || result.Streak.Current != 1 {
+		t.Errorf("expected solved with streak 1, got %+v", result)
+	}
+
+	// member2 solves implausibly fast and stays off the board
+	_, _ = f.service.Start(ctx, "member2", "p1", start)
+	fast, _ := f.service.Submit(ctx, "member2", "p1", daily.Solution.Cells(), 5*time.Second, start.Add(10*time.Second))
+	if !fast.Solved || !fast.Progress.Flagged {
+		t.Error("expected flagged solve")
+	}
+
+	board, err := f.service.Leaderboard(ctx, "p1", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(board) != 1 || board[0].Username != "player_member1" || board[0].Rank != 1 {
+		t.Errorf("expected only member1 ranked, got %+v", board)
+	}
+}
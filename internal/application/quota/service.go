@@ -0,0 +1,345 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// MaxReportRange bounds the usage history a single report can load
+const MaxReportRange = 366 * 24 * time.Hour
+
+// DefaultAlertBatch is how many pending alerts one job run sends
+const DefaultAlertBatch = 100
+
+var (
+	ErrTierNotFound       = errors.New("quota tier not found")
+	ErrAssignmentNotFound = errors.New("quota assignment not found")
+	ErrInvalidReportRange = errors.New("report range must be positive and at most 366 days")
+)
+
+// Consumer identifies who is calling the API
+type Consumer struct {
+	AccountID string
+	APIKeyID  string // Empty for session-authenticated calls
+}
+
+// Decision is the outcome of counting one call against the quota
+type Decision struct {
+	Allowed   bool
+	Limit     int64 // Zero when no limit applies
+	Remaining int64
+	Period    quota.Period
+	ResetAt   time.Time
+}
+
+// UsageNotifier emails the partner when consumption crosses a threshold
+type UsageNotifier interface {
+	NotifyQuotaUsage(ctx context.Context, recipient account.Email, alert *quota.Alert) error
+}
+
+type Service struct {
+	tiers       quota.TierRepository
+	assignments quota.AssignmentRepository
+	usage       quota.UsageRepository
+	alerts      quota.AlertRepository
+	accounts    account.UserAccountRepository
+	notifier    UsageNotifier
+	fallback    *quota.Tier // Applies to accounts without an active assignment
+}
+
+func NewService(tiers quota.TierRepository, assignments quota.AssignmentRepository, usage quota.UsageRepository, alerts quota.AlertRepository, accounts account.UserAccountRepository, notifier UsageNotifier, fallback *quota.Tier) *Service {
+	return &Service{
+		tiers:       tiers,
+		assignments: assignments,
+		usage:       usage,
+		alerts:      alerts,
+		accounts:    accounts,
+		notifier:    notifier,
+		fallback:    fallback,
+	}
+}
+
+// Assign attaches a tier to a partner account through a plan or contract
+func (s *Service) Assign(ctx context.Context, accountID, tierName string, source quota.Source, contractRef *string, startsAt time.Time, endsAt *time.Time, actorID string) (*quota.Assignment, error) {
+	tier, err := s.tiers.FindByName(ctx, strings.ToLower(strings.TrimSpace(tierName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tier: %w", err)
+	}
+	if tier == nil {
+		return nil, ErrTierNotFound
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := quota.NewAssignment(id, accountID, tier.Name(), source, contractRef, startsAt, endsAt, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.assignments.Create(ctx, a); err != nil {
+		return nil, fmt.Errorf("failed to save assignment: %w", err)
+	}
+	return a, nil
+}
+
+// EndAssignment closes a plan or terminates a contract early
+func (s *Service) EndAssignment(ctx context.Context, assignmentID string, at time.Time) (*quota.Assignment, error) {
+	a, err := s.assignments.FindByID(ctx, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assignment: %w", err)
+	}
+	if a == nil {
+		return nil, ErrAssignmentNotFound
+	}
+	if err := a.EndAt(at); err != nil {
+		return nil, err
+	}
+	if err := s.assignments.Update(ctx, a); err != nil {
+		return nil, fmt.Errorf("failed to save assignment: %w", err)
+	}
+	return a, nil
+}
+
+// Consume counts one call against the consumer's quota.
+// It is denied when any enforced counter would go over its limit; denied calls are
+// taken back off the counters so a client hammering an exhausted quota does not
+// keep inflating its usage.
+func (s *Service) Consume(ctx context.Context, consumer Consumer, route string, at time.Time) (*Decision, error) {
+	tier, _, err := s.tierFor(ctx, consumer.AccountID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := counterKeys(consumer, route, at)
+	counts, err := s.usage.Increment(ctx, keys, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record usage: %w", err)
+	}
+
+	decision := &Decision{Allowed: true, Period: quota.PeriodDaily, ResetAt: quota.PeriodDaily.WindowEnd(at)}
+	for i, key := range keys {
+		limit := limitFor(tier, key)
+		if !key.IsEnforced() || limit <= 0 {
+			continue
+		}
+
+		used := counts[i]
+		remaining := max(limit-used, 0)
+		if decision.Limit == 0 || remaining < decision.Remaining {
+			decision.Limit = limit
+			decision.Remaining = remaining
+			decision.Period = key.Period
+			decision.ResetAt = key.Period.WindowEnd(at)
+		}
+		if used > limit {
+			decision.Allowed = false
+		}
+	}
+
+	if !decision.Allowed {
+		if _, err := s.usage.Increment(ctx, keys, -1); err != nil {
+			return nil, fmt.Errorf("failed to release denied usage: %w", err)
+		}
+		return decision, nil
+	}
+
+	for i, key := range keys {
+		limit := limitFor(tier, key)
+		if !key.IsEnforced() || limit <= 0 {
+			continue
+		}
+		used := counts[i]
+		if level := quota.LevelFor(used, limit); level != quota.LevelFor(used-1, limit) {
+			// Counters are atomic, so exactly one call observes each crossing
+			if err := s.raiseAlert(ctx, key, level, used, limit); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return decision, nil
+}
+
+// AlertRunResult summarizes one run of the alert job
+type AlertRunResult struct {
+	Sent    int
+	Skipped int // Account no longer active or its address suppressed
+	Failed  int
+}
+
+// SendAlerts emails pending threshold alerts. Failures are retried on the next run.
+func (s *Service) SendAlerts(ctx context.Context, at time.Time) (*AlertRunResult, error) {
+	pending, err := s.alerts.FindUnsent(ctx, DefaultAlertBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load quota alerts: %w", err)
+	}
+
+	result := &AlertRunResult{}
+	for _, alert := range pending {
+		partner, err := s.accounts.FindByID(ctx, alert.Key.AccountID)
+		if err != nil {
+			result.Failed++
+			continue
+		}
+
+		if partner == nil || !partner.IsActive() {
+			result.Skipped++
+		} else if err := s.notifier.NotifyQuotaUsage(ctx, partner.Email, alert); errors.Is(err, suppression.ErrRecipientSuppressed) {
+			result.Skipped++
+		} else if err != nil {
+			result.Failed++
+			continue
+		} else {
+			result.Sent++
+		}
+
+		alert.MarkSent(at)
+		if err := s.alerts.Update(ctx, alert); err != nil {
+			return result, fmt.Errorf("failed to update quota alert %s: %w", alert.ID, err)
+		}
+	}
+	return result, nil
+}
+
+// Status is the live consumption of one enforced counter
+type Status struct {
+	Route   string // Empty for the account-wide counter
+	Period  quota.Period
+	Used    int64
+	Limit   int64
+	Level   quota.Level
+	ResetAt time.Time
+}
+
+// Report backs the partner usage endpoint and the admin dashboard
+type Report struct {
+	AccountID  string
+	Tier       *quota.Tier
+	Assignment *quota.Assignment // Nil when the fallback tier applies
+	Current    []Status
+	History    []quota.Usage
+}
+
+// Report returns current consumption and the counter history for [from, to)
+func (s *Service) Report(ctx context.Context, accountID string, period quota.Period, from, to, at time.Time) (*Report, error) {
+	if !period.IsValid() {
+		return nil, quota.ErrInvalidPeriod
+	}
+	if !to.After(from) || to.Sub(from) > MaxReportRange {
+		return nil, ErrInvalidReportRange
+	}
+
+	tier, assignment, err := s.tierFor(ctx, accountID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := []string{""}
+	if tier != nil {
+		routes = append(routes, tier.Routes()...)
+	}
+	var keys []quota.CounterKey
+	for _, p := range quota.Periods {
+		for _, route := range routes {
+			keys = append(keys, quota.CounterKey{AccountID: accountID, Route: route, Period: p, WindowStart: p.WindowStart(at)})
+		}
+	}
+	counts, err := s.usage.Get(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage: %w", err)
+	}
+
+	history, err := s.usage.FindHistory(ctx, accountID, period, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage history: %w", err)
+	}
+
+	report := &Report{AccountID: accountID, Tier: tier, Assignment: assignment, History: history}
+	for i, key := range keys {
+		limit := limitFor(tier, key)
+		report.Current = append(report.Current, Status{
+			Route:   key.Route,
+			Period:  key.Period,
+			Used:    counts[i],
+			Limit:   limit,
+			Level:   quota.LevelFor(counts[i], limit),
+			ResetAt: key.Period.WindowEnd(at),
+		})
+	}
+	return report, nil
+}
+
+func (s *Service) tierFor(ctx context.Context, accountID string, at time.Time) (*quota.Tier, *quota.Assignment, error) {
+	assignments, err := s.assignments.FindByAccount(ctx, accountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load quota assignments: %w", err)
+	}
+
+	current := quota.ResolveAssignment(assignments, at)
+	if current == nil {
+		return s.fallback, nil, nil
+	}
+
+	tier, err := s.tiers.FindByName(ctx, current.TierName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load tier: %w", err)
+	}
+	if tier == nil {
+		return nil, nil, ErrTierNotFound
+	}
+	return tier, current, nil
+}
+
+func (s *Service) raiseAlert(ctx context.Context, key quota.CounterKey, level quota.Level, used, limit int64) error {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	alert, err := quota.NewAlert(id, key, level, used, limit)
+	if err != nil {
+		return err
+	}
+	if err := s.alerts.Create(ctx, alert); err != nil {
+		return fmt.Errorf("failed to save quota alert: %w", err)
+	}
+	return nil
+}
+
+// counterKeys lists the account-wide, route and per-key counters for every period
+func counterKeys(consumer Consumer, route string, at time.Time) []quota.CounterKey {
+	var keys []quota.CounterKey
+	for _, p := range quota.Periods {
+		window := p.WindowStart(at)
+		keys = append(keys, quota.CounterKey{AccountID: consumer.AccountID, Period: p, WindowStart: window})
+		if route != "" {
+			keys = append(keys, quota.CounterKey{AccountID: consumer.AccountID, Route: route, Period: p, WindowStart: window})
+		}
+		if consumer.APIKeyID != "" {
+			keys = append(keys, quota.CounterKey{AccountID: consumer.AccountID, APIKeyID: consumer.APIKeyID, Period: p, WindowStart: window})
+		}
+	}
+	return keys
+}
+
+func limitFor(tier *quota.Tier, key quota.CounterKey) int64 {
+	if tier == nil {
+		return 0
+	}
+	if key.Route == "" {
+		return tier.Limits().For(key.Period)
+	}
+	limits, ok := tier.RouteLimits(key.Route)
+	if !ok {
+		return 0
+	}
+	return limits.For(key.Period)
+}
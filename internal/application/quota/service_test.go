@@ -0,0 +1,355 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memoryTiers struct {
+	tiers map[string]*quota.Tier
+}
+
+func (m *memoryTiers) Save(ctx context.Context, tier *quota.Tier) error {
+	m.tiers[tier.Name()] = tier
+	return nil
+}
+
+func (m *memoryTiers) FindByName(ctx context.Context, name string) (*quota.Tier, error) {
+	return m.tiers[name], nil
+}
+
+type memoryAssignments struct {
+	items []*quota.Assignment
+}
+
+func (m *memoryAssignments) Create(ctx context.Context, a *quota.Assignment) error {
+	m.items = append(m.items, a)
+	return nil
+}
+
+func (m *memoryAssignments) Update(ctx context.Context, a *quota.Assignment) error {
+	return nil
+}
+
+func (m *memoryAssignments) FindByID(ctx context.Context, id string) (*quota.Assignment, error) {
+	for _, a := range m.items {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAssignments) FindByAccount(ctx context.Context, accountID string) ([]*quota.Assignment, error) {
+	var found []*quota.Assignment
+	for _, a := range m.items {
+		if a.AccountID == accountID {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+type memoryUsage struct {
+	counters map[quota.CounterKey]int64
+}
+
+func (m *memoryUsage) Increment(ctx context.Context, keys []quota.CounterKey, n int64) ([]int64, error) {
+	counts := make([]int64, len(keys))
+	for i, key := range keys {
+		m.counters[key] += n
+		counts[i] = m.counters[key]
+	}
+	return counts, nil
+}
+
+func (m *memoryUsage) Get(ctx context.Context, keys []quota.CounterKey) ([]int64, error) {
+	counts := make([]int64, len(keys))
+	for i, key := range keys {
+		counts[i] = m.counters[key]
+	}
+	return counts, nil
+}
+
+func (m *memoryUsage) FindHistory(ctx context.Context, accountID string, period quota.Period, from, to time.Time) ([]quota.Usage, error) {
+	var history []quota.Usage
+	for key, count := range m.counters {
+		if key.AccountID == accountID && key.Period == period && !key.WindowStart.Before(from) && key.WindowStart.Before(to) {
+			history = append(history, quota.Usage{Key: key, Count: count})
+		}
+	}
+	return history, nil
+}
+
+type memoryAlerts struct {
+	items []*quota.Alert
+}
+
+func (m *memoryAlerts) Create(ctx context.Context, alert *quota.Alert) error {
+	m.items = append(m.items, alert)
+	return nil
+}
+
+func (m *memoryAlerts) Update(ctx context.Context, alert *quota.Alert) error {
+	return nil
+}
+
+func (m *memoryAlerts) FindUnsent(ctx context.Context, limit int) ([]*quota.Alert, error) {
+	var pending []*quota.Alert
+	for _, a := range m.items {
+		if !a.IsSent() && len(pending) < limit {
+			pending = append(pending, a)
+		}
+	}
+	return pending, nil
+}
+
+type fakeAccounts struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+}
+
+func (f *fakeAccounts) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+type fakeNotifier struct {
+	sent []*quota.Alert
+	err  error
+}
+
+func (f *fakeNotifier) NotifyQuotaUsage(ctx context.Context, recipient account.Email, alert *quota.Alert) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, alert)
+	return nil
+}
+
+type fixture struct {
+	service     *Service
+	assignments *memoryAssignments
+	usage       *memoryUsage
+	alerts      *memoryAlerts
+	notifier    *fakeNotifier
+}
+
+func newFixture(t *testing.T) *fixture {
+	partner, _ := quota.NewTier("partner", quota.Limits{Daily: 10, Monthly: 100}, map[string]quota.Limits{"search": {Daily: 5}})
+	free, _ := quota.NewTier("free", quota.Limits{Daily: 5}, nil)
+
+	acc, err := account.NewUserAccountForTesting("acc-1", "partner_one", "api@partner.example", "TestPassword123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+
+	f := &fixture{
+		assignments: &memoryAssignments{},
+		usage:       &memoryUsage{counters: map[quota.CounterKey]int64{}},
+		alerts:      &memoryAlerts{},
+		notifier:    &fakeNotifier{},
+	}
+	f.service = NewService(
+		&memoryTiers{tiers: map[string]*quota.Tier{"partner": partner}},
+		f.assignments, f.usage, f.alerts,
+		&fakeAccounts{accounts: map[string]*account.UserAccount{"acc-1": acc}},
+		f.notifier, free,
+	)
+	return f
+}
+
+func TestService_Assign(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	start := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := f.service.Assign(ctx, "acc-1", "platinum", quota.SourcePlan, nil, start, nil, "admin-1"); err != ErrTierNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrTierNotFound, err)
+	}
+
+	a, err := f.service.Assign(ctx, "acc-1", "Partner", quota.SourcePlan, nil, start, nil, "admin-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ended, err := f.service.EndAssignment(ctx, a.ID, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ended.EndsAt == nil {
+		t.Error("expected assignment to have an end")
+	}
+	if _, err := f.service.EndAssignment(ctx, "missing", start); err != ErrAssignmentNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrAssignmentNotFound, err)
+	}
+}
+
+func TestService_Consume(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	at := time.Date(2025, time.May, 10, 9, 0, 0, 0, time.UTC)
+	if _, err := f.service.Assign(ctx, "acc-1", "partner", quota.SourcePlan, nil, at.AddDate(0, -1, 0), nil, "admin-1"); err != nil {
+		t.Fatalf("failed to assign tier: %v", err)
+	}
+	consumer := Consumer{AccountID: "acc-1", APIKeyID: "key-1"}
+
+	var last *Decision
+	for i := 0; i < 5; i++ {
+		d, err := f.service.Consume(ctx, consumer, "search", at)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !d.Allowed {
+			t.Fatalf("expected call %d to be allowed", i+1)
+		}
+		last = d
+	}
+	if last.Limit != 5 || last.Remaining != 0 || !last.ResetAt.Equal(time.Date(2025, time.May, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected search limit to be the tightest, got %+v", last)
+	}
+
+	denied, _ := f.service.Consume(ctx, consumer, "search", at)
+	if denied.Allowed {
+		t.Error("expected sixth search call to be denied")
+	}
+
+	// Other routes still have account-wide headroom
+	other, _ := f.service.Consume(ctx, consumer, "articles", at)
+	if !other.Allowed || other.Limit != 10 || other.Remaining != 4 {
+		t.Errorf("expected 4 remaining account-wide, the denied call not counted, got %+v", other)
+	}
+
+	var levels []quota.Level
+	for _, a := range f.alerts.items {
+		levels = append(levels, a.Level)
+	}
+	// Search crosses 80% at 4 and 100% at 5, the account-wide daily counter stays below 80%
+	if len(levels) != 2 || levels[0] != quota.LevelWarning || levels[1] != quota.LevelExhausted {
+		t.Errorf("expected warning then exhausted alerts, got %v", levels)
+	}
+
+	perKey := quota.CounterKey{AccountID: "acc-1", APIKeyID: "key-1", Period: quota.PeriodMonthly, WindowStart: quota.PeriodMonthly.WindowStart(at)}
+	if f.usage.counters[perKey] != 6 {
+		t.Errorf("expected only the 6 allowed calls recorded for the key, got %d", f.usage.counters[perKey])
+	}
+}
+
+func TestService_Consume_FallbackTier(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	at := time.Date(2025, time.May, 10, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		f.service.Consume(ctx, Consumer{AccountID: "acc-1"}, "", at)
+	}
+	d, err := f.service.Consume(ctx, Consumer{AccountID: "acc-1"}, "", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Allowed || d.Limit != 5 {
+		t.Errorf("expected fallback limit of 5 to deny, got %+v", d)
+	}
+
+	next, _ := f.service.Consume(ctx, Consumer{AccountID: "acc-1"}, "", at.AddDate(0, 0, 1))
+	if !next.Allowed {
+		t.Error("expected counter to reset the next day")
+	}
+}
+
+func TestService_SendAlerts(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	at := time.Date(2025, time.May, 10, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		f.service.Consume(ctx, Consumer{AccountID: "acc-1"}, "", at)
+	}
+	if len(f.alerts.items) != 2 {
+		t.Fatalf("expected 2 pending alerts, got %d", len(f.alerts.items))
+	}
+
+	f.notifier.err = errors.New("smtp unavailable")
+	result, err := f.service.SendAlerts(ctx, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Failed != 2 || f.alerts.items[0].IsSent() {
+		t.Errorf("expected failed alerts to stay pending, got %+v", result)
+	}
+
+	f.notifier.err = nil
+	result, _ = f.service.SendAlerts(ctx, at)
+	if result.Sent != 2 || len(f.notifier.sent) != 2 {
+		t.Errorf("expected 2 alerts sent on retry, got %+v", result)
+	}
+
+	result, _ = f.service.SendAlerts(ctx, at)
+	if result.Sent != 0 {
+		t.Errorf("expected no alerts left, got %+v", result)
+	}
+}
+
+func TestService_SendAlerts_Suppressed(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	at := time.Date(2025, time.May, 10, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		f.service.Consume(ctx, Consumer{AccountID: "acc-1"}, "", at)
+	}
+
+	f.notifier.err = suppression.ErrRecipientSuppressed
+	result, err := f.service.SendAlerts(ctx, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Skipped != 2 || result.Failed != 0 || !f.alerts.items[0].IsSent() {
+		t.Errorf("expected suppressed alerts skipped and marked sent, got %+v", result)
+	}
+}
+
+func TestService_Report(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	at := time.Date(2025, time.May, 10, 9, 0, 0, 0, time.UTC)
+	f.service.Assign(ctx, "acc-1", "partner", quota.SourcePlan, nil, at.AddDate(0, -1, 0), nil, "admin-1")
+
+	f.service.Consume(ctx, Consumer{AccountID: "acc-1", APIKeyID: "key-1"}, "search", at.AddDate(0, 0, -1))
+	for i := 0; i < 4; i++ {
+		f.service.Consume(ctx, Consumer{AccountID: "acc-1", APIKeyID: "key-1"}, "search", at)
+	}
+
+	report, err := f.service.Report(ctx, "acc-1", quota.PeriodDaily, at.AddDate(0, 0, -7), quota.PeriodDaily.WindowStart(at), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Assignment == nil || report.Tier.Name() != "partner" {
+		t.Errorf("expected partner assignment, got %+v", report.Assignment)
+	}
+	if len(report.Current) != 4 {
+		t.Fatalf("expected 4 current statuses, got %d", len(report.Current))
+	}
+	search := report.Current[1]
+	if search.Route != "search" || search.Used != 4 || search.Level != quota.LevelWarning {
+		t.Errorf("expected search at warning level, got %+v", search)
+	}
+	if len(report.History) != 3 {
+		t.Errorf("expected yesterday's 3 daily counters in history, got %d", len(report.History))
+	}
+
+	if _, err := f.service.Report(ctx, "acc-1", quota.PeriodDaily, at, at, at); err != ErrInvalidReportRange {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidReportRange, err)
+	}
+	if _, err := f.service.Report(ctx, "acc-1", "weekly", at.AddDate(0, 0, -1), at, at); err != quota.ErrInvalidPeriod {
+		t.Errorf("expected error '%v', got '%v'", quota.ErrInvalidPeriod, err)
+	}
+}
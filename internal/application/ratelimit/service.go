@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+)
+
+// Service limits how often the public auth endpoints can be called. It
+// complements the per-account lockout and the failed-login bans, which only
+// react after passwords have been tried.
+type Service struct {
+	limiter ratelimit.Limiter
+	clock   shared.Clock
+}
+
+func NewService(limiter ratelimit.Limiter, clock shared.Clock) *Service {
+	return &Service{limiter: limiter, clock: clock}
+}
+
+// Check counts an attempt against every bucket of the rule and returns
+// ratelimit.ErrTooManyRequests, with the longest wait, when any is exhausted.
+// Every bucket is charged even after one rejects, so the count stays honest.
+func (s *Service) Check(ctx context.Context, rule ratelimit.Rule, clientIP, username string) error {
+	at := s.clock.Now()
+	rejected := ratelimit.Result{Allowed: true}
+	for _, bucket := range rule.Buckets(clientIP, username) {
+		result, err := s.limiter.Take(ctx, bucket.Key, bucket.Limit, at)
+		if err != nil {
+			return fmt.Errorf("failed to check rate limit: %w", err)
+		}
+		if !result.Allowed && (rejected.Allowed || result.RetryAfter > rejected.RetryAfter) {
+			rejected = result
+		}
+	}
+	return rejected.Err()
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+)
+
+type fakeLimiter struct {
+	counts map[string]int
+	err    error
+}
+
+// Take rejects once a key passes its limit, waiting one window
+func (f *fakeLimiter) Take(ctx context.Context, key string, limit ratelimit.Limit, at time.Time) (ratelimit.Result, error) {
+	if f.err != nil {
+		return ratelimit.Result{}, f.err
+	}
+	f.counts[key]++
+	if f.counts[key] > limit.Requests {
+		return ratelimit.Result{RetryAfter: limit.Window}, nil
+	}
+	return ratelimit.Result{Allowed: true, Remaining: limit.Requests - f.counts[key]}, nil
+}
+
+func TestService_Check(t *testing.T) {
+	limiter := &fakeLimiter{counts: map[string]int{}}
+	s := NewService(limiter, shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	rule := ratelimit.Rule{Name: "login", PerIP: ratelimit.Limit{Requests: 4, Window: time.Hour}, PerUser: ratelimit.Limit{Requests: 2, Window: time.Minute}}
+
+	for range 2 {
+		if err := s.Check(ctx, rule, "203.0.113.7", "sari"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	err := s.Check(ctx, rule, "203.0.113.7", "SARI")
+	if de, ok := shared.AsDomainError(err); !errors.Is(err, ratelimit.ErrTooManyRequests) || !ok || de.Meta[ratelimit.MetaRetryAfter] != "60" {
+		t.Fatalf("expected the username limit to reject, got %v", err)
+	}
+
+	// Another account from the same address still works until the address limit
+	if err := s.Check(ctx, rule, "203.0.113.7", "budi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = s.Check(ctx, rule, "203.0.113.7", "budi")
+	if de, _ := shared.AsDomainError(err); de == nil || de.Meta[ratelimit.MetaRetryAfter] != "3600" {
+		t.Errorf("expected the address limit with the longer wait, got %v", err)
+	}
+	if limiter.counts["login:ip:203.0.113.7"] != 5 {
+		t.Errorf("expected every attempt charged to the address, got %d", limiter.counts["login:ip:203.0.113.7"])
+	}
+}
+
+func TestService_CheckLimiterDown(t *testing.T) {
+	s := NewService(&fakeLimiter{err: errors.New("connection refused")}, shared.NewFrozenClock(time.Now()))
+	err := s.Check(context.Background(), ratelimit.LoginRule, "203.0.113.7", "sari")
+	if err == nil || errors.Is(err, ratelimit.ErrTooManyRequests) {
+		t.Errorf("expected the limiter error, got %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package referral
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/referral"
+)
+
+// maxCodeAttempts bounds retries when a generated code is already taken
+const maxCodeAttempts = 5
+
+var (
+	ErrNotEligible     = errors.New("only active members can refer")
+	ErrUnknownCode     = errors.New("referral code not found")
+	ErrAlreadyReferred = errors.New("account was already referred")
+	ErrAccountNotFound = errors.New("account not found")
+)
+
+// Status is what a member sees about their own referrals
+type Status struct {
+	Code       referral.Code
+	Link       string
+	Pending    int
+	Rewarded   int
+	Held       int // Flagged or over the monthly cap, shown without the reason
+	DaysEarned int
+	Referrals  []*referral.Referral
+}
+
+type Service struct {
+	members   referral.MemberRepository
+	referrals referral.ReferralRepository
+	accounts  account.UserAccountRepository
+	rewards   referral.RewardIssuer
+	baseURL   string
+}
+
+// NewService builds share links as baseURL + "/r/" + code
+func NewService(members referral.MemberRepository, referrals referral.ReferralRepository, accounts account.UserAccountRepository, rewards referral.RewardIssuer, baseURL string) *Service {
+	return &Service{members: members, referrals: referrals, accounts: accounts, rewards: rewards, baseURL: baseURL}
+}
+
+// CodeFor returns the member's code, issuing one on first use
+func (s *Service) CodeFor(ctx context.Context, accountID string, fingerprint referral.Fingerprint, at time.Time) (*referral.Member, error) {
+	member, err := s.members.FindByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load referral member: %w", err)
+	}
+	if member != nil {
+		return member, nil
+	}
+
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil || !acc.IsMembership() || !acc.IsActive() {
+		return nil, ErrNotEligible
+	}
+
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		code, err := generateCode()
+		if err != nil {
+			return nil, err
+		}
+		taken, err := s.members.FindByCode(ctx, *code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check referral code: %w", err)
+		}
+		if taken != nil {
+			continue
+		}
+
+		if member, err = referral.NewMember(accountID, *code, fingerprint, at); err != nil {
+			return nil, err
+		}
+		if err := s.members.Create(ctx, member); err != nil {
+			return nil, fmt.Errorf("failed to save referral member: %w", err)
+		}
+		return member, nil
+	}
+	return nil, errors.New("failed to generate a unique referral code")
+}
+
+// Link is the shareable URL for a code
+func (s *Service) Link(code referral.Code) string {
+	return s.baseURL + "/r/" + url.PathEscape(code.Value())
+}
+
+// Attribute records that a new signup came from a referral code. Suspicious
+// signups are kept but flagged, so they show up for review and never pay out.
+func (s *Service) Attribute(ctx context.Context, rawCode, refereeID string, fingerprint referral.Fingerprint, at time.Time) (*referral.Referral, error) {
+	code, err := referral.NewCode(rawCode)
+	if err != nil {
+		return nil, err
+	}
+	referrer, err := s.members.FindByCode(ctx, *code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load referral code: %w", err)
+	}
+	if referrer == nil {
+		return nil, ErrUnknownCode
+	}
+
+	existing, err := s.referrals.FindByReferee(ctx, refereeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load referral: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrAlreadyReferred
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	r, err := referral.NewReferral(id, referrer, refereeID, fingerprint, at)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := s.referrals.FindOverlapping(ctx, fingerprint, at.Add(-referral.ClusterWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recent referrals: %w", err)
+	}
+	if reason := referral.DetectFraud(r, referrer, recent); reason != nil {
+		_ = r.Flag(*reason, at)
+	}
+
+	if err := s.referrals.Create(ctx, r); err != nil {
+		return nil, fmt.Errorf("failed to save referral: %w", err)
+	}
+	return r, nil
+}
+
+// Qualify rewards the referrer once the referee's account is active. It is a
+// no-op for signups that were not referred or are no longer pending.
+func (s *Service) Qualify(ctx context.Context, refereeID string, at time.Time) (*referral.Referral, error) {
+	r, err := s.referrals.FindByReferee(ctx, refereeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load referral: %w", err)
+	}
+	if r == nil || !r.IsPending() {
+		return r, nil
+	}
+
+	referee, err := s.accounts.FindByID(ctx, refereeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if referee == nil {
+		return nil, ErrAccountNotFound
+	}
+	if !referee.IsActive() {
+		return r, nil
+	}
+
+	rewarded, err := s.referrals.CountRewardedSince(ctx, r.ReferrerID, at.AddDate(0, 0, -30))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count rewards: %w", err)
+	}
+	if rewarded >= referral.MaxRewardsPerMonth {
+		_ = r.MarkCapped(at)
+	} else {
+		if err := s.rewards.GrantPremiumDays(ctx, r.ReferrerID, referral.RewardDays, r.ID); err != nil {
+			return nil, fmt.Errorf("failed to grant reward: %w", err)
+		}
+		_ = r.MarkRewarded(referral.RewardDays, at)
+	}
+
+	if err := s.referrals.Update(ctx, r); err != nil {
+		return nil, fmt.Errorf("failed to update referral: %w", err)
+	}
+	return r, nil
+}
+
+// Status summarizes the member's referrals
+func (s *Service) Status(ctx context.Context, accountID string, fingerprint referral.Fingerprint, at time.Time) (*Status, error) {
+	member, err := s.CodeFor(ctx, accountID, fingerprint, at)
+	if err != nil {
+		return nil, err
+	}
+	referrals, err := s.referrals.FindByReferrer(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load referrals: %w", err)
+	}
+
+	status := &Status{Code: member.Code, Link: s.Link(member.Code), Referrals: referrals}
+	for _, r := range referrals {
+		switch r.Status {
+		case referral.StatusPending:
+			status.Pending++
+		case referral.StatusRewarded:
+			status.Rewarded++
+			status.DaysEarned += r.RewardDays
+		default:
+			status.Held++
+		}
+	}
+	return status, nil
+}
+
+func generateCode() (*referral.Code, error) {
+	raw := make([]byte, referral.CodeLength)
+	max := big.NewInt(int64(len(referral.CodeAlphabet)))
+	for i := range raw {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate referral code: %w", err)
+		}
+		raw[i] = referral.CodeAlphabet[n.Int64()]
+	}
+	return referral.NewCode(string(raw))
+}
@@ -0,0 +1,188 @@
+package referral
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/referral"
+)
+
+type memoryMembers struct {
+	members map[string]*referral.Member
+}
+
+func (m *memoryMembers) Create(ctx context.Context, member *referral.Member) error {
+	m.members[member.AccountID] = member
+	return nil
+}
+
+func (m *memoryMembers) FindByAccountID(ctx context.Context, accountID string) (*referral.Member, error) {
+	return m.members[accountID], nil
+}
+
+func (m *memoryMembers) FindByCode(ctx context.Context, code referral.Code) (*referral.Member, error) {
+	for _, member := range m.members {
+		if member.Code.Equals(code) {
+			return member, nil
+		}
+	}
+	return nil, nil
+}
+
+type memoryReferrals struct {
+	referrals []*referral.Referral
+}
+
+func (m *memoryReferrals) Create(ctx context.Context, r *referral.Referral) error {
+	m.referrals = append(m.referrals, r)
+	return nil
+}
+
+func (m *memoryReferrals) Update(ctx context.Context, r *referral.Referral) error {
+	return nil
+}
+
+func (m *memoryReferrals) FindByReferee(ctx context.Context, refereeID string) (*referral.Referral, error) {
+	for _, r := range m.referrals {
+		if r.RefereeID == refereeID {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryReferrals) FindByReferrer(ctx context.Context, referrerID string) ([]*referral.Referral, error) {
+	var found []*referral.Referral
+	for _, r := range m.referrals {
+		if r.ReferrerID == referrerID {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryReferrals) FindOverlapping(ctx context.Context, fingerprint referral.Fingerprint, since time.Time) ([]*referral.Referral, error) {
+	var found []*referral.Referral
+	for _, r := range m.referrals {
+		if !r.CreatedAt.Before(since) && r.Fingerprint.Overlaps(fingerprint) {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryReferrals) CountRewardedSince(ctx context.Context, referrerID string, since time.Time) (int, error) {
+	n := 0
+	for _, r := range m.referrals {
+		if r.ReferrerID == referrerID && r.RewardedAt != nil && !r.RewardedAt.Before(since) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+type fakeRewards struct {
+	granted map[string]int
+}
+
+func (f *fakeRewards) GrantPremiumDays(ctx context.Context, accountID string, days int, referralID string) error {
+	f.granted[accountID] += days
+	return nil
+}
+
+func createTestMember(t *testing.T, id string, active bool) *account.UserAccount {
+	acc, err := account.NewUserAccountForTesting(id, id, id+"@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if active {
+		if err := acc.Verify("admin123"); err != nil {
+			t.Fatalf("failed to verify account: %v", err)
+		}
+	}
+	return acc
+}
+
+func createTestService(t *testing.T) (*Service, *fakeRewards) {
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{
+		"member1": createTestMember(t, "member1", true),
+		"reader1": createTestMember(t, "reader1", true),
+		"reader2": createTestMember(t, "reader2", false),
+		"reader3": createTestMember(t, "reader3", true),
+	}}
+	rewards := &fakeRewards{granted: map[string]int{}}
+	service := NewService(&memoryMembers{members: map[string]*referral.Member{}}, &memoryReferrals{}, accounts, rewards, "https://news.example.com")
+	return service, rewards
+}
+
+func TestService_ReferAndReward(t *testing.T) {
+	ctx := context.Background()
+	service, rewards := createTestService(t)
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+
+	member, err := service.CodeFor(ctx, "member1", referral.Fingerprint{IPAddress: "203.0.113.7", DeviceID: "device-1"}, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again, _ := service.CodeFor(ctx, "member1", referral.Fingerprint{}, at); !again.Code.Equals(member.Code) {
+		t.Errorf("expected a stable code, got %s then %s", member.Code, again.Code)
+	}
+
+	if _, err := service.Attribute(ctx, member.Code.Value(), "reader1", referral.Fingerprint{IPAddress: "192.0.2.1"}, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Attribute(ctx, member.Code.Value(), "reader1", referral.Fingerprint{IPAddress: "192.0.2.1"}, at); err != ErrAlreadyReferred {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyReferred, err)
+	}
+	if _, err := service.Attribute(ctx, member.Code.Value(), "reader2", referral.Fingerprint{IPAddress: "192.0.2.2"}, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	flagged, err := service.Attribute(ctx, member.Code.Value(), "reader3", referral.Fingerprint{IPAddress: "198.51.100.2", DeviceID: "device-1"}, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if flagged.Status != referral.StatusFlagged {
+		t.Errorf("expected a signup from the referrer's device to be flagged, got %s", flagged.Status)
+	}
+
+	for _, referee := range []string{"reader1", "reader2", "reader3"} {
+		if _, err := service.Qualify(ctx, referee, at.Add(time.Hour)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if rewards.granted["member1"] != referral.RewardDays {
+		t.Errorf("expected %d days for one active clean referral, got %d", referral.RewardDays, rewards.granted["member1"])
+	}
+
+	status, err := service.Status(ctx, "member1", referral.Fingerprint{}, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Rewarded != 1 || status.Pending != 1 || status.Held != 1 || status.DaysEarned != referral.RewardDays {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if status.Link != "https://news.example.com/r/"+member.Code.Value() {
+		t.Errorf("unexpected link %s", status.Link)
+	}
+}
+
+func TestService_CodeForIneligible(t *testing.T) {
+	service, _ := createTestService(t)
+	if _, err := service.CodeFor(context.Background(), "reader2", referral.Fingerprint{}, time.Now()); err != ErrNotEligible {
+		t.Errorf("expected error '%v', got '%v'", ErrNotEligible, err)
+	}
+	if _, err := service.Attribute(context.Background(), "ZZZZZZZZ", "reader1", referral.Fingerprint{}, time.Now()); err != ErrUnknownCode {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownCode, err)
+	}
+}
@@ -0,0 +1,158 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/retention"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// PurgeBatch bounds each delete so a large backlog never holds long locks
+const PurgeBatch = 5000
+
+var (
+	ErrHoldNotFound = errors.New("legal hold not found")
+	ErrNoPurger     = errors.New("no purger registered for category")
+)
+
+type Service struct {
+	policies []retention.Policy
+	purgers  map[retention.Category]retention.Purger
+	holds    retention.HoldRepository
+	runs     retention.RunRepository
+	clock    shared.Clock
+}
+
+// NewService takes the policies from retention.DefaultPolicies or LoadPolicies
+// and one purger for every category they list
+func NewService(policies []retention.Policy, purgers map[retention.Category]retention.Purger, holds retention.HoldRepository, runs retention.RunRepository, clock shared.Clock) *Service {
+	return &Service{policies: policies, purgers: purgers, holds: holds, runs: runs, clock: clock}
+}
+
+func (s *Service) Policies() []retention.Policy {
+	return s.policies
+}
+
+// Run applies every policy. A dry run only counts, for the report reviewed
+// before a policy change goes live. A failing category does not stop the others.
+func (s *Service) Run(ctx context.Context, dryRun bool, at time.Time) ([]*retention.Run, error) {
+	holds, err := s.holds.FindActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load legal holds: %w", err)
+	}
+
+	runs := make([]*retention.Run, 0, len(s.policies))
+	var errs []error
+	for _, policy := range s.policies {
+		id, err := shared.GenerateUUID()
+		if err != nil {
+			return runs, fmt.Errorf("failed to generate run ID: %w", err)
+		}
+		run := retention.NewRun(id, policy, dryRun, at)
+		if err := s.apply(ctx, run, holds); err != nil {
+			run.Fail(err, s.clock.Now())
+			errs = append(errs, fmt.Errorf("%s: %w", policy.Category, err))
+		} else {
+			run.Finish(s.clock.Now())
+		}
+		if err := s.runs.Save(ctx, run); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save %s run: %w", policy.Category, err))
+		}
+		runs = append(runs, run)
+	}
+	return runs, errors.Join(errs...)
+}
+
+func (s *Service) apply(ctx context.Context, run *retention.Run, holds []*retention.Hold) error {
+	purger, ok := s.purgers[run.Category]
+	if !ok {
+		return ErrNoPurger
+	}
+
+	target := retention.Target{Category: run.Category, Cutoff: run.Cutoff}
+	for _, h := range holds {
+		if h.Category != run.Category || !h.IsActive() {
+			continue
+		}
+		if h.SubjectID == nil {
+			run.HeldAll = true
+			return nil
+		}
+		target.HeldSubjects = append(target.HeldSubjects, *h.SubjectID)
+	}
+
+	counts, err := purger.Count(ctx, target)
+	if err != nil {
+		return fmt.Errorf("failed to count expired records: %w", err)
+	}
+	run.Expired, run.Held = counts.Expired, counts.Held
+	if run.DryRun {
+		return nil
+	}
+
+	for {
+		n, err := purger.Purge(ctx, target, PurgeBatch)
+		run.Purged += n
+		if err != nil {
+			return fmt.Errorf("failed to purge expired records: %w", err)
+		}
+		if n < PurgeBatch {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// Reports lists recent runs, newest first
+func (s *Service) Reports(ctx context.Context, limit int) ([]*retention.Run, error) {
+	runs, err := s.runs.FindRecent(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load retention runs: %w", err)
+	}
+	return runs, nil
+}
+
+func (s *Service) PlaceHold(ctx context.Context, staffID string, category retention.Category, subjectID *string, reason string, at time.Time) (*retention.Hold, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate hold ID: %w", err)
+	}
+	hold, err := retention.NewHold(id, category, subjectID, reason, staffID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.holds.Save(ctx, hold); err != nil {
+		return nil, fmt.Errorf("failed to save legal hold: %w", err)
+	}
+	return hold, nil
+}
+
+func (s *Service) ReleaseHold(ctx context.Context, staffID, holdID string, at time.Time) (*retention.Hold, error) {
+	hold, err := s.holds.FindByID(ctx, holdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load legal hold: %w", err)
+	}
+	if hold == nil {
+		return nil, ErrHoldNotFound
+	}
+	if err := hold.Release(staffID, at); err != nil {
+		return nil, err
+	}
+	if err := s.holds.Save(ctx, hold); err != nil {
+		return nil, fmt.Errorf("failed to save legal hold: %w", err)
+	}
+	return hold, nil
+}
+
+func (s *Service) Holds(ctx context.Context) ([]*retention.Hold, error) {
+	holds, err := s.holds.FindActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load legal holds: %w", err)
+	}
+	return holds, nil
+}
@@ -0,0 +1,215 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/retention"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type record struct {
+	subject string
+	at      time.Time
+}
+
+// memoryPurger keeps records of one category like a table with a timestamp and subject column
+type memoryPurger struct {
+	records []record
+	batches int
+	err     error
+}
+
+func (m *memoryPurger) matches(r record, t retention.Target) (expired, held bool) {
+	if !r.at.Before(t.Cutoff) {
+		return false, false
+	}
+	for _, s := range t.HeldSubjects {
+		if s == r.subject {
+			return true, true
+		}
+	}
+	return true, false
+}
+
+func (m *memoryPurger) Count(ctx context.Context, t retention.Target) (retention.Counts, error) {
+	var c retention.Counts
+	for _, r := range m.records {
+		expired, held := m.matches(r, t)
+		if expired {
+			c.Expired++
+		}
+		if held {
+			c.Held++
+		}
+	}
+	return c, nil
+}
+
+func (m *memoryPurger) Purge(ctx context.Context, t retention.Target, limit int) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	m.batches++
+	kept := m.records[:0]
+	n := 0
+	for _, r := range m.records {
+		if expired, held := m.matches(r, t); expired && !held && n < limit {
+			n++
+			continue
+		}
+		kept = append(kept, r)
+	}
+	m.records = kept
+	return n, nil
+}
+
+type memoryHolds struct {
+	holds map[string]*retention.Hold
+}
+
+func (m *memoryHolds) Save(ctx context.Context, h *retention.Hold) error {
+	m.holds[h.ID] = h
+	return nil
+}
+
+func (m *memoryHolds) FindByID(ctx context.Context, id string) (*retention.Hold, error) {
+	return m.holds[id], nil
+}
+
+func (m *memoryHolds) FindActive(ctx context.Context) ([]*retention.Hold, error) {
+	var active []*retention.Hold
+	for _, h := range m.holds {
+		if h.IsActive() {
+			active = append(active, h)
+		}
+	}
+	return active, nil
+}
+
+type memoryRuns struct {
+	runs []*retention.Run
+}
+
+func (m *memoryRuns) Save(ctx context.Context, r *retention.Run) error {
+	m.runs = append(m.runs, r)
+	return nil
+}
+
+func (m *memoryRuns) FindRecent(ctx context.Context, limit int) ([]*retention.Run, error) {
+	var recent []*retention.Run
+	for i := len(m.runs) - 1; i >= 0 && len(recent) < limit; i-- {
+		recent = append(recent, m.runs[i])
+	}
+	return recent, nil
+}
+
+var now = time.Date(2025, 7, 1, 2, 0, 0, 0, time.UTC)
+
+func newTestService() (*Service, *memoryPurger, *memoryPurger, *memoryHolds, *memoryRuns) {
+	logins := &memoryPurger{}
+	for i := 0; i < PurgeBatch+10; i++ {
+		logins.records = append(logins.records, record{subject: "account-1", at: now.AddDate(0, 0, -100)})
+	}
+	logins.records = append(logins.records,
+		record{subject: "account-2", at: now.AddDate(0, 0, -120)},
+		record{subject: "account-3", at: now.AddDate(0, 0, -10)},
+	)
+	analytics := &memoryPurger{records: []record{{subject: "a1", at: now.AddDate(-2, 0, 0)}}}
+
+	policies := []retention.Policy{
+		{Category: retention.CategoryLoginAttempts, Keep: retention.Period{Days: 90}},
+		{Category: retention.CategoryAnalyticsEvents, Keep: retention.Period{Months: 13}},
+	}
+	purgers := map[retention.Category]retention.Purger{
+		retention.CategoryLoginAttempts:   logins,
+		retention.CategoryAnalyticsEvents: analytics,
+	}
+	holds := &memoryHolds{holds: map[string]*retention.Hold{}}
+	runs := &memoryRuns{}
+	return NewService(policies, purgers, holds, runs, shared.NewFrozenClock(now)), logins, analytics, holds, runs
+}
+
+func TestService_DryRun(t *testing.T) {
+	service, logins, _, _, runs := newTestService()
+
+	reports, err := service.Run(context.Background(), true, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reports) != 2 || reports[0].Expired != PurgeBatch+11 || reports[0].Purged != 0 || reports[1].Expired != 1 {
+		t.Errorf("unexpected reports %+v %+v", reports[0], reports[1])
+	}
+	if len(logins.records) != PurgeBatch+12 || logins.batches != 0 {
+		t.Errorf("expected dry run to delete nothing, %d records left", len(logins.records))
+	}
+	if len(runs.runs) != 2 {
+		t.Errorf("expected dry runs saved for the report, got %d", len(runs.runs))
+	}
+}
+
+func TestService_RunWithHolds(t *testing.T) {
+	service, logins, analytics, _, _ := newTestService()
+	ctx := context.Background()
+	subject := "account-2"
+	if _, err := service.PlaceHold(ctx, "legal-1", retention.CategoryLoginAttempts, &subject, "Case 12/2025", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hold, _ := service.PlaceHold(ctx, "legal-1", retention.CategoryAnalyticsEvents, nil, "Regulator inquiry", now)
+
+	reports, err := service.Run(ctx, false, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reports[0].Purged != PurgeBatch+10 || reports[0].Held != 1 || logins.batches != 2 {
+		t.Errorf("expected expired records purged in batches around the held one, got %+v after %d batches", reports[0], logins.batches)
+	}
+	if len(logins.records) != 2 {
+		t.Errorf("expected the held and the recent record kept, got %d", len(logins.records))
+	}
+	if !reports[1].HeldAll || len(analytics.records) != 1 {
+		t.Errorf("expected the category-wide hold to skip analytics, got %+v", reports[1])
+	}
+
+	if _, err := service.ReleaseHold(ctx, "legal-2", hold.ID, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Run(ctx, false, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(analytics.records) != 0 {
+		t.Errorf("expected analytics purged after release, got %d", len(analytics.records))
+	}
+}
+
+func TestService_RunContinuesPastFailures(t *testing.T) {
+	service, logins, analytics, _, runs := newTestService()
+	logins.err = errors.New("connection reset")
+
+	reports, err := service.Run(context.Background(), false, now)
+	if err == nil {
+		t.Fatal("expected the failure reported")
+	}
+	if reports[0].Error == nil || len(analytics.records) != 0 {
+		t.Errorf("expected login failure recorded and analytics still purged, got %+v", reports[0])
+	}
+	if len(runs.runs) != 2 {
+		t.Errorf("expected both runs saved, got %d", len(runs.runs))
+	}
+}
+
+func TestService_ReleaseHoldErrors(t *testing.T) {
+	service, _, _, _, _ := newTestService()
+	ctx := context.Background()
+
+	if _, err := service.ReleaseHold(ctx, "legal-1", "missing", now); err != ErrHoldNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrHoldNotFound, err)
+	}
+	hold, _ := service.PlaceHold(ctx, "legal-1", retention.CategoryAuditLog, nil, "Regulator inquiry", now)
+	_, _ = service.ReleaseHold(ctx, "legal-1", hold.ID, now)
+	if _, err := service.ReleaseHold(ctx, "legal-1", hold.ID, now); err != retention.ErrHoldReleased {
+		t.Errorf("expected error '%v', got '%v'", retention.ErrHoldReleased, err)
+	}
+}
@@ -0,0 +1,208 @@
+package revenue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/revenue"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// RestateDays is how many finished days every run recomputes, ad networks
+// keep adjusting their numbers for a few days after the fact
+const RestateDays = 3
+
+// Report limits for the reporting API
+const (
+	DefaultReportLimit = 50
+	MaxReportLimit     = 500
+)
+
+// DayResult summarises one aggregated day
+type DayResult struct {
+	Day      time.Time
+	Articles int
+	// Unattributed is subscription revenue from readers with no article reads in the lookback
+	Unattributed payment.Money
+}
+
+// Report is revenue grouped by one dimension over a date range
+type Report struct {
+	By       revenue.Dimension
+	From     time.Time
+	To       time.Time
+	Currency string
+	Lines    []revenue.Line
+	Total    revenue.Line
+}
+
+type Service struct {
+	conversions revenue.ConversionSource
+	ads         revenue.AdSource
+	pageviews   revenue.PageviewSource
+	articles    revenue.ArticleDirectory
+	days        revenue.ArticleDayRepository
+	currency    string
+	clock       shared.Clock
+}
+
+// NewService takes the reporting currency, every source must already report in it
+func NewService(conversions revenue.ConversionSource, ads revenue.AdSource, pageviews revenue.PageviewSource, articles revenue.ArticleDirectory, days revenue.ArticleDayRepository, currency string, clock shared.Clock) *Service {
+	return &Service{conversions: conversions, ads: ads, pageviews: pageviews, articles: articles, days: days, currency: currency, clock: clock}
+}
+
+// RunDaily is the scheduled aggregation job. It recomputes the last
+// RestateDays finished days before at, a failing day does not stop the others.
+func (s *Service) RunDaily(ctx context.Context, at time.Time) ([]DayResult, error) {
+	today := revenue.TruncateDay(at)
+	results := make([]DayResult, 0, RestateDays)
+	var errs []error
+	for i := RestateDays; i >= 1; i-- {
+		day := today.AddDate(0, 0, -i)
+		result, err := s.AggregateDay(ctx, day)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", day.Format(time.DateOnly), err))
+			continue
+		}
+		results = append(results, *result)
+	}
+	return results, errors.Join(errs...)
+}
+
+// AggregateDay joins pageviews, ad revenue and attributed conversions for one
+// UTC day and replaces whatever was stored for it
+func (s *Service) AggregateDay(ctx context.Context, day time.Time) (*DayResult, error) {
+	from := revenue.TruncateDay(day)
+	to := from.AddDate(0, 0, 1)
+	unattributed, err := payment.NewMoney(0, s.currency)
+	if err != nil {
+		return nil, err
+	}
+	result := &DayResult{Day: from, Unattributed: *unattributed}
+
+	views, err := s.pageviews.Pageviews(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pageviews: %w", err)
+	}
+	adLines, err := s.ads.AdRevenue(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ad revenue: %w", err)
+	}
+	conversions, err := s.conversions.Conversions(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conversions: %w", err)
+	}
+
+	now := s.clock.Now()
+	rows := make(map[string]*revenue.ArticleDay)
+	row := func(articleID string) (*revenue.ArticleDay, error) {
+		if d, ok := rows[articleID]; ok {
+			return d, nil
+		}
+		d, err := revenue.NewArticleDay(from, articleID, s.currency, now)
+		if err != nil {
+			return nil, err
+		}
+		rows[articleID] = d
+		return d, nil
+	}
+
+	for articleID, n := range views {
+		d, err := row(articleID)
+		if err != nil {
+			return nil, err
+		}
+		d.Pageviews += n
+	}
+	for _, line := range adLines {
+		d, err := row(line.ArticleID)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.AddAds(line); err != nil {
+			return nil, fmt.Errorf("ad revenue for article %s: %w", line.ArticleID, err)
+		}
+	}
+	for _, c := range conversions {
+		credits, err := revenue.Attribute(c)
+		if err != nil {
+			return nil, fmt.Errorf("conversion %s: %w", c.SubscriptionID, err)
+		}
+		if len(credits) == 0 {
+			total, err := result.Unattributed.Add(c.Value)
+			if err != nil {
+				return nil, fmt.Errorf("conversion %s: %w", c.SubscriptionID, err)
+			}
+			result.Unattributed = total
+			continue
+		}
+		for _, credit := range credits {
+			d, err := row(credit.ArticleID)
+			if err != nil {
+				return nil, err
+			}
+			if err := d.AddCredit(credit); err != nil {
+				return nil, fmt.Errorf("conversion %s: %w", c.SubscriptionID, err)
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	if len(ids) > 0 {
+		infos, err := s.articles.Describe(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load articles: %w", err)
+		}
+		for id, info := range infos {
+			if d, ok := rows[id]; ok {
+				d.AuthorID, d.Desk = info.AuthorID, info.Desk
+			}
+		}
+	}
+
+	sorted := make([]*revenue.ArticleDay, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, rows[id])
+	}
+	if err := s.days.ReplaceDay(ctx, from, sorted); err != nil {
+		return nil, fmt.Errorf("failed to save revenue: %w", err)
+	}
+	result.Articles = len(sorted)
+	return result, nil
+}
+
+// Report rolls up the stored days in [from, to) and returns the top lines
+func (s *Service) Report(ctx context.Context, by revenue.Dimension, from, to time.Time, limit int) (*Report, error) {
+	if !by.Valid() {
+		return nil, revenue.ErrInvalidDimension
+	}
+	r, err := analytics.NewTimeRange(revenue.TruncateDay(from), revenue.TruncateDay(to))
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > MaxReportLimit {
+		limit = DefaultReportLimit
+	}
+
+	days, err := s.days.FindRange(ctx, r.From(), r.To())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load revenue: %w", err)
+	}
+	lines, total, err := revenue.Rollup(days, by, s.currency)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) > limit {
+		lines = lines[:limit]
+	}
+	return &Report{By: by, From: r.From(), To: r.To(), Currency: s.currency, Lines: lines, Total: total}, nil
+}
@@ -0,0 +1,211 @@
+package revenue
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/revenue"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type fakeSources struct {
+	views       map[string]int64
+	ads         []revenue.AdLine
+	conversions []revenue.Conversion
+	articles    map[string]revenue.ArticleInfo
+	failOn      time.Time
+	asked       []time.Time
+}
+
+func (f *fakeSources) Pageviews(ctx context.Context, from, to time.Time) (map[string]int64, error) {
+	f.asked = append(f.asked, from)
+	if from.Equal(f.failOn) {
+		return nil, errors.New("warehouse unavailable")
+	}
+	return f.views, nil
+}
+
+func (f *fakeSources) AdRevenue(ctx context.Context, from, to time.Time) ([]revenue.AdLine, error) {
+	return f.ads, nil
+}
+
+func (f *fakeSources) Conversions(ctx context.Context, from, to time.Time) ([]revenue.Conversion, error) {
+	return f.conversions, nil
+}
+
+func (f *fakeSources) Describe(ctx context.Context, ids []string) (map[string]revenue.ArticleInfo, error) {
+	infos := make(map[string]revenue.ArticleInfo)
+	for _, id := range ids {
+		if info, ok := f.articles[id]; ok {
+			infos[id] = info
+		}
+	}
+	return infos, nil
+}
+
+type memoryDays struct {
+	days map[time.Time][]*revenue.ArticleDay
+}
+
+func (m *memoryDays) ReplaceDay(ctx context.Context, day time.Time, rows []*revenue.ArticleDay) error {
+	m.days[day] = rows
+	return nil
+}
+
+func (m *memoryDays) FindRange(ctx context.Context, from, to time.Time) ([]*revenue.ArticleDay, error) {
+	var rows []*revenue.ArticleDay
+	for day, dayRows := range m.days {
+		if !day.Before(from) && day.Before(to) {
+			rows = append(rows, dayRows...)
+		}
+	}
+	return rows, nil
+}
+
+func idr(t *testing.T, amount int64) payment.Money {
+	t.Helper()
+	m, err := payment.NewMoney(amount, "IDR")
+	if err != nil {
+		t.Fatalf("failed to create money: %v", err)
+	}
+	return *m
+}
+
+func newTestService(sources *fakeSources, days *memoryDays) *Service {
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 12, 2, 0, 0, 0, time.UTC))
+	return NewService(sources, sources, sources, sources, days, "IDR", clock)
+}
+
+func TestService_AggregateDay(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	sources := &fakeSources{
+		views: map[string]int64{"a": 1000, "b": 3000, "c": 50},
+		ads: []revenue.AdLine{
+			{ArticleID: "a", Impressions: 2000, Revenue: idr(t, 4000)},
+			{ArticleID: "b", Impressions: 6000, Revenue: idr(t, 9000)},
+		},
+		conversions: []revenue.Conversion{
+			{SubscriptionID: "sub-1", Value: idr(t, 50000), ConvertedAt: day.Add(10 * time.Hour), Touches: []revenue.Touch{
+				{ArticleID: "a", At: day.Add(9 * time.Hour)},
+				{ArticleID: "old", At: day.AddDate(0, 0, -5)},
+			}},
+			{SubscriptionID: "sub-2", Value: idr(t, 30000), ConvertedAt: day.Add(11 * time.Hour)},
+		},
+		articles: map[string]revenue.ArticleInfo{
+			"a":   {AuthorID: "alice", Desk: "politics"},
+			"b":   {AuthorID: "bob", Desk: "sports"},
+			"old": {AuthorID: "alice", Desk: "politics"},
+		},
+	}
+	days := &memoryDays{days: map[time.Time][]*revenue.ArticleDay{}}
+	service := newTestService(sources, days)
+
+	result, err := service.AggregateDay(ctx, day.Add(15*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Day.Equal(day) || result.Articles != 4 || result.Unattributed.Amount() != 30000 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	rows := days.days[day]
+	if len(rows) != 4 {
+		t.Fatalf("expected four rows, got %d", len(rows))
+	}
+	a := rows[0]
+	if a.ArticleID != "a" || a.AuthorID != "alice" || a.Desk != "politics" || a.Pageviews != 1000 || a.AdImpressions != 2000 {
+		t.Errorf("unexpected row for a: %+v", a)
+	}
+	if a.AdRevenue.Amount() != 4000 || a.SubscriptionRevenue.Amount() != 25000 || a.Conversions != 0.5 {
+		t.Errorf("expected a to earn ads and half the conversion, got %+v", a)
+	}
+	if rows[3].ArticleID != "old" || rows[3].Pageviews != 0 || rows[3].SubscriptionRevenue.Amount() != 25000 {
+		t.Errorf("expected an older article to earn credit without views that day, got %+v", rows[3])
+	}
+	if rows[2].ArticleID != "c" || rows[2].AuthorID != "" {
+		t.Errorf("expected unknown article to keep an empty author, got %+v", rows[2])
+	}
+
+	// Running the day again replaces it rather than adding to it
+	if _, err := service.AggregateDay(ctx, day); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(days.days[day]) != 4 || days.days[day][0].Pageviews != 1000 {
+		t.Errorf("expected rerun to be idempotent, got %+v", days.days[day])
+	}
+}
+
+func TestService_AggregateDay_CurrencyMismatch(t *testing.T) {
+	usd, _ := payment.NewMoney(100, "USD")
+	sources := &fakeSources{ads: []revenue.AdLine{{ArticleID: "a", Impressions: 10, Revenue: *usd}}}
+	days := &memoryDays{days: map[time.Time][]*revenue.ArticleDay{}}
+	service := newTestService(sources, days)
+
+	_, err := service.AggregateDay(context.Background(), time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC))
+	if !errors.Is(err, payment.ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if len(days.days) != 0 {
+		t.Error("expected nothing to be saved")
+	}
+}
+
+func TestService_RunDaily(t *testing.T) {
+	at := time.Date(2026, time.March, 12, 2, 0, 0, 0, time.UTC)
+	sources := &fakeSources{views: map[string]int64{"a": 10}, failOn: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)}
+	days := &memoryDays{days: map[time.Time][]*revenue.ArticleDay{}}
+	service := newTestService(sources, days)
+
+	results, err := service.RunDaily(context.Background(), at)
+	if err == nil || !strings.Contains(err.Error(), "2026-03-10") {
+		t.Fatalf("expected the failing day in the error, got %v", err)
+	}
+	if len(sources.asked) != RestateDays || !sources.asked[0].Equal(time.Date(2026, time.March, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the last %d finished days oldest first, got %v", RestateDays, sources.asked)
+	}
+	if len(results) != 2 || len(days.days) != 2 {
+		t.Errorf("expected the other days to be aggregated, got %+v", results)
+	}
+}
+
+func TestService_Report(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	days := &memoryDays{days: map[time.Time][]*revenue.ArticleDay{}}
+	for i, id := range []string{"a", "b", "c"} {
+		d, _ := revenue.NewArticleDay(day.AddDate(0, 0, i), id, "IDR", day)
+		d.AuthorID, d.Desk, d.Pageviews = "alice", "politics", 100
+		_ = d.AddAds(revenue.AdLine{ArticleID: id, Impressions: 100, Revenue: idr(t, int64(1000*(i+1)))})
+		days.days[d.Day] = []*revenue.ArticleDay{d}
+	}
+	service := newTestService(&fakeSources{}, days)
+
+	report, err := service.Report(ctx, revenue.DimensionArticle, day, day.AddDate(0, 0, 2), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Lines) != 1 || report.Lines[0].Key != "b" || report.Total.Total.Amount() != 3000 || report.Currency != "IDR" {
+		t.Errorf("expected the limit to apply to lines but not the total, got %+v", report)
+	}
+
+	report, err = service.Report(ctx, revenue.DimensionDesk, day, day.AddDate(0, 0, 3), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Lines) != 1 || report.Lines[0].Articles != 3 || report.Lines[0].Total.Amount() != 6000 {
+		t.Errorf("unexpected desk report %+v", report.Lines)
+	}
+
+	if _, err := service.Report(ctx, revenue.Dimension("section"), day, day.AddDate(0, 0, 1), 0); err != revenue.ErrInvalidDimension {
+		t.Errorf("expected ErrInvalidDimension, got %v", err)
+	}
+	if _, err := service.Report(ctx, revenue.DimensionDesk, day, day, 0); err != analytics.ErrInvalidRange {
+		t.Errorf("expected ErrInvalidRange, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package sanction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/sanction"
+)
+
+var ErrAccountNotFound = errors.New("account not found")
+
+// SystemActorID is recorded as the actor when the scheduler lifts a suspension
+const SystemActorID = "system"
+
+// Entitlements drops an account's cached access after a suspension or block
+// changes it, the entitlement service implements it
+type Entitlements interface {
+	Invalidate(ctx context.Context, accountID string) error
+}
+
+type Service struct {
+	violations   sanction.ViolationRepository
+	ladders      sanction.LadderRepository
+	accounts     account.UserAccountRepository
+	entitlements Entitlements
+}
+
+func NewService(violations sanction.ViolationRepository, ladders sanction.LadderRepository, accounts account.UserAccountRepository, entitlements Entitlements) *Service {
+	return &Service{violations: violations, ladders: ladders, accounts: accounts, entitlements: entitlements}
+}
+
+// ConfigureLadder overrides the default ladder for a category
+func (s *Service) ConfigureLadder(ctx context.Context, category string, steps []sanction.Step, lookback time.Duration) (*sanction.Ladder, error) {
+	categoryObj, err := sanction.NewCategory(category)
+	if err != nil {
+		return nil, err
+	}
+
+	ladder, err := sanction.NewLadder(*categoryObj, steps, lookback)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ladders.Save(ctx, ladder); err != nil {
+		return nil, fmt.Errorf("failed to save ladder: %w", err)
+	}
+	return ladder, nil
+}
+
+// RecordViolation records a confirmed violation and applies the next step of the category ladder
+func (s *Service) RecordViolation(ctx context.Context, accountID, category, reportedBy, description string) (*sanction.Violation, sanction.Step, error) {
+	categoryObj, err := sanction.NewCategory(category)
+	if err != nil {
+		return nil, sanction.Step{}, err
+	}
+
+	acc, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, sanction.Step{}, fmt.Errorf("failed to load account: %w", err)
+	}
+	if acc == nil {
+		return nil, sanction.Step{}, ErrAccountNotFound
+	}
+
+	ladder, err := s.ladders.FindByCategory(ctx, *categoryObj)
+	if err != nil {
+		return nil, sanction.Step{}, fmt.Errorf("failed to load ladder: %w", err)
+	}
+	if ladder == nil {
+		ladder = sanction.DefaultLadder(*categoryObj)
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, sanction.Step{}, err
+	}
+
+	violation, err := sanction.NewViolation(id, acc.ID, *categoryObj, reportedBy, description)
+	if err != nil {
+		return nil, sanction.Step{}, err
+	}
+
+	var since time.Time
+	if ladder.Lookback() > 0 {
+		since = violation.CreatedAt.Add(-ladder.Lookback())
+	}
+	history, err := s.violations.FindByAccountID(ctx, acc.ID, *categoryObj, since)
+	if err != nil {
+		return nil, sanction.Step{}, fmt.Errorf("failed to load violation history: %w", err)
+	}
+
+	step, err := violation.Escalate(acc, *ladder, history)
+	if err != nil {
+		return nil, sanction.Step{}, err
+	}
+
+	if err := s.violations.Create(ctx, violation); err != nil {
+		return nil, sanction.Step{}, fmt.Errorf("failed to save violation: %w", err)
+	}
+	if step.Action != sanction.ActionWarning {
+		if err := s.accounts.Update(ctx, acc); err != nil {
+			return nil, sanction.Step{}, fmt.Errorf("failed to update account: %w", err)
+		}
+		_ = s.entitlements.Invalidate(ctx, acc.ID)
+	}
+	return violation, step, nil
+}
+
+// ExpireSuspensions reactivates accounts whose timed suspension has elapsed.
+// Run periodically by the scheduler; returns the number of accounts reactivated.
+func (s *Service) ExpireSuspensions(ctx context.Context, at time.Time) (int, error) {
+	accounts, err := s.accounts.FindElapsedSuspensions(ctx, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load elapsed suspensions: %w", err)
+	}
+
+	expired := 0
+	for _, acc := range accounts {
+		if !acc.IsSuspensionElapsed(at) {
+			continue
+		}
+		if err := acc.ExpireSuspension(SystemActorID, at); err != nil {
+			return expired, fmt.Errorf("failed to expire suspension of %s: %w", acc.ID, err)
+		}
+		if err := s.accounts.Update(ctx, acc); err != nil {
+			return expired, fmt.Errorf("failed to update account %s: %w", acc.ID, err)
+		}
+		_ = s.entitlements.Invalidate(ctx, acc.ID)
+		expired++
+	}
+	return expired, nil
+}
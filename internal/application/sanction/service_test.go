@@ -0,0 +1,201 @@
+package sanction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/sanction"
+)
+
+type fakeViolationRepo struct {
+	violations []*sanction.Violation
+}
+
+func (f *fakeViolationRepo) Create(ctx context.Context, v *sanction.Violation) error {
+	f.violations = append(f.violations, v)
+	return nil
+}
+
+func (f *fakeViolationRepo) FindByID(ctx context.Context, id string) (*sanction.Violation, error) {
+	for _, v := range f.violations {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeViolationRepo) FindByAccountID(ctx context.Context, accountID string, category sanction.Category, since time.Time) ([]*sanction.Violation, error) {
+	var result []*sanction.Violation
+	for _, v := range f.violations {
+		if v.AccountID == accountID && v.Category.Equals(category) && !v.CreatedAt.Before(since) {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+type fakeLadderRepo struct {
+	ladders map[string]*sanction.Ladder
+}
+
+func (f *fakeLadderRepo) Save(ctx context.Context, ladder *sanction.Ladder) error {
+	f.ladders[ladder.Category().String()] = ladder
+	return nil
+}
+
+func (f *fakeLadderRepo) FindByCategory(ctx context.Context, category sanction.Category) (*sanction.Ladder, error) {
+	return f.ladders[category.String()], nil
+}
+
+type fakeAccountRepo struct {
+	account.UserAccountRepository
+	accounts map[string]*account.UserAccount
+	updated  int
+}
+
+func (f *fakeAccountRepo) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return f.accounts[id], nil
+}
+
+func (f *fakeAccountRepo) Update(ctx context.Context, acc *account.UserAccount) error {
+	f.updated++
+	return nil
+}
+
+func (f *fakeAccountRepo) FindElapsedSuspensions(ctx context.Context, at time.Time) ([]*account.UserAccount, error) {
+	var result []*account.UserAccount
+	for _, acc := range f.accounts {
+		if acc.IsSuspensionElapsed(at) {
+			result = append(result, acc)
+		}
+	}
+	return result, nil
+}
+
+// fakeEntitlements records the accounts whose cached access was dropped
+type fakeEntitlements struct {
+	invalidated []string
+}
+
+func (f *fakeEntitlements) Invalidate(ctx context.Context, accountID string) error {
+	f.invalidated = append(f.invalidated, accountID)
+	return nil
+}
+
+func TestService_RecordViolationEscalates(t *testing.T) {
+	member := createTestMember(t, "member1")
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{member.ID: member}}
+	entitlements := &fakeEntitlements{}
+	service := NewService(&fakeViolationRepo{}, &fakeLadderRepo{ladders: map[string]*sanction.Ladder{}}, accounts, entitlements)
+	ctx := context.Background()
+
+	_, step, err := service.RecordViolation(ctx, member.ID, "spam", "mod1", "Link spam")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if step.Action != sanction.ActionWarning {
+		t.Errorf("expected warning, got %s", step.Action)
+	}
+	if accounts.updated != 0 {
+		t.Errorf("expected warning not to update the account, got %d updates", accounts.updated)
+	}
+
+	v, step, err := service.RecordViolation(ctx, member.ID, "spam", "mod1", "Link spam again")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if step.Action != sanction.ActionSuspend || step.Duration != 7*24*time.Hour {
+		t.Errorf("expected 7-day suspension, got %+v", step)
+	}
+	if !member.IsSuspended() || v.SuspendedUntil == nil {
+		t.Error("expected account to be suspended")
+	}
+	if accounts.updated != 1 {
+		t.Errorf("expected account to be saved once, got %d", accounts.updated)
+	}
+	if len(entitlements.invalidated) != 1 || entitlements.invalidated[0] != member.ID {
+		t.Errorf("expected only the suspension to invalidate entitlements, got %v", entitlements.invalidated)
+	}
+
+	if _, _, err := service.RecordViolation(ctx, "missing", "spam", "mod1", "x"); err != ErrAccountNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrAccountNotFound, err)
+	}
+	if _, _, err := service.RecordViolation(ctx, member.ID, "Bad Category", "mod1", "x"); err != sanction.ErrInvalidCategory {
+		t.Errorf("expected error '%v', got '%v'", sanction.ErrInvalidCategory, err)
+	}
+}
+
+func TestService_ConfiguredLadder(t *testing.T) {
+	member := createTestMember(t, "member1")
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{member.ID: member}}
+	service := NewService(&fakeViolationRepo{}, &fakeLadderRepo{ladders: map[string]*sanction.Ladder{}}, accounts, &fakeEntitlements{})
+	ctx := context.Background()
+
+	if _, err := service.ConfigureLadder(ctx, "doxxing", []sanction.Step{{Action: sanction.ActionBlock}}, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, step, err := service.RecordViolation(ctx, member.ID, "doxxing", "mod1", "Posted a home address")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if step.Action != sanction.ActionBlock || !member.IsBlocked() {
+		t.Errorf("expected immediate block, got %s", step.Action)
+	}
+
+	if _, err := service.ConfigureLadder(ctx, "doxxing", nil, 0); err != sanction.ErrEmptyLadder {
+		t.Errorf("expected error '%v', got '%v'", sanction.ErrEmptyLadder, err)
+	}
+}
+
+func TestService_ExpireSuspensions(t *testing.T) {
+	elapsed := createTestMember(t, "member1")
+	running := createTestMember(t, "member2")
+	indefinite := createTestMember(t, "member3")
+
+	_ = elapsed.SuspendFor("mod1", "spam", time.Hour)
+	_ = running.SuspendFor("mod1", "spam", 48*time.Hour)
+	_ = indefinite.Suspend("mod1", "spam")
+
+	past := time.Now().Add(-time.Minute)
+	elapsed.SuspendedUntil = &past
+
+	accounts := &fakeAccountRepo{accounts: map[string]*account.UserAccount{
+		elapsed.ID: elapsed, running.ID: running, indefinite.ID: indefinite,
+	}}
+	service := NewService(&fakeViolationRepo{}, &fakeLadderRepo{ladders: map[string]*sanction.Ladder{}}, accounts, &fakeEntitlements{})
+
+	count, err := service.ExpireSuspensions(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 suspension expired, got %d", count)
+	}
+	if !elapsed.IsActive() {
+		t.Error("expected elapsed suspension to be lifted")
+	}
+	if !running.IsSuspended() || !indefinite.IsSuspended() {
+		t.Error("expected other suspensions to stay in place")
+	}
+
+	// A late or backfilled run judges suspensions at the time it is given
+	count, err = service.ExpireSuspensions(context.Background(), time.Now().Add(72*time.Hour))
+	if err != nil || count != 1 || !running.IsActive() {
+		t.Errorf("expected the running suspension expired at the given time, got %d, %v", count, err)
+	}
+}
+
+func createTestMember(t *testing.T, id string) *account.UserAccount {
+	acc, err := account.NewUserAccountForTesting(id, "test_"+id, id+"@example.com", "TestPassword123!", account.TypeMembership, "admin123")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+	return acc
+}
@@ -0,0 +1,201 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+const (
+	DefaultLanguage = "id"
+	DefaultResults  = 10
+	MaxResults      = 50
+	// BlendBelow is the result count under which a corrected query also runs,
+	// its results fill the page after the original ones
+	BlendBelow = 3
+	// LanguageDataTTL bounds how long vocabulary and synonym changes take to reach every instance
+	LanguageDataTTL = 5 * time.Minute
+)
+
+// Hit is one article in reader search results
+type Hit struct {
+	ID          string
+	Slug        string
+	Title       string
+	Summary     string
+	PublishedAt time.Time
+	Score       float64
+}
+
+// ArticleQuery is a query after synonym expansion. The backend matches each
+// term or any of its synonyms.
+type ArticleQuery struct {
+	Language string
+	Terms    []search.ExpandedTerm
+	Limit    int
+	Offset   int
+}
+
+// ArticleSearcher runs reader queries against the articles alias
+type ArticleSearcher interface {
+	SearchArticles(ctx context.Context, query ArticleQuery) (hits []Hit, total int, err error)
+}
+
+// VocabularySource reads a language's words and how many articles use each from the index
+type VocabularySource interface {
+	TermFrequencies(ctx context.Context, language string) (map[string]int, error)
+}
+
+// Results is one page of reader search. CorrectedQuery is set when the page
+// shows results for a corrected query because the original found nothing;
+// DidYouMean is set when corrected results were added below a few original ones.
+type Results struct {
+	Query          string
+	CorrectedQuery string
+	DidYouMean     string
+	Hits           []Hit
+	Total          int
+}
+
+type languageData struct {
+	vocabulary *search.Vocabulary
+	synonyms   *search.SynonymIndex
+	fetchedAt  time.Time
+}
+
+// QueryService answers reader search. Queries are expanded with editor
+// synonyms; a query that finds little on its first page is spell-corrected
+// against the language's vocabulary, so misspellings still find articles.
+type QueryService struct {
+	searcher   ArticleSearcher
+	vocabulary VocabularySource
+	synonyms   search.SynonymRepository
+	clock      shared.Clock
+
+	mu    sync.Mutex
+	cache map[string]languageData
+}
+
+func NewQueryService(searcher ArticleSearcher, vocabulary VocabularySource, synonyms search.SynonymRepository, clock shared.Clock) *QueryService {
+	return &QueryService{searcher: searcher, vocabulary: vocabulary, synonyms: synonyms, clock: clock, cache: map[string]languageData{}}
+}
+
+// Search returns one page of results. Correction only applies to the first
+// page, later pages are requested with the corrected query.
+func (s *QueryService) Search(ctx context.Context, language, query string, limit, offset int) (*Results, error) {
+	if language == "" {
+		language = DefaultLanguage
+	}
+	if limit <= 0 {
+		limit = DefaultResults
+	}
+	limit = min(limit, MaxResults)
+	offset = max(offset, 0)
+
+	words := search.Tokenize(query)
+	results := &Results{Query: strings.Join(words, " "), Hits: []Hit{}}
+	if len(words) == 0 {
+		return results, nil
+	}
+
+	data := s.languageData(ctx, language)
+	hits, total, err := s.run(ctx, data, language, words, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	results.Hits, results.Total = hits, total
+	if offset > 0 || total >= BlendBelow || data.vocabulary == nil {
+		return results, nil
+	}
+
+	corrected, changed := data.vocabulary.CorrectTerms(words)
+	if !changed {
+		return results, nil
+	}
+	correctedHits, correctedTotal, err := s.run(ctx, data, language, corrected, limit, 0)
+	if err != nil {
+		// The original results still stand
+		return results, nil
+	}
+	correctedQuery := strings.Join(corrected, " ")
+	if total == 0 {
+		results.CorrectedQuery = correctedQuery
+		results.Hits, results.Total = correctedHits, correctedTotal
+		return results, nil
+	}
+	if correctedTotal > 0 {
+		results.DidYouMean = correctedQuery
+		results.Hits = blend(hits, correctedHits, limit)
+		results.Total = len(results.Hits)
+	}
+	return results, nil
+}
+
+func (s *QueryService) run(ctx context.Context, data languageData, language string, words []string, limit, offset int) ([]Hit, int, error) {
+	var terms []search.ExpandedTerm
+	if data.synonyms != nil {
+		terms = data.synonyms.Expand(words)
+	} else {
+		for _, w := range words {
+			terms = append(terms, search.ExpandedTerm{Text: w})
+		}
+	}
+	hits, total, err := s.searcher.SearchArticles(ctx, ArticleQuery{Language: language, Terms: terms, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search articles: %w", err)
+	}
+	if hits == nil {
+		hits = []Hit{}
+	}
+	return hits, total, nil
+}
+
+// languageData returns the cached vocabulary and synonyms. Either may be nil
+// when it could not be loaded and nothing was cached, search then runs without it.
+func (s *QueryService) languageData(ctx context.Context, language string) languageData {
+	s.mu.Lock()
+	cached, ok := s.cache[language]
+	s.mu.Unlock()
+	now := s.clock.Now()
+	if ok && now.Sub(cached.fetchedAt) < LanguageDataTTL {
+		return cached
+	}
+
+	data := languageData{vocabulary: cached.vocabulary, synonyms: cached.synonyms, fetchedAt: now}
+	if frequencies, err := s.vocabulary.TermFrequencies(ctx, language); err == nil {
+		data.vocabulary = search.NewVocabulary(frequencies)
+	}
+	if sets, err := s.synonyms.FindByLanguage(ctx, language); err == nil {
+		data.synonyms = search.NewSynonymIndex(sets)
+	}
+
+	s.mu.Lock()
+	s.cache[language] = data
+	s.mu.Unlock()
+	return data
+}
+
+// blend keeps the original hits first and fills the page with corrected ones not already shown
+func blend(original, corrected []Hit, limit int) []Hit {
+	seen := make(map[string]bool, len(original))
+	hits := make([]Hit, 0, limit)
+	for _, h := range original {
+		seen[h.ID] = true
+		hits = append(hits, h)
+	}
+	for _, h := range corrected {
+		if len(hits) >= limit {
+			break
+		}
+		if !seen[h.ID] {
+			seen[h.ID] = true
+			hits = append(hits, h)
+		}
+	}
+	return hits
+}
@@ -0,0 +1,186 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type memorySynonyms struct {
+	byID map[string]*search.SynonymSet
+}
+
+func (m *memorySynonyms) Create(ctx context.Context, set *search.SynonymSet) error {
+	m.byID[set.ID] = set
+	return nil
+}
+
+func (m *memorySynonyms) Update(ctx context.Context, set *search.SynonymSet) error {
+	m.byID[set.ID] = set
+	return nil
+}
+
+func (m *memorySynonyms) Delete(ctx context.Context, id string) error {
+	delete(m.byID, id)
+	return nil
+}
+
+func (m *memorySynonyms) FindByID(ctx context.Context, id string) (*search.SynonymSet, error) {
+	return m.byID[id], nil
+}
+
+func (m *memorySynonyms) FindByLanguage(ctx context.Context, language string) ([]*search.SynonymSet, error) {
+	var sets []*search.SynonymSet
+	for _, set := range m.byID {
+		if set.Language == language {
+			sets = append(sets, set)
+		}
+	}
+	return sets, nil
+}
+
+// fakeSearcher matches articles containing every term or one of its synonyms
+type fakeSearcher struct {
+	articles []Hit // Title holds the text matched against
+	queries  []ArticleQuery
+}
+
+func (f *fakeSearcher) SearchArticles(ctx context.Context, query ArticleQuery) ([]Hit, int, error) {
+	f.queries = append(f.queries, query)
+	var hits []Hit
+	for _, a := range f.articles {
+		text := " " + strings.ToLower(a.Title) + " "
+		matches := true
+		for _, term := range query.Terms {
+			found := strings.Contains(text, " "+term.Text+" ")
+			for _, synonym := range term.Synonyms {
+				found = found || strings.Contains(text, " "+synonym+" ")
+			}
+			matches = matches && found
+		}
+		if matches {
+			hits = append(hits, a)
+		}
+	}
+	total := len(hits)
+	hits = hits[min(query.Offset, total):min(query.Offset+query.Limit, total)]
+	return hits, total, nil
+}
+
+type fakeVocabulary struct {
+	frequencies map[string]int
+	err         error
+	calls       int
+}
+
+func (f *fakeVocabulary) TermFrequencies(ctx context.Context, language string) (map[string]int, error) {
+	f.calls++
+	return f.frequencies, f.err
+}
+
+func newTestQueryService(t *testing.T) (*QueryService, *fakeSearcher, *fakeVocabulary, *memorySynonyms, *shared.FrozenClock) {
+	t.Helper()
+	searcher := &fakeSearcher{articles: []Hit{
+		{ID: "a1", Title: "Banjir rendam Jakarta Utara"},
+		{ID: "a2", Title: "Banjir surut di Bekasi"},
+		{ID: "a3", Title: "KPU tetapkan jadwal pemilu"},
+		{ID: "a4", Title: "Banjar Baru gelar festival"},
+		{ID: "a5", Title: "Banjir kiriman dari Bogor"},
+	}}
+	vocabulary := &fakeVocabulary{frequencies: map[string]int{
+		"banjir": 90, "banjar": 2, "jakarta": 400, "bekasi": 40, "kpu": 60, "jadwal": 30, "pemilu": 120, "festival": 20,
+	}}
+	synonyms := &memorySynonyms{byID: map[string]*search.SynonymSet{}}
+	kpu, _ := search.NewSynonymSet("s1", "id", []string{"kpu", "komisi pemilihan umum"}, "editor-1", time.Now())
+	synonyms.byID[kpu.ID] = kpu
+
+	clock := shared.NewFrozenClock(time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC))
+	return NewQueryService(searcher, vocabulary, synonyms, clock), searcher, vocabulary, synonyms, clock
+}
+
+func hitIDs(hits []Hit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ID)
+	}
+	return ids
+}
+
+func TestQueryService_Search(t *testing.T) {
+	service, _, _, _, _ := newTestQueryService(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		query          string
+		wantHits       []string
+		wantCorrected  string
+		wantDidYouMean string
+	}{
+		{"exact match", "banjir", []string{"a1", "a2", "a5"}, "", ""},
+		{"synonym phrase", "Komisi Pemilihan Umum", []string{"a3"}, "", ""},
+		{"misspelling with no results", "bnajir jakarta", []string{"a1"}, "banjir jakarta", ""},
+		{"closest frequent word", "banjor", []string{"a1", "a2", "a5"}, "banjir", ""},
+		{"rare word blended", "banjar", []string{"a4", "a1", "a2", "a5"}, "", "banjir"},
+		{"nothing to correct", "gempa", []string{}, "", ""},
+		{"empty query", "  ?! ", []string{}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := service.Search(ctx, "id", tt.query, 10, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ids := hitIDs(results.Hits); !slices.Equal(ids, tt.wantHits) {
+				t.Errorf("expected hits %v, got %v", tt.wantHits, ids)
+			}
+			if results.CorrectedQuery != tt.wantCorrected || results.DidYouMean != tt.wantDidYouMean {
+				t.Errorf("expected corrected %q and did you mean %q, got %q and %q", tt.wantCorrected, tt.wantDidYouMean, results.CorrectedQuery, results.DidYouMean)
+			}
+		})
+	}
+}
+
+func TestQueryService_LaterPagesAreNotCorrected(t *testing.T) {
+	service, searcher, _, _, _ := newTestQueryService(t)
+
+	results, err := service.Search(context.Background(), "id", "bnajir", 10, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.CorrectedQuery != "" || len(searcher.queries) != 1 {
+		t.Errorf("expected one uncorrected query, got %q after %d queries", results.CorrectedQuery, len(searcher.queries))
+	}
+}
+
+func TestQueryService_CachesLanguageData(t *testing.T) {
+	service, _, vocabulary, synonyms, clock := newTestQueryService(t)
+	ctx := context.Background()
+
+	_, _ = service.Search(ctx, "id", "banjir", 10, 0)
+	vote, _ := search.NewSynonymSet("s2", "id", []string{"pemilu", "coblosan"}, "editor-1", clock.Now())
+	synonyms.byID[vote.ID] = vote
+
+	results, _ := service.Search(ctx, "id", "coblosan", 10, 0)
+	if vocabulary.calls != 1 || len(results.Hits) != 0 {
+		t.Fatalf("expected cached language data, got %d loads and hits %v", vocabulary.calls, hitIDs(results.Hits))
+	}
+
+	// An outage after the TTL keeps the cached vocabulary, new synonyms still load
+	vocabulary.err = errors.New("index unavailable")
+	clock.Advance(LanguageDataTTL)
+	results, _ = service.Search(ctx, "id", "coblosan", 10, 0)
+	if !slices.Equal(hitIDs(results.Hits), []string{"a3"}) {
+		t.Errorf("expected the new synonym applied, got %v", hitIDs(results.Hits))
+	}
+	if results, _ = service.Search(ctx, "id", "bnajir", 10, 0); results.CorrectedQuery != "banjir" {
+		t.Errorf("expected the stale vocabulary still used, got %q", results.CorrectedQuery)
+	}
+}
@@ -0,0 +1,328 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// ArticlesAlias is the alias public search queries
+const ArticlesAlias = "articles"
+
+const (
+	BatchSize       = 500  // Articles per bulk write
+	Concurrency     = 4    // Bulk writes in flight
+	SampleSize      = 200  // Articles compared between the database and the new index
+	MaxFailureRatio = 0.01 // Rejected share above which the new index is discarded
+)
+
+var (
+	ErrRunNotFound    = errors.New("reindex run not found")
+	ErrTooManyRejects = errors.New("search backend rejected too many articles")
+	ErrInconsistent   = errors.New("new index does not match the database")
+)
+
+// Document is one published article as the search backend stores it
+type Document struct {
+	ID          string
+	Slug        string
+	Title       string
+	Summary     string
+	Body        string
+	Authors     []string
+	Tags        []string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+	Revision    string // Changes whenever the article does, compared during verification
+}
+
+// ArticleSource reads published articles from the database
+type ArticleSource interface {
+	CountPublished(ctx context.Context) (int, error)
+	// ListPublished pages by ID after afterID, only articles changed at or after since unless it is zero
+	ListPublished(ctx context.Context, since time.Time, afterID string, limit int) ([]Document, error)
+	// ListUnpublishedSince returns IDs of articles unpublished or deleted at or after since
+	ListUnpublishedSince(ctx context.Context, since time.Time) ([]string, error)
+	// SamplePublished returns up to n published articles picked at random
+	SamplePublished(ctx context.Context, n int) ([]Document, error)
+}
+
+// SearchIndex is the search backend (implementation will be in infrastructure layer)
+type SearchIndex interface {
+	CreateIndex(ctx context.Context, name string) error
+	DeleteIndex(ctx context.Context, name string) error
+	// AliasTarget returns "" when the alias does not exist yet
+	AliasTarget(ctx context.Context, alias string) (string, error)
+	// SwapAlias points alias at index in one atomic step
+	SwapAlias(ctx context.Context, alias, index string) error
+	// BulkIndex writes documents and returns the IDs the backend rejected
+	BulkIndex(ctx context.Context, index string, docs []Document) ([]string, error)
+	Delete(ctx context.Context, index string, ids []string) error
+	// Revisions returns the stored revision per ID, missing documents are left out
+	Revisions(ctx context.Context, index string, ids []string) (map[string]string, error)
+}
+
+type ReindexService struct {
+	runs     search.ReindexRunRepository
+	articles ArticleSource
+	index    SearchIndex
+	clock    shared.Clock
+
+	batchSize   int
+	concurrency int
+	sampleSize  int
+}
+
+func NewReindexService(runs search.ReindexRunRepository, articles ArticleSource, index SearchIndex, clock shared.Clock) *ReindexService {
+	return &ReindexService{
+		runs:        runs,
+		articles:    articles,
+		index:       index,
+		clock:       clock,
+		batchSize:   BatchSize,
+		concurrency: Concurrency,
+		sampleSize:  SampleSize,
+	}
+}
+
+// Request queues a rebuild of the articles index, the worker picks it up with
+// ProcessPending. Only one run per alias can be unfinished.
+func (s *ReindexService) Request(ctx context.Context, staffID string) (*search.ReindexRun, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate reindex run ID: %w", err)
+	}
+	run, err := search.NewReindexRun(id, ArticlesAlias, staffID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.runs.Create(ctx, run); err != nil {
+		if errors.Is(err, search.ErrReindexRunning) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save reindex run: %w", err)
+	}
+	return run, nil
+}
+
+func (s *ReindexService) Run(ctx context.Context, id string) (*search.ReindexRun, error) {
+	run, err := s.runs.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reindex run: %w", err)
+	}
+	if run == nil {
+		return nil, ErrRunNotFound
+	}
+	return run, nil
+}
+
+func (s *ReindexService) Recent(ctx context.Context, limit int) ([]*search.ReindexRun, error) {
+	runs, err := s.runs.FindRecent(ctx, ArticlesAlias, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reindex runs: %w", err)
+	}
+	return runs, nil
+}
+
+// ProcessPending executes the oldest pending run, nil when there is none.
+//
+// Articles are streamed into a fresh index while searches keep using the
+// alias. A catch-up pass then applies articles changed during the build, a
+// random sample is compared with the database, and only then is the alias
+// swapped and the previous index dropped. Any failure drops the new index
+// instead and leaves searches untouched.
+func (s *ReindexService) ProcessPending(ctx context.Context) (*search.ReindexRun, error) {
+	run, err := s.runs.FindPending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pending reindex run: %w", err)
+	}
+	if run == nil {
+		return nil, nil
+	}
+
+	if err := s.execute(ctx, run); err != nil {
+		run.Fail(err, s.clock.Now())
+		if run.Index != "" {
+			// Best effort, an orphaned index does not affect searches
+			_ = s.index.DeleteIndex(context.WithoutCancel(ctx), run.Index)
+		}
+		if updateErr := s.runs.Update(context.WithoutCancel(ctx), run); updateErr != nil {
+			return run, fmt.Errorf("failed to update reindex run: %w", updateErr)
+		}
+		return run, err
+	}
+
+	// The alias already moved, from here on the new index must stay
+	if err := run.Complete(s.clock.Now()); err != nil {
+		return run, err
+	}
+	if err := s.runs.Update(ctx, run); err != nil {
+		return run, fmt.Errorf("failed to update reindex run: %w", err)
+	}
+	if run.Previous != "" {
+		// Searches no longer reach it, a leftover is cleaned up by hand
+		_ = s.index.DeleteIndex(ctx, run.Previous)
+	}
+	return run, nil
+}
+
+// execute builds and verifies the new index, the alias swap is its last step
+func (s *ReindexService) execute(ctx context.Context, run *search.ReindexRun) error {
+	previous, err := s.index.AliasTarget(ctx, run.Alias)
+	if err != nil {
+		return fmt.Errorf("failed to resolve alias %s: %w", run.Alias, err)
+	}
+	total, err := s.articles.CountPublished(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count published articles: %w", err)
+	}
+	started := s.clock.Now()
+	name := search.IndexName(run.Alias, started)
+	if err := s.index.CreateIndex(ctx, name); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", name, err)
+	}
+	if err := run.Start(name, previous, total, started); err != nil {
+		return err
+	}
+	if err := s.runs.Update(ctx, run); err != nil {
+		return fmt.Errorf("failed to update reindex run: %w", err)
+	}
+
+	if err := s.stream(ctx, run, time.Time{}); err != nil {
+		return err
+	}
+	// Articles published, edited or unpublished while the full pass ran
+	caughtUp := s.clock.Now()
+	if err := s.stream(ctx, run, started); err != nil {
+		return err
+	}
+	removed, err := s.articles.ListUnpublishedSince(ctx, started)
+	if err != nil {
+		return fmt.Errorf("failed to list unpublished articles: %w", err)
+	}
+	if len(removed) > 0 {
+		if err := s.index.Delete(ctx, run.Index, removed); err != nil {
+			return fmt.Errorf("failed to remove unpublished articles: %w", err)
+		}
+	}
+	if run.FailureRatio() > MaxFailureRatio {
+		return fmt.Errorf("%w: %d of %d", ErrTooManyRejects, run.Failed, run.Indexed+run.Failed)
+	}
+
+	if err := run.StartVerifying(s.clock.Now()); err != nil {
+		return err
+	}
+	if err := s.runs.Update(ctx, run); err != nil {
+		return fmt.Errorf("failed to update reindex run: %w", err)
+	}
+	if err := s.verify(ctx, run, caughtUp); err != nil {
+		return err
+	}
+
+	if err := s.index.SwapAlias(ctx, run.Alias, run.Index); err != nil {
+		return fmt.Errorf("failed to swap alias %s: %w", run.Alias, err)
+	}
+	return nil
+}
+
+// stream pages through published articles and writes them with up to
+// concurrency bulk writes in flight. Progress is saved after every batch.
+func (s *ReindexService) stream(ctx context.Context, run *search.ReindexRun, since time.Time) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	batches := make(chan []Document)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for range s.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for docs := range batches {
+				rejected, err := s.index.BulkIndex(ctx, run.Index, docs)
+				if err != nil {
+					cancel(fmt.Errorf("failed to write batch: %w", err))
+					return
+				}
+				mu.Lock()
+				run.RecordBatch(len(docs)-len(rejected), len(rejected), s.clock.Now())
+				err = s.runs.Update(ctx, run)
+				mu.Unlock()
+				if err != nil {
+					cancel(fmt.Errorf("failed to update reindex run: %w", err))
+					return
+				}
+			}
+		}()
+	}
+
+	var readErr error
+	after := ""
+read:
+	for {
+		docs, err := s.articles.ListPublished(ctx, since, after, s.batchSize)
+		if err != nil {
+			readErr = fmt.Errorf("failed to read published articles: %w", err)
+			break
+		}
+		if len(docs) == 0 {
+			break
+		}
+		select {
+		case batches <- docs:
+		case <-ctx.Done():
+			break read
+		}
+		if len(docs) < s.batchSize {
+			break
+		}
+		after = docs[len(docs)-1].ID
+	}
+	close(batches)
+	wg.Wait()
+
+	if readErr != nil {
+		return readErr
+	}
+	return context.Cause(ctx)
+}
+
+// verify compares a random sample of the database with the new index. Articles
+// changed after the catch-up pass started are skipped, the index cannot have
+// seen them yet.
+func (s *ReindexService) verify(ctx context.Context, run *search.ReindexRun, caughtUp time.Time) error {
+	sample, err := s.articles.SamplePublished(ctx, s.sampleSize)
+	if err != nil {
+		return fmt.Errorf("failed to sample published articles: %w", err)
+	}
+	ids := make([]string, 0, len(sample))
+	for _, doc := range sample {
+		if doc.UpdatedAt.Before(caughtUp) {
+			ids = append(ids, doc.ID)
+		}
+	}
+	revisions, err := s.index.Revisions(ctx, run.Index, ids)
+	if err != nil {
+		return fmt.Errorf("failed to read sampled documents: %w", err)
+	}
+
+	mismatches := 0
+	for _, doc := range sample {
+		if !doc.UpdatedAt.Before(caughtUp) {
+			continue
+		}
+		if revision, ok := revisions[doc.ID]; !ok || revision != doc.Revision {
+			mismatches++
+		}
+	}
+	run.RecordVerification(len(ids), mismatches, s.clock.Now())
+	if mismatches > 0 {
+		return fmt.Errorf("%w: %d of %d sampled articles missing or stale", ErrInconsistent, mismatches, len(ids))
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type memoryRuns struct {
+	byID    map[string]*search.ReindexRun
+	updates int
+}
+
+func (m *memoryRuns) Create(ctx context.Context, run *search.ReindexRun) error {
+	for _, existing := range m.byID {
+		if existing.Alias == run.Alias && !existing.IsFinished() {
+			return search.ErrReindexRunning
+		}
+	}
+	m.byID[run.ID] = run
+	return nil
+}
+
+func (m *memoryRuns) Update(ctx context.Context, run *search.ReindexRun) error {
+	m.updates++
+	m.byID[run.ID] = run
+	return nil
+}
+
+func (m *memoryRuns) FindByID(ctx context.Context, id string) (*search.ReindexRun, error) {
+	return m.byID[id], nil
+}
+
+func (m *memoryRuns) FindPending(ctx context.Context) (*search.ReindexRun, error) {
+	for _, run := range m.byID {
+		if run.Status == search.StatusPending {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryRuns) FindRecent(ctx context.Context, alias string, limit int) ([]*search.ReindexRun, error) {
+	var found []*search.ReindexRun
+	for _, run := range m.byID {
+		found = append(found, run)
+	}
+	return found[:min(limit, len(found))], nil
+}
+
+type fakeSource struct {
+	docs        []Document // Sorted by ID
+	unpublished []string
+	// edited is returned by the catch-up pass, as if changed during the build
+	edited []Document
+}
+
+func (f *fakeSource) CountPublished(ctx context.Context) (int, error) {
+	return len(f.docs), nil
+}
+
+func (f *fakeSource) ListPublished(ctx context.Context, since time.Time, afterID string, limit int) ([]Document, error) {
+	if !since.IsZero() {
+		return f.edited, nil
+	}
+	i := sort.Search(len(f.docs), func(i int) bool { return f.docs[i].ID > afterID })
+	return f.docs[i:min(i+limit, len(f.docs))], nil
+}
+
+func (f *fakeSource) ListUnpublishedSince(ctx context.Context, since time.Time) ([]string, error) {
+	return f.unpublished, nil
+}
+
+func (f *fakeSource) SamplePublished(ctx context.Context, n int) ([]Document, error) {
+	current := map[string]Document{}
+	for _, doc := range f.edited {
+		current[doc.ID] = doc
+	}
+	var sample []Document
+	for _, doc := range f.docs {
+		if edited, ok := current[doc.ID]; ok {
+			doc = edited
+		}
+		if !slices.Contains(f.unpublished, doc.ID) && len(sample) < n {
+			sample = append(sample, doc)
+		}
+	}
+	return sample, nil
+}
+
+type fakeIndex struct {
+	mu       sync.Mutex
+	alias    map[string]string
+	indexes  map[string]map[string]string // index -> ID -> revision
+	reject   map[string]bool
+	bulkErr  error
+	stale    map[string]string // Revisions the backend reports instead of the written ones
+	inFlight int
+	maxBulk  int
+}
+
+func newFakeIndex() *fakeIndex {
+	return &fakeIndex{
+		alias:   map[string]string{ArticlesAlias: "articles-old"},
+		indexes: map[string]map[string]string{"articles-old": {}},
+	}
+}
+
+func (f *fakeIndex) CreateIndex(ctx context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.indexes[name] = map[string]string{}
+	return nil
+}
+
+func (f *fakeIndex) DeleteIndex(ctx context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.indexes, name)
+	return nil
+}
+
+func (f *fakeIndex) AliasTarget(ctx context.Context, alias string) (string, error) {
+	return f.alias[alias], nil
+}
+
+func (f *fakeIndex) SwapAlias(ctx context.Context, alias, index string) error {
+	f.alias[alias] = index
+	return nil
+}
+
+func (f *fakeIndex) BulkIndex(ctx context.Context, index string, docs []Document) ([]string, error) {
+	f.mu.Lock()
+	f.inFlight++
+	f.maxBulk = max(f.maxBulk, f.inFlight)
+	f.mu.Unlock()
+	time.Sleep(time.Millisecond)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inFlight--
+	if f.bulkErr != nil {
+		return nil, f.bulkErr
+	}
+	var rejected []string
+	for _, doc := range docs {
+		if f.reject[doc.ID] {
+			rejected = append(rejected, doc.ID)
+			continue
+		}
+		f.indexes[index][doc.ID] = doc.Revision
+	}
+	return rejected, nil
+}
+
+func (f *fakeIndex) Delete(ctx context.Context, index string, ids []string) error {
+	for _, id := range ids {
+		delete(f.indexes[index], id)
+	}
+	return nil
+}
+
+func (f *fakeIndex) Revisions(ctx context.Context, index string, ids []string) (map[string]string, error) {
+	revisions := map[string]string{}
+	for _, id := range ids {
+		if revision, ok := f.indexes[index][id]; ok {
+			revisions[id] = revision
+		}
+		if revision, ok := f.stale[id]; ok {
+			revisions[id] = revision
+		}
+	}
+	return revisions, nil
+}
+
+func publishedDocs(n int, at time.Time) []Document {
+	docs := make([]Document, n)
+	for i := range docs {
+		docs[i] = Document{ID: fmt.Sprintf("a%03d", i), Title: "Story", Revision: "r1", UpdatedAt: at.Add(-time.Hour)}
+	}
+	return docs
+}
+
+func newTestService(source *fakeSource, index *fakeIndex, at time.Time) (*ReindexService, *memoryRuns) {
+	runs := &memoryRuns{byID: map[string]*search.ReindexRun{}}
+	service := NewReindexService(runs, source, index, shared.NewFrozenClock(at))
+	service.batchSize = 10
+	service.sampleSize = 50
+	return service, runs
+}
+
+func TestReindexService_Rebuild(t *testing.T) {
+	at := time.Date(2025, 9, 1, 2, 0, 0, 0, time.UTC)
+	source := &fakeSource{docs: publishedDocs(95, at), unpublished: []string{"a003"}}
+	source.edited = []Document{{ID: "a007", Revision: "r2", UpdatedAt: at.Add(-time.Minute)}}
+	index := newFakeIndex()
+	service, runs := newTestService(source, index, at)
+	ctx := context.Background()
+
+	requested, err := service.Request(ctx, "staff-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Request(ctx, "staff-2"); !errors.Is(err, search.ErrReindexRunning) {
+		t.Errorf("expected error '%v', got '%v'", search.ErrReindexRunning, err)
+	}
+
+	run, err := service.ProcessPending(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.ID != requested.ID || run.Status != search.StatusCompleted || run.Indexed != 96 || run.Progress() != 1 {
+		t.Errorf("expected a completed run over every article, got %+v", run)
+	}
+	if run.Previous != "articles-old" || index.alias[ArticlesAlias] != run.Index {
+		t.Errorf("expected the alias swapped from articles-old to %s, got %s", run.Index, index.alias[ArticlesAlias])
+	}
+	if _, ok := index.indexes["articles-old"]; ok {
+		t.Error("expected the previous index dropped")
+	}
+	built := index.indexes[run.Index]
+	if len(built) != 94 || built["a007"] != "r2" {
+		t.Errorf("expected the catch-up applied and the unpublished article removed, got %d documents", len(built))
+	}
+	if index.maxBulk < 2 || index.maxBulk > Concurrency {
+		t.Errorf("expected concurrent bulk writes bounded by %d, got %d", Concurrency, index.maxBulk)
+	}
+	if run.Sampled != 50 || run.Mismatches != 0 || runs.updates < 10 {
+		t.Errorf("expected a clean sample and per-batch progress, got %+v after %d updates", run, runs.updates)
+	}
+
+	if next, err := service.ProcessPending(ctx); next != nil || err != nil {
+		t.Errorf("expected nothing pending, got %+v %v", next, err)
+	}
+}
+
+func TestReindexService_FailedRunKeepsAlias(t *testing.T) {
+	at := time.Date(2025, 9, 1, 2, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name    string
+		prepare func(source *fakeSource, index *fakeIndex)
+		wantErr error
+	}{
+		{"backend down", func(source *fakeSource, index *fakeIndex) {
+			index.bulkErr = errors.New("cluster unavailable")
+		}, nil},
+		{"too many rejects", func(source *fakeSource, index *fakeIndex) {
+			index.reject = map[string]bool{"a001": true, "a002": true}
+		}, ErrTooManyRejects},
+		{"stale sample", func(source *fakeSource, index *fakeIndex) {
+			index.stale = map[string]string{"a010": "r0"}
+		}, ErrInconsistent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			source := &fakeSource{docs: publishedDocs(40, at)}
+			index := newFakeIndex()
+			tc.prepare(source, index)
+			service, _ := newTestService(source, index, at)
+			_, _ = service.Request(context.Background(), "staff-1")
+
+			run, err := service.ProcessPending(context.Background())
+			if err == nil || (tc.wantErr != nil && !errors.Is(err, tc.wantErr)) {
+				t.Fatalf("expected error '%v', got '%v'", tc.wantErr, err)
+			}
+			if run.Status != search.StatusFailed || run.LastError == nil {
+				t.Errorf("expected a failed run, got %+v", run)
+			}
+			if index.alias[ArticlesAlias] != "articles-old" {
+				t.Errorf("expected the alias left on articles-old, got %s", index.alias[ArticlesAlias])
+			}
+			if _, ok := index.indexes[run.Index]; ok {
+				t.Error("expected the new index dropped")
+			}
+		})
+	}
+}
+
+func TestReindexService_Run(t *testing.T) {
+	service, _ := newTestService(&fakeSource{}, newFakeIndex(), time.Now())
+	if _, err := service.Run(context.Background(), "missing"); !errors.Is(err, ErrRunNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrRunNotFound, err)
+	}
+}
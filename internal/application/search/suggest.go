@@ -0,0 +1,115 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+const (
+	SuggestBudget    = 50 * time.Millisecond // Typeahead feels laggy beyond this, a late answer is worthless
+	MinPrefixLength  = 2
+	MaxPrefixLength  = 100
+	DefaultSuggested = 8
+	MaxSuggested     = 20
+)
+
+type SuggestionKind string
+
+const (
+	SuggestTitles  SuggestionKind = "titles"
+	SuggestTags    SuggestionKind = "tags"
+	SuggestAuthors SuggestionKind = "authors"
+)
+
+var ErrUnknownSuggestionKind = errors.New("unknown suggestion kind")
+
+// Suggestion is one typeahead entry, Slug links to the article, tag or author page
+type Suggestion struct {
+	ID    string
+	Text  string
+	Slug  string
+	Count int // Published articles behind a tag or author, 0 for titles
+}
+
+// SuggestIndex answers prefix lookups. The search engine is the primary, the
+// database's trigram indexes are the fallback while it is unavailable.
+type SuggestIndex interface {
+	SuggestTitles(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	SuggestTags(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+	SuggestAuthors(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+}
+
+type SuggestService struct {
+	primary  SuggestIndex
+	fallback SuggestIndex // Optional
+	budget   time.Duration
+}
+
+func NewSuggestService(primary, fallback SuggestIndex) *SuggestService {
+	return &SuggestService{primary: primary, fallback: fallback, budget: SuggestBudget}
+}
+
+// Suggest returns up to limit suggestions for what has been typed so far.
+// Prefixes shorter than MinPrefixLength return nothing without a lookup. The
+// whole call, fallback included, has SuggestBudget; running out of it returns
+// a *shared.TimeoutError.
+func (s *SuggestService) Suggest(ctx context.Context, kind SuggestionKind, query string, limit int) ([]Suggestion, error) {
+	lookup, err := lookupFor(kind)
+	if err != nil {
+		return nil, err
+	}
+	prefix := NormalizePrefix(query)
+	if utf8.RuneCountInString(prefix) < MinPrefixLength {
+		return []Suggestion{}, nil
+	}
+	if limit <= 0 {
+		limit = DefaultSuggested
+	}
+	limit = min(limit, MaxSuggested)
+
+	ctx, cancel := context.WithTimeout(ctx, s.budget)
+	defer cancel()
+
+	suggestions, err := lookup(s.primary, ctx, prefix, limit)
+	if err != nil && s.fallback != nil && ctx.Err() == nil {
+		suggestions, err = lookup(s.fallback, ctx, prefix, limit)
+	}
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, &shared.TimeoutError{Operation: "suggest " + string(kind), Kind: shared.OperationRead, Limit: s.budget, Err: err}
+		}
+		return nil, err
+	}
+	if suggestions == nil {
+		suggestions = []Suggestion{}
+	}
+	return suggestions, nil
+}
+
+// NormalizePrefix lowercases, collapses whitespace and caps the length, so
+// "  Pemilu   Ja" and "pemilu ja" hit the same lookup
+func NormalizePrefix(query string) string {
+	prefix := strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if utf8.RuneCountInString(prefix) > MaxPrefixLength {
+		prefix = string([]rune(prefix)[:MaxPrefixLength])
+	}
+	return prefix
+}
+
+func lookupFor(kind SuggestionKind) (func(SuggestIndex, context.Context, string, int) ([]Suggestion, error), error) {
+	switch kind {
+	case SuggestTitles:
+		return SuggestIndex.SuggestTitles, nil
+	case SuggestTags:
+		return SuggestIndex.SuggestTags, nil
+	case SuggestAuthors:
+		return SuggestIndex.SuggestAuthors, nil
+	default:
+		return nil, ErrUnknownSuggestionKind
+	}
+}
@@ -0,0 +1,125 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type fakeSuggestIndex struct {
+	entries []Suggestion
+	delay   time.Duration
+	err     error
+	calls   int
+	prefix  string
+}
+
+func (f *fakeSuggestIndex) lookup(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	f.calls++
+	f.prefix = prefix
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	var found []Suggestion
+	for _, entry := range f.entries {
+		if strings.HasPrefix(strings.ToLower(entry.Text), prefix) && len(found) < limit {
+			found = append(found, entry)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSuggestIndex) SuggestTitles(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	return f.lookup(ctx, prefix, limit)
+}
+
+func (f *fakeSuggestIndex) SuggestTags(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	return f.lookup(ctx, prefix, limit)
+}
+
+func (f *fakeSuggestIndex) SuggestAuthors(ctx context.Context, prefix string, limit int) ([]Suggestion, error) {
+	return f.lookup(ctx, prefix, limit)
+}
+
+var tagEntries = []Suggestion{
+	{ID: "t1", Text: "Pemilu 2024", Slug: "pemilu-2024", Count: 120},
+	{ID: "t2", Text: "Pemilu Jakarta", Slug: "pemilu-jakarta", Count: 40},
+	{ID: "t3", Text: "Pendidikan", Slug: "pendidikan", Count: 300},
+}
+
+func TestSuggestService_Suggest(t *testing.T) {
+	testCases := []struct {
+		name        string
+		kind        SuggestionKind
+		query       string
+		limit       int
+		primary     *fakeSuggestIndex
+		fallback    *fakeSuggestIndex
+		expectedLen int
+		wantErr     error
+	}{
+		{"prefix match", SuggestTags, "  PEMILU  ", 0, &fakeSuggestIndex{entries: tagEntries}, nil, 2, nil},
+		{"limit", SuggestTags, "pe", 1, &fakeSuggestIndex{entries: tagEntries}, nil, 1, nil},
+		{"too short", SuggestTitles, "p", 0, &fakeSuggestIndex{entries: tagEntries}, nil, 0, nil},
+		{"fallback on engine error", SuggestAuthors, "pen", 0, &fakeSuggestIndex{err: errors.New("cluster unavailable")}, &fakeSuggestIndex{entries: tagEntries}, 1, nil},
+		{"engine error without fallback", SuggestTitles, "pen", 0, &fakeSuggestIndex{err: errors.New("cluster unavailable")}, nil, 0, errors.New("cluster unavailable")},
+		{"over budget", SuggestTitles, "pen", 0, &fakeSuggestIndex{entries: tagEntries, delay: time.Second}, &fakeSuggestIndex{entries: tagEntries}, 0, shared.ErrTimeout},
+		{"unknown kind", "sections", "pen", 0, &fakeSuggestIndex{}, nil, 0, ErrUnknownSuggestionKind},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fallback SuggestIndex
+			if tc.fallback != nil {
+				fallback = tc.fallback
+			}
+			service := NewSuggestService(tc.primary, fallback)
+
+			started := time.Now()
+			suggestions, err := service.Suggest(context.Background(), tc.kind, tc.query, tc.limit)
+			if tc.wantErr != nil {
+				if err == nil || (!errors.Is(err, tc.wantErr) && err.Error() != tc.wantErr.Error()) {
+					t.Fatalf("expected error '%v', got '%v'", tc.wantErr, err)
+				}
+				if time.Since(started) > 10*SuggestBudget {
+					t.Errorf("expected the call bounded by the budget, took %s", time.Since(started))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if suggestions == nil || len(suggestions) != tc.expectedLen {
+				t.Errorf("expected %d suggestions, got %+v", tc.expectedLen, suggestions)
+			}
+		})
+	}
+}
+
+func TestSuggestService_ShortPrefixSkipsLookup(t *testing.T) {
+	primary := &fakeSuggestIndex{entries: tagEntries}
+	service := NewSuggestService(primary, nil)
+
+	_, _ = service.Suggest(context.Background(), SuggestTags, " p ", 0)
+	if primary.calls != 0 {
+		t.Errorf("expected no lookup for a single character, got %d", primary.calls)
+	}
+	_, _ = service.Suggest(context.Background(), SuggestTags, "Pemilu   Ja", 0)
+	if primary.prefix != "pemilu ja" {
+		t.Errorf("expected the normalized prefix, got %q", primary.prefix)
+	}
+	if got := NormalizePrefix(strings.Repeat("é", MaxPrefixLength+5)); len([]rune(got)) != MaxPrefixLength {
+		t.Errorf("expected the prefix capped at %d runes, got %d", MaxPrefixLength, len([]rune(got)))
+	}
+}
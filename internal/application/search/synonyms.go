@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var ErrSynonymsNotFound = errors.New("synonym set not found")
+
+// SynonymService lets editors manage the synonym sets of reader search.
+// Changes reach queries within LanguageDataTTL.
+type SynonymService struct {
+	synonyms search.SynonymRepository
+	clock    shared.Clock
+}
+
+func NewSynonymService(synonyms search.SynonymRepository, clock shared.Clock) *SynonymService {
+	return &SynonymService{synonyms: synonyms, clock: clock}
+}
+
+func (s *SynonymService) List(ctx context.Context, language string) ([]*search.SynonymSet, error) {
+	if language == "" {
+		language = DefaultLanguage
+	}
+	return s.synonyms.FindByLanguage(ctx, language)
+}
+
+func (s *SynonymService) Create(ctx context.Context, editorID, language string, terms []string) (*search.SynonymSet, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate synonym set ID: %w", err)
+	}
+	set, err := search.NewSynonymSet(id, language, terms, editorID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.synonyms.Create(ctx, set); err != nil {
+		return nil, fmt.Errorf("failed to save synonym set: %w", err)
+	}
+	return set, nil
+}
+
+// Update replaces the terms of a set, its language stays
+func (s *SynonymService) Update(ctx context.Context, editorID, id string, terms []string) (*search.SynonymSet, error) {
+	set, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := set.Redefine(terms, editorID, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.synonyms.Update(ctx, set); err != nil {
+		return nil, fmt.Errorf("failed to save synonym set: %w", err)
+	}
+	return set, nil
+}
+
+func (s *SynonymService) Delete(ctx context.Context, id string) error {
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if err := s.synonyms.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete synonym set: %w", err)
+	}
+	return nil
+}
+
+func (s *SynonymService) find(ctx context.Context, id string) (*search.SynonymSet, error) {
+	set, err := s.synonyms.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load synonym set: %w", err)
+	}
+	if set == nil {
+		return nil, ErrSynonymsNotFound
+	}
+	return set, nil
+}
@@ -0,0 +1,48 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+func TestSynonymService(t *testing.T) {
+	synonyms := &memorySynonyms{byID: map[string]*search.SynonymSet{}}
+	clock := shared.NewFrozenClock(time.Date(2025, 9, 1, 8, 0, 0, 0, time.UTC))
+	service := NewSynonymService(synonyms, clock)
+	ctx := context.Background()
+
+	set, err := service.Create(ctx, "editor-1", "id", []string{"Pemilu", "pemilihan umum"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Create(ctx, "editor-1", "id", []string{"pemilu"}); !errors.Is(err, search.ErrTooFewSynonyms) {
+		t.Errorf("expected error '%v', got '%v'", search.ErrTooFewSynonyms, err)
+	}
+
+	clock.Advance(time.Hour)
+	updated, err := service.Update(ctx, "editor-2", set.ID, []string{"pemilu", "pemilihan umum", "coblosan"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(updated.Terms) != 3 || updated.UpdatedBy != "editor-2" || !updated.UpdatedAt.Equal(clock.Now()) {
+		t.Errorf("expected the set redefined by editor-2, got %+v", updated)
+	}
+
+	sets, _ := service.List(ctx, "")
+	if len(sets) != 1 || !slices.Contains(sets[0].Terms, "coblosan") {
+		t.Errorf("expected the default language listed, got %v", sets)
+	}
+
+	if err := service.Delete(ctx, set.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Update(ctx, "editor-1", set.ID, []string{"a", "b"}); !errors.Is(err, ErrSynonymsNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrSynonymsNotFound, err)
+	}
+}
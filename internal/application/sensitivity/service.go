@@ -0,0 +1,68 @@
+package sensitivity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sensitivity"
+)
+
+type Service struct {
+	classifications sensitivity.ClassificationRepository
+	router          sensitivity.ReviewRouter
+}
+
+func NewService(classifications sensitivity.ClassificationRepository, router sensitivity.ReviewRouter) *Service {
+	return &Service{classifications: classifications, router: router}
+}
+
+// Classify replaces the article's sensitive categories. Newly added
+// categories route the article to the legal desk; the publish checklist
+// keeps it blocked until legal approves the current revision.
+func (s *Service) Classify(ctx context.Context, editorID, articleID string, categories []string, at time.Time) (*sensitivity.Classification, error) {
+	selected := make([]sensitivity.Category, 0, len(categories))
+	for _, c := range categories {
+		selected = append(selected, sensitivity.Category(c))
+	}
+	classification, err := sensitivity.NewClassification(articleID, selected, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.classifications.FindByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load classification: %w", err)
+	}
+	if err := s.classifications.Save(ctx, classification); err != nil {
+		return nil, fmt.Errorf("failed to save classification: %w", err)
+	}
+	if added := classification.Added(previous); len(added) > 0 {
+		if err := s.router.RouteForReview(ctx, articleID, checklist.ScopeLegalReview, added); err != nil {
+			return nil, fmt.Errorf("failed to route article for legal review: %w", err)
+		}
+	}
+	return classification, nil
+}
+
+// Classification returns the article's classification, empty when it was never classified
+func (s *Service) Classification(ctx context.Context, articleID string) (*sensitivity.Classification, error) {
+	classification, err := s.classifications.FindByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load classification: %w", err)
+	}
+	if classification == nil {
+		return &sensitivity.Classification{ArticleID: articleID}, nil
+	}
+	return classification, nil
+}
+
+// Render appends the required disclaimers to the rendered article body
+func (s *Service) Render(ctx context.Context, articleID, body, language string) (string, error) {
+	classification, err := s.Classification(ctx, articleID)
+	if err != nil {
+		return "", err
+	}
+	return classification.Render(body, language), nil
+}
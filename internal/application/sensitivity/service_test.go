@@ -0,0 +1,88 @@
+package sensitivity
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sensitivity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+type memoryClassifications struct {
+	classifications map[string]*sensitivity.Classification
+}
+
+func (m *memoryClassifications) Save(ctx context.Context, c *sensitivity.Classification) error {
+	m.classifications[c.ArticleID] = c
+	return nil
+}
+
+func (m *memoryClassifications) FindByArticle(ctx context.Context, articleID string) (*sensitivity.Classification, error) {
+	return m.classifications[articleID], nil
+}
+
+type route struct {
+	articleID  string
+	scope      entitlement.Scope
+	categories []sensitivity.Category
+}
+
+type fakeRouter struct {
+	routes []route
+}
+
+func (f *fakeRouter) RouteForReview(ctx context.Context, articleID string, scope entitlement.Scope, categories []sensitivity.Category) error {
+	f.routes = append(f.routes, route{articleID: articleID, scope: scope, categories: categories})
+	return nil
+}
+
+func TestService_Classify(t *testing.T) {
+	router := &fakeRouter{}
+	service := NewService(&memoryClassifications{classifications: map[string]*sensitivity.Classification{}}, router)
+	ctx := context.Background()
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	if _, err := service.Classify(ctx, "editor-1", "a1", []string{"court_case"}, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(router.routes) != 1 || router.routes[0].scope != checklist.ScopeLegalReview {
+		t.Fatalf("expected the article routed to the legal desk, got %+v", router.routes)
+	}
+
+	if _, err := service.Classify(ctx, "editor-1", "a1", []string{"court_case"}, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Classify(ctx, "editor-1", "a1", []string{"court_case", "minors"}, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(router.routes) != 2 || len(router.routes[1].categories) != 1 || router.routes[1].categories[0] != sensitivity.CategoryMinors {
+		t.Errorf("expected only the added category routed again, got %+v", router.routes)
+	}
+
+	if _, err := service.Classify(ctx, "editor-1", "a1", []string{"gossip"}, at); err != sensitivity.ErrInvalidCategory {
+		t.Errorf("expected error '%v', got '%v'", sensitivity.ErrInvalidCategory, err)
+	}
+}
+
+func TestService_Render(t *testing.T) {
+	classifications := &memoryClassifications{classifications: map[string]*sensitivity.Classification{
+		"a1": {ArticleID: "a1", Categories: []sensitivity.Category{sensitivity.CategoryElections}},
+	}}
+	service := NewService(classifications, &fakeRouter{})
+
+	body, err := service.Render(context.Background(), "a1", "<p>Debat capres</p>", "id")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(body, `data-sensitivity="elections"`) {
+		t.Errorf("expected election disclaimer, got %s", body)
+	}
+
+	body, _ = service.Render(context.Background(), "a2", "<p>Cuaca</p>", "id")
+	if body != "<p>Cuaca</p>" {
+		t.Errorf("expected unclassified body unchanged, got %s", body)
+	}
+}
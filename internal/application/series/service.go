@@ -0,0 +1,219 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/series"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrSeriesNotFound       = errors.New("series not found")
+	ErrSlugTaken            = errors.New("another series already uses this slug")
+	ErrArticleInOtherSeries = errors.New("article already belongs to another series")
+	ErrNotInSeries          = errors.New("article is not part of a series")
+)
+
+// Paths builds canonical article paths, the permalink router implements it
+type Paths interface {
+	Path(ref permalink.ArticleRef) (string, error)
+}
+
+// Part is one published part as readers see it
+type Part struct {
+	Number      int
+	ArticleID   string
+	Title       string
+	Excerpt     string
+	Path        string
+	PublishedAt time.Time
+	Current     bool // The article the cross-link block is shown in
+}
+
+// Landing is the series page and feed: the series with its published parts in reading order
+type Landing struct {
+	Series *series.Series
+	Parts  []Part
+}
+
+// CrossLinks is the block rendered inside each member article
+type CrossLinks struct {
+	Series   *series.Series
+	Position series.Position
+	Parts    []Part
+}
+
+type Service struct {
+	series   series.SeriesRepository
+	articles series.ArticleSummaries
+	paths    Paths
+}
+
+func NewService(seriesRepo series.SeriesRepository, articles series.ArticleSummaries, paths Paths) *Service {
+	return &Service{series: seriesRepo, articles: articles, paths: paths}
+}
+
+func (s *Service) Create(ctx context.Context, staffID, slug, title, description string, plannedParts int) (*series.Series, error) {
+	slugObj, err := series.NewSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.series.FindBySlug(ctx, slugObj.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slug: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrSlugTaken
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	created, err := series.NewSeries(id, *slugObj, title, description, plannedParts, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.series.Create(ctx, created); err != nil {
+		return nil, fmt.Errorf("failed to save series: %w", err)
+	}
+	return created, nil
+}
+
+func (s *Service) Update(ctx context.Context, staffID, id, title, description string, plannedParts int) (*series.Series, error) {
+	existing, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := existing.UpdateDetails(title, description, plannedParts, staffID); err != nil {
+		return nil, err
+	}
+	if err := s.series.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to save series: %w", err)
+	}
+	return existing, nil
+}
+
+// SetParts replaces the reading order. An article can only be in one series,
+// so its cross-link block is never ambiguous.
+func (s *Service) SetParts(ctx context.Context, staffID, id string, articleIDs []string) (*series.Series, error) {
+	existing, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, articleID := range articleIDs {
+		other, err := s.series.FindByArticleID(ctx, articleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check article series: %w", err)
+		}
+		if other != nil && other.ID != existing.ID {
+			return nil, fmt.Errorf("%w: %s is in %q", ErrArticleInOtherSeries, articleID, other.Title)
+		}
+	}
+
+	if err := existing.SetParts(articleIDs, staffID); err != nil {
+		return nil, err
+	}
+	if err := s.series.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to save series: %w", err)
+	}
+	return existing, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if err := s.series.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete series: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*series.Series, error) {
+	return s.find(ctx, id)
+}
+
+// Landing returns the series page, only published parts are listed
+func (s *Service) Landing(ctx context.Context, slug string) (*Landing, error) {
+	found, err := s.series.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load series: %w", err)
+	}
+	if found == nil {
+		return nil, ErrSeriesNotFound
+	}
+	parts, _, err := s.parts(ctx, found, "")
+	if err != nil {
+		return nil, err
+	}
+	return &Landing{Series: found, Parts: parts}, nil
+}
+
+// CrossLinks returns the series block for an article, ErrNotInSeries when it has none
+func (s *Service) CrossLinks(ctx context.Context, articleID string) (*CrossLinks, error) {
+	found, err := s.series.FindByArticleID(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load series: %w", err)
+	}
+	if found == nil {
+		return nil, ErrNotInSeries
+	}
+
+	parts, published, err := s.parts(ctx, found, articleID)
+	if err != nil {
+		return nil, err
+	}
+	pos, ok := found.PositionOf(articleID, published)
+	if !ok {
+		return nil, ErrNotInSeries
+	}
+	return &CrossLinks{Series: found, Position: *pos, Parts: parts}, nil
+}
+
+// parts loads the published parts in reading order, numbered by their place in the full series
+func (s *Service) parts(ctx context.Context, found *series.Series, current string) ([]Part, map[string]bool, error) {
+	summaries, err := s.articles.FindPublished(ctx, found.ArticleIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load series articles: %w", err)
+	}
+
+	parts := make([]Part, 0, len(summaries))
+	published := make(map[string]bool, len(summaries))
+	for i, id := range found.ArticleIDs {
+		summary, ok := summaries[id]
+		if !ok {
+			continue
+		}
+		path, err := s.paths.Path(summary.Ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build path for %s: %w", id, err)
+		}
+		published[id] = true
+		parts = append(parts, Part{
+			Number:      i + 1,
+			ArticleID:   id,
+			Title:       summary.Title,
+			Excerpt:     summary.Excerpt,
+			Path:        path,
+			PublishedAt: summary.Ref.PublishedAt,
+			Current:     id == current,
+		})
+	}
+	return parts, published, nil
+}
+
+func (s *Service) find(ctx context.Context, id string) (*series.Series, error) {
+	found, err := s.series.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load series: %w", err)
+	}
+	if found == nil {
+		return nil, ErrSeriesNotFound
+	}
+	return found, nil
+}
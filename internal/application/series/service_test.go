@@ -0,0 +1,165 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/series"
+)
+
+type memorySeries struct {
+	series map[string]*series.Series
+}
+
+func (m *memorySeries) Create(ctx context.Context, s *series.Series) error {
+	m.series[s.ID] = s
+	return nil
+}
+
+func (m *memorySeries) Update(ctx context.Context, s *series.Series) error {
+	m.series[s.ID] = s
+	return nil
+}
+
+func (m *memorySeries) Delete(ctx context.Context, id string) error {
+	delete(m.series, id)
+	return nil
+}
+
+func (m *memorySeries) FindByID(ctx context.Context, id string) (*series.Series, error) {
+	return m.series[id], nil
+}
+
+func (m *memorySeries) FindBySlug(ctx context.Context, slug string) (*series.Series, error) {
+	for _, s := range m.series {
+		if s.Slug.Value() == slug {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySeries) FindByArticleID(ctx context.Context, articleID string) (*series.Series, error) {
+	for _, s := range m.series {
+		for _, id := range s.ArticleIDs {
+			if id == articleID {
+				return s, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+type fakeArticles struct {
+	published map[string]series.ArticleSummary
+}
+
+func (f *fakeArticles) FindPublished(ctx context.Context, articleIDs []string) (map[string]series.ArticleSummary, error) {
+	found := map[string]series.ArticleSummary{}
+	for _, id := range articleIDs {
+		if summary, ok := f.published[id]; ok {
+			found[id] = summary
+		}
+	}
+	return found, nil
+}
+
+type fakePaths struct{}
+
+func (fakePaths) Path(ref permalink.ArticleRef) (string, error) {
+	return "/" + ref.CategorySlug + "/" + ref.Slug, nil
+}
+
+func createTestService(t *testing.T) (*Service, *series.Series) {
+	t.Helper()
+	published := map[string]series.ArticleSummary{}
+	for i, id := range []string{"a1", "a2", "a4"} {
+		published[id] = series.ArticleSummary{
+			Ref:   permalink.ArticleRef{ID: id, Slug: "part-" + id, CategorySlug: "investigations", PublishedAt: time.Date(2025, time.May, i+1, 0, 0, 0, 0, time.UTC)},
+			Title: "Part " + id,
+		}
+	}
+	s := NewService(&memorySeries{series: map[string]*series.Series{}}, &fakeArticles{published: published}, fakePaths{})
+
+	created, err := s.Create(context.Background(), "editor-1", "water-cartel", "The Water Cartel", "", 5)
+	if err != nil {
+		t.Fatalf("failed to create series: %v", err)
+	}
+	if _, err := s.SetParts(context.Background(), "editor-1", created.ID, []string{"a1", "a2", "a3", "a4"}); err != nil {
+		t.Fatalf("failed to set parts: %v", err)
+	}
+	return s, created
+}
+
+func TestService_Create(t *testing.T) {
+	s, _ := createTestService(t)
+
+	if _, err := s.Create(context.Background(), "editor-1", "Water-Cartel", "Copy", "", 0); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected error '%v', got '%v'", ErrSlugTaken, err)
+	}
+	if _, err := s.Create(context.Background(), "editor-1", "x", "Short", "", 0); !errors.Is(err, series.ErrInvalidSlug) {
+		t.Errorf("expected error '%v', got '%v'", series.ErrInvalidSlug, err)
+	}
+}
+
+func TestService_SetParts_OneSeriesPerArticle(t *testing.T) {
+	s, _ := createTestService(t)
+	other, _ := s.Create(context.Background(), "editor-1", "election-money", "Election Money", "", 0)
+
+	if _, err := s.SetParts(context.Background(), "editor-1", other.ID, []string{"a9", "a2"}); !errors.Is(err, ErrArticleInOtherSeries) {
+		t.Errorf("expected error '%v', got '%v'", ErrArticleInOtherSeries, err)
+	}
+}
+
+func TestService_Landing(t *testing.T) {
+	s, _ := createTestService(t)
+
+	landing, err := s.Landing(context.Background(), "water-cartel")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(landing.Parts) != 3 {
+		t.Fatalf("expected 3 published parts, got %d", len(landing.Parts))
+	}
+	if last := landing.Parts[2]; last.Number != 4 || last.Path != "/investigations/part-a4" {
+		t.Errorf("expected part 4 at its permalink, got %+v", last)
+	}
+
+	if _, err := s.Landing(context.Background(), "missing"); !errors.Is(err, ErrSeriesNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrSeriesNotFound, err)
+	}
+}
+
+func TestService_CrossLinks(t *testing.T) {
+	s, _ := createTestService(t)
+
+	links, err := s.CrossLinks(context.Background(), "a2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if links.Position.Part != 2 || links.Position.Total != 5 {
+		t.Errorf("expected part 2 of 5, got %d of %d", links.Position.Part, links.Position.Total)
+	}
+	if links.Position.Next == nil || *links.Position.Next != "a4" {
+		t.Errorf("expected next to skip unpublished part 3, got %v", links.Position.Next)
+	}
+	current := 0
+	for _, p := range links.Parts {
+		if p.Current {
+			current++
+			if p.ArticleID != "a2" {
+				t.Errorf("expected a2 to be current, got %s", p.ArticleID)
+			}
+		}
+	}
+	if current != 1 {
+		t.Errorf("expected exactly one current part, got %d", current)
+	}
+
+	if _, err := s.CrossLinks(context.Background(), "standalone"); !errors.Is(err, ErrNotInSeries) {
+		t.Errorf("expected error '%v', got '%v'", ErrNotInSeries, err)
+	}
+}
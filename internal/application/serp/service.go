@@ -0,0 +1,38 @@
+package serp
+
+import (
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/serp"
+)
+
+// Service estimates how headlines and descriptions render in search results and social cards
+type Service struct {
+	surfaces []serp.Surface
+}
+
+// NewService takes the site's surface thresholds, nil uses serp.DefaultSurfaces
+func NewService(surfaces []serp.Surface) *Service {
+	if surfaces == nil {
+		surfaces = serp.DefaultSurfaces()
+	}
+	return &Service{surfaces: surfaces}
+}
+
+// Preview measures the texts on every surface. Like the page head, the title
+// falls back to the headline when no SEO title is set.
+func (s *Service) Preview(headline, seoTitle, description string) []serp.Preview {
+	title := seoTitle
+	if strings.TrimSpace(title) == "" {
+		title = headline
+	}
+	previews := make([]serp.Preview, 0, len(s.surfaces))
+	for _, surface := range s.surfaces {
+		text := title
+		if surface.Field == serp.FieldDescription {
+			text = description
+		}
+		previews = append(previews, surface.Measure(text))
+	}
+	return previews
+}
@@ -0,0 +1,40 @@
+package serp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/serp"
+)
+
+func TestService_Preview(t *testing.T) {
+	service := NewService(nil)
+	description := "Hujan deras sejak Senin malam membuat Kali Ciliwung meluap dan merendam ribuan rumah di Jakarta Timur."
+
+	previews := service.Preview("Banjir rendam ribuan rumah di Jakarta Timur", "", description)
+	if len(previews) != len(serp.DefaultSurfaces()) {
+		t.Fatalf("expected a preview per surface, got %d", len(previews))
+	}
+	for _, p := range previews {
+		expected := "Banjir rendam ribuan rumah di Jakarta Timur"
+		if p.Field == serp.FieldDescription {
+			expected = description
+		}
+		if p.Text != expected {
+			t.Errorf("expected %s to measure %q, got %q", p.Surface, expected, p.Text)
+		}
+	}
+
+	previews = service.Preview("Banjir", "Banjir Jakarta: "+strings.Repeat("ribuan rumah terendam ", 5), description)
+	if previews[0].Surface != serp.SurfaceGoogleTitle || !previews[0].Truncated {
+		t.Errorf("expected the SEO title to be measured and truncated, got %+v", previews[0])
+	}
+}
+
+func TestService_CustomSurfaces(t *testing.T) {
+	service := NewService([]serp.Surface{{Name: serp.SurfaceGoogleTitle, Field: serp.FieldTitle, Font: serp.Font{SizePx: 20}, LineWidthPx: 100, Lines: 1}})
+	previews := service.Preview("Banjir rendam ribuan rumah", "", "")
+	if len(previews) != 1 || !previews[0].Truncated {
+		t.Errorf("expected the site's tighter threshold to apply, got %+v", previews)
+	}
+}
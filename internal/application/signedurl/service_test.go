@@ -0,0 +1,65 @@
+package signedurl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared/signedurl"
+)
+
+type memoryKeyrings struct {
+	keyrings map[signedurl.Scope]*signedurl.Keyring
+}
+
+func (m *memoryKeyrings) Save(ctx context.Context, kr *signedurl.Keyring) error {
+	m.keyrings[kr.Scope] = kr
+	return nil
+}
+
+func (m *memoryKeyrings) FindByScope(ctx context.Context, scope signedurl.Scope) (*signedurl.Keyring, error) {
+	return m.keyrings[scope], nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(&memoryKeyrings{keyrings: map[signedurl.Scope]*signedurl.Keyring{}})
+
+	if _, err := service.Sign(ctx, signedurl.ScopeMedia, "/media/1.jpg", time.Hour, ""); err != signedurl.ErrNoActiveKey {
+		t.Errorf("expected error '%v', got '%v'", signedurl.ErrNoActiveKey, err)
+	}
+	if _, err := service.Rotate(ctx, "thumbnails"); err != signedurl.ErrInvalidScope {
+		t.Errorf("expected error '%v', got '%v'", signedurl.ErrInvalidScope, err)
+	}
+
+	first, err := service.Rotate(ctx, signedurl.ScopeMedia)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	old, err := service.Sign(ctx, signedurl.ScopeMedia, "/media/1.jpg", time.Hour, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := service.Rotate(ctx, signedurl.ScopeMedia); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	current, _ := service.Sign(ctx, signedurl.ScopeMedia, "/media/1.jpg", time.Hour, "")
+
+	if err := service.Verify(ctx, signedurl.ScopeMedia, old, ""); err != nil {
+		t.Errorf("expected rotated URL to stay valid, got '%v'", err)
+	}
+	if err := service.Verify(ctx, signedurl.ScopePreview, current, ""); err != signedurl.ErrUnknownKey {
+		t.Errorf("expected error '%v', got '%v'", signedurl.ErrUnknownKey, err)
+	}
+
+	if err := service.RevokeThrough(ctx, signedurl.ScopeMedia, first.Generation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.Verify(ctx, signedurl.ScopeMedia, old, ""); err != signedurl.ErrRevoked {
+		t.Errorf("expected error '%v', got '%v'", signedurl.ErrRevoked, err)
+	}
+	if err := service.Verify(ctx, signedurl.ScopeMedia, current, ""); err != nil {
+		t.Errorf("expected current URL to stay valid, got '%v'", err)
+	}
+}
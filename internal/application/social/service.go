@@ -0,0 +1,199 @@
+package social
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/social"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// DispatchBatch caps how many posts one dispatch run delivers
+const DispatchBatch = 100
+
+var ErrPostNotFound = errors.New("social post not found")
+
+// DispatchResult summarizes one run of the dispatch job
+type DispatchResult struct {
+	Sent   int
+	Failed int // Includes posts that will be retried
+}
+
+type Service struct {
+	templates  social.TemplateRepository
+	posts      social.PostRepository
+	publishers map[social.Channel]social.Publisher
+}
+
+// NewService takes a publisher per connected channel, channels without one get no posts
+func NewService(templates social.TemplateRepository, posts social.PostRepository, publishers map[social.Channel]social.Publisher) *Service {
+	return &Service{templates: templates, posts: posts, publishers: publishers}
+}
+
+// Schedule is called by the publish flow when an article is published
+// (publishAt now) or scheduled. Posts still waiting follow a changed time,
+// sent and cancelled ones are left alone.
+func (s *Service) Schedule(ctx context.Context, article social.Article, publishAt, at time.Time) ([]*social.Post, error) {
+	existing, err := s.posts.FindByArticle(ctx, article.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load social posts: %w", err)
+	}
+	byChannel := map[social.Channel]*social.Post{}
+	for _, p := range existing {
+		byChannel[p.Channel] = p
+	}
+
+	var scheduled []*social.Post
+	for _, channel := range social.AllChannels {
+		if _, ok := s.publishers[channel]; !ok {
+			continue
+		}
+		tmpl, err := s.Template(ctx, channel)
+		if err != nil {
+			return nil, err
+		}
+		if !tmpl.Enabled {
+			continue
+		}
+		text, link, err := tmpl.Render(article)
+		if err != nil {
+			return nil, err
+		}
+
+		post := byChannel[channel]
+		switch {
+		case post == nil:
+			id, err := shared.GenerateUUID()
+			if err != nil {
+				return nil, err
+			}
+			if post, err = social.NewPost(id, article.ID, channel, text, link, publishAt, at); err != nil {
+				return nil, err
+			}
+		case post.Status == social.PostScheduled:
+			if err := post.Refresh(text, link, publishAt, at); err != nil {
+				return nil, err
+			}
+		default:
+			continue
+		}
+		if err := s.posts.Save(ctx, post); err != nil {
+			return nil, fmt.Errorf("failed to save social post: %w", err)
+		}
+		scheduled = append(scheduled, post)
+	}
+	return scheduled, nil
+}
+
+// Dispatch is the scheduled job delivering due posts. A failed delivery is
+// recorded on the post and retried later, it does not stop the run.
+func (s *Service) Dispatch(ctx context.Context, at time.Time) (*DispatchResult, error) {
+	due, err := s.posts.FindDue(ctx, at, DispatchBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load due social posts: %w", err)
+	}
+	result := &DispatchResult{}
+	for _, post := range due {
+		publisher, ok := s.publishers[post.Channel]
+		if !ok {
+			post.MarkFailed("channel is not connected", at)
+			result.Failed++
+		} else if externalID, err := publisher.Publish(ctx, post); err != nil {
+			post.MarkFailed(err.Error(), at)
+			result.Failed++
+		} else {
+			post.MarkSent(externalID, at)
+			result.Sent++
+		}
+		if err := s.posts.Save(ctx, post); err != nil {
+			return nil, fmt.Errorf("failed to save social post: %w", err)
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) Posts(ctx context.Context, articleID string) ([]*social.Post, error) {
+	posts, err := s.posts.FindByArticle(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load social posts: %w", err)
+	}
+	return posts, nil
+}
+
+// Override replaces a waiting post's text and time by hand
+func (s *Service) Override(ctx context.Context, editorID, postID, text string, scheduledAt, at time.Time) (*social.Post, error) {
+	return s.update(ctx, postID, func(p *social.Post) error {
+		return p.Override(text, scheduledAt, editorID, at)
+	})
+}
+
+func (s *Service) Cancel(ctx context.Context, editorID, postID string, at time.Time) (*social.Post, error) {
+	return s.update(ctx, postID, func(p *social.Post) error {
+		return p.Cancel(editorID, at)
+	})
+}
+
+// Retry queues a failed post again, e.g. after a channel token was renewed
+func (s *Service) Retry(ctx context.Context, editorID, postID string, at time.Time) (*social.Post, error) {
+	return s.update(ctx, postID, func(p *social.Post) error {
+		return p.Retry(editorID, at)
+	})
+}
+
+func (s *Service) update(ctx context.Context, postID string, change func(p *social.Post) error) (*social.Post, error) {
+	post, err := s.posts.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load social post: %w", err)
+	}
+	if post == nil {
+		return nil, ErrPostNotFound
+	}
+	if err := change(post); err != nil {
+		return nil, err
+	}
+	if err := s.posts.Save(ctx, post); err != nil {
+		return nil, fmt.Errorf("failed to save social post: %w", err)
+	}
+	return post, nil
+}
+
+// Template returns the channel's template, the default until one is saved
+func (s *Service) Template(ctx context.Context, channel social.Channel) (*social.Template, error) {
+	tmpl, err := s.templates.FindByChannel(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load social template: %w", err)
+	}
+	if tmpl == nil {
+		return social.DefaultTemplate(channel), nil
+	}
+	return tmpl, nil
+}
+
+func (s *Service) Templates(ctx context.Context) ([]*social.Template, error) {
+	templates := make([]*social.Template, 0, len(social.AllChannels))
+	for _, channel := range social.AllChannels {
+		tmpl, err := s.Template(ctx, channel)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+func (s *Service) SaveTemplate(ctx context.Context, editorID, channel, body string, enabled bool, at time.Time) (*social.Template, error) {
+	c, err := social.ParseChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := social.NewTemplate(c, body, enabled, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templates.Save(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to save social template: %w", err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,157 @@
+package social
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/social"
+)
+
+type memoryTemplates struct {
+	templates map[social.Channel]*social.Template
+}
+
+func (m *memoryTemplates) Save(ctx context.Context, t *social.Template) error {
+	m.templates[t.Channel] = t
+	return nil
+}
+
+func (m *memoryTemplates) FindByChannel(ctx context.Context, channel social.Channel) (*social.Template, error) {
+	return m.templates[channel], nil
+}
+
+type memoryPosts struct {
+	posts map[string]*social.Post
+}
+
+func (m *memoryPosts) Save(ctx context.Context, p *social.Post) error {
+	m.posts[p.ID] = p
+	return nil
+}
+
+func (m *memoryPosts) FindByID(ctx context.Context, id string) (*social.Post, error) {
+	return m.posts[id], nil
+}
+
+func (m *memoryPosts) FindByArticle(ctx context.Context, articleID string) ([]*social.Post, error) {
+	var found []*social.Post
+	for _, p := range m.posts {
+		if p.ArticleID == articleID {
+			found = append(found, p)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Channel < found[j].Channel })
+	return found, nil
+}
+
+func (m *memoryPosts) FindDue(ctx context.Context, at time.Time, limit int) ([]*social.Post, error) {
+	var found []*social.Post
+	for _, p := range m.posts {
+		if p.Due(at) {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+type fakePublisher struct {
+	published []string
+	err       error
+}
+
+func (f *fakePublisher) Publish(ctx context.Context, p *social.Post) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.published = append(f.published, p.Text)
+	return "ext-" + p.ID, nil
+}
+
+func createTestService() (*Service, *memoryPosts, *fakePublisher, *fakePublisher) {
+	posts := &memoryPosts{posts: map[string]*social.Post{}}
+	x, telegram := &fakePublisher{}, &fakePublisher{}
+	service := NewService(&memoryTemplates{templates: map[social.Channel]*social.Template{}}, posts, map[social.Channel]social.Publisher{social.ChannelX: x, social.ChannelTelegram: telegram})
+	return service, posts, x, telegram
+}
+
+var testArticle = social.Article{ID: "a1", Title: "Harga BBM naik", URL: "https://example.com/a1", Tags: []string{"energi"}}
+
+func TestService_Schedule(t *testing.T) {
+	service, _, _, _ := createTestService()
+	ctx := context.Background()
+	at := time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	if _, err := service.SaveTemplate(ctx, "editor-1", "telegram", "{{.Title}}", false, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	posts, err := service.Schedule(ctx, testArticle, at.Add(time.Hour), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(posts) != 1 || posts[0].Channel != social.ChannelX {
+		t.Fatalf("expected a post for connected, enabled channels only, got %+v", posts)
+	}
+
+	// The article moved, the waiting post follows
+	moved, err := service.Schedule(ctx, testArticle, at.Add(3*time.Hour), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(moved) != 1 || moved[0].ID != posts[0].ID || !moved[0].ScheduledAt.Equal(at.Add(3*time.Hour)) {
+		t.Errorf("expected the same post rescheduled, got %+v", moved)
+	}
+}
+
+func TestService_Dispatch(t *testing.T) {
+	service, posts, x, _ := createTestService()
+	ctx := context.Background()
+	at := time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	scheduled, _ := service.Schedule(ctx, testArticle, at, at)
+
+	x.err = errors.New("429 too many requests")
+	result, err := service.Dispatch(ctx, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	post := posts.posts[scheduled[0].ID]
+	if result.Failed != 1 || post.Status != social.PostScheduled || post.LastError == nil {
+		t.Fatalf("expected a failed attempt queued for retry, got %+v", post)
+	}
+
+	x.err = nil
+	result, _ = service.Dispatch(ctx, post.ScheduledAt)
+	if result.Sent != 1 || post.Status != social.PostSent || *post.ExternalID != "ext-"+post.ID || len(x.published) != 1 {
+		t.Errorf("expected the retry delivered, got %+v", post)
+	}
+
+	if _, err := service.Cancel(ctx, "editor-1", post.ID, at); err != social.ErrNotCancellable {
+		t.Errorf("expected error '%v', got '%v'", social.ErrNotCancellable, err)
+	}
+}
+
+func TestService_OverrideAndCancel(t *testing.T) {
+	service, _, x, _ := createTestService()
+	ctx := context.Background()
+	at := time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	scheduled, _ := service.Schedule(ctx, testArticle, at.Add(time.Hour), at)
+
+	post, err := service.Override(ctx, "editor-1", scheduled[0].ID, "BREAKING: Harga BBM naik", at.Add(30*time.Minute), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !post.Overridden {
+		t.Error("expected the post marked as overridden")
+	}
+	if _, err := service.Cancel(ctx, "editor-1", post.ID, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Dispatch(ctx, at.Add(time.Hour)); err != nil || len(x.published) != 0 {
+		t.Errorf("expected a cancelled post not delivered, got %v, %v", x.published, err)
+	}
+	if _, err := service.Cancel(ctx, "editor-1", "missing", at); err != ErrPostNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrPostNotFound, err)
+	}
+}
@@ -0,0 +1,238 @@
+package sports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sports"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// The refresh window covers yesterday's late finishes and the next two weeks of fixtures
+const (
+	refreshBack  = 24 * time.Hour
+	refreshAhead = 14 * 24 * time.Hour
+
+	// MaxScoreboards is how many scoreboards one page can hydrate at once
+	MaxScoreboards = 20
+)
+
+var (
+	ErrCompetitionNotFound = errors.New("competition not found")
+	ErrCompetitionExists   = errors.New("competition code is already used")
+	ErrFixtureNotFound     = errors.New("fixture not found")
+	ErrTableNotFound       = errors.New("competition has no table yet")
+	ErrUnknownProvider     = errors.New("no sports data adapter with that name")
+	ErrTooManyScoreboards  = errors.New("too many scoreboards requested, the limit is 20")
+)
+
+// RefreshResult says what one refresh changed and when to run the next
+type RefreshResult struct {
+	FixturesChanged int
+	TableChanged    bool
+	NextRefreshAt   time.Time
+}
+
+type Service struct {
+	competitions sports.CompetitionRepository
+	fixtures     sports.FixtureRepository
+	tables       sports.TableRepository
+	providers    map[string]sports.Provider
+}
+
+// NewService takes the provider adapters keyed by the name competitions refer to in Provider
+func NewService(competitions sports.CompetitionRepository, fixtures sports.FixtureRepository, tables sports.TableRepository, providers map[string]sports.Provider) *Service {
+	return &Service{competitions: competitions, fixtures: fixtures, tables: tables, providers: providers}
+}
+
+func (s *Service) CreateCompetition(ctx context.Context, editorID, code, name, season, provider, providerRef string, at time.Time) (*sports.Competition, error) {
+	if _, ok := s.providers[provider]; !ok {
+		return nil, ErrUnknownProvider
+	}
+	competition, err := sports.NewCompetition(code, name, season, provider, providerRef, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.competitions.FindByID(ctx, competition.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load competition: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrCompetitionExists
+	}
+	if err := s.competitions.Create(ctx, competition); err != nil {
+		return nil, fmt.Errorf("failed to create competition: %w", err)
+	}
+	return competition, nil
+}
+
+// SetActive turns refreshing on or off, e.g. after the season ends
+func (s *Service) SetActive(ctx context.Context, editorID, competitionID string, active bool, at time.Time) (*sports.Competition, error) {
+	competition, err := s.Competition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	competition.SetActive(active, editorID, at)
+	if err := s.competitions.Update(ctx, competition); err != nil {
+		return nil, fmt.Errorf("failed to update competition: %w", err)
+	}
+	return competition, nil
+}
+
+func (s *Service) Competition(ctx context.Context, competitionID string) (*sports.Competition, error) {
+	competition, err := s.competitions.FindByID(ctx, competitionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load competition: %w", err)
+	}
+	if competition == nil {
+		return nil, ErrCompetitionNotFound
+	}
+	return competition, nil
+}
+
+func (s *Service) Competitions(ctx context.Context) ([]*sports.Competition, error) {
+	competitions, err := s.competitions.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list competitions: %w", err)
+	}
+	return competitions, nil
+}
+
+// Refresh pulls fixtures and scores from the provider and stores only what
+// changed. The table is re-read when a match finished or it is over an hour
+// old. The scheduler runs the next refresh at NextRefreshAt.
+func (s *Service) Refresh(ctx context.Context, competitionID string, at time.Time) (*RefreshResult, error) {
+	competition, err := s.Competition(ctx, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	provider, ok := s.providers[competition.Provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	incoming, err := provider.Fixtures(ctx, competition, at.Add(-refreshBack), at.Add(refreshAhead))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fixtures: %w", err)
+	}
+	refs := make([]string, 0, len(incoming))
+	for _, f := range incoming {
+		refs = append(refs, f.ProviderRef)
+	}
+	stored, err := s.fixtures.FindByProviderRefs(ctx, competition.ID, refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixtures: %w", err)
+	}
+	byRef := make(map[string]*sports.Fixture, len(stored))
+	for _, f := range stored {
+		byRef[f.ProviderRef] = f
+	}
+
+	result := &RefreshResult{}
+	current := make([]*sports.Fixture, 0, len(incoming))
+	finished := false
+	for _, in := range incoming {
+		fixture, ok := byRef[in.ProviderRef]
+		if !ok {
+			id, err := shared.GenerateUUID()
+			if err != nil {
+				return nil, err
+			}
+			fixture = &sports.Fixture{ID: id, CompetitionID: competition.ID, ProviderRef: in.ProviderRef}
+		}
+		wasFinished := fixture.Status == sports.StatusFinished
+		if fixture.Merge(in, at) {
+			if err := s.fixtures.Save(ctx, fixture); err != nil {
+				return nil, fmt.Errorf("failed to save fixture: %w", err)
+			}
+			result.FixturesChanged++
+			finished = finished || (!wasFinished && fixture.Status == sports.StatusFinished)
+		}
+		current = append(current, fixture)
+	}
+
+	if result.TableChanged, err = s.refreshTable(ctx, provider, competition, finished, at); err != nil {
+		return nil, err
+	}
+	result.NextRefreshAt = at.Add(sports.NextRefresh(current, at))
+	return result, nil
+}
+
+func (s *Service) refreshTable(ctx context.Context, provider sports.Provider, competition *sports.Competition, finished bool, at time.Time) (bool, error) {
+	stored, err := s.tables.FindByCompetition(ctx, competition.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load table: %w", err)
+	}
+	if stored != nil && !finished && at.Sub(stored.UpdatedAt) < sports.RefreshIdle {
+		return false, nil
+	}
+
+	table, err := provider.Table(ctx, competition)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch table: %w", err)
+	}
+	if table.Equals(stored) {
+		return false, nil
+	}
+	table.CompetitionID = competition.ID
+	table.UpdatedAt = at
+	if err := s.tables.Save(ctx, table); err != nil {
+		return false, fmt.Errorf("failed to save table: %w", err)
+	}
+	return true, nil
+}
+
+// Fixtures lists a competition's fixtures kicking off in [from, to)
+func (s *Service) Fixtures(ctx context.Context, competitionID string, from, to time.Time) ([]*sports.Fixture, error) {
+	if _, err := s.Competition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+	fixtures, err := s.fixtures.FindByCompetition(ctx, competitionID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures: %w", err)
+	}
+	return fixtures, nil
+}
+
+func (s *Service) Fixture(ctx context.Context, fixtureID string) (*sports.Fixture, error) {
+	fixture, err := s.fixtures.FindByID(ctx, fixtureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixture: %w", err)
+	}
+	if fixture == nil {
+		return nil, ErrFixtureNotFound
+	}
+	return fixture, nil
+}
+
+func (s *Service) Table(ctx context.Context, competitionID string) (*sports.Table, error) {
+	if _, err := s.Competition(ctx, competitionID); err != nil {
+		return nil, err
+	}
+	table, err := s.tables.FindByCompetition(ctx, competitionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load table: %w", err)
+	}
+	if table == nil {
+		return nil, ErrTableNotFound
+	}
+	return table, nil
+}
+
+// Scoreboards loads the fixtures embedded in an article for the page to
+// hydrate its scoreboard blocks. Unknown IDs are left out.
+func (s *Service) Scoreboards(ctx context.Context, fixtureIDs []string) ([]*sports.Fixture, error) {
+	if len(fixtureIDs) > MaxScoreboards {
+		return nil, ErrTooManyScoreboards
+	}
+	if len(fixtureIDs) == 0 {
+		return nil, nil
+	}
+	fixtures, err := s.fixtures.FindByIDs(ctx, fixtureIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixtures: %w", err)
+	}
+	return fixtures, nil
+}
@@ -0,0 +1,196 @@
+package sports
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sports"
+)
+
+type memoryCompetitions struct {
+	competitions map[string]*sports.Competition
+}
+
+func (m *memoryCompetitions) Create(ctx context.Context, c *sports.Competition) error {
+	m.competitions[c.ID] = c
+	return nil
+}
+
+func (m *memoryCompetitions) Update(ctx context.Context, c *sports.Competition) error {
+	m.competitions[c.ID] = c
+	return nil
+}
+
+func (m *memoryCompetitions) FindByID(ctx context.Context, id string) (*sports.Competition, error) {
+	return m.competitions[id], nil
+}
+
+func (m *memoryCompetitions) FindAll(ctx context.Context) ([]*sports.Competition, error) {
+	var all []*sports.Competition
+	for _, c := range m.competitions {
+		all = append(all, c)
+	}
+	return all, nil
+}
+
+type memoryFixtures struct {
+	fixtures map[string]sports.Fixture
+	saves    int
+}
+
+func (m *memoryFixtures) Save(ctx context.Context, f *sports.Fixture) error {
+	m.fixtures[f.ID] = *f
+	m.saves++
+	return nil
+}
+
+func (m *memoryFixtures) FindByID(ctx context.Context, id string) (*sports.Fixture, error) {
+	if f, ok := m.fixtures[id]; ok {
+		return &f, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryFixtures) FindByCompetition(ctx context.Context, competitionID string, from, to time.Time) ([]*sports.Fixture, error) {
+	var found []*sports.Fixture
+	for _, f := range m.fixtures {
+		if f.CompetitionID == competitionID && !f.KickoffAt.Before(from) && f.KickoffAt.Before(to) {
+			copied := f
+			found = append(found, &copied)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryFixtures) FindByIDs(ctx context.Context, ids []string) ([]*sports.Fixture, error) {
+	var found []*sports.Fixture
+	for _, id := range ids {
+		if f, ok := m.fixtures[id]; ok {
+			found = append(found, &f)
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryFixtures) FindByProviderRefs(ctx context.Context, competitionID string, refs []string) ([]*sports.Fixture, error) {
+	var found []*sports.Fixture
+	for _, f := range m.fixtures {
+		for _, ref := range refs {
+			if f.CompetitionID == competitionID && f.ProviderRef == ref {
+				copied := f
+				found = append(found, &copied)
+			}
+		}
+	}
+	return found, nil
+}
+
+type memoryTables struct {
+	tables map[string]sports.Table
+}
+
+func (m *memoryTables) Save(ctx context.Context, t *sports.Table) error {
+	m.tables[t.CompetitionID] = *t
+	return nil
+}
+
+func (m *memoryTables) FindByCompetition(ctx context.Context, competitionID string) (*sports.Table, error) {
+	if t, ok := m.tables[competitionID]; ok {
+		return &t, nil
+	}
+	return nil, nil
+}
+
+type fakeProvider struct {
+	fixtures    []sports.Fixture
+	table       *sports.Table
+	tableCalls  int
+	fixturesErr error
+}
+
+func (f *fakeProvider) Fixtures(ctx context.Context, c *sports.Competition, from, to time.Time) ([]sports.Fixture, error) {
+	return f.fixtures, f.fixturesErr
+}
+
+func (f *fakeProvider) Table(ctx context.Context, c *sports.Competition) (*sports.Table, error) {
+	f.tableCalls++
+	copied := *f.table
+	return &copied, nil
+}
+
+func createTestService(t *testing.T) (*Service, *fakeProvider, *memoryFixtures) {
+	t.Helper()
+	provider := &fakeProvider{table: &sports.Table{Rows: []sports.Standing{{Position: 1, Team: sports.Team{ID: "1", Name: "Persib"}, Points: 3}}}}
+	fixtures := &memoryFixtures{fixtures: map[string]sports.Fixture{}}
+	service := NewService(&memoryCompetitions{competitions: map[string]*sports.Competition{}}, fixtures, &memoryTables{tables: map[string]sports.Table{}}, map[string]sports.Provider{"api-football": provider})
+	if _, err := service.CreateCompetition(context.Background(), "editor-1", "liga-1", "BRI Liga 1", "2025", "api-football", "274", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return service, provider, fixtures
+}
+
+func TestService_CreateCompetition(t *testing.T) {
+	service, _, _ := createTestService(t)
+	ctx := context.Background()
+
+	if _, err := service.CreateCompetition(ctx, "editor-1", "liga-1", "Liga 1", "2025", "api-football", "274", time.Now()); err != ErrCompetitionExists {
+		t.Errorf("expected error '%v', got '%v'", ErrCompetitionExists, err)
+	}
+	if _, err := service.CreateCompetition(ctx, "editor-1", "epl", "Premier League", "2025", "opta", "8", time.Now()); err != ErrUnknownProvider {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownProvider, err)
+	}
+}
+
+func TestService_Refresh(t *testing.T) {
+	service, provider, fixtures := createTestService(t)
+	ctx := context.Background()
+	at := time.Date(2026, 3, 7, 12, 30, 0, 0, time.UTC)
+	minute := 30
+
+	provider.fixtures = []sports.Fixture{
+		{ProviderRef: "9001", Home: sports.Team{ID: "1", Name: "Persib"}, Away: sports.Team{ID: "2", Name: "Persija"}, KickoffAt: at.Add(-30 * time.Minute), Status: sports.StatusLive, Score: &sports.Score{Home: 1}, Minute: &minute},
+		{ProviderRef: "9002", Home: sports.Team{ID: "3", Name: "Arema"}, Away: sports.Team{ID: "4", Name: "Bali United"}, KickoffAt: at.Add(72 * time.Hour), Status: sports.StatusScheduled},
+	}
+	result, err := service.Refresh(ctx, "liga-1", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.FixturesChanged != 2 || !result.TableChanged || !result.NextRefreshAt.Equal(at.Add(sports.RefreshLive)) {
+		t.Errorf("unexpected first refresh %+v", result)
+	}
+
+	result, _ = service.Refresh(ctx, "liga-1", at.Add(time.Minute))
+	if result.FixturesChanged != 0 || result.TableChanged || fixtures.saves != 2 || provider.tableCalls != 1 {
+		t.Errorf("expected an unchanged feed to write nothing, got %+v after %d saves", result, fixtures.saves)
+	}
+
+	provider.fixtures[0].Status, provider.fixtures[0].Minute = sports.StatusFinished, nil
+	provider.table = &sports.Table{Rows: []sports.Standing{{Position: 1, Team: sports.Team{ID: "1", Name: "Persib"}, Points: 6}}}
+	result, _ = service.Refresh(ctx, "liga-1", at.Add(2*time.Hour))
+	if result.FixturesChanged != 1 || !result.TableChanged || !result.NextRefreshAt.Equal(at.Add(2*time.Hour).Add(sports.RefreshIdle)) {
+		t.Errorf("expected the final whistle to refresh the table, got %+v", result)
+	}
+	if len(fixtures.fixtures) != 2 {
+		t.Errorf("expected fixtures matched by provider reference, got %d", len(fixtures.fixtures))
+	}
+
+	provider.fixturesErr = errors.New("rate limited")
+	if _, err := service.Refresh(ctx, "liga-1", at); err == nil {
+		t.Error("expected provider error")
+	}
+}
+
+func TestService_Scoreboards(t *testing.T) {
+	service, _, fixtures := createTestService(t)
+	fixtures.fixtures["f1"] = sports.Fixture{ID: "f1", CompetitionID: "liga-1", Status: sports.StatusLive}
+
+	found, err := service.Scoreboards(context.Background(), []string{"f1", "missing"})
+	if err != nil || len(found) != 1 {
+		t.Errorf("expected only known fixtures, got %v, %v", found, err)
+	}
+	if _, err := service.Scoreboards(context.Background(), make([]string, MaxScoreboards+1)); err != ErrTooManyScoreboards {
+		t.Errorf("expected error '%v', got '%v'", ErrTooManyScoreboards, err)
+	}
+}
@@ -0,0 +1,156 @@
+package style
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/style"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+var (
+	ErrRulesetNotFound = errors.New("ruleset not found")
+	ErrNotChiefEditor  = errors.New("only chief editors can change style rules")
+)
+
+// Permissions checks staff scopes, the entitlement service implements it
+type Permissions interface {
+	CanUseAPI(ctx context.Context, accountID string, scope entitlement.Scope, at time.Time) (entitlement.Decision, error)
+}
+
+// RuleInput is a rule as submitted by an editor
+type RuleInput struct {
+	Kind       string
+	Pattern    string
+	Suggestion string
+	Message    string
+	Severity   string
+}
+
+type Service struct {
+	rulesets    style.RulesetRepository
+	permissions Permissions
+}
+
+func NewService(rulesets style.RulesetRepository, permissions Permissions) *Service {
+	return &Service{rulesets: rulesets, permissions: permissions}
+}
+
+// Check lints a draft against the enabled rulesets for its language. Any
+// staff member may check; blocking findings are for the submit flow to enforce.
+func (s *Service) Check(ctx context.Context, language, text string) (*style.Report, error) {
+	lang, err := style.ParseLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+	rulesets, err := s.rulesets.FindEnabled(ctx, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rulesets: %w", err)
+	}
+	return style.Lint(rulesets, text, lang), nil
+}
+
+func (s *Service) Create(ctx context.Context, staffID, name, language string, inputs []RuleInput) (*style.Ruleset, error) {
+	if err := s.authorize(ctx, staffID); err != nil {
+		return nil, err
+	}
+	lang, rules, err := parse(language, inputs)
+	if err != nil {
+		return nil, err
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	ruleset, err := style.NewRuleset(id, name, lang, rules, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rulesets.Create(ctx, ruleset); err != nil {
+		return nil, fmt.Errorf("failed to create ruleset: %w", err)
+	}
+	return ruleset, nil
+}
+
+func (s *Service) Update(ctx context.Context, staffID, id, name, language string, inputs []RuleInput, enabled bool) (*style.Ruleset, error) {
+	if err := s.authorize(ctx, staffID); err != nil {
+		return nil, err
+	}
+	ruleset, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	lang, rules, err := parse(language, inputs)
+	if err != nil {
+		return nil, err
+	}
+	if err := ruleset.Update(name, lang, rules, enabled, staffID); err != nil {
+		return nil, err
+	}
+	if err := s.rulesets.Update(ctx, ruleset); err != nil {
+		return nil, fmt.Errorf("failed to update ruleset: %w", err)
+	}
+	return ruleset, nil
+}
+
+func (s *Service) Delete(ctx context.Context, staffID, id string) error {
+	if err := s.authorize(ctx, staffID); err != nil {
+		return err
+	}
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.rulesets.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete ruleset: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*style.Ruleset, error) {
+	ruleset, err := s.rulesets.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ruleset: %w", err)
+	}
+	if ruleset == nil {
+		return nil, ErrRulesetNotFound
+	}
+	return ruleset, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]*style.Ruleset, error) {
+	rulesets, err := s.rulesets.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rulesets: %w", err)
+	}
+	return rulesets, nil
+}
+
+func (s *Service) authorize(ctx context.Context, staffID string) error {
+	decision, err := s.permissions.CanUseAPI(ctx, staffID, style.ScopeManageRules, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to check permissions: %w", err)
+	}
+	if !decision.Allowed {
+		return ErrNotChiefEditor
+	}
+	return nil
+}
+
+func parse(language string, inputs []RuleInput) (style.Language, []style.Rule, error) {
+	lang, err := style.ParseLanguage(language)
+	if err != nil {
+		return "", nil, err
+	}
+	rules := make([]style.Rule, 0, len(inputs))
+	for i, in := range inputs {
+		rule, err := style.NewRule(style.Kind(in.Kind), in.Pattern, in.Suggestion, in.Message, style.Severity(in.Severity))
+		if err != nil {
+			return "", nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		rules = append(rules, rule)
+	}
+	return lang, rules, nil
+}
@@ -0,0 +1,139 @@
+package style
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/style"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+type memoryRulesets struct {
+	rulesets map[string]*style.Ruleset
+}
+
+func (m *memoryRulesets) Create(ctx context.Context, r *style.Ruleset) error {
+	m.rulesets[r.ID] = r
+	return nil
+}
+
+func (m *memoryRulesets) Update(ctx context.Context, r *style.Ruleset) error {
+	m.rulesets[r.ID] = r
+	return nil
+}
+
+func (m *memoryRulesets) Delete(ctx context.Context, id string) error {
+	delete(m.rulesets, id)
+	return nil
+}
+
+func (m *memoryRulesets) FindByID(ctx context.Context, id string) (*style.Ruleset, error) {
+	return m.rulesets[id], nil
+}
+
+func (m *memoryRulesets) FindAll(ctx context.Context) ([]*style.Ruleset, error) {
+	var all []*style.Ruleset
+	for _, r := range m.rulesets {
+		all = append(all, r)
+	}
+	return all, nil
+}
+
+func (m *memoryRulesets) FindEnabled(ctx context.Context, language style.Language) ([]*style.Ruleset, error) {
+	var enabled []*style.Ruleset
+	for _, r := range m.rulesets {
+		if r.AppliesTo(language) {
+			enabled = append(enabled, r)
+		}
+	}
+	return enabled, nil
+}
+
+// fakePermissions grants the scope to chief-1 only
+type fakePermissions struct{}
+
+func (fakePermissions) CanUseAPI(ctx context.Context, accountID string, scope entitlement.Scope, at time.Time) (entitlement.Decision, error) {
+	if accountID == "chief-1" && scope == style.ScopeManageRules {
+		return entitlement.Decision{Allowed: true, Reason: entitlement.ReasonRole}, nil
+	}
+	return entitlement.Decision{Allowed: false, Reason: entitlement.ReasonScopeMissing}, nil
+}
+
+func createTestService(t *testing.T) *Service {
+	t.Helper()
+	return NewService(&memoryRulesets{rulesets: map[string]*style.Ruleset{}}, fakePermissions{})
+}
+
+func TestService_Create(t *testing.T) {
+	testCases := []struct {
+		name        string
+		staffID     string
+		language    string
+		rules       []RuleInput
+		expectedErr error
+	}{
+		{"chief editor", "chief-1", "id", []RuleInput{{Kind: "banned_phrase", Pattern: "analisa", Suggestion: "analisis", Severity: "warning"}}, nil},
+		{"reporter", "reporter-1", "id", nil, ErrNotChiefEditor},
+		{"invalid language", "chief-1", "fr", nil, style.ErrInvalidLanguage},
+		{"invalid rule", "chief-1", "id", []RuleInput{{Kind: "banned_phrase", Pattern: "x", Severity: "fatal"}}, style.ErrInvalidSeverity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := createTestService(t).Create(context.Background(), tc.staffID, "Gaya rumah", tc.language, tc.rules)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestService_Check(t *testing.T) {
+	service := createTestService(t)
+	ctx := context.Background()
+	created, err := service.Create(ctx, "chief-1", "Gaya rumah", "id", []RuleInput{
+		{Kind: "banned_phrase", Pattern: "analisa", Suggestion: "analisis", Severity: "blocking"},
+		{Kind: "number_format", Severity: "warning"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := service.Check(ctx, "id", "Analisa 25000 pelanggan")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Findings) != 2 || !report.Blocked() {
+		t.Errorf("expected blocking and warning findings, got %+v", report.Findings)
+	}
+
+	if _, err := service.Update(ctx, "chief-1", created.ID, "Gaya rumah", "id", nil, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report, _ = service.Check(ctx, "id", "Analisa 25000 pelanggan")
+	if len(report.Findings) != 0 {
+		t.Errorf("expected disabled ruleset skipped, got %+v", report.Findings)
+	}
+
+	if _, err := service.Check(ctx, "fr", "x"); err != style.ErrInvalidLanguage {
+		t.Errorf("expected error '%v', got '%v'", style.ErrInvalidLanguage, err)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	service := createTestService(t)
+	ctx := context.Background()
+	created, _ := service.Create(ctx, "chief-1", "Gaya rumah", "", nil)
+
+	if err := service.Delete(ctx, "reporter-1", created.ID); err != ErrNotChiefEditor {
+		t.Errorf("expected error '%v', got '%v'", ErrNotChiefEditor, err)
+	}
+	if err := service.Delete(ctx, "chief-1", created.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Get(ctx, created.ID); err != ErrRulesetNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrRulesetNotFound, err)
+	}
+}
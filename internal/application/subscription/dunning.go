@@ -0,0 +1,254 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// DueBatchSize caps how many retries one job run makes
+const DueBatchSize = 200
+
+// The retry job holds a lock while it runs, so replicas that share the
+// schedule never charge a card twice. The lease is extended while a run goes on.
+const (
+	DunningLockKey = "subscription.dunning"
+	DunningLockTTL = time.Minute
+)
+
+// MaxMetricsRange keeps the growth report query bounded
+const MaxMetricsRange = 366 * 24 * time.Hour
+
+var (
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrInvalidMetricsRange  = errors.New("metrics range must be positive and at most a year")
+)
+
+// Entitlements drops a member's cached access once their subscription changes,
+// the entitlement service implements it
+type Entitlements interface {
+	Invalidate(ctx context.Context, accountID string) error
+}
+
+// DunningRunResult summarizes one retry job run
+type DunningRunResult struct {
+	Retried   int
+	Recovered int
+	Exhausted int
+	Failed    int // Retry could not be attempted, e.g. provider down; tried again next run
+}
+
+// RecoveryMetrics describes dunning outcomes for cases opened in a period
+type RecoveryMetrics struct {
+	Opened             int
+	Recovered          int
+	Exhausted          int
+	Canceled           int
+	StillOpen          int
+	RecoveryRate       float64          // Recovered / closed cases
+	RecoveredAmount    map[string]int64 // Minor units per currency
+	RecoveredByAttempt map[int]int      // Which retry succeeded
+	AvgHoursToRecover  float64
+}
+
+// DunningService runs the billing-failure workflow: retries, reminders and the final downgrade
+type DunningService struct {
+	subscriptions subscription.SubscriptionRepository
+	cases         subscription.DunningRepository
+	charger       subscription.Charger
+	notifier      subscription.DunningNotifier
+	entitlements  Entitlements
+	locker        shared.Locker
+}
+
+func NewDunningService(subscriptions subscription.SubscriptionRepository, cases subscription.DunningRepository, charger subscription.Charger, notifier subscription.DunningNotifier, entitlements Entitlements, locker shared.Locker) *DunningService {
+	return &DunningService{subscriptions: subscriptions, cases: cases, charger: charger, notifier: notifier, entitlements: entitlements, locker: locker}
+}
+
+// RenewalFailed opens a case when a scheduled renewal charge fails. The member
+// keeps premium access through the grace period. Calling it again for the same
+// subscription returns the open case.
+func (s *DunningService) RenewalFailed(ctx context.Context, subscriptionID, reason string, at time.Time) (*subscription.DunningCase, error) {
+	sub, err := s.subscriptions.FindByID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscription: %w", err)
+	}
+	if sub == nil {
+		return nil, ErrSubscriptionNotFound
+	}
+	if sub.IsStoreManaged() {
+		return nil, ErrStoreManaged
+	}
+	open, err := s.cases.FindOpenBySubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dunning case: %w", err)
+	}
+	if open != nil {
+		return open, nil
+	}
+
+	if err := sub.MarkPastDue(at); err != nil {
+		return nil, err
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	c, err := subscription.NewDunningCase(id, sub, reason, at)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptions.Update(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to update subscription: %w", err)
+	}
+	_ = s.entitlements.Invalidate(ctx, sub.AccountID)
+	s.remind(ctx, c, subscription.ReminderPaymentFailed, at)
+	if err := s.cases.Create(ctx, c); err != nil {
+		return nil, fmt.Errorf("failed to save dunning case: %w", err)
+	}
+	return c, nil
+}
+
+// RunDue retries every case whose next attempt is due. Failures for one case do
+// not stop the others. It returns shared.ErrLocked while another replica runs
+// the job.
+func (s *DunningService) RunDue(ctx context.Context, at time.Time) (*DunningRunResult, error) {
+	var result *DunningRunResult
+	err := s.locker.Run(ctx, DunningLockKey, DunningLockTTL, func(ctx context.Context) error {
+		var err error
+		result, err = s.runDue(ctx, at)
+		return err
+	})
+	return result, err
+}
+
+func (s *DunningService) runDue(ctx context.Context, at time.Time) (*DunningRunResult, error) {
+	due, err := s.cases.FindDue(ctx, at, DueBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load due dunning cases: %w", err)
+	}
+
+	result := &DunningRunResult{}
+	for _, c := range due {
+		if !c.IsDue(at) {
+			continue
+		}
+		if err := s.retry(ctx, c, at, result); err != nil {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+func (s *DunningService) retry(ctx context.Context, c *subscription.DunningCase, at time.Time, result *DunningRunResult) error {
+	sub, err := s.subscriptions.FindByID(ctx, c.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	if sub == nil {
+		return ErrSubscriptionNotFound
+	}
+	if sub.Status == subscription.StatusCanceled {
+		_ = c.Cancel(at)
+		return s.cases.Update(ctx, c)
+	}
+
+	intent, err := s.charger.Charge(ctx, c.AccountID, c.ChargeReference(), c.Amount)
+	if err != nil && !errors.Is(err, subscription.ErrChargeDeclined) {
+		// The provider did not answer, this is not a declined card and does not use up a retry
+		return err
+	}
+	result.Retried++
+
+	if err == nil && intent.IsSucceeded() {
+		if err := sub.Renew(sub.NextPeriodEnd(), at); err != nil {
+			return err
+		}
+		_ = c.Recover(at)
+		if err := s.subscriptions.Update(ctx, sub); err != nil {
+			return err
+		}
+		_ = s.entitlements.Invalidate(ctx, sub.AccountID)
+		s.remind(ctx, c, subscription.ReminderRecovered, at)
+		result.Recovered++
+		return s.cases.Update(ctx, c)
+	}
+
+	reason := "charge declined"
+	if intent != nil {
+		reason = string(intent.Status)
+	}
+	exhausted, err := c.RecordFailure(reason, at)
+	if err != nil {
+		return err
+	}
+	if exhausted {
+		if err := sub.Downgrade(at); err != nil {
+			return err
+		}
+		if err := s.subscriptions.Update(ctx, sub); err != nil {
+			return err
+		}
+		// Premium access ends now, not when the cached decision expires
+		_ = s.entitlements.Invalidate(ctx, sub.AccountID)
+		s.remind(ctx, c, subscription.ReminderDowngraded, at)
+		result.Exhausted++
+	} else if c.IsFinalRetryNext() && !c.HasSent(subscription.ReminderFinalNotice) {
+		s.remind(ctx, c, subscription.ReminderFinalNotice, at)
+	}
+	return s.cases.Update(ctx, c)
+}
+
+// remind sends a reminder and records it. A failed email must not block the
+// billing state change, the reminder is simply not recorded as sent.
+func (s *DunningService) remind(ctx context.Context, c *subscription.DunningCase, reminder subscription.Reminder, at time.Time) {
+	if err := s.notifier.SendDunningReminder(ctx, c, reminder); err != nil {
+		return
+	}
+	c.RecordReminder(reminder, at)
+}
+
+// Metrics reports how dunning cases opened in [from, to) turned out
+func (s *DunningService) Metrics(ctx context.Context, from, to time.Time) (*RecoveryMetrics, error) {
+	if !to.After(from) || to.Sub(from) > MaxMetricsRange {
+		return nil, ErrInvalidMetricsRange
+	}
+	cases, err := s.cases.FindOpenedBetween(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dunning cases: %w", err)
+	}
+
+	m := &RecoveryMetrics{
+		Opened:             len(cases),
+		RecoveredAmount:    make(map[string]int64),
+		RecoveredByAttempt: make(map[int]int),
+	}
+	var recoveryHours float64
+	for _, c := range cases {
+		switch c.Status {
+		case subscription.CaseRecovered:
+			m.Recovered++
+			m.RecoveredAmount[c.Amount.Currency()] += c.Amount.Amount()
+			m.RecoveredByAttempt[c.Attempts]++
+			recoveryHours += c.ClosedAt.Sub(c.OpenedAt).Hours()
+		case subscription.CaseExhausted:
+			m.Exhausted++
+		case subscription.CaseCanceled:
+			m.Canceled++
+		default:
+			m.StillOpen++
+		}
+	}
+	if closed := m.Recovered + m.Exhausted + m.Canceled; closed > 0 {
+		m.RecoveryRate = float64(m.Recovered) / float64(closed)
+	}
+	if m.Recovered > 0 {
+		m.AvgHoursToRecover = recoveryHours / float64(m.Recovered)
+	}
+	return m, nil
+}
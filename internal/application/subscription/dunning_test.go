@@ -0,0 +1,221 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type memorySubscriptions struct {
+	subscriptions map[string]*subscription.Subscription
+}
+
+func (m *memorySubscriptions) Create(ctx context.Context, s *subscription.Subscription) error {
+	m.subscriptions[s.ID] = s
+	return nil
+}
+
+func (m *memorySubscriptions) Update(ctx context.Context, s *subscription.Subscription) error {
+	m.subscriptions[s.ID] = s
+	return nil
+}
+
+func (m *memorySubscriptions) FindByID(ctx context.Context, id string) (*subscription.Subscription, error) {
+	return m.subscriptions[id], nil
+}
+
+func (m *memorySubscriptions) FindByExternalID(ctx context.Context, channel subscription.Channel, externalID string) (*subscription.Subscription, error) {
+	for _, s := range m.subscriptions {
+		if s.Channel == channel && s.ExternalID != nil && *s.ExternalID == externalID {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySubscriptions) FindByAccountID(ctx context.Context, accountID string) ([]*subscription.Subscription, error) {
+	var found []*subscription.Subscription
+	for _, s := range m.subscriptions {
+		if s.AccountID == accountID {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+type memoryCases struct {
+	cases []*subscription.DunningCase
+}
+
+func (m *memoryCases) Create(ctx context.Context, c *subscription.DunningCase) error {
+	m.cases = append(m.cases, c)
+	return nil
+}
+
+func (m *memoryCases) Update(ctx context.Context, c *subscription.DunningCase) error {
+	return nil
+}
+
+func (m *memoryCases) FindOpenBySubscription(ctx context.Context, subscriptionID string) (*subscription.DunningCase, error) {
+	for _, c := range m.cases {
+		if c.SubscriptionID == subscriptionID && c.Status == subscription.CaseOpen {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryCases) FindDue(ctx context.Context, at time.Time, limit int) ([]*subscription.DunningCase, error) {
+	var due []*subscription.DunningCase
+	for _, c := range m.cases {
+		if c.IsDue(at) {
+			due = append(due, c)
+		}
+	}
+	return due, nil
+}
+
+func (m *memoryCases) FindOpenedBetween(ctx context.Context, from, to time.Time) ([]*subscription.DunningCase, error) {
+	return m.cases, nil
+}
+
+// scriptedCharger answers each charge with the next scripted outcome
+type scriptedCharger struct {
+	outcomes   []error
+	charges    int
+	references []string
+}
+
+func (c *scriptedCharger) Charge(ctx context.Context, accountID, reference string, amount payment.Money) (*payment.PaymentIntent, error) {
+	c.references = append(c.references, reference)
+	outcome := c.outcomes[c.charges]
+	c.charges++
+	if outcome != nil {
+		return nil, outcome
+	}
+	return &payment.PaymentIntent{ID: "pi-1", Reference: reference, Amount: amount, Status: payment.IntentStatusSucceeded}, nil
+}
+
+// fakeEntitlements records the accounts whose cached access was dropped
+type fakeEntitlements struct {
+	invalidated []string
+}
+
+func (f *fakeEntitlements) Invalidate(ctx context.Context, accountID string) error {
+	f.invalidated = append(f.invalidated, accountID)
+	return nil
+}
+
+type fakeNotifier struct {
+	sent []subscription.Reminder
+}
+
+func (f *fakeNotifier) SendDunningReminder(ctx context.Context, c *subscription.DunningCase, reminder subscription.Reminder) error {
+	f.sent = append(f.sent, reminder)
+	return nil
+}
+
+func createDunningService(t *testing.T, outcomes ...error) (*DunningService, *memorySubscriptions, *fakeNotifier) {
+	price, _ := payment.NewMoney(49000, "IDR")
+	sub, err := subscription.NewSubscription("sub-1", "member-1", "premium-monthly", subscription.IntervalMonthly, *price, time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	subs := &memorySubscriptions{subscriptions: map[string]*subscription.Subscription{"sub-1": sub}}
+	notifier := &fakeNotifier{}
+	return NewDunningService(subs, &memoryCases{}, &scriptedCharger{outcomes: outcomes}, notifier, &fakeEntitlements{}, shared.NoLock{}), subs, notifier
+}
+
+func TestDunningService_RecoversOnRetry(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	service, subs, notifier := createDunningService(t, subscription.ErrChargeDeclined, errors.New("gateway timeout"), nil)
+
+	if _, err := service.RenewalFailed(ctx, "sub-1", "card_declined", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !subs.subscriptions["sub-1"].HasPremiumAccess() {
+		t.Error("expected access to continue during the grace period")
+	}
+
+	// Declined on the first retry, provider down on the second which must not use up a retry
+	first, _ := service.RunDue(ctx, at.Add(subscription.RetrySchedule[0]))
+	second, _ := service.RunDue(ctx, at.Add(subscription.RetrySchedule[1]))
+	third, _ := service.RunDue(ctx, at.Add(subscription.RetrySchedule[1]+time.Hour))
+	if first.Retried != 1 || second.Failed != 1 || third.Recovered != 1 {
+		t.Errorf("unexpected runs %+v %+v %+v", first, second, third)
+	}
+
+	sub := subs.subscriptions["sub-1"]
+	if sub.Status != subscription.StatusActive || !sub.CurrentPeriodEnd.Equal(time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected active subscription renewed on its anchor, got %s until %v", sub.Status, sub.CurrentPeriodEnd)
+	}
+	if len(notifier.sent) != 2 || notifier.sent[1] != subscription.ReminderRecovered {
+		t.Errorf("expected payment failed then recovered reminders, got %v", notifier.sent)
+	}
+
+	m, err := service.Metrics(ctx, at, at.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Recovered != 1 || m.RecoveryRate != 1 || m.RecoveredAmount["IDR"] != 49000 || m.RecoveredByAttempt[2] != 1 {
+		t.Errorf("unexpected metrics %+v", m)
+	}
+}
+
+func TestDunningService_DowngradesWhenExhausted(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	declines := make([]error, len(subscription.RetrySchedule))
+	for i := range declines {
+		declines[i] = subscription.ErrChargeDeclined
+	}
+	service, subs, notifier := createDunningService(t, declines...)
+
+	_, _ = service.RenewalFailed(ctx, "sub-1", "card_declined", at)
+	var exhausted int
+	for _, delay := range subscription.RetrySchedule {
+		result, err := service.RunDue(ctx, at.Add(delay))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exhausted += result.Exhausted
+	}
+
+	if exhausted != 1 || subs.subscriptions["sub-1"].Status != subscription.StatusDowngraded {
+		t.Errorf("expected a downgrade after the last retry, got %s", subs.subscriptions["sub-1"].Status)
+	}
+	// Once when the renewal failed, once on the downgrade
+	if invalidated := service.entitlements.(*fakeEntitlements).invalidated; len(invalidated) != 2 || invalidated[1] != "member-1" {
+		t.Errorf("expected the downgrade to invalidate entitlements, got %v", invalidated)
+	}
+	seen := map[string]bool{}
+	for _, ref := range service.charger.(*scriptedCharger).references {
+		if seen[ref] {
+			t.Errorf("expected a new charge reference per retry, %s was reused", ref)
+		}
+		seen[ref] = true
+	}
+	expected := []subscription.Reminder{subscription.ReminderPaymentFailed, subscription.ReminderFinalNotice, subscription.ReminderDowngraded}
+	if len(notifier.sent) != len(expected) {
+		t.Fatalf("expected reminders %v, got %v", expected, notifier.sent)
+	}
+	for i := range expected {
+		if notifier.sent[i] != expected[i] {
+			t.Errorf("expected reminders %v, got %v", expected, notifier.sent)
+		}
+	}
+
+	m, _ := service.Metrics(ctx, at, at.AddDate(0, 1, 0))
+	if m.Exhausted != 1 || m.RecoveryRate != 0 {
+		t.Errorf("unexpected metrics %+v", m)
+	}
+	if _, err := service.Metrics(ctx, at, at); err != ErrInvalidMetricsRange {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidMetricsRange, err)
+	}
+}
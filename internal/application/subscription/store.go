@@ -0,0 +1,143 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrUnsupportedChannel = errors.New("purchase channel is not configured")
+	ErrUnknownProduct     = errors.New("store product is not in the catalog")
+	ErrReceiptInUse       = errors.New("store purchase is linked to another account")
+	ErrStoreManaged       = errors.New("store subscriptions are retried by the store")
+)
+
+// StoreProduct maps a store product ID onto our plan
+type StoreProduct struct {
+	Plan     string
+	Interval subscription.Interval
+	Price    payment.Money // List price, for reporting; the store charges in the buyer's currency
+}
+
+// StoreService validates App Store and Play Store purchases and keeps the
+// matching subscriptions in step with the stores' server notifications
+type StoreService struct {
+	subscriptions subscription.SubscriptionRepository
+	verifiers     map[subscription.Channel]subscription.StoreVerifier
+	products      map[string]StoreProduct
+	entitlements  Entitlements
+}
+
+func NewStoreService(subscriptions subscription.SubscriptionRepository, verifiers map[subscription.Channel]subscription.StoreVerifier, products map[string]StoreProduct, entitlements Entitlements) *StoreService {
+	return &StoreService{subscriptions: subscriptions, verifiers: verifiers, products: products, entitlements: entitlements}
+}
+
+// ConfirmPurchase is called by the app right after a purchase. The receipt is
+// verified with the store before the member gets premium access.
+func (s *StoreService) ConfirmPurchase(ctx context.Context, accountID string, channel subscription.Channel, receipt subscription.StoreReceipt, at time.Time) (*subscription.Subscription, error) {
+	verifier, ok := s.verifiers[channel]
+	if !ok {
+		return nil, ErrUnsupportedChannel
+	}
+	event, err := verifier.VerifyPurchase(ctx, receipt)
+	if err != nil {
+		return nil, err
+	}
+	if event.AccountToken != nil && *event.AccountToken != accountID {
+		return nil, ErrReceiptInUse
+	}
+	return s.apply(ctx, accountID, event, at)
+}
+
+// HandleNotification applies an App Store Server Notification or Play
+// real-time developer notification. It returns nil for test notifications.
+func (s *StoreService) HandleNotification(ctx context.Context, channel subscription.Channel, payload []byte, at time.Time) (*subscription.Subscription, error) {
+	verifier, ok := s.verifiers[channel]
+	if !ok {
+		return nil, ErrUnsupportedChannel
+	}
+	event, err := verifier.VerifyNotification(ctx, payload)
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	accountID := ""
+	if event.AccountToken != nil {
+		accountID = *event.AccountToken
+	}
+	return s.apply(ctx, accountID, event, at)
+}
+
+// apply finds the subscription behind the event, creating it for a new purchase
+func (s *StoreService) apply(ctx context.Context, accountID string, event *subscription.StoreEvent, at time.Time) (*subscription.Subscription, error) {
+	sub, err := s.find(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	// A canceled subscription still ties the receipt to its account, so it cannot
+	// be replayed to give another account premium access
+	if sub != nil && accountID != "" && sub.AccountID != accountID {
+		return nil, ErrReceiptInUse
+	}
+
+	if sub != nil && sub.Status != subscription.StatusCanceled {
+		if err := sub.ApplyStoreEvent(*event, at); err != nil {
+			return nil, err
+		}
+		if err := s.subscriptions.Update(ctx, sub); err != nil {
+			return nil, fmt.Errorf("failed to update subscription: %w", err)
+		}
+		_ = s.entitlements.Invalidate(ctx, sub.AccountID)
+		return sub, nil
+	}
+
+	// A notification for a purchase we never saw and cannot attribute is dropped,
+	// the app confirms the purchase itself once the member signs in
+	if accountID == "" {
+		return nil, nil
+	}
+	product, ok := s.products[event.ProductID]
+	if !ok {
+		return nil, ErrUnknownProduct
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	if sub, err = subscription.NewStoreSubscription(id, accountID, product.Plan, product.Interval, product.Price, event.Channel, event.ExternalID, event.ExpiresAt); err != nil {
+		return nil, err
+	}
+	if event.State != subscription.StoreActive {
+		if err := sub.ApplyStoreEvent(*event, at); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.subscriptions.Create(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to save subscription: %w", err)
+	}
+	// A guest decision cached before the purchase would keep the paywall up
+	_ = s.entitlements.Invalidate(ctx, sub.AccountID)
+	return sub, nil
+}
+
+// find looks the subscription up by its store ID, following Google's linked
+// purchase token when an upgrade replaced the original purchase
+func (s *StoreService) find(ctx context.Context, event *subscription.StoreEvent) (*subscription.Subscription, error) {
+	sub, err := s.subscriptions.FindByExternalID(ctx, event.Channel, event.ExternalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subscription: %w", err)
+	}
+	if sub == nil && event.PreviousExternalID != nil {
+		if sub, err = s.subscriptions.FindByExternalID(ctx, event.Channel, *event.PreviousExternalID); err != nil {
+			return nil, fmt.Errorf("failed to load subscription: %w", err)
+		}
+	}
+	return sub, nil
+}
@@ -0,0 +1,138 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// fakeVerifier trusts receipts and notifications that are JSON-encoded store events
+type fakeVerifier struct{}
+
+func (fakeVerifier) VerifyPurchase(ctx context.Context, receipt subscription.StoreReceipt) (*subscription.StoreEvent, error) {
+	return decodeEvent([]byte(receipt.Token))
+}
+
+func (fakeVerifier) VerifyNotification(ctx context.Context, payload []byte) (*subscription.StoreEvent, error) {
+	return decodeEvent(payload)
+}
+
+func decodeEvent(raw []byte) (*subscription.StoreEvent, error) {
+	var event subscription.StoreEvent
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil, subscription.ErrInvalidReceipt
+	}
+	return &event, nil
+}
+
+func encodeEvent(t *testing.T, event subscription.StoreEvent) string {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to encode event: %v", err)
+	}
+	return string(raw)
+}
+
+func createStoreService(t *testing.T) (*StoreService, *memorySubscriptions) {
+	price, _ := payment.NewMoney(59000, "IDR")
+	subs := &memorySubscriptions{subscriptions: map[string]*subscription.Subscription{}}
+	verifiers := map[subscription.Channel]subscription.StoreVerifier{
+		subscription.ChannelAppStore:  fakeVerifier{},
+		subscription.ChannelPlayStore: fakeVerifier{},
+	}
+	products := map[string]StoreProduct{"premium.monthly": {Plan: "premium-monthly", Interval: subscription.IntervalMonthly, Price: *price}}
+	return NewStoreService(subs, verifiers, products, &fakeEntitlements{}), subs
+}
+
+func TestStoreService_PurchaseAndNotifications(t *testing.T) {
+	ctx := context.Background()
+	service, subs := createStoreService(t)
+	at := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	member := "member-1"
+	purchase := subscription.StoreEvent{Channel: subscription.ChannelAppStore, ExternalID: "2000000123", ProductID: "premium.monthly", State: subscription.StoreActive, ExpiresAt: at.AddDate(0, 1, 0), AccountToken: &member}
+
+	sub, err := service.ConfirmPurchase(ctx, "member-1", subscription.ChannelAppStore, subscription.StoreReceipt{ProductID: "premium.monthly", Token: encodeEvent(t, purchase)}, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !sub.HasPremiumAccess() || sub.Plan != "premium-monthly" || !sub.IsStoreManaged() {
+		t.Errorf("unexpected subscription %+v", sub)
+	}
+	if invalidated := service.entitlements.(*fakeEntitlements).invalidated; len(invalidated) != 1 || invalidated[0] != "member-1" {
+		t.Errorf("expected the purchase to invalidate entitlements, got %v", invalidated)
+	}
+
+	if _, err := service.ConfirmPurchase(ctx, "member-2", subscription.ChannelAppStore, subscription.StoreReceipt{Token: encodeEvent(t, purchase)}, at); err != ErrReceiptInUse {
+		t.Errorf("expected error '%v', got '%v'", ErrReceiptInUse, err)
+	}
+
+	// Notifications need not carry the account token, the store ID is enough
+	retry := subscription.StoreEvent{Channel: subscription.ChannelAppStore, ExternalID: "2000000123", State: subscription.StoreBillingRetry}
+	updated, err := service.HandleNotification(ctx, subscription.ChannelAppStore, []byte(encodeEvent(t, retry)), at.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.ID != sub.ID || updated.Status != subscription.StatusPastDue || !updated.HasPremiumAccess() {
+		t.Errorf("expected the same subscription in billing retry, got %s %s", updated.ID, updated.Status)
+	}
+
+	dunning := NewDunningService(subs, &memoryCases{}, &scriptedCharger{}, &fakeNotifier{}, &fakeEntitlements{}, shared.NoLock{})
+	if _, err := dunning.RenewalFailed(ctx, sub.ID, "declined", at); err != ErrStoreManaged {
+		t.Errorf("expected error '%v', got '%v'", ErrStoreManaged, err)
+	}
+}
+
+func TestStoreService_CanceledReceiptStaysWithItsAccount(t *testing.T) {
+	ctx := context.Background()
+	service, subs := createStoreService(t)
+	at := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	// Older receipts carry no account token, only the subscription row ties them to a member
+	purchase := subscription.StoreEvent{Channel: subscription.ChannelAppStore, ExternalID: "2000000456", ProductID: "premium.monthly", State: subscription.StoreActive, ExpiresAt: at.AddDate(0, 1, 0)}
+	receipt := subscription.StoreReceipt{Token: encodeEvent(t, purchase)}
+
+	sub, err := service.ConfirmPurchase(ctx, "member-1", subscription.ChannelAppStore, receipt, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	subs.subscriptions[sub.ID].Status = subscription.StatusCanceled
+
+	if _, err := service.ConfirmPurchase(ctx, "member-2", subscription.ChannelAppStore, receipt, at); err != ErrReceiptInUse {
+		t.Errorf("expected error '%v', got '%v'", ErrReceiptInUse, err)
+	}
+	if _, err := service.ConfirmPurchase(ctx, "member-1", subscription.ChannelAppStore, receipt, at); err != nil {
+		t.Errorf("expected the original account to restore its purchase, got %v", err)
+	}
+}
+
+func TestStoreService_PlayUpgradeFollowsLinkedToken(t *testing.T) {
+	ctx := context.Background()
+	service, subs := createStoreService(t)
+	at := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	member := "member-1"
+
+	first := subscription.StoreEvent{Channel: subscription.ChannelPlayStore, ExternalID: "token-a", ProductID: "premium.monthly", State: subscription.StoreActive, ExpiresAt: at.AddDate(0, 1, 0), AccountToken: &member}
+	sub, _ := service.ConfirmPurchase(ctx, member, subscription.ChannelPlayStore, subscription.StoreReceipt{Token: encodeEvent(t, first)}, at)
+
+	previous := "token-a"
+	upgraded := subscription.StoreEvent{Channel: subscription.ChannelPlayStore, ExternalID: "token-b", PreviousExternalID: &previous, ProductID: "premium.monthly", State: subscription.StoreActive, ExpiresAt: at.AddDate(0, 2, 0)}
+	updated, err := service.HandleNotification(ctx, subscription.ChannelPlayStore, []byte(encodeEvent(t, upgraded)), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.ID != sub.ID || *updated.ExternalID != "token-b" || len(subs.subscriptions) != 1 {
+		t.Errorf("expected the upgrade to relink the existing subscription, got %+v", updated)
+	}
+
+	orphan := subscription.StoreEvent{Channel: subscription.ChannelPlayStore, ExternalID: "token-z", ProductID: "premium.monthly", State: subscription.StoreActive}
+	if got, err := service.HandleNotification(ctx, subscription.ChannelPlayStore, []byte(encodeEvent(t, orphan)), at); got != nil || err != nil {
+		t.Errorf("expected an unattributed notification to be dropped, got %v %v", got, err)
+	}
+	if _, err := service.HandleNotification(ctx, subscription.ChannelWeb, nil, at); err != ErrUnsupportedChannel {
+		t.Errorf("expected error '%v', got '%v'", ErrUnsupportedChannel, err)
+	}
+}
@@ -0,0 +1,291 @@
+package survey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/survey"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrSurveyNotFound  = shared.NewDomainError("survey.not_found", shared.KindNotFound, "survey not found")
+	ErrUnknownReader   = shared.NewDomainError("survey.unknown_reader", shared.KindValidation, "reader needs a visitor ID or a session to take surveys")
+	ErrQuestionNotText = shared.NewDomainError("survey.question_not_text", shared.KindValidation, "question does not take text answers")
+)
+
+// TextAnswer is one free text answer, listed without its respondent
+type TextAnswer struct {
+	Text        string
+	SubmittedAt time.Time
+}
+
+// Service runs reader surveys. Pages with a survey block call Prompt, the
+// block then submits Respond or Dismiss.
+type Service struct {
+	surveys        survey.SurveyRepository
+	participations survey.ParticipationRepository
+	responses      survey.ResponseRepository
+	tx             shared.Transactor
+	clock          shared.Clock
+}
+
+func NewService(surveys survey.SurveyRepository, participations survey.ParticipationRepository, responses survey.ResponseRepository, tx shared.Transactor, clock shared.Clock) *Service {
+	return &Service{surveys: surveys, participations: participations, responses: responses, tx: tx, clock: clock}
+}
+
+func (s *Service) Create(ctx context.Context, staffID, title string, questions []survey.Question, targeting survey.Targeting, frequency survey.Cap, anonymity survey.Anonymity) (*survey.Survey, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	sv, err := survey.NewSurvey(id, title, questions, targeting, frequency, anonymity, staffID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.surveys.Create(ctx, sv); err != nil {
+		return nil, fmt.Errorf("failed to save survey: %w", err)
+	}
+	return sv, nil
+}
+
+func (s *Service) Edit(ctx context.Context, id, title string, questions []survey.Question, targeting survey.Targeting, frequency survey.Cap, anonymity survey.Anonymity) (*survey.Survey, error) {
+	sv, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := sv.Edit(title, questions, targeting, frequency, anonymity, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.surveys.Update(ctx, sv); err != nil {
+		return nil, fmt.Errorf("failed to save survey: %w", err)
+	}
+	return sv, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*survey.Survey, error) {
+	sv, err := s.surveys.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load survey: %w", err)
+	}
+	if sv == nil {
+		return nil, ErrSurveyNotFound
+	}
+	return sv, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]*survey.Survey, error) {
+	all, err := s.surveys.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load surveys: %w", err)
+	}
+	return all, nil
+}
+
+func (s *Service) Open(ctx context.Context, id string) (*survey.Survey, error) {
+	sv, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := sv.Open(s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.surveys.Update(ctx, sv); err != nil {
+		return nil, fmt.Errorf("failed to save survey: %w", err)
+	}
+	return sv, nil
+}
+
+func (s *Service) Close(ctx context.Context, id string) (*survey.Survey, error) {
+	sv, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := sv.Close(s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.surveys.Update(ctx, sv); err != nil {
+		return nil, fmt.Errorf("failed to save survey: %w", err)
+	}
+	return sv, nil
+}
+
+// Prompt picks the survey to show the reader on the page and counts the
+// prompt, nil when there is none. A survey pinned by an embed in the article
+// ignores targeting, frequency caps still apply to it: no reader is asked
+// more than once per MinGapBetweenSurveys, whichever survey it is.
+func (s *Service) Prompt(ctx context.Context, reader survey.Reader, page survey.Page, pinnedID string) (*survey.Survey, error) {
+	key := reader.Key()
+	if key == "" {
+		return nil, nil
+	}
+	now := s.clock.Now()
+	last, err := s.participations.LastPromptAt(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load last prompt: %w", err)
+	}
+	if last != nil && now.Sub(*last) < survey.MinGapBetweenSurveys {
+		return nil, nil
+	}
+
+	candidates, err := s.candidates(ctx, reader, page, pinnedID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sv := range candidates {
+		p, err := s.participation(ctx, sv.ID, key)
+		if err != nil {
+			return nil, err
+		}
+		if !p.CanPrompt(sv.Cap, now) {
+			continue
+		}
+		p.Prompt(now)
+		if err := s.participations.Save(ctx, p); err != nil {
+			return nil, fmt.Errorf("failed to save participation: %w", err)
+		}
+		return sv, nil
+	}
+	return nil, nil
+}
+
+func (s *Service) candidates(ctx context.Context, reader survey.Reader, page survey.Page, pinnedID string) ([]*survey.Survey, error) {
+	if pinnedID != "" {
+		sv, err := s.surveys.FindByID(ctx, pinnedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load survey: %w", err)
+		}
+		if sv == nil || sv.Status != survey.StatusActive {
+			return nil, nil
+		}
+		return []*survey.Survey{sv}, nil
+	}
+
+	active, err := s.surveys.FindActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load active surveys: %w", err)
+	}
+	var targeted []*survey.Survey
+	for _, sv := range active {
+		if sv.Targets(page, reader) {
+			targeted = append(targeted, sv)
+		}
+	}
+	return targeted, nil
+}
+
+// Respond saves the reader's answers, each reader answers a survey once
+func (s *Service) Respond(ctx context.Context, surveyID string, reader survey.Reader, page survey.Page, answers []survey.Answer) (*survey.Response, error) {
+	key := reader.Key()
+	if key == "" {
+		return nil, ErrUnknownReader
+	}
+	sv, err := s.Get(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.participation(ctx, sv.ID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	response, err := survey.NewResponse(id, sv, answers, reader, page, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Finish(now); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.responses.Create(ctx, response); err != nil {
+			return fmt.Errorf("failed to save response: %w", err)
+		}
+		if err := s.participations.Save(ctx, p); err != nil {
+			return fmt.Errorf("failed to save participation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Dismiss stops asking the reader to take the survey
+func (s *Service) Dismiss(ctx context.Context, surveyID string, reader survey.Reader) error {
+	key := reader.Key()
+	if key == "" {
+		return ErrUnknownReader
+	}
+	sv, err := s.Get(ctx, surveyID)
+	if err != nil {
+		return err
+	}
+	p, err := s.participation(ctx, sv.ID, key)
+	if err != nil {
+		return err
+	}
+	if err := p.Finish(s.clock.Now()); err != nil {
+		return err
+	}
+	if err := s.participations.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save participation: %w", err)
+	}
+	return nil
+}
+
+// Results aggregates the responses submitted in [from, to), a zero from
+// counts every response
+func (s *Service) Results(ctx context.Context, id string, from, to time.Time) (*survey.Summary, error) {
+	sv, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := s.responses.FindBySurvey(ctx, sv.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load responses: %w", err)
+	}
+	summary := survey.Summarize(sv, responses)
+	return &summary, nil
+}
+
+// TextAnswers lists the free text answers to one question, newest first
+func (s *Service) TextAnswers(ctx context.Context, id, questionID string, from, to time.Time) ([]TextAnswer, error) {
+	sv, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if q, ok := sv.Question(questionID); !ok || q.Kind != survey.KindText {
+		return nil, ErrQuestionNotText
+	}
+	responses, err := s.responses.FindBySurvey(ctx, sv.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load responses: %w", err)
+	}
+	var answers []TextAnswer
+	for i := len(responses) - 1; i >= 0; i-- {
+		if a, ok := responses[i].Answer(questionID); ok {
+			answers = append(answers, TextAnswer{Text: a.Text, SubmittedAt: responses[i].SubmittedAt})
+		}
+	}
+	return answers, nil
+}
+
+// participation loads the reader's participation, a new one when never prompted
+func (s *Service) participation(ctx context.Context, surveyID string, key survey.ReaderKey) (*survey.Participation, error) {
+	p, err := s.participations.Find(ctx, surveyID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load participation: %w", err)
+	}
+	if p == nil {
+		p = survey.NewParticipation(surveyID, key)
+	}
+	return p, nil
+}
@@ -0,0 +1,219 @@
+package survey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/survey"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type memorySurveys struct {
+	byID map[string]*survey.Survey
+}
+
+func (m *memorySurveys) Create(ctx context.Context, s *survey.Survey) error {
+	m.byID[s.ID] = s
+	return nil
+}
+
+func (m *memorySurveys) Update(ctx context.Context, s *survey.Survey) error {
+	m.byID[s.ID] = s
+	return nil
+}
+
+func (m *memorySurveys) FindByID(ctx context.Context, id string) (*survey.Survey, error) {
+	return m.byID[id], nil
+}
+
+func (m *memorySurveys) FindAll(ctx context.Context) ([]*survey.Survey, error) {
+	var all []*survey.Survey
+	for _, s := range m.byID {
+		all = append(all, s)
+	}
+	return all, nil
+}
+
+func (m *memorySurveys) FindActive(ctx context.Context) ([]*survey.Survey, error) {
+	var active []*survey.Survey
+	for _, s := range m.byID {
+		if s.Status == survey.StatusActive {
+			active = append(active, s)
+		}
+	}
+	return active, nil
+}
+
+type memoryParticipations struct {
+	byKey map[string]survey.Participation
+}
+
+func (m *memoryParticipations) Save(ctx context.Context, p *survey.Participation) error {
+	m.byKey[p.SurveyID+"/"+string(p.ReaderKey)] = *p
+	return nil
+}
+
+func (m *memoryParticipations) Find(ctx context.Context, surveyID string, key survey.ReaderKey) (*survey.Participation, error) {
+	p, ok := m.byKey[surveyID+"/"+string(key)]
+	if !ok {
+		return nil, nil
+	}
+	return &p, nil
+}
+
+func (m *memoryParticipations) LastPromptAt(ctx context.Context, key survey.ReaderKey) (*time.Time, error) {
+	var last *time.Time
+	for _, p := range m.byKey {
+		if p.ReaderKey == key && p.LastPromptAt != nil && (last == nil || p.LastPromptAt.After(*last)) {
+			last = p.LastPromptAt
+		}
+	}
+	return last, nil
+}
+
+type memoryResponses struct {
+	responses []*survey.Response
+}
+
+func (m *memoryResponses) Create(ctx context.Context, r *survey.Response) error {
+	m.responses = append(m.responses, r)
+	return nil
+}
+
+func (m *memoryResponses) FindBySurvey(ctx context.Context, surveyID string, from, to time.Time) ([]*survey.Response, error) {
+	var found []*survey.Response
+	for _, r := range m.responses {
+		if r.SurveyID == surveyID && !r.SubmittedAt.Before(from) && r.SubmittedAt.Before(to) {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+var testNow = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+func newTestService() (*Service, *memoryResponses, *shared.FrozenClock) {
+	responses := &memoryResponses{}
+	clock := shared.NewFrozenClock(testNow)
+	service := NewService(
+		&memorySurveys{byID: map[string]*survey.Survey{}},
+		&memoryParticipations{byKey: map[string]survey.Participation{}},
+		responses,
+		shared.NoTransaction{},
+		clock,
+	)
+	return service, responses, clock
+}
+
+func createSurvey(t *testing.T, service *Service, placements []survey.Placement) *survey.Survey {
+	t.Helper()
+	nps, _ := survey.NewQuestion("nps", survey.KindNPS, "How likely are you to recommend us to a friend?", nil, true)
+	why, _ := survey.NewQuestion("why", survey.KindText, "What should we improve?", nil, false)
+	targeting, _ := survey.NewTargeting(placements, nil, survey.AudienceEveryone, 100)
+	frequency, _ := survey.NewCap(2, 24*time.Hour)
+	s, err := service.Create(context.Background(), "pm-1", "Reader NPS", []survey.Question{nps, why}, targeting, frequency, survey.AnonymityAnonymous)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Open(context.Background(), s.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return s
+}
+
+func TestService_PromptFrequencyCaps(t *testing.T) {
+	service, _, clock := newTestService()
+	ctx := context.Background()
+	homepage := createSurvey(t, service, []survey.Placement{survey.PlacementHomepage})
+	reader, page := survey.Reader{VisitorID: "v1"}, survey.Page{Placement: survey.PlacementHomepage}
+
+	if s, _ := service.Prompt(ctx, reader, survey.Page{Placement: survey.PlacementArticle}, ""); s != nil {
+		t.Error("expected no survey on an untargeted placement")
+	}
+	if s, err := service.Prompt(ctx, reader, page, ""); err != nil || s == nil || s.ID != homepage.ID {
+		t.Fatalf("expected the homepage survey, got %v, %v", s, err)
+	}
+	clock.Set(testNow.Add(48 * time.Hour))
+	if s, _ := service.Prompt(ctx, reader, page, ""); s != nil {
+		t.Error("expected the gap between surveys to hold")
+	}
+	clock.Set(testNow.Add(survey.MinGapBetweenSurveys))
+	if s, _ := service.Prompt(ctx, reader, page, ""); s == nil {
+		t.Error("expected a second prompt once the gap passed")
+	}
+	clock.Set(testNow.Add(3 * survey.MinGapBetweenSurveys))
+	if s, _ := service.Prompt(ctx, reader, page, ""); s != nil {
+		t.Error("expected no more than the survey's cap of prompts")
+	}
+	if s, _ := service.Prompt(ctx, survey.Reader{}, page, ""); s != nil {
+		t.Error("expected readers without a visitor ID never prompted")
+	}
+}
+
+func TestService_PromptPinned(t *testing.T) {
+	service, _, _ := newTestService()
+	ctx := context.Background()
+	pinned := createSurvey(t, service, []survey.Placement{survey.PlacementHomepage})
+	article := survey.Page{Placement: survey.PlacementArticle, ArticleID: "a1"}
+
+	s, err := service.Prompt(ctx, survey.Reader{VisitorID: "v1"}, article, pinned.ID)
+	if err != nil || s == nil || s.ID != pinned.ID {
+		t.Fatalf("expected the embedded survey regardless of targeting, got %v, %v", s, err)
+	}
+	if s, _ := service.Prompt(ctx, survey.Reader{VisitorID: "v2"}, article, "missing"); s != nil {
+		t.Error("expected nothing for an unknown embedded survey")
+	}
+}
+
+func TestService_RespondAndResults(t *testing.T) {
+	service, responses, clock := newTestService()
+	ctx := context.Background()
+	s := createSurvey(t, service, []survey.Placement{survey.PlacementArticle})
+	page := survey.Page{Placement: survey.PlacementArticle, ArticleID: "a1"}
+	nine, three := 9, 3
+
+	if _, err := service.Respond(ctx, s.ID, survey.Reader{VisitorID: "v1"}, page, []survey.Answer{{QuestionID: "nps", Score: &nine}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Respond(ctx, s.ID, survey.Reader{VisitorID: "v1"}, page, []survey.Answer{{QuestionID: "nps", Score: &nine}}); err != survey.ErrAlreadyAnswered {
+		t.Errorf("expected ErrAlreadyAnswered, got %v", err)
+	}
+	clock.Set(testNow.AddDate(0, 0, 1))
+	if _, err := service.Respond(ctx, s.ID, survey.Reader{VisitorID: "v2"}, page, []survey.Answer{{QuestionID: "nps", Score: &three}, {QuestionID: "why", Text: "Too many ads"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Respond(ctx, s.ID, survey.Reader{}, page, nil); err != ErrUnknownReader {
+		t.Errorf("expected ErrUnknownReader, got %v", err)
+	}
+	if len(responses.responses) != 2 || responses.responses[0].Respondent != "" {
+		t.Errorf("expected two anonymous responses, got %+v", responses.responses)
+	}
+
+	if err := service.Dismiss(ctx, s.ID, survey.Reader{VisitorID: "v3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p, _ := service.Prompt(ctx, survey.Reader{VisitorID: "v3"}, page, ""); p != nil {
+		t.Error("expected a dismissed survey never prompted again")
+	}
+
+	summary, err := service.Results(ctx, s.ID, time.Time{}, testNow.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Responses != 2 || summary.Questions[0].NPS.Score() != 0 {
+		t.Errorf("expected one promoter and one detractor, got %+v", summary.Questions[0].NPS)
+	}
+	firstDay, _ := service.Results(ctx, s.ID, testNow.Truncate(24*time.Hour), testNow.AddDate(0, 0, 1).Truncate(24*time.Hour))
+	if firstDay.Responses != 1 {
+		t.Errorf("expected one response on the first day, got %d", firstDay.Responses)
+	}
+
+	texts, err := service.TextAnswers(ctx, s.ID, "why", time.Time{}, testNow.AddDate(0, 0, 2))
+	if err != nil || len(texts) != 1 || texts[0].Text != "Too many ads" {
+		t.Errorf("unexpected text answers %v, %v", texts, err)
+	}
+	if _, err := service.TextAnswers(ctx, s.ID, "nps", time.Time{}, testNow); err != ErrQuestionNotText {
+		t.Errorf("expected ErrQuestionNotText, got %v", err)
+	}
+}
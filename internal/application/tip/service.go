@@ -0,0 +1,224 @@
+package tip
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/tip"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// checkCodeBytes gives 120 random bits, too many to guess without IP-based rate limiting
+const checkCodeBytes = 15
+
+var (
+	ErrTipNotFound = shared.NewDomainError("tip.not_found", shared.KindNotFound, "tip not found")
+	ErrInvalidCode = shared.NewDomainError("tip.invalid_code", shared.KindNotFound, "invalid or already used code")
+)
+
+// codeEncoding avoids lowercase so codes survive being read aloud or written down
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// AttachmentInput is an uploaded file as received, before stripping and sealing
+type AttachmentInput struct {
+	ContentType string
+	Content     []byte
+}
+
+// Receipt is what the tipster gets back, the code is shown once and never stored
+type Receipt struct {
+	Code string
+}
+
+// Conversation is what a tipster sees when checking in. Each code works once:
+// NextCode replaces the one just used.
+type Conversation struct {
+	Replies  []tip.Reply
+	Closed   bool
+	NextCode string
+}
+
+// Service runs the anonymous tip line. It never sees who a tipster is: callers
+// pass only the message and files, which are stripped and sealed before storage.
+type Service struct {
+	tips     tip.TipRepository
+	sealer   tip.Sealer
+	stripper tip.MetadataStripper
+}
+
+func NewService(tips tip.TipRepository, sealer tip.Sealer, stripper tip.MetadataStripper) *Service {
+	return &Service{tips: tips, sealer: sealer, stripper: stripper}
+}
+
+// Submit strips and seals a tip and returns the code the tipster checks in with
+func (s *Service) Submit(ctx context.Context, message string, attachments []AttachmentInput, at time.Time) (*Receipt, error) {
+	if err := tip.ValidateMessage(message); err != nil {
+		return nil, err
+	}
+	if len(attachments) > tip.MaxAttachments {
+		return nil, tip.ErrTooManyAttachments
+	}
+
+	sealedMessage, err := s.sealer.Seal([]byte(message))
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal message: %w", err)
+	}
+	sealedAttachments := make([]tip.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		sealed, err := s.sealAttachment(a)
+		if err != nil {
+			return nil, err
+		}
+		sealedAttachments = append(sealedAttachments, *sealed)
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	code, codeHash, err := newCheckCode()
+	if err != nil {
+		return nil, err
+	}
+	t, err := tip.NewTip(id, sealedMessage, sealedAttachments, codeHash, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tips.Create(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to save tip: %w", err)
+	}
+	return &Receipt{Code: code}, nil
+}
+
+func (s *Service) sealAttachment(a AttachmentInput) (*tip.Attachment, error) {
+	if len(a.Content) > tip.MaxAttachmentSize {
+		return nil, tip.ErrAttachmentTooLarge
+	}
+	if !tip.IsSupportedContentType(a.ContentType) {
+		return nil, tip.ErrUnsupportedAttachment
+	}
+	stripped, err := s.stripper.Strip(a.ContentType, a.Content)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := s.sealer.Seal(stripped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal attachment: %w", err)
+	}
+	return &tip.Attachment{ContentType: a.ContentType, Size: int64(len(stripped)), Sealed: sealed}, nil
+}
+
+// Check shows the tipster the newsroom's replies and swaps their code for a new one
+func (s *Service) Check(ctx context.Context, code string) (*Conversation, error) {
+	t, err := s.tips.FindByCodeHash(ctx, hashCheckCode(code))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tip: %w", err)
+	}
+	if t == nil {
+		return nil, ErrInvalidCode
+	}
+
+	next, nextHash, err := newCheckCode()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.RotateCode(nextHash); err != nil {
+		return nil, err
+	}
+	if err := s.tips.Update(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to update tip: %w", err)
+	}
+	return &Conversation{Replies: t.Replies, Closed: t.IsClosed(), NextCode: next}, nil
+}
+
+// Inbox lists tips for the editors, still sealed: they are opened offline with the newsroom key
+func (s *Service) Inbox(ctx context.Context, limit, offset int) ([]*tip.Tip, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	tips, err := s.tips.FindInbox(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tips: %w", err)
+	}
+	return tips, nil
+}
+
+func (s *Service) Get(ctx context.Context, tipID string) (*tip.Tip, error) {
+	return s.find(ctx, tipID)
+}
+
+// Reply answers the tipster, they see it the next time they check in
+func (s *Service) Reply(ctx context.Context, editorID, tipID, body string, at time.Time) (*tip.Tip, error) {
+	t, err := s.find(ctx, tipID)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Reply(editorID, body, at); err != nil {
+		return nil, err
+	}
+	if err := s.tips.Update(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to update tip: %w", err)
+	}
+	return t, nil
+}
+
+func (s *Service) Close(ctx context.Context, editorID, tipID string, at time.Time) (*tip.Tip, error) {
+	t, err := s.find(ctx, tipID)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Close(editorID, at); err != nil {
+		return nil, err
+	}
+	if err := s.tips.Update(ctx, t); err != nil {
+		return nil, fmt.Errorf("failed to update tip: %w", err)
+	}
+	return t, nil
+}
+
+func (s *Service) find(ctx context.Context, tipID string) (*tip.Tip, error) {
+	t, err := s.tips.FindByID(ctx, tipID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tip: %w", err)
+	}
+	if t == nil {
+		return nil, ErrTipNotFound
+	}
+	return t, nil
+}
+
+// newCheckCode returns a code like "ABCD-EFGH-..." for the tipster and the hash to store
+func newCheckCode() (string, string, error) {
+	raw := make([]byte, checkCodeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate check code: %w", err)
+	}
+	encoded := codeEncoding.EncodeToString(raw)
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:min(i+4, len(encoded))])
+	}
+	code := strings.Join(groups, "-")
+	return code, hashCheckCode(code), nil
+}
+
+// hashCheckCode ignores case, spaces and dashes so a code typed back by hand still matches
+func hashCheckCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
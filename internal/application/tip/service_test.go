@@ -0,0 +1,173 @@
+package tip
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/tip"
+)
+
+type memoryTips struct {
+	tips map[string]*tip.Tip
+}
+
+func newMemoryTips() *memoryTips {
+	return &memoryTips{tips: map[string]*tip.Tip{}}
+}
+
+func (m *memoryTips) Create(ctx context.Context, t *tip.Tip) error {
+	m.tips[t.ID] = t
+	return nil
+}
+
+func (m *memoryTips) Update(ctx context.Context, t *tip.Tip) error {
+	m.tips[t.ID] = t
+	return nil
+}
+
+func (m *memoryTips) FindByID(ctx context.Context, id string) (*tip.Tip, error) {
+	return m.tips[id], nil
+}
+
+func (m *memoryTips) FindByCodeHash(ctx context.Context, codeHash string) (*tip.Tip, error) {
+	for _, t := range m.tips {
+		if t.CodeHash == codeHash {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryTips) FindInbox(ctx context.Context, limit, offset int) ([]*tip.Tip, error) {
+	var inbox []*tip.Tip
+	for _, t := range m.tips {
+		inbox = append(inbox, t)
+	}
+	return inbox, nil
+}
+
+// reverseSealer stands in for public-key sealing, it only has to differ from the plaintext
+type reverseSealer struct{}
+
+func (reverseSealer) Seal(plaintext []byte) ([]byte, error) {
+	sealed := make([]byte, len(plaintext))
+	for i, b := range plaintext {
+		sealed[len(plaintext)-1-i] = b
+	}
+	return sealed, nil
+}
+
+// fakeStripper drops everything after a "META" marker
+type fakeStripper struct{}
+
+func (fakeStripper) Strip(contentType string, content []byte) ([]byte, error) {
+	if bytes.HasPrefix(content, []byte("garbage")) {
+		return nil, tip.ErrUnreadableAttachment
+	}
+	stripped, _, _ := bytes.Cut(content, []byte("META"))
+	return stripped, nil
+}
+
+func createTestService() (*Service, *memoryTips) {
+	tips := newMemoryTips()
+	return NewService(tips, reverseSealer{}, fakeStripper{}), tips
+}
+
+func onlyTip(t *testing.T, tips *memoryTips) *tip.Tip {
+	t.Helper()
+	if len(tips.tips) != 1 {
+		t.Fatalf("expected one stored tip, got %d", len(tips.tips))
+	}
+	for _, stored := range tips.tips {
+		return stored
+	}
+	return nil
+}
+
+func TestService_Submit(t *testing.T) {
+	ctx := context.Background()
+	service, tips := createTestService()
+
+	receipt, err := service.Submit(ctx, "abc", []AttachmentInput{{ContentType: tip.ContentTypeJPEG, Content: []byte("pixelsMETAgps")}}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(strings.Split(receipt.Code, "-")) != 6 {
+		t.Errorf("expected a code of six groups, got %s", receipt.Code)
+	}
+
+	stored := onlyTip(t, tips)
+	if string(stored.Message) != "cba" {
+		t.Errorf("expected the message stored sealed, got %q", stored.Message)
+	}
+	if a := stored.Attachments[0]; string(a.Sealed) != "slexip" || a.Size != 6 {
+		t.Errorf("expected the attachment stripped then sealed, got %+v", a)
+	}
+	if strings.Contains(stored.CodeHash, receipt.Code) || stored.CodeHash == "" {
+		t.Errorf("expected only the code hash stored, got %s", stored.CodeHash)
+	}
+
+	testCases := []struct {
+		name          string
+		message       string
+		attachments   []AttachmentInput
+		expectedError error
+	}{
+		{"empty message", " ", nil, tip.ErrEmptyMessage},
+		{"too many attachments", "hello", make([]AttachmentInput, tip.MaxAttachments+1), tip.ErrTooManyAttachments},
+		{"unsupported type", "hello", []AttachmentInput{{ContentType: "application/msword", Content: []byte("doc")}}, tip.ErrUnsupportedAttachment},
+		{"too large", "hello", []AttachmentInput{{ContentType: tip.ContentTypePNG, Content: make([]byte, tip.MaxAttachmentSize+1)}}, tip.ErrAttachmentTooLarge},
+		{"unreadable", "hello", []AttachmentInput{{ContentType: tip.ContentTypePNG, Content: []byte("garbage")}}, tip.ErrUnreadableAttachment},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := service.Submit(ctx, tc.message, tc.attachments, time.Now()); !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+	if len(tips.tips) != 1 {
+		t.Errorf("expected rejected tips not stored, got %d", len(tips.tips))
+	}
+}
+
+func TestService_Check(t *testing.T) {
+	ctx := context.Background()
+	service, tips := createTestService()
+	receipt, _ := service.Submit(ctx, "The tender was rigged", nil, time.Now())
+	stored := onlyTip(t, tips)
+
+	if _, err := service.Reply(ctx, "editor-1", stored.ID, "Can you share the invoice?", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Codes typed back by hand still match
+	typed := strings.ToLower(strings.ReplaceAll(receipt.Code, "-", " "))
+	conversation, err := service.Check(ctx, typed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conversation.Replies) != 1 || conversation.Closed || conversation.NextCode == receipt.Code {
+		t.Errorf("expected one reply and a fresh code, got %+v", conversation)
+	}
+
+	if _, err := service.Check(ctx, receipt.Code); err != ErrInvalidCode {
+		t.Errorf("expected a used code rejected, got '%v'", err)
+	}
+
+	if _, err := service.Close(ctx, "editor-1", stored.ID, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conversation, err = service.Check(ctx, conversation.NextCode)
+	if err != nil || !conversation.Closed {
+		t.Errorf("expected the tipster to see the tip closed, got %+v, %v", conversation, err)
+	}
+
+	if _, err := service.Reply(ctx, "editor-1", "missing", "Hello", time.Now()); err != ErrTipNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrTipNotFound, err)
+	}
+}
@@ -0,0 +1,326 @@
+package topic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrTopicNotFound = errors.New("topic not found")
+	ErrSlugTaken     = errors.New("another topic already uses this slug")
+)
+
+// followerBatch is how many followers are notified per call, large topics have tens of thousands
+const followerBatch = 500
+
+// Paths builds canonical article paths, the permalink router implements it
+type Paths interface {
+	Path(ref permalink.ArticleRef) (string, error)
+}
+
+// Entry is an article on a topic page
+type Entry struct {
+	Summary topic.ArticleSummary
+	Path    string
+}
+
+// Page is a topic landing page: pinned articles first, then the automatic stream
+type Page struct {
+	Topic     *topic.Topic
+	Pinned    []Entry
+	Stream    []Entry
+	Followers int64
+	Following bool
+}
+
+type Service struct {
+	topics   topic.TopicRepository
+	follows  topic.FollowRepository
+	articles topic.ArticleStream
+	notifier topic.FollowerNotifier
+	paths    Paths
+}
+
+func NewService(topics topic.TopicRepository, follows topic.FollowRepository, articles topic.ArticleStream, notifier topic.FollowerNotifier, paths Paths) *Service {
+	return &Service{topics: topics, follows: follows, articles: articles, notifier: notifier, paths: paths}
+}
+
+func (s *Service) Create(ctx context.Context, staffID, slug, name, description string, tags, entities []string) (*topic.Topic, error) {
+	slugObj, err := s.freeSlug(ctx, slug, "")
+	if err != nil {
+		return nil, err
+	}
+	rule, err := topic.NewRule(tags, entities)
+	if err != nil {
+		return nil, err
+	}
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := topic.NewTopic(id, *slugObj, name, description, *rule, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.topics.Create(ctx, created); err != nil {
+		return nil, fmt.Errorf("failed to save topic: %w", err)
+	}
+	return created, nil
+}
+
+// Update edits the curated fields, a new slug keeps the old one redirecting
+func (s *Service) Update(ctx context.Context, staffID, id, slug, name, description, metaDescription string, tags, entities []string) (*topic.Topic, error) {
+	existing, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := topic.NewRule(tags, entities)
+	if err != nil {
+		return nil, err
+	}
+	if err := existing.UpdateDetails(name, description, metaDescription, *rule, staffID); err != nil {
+		return nil, err
+	}
+	if slug != "" && slug != existing.Slug.Value() {
+		slugObj, err := s.freeSlug(ctx, slug, existing.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := existing.ChangeSlug(*slugObj, staffID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.topics.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to save topic: %w", err)
+	}
+	return existing, nil
+}
+
+func (s *Service) SetPinned(ctx context.Context, staffID, id string, articleIDs []string) (*topic.Topic, error) {
+	existing, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := existing.SetPinned(articleIDs, staffID); err != nil {
+		return nil, err
+	}
+	if err := s.topics.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to save topic: %w", err)
+	}
+	return existing, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if err := s.topics.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete topic: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*topic.Topic, error) {
+	return s.find(ctx, id)
+}
+
+func (s *Service) List(ctx context.Context) ([]*topic.Topic, error) {
+	topics, err := s.topics.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+	return topics, nil
+}
+
+// Page loads a topic landing page. Pinned articles are only on the first page
+// and never repeat in the stream. accountID is empty for guests.
+func (s *Service) Page(ctx context.Context, slug, accountID string, limit, offset int) (*Page, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	found, err := s.topics.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load topic: %w", err)
+	}
+	if found == nil {
+		return nil, ErrTopicNotFound
+	}
+
+	page := &Page{Topic: found}
+	if offset == 0 && len(found.Pinned) > 0 {
+		pinned, err := s.articles.FindPublished(ctx, found.Pinned)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pinned articles: %w", err)
+		}
+		for _, id := range found.Pinned {
+			if summary, ok := pinned[id]; ok {
+				entry, err := s.entry(summary)
+				if err != nil {
+					return nil, err
+				}
+				page.Pinned = append(page.Pinned, entry)
+			}
+		}
+	}
+
+	stream, err := s.articles.FindMatching(ctx, found.Rule, found.Pinned, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load topic stream: %w", err)
+	}
+	for _, summary := range stream {
+		entry, err := s.entry(summary)
+		if err != nil {
+			return nil, err
+		}
+		page.Stream = append(page.Stream, entry)
+	}
+
+	if page.Followers, err = s.follows.CountFollowers(ctx, found.ID); err != nil {
+		return nil, fmt.Errorf("failed to count followers: %w", err)
+	}
+	if accountID != "" {
+		if page.Following, err = s.follows.IsFollowing(ctx, found.ID, accountID); err != nil {
+			return nil, fmt.Errorf("failed to check follow: %w", err)
+		}
+	}
+	return page, nil
+}
+
+func (s *Service) Follow(ctx context.Context, accountID, slug string, at time.Time) (*topic.Topic, error) {
+	found, err := s.bySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.follows.Follow(ctx, topic.Follow{TopicID: found.ID, AccountID: accountID, CreatedAt: at}); err != nil {
+		return nil, fmt.Errorf("failed to follow topic: %w", err)
+	}
+	return found, nil
+}
+
+func (s *Service) Unfollow(ctx context.Context, accountID, slug string) error {
+	found, err := s.bySlug(ctx, slug)
+	if err != nil {
+		return err
+	}
+	if err := s.follows.Unfollow(ctx, found.ID, accountID); err != nil {
+		return fmt.Errorf("failed to unfollow topic: %w", err)
+	}
+	return nil
+}
+
+// FollowedSlugs lists the topics a member follows, newsletter segments match on them
+func (s *Service) FollowedSlugs(ctx context.Context, accountID string) ([]string, error) {
+	ids, err := s.follows.FindTopicIDsByAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load followed topics: %w", err)
+	}
+	slugs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		found, err := s.topics.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load topic: %w", err)
+		}
+		if found != nil {
+			slugs = append(slugs, found.Slug.Value())
+		}
+	}
+	return slugs, nil
+}
+
+// ArticlePublished notifies followers of every topic the new article matches.
+// A member following several of those topics hears about the article once.
+func (s *Service) ArticlePublished(ctx context.Context, article topic.PublishedArticle, at time.Time) (int, error) {
+	matching, err := s.topics.FindMatching(ctx, article.Tags, article.Entities)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find matching topics: %w", err)
+	}
+
+	notified := map[string]struct{}{}
+	for _, t := range matching {
+		if !t.Rule.Matches(article.Tags, article.Entities) {
+			continue
+		}
+		after := ""
+		for {
+			followers, err := s.follows.FindFollowers(ctx, t.ID, after, followerBatch)
+			if err != nil {
+				return len(notified), fmt.Errorf("failed to load followers: %w", err)
+			}
+			if len(followers) == 0 {
+				break
+			}
+			after = followers[len(followers)-1]
+
+			batch := make([]string, 0, len(followers))
+			for _, id := range followers {
+				if _, ok := notified[id]; !ok {
+					notified[id] = struct{}{}
+					batch = append(batch, id)
+				}
+			}
+			if len(batch) > 0 {
+				if err := s.notifier.NotifyNewArticle(ctx, batch, t, article.Summary, at); err != nil {
+					return len(notified), fmt.Errorf("failed to notify followers: %w", err)
+				}
+			}
+			if len(followers) < followerBatch {
+				break
+			}
+		}
+	}
+	return len(notified), nil
+}
+
+func (s *Service) entry(summary topic.ArticleSummary) (Entry, error) {
+	path, err := s.paths.Path(summary.Ref)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to build path for %s: %w", summary.Ref.ID, err)
+	}
+	return Entry{Summary: summary, Path: path}, nil
+}
+
+// freeSlug validates a slug no other topic uses, current or previous
+func (s *Service) freeSlug(ctx context.Context, slug, topicID string) (*topic.Slug, error) {
+	slugObj, err := topic.NewSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.topics.FindBySlug(ctx, slugObj.Value())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slug: %w", err)
+	}
+	if existing != nil && existing.ID != topicID {
+		return nil, ErrSlugTaken
+	}
+	return slugObj, nil
+}
+
+func (s *Service) bySlug(ctx context.Context, slug string) (*topic.Topic, error) {
+	found, err := s.topics.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load topic: %w", err)
+	}
+	if found == nil {
+		return nil, ErrTopicNotFound
+	}
+	return found, nil
+}
+
+func (s *Service) find(ctx context.Context, id string) (*topic.Topic, error) {
+	found, err := s.topics.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load topic: %w", err)
+	}
+	if found == nil {
+		return nil, ErrTopicNotFound
+	}
+	return found, nil
+}
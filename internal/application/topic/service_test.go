@@ -0,0 +1,306 @@
+package topic
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+)
+
+type memoryTopics struct {
+	topics map[string]*topic.Topic
+}
+
+func (m *memoryTopics) Create(ctx context.Context, t *topic.Topic) error {
+	m.topics[t.ID] = t
+	return nil
+}
+
+func (m *memoryTopics) Update(ctx context.Context, t *topic.Topic) error {
+	m.topics[t.ID] = t
+	return nil
+}
+
+func (m *memoryTopics) Delete(ctx context.Context, id string) error {
+	delete(m.topics, id)
+	return nil
+}
+
+func (m *memoryTopics) FindByID(ctx context.Context, id string) (*topic.Topic, error) {
+	return m.topics[id], nil
+}
+
+func (m *memoryTopics) FindBySlug(ctx context.Context, slug string) (*topic.Topic, error) {
+	for _, t := range m.topics {
+		if t.Slug.Value() == slug {
+			return t, nil
+		}
+		for _, previous := range t.PreviousSlugs {
+			if previous.Value() == slug {
+				return t, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryTopics) FindAll(ctx context.Context) ([]*topic.Topic, error) {
+	all := make([]*topic.Topic, 0, len(m.topics))
+	for _, t := range m.topics {
+		all = append(all, t)
+	}
+	return all, nil
+}
+
+func (m *memoryTopics) FindMatching(ctx context.Context, tags, entities []string) ([]*topic.Topic, error) {
+	var matching []*topic.Topic
+	for _, t := range m.topics {
+		if t.Rule.Matches(tags, entities) {
+			matching = append(matching, t)
+		}
+	}
+	return matching, nil
+}
+
+type memoryFollows struct {
+	follows map[string]map[string]bool
+}
+
+func (m *memoryFollows) Follow(ctx context.Context, f topic.Follow) error {
+	if m.follows[f.TopicID] == nil {
+		m.follows[f.TopicID] = map[string]bool{}
+	}
+	m.follows[f.TopicID][f.AccountID] = true
+	return nil
+}
+
+func (m *memoryFollows) Unfollow(ctx context.Context, topicID, accountID string) error {
+	delete(m.follows[topicID], accountID)
+	return nil
+}
+
+func (m *memoryFollows) IsFollowing(ctx context.Context, topicID, accountID string) (bool, error) {
+	return m.follows[topicID][accountID], nil
+}
+
+func (m *memoryFollows) CountFollowers(ctx context.Context, topicID string) (int64, error) {
+	return int64(len(m.follows[topicID])), nil
+}
+
+func (m *memoryFollows) FindFollowers(ctx context.Context, topicID, after string, limit int) ([]string, error) {
+	var ids []string
+	for id := range m.follows[topicID] {
+		if id > after {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (m *memoryFollows) FindTopicIDsByAccount(ctx context.Context, accountID string) ([]string, error) {
+	var ids []string
+	for topicID, followers := range m.follows {
+		if followers[accountID] {
+			ids = append(ids, topicID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+type fakeStream struct {
+	published map[string]topic.ArticleSummary
+	matching  []topic.ArticleSummary
+	excluded  []string
+}
+
+func (f *fakeStream) FindPublished(ctx context.Context, articleIDs []string) (map[string]topic.ArticleSummary, error) {
+	found := map[string]topic.ArticleSummary{}
+	for _, id := range articleIDs {
+		if summary, ok := f.published[id]; ok {
+			found[id] = summary
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeStream) FindMatching(ctx context.Context, rule topic.Rule, excludeIDs []string, limit, offset int) ([]topic.ArticleSummary, error) {
+	f.excluded = excludeIDs
+	return f.matching, nil
+}
+
+type fakeNotifier struct {
+	sent map[string][]string
+}
+
+func (f *fakeNotifier) NotifyNewArticle(ctx context.Context, accountIDs []string, t *topic.Topic, article topic.ArticleSummary, at time.Time) error {
+	f.sent[t.ID] = append(f.sent[t.ID], accountIDs...)
+	return nil
+}
+
+type fakePaths struct{}
+
+func (fakePaths) Path(ref permalink.ArticleRef) (string, error) {
+	return "/" + ref.CategorySlug + "/" + ref.Slug, nil
+}
+
+func summary(id string) topic.ArticleSummary {
+	return topic.ArticleSummary{Ref: permalink.ArticleRef{ID: id, CategorySlug: "tech", Slug: id}, Title: "Article " + id}
+}
+
+func createTestService(t *testing.T) (*Service, *memoryFollows, *fakeStream, *fakeNotifier) {
+	t.Helper()
+	follows := &memoryFollows{follows: map[string]map[string]bool{}}
+	stream := &fakeStream{
+		published: map[string]topic.ArticleSummary{"a1": summary("a1"), "a2": summary("a2")},
+		matching:  []topic.ArticleSummary{summary("a3"), summary("a4")},
+	}
+	notifier := &fakeNotifier{sent: map[string][]string{}}
+	return NewService(&memoryTopics{topics: map[string]*topic.Topic{}}, follows, stream, notifier, fakePaths{}), follows, stream, notifier
+}
+
+func TestService_Create(t *testing.T) {
+	service, _, _, _ := createTestService(t)
+	ctx := context.Background()
+
+	created, err := service.Create(ctx, "editor-1", "climate", "Climate", "Coverage of the climate crisis", []string{"Climate"}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if created.Slug.Value() != "climate" {
+		t.Errorf("expected slug 'climate', got '%s'", created.Slug.Value())
+	}
+
+	if _, err := service.Create(ctx, "editor-1", "climate", "Climate again", "", []string{"weather"}, nil); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected error '%v', got '%v'", ErrSlugTaken, err)
+	}
+	if _, err := service.Create(ctx, "editor-1", "energy", "Energy", "", nil, nil); !errors.Is(err, topic.ErrEmptyRule) {
+		t.Errorf("expected error '%v', got '%v'", topic.ErrEmptyRule, err)
+	}
+}
+
+func TestService_Update_ChangesSlugAndKeepsOld(t *testing.T) {
+	service, _, _, _ := createTestService(t)
+	ctx := context.Background()
+
+	created, _ := service.Create(ctx, "editor-1", "climate", "Climate", "", []string{"climate"}, nil)
+	if _, err := service.Create(ctx, "editor-1", "energy", "Energy", "", []string{"energy"}, nil); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+
+	if _, err := service.Update(ctx, "editor-2", created.ID, "energy", "Climate", "", "", []string{"climate"}, nil); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected error '%v', got '%v'", ErrSlugTaken, err)
+	}
+
+	updated, err := service.Update(ctx, "editor-2", created.ID, "climate-crisis", "Climate crisis", "", "", []string{"climate"}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if updated.Slug.Value() != "climate-crisis" {
+		t.Errorf("expected slug 'climate-crisis', got '%s'", updated.Slug.Value())
+	}
+
+	page, err := service.Page(ctx, "climate", "", 20, 0)
+	if err != nil {
+		t.Fatalf("expected old slug to resolve, got '%v'", err)
+	}
+	if page.Topic.Slug.Value() != "climate-crisis" {
+		t.Errorf("expected canonical slug 'climate-crisis', got '%s'", page.Topic.Slug.Value())
+	}
+}
+
+func TestService_Page(t *testing.T) {
+	service, follows, stream, _ := createTestService(t)
+	ctx := context.Background()
+
+	created, _ := service.Create(ctx, "editor-1", "climate", "Climate", "", []string{"climate"}, nil)
+	if _, err := service.SetPinned(ctx, "editor-1", created.ID, []string{"a2", "gone", "a1"}); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	_ = follows.Follow(ctx, topic.Follow{TopicID: created.ID, AccountID: "member-1"})
+
+	page, err := service.Page(ctx, "climate", "member-1", 20, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(page.Pinned) != 2 || page.Pinned[0].Summary.Ref.ID != "a2" || page.Pinned[0].Path != "/tech/a2" {
+		t.Errorf("expected pinned a2 then a1 with unpublished left out, got %+v", page.Pinned)
+	}
+	if len(stream.excluded) != 3 {
+		t.Errorf("expected pinned articles excluded from the stream, got %v", stream.excluded)
+	}
+	if len(page.Stream) != 2 || page.Followers != 1 || !page.Following {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	second, _ := service.Page(ctx, "climate", "", 20, 20)
+	if len(second.Pinned) != 0 || second.Following {
+		t.Errorf("expected no pinned articles past the first page, got %+v", second.Pinned)
+	}
+
+	if _, err := service.Page(ctx, "missing", "", 20, 0); !errors.Is(err, ErrTopicNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrTopicNotFound, err)
+	}
+}
+
+func TestService_FollowedSlugs(t *testing.T) {
+	service, _, _, _ := createTestService(t)
+	ctx := context.Background()
+
+	_, _ = service.Create(ctx, "editor-1", "climate", "Climate", "", []string{"climate"}, nil)
+	_, _ = service.Create(ctx, "editor-1", "energy", "Energy", "", []string{"energy"}, nil)
+	_, _ = service.Follow(ctx, "member-1", "climate", time.Now())
+	_, _ = service.Follow(ctx, "member-1", "energy", time.Now())
+	_ = service.Unfollow(ctx, "member-1", "energy")
+
+	slugs, err := service.FollowedSlugs(ctx, "member-1")
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(slugs) != 1 || slugs[0] != "climate" {
+		t.Errorf("expected [climate], got %v", slugs)
+	}
+
+	if _, err := service.Follow(ctx, "member-1", "missing", time.Now()); !errors.Is(err, ErrTopicNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrTopicNotFound, err)
+	}
+}
+
+func TestService_ArticlePublished_NotifiesEachFollowerOnce(t *testing.T) {
+	service, follows, _, notifier := createTestService(t)
+	ctx := context.Background()
+
+	climate, _ := service.Create(ctx, "editor-1", "climate", "Climate", "", []string{"climate"}, nil)
+	energy, _ := service.Create(ctx, "editor-1", "energy", "Energy", "", nil, []string{"IEA"})
+	sports, _ := service.Create(ctx, "editor-1", "sports", "Sports", "", []string{"football"}, nil)
+	for _, f := range []topic.Follow{
+		{TopicID: climate.ID, AccountID: "member-1"},
+		{TopicID: climate.ID, AccountID: "member-2"},
+		{TopicID: energy.ID, AccountID: "member-2"},
+		{TopicID: energy.ID, AccountID: "member-3"},
+		{TopicID: sports.ID, AccountID: "member-4"},
+	} {
+		_ = follows.Follow(ctx, f)
+	}
+
+	article := topic.PublishedArticle{Summary: summary("a9"), Tags: []string{"Climate"}, Entities: []string{"iea"}}
+	n, err := service.ArticlePublished(ctx, article, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 members notified, got %d", n)
+	}
+	total := len(notifier.sent[climate.ID]) + len(notifier.sent[energy.ID])
+	if total != 3 || len(notifier.sent[sports.ID]) != 0 {
+		t.Errorf("expected each follower notified once and sports untouched, got %v", notifier.sent)
+	}
+}
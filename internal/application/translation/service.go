@@ -0,0 +1,163 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/translation"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrArticleNotFound = errors.New("article not found")
+	ErrDraftNotFound   = errors.New("translation draft not found")
+	ErrDraftExists     = errors.New("the translation group already has a draft in this language")
+	ErrUnknownProvider = errors.New("unknown translation provider")
+)
+
+type Service struct {
+	drafts          translation.DraftRepository
+	glossaries      translation.GlossaryRepository
+	articles        translation.ArticleSource
+	providers       map[string]translation.Provider
+	defaultProvider string
+}
+
+// NewService takes the configured providers by name, e.g. "deepl", and the one
+// used when an editor does not pick
+func NewService(drafts translation.DraftRepository, glossaries translation.GlossaryRepository, articles translation.ArticleSource, providers map[string]translation.Provider, defaultProvider string) *Service {
+	return &Service{drafts: drafts, glossaries: glossaries, articles: articles, providers: providers, defaultProvider: defaultProvider}
+}
+
+// Prefill creates the target-language draft of the article's translation
+// group from a machine translation of each block
+func (s *Service) Prefill(ctx context.Context, editorID, articleID, target, providerName string, at time.Time) (*translation.Draft, error) {
+	if providerName == "" {
+		providerName = s.defaultProvider
+	}
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, providerName)
+	}
+	target, err := translation.ParseLanguage(target)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.articles.FindForTranslation(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load article: %w", err)
+	}
+	if source == nil {
+		return nil, ErrArticleNotFound
+	}
+	existing, err := s.drafts.FindByGroup(ctx, source.GroupID, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load translation draft: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrDraftExists
+	}
+
+	glossary, err := s.glossaries.Find(ctx, source.Language, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load glossary: %w", err)
+	}
+	segments := source.Segments(glossary)
+	var translated []string
+	if len(segments) > 0 {
+		if translated, err = provider.Translate(ctx, segments, source.Language, target); err != nil {
+			return nil, fmt.Errorf("failed to translate article: %w", err)
+		}
+	}
+
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	draft, err := translation.NewDraft(id, source, target, providerName, translated, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.drafts.Save(ctx, draft); err != nil {
+		return nil, fmt.Errorf("failed to save translation draft: %w", err)
+	}
+	return draft, nil
+}
+
+func (s *Service) Draft(ctx context.Context, id string) (*translation.Draft, error) {
+	draft, err := s.drafts.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load translation draft: %w", err)
+	}
+	if draft == nil {
+		return nil, ErrDraftNotFound
+	}
+	return draft, nil
+}
+
+// ConfirmBlock records that the editor checked a machine-translated block
+func (s *Service) ConfirmBlock(ctx context.Context, editorID, draftID string, index int, at time.Time) (*translation.Draft, error) {
+	return s.update(ctx, draftID, func(d *translation.Draft) error {
+		return d.Confirm(index, editorID, at)
+	})
+}
+
+// EditBlock replaces a block with the editor's translation
+func (s *Service) EditBlock(ctx context.Context, editorID, draftID string, index int, text string, at time.Time) (*translation.Draft, error) {
+	return s.update(ctx, draftID, func(d *translation.Draft) error {
+		return d.Edit(index, text, editorID, at)
+	})
+}
+
+// CheckPublish is the guard the publish flow calls for a translated article
+func (s *Service) CheckPublish(ctx context.Context, groupID, language string) error {
+	draft, err := s.drafts.FindByGroup(ctx, groupID, language)
+	if err != nil {
+		return fmt.Errorf("failed to load translation draft: %w", err)
+	}
+	if draft == nil {
+		// Written from scratch, nothing machine translated
+		return nil
+	}
+	return draft.CheckPublish()
+}
+
+func (s *Service) update(ctx context.Context, draftID string, change func(d *translation.Draft) error) (*translation.Draft, error) {
+	draft, err := s.Draft(ctx, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(draft); err != nil {
+		return nil, err
+	}
+	if err := s.drafts.Save(ctx, draft); err != nil {
+		return nil, fmt.Errorf("failed to save translation draft: %w", err)
+	}
+	return draft, nil
+}
+
+// Glossary returns the terms of a language pair, empty until some are saved
+func (s *Service) Glossary(ctx context.Context, source, target string) (*translation.Glossary, error) {
+	glossary, err := s.glossaries.Find(ctx, source, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load glossary: %w", err)
+	}
+	if glossary == nil {
+		return &translation.Glossary{SourceLanguage: source, TargetLanguage: target}, nil
+	}
+	return glossary, nil
+}
+
+func (s *Service) SaveGlossary(ctx context.Context, editorID, source, target string, terms []translation.Term, at time.Time) (*translation.Glossary, error) {
+	glossary, err := translation.NewGlossary(source, target, terms, editorID, at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.glossaries.Save(ctx, glossary); err != nil {
+		return nil, fmt.Errorf("failed to save glossary: %w", err)
+	}
+	return glossary, nil
+}
@@ -0,0 +1,144 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/translation"
+)
+
+type memoryDrafts struct {
+	drafts map[string]*translation.Draft
+}
+
+func (m *memoryDrafts) Save(ctx context.Context, d *translation.Draft) error {
+	m.drafts[d.ID] = d
+	return nil
+}
+
+func (m *memoryDrafts) FindByID(ctx context.Context, id string) (*translation.Draft, error) {
+	return m.drafts[id], nil
+}
+
+func (m *memoryDrafts) FindByGroup(ctx context.Context, groupID, language string) (*translation.Draft, error) {
+	for _, d := range m.drafts {
+		if d.GroupID == groupID && d.TargetLanguage == language {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+type memoryGlossaries struct {
+	glossaries map[string]*translation.Glossary
+}
+
+func (m *memoryGlossaries) Save(ctx context.Context, g *translation.Glossary) error {
+	m.glossaries[g.SourceLanguage+"/"+g.TargetLanguage] = g
+	return nil
+}
+
+func (m *memoryGlossaries) Find(ctx context.Context, source, target string) (*translation.Glossary, error) {
+	return m.glossaries[source+"/"+target], nil
+}
+
+type fakeArticles struct{}
+
+func (f *fakeArticles) FindForTranslation(ctx context.Context, articleID string) (*translation.Source, error) {
+	if articleID != "a1" {
+		return nil, nil
+	}
+	return &translation.Source{ArticleID: "a1", GroupID: "g1", Revision: "r1", Language: "id", Body: "<p>Kompas melaporkan harga naik.</p><p>Stok aman.</p>"}, nil
+}
+
+// fakeProvider "translates" one phrase and keeps the rest
+type fakeProvider struct {
+	segments []string
+	err      error
+}
+
+func (f *fakeProvider) Translate(ctx context.Context, segments []string, source, target string) ([]string, error) {
+	f.segments = segments
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make([]string, len(segments))
+	for i, s := range segments {
+		out[i] = strings.ReplaceAll(s, "harga naik", "prices rose")
+	}
+	return out, nil
+}
+
+func createTestService() (*Service, *fakeProvider) {
+	provider := &fakeProvider{}
+	service := NewService(&memoryDrafts{drafts: map[string]*translation.Draft{}}, &memoryGlossaries{glossaries: map[string]*translation.Glossary{}}, &fakeArticles{}, map[string]translation.Provider{"deepl": provider}, "deepl")
+	return service, provider
+}
+
+func TestService_Prefill(t *testing.T) {
+	service, provider := createTestService()
+	ctx := context.Background()
+	at := time.Now()
+	if _, err := service.SaveGlossary(ctx, "editor-1", "id", "en", []translation.Term{{Source: "Kompas"}}, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	draft, err := service.Prefill(ctx, "editor-1", "a1", "en", "", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(provider.segments[0], `<span translate="no" data-glossary>Kompas</span>`) {
+		t.Errorf("expected the brand protected, got %q", provider.segments[0])
+	}
+	if draft.Blocks[0].Text != "<p>Kompas melaporkan prices rose.</p>" || len(draft.Pending()) != 2 {
+		t.Errorf("unexpected draft %+v", draft.Blocks)
+	}
+	if err := service.CheckPublish(ctx, "g1", "en"); err != translation.ErrUnconfirmedBlocks {
+		t.Errorf("expected error '%v', got '%v'", translation.ErrUnconfirmedBlocks, err)
+	}
+
+	if _, err := service.Prefill(ctx, "editor-1", "a1", "en", "", at); err != ErrDraftExists {
+		t.Errorf("expected error '%v', got '%v'", ErrDraftExists, err)
+	}
+	if _, err := service.Prefill(ctx, "editor-1", "a9", "en", "", at); err != ErrArticleNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrArticleNotFound, err)
+	}
+	if _, err := service.Prefill(ctx, "editor-1", "a1", "en", "nllb", at); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownProvider, err)
+	}
+}
+
+func TestService_ConfirmBlocks(t *testing.T) {
+	service, _ := createTestService()
+	ctx := context.Background()
+	at := time.Now()
+	draft, _ := service.Prefill(ctx, "editor-1", "a1", "en", "deepl", at)
+
+	if _, err := service.ConfirmBlock(ctx, "editor-2", draft.ID, 0, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.EditBlock(ctx, "editor-2", draft.ID, 1, "<p>Stock is sufficient.</p>", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.CheckPublish(ctx, "g1", "en"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := service.ConfirmBlock(ctx, "editor-2", "missing", 0, at); err != ErrDraftNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrDraftNotFound, err)
+	}
+}
+
+func TestService_Prefill_ProviderDown(t *testing.T) {
+	service, provider := createTestService()
+	provider.err = errors.New("503 service unavailable")
+
+	if _, err := service.Prefill(context.Background(), "editor-1", "a1", "en", "", time.Now()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := service.CheckPublish(context.Background(), "g1", "en"); err != nil {
+		t.Errorf("expected no draft saved, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/webhook"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// ReplayBatch bounds one replay run over failed deliveries
+const ReplayBatch = 100
+
+var (
+	ErrUnknownProvider  = errors.New("no processor registered for provider")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// Processor applies one provider's payload, e.g. records a payment or imports
+// a wire story. It may run more than once for the same event when a replay
+// follows a partial failure, so it must be idempotent.
+type Processor interface {
+	Process(ctx context.Context, eventID string, payload []byte) error
+}
+
+// Result is the outcome of one received delivery
+type Result struct {
+	Delivery  *webhook.Delivery
+	Duplicate bool // The event was received before, nothing was processed
+}
+
+// ReplayReport summarises a replay run
+type ReplayReport struct {
+	Replayed int
+	Failed   int
+}
+
+type Service struct {
+	deliveries webhook.DeliveryRepository
+	archive    webhook.PayloadArchive
+	processors map[string]Processor
+}
+
+func NewService(deliveries webhook.DeliveryRepository, archive webhook.PayloadArchive, processors map[string]Processor) *Service {
+	return &Service{deliveries: deliveries, archive: archive, processors: processors}
+}
+
+// Receive archives a verified payload, then processes it. The payload is
+// archived before processing so a failed delivery can be replayed without the
+// provider sending it again. A processing failure is recorded on the delivery
+// and returned, the caller answers with a 5xx so providers that retry do so.
+func (s *Service) Receive(ctx context.Context, provider, eventID string, body []byte, at time.Time) (*Result, error) {
+	processor, ok := s.processors[provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	existing, err := s.deliveries.FindByEventID(ctx, provider, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook delivery: %w", err)
+	}
+	if existing != nil && !existing.CanReplay() {
+		return &Result{Delivery: existing, Duplicate: true}, nil
+	}
+
+	delivery := existing
+	if delivery == nil {
+		if delivery, err = s.store(ctx, provider, eventID, body, at); err != nil {
+			if errors.Is(err, webhook.ErrDuplicateDelivery) {
+				// A concurrent retry of the same event got there first
+				return &Result{Duplicate: true}, nil
+			}
+			return nil, err
+		}
+	}
+	return &Result{Delivery: delivery}, s.process(ctx, processor, delivery, body, at)
+}
+
+// Replay processes a failed delivery again from its archived payload
+func (s *Service) Replay(ctx context.Context, id string, at time.Time) (*webhook.Delivery, error) {
+	delivery, err := s.deliveries.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook delivery: %w", err)
+	}
+	if delivery == nil {
+		return nil, ErrDeliveryNotFound
+	}
+	return delivery, s.replay(ctx, delivery, at)
+}
+
+// ReplayFailed replays up to ReplayBatch failed deliveries, provider "" replays every provider
+func (s *Service) ReplayFailed(ctx context.Context, provider string, at time.Time) (*ReplayReport, error) {
+	deliveries, err := s.deliveries.FindReplayable(ctx, provider, ReplayBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load failed webhook deliveries: %w", err)
+	}
+
+	report := &ReplayReport{}
+	for _, d := range deliveries {
+		if err := s.replay(ctx, d, at); err != nil {
+			if shared.IsTimeout(err) || ctx.Err() != nil {
+				return report, err
+			}
+			report.Failed++
+			continue
+		}
+		report.Replayed++
+	}
+	return report, nil
+}
+
+// Failed lists deliveries waiting for a replay
+func (s *Service) Failed(ctx context.Context, provider string, limit int) ([]*webhook.Delivery, error) {
+	if limit <= 0 || limit > ReplayBatch {
+		limit = ReplayBatch
+	}
+	return s.deliveries.FindReplayable(ctx, provider, limit)
+}
+
+func (s *Service) store(ctx context.Context, provider, eventID string, body []byte, at time.Time) (*webhook.Delivery, error) {
+	id, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook delivery ID: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	key := fmt.Sprintf("%s/%s/%s", provider, at.UTC().Format("2006/01/02"), id)
+
+	delivery, err := webhook.NewDelivery(id, provider, eventID, key, hex.EncodeToString(sum[:]), at)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.archive.Put(ctx, key, body); err != nil {
+		return nil, fmt.Errorf("failed to archive webhook payload: %w", err)
+	}
+	if err := s.deliveries.Create(ctx, delivery); err != nil {
+		if errors.Is(err, webhook.ErrDuplicateDelivery) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return delivery, nil
+}
+
+func (s *Service) replay(ctx context.Context, delivery *webhook.Delivery, at time.Time) error {
+	if !delivery.CanReplay() {
+		return webhook.ErrNotReplayable
+	}
+	processor, ok := s.processors[delivery.Provider]
+	if !ok {
+		return ErrUnknownProvider
+	}
+	body, err := s.archive.Get(ctx, delivery.ArchiveKey)
+	if err != nil {
+		return fmt.Errorf("failed to load archived webhook payload: %w", err)
+	}
+	return s.process(ctx, processor, delivery, body, at)
+}
+
+func (s *Service) process(ctx context.Context, processor Processor, delivery *webhook.Delivery, body []byte, at time.Time) error {
+	processErr := processor.Process(ctx, delivery.EventID, body)
+	if processErr != nil {
+		delivery.Fail(processErr, at)
+	} else {
+		delivery.Succeed(at)
+	}
+	if err := s.deliveries.Update(ctx, delivery); err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	if processErr != nil {
+		return fmt.Errorf("failed to process %s webhook: %w", delivery.Provider, processErr)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/webhook"
+)
+
+type memoryDeliveries struct {
+	byID map[string]*webhook.Delivery
+}
+
+func (m *memoryDeliveries) Create(ctx context.Context, d *webhook.Delivery) error {
+	for _, existing := range m.byID {
+		if existing.Provider == d.Provider && existing.EventID == d.EventID {
+			return webhook.ErrDuplicateDelivery
+		}
+	}
+	m.byID[d.ID] = d
+	return nil
+}
+
+func (m *memoryDeliveries) Update(ctx context.Context, d *webhook.Delivery) error {
+	m.byID[d.ID] = d
+	return nil
+}
+
+func (m *memoryDeliveries) FindByID(ctx context.Context, id string) (*webhook.Delivery, error) {
+	return m.byID[id], nil
+}
+
+func (m *memoryDeliveries) FindByEventID(ctx context.Context, provider, eventID string) (*webhook.Delivery, error) {
+	for _, d := range m.byID {
+		if d.Provider == provider && d.EventID == eventID {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDeliveries) FindReplayable(ctx context.Context, provider string, limit int) ([]*webhook.Delivery, error) {
+	var found []*webhook.Delivery
+	for _, d := range m.byID {
+		if d.CanReplay() && (provider == "" || d.Provider == provider) {
+			found = append(found, d)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ReceivedAt.Before(found[j].ReceivedAt) })
+	return found[:min(limit, len(found))], nil
+}
+
+type memoryArchive struct {
+	objects map[string][]byte
+}
+
+func (m *memoryArchive) Put(ctx context.Context, key string, body []byte) error {
+	m.objects[key] = append([]byte(nil), body...)
+	return nil
+}
+
+func (m *memoryArchive) Get(ctx context.Context, key string) ([]byte, error) {
+	body, ok := m.objects[key]
+	if !ok {
+		return nil, errors.New("object not found")
+	}
+	return body, nil
+}
+
+// fakeProcessor fails while err is set and counts what it applied
+type fakeProcessor struct {
+	err     error
+	applied map[string]int
+}
+
+func (f *fakeProcessor) Process(ctx context.Context, eventID string, payload []byte) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.applied[eventID]++
+	return nil
+}
+
+func newTestService() (*Service, *fakeProcessor, *memoryArchive) {
+	processor := &fakeProcessor{applied: map[string]int{}}
+	archive := &memoryArchive{objects: map[string][]byte{}}
+	service := NewService(&memoryDeliveries{byID: map[string]*webhook.Delivery{}}, archive, map[string]Processor{"payment-gateway": processor})
+	return service, processor, archive
+}
+
+func TestService_ReceiveIdempotent(t *testing.T) {
+	service, processor, archive := newTestService()
+	ctx := context.Background()
+
+	result, err := service.Receive(ctx, "payment-gateway", "evt_1", []byte(`{"amount":100}`), time.Now())
+	if err != nil || result.Duplicate || !result.Delivery.IsProcessed() {
+		t.Fatalf("expected the delivery processed, got %+v %v", result, err)
+	}
+	if string(archive.objects[result.Delivery.ArchiveKey]) != `{"amount":100}` {
+		t.Errorf("expected the payload archived as received")
+	}
+
+	again, err := service.Receive(ctx, "payment-gateway", "evt_1", []byte(`{"amount":100}`), time.Now())
+	if err != nil || !again.Duplicate || processor.applied["evt_1"] != 1 {
+		t.Errorf("expected the redelivery ignored, got %+v applied %d", again, processor.applied["evt_1"])
+	}
+
+	if _, err := service.Receive(ctx, "wire-agency", "story-1", nil, time.Now()); err != ErrUnknownProvider {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownProvider, err)
+	}
+}
+
+func TestService_ReplayFailed(t *testing.T) {
+	service, processor, _ := newTestService()
+	ctx := context.Background()
+	processor.err = errors.New("ledger unavailable")
+
+	result, err := service.Receive(ctx, "payment-gateway", "evt_1", []byte(`{}`), time.Now())
+	if err == nil || result.Delivery.Status != webhook.StatusFailed {
+		t.Fatalf("expected a failed delivery, got %+v %v", result, err)
+	}
+	if _, err := service.Receive(ctx, "payment-gateway", "evt_2", []byte(`{}`), time.Now()); err == nil {
+		t.Fatal("expected the second delivery to fail too")
+	}
+
+	failed, _ := service.Failed(ctx, "payment-gateway", 10)
+	if len(failed) != 2 {
+		t.Fatalf("expected 2 failed deliveries, got %d", len(failed))
+	}
+
+	processor.err = nil
+	report, err := service.ReplayFailed(ctx, "", time.Now())
+	if err != nil || report.Replayed != 2 || processor.applied["evt_1"] != 1 {
+		t.Fatalf("expected both replayed from the archive, got %+v %v", report, err)
+	}
+	if _, err := service.Replay(ctx, result.Delivery.ID, time.Now()); err != webhook.ErrNotReplayable {
+		t.Errorf("expected error '%v', got '%v'", webhook.ErrNotReplayable, err)
+	}
+	if _, err := service.Replay(ctx, "missing", time.Now()); err != ErrDeliveryNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrDeliveryNotFound, err)
+	}
+}
@@ -0,0 +1,206 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func run(t *testing.T, sources *fakeSources, query string, variables map[string]any) string {
+	t.Helper()
+	body, err := json.Marshal(request{Query: query, Variables: variables})
+	if err != nil {
+		t.Fatalf("failed to encode request: %v", err)
+	}
+	return strings.TrimSpace(post(t, NewRouter(sources.sources()), string(body)).Body.String())
+}
+
+func TestExecute(t *testing.T) {
+	testCases := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		expected  string
+	}{
+		{
+			"aliases keep selection order",
+			`{ second: article(id: "a2") { title id } first: article(id: "a1") { id } }`,
+			nil,
+			`{"data":{"second":{"title":"Budget passed","id":"a2"},"first":{"id":"a1"}}}`,
+		},
+		{
+			"fragments merge into one object",
+			`{ article(slug: "floods-recede") { ...Head ... on Article { author { username } } __typename } } fragment Head on Article { id title }`,
+			nil,
+			`{"data":{"article":{"id":"a3","title":"Floods recede","author":{"username":"budi"},"__typename":"Article"}}}`,
+		},
+		{
+			"skip and include",
+			`query ($full: Boolean!) { article(id: "a2") { id body @include(if: $full) title @skip(if: true) } }`,
+			map[string]any{"full": false},
+			`{"data":{"article":{"id":"a2"}}}`,
+		},
+		{
+			"variable defaults and null arguments",
+			`query ($n: Int = 1) { a: articles(first: $n) { id } b: articles(first: null, offset: 2) { id } }`,
+			nil,
+			`{"data":{"a":[{"id":"a3"}],"b":[{"id":"a1"}]}}`,
+		},
+		{
+			"JSON numbers as Int",
+			`query ($n: Int!) { articles(first: $n) { id } }`,
+			map[string]any{"n": 1.0},
+			`{"data":{"articles":[{"id":"a3"}]}}`,
+		},
+		{
+			"unknown article",
+			`{ article(id: "a9") { id } category(slug: "sport") { id } }`,
+			nil,
+			`{"data":{"article":null,"category":null}}`,
+		},
+		{
+			"deleted author",
+			`{ author(id: "u3") { username } }`,
+			nil,
+			`{"data":{"author":null}}`,
+		},
+		{
+			"section with its articles",
+			`{ category(slug: "regional") { name articles(first: 1) { title } } }`,
+			nil,
+			`{"data":{"category":{"name":"Regional","articles":[{"title":"Floods recede"}]}}}`,
+		},
+		{
+			"invalid sort",
+			`{ article(id: "a1") { comments(sort: "hot") { id } } }`,
+			nil,
+			`{"errors":[{"message":"sort must be best, newest or oldest","path":["article","comments"],"locations":[{"line":1,"column":23}]}],"data":{"article":{"comments":null}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := run(t, newFakeSources(t), tc.query, tc.variables); got != tc.expected {
+				t.Errorf("expected\n%s\ngot\n%s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestExecute_Validation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{"unknown field", `{ article(id: "a1") { headline } }`, `Cannot query field "headline" on type "Article".`},
+		{"missing subfields", `{ article(id: "a1") }`, `Field "article" of type "Article" must have a selection of subfields. Did you mean "article { ... }"?`},
+		{"subfields on a scalar", `{ article(id: "a1") { title { text } } }`, `Field "title" must not have a selection since type "String" has no subfields.`},
+		{"unknown argument", `{ articles(tag: "floods") { id } }`, `Unknown argument "tag" on field "Query.articles".`},
+		{"required argument", `{ category { id } }`, `Field "category" argument "slug" of type "String!" is required, but it was not provided.`},
+		{"invalid literal", `{ articles(first: "ten") { id } }`, `Int cannot represent non-integer value: "ten"`},
+		{"undefined variable", `{ article(id: $id) { id } }`, `Variable "$id" is not defined.`},
+		{"unknown variable type", `query ($id: Slug) { article(slug: $id) { id } }`, `Unknown type "Slug".`},
+		{"unknown directive", `{ article(id: "a1") @cached { id } }`, `Unknown directive "@cached".`},
+		{"unknown fragment", `{ article(id: "a1") { ...Teaser } }`, `Unknown fragment "Teaser".`},
+		{"fragment on another type", `{ article(id: "a1") { ...Section } } fragment Section on Category { id }`, `Fragment "Section" cannot be spread here as objects of type "Article" can never be of type "Category".`},
+		{"fragment cycle", `{ article(id: "a1") { ...A } } fragment A on Article { ...B } fragment B on Article { ...A }`, `Cannot spread fragment "A" within itself via "B".`},
+		{"too deep", `{ articles { author { articles { author { articles { author { articles { author { articles { author { id } } } } } } } } } } }`, `Query is nested too deeply, at most 10 levels are allowed.`},
+		{"too deep through fragments", `{ articles { ...Author } } fragment Author on Article { author { articles { author { articles { author { articles { author { articles { author { id } } } } } } } } } }`, `Query is nested too deeply, at most 10 levels are allowed.`},
+		{"too many fields once fragments expand", nestedFragments(9), `Query selects too many fields once fragments are expanded, at most 500 are allowed.`},
+		{"too complex", `{ articles(first: 50) { comments(first: 50) { replies(first: 50) { id } } } }`, `operation has complexity 10001, which exceeds the limit of 10000`},
+		{"several operations", `query A { categories { id } } query B { categories { id } }`, `Must provide operation name if query contains multiple operations.`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sources := newFakeSources(t)
+			got := run(t, sources, tc.query, nil)
+			var resp struct {
+				Data   any           `json:"data"`
+				Errors gqlerror.List `json:"errors"`
+			}
+			_ = json.Unmarshal([]byte(got), &resp)
+			found := slices.ContainsFunc(resp.Errors, func(err *gqlerror.Error) bool { return err.Message == tc.expected })
+			if resp.Data != nil || !found {
+				t.Errorf("expected no data and the error %q, got %s", tc.expected, got)
+			}
+			if len(sources.calls) != 0 {
+				t.Errorf("expected nothing resolved for an invalid query, got %v", sources.calls)
+			}
+		})
+	}
+}
+
+// nestedFragments spreads each fragment twice in the next, so the query
+// doubles in size with every level once expanded
+func nestedFragments(levels int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "{ articles { ...F%d } } fragment F0 on Article { id title }", levels)
+	for i := 1; i <= levels; i++ {
+		fmt.Fprintf(&b, " fragment F%d on Article { ...F%d ...F%d }", i, i-1, i-1)
+	}
+	return b.String()
+}
+
+// TestRouter_FragmentExpansion sends a query that expands into 2^27 fields
+// and expects it turned away before gqlgen walks the expansion
+func TestRouter_FragmentExpansion(t *testing.T) {
+	sources := newFakeSources(t)
+	body, _ := json.Marshal(request{Query: nestedFragments(26)})
+
+	start := time.Now()
+	rec := post(t, NewRouter(sources.sources()), string(body))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the query rejected right away, took %s", elapsed)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "too many fields") {
+		t.Errorf("expected the selection limit error, got %s", rec.Body.String())
+	}
+	if len(sources.calls) != 0 {
+		t.Errorf("expected nothing resolved, got %v", sources.calls)
+	}
+}
+
+func TestLoader(t *testing.T) {
+	var batches [][]string
+	l := newLoader(context.Background(), func(ctx context.Context, keys []string) (map[string]int, error) {
+		batches = append(batches, slices.Sorted(slices.Values(keys)))
+		values := map[string]int{}
+		for _, k := range keys {
+			values[k] = len(k)
+		}
+		return values, nil
+	})
+
+	var wg sync.WaitGroup
+	got := make([]int, 3)
+	for i, key := range []string{"a", "bb", "a"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i], _ = l.load(key)
+		}()
+	}
+	wg.Wait()
+	if !slices.Equal(got, []int{1, 2, 1}) {
+		t.Errorf("expected 1, 2, 1, got %v", got)
+	}
+	if len(batches) != 1 || strings.Join(batches[0], ",") != "a,bb" {
+		t.Errorf("expected one batch of a and bb, got %v", batches)
+	}
+	if v, _ := l.load("bb"); v != 2 || len(batches) != 1 {
+		t.Errorf("expected a loaded key served from cache, got %v", batches)
+	}
+}
@@ -0,0 +1,162 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+//go:generate go tool gqlgen generate
+
+// maxRequestBytes bounds a POSTed query and its variables, real queries are a few KB
+const maxRequestBytes = 64 << 10
+
+//go:embed schema.graphqls
+var schemaSDL []byte
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type Handler struct {
+	exec    *executor.Executor
+	sources Sources
+}
+
+func NewHandler(sources Sources) *Handler {
+	exec := executor.New(NewExecutableSchema(Config{
+		Resolvers:  &Resolver{sources: sources},
+		Complexity: complexity(),
+	}))
+	exec.AroundFields(hideInternalErrors)
+	exec.SetParserTokenLimit(maxTokens)
+	exec.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	exec.Use(queryLimits{})
+	exec.Use(extension.FixedComplexityLimit(maxComplexity))
+	return &Handler{exec: exec, sources: sources}
+}
+
+// NewRouter mounts the public read API. Queries may be sent as GET so CDNs
+// can cache them, or POSTed as JSON; the schema is served as SDL for
+// frontends that generate typed clients.
+func NewRouter(sources Sources) http.Handler {
+	h := NewHandler(sources)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /graphql", h.Query)
+	mux.HandleFunc("POST /graphql", h.Query)
+	mux.HandleFunc("GET /graphql/schema", h.Schema)
+	return mux
+}
+
+// Query handles GET /graphql?query=…&variables=…&operationName=… and
+// POST /graphql with a {"query", "variables", "operationName"} body. Requests
+// that never run, e.g. syntax errors, answer 400; errors while resolving
+// answer 200 next to the data that did resolve.
+func (h *Handler) Query(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if raw := q.Get("variables"); raw != "" {
+			if err := decodeJSON(strings.NewReader(raw), &req.Variables); err != nil {
+				writeError(w, http.StatusBadRequest, "variables must be a JSON object")
+				return
+			}
+		}
+	} else if err := decodeJSON(http.MaxBytesReader(w, r.Body, maxRequestBytes), &req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "query is too large")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Query == "" {
+		writeError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	ctx := withLoaders(r.Context(), newLoaders(r.Context(), h.sources))
+	ctx = graphql.StartOperationTrace(ctx)
+	opCtx, errs := h.exec.CreateOperationContext(ctx, &graphql.RawParams{
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     req.Variables,
+	})
+	if len(errs) > 0 {
+		writeJSON(w, http.StatusBadRequest, h.exec.DispatchError(graphql.WithOperationContext(ctx, opCtx), errs))
+		return
+	}
+	respond, ctx := h.exec.DispatchOperation(ctx, opCtx)
+	writeJSON(w, http.StatusOK, respond(ctx))
+}
+
+// Schema handles GET /graphql/schema
+func (h *Handler) Schema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(schemaSDL)
+}
+
+// hideInternalErrors keeps storage details out of resolver errors and points
+// them at the field that failed, as validation errors are
+func hideInternalErrors(ctx context.Context, next graphql.Resolver) (any, error) {
+	res, err := next(ctx)
+	if err == nil {
+		return res, nil
+	}
+	var gqlErr *gqlerror.Error
+	if !errors.As(err, &gqlErr) {
+		gqlErr = userError(errorMessage(err))
+	}
+	if pos := graphql.GetFieldContext(ctx).Field.Position; pos != nil && len(gqlErr.Locations) == 0 {
+		gqlErr.Locations = []gqlerror.Location{{Line: pos.Line, Column: pos.Column}}
+	}
+	return res, gqlErr
+}
+
+// errorMessage answers "something went wrong" for storage errors, the same way
+// the REST handlers do
+func errorMessage(err error) string {
+	switch {
+	case shared.IsTimeout(err):
+		return "request timed out"
+	case shared.ErrorKindOf(err) == shared.KindValidation, shared.ErrorKindOf(err) == shared.KindNotFound:
+		return err.Error()
+	}
+	return "something went wrong"
+}
+
+// decodeJSON keeps numbers as json.Number, which gqlgen coerces to Int and Float
+func decodeJSON(r io.Reader, v any) error {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, graphql.Response{Errors: gqlerror.List{{Message: message}}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,324 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/taxonomy"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// fakeSources serves every lookup from memory and counts the calls, so tests
+// can tell a batched level from one query per parent
+type fakeSources struct {
+	articles   []*PublishedArticle // Newest first
+	categories []*taxonomy.Category
+	accounts   map[string]*account.UserAccount
+	comments   []*comment.Comment
+	err        error
+
+	mu    sync.Mutex // Loaders fetch from several goroutines
+	calls map[string]int
+}
+
+func (f *fakeSources) called(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[name]++
+	return f.err
+}
+
+func (f *fakeSources) FindPublishedByIDs(ctx context.Context, ids []string) (map[string]*PublishedArticle, error) {
+	if err := f.called("FindPublishedByIDs"); err != nil {
+		return nil, err
+	}
+	found := map[string]*PublishedArticle{}
+	for _, a := range f.articles {
+		if slices.Contains(ids, a.ID) {
+			found[a.ID] = a
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSources) FindPublishedBySlug(ctx context.Context, slug string) (*PublishedArticle, error) {
+	if err := f.called("FindPublishedBySlug"); err != nil {
+		return nil, err
+	}
+	for _, a := range f.articles {
+		if a.Slug.Value() == slug {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSources) FindPublished(ctx context.Context, query ArticleQuery) ([]*PublishedArticle, error) {
+	if err := f.called("FindPublished"); err != nil {
+		return nil, err
+	}
+	var found []*PublishedArticle
+	for _, a := range f.articles {
+		if (query.CategoryID == nil || a.CategoryID == *query.CategoryID) && (query.AuthorID == nil || a.AuthorID == *query.AuthorID) {
+			found = append(found, a)
+		}
+	}
+	return found[min(query.Offset, len(found)):min(query.Offset+query.Limit, len(found))], nil
+}
+
+func (f *fakeSources) FindLatestByCategories(ctx context.Context, categoryIDs []string, perCategory int) (map[string][]*PublishedArticle, error) {
+	if err := f.called("FindLatestByCategories"); err != nil {
+		return nil, err
+	}
+	found := map[string][]*PublishedArticle{}
+	for _, a := range f.articles {
+		if slices.Contains(categoryIDs, a.CategoryID) && len(found[a.CategoryID]) < perCategory {
+			found[a.CategoryID] = append(found[a.CategoryID], a)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSources) FindLatestByAuthors(ctx context.Context, authorIDs []string, perAuthor int) (map[string][]*PublishedArticle, error) {
+	if err := f.called("FindLatestByAuthors"); err != nil {
+		return nil, err
+	}
+	found := map[string][]*PublishedArticle{}
+	for _, a := range f.articles {
+		if slices.Contains(authorIDs, a.AuthorID) && len(found[a.AuthorID]) < perAuthor {
+			found[a.AuthorID] = append(found[a.AuthorID], a)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSources) FindBySlug(ctx context.Context, slug string) (*taxonomy.Category, error) {
+	if err := f.called("FindBySlug"); err != nil {
+		return nil, err
+	}
+	for _, c := range f.categories {
+		if c.Slug.Value() == slug {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeSources) FindTree(ctx context.Context) ([]*taxonomy.Category, error) {
+	return f.categories, f.called("FindTree")
+}
+
+func (f *fakeSources) FindByIDs(ctx context.Context, ids []string) (map[string]*account.UserAccount, error) {
+	if err := f.called("FindByIDs"); err != nil {
+		return nil, err
+	}
+	found := map[string]*account.UserAccount{}
+	for _, id := range ids {
+		if a, ok := f.accounts[id]; ok {
+			found[id] = a
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSources) FindTopLevel(ctx context.Context, articleIDs []string, sort comment.SortMode, perArticle int) (map[string][]*comment.Comment, error) {
+	if err := f.called("FindTopLevel"); err != nil {
+		return nil, err
+	}
+	found := map[string][]*comment.Comment{}
+	for _, c := range f.comments {
+		if c.ParentID == nil && slices.Contains(articleIDs, c.ArticleID) && len(found[c.ArticleID]) < perArticle {
+			found[c.ArticleID] = append(found[c.ArticleID], c)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSources) FindReplyPreviews(ctx context.Context, parentIDs []string, perParent int) (map[string][]*comment.Comment, error) {
+	if err := f.called("FindReplyPreviews"); err != nil {
+		return nil, err
+	}
+	found := map[string][]*comment.Comment{}
+	for _, c := range f.comments {
+		if c.ParentID != nil && slices.Contains(parentIDs, *c.ParentID) && len(found[*c.ParentID]) < perParent {
+			found[*c.ParentID] = append(found[*c.ParentID], c)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSources) CountVisible(ctx context.Context, articleIDs []string) (map[string]int, error) {
+	if err := f.called("CountVisible"); err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	for _, c := range f.comments {
+		if c.IsVisible() && slices.Contains(articleIDs, c.ArticleID) {
+			counts[c.ArticleID]++
+		}
+	}
+	return counts, nil
+}
+
+func (f *fakeSources) sources() Sources {
+	return Sources{Articles: f, Categories: f, Accounts: f, Comments: f}
+}
+
+func newFakeSources(t *testing.T) *fakeSources {
+	t.Helper()
+	f := &fakeSources{accounts: map[string]*account.UserAccount{}, calls: map[string]int{}}
+
+	newsSlug, _ := taxonomy.NewSlug("news")
+	news, err := taxonomy.NewCategory("cat-1", *newsSlug, "News", "", nil, 0, "admin-1")
+	if err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	regionalSlug, _ := taxonomy.NewSlug("regional")
+	regional, _ := taxonomy.NewCategory("cat-2", *regionalSlug, "Regional", "", news, 0, "admin-1")
+	f.categories = []*taxonomy.Category{news, regional}
+
+	for _, u := range []struct{ id, username string }{{"u1", "sari"}, {"u2", "budi"}, {"u3", "gone"}} {
+		username, _ := account.NewUsername(u.username)
+		f.accounts[u.id] = &account.UserAccount{ID: u.id, Username: *username, Status: account.StatusActive}
+	}
+	f.accounts["u3"].Status = account.StatusDeleted
+
+	published := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	for i, a := range []struct{ id, slug, title, author, category string }{
+		{"a3", "floods-recede", "Floods recede", "u2", "cat-2"},
+		{"a2", "budget-passed", "Budget passed", "u1", "cat-1"},
+		{"a1", "floods-in-jakarta", "Floods in Jakarta", "u1", "cat-2"},
+	} {
+		slug, _ := article.NewSlug(a.slug)
+		art, err := article.NewArticle(a.id, *slug, a.title, "", "<p>Body</p>", a.author)
+		if err != nil {
+			t.Fatalf("failed to create article: %v", err)
+		}
+		at := published.Add(-time.Duration(i) * time.Hour)
+		art.Status, art.PublishedAt, art.UpdatedAt = article.StatusPublished, &at, at
+		f.articles = append(f.articles, &PublishedArticle{Article: art, CategoryID: a.category})
+	}
+
+	root, _ := comment.NewComment("c1", "a1", comment.AccountAuthor("u2"), "Stay safe", 1)
+	reply, _ := comment.NewReply("c2", root, comment.AccountAuthor("u3"), "Thanks", 1)
+	guest, _ := comment.NewGuestAuthor("Rina", "rina@example.com")
+	other, _ := comment.NewComment("c3", "a3", *guest, "Good news", 1)
+	removed, _ := comment.NewComment("c4", "a3", comment.AccountAuthor("u1"), "Off topic", 2)
+	for _, c := range []*comment.Comment{root, reply, other} {
+		_ = c.Approve("mod-1", published)
+	}
+	_ = removed.Reject("mod-1", published)
+	root.ReplyCount = 1
+	f.comments = []*comment.Comment{root, reply, other, removed}
+	return f
+}
+
+func post(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+	return rec
+}
+
+func TestRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"get", http.MethodGet, "/graphql?query=" + url.QueryEscape(`{ article(slug: "budget-passed") { title } }`), "", nil, http.StatusOK, `{"data":{"article":{"title":"Budget passed"}}}`},
+		{"get with variables", http.MethodGet, "/graphql?query=" + url.QueryEscape(`query ($id: ID!) { article(id: $id) { title } }`) + "&variables=" + url.QueryEscape(`{"id": "a3"}`), "", nil, http.StatusOK, `{"data":{"article":{"title":"Floods recede"}}}`},
+		{"get invalid variables", http.MethodGet, "/graphql?query=%7B%7D&variables=%5B", "", nil, http.StatusBadRequest, `{"errors":[{"message":"variables must be a JSON object"}],"data":null}`},
+		{"post", http.MethodPost, "/graphql", `{"query": "query Front($n: Int) { articles(first: $n) { id } }", "variables": {"n": 2}}`, nil, http.StatusOK, `{"data":{"articles":[{"id":"a3"},{"id":"a2"}]}}`},
+		{"post invalid body", http.MethodPost, "/graphql", `{"query":`, nil, http.StatusBadRequest, `{"errors":[{"message":"invalid request body"}],"data":null}`},
+		{"post without query", http.MethodPost, "/graphql", `{}`, nil, http.StatusBadRequest, `{"errors":[{"message":"query is required"}],"data":null}`},
+		{"post too large", http.MethodPost, "/graphql", `{"query": "` + strings.Repeat(" ", maxRequestBytes) + `"}`, nil, http.StatusRequestEntityTooLarge, `{"errors":[{"message":"query is too large"}],"data":null}`},
+		{"syntax error", http.MethodPost, "/graphql", `{"query": "{ article {"}`, nil, http.StatusBadRequest, `{"errors":[{"message":"Expected Name, found \u003cEOF\u003e","locations":[{"line":1,"column":12}],"extensions":{"code":"GRAPHQL_PARSE_FAILED"}}],"data":null}`},
+		{"missing variable", http.MethodPost, "/graphql", `{"query": "query ($id: ID!) { article(id: $id) { id } }"}`, nil, http.StatusBadRequest, `{"errors":[{"message":"must be defined","path":["variable","id"],"extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}],"data":null}`},
+		{"mutation", http.MethodPost, "/graphql", `{"query": "mutation { article { id } }"}`, nil, http.StatusBadRequest, `{"errors":[{"message":"Schema does not support operation type \"mutation\"","locations":[{"line":1,"column":1}],"extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}],"data":null}`},
+		{"resolver error", http.MethodPost, "/graphql", `{"query": "{ article { id } }"}`, nil, http.StatusOK, `{"errors":[{"message":"pass either id or slug","path":["article"],"locations":[{"line":1,"column":3}]}],"data":{"article":null}}`},
+		{"page too large", http.MethodPost, "/graphql", `{"query": "{ articles(first: 500) { id } }"}`, nil, http.StatusOK, `{"errors":[{"message":"first must be between 1 and 50","path":["articles"],"locations":[{"line":1,"column":3}]}],"data":{"articles":null}}`},
+		{"store failure", http.MethodPost, "/graphql", `{"query": "{ article(id: \"a1\") { id } }"}`, errors.New("connection reset"), http.StatusOK, `{"errors":[{"message":"something went wrong","path":["article"],"locations":[{"line":1,"column":3}]}],"data":{"article":null}}`},
+		{"timeout", http.MethodPost, "/graphql", `{"query": "{ categories { id } }"}`, context.DeadlineExceeded, http.StatusOK, `{"errors":[{"message":"request timed out","path":["categories"],"locations":[{"line":1,"column":3}]}],"data":{"categories":null}}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sources := newFakeSources(t)
+			sources.err = tc.err
+			rec := httptest.NewRecorder()
+			NewRouter(sources.sources()).ServeHTTP(rec, httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body)))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if got := strings.TrimSpace(rec.Body.String()); got != tc.expectedBody {
+				t.Errorf("expected body\n%s\ngot\n%s", tc.expectedBody, got)
+			}
+		})
+	}
+}
+
+// TestRouter_Batching asks for every nested field of a front page and expects
+// one lookup per field and level, however many articles and comments the page has
+func TestRouter_Batching(t *testing.T) {
+	sources := newFakeSources(t)
+	rec := post(t, NewRouter(sources.sources()), `{"query": "{ articles { slug category { name parent { slug } } author { username } commentCount comments(first: 5) { body authorName replies { authorName body } } } }"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	body := `{"data":{"articles":[` +
+		`{"slug":"floods-recede","category":{"name":"Regional","parent":{"slug":"news"}},"author":{"username":"budi"},"commentCount":1,"comments":[{"body":"Good news","authorName":"Rina","replies":[]},{"body":null,"authorName":null,"replies":[]}]},` +
+		`{"slug":"budget-passed","category":{"name":"News","parent":null},"author":{"username":"sari"},"commentCount":0,"comments":[]},` +
+		`{"slug":"floods-in-jakarta","category":{"name":"Regional","parent":{"slug":"news"}},"author":{"username":"sari"},"commentCount":2,"comments":[{"body":"Stay safe","authorName":"budi","replies":[{"authorName":null,"body":"Thanks"}]}]}` +
+		`]}}`
+	if got := strings.TrimSpace(rec.Body.String()); got != body {
+		t.Errorf("expected body\n%s\ngot\n%s", body, got)
+	}
+	// Accounts are read for the article authors, then for the reply authors
+	// two levels down; the comment authors one level down were already cached
+	want := map[string]int{"FindPublished": 1, "FindTree": 1, "FindByIDs": 2, "CountVisible": 1, "FindTopLevel": 1, "FindReplyPreviews": 1}
+	if !maps.Equal(sources.calls, want) {
+		t.Errorf("expected lookups %v, got %v", want, sources.calls)
+	}
+}
+
+func TestRouter_Schema(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewRouter(newFakeSources(t).sources()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/graphql/schema", nil))
+
+	sdl := rec.Body.String()
+	for _, want := range []string{
+		"scalar DateTime\n",
+		"type Query {\n",
+		`  articles(category: String, author: ID, first: Int = 20, offset: Int = 0): [Article!]` + "\n",
+		`  comments(sort: String = "best", first: Int = 20): [Comment!]` + "\n",
+		"  publishedAt: DateTime!\n",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("expected the schema to contain %q, got\n%s", want, sdl)
+		}
+	}
+
+	var resp map[string]any
+	if err := json.Unmarshal([]byte(sdl), &resp); err == nil {
+		t.Error("expected the schema as SDL, not JSON")
+	}
+}
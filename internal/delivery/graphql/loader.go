@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// batchWait is how long a loader collects keys before fetching them. gqlgen
+// resolves the items of a list in parallel, so the keys of a whole level of
+// the query arrive within a few microseconds of each other.
+const batchWait = 2 * time.Millisecond
+
+// loader is a per-request dataloader. Keys asked for within batchWait of the
+// first are fetched in one batch; values are cached for the rest of the request.
+type loader[K comparable, V any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending []K
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+func newLoader[K comparable, V any](ctx context.Context, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{ctx: ctx, fetch: fetch, results: map[K]*result[V]{}}
+}
+
+// load waits for the batch holding the key, the value is the zero value when
+// the batch did not return it
+func (l *loader[K, V]) load(key K) (V, error) {
+	l.mu.Lock()
+	res, ok := l.results[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.results[key] = res
+		if len(l.pending) == 0 {
+			time.AfterFunc(batchWait, l.flush)
+		}
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	<-res.done
+	return res.value, res.err
+}
+
+func (l *loader[K, V]) flush() {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	batch := make([]*result[V], len(keys))
+	for i, key := range keys {
+		batch[i] = l.results[key]
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(l.ctx, keys)
+	for i, key := range keys {
+		res := batch[i]
+		if err != nil {
+			res.err = err
+		} else {
+			res.value = values[key]
+		}
+		close(res.done)
+	}
+}
+
+// loaderSet keeps one loader per argument combination, e.g. the first three
+// replies and the first ten are separate batches
+type loaderSet[K comparable, V any] struct {
+	ctx     context.Context
+	mu      sync.Mutex
+	loaders map[string]*loader[K, V]
+}
+
+func newLoaderSet[K comparable, V any](ctx context.Context) *loaderSet[K, V] {
+	return &loaderSet[K, V]{ctx: ctx, loaders: map[string]*loader[K, V]{}}
+}
+
+func (s *loaderSet[K, V]) get(variant string, fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.loaders[variant]
+	if !ok {
+		l = newLoader(s.ctx, fetch)
+		s.loaders[variant] = l
+	}
+	return l
+}
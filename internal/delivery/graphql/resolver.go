@@ -0,0 +1,319 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/taxonomy"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Page sizes, nested lists default smaller since every parent gets one. The
+// defaults are repeated in schema.graphqls, they apply here to explicit nulls.
+const (
+	maxFirst             = 50
+	defaultArticlesFirst = 20
+	defaultNestedFirst   = 10
+	defaultCommentsFirst = 20
+	defaultRepliesFirst  = 3
+)
+
+// loaders are created per request, so nothing is cached across readers
+type loaders struct {
+	articles         *loader[string, *PublishedArticle]
+	accounts         *loader[string, *account.UserAccount]
+	commentCounts    *loader[string, int]
+	categoryArticles *loaderSet[string, []*PublishedArticle]
+	authorArticles   *loaderSet[string, []*PublishedArticle]
+	comments         *loaderSet[string, []*comment.Comment]
+	replies          *loaderSet[string, []*comment.Comment]
+	categories       func() (*categoryTree, error)
+}
+
+func newLoaders(ctx context.Context, sources Sources) *loaders {
+	return &loaders{
+		articles:         newLoader(ctx, sources.Articles.FindPublishedByIDs),
+		accounts:         newLoader(ctx, sources.Accounts.FindByIDs),
+		commentCounts:    newLoader(ctx, sources.Comments.CountVisible),
+		categoryArticles: newLoaderSet[string, []*PublishedArticle](ctx),
+		authorArticles:   newLoaderSet[string, []*PublishedArticle](ctx),
+		comments:         newLoaderSet[string, []*comment.Comment](ctx),
+		replies:          newLoaderSet[string, []*comment.Comment](ctx),
+		// The whole tree is one small query, cheaper than batching lookups by ID
+		categories: sync.OnceValues(func() (*categoryTree, error) {
+			categories, err := sources.Categories.FindTree(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return newCategoryTree(categories), nil
+		}),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+type categoryTree struct {
+	byID     map[string]*taxonomy.Category
+	roots    []*taxonomy.Category
+	children map[string][]*taxonomy.Category
+}
+
+// newCategoryTree indexes FindTree's result, which lists siblings in position order
+func newCategoryTree(categories []*taxonomy.Category) *categoryTree {
+	t := &categoryTree{byID: map[string]*taxonomy.Category{}, children: map[string][]*taxonomy.Category{}}
+	for _, c := range categories {
+		t.byID[c.ID] = c
+		if c.ParentID == nil {
+			t.roots = append(t.roots, c)
+		} else {
+			t.children[*c.ParentID] = append(t.children[*c.ParentID], c)
+		}
+	}
+	return t
+}
+
+// Resolver answers the public read schema: articles, their sections and
+// authors, and comment threads. generated.go calls it for every field that
+// does not read straight off the domain type.
+type Resolver struct {
+	sources Sources
+}
+
+func (r *Resolver) Query() QueryResolver       { return &queryResolver{r} }
+func (r *Resolver) Article() ArticleResolver   { return &articleResolver{r} }
+func (r *Resolver) Category() CategoryResolver { return &categoryResolver{r} }
+func (r *Resolver) Author() AuthorResolver     { return &authorResolver{r} }
+func (r *Resolver) Comment() CommentResolver   { return &commentResolver{r} }
+
+type queryResolver struct{ *Resolver }
+
+func (r *queryResolver) Article(ctx context.Context, id *string, slug *string) (*PublishedArticle, error) {
+	if (id == nil) == (slug == nil) {
+		return nil, userError("pass either id or slug")
+	}
+	if id != nil {
+		return loadersFrom(ctx).articles.load(*id)
+	}
+	return r.sources.Articles.FindPublishedBySlug(ctx, *slug)
+}
+
+func (r *queryResolver) Articles(ctx context.Context, category *string, author *string, first *int, offset *int) ([]*PublishedArticle, error) {
+	limit, err := pageSize(first, defaultArticlesFirst)
+	if err != nil {
+		return nil, err
+	}
+	q := ArticleQuery{Limit: limit, AuthorID: author}
+	if offset != nil {
+		q.Offset = *offset
+	}
+	if q.Offset < 0 {
+		return nil, userError("offset cannot be negative")
+	}
+	if category != nil {
+		c, err := r.sources.Categories.FindBySlug(ctx, *category)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return []*PublishedArticle{}, nil
+		}
+		q.CategoryID = &c.ID
+	}
+	return list(r.sources.Articles.FindPublished(ctx, q))
+}
+
+func (r *queryResolver) Category(ctx context.Context, slug string) (*taxonomy.Category, error) {
+	return r.sources.Categories.FindBySlug(ctx, slug)
+}
+
+func (r *queryResolver) Categories(ctx context.Context) ([]*taxonomy.Category, error) {
+	tree, err := loadersFrom(ctx).categories()
+	if err != nil {
+		return nil, err
+	}
+	return list(tree.roots, nil)
+}
+
+func (r *queryResolver) Author(ctx context.Context, id string) (*account.UserAccount, error) {
+	return loadAuthor(ctx, id)
+}
+
+type articleResolver struct{ *Resolver }
+
+func (r *articleResolver) Slug(ctx context.Context, a *PublishedArticle) (string, error) {
+	return a.Slug.Value(), nil
+}
+
+func (r *articleResolver) Category(ctx context.Context, a *PublishedArticle) (*taxonomy.Category, error) {
+	tree, err := loadersFrom(ctx).categories()
+	if err != nil {
+		return nil, err
+	}
+	return tree.byID[a.CategoryID], nil
+}
+
+func (r *articleResolver) Author(ctx context.Context, a *PublishedArticle) (*account.UserAccount, error) {
+	return loadAuthor(ctx, a.AuthorID)
+}
+
+func (r *articleResolver) CommentCount(ctx context.Context, a *PublishedArticle) (*int, error) {
+	count, err := loadersFrom(ctx).commentCounts.load(a.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+func (r *articleResolver) Comments(ctx context.Context, a *PublishedArticle, sortBy *string, first *int) ([]*comment.Comment, error) {
+	limit, err := pageSize(first, defaultCommentsFirst)
+	if err != nil {
+		return nil, err
+	}
+	sort := comment.SortBest
+	if sortBy != nil {
+		if sort, err = comment.ParseSortMode(*sortBy); err != nil {
+			return nil, userError("sort must be best, newest or oldest")
+		}
+	}
+	comments := loadersFrom(ctx).comments.get(string(sort)+":"+strconv.Itoa(limit), func(ctx context.Context, articleIDs []string) (map[string][]*comment.Comment, error) {
+		return r.sources.Comments.FindTopLevel(ctx, articleIDs, sort, limit)
+	})
+	return list(comments.load(a.ID))
+}
+
+type categoryResolver struct{ *Resolver }
+
+func (r *categoryResolver) Slug(ctx context.Context, c *taxonomy.Category) (string, error) {
+	return c.Slug.Value(), nil
+}
+
+func (r *categoryResolver) Parent(ctx context.Context, c *taxonomy.Category) (*taxonomy.Category, error) {
+	if c.ParentID == nil {
+		return nil, nil
+	}
+	tree, err := loadersFrom(ctx).categories()
+	if err != nil {
+		return nil, err
+	}
+	return tree.byID[*c.ParentID], nil
+}
+
+func (r *categoryResolver) Children(ctx context.Context, c *taxonomy.Category) ([]*taxonomy.Category, error) {
+	tree, err := loadersFrom(ctx).categories()
+	if err != nil {
+		return nil, err
+	}
+	return list(tree.children[c.ID], nil)
+}
+
+func (r *categoryResolver) Articles(ctx context.Context, c *taxonomy.Category, first *int) ([]*PublishedArticle, error) {
+	limit, err := pageSize(first, defaultNestedFirst)
+	if err != nil {
+		return nil, err
+	}
+	articles := loadersFrom(ctx).categoryArticles.get(strconv.Itoa(limit), func(ctx context.Context, categoryIDs []string) (map[string][]*PublishedArticle, error) {
+		return r.sources.Articles.FindLatestByCategories(ctx, categoryIDs, limit)
+	})
+	return list(articles.load(c.ID))
+}
+
+type authorResolver struct{ *Resolver }
+
+func (r *authorResolver) Username(ctx context.Context, a *account.UserAccount) (string, error) {
+	return a.Username.String(), nil
+}
+
+func (r *authorResolver) Articles(ctx context.Context, a *account.UserAccount, first *int) ([]*PublishedArticle, error) {
+	limit, err := pageSize(first, defaultNestedFirst)
+	if err != nil {
+		return nil, err
+	}
+	articles := loadersFrom(ctx).authorArticles.get(strconv.Itoa(limit), func(ctx context.Context, authorIDs []string) (map[string][]*PublishedArticle, error) {
+		return r.sources.Articles.FindLatestByAuthors(ctx, authorIDs, limit)
+	})
+	return list(articles.load(a.ID))
+}
+
+type commentResolver struct{ *Resolver }
+
+func (r *commentResolver) Body(ctx context.Context, c *comment.Comment) (*string, error) {
+	if !c.IsVisible() {
+		return nil, nil
+	}
+	return &c.Body, nil
+}
+
+func (r *commentResolver) AuthorName(ctx context.Context, c *comment.Comment) (*string, error) {
+	if !c.IsVisible() {
+		return nil, nil
+	}
+	if c.Author.IsGuest() {
+		return &c.Author.GuestName, nil
+	}
+	author, err := loadAuthor(ctx, c.Author.AccountID)
+	if err != nil || author == nil {
+		return nil, err
+	}
+	name := author.Username.String()
+	return &name, nil
+}
+
+func (r *commentResolver) Replies(ctx context.Context, c *comment.Comment, first *int) ([]*comment.Comment, error) {
+	limit, err := pageSize(first, defaultRepliesFirst)
+	if err != nil {
+		return nil, err
+	}
+	if c.ReplyCount == 0 {
+		return []*comment.Comment{}, nil
+	}
+	replies := loadersFrom(ctx).replies.get(strconv.Itoa(limit), func(ctx context.Context, parentIDs []string) (map[string][]*comment.Comment, error) {
+		return r.sources.Comments.FindReplyPreviews(ctx, parentIDs, limit)
+	})
+	return list(replies.load(c.ID))
+}
+
+// loadAuthor leaves out deleted accounts, so their profile is not reachable by ID
+func loadAuthor(ctx context.Context, id string) (*account.UserAccount, error) {
+	a, err := loadersFrom(ctx).accounts.load(id)
+	if err != nil || a == nil || a.Status == account.StatusDeleted {
+		return nil, err
+	}
+	return a, nil
+}
+
+// pageSize applies the default to a left out or null first
+func pageSize(first *int, fallback int) (int, error) {
+	if first == nil {
+		return fallback, nil
+	}
+	if *first < 1 || *first > maxFirst {
+		return 0, userError(fmt.Sprintf("first must be between 1 and %d", maxFirst))
+	}
+	return *first, nil
+}
+
+// list answers an empty list rather than null when there is nothing to list
+func list[T any](items []T, err error) ([]T, error) {
+	if items == nil && err == nil {
+		return []T{}, nil
+	}
+	return items, err
+}
+
+// userError is a resolver error whose message is safe to show the client
+func userError(message string) *gqlerror.Error {
+	return &gqlerror.Error{Message: message}
+}
@@ -0,0 +1,73 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/taxonomy"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// The lookups the schema reads through. Every list lookup takes the keys of a
+// whole level of the query, so a page of articles costs one query per field
+// rather than one per article.
+
+// PublishedArticle is a published article with the section it is filed under
+type PublishedArticle struct {
+	*article.Article
+	CategoryID string
+}
+
+// ArticleQuery selects a page of published articles, newest first
+type ArticleQuery struct {
+	CategoryID *string
+	AuthorID   *string
+	Limit      int
+	Offset     int
+}
+
+// Articles reads published articles (implemented by the article module).
+// Drafts, scheduled and archived articles are never returned.
+type Articles interface {
+	// FindPublishedByIDs returns the articles keyed by ID, unknown IDs are left out
+	FindPublishedByIDs(ctx context.Context, ids []string) (map[string]*PublishedArticle, error)
+	// FindPublishedBySlug returns nil when no published article has the slug
+	FindPublishedBySlug(ctx context.Context, slug string) (*PublishedArticle, error)
+	FindPublished(ctx context.Context, query ArticleQuery) ([]*PublishedArticle, error)
+
+	// FindLatestByCategories returns up to perCategory newest articles of each category in one query
+	FindLatestByCategories(ctx context.Context, categoryIDs []string, perCategory int) (map[string][]*PublishedArticle, error)
+	// FindLatestByAuthors returns up to perAuthor newest articles of each author in one query
+	FindLatestByAuthors(ctx context.Context, authorIDs []string, perAuthor int) (map[string][]*PublishedArticle, error)
+}
+
+// Categories is the part of taxonomy.CategoryRepository the schema reads
+type Categories interface {
+	FindBySlug(ctx context.Context, slug string) (*taxonomy.Category, error)
+	FindTree(ctx context.Context) ([]*taxonomy.Category, error)
+}
+
+// Accounts reads the authors of articles and comments (implemented by the account module)
+type Accounts interface {
+	// FindByIDs returns the accounts keyed by ID, unknown IDs are left out
+	FindByIDs(ctx context.Context, ids []string) (map[string]*account.UserAccount, error)
+}
+
+// Comments reads comment threads (implemented by the comment module)
+type Comments interface {
+	// FindTopLevel returns up to perArticle top-level comments of each article in one query,
+	// the same comments comment.CommentRepository.FindPage shows readers
+	FindTopLevel(ctx context.Context, articleIDs []string, sort comment.SortMode, perArticle int) (map[string][]*comment.Comment, error)
+	// FindReplyPreviews returns up to perParent oldest direct replies of each parent in one query
+	FindReplyPreviews(ctx context.Context, parentIDs []string, perParent int) (map[string][]*comment.Comment, error)
+	// CountVisible returns the number of visible comments of each article, all depths
+	CountVisible(ctx context.Context, articleIDs []string) (map[string]int, error)
+}
+
+type Sources struct {
+	Articles   Articles
+	Categories Categories
+	Accounts   Accounts
+	Comments   Comments
+}
@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// Limits on a query, checked before it is validated or run
+const (
+	// maxTokens bounds the size of the query text
+	maxTokens = 5000
+	// maxDepth bounds how deeply a query may nest fields, so one request cannot
+	// walk article → comments → replies → … without end
+	maxDepth = 10
+	// maxSelections bounds the fields of the query once every fragment spread
+	// is expanded, nested fragments can otherwise double it at each level
+	maxSelections = 500
+	// maxComplexity bounds the objects a query may ask for, lists count as
+	// many times as their first argument allows
+	maxComplexity = 10000
+)
+
+// queryLimits rejects queries that are too deep or expand into too many
+// fields, and documents of several operations that do not name one. It runs
+// before gqlgen parses and validates the query: both expand fragments as
+// often as they are spread, so the check itself has to count each fragment
+// once.
+type queryLimits struct{}
+
+var (
+	_ graphql.HandlerExtension          = queryLimits{}
+	_ graphql.OperationParameterMutator = queryLimits{}
+)
+
+func (queryLimits) ExtensionName() string {
+	return "QueryLimits"
+}
+
+func (queryLimits) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (queryLimits) MutateOperationParameters(_ context.Context, params *graphql.RawParams) *gqlerror.Error {
+	doc, err := parser.ParseQueryWithTokenLimit(&ast.Source{Input: params.Query}, maxTokens)
+	if err != nil {
+		// gqlgen parses the query again and reports the error
+		return nil
+	}
+
+	if params.OperationName == "" && len(doc.Operations) > 1 {
+		return validationError("Must provide operation name if query contains multiple operations.")
+	}
+
+	s := &sizer{fragments: doc.Fragments, sizes: map[string]size{}, visiting: map[string]bool{}}
+	for _, op := range doc.Operations {
+		sz := s.selectionSet(op.SelectionSet)
+		if sz.depth > maxDepth {
+			return validationError(fmt.Sprintf("Query is nested too deeply, at most %d levels are allowed.", maxDepth))
+		}
+		if sz.selections > maxSelections {
+			return validationError(fmt.Sprintf("Query selects too many fields once fragments are expanded, at most %d are allowed.", maxSelections))
+		}
+	}
+	return nil
+}
+
+func validationError(message string) *gqlerror.Error {
+	err := gqlerror.Errorf("%s", message)
+	errcode.Set(err, errcode.ValidationFailed)
+	return err
+}
+
+// size is the depth and field count of a selection set with its fragments expanded
+type size struct {
+	depth      int
+	selections int
+}
+
+// sizer measures selection sets, remembering the size of each fragment.
+// Counts stop growing past the limits so they cannot overflow.
+type sizer struct {
+	fragments ast.FragmentDefinitionList
+	sizes     map[string]size
+	visiting  map[string]bool // Fragments being measured, to stop at cycles
+}
+
+func (s *sizer) selectionSet(set ast.SelectionSet) size {
+	var total size
+	for _, sel := range set {
+		var sz size
+		switch sel := sel.(type) {
+		case *ast.Field:
+			sz = s.selectionSet(sel.SelectionSet)
+			sz.depth++
+			sz.selections++
+		case *ast.InlineFragment:
+			sz = s.selectionSet(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			sz = s.fragment(sel.Name)
+		}
+		total.depth = max(total.depth, sz.depth)
+		total.selections = min(total.selections+sz.selections, maxSelections+1)
+	}
+	return total
+}
+
+// fragment sizes a named fragment. Unknown and cyclic fragments count as
+// empty, validation rejects the query for them.
+func (s *sizer) fragment(name string) size {
+	if sz, ok := s.sizes[name]; ok {
+		return sz
+	}
+	def := s.fragments.ForName(name)
+	if def == nil || s.visiting[name] {
+		return size{}
+	}
+	s.visiting[name] = true
+	sz := s.selectionSet(def.SelectionSet)
+	delete(s.visiting, name)
+	s.sizes[name] = sz
+	return sz
+}
+
+// complexity counts a list field once for every item its first argument lets
+// it return, other fields count one each
+func complexity() ComplexityRoot {
+	var c ComplexityRoot
+	c.Query.Articles = func(child int, _, _ *string, first, _ *int) int {
+		return listComplexity(child, first, defaultArticlesFirst)
+	}
+	c.Article.Comments = func(child int, _ *string, first *int) int {
+		return listComplexity(child, first, defaultCommentsFirst)
+	}
+	c.Category.Articles = func(child int, first *int) int {
+		return listComplexity(child, first, defaultNestedFirst)
+	}
+	c.Author.Articles = func(child int, first *int) int {
+		return listComplexity(child, first, defaultNestedFirst)
+	}
+	c.Comment.Replies = func(child int, first *int) int {
+		return listComplexity(child, first, defaultRepliesFirst)
+	}
+	return c
+}
+
+// listComplexity stops growing past maxComplexity so nested lists cannot overflow
+func listComplexity(child int, first *int, fallback int) int {
+	n := fallback
+	if first != nil && *first >= 1 && *first <= maxFirst {
+		n = *first
+	}
+	return min(1+n*child, maxComplexity+1)
+}
@@ -0,0 +1,213 @@
+package accessibility
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Checklists is the part of the accessibility service the endpoints need
+type Checklists interface {
+	Review(ctx context.Context, siteID string, d app.Draft) (*app.Review, error)
+	Policy(ctx context.Context, siteID string) (*accessibility.Policy, error)
+	SetPolicy(ctx context.Context, staffID, siteID string, strictness accessibility.Strictness, at time.Time) (*accessibility.Policy, error)
+}
+
+type reviewRequest struct {
+	Title           string `json:"title"`
+	SEOTitle        string `json:"seo_title"`
+	MetaDescription string `json:"meta_description"`
+	Language        string `json:"language"`
+	Body            string `json:"body"`
+}
+
+type policyRequest struct {
+	Strictness string `json:"strictness"`
+}
+
+type issueResponse struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+type resultResponse struct {
+	Check    string          `json:"check"`
+	Passed   bool            `json:"passed"`
+	Blocking bool            `json:"blocking"`
+	Issues   []issueResponse `json:"issues"`
+}
+
+type checklistResponse struct {
+	Strictness string           `json:"strictness"`
+	Score      int              `json:"score"`
+	Blocked    bool             `json:"blocked"`
+	Results    []resultResponse `json:"results"`
+}
+
+type warningResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type headlineResponse struct {
+	Surface   string            `json:"surface"`
+	Field     string            `json:"field"`
+	WidthPx   float64           `json:"width_px"`
+	MaxPx     float64           `json:"max_px"`
+	Display   string            `json:"display"`
+	Truncated bool              `json:"truncated"`
+	Warnings  []warningResponse `json:"warnings"`
+}
+
+type reviewResponse struct {
+	checklistResponse
+	Headlines []headlineResponse `json:"headlines"`
+	Warnings  int                `json:"warnings"`
+}
+
+type policyResponse struct {
+	SiteID     string  `json:"site_id"`
+	Strictness string  `json:"strictness"`
+	UpdatedBy  *string `json:"updated_by,omitempty"`
+	UpdatedAt  *string `json:"updated_at,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	checklists Checklists
+	staff      StaffResolver
+}
+
+func NewHandler(checklists Checklists, staff StaffResolver) *Handler {
+	return &Handler{checklists: checklists, staff: staff}
+}
+
+// NewAdminRouter mounts the review checklist and per-site strictness, it must sit behind admin authentication
+func NewAdminRouter(checklists Checklists, staff StaffResolver) http.Handler {
+	h := NewHandler(checklists, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/sites/{siteID}/accessibility/review", h.Review)
+	mux.HandleFunc("GET /admin/sites/{siteID}/accessibility/policy", h.Policy)
+	mux.HandleFunc("PUT /admin/sites/{siteID}/accessibility/policy", h.SetPolicy)
+	return mux
+}
+
+// Review audits a draft without saving it. The review screen shows the
+// checklist, score and headline warnings and disables publishing while the
+// checklist is blocked.
+func (h *Handler) Review(w http.ResponseWriter, r *http.Request) {
+	var req reviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	review, err := h.checklists.Review(r.Context(), r.PathValue("siteID"), app.Draft{
+		Title:           req.Title,
+		SEOTitle:        req.SEOTitle,
+		MetaDescription: req.MetaDescription,
+		Language:        req.Language,
+		Body:            req.Body,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := reviewResponse{
+		checklistResponse: toChecklistResponse(review.Checklist),
+		Headlines:         make([]headlineResponse, 0, len(review.Headlines)),
+		Warnings:          review.Warnings(),
+	}
+	for _, p := range review.Headlines {
+		item := headlineResponse{Surface: string(p.Surface), Field: string(p.Field), WidthPx: p.WidthPx, MaxPx: p.MaxPx, Display: p.Display, Truncated: p.Truncated, Warnings: make([]warningResponse, 0, len(p.Warnings))}
+		for _, warning := range p.Warnings {
+			item.Warnings = append(item.Warnings, warningResponse{Code: string(warning.Code), Message: warning.Message})
+		}
+		resp.Headlines = append(resp.Headlines, item)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Policy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.checklists.Policy(r.Context(), r.PathValue("siteID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPolicyResponse(policy))
+}
+
+func (h *Handler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req policyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	policy, err := h.checklists.SetPolicy(r.Context(), staffID, r.PathValue("siteID"), accessibility.Strictness(req.Strictness), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPolicyResponse(policy))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, accessibility.ErrInvalidStrictness):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toChecklistResponse(list *accessibility.Checklist) checklistResponse {
+	resp := checklistResponse{
+		Strictness: string(list.Strictness),
+		Score:      list.Score,
+		Blocked:    list.Blocked(),
+		Results:    make([]resultResponse, 0, len(list.Results)),
+	}
+	for _, result := range list.Results {
+		item := resultResponse{Check: string(result.Check), Passed: result.Passed, Blocking: result.Blocking, Issues: make([]issueResponse, 0, len(result.Issues))}
+		for _, issue := range result.Issues {
+			item.Issues = append(item.Issues, issueResponse{Index: issue.Index, Message: issue.Message})
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp
+}
+
+func toPolicyResponse(p *accessibility.Policy) policyResponse {
+	resp := policyResponse{SiteID: p.SiteID, Strictness: string(p.Strictness), UpdatedBy: p.LastActionBy}
+	if !p.UpdatedAt.IsZero() {
+		at := p.UpdatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
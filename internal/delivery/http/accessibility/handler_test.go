@@ -0,0 +1,106 @@
+package accessibility
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/serp"
+)
+
+type fakeChecklists struct {
+	siteID string
+	draft  app.Draft
+	err    error
+}
+
+func (f *fakeChecklists) Review(ctx context.Context, siteID string, d app.Draft) (*app.Review, error) {
+	f.siteID, f.draft = siteID, d
+	if f.err != nil {
+		return nil, f.err
+	}
+	checklist := accessibility.DefaultPolicy(siteID).Audit(accessibility.Content{
+		Language: d.Language,
+		Images:   []accessibility.Image{{Src: "/media/banjir.jpg"}},
+	})
+	return &app.Review{Checklist: checklist, Headlines: []serp.Preview{serp.DefaultSurfaces()[1].Measure(d.MetaDescription)}}, nil
+}
+
+func (f *fakeChecklists) Policy(ctx context.Context, siteID string) (*accessibility.Policy, error) {
+	return accessibility.DefaultPolicy(siteID), f.err
+}
+
+func (f *fakeChecklists) SetPolicy(ctx context.Context, staffID, siteID string, strictness accessibility.Strictness, at time.Time) (*accessibility.Policy, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return accessibility.NewPolicy(siteID, strictness, staffID, at)
+}
+
+func staff(r *http.Request) (string, bool) {
+	return "editor-1", r.Header.Get("Authorization") != ""
+}
+
+func TestHandler_Review(t *testing.T) {
+	checklists := &fakeChecklists{}
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/sites/site-1/accessibility/review", strings.NewReader(`{"title":"Banjir","language":"id","body":"<img src=\"banjir.jpg\">"}`))
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(checklists, staff)).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if checklists.siteID != "site-1" || checklists.draft.Language != "id" {
+		t.Errorf("expected draft for site-1, got %s %+v", checklists.siteID, checklists.draft)
+	}
+	var resp reviewResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if len(resp.Headlines) != 1 || resp.Headlines[0].Surface != "google_description" || resp.Warnings != 1 || resp.Headlines[0].Warnings[0].Code != "empty" {
+		t.Errorf("expected an empty meta description warning, got %+v", resp.Headlines)
+	}
+	if !resp.Blocked || resp.Strictness != "standard" || resp.Score >= 100 {
+		t.Errorf("expected blocked checklist, got %+v", resp)
+	}
+	if resp.Results[0].Check != "image_alt" || !resp.Results[0].Blocking || len(resp.Results[0].Issues) != 1 {
+		t.Errorf("expected blocking image_alt issue, got %+v", resp.Results[0])
+	}
+}
+
+func TestHandler_SetPolicy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"ok", `{"strictness":"strict"}`, true, nil, http.StatusOK},
+		{"no session", `{"strictness":"strict"}`, false, nil, http.StatusUnauthorized},
+		{"invalid body", `{`, true, nil, http.StatusBadRequest},
+		{"invalid strictness", `{"strictness":"lenient"}`, true, nil, http.StatusUnprocessableEntity},
+		{"store failure", `{"strictness":"strict"}`, true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/admin/sites/site-1/accessibility/policy", strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeChecklists{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
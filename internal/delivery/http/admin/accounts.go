@@ -0,0 +1,155 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// AccountLister is the part of the admin list service the handler needs
+type AccountLister interface {
+	List(ctx context.Context, filter *account.UserAccountFilter) (*app.ListResult, error)
+}
+
+type highlightResponse struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type accountItemResponse struct {
+	ID          string                         `json:"id"`
+	Username    string                         `json:"username"`
+	Email       string                         `json:"email"`
+	DisplayName *string                        `json:"display_name,omitempty"`
+	Status      string                         `json:"status"`
+	Type        string                         `json:"type"`
+	Score       float64                        `json:"score,omitempty"`
+	MatchedOn   string                         `json:"matched_on,omitempty"`
+	Highlights  map[string][]highlightResponse `json:"highlights,omitempty"`
+}
+
+type accountListResponse struct {
+	Items  []accountItemResponse `json:"items"`
+	Total  int64                 `json:"total"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"` // Stable domain error code, the message may change
+}
+
+type AccountsHandler struct {
+	lister AccountLister
+	admin  AdminResolver
+}
+
+func NewAccountsHandler(lister AccountLister, admin AdminResolver) *AccountsHandler {
+	return &AccountsHandler{lister: lister, admin: admin}
+}
+
+// ServeHTTP handles GET /admin/accounts?q=jhon&status=active&type=membership&limit=20&offset=0
+func (h *AccountsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.admin(r); !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "admin session required"})
+		return
+	}
+	q := r.URL.Query()
+	filter := &account.UserAccountFilter{
+		OrderBy:   q.Get("order_by"),
+		SortOrder: q.Get("sort_order"),
+	}
+	if search := q.Get("q"); search != "" {
+		filter.SearchQuery = &search
+	}
+	if status := q.Get("status"); status != "" {
+		s := account.UserAccountStatus(status)
+		filter.Status = &s
+	}
+	if accountType := q.Get("type"); accountType != "" {
+		t := account.UserAccountType(accountType)
+		filter.Type = &t
+	}
+
+	var err error
+	if filter.Limit, err = intParam(q.Get("limit")); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit must be a number"})
+		return
+	}
+	if filter.Offset, err = intParam(q.Get("offset")); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "offset must be a number"})
+		return
+	}
+
+	filter.SetDefaults()
+	if err := filter.Validate(); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := h.lister.List(r.Context(), filter)
+	if err != nil {
+		if de, ok := shared.AsDomainError(err); ok && de.Kind == shared.KindValidation {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: de.Error(), Code: de.Code})
+			return
+		}
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "search took too long, refine the query"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to list accounts"})
+		return
+	}
+
+	resp := accountListResponse{
+		Items:  make([]accountItemResponse, 0, len(result.Items)),
+		Total:  result.Total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for _, item := range result.Items {
+		resp.Items = append(resp.Items, toAccountItem(item))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toAccountItem(item account.SearchResult) accountItemResponse {
+	resp := accountItemResponse{
+		ID:          item.Account.ID,
+		Username:    item.Account.Username.String(),
+		Email:       item.Account.Email.String(),
+		DisplayName: item.DisplayName,
+		Status:      string(item.Account.Status),
+		Type:        string(item.Account.Type),
+		Score:       item.Score,
+		MatchedOn:   string(item.Field),
+	}
+	if len(item.Highlights) > 0 {
+		resp.Highlights = make(map[string][]highlightResponse, len(item.Highlights))
+		for field, highlights := range item.Highlights {
+			for _, h := range highlights {
+				resp.Highlights[string(field)] = append(resp.Highlights[string(field)], highlightResponse{Start: h.Start, End: h.End})
+			}
+		}
+	}
+	return resp
+}
+
+func intParam(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
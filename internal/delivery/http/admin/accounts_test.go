@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakeLister struct {
+	filter *account.UserAccountFilter
+	result *app.ListResult
+	err    error
+}
+
+func (f *fakeLister) List(ctx context.Context, filter *account.UserAccountFilter) (*app.ListResult, error) {
+	f.filter = filter
+	return f.result, f.err
+}
+
+// signedIn resolves every request to the same administrator
+func signedIn(r *http.Request) (string, bool) { return "admin123", true }
+
+func TestAccountsHandler_Search(t *testing.T) {
+	acc, _ := account.NewUserAccountForTesting("u1", "jonathan", "jon@example.com", "TestPassword123!", account.TypeMembership, account.SelfRegistration)
+	lister := &fakeLister{result: &app.ListResult{Total: 1, Items: []account.SearchResult{{
+		Account:    acc,
+		Score:      0.95,
+		Field:      account.SearchFieldUsername,
+		Highlights: map[account.SearchField][]account.Highlight{account.SearchFieldUsername: {{Start: 0, End: 4}}},
+	}}}}
+
+	rec := httptest.NewRecorder()
+	NewAccountsHandler(lister, signedIn).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/accounts?q=jona&status=active&limit=10", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if *lister.filter.SearchQuery != "jona" || *lister.filter.Status != account.StatusActive || lister.filter.Limit != 10 {
+		t.Errorf("unexpected filter %+v", lister.filter)
+	}
+
+	var resp accountListResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if len(resp.Items) != 1 || resp.Items[0].MatchedOn != "username" || resp.Items[0].Highlights["username"][0].End != 4 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestAccountsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		err      error
+		expected int
+	}{
+		{name: "invalid limit", url: "/admin/accounts?limit=abc", expected: http.StatusBadRequest},
+		{name: "limit out of range", url: "/admin/accounts?limit=500", expected: http.StatusBadRequest},
+		{name: "query too short", url: "/admin/accounts?q=j", err: account.ErrSearchQueryTooShort, expected: http.StatusBadRequest},
+		{name: "search timeout", url: "/admin/accounts?q=jon", err: context.DeadlineExceeded, expected: http.StatusGatewayTimeout},
+		{name: "store failure", url: "/admin/accounts", err: errors.New("connection refused"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewAccountsHandler(&fakeLister{err: tt.err}, signedIn).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestAccountsHandler_ErrorCode(t *testing.T) {
+	rec := httptest.NewRecorder()
+	wrapped := fmt.Errorf("failed to search accounts: %w", account.ErrSearchQueryTooShort)
+	NewAccountsHandler(&fakeLister{err: wrapped}, signedIn).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/accounts?q=j", nil))
+
+	var resp errorResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if rec.Code != http.StatusBadRequest || resp.Code != "account.search_query_too_short" {
+		t.Errorf("expected the domain error code, got %d %+v", rec.Code, resp)
+	}
+}
@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// AccountLifecycle is the part of the account service the lifecycle actions need
+type AccountLifecycle interface {
+	Verify(ctx context.Context, staffID, accountID string) (*account.UserAccount, error)
+	Disable(ctx context.Context, staffID, accountID string, disabilityType account.DisabilityType, reason string) (*account.UserAccount, error)
+	Reactivate(ctx context.Context, staffID, accountID string) (*account.UserAccount, error)
+	Delete(ctx context.Context, staffID, accountID string) (*account.UserAccount, error)
+}
+
+type disableRequest struct {
+	Type   string `json:"type"` // manual, inactive, suspended, blocked, expired or violation
+	Reason string `json:"reason"`
+}
+
+type accountStatusResponse struct {
+	ID             string  `json:"id"`
+	Username       string  `json:"username"`
+	Status         string  `json:"status"`
+	IsVerified     bool    `json:"is_verified"`
+	DisabilityType *string `json:"disability_type,omitempty"`
+	Reason         *string `json:"reason,omitempty"`
+	UpdatedAt      string  `json:"updated_at"`
+}
+
+type LifecycleHandler struct {
+	lifecycle AccountLifecycle
+	admin     AdminResolver
+}
+
+func NewLifecycleHandler(lifecycle AccountLifecycle, admin AdminResolver) *LifecycleHandler {
+	return &LifecycleHandler{lifecycle: lifecycle, admin: admin}
+}
+
+// NewAccountsRouter mounts the account list and lifecycle actions, it must sit behind admin authentication
+func NewAccountsRouter(lister AccountLister, lifecycle AccountLifecycle, admin AdminResolver) http.Handler {
+	h := NewLifecycleHandler(lifecycle, admin)
+	mux := http.NewServeMux()
+	mux.Handle("GET /admin/accounts", NewAccountsHandler(lister, admin))
+	mux.HandleFunc("POST /admin/accounts/{id}/verify", h.Verify)
+	mux.HandleFunc("POST /admin/accounts/{id}/disable", h.Disable)
+	mux.HandleFunc("POST /admin/accounts/{id}/reactivate", h.Reactivate)
+	mux.HandleFunc("DELETE /admin/accounts/{id}", h.Delete)
+	return mux
+}
+
+func (h *LifecycleHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	h.apply(w, r, func(ctx context.Context, adminID, id string) (*account.UserAccount, error) {
+		return h.lifecycle.Verify(ctx, adminID, id)
+	})
+}
+
+// Disable handles POST /admin/accounts/{id}/disable with {"type":"suspended","reason":"..."}
+func (h *LifecycleHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	var req disableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	if strings.TrimSpace(req.Type) == "" {
+		req.Type = string(account.DisabilityTypeManual)
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: account.ErrEmptyReason.Error(), Code: account.ErrEmptyReason.Code})
+		return
+	}
+
+	h.apply(w, r, func(ctx context.Context, adminID, id string) (*account.UserAccount, error) {
+		return h.lifecycle.Disable(ctx, adminID, id, account.DisabilityType(req.Type), req.Reason)
+	})
+}
+
+func (h *LifecycleHandler) Reactivate(w http.ResponseWriter, r *http.Request) {
+	h.apply(w, r, func(ctx context.Context, adminID, id string) (*account.UserAccount, error) {
+		return h.lifecycle.Reactivate(ctx, adminID, id)
+	})
+}
+
+// Delete soft deletes, the account stays listed with status deleted
+func (h *LifecycleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	h.apply(w, r, func(ctx context.Context, adminID, id string) (*account.UserAccount, error) {
+		return h.lifecycle.Delete(ctx, adminID, id)
+	})
+}
+
+func (h *LifecycleHandler) apply(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, adminID, id string) (*account.UserAccount, error)) {
+	adminID, ok := h.admin(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "admin session required"})
+		return
+	}
+	acc, err := action(r.Context(), adminID, r.PathValue("id"))
+	if err != nil {
+		writeLifecycleError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toAccountStatusResponse(acc))
+}
+
+// writeLifecycleError maps domain errors by kind, the code tells e.g. an
+// unverified account from an already disabled one
+func writeLifecycleError(w http.ResponseWriter, err error) {
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	case shared.KindForbidden:
+		status = http.StatusForbidden
+	}
+	writeJSON(w, status, errorResponse{Error: de.Error(), Code: de.Code})
+}
+
+func toAccountStatusResponse(acc *account.UserAccount) accountStatusResponse {
+	resp := accountStatusResponse{
+		ID:         acc.ID,
+		Username:   acc.Username.String(),
+		Status:     string(acc.Status),
+		IsVerified: acc.IsVerified,
+		Reason:     acc.IssuedReason,
+		UpdatedAt:  acc.UpdatedAt.Format(time.RFC3339),
+	}
+	if acc.DisabilityType != nil {
+		t := string(*acc.DisabilityType)
+		resp.DisabilityType = &t
+	}
+	return resp
+}
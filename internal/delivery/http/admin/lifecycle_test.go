@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// fakeLifecycle applies the actions to one account held in memory
+type fakeLifecycle struct {
+	acc     *account.UserAccount
+	staffID string
+}
+
+func (f *fakeLifecycle) find(staffID, id string) (*account.UserAccount, error) {
+	if id != f.acc.ID {
+		return nil, app.ErrAccountNotFound
+	}
+	f.staffID = staffID
+	return f.acc, nil
+}
+
+func (f *fakeLifecycle) Verify(ctx context.Context, staffID, accountID string) (*account.UserAccount, error) {
+	acc, err := f.find(staffID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return acc, acc.Verify(staffID)
+}
+
+func (f *fakeLifecycle) Disable(ctx context.Context, staffID, accountID string, disabilityType account.DisabilityType, reason string) (*account.UserAccount, error) {
+	acc, err := f.find(staffID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return acc, acc.Disable(staffID, disabilityType, reason)
+}
+
+func (f *fakeLifecycle) Reactivate(ctx context.Context, staffID, accountID string) (*account.UserAccount, error) {
+	acc, err := f.find(staffID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return acc, acc.Reactivate(staffID)
+}
+
+func (f *fakeLifecycle) Delete(ctx context.Context, staffID, accountID string) (*account.UserAccount, error) {
+	acc, err := f.find(staffID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return acc, acc.Delete(staffID)
+}
+
+func TestAccountsRouter_Lifecycle(t *testing.T) {
+	acc, _ := account.NewUserAccountWithHash("a1", "staff_writer", "writer@example.com", "hashed", account.TypeInternal, "admin123")
+	lifecycle := &fakeLifecycle{acc: acc}
+	admin := func(r *http.Request) (string, bool) { return "admin123", true }
+	router := openapitest.Handler(t, RegisterSpec, NewAccountsRouter(&fakeLister{result: &app.ListResult{}}, lifecycle, admin))
+
+	// Steps run in order against the same account
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"disable unverified", http.MethodPost, "/admin/accounts/a1/disable", `{"reason":"left"}`, http.StatusConflict, "account.unverified"},
+		{"verify", http.MethodPost, "/admin/accounts/a1/verify", ``, http.StatusOK, ""},
+		{"verify twice", http.MethodPost, "/admin/accounts/a1/verify", ``, http.StatusConflict, "account.not_pending_verification"},
+		{"disable without reason", http.MethodPost, "/admin/accounts/a1/disable", `{"type":"suspended"}`, http.StatusUnprocessableEntity, "account.reason_required"},
+		{"disable unknown type", http.MethodPost, "/admin/accounts/a1/disable", `{"type":"banished","reason":"spam"}`, http.StatusUnprocessableEntity, "account.invalid_disability_type"},
+		{"disable", http.MethodPost, "/admin/accounts/a1/disable", `{"type":"suspended","reason":"spam"}`, http.StatusOK, ""},
+		{"disable invalid body", http.MethodPost, "/admin/accounts/a1/disable", `{`, http.StatusBadRequest, ""},
+		{"reactivate", http.MethodPost, "/admin/accounts/a1/reactivate", ``, http.StatusOK, ""},
+		{"delete", http.MethodDelete, "/admin/accounts/a1", ``, http.StatusOK, ""},
+		{"delete twice", http.MethodDelete, "/admin/accounts/a1", ``, http.StatusConflict, "account.already_deleted"},
+		{"unknown account", http.MethodPost, "/admin/accounts/missing/reactivate", ``, http.StatusNotFound, "account.not_found"},
+		{"list", http.MethodGet, "/admin/accounts", ``, http.StatusOK, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body)))
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.expectedCode != "" {
+				var resp errorResponse
+				_ = json.NewDecoder(rec.Body).Decode(&resp)
+				if resp.Code != tc.expectedCode {
+					t.Errorf("expected code %q, got %q", tc.expectedCode, resp.Code)
+				}
+			}
+		})
+	}
+
+	if !acc.IsSoftDeleted() || lifecycle.staffID != "admin123" {
+		t.Errorf("expected the account deleted by admin123, got %s by %s", acc.Status, lifecycle.staffID)
+	}
+}
+
+func TestAccountsRouter_RequiresAdmin(t *testing.T) {
+	acc, _ := account.NewUserAccountWithHash("a1", "staff_writer", "writer@example.com", "hashed", account.TypeInternal, "admin123")
+	router := openapitest.Handler(t, RegisterSpec, NewAccountsRouter(&fakeLister{}, &fakeLifecycle{acc: acc}, func(r *http.Request) (string, bool) { return "", false }))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/accounts/a1", nil))
+	if rec.Code != http.StatusUnauthorized || acc.IsSoftDeleted() {
+		t.Errorf("expected status 401 and the account kept, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/accounts", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected the account list to require an admin session, got %d", rec.Code)
+	}
+}
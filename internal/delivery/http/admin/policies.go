@@ -0,0 +1,192 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// AdminResolver returns the signed-in administrator
+type AdminResolver func(r *http.Request) (string, bool)
+
+// SecurityPolicies is the part of the policy service the handler needs
+type SecurityPolicies interface {
+	List(ctx context.Context) ([]*account.SecurityPolicy, error)
+	Resolve(ctx context.Context, accountType account.UserAccountType) (*account.SecurityPolicy, error)
+	Update(ctx context.Context, adminID string, accountType account.UserAccountType, password account.PasswordPolicy, lockout account.LockoutPolicy) (*account.SecurityPolicy, error)
+}
+
+type passwordPolicyBody struct {
+	MinLength      int      `json:"min_length"`
+	MaxLength      int      `json:"max_length,omitempty"`
+	RequireUpper   bool     `json:"require_upper"`
+	RequireLower   bool     `json:"require_lower"`
+	RequireNumber  bool     `json:"require_number"`
+	RequireSpecial bool     `json:"require_special"`
+	Banned         []string `json:"banned,omitempty"`
+}
+
+type lockoutPolicyBody struct {
+	MaxAttempts         int   `json:"max_attempts"`
+	LockDurationSeconds int64 `json:"lock_duration_seconds"`
+}
+
+type updatePolicyRequest struct {
+	Password passwordPolicyBody `json:"password"`
+	Lockout  lockoutPolicyBody  `json:"lockout"`
+}
+
+type policyResponse struct {
+	AccountType  string             `json:"account_type"`
+	Password     passwordPolicyBody `json:"password"`
+	Lockout      lockoutPolicyBody  `json:"lockout"`
+	Requirements []string           `json:"requirements"`
+	IsDefault    bool               `json:"is_default"`
+	UpdatedBy    *string            `json:"updated_by,omitempty"`
+	UpdatedAt    *string            `json:"updated_at,omitempty"`
+}
+
+type policyListResponse struct {
+	Items []policyResponse `json:"items"`
+}
+
+type PoliciesHandler struct {
+	policies SecurityPolicies
+	admin    AdminResolver
+}
+
+func NewPoliciesHandler(policies SecurityPolicies, admin AdminResolver) *PoliciesHandler {
+	return &PoliciesHandler{policies: policies, admin: admin}
+}
+
+// NewPoliciesRouter mounts the security policy settings, it must sit behind admin authentication
+func NewPoliciesRouter(policies SecurityPolicies, admin AdminResolver) http.Handler {
+	h := NewPoliciesHandler(policies, admin)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/security-policies", h.List)
+	mux.HandleFunc("GET /admin/security-policies/{accountType}", h.Get)
+	mux.HandleFunc("PUT /admin/security-policies/{accountType}", h.Update)
+	return mux
+}
+
+func (h *PoliciesHandler) List(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.policies.List(r.Context())
+	if err != nil {
+		writePolicyError(w, err)
+		return
+	}
+
+	resp := policyListResponse{Items: make([]policyResponse, 0, len(policies))}
+	for _, p := range policies {
+		resp.Items = append(resp.Items, toPolicyResponse(p))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *PoliciesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	accountType, ok := parseAccountType(r)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "unknown account type"})
+		return
+	}
+
+	policy, err := h.policies.Resolve(r.Context(), accountType)
+	if err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPolicyResponse(policy))
+}
+
+func (h *PoliciesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := h.admin(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "admin session required"})
+		return
+	}
+	accountType, ok := parseAccountType(r)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "unknown account type"})
+		return
+	}
+
+	var req updatePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	password := account.PasswordPolicy{
+		MinLength:      req.Password.MinLength,
+		MaxLength:      req.Password.MaxLength,
+		RequireUpper:   req.Password.RequireUpper,
+		RequireLower:   req.Password.RequireLower,
+		RequireNumber:  req.Password.RequireNumber,
+		RequireSpecial: req.Password.RequireSpecial,
+		Banned:         req.Password.Banned,
+	}
+	lockout := account.LockoutPolicy{
+		MaxAttempts:  req.Lockout.MaxAttempts,
+		LockDuration: time.Duration(req.Lockout.LockDurationSeconds) * time.Second,
+	}
+
+	policy, err := h.policies.Update(r.Context(), adminID, accountType, password, lockout)
+	if err != nil {
+		writePolicyError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPolicyResponse(policy))
+}
+
+func parseAccountType(r *http.Request) (account.UserAccountType, bool) {
+	accountType := account.UserAccountType(r.PathValue("accountType"))
+	for _, t := range account.AccountTypes() {
+		if t == accountType {
+			return accountType, true
+		}
+	}
+	return "", false
+}
+
+func writePolicyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, account.ErrInvalidSecurityPolicy):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "security policy settings are unavailable"})
+	}
+}
+
+func toPolicyResponse(p *account.SecurityPolicy) policyResponse {
+	resp := policyResponse{
+		AccountType: string(p.AccountType),
+		Password: passwordPolicyBody{
+			MinLength:      p.Password.MinLength,
+			MaxLength:      p.Password.MaxLength,
+			RequireUpper:   p.Password.RequireUpper,
+			RequireLower:   p.Password.RequireLower,
+			RequireNumber:  p.Password.RequireNumber,
+			RequireSpecial: p.Password.RequireSpecial,
+			Banned:         p.Password.Banned,
+		},
+		Lockout: lockoutPolicyBody{
+			MaxAttempts:         p.Lockout.MaxAttempts,
+			LockDurationSeconds: int64(p.Lockout.LockDuration / time.Second),
+		},
+		Requirements: p.Password.Requirements(),
+		IsDefault:    p.IsDefault(),
+		UpdatedBy:    p.UpdatedBy,
+	}
+	if !p.IsDefault() {
+		at := p.UpdatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = &at
+	}
+	return resp
+}
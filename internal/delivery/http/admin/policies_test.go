@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakePolicies struct {
+	adminID  string
+	password account.PasswordPolicy
+	lockout  account.LockoutPolicy
+}
+
+func (f *fakePolicies) List(ctx context.Context) ([]*account.SecurityPolicy, error) {
+	var policies []*account.SecurityPolicy
+	for _, t := range account.AccountTypes() {
+		policies = append(policies, account.DefaultSecurityPolicy(t))
+	}
+	return policies, nil
+}
+
+func (f *fakePolicies) Resolve(ctx context.Context, accountType account.UserAccountType) (*account.SecurityPolicy, error) {
+	return account.DefaultSecurityPolicy(accountType), nil
+}
+
+func (f *fakePolicies) Update(ctx context.Context, adminID string, accountType account.UserAccountType, password account.PasswordPolicy, lockout account.LockoutPolicy) (*account.SecurityPolicy, error) {
+	policy, err := account.NewSecurityPolicy(accountType, password, lockout, adminID)
+	if err != nil {
+		return nil, err
+	}
+	f.adminID, f.password, f.lockout = adminID, password, lockout
+	return policy, nil
+}
+
+func TestPoliciesHandler_Update(t *testing.T) {
+	policies := &fakePolicies{}
+	admin := func(r *http.Request) (string, bool) { return "admin123", true }
+	router := openapitest.Handler(t, RegisterSpec, NewPoliciesRouter(policies, admin))
+
+	testCases := []struct {
+		name           string
+		url            string
+		body           string
+		expectedStatus int
+	}{
+		{"tighten internal", "/admin/security-policies/internal", `{"password":{"min_length":14,"require_upper":true,"require_number":true,"banned":["Newsroom2025!"]},"lockout":{"max_attempts":3,"lock_duration_seconds":3600}}`, http.StatusOK},
+		{"below floor", "/admin/security-policies/internal", `{"password":{"min_length":4},"lockout":{"max_attempts":3,"lock_duration_seconds":3600}}`, http.StatusUnprocessableEntity},
+		{"unknown account type", "/admin/security-policies/robot", `{}`, http.StatusNotFound},
+		{"invalid body", "/admin/security-policies/internal", `{`, http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, tc.url, strings.NewReader(tc.body)))
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if policies.adminID != "admin123" || policies.lockout.LockDuration != time.Hour || policies.password.MinLength != 14 {
+		t.Errorf("unexpected update %+v %+v by %s", policies.password, policies.lockout, policies.adminID)
+	}
+}
+
+func TestPoliciesHandler_List(t *testing.T) {
+	router := openapitest.Handler(t, RegisterSpec, NewPoliciesRouter(&fakePolicies{}, func(r *http.Request) (string, bool) { return "", false }))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/security-policies", nil))
+
+	var resp policyListResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Items) != len(account.AccountTypes()) || !resp.Items[0].IsDefault || len(resp.Items[0].Requirements) == 0 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if resp.Items[0].Lockout.LockDurationSeconds != 900 {
+		t.Errorf("expected default lock of 900s, got %d", resp.Items[0].Lockout.LockDurationSeconds)
+	}
+}
@@ -0,0 +1,32 @@
+// Package apispec assembles the OpenAPI document of every REST endpoint the
+// delivery packages mount. The packages' specs are generated from their
+// handlers and DTOs; a handler changed without regenerating fails the tests.
+package apispec
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi"
+)
+
+//go:generate go run ../../../../cmd/apigen
+
+// Title and Version head the document
+const (
+	Title   = "News Portal CMS"
+	Version = "1.0.0"
+)
+
+// Document builds the document of every endpoint
+func Document() *openapi.Document {
+	b := openapi.NewBuilder(Title, Version)
+	register(b)
+	return b.Document()
+}
+
+// NewRouter serves the document at GET /openapi.json
+func NewRouter() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /openapi.json", openapi.Handler(Document()))
+	return mux
+}
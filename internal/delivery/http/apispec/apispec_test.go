@@ -0,0 +1,84 @@
+package apispec
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/specgen"
+)
+
+// The specs must be regenerated whenever a handler or DTO changes
+func TestGeneratedSpecsUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type-checks every delivery package")
+	}
+	want, err := specgen.Generate("..", "github.com/jokosaputro95/news-portal-cms/internal/delivery/http", ".")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	for path, src := range want {
+		current, err := os.ReadFile(path)
+		if err != nil || !bytes.Equal(current, src) {
+			t.Errorf("%s is stale, run go generate ./internal/delivery/http/apispec", path)
+		}
+	}
+	onDisk, err := filepath.Glob(filepath.Join("..", "*", specgen.FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range onDisk {
+		if _, ok := want[path]; !ok {
+			t.Errorf("%s documents no routes anymore, run go generate ./internal/delivery/http/apispec", path)
+		}
+	}
+}
+
+func TestDocument(t *testing.T) {
+	doc := Document()
+	if doc.OpenAPI != openapi.Version || doc.Info.Title != Title {
+		t.Fatalf("unexpected header: %s %s", doc.OpenAPI, doc.Info.Title)
+	}
+
+	ids := map[string]string{}
+	for path, item := range doc.Paths {
+		for method, op := range *item {
+			if op.OperationID == "" {
+				t.Errorf("%s %s has no operationId", method, path)
+			}
+			if other, ok := ids[op.OperationID]; ok {
+				t.Errorf("operationId %s used by %s and %s %s", op.OperationID, other, method, path)
+			}
+			ids[op.OperationID] = method + " " + path
+			if len(op.Responses) == 0 {
+				t.Errorf("%s %s documents no response", method, path)
+			}
+		}
+	}
+	for _, path := range []string{"/mobile/v1/feed", "/auth/login", "/admin/accounts"} {
+		if doc.Paths[path] == nil {
+			t.Errorf("expected %s to be documented", path)
+		}
+	}
+}
+
+func TestRouter_ServesDocument(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var doc openapi.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid document: %v", err)
+	}
+	if len(doc.Paths) != len(Document().Paths) {
+		t.Errorf("expected %d paths, got %d", len(Document().Paths), len(doc.Paths))
+	}
+}
@@ -0,0 +1,113 @@
+// Code generated by apigen from the delivery packages. DO NOT EDIT.
+
+package apispec
+
+import (
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/accessibility"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/admin"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/appeal"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/approval"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/auth"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/badge"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/bruteforce"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/churn"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/consent"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/crm"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/election"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/entity"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/experiment"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/export"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/freshness"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/geo"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/institution"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/jobwatch"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/linkpreview"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/localization"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/mobile"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/moderation"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/offline"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/publishguard"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/purchase"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/puzzle"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/referral"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/retention"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/revenue"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/sensitivity"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/series"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/serp"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/social"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/sports"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/style"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/survey"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/tip"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/translation"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/webhook"
+)
+
+// register adds the spec of every delivery package
+func register(b *openapi.Builder) {
+	accessibility.RegisterSpec(b)
+	admin.RegisterSpec(b)
+	appeal.RegisterSpec(b)
+	approval.RegisterSpec(b)
+	audit.RegisterSpec(b)
+	auth.RegisterSpec(b)
+	badge.RegisterSpec(b)
+	bruteforce.RegisterSpec(b)
+	checklist.RegisterSpec(b)
+	churn.RegisterSpec(b)
+	comment.RegisterSpec(b)
+	consent.RegisterSpec(b)
+	crm.RegisterSpec(b)
+	election.RegisterSpec(b)
+	emailtemplate.RegisterSpec(b)
+	entity.RegisterSpec(b)
+	experiment.RegisterSpec(b)
+	export.RegisterSpec(b)
+	freshness.RegisterSpec(b)
+	geo.RegisterSpec(b)
+	institution.RegisterSpec(b)
+	jobwatch.RegisterSpec(b)
+	letter.RegisterSpec(b)
+	linkpreview.RegisterSpec(b)
+	localization.RegisterSpec(b)
+	media.RegisterSpec(b)
+	mobile.RegisterSpec(b)
+	moderation.RegisterSpec(b)
+	newsletter.RegisterSpec(b)
+	offline.RegisterSpec(b)
+	publishguard.RegisterSpec(b)
+	purchase.RegisterSpec(b)
+	puzzle.RegisterSpec(b)
+	quota.RegisterSpec(b)
+	referral.RegisterSpec(b)
+	retention.RegisterSpec(b)
+	revenue.RegisterSpec(b)
+	search.RegisterSpec(b)
+	sensitivity.RegisterSpec(b)
+	series.RegisterSpec(b)
+	serp.RegisterSpec(b)
+	social.RegisterSpec(b)
+	sports.RegisterSpec(b)
+	style.RegisterSpec(b)
+	subscription.RegisterSpec(b)
+	suppression.RegisterSpec(b)
+	survey.RegisterSpec(b)
+	tip.RegisterSpec(b)
+	topic.RegisterSpec(b)
+	translation.RegisterSpec(b)
+	webhook.RegisterSpec(b)
+}
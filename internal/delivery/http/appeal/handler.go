@@ -0,0 +1,138 @@
+package appeal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	accountapp "github.com/jokosaputro95/news-portal-cms/internal/application/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/middleware"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/appeal"
+)
+
+// maxSubmitBytes bounds the body, the statement is at most 4000 characters
+const maxSubmitBytes = 32 << 10
+
+// Credentials checks the password of an account that cannot sign in
+type Credentials interface {
+	Authenticate(ctx context.Context, identifier, password, ipAddress string) (*account.UserAccount, error)
+}
+
+// Appeals is the part of the appeal service the endpoint needs
+type Appeals interface {
+	Submit(ctx context.Context, accountID, statement string, ticketReference *string) (*appeal.Appeal, error)
+}
+
+type submitRequest struct {
+	Identifier      string  `json:"identifier"` // Username or email address
+	Password        string  `json:"password"`
+	Statement       string  `json:"statement"`
+	TicketReference *string `json:"ticket_reference"`
+}
+
+type appealResponse struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	DisabilityType string `json:"disability_type"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	credentials Credentials
+	appeals     Appeals
+}
+
+func NewHandler(credentials Credentials, appeals Appeals) *Handler {
+	return &Handler{credentials: credentials, appeals: appeals}
+}
+
+// NewRouter mounts the appeal form. A disabled account has no session, so the
+// reader signs the appeal with their password; this endpoint is public.
+func NewRouter(credentials Credentials, appeals Appeals) http.Handler {
+	h := NewHandler(credentials, appeals)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /appeals", h.Submit)
+	return mux
+}
+
+// Submit files an appeal against the account's current disable. Wrong
+// passwords count towards the lockout like a sign-in.
+func (h *Handler) Submit(w http.ResponseWriter, r *http.Request) {
+	var req submitRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSubmitBytes)).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: "appeal is too large"})
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	if strings.TrimSpace(req.Identifier) == "" || req.Password == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	acc, err := h.credentials.Authenticate(r.Context(), req.Identifier, req.Password, middleware.ClientIP(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	a, err := h.appeals.Submit(r.Context(), acc.ID, req.Statement, req.TicketReference)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, appealResponse{
+		ID:             a.ID,
+		Status:         string(a.Status),
+		DisabilityType: string(a.DisabilityType),
+		CreatedAt:      a.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+// writeError maps domain errors by kind, the code tells e.g. an active account
+// from one that already has an appeal pending
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, accountapp.ErrInvalidCredentials) {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: err.Error(), Code: accountapp.ErrInvalidCredentials.Code})
+		return
+	}
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	case shared.KindForbidden:
+		status = http.StatusForbidden
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error(), Code: de.Code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
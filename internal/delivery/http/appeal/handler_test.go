@@ -0,0 +1,88 @@
+package appeal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	accountapp "github.com/jokosaputro95/news-portal-cms/internal/application/account"
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/appeal"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/appeal"
+)
+
+type fakeCredentials struct {
+	acc *account.UserAccount
+}
+
+func (f *fakeCredentials) Authenticate(ctx context.Context, identifier, password, ipAddress string) (*account.UserAccount, error) {
+	if identifier != "member_user" || password != "Secret123!" {
+		return nil, accountapp.ErrInvalidCredentials
+	}
+	return f.acc, nil
+}
+
+type fakeAppeals struct {
+	accounts  map[string]*account.UserAccount
+	accountID string
+	err       error
+}
+
+func (f *fakeAppeals) Submit(ctx context.Context, accountID, statement string, ticketReference *string) (*appeal.Appeal, error) {
+	f.accountID = accountID
+	if f.err != nil {
+		return nil, f.err
+	}
+	return appeal.NewAppeal("ap1", f.accounts[accountID], statement, ticketReference)
+}
+
+func TestHandler(t *testing.T) {
+	disabled, _ := account.NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed:Secret123!")
+	_ = disabled.SelfVerify()
+	_ = disabled.Disable("mod1", account.DisabilityTypeManual, "spam")
+	active, _ := account.NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed:Secret123!")
+	_ = active.SelfVerify()
+
+	statement := `"statement":"I was travelling and someone else used my account."`
+	submission := `{"identifier":"member_user","password":"Secret123!",` + statement + `}`
+
+	testCases := []struct {
+		name           string
+		body           string
+		acc            *account.UserAccount
+		err            error
+		expectedStatus int
+	}{
+		{"submit", submission, disabled, nil, http.StatusCreated},
+		{"submit with ticket", `{"identifier":"member_user","password":"Secret123!","ticket_reference":"ZD-10234",` + statement + `}`, disabled, nil, http.StatusCreated},
+		{"invalid body", `{`, disabled, nil, http.StatusBadRequest},
+		{"missing password", `{"identifier":"member_user",` + statement + `}`, disabled, nil, http.StatusBadRequest},
+		{"too large", `{"statement":"` + strings.Repeat("a", maxSubmitBytes) + `"}`, disabled, nil, http.StatusRequestEntityTooLarge},
+		{"wrong password", `{"identifier":"member_user","password":"Wrong123!",` + statement + `}`, disabled, nil, http.StatusUnauthorized},
+		{"locked", submission, disabled, accountapp.ErrAccountLocked, http.StatusForbidden},
+		{"short statement", `{"identifier":"member_user","password":"Secret123!","statement":"please"}`, disabled, nil, http.StatusUnprocessableEntity},
+		{"active account", submission, active, nil, http.StatusConflict},
+		{"already pending", submission, disabled, app.ErrAppealAlreadyExists, http.StatusConflict},
+		{"store failure", submission, disabled, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/appeals", strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			appeals := &fakeAppeals{accounts: map[string]*account.UserAccount{"m1": tc.acc}, err: tc.err}
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakeCredentials{acc: tc.acc}, appeals)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusCreated && appeals.accountID != "m1" {
+				t.Errorf("expected the appeal filed for the authenticated account, got %q", appeals.accountID)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/approval"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Approvals is the part of the approval service the endpoints need
+type Approvals interface {
+	RequestApproval(ctx context.Context, articleID string, editorIDs []string, at time.Time) (*app.RequestResult, error)
+	Preview(ctx context.Context, token string, at time.Time) (*app.Pending, error)
+	Decide(ctx context.Context, token, note string, at time.Time) (*article.Article, error)
+}
+
+type requestApprovalRequest struct {
+	EditorIDs []string `json:"editor_ids"`
+}
+
+// tokenRequest carries the deep-link token in the body so it stays out of access logs
+type tokenRequest struct {
+	Token string `json:"token"`
+	Note  string `json:"note"`
+}
+
+type requestResultResponse struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
+type pendingResponse struct {
+	ArticleID string `json:"article_id"`
+	Title     string `json:"title"`
+	Summary   string `json:"summary"`
+	Action    string `json:"action"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type decisionResponse struct {
+	ArticleID string `json:"article_id"`
+	Status    string `json:"status"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	approvals Approvals
+}
+
+func NewHandler(approvals Approvals) *Handler {
+	return &Handler{approvals: approvals}
+}
+
+// NewRouter mounts the endpoints the mobile app calls from an approval push.
+// The token is the only credential: it allows one action on one article, for
+// minutes, once.
+func NewRouter(approvals Approvals) http.Handler {
+	h := NewHandler(approvals)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /mobile/approvals/preview", h.Preview)
+	mux.HandleFunc("POST /mobile/approvals/decide", h.Decide)
+	return mux
+}
+
+// NewAdminRouter mounts approval requests, it must sit behind admin authentication
+func NewAdminRouter(approvals Approvals) http.Handler {
+	h := NewHandler(approvals)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/articles/{id}/approval-requests", h.RequestApproval)
+	return mux
+}
+
+func (h *Handler) RequestApproval(w http.ResponseWriter, r *http.Request) {
+	var req requestApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.EditorIDs) == 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	result, err := h.approvals.RequestApproval(r.Context(), r.PathValue("id"), req.EditorIDs, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, requestResultResponse{Sent: result.Sent, Failed: result.Failed})
+}
+
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	var req tokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	pending, err := h.approvals.Preview(r.Context(), req.Token, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, pendingResponse{
+		ArticleID: pending.Article.ID,
+		Title:     pending.Article.Title,
+		Summary:   pending.Article.Summary,
+		Action:    string(pending.Action),
+		ExpiresAt: pending.ExpiresAt.Format(time.RFC3339),
+	})
+}
+
+func (h *Handler) Decide(w http.ResponseWriter, r *http.Request) {
+	var req tokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	a, err := h.approvals.Decide(r.Context(), req.Token, req.Note, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, decisionResponse{ArticleID: a.ID, Status: string(a.Status)})
+}
+
+// writeError maps the service's errors, then domain errors by kind so the app can
+// tell an expired link from a used one by its code
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrGrantNotFound), errors.Is(err, app.ErrArticleNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+		return
+	case errors.Is(err, app.ErrNotInReview):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+		return
+	case errors.Is(err, app.ErrEditorInactive):
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+		return
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	case shared.KindForbidden:
+		status = http.StatusForbidden
+	}
+	writeJSON(w, status, errorResponse{Error: de.Error(), Code: de.Code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,115 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/approval"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/approval"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+)
+
+type fakeApprovals struct {
+	token string
+	note  string
+	err   error
+}
+
+func (f *fakeApprovals) RequestApproval(ctx context.Context, articleID string, editorIDs []string, at time.Time) (*app.RequestResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.RequestResult{Sent: len(editorIDs)}, nil
+}
+
+func (f *fakeApprovals) Preview(ctx context.Context, token string, at time.Time) (*app.Pending, error) {
+	f.token = token
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.Pending{Article: &article.Article{ID: "article-1", Title: "Budget vote delayed"}, Action: approval.ActionApprove, ExpiresAt: at.Add(approval.DefaultTTL)}, nil
+}
+
+func (f *fakeApprovals) Decide(ctx context.Context, token, note string, at time.Time) (*article.Article, error) {
+	f.token, f.note = token, note
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &article.Article{ID: "article-1", Status: article.StatusApproved}, nil
+}
+
+func TestHandler_Decide(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"approved", `{"token":"abc"}`, nil, http.StatusOK, ""},
+		{"invalid body", `{`, nil, http.StatusBadRequest, ""},
+		{"unknown token", `{"token":"forged"}`, app.ErrGrantNotFound, http.StatusNotFound, ""},
+		{"expired", `{"token":"abc"}`, approval.ErrExpired, http.StatusConflict, "approval.expired"},
+		{"used", `{"token":"abc"}`, approval.ErrUsed, http.StatusConflict, "approval.used"},
+		{"no note", `{"token":"abc"}`, article.ErrEmptyReviewNote, http.StatusUnprocessableEntity, "article.review_note_required"},
+		{"own article", `{"token":"abc"}`, article.ErrSelfApproval, http.StatusForbidden, "article.self_approval"},
+		{"editor disabled", `{"token":"abc"}`, app.ErrEditorInactive, http.StatusForbidden, ""},
+		{"store down", `{"token":"abc"}`, errors.New("connection reset"), http.StatusInternalServerError, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakeApprovals{err: tc.err})).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mobile/approvals/decide", strings.NewReader(tc.body)))
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			var resp errorResponse
+			_ = json.NewDecoder(rec.Body).Decode(&resp)
+			if resp.Code != tc.expectedCode {
+				t.Errorf("expected code %q, got %q", tc.expectedCode, resp.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_Preview(t *testing.T) {
+	approvals := &fakeApprovals{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(approvals)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mobile/approvals/preview", strings.NewReader(`{"token":"abc"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var resp pendingResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if approvals.token != "abc" || resp.Action != "approve" || resp.Title != "Budget vote delayed" {
+		t.Errorf("unexpected preview %+v", resp)
+	}
+}
+
+func TestHandler_RequestApproval(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeApprovals{})).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/articles/article-1/approval-requests", strings.NewReader(`{"editor_ids":["editor-1","editor-2"]}`)))
+	if rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), `"sent":2`) {
+		t.Errorf("expected two pushes accepted, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeApprovals{})).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/articles/article-1/approval-requests", strings.NewReader(`{"editor_ids":[]}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeApprovals{err: app.ErrNotInReview})).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/articles/article-1/approval-requests", strings.NewReader(`{"editor_ids":["editor-1"]}`)))
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", rec.Code)
+	}
+}
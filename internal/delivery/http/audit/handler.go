@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// History is the part of the audit service the admin endpoints need
+type History interface {
+	History(ctx context.Context, targetType, targetID, actorID string, beforeSeq uint64, limit int) ([]*audit.Entry, error)
+}
+
+type changeResponse struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type entryResponse struct {
+	Seq        uint64            `json:"seq"`
+	ID         string            `json:"id"`
+	ActorID    string            `json:"actor_id"`
+	Action     string            `json:"action"`
+	TargetType string            `json:"target_type"`
+	TargetID   string            `json:"target_id"`
+	IP         string            `json:"ip"`
+	Details    map[string]string `json:"details"`
+	Changes    []changeResponse  `json:"changes"`
+	At         string            `json:"at"`
+	Hash       string            `json:"hash"`
+}
+
+// historyResponse has next_before set while there may be older entries,
+// pass it back as before for the next page
+type historyResponse struct {
+	Entries    []entryResponse `json:"entries"`
+	NextBefore *uint64         `json:"next_before,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	history History
+}
+
+func NewHandler(history History) *Handler {
+	return &Handler{history: history}
+}
+
+// NewAdminRouter mounts the audit history of targets and actors, it must sit behind admin authentication
+func NewAdminRouter(history History) http.Handler {
+	h := NewHandler(history)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/audit/entries", h.Entries)
+	return mux
+}
+
+// Entries handles GET /admin/audit/entries?target_type=account&target_id=a1
+// or ?actor_id=admin-1, with optional before and limit for paging
+func (h *Handler) Entries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var beforeSeq uint64
+	if raw := q.Get("before"); raw != "" {
+		seq, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "before must be an entry sequence number"})
+			return
+		}
+		beforeSeq = seq
+	}
+	var limit int
+	if raw := q.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit must be a positive number"})
+			return
+		}
+		limit = n
+	}
+
+	entries, err := h.history.History(r.Context(), q.Get("target_type"), q.Get("target_id"), q.Get("actor_id"), beforeSeq, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := historyResponse{Entries: make([]entryResponse, 0, len(entries))}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, toEntryResponse(e))
+	}
+	if n := len(entries); n > 0 && entries[n-1].Seq > 1 {
+		next := entries[n-1].Seq
+		resp.NextBefore = &next
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toEntryResponse(e *audit.Entry) entryResponse {
+	resp := entryResponse{
+		Seq:        e.Seq,
+		ID:         e.ID,
+		ActorID:    e.ActorID,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		IP:         e.IP,
+		Details:    e.Details,
+		Changes:    make([]changeResponse, 0, len(e.Changes)),
+		At:         e.At.Format(time.RFC3339),
+		Hash:       e.Hash,
+	}
+	if resp.Details == nil {
+		resp.Details = map[string]string{}
+	}
+	for _, c := range e.Changes {
+		resp.Changes = append(resp.Changes, changeResponse{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrInvalidHistory):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+)
+
+type fakeHistory struct {
+	err       error
+	beforeSeq uint64
+	limit     int
+}
+
+func (f *fakeHistory) History(ctx context.Context, targetType, targetID, actorID string, beforeSeq uint64, limit int) ([]*audit.Entry, error) {
+	f.beforeSeq, f.limit = beforeSeq, limit
+	if f.err != nil {
+		return nil, f.err
+	}
+	changes := audit.Diff(map[string]string{"status": "active"}, map[string]string{"status": "disabled"})
+	first, _ := audit.NewEntry(nil, "e1", "admin-1", "account.verify", "account", "a1", "203.0.113.7", nil, nil, time.Now())
+	second, _ := audit.NewEntry(first, "e2", "admin-1", "account.disable", "account", "a1", "203.0.113.7", map[string]string{"reason": "spam"}, changes, time.Now())
+	return []*audit.Entry{second, first}, nil
+}
+
+func TestAdminRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+	}{
+		{"by target", "/admin/audit/entries?target_type=account&target_id=a1", nil, http.StatusOK},
+		{"by actor", "/admin/audit/entries?actor_id=admin-1&before=40&limit=20", nil, http.StatusOK},
+		{"invalid before", "/admin/audit/entries?actor_id=admin-1&before=latest", nil, http.StatusBadRequest},
+		{"invalid limit", "/admin/audit/entries?actor_id=admin-1&limit=0", nil, http.StatusBadRequest},
+		{"no filter", "/admin/audit/entries", app.ErrInvalidHistory, http.StatusUnprocessableEntity},
+		{"store failure", "/admin/audit/entries?actor_id=admin-1", errors.New("connection reset"), http.StatusInternalServerError},
+		{"timeout", "/admin/audit/entries?actor_id=admin-1", context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeHistory{err: tc.err})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminRouter_Entries(t *testing.T) {
+	history := &fakeHistory{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(history)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/audit/entries?target_type=account&target_id=a1&before=3&limit=2", nil))
+
+	var resp historyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if history.beforeSeq != 3 || history.limit != 2 {
+		t.Errorf("expected paging passed through, got before %d limit %d", history.beforeSeq, history.limit)
+	}
+	if len(resp.Entries) != 2 || resp.NextBefore != nil {
+		t.Fatalf("expected two entries and no older page, got %+v", resp)
+	}
+	disable := resp.Entries[0]
+	if disable.Details["reason"] != "spam" || len(disable.Changes) != 1 || disable.Changes[0].After != "disabled" || disable.IP != "203.0.113.7" {
+		t.Errorf("unexpected entry %+v", disable)
+	}
+}
@@ -0,0 +1,346 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/middleware"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// FingerprintHeader carries the client's device fingerprint, a refresh from
+// another device revokes the session
+const FingerprintHeader = "X-Device-Fingerprint"
+
+// Accounts is the part of the account service the endpoints need
+type Accounts interface {
+	Register(ctx context.Context, in app.RegisterInput) (*account.UserAccount, error)
+	VerifyEmail(ctx context.Context, token string) (*account.UserAccount, error)
+	Login(ctx context.Context, in app.LoginInput) (*app.LoginResult, error)
+	VerifyLogin(ctx context.Context, in app.VerifyLoginInput) (*app.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken, deviceFingerprint, ipAddress string) (*app.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+// validator is a request body, validate names the problem of each bad field
+type validator interface {
+	validate() map[string]string
+}
+
+type registerRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (req registerRequest) validate() map[string]string {
+	fields := map[string]string{}
+	if strings.TrimSpace(req.Username) == "" {
+		fields["username"] = "is required"
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		fields["email"] = "is required"
+	}
+	if req.Password == "" {
+		fields["password"] = "is required"
+	}
+	return fields
+}
+
+type verifyRequest struct {
+	Token string `json:"token"`
+}
+
+func (req verifyRequest) validate() map[string]string {
+	fields := map[string]string{}
+	if strings.TrimSpace(req.Token) == "" {
+		fields["token"] = "is required"
+	}
+	return fields
+}
+
+type loginRequest struct {
+	Identifier string `json:"identifier"` // Username or email address
+	Password   string `json:"password"`
+}
+
+func (req loginRequest) validate() map[string]string {
+	fields := map[string]string{}
+	if strings.TrimSpace(req.Identifier) == "" {
+		fields["identifier"] = "is required"
+	}
+	if req.Password == "" {
+		fields["password"] = "is required"
+	}
+	return fields
+}
+
+type verifyLoginRequest struct {
+	Challenge string `json:"challenge"`
+	Code      string `json:"code"` // Authenticator or backup code
+}
+
+func (req verifyLoginRequest) validate() map[string]string {
+	fields := map[string]string{}
+	if strings.TrimSpace(req.Challenge) == "" {
+		fields["challenge"] = "is required"
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		fields["code"] = "is required"
+	}
+	return fields
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (req refreshRequest) validate() map[string]string {
+	fields := map[string]string{}
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		fields["refresh_token"] = "is required"
+	}
+	return fields
+}
+
+type accountResponse struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	Email      string `json:"email"`
+	Status     string `json:"status"`
+	IsVerified bool   `json:"is_verified"`
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	AccessExpiresAt  string `json:"access_expires_at"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt string `json:"refresh_expires_at"`
+	TokenType        string `json:"token_type"`
+}
+
+// challengeResponse asks for the second sign-in step at POST /auth/login/verify
+type challengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	Challenge   string `json:"challenge"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+type errorResponse struct {
+	Error  string            `json:"error"`
+	Code   string            `json:"code,omitempty"`   // Stable domain error code, the message may change
+	Fields map[string]string `json:"fields,omitempty"` // Per field problems of a rejected request body
+}
+
+type Handler struct {
+	accounts Accounts
+}
+
+func NewHandler(accounts Accounts) *Handler {
+	return &Handler{accounts: accounts}
+}
+
+// NewAuthRouter mounts registration and sign-in, these endpoints are public
+func NewAuthRouter(accounts Accounts) http.Handler {
+	h := NewHandler(accounts)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth/register", h.Register)
+	mux.HandleFunc("POST /auth/verify", h.Verify)
+	mux.HandleFunc("POST /auth/login", h.Login)
+	mux.HandleFunc("POST /auth/login/verify", h.VerifyLogin)
+	mux.HandleFunc("POST /auth/refresh", h.Refresh)
+	mux.HandleFunc("POST /auth/logout", h.Logout)
+	return mux
+}
+
+// Register creates a membership account and emails the verification link
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	var req registerRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	acc, err := h.accounts.Register(r.Context(), app.RegisterInput{Username: req.Username, Email: req.Email, Password: req.Password})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAccountResponse(acc))
+}
+
+// Verify redeems the token from the verification email
+func (h *Handler) Verify(w http.ResponseWriter, r *http.Request) {
+	var req verifyRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	acc, err := h.accounts.VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toAccountResponse(acc))
+}
+
+// Login answers with tokens, or with a challenge when the account has
+// two-factor authentication on
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	result, err := h.accounts.Login(r.Context(), app.LoginInput{
+		Identifier:        req.Identifier,
+		Password:          req.Password,
+		IPAddress:         middleware.ClientIP(r),
+		UserAgent:         r.UserAgent(),
+		DeviceFingerprint: r.Header.Get(FingerprintHeader),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if result.Challenge != nil {
+		writeJSON(w, http.StatusOK, challengeResponse{
+			MFARequired: true,
+			Challenge:   result.Challenge.Token,
+			ExpiresAt:   result.Challenge.ExpiresAt.Format(time.RFC3339),
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, toTokenResponse(result.Tokens))
+}
+
+// VerifyLogin answers the challenge of a two-factor sign-in. An unknown or
+// expired challenge answers 401 so the client asks for the password again.
+func (h *Handler) VerifyLogin(w http.ResponseWriter, r *http.Request) {
+	var req verifyLoginRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	pair, err := h.accounts.VerifyLogin(r.Context(), app.VerifyLoginInput{
+		Challenge:         req.Challenge,
+		Code:              req.Code,
+		IPAddress:         middleware.ClientIP(r),
+		UserAgent:         r.UserAgent(),
+		DeviceFingerprint: r.Header.Get(FingerprintHeader),
+	})
+	if err != nil {
+		if errors.Is(err, app.ErrInvalidMFAChallenge) {
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: err.Error(), Code: app.ErrInvalidMFAChallenge.Code})
+			return
+		}
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTokenResponse(pair))
+}
+
+// Refresh rotates the refresh token. Any rejection answers 401 so the client
+// signs in again.
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	pair, err := h.accounts.Refresh(r.Context(), req.RefreshToken, r.Header.Get(FingerprintHeader), middleware.ClientIP(r))
+	if err != nil {
+		if de, ok := shared.AsDomainError(err); ok && de.Kind != shared.KindNotFound {
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: de.Error(), Code: de.Code})
+			return
+		}
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTokenResponse(pair))
+}
+
+// Logout ends the session behind the refresh token, unknown tokens are ignored
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if err := h.accounts.Logout(r.Context(), req.RefreshToken); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decode reads a JSON body into req and answers 400 or 422 itself when it is unusable
+func decode(w http.ResponseWriter, r *http.Request, req validator) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return false
+	}
+	if fields := req.validate(); len(fields) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: "request is invalid", Code: "request.invalid", Fields: fields})
+		return false
+	}
+	return true
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, app.ErrInvalidCredentials) {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: err.Error(), Code: app.ErrInvalidCredentials.Code})
+		return
+	}
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	case shared.KindForbidden:
+		status = http.StatusForbidden
+	case shared.KindRateLimited:
+		status = http.StatusTooManyRequests
+	}
+	// err rather than de, a password policy error names the configured limits
+	writeJSON(w, status, errorResponse{Error: err.Error(), Code: de.Code})
+}
+
+func toAccountResponse(acc *account.UserAccount) accountResponse {
+	return accountResponse{
+		ID:         acc.ID,
+		Username:   acc.Username.String(),
+		Email:      acc.Email.String(),
+		Status:     string(acc.Status),
+		IsVerified: acc.IsVerified,
+	}
+}
+
+func toTokenResponse(pair *app.TokenPair) tokenResponse {
+	return tokenResponse{
+		AccessToken:      pair.AccessToken,
+		AccessExpiresAt:  pair.AccessExpiresAt.Format(time.RFC3339),
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshExpiresAt.Format(time.RFC3339),
+		TokenType:        "Bearer",
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+)
+
+var errRefreshRejected = shared.NewDomainError("auth.invalid_refresh_token", shared.KindForbidden, "refresh token is invalid or has expired")
+
+type fakeAccounts struct {
+	login       app.LoginInput
+	loggedOut   []string
+	fingerprint string
+}
+
+func (f *fakeAccounts) Register(ctx context.Context, in app.RegisterInput) (*account.UserAccount, error) {
+	if in.Username == "taken_user" {
+		return nil, app.ErrUsernameTaken
+	}
+	return account.NewUserAccountForSelfRegistration("m1", in.Username, in.Email, "hashed")
+}
+
+func (f *fakeAccounts) VerifyEmail(ctx context.Context, token string) (*account.UserAccount, error) {
+	if token != "good-token" {
+		return nil, app.ErrInvalidVerificationToken
+	}
+	acc, _ := account.NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed")
+	_ = acc.SelfVerify()
+	return acc, nil
+}
+
+func (f *fakeAccounts) Login(ctx context.Context, in app.LoginInput) (*app.LoginResult, error) {
+	f.login = in
+	switch in.Password {
+	case "Secret123!":
+		return &app.LoginResult{Tokens: &app.TokenPair{AccessToken: "access", RefreshToken: "refresh", AccessExpiresAt: time.Now()}}, nil
+	case "TwoFactor123!":
+		return &app.LoginResult{Challenge: &app.MFAChallenge{Token: "challenge-1", ExpiresAt: time.Now().Add(app.MFAChallengeTTL)}}, nil
+	case "Locked123!":
+		return nil, app.ErrAccountLocked
+	case "Limited123!":
+		return nil, ratelimit.ErrTooManyRequests
+	}
+	return nil, app.ErrInvalidCredentials
+}
+
+func (f *fakeAccounts) VerifyLogin(ctx context.Context, in app.VerifyLoginInput) (*app.TokenPair, error) {
+	switch {
+	case in.Challenge != "challenge-1":
+		return nil, app.ErrInvalidMFAChallenge
+	case in.Code != "123456":
+		return nil, account.ErrInvalidMFACode
+	}
+	return &app.TokenPair{AccessToken: "access", RefreshToken: "refresh"}, nil
+}
+
+func (f *fakeAccounts) Refresh(ctx context.Context, refreshToken, deviceFingerprint, ipAddress string) (*app.TokenPair, error) {
+	f.fingerprint = deviceFingerprint
+	if refreshToken != "refresh" {
+		return nil, errRefreshRejected
+	}
+	return &app.TokenPair{AccessToken: "access-2", RefreshToken: "refresh-2"}, nil
+}
+
+func (f *fakeAccounts) Logout(ctx context.Context, refreshToken string) error {
+	f.loggedOut = append(f.loggedOut, refreshToken)
+	return nil
+}
+
+func TestAuthRouter(t *testing.T) {
+	accounts := &fakeAccounts{}
+	router := openapitest.Handler(t, RegisterSpec, NewAuthRouter(accounts))
+
+	testCases := []struct {
+		name           string
+		url            string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"register", "/auth/register", `{"username":"jhon_doe","email":"jhon@example.com","password":"Secret123!"}`, http.StatusCreated, ""},
+		{"register missing fields", "/auth/register", `{"username":"jhon_doe"}`, http.StatusUnprocessableEntity, "request.invalid"},
+		{"register unknown field", "/auth/register", `{"username":"jhon_doe","role":"admin"}`, http.StatusBadRequest, ""},
+		{"register taken", "/auth/register", `{"username":"taken_user","email":"jhon@example.com","password":"Secret123!"}`, http.StatusConflict, "account.username_taken"},
+		{"verify", "/auth/verify", `{"token":"good-token"}`, http.StatusOK, ""},
+		{"verify bad token", "/auth/verify", `{"token":"made-up"}`, http.StatusUnprocessableEntity, "account.invalid_verification_token"},
+		{"login", "/auth/login", `{"identifier":"jhon_doe","password":"Secret123!"}`, http.StatusOK, ""},
+		{"login wrong password", "/auth/login", `{"identifier":"jhon_doe","password":"Wrong123!"}`, http.StatusUnauthorized, "account.invalid_credentials"},
+		{"login locked", "/auth/login", `{"identifier":"jhon_doe","password":"Locked123!"}`, http.StatusForbidden, "account.locked"},
+		{"login rate limited", "/auth/login", `{"identifier":"jhon_doe","password":"Limited123!"}`, http.StatusTooManyRequests, "ratelimit.too_many_requests"},
+		{"login invalid body", "/auth/login", `{`, http.StatusBadRequest, ""},
+		{"login two-factor", "/auth/login", `{"identifier":"jhon_doe","password":"TwoFactor123!"}`, http.StatusOK, ""},
+		{"verify login", "/auth/login/verify", `{"challenge":"challenge-1","code":"123456"}`, http.StatusOK, ""},
+		{"verify login wrong code", "/auth/login/verify", `{"challenge":"challenge-1","code":"000000"}`, http.StatusUnprocessableEntity, "account.invalid_mfa_code"},
+		{"verify login expired", "/auth/login/verify", `{"challenge":"old","code":"123456"}`, http.StatusUnauthorized, "account.invalid_mfa_challenge"},
+		{"verify login missing code", "/auth/login/verify", `{"challenge":"challenge-1"}`, http.StatusUnprocessableEntity, "request.invalid"},
+		{"refresh", "/auth/refresh", `{"refresh_token":"refresh"}`, http.StatusOK, ""},
+		{"refresh rejected", "/auth/refresh", `{"refresh_token":"stolen"}`, http.StatusUnauthorized, "auth.invalid_refresh_token"},
+		{"logout", "/auth/logout", `{"refresh_token":"refresh"}`, http.StatusNoContent, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.url, strings.NewReader(tc.body))
+			req.Header.Set(FingerprintHeader, "device-1")
+			req.Header.Set("User-Agent", "Firefox")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.expectedCode != "" {
+				var resp errorResponse
+				_ = json.NewDecoder(rec.Body).Decode(&resp)
+				if resp.Code != tc.expectedCode {
+					t.Errorf("expected code %q, got %q", tc.expectedCode, resp.Code)
+				}
+			}
+		})
+	}
+
+	if accounts.login.IPAddress != "192.0.2.1" || accounts.login.UserAgent != "Firefox" || accounts.login.DeviceFingerprint != "device-1" {
+		t.Errorf("expected the client's address, agent and fingerprint, got %+v", accounts.login)
+	}
+	if accounts.fingerprint != "device-1" || len(accounts.loggedOut) != 1 {
+		t.Errorf("expected fingerprinted refresh and one logout, got %q %v", accounts.fingerprint, accounts.loggedOut)
+	}
+}
+
+func TestAuthRouter_FieldErrors(t *testing.T) {
+	router := openapitest.Handler(t, RegisterSpec, NewAuthRouter(&fakeAccounts{}))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(`{"identifier":" "}`)))
+
+	var resp errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Fields) != 2 || resp.Fields["identifier"] == "" || resp.Fields["password"] == "" {
+		t.Errorf("expected identifier and password named, got %v", resp.Fields)
+	}
+}
@@ -0,0 +1,210 @@
+package badge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/badge"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/badge"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Badges is the part of the badge service the endpoints need
+type Badges interface {
+	Grant(ctx context.Context, staffID, accountID string, kind badge.Kind, reason string, at time.Time) (*badge.Badge, error)
+	Revoke(ctx context.Context, staffID, accountID, reason string, at time.Time) (*badge.Badge, error)
+	History(ctx context.Context, accountID string) ([]badge.AuditEntry, error)
+	Profile(ctx context.Context, username string) (*app.Profile, error)
+}
+
+type grantRequest struct {
+	Kind   string `json:"kind"`
+	Reason string `json:"reason"`
+}
+
+type revokeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// badgeResponse is how a badge appears next to a username in public payloads
+type badgeResponse struct {
+	Kind  string `json:"kind"`
+	Label string `json:"label"`
+}
+
+type profileResponse struct {
+	Username string         `json:"username"`
+	Badge    *badgeResponse `json:"badge,omitempty"`
+	JoinedAt string         `json:"joined_at"`
+}
+
+type adminBadgeResponse struct {
+	AccountID string  `json:"account_id"`
+	Kind      string  `json:"kind"`
+	Reason    string  `json:"reason"`
+	GrantedBy string  `json:"granted_by"`
+	GrantedAt string  `json:"granted_at"`
+	RevokedBy *string `json:"revoked_by,omitempty"`
+	RevokedAt *string `json:"revoked_at,omitempty"`
+}
+
+type auditResponse struct {
+	Kind    string `json:"kind"`
+	Action  string `json:"action"`
+	ActorID string `json:"actor_id"`
+	Reason  string `json:"reason"`
+	At      string `json:"at"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	badges Badges
+	staff  StaffResolver
+}
+
+func NewHandler(badges Badges, staff StaffResolver) *Handler {
+	return &Handler{badges: badges, staff: staff}
+}
+
+// NewRouter mounts public profiles
+func NewRouter(badges Badges) http.Handler {
+	h := NewHandler(badges, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /profiles/{username}", h.Profile)
+	return mux
+}
+
+// NewAdminRouter mounts badge grants, revocations and their audit trail, it must sit behind admin authentication
+func NewAdminRouter(badges Badges, staff StaffResolver) http.Handler {
+	h := NewHandler(badges, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/accounts/{id}/badge", h.Grant)
+	mux.HandleFunc("DELETE /admin/accounts/{id}/badge", h.Revoke)
+	mux.HandleFunc("GET /admin/accounts/{id}/badge/history", h.History)
+	return mux
+}
+
+func (h *Handler) Profile(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.badges.Profile(r.Context(), r.PathValue("username"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := profileResponse{
+		Username: profile.Account.Username.String(),
+		JoinedAt: profile.Account.CreatedAt.Format(time.RFC3339),
+	}
+	if profile.Badge != nil {
+		resp.Badge = toBadgeResponse(profile.Badge.Kind)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Grant(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req grantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	kind, err := badge.ParseKind(req.Kind)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	b, err := h.badges.Grant(r.Context(), staffID, r.PathValue("id"), kind, req.Reason, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAdminBadgeResponse(b))
+}
+
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req revokeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	b, err := h.badges.Revoke(r.Context(), staffID, r.PathValue("id"), req.Reason, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toAdminBadgeResponse(b))
+}
+
+func (h *Handler) History(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.badges.History(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]auditResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, auditResponse{Kind: string(e.Kind), Action: string(e.Action), ActorID: e.ActorID, Reason: e.Reason, At: e.At.Format(time.RFC3339)})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// toBadgeResponse renders a badge next to a username
+func toBadgeResponse(kind badge.Kind) *badgeResponse {
+	return &badgeResponse{Kind: string(kind), Label: kind.Label()}
+}
+
+func toAdminBadgeResponse(b *badge.Badge) adminBadgeResponse {
+	resp := adminBadgeResponse{
+		AccountID: b.AccountID,
+		Kind:      string(b.Kind),
+		Reason:    b.Reason,
+		GrantedBy: b.GrantedBy,
+		GrantedAt: b.GrantedAt.Format(time.RFC3339),
+		RevokedBy: b.RevokedBy,
+	}
+	if b.RevokedAt != nil {
+		at := b.RevokedAt.Format(time.RFC3339)
+		resp.RevokedAt = &at
+	}
+	return resp
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrAccountNotFound), errors.Is(err, app.ErrNotBadged):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrAlreadyBadged), errors.Is(err, badge.ErrImpersonation):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, badge.ErrInvalidKind), errors.Is(err, badge.ErrReasonRequired):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
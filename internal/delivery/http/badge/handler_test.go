@@ -0,0 +1,108 @@
+package badge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/badge"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/badge"
+)
+
+type fakeBadges struct {
+	staffID string
+	kind    badge.Kind
+	profile *app.Profile
+	err     error
+}
+
+func (f *fakeBadges) Grant(ctx context.Context, staffID, accountID string, kind badge.Kind, reason string, at time.Time) (*badge.Badge, error) {
+	f.staffID, f.kind = staffID, kind
+	if f.err != nil {
+		return nil, f.err
+	}
+	return badge.NewBadge(accountID, "jokosaputro", kind, staffID, reason, at)
+}
+
+func (f *fakeBadges) Revoke(ctx context.Context, staffID, accountID, reason string, at time.Time) (*badge.Badge, error) {
+	return nil, f.err
+}
+
+func (f *fakeBadges) History(ctx context.Context, accountID string) ([]badge.AuditEntry, error) {
+	return []badge.AuditEntry{{AccountID: accountID, Kind: badge.KindAuthor, Action: badge.ActionGrant, ActorID: "staff-1", Reason: "Columnist", At: time.Now()}}, f.err
+}
+
+func (f *fakeBadges) Profile(ctx context.Context, username string) (*app.Profile, error) {
+	return f.profile, f.err
+}
+
+func TestHandler_Profile(t *testing.T) {
+	acc, _ := account.NewUserAccountForTesting("acc-1", "jokosaputro", "joko@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	b, _ := badge.NewBadge("acc-1", "jokosaputro", badge.KindAuthor, "staff-1", "Columnist", time.Now())
+
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakeBadges{profile: &app.Profile{Account: acc, Badge: b}})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/profiles/jokosaputro", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var resp profileResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Badge == nil || resp.Badge.Label != "Verified author" {
+		t.Errorf("expected verified author badge, got %+v", resp.Badge)
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakeBadges{err: app.ErrAccountNotFound})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/profiles/nobody", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Grant(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		signedIn       bool
+		err            error
+		expectedStatus int
+	}{
+		{"granted", `{"kind":"author","reason":"Columnist"}`, true, nil, http.StatusCreated},
+		{"no staff session", `{"kind":"author","reason":"Columnist"}`, false, nil, http.StatusUnauthorized},
+		{"invalid kind", `{"kind":"celebrity","reason":"Famous"}`, true, nil, http.StatusUnprocessableEntity},
+		{"look-alike username", `{"kind":"author","reason":"Columnist"}`, true, badge.ErrImpersonation, http.StatusConflict},
+		{"already badged", `{"kind":"author","reason":"Columnist"}`, true, app.ErrAlreadyBadged, http.StatusConflict},
+		{"store down", `{"kind":"author","reason":"Columnist"}`, true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			badges := &fakeBadges{err: tc.err}
+			staff := func(r *http.Request) (string, bool) { return "staff-1", tc.signedIn }
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(badges, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/accounts/acc-1/badge", strings.NewReader(tc.body)))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusCreated && (badges.staffID != "staff-1" || badges.kind != badge.KindAuthor) {
+				t.Errorf("expected author badge granted by staff-1, got %s %s", badges.staffID, badges.kind)
+			}
+		})
+	}
+}
+
+func TestHandler_Revoke(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "staff-1", true }
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeBadges{err: app.ErrNotBadged}, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/accounts/acc-1/badge", strings.NewReader(`{"reason":"Left"}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
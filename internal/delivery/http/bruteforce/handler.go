@@ -0,0 +1,125 @@
+package bruteforce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/bruteforce"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/bruteforce"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Bans is the part of the brute-force service the admin endpoints need
+type Bans interface {
+	Bans(ctx context.Context, at time.Time) ([]*bruteforce.Ban, error)
+	Lift(ctx context.Context, staffID string, scope bruteforce.Scope, value string, at time.Time) (*bruteforce.Ban, error)
+}
+
+type banResponse struct {
+	Scope     string  `json:"scope"`
+	Value     string  `json:"value"`
+	Failures  int     `json:"failures"`
+	Strike    int     `json:"strike"`
+	BannedAt  string  `json:"banned_at"`
+	ExpiresAt string  `json:"expires_at"`
+	LiftedBy  *string `json:"lifted_by,omitempty"`
+	LiftedAt  *string `json:"lifted_at,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	bans  Bans
+	staff StaffResolver
+}
+
+func NewHandler(bans Bans, staff StaffResolver) *Handler {
+	return &Handler{bans: bans, staff: staff}
+}
+
+// NewAdminRouter mounts the login ban overview, it must sit behind admin authentication
+func NewAdminRouter(bans Bans, staff StaffResolver) http.Handler {
+	h := NewHandler(bans, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/security/bans", h.List)
+	mux.HandleFunc("DELETE /admin/security/bans/{scope}/{value}", h.Lift)
+	return mux
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	bans, err := h.bans.Bans(r.Context(), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]banResponse, 0, len(bans))
+	for _, b := range bans {
+		resp = append(resp, toBanResponse(b))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Lift handles DELETE /admin/security/bans/ip/203.0.113.7 and /admin/security/bans/asn/64500
+func (h *Handler) Lift(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	scope, err := bruteforce.ParseScope(r.PathValue("scope"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ban, err := h.bans.Lift(r.Context(), staffID, scope, r.PathValue("value"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toBanResponse(ban))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrBanNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, bruteforce.ErrInvalidScope), errors.Is(err, bruteforce.ErrInvalidSubject):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toBanResponse(b *bruteforce.Ban) banResponse {
+	resp := banResponse{
+		Scope:     string(b.Subject.Scope),
+		Value:     b.Subject.Value,
+		Failures:  b.Failures,
+		Strike:    b.Strike,
+		BannedAt:  b.BannedAt.Format(time.RFC3339),
+		ExpiresAt: b.ExpiresAt.Format(time.RFC3339),
+		LiftedBy:  b.LiftedBy,
+	}
+	if b.LiftedAt != nil {
+		at := b.LiftedAt.Format(time.RFC3339)
+		resp.LiftedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
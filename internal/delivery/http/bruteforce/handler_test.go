@@ -0,0 +1,76 @@
+package bruteforce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/bruteforce"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/bruteforce"
+)
+
+type fakeBans struct {
+	err error
+}
+
+func (f *fakeBans) Bans(ctx context.Context, at time.Time) ([]*bruteforce.Ban, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	subject, _ := bruteforce.NewSubject(bruteforce.ScopeASN, "64500")
+	ban, _ := bruteforce.NewBan(subject, 300, 1, time.Hour, at)
+	return []*bruteforce.Ban{ban}, nil
+}
+
+func (f *fakeBans) Lift(ctx context.Context, staffID string, scope bruteforce.Scope, value string, at time.Time) (*bruteforce.Ban, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	subject, err := bruteforce.NewSubject(scope, value)
+	if err != nil {
+		return nil, err
+	}
+	ban, _ := bruteforce.NewBan(subject, 20, 1, time.Hour, at)
+	return ban, ban.Lift(staffID, at)
+}
+
+func TestHandler(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "admin-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"list", http.MethodGet, "/admin/security/bans", true, nil, http.StatusOK},
+		{"list failure", http.MethodGet, "/admin/security/bans", true, errors.New("connection refused"), http.StatusInternalServerError},
+		{"lift ip", http.MethodDelete, "/admin/security/bans/ip/203.0.113.7", true, nil, http.StatusOK},
+		{"lift asn", http.MethodDelete, "/admin/security/bans/asn/AS64500", true, nil, http.StatusOK},
+		{"without session", http.MethodDelete, "/admin/security/bans/ip/203.0.113.7", false, nil, http.StatusUnauthorized},
+		{"unknown scope", http.MethodDelete, "/admin/security/bans/country/ID", true, nil, http.StatusUnprocessableEntity},
+		{"invalid address", http.MethodDelete, "/admin/security/bans/ip/office", true, nil, http.StatusUnprocessableEntity},
+		{"no ban", http.MethodDelete, "/admin/security/bans/ip/203.0.113.8", true, app.ErrBanNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, nil)
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeBans{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
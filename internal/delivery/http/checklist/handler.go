@@ -0,0 +1,229 @@
+package checklist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Checklists is the part of the checklist service the endpoints need
+type Checklists interface {
+	Evaluate(ctx context.Context, articleID string) (*checklist.Result, error)
+	ApproveLegal(ctx context.Context, staffID, articleID, note string, at time.Time) (*checklist.Approval, error)
+	Template(ctx context.Context, desk string) (*checklist.Template, error)
+	Templates(ctx context.Context) ([]*checklist.Template, error)
+	SaveTemplate(ctx context.Context, staffID, desk string, items []app.ItemInput, at time.Time) (*checklist.Template, error)
+	DeleteTemplate(ctx context.Context, desk string) error
+}
+
+type itemRequest struct {
+	Kind          string   `json:"kind"`
+	Required      bool     `json:"required"`
+	MinTags       int      `json:"min_tags,omitempty"`
+	SensitiveTags []string `json:"sensitive_tags,omitempty"`
+}
+
+type templateRequest struct {
+	Items []itemRequest `json:"items"`
+}
+
+type approvalRequest struct {
+	Note string `json:"note"`
+}
+
+type templateResponse struct {
+	Desk      string        `json:"desk"`
+	Items     []itemRequest `json:"items"`
+	UpdatedBy *string       `json:"updated_by,omitempty"`
+	UpdatedAt *string       `json:"updated_at,omitempty"`
+}
+
+type itemResultResponse struct {
+	Kind     string `json:"kind"`
+	Required bool   `json:"required"`
+	Passed   bool   `json:"passed"`
+	Message  string `json:"message,omitempty"`
+}
+
+type resultResponse struct {
+	Desk     string               `json:"desk"`
+	Blocked  bool                 `json:"blocked"`
+	Blocking []string             `json:"blocking"`
+	Items    []itemResultResponse `json:"items"`
+}
+
+type approvalResponse struct {
+	ArticleID  string `json:"article_id"`
+	Revision   string `json:"revision"`
+	ReviewerID string `json:"reviewer_id"`
+	Note       string `json:"note,omitempty"`
+	ApprovedAt string `json:"approved_at"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	checklists Checklists
+	staff      StaffResolver
+}
+
+func NewHandler(checklists Checklists, staff StaffResolver) *Handler {
+	return &Handler{checklists: checklists, staff: staff}
+}
+
+// NewAdminRouter mounts the publish checklist, legal approval and per-desk variants, it must sit behind admin authentication
+func NewAdminRouter(checklists Checklists, staff StaffResolver) http.Handler {
+	h := NewHandler(checklists, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/articles/{id}/publish-checklist", h.Evaluate)
+	mux.HandleFunc("POST /admin/articles/{id}/legal-approval", h.ApproveLegal)
+	mux.HandleFunc("GET /admin/publish-checklists", h.Templates)
+	mux.HandleFunc("GET /admin/publish-checklists/{desk}", h.Template)
+	mux.HandleFunc("PUT /admin/publish-checklists/{desk}", h.SaveTemplate)
+	mux.HandleFunc("DELETE /admin/publish-checklists/{desk}", h.DeleteTemplate)
+	return mux
+}
+
+func (h *Handler) Evaluate(w http.ResponseWriter, r *http.Request) {
+	result, err := h.checklists.Evaluate(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := resultResponse{Desk: result.Desk, Blocked: result.Blocked(), Blocking: []string{}, Items: make([]itemResultResponse, 0, len(result.Items))}
+	for _, kind := range result.Blocking() {
+		resp.Blocking = append(resp.Blocking, string(kind))
+	}
+	for _, item := range result.Items {
+		resp.Items = append(resp.Items, itemResultResponse{Kind: string(item.Kind), Required: item.Required, Passed: item.Passed, Message: item.Message})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) ApproveLegal(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req approvalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	approval, err := h.checklists.ApproveLegal(r.Context(), staffID, r.PathValue("id"), req.Note, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, approvalResponse{
+		ArticleID:  approval.ArticleID,
+		Revision:   approval.Revision,
+		ReviewerID: approval.ReviewerID,
+		Note:       approval.Note,
+		ApprovedAt: approval.ApprovedAt.Format(time.RFC3339),
+	})
+}
+
+func (h *Handler) Templates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.checklists.Templates(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]templateResponse, 0, len(templates))
+	for _, t := range templates {
+		resp = append(resp, toTemplateResponse(t))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Template returns the checklist the desk gets, which may be the default
+func (h *Handler) Template(w http.ResponseWriter, r *http.Request) {
+	template, err := h.checklists.Template(r.Context(), r.PathValue("desk"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTemplateResponse(template))
+}
+
+func (h *Handler) SaveTemplate(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req templateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	inputs := make([]app.ItemInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		inputs = append(inputs, app.ItemInput{Kind: item.Kind, Required: item.Required, MinTags: item.MinTags, SensitiveTags: item.SensitiveTags})
+	}
+
+	template, err := h.checklists.SaveTemplate(r.Context(), staffID, r.PathValue("desk"), inputs, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTemplateResponse(template))
+}
+
+func (h *Handler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := h.checklists.DeleteTemplate(r.Context(), r.PathValue("desk")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrArticleNotFound), errors.Is(err, app.ErrTemplateNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrNotLegalReviewer):
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+	case errors.Is(err, checklist.ErrNothingToApprove):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, checklist.ErrInvalidItemKind), errors.Is(err, checklist.ErrInvalidMinTags), errors.Is(err, checklist.ErrDuplicateItem),
+		errors.Is(err, checklist.ErrNoItems), errors.Is(err, checklist.ErrTooManySensitive), errors.Is(err, checklist.ErrInvalidDesk):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toTemplateResponse(t *checklist.Template) templateResponse {
+	resp := templateResponse{Desk: t.Desk, Items: make([]itemRequest, 0, len(t.Items)), UpdatedBy: t.LastActionBy}
+	for _, item := range t.Items {
+		resp.Items = append(resp.Items, itemRequest{Kind: string(item.Kind), Required: item.Required, MinTags: item.MinTags, SensitiveTags: item.SensitiveTags})
+	}
+	if !t.UpdatedAt.IsZero() {
+		at := t.UpdatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,117 @@
+package checklist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/checklist"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/checklist"
+)
+
+type fakeChecklists struct {
+	desk   string
+	inputs []app.ItemInput
+	err    error
+}
+
+func (f *fakeChecklists) Evaluate(ctx context.Context, articleID string) (*checklist.Result, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return checklist.DefaultTemplate().Evaluate(checklist.Article{ID: articleID, CategoryID: "cat-1", Tags: []string{"kpk"}}, checklist.Evidence{}), nil
+}
+
+func (f *fakeChecklists) ApproveLegal(ctx context.Context, staffID, articleID, note string, at time.Time) (*checklist.Approval, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &checklist.Approval{ArticleID: articleID, Revision: "r1", ReviewerID: staffID, Note: note, ApprovedAt: at}, nil
+}
+
+func (f *fakeChecklists) Template(ctx context.Context, desk string) (*checklist.Template, error) {
+	return checklist.DefaultTemplate(), f.err
+}
+
+func (f *fakeChecklists) Templates(ctx context.Context) ([]*checklist.Template, error) {
+	return nil, f.err
+}
+
+func (f *fakeChecklists) SaveTemplate(ctx context.Context, staffID, desk string, items []app.ItemInput, at time.Time) (*checklist.Template, error) {
+	f.desk, f.inputs = desk, items
+	if f.err != nil {
+		return nil, f.err
+	}
+	return checklist.NewTemplate(desk, []checklist.Item{{Kind: checklist.ItemCategory, Required: true}}, staffID, at)
+}
+
+func (f *fakeChecklists) DeleteTemplate(ctx context.Context, desk string) error {
+	return f.err
+}
+
+func staff(r *http.Request) (string, bool) {
+	return "legal-1", r.Header.Get("Authorization") != ""
+}
+
+func TestHandler_Evaluate(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeChecklists{}, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/articles/a1/publish-checklist", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp resultResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if !resp.Blocked || len(resp.Items) != 6 || strings.Join(resp.Blocking, ",") != "hero_image,seo_fields" {
+		t.Errorf("unexpected result %+v", resp)
+	}
+}
+
+func TestHandler_Endpoints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"approve", http.MethodPost, "/admin/articles/a1/legal-approval", `{"note":"ok"}`, true, nil, http.StatusCreated},
+		{"approve without session", http.MethodPost, "/admin/articles/a1/legal-approval", `{}`, false, nil, http.StatusUnauthorized},
+		{"approve by reporter", http.MethodPost, "/admin/articles/a1/legal-approval", `{}`, true, app.ErrNotLegalReviewer, http.StatusForbidden},
+		{"approve nothing sensitive", http.MethodPost, "/admin/articles/a1/legal-approval", `{}`, true, checklist.ErrNothingToApprove, http.StatusConflict},
+		{"evaluate missing article", http.MethodGet, "/admin/articles/zz/publish-checklist", ``, true, app.ErrArticleNotFound, http.StatusNotFound},
+		{"save variant", http.MethodPut, "/admin/publish-checklists/politics", `{"items":[{"kind":"category","required":true}]}`, true, nil, http.StatusOK},
+		{"save invalid item", http.MethodPut, "/admin/publish-checklists/politics", `{"items":[{"kind":"tags"}]}`, true, fmt.Errorf("item 1: %w", checklist.ErrInvalidMinTags), http.StatusUnprocessableEntity},
+		{"get variant", http.MethodGet, "/admin/publish-checklists/politics", ``, true, nil, http.StatusOK},
+		{"delete missing variant", http.MethodDelete, "/admin/publish-checklists/sports", ``, true, app.ErrTemplateNotFound, http.StatusNotFound},
+		{"list failure", http.MethodGet, "/admin/publish-checklists", ``, true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checklists := &fakeChecklists{err: tc.err}
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(checklists, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.name == "save variant" && (checklists.desk != "politics" || len(checklists.inputs) != 1 || !checklists.inputs[0].Required) {
+				t.Errorf("expected items passed for politics, got %s %+v", checklists.desk, checklists.inputs)
+			}
+		})
+	}
+}
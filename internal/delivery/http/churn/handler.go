@@ -0,0 +1,166 @@
+package churn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/churn"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/churn"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Scores is the part of the churn service the admin endpoints need, scoring
+// itself only runs as the scheduled job
+type Scores interface {
+	History(ctx context.Context, accountID string, limit int) ([]*churn.Assessment, error)
+	Export(ctx context.Context, after string, limit int) ([]*churn.Assessment, error)
+}
+
+type factorResponse struct {
+	Code   string `json:"code"`
+	Points int    `json:"points"`
+}
+
+type signalsResponse struct {
+	Reads           int     `json:"reads"`
+	PriorReads      int     `json:"prior_reads"`
+	Logins          int     `json:"logins"`
+	LastLoginAt     *string `json:"last_login_at,omitempty"`
+	NewslettersSent int     `json:"newsletters_sent"`
+	NewsletterOpens int     `json:"newsletter_opens"`
+}
+
+type assessmentResponse struct {
+	AccountID      string           `json:"account_id"`
+	SubscriptionID string           `json:"subscription_id"`
+	Score          int              `json:"score"`
+	Level          string           `json:"level"`
+	Factors        []factorResponse `json:"factors"`
+	Signals        signalsResponse  `json:"signals"`
+	ScoredAt       string           `json:"scored_at"`
+}
+
+type exportResponse struct {
+	Items     []assessmentResponse `json:"items"`
+	NextAfter *string              `json:"next_after,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	scores Scores
+}
+
+func NewHandler(scores Scores) *Handler {
+	return &Handler{scores: scores}
+}
+
+// NewAdminRouter mounts churn-risk history and the CRM export feed, it must sit behind admin authentication
+func NewAdminRouter(scores Scores) http.Handler {
+	h := NewHandler(scores)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/crm/churn-scores", h.Export)
+	mux.HandleFunc("GET /admin/members/{id}/churn-scores", h.History)
+	return mux
+}
+
+// Export pages the latest score per member by account ID. The CRM keeps
+// calling with next_after until it is missing from the response.
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	limit, ok := limitParam(w, r)
+	if !ok {
+		return
+	}
+	if limit == 0 {
+		limit = app.DefaultPageSize
+	}
+	limit = min(limit, app.MaxPageSize)
+
+	assessments, err := h.scores.Export(r.Context(), r.URL.Query().Get("after"), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := exportResponse{Items: toAssessmentResponses(assessments)}
+	if len(assessments) == limit {
+		resp.NextAfter = &assessments[len(assessments)-1].AccountID
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) History(w http.ResponseWriter, r *http.Request) {
+	limit, ok := limitParam(w, r)
+	if !ok {
+		return
+	}
+	assessments, err := h.scores.History(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toAssessmentResponses(assessments))
+}
+
+// limitParam returns zero when no limit was given
+func limitParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit"})
+		return 0, false
+	}
+	return n, true
+}
+
+func toAssessmentResponses(assessments []*churn.Assessment) []assessmentResponse {
+	resp := make([]assessmentResponse, 0, len(assessments))
+	for _, a := range assessments {
+		item := assessmentResponse{
+			AccountID:      a.AccountID,
+			SubscriptionID: a.SubscriptionID,
+			Score:          a.Score,
+			Level:          string(a.Level),
+			Factors:        make([]factorResponse, 0, len(a.Factors)),
+			Signals: signalsResponse{
+				Reads:           a.Signals.Reads,
+				PriorReads:      a.Signals.PriorReads,
+				Logins:          a.Signals.Logins,
+				NewslettersSent: a.Signals.NewslettersSent,
+				NewsletterOpens: a.Signals.NewsletterOpens,
+			},
+			ScoredAt: a.ScoredAt.Format(time.RFC3339),
+		}
+		if a.Signals.LastLoginAt != nil {
+			at := a.Signals.LastLoginAt.Format(time.RFC3339)
+			item.Signals.LastLoginAt = &at
+		}
+		for _, f := range a.Factors {
+			item.Factors = append(item.Factors, factorResponse{Code: f.Code, Points: f.Points})
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
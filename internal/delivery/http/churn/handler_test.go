@@ -0,0 +1,105 @@
+package churn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/churn"
+)
+
+type fakeScores struct {
+	err       error
+	after     string
+	limit     int
+	accountID string
+}
+
+func (f *fakeScores) assessments(n int) []*churn.Assessment {
+	at := time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC)
+	var list []*churn.Assessment
+	for i := range n {
+		a, _ := churn.NewAssessment("a", churn.Member{AccountID: string(rune('a' + i)), SubscriptionID: "sub"}, churn.Signals{NewslettersSent: 4}, at)
+		list = append(list, a)
+	}
+	return list
+}
+
+func (f *fakeScores) History(ctx context.Context, accountID string, limit int) ([]*churn.Assessment, error) {
+	f.accountID, f.limit = accountID, limit
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.assessments(2), nil
+}
+
+func (f *fakeScores) Export(ctx context.Context, after string, limit int) ([]*churn.Assessment, error) {
+	f.after, f.limit = after, limit
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.assessments(min(limit, 3)), nil
+}
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+	}{
+		{"export", "/admin/crm/churn-scores?after=account-1&limit=2", nil, http.StatusOK},
+		{"export invalid limit", "/admin/crm/churn-scores?limit=0", nil, http.StatusBadRequest},
+		{"history", "/admin/members/account-1/churn-scores", nil, http.StatusOK},
+		{"history invalid limit", "/admin/members/account-1/churn-scores?limit=ten", nil, http.StatusBadRequest},
+		{"store failure", "/admin/crm/churn-scores", errors.New("connection reset"), http.StatusInternalServerError},
+		{"timeout", "/admin/members/account-1/churn-scores", context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeScores{err: tc.err})).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ExportCursor(t *testing.T) {
+	scores := &fakeScores{}
+	decode := func(url string) exportResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		openapitest.Handler(t, RegisterSpec, NewAdminRouter(scores)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		var resp exportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	full := decode("/admin/crm/churn-scores?after=x&limit=2")
+	if scores.after != "x" || scores.limit != 2 {
+		t.Errorf("expected cursor x and limit 2, got %q %d", scores.after, scores.limit)
+	}
+	if len(full.Items) != 2 || full.NextAfter == nil || *full.NextAfter != "b" {
+		t.Fatalf("expected a full page to point at the next one, got %+v", full)
+	}
+	if full.Items[0].Level != "high" || full.Items[0].Score != 100 || len(full.Items[0].Factors) != 3 {
+		t.Errorf("unexpected item %+v", full.Items[0])
+	}
+
+	last := decode("/admin/crm/churn-scores?limit=5000")
+	if scores.limit != 1000 || last.NextAfter != nil {
+		t.Errorf("expected the limit to be capped and the short page to end the feed, got %d %+v", scores.limit, last.NextAfter)
+	}
+}
@@ -0,0 +1,328 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// ArchiveFormat names the archive layout, bumped when a field changes meaning
+const ArchiveFormat = "news-portal-cms/comments/v1"
+
+// Upload limits
+const (
+	maxArchiveBytes = 32 << 20  // A member's archive, MaxArchiveComments at the longest body fit
+	maxLegacyBytes  = 512 << 20 // A legacy export, split bigger ones by date
+)
+
+var errInvalidArchive = errors.New("invalid comment archive")
+
+// MemberResolver returns the signed-in member's account ID
+type MemberResolver func(r *http.Request) (string, bool)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// LegacyParser reads a legacy platform's export, e.g. the Disqus XML parser
+type LegacyParser func(r io.Reader) (*comment.LegacyExport, error)
+
+// Portability is the part of the portability service the endpoints need
+type Portability interface {
+	Export(ctx context.Context, accountID string) (*comment.Archive, error)
+	ImportArchive(ctx context.Context, accountID string, archive *comment.Archive) (*app.ImportReport, error)
+	ImportLegacy(ctx context.Context, export *comment.LegacyExport) (*app.LegacyReport, error)
+}
+
+// archiveDocument is the documented archive format members download and upload:
+//
+//	{
+//	  "format": "news-portal-cms/comments/v1",
+//	  "account_id": "6f1c...",
+//	  "exported_at": "2026-03-10T09:00:00Z",
+//	  "comments": [
+//	    {"id": "c1", "article_id": "a1", "parent_id": null, "body": "Sharp analysis",
+//	     "status": "approved", "created_at": "2026-03-01T08:00:00Z", "edited_at": null}
+//	  ],
+//	  "reactions": [
+//	    {"comment_id": "c7", "article_id": "a2", "kind": "up", "created_at": "2026-03-02T10:00:00Z"}
+//	  ]
+//	}
+//
+// Times are RFC 3339. Status is approved, pending, rejected or deleted, only
+// approved and pending comments are imported back. Bodies are plain text.
+type archiveDocument struct {
+	Format     string            `json:"format"`
+	AccountID  string            `json:"account_id"`
+	ExportedAt string            `json:"exported_at"`
+	Comments   []archiveComment  `json:"comments"`
+	Reactions  []archiveReaction `json:"reactions"`
+}
+
+type archiveComment struct {
+	ID        string  `json:"id"`
+	ArticleID string  `json:"article_id"`
+	ParentID  *string `json:"parent_id"`
+	Body      string  `json:"body"`
+	Status    string  `json:"status"`
+	CreatedAt string  `json:"created_at"`
+	EditedAt  *string `json:"edited_at"`
+}
+
+type archiveReaction struct {
+	CommentID string `json:"comment_id"`
+	ArticleID string `json:"article_id"`
+	Kind      string `json:"kind"`
+	CreatedAt string `json:"created_at"`
+}
+
+type skipResponse struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+type importResponse struct {
+	Comments  int            `json:"comments"`
+	Reactions int            `json:"reactions"`
+	Skipped   []skipResponse `json:"skipped"`
+}
+
+type legacyResponse struct {
+	Threads          int            `json:"threads"`
+	UnmatchedThreads []string       `json:"unmatched_threads"`
+	Imported         int            `json:"imported"`
+	AlreadyImported  int            `json:"already_imported"`
+	Matches          map[string]int `json:"matches"`
+	Skipped          []skipResponse `json:"skipped"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	portability Portability
+	parse       LegacyParser
+	member      MemberResolver
+	staff       StaffResolver
+}
+
+func NewHandler(portability Portability, parse LegacyParser, member MemberResolver, staff StaffResolver) *Handler {
+	return &Handler{portability: portability, parse: parse, member: member, staff: staff}
+}
+
+// NewRouter mounts the member's comment export and import
+func NewRouter(portability Portability, member MemberResolver) http.Handler {
+	h := NewHandler(portability, nil, member, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /me/comments/export", h.Export)
+	mux.HandleFunc("POST /me/comments/import", h.Import)
+	return mux
+}
+
+// NewAdminRouter mounts legacy comment migration, it must sit behind admin authentication
+func NewAdminRouter(portability Portability, parse LegacyParser, staff StaffResolver) http.Handler {
+	h := NewHandler(portability, parse, nil, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/comments/imports/disqus", h.ImportLegacy)
+	return mux
+}
+
+// Export downloads the member's archive as a file
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to export your comments"})
+		return
+	}
+	archive, err := h.portability.Export(r.Context(), accountID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="comments-%s.json"`, archive.ExportedAt.Format(time.DateOnly)))
+	writeJSON(w, http.StatusOK, toArchiveDocument(archive))
+}
+
+// Import takes an archive in the format Export produces, possibly from another
+// site running this CMS. Comments come back pending and go through screening.
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to import your comments"})
+		return
+	}
+	var doc archiveDocument
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxArchiveBytes)).Decode(&doc); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: "archive is too large"})
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	archive, err := doc.archive()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	report, err := h.portability.ImportArchive(r.Context(), accountID, archive)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, importResponse{Comments: report.Comments, Reactions: report.Reactions, Skipped: toSkipResponses(report.Skipped)})
+}
+
+// ImportLegacy handles POST /admin/comments/imports/disqus with the export
+// file as the body. Re-running the same export only adds what is missing.
+func (h *Handler) ImportLegacy(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.staff(r); !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	export, err := h.parse(http.MaxBytesReader(w, r.Body, maxLegacyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: "export is too large"})
+			return
+		}
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+		return
+	}
+
+	report, err := h.portability.ImportLegacy(r.Context(), export)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := legacyResponse{
+		Threads:          report.Threads,
+		UnmatchedThreads: report.UnmatchedThreads,
+		Imported:         report.Imported,
+		AlreadyImported:  report.AlreadyImported,
+		Matches:          map[string]int{},
+		Skipped:          toSkipResponses(report.Skipped),
+	}
+	if resp.UnmatchedThreads == nil {
+		resp.UnmatchedThreads = []string{}
+	}
+	for kind, n := range report.Matches {
+		resp.Matches[string(kind)] = n
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (d archiveDocument) archive() (*comment.Archive, error) {
+	if d.Format != ArchiveFormat {
+		return nil, fmt.Errorf("%w: format must be %s", errInvalidArchive, ArchiveFormat)
+	}
+	archive := &comment.Archive{
+		AccountID: d.AccountID,
+		Comments:  make([]comment.ArchivedComment, 0, len(d.Comments)),
+		Reactions: make([]comment.ArchivedReaction, 0, len(d.Reactions)),
+	}
+	if len(d.Comments) > app.MaxArchiveComments {
+		return nil, app.ErrImportTooLarge
+	}
+	for _, c := range d.Comments {
+		createdAt, err := time.Parse(time.RFC3339, c.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: comment %s has an invalid created_at", errInvalidArchive, c.ID)
+		}
+		ac := comment.ArchivedComment{
+			ID:        c.ID,
+			ArticleID: c.ArticleID,
+			ParentID:  c.ParentID,
+			Body:      c.Body,
+			Status:    comment.Status(c.Status),
+			CreatedAt: createdAt,
+		}
+		if c.EditedAt != nil {
+			editedAt, err := time.Parse(time.RFC3339, *c.EditedAt)
+			if err != nil {
+				return nil, fmt.Errorf("%w: comment %s has an invalid edited_at", errInvalidArchive, c.ID)
+			}
+			ac.EditedAt = &editedAt
+		}
+		archive.Comments = append(archive.Comments, ac)
+	}
+	for _, re := range d.Reactions {
+		kind, err := comment.ParseReactionKind(re.Kind)
+		if err != nil {
+			return nil, err
+		}
+		createdAt, err := time.Parse(time.RFC3339, re.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reaction on %s has an invalid created_at", errInvalidArchive, re.CommentID)
+		}
+		archive.Reactions = append(archive.Reactions, comment.ArchivedReaction{CommentID: re.CommentID, ArticleID: re.ArticleID, Kind: kind, CreatedAt: createdAt})
+	}
+	return archive, nil
+}
+
+func toArchiveDocument(a *comment.Archive) archiveDocument {
+	doc := archiveDocument{
+		Format:     ArchiveFormat,
+		AccountID:  a.AccountID,
+		ExportedAt: a.ExportedAt.Format(time.RFC3339),
+		Comments:   make([]archiveComment, 0, len(a.Comments)),
+		Reactions:  make([]archiveReaction, 0, len(a.Reactions)),
+	}
+	for _, c := range a.Comments {
+		ac := archiveComment{
+			ID:        c.ID,
+			ArticleID: c.ArticleID,
+			ParentID:  c.ParentID,
+			Body:      c.Body,
+			Status:    string(c.Status),
+			CreatedAt: c.CreatedAt.Format(time.RFC3339),
+		}
+		if c.EditedAt != nil {
+			at := c.EditedAt.Format(time.RFC3339)
+			ac.EditedAt = &at
+		}
+		doc.Comments = append(doc.Comments, ac)
+	}
+	for _, r := range a.Reactions {
+		doc.Reactions = append(doc.Reactions, archiveReaction{CommentID: r.CommentID, ArticleID: r.ArticleID, Kind: string(r.Kind), CreatedAt: r.CreatedAt.Format(time.RFC3339)})
+	}
+	return doc
+}
+
+func toSkipResponses(skipped []app.Skip) []skipResponse {
+	resp := make([]skipResponse, 0, len(skipped))
+	for _, s := range skipped {
+		resp = append(resp, skipResponse{ID: s.ID, Reason: s.Reason})
+	}
+	return resp
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrImportTooLarge):
+		writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: err.Error()})
+	case errors.Is(err, errInvalidArchive), errors.Is(err, comment.ErrInvalidReaction):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,181 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+)
+
+type fakePortability struct {
+	err      error
+	imported *comment.Archive
+}
+
+func (f *fakePortability) Export(ctx context.Context, accountID string) (*comment.Archive, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	c, _ := comment.NewComment("c1", "a1", comment.AccountAuthor(accountID), "Sharp analysis", 1)
+	r := &comment.Reaction{CommentID: "c7", ArticleID: "a2", AccountID: accountID, Kind: comment.ReactionUp, CreatedAt: at}
+	return comment.NewArchive(accountID, []*comment.Comment{c}, []*comment.Reaction{r}, at), nil
+}
+
+func (f *fakePortability) ImportArchive(ctx context.Context, accountID string, archive *comment.Archive) (*app.ImportReport, error) {
+	f.imported = archive
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.ImportReport{Comments: len(archive.Comments), Skipped: []app.Skip{{ID: "c9", Reason: app.SkipArticleNotFound}}}, nil
+}
+
+func (f *fakePortability) ImportLegacy(ctx context.Context, export *comment.LegacyExport) (*app.LegacyReport, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.LegacyReport{Threads: len(export.Threads), Imported: len(export.Posts), Matches: map[comment.MatchKind]int{comment.MatchEmail: len(export.Posts)}}, nil
+}
+
+func parseLegacy(r io.Reader) (*comment.LegacyExport, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if string(raw) != "<disqus/>" {
+		return nil, errors.New("not a disqus export")
+	}
+	return &comment.LegacyExport{Threads: []comment.LegacyThread{{ID: "t1"}}, Posts: []comment.LegacyPost{{ID: "p1"}}}, nil
+}
+
+func member(r *http.Request) (string, bool) {
+	return "u1", true
+}
+
+func staff(r *http.Request) (string, bool) {
+	return "admin-1", true
+}
+
+func nobody(r *http.Request) (string, bool) {
+	return "", false
+}
+
+const archiveBody = `{"format": "news-portal-cms/comments/v1", "comments": [{"id": "c1", "article_id": "a1", "body": "Hi", "status": "approved", "created_at": "2026-03-01T08:00:00Z", "edited_at": "2026-03-01T08:05:00Z"}], "reactions": [{"comment_id": "c7", "kind": "up", "created_at": "2026-03-02T10:00:00Z"}]}`
+
+func TestRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		member         MemberResolver
+		err            error
+		expectedStatus int
+	}{
+		{"export", http.MethodGet, "/me/comments/export", "", member, nil, http.StatusOK},
+		{"export signed out", http.MethodGet, "/me/comments/export", "", nobody, nil, http.StatusUnauthorized},
+		{"export timeout", http.MethodGet, "/me/comments/export", "", member, context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"import", http.MethodPost, "/me/comments/import", archiveBody, member, nil, http.StatusOK},
+		{"import signed out", http.MethodPost, "/me/comments/import", archiveBody, nobody, nil, http.StatusUnauthorized},
+		{"import invalid body", http.MethodPost, "/me/comments/import", `{"format":`, member, nil, http.StatusBadRequest},
+		{"import unknown format", http.MethodPost, "/me/comments/import", `{"format": "disqus"}`, member, nil, http.StatusUnprocessableEntity},
+		{"import invalid time", http.MethodPost, "/me/comments/import", `{"format": "news-portal-cms/comments/v1", "comments": [{"id": "c1", "created_at": "yesterday"}]}`, member, nil, http.StatusUnprocessableEntity},
+		{"import invalid reaction", http.MethodPost, "/me/comments/import", `{"format": "news-portal-cms/comments/v1", "reactions": [{"comment_id": "c7", "kind": "love"}]}`, member, nil, http.StatusUnprocessableEntity},
+		{"import too large", http.MethodPost, "/me/comments/import", archiveBody, member, app.ErrImportTooLarge, http.StatusRequestEntityTooLarge},
+		{"import store failure", http.MethodPost, "/me/comments/import", archiveBody, member, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakePortability{err: tc.err}, tc.member)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_ExportRoundTrip(t *testing.T) {
+	portability := &fakePortability{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(portability, member)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/me/comments/export", nil))
+
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="comments-2026-03-10.json"` {
+		t.Errorf("unexpected disposition %q", got)
+	}
+	exported := rec.Body.String()
+	if !strings.Contains(exported, `"format":"news-portal-cms/comments/v1"`) || !strings.Contains(exported, `"kind":"up"`) {
+		t.Fatalf("unexpected archive %s", exported)
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(portability, member)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/me/comments/import", strings.NewReader(exported)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the export to import back, got %d: %s", rec.Code, rec.Body.String())
+	}
+	archive := portability.imported
+	if len(archive.Comments) != 1 || archive.Comments[0].Body != "Sharp analysis" || archive.Comments[0].Status != comment.StatusPending || archive.Reactions[0].Kind != comment.ReactionUp {
+		t.Errorf("unexpected archive %+v", archive)
+	}
+
+	var resp importResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Comments != 1 || len(resp.Skipped) != 1 || resp.Skipped[0].Reason != app.SkipArticleNotFound {
+		t.Errorf("unexpected report %+v", resp)
+	}
+}
+
+func TestAdminRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		staff          StaffResolver
+		err            error
+		expectedStatus int
+	}{
+		{"import", "<disqus/>", staff, nil, http.StatusOK},
+		{"without session", "<disqus/>", nobody, nil, http.StatusUnauthorized},
+		{"not an export", "id,message", staff, nil, http.StatusUnprocessableEntity},
+		{"too many posts", "<disqus/>", staff, app.ErrImportTooLarge, http.StatusRequestEntityTooLarge},
+		{"timeout", "<disqus/>", staff, context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/comments/imports/disqus", strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakePortability{err: tc.err}, parseLegacy, tc.staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminRouter_Report(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakePortability{}, parseLegacy, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/comments/imports/disqus", strings.NewReader("<disqus/>")))
+
+	var resp legacyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Threads != 1 || resp.Imported != 1 || resp.Matches["email"] != 1 || resp.UnmatchedThreads == nil {
+		t.Errorf("unexpected report %+v", resp)
+	}
+}
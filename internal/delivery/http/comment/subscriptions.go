@@ -0,0 +1,185 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Subscriptions is the part of the subscription service the endpoints need
+type Subscriptions interface {
+	Subscribe(ctx context.Context, accountID, articleID string, commentID *string, frequency comment.Frequency) (*comment.Subscription, error)
+	List(ctx context.Context, accountID string) ([]*comment.Subscription, error)
+	ChangeFrequency(ctx context.Context, accountID, id string, frequency comment.Frequency) (*comment.Subscription, error)
+	Unsubscribe(ctx context.Context, accountID, id string) error
+	UnsubscribeByToken(ctx context.Context, token string) error
+}
+
+type subscribeRequest struct {
+	ArticleID string  `json:"article_id"`
+	CommentID *string `json:"comment_id"`
+	Frequency string  `json:"frequency"`
+}
+
+type frequencyRequest struct {
+	Frequency string `json:"frequency"`
+}
+
+type unsubscribeRequest struct {
+	Token string `json:"token"`
+}
+
+type subscriptionResponse struct {
+	ID         string  `json:"id"`
+	ArticleID  string  `json:"article_id"`
+	CommentID  *string `json:"comment_id"`
+	Frequency  string  `json:"frequency"`
+	LastSentAt *string `json:"last_sent_at,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+type SubscriptionHandler struct {
+	subscriptions Subscriptions
+	member        MemberResolver
+}
+
+func NewSubscriptionHandler(subscriptions Subscriptions, member MemberResolver) *SubscriptionHandler {
+	return &SubscriptionHandler{subscriptions: subscriptions, member: member}
+}
+
+// NewSubscriptionRouter mounts the member's discussion and thread subscriptions
+// and the unsubscribe endpoint behind the link in the reply emails
+func NewSubscriptionRouter(subscriptions Subscriptions, member MemberResolver) http.Handler {
+	h := NewSubscriptionHandler(subscriptions, member)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /me/comment-subscriptions", h.List)
+	mux.HandleFunc("POST /me/comment-subscriptions", h.Subscribe)
+	mux.HandleFunc("PATCH /me/comment-subscriptions/{id}", h.ChangeFrequency)
+	mux.HandleFunc("DELETE /me/comment-subscriptions/{id}", h.Unsubscribe)
+	mux.HandleFunc("POST /comments/subscriptions/unsubscribe", h.UnsubscribeByToken)
+	return mux
+}
+
+func (h *SubscriptionHandler) List(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to manage comment subscriptions"})
+		return
+	}
+	subs, err := h.subscriptions.List(r.Context(), accountID)
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+	resp := make([]subscriptionResponse, 0, len(subs))
+	for _, s := range subs {
+		resp = append(resp, toSubscriptionResponse(s))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Subscribe follows an article's discussion, or the thread under comment_id
+// when it is set. Frequency is instant or daily.
+func (h *SubscriptionHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to manage comment subscriptions"})
+		return
+	}
+	var req subscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	sub, err := h.subscriptions.Subscribe(r.Context(), accountID, req.ArticleID, req.CommentID, comment.Frequency(req.Frequency))
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSubscriptionResponse(sub))
+}
+
+func (h *SubscriptionHandler) ChangeFrequency(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to manage comment subscriptions"})
+		return
+	}
+	var req frequencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	sub, err := h.subscriptions.ChangeFrequency(r.Context(), accountID, r.PathValue("id"), comment.Frequency(req.Frequency))
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSubscriptionResponse(sub))
+}
+
+func (h *SubscriptionHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to manage comment subscriptions"})
+		return
+	}
+	if err := h.subscriptions.Unsubscribe(r.Context(), accountID, r.PathValue("id")); err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnsubscribeByToken is a POST from the landing page the emailed link opens,
+// so mail scanners that prefetch links cannot unsubscribe the reader
+func (h *SubscriptionHandler) UnsubscribeByToken(w http.ResponseWriter, r *http.Request) {
+	var req unsubscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	if err := h.subscriptions.UnsubscribeByToken(r.Context(), req.Token); err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toSubscriptionResponse(s *comment.Subscription) subscriptionResponse {
+	resp := subscriptionResponse{
+		ID:        s.ID,
+		ArticleID: s.ArticleID,
+		CommentID: s.CommentID,
+		Frequency: string(s.Frequency),
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+	}
+	if s.LastSentAt != nil {
+		sent := s.LastSentAt.Format(time.RFC3339)
+		resp.LastSentAt = &sent
+	}
+	return resp
+}
+
+func writeSubscriptionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrSubscriptionNotFound), errors.Is(err, app.ErrArticleNotFound), errors.Is(err, app.ErrCommentNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, comment.ErrTooManySubscriptions), errors.Is(err, comment.ErrNotSubscribable):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, comment.ErrInvalidFrequency), errors.Is(err, app.ErrParentMismatch):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
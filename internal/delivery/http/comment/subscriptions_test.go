@@ -0,0 +1,107 @@
+package comment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+)
+
+type fakeSubscriptions struct {
+	err error
+}
+
+func (f *fakeSubscriptions) subscription(articleID string, commentID *string, frequency comment.Frequency) (*comment.Subscription, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	return &comment.Subscription{ID: "s1", AccountID: "u1", ArticleID: articleID, CommentID: commentID, Frequency: frequency, CreatedAt: at}, nil
+}
+
+func (f *fakeSubscriptions) Subscribe(ctx context.Context, accountID, articleID string, commentID *string, frequency comment.Frequency) (*comment.Subscription, error) {
+	return f.subscription(articleID, commentID, frequency)
+}
+
+func (f *fakeSubscriptions) List(ctx context.Context, accountID string) ([]*comment.Subscription, error) {
+	s, err := f.subscription("a1", nil, comment.FrequencyDaily)
+	if err != nil {
+		return nil, err
+	}
+	return []*comment.Subscription{s}, nil
+}
+
+func (f *fakeSubscriptions) ChangeFrequency(ctx context.Context, accountID, id string, frequency comment.Frequency) (*comment.Subscription, error) {
+	return f.subscription("a1", nil, frequency)
+}
+
+func (f *fakeSubscriptions) Unsubscribe(ctx context.Context, accountID, id string) error {
+	return f.err
+}
+
+func (f *fakeSubscriptions) UnsubscribeByToken(ctx context.Context, token string) error {
+	return f.err
+}
+
+func TestSubscriptionRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		member         MemberResolver
+		err            error
+		expectedStatus int
+	}{
+		{"list", http.MethodGet, "/me/comment-subscriptions", "", member, nil, http.StatusOK},
+		{"list signed out", http.MethodGet, "/me/comment-subscriptions", "", nobody, nil, http.StatusUnauthorized},
+		{"subscribe", http.MethodPost, "/me/comment-subscriptions", `{"article_id": "a1", "comment_id": "c1", "frequency": "instant"}`, member, nil, http.StatusCreated},
+		{"subscribe signed out", http.MethodPost, "/me/comment-subscriptions", `{"article_id": "a1"}`, nobody, nil, http.StatusUnauthorized},
+		{"subscribe invalid body", http.MethodPost, "/me/comment-subscriptions", `{"article_id":`, member, nil, http.StatusBadRequest},
+		{"subscribe unknown article", http.MethodPost, "/me/comment-subscriptions", `{"article_id": "a9"}`, member, app.ErrArticleNotFound, http.StatusNotFound},
+		{"subscribe pending comment", http.MethodPost, "/me/comment-subscriptions", `{"article_id": "a1", "comment_id": "c2"}`, member, comment.ErrNotSubscribable, http.StatusConflict},
+		{"subscribe over the cap", http.MethodPost, "/me/comment-subscriptions", `{"article_id": "a1"}`, member, comment.ErrTooManySubscriptions, http.StatusConflict},
+		{"subscribe unknown frequency", http.MethodPost, "/me/comment-subscriptions", `{"article_id": "a1", "frequency": "weekly"}`, member, comment.ErrInvalidFrequency, http.StatusUnprocessableEntity},
+		{"change frequency", http.MethodPatch, "/me/comment-subscriptions/s1", `{"frequency": "daily"}`, member, nil, http.StatusOK},
+		{"change frequency missing", http.MethodPatch, "/me/comment-subscriptions/s9", `{"frequency": "daily"}`, member, app.ErrSubscriptionNotFound, http.StatusNotFound},
+		{"unsubscribe", http.MethodDelete, "/me/comment-subscriptions/s1", "", member, nil, http.StatusNoContent},
+		{"unsubscribe timeout", http.MethodDelete, "/me/comment-subscriptions/s1", "", member, context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"unsubscribe by token", http.MethodPost, "/comments/subscriptions/unsubscribe", `{"token": "t1"}`, nobody, nil, http.StatusNoContent},
+		{"unsubscribe used token", http.MethodPost, "/comments/subscriptions/unsubscribe", `{"token": "t1"}`, nobody, app.ErrSubscriptionNotFound, http.StatusNotFound},
+		{"unsubscribe store failure", http.MethodPost, "/comments/subscriptions/unsubscribe", `{"token": "t1"}`, nobody, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewSubscriptionRouter(&fakeSubscriptions{err: tc.err}, tc.member)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSubscriptionRouter_Response(t *testing.T) {
+	rec := httptest.NewRecorder()
+	body := strings.NewReader(`{"article_id": "a1", "comment_id": "c1", "frequency": "instant"}`)
+	openapitest.Handler(t, RegisterSpec, NewSubscriptionRouter(&fakeSubscriptions{}, member)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/me/comment-subscriptions", body))
+
+	var resp subscriptionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ArticleID != "a1" || resp.CommentID == nil || *resp.CommentID != "c1" || resp.Frequency != "instant" || resp.CreatedAt != "2026-03-10T09:00:00Z" {
+		t.Errorf("unexpected subscription %+v", resp)
+	}
+}
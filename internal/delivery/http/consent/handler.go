@@ -0,0 +1,141 @@
+package consent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/middleware"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+// VisitorCookie carries the anonymous visitor ID set by the consent banner
+const VisitorCookie = "visitor_id"
+
+// SubjectResolver identifies who is asking, the signed-in account or the anonymous visitor
+type SubjectResolver func(r *http.Request) (*consent.Subject, error)
+
+// Store is the part of the consent service the handler needs
+type Store interface {
+	Get(ctx context.Context, subject consent.Subject) (*consent.Record, error)
+	Update(ctx context.Context, subject consent.Subject, choices map[consent.Purpose]bool, source consent.Source, ipAddress string) (*consent.Record, error)
+}
+
+type consentResponse struct {
+	Subject  string          `json:"subject"`
+	Decided  bool            `json:"decided"`
+	Purposes map[string]bool `json:"purposes"`
+}
+
+type updateRequest struct {
+	Source   string          `json:"source"`
+	Purposes map[string]bool `json:"purposes"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	store   Store
+	subject SubjectResolver
+}
+
+func NewHandler(store Store, subject SubjectResolver) *Handler {
+	return &Handler{store: store, subject: subject}
+}
+
+// NewRouter mounts GET and PUT /consent
+func NewRouter(store Store, subject SubjectResolver) http.Handler {
+	h := NewHandler(store, subject)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /consent", h.Get)
+	mux.HandleFunc("PUT /consent", h.Update)
+	return mux
+}
+
+// VisitorSubject resolves the subject from the visitor cookie, for routes without sign-in
+func VisitorSubject(r *http.Request) (*consent.Subject, error) {
+	cookie, err := r.Cookie(VisitorCookie)
+	if err != nil {
+		return nil, errors.New("missing visitor ID")
+	}
+	return consent.NewVisitorSubject(cookie.Value)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	subject, err := h.subject(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	record, err := h.store.Get(r.Context(), *subject)
+	if err != nil {
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to load consent"})
+		return
+	}
+	writeJSON(w, http.StatusOK, toResponse(record))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	subject, err := h.subject(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	var req updateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	choices := make(map[consent.Purpose]bool, len(req.Purposes))
+	for purpose, granted := range req.Purposes {
+		choices[consent.Purpose(purpose)] = granted
+	}
+	source := consent.Source(req.Source)
+	if source == "" {
+		source = consent.SourceAPI
+	}
+
+	record, err := h.store.Update(r.Context(), *subject, choices, source, middleware.ClientIP(r))
+	if err != nil {
+		if errors.Is(err, consent.ErrInvalidPurpose) || errors.Is(err, consent.ErrInvalidSource) || errors.Is(err, consent.ErrNoChoices) {
+			writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+			return
+		}
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to update consent"})
+		return
+	}
+	writeJSON(w, http.StatusOK, toResponse(record))
+}
+
+func toResponse(record *consent.Record) consentResponse {
+	resp := consentResponse{
+		Subject:  record.Subject.String(),
+		Decided:  record.IsDecided(),
+		Purposes: make(map[string]bool, len(consent.AllPurposes)),
+	}
+	for _, purpose := range consent.AllPurposes {
+		resp.Purposes[string(purpose)] = record.Allows(purpose)
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,95 @@
+package consent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+type fakeStore struct {
+	records map[string]*consent.Record
+}
+
+func (f *fakeStore) Get(ctx context.Context, subject consent.Subject) (*consent.Record, error) {
+	if r, ok := f.records[subject.String()]; ok {
+		return r, nil
+	}
+	return consent.NewRecord(subject), nil
+}
+
+func (f *fakeStore) Update(ctx context.Context, subject consent.Subject, choices map[consent.Purpose]bool, source consent.Source, ipAddress string) (*consent.Record, error) {
+	record, _ := f.Get(ctx, subject)
+	if _, err := record.Apply(choices, source, ipAddress); err != nil {
+		return nil, err
+	}
+	f.records[subject.String()] = record
+	return record, nil
+}
+
+const testVisitorID = "5f0c2b6e-9a47-4c1b-8e0d-3a1f6b2c7d90"
+
+func TestHandler_GetAndUpdate(t *testing.T) {
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(&fakeStore{records: map[string]*consent.Record{}}, VisitorSubject))
+
+	req := httptest.NewRequest(http.MethodPut, "/consent", strings.NewReader(`{"source":"banner","purposes":{"analytics":true}}`))
+	req.AddCookie(&http.Cookie{Name: VisitorCookie, Value: testVisitorID})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/consent", nil)
+	req.AddCookie(&http.Cookie{Name: VisitorCookie, Value: testVisitorID})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	var resp consentResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Decided || !resp.Purposes["analytics"] || resp.Purposes["marketing_email"] {
+		t.Errorf("unexpected consent response %+v", resp)
+	}
+	if len(resp.Purposes) != len(consent.AllPurposes) {
+		t.Errorf("expected every purpose in response, got %d", len(resp.Purposes))
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(&fakeStore{records: map[string]*consent.Record{}}, VisitorSubject))
+
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		cookie   string
+		expected int
+	}{
+		{name: "missing visitor cookie", method: http.MethodGet, expected: http.StatusBadRequest},
+		{name: "invalid visitor id", method: http.MethodGet, cookie: "abc", expected: http.StatusBadRequest},
+		{name: "malformed body", method: http.MethodPut, body: "{", cookie: testVisitorID, expected: http.StatusBadRequest},
+		{name: "unknown purpose", method: http.MethodPut, body: `{"purposes":{"fingerprinting":true}}`, cookie: testVisitorID, expected: http.StatusUnprocessableEntity},
+		{name: "no purposes", method: http.MethodPut, body: `{"purposes":{}}`, cookie: testVisitorID, expected: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/consent", strings.NewReader(tt.body))
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: VisitorCookie, Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
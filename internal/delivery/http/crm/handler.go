@@ -0,0 +1,166 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/crm"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Connector is the part of the CRM service the admin endpoints need, syncing
+// itself only runs as the scheduled job
+type Connector interface {
+	Mapping() *crm.Mapping
+	Reconcile(ctx context.Context) (*crm.Report, error)
+	Reports(ctx context.Context, limit int) ([]*crm.Report, error)
+}
+
+type mappingResponse struct {
+	Field    string `json:"field"`
+	Property string `json:"property"`
+}
+
+type driftResponse struct {
+	Property string `json:"property"`
+	CMS      string `json:"cms"`
+	CRM      string `json:"crm"`
+}
+
+type accountDriftResponse struct {
+	AccountID string          `json:"account_id"`
+	Drifts    []driftResponse `json:"drifts"`
+}
+
+type reportResponse struct {
+	ID           string                 `json:"id"`
+	Provider     string                 `json:"provider"`
+	Checked      int                    `json:"checked"`
+	InSync       int                    `json:"in_sync"`
+	Drifted      []accountDriftResponse `json:"drifted"`
+	MissingInCRM []string               `json:"missing_in_crm"`
+	UnknownInCRM []string               `json:"unknown_in_crm"`
+	Queued       int                    `json:"queued"`
+	Error        *string                `json:"error,omitempty"`
+	StartedAt    string                 `json:"started_at"`
+	FinishedAt   string                 `json:"finished_at"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	connector Connector
+}
+
+func NewHandler(connector Connector) *Handler {
+	return &Handler{connector: connector}
+}
+
+// NewAdminRouter mounts the CRM field mapping and reconciliation reports, it must sit behind admin authentication
+func NewAdminRouter(connector Connector) http.Handler {
+	h := NewHandler(connector)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/crm/mapping", h.Mapping)
+	mux.HandleFunc("GET /admin/crm/reconciliations", h.Reports)
+	mux.HandleFunc("POST /admin/crm/reconciliations", h.Reconcile)
+	return mux
+}
+
+func (h *Handler) Mapping(w http.ResponseWriter, r *http.Request) {
+	mapping := h.connector.Mapping()
+	resp := make([]mappingResponse, 0, len(mapping.Fields()))
+	for _, f := range mapping.Fields() {
+		property, _ := mapping.Property(f)
+		resp = append(resp, mappingResponse{Field: string(f), Property: property})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Reports(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit"})
+			return
+		}
+		limit = n
+	}
+	reports, err := h.connector.Reports(r.Context(), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]reportResponse, 0, len(reports))
+	for _, report := range reports {
+		resp = append(resp, toReportResponse(report))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Reconcile runs a reconciliation now instead of waiting for the nightly job
+func (h *Handler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	report, err := h.connector.Reconcile(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toReportResponse(report))
+}
+
+func toReportResponse(r *crm.Report) reportResponse {
+	resp := reportResponse{
+		ID:           r.ID,
+		Provider:     r.Provider,
+		Checked:      r.Checked,
+		InSync:       r.InSync,
+		Drifted:      make([]accountDriftResponse, 0, len(r.Drifted)),
+		MissingInCRM: append([]string{}, r.MissingInCRM...),
+		UnknownInCRM: append([]string{}, r.UnknownInCRM...),
+		Queued:       r.Queued,
+		Error:        r.Error,
+		StartedAt:    r.StartedAt.Format(time.RFC3339),
+		FinishedAt:   r.FinishedAt.Format(time.RFC3339),
+	}
+	for _, d := range r.Drifted {
+		item := accountDriftResponse{AccountID: d.AccountID, Drifts: make([]driftResponse, 0, len(d.Drifts))}
+		for _, drift := range d.Drifts {
+			item.Drifts = append(item.Drifts, driftResponse{Property: drift.Property, CMS: drift.CMS, CRM: drift.CRM})
+		}
+		resp.Drifted = append(resp.Drifted, item)
+	}
+	return resp
+}
+
+// writeError maps domain errors by kind. The CRM's rate limit is a 503: the
+// provider is unavailable, the caller did not ask too often.
+func writeError(w http.ResponseWriter, err error) {
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	switch de.Kind {
+	case shared.KindRateLimited:
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "CRM rate limit reached, try again later", Code: de.Code})
+	default:
+		// Anything else is a failure on our or the CRM's side, not the caller's to fix
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,107 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/crm"
+)
+
+type fakeConnector struct {
+	err   error
+	limit int
+}
+
+func (f *fakeConnector) Mapping() *crm.Mapping {
+	return crm.DefaultHubSpotMapping()
+}
+
+func (f *fakeConnector) report() *crm.Report {
+	at := time.Date(2026, time.March, 10, 3, 0, 0, 0, time.UTC)
+	r, _ := crm.NewReport("r1", "hubspot", at)
+	r.Checked, r.InSync, r.Queued = 3, 1, 2
+	r.Drifted = []crm.AccountDrift{{AccountID: "a", Drifts: []crm.Drift{{Property: "cms_plan", CMS: "premium", CRM: "basic"}}}}
+	r.MissingInCRM = []string{"b"}
+	r.Finish(at.Add(time.Minute))
+	return r
+}
+
+func (f *fakeConnector) Reconcile(ctx context.Context) (*crm.Report, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.report(), nil
+}
+
+func (f *fakeConnector) Reports(ctx context.Context, limit int) ([]*crm.Report, error) {
+	f.limit = limit
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*crm.Report{f.report()}, nil
+}
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		err            error
+		expectedStatus int
+	}{
+		{"mapping", http.MethodGet, "/admin/crm/mapping", nil, http.StatusOK},
+		{"reports", http.MethodGet, "/admin/crm/reconciliations?limit=5", nil, http.StatusOK},
+		{"reports invalid limit", http.MethodGet, "/admin/crm/reconciliations?limit=-1", nil, http.StatusBadRequest},
+		{"reconcile", http.MethodPost, "/admin/crm/reconciliations", nil, http.StatusCreated},
+		{"rate limited", http.MethodPost, "/admin/crm/reconciliations", &crm.RateLimitError{RetryAfter: time.Hour}, http.StatusServiceUnavailable},
+		{"store failure", http.MethodGet, "/admin/crm/reconciliations", errors.New("connection reset"), http.StatusInternalServerError},
+		{"timeout", http.MethodPost, "/admin/crm/reconciliations", context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, nil)
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeConnector{err: tc.err})).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_Reconcile(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeConnector{})).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/crm/reconciliations", nil))
+
+	var resp reportResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Checked != 3 || resp.Queued != 2 || len(resp.Drifted) != 1 || resp.Drifted[0].Drifts[0].CRM != "basic" {
+		t.Errorf("unexpected report %+v", resp)
+	}
+	if len(resp.UnknownInCRM) != 0 || resp.UnknownInCRM == nil || resp.FinishedAt != "2026-03-10T03:01:00Z" {
+		t.Errorf("expected empty lists and the finish time, got %+v", resp)
+	}
+}
+
+func TestHandler_Mapping(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeConnector{})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/crm/mapping", nil))
+
+	var resp []mappingResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp) != len(crm.Fields) || resp[0].Field != string(crm.FieldEmail) || resp[0].Property != "email" {
+		t.Errorf("unexpected mapping %+v", resp)
+	}
+}
@@ -0,0 +1,351 @@
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/election"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/election"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Elections is the part of the election service the endpoints need
+type Elections interface {
+	CreateRace(ctx context.Context, editorID, name, source, feedRef string, candidates []app.CandidateInput, at time.Time) (*election.Race, error)
+	CloseRace(ctx context.Context, editorID, raceID string, at time.Time) (*election.Race, error)
+	Races(ctx context.Context) ([]*election.Race, error)
+	Ingest(ctx context.Context, raceID string, at time.Time) (*app.IngestResult, error)
+	Results(ctx context.Context, raceID string, since time.Time) (*election.Results, error)
+	Override(ctx context.Context, editorID, raceID, region string, votes election.Counts, reason string, at time.Time) (*election.Tally, error)
+	ClearOverride(ctx context.Context, editorID, raceID, region, reason string, at time.Time) (*election.Tally, error)
+	History(ctx context.Context, raceID, region string) ([]*election.Revision, error)
+}
+
+type candidateRequest struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Party string `json:"party,omitempty"`
+}
+
+type raceRequest struct {
+	Name       string             `json:"name"`
+	Source     string             `json:"source"`
+	FeedRef    string             `json:"feed_ref"`
+	Candidates []candidateRequest `json:"candidates"`
+}
+
+type overrideRequest struct {
+	Votes  map[string]int64 `json:"votes"`
+	Reason string           `json:"reason"`
+}
+
+type raceResponse struct {
+	ID         string             `json:"id"`
+	Name       string             `json:"name"`
+	Source     string             `json:"source"`
+	FeedRef    string             `json:"feed_ref"`
+	Candidates []candidateRequest `json:"candidates"`
+	Closed     bool               `json:"closed"`
+	UpdatedAt  string             `json:"updated_at"`
+}
+
+type regionResponse struct {
+	Region           string           `json:"region"`
+	Votes            map[string]int64 `json:"votes"`
+	StationsReported int              `json:"stations_reported"`
+	StationsTotal    int              `json:"stations_total"`
+	Overridden       bool             `json:"overridden"`
+	Version          int64            `json:"version"`
+	UpdatedAt        string           `json:"updated_at"`
+}
+
+type resultsResponse struct {
+	RaceID           string             `json:"race_id"`
+	Name             string             `json:"name"`
+	Final            bool               `json:"final"`
+	Candidates       []candidateRequest `json:"candidates"`
+	Totals           map[string]int64   `json:"totals"`
+	StationsReported int                `json:"stations_reported"`
+	StationsTotal    int                `json:"stations_total"`
+	Regions          []regionResponse   `json:"regions"`
+	UpdatedAt        *string            `json:"updated_at,omitempty"`
+}
+
+type ingestResponse struct {
+	Changed  int    `json:"changed"`
+	Rejected string `json:"rejected,omitempty"`
+}
+
+type revisionResponse struct {
+	Version          int64            `json:"version"`
+	Origin           string           `json:"origin"`
+	Votes            map[string]int64 `json:"votes"`
+	StationsReported int              `json:"stations_reported"`
+	StationsTotal    int              `json:"stations_total"`
+	EditorID         *string          `json:"editor_id,omitempty"`
+	Reason           string           `json:"reason,omitempty"`
+	CreatedAt        string           `json:"created_at"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	elections Elections
+	staff     StaffResolver
+}
+
+func NewHandler(elections Elections, staff StaffResolver) *Handler {
+	return &Handler{elections: elections, staff: staff}
+}
+
+// NewRouter mounts the live results API used by election coverage pages
+func NewRouter(elections Elections) http.Handler {
+	h := NewHandler(elections, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /elections/races/{id}/results", h.Results)
+	return mux
+}
+
+// NewAdminRouter mounts races, feed ingestion and overrides, it must sit behind admin authentication
+func NewAdminRouter(elections Elections, staff StaffResolver) http.Handler {
+	h := NewHandler(elections, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/elections/races", h.Races)
+	mux.HandleFunc("POST /admin/elections/races", h.CreateRace)
+	mux.HandleFunc("POST /admin/elections/races/{id}/close", h.CloseRace)
+	mux.HandleFunc("POST /admin/elections/races/{id}/ingest", h.Ingest)
+	mux.HandleFunc("PUT /admin/elections/races/{id}/regions/{region}/override", h.Override)
+	mux.HandleFunc("DELETE /admin/elections/races/{id}/regions/{region}/override", h.ClearOverride)
+	mux.HandleFunc("GET /admin/elections/races/{id}/regions/{region}/history", h.History)
+	return mux
+}
+
+// Results handles GET /elections/races/{id}/results?since=2029-02-14T15:00:00Z.
+// Pollers pass the previous updated_at as since to get only changed regions.
+func (h *Handler) Results(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, raw); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "since must be an RFC 3339 timestamp"})
+			return
+		}
+	}
+
+	results, err := h.elections.Results(r.Context(), r.PathValue("id"), since)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := resultsResponse{
+		RaceID:           results.Race.ID,
+		Name:             results.Race.Name,
+		Final:            results.Race.Closed,
+		Candidates:       toCandidates(results.Race.Candidates),
+		Totals:           results.Totals,
+		StationsReported: results.StationsReported,
+		StationsTotal:    results.StationsTotal,
+		Regions:          make([]regionResponse, 0, len(results.Regions)),
+	}
+	for _, t := range results.Regions {
+		resp.Regions = append(resp.Regions, toRegionResponse(t))
+	}
+	if !results.UpdatedAt.IsZero() {
+		at := results.UpdatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = &at
+		w.Header().Set("Last-Modified", results.UpdatedAt.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Cache-Control", "public, max-age=15")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Races(w http.ResponseWriter, r *http.Request) {
+	races, err := h.elections.Races(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]raceResponse, 0, len(races))
+	for _, race := range races {
+		resp = append(resp, toRaceResponse(race))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) CreateRace(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req raceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	candidates := make([]app.CandidateInput, 0, len(req.Candidates))
+	for _, c := range req.Candidates {
+		candidates = append(candidates, app.CandidateInput{ID: c.ID, Name: c.Name, Party: c.Party})
+	}
+
+	race, err := h.elections.CreateRace(r.Context(), staffID, req.Name, req.Source, req.FeedRef, candidates, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toRaceResponse(race))
+}
+
+func (h *Handler) CloseRace(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	race, err := h.elections.CloseRace(r.Context(), staffID, r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toRaceResponse(race))
+}
+
+// Ingest polls the feed right away instead of waiting for the scheduler
+func (h *Handler) Ingest(w http.ResponseWriter, r *http.Request) {
+	result, err := h.elections.Ingest(r.Context(), r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := ingestResponse{Changed: result.Changed}
+	if result.Rejected != nil {
+		resp.Rejected = result.Rejected.Error()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Override(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req overrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	tally, err := h.elections.Override(r.Context(), staffID, r.PathValue("id"), r.PathValue("region"), election.Counts(req.Votes), req.Reason, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toRegionResponse(tally))
+}
+
+// ClearOverride takes the reason in the body, e.g. {"reason":"feed corrected"}
+func (h *Handler) ClearOverride(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req overrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	tally, err := h.elections.ClearOverride(r.Context(), staffID, r.PathValue("id"), r.PathValue("region"), req.Reason, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toRegionResponse(tally))
+}
+
+func (h *Handler) History(w http.ResponseWriter, r *http.Request) {
+	revisions, err := h.elections.History(r.Context(), r.PathValue("id"), r.PathValue("region"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]revisionResponse, 0, len(revisions))
+	for _, rev := range revisions {
+		resp = append(resp, revisionResponse{
+			Version:          rev.Version,
+			Origin:           string(rev.Origin),
+			Votes:            rev.Votes,
+			StationsReported: rev.StationsReported,
+			StationsTotal:    rev.StationsTotal,
+			EditorID:         rev.EditorID,
+			Reason:           rev.Reason,
+			CreatedAt:        rev.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrRaceNotFound), errors.Is(err, app.ErrRegionNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, election.ErrRaceClosed), errors.Is(err, election.ErrNoOverride):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrUnknownSource), errors.Is(err, election.ErrInvalidRaceName), errors.Is(err, election.ErrInvalidCandidates),
+		errors.Is(err, election.ErrInvalidCandidate), errors.Is(err, election.ErrDuplicateCandidate), errors.Is(err, election.ErrUnknownCandidate),
+		errors.Is(err, election.ErrNegativeVotes), errors.Is(err, election.ErrInvalidRegion), errors.Is(err, election.ErrOverrideReasonRequired):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toCandidates(candidates []election.Candidate) []candidateRequest {
+	resp := make([]candidateRequest, 0, len(candidates))
+	for _, c := range candidates {
+		resp = append(resp, candidateRequest{ID: c.ID, Name: c.Name, Party: c.Party})
+	}
+	return resp
+}
+
+func toRaceResponse(race *election.Race) raceResponse {
+	return raceResponse{
+		ID:         race.ID,
+		Name:       race.Name,
+		Source:     race.Source,
+		FeedRef:    race.FeedRef,
+		Candidates: toCandidates(race.Candidates),
+		Closed:     race.Closed,
+		UpdatedAt:  race.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toRegionResponse(t *election.Tally) regionResponse {
+	return regionResponse{
+		Region:           t.Region,
+		Votes:            t.Votes(),
+		StationsReported: t.Feed.StationsReported,
+		StationsTotal:    t.Feed.StationsTotal,
+		Overridden:       t.Overridden(),
+		Version:          t.Version,
+		UpdatedAt:        t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,142 @@
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/election"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/election"
+)
+
+type fakeElections struct {
+	since time.Time
+	votes election.Counts
+	err   error
+}
+
+func testRace() *election.Race {
+	race, _ := election.NewRace("race-1", "Pilkada DKI", "json", "dki-2029", []election.Candidate{{ID: "01", Name: "Calon A"}, {ID: "02", Name: "Calon B"}}, "editor-1", time.Now())
+	return race
+}
+
+func (f *fakeElections) CreateRace(ctx context.Context, editorID, name, source, feedRef string, candidates []app.CandidateInput, at time.Time) (*election.Race, error) {
+	return testRace(), f.err
+}
+
+func (f *fakeElections) CloseRace(ctx context.Context, editorID, raceID string, at time.Time) (*election.Race, error) {
+	return testRace(), f.err
+}
+
+func (f *fakeElections) Races(ctx context.Context) ([]*election.Race, error) {
+	return []*election.Race{testRace()}, f.err
+}
+
+func (f *fakeElections) Ingest(ctx context.Context, raceID string, at time.Time) (*app.IngestResult, error) {
+	return &app.IngestResult{Changed: 2}, f.err
+}
+
+func (f *fakeElections) Results(ctx context.Context, raceID string, since time.Time) (*election.Results, error) {
+	f.since = since
+	if f.err != nil {
+		return nil, f.err
+	}
+	tally := election.NewTally(raceID, "jaksel")
+	tally.ApplyFeed(election.Report{Votes: election.Counts{"01": 10, "02": 7}, StationsReported: 3, StationsTotal: 5}, time.Date(2029, 2, 14, 8, 0, 0, 0, time.UTC))
+	return election.Summarize(testRace(), []*election.Tally{tally}, since), nil
+}
+
+func (f *fakeElections) Override(ctx context.Context, editorID, raceID, region string, votes election.Counts, reason string, at time.Time) (*election.Tally, error) {
+	f.votes = votes
+	if f.err != nil {
+		return nil, f.err
+	}
+	tally := election.NewTally(raceID, region)
+	_, err := tally.SetOverride(votes, editorID, reason, at)
+	return tally, err
+}
+
+func (f *fakeElections) ClearOverride(ctx context.Context, editorID, raceID, region, reason string, at time.Time) (*election.Tally, error) {
+	return nil, f.err
+}
+
+func (f *fakeElections) History(ctx context.Context, raceID, region string) ([]*election.Revision, error) {
+	return nil, f.err
+}
+
+func TestHandler_Results(t *testing.T) {
+	elections := &fakeElections{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(elections)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/elections/races/race-1/results?since=2029-02-14T07:00:00Z", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !elections.since.Equal(time.Date(2029, 2, 14, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected since passed through, got %v", elections.since)
+	}
+	if rec.Header().Get("Last-Modified") != "Wed, 14 Feb 2029 08:00:00 GMT" {
+		t.Errorf("expected Last-Modified from the latest change, got %q", rec.Header().Get("Last-Modified"))
+	}
+	var resp resultsResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Totals["01"] != 10 || len(resp.Regions) != 1 || resp.Regions[0].Version != 1 || resp.UpdatedAt == nil {
+		t.Errorf("unexpected results %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(elections)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/elections/races/race-1/results?since=yesterday", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Admin(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "editor-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"create race", http.MethodPost, "/admin/elections/races", `{"name":"Pilkada DKI","source":"json","candidates":[{"id":"01","name":"A"}]}`, true, nil, http.StatusCreated},
+		{"create with unknown source", http.MethodPost, "/admin/elections/races", `{"name":"x","source":"rss"}`, true, app.ErrUnknownSource, http.StatusUnprocessableEntity},
+		{"create without session", http.MethodPost, "/admin/elections/races", `{}`, false, nil, http.StatusUnauthorized},
+		{"ingest", http.MethodPost, "/admin/elections/races/race-1/ingest", ``, true, nil, http.StatusOK},
+		{"ingest closed race", http.MethodPost, "/admin/elections/races/race-1/ingest", ``, true, election.ErrRaceClosed, http.StatusConflict},
+		{"override", http.MethodPut, "/admin/elections/races/race-1/regions/jaksel/override", `{"votes":{"01":12},"reason":"Koreksi C1"}`, true, nil, http.StatusOK},
+		{"override without reason", http.MethodPut, "/admin/elections/races/race-1/regions/jaksel/override", `{"votes":{"01":12}}`, true, nil, http.StatusUnprocessableEntity},
+		{"clear missing override", http.MethodDelete, "/admin/elections/races/race-1/regions/jaksel/override", `{"reason":"x"}`, true, election.ErrNoOverride, http.StatusConflict},
+		{"history of missing race", http.MethodGet, "/admin/elections/races/zz/regions/jaksel/history", ``, true, app.ErrRaceNotFound, http.StatusNotFound},
+		{"list failure", http.MethodGet, "/admin/elections/races", ``, true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elections := &fakeElections{err: tc.err}
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(elections, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.name == "override" && elections.votes["01"] != 12 {
+				t.Errorf("expected votes passed through, got %v", elections.votes)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package emailtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Templates is the part of the template service the endpoints need
+type Templates interface {
+	CreateTemplate(ctx context.Context, staffID, key, description string, sampleData map[string]any) (*emailtemplate.Template, error)
+	UpdateSampleData(ctx context.Context, key string, sampleData map[string]any) (*emailtemplate.Template, error)
+	List(ctx context.Context) ([]*emailtemplate.Template, error)
+	Versions(ctx context.Context, key string) (*emailtemplate.Template, []*emailtemplate.Version, error)
+	Draft(ctx context.Context, staffID, key string, content emailtemplate.Content, note string) (*emailtemplate.Version, error)
+	Activate(ctx context.Context, staffID, key string, number int) (*emailtemplate.Template, error)
+	Rollback(ctx context.Context, staffID, key string) (*emailtemplate.Template, error)
+	Preview(ctx context.Context, key string, number *int, data map[string]any) (*emailtemplate.Rendered, error)
+	SendTest(ctx context.Context, staffID, key string, number *int, data map[string]any) error
+}
+
+type createTemplateRequest struct {
+	Key         string         `json:"key"`
+	Description string         `json:"description"`
+	SampleData  map[string]any `json:"sample_data"`
+}
+
+type sampleDataRequest struct {
+	SampleData map[string]any `json:"sample_data"`
+}
+
+type draftRequest struct {
+	Subject  string `json:"subject"`
+	HTMLBody string `json:"html_body"`
+	TextBody string `json:"text_body"`
+	Note     string `json:"note"`
+}
+
+// renderRequest picks a version, the live one when omitted, and data overriding the sample data
+type renderRequest struct {
+	Version *int           `json:"version"`
+	Data    map[string]any `json:"data"`
+}
+
+type templateResponse struct {
+	Key             string         `json:"key"`
+	Description     string         `json:"description"`
+	SampleData      map[string]any `json:"sample_data"`
+	LatestVersion   int            `json:"latest_version"`
+	ActiveVersion   *int           `json:"active_version,omitempty"`
+	PreviousVersion *int           `json:"previous_version,omitempty"`
+	ActivatedBy     *string        `json:"activated_by,omitempty"`
+	ActivatedAt     *string        `json:"activated_at,omitempty"`
+}
+
+type versionResponse struct {
+	Number    int    `json:"number"`
+	Subject   string `json:"subject"`
+	HTMLBody  string `json:"html_body"`
+	TextBody  string `json:"text_body,omitempty"`
+	Note      string `json:"note,omitempty"`
+	AuthorID  string `json:"author_id"`
+	CreatedAt string `json:"created_at"`
+	IsActive  bool   `json:"is_active"`
+}
+
+type versionListResponse struct {
+	Template templateResponse  `json:"template"`
+	Versions []versionResponse `json:"versions"`
+}
+
+type renderedResponse struct {
+	Subject  string `json:"subject"`
+	HTMLBody string `json:"html_body"`
+	TextBody string `json:"text_body"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	templates Templates
+	staff     StaffResolver
+}
+
+func NewHandler(templates Templates, staff StaffResolver) *Handler {
+	return &Handler{templates: templates, staff: staff}
+}
+
+// NewAdminRouter mounts template management, preview and test sends, it must sit behind admin authentication
+func NewAdminRouter(templates Templates, staff StaffResolver) http.Handler {
+	h := NewHandler(templates, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/email-templates", h.List)
+	mux.HandleFunc("POST /admin/email-templates", h.Create)
+	mux.HandleFunc("PUT /admin/email-templates/{key}/sample-data", h.UpdateSampleData)
+	mux.HandleFunc("GET /admin/email-templates/{key}/versions", h.Versions)
+	mux.HandleFunc("POST /admin/email-templates/{key}/versions", h.Draft)
+	mux.HandleFunc("POST /admin/email-templates/{key}/versions/{number}/activate", h.Activate)
+	mux.HandleFunc("POST /admin/email-templates/{key}/rollback", h.Rollback)
+	mux.HandleFunc("POST /admin/email-templates/{key}/preview", h.Preview)
+	mux.HandleFunc("POST /admin/email-templates/{key}/test-send", h.SendTest)
+	return mux
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templates.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]templateResponse, 0, len(templates))
+	for _, tmpl := range templates {
+		resp = append(resp, toTemplateResponse(tmpl))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req createTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	tmpl, err := h.templates.CreateTemplate(r.Context(), staffID, req.Key, req.Description, req.SampleData)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toTemplateResponse(tmpl))
+}
+
+func (h *Handler) UpdateSampleData(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.staff(r); !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req sampleDataRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	tmpl, err := h.templates.UpdateSampleData(r.Context(), r.PathValue("key"), req.SampleData)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTemplateResponse(tmpl))
+}
+
+func (h *Handler) Versions(w http.ResponseWriter, r *http.Request) {
+	tmpl, versions, err := h.templates.Versions(r.Context(), r.PathValue("key"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := versionListResponse{Template: toTemplateResponse(tmpl), Versions: make([]versionResponse, 0, len(versions))}
+	for _, v := range versions {
+		resp.Versions = append(resp.Versions, toVersionResponse(tmpl, v))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Draft(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req draftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	content := emailtemplate.Content{Subject: req.Subject, HTMLBody: req.HTMLBody, TextBody: req.TextBody}
+	version, err := h.templates.Draft(r.Context(), staffID, r.PathValue("key"), content, req.Note)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toVersionResponse(nil, version))
+}
+
+func (h *Handler) Activate(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "version must be a number"})
+		return
+	}
+
+	tmpl, err := h.templates.Activate(r.Context(), staffID, r.PathValue("key"), number)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTemplateResponse(tmpl))
+}
+
+func (h *Handler) Rollback(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+
+	tmpl, err := h.templates.Rollback(r.Context(), staffID, r.PathValue("key"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTemplateResponse(tmpl))
+}
+
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRenderRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rendered, err := h.templates.Preview(r.Context(), r.PathValue("key"), req.Version, req.Data)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, renderedResponse{Subject: rendered.Subject, HTMLBody: rendered.HTMLBody, TextBody: rendered.TextBody})
+}
+
+// SendTest emails the rendered version to the requesting staff member, never to anyone else
+func (h *Handler) SendTest(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	req, ok := decodeRenderRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.templates.SendTest(r.Context(), staffID, r.PathValue("key"), req.Version, req.Data); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// decodeRenderRequest accepts an empty body as "live version with sample data"
+func decodeRenderRequest(w http.ResponseWriter, r *http.Request) (renderRequest, bool) {
+	var req renderRequest
+	if r.ContentLength == 0 {
+		return req, true
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return req, false
+	}
+	return req, true
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrTemplateNotFound), errors.Is(err, emailtemplate.ErrVersionNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrTemplateExists), errors.Is(err, app.ErrTemplateNotLive),
+		errors.Is(err, emailtemplate.ErrAlreadyActive), errors.Is(err, emailtemplate.ErrNothingToRollback):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrTestSendNotAllowed):
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+	case errors.Is(err, emailtemplate.ErrRenderFailed), errors.Is(err, emailtemplate.ErrInvalidContent), errors.Is(err, emailtemplate.ErrInvalidKey):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toTemplateResponse(tmpl *emailtemplate.Template) templateResponse {
+	resp := templateResponse{
+		Key:             tmpl.Key,
+		Description:     tmpl.Description,
+		SampleData:      tmpl.SampleData,
+		LatestVersion:   tmpl.LatestVersion,
+		ActiveVersion:   tmpl.ActiveVersion,
+		PreviousVersion: tmpl.PreviousVersion,
+		ActivatedBy:     tmpl.ActivatedBy,
+	}
+	if tmpl.ActivatedAt != nil {
+		at := tmpl.ActivatedAt.Format(time.RFC3339)
+		resp.ActivatedAt = &at
+	}
+	return resp
+}
+
+func toVersionResponse(tmpl *emailtemplate.Template, v *emailtemplate.Version) versionResponse {
+	return versionResponse{
+		Number:    v.Number,
+		Subject:   v.Content.Subject,
+		HTMLBody:  v.Content.HTMLBody,
+		TextBody:  v.Content.TextBody,
+		Note:      v.Note,
+		AuthorID:  v.AuthorID,
+		CreatedAt: v.CreatedAt.Format(time.RFC3339),
+		IsActive:  tmpl != nil && tmpl.ActiveVersion != nil && *tmpl.ActiveVersion == v.Number,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,109 @@
+package emailtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/emailtemplate"
+)
+
+// fakeTemplates implements only the calls under test
+type fakeTemplates struct {
+	Templates
+	staffID string
+	version *int
+	data    map[string]any
+	err     error
+}
+
+func (f *fakeTemplates) Preview(ctx context.Context, key string, number *int, data map[string]any) (*emailtemplate.Rendered, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.version, f.data = number, data
+	return &emailtemplate.Rendered{Subject: "Welcome, Budi", HTMLBody: "<p>Hi Budi</p>"}, nil
+}
+
+func (f *fakeTemplates) SendTest(ctx context.Context, staffID, key string, number *int, data map[string]any) error {
+	f.staffID, f.version = staffID, number
+	return f.err
+}
+
+func (f *fakeTemplates) Draft(ctx context.Context, staffID, key string, content emailtemplate.Content, note string) (*emailtemplate.Version, error) {
+	if err := content.Validate(); err != nil {
+		return nil, err
+	}
+	return &emailtemplate.Version{TemplateKey: key, Number: 4, Content: content, AuthorID: staffID}, nil
+}
+
+func staffSession(r *http.Request) (string, bool) {
+	id := r.Header.Get("X-Staff-ID")
+	return id, id != ""
+}
+
+func TestHandler_Preview(t *testing.T) {
+	templates := &fakeTemplates{}
+	router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(templates, staffSession))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/email-templates/welcome/preview", strings.NewReader(`{"version":2,"data":{"Name":"Budi"}}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp renderedResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Subject != "Welcome, Budi" || templates.version == nil || *templates.version != 2 || templates.data["Name"] != "Budi" {
+		t.Errorf("unexpected preview %+v for version %v", resp, templates.version)
+	}
+
+	// Empty body previews the live version with sample data
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/email-templates/welcome/preview", nil))
+	if rec.Code != http.StatusOK || templates.version != nil {
+		t.Errorf("expected live preview, got %d for version %v", rec.Code, templates.version)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method, url    string
+		body           string
+		staff          string
+		err            error
+		expectedStatus int
+	}{
+		{"test send to self", http.MethodPost, "/admin/email-templates/welcome/test-send", `{"version":1}`, "staff1", nil, http.StatusAccepted},
+		{"test send without session", http.MethodPost, "/admin/email-templates/welcome/test-send", ``, "", nil, http.StatusUnauthorized},
+		{"test send from reader", http.MethodPost, "/admin/email-templates/welcome/test-send", ``, "reader1", app.ErrTestSendNotAllowed, http.StatusForbidden},
+		{"preview missing variable", http.MethodPost, "/admin/email-templates/welcome/preview", ``, "staff1", emailtemplate.ErrRenderFailed, http.StatusUnprocessableEntity},
+		{"preview unknown template", http.MethodPost, "/admin/email-templates/nope/preview", ``, "staff1", app.ErrTemplateNotFound, http.StatusNotFound},
+		{"draft with broken template", http.MethodPost, "/admin/email-templates/welcome/versions", `{"subject":"Hi {{.Name","html_body":"<p>x</p>"}`, "staff1", nil, http.StatusUnprocessableEntity},
+		{"sample data without session", http.MethodPut, "/admin/email-templates/welcome/sample-data", `{"sample_data":{"Name":"Budi"}}`, "", nil, http.StatusUnauthorized},
+		{"draft", http.MethodPost, "/admin/email-templates/welcome/versions", `{"subject":"Hi {{.Name}}","html_body":"<p>x</p>"}`, "staff1", nil, http.StatusCreated},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			templates := &fakeTemplates{err: tc.err}
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.staff != "" {
+				req.Header.Set("X-Staff-ID", tc.staff)
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(templates, staffSession)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
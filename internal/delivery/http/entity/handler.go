@@ -0,0 +1,378 @@
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/entity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/entity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+const pageSize = 20
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Entities is the part of the entity service the endpoints need
+type Entities interface {
+	Page(ctx context.Context, slug string, limit, offset int) (*app.Page, error)
+	Create(ctx context.Context, staffID, kind, slug, name, description string, aliases []string) (*entity.Entity, error)
+	Update(ctx context.Context, staffID, id, name, description string, aliases []string) (*entity.Entity, error)
+	Get(ctx context.Context, id string) (*entity.Entity, error)
+	Search(ctx context.Context, query string, limit int) ([]*entity.Entity, error)
+	Merge(ctx context.Context, staffID, sourceID, targetID string) (*entity.Entity, error)
+	Extract(ctx context.Context, articleID string, at time.Time) (*app.ExtractResult, error)
+	Ambiguities(ctx context.Context, limit, offset int) ([]entity.Ambiguity, int64, error)
+	Resolve(ctx context.Context, articleID, surface, entityID string, at time.Time) error
+	Dismiss(ctx context.Context, articleID, surface string) error
+	Unlink(ctx context.Context, articleID, entityID string) error
+}
+
+type entityRequest struct {
+	Kind        string   `json:"kind"`
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Aliases     []string `json:"aliases"`
+}
+
+type mergeRequest struct {
+	Into string `json:"into"`
+}
+
+type resolveRequest struct {
+	ArticleID string `json:"article_id"`
+	Surface   string `json:"surface"`
+	EntityID  string `json:"entity_id"` // Empty dismisses the mention
+}
+
+type entityResponse struct {
+	ID          string   `json:"id"`
+	Kind        string   `json:"kind"`
+	Key         string   `json:"key"`
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name"`
+	Label       string   `json:"label"`
+	Description string   `json:"description,omitempty"`
+	Aliases     []string `json:"aliases"`
+	MergedInto  *string  `json:"merged_into,omitempty"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type articleResponse struct {
+	ArticleID string `json:"article_id"`
+	Title     string `json:"title"`
+	Excerpt   string `json:"excerpt,omitempty"`
+	URL       string `json:"url"`
+}
+
+type pageResponse struct {
+	Slug         string            `json:"slug"`
+	Kind         string            `json:"kind"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description,omitempty"`
+	Articles     []articleResponse `json:"articles"`
+	Total        int64             `json:"total"`
+	CanonicalURL string            `json:"canonical_url"`
+}
+
+type extractResponse struct {
+	Linked    int `json:"linked"`
+	Ambiguous int `json:"ambiguous"`
+	Unknown   int `json:"unknown"`
+}
+
+type ambiguityResponse struct {
+	ArticleID    string   `json:"article_id"`
+	Surface      string   `json:"surface"`
+	Kind         string   `json:"kind"`
+	CandidateIDs []string `json:"candidate_ids"`
+	Mentions     int      `json:"mentions"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+type ambiguityListResponse struct {
+	Items []ambiguityResponse `json:"items"`
+	Total int64               `json:"total"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	entities Entities
+	staff    StaffResolver
+	baseURL  string
+}
+
+func NewHandler(entities Entities, staff StaffResolver, baseURL string) *Handler {
+	return &Handler{entities: entities, staff: staff, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// NewRouter mounts entity pages. baseURL makes links absolute, e.g. "https://news.example.com".
+func NewRouter(entities Entities, baseURL string) http.Handler {
+	h := NewHandler(entities, nil, baseURL)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /entities/{slug}", h.Page)
+	return mux
+}
+
+// NewAdminRouter mounts entity management and the disambiguation queue, it must sit behind admin authentication
+func NewAdminRouter(entities Entities, staff StaffResolver) http.Handler {
+	h := NewHandler(entities, staff, "")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/entities", h.Search)
+	mux.HandleFunc("POST /admin/entities", h.Create)
+	mux.HandleFunc("GET /admin/entities/ambiguities", h.Ambiguities)
+	mux.HandleFunc("POST /admin/entities/ambiguities/resolve", h.Resolve)
+	mux.HandleFunc("GET /admin/entities/{id}", h.Get)
+	mux.HandleFunc("PUT /admin/entities/{id}", h.Update)
+	mux.HandleFunc("POST /admin/entities/{id}/merge", h.Merge)
+	mux.HandleFunc("POST /admin/articles/{id}/entities/extract", h.Extract)
+	mux.HandleFunc("DELETE /admin/articles/{id}/entities/{entityID}", h.Unlink)
+	return mux
+}
+
+// Page handles GET /entities/{slug}?page=2, a merged entity redirects permanently to the survivor
+func (h *Handler) Page(w http.ResponseWriter, r *http.Request) {
+	pageNumber := 1
+	if raw := r.URL.Query().Get("page"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "page must be a positive number"})
+			return
+		}
+		pageNumber = n
+	}
+
+	page, err := h.entities.Page(r.Context(), r.PathValue("slug"), pageSize, (pageNumber-1)*pageSize)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	slug := page.Entity.Slug.Value()
+	if slug != r.PathValue("slug") {
+		http.Redirect(w, r, "/entities/"+slug, http.StatusMovedPermanently)
+		return
+	}
+
+	resp := pageResponse{
+		Slug:         slug,
+		Kind:         string(page.Entity.Kind),
+		Name:         page.Entity.Name,
+		Description:  page.Entity.Description,
+		Articles:     make([]articleResponse, 0, len(page.Articles)),
+		Total:        page.Total,
+		CanonicalURL: h.baseURL + "/entities/" + slug,
+	}
+	if pageNumber > 1 {
+		resp.CanonicalURL += "?page=" + strconv.Itoa(pageNumber)
+	}
+	for _, a := range page.Articles {
+		resp.Articles = append(resp.Articles, articleResponse{ArticleID: a.Summary.Ref.ID, Title: a.Summary.Title, Excerpt: a.Summary.Excerpt, URL: h.baseURL + a.Path})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Search handles GET /admin/entities?q=budi, editors use it to pick namesakes
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	found, err := h.entities.Search(r.Context(), r.URL.Query().Get("q"), pageSize)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]entityResponse, 0, len(found))
+	for _, e := range found {
+		resp = append(resp, toEntityResponse(e))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	found, err := h.entities.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toEntityResponse(found))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req entityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	created, err := h.entities.Create(r.Context(), staffID, req.Kind, req.Slug, req.Name, req.Description, req.Aliases)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toEntityResponse(created))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req entityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	updated, err := h.entities.Update(r.Context(), staffID, r.PathValue("id"), req.Name, req.Description, req.Aliases)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toEntityResponse(updated))
+}
+
+// Merge folds the entity in the path into the one named in the body
+func (h *Handler) Merge(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req mergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	target, err := h.entities.Merge(r.Context(), staffID, r.PathValue("id"), req.Into)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toEntityResponse(target))
+}
+
+// Extract re-runs extraction on one article, e.g. after editors add aliases
+func (h *Handler) Extract(w http.ResponseWriter, r *http.Request) {
+	result, err := h.entities.Extract(r.Context(), r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, extractResponse{Linked: result.Linked, Ambiguous: result.Ambiguous, Unknown: result.Unknown})
+}
+
+func (h *Handler) Unlink(w http.ResponseWriter, r *http.Request) {
+	if err := h.entities.Unlink(r.Context(), r.PathValue("id"), r.PathValue("entityID")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Ambiguities handles GET /admin/entities/ambiguities?limit=20&offset=0
+func (h *Handler) Ambiguities(w http.ResponseWriter, r *http.Request) {
+	limit, errLimit := intParam(r.URL.Query().Get("limit"))
+	offset, errOffset := intParam(r.URL.Query().Get("offset"))
+	if errLimit != nil || errOffset != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit and offset must be numbers"})
+		return
+	}
+
+	pending, total, err := h.entities.Ambiguities(r.Context(), limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := ambiguityListResponse{Items: make([]ambiguityResponse, 0, len(pending)), Total: total}
+	for _, a := range pending {
+		resp.Items = append(resp.Items, ambiguityResponse{
+			ArticleID:    a.ArticleID,
+			Surface:      a.Surface,
+			Kind:         string(a.Kind),
+			CandidateIDs: a.CandidateIDs,
+			Mentions:     a.Mentions,
+			CreatedAt:    a.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Resolve settles a queued mention, or dismisses it when no entity is given
+func (h *Handler) Resolve(w http.ResponseWriter, r *http.Request) {
+	var req resolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	var err error
+	if req.EntityID == "" {
+		err = h.entities.Dismiss(r.Context(), req.ArticleID, req.Surface)
+	} else {
+		err = h.entities.Resolve(r.Context(), req.ArticleID, req.Surface, req.EntityID, time.Now())
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrEntityNotFound), errors.Is(err, app.ErrAmbiguityNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrSlugTaken), errors.Is(err, entity.ErrAlreadyMerged):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, entity.ErrInvalidKind), errors.Is(err, entity.ErrInvalidSlug), errors.Is(err, entity.ErrNameEmpty),
+		errors.Is(err, entity.ErrNameTooLong), errors.Is(err, entity.ErrDescriptionTooLong), errors.Is(err, entity.ErrAliasTaken),
+		errors.Is(err, entity.ErrMergeIntoSelf), errors.Is(err, entity.ErrNotCandidate):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toEntityResponse(e *entity.Entity) entityResponse {
+	return entityResponse{
+		ID:          e.ID,
+		Kind:        string(e.Kind),
+		Key:         e.Key(),
+		Slug:        e.Slug.Value(),
+		Name:        e.Name,
+		Label:       e.Label(),
+		Description: e.Description,
+		Aliases:     append([]string{}, e.Aliases...),
+		MergedInto:  e.MergedInto,
+		UpdatedAt:   e.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func intParam(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/entity"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/entity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+type fakeEntities struct {
+	entity    *entity.Entity
+	page      *app.Page
+	resolved  string
+	dismissed bool
+	err       error
+}
+
+func (f *fakeEntities) Page(ctx context.Context, slug string, limit, offset int) (*app.Page, error) {
+	return f.page, f.err
+}
+
+func (f *fakeEntities) Create(ctx context.Context, staffID, kind, slug, name, description string, aliases []string) (*entity.Entity, error) {
+	return f.entity, f.err
+}
+
+func (f *fakeEntities) Update(ctx context.Context, staffID, id, name, description string, aliases []string) (*entity.Entity, error) {
+	return f.entity, f.err
+}
+
+func (f *fakeEntities) Get(ctx context.Context, id string) (*entity.Entity, error) {
+	return f.entity, f.err
+}
+
+func (f *fakeEntities) Search(ctx context.Context, query string, limit int) ([]*entity.Entity, error) {
+	return []*entity.Entity{f.entity}, f.err
+}
+
+func (f *fakeEntities) Merge(ctx context.Context, staffID, sourceID, targetID string) (*entity.Entity, error) {
+	return f.entity, f.err
+}
+
+func (f *fakeEntities) Extract(ctx context.Context, articleID string, at time.Time) (*app.ExtractResult, error) {
+	return &app.ExtractResult{Linked: 2}, f.err
+}
+
+func (f *fakeEntities) Ambiguities(ctx context.Context, limit, offset int) ([]entity.Ambiguity, int64, error) {
+	return nil, 0, f.err
+}
+
+func (f *fakeEntities) Resolve(ctx context.Context, articleID, surface, entityID string, at time.Time) error {
+	f.resolved = entityID
+	return f.err
+}
+
+func (f *fakeEntities) Dismiss(ctx context.Context, articleID, surface string) error {
+	f.dismissed = true
+	return f.err
+}
+
+func (f *fakeEntities) Unlink(ctx context.Context, articleID, entityID string) error {
+	return f.err
+}
+
+func createTestEntity(t *testing.T, slug string) *entity.Entity {
+	t.Helper()
+	s, _ := entity.NewSlug(slug)
+	e, err := entity.NewEntity("entity-1", entity.KindOrganization, *s, "Corruption Eradication Commission", "", []string{"KPK"}, "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create entity: %v", err)
+	}
+	return e
+}
+
+func TestHandler_Page(t *testing.T) {
+	kpk := createTestEntity(t, "kpk")
+	entry := app.Entry{Summary: entity.ArticleSummary{Ref: permalink.ArticleRef{ID: "a1"}, Title: "KPK names suspect"}, Path: "/news/kpk-suspect"}
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(&fakeEntities{page: &app.Page{Entity: kpk, Articles: []app.Entry{entry}, Total: 41}}, "https://news.example.com"))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/entities/kpk", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp pageResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Total != 41 || len(resp.Articles) != 1 || resp.Articles[0].URL != "https://news.example.com/news/kpk-suspect" {
+		t.Errorf("unexpected page %+v", resp)
+	}
+
+	// A merged duplicate's slug lands on the survivor
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/entities/komisi-pemberantasan-korupsi", nil))
+	if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "/entities/kpk" {
+		t.Errorf("expected redirect to /entities/kpk, got %d %s", rec.Code, rec.Header().Get("Location"))
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/entities/kpk?page=0", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Resolve(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+		expectDismiss  bool
+	}{
+		{"resolve", `{"article_id":"a1","surface":"budi santoso","entity_id":"entity-1"}`, nil, http.StatusNoContent, false},
+		{"dismiss", `{"article_id":"a1","surface":"budi santoso"}`, nil, http.StatusNoContent, true},
+		{"not queued", `{"article_id":"a1","surface":"budi","entity_id":"entity-1"}`, app.ErrAmbiguityNotFound, http.StatusNotFound, false},
+		{"wrong kind", `{"article_id":"a1","surface":"budi santoso","entity_id":"entity-2"}`, entity.ErrNotCandidate, http.StatusUnprocessableEntity, false},
+		{"invalid body", `{`, nil, http.StatusBadRequest, false},
+	}
+
+	staff := func(r *http.Request) (string, bool) { return "editor-1", true }
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := &fakeEntities{err: tc.err}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/admin/entities/ambiguities/resolve", strings.NewReader(tc.body))
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(entities, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if entities.dismissed != tc.expectDismiss {
+				t.Errorf("expected dismissed %v, got %v", tc.expectDismiss, entities.dismissed)
+			}
+		})
+	}
+}
+
+func TestHandler_Merge(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"ok", nil, http.StatusOK},
+		{"into itself", entity.ErrMergeIntoSelf, http.StatusUnprocessableEntity},
+		{"already merged", entity.ErrAlreadyMerged, http.StatusConflict},
+		{"store failure", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	staff := func(r *http.Request) (string, bool) { return "editor-1", true }
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := &fakeEntities{entity: createTestEntity(t, "kpk"), err: tc.err}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/admin/entities/entity-2/merge", strings.NewReader(`{"into":"entity-1"}`))
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(entities, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/experiment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Experiments is the part of the experiment service the admin endpoints need
+type Experiments interface {
+	Create(ctx context.Context, staffID, name, hypothesis string, targets experiment.Targets, holdoutPercent int, endsAt time.Time) (*experiment.Experiment, error)
+	Get(ctx context.Context, id string) (*experiment.Experiment, error)
+	List(ctx context.Context) ([]*experiment.Experiment, error)
+	Start(ctx context.Context, id string) (*experiment.Experiment, error)
+	Stop(ctx context.Context, id string) (*experiment.Experiment, error)
+	Results(ctx context.Context, id string) (*experiment.Results, error)
+}
+
+type createRequest struct {
+	Name           string   `json:"name"`
+	Hypothesis     string   `json:"hypothesis"`
+	ArticleIDs     []string `json:"article_ids"`
+	CategoryIDs    []string `json:"category_ids"`
+	HoldoutPercent int      `json:"holdout_percent"`
+	EndsAt         string   `json:"ends_at"`
+}
+
+type experimentResponse struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Hypothesis     string   `json:"hypothesis"`
+	ArticleIDs     []string `json:"article_ids"`
+	CategoryIDs    []string `json:"category_ids"`
+	HoldoutPercent int      `json:"holdout_percent"`
+	Status         string   `json:"status"`
+	StartedAt      *string  `json:"started_at,omitempty"`
+	EndsAt         string   `json:"ends_at"`
+	EndedAt        *string  `json:"ended_at,omitempty"`
+	EndReason      *string  `json:"end_reason,omitempty"`
+	CreatedBy      string   `json:"created_by"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+}
+
+type groupResponse struct {
+	Visitors    int     `json:"visitors"`
+	Conversions int     `json:"conversions"`
+	Rate        float64 `json:"rate"`
+}
+
+// resultsResponse has lift as the relative change of the holdout rate
+// against control, -0.2 means holding out converted 20% fewer visitors
+type resultsResponse struct {
+	ExperimentID string        `json:"experiment_id"`
+	Control      groupResponse `json:"control"`
+	Holdout      groupResponse `json:"holdout"`
+	Lift         float64       `json:"lift"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	experiments Experiments
+	staff       StaffResolver
+}
+
+func NewHandler(experiments Experiments, staff StaffResolver) *Handler {
+	return &Handler{experiments: experiments, staff: staff}
+}
+
+// NewAdminRouter mounts paywall experiments and their results, it must sit behind admin authentication
+func NewAdminRouter(experiments Experiments, staff StaffResolver) http.Handler {
+	h := NewHandler(experiments, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/experiments", h.List)
+	mux.HandleFunc("POST /admin/experiments", h.Create)
+	mux.HandleFunc("GET /admin/experiments/{id}", h.Get)
+	mux.HandleFunc("POST /admin/experiments/{id}/start", h.Start)
+	mux.HandleFunc("POST /admin/experiments/{id}/stop", h.Stop)
+	mux.HandleFunc("GET /admin/experiments/{id}/results", h.Results)
+	return mux
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	all, err := h.experiments.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]experimentResponse, 0, len(all))
+	for _, e := range all {
+		resp = append(resp, toExperimentResponse(e))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Create saves a draft, ends_at is an RFC3339 time and the 90 day limit is
+// checked when the experiment starts
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	endsAt, err := time.Parse(time.RFC3339, req.EndsAt)
+	if err != nil {
+		writeError(w, experiment.ErrInvalidSchedule)
+		return
+	}
+	targets, err := experiment.NewTargets(req.ArticleIDs, req.CategoryIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	e, err := h.experiments.Create(r.Context(), staffID, req.Name, req.Hypothesis, targets, req.HoldoutPercent, endsAt)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toExperimentResponse(e))
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	e, err := h.experiments.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toExperimentResponse(e))
+}
+
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	e, err := h.experiments.Start(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toExperimentResponse(e))
+}
+
+func (h *Handler) Stop(w http.ResponseWriter, r *http.Request) {
+	e, err := h.experiments.Stop(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toExperimentResponse(e))
+}
+
+func (h *Handler) Results(w http.ResponseWriter, r *http.Request) {
+	results, err := h.experiments.Results(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resultsResponse{
+		ExperimentID: results.ExperimentID,
+		Control:      toGroupResponse(results.Control),
+		Holdout:      toGroupResponse(results.Holdout),
+		Lift:         results.Lift(),
+	})
+}
+
+// writeError maps domain errors by kind, the code tells e.g. an experiment that
+// already started from a target another experiment holds
+func writeError(w http.ResponseWriter, err error) {
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error(), Code: de.Code})
+}
+
+func toExperimentResponse(e *experiment.Experiment) experimentResponse {
+	resp := experimentResponse{
+		ID:             e.ID,
+		Name:           e.Name,
+		Hypothesis:     e.Hypothesis,
+		ArticleIDs:     append([]string{}, e.Targets.ArticleIDs...),
+		CategoryIDs:    append([]string{}, e.Targets.CategoryIDs...),
+		HoldoutPercent: e.HoldoutPercent,
+		Status:         string(e.Status),
+		StartedAt:      formatTime(e.StartedAt),
+		EndsAt:         e.EndsAt.Format(time.RFC3339),
+		EndedAt:        formatTime(e.EndedAt),
+		CreatedBy:      e.CreatedBy,
+		CreatedAt:      e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      e.UpdatedAt.Format(time.RFC3339),
+	}
+	if e.EndReason != nil {
+		reason := string(*e.EndReason)
+		resp.EndReason = &reason
+	}
+	return resp
+}
+
+func toGroupResponse(g experiment.GroupResult) groupResponse {
+	return groupResponse{Visitors: g.Visitors, Conversions: g.Conversions, Rate: g.Rate()}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
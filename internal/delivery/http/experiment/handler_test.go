@@ -0,0 +1,137 @@
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/experiment"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/experiment"
+)
+
+type fakeExperiments struct {
+	err     error
+	targets experiment.Targets
+}
+
+func (f *fakeExperiments) experiment() *experiment.Experiment {
+	now := time.Now()
+	targets, _ := experiment.NewTargets(nil, []string{"opini"})
+	e, _ := experiment.NewExperiment("exp-1", "Free opinion", "", targets, 10, now.AddDate(0, 0, 14), "pm-1", now)
+	_ = e.Start(now)
+	return e
+}
+
+func (f *fakeExperiments) Create(ctx context.Context, staffID, name, hypothesis string, targets experiment.Targets, holdoutPercent int, endsAt time.Time) (*experiment.Experiment, error) {
+	f.targets = targets
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.experiment(), nil
+}
+
+func (f *fakeExperiments) Get(ctx context.Context, id string) (*experiment.Experiment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.experiment(), nil
+}
+
+func (f *fakeExperiments) List(ctx context.Context) ([]*experiment.Experiment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*experiment.Experiment{f.experiment()}, nil
+}
+
+func (f *fakeExperiments) Start(ctx context.Context, id string) (*experiment.Experiment, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeExperiments) Stop(ctx context.Context, id string) (*experiment.Experiment, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeExperiments) Results(ctx context.Context, id string) (*experiment.Results, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &experiment.Results{
+		ExperimentID: id,
+		Control:      experiment.GroupResult{Group: experiment.GroupControl, Visitors: 900, Conversions: 18},
+		Holdout:      experiment.GroupResult{Group: experiment.GroupHoldout, Visitors: 100, Conversions: 1},
+	}, nil
+}
+
+func staff(r *http.Request) (string, bool) {
+	return "pm-1", true
+}
+
+func noStaff(r *http.Request) (string, bool) {
+	return "", false
+}
+
+var createBody = fmt.Sprintf(`{"name": "Free opinion", "category_ids": ["opini"], "holdout_percent": 10, "ends_at": %q}`, time.Now().AddDate(0, 0, 14).Format(time.RFC3339))
+
+func TestAdminRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		staff          StaffResolver
+		err            error
+		expectedStatus int
+	}{
+		{"list", http.MethodGet, "/admin/experiments", "", staff, nil, http.StatusOK},
+		{"create", http.MethodPost, "/admin/experiments", createBody, staff, nil, http.StatusCreated},
+		{"create without session", http.MethodPost, "/admin/experiments", createBody, noStaff, nil, http.StatusUnauthorized},
+		{"create invalid body", http.MethodPost, "/admin/experiments", `{"name":`, staff, nil, http.StatusBadRequest},
+		{"create invalid end", http.MethodPost, "/admin/experiments", `{"name": "Free opinion", "category_ids": ["opini"], "holdout_percent": 10, "ends_at": "next month"}`, staff, nil, http.StatusUnprocessableEntity},
+		{"create without targets", http.MethodPost, "/admin/experiments", `{"name": "Free opinion", "holdout_percent": 10, "ends_at": "2026-04-01T00:00:00Z"}`, staff, nil, http.StatusUnprocessableEntity},
+		{"create holdout too large", http.MethodPost, "/admin/experiments", createBody, staff, experiment.ErrInvalidHoldout, http.StatusUnprocessableEntity},
+		{"get", http.MethodGet, "/admin/experiments/exp-1", "", staff, nil, http.StatusOK},
+		{"get unknown", http.MethodGet, "/admin/experiments/exp-9", "", staff, app.ErrExperimentNotFound, http.StatusNotFound},
+		{"start", http.MethodPost, "/admin/experiments/exp-1/start", "", staff, nil, http.StatusOK},
+		{"start twice", http.MethodPost, "/admin/experiments/exp-1/start", "", staff, experiment.ErrNotDraft, http.StatusConflict},
+		{"start overlapping", http.MethodPost, "/admin/experiments/exp-1/start", "", staff, fmt.Errorf("%w: Free opinion", experiment.ErrTargetTaken), http.StatusConflict},
+		{"start too long", http.MethodPost, "/admin/experiments/exp-1/start", "", staff, experiment.ErrInvalidSchedule, http.StatusUnprocessableEntity},
+		{"stop", http.MethodPost, "/admin/experiments/exp-1/stop", "", staff, nil, http.StatusOK},
+		{"stop ended", http.MethodPost, "/admin/experiments/exp-1/stop", "", staff, experiment.ErrNotRunning, http.StatusConflict},
+		{"results", http.MethodGet, "/admin/experiments/exp-1/results", "", staff, nil, http.StatusOK},
+		{"store failure", http.MethodGet, "/admin/experiments", "", staff, errors.New("connection reset"), http.StatusInternalServerError},
+		{"timeout", http.MethodGet, "/admin/experiments/exp-1", "", staff, context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeExperiments{err: tc.err}, tc.staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminRouter_Results(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeExperiments{}, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/experiments/exp-1/results", nil))
+
+	var resp resultsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Control.Rate != 0.02 || resp.Holdout.Rate != 0.01 || resp.Lift != -0.5 {
+		t.Errorf("unexpected results %+v", resp)
+	}
+}
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/export"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/export"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// MemberResolver returns the signed-in member, guests get ok false
+type MemberResolver func(r *http.Request) (string, bool)
+
+// Exports is the part of the export service the endpoints need
+type Exports interface {
+	Download(ctx context.Context, accountID, articleID string, format export.Format, at time.Time) (*export.Document, error)
+	Archive(ctx context.Context, articleID string, format export.Format, at time.Time) (*export.Document, error)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	exports Exports
+	member  MemberResolver
+}
+
+func NewHandler(exports Exports, member MemberResolver) *Handler {
+	return &Handler{exports: exports, member: member}
+}
+
+// NewRouter mounts reader downloads, guests may export free articles
+func NewRouter(exports Exports, member MemberResolver) http.Handler {
+	h := NewHandler(exports, member)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /articles/{id}/export", h.Download)
+	return mux
+}
+
+// NewAdminRouter mounts archival exports, it must sit behind admin authentication
+func NewAdminRouter(exports Exports) http.Handler {
+	h := NewHandler(exports, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/articles/{id}/export", h.Archive)
+	return mux
+}
+
+// Download handles GET /articles/{id}/export?format=epub|text|html
+func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	accountID, _ := h.member(r)
+	doc, err := h.exports.Download(r.Context(), accountID, r.PathValue("id"), format, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// Access depends on the reader, shared caches must not keep it
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	writeDocument(w, r, doc)
+}
+
+func (h *Handler) Archive(w http.ResponseWriter, r *http.Request) {
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	doc, err := h.exports.Archive(r.Context(), r.PathValue("id"), format, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeDocument(w, r, doc)
+}
+
+// writeDocument sends the file. The ETag follows the article revision, so an
+// unchanged article costs the reader a 304. Books are downloaded, text and
+// HTML open in the browser.
+func writeDocument(w http.ResponseWriter, r *http.Request, doc *export.Document) {
+	etag := strconv.Quote(doc.Key())
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	disposition := "inline"
+	if doc.Format == export.FormatEPUB {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Type", doc.ContentType())
+	w.Header().Set("Content-Length", strconv.Itoa(len(doc.Data)))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": doc.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(doc.Data)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrArticleNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrExportNotAllowed):
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+	case errors.Is(err, export.ErrInvalidFormat), errors.Is(err, app.ErrFormatNotEnabled):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
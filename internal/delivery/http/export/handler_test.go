@@ -0,0 +1,119 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/export"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/export"
+)
+
+type fakeExports struct {
+	accountID string
+	archived  bool
+	err       error
+}
+
+func (f *fakeExports) Download(ctx context.Context, accountID, articleID string, format export.Format, at time.Time) (*export.Document, error) {
+	f.accountID = accountID
+	return f.document(articleID, format, at)
+}
+
+func (f *fakeExports) Archive(ctx context.Context, articleID string, format export.Format, at time.Time) (*export.Document, error) {
+	f.archived = true
+	return f.document(articleID, format, at)
+}
+
+func (f *fakeExports) document(articleID string, format export.Format, at time.Time) (*export.Document, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return export.NewDocument(&export.Article{ID: articleID, Revision: "r1", Slug: "banjir-jakarta"}, format, []byte("content"), at)
+}
+
+func member(r *http.Request) (string, bool) {
+	if r.Header.Get("Authorization") == "" {
+		return "", false
+	}
+	return "acc-1", true
+}
+
+func TestHandler_Download(t *testing.T) {
+	testCases := []struct {
+		name                string
+		url                 string
+		err                 error
+		expectedStatus      int
+		expectedType        string
+		expectedDisposition string
+	}{
+		{"epub", "/articles/a1/export?format=epub", nil, http.StatusOK, "application/epub+zip", "attachment; filename=banjir-jakarta.epub"},
+		{"text", "/articles/a1/export?format=text", nil, http.StatusOK, "text/plain; charset=utf-8", "inline; filename=banjir-jakarta.txt"},
+		{"html", "/articles/a1/export?format=html", nil, http.StatusOK, "text/html; charset=utf-8", "inline; filename=banjir-jakarta.html"},
+		{"missing format", "/articles/a1/export", nil, http.StatusBadRequest, "application/json", ""},
+		{"format not enabled", "/articles/a1/export?format=html", app.ErrFormatNotEnabled, http.StatusBadRequest, "application/json", ""},
+		{"premium", "/articles/a1/export?format=text", app.ErrExportNotAllowed, http.StatusForbidden, "application/json", ""},
+		{"not found", "/articles/a1/export?format=text", app.ErrArticleNotFound, http.StatusNotFound, "application/json", ""},
+		{"render failure", "/articles/a1/export?format=text", errors.New("disk full"), http.StatusInternalServerError, "application/json", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakeExports{err: tc.err}, member)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if got := rec.Header().Get("Content-Type"); got != tc.expectedType {
+				t.Errorf("expected content type %s, got %s", tc.expectedType, got)
+			}
+			if got := rec.Header().Get("Content-Disposition"); got != tc.expectedDisposition {
+				t.Errorf("expected disposition %q, got %q", tc.expectedDisposition, got)
+			}
+		})
+	}
+}
+
+func TestHandler_DownloadMemberAndETag(t *testing.T) {
+	exports := &fakeExports{}
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(exports, member))
+
+	req := httptest.NewRequest(http.MethodGet, "/articles/a1/export?format=text", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if exports.accountID != "acc-1" {
+		t.Errorf("expected the member's access to be checked, got %q", exports.accountID)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" || rec.Header().Get("Cache-Control") != "private, max-age=300" {
+		t.Fatalf("expected private caching with an ETag, got %v", rec.Header())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/articles/a1/export?format=text", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected 304 for the same revision, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Archive(t *testing.T) {
+	exports := &fakeExports{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(exports)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/articles/a1/export?format=epub", nil))
+
+	if rec.Code != http.StatusOK || !exports.archived {
+		t.Errorf("expected archival export, got %d", rec.Code)
+	}
+	if rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected no-store, got %s", rec.Header().Get("Cache-Control"))
+	}
+}
@@ -0,0 +1,249 @@
+package freshness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/freshness"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/freshness"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Monitor is the part of the freshness service the producer dashboard needs
+type Monitor interface {
+	Velocity(ctx context.Context, date string, at time.Time) ([]freshness.DeskDay, error)
+	SetTarget(ctx context.Context, staffID, desk string, dailyTarget int, at time.Time) (*freshness.DeskTarget, error)
+	RemoveTarget(ctx context.Context, desk string) error
+
+	Freshness(ctx context.Context, at time.Time) ([]app.RailStatus, error)
+	CreateRail(ctx context.Context, slug, name, desk string, categoryIDs []string, maxAge time.Duration, at time.Time) (*freshness.Rail, error)
+	UpdateRail(ctx context.Context, slug, name, desk string, categoryIDs []string, maxAge time.Duration, at time.Time) (*freshness.Rail, error)
+	DeleteRail(ctx context.Context, slug string) error
+}
+
+type targetRequest struct {
+	DailyTarget int `json:"daily_target"`
+}
+
+type railRequest struct {
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name"`
+	Desk        string   `json:"desk"`
+	CategoryIDs []string `json:"category_ids"`
+	MaxAgeHours int      `json:"max_age_hours"`
+}
+
+type deskDayResponse struct {
+	Desk      string `json:"desk"`
+	Date      string `json:"date"`
+	Published int    `json:"published"`
+	Target    int    `json:"target"`
+	Expected  int    `json:"expected"` // Share of the target due by now
+	OnPace    bool   `json:"on_pace"`
+}
+
+type targetResponse struct {
+	Desk        string `json:"desk"`
+	DailyTarget int    `json:"daily_target"`
+	UpdatedBy   string `json:"updated_by"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type railResponse struct {
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name"`
+	Desk        string   `json:"desk"`
+	CategoryIDs []string `json:"category_ids"`
+	MaxAgeHours int      `json:"max_age_hours"`
+	Alerted     bool     `json:"alerted"`
+}
+
+type railStatusResponse struct {
+	railResponse
+	LatestAt *string `json:"latest_at"`
+	StaleAt  string  `json:"stale_at"`
+	Stale    bool    `json:"stale"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	monitor Monitor
+	staff   StaffResolver
+}
+
+func NewHandler(monitor Monitor, staff StaffResolver) *Handler {
+	return &Handler{monitor: monitor, staff: staff}
+}
+
+// NewAdminRouter mounts desk publishing targets and homepage rail freshness, it must sit behind admin authentication
+func NewAdminRouter(monitor Monitor, staff StaffResolver) http.Handler {
+	h := NewHandler(monitor, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/freshness/velocity", h.Velocity)
+	mux.HandleFunc("PUT /admin/freshness/targets/{desk}", h.SetTarget)
+	mux.HandleFunc("DELETE /admin/freshness/targets/{desk}", h.RemoveTarget)
+	mux.HandleFunc("GET /admin/freshness/rails", h.Rails)
+	mux.HandleFunc("POST /admin/freshness/rails", h.CreateRail)
+	mux.HandleFunc("PUT /admin/freshness/rails/{slug}", h.UpdateRail)
+	mux.HandleFunc("DELETE /admin/freshness/rails/{slug}", h.DeleteRail)
+	return mux
+}
+
+// Velocity handles GET /admin/freshness/velocity?date=YYYY-MM-DD, today when the date is left out
+func (h *Handler) Velocity(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	days, err := h.monitor.Velocity(r.Context(), r.URL.Query().Get("date"), now)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]deskDayResponse, 0, len(days))
+	for _, d := range days {
+		resp = append(resp, deskDayResponse{
+			Desk:      d.Desk,
+			Date:      d.Day.Format(time.DateOnly),
+			Published: d.Published,
+			Target:    d.Target,
+			Expected:  d.ExpectedBy(now),
+			OnPace:    d.OnPace(now),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) SetTarget(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req targetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	target, err := h.monitor.SetTarget(r.Context(), staffID, r.PathValue("desk"), req.DailyTarget, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, targetResponse{
+		Desk:        target.Desk,
+		DailyTarget: target.DailyTarget,
+		UpdatedBy:   target.UpdatedBy,
+		UpdatedAt:   target.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+func (h *Handler) RemoveTarget(w http.ResponseWriter, r *http.Request) {
+	if err := h.monitor.RemoveTarget(r.Context(), r.PathValue("desk")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Rails lists every homepage rail with its newest article, stale ones first
+func (h *Handler) Rails(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.monitor.Freshness(r.Context(), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]railStatusResponse, 0, len(statuses))
+	for _, s := range statuses {
+		row := railStatusResponse{railResponse: toRailResponse(s.Rail), StaleAt: s.StaleAt.Format(time.RFC3339), Stale: s.Stale}
+		if s.LatestAt != nil {
+			at := s.LatestAt.Format(time.RFC3339)
+			row.LatestAt = &at
+		}
+		resp = append(resp, row)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) CreateRail(w http.ResponseWriter, r *http.Request) {
+	var req railRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	rail, err := h.monitor.CreateRail(r.Context(), req.Slug, req.Name, req.Desk, req.CategoryIDs, time.Duration(req.MaxAgeHours)*time.Hour, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toRailResponse(rail))
+}
+
+func (h *Handler) UpdateRail(w http.ResponseWriter, r *http.Request) {
+	var req railRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	rail, err := h.monitor.UpdateRail(r.Context(), r.PathValue("slug"), req.Name, req.Desk, req.CategoryIDs, time.Duration(req.MaxAgeHours)*time.Hour, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toRailResponse(rail))
+}
+
+func (h *Handler) DeleteRail(w http.ResponseWriter, r *http.Request) {
+	if err := h.monitor.DeleteRail(r.Context(), r.PathValue("slug")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrTargetNotFound), errors.Is(err, app.ErrRailNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrRailExists):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, freshness.ErrInvalidDesk),
+		errors.Is(err, freshness.ErrInvalidTarget),
+		errors.Is(err, freshness.ErrInvalidRailSlug),
+		errors.Is(err, freshness.ErrInvalidRailName),
+		errors.Is(err, freshness.ErrInvalidMaxAge),
+		errors.Is(err, freshness.ErrInvalidCategories),
+		errors.Is(err, freshness.ErrInvalidDay):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toRailResponse(rail *freshness.Rail) railResponse {
+	return railResponse{
+		Slug:        rail.Slug,
+		Name:        rail.Name,
+		Desk:        rail.Desk,
+		CategoryIDs: rail.CategoryIDs,
+		MaxAgeHours: int(rail.MaxAge / time.Hour),
+		Alerted:     rail.AlertedAt != nil,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
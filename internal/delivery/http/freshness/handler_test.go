@@ -0,0 +1,116 @@
+package freshness
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/freshness"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/freshness"
+)
+
+type fakeMonitor struct {
+	err error
+}
+
+func (f *fakeMonitor) Velocity(ctx context.Context, date string, at time.Time) ([]freshness.DeskDay, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if date != "" {
+		if _, err := freshness.DefaultRules.ParseDay(date); err != nil {
+			return nil, err
+		}
+	}
+	return []freshness.DeskDay{{Desk: "metro", Day: freshness.DefaultRules.DayOf(at), Published: 3, Target: 10}}, nil
+}
+
+func (f *fakeMonitor) SetTarget(ctx context.Context, staffID, desk string, dailyTarget int, at time.Time) (*freshness.DeskTarget, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return freshness.NewDeskTarget(desk, dailyTarget, staffID, at)
+}
+
+func (f *fakeMonitor) RemoveTarget(ctx context.Context, desk string) error {
+	return f.err
+}
+
+func (f *fakeMonitor) Freshness(ctx context.Context, at time.Time) ([]app.RailStatus, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	rail, _ := freshness.NewRail("metro", "Metro", "metro", []string{"c1"}, 3*time.Hour, at.Add(-4*time.Hour))
+	return []app.RailStatus{{Rail: rail, StaleAt: rail.StaleAt(nil), Stale: true}}, nil
+}
+
+func (f *fakeMonitor) CreateRail(ctx context.Context, slug, name, desk string, categoryIDs []string, maxAge time.Duration, at time.Time) (*freshness.Rail, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return freshness.NewRail(slug, name, desk, categoryIDs, maxAge, at)
+}
+
+func (f *fakeMonitor) UpdateRail(ctx context.Context, slug, name, desk string, categoryIDs []string, maxAge time.Duration, at time.Time) (*freshness.Rail, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return freshness.NewRail(slug, name, desk, categoryIDs, maxAge, at)
+}
+
+func (f *fakeMonitor) DeleteRail(ctx context.Context, slug string) error {
+	return f.err
+}
+
+func TestHandler(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "s1", r.Header.Get("Authorization") != "" }
+	rail := `{"slug":"metro","name":"Metro","desk":"metro","category_ids":["c1"],"max_age_hours":3}`
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"velocity", http.MethodGet, "/admin/freshness/velocity", "", true, nil, http.StatusOK},
+		{"velocity for a day", http.MethodGet, "/admin/freshness/velocity?date=2026-03-10", "", true, nil, http.StatusOK},
+		{"velocity invalid date", http.MethodGet, "/admin/freshness/velocity?date=yesterday", "", true, nil, http.StatusUnprocessableEntity},
+		{"set target", http.MethodPut, "/admin/freshness/targets/metro", `{"daily_target":12}`, true, nil, http.StatusOK},
+		{"set target without session", http.MethodPut, "/admin/freshness/targets/metro", `{"daily_target":12}`, false, nil, http.StatusUnauthorized},
+		{"set target invalid body", http.MethodPut, "/admin/freshness/targets/metro", `{`, true, nil, http.StatusBadRequest},
+		{"set target out of range", http.MethodPut, "/admin/freshness/targets/metro", `{"daily_target":0}`, true, nil, http.StatusUnprocessableEntity},
+		{"remove target", http.MethodDelete, "/admin/freshness/targets/metro", "", true, nil, http.StatusNoContent},
+		{"remove missing target", http.MethodDelete, "/admin/freshness/targets/metro", "", true, app.ErrTargetNotFound, http.StatusNotFound},
+		{"rails", http.MethodGet, "/admin/freshness/rails", "", true, nil, http.StatusOK},
+		{"create rail", http.MethodPost, "/admin/freshness/rails", rail, true, nil, http.StatusCreated},
+		{"create rail invalid max age", http.MethodPost, "/admin/freshness/rails", `{"slug":"metro","name":"Metro","desk":"metro","category_ids":["c1"]}`, true, nil, http.StatusUnprocessableEntity},
+		{"create duplicate rail", http.MethodPost, "/admin/freshness/rails", rail, true, app.ErrRailExists, http.StatusConflict},
+		{"update rail", http.MethodPut, "/admin/freshness/rails/metro", rail, true, nil, http.StatusOK},
+		{"update missing rail", http.MethodPut, "/admin/freshness/rails/metro", rail, true, app.ErrRailNotFound, http.StatusNotFound},
+		{"delete rail", http.MethodDelete, "/admin/freshness/rails/metro", "", true, nil, http.StatusNoContent},
+		{"store failure", http.MethodGet, "/admin/freshness/rails", "", true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeMonitor{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/geo"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/geo"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Locations is the part of the geo service the endpoints need
+type Locations interface {
+	SetLocation(ctx context.Context, staffID, articleID string, location geo.Location) (*geo.ArticleLocation, error)
+	RemoveLocation(ctx context.Context, articleID string) error
+	Location(ctx context.Context, articleID string) (*geo.ArticleLocation, error)
+	Within(ctx context.Context, box geo.BoundingBox, since time.Time, limit int) ([]app.Pin, error)
+	Near(ctx context.Context, center geo.Point, radiusKm float64, since time.Time, limit int) ([]app.Pin, error)
+	Clusters(ctx context.Context, box geo.BoundingBox, zoom int, since time.Time) ([]geo.Cluster, error)
+}
+
+// geometry is a GeoJSON Point or Polygon, coordinates are [lng, lat]
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+type locationRequest struct {
+	Geometry  geometry `json:"geometry"`
+	PlaceName string   `json:"place_name"`
+}
+
+type locationResponse struct {
+	ArticleID string     `json:"article_id"`
+	Kind      string     `json:"kind"`
+	Geometry  geometry   `json:"geometry"`
+	Center    [2]float64 `json:"center"`
+	PlaceName string     `json:"place_name,omitempty"`
+	OnMap     bool       `json:"on_map"`
+	UpdatedAt string     `json:"updated_at"`
+}
+
+type feature struct {
+	Type       string         `json:"type"`
+	Geometry   geometry       `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// featureCollection is what map libraries load directly
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	locations Locations
+	staff     StaffResolver
+	baseURL   string
+}
+
+func NewHandler(locations Locations, staff StaffResolver, baseURL string) *Handler {
+	return &Handler{locations: locations, staff: staff, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// NewRouter mounts the news map API, answers are GeoJSON.
+// baseURL makes article links absolute, e.g. "https://news.example.com".
+func NewRouter(locations Locations, baseURL string) http.Handler {
+	h := NewHandler(locations, nil, baseURL)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /map/articles", h.Within)
+	mux.HandleFunc("GET /map/articles/nearby", h.Near)
+	mux.HandleFunc("GET /map/clusters", h.Clusters)
+	return mux
+}
+
+// NewAdminRouter mounts article geo-tagging, it must sit behind admin authentication
+func NewAdminRouter(locations Locations, staff StaffResolver) http.Handler {
+	h := NewHandler(locations, staff, "")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/articles/{id}/location", h.Get)
+	mux.HandleFunc("PUT /admin/articles/{id}/location", h.Set)
+	mux.HandleFunc("DELETE /admin/articles/{id}/location", h.Delete)
+	return mux
+}
+
+// Within handles GET /map/articles?bbox=106.6,-6.4,107.0,-6.0&since=2025-06-01&limit=100
+func (h *Handler) Within(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	box, err := parseBoundingBox(q.Get("bbox"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	since, limit, ok := parseCommon(w, q.Get("since"), q.Get("limit"))
+	if !ok {
+		return
+	}
+
+	pins, err := h.locations.Within(r.Context(), *box, since, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.toPinCollection(pins))
+}
+
+// Near handles GET /map/articles/nearby?lat=-6.17&lng=106.82&radius_km=10
+func (h *Handler) Near(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lat, errLat := strconv.ParseFloat(q.Get("lat"), 64)
+	lng, errLng := strconv.ParseFloat(q.Get("lng"), 64)
+	radius, errRadius := strconv.ParseFloat(q.Get("radius_km"), 64)
+	if errLat != nil || errLng != nil || errRadius != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "lat, lng and radius_km must be numbers"})
+		return
+	}
+	center, err := geo.NewPoint(lat, lng)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	since, limit, ok := parseCommon(w, q.Get("since"), q.Get("limit"))
+	if !ok {
+		return
+	}
+
+	pins, err := h.locations.Near(r.Context(), *center, radius, since, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.toPinCollection(pins))
+}
+
+// Clusters handles GET /map/clusters?bbox=95,-11,141,6&zoom=5. A cluster of one
+// carries its article_id so the frontend can draw a pin instead.
+func (h *Handler) Clusters(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	box, err := parseBoundingBox(q.Get("bbox"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	zoom, err := strconv.Atoi(q.Get("zoom"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "zoom must be a number"})
+		return
+	}
+	since, _, ok := parseCommon(w, q.Get("since"), "")
+	if !ok {
+		return
+	}
+
+	clusters, err := h.locations.Clusters(r.Context(), *box, zoom, since)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := featureCollection{Type: "FeatureCollection", Features: make([]feature, 0, len(clusters))}
+	for _, c := range clusters {
+		properties := map[string]any{
+			"count": c.Count,
+			"bbox":  [4]float64{c.Bounds.West, c.Bounds.South, c.Bounds.East, c.Bounds.North},
+		}
+		if c.ArticleID != "" {
+			properties["article_id"] = c.ArticleID
+		}
+		resp.Features = append(resp.Features, feature{Type: "Feature", Geometry: pointGeometry(c.Center), Properties: properties})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	location, err := h.locations.Location(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toLocationResponse(location))
+}
+
+// Set tags an article with a GeoJSON Point or Polygon
+func (h *Handler) Set(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req locationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	location, err := req.Geometry.toLocation(req.PlaceName)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	saved, err := h.locations.SetLocation(r.Context(), staffID, r.PathValue("id"), *location)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toLocationResponse(saved))
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.locations.RemoveLocation(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+var errInvalidGeometry = errors.New("geometry must be a GeoJSON Point or Polygon")
+
+func (g geometry) toLocation(placeName string) (*geo.Location, error) {
+	switch g.Type {
+	case "Point":
+		var c [2]float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, errInvalidGeometry
+		}
+		point, err := geo.NewPoint(c[1], c[0])
+		if err != nil {
+			return nil, err
+		}
+		return geo.NewPointLocation(*point, placeName)
+	case "Polygon":
+		var rings [][][2]float64
+		if err := json.Unmarshal(g.Coordinates, &rings); err != nil || len(rings) == 0 {
+			return nil, errInvalidGeometry
+		}
+		ring := make([]geo.Point, len(rings[0]))
+		for i, c := range rings[0] {
+			ring[i] = geo.Point{Lat: c[1], Lng: c[0]}
+		}
+		return geo.NewRegionLocation(ring, placeName)
+	}
+	return nil, errInvalidGeometry
+}
+
+func pointGeometry(p geo.Point) geometry {
+	coordinates, _ := json.Marshal([2]float64{p.Lng, p.Lat})
+	return geometry{Type: "Point", Coordinates: coordinates}
+}
+
+func locationGeometry(l geo.Location) geometry {
+	if l.Kind != geo.KindRegion {
+		return pointGeometry(l.Center)
+	}
+	ring := make([][2]float64, len(l.Ring))
+	for i, p := range l.Ring {
+		ring[i] = [2]float64{p.Lng, p.Lat}
+	}
+	coordinates, _ := json.Marshal([][][2]float64{ring})
+	return geometry{Type: "Polygon", Coordinates: coordinates}
+}
+
+func (h *Handler) toPinCollection(pins []app.Pin) featureCollection {
+	resp := featureCollection{Type: "FeatureCollection", Features: make([]feature, 0, len(pins))}
+	for _, p := range pins {
+		properties := map[string]any{
+			"article_id": p.Summary.Ref.ID,
+			"title":      p.Summary.Title,
+			"url":        h.baseURL + p.Path,
+			"center":     [2]float64{p.Location.Location.Center.Lng, p.Location.Location.Center.Lat},
+		}
+		if p.Location.Location.PlaceName != "" {
+			properties["place_name"] = p.Location.Location.PlaceName
+		}
+		if p.Location.PublishedAt != nil {
+			properties["published_at"] = p.Location.PublishedAt.Format(time.RFC3339)
+		}
+		if p.DistanceKm != nil {
+			properties["distance_km"] = *p.DistanceKm
+		}
+		resp.Features = append(resp.Features, feature{Type: "Feature", Geometry: locationGeometry(p.Location.Location), Properties: properties})
+	}
+	return resp
+}
+
+func toLocationResponse(l *geo.ArticleLocation) locationResponse {
+	return locationResponse{
+		ArticleID: l.ArticleID,
+		Kind:      string(l.Location.Kind),
+		Geometry:  locationGeometry(l.Location),
+		Center:    [2]float64{l.Location.Center.Lng, l.Location.Center.Lat},
+		PlaceName: l.Location.PlaceName,
+		OnMap:     l.IsOnMap(),
+		UpdatedAt: l.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// parseBoundingBox reads "west,south,east,north", the GeoJSON bbox order
+func parseBoundingBox(raw string) (*geo.BoundingBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, errInvalidBBox
+	}
+	var values [4]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, errInvalidBBox
+		}
+		values[i] = v
+	}
+	return geo.NewBoundingBox(values[1], values[0], values[3], values[2])
+}
+
+var errInvalidBBox = errors.New("bbox must be west,south,east,north in degrees")
+
+// parseCommon reads ?since= (a date or RFC 3339) and ?limit=, writing the 400 itself
+func parseCommon(w http.ResponseWriter, rawSince, rawLimit string) (time.Time, int, bool) {
+	var since time.Time
+	if rawSince != "" {
+		var err error
+		if since, err = time.Parse(time.DateOnly, rawSince); err != nil {
+			if since, err = time.Parse(time.RFC3339, rawSince); err != nil {
+				writeJSON(w, http.StatusBadRequest, errorResponse{Error: "since must be a date or an RFC 3339 timestamp"})
+				return time.Time{}, 0, false
+			}
+		}
+	}
+	limit := 0
+	if rawLimit != "" {
+		var err error
+		if limit, err = strconv.Atoi(rawLimit); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit must be a number"})
+			return time.Time{}, 0, false
+		}
+	}
+	return since, limit, true
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrLocationNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, errInvalidBBox), errors.Is(err, geo.ErrInvalidBoundingBox), errors.Is(err, geo.ErrInvalidLatitude),
+		errors.Is(err, geo.ErrInvalidLongitude), errors.Is(err, geo.ErrInvalidRadius), errors.Is(err, geo.ErrInvalidZoom):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	case errors.Is(err, errInvalidGeometry), errors.Is(err, geo.ErrRegionTooSmall), errors.Is(err, geo.ErrRegionTooLarge),
+		errors.Is(err, geo.ErrPlaceNameTooLong):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
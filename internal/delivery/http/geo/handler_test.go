@@ -0,0 +1,161 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/geo"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/geo"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+type fakeLocations struct {
+	box      geo.BoundingBox
+	since    time.Time
+	location geo.Location
+	pins     []app.Pin
+	clusters []geo.Cluster
+	err      error
+}
+
+func (f *fakeLocations) SetLocation(ctx context.Context, staffID, articleID string, location geo.Location) (*geo.ArticleLocation, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.location = location
+	return geo.NewArticleLocation(articleID, location, staffID)
+}
+
+func (f *fakeLocations) RemoveLocation(ctx context.Context, articleID string) error {
+	return f.err
+}
+
+func (f *fakeLocations) Location(ctx context.Context, articleID string) (*geo.ArticleLocation, error) {
+	return nil, f.err
+}
+
+func (f *fakeLocations) Within(ctx context.Context, box geo.BoundingBox, since time.Time, limit int) ([]app.Pin, error) {
+	f.box, f.since = box, since
+	return f.pins, f.err
+}
+
+func (f *fakeLocations) Near(ctx context.Context, center geo.Point, radiusKm float64, since time.Time, limit int) ([]app.Pin, error) {
+	return f.pins, f.err
+}
+
+func (f *fakeLocations) Clusters(ctx context.Context, box geo.BoundingBox, zoom int, since time.Time) ([]geo.Cluster, error) {
+	if _, err := geo.GridSize(zoom); err != nil {
+		return nil, err
+	}
+	return f.clusters, f.err
+}
+
+func testPin(t *testing.T) app.Pin {
+	t.Helper()
+	point, _ := geo.NewPointLocation(geo.Point{Lat: -6.2241, Lng: 106.865}, "Kampung Pulo")
+	tagged, _ := geo.NewArticleLocation("a1", *point, "editor-1")
+	published := time.Date(2025, time.June, 1, 8, 0, 0, 0, time.UTC)
+	tagged.PublishedAt = &published
+	return app.Pin{Location: tagged, Summary: geo.ArticleSummary{Ref: permalink.ArticleRef{ID: "a1"}, Title: "Floods"}, Path: "/metro/floods"}
+}
+
+func TestHandler_Within(t *testing.T) {
+	locations := &fakeLocations{pins: []app.Pin{testPin(t)}}
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(locations, "https://news.example.com"))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/map/articles?bbox=106.6,-6.4,107.0,-6.0&since=2025-05-01", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if locations.box != (geo.BoundingBox{South: -6.4, West: 106.6, North: -6.0, East: 107.0}) || locations.since.Month() != time.May {
+		t.Errorf("unexpected query %+v since %v", locations.box, locations.since)
+	}
+
+	var resp featureCollection
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Type != "FeatureCollection" || len(resp.Features) != 1 {
+		t.Fatalf("expected one feature, got %+v", resp)
+	}
+	f := resp.Features[0]
+	if f.Geometry.Type != "Point" || string(f.Geometry.Coordinates) != "[106.865,-6.2241]" {
+		t.Errorf("expected a [lng, lat] point, got %s %s", f.Geometry.Type, f.Geometry.Coordinates)
+	}
+	if f.Properties["url"] != "https://news.example.com/metro/floods" || f.Properties["place_name"] != "Kampung Pulo" {
+		t.Errorf("unexpected properties %+v", f.Properties)
+	}
+
+	for _, url := range []string{"/map/articles?bbox=1,2,3", "/map/articles?bbox=107,-6.4,106,-6.0", "/map/articles?bbox=106.6,-6.4,107.0,-6.0&since=yesterday"} {
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %s, got %d", url, rec.Code)
+		}
+	}
+}
+
+func TestHandler_Clusters(t *testing.T) {
+	locations := &fakeLocations{clusters: []geo.Cluster{
+		{Center: geo.Point{Lat: -6.2, Lng: 106.8}, Count: 12},
+		{Center: geo.Point{Lat: -8.65, Lng: 115.2}, Count: 1, ArticleID: "a7"},
+	}}
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(locations, ""))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/map/clusters?bbox=95,-11,141,6&zoom=5", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp featureCollection
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if len(resp.Features) != 2 || resp.Features[0].Properties["count"] != float64(12) || resp.Features[1].Properties["article_id"] != "a7" {
+		t.Errorf("unexpected clusters %+v", resp.Features)
+	}
+	if _, ok := resp.Features[0].Properties["article_id"]; ok {
+		t.Error("expected no article_id on a multi-article cluster")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/map/clusters?bbox=95,-11,141,6&zoom=30", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Set(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedKind   geo.LocationKind
+	}{
+		{"point", `{"geometry":{"type":"Point","coordinates":[106.865,-6.2241]},"place_name":"Kampung Pulo"}`, http.StatusOK, geo.KindPoint},
+		{"region", `{"geometry":{"type":"Polygon","coordinates":[[[106.8,-6.3],[106.9,-6.3],[106.9,-6.2],[106.8,-6.3]]]}}`, http.StatusOK, geo.KindRegion},
+		{"line", `{"geometry":{"type":"LineString","coordinates":[[106.8,-6.3],[106.9,-6.3]]}}`, http.StatusUnprocessableEntity, ""},
+		{"degenerate region", `{"geometry":{"type":"Polygon","coordinates":[[[106.8,-6.3],[106.9,-6.3]]]}}`, http.StatusUnprocessableEntity, ""},
+		{"latitude out of range", `{"geometry":{"type":"Point","coordinates":[106.8,-96]}}`, http.StatusBadRequest, ""},
+	}
+
+	staff := func(r *http.Request) (string, bool) { return "editor-1", true }
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			locations := &fakeLocations{}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/admin/articles/a1/location", strings.NewReader(tc.body))
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(locations, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if locations.location.Kind != tc.expectedKind {
+				t.Errorf("expected kind '%s', got '%s'", tc.expectedKind, locations.location.Kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package institution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/institution"
+)
+
+// defaultUsageDays is the usage window when the request gives no dates
+const defaultUsageDays = 30
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Institutions is the part of the institution service the admin endpoints need
+type Institutions interface {
+	Create(ctx context.Context, staffID, name string, contract institution.Contract, ranges []string) (*institution.Institution, error)
+	Get(ctx context.Context, id string) (*institution.Institution, error)
+	List(ctx context.Context) ([]*institution.Institution, error)
+	SetRanges(ctx context.Context, id string, ranges []string) (*institution.Institution, error)
+	Renew(ctx context.Context, id string, contract institution.Contract) (*institution.Institution, error)
+	Suspend(ctx context.Context, id, reason string) (*institution.Institution, error)
+	Reinstate(ctx context.Context, id string) (*institution.Institution, error)
+	IssueProxyToken(ctx context.Context, id, label string) (string, *institution.ProxyToken, error)
+	RevokeProxyToken(ctx context.Context, id, tokenID string) (*institution.Institution, error)
+	Usage(ctx context.Context, id string, from, to time.Time) ([]institution.DailyUsage, error)
+	Alerts(ctx context.Context) ([]*institution.Alert, error)
+	ResolveAlert(ctx context.Context, staffID, alertID string, actioned bool) (*institution.Alert, error)
+}
+
+// contractRequest has inclusive dates, end is the last day of access
+type contractRequest struct {
+	Reference string `json:"reference"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+}
+
+type createRequest struct {
+	Name     string          `json:"name"`
+	Contract contractRequest `json:"contract"`
+	Ranges   []string        `json:"ranges"`
+}
+
+type rangesRequest struct {
+	Ranges []string `json:"ranges"`
+}
+
+type suspendRequest struct {
+	Reason string `json:"reason"`
+}
+
+type tokenRequest struct {
+	Label string `json:"label"`
+}
+
+type resolveAlertRequest struct {
+	Actioned bool `json:"actioned"`
+}
+
+type contractResponse struct {
+	Reference string `json:"reference"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	DaysLeft  int    `json:"days_left"`
+}
+
+type tokenResponse struct {
+	ID        string  `json:"id"`
+	Label     string  `json:"label"`
+	CreatedAt string  `json:"created_at"`
+	RevokedAt *string `json:"revoked_at,omitempty"`
+}
+
+type institutionResponse struct {
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	Status          string           `json:"status"`
+	SuspendedReason *string          `json:"suspended_reason,omitempty"`
+	GrantsAccess    bool             `json:"grants_access"`
+	Contract        contractResponse `json:"contract"`
+	Ranges          []string         `json:"ranges"`
+	ProxyTokens     []tokenResponse  `json:"proxy_tokens"`
+	CreatedAt       string           `json:"created_at"`
+	UpdatedAt       string           `json:"updated_at"`
+}
+
+// issuedTokenResponse is the only time the raw token is shown
+type issuedTokenResponse struct {
+	tokenResponse
+	Token string `json:"token"`
+}
+
+type usageResponse struct {
+	Day            string `json:"day"`
+	Reads          int    `json:"reads"`
+	UniqueArticles int    `json:"unique_articles"`
+}
+
+type alertResponse struct {
+	ID            string  `json:"id"`
+	InstitutionID string  `json:"institution_id"`
+	Source        string  `json:"source"`
+	Value         string  `json:"value"`
+	Hour          string  `json:"hour"`
+	Requests      int     `json:"requests"`
+	Baseline      float64 `json:"baseline"`
+	Status        string  `json:"status"`
+	DetectedAt    string  `json:"detected_at"`
+	ResolvedBy    *string `json:"resolved_by,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	institutions Institutions
+	staff        StaffResolver
+}
+
+func NewHandler(institutions Institutions, staff StaffResolver) *Handler {
+	return &Handler{institutions: institutions, staff: staff}
+}
+
+// NewAdminRouter mounts institution licences, usage and abuse alerts, it must sit behind admin authentication
+func NewAdminRouter(institutions Institutions, staff StaffResolver) http.Handler {
+	h := NewHandler(institutions, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/institutions", h.List)
+	mux.HandleFunc("POST /admin/institutions", h.Create)
+	mux.HandleFunc("GET /admin/institutions/{id}", h.Get)
+	mux.HandleFunc("PUT /admin/institutions/{id}/ranges", h.SetRanges)
+	mux.HandleFunc("PUT /admin/institutions/{id}/contract", h.Renew)
+	mux.HandleFunc("POST /admin/institutions/{id}/suspend", h.Suspend)
+	mux.HandleFunc("POST /admin/institutions/{id}/reinstate", h.Reinstate)
+	mux.HandleFunc("POST /admin/institutions/{id}/proxy-tokens", h.IssueProxyToken)
+	mux.HandleFunc("DELETE /admin/institutions/{id}/proxy-tokens/{tokenID}", h.RevokeProxyToken)
+	mux.HandleFunc("GET /admin/institutions/{id}/usage", h.Usage)
+	mux.HandleFunc("GET /admin/institutions/alerts", h.Alerts)
+	mux.HandleFunc("POST /admin/institutions/alerts/{id}/resolve", h.ResolveAlert)
+	return mux
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	all, err := h.institutions.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]institutionResponse, 0, len(all))
+	for _, i := range all {
+		resp = append(resp, toInstitutionResponse(i))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	contract, err := req.Contract.toContract()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	i, err := h.institutions.Create(r.Context(), staffID, req.Name, contract, req.Ranges)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toInstitutionResponse(i))
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	i, err := h.institutions.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInstitutionResponse(i))
+}
+
+// SetRanges replaces every range, an empty list leaves only the proxy tokens
+func (h *Handler) SetRanges(w http.ResponseWriter, r *http.Request) {
+	var req rangesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	i, err := h.institutions.SetRanges(r.Context(), r.PathValue("id"), req.Ranges)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInstitutionResponse(i))
+}
+
+func (h *Handler) Renew(w http.ResponseWriter, r *http.Request) {
+	var req contractRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	contract, err := req.toContract()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	i, err := h.institutions.Renew(r.Context(), r.PathValue("id"), contract)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInstitutionResponse(i))
+}
+
+func (h *Handler) Suspend(w http.ResponseWriter, r *http.Request) {
+	var req suspendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	if req.Reason == "" {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: "reason is required"})
+		return
+	}
+	i, err := h.institutions.Suspend(r.Context(), r.PathValue("id"), req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInstitutionResponse(i))
+}
+
+func (h *Handler) Reinstate(w http.ResponseWriter, r *http.Request) {
+	i, err := h.institutions.Reinstate(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toInstitutionResponse(i))
+}
+
+func (h *Handler) IssueProxyToken(w http.ResponseWriter, r *http.Request) {
+	var req tokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	token, issued, err := h.institutions.IssueProxyToken(r.Context(), r.PathValue("id"), req.Label)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, issuedTokenResponse{tokenResponse: toTokenResponse(*issued), Token: token})
+}
+
+func (h *Handler) RevokeProxyToken(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.institutions.RevokeProxyToken(r.Context(), r.PathValue("id"), r.PathValue("tokenID")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Usage reports daily reads, dates are inclusive UTC days and default to the last 30
+func (h *Handler) Usage(w http.ResponseWriter, r *http.Request) {
+	today := institution.Day(time.Now())
+	to, err := dateParam(r.URL.Query().Get("to"), today)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "to must be a date like 2026-03-31"})
+		return
+	}
+	from, err := dateParam(r.URL.Query().Get("from"), to.AddDate(0, 0, 1-defaultUsageDays))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "from must be a date like 2026-03-01"})
+		return
+	}
+
+	usage, err := h.institutions.Usage(r.Context(), r.PathValue("id"), from, to.AddDate(0, 0, 1))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]usageResponse, 0, len(usage))
+	for _, u := range usage {
+		resp = append(resp, usageResponse{Day: u.Day.Format(time.DateOnly), Reads: u.Reads, UniqueArticles: u.UniqueArticles})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Alerts(w http.ResponseWriter, r *http.Request) {
+	alerts, err := h.institutions.Alerts(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]alertResponse, 0, len(alerts))
+	for _, a := range alerts {
+		resp = append(resp, toAlertResponse(a))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ResolveAlert closes an alert, "actioned" records that staff suspended the
+// institution or revoked the token rather than judging the spike legitimate
+func (h *Handler) ResolveAlert(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req resolveAlertRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	alert, err := h.institutions.ResolveAlert(r.Context(), staffID, r.PathValue("id"), req.Actioned)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toAlertResponse(alert))
+}
+
+func (c contractRequest) toContract() (institution.Contract, error) {
+	start, err := time.Parse(time.DateOnly, c.Start)
+	if err != nil {
+		return institution.Contract{}, institution.ErrInvalidContract
+	}
+	end, err := time.Parse(time.DateOnly, c.End)
+	if err != nil {
+		return institution.Contract{}, institution.ErrInvalidContract
+	}
+	return institution.NewContract(c.Reference, start, end.AddDate(0, 0, 1))
+}
+
+func dateParam(raw string, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
+
+// writeError maps domain errors by kind, the code tells e.g. a suspended
+// institution from an alert already resolved
+func writeError(w http.ResponseWriter, err error) {
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error(), Code: de.Code})
+}
+
+func toInstitutionResponse(i *institution.Institution) institutionResponse {
+	now := time.Now()
+	resp := institutionResponse{
+		ID:              i.ID,
+		Name:            i.Name,
+		Status:          string(i.Status),
+		SuspendedReason: i.SuspendedReason,
+		GrantsAccess:    i.GrantsAccess(now),
+		Contract: contractResponse{
+			Reference: i.Contract.Reference,
+			Start:     i.Contract.Start.Format(time.DateOnly),
+			End:       i.Contract.End.AddDate(0, 0, -1).Format(time.DateOnly),
+			DaysLeft:  i.Contract.DaysLeft(now),
+		},
+		Ranges:      make([]string, 0, len(i.Ranges)),
+		ProxyTokens: make([]tokenResponse, 0, len(i.Proxies)),
+		CreatedAt:   i.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   i.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, prefix := range i.Ranges {
+		resp.Ranges = append(resp.Ranges, prefix.String())
+	}
+	for _, t := range i.Proxies {
+		resp.ProxyTokens = append(resp.ProxyTokens, toTokenResponse(t))
+	}
+	return resp
+}
+
+func toTokenResponse(t institution.ProxyToken) tokenResponse {
+	resp := tokenResponse{ID: t.ID, Label: t.Label, CreatedAt: t.CreatedAt.Format(time.RFC3339)}
+	if t.RevokedAt != nil {
+		at := t.RevokedAt.Format(time.RFC3339)
+		resp.RevokedAt = &at
+	}
+	return resp
+}
+
+func toAlertResponse(a *institution.Alert) alertResponse {
+	return alertResponse{
+		ID:            a.ID,
+		InstitutionID: a.Source.InstitutionID,
+		Source:        string(a.Source.Kind),
+		Value:         a.Source.Value,
+		Hour:          a.Hour.Format(time.RFC3339),
+		Requests:      a.Requests,
+		Baseline:      a.Baseline,
+		Status:        string(a.Status),
+		DetectedAt:    a.DetectedAt.Format(time.RFC3339),
+		ResolvedBy:    a.ResolvedBy,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
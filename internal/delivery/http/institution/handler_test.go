@@ -0,0 +1,202 @@
+package institution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/institution"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/institution"
+)
+
+type fakeInstitutions struct {
+	err      error
+	contract institution.Contract
+	from, to time.Time
+}
+
+func (f *fakeInstitutions) institution() *institution.Institution {
+	now := time.Now()
+	contract, _ := institution.NewContract("UI-2026-014", now.AddDate(0, -1, 0), now.AddDate(0, 11, 0))
+	i, _ := institution.NewInstitution("inst-1", "Universitas Indonesia", contract, "admin-1", now)
+	_ = i.SetRanges([]string{"152.118.0.0/16"}, now)
+	return i
+}
+
+func (f *fakeInstitutions) Create(ctx context.Context, staffID, name string, contract institution.Contract, ranges []string) (*institution.Institution, error) {
+	f.contract = contract
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.institution(), nil
+}
+
+func (f *fakeInstitutions) Get(ctx context.Context, id string) (*institution.Institution, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.institution(), nil
+}
+
+func (f *fakeInstitutions) List(ctx context.Context) ([]*institution.Institution, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*institution.Institution{f.institution()}, nil
+}
+
+func (f *fakeInstitutions) SetRanges(ctx context.Context, id string, ranges []string) (*institution.Institution, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeInstitutions) Renew(ctx context.Context, id string, contract institution.Contract) (*institution.Institution, error) {
+	f.contract = contract
+	return f.Get(ctx, id)
+}
+
+func (f *fakeInstitutions) Suspend(ctx context.Context, id, reason string) (*institution.Institution, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeInstitutions) Reinstate(ctx context.Context, id string) (*institution.Institution, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeInstitutions) IssueProxyToken(ctx context.Context, id, label string) (string, *institution.ProxyToken, error) {
+	if f.err != nil {
+		return "", nil, f.err
+	}
+	return "raw-token", &institution.ProxyToken{ID: "tok-1", Label: label, TokenHash: "hash", CreatedAt: time.Now()}, nil
+}
+
+func (f *fakeInstitutions) RevokeProxyToken(ctx context.Context, id, tokenID string) (*institution.Institution, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeInstitutions) Usage(ctx context.Context, id string, from, to time.Time) ([]institution.DailyUsage, error) {
+	f.from, f.to = from, to
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []institution.DailyUsage{{Day: from, Reads: 120, UniqueArticles: 80}}, nil
+}
+
+func (f *fakeInstitutions) Alerts(ctx context.Context) ([]*institution.Alert, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	a, _ := institution.NewAlert("alert-1", institution.Volume{Source: institution.Source{InstitutionID: "inst-1", Kind: institution.SourceProxy, Value: "tok-1"}, Requests: 3000}, 20, time.Now())
+	return []*institution.Alert{a}, nil
+}
+
+func (f *fakeInstitutions) ResolveAlert(ctx context.Context, staffID, alertID string, actioned bool) (*institution.Alert, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	alerts, _ := f.Alerts(ctx)
+	_ = alerts[0].Resolve(staffID, actioned, time.Now())
+	return alerts[0], nil
+}
+
+func staff(r *http.Request) (string, bool) {
+	return "admin-1", true
+}
+
+func noStaff(r *http.Request) (string, bool) {
+	return "", false
+}
+
+const createBody = `{"name": "Universitas Indonesia", "contract": {"reference": "UI-2026-014", "start": "2026-01-01", "end": "2026-12-31"}, "ranges": ["152.118.0.0/16"]}`
+
+func TestAdminRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		staff          StaffResolver
+		err            error
+		expectedStatus int
+	}{
+		{"list", http.MethodGet, "/admin/institutions", "", staff, nil, http.StatusOK},
+		{"create", http.MethodPost, "/admin/institutions", createBody, staff, nil, http.StatusCreated},
+		{"create without session", http.MethodPost, "/admin/institutions", createBody, noStaff, nil, http.StatusUnauthorized},
+		{"create invalid body", http.MethodPost, "/admin/institutions", `{"name":`, staff, nil, http.StatusBadRequest},
+		{"create invalid contract date", http.MethodPost, "/admin/institutions", `{"name": "UI", "contract": {"reference": "UI", "start": "01/01/2026", "end": "2026-12-31"}}`, staff, nil, http.StatusUnprocessableEntity},
+		{"create overlapping range", http.MethodPost, "/admin/institutions", createBody, staff, app.ErrRangeTaken, http.StatusConflict},
+		{"create broad range", http.MethodPost, "/admin/institutions", createBody, staff, institution.ErrRangeTooBroad, http.StatusUnprocessableEntity},
+		{"get", http.MethodGet, "/admin/institutions/inst-1", "", staff, nil, http.StatusOK},
+		{"get unknown", http.MethodGet, "/admin/institutions/inst-9", "", staff, app.ErrInstitutionNotFound, http.StatusNotFound},
+		{"set ranges", http.MethodPut, "/admin/institutions/inst-1/ranges", `{"ranges": ["152.118.0.0/16"]}`, staff, nil, http.StatusOK},
+		{"renew", http.MethodPut, "/admin/institutions/inst-1/contract", `{"reference": "UI-2027", "start": "2027-01-01", "end": "2027-12-31"}`, staff, nil, http.StatusOK},
+		{"suspend", http.MethodPost, "/admin/institutions/inst-1/suspend", `{"reason": "scraping"}`, staff, nil, http.StatusOK},
+		{"suspend without reason", http.MethodPost, "/admin/institutions/inst-1/suspend", `{}`, staff, nil, http.StatusUnprocessableEntity},
+		{"suspend twice", http.MethodPost, "/admin/institutions/inst-1/suspend", `{"reason": "scraping"}`, staff, institution.ErrNotActive, http.StatusConflict},
+		{"reinstate", http.MethodPost, "/admin/institutions/inst-1/reinstate", "", staff, nil, http.StatusOK},
+		{"issue token", http.MethodPost, "/admin/institutions/inst-1/proxy-tokens", `{"label": "EZproxy"}`, staff, nil, http.StatusCreated},
+		{"too many tokens", http.MethodPost, "/admin/institutions/inst-1/proxy-tokens", `{"label": "EZproxy"}`, staff, institution.ErrTooManyTokens, http.StatusConflict},
+		{"revoke token", http.MethodDelete, "/admin/institutions/inst-1/proxy-tokens/tok-1", "", staff, nil, http.StatusNoContent},
+		{"revoke unknown token", http.MethodDelete, "/admin/institutions/inst-1/proxy-tokens/tok-9", "", staff, institution.ErrTokenNotFound, http.StatusNotFound},
+		{"usage", http.MethodGet, "/admin/institutions/inst-1/usage?from=2026-03-01&to=2026-03-31", "", staff, nil, http.StatusOK},
+		{"usage invalid date", http.MethodGet, "/admin/institutions/inst-1/usage?from=March", "", staff, nil, http.StatusBadRequest},
+		{"usage too long", http.MethodGet, "/admin/institutions/inst-1/usage?from=2024-01-01", "", staff, app.ErrInvalidUsageRange, http.StatusUnprocessableEntity},
+		{"alerts", http.MethodGet, "/admin/institutions/alerts", "", staff, nil, http.StatusOK},
+		{"resolve alert", http.MethodPost, "/admin/institutions/alerts/alert-1/resolve", `{"actioned": true}`, staff, nil, http.StatusOK},
+		{"resolve alert without session", http.MethodPost, "/admin/institutions/alerts/alert-1/resolve", `{}`, noStaff, nil, http.StatusUnauthorized},
+		{"resolve closed alert", http.MethodPost, "/admin/institutions/alerts/alert-1/resolve", `{}`, staff, institution.ErrAlertNotOpen, http.StatusConflict},
+		{"store failure", http.MethodGet, "/admin/institutions", "", staff, errors.New("connection reset"), http.StatusInternalServerError},
+		{"timeout", http.MethodGet, "/admin/institutions/inst-1", "", staff, context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeInstitutions{err: tc.err}, tc.staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminRouter_ContractDates(t *testing.T) {
+	institutions := &fakeInstitutions{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(institutions, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/institutions", strings.NewReader(createBody)))
+
+	if !institutions.contract.End.Equal(time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the last day to be included, got %v", institutions.contract.End)
+	}
+
+	var resp institutionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Ranges) != 1 || resp.Ranges[0] != "152.118.0.0/16" || !resp.GrantsAccess {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestAdminRouter_IssueProxyToken(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeInstitutions{}, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/institutions/inst-1/proxy-tokens", strings.NewReader(`{"label": "EZproxy"}`)))
+
+	var resp map[string]any
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp["token"] != "raw-token" || resp["id"] != "tok-1" || resp["label"] != "EZproxy" {
+		t.Errorf("expected the raw token shown once, got %v", resp)
+	}
+	if _, ok := resp["token_hash"]; ok {
+		t.Error("expected the hash not to be exposed")
+	}
+}
@@ -0,0 +1,153 @@
+package jobwatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/jobwatch"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Monitor is the part of the job watch service the endpoints need
+type Monitor interface {
+	ReportSuccess(ctx context.Context, name string, at time.Time, duration time.Duration) error
+	ReportFailure(ctx context.Context, name string, at time.Time, message string) error
+	Statuses(ctx context.Context, at time.Time) ([]app.JobStatus, error)
+}
+
+type heartbeatRequest struct {
+	Status     string `json:"status"` // "success" or "failure"
+	DurationMS int64  `json:"duration_ms"`
+	Error      string `json:"error"`
+}
+
+type jobResponse struct {
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	IntervalSeconds int64   `json:"interval_seconds"`
+	GraceSeconds    int64   `json:"grace_seconds"`
+	LastSuccessAt   *string `json:"last_success_at,omitempty"`
+	LastDurationMS  int64   `json:"last_duration_ms,omitempty"`
+	LastFailureAt   *string `json:"last_failure_at,omitempty"`
+	LastError       *string `json:"last_error,omitempty"`
+	DeadlineAt      string  `json:"deadline_at"`
+}
+
+type statusResponse struct {
+	Jobs []jobResponse `json:"jobs"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	monitor Monitor
+}
+
+func NewHandler(monitor Monitor) *Handler {
+	return &Handler{monitor: monitor}
+}
+
+// NewAdminRouter mounts the job status and heartbeat endpoints, it must sit behind admin authentication.
+// External cron runners post their heartbeat with an admin service token.
+func NewAdminRouter(monitor Monitor) http.Handler {
+	h := NewHandler(monitor)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/jobs", h.Status)
+	mux.HandleFunc("POST /admin/jobs/{name}/heartbeat", h.Heartbeat)
+	return mux
+}
+
+func (h *Handler) Status(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.monitor.Statuses(r.Context(), time.Now())
+	if err != nil {
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to load job status"})
+		return
+	}
+
+	resp := statusResponse{Jobs: make([]jobResponse, 0, len(statuses))}
+	for _, s := range statuses {
+		resp.Jobs = append(resp.Jobs, toJobResponse(s))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	var req heartbeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	name := r.PathValue("name")
+	now := time.Now()
+
+	var err error
+	switch req.Status {
+	case "success":
+		if req.DurationMS < 0 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "duration_ms cannot be negative"})
+			return
+		}
+		err = h.monitor.ReportSuccess(r.Context(), name, now, time.Duration(req.DurationMS)*time.Millisecond)
+	case "failure":
+		if req.Error == "" {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "error is required for a failure"})
+			return
+		}
+		err = h.monitor.ReportFailure(r.Context(), name, now, req.Error)
+	default:
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "status must be success or failure"})
+		return
+	}
+
+	if err != nil {
+		if errors.Is(err, app.ErrJobNotRegistered) {
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+			return
+		}
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to record heartbeat"})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toJobResponse(s app.JobStatus) jobResponse {
+	job := s.Job
+	resp := jobResponse{
+		Name:            job.Name,
+		Status:          string(s.Status),
+		IntervalSeconds: int64(job.Interval / time.Second),
+		GraceSeconds:    int64(job.Grace / time.Second),
+		LastError:       job.LastError,
+		DeadlineAt:      job.DeadlineAt().Format(time.RFC3339),
+	}
+	if job.LastSuccessAt != nil {
+		at := job.LastSuccessAt.Format(time.RFC3339)
+		resp.LastSuccessAt = &at
+		resp.LastDurationMS = job.LastDuration.Milliseconds()
+	}
+	if job.LastFailureAt != nil {
+		at := job.LastFailureAt.Format(time.RFC3339)
+		resp.LastFailureAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
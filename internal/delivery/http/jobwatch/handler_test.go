@@ -0,0 +1,104 @@
+package jobwatch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/jobwatch"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/jobwatch"
+)
+
+type fakeMonitor struct {
+	successes map[string]time.Duration
+	failures  map[string]string
+	statuses  []app.JobStatus
+}
+
+func (f *fakeMonitor) ReportSuccess(ctx context.Context, name string, at time.Time, duration time.Duration) error {
+	if name == "unknown-job" {
+		return app.ErrJobNotRegistered
+	}
+	f.successes[name] = duration
+	return nil
+}
+
+func (f *fakeMonitor) ReportFailure(ctx context.Context, name string, at time.Time, message string) error {
+	f.failures[name] = message
+	return nil
+}
+
+func (f *fakeMonitor) Statuses(ctx context.Context, at time.Time) ([]app.JobStatus, error) {
+	return f.statuses, nil
+}
+
+func TestHandler_Heartbeat(t *testing.T) {
+	monitor := &fakeMonitor{successes: map[string]time.Duration{}, failures: map[string]string{}}
+	router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(monitor))
+
+	testCases := []struct {
+		name     string
+		job      string
+		body     string
+		expected int
+	}{
+		{name: "success", job: "sitemap-rebuild", body: `{"status":"success","duration_ms":1500}`, expected: http.StatusNoContent},
+		{name: "failure", job: "cache-purge", body: `{"status":"failure","error":"cdn: 429"}`, expected: http.StatusNoContent},
+		{name: "failure without error", job: "cache-purge", body: `{"status":"failure"}`, expected: http.StatusBadRequest},
+		{name: "unknown status", job: "cache-purge", body: `{"status":"done"}`, expected: http.StatusBadRequest},
+		{name: "unregistered job", job: "unknown-job", body: `{"status":"success"}`, expected: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/jobs/"+tc.job+"/heartbeat", strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expected {
+				t.Errorf("expected status %d, got %d: %s", tc.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if monitor.successes["sitemap-rebuild"] != 1500*time.Millisecond {
+		t.Errorf("expected duration 1.5s, got %v", monitor.successes["sitemap-rebuild"])
+	}
+	if monitor.failures["cache-purge"] != "cdn: 429" {
+		t.Errorf("expected failure message, got %q", monitor.failures["cache-purge"])
+	}
+}
+
+func TestHandler_Status(t *testing.T) {
+	registered := time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC)
+	job, _ := jobwatch.NewJob("newsletter-send", 24*time.Hour, time.Hour, registered)
+	job.RecordSuccess(registered.Add(24*time.Hour), 2*time.Minute)
+
+	router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeMonitor{statuses: []app.JobStatus{{Job: job, Status: jobwatch.StatusOK}}}))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/jobs", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp statusResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	got := resp.Jobs[0]
+	if got.Name != "newsletter-send" || got.Status != "ok" || got.IntervalSeconds != 86400 {
+		t.Errorf("unexpected job %+v", got)
+	}
+	if got.LastSuccessAt == nil || *got.LastSuccessAt != "2025-08-02T00:00:00Z" || got.LastDurationMS != 120000 {
+		t.Errorf("expected last run details, got %+v", got)
+	}
+	if got.DeadlineAt != "2025-08-03T01:00:00Z" {
+		t.Errorf("expected deadline 2025-08-03T01:00:00Z, got %s", got.DeadlineAt)
+	}
+}
@@ -0,0 +1,259 @@
+package letter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// maxSubmitBytes fits a 500 word letter with room for the author fields
+const maxSubmitBytes = 64 << 10
+
+// MemberResolver returns the signed-in reader's account ID
+type MemberResolver func(r *http.Request) (string, bool)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Letters is the part of the letter service the endpoints need
+type Letters interface {
+	Submit(ctx context.Context, input app.SubmitInput) (*letter.LetterSubmission, error)
+	EditorialQueue(ctx context.Context, limit, offset int) ([]*letter.LetterSubmission, error)
+	Get(ctx context.Context, letterID string) (*letter.LetterSubmission, error)
+	Shortlist(ctx context.Context, letterID, editorID string) (*letter.LetterSubmission, error)
+	Decline(ctx context.Context, letterID, editorID, reason string) (*letter.LetterSubmission, error)
+	Publish(ctx context.Context, letterID, editorID string) (*letter.LetterSubmission, error)
+}
+
+type submitRequest struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"` // Ignored for signed-in readers, their account email is used
+	City    string `json:"city"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+type declineRequest struct {
+	Reason string `json:"reason"`
+}
+
+// receiptResponse is all a reader gets back, the letter itself stays with the desk
+type receiptResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type letterResponse struct {
+	ID            string  `json:"id"`
+	Status        string  `json:"status"`
+	AccountID     *string `json:"account_id,omitempty"`
+	AuthorName    string  `json:"author_name"`
+	AuthorEmail   string  `json:"author_email"`
+	AuthorCity    string  `json:"author_city,omitempty"`
+	Attribution   string  `json:"attribution"`
+	Subject       string  `json:"subject"`
+	Body          string  `json:"body"`
+	ReviewedBy    *string `json:"reviewed_by,omitempty"`
+	DeclineReason *string `json:"decline_reason,omitempty"`
+	ArticleID     *string `json:"article_id,omitempty"`
+	Acknowledged  bool    `json:"acknowledged"`
+	ReceivedAt    string  `json:"received_at"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	letters Letters
+	member  MemberResolver
+	staff   StaffResolver
+}
+
+func NewHandler(letters Letters, member MemberResolver, staff StaffResolver) *Handler {
+	return &Handler{letters: letters, member: member, staff: staff}
+}
+
+// NewRouter mounts the public submission form. Anyone may write in, a signed-in
+// reader's letter is tied to their account.
+func NewRouter(letters Letters, member MemberResolver) http.Handler {
+	h := NewHandler(letters, member, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /letters", h.Submit)
+	return mux
+}
+
+// NewAdminRouter mounts the editorial queue, it must sit behind admin authentication
+func NewAdminRouter(letters Letters, staff StaffResolver) http.Handler {
+	h := NewHandler(letters, nil, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/letters", h.Queue)
+	mux.HandleFunc("GET /admin/letters/{id}", h.Get)
+	mux.HandleFunc("POST /admin/letters/{id}/shortlist", h.Shortlist)
+	mux.HandleFunc("POST /admin/letters/{id}/decline", h.Decline)
+	mux.HandleFunc("POST /admin/letters/{id}/publish", h.Publish)
+	return mux
+}
+
+func (h *Handler) Submit(w http.ResponseWriter, r *http.Request) {
+	var req submitRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSubmitBytes)).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: "letter is too large"})
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	input := app.SubmitInput{Name: req.Name, Email: req.Email, City: req.City, Subject: req.Subject, Body: req.Body}
+	if h.member != nil {
+		if accountID, ok := h.member(r); ok {
+			input.AccountID = &accountID
+		}
+	}
+
+	l, err := h.letters.Submit(r.Context(), input)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, receiptResponse{ID: l.ID, Status: string(l.Status)})
+}
+
+// Queue lists received and shortlisted letters oldest first
+func (h *Handler) Queue(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	letters, err := h.letters.EditorialQueue(r.Context(), limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]letterResponse, 0, len(letters))
+	for _, l := range letters {
+		resp = append(resp, toLetterResponse(l))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	l, err := h.letters.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toLetterResponse(l))
+}
+
+func (h *Handler) Shortlist(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	l, err := h.letters.Shortlist(r.Context(), r.PathValue("id"), editorID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toLetterResponse(l))
+}
+
+func (h *Handler) Decline(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req declineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	l, err := h.letters.Decline(r.Context(), r.PathValue("id"), editorID, req.Reason)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toLetterResponse(l))
+}
+
+// Publish turns a shortlisted letter into an article draft on the letters page
+func (h *Handler) Publish(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	l, err := h.letters.Publish(r.Context(), r.PathValue("id"), editorID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toLetterResponse(l))
+}
+
+func toLetterResponse(l *letter.LetterSubmission) letterResponse {
+	return letterResponse{
+		ID:            l.ID,
+		Status:        string(l.Status),
+		AccountID:     l.AccountID,
+		AuthorName:    l.Author.Name(),
+		AuthorEmail:   l.Author.Email().String(),
+		AuthorCity:    l.Author.City(),
+		Attribution:   l.Author.Attribution(),
+		Subject:       l.Subject,
+		Body:          l.Body,
+		ReviewedBy:    l.ReviewedBy,
+		DeclineReason: l.DeclineReason,
+		ArticleID:     l.ArticleID,
+		Acknowledged:  l.IsAcknowledged(),
+		ReceivedAt:    l.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrLetterNotFound), errors.Is(err, app.ErrAccountNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+		return
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	case shared.KindForbidden:
+		status = http.StatusForbidden
+	}
+	writeJSON(w, status, errorResponse{Error: de.Error(), Code: de.Code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,158 @@
+package letter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type fakeLetters struct {
+	input app.SubmitInput
+	err   error
+}
+
+func (f *fakeLetters) letter() (*letter.LetterSubmission, error) {
+	author, err := letter.NewAuthor("Jane Doe", "jane@example.com", "Bandung")
+	if err != nil {
+		return nil, err
+	}
+	return letter.NewLetterSubmission("ltr-1", *author, "Flooding", "The drains were never cleared.", nil)
+}
+
+func (f *fakeLetters) Submit(ctx context.Context, input app.SubmitInput) (*letter.LetterSubmission, error) {
+	f.input = input
+	if f.err != nil {
+		return nil, f.err
+	}
+	if strings.TrimSpace(input.Subject) == "" {
+		return nil, letter.ErrEmptySubject
+	}
+	return f.letter()
+}
+
+func (f *fakeLetters) EditorialQueue(ctx context.Context, limit, offset int) ([]*letter.LetterSubmission, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	l, _ := f.letter()
+	return []*letter.LetterSubmission{l}, nil
+}
+
+func (f *fakeLetters) Get(ctx context.Context, letterID string) (*letter.LetterSubmission, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.letter()
+}
+
+func (f *fakeLetters) Shortlist(ctx context.Context, letterID, editorID string) (*letter.LetterSubmission, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	l, _ := f.letter()
+	return l, l.Shortlist(editorID)
+}
+
+func (f *fakeLetters) Decline(ctx context.Context, letterID, editorID, reason string) (*letter.LetterSubmission, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	l, _ := f.letter()
+	if err := l.Decline(editorID, reason); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (f *fakeLetters) Publish(ctx context.Context, letterID, editorID string) (*letter.LetterSubmission, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	l, _ := f.letter()
+	if err := l.Publish(editorID, "art-1"); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func TestHandler(t *testing.T) {
+	member := func(r *http.Request) (string, bool) { return "member123", r.Header.Get("Cookie") != "" }
+	staff := func(r *http.Request) (string, bool) { return "editor1", r.Header.Get("Authorization") != "" }
+	submission := `{"name":"Jane Doe","email":"jane@example.com","city":"Bandung","subject":"Flooding","body":"The drains were never cleared."}`
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"submit", http.MethodPost, "/letters", submission, false, nil, http.StatusCreated},
+		{"submit invalid body", http.MethodPost, "/letters", `{`, false, nil, http.StatusBadRequest},
+		{"submit too large", http.MethodPost, "/letters", `{"body":"` + strings.Repeat("a", maxSubmitBytes) + `"}`, false, nil, http.StatusRequestEntityTooLarge},
+		{"submit without subject", http.MethodPost, "/letters", `{"name":"Jane Doe","email":"jane@example.com","body":"Body"}`, false, nil, http.StatusUnprocessableEntity},
+		{"submit invalid email", http.MethodPost, "/letters", submission, false, letter.ErrAuthorNameEmpty, http.StatusUnprocessableEntity},
+		{"submit store failure", http.MethodPost, "/letters", submission, false, errors.New("connection reset"), http.StatusInternalServerError},
+		{"queue", http.MethodGet, "/admin/letters", "", true, nil, http.StatusOK},
+		{"queue invalid page", http.MethodGet, "/admin/letters?limit=500", "", true, app.ErrInvalidPage, http.StatusUnprocessableEntity},
+		{"get", http.MethodGet, "/admin/letters/ltr-1", "", true, nil, http.StatusOK},
+		{"get missing", http.MethodGet, "/admin/letters/ltr-1", "", true, app.ErrLetterNotFound, http.StatusNotFound},
+		{"shortlist", http.MethodPost, "/admin/letters/ltr-1/shortlist", "", true, nil, http.StatusOK},
+		{"shortlist without session", http.MethodPost, "/admin/letters/ltr-1/shortlist", "", false, nil, http.StatusUnauthorized},
+		{"decline", http.MethodPost, "/admin/letters/ltr-1/decline", `{"reason":"Off topic"}`, true, nil, http.StatusOK},
+		{"decline without reason", http.MethodPost, "/admin/letters/ltr-1/decline", `{}`, true, nil, http.StatusUnprocessableEntity},
+		{"decline invalid body", http.MethodPost, "/admin/letters/ltr-1/decline", `{`, true, nil, http.StatusBadRequest},
+		{"publish not shortlisted", http.MethodPost, "/admin/letters/ltr-1/publish", "", true, nil, http.StatusConflict},
+		{"publish timeout", http.MethodPost, "/admin/letters/ltr-1/publish", "", true, &shared.TimeoutError{Operation: "letter.Update"}, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			letters := &fakeLetters{err: tc.err}
+			mux := http.NewServeMux()
+			mux.Handle("/letters", openapitest.Handler(t, RegisterSpec, NewRouter(letters, member)))
+			mux.Handle("/admin/", openapitest.Handler(t, RegisterSpec, NewAdminRouter(letters, staff)))
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_SubmitAsMember(t *testing.T) {
+	letters := &fakeLetters{}
+	member := func(r *http.Request) (string, bool) { return "member123", true }
+	req := httptest.NewRequest(http.MethodPost, "/letters", strings.NewReader(`{"name":"Jane Doe","subject":"Flooding","body":"Body"}`))
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(letters, member)).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if letters.input.AccountID == nil || *letters.input.AccountID != "member123" {
+		t.Errorf("expected the letter tied to the reader's account, got %+v", letters.input)
+	}
+	var receipt map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &receipt)
+	if _, leaked := receipt["author_email"]; leaked || receipt["status"] != "received" {
+		t.Errorf("expected only the receipt returned, got %v", receipt)
+	}
+}
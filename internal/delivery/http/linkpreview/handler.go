@@ -0,0 +1,90 @@
+package linkpreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/linkpreview"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Previewer is the part of the link preview service the endpoint needs
+type Previewer interface {
+	Preview(ctx context.Context, rawURL string, at time.Time) (*linkpreview.Card, error)
+}
+
+type cardResponse struct {
+	URL         string  `json:"url"`
+	Title       string  `json:"title"`
+	Description string  `json:"description,omitempty"`
+	ImageURL    string  `json:"image_url,omitempty"`
+	SiteName    string  `json:"site_name,omitempty"`
+	Internal    bool    `json:"internal"`
+	ArticleID   *string `json:"article_id,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	previewer Previewer
+}
+
+func NewHandler(previewer Previewer) *Handler {
+	return &Handler{previewer: previewer}
+}
+
+// NewAdminRouter mounts the preview lookup the editor calls when inserting a link card, it must sit behind admin authentication
+func NewAdminRouter(previewer Previewer) http.Handler {
+	h := NewHandler(previewer)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/link-preview", h.Preview)
+	return mux
+}
+
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	rawURL := r.URL.Query().Get("url")
+	if rawURL == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "url is required"})
+		return
+	}
+
+	card, err := h.previewer.Preview(r.Context(), rawURL, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, cardResponse{
+		URL:         card.URL,
+		Title:       card.Title,
+		Description: card.Description,
+		ImageURL:    card.ImageURL,
+		SiteName:    card.SiteName,
+		Internal:    card.Internal(),
+		ArticleID:   card.ArticleID,
+	})
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, linkpreview.ErrInvalidURL), errors.Is(err, linkpreview.ErrBlockedHost), errors.Is(err, linkpreview.ErrNotHTML):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case errors.Is(err, linkpreview.ErrFetchFailed):
+		// The wrapped detail names the remote status or network error, editors only need the outcome
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: linkpreview.ErrFetchFailed.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,64 @@
+package linkpreview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/linkpreview"
+)
+
+type fakePreviewer struct {
+	err error
+}
+
+func (f *fakePreviewer) Preview(ctx context.Context, rawURL string, at time.Time) (*linkpreview.Card, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &linkpreview.Card{URL: rawURL, Title: "Remote page", SiteName: "example.org"}, nil
+}
+
+func TestHandler_Preview(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+	}{
+		{"ok", "https://example.org/story", nil, http.StatusOK},
+		{"missing url", "", nil, http.StatusBadRequest},
+		{"invalid url", "ftp://example.org", linkpreview.ErrInvalidURL, http.StatusUnprocessableEntity},
+		{"private address", "http://10.0.0.1", linkpreview.ErrBlockedHost, http.StatusUnprocessableEntity},
+		{"remote error", "https://example.org/gone", fmt.Errorf("%w: page returned 404", linkpreview.ErrFetchFailed), http.StatusUnprocessableEntity},
+		{"timeout", "https://example.org/slow", context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{"cache failure", "https://example.org/story", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/link-preview?url="+url.QueryEscape(tc.url), nil)
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakePreviewer{err: tc.err})).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			var resp map[string]any
+			_ = json.NewDecoder(rec.Body).Decode(&resp)
+			if tc.expectedStatus == http.StatusOK && (resp["title"] != "Remote page" || resp["internal"] != false) {
+				t.Errorf("unexpected card %v", resp)
+			}
+			if tc.name == "remote error" && resp["error"] != linkpreview.ErrFetchFailed.Error() {
+				t.Errorf("expected remote detail hidden, got %v", resp["error"])
+			}
+		})
+	}
+}
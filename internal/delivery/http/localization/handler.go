@@ -0,0 +1,225 @@
+package localization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/localization"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// MemberResolver returns the signed-in member's account ID
+type MemberResolver func(r *http.Request) (string, bool)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Localizer is the part of the localization service the endpoints need
+type Localizer interface {
+	Preference(ctx context.Context, accountID string) (*localization.ReaderPreference, error)
+	SavePreference(ctx context.Context, accountID string, currency localization.Currency, units localization.UnitSystem, at time.Time) (*localization.ReaderPreference, error)
+	ArticleSettings(ctx context.Context, articleID string) (*localization.ArticleSettings, error)
+	SetOptOut(ctx context.Context, editorID, articleID string, disabled bool, at time.Time) (*localization.ArticleSettings, error)
+	Preview(ctx context.Context, body, language string, pref localization.Preference, at time.Time) (string, error)
+	RefreshRates(ctx context.Context) (*localization.Rate, error)
+}
+
+type preferenceRequest struct {
+	Currency string `json:"currency"`
+	Units    string `json:"units"`
+}
+
+type preferenceResponse struct {
+	Currency string `json:"currency"`
+	Units    string `json:"units"`
+}
+
+type settingsRequest struct {
+	Disabled bool `json:"disabled"`
+}
+
+type settingsResponse struct {
+	ArticleID string  `json:"article_id"`
+	Disabled  bool    `json:"disabled"`
+	UpdatedBy *string `json:"updated_by,omitempty"`
+	UpdatedAt *string `json:"updated_at,omitempty"`
+}
+
+type previewRequest struct {
+	Body     string `json:"body"`
+	Language string `json:"language"`
+	Currency string `json:"currency"`
+	Units    string `json:"units"`
+}
+
+type previewResponse struct {
+	Body string `json:"body"`
+}
+
+type rateResponse struct {
+	Base      string  `json:"base"`
+	Quote     string  `json:"quote"`
+	Value     float64 `json:"value"`
+	FetchedAt string  `json:"fetched_at"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	localizer Localizer
+	member    MemberResolver
+	staff     StaffResolver
+}
+
+func NewHandler(localizer Localizer, member MemberResolver, staff StaffResolver) *Handler {
+	return &Handler{localizer: localizer, member: member, staff: staff}
+}
+
+// NewRouter mounts the reader's currency and unit preference
+func NewRouter(localizer Localizer, member MemberResolver) http.Handler {
+	h := NewHandler(localizer, member, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /me/localization", h.Preference)
+	mux.HandleFunc("PUT /me/localization", h.SavePreference)
+	return mux
+}
+
+// NewAdminRouter mounts the per-article opt-out, previews and the rates refresh, it must sit behind admin authentication
+func NewAdminRouter(localizer Localizer, staff StaffResolver) http.Handler {
+	h := NewHandler(localizer, nil, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/articles/{id}/localization", h.Settings)
+	mux.HandleFunc("PUT /admin/articles/{id}/localization", h.SetOptOut)
+	mux.HandleFunc("POST /admin/localization/preview", h.Preview)
+	mux.HandleFunc("POST /admin/localization/rates/refresh", h.RefreshRates)
+	return mux
+}
+
+func (h *Handler) Preference(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to manage preferences"})
+		return
+	}
+	pref, err := h.localizer.Preference(r.Context(), accountID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPreferenceResponse(pref.Preference))
+}
+
+func (h *Handler) SavePreference(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to manage preferences"})
+		return
+	}
+	var req preferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	pref, err := h.localizer.SavePreference(r.Context(), accountID, localization.Currency(req.Currency), localization.UnitSystem(req.Units), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPreferenceResponse(pref.Preference))
+}
+
+func (h *Handler) Settings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.localizer.ArticleSettings(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSettingsResponse(settings))
+}
+
+func (h *Handler) SetOptOut(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req settingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	settings, err := h.localizer.SetOptOut(r.Context(), staffID, r.PathValue("id"), req.Disabled, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSettingsResponse(settings))
+}
+
+// Preview renders a draft body as a reader with the given preference would see it
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	var req previewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	pref, err := localization.NewPreference(localization.Currency(req.Currency), localization.UnitSystem(req.Units))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	body, err := h.localizer.Preview(r.Context(), req.Body, req.Language, pref, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, previewResponse{Body: body})
+}
+
+// RefreshRates runs the rates job now, e.g. after the provider had an outage
+func (h *Handler) RefreshRates(w http.ResponseWriter, r *http.Request) {
+	rate, err := h.localizer.RefreshRates(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rateResponse{Base: string(rate.Base), Quote: string(rate.Quote), Value: rate.Value, FetchedAt: rate.FetchedAt.Format(time.RFC3339)})
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, localization.ErrInvalidCurrency), errors.Is(err, localization.ErrInvalidUnitSystem):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toPreferenceResponse(p localization.Preference) preferenceResponse {
+	return preferenceResponse{Currency: string(p.Currency), Units: string(p.Units)}
+}
+
+func toSettingsResponse(s *localization.ArticleSettings) settingsResponse {
+	resp := settingsResponse{ArticleID: s.ArticleID, Disabled: s.Disabled, UpdatedBy: s.LastActionBy}
+	if !s.UpdatedAt.IsZero() {
+		at := s.UpdatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,139 @@
+package localization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/localization"
+)
+
+type fakeLocalizer struct {
+	disabled bool
+	err      error
+}
+
+func (f *fakeLocalizer) Preference(ctx context.Context, accountID string) (*localization.ReaderPreference, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &localization.ReaderPreference{AccountID: accountID, Preference: localization.DefaultPreference()}, nil
+}
+
+func (f *fakeLocalizer) SavePreference(ctx context.Context, accountID string, currency localization.Currency, units localization.UnitSystem, at time.Time) (*localization.ReaderPreference, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	pref, err := localization.NewPreference(currency, units)
+	if err != nil {
+		return nil, err
+	}
+	return &localization.ReaderPreference{AccountID: accountID, Preference: pref, UpdatedAt: at}, nil
+}
+
+func (f *fakeLocalizer) ArticleSettings(ctx context.Context, articleID string) (*localization.ArticleSettings, error) {
+	return &localization.ArticleSettings{ArticleID: articleID}, f.err
+}
+
+func (f *fakeLocalizer) SetOptOut(ctx context.Context, editorID, articleID string, disabled bool, at time.Time) (*localization.ArticleSettings, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.disabled = disabled
+	return localization.NewArticleSettings(articleID, disabled, editorID, at)
+}
+
+func (f *fakeLocalizer) Preview(ctx context.Context, body, language string, pref localization.Preference, at time.Time) (string, error) {
+	return body, f.err
+}
+
+func (f *fakeLocalizer) RefreshRates(ctx context.Context) (*localization.Rate, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return localization.NewRate(localization.CurrencyUSD, localization.CurrencyIDR, 16000, time.Now())
+}
+
+func TestHandler_SavePreference(t *testing.T) {
+	member := func(r *http.Request) (string, bool) { return "member-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"ok", `{"currency":"USD","units":"imperial"}`, true, nil, http.StatusOK},
+		{"guest", `{"currency":"USD","units":"imperial"}`, false, nil, http.StatusUnauthorized},
+		{"invalid body", `{`, true, nil, http.StatusBadRequest},
+		{"unknown currency", `{"currency":"EUR","units":"metric"}`, true, nil, http.StatusUnprocessableEntity},
+		{"store failure", `{"currency":"USD","units":"metric"}`, true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/me/localization", strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakeLocalizer{err: tc.err}, member)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_Admin(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "editor-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"get settings", http.MethodGet, "/admin/articles/a1/localization", "", true, nil, http.StatusOK},
+		{"opt out", http.MethodPut, "/admin/articles/a1/localization", `{"disabled":true}`, true, nil, http.StatusOK},
+		{"opt out without session", http.MethodPut, "/admin/articles/a1/localization", `{"disabled":true}`, false, nil, http.StatusUnauthorized},
+		{"preview", http.MethodPost, "/admin/localization/preview", `{"body":"<p>10 km</p>","language":"id","currency":"IDR","units":"imperial"}`, true, nil, http.StatusOK},
+		{"preview invalid units", http.MethodPost, "/admin/localization/preview", `{"body":"","currency":"IDR","units":"nautical"}`, true, nil, http.StatusUnprocessableEntity},
+		{"refresh rates", http.MethodPost, "/admin/localization/rates/refresh", "", true, nil, http.StatusOK},
+		{"provider down", http.MethodPost, "/admin/localization/rates/refresh", "", true, errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			localizer := &fakeLocalizer{err: tc.err}
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(localizer, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.name == "opt out" {
+				var resp settingsResponse
+				_ = json.NewDecoder(rec.Body).Decode(&resp)
+				if !localizer.disabled || !resp.Disabled || resp.UpdatedBy == nil {
+					t.Errorf("unexpected settings %+v", resp)
+				}
+			}
+		})
+	}
+}
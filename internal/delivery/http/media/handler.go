@@ -0,0 +1,134 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Documents is the part of the document service the endpoints need
+type Documents interface {
+	Open(ctx context.Context, documentID string) (*media.Document, io.ReadCloser, error)
+	Rescan(ctx context.Context, documentID string) (*media.Document, error)
+	RescanSince(ctx context.Context, uploadedSince time.Time) (int64, error)
+}
+
+type documentResponse struct {
+	ID         string  `json:"id"`
+	Filename   string  `json:"filename"`
+	ScanStatus string  `json:"scan_status"`
+	Threat     *string `json:"threat,omitempty"`
+}
+
+type rescanResponse struct {
+	Queued int64 `json:"queued"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	documents Documents
+}
+
+func NewHandler(documents Documents) *Handler {
+	return &Handler{documents: documents}
+}
+
+// NewRouter mounts document downloads, wrap it in the SignedURL middleware for private documents
+func NewRouter(documents Documents) http.Handler {
+	h := NewHandler(documents)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /media/documents/{id}/download", h.Download)
+	return mux
+}
+
+// NewAdminRouter mounts the re-scan commands, it must sit behind admin authentication
+func NewAdminRouter(documents Documents) http.Handler {
+	h := NewHandler(documents)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/media/documents/{id}/rescan", h.Rescan)
+	mux.HandleFunc("POST /admin/media/documents/rescan", h.RescanSince)
+	return mux
+}
+
+func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
+	d, content, err := h.documents.Open(r.Context(), r.PathValue("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrDocumentNotFound):
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+		case errors.Is(err, media.ErrNotDownloadable) && d != nil && d.ScanStatus == media.ScanPending:
+			w.Header().Set("Retry-After", "30")
+			writeJSON(w, http.StatusConflict, errorResponse{Error: "document is still being scanned"})
+		case errors.Is(err, media.ErrNotDownloadable):
+			writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+		case shared.IsTimeout(err):
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to open document"})
+		}
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", d.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(d.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": d.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content)
+}
+
+func (h *Handler) Rescan(w http.ResponseWriter, r *http.Request) {
+	d, err := h.documents.Rescan(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, app.ErrDocumentNotFound) {
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+			return
+		}
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to queue re-scan"})
+		return
+	}
+	writeJSON(w, http.StatusAccepted, documentResponse{ID: d.ID, Filename: d.Filename, ScanStatus: string(d.ScanStatus), Threat: d.Threat})
+}
+
+// RescanSince queues every document uploaded since ?since= (RFC 3339), e.g. after a signature update
+func (h *Handler) RescanSince(w http.ResponseWriter, r *http.Request) {
+	since, err := time.Parse(time.RFC3339, r.URL.Query().Get("since"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "since must be an RFC 3339 timestamp"})
+		return
+	}
+
+	n, err := h.documents.RescanSince(r.Context(), since)
+	if err != nil {
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to queue re-scan"})
+		return
+	}
+	writeJSON(w, http.StatusAccepted, rescanResponse{Queued: n})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,121 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+)
+
+type fakeDocuments struct {
+	document *media.Document
+	content  string
+	err      error
+	since    time.Time
+}
+
+func (f *fakeDocuments) Open(ctx context.Context, documentID string) (*media.Document, io.ReadCloser, error) {
+	if f.err != nil {
+		return f.document, nil, f.err
+	}
+	return f.document, io.NopCloser(strings.NewReader(f.content)), nil
+}
+
+func (f *fakeDocuments) Rescan(ctx context.Context, documentID string) (*media.Document, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.document.RequestRescan()
+	return f.document, nil
+}
+
+func (f *fakeDocuments) RescanSince(ctx context.Context, uploadedSince time.Time) (int64, error) {
+	f.since = uploadedSince
+	return 12, f.err
+}
+
+func createTestDocument(t *testing.T, status media.ScanStatus) *media.Document {
+	d, err := media.NewDocument("doc-1", "annual report.pdf", "application/pdf", 5, "abc", "documents/doc-1", "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create document: %v", err)
+	}
+	d.ScanStatus = status
+	return d
+}
+
+func TestHandler_Download(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         media.ScanStatus
+		err            error
+		expectedStatus int
+	}{
+		{"clean", media.ScanClean, nil, http.StatusOK},
+		{"pending", media.ScanPending, media.ErrNotDownloadable, http.StatusConflict},
+		{"quarantined", media.ScanQuarantined, media.ErrNotDownloadable, http.StatusForbidden},
+		{"not found", "", app.ErrDocumentNotFound, http.StatusNotFound},
+		{"storage failure", media.ScanClean, errors.New("s3 timeout"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			documents := &fakeDocuments{content: "%PDF-", err: tc.err}
+			if tc.status != "" {
+				documents.document = createTestDocument(t, tc.status)
+			}
+
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(documents)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/media/documents/doc-1/download", nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				if rec.Body.String() != "%PDF-" || rec.Header().Get("Content-Disposition") != `attachment; filename="annual report.pdf"` {
+					t.Errorf("unexpected download %q with %q", rec.Body.String(), rec.Header().Get("Content-Disposition"))
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_Rescan(t *testing.T) {
+	documents := &fakeDocuments{document: createTestDocument(t, media.ScanQuarantined)}
+	router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(documents))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/media/documents/doc-1/rescan", nil))
+	if rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), `"scan_status":"pending"`) {
+		t.Errorf("expected accepted pending document, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/media/documents/rescan?since=2025-07-01T00:00:00Z", nil))
+	if rec.Code != http.StatusAccepted || !strings.Contains(rec.Body.String(), `"queued":12`) {
+		t.Errorf("expected 12 queued, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !documents.since.Equal(time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected since %v", documents.since)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/media/documents/rescan?since=yesterday", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+
+	documents.err = app.ErrDocumentNotFound
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/media/documents/doc-9/rescan", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
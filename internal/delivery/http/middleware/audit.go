@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+)
+
+// AuditIP puts the client IP in the request context, so the audit entries the
+// request's services emit record where the action came from
+func AuditIP() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(audit.WithIP(r.Context(), ClientIP(r))))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+)
+
+func TestAuditIP(t *testing.T) {
+	var got string
+	handler := AuditIP()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = audit.IPFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/accounts/a1/disable", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "203.0.113.7" {
+		t.Errorf("expected the client IP in the context, got %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+)
+
+// maxAuthBodyBytes is as much of an auth request body as is read to find the username
+const maxAuthBodyBytes = 64 << 10
+
+// AttemptLimiter counts sign-in and sign-up attempts
+type AttemptLimiter interface {
+	Check(ctx context.Context, rule ratelimit.Rule, clientIP, username string) error
+}
+
+// AuthRateLimit limits an auth endpoint per client address, and per address
+// and username taken from the JSON body field, e.g. "identifier" on login or
+// "username" on registration. The body is restored for the handler. When the
+// counters cannot be reached the request is served, the per-account lockout
+// and the login bans still apply.
+func AuthRateLimit(limiter AttemptLimiter, rule ratelimit.Rule, field string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxAuthBodyBytes))
+			if err != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid request body"})
+				return
+			}
+			r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+			err = limiter.Check(r.Context(), rule, ClientIP(r), usernameField(body, field))
+			if !errors.Is(err, ratelimit.ErrTooManyRequests) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			de, _ := shared.AsDomainError(err)
+			if retryAfter := de.Meta[ratelimit.MetaRetryAfter]; retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": de.Message, "code": de.Code})
+		})
+	}
+}
+
+// usernameField returns the string field of a JSON object body, empty when there is none
+func usernameField(body []byte, field string) string {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return ""
+	}
+	var value string
+	if json.Unmarshal(fields[field], &value) != nil {
+		return ""
+	}
+	return value
+}
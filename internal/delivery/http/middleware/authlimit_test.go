@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+)
+
+type fakeAttemptLimiter struct {
+	err      error
+	clientIP string
+	username string
+}
+
+func (f *fakeAttemptLimiter) Check(ctx context.Context, rule ratelimit.Rule, clientIP, username string) error {
+	f.clientIP, f.username = clientIP, username
+	return f.err
+}
+
+func TestAuthRateLimit(t *testing.T) {
+	limited := ratelimit.Result{RetryAfter: 42 * time.Second}.Err()
+
+	testCases := []struct {
+		name             string
+		body             string
+		err              error
+		expectedStatus   int
+		expectedUsername string
+	}{
+		{"allowed", `{"identifier": "sari", "password": "secret"}`, nil, http.StatusOK, "sari"},
+		{"limited", `{"identifier": "sari", "password": "secret"}`, limited, http.StatusTooManyRequests, "sari"},
+		{"no username", `{"password": "secret"}`, nil, http.StatusOK, ""},
+		{"not JSON", `identifier=sari`, nil, http.StatusOK, ""},
+		{"limiter down", `{"identifier": "sari"}`, errors.New("connection refused"), http.StatusOK, "sari"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			limiter := &fakeAttemptLimiter{err: tc.err}
+			var seen string
+			handler := AuthRateLimit(limiter, ratelimit.LoginRule, "identifier")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				seen = string(body)
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(tc.body))
+			req.RemoteAddr = "192.0.2.10:4000"
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if limiter.clientIP != "192.0.2.10" || limiter.username != tc.expectedUsername {
+				t.Errorf("expected the attempt keyed by address and %q, got %q %q", tc.expectedUsername, limiter.clientIP, limiter.username)
+			}
+			if tc.expectedStatus == http.StatusOK && seen != tc.body {
+				t.Errorf("expected the handler to get the whole body, got %q", seen)
+			}
+			if tc.expectedStatus != http.StatusTooManyRequests {
+				return
+			}
+			var resp map[string]string
+			_ = json.NewDecoder(rec.Body).Decode(&resp)
+			if rec.Header().Get("Retry-After") != "42" || resp["code"] != ratelimit.ErrTooManyRequests.Code {
+				t.Errorf("expected Retry-After 42 and the error code, got %q %v", rec.Header().Get("Retry-After"), resp)
+			}
+		})
+	}
+}
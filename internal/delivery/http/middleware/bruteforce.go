@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/bruteforce"
+)
+
+// BanChecker looks up network-level login bans
+type BanChecker interface {
+	Check(ctx context.Context, clientIP string, at time.Time) (*bruteforce.Ban, error)
+}
+
+// LoginThrottle rejects login attempts from banned addresses and networks
+// before any password is checked. The login handler records failures itself.
+// When the ban store cannot be reached the request is served and the
+// per-account lockout still applies.
+func LoginThrottle(bans BanChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			now := time.Now()
+			ban, err := bans.Check(r.Context(), ClientIP(r), now)
+			if err != nil || ban == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			retryAfter := int64(ban.RetryAfter(now).Seconds()) + 1
+			w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "too many failed logins, try again later"})
+		})
+	}
+}
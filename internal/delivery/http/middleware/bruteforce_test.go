@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/bruteforce"
+)
+
+type fakeBanChecker struct {
+	banned map[string]bool
+	err    error
+}
+
+func (f *fakeBanChecker) Check(ctx context.Context, clientIP string, at time.Time) (*bruteforce.Ban, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if !f.banned[clientIP] {
+		return nil, nil
+	}
+	subject, _ := bruteforce.NewSubject(bruteforce.ScopeIP, clientIP)
+	return bruteforce.NewBan(subject, 20, 1, 10*time.Minute, at)
+}
+
+func TestLoginThrottle(t *testing.T) {
+	testCases := []struct {
+		name           string
+		remoteAddr     string
+		err            error
+		expectedStatus int
+	}{
+		{"allowed", "192.0.2.11:4000", nil, http.StatusNoContent},
+		{"banned", "192.0.2.10:4000", nil, http.StatusTooManyRequests},
+		{"store down", "192.0.2.10:4000", errors.New("connection refused"), http.StatusNoContent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := &fakeBanChecker{banned: map[string]bool{"192.0.2.10": true}, err: tc.err}
+			handler := LoginThrottle(checker)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/admin/login", nil)
+			req.RemoteAddr = tc.remoteAddr
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedStatus == http.StatusTooManyRequests && rec.Header().Get("Retry-After") != "601" {
+				t.Errorf("expected Retry-After 601, got %q", rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
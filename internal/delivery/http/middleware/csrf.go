@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CSRFConfig protects cookie-authenticated admin routes. Tokens are signed
+// double-submit cookies: the cookie value is a random ID signed together with
+// the session cookie, so a token planted before login or taken from another
+// session does not verify, and no server-side token store is needed.
+type CSRFConfig struct {
+	Secret         []byte
+	SessionCookie  string // Name of the admin session cookie
+	CookieName     string // Defaults to "csrf_token", readable by the admin UI's scripts
+	HeaderName     string // Defaults to "X-CSRF-Token"
+	FormField      string // Defaults to "csrf_token" for plain form posts
+	Secure         bool   // Set outside local development
+	SameSite       http.SameSite
+	TrustedOrigins []string // Other origins allowed to post, e.g. "https://preview.example.com"
+	ExemptPrefixes []string // Paths authenticated otherwise, e.g. signed webhooks
+}
+
+var errInvalidCSRFToken = errors.New("invalid CSRF token")
+
+func (c CSRFConfig) withDefaults() CSRFConfig {
+	if c.CookieName == "" {
+		c.CookieName = "csrf_token"
+	}
+	if c.HeaderName == "" {
+		c.HeaderName = "X-CSRF-Token"
+	}
+	if c.FormField == "" {
+		c.FormField = "csrf_token"
+	}
+	if c.SameSite == 0 {
+		c.SameSite = http.SameSiteStrictMode
+	}
+	return c
+}
+
+// NewSessionCookie returns the admin session cookie with the same SameSite
+// and Secure settings as the CSRF cookie, for the login handler to set
+func (c CSRFConfig) NewSessionCookie(value string, maxAge time.Duration) *http.Cookie {
+	c = c.withDefaults()
+	return &http.Cookie{
+		Name:     c.SessionCookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   c.Secure,
+		HttpOnly: true,
+		SameSite: c.SameSite,
+	}
+}
+
+type csrfContextKey struct{}
+
+// CSRF issues a token on every request and checks it on unsafe methods.
+// Requests carrying an Authorization header are API clients authenticated by
+// token; browsers cannot attach that header cross-site, so they are exempt.
+func CSRF(cfg CSRFConfig) func(http.Handler) http.Handler {
+	cfg = cfg.withDefaults()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" || exempt(cfg.ExemptPrefixes, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			session := ""
+			if cookie, err := r.Cookie(cfg.SessionCookie); err == nil {
+				session = cookie.Value
+			}
+			token := ""
+			if cookie, err := r.Cookie(cfg.CookieName); err == nil && cfg.verify(cookie.Value, session) {
+				token = cookie.Value
+			}
+
+			if !safeMethod(r.Method) {
+				if token == "" || !cfg.trustedOrigin(r) || !hmac.Equal([]byte(cfg.submitted(r)), []byte(token)) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusForbidden)
+					_ = json.NewEncoder(w).Encode(map[string]string{"error": errInvalidCSRFToken.Error()})
+					return
+				}
+			}
+
+			if token == "" {
+				var err error
+				if token, err = cfg.issue(session); err != nil {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusInternalServerError)
+					_ = json.NewEncoder(w).Encode(map[string]string{"error": "something went wrong"})
+					return
+				}
+				http.SetCookie(w, &http.Cookie{
+					Name:     cfg.CookieName,
+					Value:    token,
+					Path:     "/",
+					Secure:   cfg.Secure,
+					SameSite: cfg.SameSite,
+				})
+			}
+
+			ctx := context.WithValue(r.Context(), csrfContextKey{}, token)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// CSRFTokenFromContext returns the token forms embed in their hidden field
+func CSRFTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(csrfContextKey{}).(string)
+	return token
+}
+
+func (c CSRFConfig) issue(session string) (string, error) {
+	id := make([]byte, 18)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(id)
+	return encoded + "." + c.sign(encoded, session), nil
+}
+
+func (c CSRFConfig) verify(token, session string) bool {
+	id, signature, ok := strings.Cut(token, ".")
+	return ok && id != "" && hmac.Equal([]byte(signature), []byte(c.sign(id, session)))
+}
+
+func (c CSRFConfig) sign(id, session string) string {
+	mac := hmac.New(sha256.New, c.Secret)
+	mac.Write([]byte(id + "\x00" + session))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (c CSRFConfig) submitted(r *http.Request) string {
+	if token := r.Header.Get(c.HeaderName); token != "" {
+		return token
+	}
+	return r.PostFormValue(c.FormField)
+}
+
+// trustedOrigin rejects cross-origin posts outright, browsers send Origin on
+// every unsafe request so a missing header only comes from non-browser clients
+func (c CSRFConfig) trustedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return u.Host == r.Host || slices.Contains(c.TrustedOrigins, origin)
+}
+
+func safeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func exempt(prefixes []string, path string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newCSRFTestHandler() (http.Handler, CSRFConfig) {
+	cfg := CSRFConfig{Secret: []byte("test-secret"), SessionCookie: "admin_session", ExemptPrefixes: []string{"/webhooks/"}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/login", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(CSRFTokenFromContext(r.Context())))
+	})
+	mux.HandleFunc("POST /admin/login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, cfg.NewSessionCookie("session-1", time.Hour))
+	})
+	mux.HandleFunc("GET /admin/articles/new", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(CSRFTokenFromContext(r.Context())))
+	})
+	mux.HandleFunc("POST /admin/articles", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("POST /webhooks/payment", func(w http.ResponseWriter, r *http.Request) {})
+	return CSRF(cfg)(mux), cfg
+}
+
+// browser keeps cookies between requests the way the admin UI would
+type browser struct {
+	handler http.Handler
+	cookies map[string]*http.Cookie
+}
+
+func (b *browser) do(method, path string, form url.Values, header map[string]string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if form != nil {
+		req = httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	for _, c := range b.cookies {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	b.handler.ServeHTTP(rec, req)
+	for _, c := range rec.Result().Cookies() {
+		b.cookies[c.Name] = c
+	}
+	return rec
+}
+
+func TestCSRF_LoginAndFormPost(t *testing.T) {
+	handler, _ := newCSRFTestHandler()
+	b := &browser{handler: handler, cookies: map[string]*http.Cookie{}}
+
+	loginToken := b.do(http.MethodGet, "/admin/login", nil, nil).Body.String()
+	if loginToken == "" || b.cookies["csrf_token"].Value != loginToken || b.cookies["csrf_token"].SameSite != http.SameSiteStrictMode {
+		t.Fatalf("expected a strict token cookie matching the form token, got %+v", b.cookies["csrf_token"])
+	}
+
+	if rec := b.do(http.MethodPost, "/admin/login", url.Values{"email": {"editor@example.com"}}, nil); rec.Code != http.StatusForbidden {
+		t.Errorf("expected login without token rejected, got %d", rec.Code)
+	}
+	if rec := b.do(http.MethodPost, "/admin/login", url.Values{"csrf_token": {loginToken}}, nil); rec.Code != http.StatusOK {
+		t.Fatalf("expected login with token accepted, got %d", rec.Code)
+	}
+	if session := b.cookies["admin_session"]; session == nil || !session.HttpOnly || session.SameSite != http.SameSiteStrictMode {
+		t.Fatalf("expected an HttpOnly strict session cookie, got %+v", session)
+	}
+
+	// The pre-login token is bound to no session and must not carry over
+	if rec := b.do(http.MethodPost, "/admin/articles", url.Values{"csrf_token": {loginToken}}, nil); rec.Code != http.StatusForbidden {
+		t.Errorf("expected pre-login token rejected after login, got %d", rec.Code)
+	}
+	formToken := b.do(http.MethodGet, "/admin/articles/new", nil, nil).Body.String()
+	if formToken == loginToken {
+		t.Fatal("expected a new token for the session")
+	}
+	if rec := b.do(http.MethodPost, "/admin/articles", url.Values{"csrf_token": {formToken}}, nil); rec.Code != http.StatusCreated {
+		t.Errorf("expected form post accepted, got %d", rec.Code)
+	}
+	if rec := b.do(http.MethodPost, "/admin/articles", nil, map[string]string{"X-CSRF-Token": formToken}); rec.Code != http.StatusCreated {
+		t.Errorf("expected header token accepted, got %d", rec.Code)
+	}
+	if rec := b.do(http.MethodPost, "/admin/articles", nil, map[string]string{"X-CSRF-Token": formToken, "Origin": "https://evil.example.net"}); rec.Code != http.StatusForbidden {
+		t.Errorf("expected cross-origin post rejected, got %d", rec.Code)
+	}
+}
+
+func TestCSRF_Exemptions(t *testing.T) {
+	handler, _ := newCSRFTestHandler()
+
+	testCases := []struct {
+		name           string
+		path           string
+		header         map[string]string
+		cookie         *http.Cookie
+		expectedStatus int
+	}{
+		{"bearer token", "/admin/articles", map[string]string{"Authorization": "Bearer api-token"}, nil, http.StatusCreated},
+		{"exempt prefix", "/webhooks/payment", nil, nil, http.StatusOK},
+		{"forged cookie", "/admin/articles", map[string]string{"X-CSRF-Token": "abc.def"}, &http.Cookie{Name: "csrf_token", Value: "abc.def"}, http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+			if tc.cookie != nil {
+				req.AddCookie(tc.cookie)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
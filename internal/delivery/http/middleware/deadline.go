@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Deadline caps the whole request, storage calls inherit it through the request context
+// and stop early instead of finishing work nobody is waiting for
+func Deadline(limit time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), limit)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
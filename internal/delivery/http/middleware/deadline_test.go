@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeadline(t *testing.T) {
+	var deadline time.Time
+	var ok bool
+	handler := Deadline(5 * time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok = r.Context().Deadline()
+	}))
+
+	latest := time.Now().Add(5 * time.Second)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/articles", nil))
+
+	if !ok {
+		t.Fatal("expected request context to carry a deadline")
+	}
+	if deadline.Before(latest) || deadline.Sub(latest) > time.Second {
+		t.Errorf("expected deadline about 5s out, got %v", time.Until(deadline))
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/institution"
+)
+
+// ProxyTokenHeader carries the token a library proxy was issued, the proxy adds
+// it to every request it forwards for an off-campus reader
+const ProxyTokenHeader = "X-Institution-Token"
+
+// InstitutionResolver matches a request to a licensed institution
+type InstitutionResolver interface {
+	Resolve(ctx context.Context, clientIP, proxyToken string) (*app.Access, error)
+}
+
+type institutionContextKey struct{}
+
+// Institution resolves institutional access for every request and stores it in
+// the context. Requests it cannot resolve, also on a lookup failure, carry no
+// institution and fall back to individual access.
+func Institution(resolver InstitutionResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			access, err := resolver.Resolve(r.Context(), ClientIP(r), r.Header.Get(ProxyTokenHeader))
+			if err != nil || access == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := context.WithValue(r.Context(), institutionContextKey{}, access)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// InstitutionFromContext returns the institution the request came through, nil when none
+func InstitutionFromContext(ctx context.Context) *app.Access {
+	access, _ := ctx.Value(institutionContextKey{}).(*app.Access)
+	return access
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/institution"
+)
+
+type fakeInstitutionResolver struct {
+	ip, token string
+	err       error
+}
+
+func (f *fakeInstitutionResolver) Resolve(ctx context.Context, clientIP, proxyToken string) (*app.Access, error) {
+	f.ip, f.token = clientIP, proxyToken
+	if f.err != nil {
+		return nil, f.err
+	}
+	if clientIP == "152.118.24.7" || proxyToken == "secret" {
+		return &app.Access{InstitutionID: "inst-1", Name: "UI"}, nil
+	}
+	return nil, nil
+}
+
+func TestInstitution(t *testing.T) {
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		token      string
+		err        error
+		expected   string
+	}{
+		{"campus address", "152.118.24.7:51234", "", nil, "inst-1"},
+		{"proxy token", "203.0.113.7:443", "secret", nil, "inst-1"},
+		{"outside", "203.0.113.7:443", "", nil, ""},
+		{"lookup failure", "152.118.24.7:51234", "", errors.New("database down"), ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &fakeInstitutionResolver{err: tc.err}
+			got := ""
+			handler := Institution(resolver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if access := InstitutionFromContext(r.Context()); access != nil {
+					got = access.InstitutionID
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/articles/a1", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if tc.token != "" {
+				req.Header.Set(ProxyTokenHeader, tc.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got != tc.expected {
+				t.Errorf("expected institution %q, got %q", tc.expected, got)
+			}
+			if rec.Code != http.StatusOK {
+				t.Errorf("expected the request served, got %d", rec.Code)
+			}
+		})
+	}
+}
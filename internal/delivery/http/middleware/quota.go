@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/quota"
+)
+
+// QuotaEnforcer counts a call against the caller's API quota
+type QuotaEnforcer interface {
+	Consume(ctx context.Context, consumer app.Consumer, route string, at time.Time) (*app.Decision, error)
+}
+
+// ConsumerResolver identifies the API key or account behind the request
+type ConsumerResolver func(r *http.Request) (app.Consumer, bool)
+
+// RouteGroup maps a request to the route group its tier may limit separately, e.g. "search"
+type RouteGroup func(r *http.Request) string
+
+// Quota enforces per-account API quotas and reports them in X-RateLimit-* headers.
+// Anonymous requests pass through, and the request is served if the counters cannot be reached.
+func Quota(enforcer QuotaEnforcer, consumer ConsumerResolver, group RouteGroup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c, ok := consumer(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route := ""
+			if group != nil {
+				route = group(r)
+			}
+
+			now := time.Now()
+			decision, err := enforcer.Consume(r.Context(), c, route, now)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if decision.Limit > 0 {
+				w.Header().Set("X-RateLimit-Limit", strconv.FormatInt(decision.Limit, 10))
+				w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(decision.Remaining, 10))
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(decision.ResetAt.Unix(), 10))
+			}
+
+			if !decision.Allowed {
+				retryAfter := int64(decision.ResetAt.Sub(now).Seconds()) + 1
+				w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": string(decision.Period) + " API quota exceeded"})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
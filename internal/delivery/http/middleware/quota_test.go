@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/quota"
+)
+
+type fakeEnforcer struct {
+	decision *app.Decision
+	err      error
+	route    string
+}
+
+func (f *fakeEnforcer) Consume(ctx context.Context, consumer app.Consumer, route string, at time.Time) (*app.Decision, error) {
+	f.route = route
+	return f.decision, f.err
+}
+
+func TestQuota(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	apiKey := func(r *http.Request) (app.Consumer, bool) {
+		key := r.Header.Get("X-API-Key")
+		return app.Consumer{AccountID: "acc-1", APIKeyID: key}, key != ""
+	}
+	search := func(r *http.Request) string { return "search" }
+
+	testCases := []struct {
+		name           string
+		apiKey         string
+		enforcer       *fakeEnforcer
+		expectedStatus int
+		expectedLimit  string
+	}{
+		{"anonymous passes through", "", &fakeEnforcer{}, http.StatusOK, ""},
+		{"allowed", "key-1", &fakeEnforcer{decision: &app.Decision{Allowed: true, Limit: 100, Remaining: 42, Period: quota.PeriodDaily, ResetAt: reset}}, http.StatusOK, "100"},
+		{"exceeded", "key-1", &fakeEnforcer{decision: &app.Decision{Allowed: false, Limit: 100, Period: quota.PeriodDaily, ResetAt: reset}}, http.StatusTooManyRequests, "100"},
+		{"counter failure fails open", "key-1", &fakeEnforcer{err: errors.New("redis down")}, http.StatusOK, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := Quota(tc.enforcer, apiKey, search)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/search", nil)
+			if tc.apiKey != "" {
+				req.Header.Set("X-API-Key", tc.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if got := rec.Header().Get("X-RateLimit-Limit"); got != tc.expectedLimit {
+				t.Errorf("expected limit header %q, got %q", tc.expectedLimit, got)
+			}
+			if tc.expectedStatus == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+				t.Error("expected Retry-After header on 429")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// pruneEvery is how many calls pass between sweeps of idle buckets
+const pruneEvery = 1024
+
+// RateLimiter is an in-process token bucket per key. It suits cheap, chatty
+// endpoints such as typeahead where a shared store would cost more than the
+// lookup; each instance limits on its own.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // Tokens per second
+	burst   float64
+	buckets map[string]*bucket
+	calls   int
+}
+
+type bucket struct {
+	tokens float64
+	at     time.Time
+}
+
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	return &RateLimiter{rate: perSecond, burst: float64(burst), buckets: map[string]*bucket{}}
+}
+
+// Allow takes a token for key, or reports how long until one is available
+func (l *RateLimiter) Allow(key string, at time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls++
+	if l.calls%pruneEvery == 0 {
+		l.prune(at)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, at: at}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+at.Sub(b.at).Seconds()*l.rate)
+	b.at = at
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// prune drops buckets that have refilled, they are the same as a new one
+func (l *RateLimiter) prune(at time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+at.Sub(b.at).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimit limits each client address per route. Wrapping handlers inside a
+// ServeMux keys on the matched pattern, so one endpoint's traffic does not use
+// up another's allowance; wrapping the mux itself limits the client overall.
+func RateLimit(limiter *RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, wait := limiter.Allow(ClientIP(r)+" "+r.Pattern, time.Now())
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(wait.Seconds())+1, 10))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "too many requests, slow down"})
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	limiter := NewRateLimiter(2, 3)
+	at := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		if ok, _ := limiter.Allow("192.0.2.10", at); !ok {
+			t.Fatalf("expected call %d inside the burst allowed", i+1)
+		}
+	}
+	ok, wait := limiter.Allow("192.0.2.10", at)
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("expected a 500ms wait after the burst, got %v %s", ok, wait)
+	}
+	if ok, _ := limiter.Allow("192.0.2.11", at); !ok {
+		t.Error("expected another client unaffected")
+	}
+	if ok, _ := limiter.Allow("192.0.2.10", at.Add(500*time.Millisecond)); !ok {
+		t.Error("expected a token refilled after 500ms")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	mux := http.NewServeMux()
+	limit := RateLimit(NewRateLimiter(1, 2))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	mux.Handle("GET /suggest/titles", limit(ok))
+	mux.Handle("GET /suggest/tags", limit(ok))
+
+	request := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = "192.0.2.10:4000"
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	request("/suggest/titles")
+	request("/suggest/titles")
+	if rec := request("/suggest/titles"); rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("expected 429 with Retry-After, got %d", rec.Code)
+	}
+	if rec := request("/suggest/tags"); rec.Code != http.StatusNoContent {
+		t.Errorf("expected the other route limited separately, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/region"
+)
+
+type regionContextKey struct{}
+
+// Region resolves the regional edition for every request and stores it in the context.
+// Handlers behind it that set their own Cache-Control must keep it private when the
+// edition came from the client address.
+func Region(resolver *region.Resolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resolution := resolver.Resolve(r.Context(), region.ResolutionInput{
+				Query:    r.URL.Query().Get("region"),
+				Host:     r.Host,
+				ClientIP: ClientIP(r),
+			})
+
+			// The query is part of the cache key and the subdomain varies with Host, but no
+			// shared cache can key on the client address that GeoIP and the fallback depend on
+			w.Header().Add("Vary", "Host")
+			if resolution.Source == region.SourceGeoIP || resolution.Source == region.SourceFallback {
+				w.Header().Set("Cache-Control", "private")
+			}
+			w.Header().Set("X-Region", resolution.Region.Value())
+
+			ctx := context.WithValue(r.Context(), regionContextKey{}, resolution)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RegionFromContext returns the resolved edition, falling back to national coverage
+func RegionFromContext(ctx context.Context) region.Resolution {
+	if resolution, ok := ctx.Value(regionContextKey{}).(region.Resolution); ok {
+		return resolution
+	}
+	return region.Resolution{Region: region.NationalCode(), Source: region.SourceFallback}
+}
+
+// ClientIP is the address the request came from, without its port. Handlers
+// use it for the IP they record alongside an action.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/region"
+)
+
+func TestRegion(t *testing.T) {
+	subdomain := "jabar"
+	jabar, err := region.NewRegion("jabar", "Jawa Barat", &subdomain, []string{"ID-JB"})
+	if err != nil {
+		t.Fatalf("failed to create region: %v", err)
+	}
+	resolver := region.NewResolver([]*region.Region{jabar}, "example.com", nil)
+
+	var got region.Resolution
+	handler := Region(resolver)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = RegionFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "http://jabar.example.com/articles", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got.Region.Value() != "jabar" || got.Source != region.SourceSubdomain {
+		t.Errorf("expected jabar from subdomain, got %s from %s", got.Region.Value(), got.Source)
+	}
+	if rec.Header().Get("X-Region") != "jabar" {
+		t.Errorf("expected X-Region header jabar, got %q", rec.Header().Get("X-Region"))
+	}
+	if rec.Header().Get("Vary") != "Host" || rec.Header().Get("Cache-Control") != "" {
+		t.Errorf("expected a subdomain edition cacheable per host, got Vary %q Cache-Control %q", rec.Header().Get("Vary"), rec.Header().Get("Cache-Control"))
+	}
+
+	// Without a query or subdomain the edition depends on the client address
+	req = httptest.NewRequest(http.MethodGet, "http://www.example.com/articles", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got.Source != region.SourceFallback || rec.Header().Get("Cache-Control") != "private" {
+		t.Errorf("expected a fallback edition kept out of shared caches, got %s with Cache-Control %q", got.Source, rec.Header().Get("Cache-Control"))
+	}
+}
+
+func TestRegionFromContext_Fallback(t *testing.T) {
+	got := RegionFromContext(context.Background())
+	if !got.Region.IsNational() || got.Source != region.SourceFallback {
+		t.Errorf("expected national fallback, got %s from %s", got.Region.Value(), got.Source)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		expected   string
+	}{
+		{"IPv4 with port", "198.51.100.7:51234", "198.51.100.7"},
+		{"IPv6 with port", "[2001:db8::1]:443", "2001:db8::1"},
+		{"without port", "198.51.100.7", "198.51.100.7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tc.remoteAddr
+			if got := ClientIP(req); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
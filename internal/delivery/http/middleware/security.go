@@ -0,0 +1,268 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SecurityPolicy configures the security headers of one surface
+type SecurityPolicy struct {
+	// CSP source lists, 'self' is always included. Scripts also get the
+	// request's nonce with 'strict-dynamic', so inline scripts without the
+	// nonce never run.
+	ScriptSources  []string
+	StyleSources   []string
+	ImageSources   []string
+	ConnectSources []string
+	FrameSources   []string
+	FrameAncestors []string // Empty forbids framing
+	ReportURI      string
+	ReportOnly     bool // Send Content-Security-Policy-Report-Only while tightening a policy
+
+	HSTSMaxAge        time.Duration // 0 omits the header
+	HSTSPreload       bool
+	ReferrerPolicy    string
+	PermissionsPolicy string
+}
+
+// PublicSecurityPolicy is the reader-facing policy: ads, embeds and analytics
+// need third-party sources, so those lists are meant to be extended per site
+func PublicSecurityPolicy(reportURI string) SecurityPolicy {
+	return SecurityPolicy{
+		ScriptSources:     []string{"https:"},
+		StyleSources:      []string{"'unsafe-inline'", "https:"},
+		ImageSources:      []string{"data:", "https:"},
+		ConnectSources:    []string{"https:"},
+		FrameSources:      []string{"https:"},
+		ReportURI:         reportURI,
+		HSTSMaxAge:        365 * 24 * time.Hour,
+		HSTSPreload:       true,
+		ReferrerPolicy:    "strict-origin-when-cross-origin",
+		PermissionsPolicy: "camera=(), microphone=(), geolocation=(self), payment=(self), interest-cohort=()",
+	}
+}
+
+// AdminSecurityPolicy locks the newsroom down to the CMS's own origin, the
+// editor only loads previews of external media
+func AdminSecurityPolicy(reportURI string) SecurityPolicy {
+	return SecurityPolicy{
+		StyleSources:      []string{"'unsafe-inline'"},
+		ImageSources:      []string{"data:", "blob:", "https:"},
+		FrameSources:      []string{"https:"},
+		ReportURI:         reportURI,
+		HSTSMaxAge:        365 * 24 * time.Hour,
+		HSTSPreload:       true,
+		ReferrerPolicy:    "same-origin",
+		PermissionsPolicy: "camera=(), microphone=(), geolocation=(), payment=(), interest-cohort=()",
+	}
+}
+
+func (p SecurityPolicy) contentSecurityPolicy(nonce string) string {
+	directive := func(name string, sources ...string) string {
+		return name + " " + strings.Join(sources, " ")
+	}
+	ancestors := []string{"'none'"}
+	if len(p.FrameAncestors) > 0 {
+		ancestors = p.FrameAncestors
+	}
+
+	directives := []string{
+		"default-src 'self'",
+		directive("script-src", append([]string{"'self'", "'nonce-" + nonce + "'", "'strict-dynamic'"}, p.ScriptSources...)...),
+		directive("style-src", append([]string{"'self'"}, p.StyleSources...)...),
+		directive("img-src", append([]string{"'self'"}, p.ImageSources...)...),
+		directive("connect-src", append([]string{"'self'"}, p.ConnectSources...)...),
+		directive("frame-src", append([]string{"'self'"}, p.FrameSources...)...),
+		directive("frame-ancestors", ancestors...),
+		"object-src 'none'",
+		"base-uri 'self'",
+		"form-action 'self'",
+	}
+	if p.ReportURI != "" {
+		directives = append(directives, "report-uri "+p.ReportURI, "report-to csp")
+	}
+	return strings.Join(directives, "; ")
+}
+
+type nonceContextKey struct{}
+
+// SecurityHeaders sets the security headers of the surface the request path
+// falls under. overrides are keyed by path prefix, e.g. "/admin/", and the
+// longest matching prefix wins over the default policy.
+func SecurityHeaders(defaultPolicy SecurityPolicy, overrides map[string]SecurityPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			policy, matched := defaultPolicy, ""
+			for prefix, p := range overrides {
+				if strings.HasPrefix(r.URL.Path, prefix) && len(prefix) > len(matched) {
+					policy, matched = p, prefix
+				}
+			}
+
+			nonce, err := newNonce()
+			if err != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "something went wrong"})
+				return
+			}
+
+			h := w.Header()
+			cspHeader := "Content-Security-Policy"
+			if policy.ReportOnly {
+				cspHeader = "Content-Security-Policy-Report-Only"
+			}
+			h.Set(cspHeader, policy.contentSecurityPolicy(nonce))
+			if policy.ReportURI != "" {
+				h.Set("Reporting-Endpoints", `csp="`+policy.ReportURI+`"`)
+			}
+			if policy.HSTSMaxAge > 0 {
+				hsts := "max-age=" + strconv.Itoa(int(policy.HSTSMaxAge.Seconds())) + "; includeSubDomains"
+				if policy.HSTSPreload {
+					hsts += "; preload"
+				}
+				h.Set("Strict-Transport-Security", hsts)
+			}
+			h.Set("X-Content-Type-Options", "nosniff")
+			if len(policy.FrameAncestors) == 0 {
+				h.Set("X-Frame-Options", "DENY") // For browsers without frame-ancestors
+			}
+			if policy.ReferrerPolicy != "" {
+				h.Set("Referrer-Policy", policy.ReferrerPolicy)
+			}
+			if policy.PermissionsPolicy != "" {
+				h.Set("Permissions-Policy", policy.PermissionsPolicy)
+			}
+
+			ctx := context.WithValue(r.Context(), nonceContextKey{}, nonce)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// NonceFromContext returns the CSP nonce templates put on inline <script>
+// tags, empty outside SecurityHeaders
+func NonceFromContext(ctx context.Context) string {
+	nonce, _ := ctx.Value(nonceContextKey{}).(string)
+	return nonce
+}
+
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// CSPReport is one violation, from either the legacy report-uri format or the Reporting API
+type CSPReport struct {
+	DocumentURI        string
+	BlockedURI         string
+	EffectiveDirective string
+	SourceFile         string
+	LineNumber         int
+	Disposition        string // "enforce" or "report"
+	UserAgent          string
+	ReceivedAt         time.Time
+}
+
+// CSPReportRecorder stores violation reports, e.g. for the security dashboard
+type CSPReportRecorder interface {
+	Record(ctx context.Context, reports []CSPReport) error
+}
+
+// maxReportBody bounds what an anonymous endpoint reads, browsers send a few KB at most
+const maxReportBody = 64 << 10
+
+type legacyReport struct {
+	Body struct {
+		DocumentURI        string `json:"document-uri"`
+		BlockedURI         string `json:"blocked-uri"`
+		EffectiveDirective string `json:"effective-directive"`
+		ViolatedDirective  string `json:"violated-directive"`
+		SourceFile         string `json:"source-file"`
+		LineNumber         int    `json:"line-number"`
+		Disposition        string `json:"disposition"`
+	} `json:"csp-report"`
+}
+
+type reportingAPIReport struct {
+	Type string `json:"type"`
+	Body struct {
+		DocumentURL        string `json:"documentURL"`
+		BlockedURL         string `json:"blockedURL"`
+		EffectiveDirective string `json:"effectiveDirective"`
+		SourceFile         string `json:"sourceFile"`
+		LineNumber         int    `json:"lineNumber"`
+		Disposition        string `json:"disposition"`
+	} `json:"body"`
+}
+
+// CSPReportHandler collects violation reports posted by browsers. It always
+// answers 204 to well-formed requests so a flood of reports costs nothing
+// beyond the recorder.
+func CSPReportHandler(recorder CSPReportRecorder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxReportBody))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		now := time.Now()
+
+		var reports []CSPReport
+		switch {
+		case strings.HasPrefix(r.Header.Get("Content-Type"), "application/reports+json"):
+			var batch []reportingAPIReport
+			if err := json.Unmarshal(body, &batch); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for _, b := range batch {
+				if b.Type != "csp-violation" {
+					continue
+				}
+				reports = append(reports, CSPReport{
+					DocumentURI: b.Body.DocumentURL, BlockedURI: b.Body.BlockedURL, EffectiveDirective: b.Body.EffectiveDirective,
+					SourceFile: b.Body.SourceFile, LineNumber: b.Body.LineNumber, Disposition: b.Body.Disposition,
+				})
+			}
+		default:
+			var legacy legacyReport
+			if err := json.Unmarshal(body, &legacy); err != nil || legacy.Body.DocumentURI == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			directive := legacy.Body.EffectiveDirective
+			if directive == "" {
+				directive = legacy.Body.ViolatedDirective
+			}
+			reports = append(reports, CSPReport{
+				DocumentURI: legacy.Body.DocumentURI, BlockedURI: legacy.Body.BlockedURI, EffectiveDirective: directive,
+				SourceFile: legacy.Body.SourceFile, LineNumber: legacy.Body.LineNumber, Disposition: legacy.Body.Disposition,
+			})
+		}
+
+		for i := range reports {
+			reports[i].UserAgent = r.UserAgent()
+			reports[i].ReceivedAt = now
+		}
+		if len(reports) > 0 {
+			// Reports are diagnostics, losing some when the store is down is acceptable
+			_ = recorder.Record(r.Context(), reports)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	var nonces []string
+	handler := SecurityHeaders(PublicSecurityPolicy("/csp-reports"), map[string]SecurityPolicy{
+		"/admin/": AdminSecurityPolicy("/csp-reports"),
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonces = append(nonces, NonceFromContext(r.Context()))
+	}))
+
+	testCases := []struct {
+		name             string
+		path             string
+		expectedReferrer string
+		expectedScript   string
+	}{
+		{"public", "/articles/a1", "strict-origin-when-cross-origin", "'strict-dynamic' https:;"},
+		{"admin", "/admin/articles", "same-origin", "'strict-dynamic'; "},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			csp := rec.Header().Get("Content-Security-Policy")
+			nonce := nonces[len(nonces)-1]
+			if nonce == "" || !strings.Contains(csp, "'nonce-"+nonce+"'") || !strings.Contains(csp, tc.expectedScript) {
+				t.Errorf("expected script-src with the request nonce, got %q", csp)
+			}
+			if !strings.Contains(csp, "frame-ancestors 'none'") || !strings.Contains(csp, "report-uri /csp-reports") {
+				t.Errorf("unexpected policy %q", csp)
+			}
+			if rec.Header().Get("Referrer-Policy") != tc.expectedReferrer {
+				t.Errorf("expected referrer policy %q, got %q", tc.expectedReferrer, rec.Header().Get("Referrer-Policy"))
+			}
+			if rec.Header().Get("X-Content-Type-Options") != "nosniff" || !strings.HasPrefix(rec.Header().Get("Strict-Transport-Security"), "max-age=31536000") {
+				t.Errorf("missing baseline headers: %v", rec.Header())
+			}
+		})
+	}
+
+	if nonces[0] == nonces[1] {
+		t.Error("expected a fresh nonce per request")
+	}
+}
+
+func TestSecurityHeaders_ReportOnly(t *testing.T) {
+	policy := SecurityPolicy{ReportOnly: true, FrameAncestors: []string{"https://partner.example.com"}}
+	rec := httptest.NewRecorder()
+	SecurityHeaders(policy, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Header().Get("Content-Security-Policy") != "" || !strings.Contains(rec.Header().Get("Content-Security-Policy-Report-Only"), "frame-ancestors https://partner.example.com") {
+		t.Errorf("expected report-only policy, got %v", rec.Header())
+	}
+	if rec.Header().Get("X-Frame-Options") != "" || rec.Header().Get("Strict-Transport-Security") != "" {
+		t.Errorf("expected no frame denial or HSTS, got %v", rec.Header())
+	}
+}
+
+type recordingReports struct {
+	reports []CSPReport
+}
+
+func (r *recordingReports) Record(ctx context.Context, reports []CSPReport) error {
+	r.reports = append(r.reports, reports...)
+	return nil
+}
+
+func TestCSPReportHandler(t *testing.T) {
+	testCases := []struct {
+		name            string
+		contentType     string
+		body            string
+		expectedStatus  int
+		expectedBlocked string
+	}{
+		{"legacy", "application/csp-report", `{"csp-report":{"document-uri":"https://example.com/a","blocked-uri":"https://evil.example.net/x.js","violated-directive":"script-src"}}`, http.StatusNoContent, "https://evil.example.net/x.js"},
+		{"reporting api", "application/reports+json", `[{"type":"csp-violation","body":{"documentURL":"https://example.com/a","blockedURL":"inline","effectiveDirective":"script-src-elem"}},{"type":"deprecation","body":{}}]`, http.StatusNoContent, "inline"},
+		{"malformed", "application/csp-report", `{`, http.StatusBadRequest, ""},
+		{"not a report", "application/csp-report", `{"hello":"world"}`, http.StatusBadRequest, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &recordingReports{}
+			req := httptest.NewRequest(http.MethodPost, "/csp-reports", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+			CSPReportHandler(recorder).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedBlocked != "" && (len(recorder.reports) != 1 || recorder.reports[0].BlockedURI != tc.expectedBlocked || recorder.reports[0].EffectiveDirective == "") {
+				t.Errorf("unexpected reports %+v", recorder.reports)
+			}
+		})
+	}
+}
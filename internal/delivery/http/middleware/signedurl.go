@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared/signedurl"
+)
+
+// URLVerifier checks signed URLs of a scope
+type URLVerifier interface {
+	Verify(ctx context.Context, scope signedurl.Scope, rawURL, clientIP string) error
+}
+
+// SignedURL only lets requests through whose URL was signed for the scope
+func SignedURL(verifier URLVerifier, scope signedurl.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			err := verifier.Verify(r.Context(), scope, r.URL.RequestURI(), ClientIP(r))
+			if err == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			status := http.StatusForbidden
+			message := "link is invalid"
+			switch {
+			case errors.Is(err, signedurl.ErrExpired):
+				status, message = http.StatusGone, "link has expired"
+			case errors.Is(err, signedurl.ErrRevoked):
+				status, message = http.StatusGone, "link has been revoked"
+			case errors.Is(err, signedurl.ErrIPMismatch):
+				message = "link was issued to another network"
+			case errors.Is(err, signedurl.ErrMalformed), errors.Is(err, signedurl.ErrUnknownKey), errors.Is(err, signedurl.ErrInvalidSignature):
+				// Generic forbidden, tampered links get no detail
+			default:
+				status, message = http.StatusInternalServerError, "failed to verify link"
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared/signedurl"
+)
+
+type fakeVerifier struct {
+	err      error
+	rawURL   string
+	clientIP string
+}
+
+func (f *fakeVerifier) Verify(ctx context.Context, scope signedurl.Scope, rawURL, clientIP string) error {
+	f.rawURL, f.clientIP = rawURL, clientIP
+	return f.err
+}
+
+func TestSignedURL(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"valid", nil, http.StatusOK},
+		{"expired", signedurl.ErrExpired, http.StatusGone},
+		{"revoked", signedurl.ErrRevoked, http.StatusGone},
+		{"bad signature", signedurl.ErrInvalidSignature, http.StatusForbidden},
+		{"other IP", signedurl.ErrIPMismatch, http.StatusForbidden},
+		{"keyring unavailable", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := &fakeVerifier{err: tc.err}
+			handler := SignedURL(verifier, signedurl.ScopeExport)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/exports/42.csv?expires=1&kid=k1&sig=abc", nil)
+			req.RemoteAddr = "203.0.113.7:5123"
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if verifier.rawURL != "/exports/42.csv?expires=1&kid=k1&sig=abc" || verifier.clientIP != "203.0.113.7" {
+				t.Errorf("unexpected verify input %q from %q", verifier.rawURL, verifier.clientIP)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package mobile
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last item a client has seen, keyset pagination stays stable
+// while new stories are published at the top of an infinite-scroll feed
+type Cursor struct {
+	PublishedAt time.Time
+	ID          string
+}
+
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.PublishedAt.UnixNano(), 10) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{PublishedAt: time.Unix(0, nanos).UTC(), ID: parts[1]}, nil
+}
@@ -0,0 +1,109 @@
+package mobile
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type DeviceClass string
+
+const (
+	DevicePhone   DeviceClass = "phone"
+	DeviceTablet  DeviceClass = "tablet"
+	DeviceDesktop DeviceClass = "desktop"
+)
+
+// Device describes the rendering target of a request
+type Device struct {
+	Class      DeviceClass
+	PixelRatio float64
+}
+
+// ViewportWidth is the CSS pixel width images are laid out at for each class
+func (d Device) ViewportWidth() int {
+	switch d.Class {
+	case DevicePhone:
+		return 420
+	case DeviceTablet:
+		return 820
+	default:
+		return 1280
+	}
+}
+
+// TargetImageWidth is the physical pixel width an image should have
+func (d Device) TargetImageWidth() int {
+	return int(float64(d.ViewportWidth()) * d.PixelRatio)
+}
+
+// DetectDevice reads the app-provided hints, falling back to User-Agent sniffing.
+// Apps send X-Device-Class and X-Device-Pixel-Ratio; browsers may send Sec-CH-DPR.
+func DetectDevice(r *http.Request) Device {
+	device := Device{Class: classFromHeader(r.Header.Get("X-Device-Class")), PixelRatio: 1}
+	if device.Class == "" {
+		device.Class = classFromUserAgent(r.UserAgent())
+	}
+
+	for _, header := range []string{"X-Device-Pixel-Ratio", "Sec-CH-DPR"} {
+		if ratio, err := strconv.ParseFloat(r.Header.Get(header), 64); err == nil && ratio >= 1 && ratio <= 4 {
+			device.PixelRatio = ratio
+			break
+		}
+	}
+	return device
+}
+
+func classFromHeader(value string) DeviceClass {
+	switch DeviceClass(strings.ToLower(strings.TrimSpace(value))) {
+	case DevicePhone:
+		return DevicePhone
+	case DeviceTablet:
+		return DeviceTablet
+	case DeviceDesktop:
+		return DeviceDesktop
+	}
+	return ""
+}
+
+func classFromUserAgent(ua string) DeviceClass {
+	ua = strings.ToLower(ua)
+	switch {
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"),
+		strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return DeviceTablet
+	case strings.Contains(ua, "mobile"), strings.Contains(ua, "iphone"):
+		return DevicePhone
+	}
+	return DeviceDesktop
+}
+
+// ImageVariant is one rendition of an image produced by the media pipeline
+type ImageVariant struct {
+	Width int
+	URL   string
+}
+
+// SelectVariant picks the smallest variant that covers the device's target width,
+// or the largest available one when none is wide enough
+func SelectVariant(variants []ImageVariant, device Device) *ImageVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	target := device.TargetImageWidth()
+	var best, largest *ImageVariant
+	for i := range variants {
+		v := &variants[i]
+		if largest == nil || v.Width > largest.Width {
+			largest = v
+		}
+		if v.Width >= target && (best == nil || v.Width < best.Width) {
+			best = v
+		}
+	}
+	if best == nil {
+		return largest
+	}
+	return best
+}
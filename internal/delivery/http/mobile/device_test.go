@@ -0,0 +1,88 @@
+package mobile
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDetectDevice(t *testing.T) {
+	testCases := []struct {
+		name          string
+		headers       map[string]string
+		expectedClass DeviceClass
+		expectedRatio float64
+	}{
+		{"explicit app hint", map[string]string{"X-Device-Class": "Tablet", "X-Device-Pixel-Ratio": "2"}, DeviceTablet, 2},
+		{"iphone user agent", map[string]string{"User-Agent": "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0) Mobile/15E148"}, DevicePhone, 1},
+		{"android tablet user agent", map[string]string{"User-Agent": "Mozilla/5.0 (Linux; Android 14; SM-X710)"}, DeviceTablet, 1},
+		{"desktop with client hint", map[string]string{"User-Agent": "Mozilla/5.0 (X11; Linux x86_64)", "Sec-CH-DPR": "1.5"}, DeviceDesktop, 1.5},
+		{"out of range ratio ignored", map[string]string{"X-Device-Class": "phone", "X-Device-Pixel-Ratio": "12"}, DevicePhone, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			device := DetectDevice(req)
+			if device.Class != tc.expectedClass {
+				t.Errorf("expected class %s, got %s", tc.expectedClass, device.Class)
+			}
+			if device.PixelRatio != tc.expectedRatio {
+				t.Errorf("expected pixel ratio %v, got %v", tc.expectedRatio, device.PixelRatio)
+			}
+		})
+	}
+}
+
+func TestSelectVariant(t *testing.T) {
+	variants := []ImageVariant{
+		{Width: 1600, URL: "large"},
+		{Width: 480, URL: "small"},
+		{Width: 960, URL: "medium"},
+	}
+
+	testCases := []struct {
+		name     string
+		device   Device
+		expected string
+	}{
+		{"phone at 1x", Device{Class: DevicePhone, PixelRatio: 1}, "small"},
+		{"phone at 2x", Device{Class: DevicePhone, PixelRatio: 2}, "medium"},
+		{"tablet at 2x", Device{Class: DeviceTablet, PixelRatio: 2}, "large"},
+		{"desktop at 2x falls back to largest", Device{Class: DeviceDesktop, PixelRatio: 2}, "large"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SelectVariant(variants, tc.device)
+			if got == nil || got.URL != tc.expected {
+				t.Errorf("expected variant %s, got %v", tc.expected, got)
+			}
+		})
+	}
+
+	if SelectVariant(nil, Device{Class: DevicePhone, PixelRatio: 1}) != nil {
+		t.Error("expected nil variant for empty list")
+	}
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	original := Cursor{PublishedAt: time.Date(2026, 3, 1, 10, 30, 0, 123, time.UTC), ID: "art-42"}
+
+	decoded, err := DecodeCursor(original.Encode())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !decoded.PublishedAt.Equal(original.PublishedAt) || decoded.ID != original.ID {
+		t.Errorf("expected %v, got %v", original, decoded)
+	}
+
+	for _, bad := range []string{"!!!", "bm8tc2VwYXJhdG9y", "YWJjfA"} {
+		if _, err := DecodeCursor(bad); err != ErrInvalidCursor {
+			t.Errorf("expected error '%v' for %q, got '%v'", ErrInvalidCursor, bad, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package mobile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// APIVersion is versioned independently of the web API so apps can evolve on their own schedule
+const APIVersion = "v1"
+
+// Page sizes tuned for infinite scroll on small screens
+const (
+	DefaultPageSize = 15
+	MaxPageSize     = 30
+)
+
+// Teaser is the subset of an article the mobile feed needs
+type Teaser struct {
+	ID          string
+	Slug        string
+	Title       string
+	Summary     string
+	Category    string
+	IsPremium   bool
+	PublishedAt time.Time
+	Images      []ImageVariant
+}
+
+// FeedSource lists published teasers in reverse chronological order after the cursor
+type FeedSource interface {
+	ListTeasers(ctx context.Context, after *Cursor, limit int) ([]Teaser, error)
+}
+
+type teaserResponse struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Summary     string `json:"summary,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Premium     bool   `json:"premium"`
+	PublishedAt int64  `json:"published_at"` // Unix seconds keep payloads small
+	ImageURL    string `json:"image_url,omitempty"`
+}
+
+type feedResponse struct {
+	Items      []teaserResponse `json:"items"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type FeedHandler struct {
+	source FeedSource
+}
+
+func NewFeedHandler(source FeedSource) *FeedHandler {
+	return &FeedHandler{source: source}
+}
+
+// NewRouter mounts the mobile BFF endpoints under /mobile/v1
+func NewRouter(source FeedSource) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /mobile/"+APIVersion+"/feed", NewFeedHandler(source))
+	return mux
+}
+
+// ServeHTTP lists the condensed teaser feed for infinite scroll
+//
+//openapi:query limit Page size, 1-30
+//openapi:query cursor Opaque cursor from next_cursor
+func (h *FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	limit := DefaultPageSize
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > MaxPageSize {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit must be between 1 and 30"})
+			return
+		}
+		limit = n
+	}
+
+	var after *Cursor
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		cursor, err := DecodeCursor(raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		after = cursor
+	}
+
+	// Fetch one extra teaser to know whether another page exists
+	teasers, err := h.source.ListTeasers(r.Context(), after, limit+1)
+	if err != nil {
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to load feed"})
+		return
+	}
+
+	device := DetectDevice(r)
+	resp := feedResponse{Items: make([]teaserResponse, 0, limit)}
+	for i, t := range teasers {
+		if i == limit {
+			last := teasers[limit-1]
+			resp.NextCursor = Cursor{PublishedAt: last.PublishedAt, ID: last.ID}.Encode()
+			break
+		}
+
+		item := teaserResponse{
+			ID:          t.ID,
+			Slug:        t.Slug,
+			Title:       t.Title,
+			Summary:     t.Summary,
+			Category:    t.Category,
+			Premium:     t.IsPremium,
+			PublishedAt: t.PublishedAt.Unix(),
+		}
+		// Phones get the title-only teaser to keep the list compact
+		if device.Class == DevicePhone {
+			item.Summary = ""
+		}
+		if img := SelectVariant(t.Images, device); img != nil {
+			item.ImageURL = img.URL
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	w.Header().Set("X-Mobile-API-Version", APIVersion)
+	w.Header().Add("Vary", "X-Device-Class, X-Device-Pixel-Ratio, Sec-CH-DPR, User-Agent")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
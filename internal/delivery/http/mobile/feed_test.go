@@ -0,0 +1,91 @@
+package mobile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+)
+
+type stubFeedSource struct {
+	teasers   []Teaser
+	lastAfter *Cursor
+	lastLimit int
+}
+
+func (s *stubFeedSource) ListTeasers(ctx context.Context, after *Cursor, limit int) ([]Teaser, error) {
+	s.lastAfter = after
+	s.lastLimit = limit
+	if limit > len(s.teasers) {
+		return s.teasers, nil
+	}
+	return s.teasers[:limit], nil
+}
+
+func TestFeedHandler(t *testing.T) {
+	now := time.Now()
+	source := &stubFeedSource{}
+	for i := 0; i < 5; i++ {
+		source.teasers = append(source.teasers, Teaser{
+			ID:          string(rune('a' + i)),
+			Title:       "Story",
+			Summary:     "Summary",
+			PublishedAt: now.Add(-time.Duration(i) * time.Hour),
+			Images:      []ImageVariant{{Width: 480, URL: "small"}, {Width: 1600, URL: "large"}},
+		})
+	}
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(source))
+
+	req := httptest.NewRequest(http.MethodGet, "/mobile/v1/feed?limit=3", nil)
+	req.Header.Set("X-Device-Class", "phone")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if rec.Header().Get("X-Mobile-API-Version") != APIVersion {
+		t.Error("expected mobile API version header")
+	}
+	if source.lastLimit != 4 {
+		t.Errorf("expected source to be asked for 4 teasers, got %d", source.lastLimit)
+	}
+
+	var resp feedResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(resp.Items))
+	}
+	if resp.Items[0].Summary != "" {
+		t.Error("expected phone teasers without summary")
+	}
+	if resp.Items[0].ImageURL != "small" {
+		t.Errorf("expected small image for phone, got %s", resp.Items[0].ImageURL)
+	}
+
+	cursor, err := DecodeCursor(resp.NextCursor)
+	if err != nil {
+		t.Fatalf("expected valid next cursor: %v", err)
+	}
+	if cursor.ID != "c" {
+		t.Errorf("expected cursor at item c, got %s", cursor.ID)
+	}
+}
+
+func TestFeedHandler_InvalidParams(t *testing.T) {
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(&stubFeedSource{}))
+
+	for _, url := range []string{"/mobile/v1/feed?limit=0", "/mobile/v1/feed?limit=31", "/mobile/v1/feed?cursor=!!"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %s, got %d", url, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package mobile
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi"
+)
+
+// Contract tests: responses must match the published OpenAPI document
+func TestFeedContract(t *testing.T) {
+	b := openapi.NewBuilder("News Portal CMS", "test")
+	RegisterSpec(b)
+	doc := b.Document()
+
+	source := &stubFeedSource{teasers: []Teaser{
+		{ID: "a", Slug: "a", Title: "A", PublishedAt: time.Now(), Images: []ImageVariant{{Width: 480, URL: "small"}}},
+		{ID: "b", Slug: "b", Title: "B", Summary: "Summary", PublishedAt: time.Now()},
+	}}
+	router := NewRouter(source)
+
+	for _, tc := range []struct {
+		url    string
+		status int
+	}{
+		{"/mobile/v1/feed?limit=1", http.StatusOK},
+		{"/mobile/v1/feed?limit=abc", http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.url, nil))
+		if rec.Code != tc.status {
+			t.Fatalf("expected status %d for %s, got %d", tc.status, tc.url, rec.Code)
+		}
+		if err := doc.ValidateResponse(http.MethodGet, "/mobile/v1/feed", rec.Code, rec.Body.Bytes()); err != nil {
+			t.Errorf("contract violation for %s: %v", tc.url, err)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/moderation"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/moderation"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Moderation is the part of the moderation service the endpoints need
+type Moderation interface {
+	Queue(ctx context.Context, staffID *string, limit, offset int) ([]*moderation.Review, error)
+	Resolve(ctx context.Context, staffID, reviewID string, approve bool, at time.Time) (*moderation.Review, error)
+	Profiles(ctx context.Context) ([]*moderation.Profile, error)
+	SetLanguages(ctx context.Context, editorID, staffID string, languages []string, at time.Time) (*moderation.Profile, error)
+	Dictionary(ctx context.Context, language string) (*moderation.Dictionary, error)
+	SetDictionary(ctx context.Context, editorID, language string, words []string, at time.Time) (*moderation.Dictionary, error)
+	HeatedDiscussions(ctx context.Context, at time.Time) ([]moderation.DiscussionHeat, error)
+}
+
+type reviewResponse struct {
+	ID         string   `json:"id"`
+	CommentID  string   `json:"comment_id"`
+	ArticleID  string   `json:"article_id"`
+	Language   string   `json:"language"`
+	Matches    []string `json:"matches"`
+	Toxicity   float64  `json:"toxicity"`
+	Sentiment  float64  `json:"sentiment"`
+	ScoredBy   string   `json:"scored_by"`
+	Held       bool     `json:"held"`
+	AssigneeID *string  `json:"assignee_id,omitempty"`
+	Status     string   `json:"status"`
+	ResolvedBy *string  `json:"resolved_by,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+type heatResponse struct {
+	ArticleID     string  `json:"article_id"`
+	Comments      int     `json:"comments"`
+	MeanToxicity  float64 `json:"mean_toxicity"`
+	NegativeShare float64 `json:"negative_share"`
+}
+
+type languagesRequest struct {
+	Languages []string `json:"languages"`
+}
+
+type profileResponse struct {
+	StaffID   string   `json:"staff_id"`
+	Languages []string `json:"languages"`
+	UpdatedBy *string  `json:"updated_by,omitempty"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type dictionaryRequest struct {
+	Words []string `json:"words"`
+}
+
+type dictionaryResponse struct {
+	Language  string   `json:"language"`
+	Words     []string `json:"words"`
+	UpdatedBy *string  `json:"updated_by,omitempty"`
+	UpdatedAt *string  `json:"updated_at,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	moderation Moderation
+	staff      StaffResolver
+}
+
+func NewHandler(moderation Moderation, staff StaffResolver) *Handler {
+	return &Handler{moderation: moderation, staff: staff}
+}
+
+// NewAdminRouter mounts the comment moderation queues, heated discussions,
+// moderator languages and banned-word dictionaries, it must sit behind admin authentication
+func NewAdminRouter(moderation Moderation, staff StaffResolver) http.Handler {
+	h := NewHandler(moderation, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/moderation/queue", h.MyQueue)
+	mux.HandleFunc("GET /admin/moderation/queue/general", h.GeneralQueue)
+	mux.HandleFunc("POST /admin/moderation/reviews/{id}/approve", h.Approve)
+	mux.HandleFunc("POST /admin/moderation/reviews/{id}/remove", h.Remove)
+	mux.HandleFunc("GET /admin/moderation/heated", h.Heated)
+	mux.HandleFunc("GET /admin/moderation/moderators", h.Profiles)
+	mux.HandleFunc("PUT /admin/moderation/moderators/{staffID}/languages", h.SetLanguages)
+	mux.HandleFunc("GET /admin/moderation/dictionaries/{language}", h.Dictionary)
+	mux.HandleFunc("PUT /admin/moderation/dictionaries/{language}", h.SetDictionary)
+	return mux
+}
+
+// MyQueue returns the comments routed to the signed-in moderator
+func (h *Handler) MyQueue(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	h.queue(w, r, &staffID)
+}
+
+// GeneralQueue returns comments nobody could be matched to, e.g. undetected languages
+func (h *Handler) GeneralQueue(w http.ResponseWriter, r *http.Request) {
+	h.queue(w, r, nil)
+}
+
+func (h *Handler) queue(w http.ResponseWriter, r *http.Request, staffID *string) {
+	limit, offset, ok := pagination(r)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid pagination"})
+		return
+	}
+	reviews, err := h.moderation.Queue(r.Context(), staffID, limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]reviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		resp = append(resp, toReviewResponse(review))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, true)
+}
+
+func (h *Handler) Remove(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, false)
+}
+
+func (h *Handler) resolve(w http.ResponseWriter, r *http.Request, approve bool) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	review, err := h.moderation.Resolve(r.Context(), staffID, r.PathValue("id"), approve, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toReviewResponse(review))
+}
+
+// Heated lists articles whose comment sections turned toxic or negative, for editors
+func (h *Handler) Heated(w http.ResponseWriter, r *http.Request) {
+	heated, err := h.moderation.HeatedDiscussions(r.Context(), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]heatResponse, 0, len(heated))
+	for _, heat := range heated {
+		resp = append(resp, heatResponse{
+			ArticleID:     heat.ArticleID,
+			Comments:      heat.Comments,
+			MeanToxicity:  heat.MeanToxicity,
+			NegativeShare: heat.NegativeShare,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Profiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.moderation.Profiles(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]profileResponse, 0, len(profiles))
+	for _, p := range profiles {
+		resp = append(resp, toProfileResponse(p))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) SetLanguages(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req languagesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	profile, err := h.moderation.SetLanguages(r.Context(), editorID, r.PathValue("staffID"), req.Languages, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toProfileResponse(profile))
+}
+
+func (h *Handler) Dictionary(w http.ResponseWriter, r *http.Request) {
+	dictionary, err := h.moderation.Dictionary(r.Context(), r.PathValue("language"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDictionaryResponse(dictionary))
+}
+
+func (h *Handler) SetDictionary(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req dictionaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	dictionary, err := h.moderation.SetDictionary(r.Context(), editorID, r.PathValue("language"), req.Words, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDictionaryResponse(dictionary))
+}
+
+func pagination(r *http.Request) (int, int, bool) {
+	limit, offset := 20, 0
+	var err error
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 || limit > 100 {
+			return 0, 0, false
+		}
+	}
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, false
+		}
+	}
+	return limit, offset, true
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrReviewNotFound), errors.Is(err, app.ErrCommentNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, moderation.ErrAlreadyResolved):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, moderation.ErrInvalidLanguage), errors.Is(err, moderation.ErrNoLanguages),
+		errors.Is(err, moderation.ErrInvalidWord), errors.Is(err, moderation.ErrTooManyWords):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toReviewResponse(r *moderation.Review) reviewResponse {
+	resp := reviewResponse{
+		ID:         r.ID,
+		CommentID:  r.CommentID,
+		ArticleID:  r.ArticleID,
+		Language:   string(r.Language),
+		Matches:    r.Matches,
+		Toxicity:   r.Scores.Toxicity,
+		Sentiment:  r.Scores.Sentiment,
+		ScoredBy:   string(r.Scores.Source),
+		Held:       r.Held,
+		AssigneeID: r.AssigneeID,
+		Status:     string(r.Status),
+		ResolvedBy: r.ResolvedBy,
+		CreatedAt:  r.CreatedAt.Format(time.RFC3339),
+	}
+	if resp.Matches == nil {
+		resp.Matches = []string{}
+	}
+	return resp
+}
+
+func toProfileResponse(p *moderation.Profile) profileResponse {
+	resp := profileResponse{StaffID: p.StaffID, Languages: make([]string, 0, len(p.Languages)), UpdatedBy: p.LastActionBy, UpdatedAt: p.UpdatedAt.Format(time.RFC3339)}
+	for _, l := range p.Languages {
+		resp.Languages = append(resp.Languages, string(l))
+	}
+	return resp
+}
+
+func toDictionaryResponse(d *moderation.Dictionary) dictionaryResponse {
+	resp := dictionaryResponse{Language: string(d.Language), Words: d.Words, UpdatedBy: d.LastActionBy}
+	if resp.Words == nil {
+		resp.Words = []string{}
+	}
+	if !d.UpdatedAt.IsZero() {
+		at := d.UpdatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
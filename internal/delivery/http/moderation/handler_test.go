@@ -0,0 +1,136 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/moderation"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/moderation"
+)
+
+type fakeModeration struct {
+	queueFor *string
+	approved *bool
+	err      error
+}
+
+func (f *fakeModeration) Queue(ctx context.Context, staffID *string, limit, offset int) ([]*moderation.Review, error) {
+	f.queueFor = staffID
+	if f.err != nil {
+		return nil, f.err
+	}
+	review, _ := moderation.NewReview("r1", "c1", "a1", moderation.LanguageJavanese, nil, time.Now())
+	return []*moderation.Review{review}, nil
+}
+
+func (f *fakeModeration) Resolve(ctx context.Context, staffID, reviewID string, approve bool, at time.Time) (*moderation.Review, error) {
+	f.approved = &approve
+	if f.err != nil {
+		return nil, f.err
+	}
+	review, _ := moderation.NewReview(reviewID, "c1", "a1", moderation.LanguageJavanese, nil, at)
+	return review, review.Resolve(staffID, approve, at)
+}
+
+func (f *fakeModeration) Profiles(ctx context.Context) ([]*moderation.Profile, error) {
+	return nil, f.err
+}
+
+func (f *fakeModeration) SetLanguages(ctx context.Context, editorID, staffID string, languages []string, at time.Time) (*moderation.Profile, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return moderation.NewProfile(staffID, languages, editorID, at)
+}
+
+func (f *fakeModeration) Dictionary(ctx context.Context, language string) (*moderation.Dictionary, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &moderation.Dictionary{Language: moderation.Language(language)}, nil
+}
+
+func (f *fakeModeration) SetDictionary(ctx context.Context, editorID, language string, words []string, at time.Time) (*moderation.Dictionary, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return moderation.NewDictionary(moderation.Language(language), words, editorID, at)
+}
+
+func (f *fakeModeration) HeatedDiscussions(ctx context.Context, at time.Time) ([]moderation.DiscussionHeat, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []moderation.DiscussionHeat{{ArticleID: "a1", Comments: 14, MeanToxicity: 0.42, NegativeShare: 0.7, Heated: true}}, nil
+}
+
+func TestHandler_Admin(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "ana", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"my queue", http.MethodGet, "/admin/moderation/queue", "", true, nil, http.StatusOK},
+		{"my queue without session", http.MethodGet, "/admin/moderation/queue", "", false, nil, http.StatusUnauthorized},
+		{"general queue", http.MethodGet, "/admin/moderation/queue/general?limit=50", "", true, nil, http.StatusOK},
+		{"invalid limit", http.MethodGet, "/admin/moderation/queue/general?limit=500", "", true, nil, http.StatusBadRequest},
+		{"approve", http.MethodPost, "/admin/moderation/reviews/r1/approve", "", true, nil, http.StatusOK},
+		{"remove", http.MethodPost, "/admin/moderation/reviews/r1/remove", "", true, nil, http.StatusOK},
+		{"unknown review", http.MethodPost, "/admin/moderation/reviews/r9/approve", "", true, app.ErrReviewNotFound, http.StatusNotFound},
+		{"already resolved", http.MethodPost, "/admin/moderation/reviews/r1/remove", "", true, moderation.ErrAlreadyResolved, http.StatusConflict},
+		{"set languages", http.MethodPut, "/admin/moderation/moderators/ana/languages", `{"languages":["id","jv"]}`, true, nil, http.StatusOK},
+		{"unsupported language", http.MethodPut, "/admin/moderation/moderators/ana/languages", `{"languages":["fr"]}`, true, nil, http.StatusUnprocessableEntity},
+		{"invalid body", http.MethodPut, "/admin/moderation/moderators/ana/languages", `{`, true, nil, http.StatusBadRequest},
+		{"get dictionary", http.MethodGet, "/admin/moderation/dictionaries/id", "", true, nil, http.StatusOK},
+		{"set dictionary", http.MethodPut, "/admin/moderation/dictionaries/id", `{"words":["bodoh"]}`, true, nil, http.StatusOK},
+		{"empty word", http.MethodPut, "/admin/moderation/dictionaries/id", `{"words":[""]}`, true, nil, http.StatusUnprocessableEntity},
+		{"store failure", http.MethodGet, "/admin/moderation/moderators", "", true, errors.New("connection reset"), http.StatusInternalServerError},
+		{"heated discussions", http.MethodGet, "/admin/moderation/heated", "", true, nil, http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			moderations := &fakeModeration{err: tc.err}
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(moderations, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			switch tc.name {
+			case "my queue":
+				if moderations.queueFor == nil || *moderations.queueFor != "ana" {
+					t.Errorf("expected the signed-in moderator's queue, got %v", moderations.queueFor)
+				}
+			case "general queue":
+				if moderations.queueFor != nil {
+					t.Errorf("expected the general queue, got %v", *moderations.queueFor)
+				}
+			case "heated discussions":
+				if !strings.Contains(rec.Body.String(), `"mean_toxicity":0.42`) {
+					t.Errorf("expected the heat signal, got %s", rec.Body.String())
+				}
+			case "remove":
+				if moderations.approved == nil || *moderations.approved {
+					t.Error("expected remove to reject the comment")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package newsletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Segments is the part of the segment service the endpoints need
+type Segments interface {
+	Create(ctx context.Context, staffID, name, description string, rules newsletter.Group) (*newsletter.Segment, error)
+	Update(ctx context.Context, staffID, id, name, description string, rules newsletter.Group) (*newsletter.Segment, error)
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (*newsletter.Segment, error)
+	List(ctx context.Context) ([]*newsletter.Segment, error)
+	Preview(ctx context.Context, rules newsletter.Group, at time.Time) (*app.Preview, error)
+	Count(ctx context.Context, id string, at time.Time) (*newsletter.Segment, error)
+}
+
+type ruleRequest struct {
+	Field    string   `json:"field"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+// groupRequest is a rule tree, e.g. {"match":"all","rules":[{"field":"follows","operator":"contains","values":["tech"]}]}
+type groupRequest struct {
+	Match  string         `json:"match"`
+	Rules  []ruleRequest  `json:"rules"`
+	Groups []groupRequest `json:"groups,omitempty"`
+}
+
+type segmentRequest struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Rules       groupRequest `json:"rules"`
+}
+
+type segmentResponse struct {
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description,omitempty"`
+	Rules         groupRequest `json:"rules"`
+	LastCount     *int64       `json:"last_count,omitempty"`
+	LastCountedAt *string      `json:"last_counted_at,omitempty"`
+	UpdatedBy     string       `json:"updated_by"`
+	UpdatedAt     string       `json:"updated_at"`
+}
+
+type sampleResponse struct {
+	AccountID string `json:"account_id"`
+	Email     string `json:"email"`
+}
+
+type previewResponse struct {
+	Count  int64            `json:"count"`
+	Sample []sampleResponse `json:"sample"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	segments Segments
+	staff    StaffResolver
+}
+
+func NewHandler(segments Segments, staff StaffResolver) *Handler {
+	return &Handler{segments: segments, staff: staff}
+}
+
+// NewAdminRouter mounts segment management and preview counts, it must sit behind admin authentication
+func NewAdminRouter(segments Segments, staff StaffResolver) http.Handler {
+	h := NewHandler(segments, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/newsletter/segments", h.List)
+	mux.HandleFunc("POST /admin/newsletter/segments", h.Create)
+	mux.HandleFunc("POST /admin/newsletter/segments/preview", h.Preview)
+	mux.HandleFunc("GET /admin/newsletter/segments/{id}", h.Get)
+	mux.HandleFunc("PUT /admin/newsletter/segments/{id}", h.Update)
+	mux.HandleFunc("DELETE /admin/newsletter/segments/{id}", h.Delete)
+	mux.HandleFunc("POST /admin/newsletter/segments/{id}/count", h.Count)
+	return mux
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	segments, err := h.segments.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]segmentResponse, 0, len(segments))
+	for _, s := range segments {
+		resp = append(resp, toSegmentResponse(s))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	segment, err := h.segments.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSegmentResponse(segment))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req segmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	segment, err := h.segments.Create(r.Context(), staffID, req.Name, req.Description, req.Rules.toGroup())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSegmentResponse(segment))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req segmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	segment, err := h.segments.Update(r.Context(), staffID, r.PathValue("id"), req.Name, req.Description, req.Rules.toGroup())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSegmentResponse(segment))
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.segments.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Preview counts an unsaved rule tree so editors can tune it before saving
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	var req groupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	preview, err := h.segments.Preview(r.Context(), req.toGroup(), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := previewResponse{Count: preview.Count, Sample: make([]sampleResponse, 0, len(preview.Sample))}
+	for _, p := range preview.Sample {
+		resp.Sample = append(resp.Sample, sampleResponse{AccountID: p.AccountID, Email: p.Email})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Count refreshes the saved count shown in the segment list
+func (h *Handler) Count(w http.ResponseWriter, r *http.Request) {
+	segment, err := h.segments.Count(r.Context(), r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSegmentResponse(segment))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrSegmentNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, newsletter.ErrInvalidRule), errors.Is(err, newsletter.ErrSegmentNameEmpty), errors.Is(err, newsletter.ErrSegmentNameTooLong):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func (g groupRequest) toGroup() newsletter.Group {
+	group := newsletter.Group{Match: newsletter.Match(g.Match)}
+	for _, rule := range g.Rules {
+		group.Rules = append(group.Rules, newsletter.Rule{Field: newsletter.Field(rule.Field), Operator: newsletter.Operator(rule.Operator), Values: rule.Values})
+	}
+	for _, nested := range g.Groups {
+		group.Groups = append(group.Groups, nested.toGroup())
+	}
+	return group
+}
+
+func toGroupResponse(g newsletter.Group) groupRequest {
+	resp := groupRequest{Match: string(g.Match), Rules: make([]ruleRequest, 0, len(g.Rules))}
+	for _, rule := range g.Rules {
+		resp.Rules = append(resp.Rules, ruleRequest{Field: string(rule.Field), Operator: string(rule.Operator), Values: rule.Values})
+	}
+	for _, nested := range g.Groups {
+		resp.Groups = append(resp.Groups, toGroupResponse(nested))
+	}
+	return resp
+}
+
+func toSegmentResponse(s *newsletter.Segment) segmentResponse {
+	resp := segmentResponse{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		Rules:       toGroupResponse(s.Rules),
+		LastCount:   s.LastCount,
+		UpdatedBy:   s.UpdatedBy,
+		UpdatedAt:   s.UpdatedAt.Format(time.RFC3339),
+	}
+	if s.LastCountedAt != nil {
+		at := s.LastCountedAt.Format(time.RFC3339)
+		resp.LastCountedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,111 @@
+package newsletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type fakeSegments struct {
+	Segments
+	rules newsletter.Group
+	err   error
+}
+
+func (f *fakeSegments) Create(ctx context.Context, staffID, name, description string, rules newsletter.Group) (*newsletter.Segment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.rules = rules
+	return newsletter.NewSegment("seg-1", name, description, rules, staffID)
+}
+
+func (f *fakeSegments) Preview(ctx context.Context, rules newsletter.Group, at time.Time) (*app.Preview, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.rules = rules
+	return &app.Preview{Count: 1250, Sample: []newsletter.Profile{{AccountID: "acc-1", Email: "reader@example.com"}}}, nil
+}
+
+func staff(r *http.Request) (string, bool) {
+	return "staff-1", r.Header.Get("X-Staff") != ""
+}
+
+const engagedTechBody = `{"name":"Engaged tech readers","rules":{"match":"all","rules":[
+	{"field":"follows","operator":"contains","values":["tech"]},
+	{"field":"articles_read_7d","operator":"gte","values":["3"]}
+],"groups":[{"match":"any","rules":[{"field":"premium","operator":"eq","values":["true"]}]}]}}`
+
+func TestHandler_CreateSegment(t *testing.T) {
+	segments := &fakeSegments{}
+	router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(segments, staff))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/newsletter/segments", strings.NewReader(engagedTechBody))
+	req.Header.Set("X-Staff", "1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(segments.rules.Rules) != 2 || len(segments.rules.Groups) != 1 || segments.rules.Groups[0].Rules[0].Field != newsletter.FieldPremium {
+		t.Errorf("expected nested rule tree, got %+v", segments.rules)
+	}
+
+	var resp segmentResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.ID != "seg-1" || resp.UpdatedBy != "staff-1" || len(resp.Rules.Groups) != 1 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/newsletter/segments", strings.NewReader(engagedTechBody)))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+}
+
+func TestHandler_PreviewSegment(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"ok", `{"match":"all","rules":[{"field":"premium","operator":"eq","values":["true"]}]}`, nil, http.StatusOK},
+		{"malformed body", `{"match":`, nil, http.StatusBadRequest},
+		{"invalid rule", `{"match":"all","rules":[]}`, newsletter.ErrInvalidRule, http.StatusUnprocessableEntity},
+		{"store failure", `{"match":"all"}`, errors.New("connection reset"), http.StatusInternalServerError},
+		{"scan timed out", `{"match":"all"}`, &shared.TimeoutError{Operation: "newsletter.ScanProfiles", Kind: shared.OperationReport, Limit: 10 * time.Second}, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/admin/newsletter/segments/preview", strings.NewReader(tc.body))
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeSegments{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				var resp previewResponse
+				_ = json.NewDecoder(rec.Body).Decode(&resp)
+				if resp.Count != 1250 || len(resp.Sample) != 1 {
+					t.Errorf("unexpected preview %+v", resp)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package newsletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/middleware"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Subscriptions is the part of the subscription service the endpoints need
+type Subscriptions interface {
+	Subscribe(ctx context.Context, email, source, ip string, at time.Time) error
+	Confirm(ctx context.Context, token, ip string, at time.Time) (*newsletter.Subscriber, error)
+	Proof(ctx context.Context, email string) (*app.OptInProof, error)
+	Subscribers(ctx context.Context, status *newsletter.SubscriberStatus, limit, offset int) ([]*newsletter.Subscriber, int64, error)
+}
+
+type subscribeRequest struct {
+	Email  string `json:"email"`
+	Source string `json:"source"`
+}
+
+type confirmRequest struct {
+	Token string `json:"token"`
+}
+
+type subscriberResponse struct {
+	Email       string  `json:"email"`
+	Status      string  `json:"status"`
+	Source      string  `json:"source,omitempty"`
+	RequestedAt string  `json:"requested_at"`
+	RequestIP   *string `json:"request_ip,omitempty"`
+	ConfirmedAt *string `json:"confirmed_at,omitempty"`
+	ConfirmIP   *string `json:"confirm_ip,omitempty"`
+}
+
+type proofResponse struct {
+	subscriberResponse
+	Suppressed       bool    `json:"suppressed"`
+	SuppressedReason *string `json:"suppressed_reason,omitempty"`
+}
+
+type subscriberListResponse struct {
+	Items []subscriberResponse `json:"items"`
+	Total int64                `json:"total"`
+}
+
+type SubscriptionHandler struct {
+	subscriptions Subscriptions
+}
+
+func NewSubscriptionHandler(subscriptions Subscriptions) *SubscriptionHandler {
+	return &SubscriptionHandler{subscriptions: subscriptions}
+}
+
+// NewSubscriptionRouter mounts the public signup and confirmation endpoints
+func NewSubscriptionRouter(subscriptions Subscriptions) http.Handler {
+	h := NewSubscriptionHandler(subscriptions)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /newsletter/subscribe", h.Subscribe)
+	mux.HandleFunc("POST /newsletter/confirm", h.Confirm)
+	return mux
+}
+
+// NewSubscribersAdminRouter mounts the opt-in compliance report, it must sit behind admin authentication
+func NewSubscribersAdminRouter(subscriptions Subscriptions) http.Handler {
+	h := NewSubscriptionHandler(subscriptions)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/newsletter/subscribers", h.List)
+	mux.HandleFunc("GET /admin/newsletter/subscribers/{email}/proof", h.Proof)
+	return mux
+}
+
+// Subscribe always answers 202 for a valid address, whether or not an email went out
+func (h *SubscriptionHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var req subscribeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	if err := h.subscriptions.Subscribe(r.Context(), req.Email, req.Source, middleware.ClientIP(r), time.Now()); err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Confirm is a POST from the landing page, not the emailed GET link, so mail
+// scanners that prefetch links cannot confirm on the reader's behalf
+func (h *SubscriptionHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	var req confirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	subscriber, err := h.subscriptions.Confirm(r.Context(), req.Token, middleware.ClientIP(r), time.Now())
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"email": subscriber.Email.String(), "status": string(subscriber.Status)})
+}
+
+// List handles GET /admin/newsletter/subscribers?status=confirmed&limit=20&offset=0
+func (h *SubscriptionHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var status *newsletter.SubscriberStatus
+	if raw := q.Get("status"); raw != "" {
+		s := newsletter.SubscriberStatus(raw)
+		status = &s
+	}
+
+	limit, err := intParam(q.Get("limit"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit must be a number"})
+		return
+	}
+	offset, err := intParam(q.Get("offset"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "offset must be a number"})
+		return
+	}
+
+	subscribers, total, err := h.subscriptions.Subscribers(r.Context(), status, limit, offset)
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+	resp := subscriberListResponse{Items: make([]subscriberResponse, 0, len(subscribers)), Total: total}
+	for _, s := range subscribers {
+		resp.Items = append(resp.Items, toSubscriberResponse(s))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *SubscriptionHandler) Proof(w http.ResponseWriter, r *http.Request) {
+	proof, err := h.subscriptions.Proof(r.Context(), r.PathValue("email"))
+	if err != nil {
+		writeSubscriptionError(w, err)
+		return
+	}
+
+	resp := proofResponse{subscriberResponse: toSubscriberResponse(proof.Subscriber), Suppressed: proof.Suppression != nil}
+	if proof.Suppression != nil {
+		reason := string(proof.Suppression.Reason)
+		resp.SuppressedReason = &reason
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeSubscriptionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, account.ErrInvalidEmail):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrSubscriberNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, newsletter.ErrInvalidConfirmation), errors.Is(err, newsletter.ErrConfirmationExpired):
+		writeJSON(w, http.StatusGone, errorResponse{Error: err.Error()})
+	case errors.Is(err, newsletter.ErrAlreadyConfirmed):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toSubscriberResponse(s *newsletter.Subscriber) subscriberResponse {
+	resp := subscriberResponse{
+		Email:       s.Email.String(),
+		Status:      string(s.Status),
+		Source:      s.OptIn.Source,
+		RequestedAt: s.OptIn.RequestedAt.Format(time.RFC3339),
+		RequestIP:   s.OptIn.RequestIP,
+		ConfirmIP:   s.OptIn.ConfirmIP,
+	}
+	if s.OptIn.ConfirmedAt != nil {
+		at := s.OptIn.ConfirmedAt.Format(time.RFC3339)
+		resp.ConfirmedAt = &at
+	}
+	return resp
+}
+
+func intParam(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
@@ -0,0 +1,113 @@
+package newsletter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/newsletter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakeSubscriptions struct {
+	Subscriptions
+	ip         string
+	confirmErr error
+	proof      *app.OptInProof
+}
+
+func (f *fakeSubscriptions) Subscribe(ctx context.Context, email, source, ip string, at time.Time) error {
+	f.ip = ip
+	_, err := account.NewEmail(email)
+	return err
+}
+
+func (f *fakeSubscriptions) Confirm(ctx context.Context, token, ip string, at time.Time) (*newsletter.Subscriber, error) {
+	if f.confirmErr != nil {
+		return nil, f.confirmErr
+	}
+	return f.proof.Subscriber, nil
+}
+
+func (f *fakeSubscriptions) Proof(ctx context.Context, email string) (*app.OptInProof, error) {
+	if f.proof == nil {
+		return nil, app.ErrSubscriberNotFound
+	}
+	return f.proof, nil
+}
+
+func createTestProof(t *testing.T) *app.OptInProof {
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	email, _ := account.NewEmail("reader@example.com")
+	subscriber, err := newsletter.NewSubscriber("sub-1", *email, "footer", "203.0.113.7", "hash-1", at)
+	if err != nil {
+		t.Fatalf("failed to create subscriber: %v", err)
+	}
+	_ = subscriber.Confirm("hash-1", "198.51.100.2", at.Add(time.Hour))
+	entry, _ := suppression.NewEntry(*email, suppression.ReasonComplaint, "postmark", "", at.Add(24*time.Hour))
+	return &app.OptInProof{Subscriber: subscriber, Suppression: entry}
+}
+
+func TestSubscriptionHandler_SubscribeAndConfirm(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		body           string
+		confirmErr     error
+		expectedStatus int
+	}{
+		{"subscribe", "/newsletter/subscribe", `{"email":"reader@example.com","source":"footer"}`, nil, http.StatusAccepted},
+		{"invalid email", "/newsletter/subscribe", `{"email":"reader"}`, nil, http.StatusBadRequest},
+		{"confirm", "/newsletter/confirm", `{"token":"abc"}`, nil, http.StatusOK},
+		{"expired link", "/newsletter/confirm", `{"token":"abc"}`, newsletter.ErrConfirmationExpired, http.StatusGone},
+		{"already confirmed", "/newsletter/confirm", `{"token":"abc"}`, newsletter.ErrAlreadyConfirmed, http.StatusConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subscriptions := &fakeSubscriptions{confirmErr: tc.confirmErr, proof: createTestProof(t)}
+			req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+			req.RemoteAddr = "203.0.113.7:51234"
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewSubscriptionRouter(subscriptions)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.name == "subscribe" && subscriptions.ip != "203.0.113.7" {
+				t.Errorf("expected client IP to be recorded, got %q", subscriptions.ip)
+			}
+		})
+	}
+}
+
+func TestSubscriptionHandler_Proof(t *testing.T) {
+	rec := httptest.NewRecorder()
+	router := openapitest.Handler(t, RegisterSpec, NewSubscribersAdminRouter(&fakeSubscriptions{proof: createTestProof(t)}))
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/newsletter/subscribers/reader@example.com/proof", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp proofResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Status != "confirmed" || *resp.RequestIP != "203.0.113.7" || *resp.ConfirmIP != "198.51.100.2" || resp.ConfirmedAt == nil {
+		t.Errorf("unexpected opt-in proof %+v", resp.subscriberResponse)
+	}
+	if !resp.Suppressed || *resp.SuppressedReason != "complaint" {
+		t.Errorf("expected complaint suppression, got %v %v", resp.Suppressed, resp.SuppressedReason)
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewSubscribersAdminRouter(&fakeSubscriptions{})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/newsletter/subscribers/nobody@example.com/proof", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
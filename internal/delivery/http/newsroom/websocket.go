@@ -0,0 +1,131 @@
+package newsroom
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/newsroom"
+)
+
+// Keepalive timings, pings let both sides notice dead connections behind proxies
+const (
+	writeTimeout = 10 * time.Second
+	pongTimeout  = 60 * time.Second
+	pingInterval = 25 * time.Second
+)
+
+// Authorizer decides whether the request comes from newsroom staff
+type Authorizer func(r *http.Request) bool
+
+type Handler struct {
+	hub       *newsroom.Hub
+	authorize Authorizer
+	upgrader  websocket.Upgrader
+}
+
+// NewHandler serves the live dashboard at e.g. /newsroom/live?desk=politics&desk=sport&since=42.
+// On reconnect the client passes the seq of the last update it processed as "since".
+func NewHandler(hub *newsroom.Hub, authorize Authorizer, allowedOrigins []string) *Handler {
+	origins := make(map[string]bool, len(allowedOrigins))
+	for _, o := range allowedOrigins {
+		origins[o] = true
+	}
+
+	return &Handler{
+		hub:       hub,
+		authorize: authorize,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || origins[origin]
+			},
+		},
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorize(r) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	var since uint64
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			http.Error(w, "since must be a sequence number", http.StatusBadRequest)
+			return
+		}
+		since = n
+	}
+	desks := parseDesks(r.URL.Query()["desk"])
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written the error response
+		return
+	}
+	defer conn.Close()
+
+	sub := h.hub.Subscribe(desks, since)
+	defer h.hub.Unsubscribe(sub)
+
+	// The dashboard is push only; reading is needed to process pongs and notice a close
+	done := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongTimeout))
+	})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-r.Context().Done():
+			return
+		case update, ok := <-sub.Updates():
+			if !ok {
+				// Fell behind, ask the client to reconnect with its last seq
+				closeMessage := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber too slow")
+				_ = conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(writeTimeout))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(update); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseDesks accepts repeated and comma separated desk parameters
+func parseDesks(values []string) []string {
+	var desks []string
+	for _, v := range values {
+		for _, d := range strings.Split(v, ",") {
+			if d = strings.TrimSpace(strings.ToLower(d)); d != "" {
+				desks = append(desks, d)
+			}
+		}
+	}
+	return desks
+}
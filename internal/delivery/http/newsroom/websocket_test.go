@@ -0,0 +1,64 @@
+package newsroom
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/newsroom"
+)
+
+func TestHandler_StreamsSnapshotAndUpdates(t *testing.T) {
+	hub := newsroom.NewHub(nil)
+	hub.UpdateReaders(newsroom.ArticleReaders{ArticleID: "a1", Desk: "sport", Readers: 80}, time.Now())
+
+	server := httptest.NewServer(NewHandler(hub, func(r *http.Request) bool { return true }, nil))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?desk=sport,politics"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var snapshot newsroom.Update
+	if err := conn.ReadJSON(&snapshot); err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	if snapshot.Type != newsroom.UpdateSnapshot || len(snapshot.Snapshot.Readers) != 1 {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+
+	hub.RecordPublishing(newsroom.PublishingEvent{ArticleID: "a2", Desk: "culture"}, time.Now())
+	hub.RecordPublishing(newsroom.PublishingEvent{ArticleID: "a3", Desk: "politics"}, time.Now())
+
+	var update newsroom.Update
+	if err := conn.ReadJSON(&update); err != nil {
+		t.Fatalf("failed to read update: %v", err)
+	}
+	if update.Publishing == nil || update.Publishing.ArticleID != "a3" {
+		t.Errorf("expected politics publishing update, got %+v", update)
+	}
+}
+
+func TestHandler_Rejects(t *testing.T) {
+	hub := newsroom.NewHub(nil)
+
+	forbidden := httptest.NewRecorder()
+	NewHandler(hub, func(r *http.Request) bool { return false }, nil).ServeHTTP(forbidden, httptest.NewRequest(http.MethodGet, "/", nil))
+	if forbidden.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", forbidden.Code)
+	}
+
+	badSince := httptest.NewRecorder()
+	NewHandler(hub, func(r *http.Request) bool { return true }, nil).ServeHTTP(badSince, httptest.NewRequest(http.MethodGet, "/?since=abc", nil))
+	if badSince.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", badSince.Code)
+	}
+}
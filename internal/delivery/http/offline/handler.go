@@ -0,0 +1,172 @@
+package offline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/offline"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// MemberResolver returns the signed-in member, guests get ok false
+type MemberResolver func(r *http.Request) (string, bool)
+
+// Packages is the part of the offline service the endpoints need
+type Packages interface {
+	Build(ctx context.Context, accountID string, format offline.Format, have offline.Manifest, at time.Time) (*offline.Package, error)
+	WriteBook(w io.Writer, pkg *offline.Package) error
+}
+
+// packageRequest carries the app's manifest, e.g. {"format":"json","have":{"a1":"r3"}}.
+// Leaving have out asks for the whole queue.
+type packageRequest struct {
+	Format string            `json:"format"`
+	Have   map[string]string `json:"have"`
+}
+
+type imageResponse struct {
+	Name        string `json:"name"`
+	Alt         string `json:"alt,omitempty"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"` // Base64 in JSON
+}
+
+type articleResponse struct {
+	ID          string          `json:"id"`
+	Revision    string          `json:"revision"`
+	Title       string          `json:"title"`
+	Byline      string          `json:"byline,omitempty"`
+	Section     string          `json:"section,omitempty"`
+	URL         string          `json:"url"`
+	PublishedAt string          `json:"published_at"`
+	UpdatedAt   string          `json:"updated_at"`
+	Body        string          `json:"body"`
+	Images      []imageResponse `json:"images"`
+}
+
+type packageResponse struct {
+	Articles    []articleResponse `json:"articles"`
+	Removed     []string          `json:"removed"`
+	Locked      []string          `json:"locked"`
+	Manifest    map[string]string `json:"manifest"`
+	Delta       bool              `json:"delta"`
+	GeneratedAt string            `json:"generated_at"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	packages Packages
+	member   MemberResolver
+}
+
+func NewHandler(packages Packages, member MemberResolver) *Handler {
+	return &Handler{packages: packages, member: member}
+}
+
+// NewRouter mounts offline package downloads for signed-in members
+func NewRouter(packages Packages, member MemberResolver) http.Handler {
+	h := NewHandler(packages, member)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /offline/packages", h.Build)
+	return mux
+}
+
+// Build returns the member's reading queue as JSON for the app or as an EPUB download
+func (h *Handler) Build(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in required"})
+		return
+	}
+	var req packageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	format, err := offline.ParseFormat(req.Format)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	now := time.Now()
+	pkg, err := h.packages.Build(r.Context(), accountID, format, offline.Manifest(req.Have), now)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if format == offline.FormatEPUB {
+		// Buffered so a failed encode still gets a proper error response
+		var book bytes.Buffer
+		if err := h.packages.WriteBook(&book, pkg); err != nil {
+			writeError(w, err)
+			return
+		}
+		filename := "reading-list-" + now.Format("2006-01-02") + ".epub"
+		w.Header().Set("Content-Type", "application/epub+zip")
+		w.Header().Set("Content-Length", strconv.Itoa(book.Len()))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		w.WriteHeader(http.StatusOK)
+		_, _ = book.WriteTo(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPackageResponse(pkg))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, offline.ErrInvalidFormat), errors.Is(err, offline.ErrDeltaNeedsJSON):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toPackageResponse(p *offline.Package) packageResponse {
+	resp := packageResponse{
+		Articles:    make([]articleResponse, 0, len(p.Articles)),
+		Removed:     append([]string{}, p.Removed...),
+		Locked:      append([]string{}, p.Locked...),
+		Manifest:    p.Manifest,
+		Delta:       p.Delta,
+		GeneratedAt: p.GeneratedAt.Format(time.RFC3339),
+	}
+	for _, a := range p.Articles {
+		article := articleResponse{
+			ID:          a.ID,
+			Revision:    a.Revision,
+			Title:       a.Title,
+			Byline:      a.Byline,
+			Section:     a.Section,
+			URL:         a.URL,
+			PublishedAt: a.PublishedAt.Format(time.RFC3339),
+			UpdatedAt:   a.UpdatedAt.Format(time.RFC3339),
+			Body:        a.Body,
+			Images:      make([]imageResponse, 0, len(a.Images)),
+		}
+		for _, img := range a.Images {
+			article.Images = append(article.Images, imageResponse{Name: img.Name, Alt: img.Alt, ContentType: img.ContentType, Data: img.Data})
+		}
+		resp.Articles = append(resp.Articles, article)
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,127 @@
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/offline"
+)
+
+type fakePackages struct {
+	format offline.Format
+	have   offline.Manifest
+	err    error
+}
+
+func (f *fakePackages) Build(ctx context.Context, accountID string, format offline.Format, have offline.Manifest, at time.Time) (*offline.Package, error) {
+	f.format, f.have = format, have
+	if f.err != nil {
+		return nil, f.err
+	}
+	pkg, err := offline.NewPackage(accountID, format, have != nil, at)
+	if err != nil {
+		return nil, err
+	}
+	pkg.Add(offline.Article{ID: "a1", Revision: "r2", Title: "Banjir", Body: `<img src="images/a1-0.jpg">`,
+		Images: []offline.Image{{Name: "a1-0.jpg", ContentType: "image/jpeg", Data: []byte("jpeg")}}})
+	pkg.Lock("a9")
+	return pkg, nil
+}
+
+func (f *fakePackages) WriteBook(w io.Writer, pkg *offline.Package) error {
+	_, err := io.WriteString(w, "PK-book")
+	return err
+}
+
+func member(r *http.Request) (string, bool) {
+	return "acc-1", r.Header.Get("Authorization") != ""
+}
+
+func TestHandler_Build(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+		expectedType   string
+	}{
+		{"full json", `{}`, nil, http.StatusOK, "application/json"},
+		{"empty body", ``, nil, http.StatusOK, "application/json"},
+		{"delta json", `{"format":"json","have":{"a1":"r1"}}`, nil, http.StatusOK, "application/json"},
+		{"epub", `{"format":"epub"}`, nil, http.StatusOK, "application/epub+zip"},
+		{"epub delta", `{"format":"epub","have":{"a1":"r1"}}`, nil, http.StatusBadRequest, "application/json"},
+		{"unknown format", `{"format":"pdf"}`, nil, http.StatusBadRequest, "application/json"},
+		{"invalid body", `{`, nil, http.StatusBadRequest, "application/json"},
+		{"store failure", `{}`, errors.New("connection reset"), http.StatusInternalServerError, "application/json"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			packages := &fakePackages{err: tc.err}
+			req := httptest.NewRequest(http.MethodPost, "/offline/packages", strings.NewReader(tc.body))
+			req.Header.Set("Authorization", "Bearer token")
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(packages, member)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if got := rec.Header().Get("Content-Type"); got != tc.expectedType {
+				t.Errorf("expected content type %s, got %s", tc.expectedType, got)
+			}
+		})
+	}
+}
+
+func TestHandler_BuildJSON(t *testing.T) {
+	packages := &fakePackages{}
+	req := httptest.NewRequest(http.MethodPost, "/offline/packages", strings.NewReader(`{"have":{"a1":"r1","a5":"r1"}}`))
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(packages, member)).ServeHTTP(rec, req)
+
+	if packages.have["a5"] != "r1" {
+		t.Errorf("expected manifest passed to the service, got %v", packages.have)
+	}
+	var resp packageResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if !resp.Delta || len(resp.Articles) != 1 || resp.Manifest["a1"] != "r2" {
+		t.Errorf("unexpected package %+v", resp)
+	}
+	if img := resp.Articles[0].Images; len(img) != 1 || string(img[0].Data) != "jpeg" {
+		t.Errorf("expected bundled image data, got %+v", img)
+	}
+	if len(resp.Locked) != 1 || resp.Locked[0] != "a9" {
+		t.Errorf("expected locked article, got %v", resp.Locked)
+	}
+}
+
+func TestHandler_BuildEPUB(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/offline/packages", strings.NewReader(`{"format":"epub"}`))
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakePackages{}, member)).ServeHTTP(rec, req)
+
+	if !strings.HasPrefix(rec.Header().Get("Content-Disposition"), "attachment; filename=reading-list-") {
+		t.Errorf("expected attachment disposition, got %s", rec.Header().Get("Content-Disposition"))
+	}
+	if rec.Body.String() != "PK-book" {
+		t.Errorf("expected book bytes, got %s", rec.Body.String())
+	}
+}
+
+func TestHandler_BuildRequiresMember(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakePackages{}, member)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/offline/packages", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Route declares one REST endpoint. Request and response bodies are example
+// values (usually zero values of the DTO types) or reflect.Types the schemas
+// are generated from, Raw for bodies that are not JSON, or AnyOf several.
+type Route struct {
+	Method      string
+	Path        string // OpenAPI style path, e.g. /accounts/{id}
+	OperationID string
+	Summary     string
+	Tags        []string
+	Query       []Parameter
+	Request     any
+	Responses   map[int]any // Status code to body DTO, nil body for empty responses
+}
+
+// Raw documents a body that is not JSON by its content type, e.g. Raw("application/epub+zip")
+type Raw string
+
+// AnyOf documents a body that may be any of several DTOs or content types
+type AnyOf []any
+
+// Builder accumulates routes into a Document
+type Builder struct {
+	doc   *Document
+	names map[reflect.Type]string // Component names handed out so far
+}
+
+func NewBuilder(title, version string) *Builder {
+	return &Builder{
+		doc: &Document{
+			OpenAPI:    Version,
+			Info:       Info{Title: title, Version: version},
+			Paths:      map[string]*PathItem{},
+			Components: Components{Schemas: map[string]*Schema{}},
+		},
+		names: map[reflect.Type]string{},
+	}
+}
+
+// Add registers a route, it panics on duplicates since specs are assembled at startup
+func (b *Builder) Add(route Route) *Builder {
+	method := strings.ToLower(route.Method)
+	item, ok := b.doc.Paths[route.Path]
+	if !ok {
+		item = &PathItem{}
+		b.doc.Paths[route.Path] = item
+	}
+	if _, exists := (*item)[method]; exists {
+		panic(fmt.Sprintf("openapi: duplicate route %s %s", route.Method, route.Path))
+	}
+
+	op := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Tags:        route.Tags,
+		Parameters:  append(pathParameters(route.Path), route.Query...),
+		Responses:   map[string]*Response{},
+	}
+
+	if route.Request != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: b.content(route.Request)}
+	}
+
+	codes := make([]int, 0, len(route.Responses))
+	for code := range route.Responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		resp := &Response{Description: http.StatusText(code)}
+		if body := route.Responses[code]; body != nil {
+			resp.Content = b.content(body)
+		}
+		op.Responses[strconv.Itoa(code)] = resp
+	}
+
+	(*item)[method] = op
+	return b
+}
+
+// content documents a body by media type, JSON DTOs share one schema
+func (b *Builder) content(body any) map[string]MediaType {
+	bodies, ok := body.(AnyOf)
+	if !ok {
+		bodies = AnyOf{body}
+	}
+
+	content := map[string]MediaType{}
+	var schemas []*Schema
+	for _, v := range bodies {
+		switch v := v.(type) {
+		case Raw:
+			content[string(v)] = MediaType{Schema: rawSchema(string(v))}
+		case reflect.Type:
+			schemas = append(schemas, b.schemaFor(v))
+		default:
+			schemas = append(schemas, b.schemaFor(reflect.TypeOf(v)))
+		}
+	}
+	switch len(schemas) {
+	case 0:
+	case 1:
+		content["application/json"] = MediaType{Schema: schemas[0]}
+	default:
+		content["application/json"] = MediaType{Schema: &Schema{AnyOf: schemas}}
+	}
+	return content
+}
+
+func rawSchema(contentType string) *Schema {
+	if contentType == "multipart/form-data" {
+		return &Schema{Type: "object"}
+	}
+	return &Schema{Type: "string", Format: "binary"}
+}
+
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+// QueryParam is a shorthand for optional query string parameters
+func QueryParam(name, typ, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: typ}}
+}
+
+// QueryList is a shorthand for optional query parameters that may repeat, e.g. ?id=a&id=b
+func QueryList(name, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "array", Items: &Schema{Type: "string"}}}
+}
+
+func pathParameters(path string) []Parameter {
+	var params []Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params = append(params, Parameter{
+				Name:     strings.Trim(segment, "{}"),
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+	return params
+}
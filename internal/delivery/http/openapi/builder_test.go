@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	City string `json:"city"`
+}
+
+type testAccount struct {
+	ID        string            `json:"id" doc:"Account identifier"`
+	Status    string            `json:"status" enum:"active,disabled"`
+	Age       int               `json:"age,omitempty"`
+	Nickname  *string           `json:"nickname"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	Address   testAddress       `json:"address"`
+	Previous  *testAddress      `json:"previous"`
+	Metadata  *any              `json:"metadata,omitempty"`
+	Internal  string            `json:"-"`
+	secret    string
+}
+
+func TestBuilder_Add(t *testing.T) {
+	b := NewBuilder("CMS", "1.0.0")
+	b.Add(Route{
+		Method:      http.MethodGet,
+		Path:        "/accounts/{id}",
+		OperationID: "getAccount",
+		Responses:   map[int]any{http.StatusOK: testAccount{}, http.StatusNoContent: nil},
+	})
+	doc := b.Document()
+
+	op := (*doc.Paths["/accounts/{id}"])["get"]
+	if op == nil {
+		t.Fatal("expected get operation to be registered")
+	}
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "id" || !op.Parameters[0].Required {
+		t.Errorf("expected required id path parameter, got %+v", op.Parameters)
+	}
+	if op.Responses["204"].Content != nil {
+		t.Error("expected empty 204 response")
+	}
+	if op.Responses["200"].Content["application/json"].Schema.Ref != "#/components/schemas/OpenapiTestAccount" {
+		t.Errorf("expected component reference, got %+v", op.Responses["200"].Content["application/json"].Schema)
+	}
+
+	schema := doc.Components.Schemas["OpenapiTestAccount"]
+	if schema == nil {
+		t.Fatal("expected account schema component")
+	}
+	if _, ok := schema.Properties["Internal"]; ok {
+		t.Error("expected json:\"-\" field to be skipped")
+	}
+	if _, ok := schema.Properties["secret"]; ok {
+		t.Error("expected unexported field to be skipped")
+	}
+	if schema.Properties["id"].Description != "Account identifier" {
+		t.Error("expected doc tag to become description")
+	}
+	if len(schema.Properties["status"].Enum) != 2 {
+		t.Error("expected enum tag to be applied")
+	}
+	if schema.Properties["created_at"].Format != "date-time" {
+		t.Error("expected time.Time to be a date-time string")
+	}
+	if previous := schema.Properties["previous"]; len(previous.AnyOf) != 2 || previous.AnyOf[0].Ref != "#/components/schemas/OpenapiTestAddress" || previous.AnyOf[1].Type != "null" {
+		t.Errorf("expected a nullable reference, got %+v", previous)
+	}
+	if metadata := schema.Properties["metadata"]; metadata.Type != nil {
+		t.Errorf("expected a pointer to any to accept every value, got %+v", metadata)
+	}
+
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	if !required["id"] || required["age"] || required["nickname"] {
+		t.Errorf("unexpected required list %v", schema.Required)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected duplicate route to panic")
+		}
+	}()
+	b.Add(Route{Method: http.MethodGet, Path: "/accounts/{id}", OperationID: "dup"})
+}
+
+func TestHandler(t *testing.T) {
+	doc := NewBuilder("CMS", "1.0.0").Document()
+
+	rec := httptest.NewRecorder()
+	Handler(doc).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	var got Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode document: %v", err)
+	}
+	if got.OpenAPI != Version {
+		t.Errorf("expected openapi %s, got %s", Version, got.OpenAPI)
+	}
+}
+
+func TestDocument_ValidateResponse(t *testing.T) {
+	doc := NewBuilder("CMS", "1.0.0").Add(Route{
+		Method:      http.MethodGet,
+		Path:        "/accounts/{id}",
+		OperationID: "getAccount",
+		Responses:   map[int]any{http.StatusOK: testAccount{}},
+	}).Document()
+
+	for _, valid := range []string{
+		`{"id":"1","status":"active","nickname":null,"tags":["a"],"created_at":"2026-01-01T00:00:00Z","address":{"city":"Bandung"},"previous":null}`,
+		`{"id":"1","status":"active","nickname":null,"tags":["a"],"created_at":"2026-01-01T00:00:00Z","address":{"city":"Bandung"},"previous":{"city":"Depok"},"metadata":[1]}`,
+	} {
+		if err := doc.ValidateResponse("GET", "/accounts/{id}", 200, []byte(valid)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{"missing required", `{"status":"active","nickname":null,"tags":[],"created_at":"x","address":{"city":"x"}}`},
+		{"undocumented property", `{"id":"1","status":"a","nickname":null,"tags":[],"created_at":"x","address":{"city":"x"},"extra":1}`},
+		{"wrong type", `{"id":1,"status":"a","nickname":null,"tags":[],"created_at":"x","address":{"city":"x"}}`},
+		{"wrong nested type", `{"id":"1","status":"a","nickname":null,"tags":[1],"created_at":"x","address":{"city":"x"}}`},
+		{"unexpected null", `{"id":null,"status":"a","nickname":null,"tags":[],"created_at":"x","address":{"city":"x"},"previous":null}`},
+		{"wrong nullable reference", `{"id":"1","status":"a","nickname":null,"tags":[],"created_at":"x","address":{"city":"x"},"previous":{"city":1}}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := doc.ValidateResponse("GET", "/accounts/{id}", 200, []byte(tc.body)); err == nil {
+				t.Error("expected contract violation")
+			}
+		})
+	}
+
+	if err := doc.ValidateResponse("GET", "/accounts/{id}", 404, []byte(`{}`)); err == nil {
+		t.Error("expected error for undocumented status")
+	}
+	if err := doc.ValidateResponse("POST", "/accounts/{id}", 200, []byte(`{}`)); err == nil {
+		t.Error("expected error for undocumented operation")
+	}
+}
@@ -0,0 +1,439 @@
+// Package clientgen renders a typed Go client from an OpenAPI document: a
+// struct per component schema and a Client method per operation.
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi"
+)
+
+const refPrefix = "#/components/schemas/"
+
+// initialisms are spelled in capitals in Go names, e.g. site_id -> SiteID
+var initialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true,
+	"json": true, "url": true, "uri": true, "uuid": true, "epub": true,
+}
+
+// Generate renders the client file of package pkgName. The package provides
+// the hand-written Client, its do method and rawBody.
+func Generate(doc *openapi.Document, pkgName string) ([]byte, error) {
+	g := &generator{doc: doc, imports: map[string]bool{"context": true}, types: map[string]bool{}}
+
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !token.IsIdentifier(name) {
+			return nil, fmt.Errorf("component %q is not a Go identifier", name)
+		}
+		g.types[name] = true
+	}
+
+	var ops []operation
+	for path, item := range doc.Paths {
+		for method, op := range *item {
+			ops = append(ops, operation{method: strings.ToUpper(method), path: path, op: op})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].op.OperationID < ops[j].op.OperationID })
+
+	var body bytes.Buffer
+	for _, name := range names {
+		if err := g.component(&body, name, doc.Components.Schemas[name]); err != nil {
+			return nil, fmt.Errorf("component %s: %w", name, err)
+		}
+	}
+	for _, op := range ops {
+		if err := g.operation(&body, op); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.method, op.path, err)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by clientgen from the OpenAPI document. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkgName)
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, strconv.Quote(path))
+	}
+	sort.Strings(imports)
+	fmt.Fprintf(&out, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+type operation struct {
+	method, path string
+	op           *openapi.Operation
+}
+
+type generator struct {
+	doc     *openapi.Document
+	imports map[string]bool
+	types   map[string]bool // Declared type names
+}
+
+func (g *generator) component(out *bytes.Buffer, name string, s *openapi.Schema) error {
+	typ, err := g.goType(s)
+	if err != nil {
+		return err
+	}
+	if s.Description != "" {
+		fmt.Fprintf(out, "// %s %s\n", name, s.Description)
+	}
+	fmt.Fprintf(out, "type %s %s\n\n", name, typ)
+	return nil
+}
+
+// goType spells a schema as a Go type
+func (g *generator) goType(s *openapi.Schema) (string, error) {
+	if s == nil {
+		return g.raw(), nil
+	}
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, refPrefix)
+		if !ok || !g.types[name] {
+			return "", fmt.Errorf("unknown reference %s", s.Ref)
+		}
+		return name, nil
+	}
+	if len(s.AnyOf) > 0 {
+		// A nullable reference, anything else could hold any of several shapes
+		if len(s.AnyOf) == 2 && typeName(s.AnyOf[1]) == "null" {
+			typ, err := g.goType(s.AnyOf[0])
+			return "*" + typ, err
+		}
+		return g.raw(), nil
+	}
+
+	name, nullable := typeName(s), false
+	if types, ok := s.Type.([]string); ok && len(types) == 2 && types[1] == "null" {
+		name, nullable = types[0], true
+	}
+	typ, err := g.baseType(name, s)
+	if err != nil || !nullable || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == "json.RawMessage" {
+		return typ, err
+	}
+	return "*" + typ, nil
+}
+
+func (g *generator) baseType(name string, s *openapi.Schema) (string, error) {
+	switch name {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			g.imports["time"] = true
+			return "time.Time", nil
+		case "byte", "binary":
+			return "[]byte", nil
+		}
+		return "string", nil
+	case "boolean":
+		return "bool", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "array":
+		elem, err := g.goType(s.Items)
+		return "[]" + elem, err
+	case "object":
+		if extra, ok := s.AdditionalProperties.(*openapi.Schema); ok {
+			elem, err := g.goType(extra)
+			return "map[string]" + elem, err
+		}
+		return g.structType(s)
+	case "":
+		return g.raw(), nil
+	}
+	return "", fmt.Errorf("unsupported type %q", name)
+}
+
+func (g *generator) structType(s *openapi.Schema) (string, error) {
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	required := map[string]bool{}
+	for _, prop := range s.Required {
+		required[prop] = true
+	}
+
+	var out strings.Builder
+	out.WriteString("struct {\n")
+	fields := map[string]bool{}
+	for _, prop := range props {
+		field := goName(prop)
+		if fields[field] || !token.IsIdentifier(field) {
+			return "", fmt.Errorf("property %q has no distinct Go name", prop)
+		}
+		fields[field] = true
+
+		typ, err := g.goType(s.Properties[prop])
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", prop, err)
+		}
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		if desc := s.Properties[prop].Description; desc != "" {
+			fmt.Fprintf(&out, "// %s\n", desc)
+		}
+		fmt.Fprintf(&out, "%s %s `json:%s`\n", field, typ, strconv.Quote(tag))
+	}
+	out.WriteString("}")
+	return out.String(), nil
+}
+
+func (g *generator) raw() string {
+	g.imports["encoding/json"] = true
+	return "json.RawMessage"
+}
+
+func typeName(s *openapi.Schema) string {
+	name, _ := s.Type.(string)
+	return name
+}
+
+// goName turns a JSON or path parameter name into an exported Go name,
+// e.g. canonical_url -> CanonicalURL and siteID -> SiteID
+func goName(name string) string {
+	var words []string
+	start := 0
+	for i := 1; i <= len(name); i++ {
+		switch {
+		case i == len(name) || name[i] == '_' || name[i] == '-' || name[i] == '.':
+			words = append(words, name[start:i])
+			start = i + 1
+		case name[i] >= 'A' && name[i] <= 'Z' && name[i-1] >= 'a' && name[i-1] <= 'z':
+			words = append(words, name[start:i])
+			start = i
+		}
+	}
+
+	var out strings.Builder
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		lower := strings.ToLower(word)
+		if initialisms[lower] {
+			out.WriteString(strings.ToUpper(lower))
+			continue
+		}
+		out.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	if s := out.String(); s != "" && (s[0] < '0' || s[0] > '9') {
+		return s
+	}
+	return "X" + out.String()
+}
+
+// operation renders a Client method sending one request and decoding the
+// answer of the lowest documented 2xx status
+func (g *generator) operation(out *bytes.Buffer, o operation) error {
+	method := goName(o.op.OperationID)
+	if method == "" || g.types[method] {
+		return fmt.Errorf("operationId %q has no distinct Go name", o.op.OperationID)
+	}
+	g.imports["net/http"] = true
+
+	var pathParams, query []openapi.Parameter
+	for _, p := range o.op.Parameters {
+		switch p.In {
+		case "path":
+			pathParams = append(pathParams, p)
+		case "query":
+			query = append(query, p)
+		}
+	}
+
+	args := []string{"ctx context.Context"}
+	if len(pathParams)+len(query) > 0 {
+		params := method + "Params"
+		if g.types[params] {
+			return fmt.Errorf("%s clashes with a component", params)
+		}
+		g.types[params] = true
+		fmt.Fprintf(out, "// %s are the path and query parameters of %s\n", params, method)
+		fmt.Fprintf(out, "type %s struct {\n", params)
+		for _, p := range pathParams {
+			fmt.Fprintf(out, "%s string\n", goName(p.Name))
+		}
+		for _, p := range query {
+			typ, err := queryType(p)
+			if err != nil {
+				return err
+			}
+			if p.Description != "" {
+				fmt.Fprintf(out, "// %s\n", p.Description)
+			}
+			fmt.Fprintf(out, "%s %s\n", goName(p.Name), typ)
+		}
+		fmt.Fprintf(out, "}\n\n")
+		args = append(args, "params "+params)
+	}
+
+	in := "nil"
+	if o.op.RequestBody != nil {
+		if media, ok := o.op.RequestBody.Content["application/json"]; ok {
+			typ, err := g.goType(media.Schema)
+			if err != nil {
+				return err
+			}
+			args = append(args, "body "+typ)
+			in = "body"
+		} else {
+			g.imports["io"] = true
+			args = append(args, "body io.Reader", "contentType string")
+			in = "rawBody{body, contentType}"
+		}
+	}
+
+	result, err := g.result(o.op)
+	if err != nil {
+		return err
+	}
+	results := "error"
+	if result != "" {
+		results = "(" + result + ", error)"
+	}
+
+	summary := o.op.Summary
+	if summary == "" {
+		summary = "calls " + o.method + " " + o.path
+	} else {
+		summary = strings.ToLower(summary[:1]) + summary[1:]
+	}
+	fmt.Fprintf(out, "// %s %s\n", method, summary)
+	fmt.Fprintf(out, "func (c *Client) %s(%s) %s {\n", method, strings.Join(args, ", "), results)
+
+	queryArg := "nil"
+	if len(query) > 0 {
+		g.imports["net/url"] = true
+		queryArg = "query"
+		fmt.Fprintf(out, "query := url.Values{}\n")
+		for _, p := range query {
+			g.setQuery(out, p)
+		}
+	}
+
+	path := g.path(o.path)
+	call := fmt.Sprintf("c.do(ctx, http.Method%s, %s, %s, %s", exportName(strings.ToLower(o.method)), path, queryArg, in)
+	if result == "" {
+		fmt.Fprintf(out, "return %s, nil)\n}\n\n", call)
+		return nil
+	}
+	value := strings.TrimPrefix(result, "*")
+	fmt.Fprintf(out, "var out %s\n", value)
+	fmt.Fprintf(out, "if err := %s, &out); err != nil {\n", call)
+	if strings.HasPrefix(result, "*") {
+		fmt.Fprintf(out, "return nil, err\n}\nreturn &out, nil\n}\n\n")
+	} else {
+		fmt.Fprintf(out, "return nil, err\n}\nreturn out, nil\n}\n\n")
+	}
+	return nil
+}
+
+// result is the Go type the lowest 2xx answer decodes into: a pointer to a
+// DTO, a slice or map, raw bytes for bodies that are not JSON, or nothing
+func (g *generator) result(op *openapi.Operation) (string, error) {
+	status := ""
+	for code := range op.Responses {
+		if code >= "200" && code < "300" && (status == "" || code < status) {
+			status = code
+		}
+	}
+	if status == "" || len(op.Responses[status].Content) == 0 {
+		return "", nil
+	}
+	content := op.Responses[status].Content
+	media, ok := content["application/json"]
+	if !ok || len(content) > 1 {
+		return "[]byte", nil
+	}
+	typ, err := g.goType(media.Schema)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || strings.HasPrefix(typ, "*") || typ == "json.RawMessage" {
+		return typ, nil
+	}
+	return "*" + typ, nil
+}
+
+func queryType(p openapi.Parameter) (string, error) {
+	switch typeName(p.Schema) {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "*int", nil
+	case "number":
+		return "*float64", nil
+	case "boolean":
+		return "*bool", nil
+	case "array":
+		return "[]string", nil
+	}
+	return "", fmt.Errorf("unsupported query parameter %s", p.Name)
+}
+
+func (g *generator) setQuery(out *bytes.Buffer, p openapi.Parameter) {
+	field, name := "params."+goName(p.Name), strconv.Quote(p.Name)
+	switch typeName(p.Schema) {
+	case "string":
+		fmt.Fprintf(out, "if %s != \"\" {\nquery.Set(%s, %s)\n}\n", field, name, field)
+	case "array":
+		fmt.Fprintf(out, "if len(%s) > 0 {\nquery[%s] = %s\n}\n", field, name, field)
+	default:
+		g.imports["strconv"] = true
+		format := map[string]string{
+			"integer": "strconv.Itoa(*%s)",
+			"number":  "strconv.FormatFloat(*%s, 'g', -1, 64)",
+			"boolean": "strconv.FormatBool(*%s)",
+		}[typeName(p.Schema)]
+		fmt.Fprintf(out, "if %s != nil {\nquery.Set(%s, %s)\n}\n", field, name, fmt.Sprintf(format, field))
+	}
+}
+
+// path spells the request path with escaped path parameters
+func (g *generator) path(path string) string {
+	var parts []string
+	literal := ""
+	for _, segment := range strings.SplitAfter(path, "/") {
+		name, ok := strings.CutPrefix(strings.TrimSuffix(segment, "/"), "{")
+		if !ok || !strings.HasSuffix(name, "}") {
+			literal += segment
+			continue
+		}
+		if literal != "" {
+			parts = append(parts, strconv.Quote(literal))
+		}
+		g.imports["net/url"] = true
+		parts = append(parts, "url.PathEscape(params."+goName(strings.TrimSuffix(name, "}"))+")")
+		literal = strings.TrimPrefix(segment, strings.TrimSuffix(segment, "/"))
+	}
+	if literal != "" {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, "+")
+}
+
+func exportName(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
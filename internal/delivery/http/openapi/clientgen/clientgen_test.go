@@ -0,0 +1,76 @@
+package clientgen
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi"
+)
+
+type noteRequest struct {
+	Body string `json:"body"`
+}
+
+type noteResponse struct {
+	ID        string    `json:"id"`
+	SiteURL   string    `json:"site_url,omitempty"`
+	EditedAt  *string   `json:"edited_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func TestGenerate(t *testing.T) {
+	b := openapi.NewBuilder("test", "1")
+	b.Add(openapi.Route{
+		Method:      http.MethodPost,
+		Path:        "/sites/{siteID}/notes",
+		OperationID: "notesCreate",
+		Summary:     "Stores a note",
+		Query:       []openapi.Parameter{openapi.QueryParam("draft", "boolean", "")},
+		Request:     noteRequest{},
+		Responses:   map[int]any{http.StatusCreated: noteResponse{}, http.StatusOK: nil},
+	})
+	b.Add(openapi.Route{
+		Method:      http.MethodGet,
+		Path:        "/notes/{id}/raw",
+		OperationID: "notesRaw",
+		Responses:   map[int]any{http.StatusOK: openapi.Raw("text/markdown")},
+	})
+
+	src, err := Generate(b.Document(), "notes")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	for _, want := range []string{
+		"package notes",
+		"type ClientgenNoteResponse struct",
+		"SiteURL   string    `json:\"site_url,omitempty\"`",
+		"EditedAt  *string   `json:\"edited_at,omitempty\"`",
+		"CreatedAt time.Time `json:\"created_at\"`",
+		"type NotesCreateParams struct {\n\tSiteID string\n\tDraft  *bool\n}",
+		"// NotesCreate stores a note",
+		// The lowest 2xx status decides the result
+		"func (c *Client) NotesCreate(ctx context.Context, params NotesCreateParams, body ClientgenNoteRequest) error",
+		`"/sites/"+url.PathEscape(params.SiteID)+"/notes"`,
+		"func (c *Client) NotesRaw(ctx context.Context, params NotesRawParams) ([]byte, error)",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("expected %s in\n%s", want, src)
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for in, want := range map[string]string{
+		"id":            "ID",
+		"canonical_url": "CanonicalURL",
+		"siteID":        "SiteID",
+		"ip_address":    "IPAddress",
+		"2fa":           "X2fa",
+	} {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
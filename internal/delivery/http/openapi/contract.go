@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidateResponse checks a raw JSON response body against the documented schema
+// for the operation and status code. It is meant for contract tests, so a handler
+// that drifts from its declared DTOs fails the build.
+func (d *Document) ValidateResponse(method, path string, status int, body []byte) error {
+	return d.ValidateContent(method, path, status, "application/json", body)
+}
+
+// ValidateContent is ValidateResponse for a body of any content type. Bodies
+// that are not JSON only need their media type documented, and redirects may
+// carry the short note net/http writes for browsers.
+func (d *Document) ValidateContent(method, path string, status int, contentType string, body []byte) error {
+	item, ok := d.Paths[path]
+	if !ok {
+		return fmt.Errorf("path %s is not documented", path)
+	}
+	op, ok := (*item)[strings.ToLower(method)]
+	if !ok {
+		return fmt.Errorf("operation %s %s is not documented", method, path)
+	}
+	resp, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		return fmt.Errorf("status %d is not documented for %s %s", status, method, path)
+	}
+
+	if len(resp.Content) == 0 {
+		if len(strings.TrimSpace(string(body))) != 0 && (status < 300 || status >= 400) {
+			return fmt.Errorf("undocumented response body for status %d", status)
+		}
+		return nil
+	}
+
+	mediaType := mediaTypeOf(contentType)
+	if mediaType != "application/json" {
+		for documented := range resp.Content {
+			if documented == "*/*" || mediaTypeOf(documented) == mediaType {
+				return nil
+			}
+		}
+		return fmt.Errorf("content type %s is not documented for status %d", contentType, status)
+	}
+
+	media, ok := resp.Content["application/json"]
+	if !ok {
+		return fmt.Errorf("JSON body is not documented for status %d", status)
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("response is not valid JSON: %w", err)
+	}
+
+	var problems []string
+	d.validate(media.Schema, value, "$", &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("response does not match schema: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// mediaTypeOf drops parameters such as the charset
+func mediaTypeOf(contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.TrimSpace(contentType)
+}
+
+func (d *Document) validate(s *Schema, value any, at string, problems *[]string) {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		resolved, ok := d.Components.Schemas[name]
+		if !ok {
+			*problems = append(*problems, at+": unresolved reference "+s.Ref)
+			return
+		}
+		s = resolved
+	}
+
+	if len(s.AnyOf) > 0 {
+		for _, candidate := range s.AnyOf {
+			var inner []string
+			if d.validate(candidate, value, at, &inner); len(inner) == 0 {
+				return
+			}
+		}
+		*problems = append(*problems, at+": matches none of the allowed schemas")
+		return
+	}
+
+	if value == nil {
+		if !allowsType(s, "null") {
+			*problems = append(*problems, at+": unexpected null")
+		}
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if !allowsType(s, "object") {
+			*problems = append(*problems, at+": unexpected object")
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*problems = append(*problems, at+": missing required property "+name)
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if prop, ok := s.Properties[k]; ok {
+				d.validate(prop, v[k], at+"."+k, problems)
+				continue
+			}
+			switch extra := s.AdditionalProperties.(type) {
+			case *Schema:
+				d.validate(extra, v[k], at+"."+k, problems)
+			case bool:
+				if !extra {
+					*problems = append(*problems, at+": undocumented property "+k)
+				}
+			}
+		}
+	case []any:
+		if !allowsType(s, "array") {
+			*problems = append(*problems, at+": unexpected array")
+			return
+		}
+		if s.Items == nil {
+			return // An untyped schema accepts any items
+		}
+		for i, item := range v {
+			d.validate(s.Items, item, fmt.Sprintf("%s[%d]", at, i), problems)
+		}
+	case string:
+		if !allowsType(s, "string") {
+			*problems = append(*problems, at+": unexpected string")
+		}
+	case bool:
+		if !allowsType(s, "boolean") {
+			*problems = append(*problems, at+": unexpected boolean")
+		}
+	case float64:
+		if allowsType(s, "number") {
+			return
+		}
+		if !allowsType(s, "integer") || v != float64(int64(v)) {
+			*problems = append(*problems, at+": unexpected number")
+		}
+	}
+}
+
+func allowsType(s *Schema, typ string) bool {
+	switch t := s.Type.(type) {
+	case nil:
+		return true
+	case string:
+		return t == typ
+	case []string:
+		for _, candidate := range t {
+			if candidate == typ {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler serves the document at /openapi.json, it is rendered once up front
+func Handler(doc *Document) http.Handler {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic("openapi: failed to render document: " + err.Error())
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		_, _ = w.Write(body)
+	})
+}
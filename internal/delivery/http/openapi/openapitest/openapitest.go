@@ -0,0 +1,53 @@
+// Package openapitest checks handlers against their generated OpenAPI spec
+// from the handler tests, so a response that drifts from the documented DTOs
+// fails the build.
+package openapitest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi"
+)
+
+// Handler wraps h, usually a package's router, and validates every response
+// it writes against the routes register documents. Requests the spec does
+// not route may only be answered 404 or 405.
+func Handler(t testing.TB, register func(*openapi.Builder), h http.Handler) http.Handler {
+	b := openapi.NewBuilder("contract", "test")
+	register(b)
+	doc := b.Document()
+
+	routes := http.NewServeMux()
+	for path, item := range doc.Paths {
+		for method := range *item {
+			routes.HandleFunc(strings.ToUpper(method)+" "+path, func(http.ResponseWriter, *http.Request) {})
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(rec.Body.Bytes())
+
+		_, pattern := routes.Handler(r)
+		if pattern == "" {
+			if rec.Code != http.StatusNotFound && rec.Code != http.StatusMethodNotAllowed {
+				t.Errorf("contract: %s %s answered %d but is not documented", r.Method, r.URL.Path, rec.Code)
+			}
+			return
+		}
+		_, path, _ := strings.Cut(pattern, " ")
+		if err := doc.ValidateContent(r.Method, path, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes()); err != nil {
+			t.Errorf("contract: %s %s: %v", r.Method, r.URL.Path, err)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaFor converts a Go type into a schema. Named structs are registered as
+// components and referenced, field names and optionality follow the json tags,
+// and the `doc` tag becomes the property description.
+func (b *Builder) schemaFor(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	s := b.baseSchema(t)
+	if !nullable {
+		return s
+	}
+	switch typ := s.Type.(type) {
+	case string:
+		s.Type = []string{typ, "null"}
+	case nil:
+		// A reference cannot carry siblings, interfaces and any already allow null
+		if s.Ref != "" {
+			return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+		}
+	}
+	return s
+}
+
+func (b *Builder) baseSchema(t reflect.Type) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	// Types encoding themselves can be anything, or a string for text marshalers
+	switch {
+	case implements(t, marshalerType):
+		return &Schema{}
+	case implements(t, textMarshalerType):
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return b.structSchema(t)
+	}
+	return &Schema{}
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+func (b *Builder) structSchema(t reflect.Type) *Schema {
+	name := b.componentName(t)
+	if name != "" {
+		if _, ok := b.doc.Components.Schemas[name]; ok {
+			return &Schema{Ref: "#/components/schemas/" + name}
+		}
+		// Reserve the name first so recursive types terminate
+		b.doc.Components.Schemas[name] = &Schema{}
+	}
+
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: false}
+	// Fields of untagged embedded structs are promoted, as encoding/json does
+	for _, field := range reflect.VisibleFields(t) {
+		if embedded(field) || !field.IsExported() || !promoted(t, field.Index) {
+			continue
+		}
+
+		jsonName, omitEmpty, skip := parseJSONTag(field)
+		if skip {
+			continue
+		}
+
+		prop := b.schemaFor(field.Type)
+		if desc := field.Tag.Get("doc"); desc != "" && prop.Ref == "" {
+			prop.Description = desc
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			for _, v := range strings.Split(enum, ",") {
+				prop.Enum = append(prop.Enum, v)
+			}
+		}
+		s.Properties[jsonName] = prop
+		if !omitEmpty && field.Type.Kind() != reflect.Pointer {
+			s.Required = append(s.Required, jsonName)
+		}
+	}
+
+	if name == "" {
+		return s
+	}
+	b.doc.Components.Schemas[name] = s
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func embedded(field reflect.StructField) bool {
+	ft := field.Type
+	if ft.Kind() == reflect.Pointer {
+		ft = ft.Elem()
+	}
+	return field.Anonymous && ft.Kind() == reflect.Struct && field.Tag.Get("json") == ""
+}
+
+// promoted reports whether every struct the field is reached through is embedded
+func promoted(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		field := t.Field(i)
+		if !embedded(field) {
+			return false
+		}
+		t = field.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+	return true
+}
+
+func parseJSONTag(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// componentName derives a stable component name, e.g. mobile.feedResponse ->
+// MobileFeedResponse. A name already taken by a type of another package with
+// the same name gets the parent directories too, e.g. HttpLetterLetterResponse.
+func (b *Builder) componentName(t reflect.Type) string {
+	if t.Name() == "" {
+		return ""
+	}
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+
+	segments := strings.Split(t.PkgPath(), "/")
+	name := exportName(t.Name())
+	for i := len(segments) - 1; i >= 0; i-- {
+		name = exportName(segments[i]) + name
+		if _, taken := b.doc.Components.Schemas[name]; !taken || i == 0 {
+			break
+		}
+	}
+	b.names[t] = name
+	return name
+}
+
+func exportName(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,569 @@
+// Package specgen derives the OpenAPI routes of a delivery package from its
+// source: the patterns its routers mount, the body each handler decodes, the
+// query parameters it reads and every status and DTO it writes back. Handlers
+// and DTOs are the annotations; summaries come from the handlers' doc comments
+// and descriptions from the DTOs' `doc` tags.
+package specgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Route is one endpoint as the handler implements it
+type Route struct {
+	Method             string
+	Path               string // OpenAPI style, e.g. /articles/{id}
+	OperationID        string
+	Summary            string
+	Query              []QueryParam
+	Request            types.Type // JSON body, nil when there is none
+	RequestContentType string     // Set for bodies that are not JSON
+	Responses          map[int]Response
+}
+
+type QueryParam struct {
+	Name        string
+	Type        string // string, integer, number, boolean or array
+	Description string
+}
+
+// Response is what a handler writes for one status, any of the JSON bodies
+// or content types; a response with neither is empty
+type Response struct {
+	Bodies       []types.Type
+	ContentTypes []string // Bodies that are not JSON, */* when it varies
+}
+
+// Package is an analyzed delivery package
+type Package struct {
+	Name    string
+	Path    string
+	Dir     string
+	Routes  []Route
+	types   *types.Package
+	http    *types.Package
+	imports map[string]string // Import path to the name the package's files use
+}
+
+// Loader type-checks packages from source, sharing imports between them
+type Loader struct {
+	fset     *token.FileSet
+	importer types.ImporterFrom
+}
+
+func NewLoader() *Loader {
+	fset := token.NewFileSet()
+	return &Loader{fset: fset, importer: importer.ForCompiler(fset, "source", nil).(types.ImporterFrom)}
+}
+
+// Load analyzes the package in dir; it has no routes when it mounts none
+func (l *Loader) Load(dir, importPath string) (*Package, error) {
+	pkgs, err := parser.ParseDir(l.fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != FileName
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	var files []*ast.File
+	for _, p := range pkgs {
+		names := make([]string, 0, len(p.Files))
+		for name := range p.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			files = append(files, p.Files[name])
+		}
+	}
+
+	info := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+	}
+	conf := types.Config{Importer: l.importer}
+	tpkg, err := conf.Check(importPath, l.fset, files, info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to type-check %s: %w", importPath, err)
+	}
+
+	a := newAnalyzer(l.fset, tpkg, info, files)
+	routes, err := a.routes()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", importPath, err)
+	}
+
+	imports := map[string]string{}
+	for _, f := range files {
+		for _, spec := range f.Imports {
+			path := strings.Trim(spec.Path.Value, `"`)
+			if spec.Name != nil {
+				imports[path] = spec.Name.Name
+			} else if imported, err := l.importer.ImportFrom(path, dir, 0); err == nil {
+				imports[path] = imported.Name()
+			}
+		}
+	}
+	httpPkg, err := l.importer.ImportFrom("net/http", dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Package{Name: tpkg.Name(), Path: importPath, Dir: dir, Routes: routes, types: tpkg, http: httpPkg, imports: imports}, nil
+}
+
+// sink is a writeJSON style helper: the parameters holding the status and the body
+type sink struct {
+	status, body int
+}
+
+// assignment is a value stored in a variable, index picks the result of a multi-value call
+type assignment struct {
+	expr  ast.Expr
+	index int
+}
+
+type analyzer struct {
+	fset  *token.FileSet
+	pkg   *types.Package
+	info  *types.Info
+	files []*ast.File
+
+	funcs      map[*types.Func]*ast.FuncDecl
+	sinks      map[*types.Func]sink
+	assigns    map[*types.Var][]assignment
+	parsed     map[ast.Expr]string // Values by the type they are parsed as, e.g. integer
+	parsedVars map[*types.Var]string
+}
+
+func newAnalyzer(fset *token.FileSet, pkg *types.Package, info *types.Info, files []*ast.File) *analyzer {
+	a := &analyzer{
+		fset:       fset,
+		pkg:        pkg,
+		info:       info,
+		files:      files,
+		funcs:      map[*types.Func]*ast.FuncDecl{},
+		sinks:      map[*types.Func]sink{},
+		assigns:    map[*types.Var][]assignment{},
+		parsed:     map[ast.Expr]string{},
+		parsedVars: map[*types.Var]string{},
+	}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Body != nil {
+				if fn, ok := info.Defs[fd.Name].(*types.Func); ok {
+					a.funcs[fn] = fd
+				}
+			}
+		}
+		ast.Inspect(f, a.collect)
+	}
+	for fn, fd := range a.funcs {
+		if s, ok := a.sinkOf(fn, fd); ok {
+			a.sinks[fn] = s
+		}
+	}
+	return a
+}
+
+// collect records what variables are assigned and which values get parsed as numbers
+func (a *analyzer) collect(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		a.assign(n.Lhs, n.Rhs)
+	case *ast.ValueSpec:
+		lhs := make([]ast.Expr, len(n.Names))
+		for i, name := range n.Names {
+			lhs[i] = name
+		}
+		a.assign(lhs, n.Values)
+	case *ast.CallExpr:
+		typ := ""
+		switch a.funcName(n) {
+		case "strconv.Atoi", "strconv.ParseInt", "strconv.ParseUint":
+			typ = "integer"
+		case "strconv.ParseFloat":
+			typ = "number"
+		case "strconv.ParseBool":
+			typ = "boolean"
+		}
+		if typ == "" || len(n.Args) == 0 {
+			return true
+		}
+		arg := ast.Unparen(n.Args[0])
+		a.parsed[arg] = typ
+		if id, ok := arg.(*ast.Ident); ok {
+			if v, ok := a.info.Uses[id].(*types.Var); ok {
+				a.parsedVars[v] = typ
+				for _, as := range a.assigns[v] {
+					a.parsed[ast.Unparen(as.expr)] = typ
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (a *analyzer) assign(lhs, rhs []ast.Expr) {
+	for i, l := range lhs {
+		id, ok := l.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		obj := a.info.Defs[id]
+		if obj == nil {
+			obj = a.info.Uses[id]
+		}
+		v, ok := obj.(*types.Var)
+		if !ok {
+			continue
+		}
+		switch {
+		case len(lhs) == len(rhs):
+			a.assigns[v] = append(a.assigns[v], assignment{expr: rhs[i], index: -1})
+		case len(rhs) == 1:
+			a.assigns[v] = append(a.assigns[v], assignment{expr: rhs[0], index: i})
+		}
+	}
+}
+
+// sinkOf recognizes helpers that write their status parameter as the header
+// and encode their body parameter as JSON
+func (a *analyzer) sinkOf(fn *types.Func, fd *ast.FuncDecl) (sink, bool) {
+	sig := fn.Type().(*types.Signature)
+	params := map[*types.Var]int{}
+	for i := 0; i < sig.Params().Len(); i++ {
+		params[sig.Params().At(i)] = i
+	}
+	param := func(e ast.Expr) int {
+		if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+			if v, ok := a.info.Uses[id].(*types.Var); ok {
+				if i, ok := params[v]; ok {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+
+	s := sink{status: -1, body: -1}
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return true
+		}
+		switch a.funcName(call) {
+		case "(net/http.ResponseWriter).WriteHeader":
+			s.status = param(call.Args[0])
+		case "(*encoding/json.Encoder).Encode":
+			s.body = param(call.Args[0])
+		}
+		return true
+	})
+	return s, s.status >= 0 && s.body >= 0
+}
+
+// funcName names the called function like types.Func.FullName, empty for
+// calls through variables and conversions
+func (a *analyzer) funcName(call *ast.CallExpr) string {
+	if fn := a.callee(call); fn != nil {
+		return fn.FullName()
+	}
+	return ""
+}
+
+func (a *analyzer) callee(call *ast.CallExpr) *types.Func {
+	return a.funcOf(call.Fun)
+}
+
+// funcOf resolves a function or method value expression
+func (a *analyzer) funcOf(e ast.Expr) *types.Func {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		fn, _ := a.info.Uses[e].(*types.Func)
+		return fn
+	case *ast.SelectorExpr:
+		if sel, ok := a.info.Selections[e]; ok {
+			fn, _ := sel.Obj().(*types.Func)
+			return fn
+		}
+		fn, _ := a.info.Uses[e.Sel].(*types.Func)
+		return fn
+	case *ast.IndexExpr: // Instantiated generic function
+		return a.funcOf(e.X)
+	}
+	return nil
+}
+
+// decl returns the declaration of a function of this package
+func (a *analyzer) decl(fn *types.Func) *ast.FuncDecl {
+	if fn == nil {
+		return nil
+	}
+	return a.funcs[fn.Origin()]
+}
+
+// routes finds every mux.Handle and mux.HandleFunc registration
+func (a *analyzer) routes() ([]Route, error) {
+	var routes []Route
+	var errs []string
+	for _, f := range a.files {
+		var router *ast.FuncDecl
+		ast.Inspect(f, func(n ast.Node) bool {
+			if fd, ok := n.(*ast.FuncDecl); ok {
+				router = fd
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 2 {
+				return true
+			}
+			switch a.funcName(call) {
+			case "(*net/http.ServeMux).Handle", "(*net/http.ServeMux).HandleFunc":
+			default:
+				return true
+			}
+			tv := a.info.Types[call.Args[0]]
+			if tv.Value == nil || tv.Value.Kind() != constant.String {
+				errs = append(errs, fmt.Sprintf("%s: pattern is not a constant", a.pos(call)))
+				return true
+			}
+			route, err := a.route(constant.StringVal(tv.Value), call.Args[1], router)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", a.pos(call), err))
+				return true
+			}
+			routes = append(routes, route)
+			return true
+		})
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	a.nameOperations(routes)
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes, nil
+}
+
+// pos locates a node by file name and line for error messages
+func (a *analyzer) pos(n ast.Node) token.Position {
+	pos := a.fset.Position(n.Pos())
+	if i := strings.LastIndex(pos.Filename, "/"); i != -1 {
+		pos.Filename = pos.Filename[i+1:]
+	}
+	return pos
+}
+
+// route follows the handler expression of one registration in router
+func (a *analyzer) route(pattern string, handler ast.Expr, router *ast.FuncDecl) (Route, error) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok || strings.ContainsAny(method, "/{") {
+		return Route{}, fmt.Errorf("pattern %q has no method", pattern)
+	}
+	path = strings.TrimSuffix(strings.TrimSpace(path), "{$}")
+	path = strings.ReplaceAll(path, "...}", "}")
+
+	w := &walk{a: a, responses: map[int][]Response{}, stack: map[*ast.FuncDecl]bool{}}
+	w.expr(handler, nil)
+	name, doc := a.handlerDoc(handler)
+
+	route := Route{
+		Method:      method,
+		Path:        path,
+		OperationID: name,
+		Summary:     summary(doc),
+		Responses:   map[int]Response{},
+	}
+	switch {
+	case len(w.requests) > 0:
+		route.Request = w.requests[0]
+		for _, t := range w.requests[1:] {
+			if !types.Identical(t, route.Request) {
+				return Route{}, fmt.Errorf("%s decodes both %s and %s", pattern, route.Request, t)
+			}
+		}
+	case w.multipart:
+		route.RequestContentType = "multipart/form-data"
+	case w.readsBody:
+		route.RequestContentType = "application/octet-stream"
+	}
+	for _, fd := range []*ast.FuncDecl{router, doc} {
+		if err := w.directives(fd); err != nil {
+			return Route{}, err
+		}
+	}
+	route.Query = w.query
+	for status, candidates := range w.responses {
+		route.Responses[status] = merge(candidates)
+	}
+	if len(route.Responses) == 0 {
+		return Route{}, fmt.Errorf("%s writes no response", pattern)
+	}
+	return route, nil
+}
+
+// merge combines what a handler writes for one status; a status answered with
+// several DTOs or content types documents any of them
+func merge(candidates []Response) Response {
+	var resp Response
+	for _, c := range candidates {
+		for _, body := range c.Bodies {
+			resp.Bodies = appendType(resp.Bodies, body)
+		}
+		for _, ct := range c.ContentTypes {
+			if !slices.Contains(resp.ContentTypes, ct) {
+				resp.ContentTypes = append(resp.ContentTypes, ct)
+			}
+		}
+	}
+	// An interface says nothing once a concrete DTO is known
+	var concrete []types.Type
+	for _, body := range resp.Bodies {
+		if !isInterface(body) {
+			concrete = append(concrete, body)
+		}
+	}
+	if len(concrete) > 0 {
+		resp.Bodies = concrete
+	}
+	sort.Strings(resp.ContentTypes)
+	return resp
+}
+
+func appendType(list []types.Type, t types.Type) []types.Type {
+	for _, seen := range list {
+		if types.Identical(seen, t) {
+			return list
+		}
+	}
+	return append(list, t)
+}
+
+// handlerDoc names the operation after the handler method or function, and
+// returns the doc comment to summarize it with
+func (a *analyzer) handlerDoc(e ast.Expr) (string, *ast.FuncDecl) {
+	e = ast.Unparen(e)
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		if fd := a.decl(a.funcOf(e)); fd != nil {
+			return fd.Name.Name, fd
+		}
+		return "", nil
+	}
+
+	// A wrapper such as requireStaff(h.Create) is named after what it wraps
+	for _, arg := range call.Args {
+		if fd := a.decl(a.funcOf(arg)); fd != nil {
+			return fd.Name.Name, fd
+		}
+	}
+	fn := a.callee(call)
+	fd := a.decl(fn)
+	if fd == nil {
+		return "", nil
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(fd.Name.Name, "New"), "Handler")
+	if serve := a.serveHTTP(fn.Type().(*types.Signature).Results()); serve != nil && a.decl(serve).Doc != nil {
+		return name, a.decl(serve)
+	}
+	return name, fd
+}
+
+// serveHTTP returns the ServeHTTP method of a handler type declared here
+func (a *analyzer) serveHTTP(results *types.Tuple) *types.Func {
+	if results.Len() != 1 {
+		return nil
+	}
+	obj, _, _ := types.LookupFieldOrMethod(results.At(0).Type(), true, a.pkg, "ServeHTTP")
+	fn, _ := obj.(*types.Func)
+	if a.decl(fn) == nil {
+		return nil
+	}
+	return fn
+}
+
+// nameOperations prefixes handler names with the package and falls back to
+// the method and path where one handler serves several routes
+func (a *analyzer) nameOperations(routes []Route) {
+	seen := map[string]int{}
+	for _, r := range routes {
+		seen[r.OperationID]++
+	}
+	for i, r := range routes {
+		name := r.OperationID
+		if name == "" || seen[name] > 1 {
+			name = pathName(r.Method, r.Path)
+		}
+		routes[i].OperationID = a.pkg.Name() + exportName(name)
+	}
+}
+
+// pathName spells a route as an identifier, e.g. GET /search/suggest/{kind} -> GetSearchSuggestByKind
+func pathName(method, path string) string {
+	name := exportName(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") {
+			segment = "by-" + strings.Trim(segment, "{}")
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			name += exportName(word)
+		}
+	}
+	return name
+}
+
+func exportName(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// summary is the first sentence of the handler's doc comment without the
+// handler's name, e.g. "Create files a letter" -> "Files a letter"
+func summary(fd *ast.FuncDecl) string {
+	if fd == nil || fd.Doc == nil {
+		return ""
+	}
+	text := strings.Join(strings.Fields(fd.Doc.Text()), " ")
+	for from := 0; ; {
+		i := strings.Index(text[from:], ". ")
+		if i == -1 {
+			break
+		}
+		end := from + i
+		if !strings.HasSuffix(text[:end], "e.g") && !strings.HasSuffix(text[:end], "i.e") {
+			text = text[:end]
+			break
+		}
+		from = end + 2
+	}
+	text = strings.TrimSuffix(text, ".")
+	if rest, ok := strings.CutPrefix(text, fd.Name.Name+" "); ok {
+		text = exportName(rest)
+	}
+	return text
+}
+
+func isInterface(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Interface)
+	return ok
+}
@@ -0,0 +1,287 @@
+package specgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi"
+)
+
+// FileName is the file the spec of a package is generated into
+const FileName = "spec_gen.go"
+
+// RegistryFileName lists every package's RegisterSpec in the aggregating package
+const RegistryFileName = "register_gen.go"
+
+const header = "// Code generated by apigen from the package's handlers and DTOs. DO NOT EDIT.\n\n"
+
+var openapiPath = reflect.TypeOf(openapi.Route{}).PkgPath()
+
+// Generate analyzes every package under root, the delivery/http directory with
+// import path rootPath, and renders the spec files plus the registry of the
+// aggregate package in dir aggregate. Files are keyed by path.
+func Generate(root, rootPath, aggregate string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregateDir, err := filepath.Abs(aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := NewLoader()
+	files := map[string][]byte{}
+	var registered []*Package
+	for _, e := range entries {
+		dir := filepath.Join(root, e.Name())
+		if abs, err := filepath.Abs(dir); err != nil || !e.IsDir() || abs == aggregateDir || rootPath+"/"+e.Name() == openapiPath {
+			continue
+		}
+		pkg, err := loader.Load(dir, rootPath+"/"+e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if len(pkg.Routes) == 0 {
+			continue
+		}
+		src, err := Render(pkg)
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.Join(dir, FileName)] = src
+		registered = append(registered, pkg)
+	}
+
+	src, err := renderRegistry(filepath.Base(aggregateDir), registered)
+	if err != nil {
+		return nil, err
+	}
+	files[filepath.Join(aggregate, RegistryFileName)] = src
+	return files, nil
+}
+
+// Render writes the package's RegisterSpec
+func Render(pkg *Package) ([]byte, error) {
+	r := &renderer{pkg: pkg, used: map[string]string{"net/http": "http", openapiPath: "openapi"}}
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// RegisterSpec documents the endpoints the package's routers mount\n")
+	fmt.Fprintf(&body, "func RegisterSpec(b *openapi.Builder) {\n")
+	for _, route := range pkg.Routes {
+		if err := r.route(&body, route); err != nil {
+			return nil, fmt.Errorf("%s %s %s: %w", pkg.Path, route.Method, route.Path, err)
+		}
+	}
+	fmt.Fprintf(&body, "}\n")
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	fmt.Fprintf(&out, "package %s\n\n", pkg.Name)
+	writeImports(&out, r.used)
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+type renderer struct {
+	pkg  *Package
+	used map[string]string // Import path to name
+}
+
+func (r *renderer) route(out *bytes.Buffer, route Route) error {
+	fmt.Fprintf(out, "b.Add(openapi.Route{\n")
+	fmt.Fprintf(out, "Method: http.Method%s,\n", exportName(strings.ToLower(route.Method)))
+	fmt.Fprintf(out, "Path: %s,\n", strconv.Quote(route.Path))
+	fmt.Fprintf(out, "OperationID: %s,\n", strconv.Quote(route.OperationID))
+	if route.Summary != "" {
+		fmt.Fprintf(out, "Summary: %s,\n", strconv.Quote(route.Summary))
+	}
+	fmt.Fprintf(out, "Tags: []string{%s},\n", strconv.Quote(r.pkg.Name))
+
+	if len(route.Query) > 0 {
+		query := append([]QueryParam(nil), route.Query...)
+		sort.Slice(query, func(i, j int) bool { return query[i].Name < query[j].Name })
+		fmt.Fprintf(out, "Query: []openapi.Parameter{\n")
+		for _, q := range query {
+			if q.Type == "array" {
+				fmt.Fprintf(out, "openapi.QueryList(%s, %s),\n", strconv.Quote(q.Name), strconv.Quote(q.Description))
+				continue
+			}
+			fmt.Fprintf(out, "openapi.QueryParam(%s, %s, %s),\n", strconv.Quote(q.Name), strconv.Quote(q.Type), strconv.Quote(q.Description))
+		}
+		fmt.Fprintf(out, "},\n")
+	}
+
+	switch {
+	case route.Request != nil:
+		typ, err := r.typeOf(route.Request)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Request: %s,\n", typ)
+	case route.RequestContentType != "":
+		fmt.Fprintf(out, "Request: openapi.Raw(%s),\n", strconv.Quote(route.RequestContentType))
+	}
+
+	codes := make([]int, 0, len(route.Responses))
+	for code := range route.Responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	fmt.Fprintf(out, "Responses: map[int]any{\n")
+	for _, code := range codes {
+		body, err := r.response(route.Responses[code])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s: %s,\n", r.statusName(code), body)
+	}
+	fmt.Fprintf(out, "},\n")
+	fmt.Fprintf(out, "})\n")
+	return nil
+}
+
+func (r *renderer) response(resp Response) (string, error) {
+	var bodies []string
+	for _, t := range resp.Bodies {
+		typ, err := r.typeOf(t)
+		if err != nil {
+			return "", err
+		}
+		bodies = append(bodies, typ)
+	}
+	for _, ct := range resp.ContentTypes {
+		bodies = append(bodies, "openapi.Raw("+strconv.Quote(ct)+")")
+	}
+	switch len(bodies) {
+	case 0:
+		return "nil", nil
+	case 1:
+		return bodies[0], nil
+	}
+	return "openapi.AnyOf{" + strings.Join(bodies, ", ") + "}", nil
+}
+
+// typeOf spells a DTO type as a reflect.Type expression
+func (r *renderer) typeOf(t types.Type) (string, error) {
+	var err error
+	name := types.TypeString(t, func(p *types.Package) string {
+		if p == r.pkg.types {
+			return ""
+		}
+		return r.importName(p)
+	})
+	// Unexported types of other packages cannot be named here
+	var check func(types.Type)
+	check = func(t types.Type) {
+		switch t := t.(type) {
+		case *types.Named:
+			if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg() != r.pkg.types && !obj.Exported() {
+				err = fmt.Errorf("cannot refer to unexported %s", t)
+			}
+		case *types.Pointer:
+			check(t.Elem())
+		case *types.Slice:
+			check(t.Elem())
+		case *types.Array:
+			check(t.Elem())
+		case *types.Map:
+			check(t.Key())
+			check(t.Elem())
+		}
+	}
+	check(t)
+	if err != nil {
+		return "", err
+	}
+	r.used["reflect"] = "reflect"
+	return "reflect.TypeFor[" + name + "]()", nil
+}
+
+// importName reuses the name the package's own files import p with
+func (r *renderer) importName(p *types.Package) string {
+	if name, ok := r.used[p.Path()]; ok {
+		return name
+	}
+	name, ok := r.pkg.imports[p.Path()]
+	if !ok {
+		name = p.Name()
+	}
+	for taken := true; taken; {
+		taken = false
+		for path, used := range r.used {
+			if used == name && path != p.Path() {
+				name += "_"
+				taken = true
+			}
+		}
+	}
+	r.used[p.Path()] = name
+	return name
+}
+
+// statusName spells a status as its net/http constant, e.g. http.StatusNotFound
+func (r *renderer) statusName(code int) string {
+	name := "Status" + strings.NewReplacer(" ", "", "-", "", "'", "").Replace(http.StatusText(code))
+	if r.pkg.http.Scope().Lookup(name) != nil {
+		return "http." + name
+	}
+	return strconv.Itoa(code)
+}
+
+func writeImports(out *bytes.Buffer, imports map[string]string) {
+	var std, module []string
+	for path, name := range imports {
+		spec := strconv.Quote(path)
+		if i := strings.LastIndex(path, "/"); name != path[i+1:] {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			module = append(module, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(module)
+
+	out.WriteString("import (\n")
+	for _, spec := range std {
+		out.WriteString(spec + "\n")
+	}
+	if len(std) > 0 && len(module) > 0 {
+		out.WriteString("\n")
+	}
+	for _, spec := range module {
+		out.WriteString(spec + "\n")
+	}
+	out.WriteString(")\n\n")
+}
+
+func renderRegistry(name string, pkgs []*Package) ([]byte, error) {
+	imports := map[string]string{openapiPath: "openapi"}
+	var body bytes.Buffer
+	body.WriteString("// register adds the spec of every delivery package\n")
+	body.WriteString("func register(b *openapi.Builder) {\n")
+	for _, pkg := range pkgs {
+		imports[pkg.Path] = pkg.Name
+		fmt.Fprintf(&body, "%s.RegisterSpec(b)\n", pkg.Name)
+	}
+	body.WriteString("}\n")
+
+	var out bytes.Buffer
+	out.WriteString(strings.Replace(header, "the package's handlers and DTOs", "the delivery packages", 1))
+	fmt.Fprintf(&out, "package %s\n\n", name)
+	writeImports(&out, imports)
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
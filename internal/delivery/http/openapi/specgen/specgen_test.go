@@ -0,0 +1,70 @@
+package specgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	pkg, err := NewLoader().Load("testdata/notes", "example.com/notes")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(pkg.Routes) != 3 {
+		t.Fatalf("expected 3 routes, got %d", len(pkg.Routes))
+	}
+
+	list, create, raw := pkg.Routes[0], pkg.Routes[1], pkg.Routes[2]
+	if list.Method != "GET" || list.Path != "/notes" || list.OperationID != "notesList" || list.Summary != "Pages through the notes" {
+		t.Errorf("unexpected list route: %+v", list)
+	}
+	if len(list.Query) != 1 || list.Query[0] != (QueryParam{Name: "limit", Type: "integer", Description: "Page size"}) {
+		t.Errorf("expected an integer limit, got %+v", list.Query)
+	}
+	if got := list.Responses[200].Bodies; len(got) != 1 || got[0].String() != "[]example.com/notes.noteResponse" {
+		t.Errorf("unexpected list 200: %v", got)
+	}
+	for _, status := range []int{400, 429} {
+		if got := list.Responses[status].Bodies; len(got) != 1 || got[0].String() != "example.com/notes.errorResponse" {
+			t.Errorf("unexpected list %d: %v", status, got)
+		}
+	}
+
+	if create.Request == nil || create.Request.String() != "example.com/notes.noteRequest" {
+		t.Errorf("expected the decoded request, got %v", create.Request)
+	}
+	if create.Summary != "Stores a note" {
+		t.Errorf("expected the first sentence, got %q", create.Summary)
+	}
+	if _, ok := create.Responses[201]; !ok {
+		t.Errorf("expected 201, got %v", create.Responses)
+	}
+
+	if got := raw.Responses[200].ContentTypes; len(got) != 1 || got[0] != "text/markdown" {
+		t.Errorf("expected a markdown body, got %v", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	pkg, err := NewLoader().Load("testdata/notes", "example.com/notes")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	src, err := Render(pkg)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		"DO NOT EDIT",
+		"func RegisterSpec(b *openapi.Builder)",
+		`openapi.QueryParam("limit", "integer", "Page size")`,
+		"Request:     reflect.TypeFor[noteRequest]()",
+		"http.StatusCreated:",
+		"http.StatusTooManyRequests:",
+		`openapi.Raw("text/markdown")`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("expected %s in\n%s", want, src)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Package notes is a delivery package for the generator's tests
+package notes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type noteRequest struct {
+	Body string `json:"body"`
+}
+
+type noteResponse struct {
+	ID   string `json:"id"`
+	Body string `json:"body"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct{}
+
+// NewRouter mounts the notes endpoints
+//
+//openapi:response 429 errorResponse
+func NewRouter(h *Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /notes", h.list)
+	mux.HandleFunc("POST /notes", h.create)
+	mux.HandleFunc("GET /notes/{id}/raw", h.raw)
+	return mux
+}
+
+// list pages through the notes
+//
+//openapi:query limit Page size
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	if _, err := strconv.Atoi(r.URL.Query().Get("limit")); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	writeJSON(w, http.StatusOK, []noteResponse{})
+}
+
+// create stores a note. The body is kept as is.
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	var req noteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	writeJSON(w, http.StatusCreated, noteResponse{ID: "n1", Body: req.Body})
+}
+
+func (h *Handler) raw(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/markdown")
+	_, _ = w.Write([]byte("# note"))
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
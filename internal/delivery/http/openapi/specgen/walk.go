@@ -0,0 +1,430 @@
+package specgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxDepth bounds how deep calls are followed from a handler
+const maxDepth = 12
+
+// frame binds the parameters of a followed call to its arguments
+type frame struct {
+	params map[*types.Var]binding
+	caller *frame
+	depth  int
+}
+
+type binding struct {
+	expr  ast.Expr
+	frame *frame
+}
+
+func (f *frame) lookup(v *types.Var) (binding, bool) {
+	for ; f != nil; f = f.caller {
+		if b, ok := f.params[v]; ok {
+			return b, true
+		}
+	}
+	return binding{}, false
+}
+
+// walk collects what one route's handler reads and writes
+type walk struct {
+	a         *analyzer
+	responses map[int][]Response
+	requests  []types.Type
+	multipart bool
+	readsBody bool
+	query     []QueryParam
+	stack     map[*ast.FuncDecl]bool
+}
+
+// scope is what one function body does to the response writer directly
+type scope struct {
+	statuses    []int
+	writes      bool
+	contentType string
+}
+
+// expr follows a handler expression: a method value, a function, a func
+// literal, or a call returning one of them or a type with ServeHTTP
+func (w *walk) expr(e ast.Expr, f *frame) {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.FuncLit:
+		w.body(e.Body, f)
+	case *ast.CallExpr:
+		fn := w.a.callee(e)
+		if fd := w.a.decl(fn); fd != nil {
+			callee := w.bind(fn, e.Args, f)
+			w.fn(fd, callee)
+			if serve := w.a.serveHTTP(fn.Type().(*types.Signature).Results()); serve != nil {
+				w.fn(w.a.decl(serve), callee)
+			}
+		}
+		for _, arg := range e.Args {
+			w.expr(arg, f)
+		}
+	default:
+		if fd := w.a.decl(w.a.funcOf(e)); fd != nil {
+			w.fn(fd, &frame{caller: f, depth: depthOf(f) + 1})
+		}
+	}
+}
+
+func depthOf(f *frame) int {
+	if f == nil {
+		return 0
+	}
+	return f.depth
+}
+
+func (w *walk) bind(fn *types.Func, args []ast.Expr, f *frame) *frame {
+	callee := &frame{params: map[*types.Var]binding{}, caller: f, depth: depthOf(f) + 1}
+	params := fn.Type().(*types.Signature).Params()
+	for i := 0; i < params.Len() && i < len(args); i++ {
+		callee.params[params.At(i)] = binding{expr: args[i], frame: f}
+	}
+	return callee
+}
+
+func (w *walk) fn(fd *ast.FuncDecl, f *frame) {
+	if w.stack[fd] || f.depth > maxDepth {
+		return
+	}
+	w.stack[fd] = true
+	defer delete(w.stack, fd)
+	w.body(fd.Body, f)
+}
+
+// body inspects a function body; func literals in it get a scope of their own
+func (w *walk) body(body *ast.BlockStmt, f *frame) {
+	s := &scope{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			w.body(n.Body, f)
+			return false
+		case *ast.SelectorExpr:
+			if sel, ok := w.a.info.Selections[n]; ok && sel.Kind() == types.FieldVal && n.Sel.Name == "Body" && isNamed(deref(sel.Recv()), "net/http", "Request") {
+				w.readsBody = true
+			}
+		case *ast.IndexExpr:
+			if isNamed(w.a.info.TypeOf(n.X), "net/url", "Values") {
+				for _, name := range w.strings(n.Index, f) {
+					w.addQuery(name, "array")
+				}
+			}
+		case *ast.CallExpr:
+			w.call(n, f, s)
+		}
+		return true
+	})
+
+	if !s.writes {
+		for _, status := range s.statuses {
+			w.respond(status, Response{})
+		}
+		return
+	}
+	if len(s.statuses) == 0 {
+		s.statuses = []int{http.StatusOK}
+	}
+	if s.contentType == "" {
+		s.contentType = "application/octet-stream"
+	}
+	for _, status := range s.statuses {
+		if !bodyAllowed(status) {
+			w.respond(status, Response{})
+			continue
+		}
+		w.respond(status, Response{ContentTypes: []string{s.contentType}})
+	}
+}
+
+func (w *walk) call(call *ast.CallExpr, f *frame, s *scope) {
+	a := w.a
+	fn := a.callee(call)
+	if fn == nil {
+		return
+	}
+
+	if sink, ok := a.sinks[fn.Origin()]; ok {
+		statuses := w.ints(call.Args[sink.status], f)
+		bodies := w.types(call.Args[sink.body], f)
+		for _, status := range statuses {
+			w.respond(status, Response{Bodies: bodies})
+		}
+		return
+	}
+
+	switch fn.FullName() {
+	case "(net/http.ResponseWriter).WriteHeader":
+		s.statuses = append(s.statuses, w.ints(call.Args[0], f)...)
+		return
+	case "(net/http.ResponseWriter).Write":
+		s.writes = true
+		return
+	case "(*net/http.Request).ParseMultipartForm", "(*net/http.Request).MultipartReader", "(*net/http.Request).FormFile":
+		w.multipart = true
+		return
+	case "net/http.MaxBytesReader", "net/http.SetCookie":
+		return
+	case "(net/http.Header).Set":
+		if name := w.strings(call.Args[0], f); len(name) == 1 && http.CanonicalHeaderKey(name[0]) == "Content-Type" {
+			types := w.strings(call.Args[1], f)
+			switch {
+			case len(types) == 1 && (s.contentType == "" || s.contentType == types[0]):
+				s.contentType = types[0]
+			default:
+				s.contentType = "*/*"
+			}
+		}
+		return
+	case "net/http.Error":
+		for _, status := range w.ints(call.Args[2], f) {
+			w.respond(status, Response{ContentTypes: []string{"text/plain"}})
+		}
+		return
+	case "net/http.Redirect":
+		for _, status := range w.ints(call.Args[3], f) {
+			w.respond(status, Response{})
+		}
+		return
+	case "(*encoding/json.Decoder).Decode":
+		for _, t := range w.types(call.Args[0], f) {
+			if p, ok := t.(*types.Pointer); ok && !isInterface(p.Elem()) {
+				w.requests = append(w.requests, p.Elem())
+			}
+		}
+		return
+	case "(net/url.Values).Get":
+		typ := a.parsed[call]
+		if typ == "" {
+			typ = "string"
+		}
+		for _, name := range w.strings(call.Args[0], f) {
+			w.addQuery(name, typ)
+		}
+		return
+	}
+
+	fd := a.decl(fn)
+	if fd == nil {
+		// Anything else handed the writer writes a body, e.g. io.Copy or book.WriteTo
+		for _, arg := range call.Args {
+			if isNamed(a.info.TypeOf(arg), "net/http", "ResponseWriter") {
+				s.writes = true
+			}
+		}
+		return
+	}
+	callee := w.bind(fn, call.Args, f)
+	for v, b := range callee.params {
+		// A query value parsed by a helper, e.g. intParam(q.Get("limit"))
+		if typ := a.parsedVars[v]; typ != "" {
+			a.parsed[ast.Unparen(b.expr)] = typ
+		}
+	}
+	w.fn(fd, callee)
+	// Handlers handed to helpers, e.g. h.requireStaff(h.create)
+	for _, arg := range call.Args {
+		if _, ok := ast.Unparen(arg).(*ast.CallExpr); ok {
+			continue
+		}
+		if fd := a.decl(a.funcOf(arg)); fd != nil {
+			w.fn(fd, &frame{caller: f, depth: depthOf(f) + 1})
+		}
+	}
+}
+
+func (w *walk) respond(status int, resp Response) {
+	w.responses[status] = append(w.responses[status], resp)
+}
+
+func (w *walk) addQuery(name, typ string) {
+	for i, q := range w.query {
+		if q.Name == name {
+			if q.Type == "string" {
+				w.query[i].Type = typ
+			}
+			return
+		}
+	}
+	w.query = append(w.query, QueryParam{Name: name, Type: typ})
+}
+
+// sources follows an expression through variables, parameters and results of
+// the package's functions to the expressions the value comes from
+func (w *walk) sources(e ast.Expr, f *frame, seen map[*types.Var]bool) []binding {
+	e = ast.Unparen(e)
+	switch x := e.(type) {
+	case *ast.Ident:
+		v, ok := w.a.info.Uses[x].(*types.Var)
+		if !ok || seen[v] {
+			break
+		}
+		seen[v] = true
+		defer delete(seen, v)
+		if b, ok := f.lookup(v); ok {
+			return w.sources(b.expr, b.frame, seen)
+		}
+		if assigns := w.a.assigns[v]; len(assigns) > 0 {
+			var out []binding
+			for _, as := range assigns {
+				if as.index < 0 {
+					out = append(out, w.sources(as.expr, f, seen)...)
+				} else if call, ok := ast.Unparen(as.expr).(*ast.CallExpr); ok {
+					out = append(out, w.results(call, as.index, f, seen)...)
+				}
+			}
+			if len(out) > 0 {
+				return out
+			}
+		}
+	case *ast.CallExpr:
+		if out := w.results(x, 0, f, seen); len(out) > 0 {
+			return out
+		}
+	}
+	return []binding{{expr: e, frame: f}}
+}
+
+// results follows what a function of the package returns in position index
+func (w *walk) results(call *ast.CallExpr, index int, f *frame, seen map[*types.Var]bool) []binding {
+	fn := w.a.callee(call)
+	fd := w.a.decl(fn)
+	if fd == nil || w.stack[fd] || depthOf(f) > maxDepth {
+		return nil
+	}
+	w.stack[fd] = true
+	defer delete(w.stack, fd)
+
+	callee := w.bind(fn, call.Args, f)
+	var out []binding
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) > index && (len(n.Results) > 1 || index == 0) {
+				out = append(out, w.sources(n.Results[index], callee, seen)...)
+			}
+		}
+		return true
+	})
+	return out
+}
+
+// ints are the constant integers an expression may hold
+func (w *walk) ints(e ast.Expr, f *frame) []int {
+	var out []int
+	for _, src := range w.sources(e, f, map[*types.Var]bool{}) {
+		if tv := w.a.info.Types[src.expr]; tv.Value != nil {
+			if v, ok := constant.Int64Val(constant.ToInt(tv.Value)); ok && !containsInt(out, int(v)) {
+				out = append(out, int(v))
+			}
+		}
+	}
+	return out
+}
+
+// strings are the constant strings an expression may hold
+func (w *walk) strings(e ast.Expr, f *frame) []string {
+	var out []string
+	for _, src := range w.sources(e, f, map[*types.Var]bool{}) {
+		if tv := w.a.info.Types[src.expr]; tv.Value != nil && tv.Value.Kind() == constant.String {
+			out = append(out, constant.StringVal(tv.Value))
+		}
+	}
+	return out
+}
+
+// types are the dynamic types an expression may hold, interfaces only where
+// nothing more precise is known
+func (w *walk) types(e ast.Expr, f *frame) []types.Type {
+	var out []types.Type
+	for _, src := range w.sources(e, f, map[*types.Var]bool{}) {
+		if tv := w.a.info.Types[src.expr]; tv.Type != nil && !tv.IsNil() {
+			out = appendType(out, tv.Type)
+		}
+	}
+	return out
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
+
+func isNamed(t types.Type, pkg, name string) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == pkg && named.Obj().Name() == name
+}
+
+// bodyAllowed reports whether a status may carry a body
+func bodyAllowed(status int) bool {
+	return status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// directives applies what a function declares beyond its code: responses the
+// analysis cannot see, such as those of injected middleware, and descriptions
+// of query parameters:
+//
+//	//openapi:response 429 errorResponse
+//	//openapi:query limit Page size, 1-30
+func (w *walk) directives(fd *ast.FuncDecl) error {
+	if fd == nil || fd.Doc == nil {
+		return nil
+	}
+	for _, c := range fd.Doc.List {
+		if args, ok := strings.CutPrefix(c.Text, "//openapi:query "); ok {
+			name, desc, _ := strings.Cut(strings.TrimSpace(args), " ")
+			i := slices.IndexFunc(w.query, func(q QueryParam) bool { return q.Name == name })
+			if i == -1 {
+				return fmt.Errorf("%s: query parameter %q is not read", w.a.pos(c), name)
+			}
+			w.query[i].Description = strings.TrimSpace(desc)
+			continue
+		}
+		args, ok := strings.CutPrefix(c.Text, "//openapi:response ")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(args)
+		if len(fields) == 0 || len(fields) > 2 {
+			return fmt.Errorf("%s: want //openapi:response <status> [<type>]", w.a.pos(c))
+		}
+		status, err := strconv.Atoi(fields[0])
+		if err != nil || http.StatusText(status) == "" {
+			return fmt.Errorf("%s: invalid status %q", w.a.pos(c), fields[0])
+		}
+		var resp Response
+		if len(fields) == 2 {
+			tv, err := types.Eval(w.a.fset, w.a.pkg, fd.Pos(), fields[1])
+			if err != nil || !tv.IsType() {
+				return fmt.Errorf("%s: %s is not a type", w.a.pos(c), fields[1])
+			}
+			resp.Bodies = []types.Type{tv.Type}
+		}
+		w.respond(status, resp)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package publishguard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/publishguard"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/publishguard"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Incidents is the part of the publish guard the security endpoints need
+type Incidents interface {
+	OpenIncidents(ctx context.Context) ([]*publishguard.Incident, error)
+	Incident(ctx context.Context, id string) (*publishguard.Incident, error)
+	Resolve(ctx context.Context, staffID, id string, compromised bool, note string, at time.Time) (*publishguard.Incident, error)
+}
+
+type resolveRequest struct {
+	Compromised bool   `json:"compromised"`
+	Note        string `json:"note"`
+}
+
+type actionResponse struct {
+	Kind      string `json:"kind"`
+	ArticleID string `json:"article_id"`
+	IP        string `json:"ip,omitempty"`
+	At        string `json:"at"`
+}
+
+type incidentResponse struct {
+	ID         string           `json:"id"`
+	StaffID    string           `json:"staff_id"`
+	SessionID  string           `json:"session_id"`
+	Signals    []string         `json:"signals"`
+	Response   string           `json:"response"`
+	Trigger    actionResponse   `json:"trigger"`
+	Evidence   []actionResponse `json:"evidence"`
+	Status     string           `json:"status"`
+	CreatedAt  string           `json:"created_at"`
+	ResolvedBy *string          `json:"resolved_by,omitempty"`
+	ResolvedAt *string          `json:"resolved_at,omitempty"`
+	Note       *string          `json:"note,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	incidents Incidents
+	staff     StaffResolver
+}
+
+func NewHandler(incidents Incidents, staff StaffResolver) *Handler {
+	return &Handler{incidents: incidents, staff: staff}
+}
+
+// NewAdminRouter mounts the security review of flagged publish actions, it must sit behind admin authentication
+func NewAdminRouter(incidents Incidents, staff StaffResolver) http.Handler {
+	h := NewHandler(incidents, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/security/incidents", h.List)
+	mux.HandleFunc("GET /admin/security/incidents/{id}", h.Get)
+	mux.HandleFunc("POST /admin/security/incidents/{id}/resolve", h.Resolve)
+	return mux
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	incidents, err := h.incidents.OpenIncidents(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]incidentResponse, 0, len(incidents))
+	for _, i := range incidents {
+		resp = append(resp, toIncidentResponse(i))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	incident, err := h.incidents.Incident(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toIncidentResponse(incident))
+}
+
+func (h *Handler) Resolve(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req resolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	incident, err := h.incidents.Resolve(r.Context(), staffID, r.PathValue("id"), req.Compromised, req.Note, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toIncidentResponse(incident))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrIncidentNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, publishguard.ErrIncidentResolved):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toActionResponse(a publishguard.Action) actionResponse {
+	return actionResponse{Kind: string(a.Kind), ArticleID: a.ArticleID, IP: a.IP, At: a.At.Format(time.RFC3339)}
+}
+
+func toIncidentResponse(i *publishguard.Incident) incidentResponse {
+	resp := incidentResponse{
+		ID:         i.ID,
+		StaffID:    i.StaffID,
+		SessionID:  i.SessionID,
+		Signals:    make([]string, 0, len(i.Signals)),
+		Response:   string(i.Response),
+		Trigger:    toActionResponse(i.Trigger),
+		Evidence:   make([]actionResponse, 0, len(i.Evidence)),
+		Status:     string(i.Status),
+		CreatedAt:  i.CreatedAt.Format(time.RFC3339),
+		ResolvedBy: i.ResolvedBy,
+		Note:       i.Note,
+	}
+	for _, s := range i.Signals {
+		resp.Signals = append(resp.Signals, string(s))
+	}
+	for _, a := range i.Evidence {
+		resp.Evidence = append(resp.Evidence, toActionResponse(a))
+	}
+	if i.ResolvedAt != nil {
+		at := i.ResolvedAt.Format(time.RFC3339)
+		resp.ResolvedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
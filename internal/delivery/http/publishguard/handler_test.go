@@ -0,0 +1,85 @@
+package publishguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/publishguard"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/publishguard"
+)
+
+type fakeIncidents struct {
+	err error
+}
+
+func (f *fakeIncidents) incident() *publishguard.Incident {
+	trigger := publishguard.Action{StaffID: "s1", SessionID: "sess-1", Kind: publishguard.ActionUnpublish, ArticleID: "a1", At: time.Now()}
+	incident, _ := publishguard.NewIncident("i1", trigger, []publishguard.Action{trigger}, []publishguard.Signal{publishguard.SignalMassUnpublish}, time.Now())
+	return incident
+}
+
+func (f *fakeIncidents) OpenIncidents(ctx context.Context) ([]*publishguard.Incident, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*publishguard.Incident{f.incident()}, nil
+}
+
+func (f *fakeIncidents) Incident(ctx context.Context, id string) (*publishguard.Incident, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.incident(), nil
+}
+
+func (f *fakeIncidents) Resolve(ctx context.Context, staffID, id string, compromised bool, note string, at time.Time) (*publishguard.Incident, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	incident := f.incident()
+	return incident, incident.Resolve(staffID, compromised, note, at)
+}
+
+func TestHandler(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "sec-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"list", http.MethodGet, "/admin/security/incidents", "", true, nil, http.StatusOK},
+		{"get", http.MethodGet, "/admin/security/incidents/i1", "", true, nil, http.StatusOK},
+		{"get missing", http.MethodGet, "/admin/security/incidents/i2", "", true, app.ErrIncidentNotFound, http.StatusNotFound},
+		{"resolve", http.MethodPost, "/admin/security/incidents/i1/resolve", `{"compromised":true,"note":"password reset"}`, true, nil, http.StatusOK},
+		{"resolve without session", http.MethodPost, "/admin/security/incidents/i1/resolve", `{}`, false, nil, http.StatusUnauthorized},
+		{"resolve invalid body", http.MethodPost, "/admin/security/incidents/i1/resolve", `{`, true, nil, http.StatusBadRequest},
+		{"resolve twice", http.MethodPost, "/admin/security/incidents/i1/resolve", `{}`, true, publishguard.ErrIncidentResolved, http.StatusConflict},
+		{"store failure", http.MethodGet, "/admin/security/incidents", "", true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeIncidents{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package purchase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/purchase"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/purchase"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// MemberResolver returns the signed-in member's account ID
+type MemberResolver func(r *http.Request) (string, bool)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Purchases is the part of the purchase service the endpoints need
+type Purchases interface {
+	Price(ctx context.Context, articleID string) (*purchase.Price, error)
+	SetPrice(ctx context.Context, editorID, articleID string, amount payment.Money) (*purchase.Price, error)
+	RemovePrice(ctx context.Context, articleID string) error
+	Buy(ctx context.Context, accountID, articleID string) (*app.Checkout, error)
+	Confirm(ctx context.Context, accountID, purchaseID string) (*purchase.Purchase, error)
+	Get(ctx context.Context, accountID, purchaseID string) (*purchase.Purchase, error)
+	History(ctx context.Context, accountID string, limit, offset int) ([]*purchase.Purchase, error)
+}
+
+type priceRequest struct {
+	Amount   int64  `json:"amount"` // Minor units
+	Currency string `json:"currency"`
+}
+
+type priceResponse struct {
+	ArticleID string `json:"article_id"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+}
+
+type purchaseResponse struct {
+	ID           string  `json:"id"`
+	ArticleID    string  `json:"article_id"`
+	ArticleTitle string  `json:"article_title"`
+	Amount       int64   `json:"amount"`
+	Currency     string  `json:"currency"`
+	Status       string  `json:"status"`
+	CreatedAt    string  `json:"created_at"`
+	CompletedAt  *string `json:"completed_at,omitempty"`
+}
+
+type checkoutResponse struct {
+	Purchase     purchaseResponse `json:"purchase"`
+	ClientSecret string           `json:"client_secret"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	purchases Purchases
+	member    MemberResolver
+	staff     StaffResolver
+}
+
+func NewHandler(purchases Purchases, member MemberResolver, staff StaffResolver) *Handler {
+	return &Handler{purchases: purchases, member: member, staff: staff}
+}
+
+// NewRouter mounts article prices, checkout and the member's purchase history
+func NewRouter(purchases Purchases, member MemberResolver) http.Handler {
+	h := NewHandler(purchases, member, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /articles/{id}/price", h.Price)
+	mux.HandleFunc("POST /articles/{id}/purchase", h.Buy)
+	mux.HandleFunc("GET /purchases", h.History)
+	mux.HandleFunc("GET /purchases/{id}", h.Get)
+	mux.HandleFunc("POST /purchases/{id}/confirm", h.Confirm)
+	return mux
+}
+
+// NewAdminRouter mounts article pricing, it must sit behind admin authentication
+func NewAdminRouter(purchases Purchases, staff StaffResolver) http.Handler {
+	h := NewHandler(purchases, nil, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /admin/articles/{id}/price", h.SetPrice)
+	mux.HandleFunc("DELETE /admin/articles/{id}/price", h.RemovePrice)
+	return mux
+}
+
+func (h *Handler) Price(w http.ResponseWriter, r *http.Request) {
+	price, err := h.purchases.Price(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPriceResponse(price))
+}
+
+// Buy starts the checkout, the client completes the payment with the client
+// secret and then calls Confirm
+func (h *Handler) Buy(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to buy articles"})
+		return
+	}
+	checkout, err := h.purchases.Buy(r.Context(), accountID, r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, checkoutResponse{Purchase: toPurchaseResponse(checkout.Purchase), ClientSecret: checkout.ClientSecret})
+}
+
+func (h *Handler) Confirm(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to buy articles"})
+		return
+	}
+	p, err := h.purchases.Confirm(r.Context(), accountID, r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPurchaseResponse(p))
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to see your purchases"})
+		return
+	}
+	p, err := h.purchases.Get(r.Context(), accountID, r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPurchaseResponse(p))
+}
+
+// History handles GET /purchases?limit=20&offset=0
+func (h *Handler) History(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to see your purchases"})
+		return
+	}
+	q := r.URL.Query()
+	limit, err := intParam(q.Get("limit"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit"})
+		return
+	}
+	offset, err := intParam(q.Get("offset"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid offset"})
+		return
+	}
+
+	purchases, err := h.purchases.History(r.Context(), accountID, limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]purchaseResponse, 0, len(purchases))
+	for _, p := range purchases {
+		resp = append(resp, toPurchaseResponse(p))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) SetPrice(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req priceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	amount, err := payment.NewMoney(req.Amount, req.Currency)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	price, err := h.purchases.SetPrice(r.Context(), editorID, r.PathValue("id"), *amount)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPriceResponse(price))
+}
+
+func (h *Handler) RemovePrice(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.staff(r); !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	if err := h.purchases.RemovePrice(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// intParam returns zero when the parameter is missing
+func intParam(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, errors.New("invalid number")
+	}
+	return n, nil
+}
+
+func toPriceResponse(p *purchase.Price) priceResponse {
+	return priceResponse{ArticleID: p.ArticleID, Amount: p.Amount.Amount(), Currency: p.Amount.Currency()}
+}
+
+func toPurchaseResponse(p *purchase.Purchase) purchaseResponse {
+	resp := purchaseResponse{
+		ID:           p.ID,
+		ArticleID:    p.ArticleID,
+		ArticleTitle: p.ArticleTitle,
+		Amount:       p.Amount.Amount(),
+		Currency:     p.Amount.Currency(),
+		Status:       string(p.Status),
+		CreatedAt:    p.CreatedAt.Format(time.RFC3339),
+	}
+	if p.CompletedAt != nil {
+		at := p.CompletedAt.Format(time.RFC3339)
+		resp.CompletedAt = &at
+	}
+	return resp
+}
+
+// writeError maps domain errors by kind, the code tells e.g. an article that
+// is not for sale from one the reader already bought
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, payment.ErrInvalidCurrency) || errors.Is(err, payment.ErrNegativeAmount) {
+		// Money is parsed by the payment domain, whose errors carry no kind
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+		return
+	}
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(de, purchase.ErrPaymentNotSucceeded), errors.Is(de, purchase.ErrAmountMismatch):
+		// The reader is asked to pay again rather than fix their request
+		status = http.StatusPaymentRequired
+	case de.Kind == shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case de.Kind == shared.KindNotFound:
+		status = http.StatusNotFound
+	case de.Kind == shared.KindConflict, de.Kind == shared.KindState:
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error(), Code: de.Code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
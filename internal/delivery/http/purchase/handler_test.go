@@ -0,0 +1,184 @@
+package purchase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/purchase"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/purchase"
+)
+
+var testNow = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+type fakePurchases struct {
+	err      error
+	editorID string
+	amount   payment.Money
+}
+
+func (f *fakePurchases) price(articleID string) *purchase.Price {
+	amount, _ := payment.NewMoney(5000, "IDR")
+	p, _ := purchase.NewPrice(articleID, *amount, "editor-1", testNow)
+	return p
+}
+
+func (f *fakePurchases) purchase(accountID string) *purchase.Purchase {
+	p, _ := purchase.NewPurchase("p1", accountID, purchase.Article{ID: "article-1", Title: "Inside the budget", Premium: true}, *f.price("article-1"), testNow)
+	_ = p.AttachPaymentIntent("pi_1")
+	return p
+}
+
+func (f *fakePurchases) Price(ctx context.Context, articleID string) (*purchase.Price, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.price(articleID), nil
+}
+
+func (f *fakePurchases) SetPrice(ctx context.Context, editorID, articleID string, amount payment.Money) (*purchase.Price, error) {
+	f.editorID, f.amount = editorID, amount
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &purchase.Price{ArticleID: articleID, Amount: amount, SetBy: editorID, UpdatedAt: testNow}, nil
+}
+
+func (f *fakePurchases) RemovePrice(ctx context.Context, articleID string) error {
+	return f.err
+}
+
+func (f *fakePurchases) Buy(ctx context.Context, accountID, articleID string) (*app.Checkout, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.Checkout{Purchase: f.purchase(accountID), ClientSecret: "secret_1"}, nil
+}
+
+func (f *fakePurchases) Confirm(ctx context.Context, accountID, purchaseID string) (*purchase.Purchase, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	p := f.purchase(accountID)
+	amount, _ := payment.NewMoney(5000, "IDR")
+	_ = p.Complete(payment.PaymentIntent{ID: "pi_1", Amount: *amount, Status: payment.IntentStatusSucceeded}, testNow)
+	return p, nil
+}
+
+func (f *fakePurchases) Get(ctx context.Context, accountID, purchaseID string) (*purchase.Purchase, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.purchase(accountID), nil
+}
+
+func (f *fakePurchases) History(ctx context.Context, accountID string, limit, offset int) ([]*purchase.Purchase, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*purchase.Purchase{f.purchase(accountID)}, nil
+}
+
+func signedIn(r *http.Request) (string, bool) {
+	return "member-1", true
+}
+
+func signedOut(r *http.Request) (string, bool) {
+	return "", false
+}
+
+func TestRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		member         MemberResolver
+		err            error
+		expectedStatus int
+	}{
+		{"price", http.MethodGet, "/articles/article-1/price", signedOut, nil, http.StatusOK},
+		{"not for sale", http.MethodGet, "/articles/article-1/price", signedOut, purchase.ErrNotForSale, http.StatusNotFound},
+		{"buy", http.MethodPost, "/articles/article-1/purchase", signedIn, nil, http.StatusCreated},
+		{"buy signed out", http.MethodPost, "/articles/article-1/purchase", signedOut, nil, http.StatusUnauthorized},
+		{"buy twice", http.MethodPost, "/articles/article-1/purchase", signedIn, purchase.ErrAlreadyPurchased, http.StatusConflict},
+		{"confirm", http.MethodPost, "/purchases/p1/confirm", signedIn, nil, http.StatusOK},
+		{"confirm unpaid", http.MethodPost, "/purchases/p1/confirm", signedIn, purchase.ErrPaymentNotSucceeded, http.StatusPaymentRequired},
+		{"confirm unknown", http.MethodPost, "/purchases/p9/confirm", signedIn, app.ErrPurchaseNotFound, http.StatusNotFound},
+		{"get", http.MethodGet, "/purchases/p1", signedIn, nil, http.StatusOK},
+		{"history", http.MethodGet, "/purchases?limit=10&offset=10", signedIn, nil, http.StatusOK},
+		{"history invalid limit", http.MethodGet, "/purchases?limit=ten", signedIn, nil, http.StatusBadRequest},
+		{"history signed out", http.MethodGet, "/purchases", signedOut, nil, http.StatusUnauthorized},
+		{"store failure", http.MethodGet, "/purchases", signedIn, errors.New("connection reset"), http.StatusInternalServerError},
+		{"timeout", http.MethodPost, "/articles/article-1/purchase", signedIn, context.DeadlineExceeded, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, nil)
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakePurchases{err: tc.err}, tc.member)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_Buy(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakePurchases{}, signedIn)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/articles/article-1/purchase", nil))
+
+	var resp checkoutResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ClientSecret != "secret_1" || resp.Purchase.Status != "pending" || resp.Purchase.Amount != 5000 || resp.Purchase.Currency != "IDR" {
+		t.Errorf("unexpected checkout %+v", resp)
+	}
+}
+
+func TestAdminRouter(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "editor-1", true }
+
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		staff          StaffResolver
+		err            error
+		expectedStatus int
+	}{
+		{"set price", http.MethodPut, `{"amount": 5000, "currency": "idr"}`, staff, nil, http.StatusOK},
+		{"invalid body", http.MethodPut, `{"amount":`, staff, nil, http.StatusBadRequest},
+		{"invalid currency", http.MethodPut, `{"amount": 5000, "currency": "rupiah"}`, staff, nil, http.StatusUnprocessableEntity},
+		{"not premium", http.MethodPut, `{"amount": 5000, "currency": "IDR"}`, staff, purchase.ErrNotPremium, http.StatusUnprocessableEntity},
+		{"unknown article", http.MethodPut, `{"amount": 5000, "currency": "IDR"}`, staff, app.ErrArticleNotFound, http.StatusNotFound},
+		{"no session", http.MethodPut, `{"amount": 5000, "currency": "IDR"}`, signedOut, nil, http.StatusUnauthorized},
+		{"remove price", http.MethodDelete, "", staff, nil, http.StatusNoContent},
+		{"remove missing price", http.MethodDelete, "", staff, purchase.ErrNotForSale, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			purchases := &fakePurchases{err: tc.err}
+			req := httptest.NewRequest(tc.method, "/admin/articles/article-1/price", strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(purchases, tc.staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.name == "set price" && (purchases.editorID != "editor-1" || purchases.amount.Currency() != "IDR") {
+				t.Errorf("expected the editor and normalized price, got %s %+v", purchases.editorID, purchases.amount)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package puzzle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/puzzle"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/puzzle"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// MemberResolver returns the signed-in member's account ID
+type MemberResolver func(r *http.Request) (string, bool)
+
+// Games is the part of the puzzle service the play API needs
+type Games interface {
+	Daily(ctx context.Context, kind puzzle.Kind, at time.Time) (*puzzle.Puzzle, error)
+	Start(ctx context.Context, accountID, puzzleID string, at time.Time) (*puzzle.Progress, error)
+	SaveProgress(ctx context.Context, accountID, puzzleID string, cells []string, elapsed time.Duration, at time.Time) (*puzzle.Progress, error)
+	Submit(ctx context.Context, accountID, puzzleID string, cells []string, elapsed time.Duration, at time.Time) (*app.SubmitResult, error)
+	Leaderboard(ctx context.Context, puzzleID string, limit int) ([]app.LeaderboardEntry, error)
+}
+
+type clueResponse struct {
+	Number    int    `json:"number"`
+	Direction string `json:"direction"`
+	Row       int    `json:"row"`
+	Col       int    `json:"col"`
+	Text      string `json:"text"`
+}
+
+// puzzleResponse never includes the solution
+type puzzleResponse struct {
+	ID         string         `json:"id"`
+	Kind       string         `json:"kind"`
+	Title      string         `json:"title"`
+	Difficulty string         `json:"difficulty"`
+	PlayDate   string         `json:"play_date"`
+	Rows       int            `json:"rows"`
+	Cols       int            `json:"cols"`
+	Board      []string       `json:"board"`
+	Clues      []clueResponse `json:"clues,omitempty"`
+}
+
+type progressResponse struct {
+	PuzzleID       string   `json:"puzzle_id"`
+	Entries        []string `json:"entries"`
+	ElapsedSeconds int64    `json:"elapsed_seconds"`
+	Completed      bool     `json:"completed"`
+	Ranked         bool     `json:"ranked"`
+}
+
+type submitResponse struct {
+	Solved        bool             `json:"solved"`
+	Progress      progressResponse `json:"progress"`
+	CurrentStreak int              `json:"current_streak,omitempty"`
+	LongestStreak int              `json:"longest_streak,omitempty"`
+}
+
+type leaderboardEntryResponse struct {
+	Rank           int    `json:"rank"`
+	Username       string `json:"username"`
+	ElapsedSeconds int64  `json:"elapsed_seconds"`
+}
+
+type entriesRequest struct {
+	Entries        []string `json:"entries"`
+	ElapsedSeconds int64    `json:"elapsed_seconds"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	games  Games
+	member MemberResolver
+}
+
+func NewHandler(games Games, member MemberResolver) *Handler {
+	return &Handler{games: games, member: member}
+}
+
+// NewRouter mounts the public play API under /games
+func NewRouter(games Games, member MemberResolver) http.Handler {
+	h := NewHandler(games, member)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /games/{kind}/daily", h.Daily)
+	mux.HandleFunc("GET /games/puzzles/{id}/leaderboard", h.Leaderboard)
+	mux.HandleFunc("POST /games/puzzles/{id}/start", h.Start)
+	mux.HandleFunc("PUT /games/puzzles/{id}/progress", h.SaveProgress)
+	mux.HandleFunc("POST /games/puzzles/{id}/submit", h.Submit)
+	return mux
+}
+
+func (h *Handler) Daily(w http.ResponseWriter, r *http.Request) {
+	p, err := h.games.Daily(r.Context(), puzzle.Kind(r.PathValue("kind")), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	board := p.Board()
+	resp := puzzleResponse{
+		ID:         p.ID,
+		Kind:       string(p.Kind),
+		Title:      p.Title,
+		Difficulty: string(p.Difficulty),
+		Rows:       board.Rows(),
+		Cols:       board.Cols(),
+		Board:      board.Cells(),
+	}
+	if p.PlayDate != nil {
+		resp.PlayDate = p.PlayDate.Format("2006-01-02")
+	}
+	for _, c := range p.Clues {
+		resp.Clues = append(resp.Clues, clueResponse{Number: c.Number, Direction: string(c.Direction), Row: c.Row, Col: c.Col, Text: c.Text})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to play"})
+		return
+	}
+
+	progress, err := h.games.Start(r.Context(), accountID, r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toProgressResponse(progress))
+}
+
+func (h *Handler) SaveProgress(w http.ResponseWriter, r *http.Request) {
+	accountID, req, ok := h.decodeEntries(w, r)
+	if !ok {
+		return
+	}
+
+	progress, err := h.games.SaveProgress(r.Context(), accountID, r.PathValue("id"), req.Entries, time.Duration(req.ElapsedSeconds)*time.Second, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toProgressResponse(progress))
+}
+
+func (h *Handler) Submit(w http.ResponseWriter, r *http.Request) {
+	accountID, req, ok := h.decodeEntries(w, r)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	result, err := h.games.Submit(r.Context(), accountID, r.PathValue("id"), req.Entries, time.Duration(req.ElapsedSeconds)*time.Second, now)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := submitResponse{Solved: result.Solved, Progress: toProgressResponse(result.Progress)}
+	if result.Streak != nil {
+		resp.CurrentStreak = result.Streak.CurrentAt(now)
+		resp.LongestStreak = result.Streak.Longest
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Leaderboard(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit must be a number"})
+			return
+		}
+		limit = n
+	}
+
+	entries, err := h.games.Leaderboard(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := make([]leaderboardEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, leaderboardEntryResponse{Rank: e.Rank, Username: e.Username, ElapsedSeconds: int64(e.Elapsed / time.Second)})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) decodeEntries(w http.ResponseWriter, r *http.Request) (string, *entriesRequest, bool) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in to play"})
+		return "", nil, false
+	}
+
+	var req entriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ElapsedSeconds < 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return "", nil, false
+	}
+	return accountID, &req, true
+}
+
+func toProgressResponse(p *puzzle.Progress) progressResponse {
+	return progressResponse{
+		PuzzleID:       p.PuzzleID,
+		Entries:        p.Entries.Cells(),
+		ElapsedSeconds: int64(p.Elapsed / time.Second),
+		Completed:      p.IsCompleted(),
+		Ranked:         p.IsRanked(),
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrPuzzleNotFound), errors.Is(err, app.ErrNoDailyPuzzle), errors.Is(err, puzzle.ErrNotPlayable):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrMemberNotAllowed):
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+	case errors.Is(err, puzzle.ErrSavingTooFast):
+		writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: err.Error()})
+	case errors.Is(err, puzzle.ErrAlreadySolved):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, puzzle.ErrGridMismatch), errors.Is(err, puzzle.ErrGivenOverridden),
+		errors.Is(err, puzzle.ErrClockRewound), errors.Is(err, puzzle.ErrCellCountMismatch):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
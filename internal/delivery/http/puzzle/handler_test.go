@@ -0,0 +1,109 @@
+package puzzle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/puzzle"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/puzzle"
+)
+
+type fakeGames struct {
+	daily *puzzle.Puzzle
+	err   error
+}
+
+func (f *fakeGames) Daily(ctx context.Context, kind puzzle.Kind, at time.Time) (*puzzle.Puzzle, error) {
+	if f.daily == nil {
+		return nil, app.ErrNoDailyPuzzle
+	}
+	return f.daily, nil
+}
+
+func (f *fakeGames) Start(ctx context.Context, accountID, puzzleID string, at time.Time) (*puzzle.Progress, error) {
+	return puzzle.NewProgress("pr1", f.daily, accountID, at)
+}
+
+func (f *fakeGames) SaveProgress(ctx context.Context, accountID, puzzleID string, cells []string, elapsed time.Duration, at time.Time) (*puzzle.Progress, error) {
+	return nil, f.err
+}
+
+func (f *fakeGames) Submit(ctx context.Context, accountID, puzzleID string, cells []string, elapsed time.Duration, at time.Time) (*app.SubmitResult, error) {
+	return nil, f.err
+}
+
+func (f *fakeGames) Leaderboard(ctx context.Context, puzzleID string, limit int) ([]app.LeaderboardEntry, error) {
+	return []app.LeaderboardEntry{{Rank: 1, Username: "player_one", Elapsed: 95 * time.Second}}, nil
+}
+
+func createTestDaily(t *testing.T) *puzzle.Puzzle {
+	solution, _ := puzzle.NewGrid(5, 5, strings.Split("C,A,T,#,S,A,#,O,A,K,R,A,T,E,S,E,#,O,N,E,S,L,O,T,S", ","))
+	p, err := puzzle.NewCrossword("p1", "editor1", "Mini", puzzle.DifficultyEasy, *solution, []puzzle.Clue{
+		{Number: 1, Direction: puzzle.DirectionAcross, Row: 0, Col: 0, Text: "Feline pet"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create puzzle: %v", err)
+	}
+	_ = p.Schedule(time.Now())
+	return p
+}
+
+func TestHandler_DailyHidesSolution(t *testing.T) {
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(&fakeGames{daily: createTestDaily(t)}, func(r *http.Request) (string, bool) { return "", false }))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/crossword/daily", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "RATES") || strings.Contains(rec.Body.String(), `"C"`) {
+		t.Error("expected response not to contain solution letters")
+	}
+
+	var resp puzzleResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Rows != 5 || len(resp.Board) != 25 || len(resp.Clues) != 1 {
+		t.Errorf("unexpected puzzle response %+v", resp)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	member := func(r *http.Request) (string, bool) { return "member1", r.Header.Get("X-Test-Member") != "" }
+
+	tests := []struct {
+		name     string
+		games    *fakeGames
+		method   string
+		path     string
+		body     string
+		member   bool
+		expected int
+	}{
+		{name: "no daily puzzle", games: &fakeGames{}, method: http.MethodGet, path: "/games/sudoku/daily", expected: http.StatusNotFound},
+		{name: "anonymous save", games: &fakeGames{}, method: http.MethodPut, path: "/games/puzzles/p1/progress", body: `{}`, expected: http.StatusUnauthorized},
+		{name: "malformed body", games: &fakeGames{}, method: http.MethodPut, path: "/games/puzzles/p1/progress", body: `{`, member: true, expected: http.StatusBadRequest},
+		{name: "saving too fast", games: &fakeGames{err: puzzle.ErrSavingTooFast}, method: http.MethodPut, path: "/games/puzzles/p1/progress", body: `{"entries":[]}`, member: true, expected: http.StatusTooManyRequests},
+		{name: "already solved", games: &fakeGames{err: puzzle.ErrAlreadySolved}, method: http.MethodPost, path: "/games/puzzles/p1/submit", body: `{"entries":[]}`, member: true, expected: http.StatusConflict},
+		{name: "leaderboard", games: &fakeGames{}, method: http.MethodGet, path: "/games/puzzles/p1/leaderboard", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.member {
+				req.Header.Set("X-Test-Member", "1")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(tt.games, member)).ServeHTTP(rec, req)
+			if rec.Code != tt.expected {
+				t.Errorf("expected status %d, got %d: %s", tt.expected, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
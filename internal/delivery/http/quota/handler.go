@@ -0,0 +1,212 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+const dateLayout = "2006-01-02"
+
+// DefaultReportDays is the history shown when no range is requested
+const DefaultReportDays = 30
+
+// PartnerResolver returns the partner account behind the request
+type PartnerResolver func(r *http.Request) (string, bool)
+
+// Reports is the part of the quota service the usage endpoints need
+type Reports interface {
+	Report(ctx context.Context, accountID string, period quota.Period, from, to, at time.Time) (*app.Report, error)
+}
+
+type limitsResponse struct {
+	Daily   int64 `json:"daily"`
+	Monthly int64 `json:"monthly"`
+}
+
+type tierResponse struct {
+	Name   string                    `json:"name"`
+	Limits limitsResponse            `json:"limits"`
+	Routes map[string]limitsResponse `json:"routes,omitempty"`
+}
+
+type assignmentResponse struct {
+	Source      string  `json:"source"`
+	ContractRef *string `json:"contract_ref,omitempty"`
+	StartsAt    string  `json:"starts_at"`
+	EndsAt      *string `json:"ends_at,omitempty"`
+}
+
+type statusResponse struct {
+	Route   string `json:"route,omitempty"`
+	Period  string `json:"period"`
+	Used    int64  `json:"used"`
+	Limit   int64  `json:"limit"`
+	Level   string `json:"level,omitempty"`
+	ResetAt string `json:"reset_at"`
+}
+
+type usageResponse struct {
+	Window   string `json:"window"`
+	APIKeyID string `json:"api_key_id,omitempty"`
+	Route    string `json:"route,omitempty"`
+	Count    int64  `json:"count"`
+}
+
+type reportResponse struct {
+	AccountID  string              `json:"account_id"`
+	Tier       *tierResponse       `json:"tier,omitempty"`
+	Assignment *assignmentResponse `json:"assignment,omitempty"`
+	Current    []statusResponse    `json:"current"`
+	History    []usageResponse     `json:"history"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	reports Reports
+	partner PartnerResolver
+}
+
+func NewHandler(reports Reports, partner PartnerResolver) *Handler {
+	return &Handler{reports: reports, partner: partner}
+}
+
+// NewRouter mounts the partner usage report
+func NewRouter(reports Reports, partner PartnerResolver) http.Handler {
+	h := NewHandler(reports, partner)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /partner/usage", h.PartnerUsage)
+	return mux
+}
+
+// NewAdminRouter mounts the usage dashboard endpoint, it must sit behind admin authentication
+func NewAdminRouter(reports Reports) http.Handler {
+	h := NewHandler(reports, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/quotas/{accountID}/usage", h.AccountUsage)
+	return mux
+}
+
+func (h *Handler) PartnerUsage(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.partner(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "API credentials required"})
+		return
+	}
+	h.writeReport(w, r, accountID)
+}
+
+func (h *Handler) AccountUsage(w http.ResponseWriter, r *http.Request) {
+	h.writeReport(w, r, r.PathValue("accountID"))
+}
+
+func (h *Handler) writeReport(w http.ResponseWriter, r *http.Request, accountID string) {
+	q := r.URL.Query()
+	now := time.Now()
+
+	period := quota.PeriodDaily
+	if raw := q.Get("period"); raw != "" {
+		period = quota.Period(raw)
+	}
+
+	to := now
+	if raw := q.Get("to"); raw != "" {
+		day, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "to must be a date (YYYY-MM-DD)"})
+			return
+		}
+		to = day.AddDate(0, 0, 1) // Inclusive of the requested day
+	}
+
+	from := to.AddDate(0, 0, -DefaultReportDays)
+	if raw := q.Get("from"); raw != "" {
+		day, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "from must be a date (YYYY-MM-DD)"})
+			return
+		}
+		from = day
+	}
+
+	report, err := h.reports.Report(r.Context(), accountID, period, from, to, now)
+	if err != nil {
+		switch {
+		case errors.Is(err, quota.ErrInvalidPeriod), errors.Is(err, app.ErrInvalidReportRange):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		case errors.Is(err, app.ErrTierNotFound):
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+		case shared.IsTimeout(err):
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "usage report timed out, narrow the date range"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to load usage"})
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, toReportResponse(report))
+}
+
+func toReportResponse(report *app.Report) reportResponse {
+	resp := reportResponse{
+		AccountID: report.AccountID,
+		Current:   make([]statusResponse, 0, len(report.Current)),
+		History:   make([]usageResponse, 0, len(report.History)),
+	}
+
+	if report.Tier != nil {
+		limits := report.Tier.Limits()
+		tier := &tierResponse{Name: report.Tier.Name(), Limits: limitsResponse{Daily: limits.Daily, Monthly: limits.Monthly}}
+		for _, route := range report.Tier.Routes() {
+			if tier.Routes == nil {
+				tier.Routes = map[string]limitsResponse{}
+			}
+			routeLimits, _ := report.Tier.RouteLimits(route)
+			tier.Routes[route] = limitsResponse{Daily: routeLimits.Daily, Monthly: routeLimits.Monthly}
+		}
+		resp.Tier = tier
+	}
+
+	if a := report.Assignment; a != nil {
+		assignment := &assignmentResponse{Source: string(a.Source), ContractRef: a.ContractRef, StartsAt: a.StartsAt.Format(time.RFC3339)}
+		if a.EndsAt != nil {
+			ends := a.EndsAt.Format(time.RFC3339)
+			assignment.EndsAt = &ends
+		}
+		resp.Assignment = assignment
+	}
+
+	for _, s := range report.Current {
+		resp.Current = append(resp.Current, statusResponse{
+			Route:   s.Route,
+			Period:  string(s.Period),
+			Used:    s.Used,
+			Limit:   s.Limit,
+			Level:   string(s.Level),
+			ResetAt: s.ResetAt.Format(time.RFC3339),
+		})
+	}
+	for _, u := range report.History {
+		resp.History = append(resp.History, usageResponse{
+			Window:   u.Key.WindowStart.Format(dateLayout),
+			APIKeyID: u.Key.APIKeyID,
+			Route:    u.Key.Route,
+			Count:    u.Count,
+		})
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,111 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type fakeReports struct {
+	accountID string
+	period    quota.Period
+	from, to  time.Time
+	report    *app.Report
+	err       error
+}
+
+func (f *fakeReports) Report(ctx context.Context, accountID string, period quota.Period, from, to, at time.Time) (*app.Report, error) {
+	f.accountID, f.period, f.from, f.to = accountID, period, from, to
+	return f.report, f.err
+}
+
+func testReport() *app.Report {
+	tier, _ := quota.NewTier("partner", quota.Limits{Daily: 1000}, map[string]quota.Limits{"search": {Daily: 100}})
+	window := time.Date(2025, time.May, 9, 0, 0, 0, 0, time.UTC)
+	return &app.Report{
+		AccountID: "acc-1",
+		Tier:      tier,
+		Current: []app.Status{
+			{Period: quota.PeriodDaily, Used: 850, Limit: 1000, Level: quota.LevelWarning, ResetAt: window.AddDate(0, 0, 2)},
+		},
+		History: []quota.Usage{
+			{Key: quota.CounterKey{AccountID: "acc-1", APIKeyID: "key-1", Period: quota.PeriodDaily, WindowStart: window}, Count: 420},
+		},
+	}
+}
+
+func TestHandler_PartnerUsage(t *testing.T) {
+	reports := &fakeReports{report: testReport()}
+	partner := func(r *http.Request) (string, bool) { return "acc-1", r.Header.Get("X-API-Key") != "" }
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(reports, partner))
+
+	req := httptest.NewRequest(http.MethodGet, "/partner/usage?period=daily&from=2025-05-01&to=2025-05-09", nil)
+	req.Header.Set("X-API-Key", "key-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !reports.from.Equal(time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)) || !reports.to.Equal(time.Date(2025, time.May, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected inclusive date range, got %v to %v", reports.from, reports.to)
+	}
+
+	var resp reportResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Tier == nil || resp.Tier.Routes["search"].Daily != 100 {
+		t.Errorf("expected tier with search limits, got %+v", resp.Tier)
+	}
+	if len(resp.Current) != 1 || resp.Current[0].Level != "warning" {
+		t.Errorf("expected current warning status, got %+v", resp.Current)
+	}
+	if len(resp.History) != 1 || resp.History[0].Window != "2025-05-09" || resp.History[0].APIKeyID != "key-1" {
+		t.Errorf("unexpected history %+v", resp.History)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/partner/usage", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+}
+
+func TestHandler_AccountUsage(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+	}{
+		{"ok", "/admin/quotas/acc-9/usage?period=monthly", nil, http.StatusOK},
+		{"invalid date", "/admin/quotas/acc-9/usage?from=yesterday", nil, http.StatusBadRequest},
+		{"invalid period", "/admin/quotas/acc-9/usage?period=weekly", quota.ErrInvalidPeriod, http.StatusBadRequest},
+		{"range too long", "/admin/quotas/acc-9/usage?from=2020-01-01", app.ErrInvalidReportRange, http.StatusBadRequest},
+		{"store failure", "/admin/quotas/acc-9/usage", errors.New("connection reset"), http.StatusInternalServerError},
+		{"report timed out", "/admin/quotas/acc-9/usage", &shared.TimeoutError{Operation: "quota.FindHistory", Kind: shared.OperationReport, Limit: 10 * time.Second}, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reports := &fakeReports{report: testReport(), err: tc.err}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(reports)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedStatus == http.StatusOK && (reports.accountID != "acc-9" || reports.period != quota.PeriodMonthly) {
+				t.Errorf("expected monthly report for acc-9, got %s %s", reports.accountID, reports.period)
+			}
+		})
+	}
+}
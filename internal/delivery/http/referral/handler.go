@@ -0,0 +1,155 @@
+package referral
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/referral"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/middleware"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/referral"
+)
+
+const (
+	// RefCookie carries the code from the share link to the signup form
+	RefCookie = "ref"
+	// DeviceCookie is the anonymous visitor ID set by the consent banner, reused as the device signal
+	DeviceCookie = "visitor_id"
+	// refCookieMaxAge keeps the attribution for a week of browsing before signup
+	refCookieMaxAge = 7 * 24 * 60 * 60
+)
+
+// MemberResolver returns the signed-in member
+type MemberResolver func(r *http.Request) (string, bool)
+
+// Referrals is the part of the referral service the endpoints need
+type Referrals interface {
+	Status(ctx context.Context, accountID string, fingerprint referral.Fingerprint, at time.Time) (*app.Status, error)
+}
+
+type referralItemResponse struct {
+	Status     string  `json:"status"` // pending, rewarded or held
+	SignedUpAt string  `json:"signed_up_at"`
+	RewardedAt *string `json:"rewarded_at,omitempty"`
+}
+
+type statusResponse struct {
+	Code       string                 `json:"code"`
+	Link       string                 `json:"link"`
+	Pending    int                    `json:"pending"`
+	Rewarded   int                    `json:"rewarded"`
+	Held       int                    `json:"held"`
+	DaysEarned int                    `json:"days_earned"`
+	Referrals  []referralItemResponse `json:"referrals"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	referrals Referrals
+	member    MemberResolver
+	signupURL string
+}
+
+func NewHandler(referrals Referrals, member MemberResolver, signupURL string) *Handler {
+	return &Handler{referrals: referrals, member: member, signupURL: signupURL}
+}
+
+// NewRouter mounts the share link landing and the member's referral status
+func NewRouter(referrals Referrals, member MemberResolver, signupURL string) http.Handler {
+	h := NewHandler(referrals, member, signupURL)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /r/{code}", h.Landing)
+	mux.HandleFunc("GET /referrals/me", h.Status)
+	return mux
+}
+
+// Landing remembers a well-formed code and sends the visitor to signup, unknown
+// codes are only rejected at attribution so the link never shows an error page
+func (h *Handler) Landing(w http.ResponseWriter, r *http.Request) {
+	target := h.signupURL
+	if code, err := referral.NewCode(r.PathValue("code")); err == nil {
+		http.SetCookie(w, &http.Cookie{
+			Name:     RefCookie,
+			Value:    code.Value(),
+			Path:     "/",
+			MaxAge:   refCookieMaxAge,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		target += "?" + url.Values{"ref": {code.Value()}}.Encode()
+	}
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+func (h *Handler) Status(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in required"})
+		return
+	}
+
+	status, err := h.referrals.Status(r.Context(), accountID, Fingerprint(r), time.Now())
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrNotEligible):
+			writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+		case shared.IsTimeout(err):
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to load referrals"})
+		}
+		return
+	}
+
+	resp := statusResponse{
+		Code:       status.Code.Value(),
+		Link:       status.Link,
+		Pending:    status.Pending,
+		Rewarded:   status.Rewarded,
+		Held:       status.Held,
+		DaysEarned: status.DaysEarned,
+		Referrals:  make([]referralItemResponse, 0, len(status.Referrals)),
+	}
+	for _, ref := range status.Referrals {
+		resp.Referrals = append(resp.Referrals, toReferralItem(ref))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Fingerprint reads the fraud signals of a request, signup calls this before attributing
+func Fingerprint(r *http.Request) referral.Fingerprint {
+	f := referral.Fingerprint{IPAddress: middleware.ClientIP(r)}
+	if cookie, err := r.Cookie(DeviceCookie); err == nil {
+		f.DeviceID = cookie.Value
+	}
+	return f
+}
+
+// toReferralItem leaves out who was referred and why a referral is held,
+// fraud reasons would tell abusers which check they tripped
+func toReferralItem(ref *referral.Referral) referralItemResponse {
+	item := referralItemResponse{Status: "held", SignedUpAt: ref.CreatedAt.Format(time.RFC3339)}
+	switch ref.Status {
+	case referral.StatusPending:
+		item.Status = "pending"
+	case referral.StatusRewarded:
+		item.Status = "rewarded"
+		at := ref.RewardedAt.Format(time.RFC3339)
+		item.RewardedAt = &at
+	}
+	return item
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,112 @@
+package referral
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/referral"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/referral"
+)
+
+type fakeReferrals struct {
+	fingerprint referral.Fingerprint
+	err         error
+}
+
+func (f *fakeReferrals) Status(ctx context.Context, accountID string, fingerprint referral.Fingerprint, at time.Time) (*app.Status, error) {
+	f.fingerprint = fingerprint
+	if f.err != nil {
+		return nil, f.err
+	}
+	code, _ := referral.NewCode("K7QM4XPA")
+	rewardedAt := time.Date(2025, time.September, 2, 9, 0, 0, 0, time.UTC)
+	reason := referral.FlagCluster
+	return &app.Status{
+		Code:       *code,
+		Link:       "https://news.example.com/r/K7QM4XPA",
+		Rewarded:   1,
+		Held:       1,
+		DaysEarned: referral.RewardDays,
+		Referrals: []*referral.Referral{
+			{RefereeID: "reader1", Status: referral.StatusRewarded, RewardDays: referral.RewardDays, RewardedAt: &rewardedAt},
+			{RefereeID: "reader2", Status: referral.StatusFlagged, FlagReason: &reason},
+		},
+	}, nil
+}
+
+func member(r *http.Request) (string, bool) {
+	return "member1", r.Header.Get("Authorization") != ""
+}
+
+func TestHandler_Landing(t *testing.T) {
+	testCases := []struct {
+		name             string
+		path             string
+		expectedLocation string
+		expectCookie     bool
+	}{
+		{"valid code", "/r/k7qm4xpa", "/register?ref=K7QM4XPA", true},
+		{"malformed code", "/r/nope", "/register", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakeReferrals{}, member, "/register")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if rec.Code != http.StatusFound || rec.Header().Get("Location") != tc.expectedLocation {
+				t.Errorf("expected redirect to %s, got %d %s", tc.expectedLocation, rec.Code, rec.Header().Get("Location"))
+			}
+			if got := len(rec.Result().Cookies()) == 1; got != tc.expectCookie {
+				t.Errorf("expected cookie=%v, got %v", tc.expectCookie, rec.Result().Cookies())
+			}
+		})
+	}
+}
+
+func TestHandler_Status(t *testing.T) {
+	referrals := &fakeReferrals{}
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(referrals, member, "/register"))
+
+	req := httptest.NewRequest(http.MethodGet, "/referrals/me", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.AddCookie(&http.Cookie{Name: DeviceCookie, Value: "device-1"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if referrals.fingerprint.IPAddress != "203.0.113.7" || referrals.fingerprint.DeviceID != "device-1" {
+		t.Errorf("unexpected fingerprint %+v", referrals.fingerprint)
+	}
+
+	var resp statusResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Code != "K7QM4XPA" || resp.DaysEarned != referral.RewardDays || len(resp.Referrals) != 2 {
+		t.Errorf("unexpected status %+v", resp)
+	}
+	if resp.Referrals[1].Status != "held" {
+		t.Errorf("expected flagged referral to show as held, got %s", resp.Referrals[1].Status)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/referrals/me", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/referrals/me", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakeReferrals{err: app.ErrNotEligible}, member, "/register")).ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", rec.Code)
+	}
+}
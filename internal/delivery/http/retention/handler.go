@@ -0,0 +1,242 @@
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/retention"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/retention"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+const (
+	defaultRunLimit = 50
+	maxRunLimit     = 500
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Retention is the part of the retention engine the admin endpoints need.
+// Real runs only come from the scheduled job, the endpoints can dry-run.
+type Retention interface {
+	Policies() []retention.Policy
+	Run(ctx context.Context, dryRun bool, at time.Time) ([]*retention.Run, error)
+	Reports(ctx context.Context, limit int) ([]*retention.Run, error)
+	Holds(ctx context.Context) ([]*retention.Hold, error)
+	PlaceHold(ctx context.Context, staffID string, category retention.Category, subjectID *string, reason string, at time.Time) (*retention.Hold, error)
+	ReleaseHold(ctx context.Context, staffID, holdID string, at time.Time) (*retention.Hold, error)
+}
+
+type holdRequest struct {
+	Category  string  `json:"category"`
+	SubjectID *string `json:"subject_id"`
+	Reason    string  `json:"reason"`
+}
+
+type policyResponse struct {
+	Category string `json:"category"`
+	Keep     string `json:"keep"`
+	Minimum  string `json:"minimum"`
+	Reason   string `json:"reason"`
+}
+
+type runResponse struct {
+	ID         string  `json:"id"`
+	Category   string  `json:"category"`
+	DryRun     bool    `json:"dry_run"`
+	Keep       string  `json:"keep"`
+	Cutoff     string  `json:"cutoff"`
+	Expired    int     `json:"expired"`
+	Held       int     `json:"held"`
+	Purged     int     `json:"purged"`
+	HeldAll    bool    `json:"held_all"`
+	Error      *string `json:"error,omitempty"`
+	StartedAt  string  `json:"started_at"`
+	FinishedAt string  `json:"finished_at"`
+}
+
+type holdResponse struct {
+	ID         string  `json:"id"`
+	Category   string  `json:"category"`
+	SubjectID  *string `json:"subject_id,omitempty"`
+	Reason     string  `json:"reason"`
+	PlacedBy   string  `json:"placed_by"`
+	PlacedAt   string  `json:"placed_at"`
+	ReleasedBy *string `json:"released_by,omitempty"`
+	ReleasedAt *string `json:"released_at,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	retention Retention
+	staff     StaffResolver
+}
+
+func NewHandler(retention Retention, staff StaffResolver) *Handler {
+	return &Handler{retention: retention, staff: staff}
+}
+
+// NewAdminRouter mounts retention reports and legal holds, it must sit behind admin authentication
+func NewAdminRouter(retention Retention, staff StaffResolver) http.Handler {
+	h := NewHandler(retention, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/retention/policies", h.Policies)
+	mux.HandleFunc("POST /admin/retention/dry-run", h.DryRun)
+	mux.HandleFunc("GET /admin/retention/runs", h.Runs)
+	mux.HandleFunc("GET /admin/retention/holds", h.Holds)
+	mux.HandleFunc("POST /admin/retention/holds", h.PlaceHold)
+	mux.HandleFunc("POST /admin/retention/holds/{id}/release", h.ReleaseHold)
+	return mux
+}
+
+func (h *Handler) Policies(w http.ResponseWriter, r *http.Request) {
+	policies := h.retention.Policies()
+	resp := make([]policyResponse, 0, len(policies))
+	for _, p := range policies {
+		resp = append(resp, policyResponse{Category: string(p.Category), Keep: p.Keep.String(), Minimum: p.Minimum.String(), Reason: p.Reason})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// DryRun reports what a run would purge now. Category failures are part of
+// the report, so partial results still come back with 200.
+func (h *Handler) DryRun(w http.ResponseWriter, r *http.Request) {
+	runs, err := h.retention.Run(r.Context(), true, time.Now())
+	if err != nil && len(runs) == 0 {
+		writeError(w, err)
+		return
+	}
+	writeRuns(w, runs)
+}
+
+func (h *Handler) Runs(w http.ResponseWriter, r *http.Request) {
+	limit := defaultRunLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit"})
+			return
+		}
+		limit = min(n, maxRunLimit)
+	}
+	runs, err := h.retention.Reports(r.Context(), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeRuns(w, runs)
+}
+
+func (h *Handler) Holds(w http.ResponseWriter, r *http.Request) {
+	holds, err := h.retention.Holds(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]holdResponse, 0, len(holds))
+	for _, hold := range holds {
+		resp = append(resp, toHoldResponse(hold))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PlaceHold(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req holdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	hold, err := h.retention.PlaceHold(r.Context(), staffID, retention.Category(req.Category), req.SubjectID, req.Reason, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toHoldResponse(hold))
+}
+
+func (h *Handler) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	hold, err := h.retention.ReleaseHold(r.Context(), staffID, r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toHoldResponse(hold))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrHoldNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, retention.ErrHoldReleased):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, retention.ErrUnknownCategory), errors.Is(err, retention.ErrEmptyReason):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeRuns(w http.ResponseWriter, runs []*retention.Run) {
+	resp := make([]runResponse, 0, len(runs))
+	for _, run := range runs {
+		resp = append(resp, runResponse{
+			ID:         run.ID,
+			Category:   string(run.Category),
+			DryRun:     run.DryRun,
+			Keep:       run.Keep.String(),
+			Cutoff:     run.Cutoff.Format(time.RFC3339),
+			Expired:    run.Expired,
+			Held:       run.Held,
+			Purged:     run.Purged,
+			HeldAll:    run.HeldAll,
+			Error:      run.Error,
+			StartedAt:  run.StartedAt.Format(time.RFC3339),
+			FinishedAt: run.FinishedAt.Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toHoldResponse(h *retention.Hold) holdResponse {
+	resp := holdResponse{
+		ID:         h.ID,
+		Category:   string(h.Category),
+		SubjectID:  h.SubjectID,
+		Reason:     h.Reason,
+		PlacedBy:   h.PlacedBy,
+		PlacedAt:   h.PlacedAt.Format(time.RFC3339),
+		ReleasedBy: h.ReleasedBy,
+	}
+	if h.ReleasedAt != nil {
+		at := h.ReleasedAt.Format(time.RFC3339)
+		resp.ReleasedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
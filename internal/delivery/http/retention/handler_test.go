@@ -0,0 +1,100 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/retention"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/retention"
+)
+
+type fakeRetention struct {
+	err error
+}
+
+func (f *fakeRetention) Policies() []retention.Policy {
+	return retention.DefaultPolicies()
+}
+
+func (f *fakeRetention) Run(ctx context.Context, dryRun bool, at time.Time) ([]*retention.Run, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*retention.Run{retention.NewRun("r1", retention.DefaultPolicies()[0], dryRun, at)}, nil
+}
+
+func (f *fakeRetention) Reports(ctx context.Context, limit int) ([]*retention.Run, error) {
+	return f.Run(ctx, false, time.Now())
+}
+
+func (f *fakeRetention) Holds(ctx context.Context) ([]*retention.Hold, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	hold, _ := retention.NewHold("h1", retention.CategoryAuditLog, nil, "Regulator inquiry", "legal-1", time.Now())
+	return []*retention.Hold{hold}, nil
+}
+
+func (f *fakeRetention) PlaceHold(ctx context.Context, staffID string, category retention.Category, subjectID *string, reason string, at time.Time) (*retention.Hold, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return retention.NewHold("h1", category, subjectID, reason, staffID, at)
+}
+
+func (f *fakeRetention) ReleaseHold(ctx context.Context, staffID, holdID string, at time.Time) (*retention.Hold, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	hold, _ := retention.NewHold(holdID, retention.CategoryAuditLog, nil, "Regulator inquiry", "legal-1", at)
+	return hold, hold.Release(staffID, at)
+}
+
+func TestHandler(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "legal-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"policies", http.MethodGet, "/admin/retention/policies", "", true, nil, http.StatusOK},
+		{"dry run", http.MethodPost, "/admin/retention/dry-run", "", true, nil, http.StatusOK},
+		{"runs", http.MethodGet, "/admin/retention/runs?limit=10", "", true, nil, http.StatusOK},
+		{"runs invalid limit", http.MethodGet, "/admin/retention/runs?limit=0", "", true, nil, http.StatusBadRequest},
+		{"holds", http.MethodGet, "/admin/retention/holds", "", true, nil, http.StatusOK},
+		{"place hold", http.MethodPost, "/admin/retention/holds", `{"category":"login_attempts","subject_id":"account-1","reason":"Case 12/2025"}`, true, nil, http.StatusCreated},
+		{"place hold without session", http.MethodPost, "/admin/retention/holds", `{}`, false, nil, http.StatusUnauthorized},
+		{"place hold invalid body", http.MethodPost, "/admin/retention/holds", `{`, true, nil, http.StatusBadRequest},
+		{"place hold unknown category", http.MethodPost, "/admin/retention/holds", `{"category":"comments","reason":"Case"}`, true, nil, http.StatusUnprocessableEntity},
+		{"release", http.MethodPost, "/admin/retention/holds/h1/release", "", true, nil, http.StatusOK},
+		{"release missing", http.MethodPost, "/admin/retention/holds/h2/release", "", true, app.ErrHoldNotFound, http.StatusNotFound},
+		{"release twice", http.MethodPost, "/admin/retention/holds/h1/release", "", true, retention.ErrHoldReleased, http.StatusConflict},
+		{"store failure", http.MethodGet, "/admin/retention/holds", "", true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeRetention{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
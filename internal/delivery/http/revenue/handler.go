@@ -0,0 +1,151 @@
+package revenue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/revenue"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/revenue"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// defaultDays is the report range when no dates are given, ending yesterday
+const defaultDays = 30
+
+// Reports is the part of the revenue service the reporting API needs, the
+// numbers themselves come from the scheduled aggregation job
+type Reports interface {
+	Report(ctx context.Context, by revenue.Dimension, from, to time.Time, limit int) (*app.Report, error)
+}
+
+type lineResponse struct {
+	Key                 string  `json:"key"`
+	Articles            int     `json:"articles"`
+	Pageviews           int64   `json:"pageviews"`
+	AdImpressions       int64   `json:"ad_impressions"`
+	AdRevenue           int64   `json:"ad_revenue"`
+	SubscriptionRevenue int64   `json:"subscription_revenue"`
+	Total               int64   `json:"total"`
+	Conversions         float64 `json:"conversions"`
+	RPM                 float64 `json:"rpm"`
+}
+
+type reportResponse struct {
+	By       string         `json:"by"`
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Currency string         `json:"currency"`
+	Total    lineResponse   `json:"total"`
+	Lines    []lineResponse `json:"lines"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	reports Reports
+}
+
+func NewHandler(reports Reports) *Handler {
+	return &Handler{reports: reports}
+}
+
+// NewAdminRouter mounts the revenue reports for the business team, it must sit behind admin authentication
+func NewAdminRouter(reports Reports) http.Handler {
+	h := NewHandler(reports)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/reports/revenue/articles", h.report(revenue.DimensionArticle))
+	mux.HandleFunc("GET /admin/reports/revenue/authors", h.report(revenue.DimensionAuthor))
+	mux.HandleFunc("GET /admin/reports/revenue/desks", h.report(revenue.DimensionDesk))
+	return mux
+}
+
+// report serves one dimension. Dates are inclusive UTC days, amounts are in
+// minor units of the reporting currency.
+func (h *Handler) report(by revenue.Dimension) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+		to, err := dateParam(r.URL.Query().Get("to"), yesterday)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "to must be a date like 2026-03-31"})
+			return
+		}
+		from, err := dateParam(r.URL.Query().Get("from"), to.AddDate(0, 0, 1-defaultDays))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "from must be a date like 2026-03-01"})
+			return
+		}
+		limit := 0
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit"})
+				return
+			}
+			limit = n
+		}
+
+		report, err := h.reports.Report(r.Context(), by, from, to.AddDate(0, 0, 1), limit)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		resp := reportResponse{
+			By:       string(report.By),
+			From:     report.From.Format(time.DateOnly),
+			To:       report.To.AddDate(0, 0, -1).Format(time.DateOnly),
+			Currency: report.Currency,
+			Total:    toLineResponse(report.Total),
+			Lines:    make([]lineResponse, 0, len(report.Lines)),
+		}
+		for _, line := range report.Lines {
+			resp.Lines = append(resp.Lines, toLineResponse(line))
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func toLineResponse(l revenue.Line) lineResponse {
+	return lineResponse{
+		Key:                 l.Key,
+		Articles:            l.Articles,
+		Pageviews:           l.Pageviews,
+		AdImpressions:       l.AdImpressions,
+		AdRevenue:           l.AdRevenue.Amount(),
+		SubscriptionRevenue: l.SubscriptionRevenue.Amount(),
+		Total:               l.Total.Amount(),
+		Conversions:         l.Conversions,
+		RPM:                 l.RPM(),
+	}
+}
+
+func dateParam(raw string, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, analytics.ErrInvalidRange), errors.Is(err, analytics.ErrRangeTooLong), errors.Is(err, revenue.ErrInvalidDimension):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
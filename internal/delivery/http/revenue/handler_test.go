@@ -0,0 +1,114 @@
+package revenue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/revenue"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/revenue"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+)
+
+type fakeReports struct {
+	err   error
+	by    revenue.Dimension
+	from  time.Time
+	to    time.Time
+	limit int
+}
+
+func (f *fakeReports) Report(ctx context.Context, by revenue.Dimension, from, to time.Time, limit int) (*app.Report, error) {
+	f.by, f.from, f.to, f.limit = by, from, to, limit
+	if f.err != nil {
+		return nil, f.err
+	}
+	days := []*revenue.ArticleDay{}
+	for _, id := range []string{"a", "b"} {
+		d, _ := revenue.NewArticleDay(from, id, "IDR", from)
+		d.AuthorID, d.Desk, d.Pageviews = "alice", "politics", 1000
+		days = append(days, d)
+	}
+	lines, total, err := revenue.Rollup(days, by, "IDR")
+	if err != nil {
+		return nil, err
+	}
+	return &app.Report{By: by, From: from, To: to, Currency: "IDR", Lines: lines, Total: total}, nil
+}
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+		expectedBy     revenue.Dimension
+	}{
+		{"articles", "/admin/reports/revenue/articles?from=2026-03-01&to=2026-03-31&limit=10", nil, http.StatusOK, revenue.DimensionArticle},
+		{"authors", "/admin/reports/revenue/authors", nil, http.StatusOK, revenue.DimensionAuthor},
+		{"desks", "/admin/reports/revenue/desks?from=2026-03-01", nil, http.StatusOK, revenue.DimensionDesk},
+		{"invalid from", "/admin/reports/revenue/desks?from=March", nil, http.StatusBadRequest, ""},
+		{"invalid to", "/admin/reports/revenue/desks?to=31-03-2026", nil, http.StatusBadRequest, ""},
+		{"invalid limit", "/admin/reports/revenue/desks?limit=-1", nil, http.StatusBadRequest, ""},
+		{"unknown dimension", "/admin/reports/revenue/sections", nil, http.StatusNotFound, ""},
+		{"range too long", "/admin/reports/revenue/desks", analytics.ErrRangeTooLong, http.StatusUnprocessableEntity, revenue.DimensionDesk},
+		{"inverted range", "/admin/reports/revenue/desks", analytics.ErrInvalidRange, http.StatusUnprocessableEntity, revenue.DimensionDesk},
+		{"store failure", "/admin/reports/revenue/desks", errors.New("connection reset"), http.StatusInternalServerError, revenue.DimensionDesk},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reports := &fakeReports{err: tc.err}
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(reports)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if reports.by != tc.expectedBy {
+				t.Errorf("expected dimension %q, got %q", tc.expectedBy, reports.by)
+			}
+		})
+	}
+}
+
+func TestHandler_InclusiveDates(t *testing.T) {
+	reports := &fakeReports{}
+	req := httptest.NewRequest(http.MethodGet, "/admin/reports/revenue/articles?from=2026-03-01&to=2026-03-31&limit=1", nil)
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(reports)).ServeHTTP(rec, req)
+
+	if !reports.from.Equal(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)) || !reports.to.Equal(time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the to date to be inclusive, got %v to %v", reports.from, reports.to)
+	}
+	if reports.limit != 1 {
+		t.Errorf("expected limit 1, got %d", reports.limit)
+	}
+
+	var body struct {
+		From     string `json:"from"`
+		To       string `json:"to"`
+		Currency string `json:"currency"`
+		Total    struct {
+			Articles  int     `json:"articles"`
+			Pageviews int64   `json:"pageviews"`
+			RPM       float64 `json:"rpm"`
+		} `json:"total"`
+		Lines []json.RawMessage `json:"lines"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.From != "2026-03-01" || body.To != "2026-03-31" || body.Currency != "IDR" {
+		t.Errorf("unexpected report range %+v", body)
+	}
+	if body.Total.Articles != 2 || body.Total.Pageviews != 2000 || len(body.Lines) != 2 {
+		t.Errorf("unexpected totals %+v", body)
+	}
+}
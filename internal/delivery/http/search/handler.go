@@ -0,0 +1,166 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+const (
+	defaultRunLimit = 20
+	maxRunLimit     = 100
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Reindexer is the part of the reindex service the admin endpoints need. The
+// rebuild itself runs in the worker, the endpoints queue it and report progress.
+type Reindexer interface {
+	Request(ctx context.Context, staffID string) (*search.ReindexRun, error)
+	Run(ctx context.Context, id string) (*search.ReindexRun, error)
+	Recent(ctx context.Context, limit int) ([]*search.ReindexRun, error)
+}
+
+type runResponse struct {
+	ID          string  `json:"id"`
+	Alias       string  `json:"alias"`
+	Index       string  `json:"index,omitempty"`
+	Previous    string  `json:"previous,omitempty"`
+	Status      string  `json:"status"`
+	Progress    float64 `json:"progress"`
+	Total       int     `json:"total"`
+	Indexed     int     `json:"indexed"`
+	Failed      int     `json:"failed"`
+	Sampled     int     `json:"sampled"`
+	Mismatches  int     `json:"mismatches"`
+	Error       *string `json:"error,omitempty"`
+	RequestedBy string  `json:"requested_by"`
+	RequestedAt string  `json:"requested_at"`
+	StartedAt   *string `json:"started_at,omitempty"`
+	FinishedAt  *string `json:"finished_at,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	reindexer Reindexer
+	staff     StaffResolver
+}
+
+func NewHandler(reindexer Reindexer, staff StaffResolver) *Handler {
+	return &Handler{reindexer: reindexer, staff: staff}
+}
+
+// NewAdminRouter mounts search index rebuilds, it must sit behind admin authentication
+func NewAdminRouter(reindexer Reindexer, staff StaffResolver) http.Handler {
+	h := NewHandler(reindexer, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/search/reindex", h.Request)
+	mux.HandleFunc("GET /admin/search/reindex", h.Runs)
+	mux.HandleFunc("GET /admin/search/reindex/{id}", h.Run)
+	return mux
+}
+
+// Request queues a rebuild and answers 202, progress is polled from the run
+func (h *Handler) Request(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	run, err := h.reindexer.Request(r.Context(), staffID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, toRunResponse(run))
+}
+
+func (h *Handler) Runs(w http.ResponseWriter, r *http.Request) {
+	limit := defaultRunLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit"})
+			return
+		}
+		limit = min(n, maxRunLimit)
+	}
+	runs, err := h.reindexer.Recent(r.Context(), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]runResponse, 0, len(runs))
+	for _, run := range runs {
+		resp = append(resp, toRunResponse(run))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Run(w http.ResponseWriter, r *http.Request) {
+	run, err := h.reindexer.Run(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toRunResponse(run))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrRunNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, search.ErrReindexRunning):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toRunResponse(run *search.ReindexRun) runResponse {
+	return runResponse{
+		ID:          run.ID,
+		Alias:       run.Alias,
+		Index:       run.Index,
+		Previous:    run.Previous,
+		Status:      string(run.Status),
+		Progress:    run.Progress(),
+		Total:       run.Total,
+		Indexed:     run.Indexed,
+		Failed:      run.Failed,
+		Sampled:     run.Sampled,
+		Mismatches:  run.Mismatches,
+		Error:       run.LastError,
+		RequestedBy: run.RequestedBy,
+		RequestedAt: run.RequestedAt.Format(time.RFC3339),
+		StartedAt:   formatTime(run.StartedAt),
+		FinishedAt:  formatTime(run.FinishedAt),
+	}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+)
+
+type fakeReindexer struct {
+	err error
+}
+
+func (f *fakeReindexer) Request(ctx context.Context, staffID string) (*search.ReindexRun, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return search.NewReindexRun("r1", app.ArticlesAlias, staffID, time.Now())
+}
+
+func (f *fakeReindexer) Run(ctx context.Context, id string) (*search.ReindexRun, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	run, _ := search.NewReindexRun(id, app.ArticlesAlias, "staff-1", time.Now())
+	_ = run.Start("articles-20250901t020000", "articles-20250801t020000", 200, time.Now())
+	run.RecordBatch(50, 0, time.Now())
+	return run, nil
+}
+
+func (f *fakeReindexer) Recent(ctx context.Context, limit int) ([]*search.ReindexRun, error) {
+	run, err := f.Run(ctx, "r1")
+	if err != nil {
+		return nil, err
+	}
+	return []*search.ReindexRun{run}, nil
+}
+
+func TestHandler(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "staff-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		auth           bool
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"request", http.MethodPost, "/admin/search/reindex", true, nil, http.StatusAccepted, `"status":"pending"`},
+		{"request without session", http.MethodPost, "/admin/search/reindex", false, nil, http.StatusUnauthorized, "staff session required"},
+		{"request while running", http.MethodPost, "/admin/search/reindex", true, search.ErrReindexRunning, http.StatusConflict, "already running"},
+		{"progress", http.MethodGet, "/admin/search/reindex/r1", true, nil, http.StatusOK, `"progress":0.25`},
+		{"missing run", http.MethodGet, "/admin/search/reindex/r2", true, app.ErrRunNotFound, http.StatusNotFound, "not found"},
+		{"runs", http.MethodGet, "/admin/search/reindex?limit=5", true, nil, http.StatusOK, `"indexed":50`},
+		{"runs invalid limit", http.MethodGet, "/admin/search/reindex?limit=0", true, nil, http.StatusBadRequest, "invalid limit"},
+		{"store failure", http.MethodGet, "/admin/search/reindex", true, errors.New("connection reset"), http.StatusInternalServerError, "something went wrong"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, nil)
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeReindexer{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tc.expectedBody) {
+				t.Errorf("expected body to contain %q, got %s", tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Searcher answers reader search
+type Searcher interface {
+	Search(ctx context.Context, language, query string, limit, offset int) (*app.Results, error)
+}
+
+type hitResponse struct {
+	ID          string  `json:"id"`
+	Slug        string  `json:"slug"`
+	Title       string  `json:"title"`
+	Summary     string  `json:"summary,omitempty"`
+	PublishedAt string  `json:"published_at"`
+	Score       float64 `json:"score"`
+}
+
+type resultsResponse struct {
+	Query          string        `json:"query"`
+	CorrectedQuery string        `json:"corrected_query,omitempty"` // "Showing results for", the original found nothing
+	DidYouMean     string        `json:"did_you_mean,omitempty"`
+	Items          []hitResponse `json:"items"`
+	Total          int           `json:"total"`
+	Limit          int           `json:"limit"`
+	Offset         int           `json:"offset"`
+}
+
+type QueryHandler struct {
+	searcher Searcher
+}
+
+func NewQueryHandler(searcher Searcher) *QueryHandler {
+	return &QueryHandler{searcher: searcher}
+}
+
+// NewSearchRouter mounts reader search
+func NewSearchRouter(searcher Searcher) http.Handler {
+	h := NewQueryHandler(searcher)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /search", h.Search)
+	return mux
+}
+
+// Search handles GET /search?q=banjir+jakarta&lang=id&limit=10&offset=0
+func (h *QueryHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, ok := queryInt(q.Get("limit"), app.DefaultResults)
+	if !ok || limit < 1 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit"})
+		return
+	}
+	offset, ok := queryInt(q.Get("offset"), 0)
+	if !ok || offset < 0 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid offset"})
+		return
+	}
+	limit = min(limit, app.MaxResults)
+
+	results, err := h.searcher.Search(r.Context(), q.Get("lang"), q.Get("q"), limit, offset)
+	if err != nil {
+		if shared.IsTimeout(err) {
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+
+	resp := resultsResponse{
+		Query:          results.Query,
+		CorrectedQuery: results.CorrectedQuery,
+		DidYouMean:     results.DidYouMean,
+		Items:          make([]hitResponse, 0, len(results.Hits)),
+		Total:          results.Total,
+		Limit:          limit,
+		Offset:         offset,
+	}
+	for _, hit := range results.Hits {
+		resp.Items = append(resp.Items, hitResponse{
+			ID:          hit.ID,
+			Slug:        hit.Slug,
+			Title:       hit.Title,
+			Summary:     hit.Summary,
+			PublishedAt: hit.PublishedAt.Format(time.RFC3339),
+			Score:       hit.Score,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func queryInt(raw string, fallback int) (int, bool) {
+	if raw == "" {
+		return fallback, true
+	}
+	n, err := strconv.Atoi(raw)
+	return n, err == nil
+}
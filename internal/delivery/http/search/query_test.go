@@ -0,0 +1,65 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+)
+
+type fakeSearcher struct {
+	language      string
+	limit, offset int
+	err           error
+}
+
+func (f *fakeSearcher) Search(ctx context.Context, language, query string, limit, offset int) (*app.Results, error) {
+	f.language, f.limit, f.offset = language, limit, offset
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.Results{
+		Query:          query,
+		CorrectedQuery: "banjir jakarta",
+		Hits:           []app.Hit{{ID: "a1", Slug: "banjir-rendam-jakarta", Title: "Banjir rendam Jakarta", PublishedAt: time.Now()}},
+		Total:          1,
+	}, nil
+}
+
+func TestQueryHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+		expectedLimit  int
+		expectedBody   string
+	}{
+		{"corrected", "/search?q=bnajir+jakarta&lang=id", nil, http.StatusOK, app.DefaultResults, `"corrected_query":"banjir jakarta"`},
+		{"limit capped", "/search?q=banjir&limit=500&offset=20", nil, http.StatusOK, app.MaxResults, `"slug":"banjir-rendam-jakarta"`},
+		{"invalid limit", "/search?q=banjir&limit=0", nil, http.StatusBadRequest, 0, "invalid limit"},
+		{"invalid offset", "/search?q=banjir&offset=-1", nil, http.StatusBadRequest, 0, "invalid offset"},
+		{"backend down", "/search?q=banjir", errors.New("cluster unavailable"), http.StatusInternalServerError, app.DefaultResults, "something went wrong"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			searcher := &fakeSearcher{err: tc.err}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewSearchRouter(searcher)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if searcher.limit != tc.expectedLimit || !strings.Contains(rec.Body.String(), tc.expectedBody) {
+				t.Errorf("expected limit %d answering %q, got %d: %s", tc.expectedLimit, tc.expectedBody, searcher.limit, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Suggester answers typeahead lookups
+type Suggester interface {
+	Suggest(ctx context.Context, kind app.SuggestionKind, query string, limit int) ([]app.Suggestion, error)
+}
+
+type suggestionResponse struct {
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+	Slug  string `json:"slug"`
+	Count int    `json:"count,omitempty"`
+}
+
+type SuggestHandler struct {
+	suggester Suggester
+}
+
+func NewSuggestHandler(suggester Suggester) *SuggestHandler {
+	return &SuggestHandler{suggester: suggester}
+}
+
+// NewSuggestRouter mounts the typeahead endpoints. limit wraps each route on
+// its own, e.g. middleware.RateLimit, so titles and tags have separate
+// allowances; nil leaves them unlimited.
+//
+//openapi:response 429 errorResponse
+func NewSuggestRouter(suggester Suggester, limit func(http.Handler) http.Handler) http.Handler {
+	h := NewSuggestHandler(suggester)
+	if limit == nil {
+		limit = func(next http.Handler) http.Handler { return next }
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /search/suggest/titles", limit(h.handle(app.SuggestTitles)))
+	mux.Handle("GET /search/suggest/tags", limit(h.handle(app.SuggestTags)))
+	mux.Handle("GET /search/suggest/authors", limit(h.handle(app.SuggestAuthors)))
+	return mux
+}
+
+// handle serves GET /search/suggest/{kind}?q=pemi&limit=8. Responses may be
+// cached briefly, the same prefix is typed by many readers at once.
+func (h *SuggestHandler) handle(kind app.SuggestionKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 0
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 {
+				writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid limit"})
+				return
+			}
+			limit = n
+		}
+
+		suggestions, err := h.suggester.Suggest(r.Context(), kind, r.URL.Query().Get("q"), limit)
+		if err != nil {
+			writeSuggestError(w, err)
+			return
+		}
+		resp := make([]suggestionResponse, 0, len(suggestions))
+		for _, s := range suggestions {
+			resp = append(resp, suggestionResponse{ID: s.ID, Text: s.Text, Slug: s.Slug, Count: s.Count})
+		}
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func writeSuggestError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrUnknownSuggestionKind):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
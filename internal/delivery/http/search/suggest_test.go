@@ -0,0 +1,78 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type fakeSuggester struct {
+	kind  app.SuggestionKind
+	query string
+	limit int
+	err   error
+}
+
+func (f *fakeSuggester) Suggest(ctx context.Context, kind app.SuggestionKind, query string, limit int) ([]app.Suggestion, error) {
+	f.kind, f.query, f.limit = kind, query, limit
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []app.Suggestion{{ID: "t1", Text: "Pemilu 2024", Slug: "pemilu-2024", Count: 120}}, nil
+}
+
+func TestSuggestHandler(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+		expectedKind   app.SuggestionKind
+		expectedBody   string
+	}{
+		{"tags", "/search/suggest/tags?q=pemi&limit=5", nil, http.StatusOK, app.SuggestTags, `"slug":"pemilu-2024"`},
+		{"titles", "/search/suggest/titles?q=pemi", nil, http.StatusOK, app.SuggestTitles, `"text":"Pemilu 2024"`},
+		{"authors", "/search/suggest/authors?q=budi", nil, http.StatusOK, app.SuggestAuthors, `"count":120`},
+		{"invalid limit", "/search/suggest/tags?q=pemi&limit=x", nil, http.StatusBadRequest, "", "invalid limit"},
+		{"over budget", "/search/suggest/titles?q=pemi", &shared.TimeoutError{Operation: "suggest titles", Err: context.DeadlineExceeded}, http.StatusGatewayTimeout, app.SuggestTitles, "request timed out"},
+		{"backend down", "/search/suggest/titles?q=pemi", errors.New("cluster unavailable"), http.StatusInternalServerError, app.SuggestTitles, "something went wrong"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			suggester := &fakeSuggester{err: tc.err}
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewSuggestRouter(suggester, nil)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if suggester.kind != tc.expectedKind || !strings.Contains(rec.Body.String(), tc.expectedBody) {
+				t.Errorf("expected a %q lookup answering %q, got %q: %s", tc.expectedKind, tc.expectedBody, suggester.kind, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSuggestHandler_Limited(t *testing.T) {
+	blocked := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusTooManyRequests, errorResponse{Error: "too many requests, slow down"})
+		})
+	}
+	suggester := &fakeSuggester{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewSuggestRouter(suggester, blocked)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search/suggest/tags?q=pemi", nil))
+
+	if rec.Code != http.StatusTooManyRequests || suggester.kind != "" {
+		t.Errorf("expected the limit applied before the lookup, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// SynonymEditor is the part of the synonym service the admin endpoints need
+type SynonymEditor interface {
+	List(ctx context.Context, language string) ([]*search.SynonymSet, error)
+	Create(ctx context.Context, editorID, language string, terms []string) (*search.SynonymSet, error)
+	Update(ctx context.Context, editorID, id string, terms []string) (*search.SynonymSet, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type synonymRequest struct {
+	Language string   `json:"language"` // Ignored on update
+	Terms    []string `json:"terms"`
+}
+
+type synonymResponse struct {
+	ID        string   `json:"id"`
+	Language  string   `json:"language"`
+	Terms     []string `json:"terms"`
+	UpdatedBy string   `json:"updated_by"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type SynonymHandler struct {
+	synonyms SynonymEditor
+	staff    StaffResolver
+}
+
+func NewSynonymHandler(synonyms SynonymEditor, staff StaffResolver) *SynonymHandler {
+	return &SynonymHandler{synonyms: synonyms, staff: staff}
+}
+
+// NewSynonymRouter mounts synonym management, it must sit behind admin authentication
+func NewSynonymRouter(synonyms SynonymEditor, staff StaffResolver) http.Handler {
+	h := NewSynonymHandler(synonyms, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/search/synonyms", h.List)
+	mux.HandleFunc("POST /admin/search/synonyms", h.Create)
+	mux.HandleFunc("PUT /admin/search/synonyms/{id}", h.Update)
+	mux.HandleFunc("DELETE /admin/search/synonyms/{id}", h.Delete)
+	return mux
+}
+
+// List handles GET /admin/search/synonyms?lang=id
+func (h *SynonymHandler) List(w http.ResponseWriter, r *http.Request) {
+	sets, err := h.synonyms.List(r.Context(), r.URL.Query().Get("lang"))
+	if err != nil {
+		writeSynonymError(w, err)
+		return
+	}
+	resp := make([]synonymResponse, 0, len(sets))
+	for _, set := range sets {
+		resp = append(resp, toSynonymResponse(set))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *SynonymHandler) Create(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req synonymRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	if req.Language == "" {
+		req.Language = app.DefaultLanguage
+	}
+	set, err := h.synonyms.Create(r.Context(), editorID, req.Language, req.Terms)
+	if err != nil {
+		writeSynonymError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSynonymResponse(set))
+}
+
+func (h *SynonymHandler) Update(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req synonymRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	set, err := h.synonyms.Update(r.Context(), editorID, r.PathValue("id"), req.Terms)
+	if err != nil {
+		writeSynonymError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSynonymResponse(set))
+}
+
+func (h *SynonymHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.staff(r); !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	if err := h.synonyms.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeSynonymError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeSynonymError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrSynonymsNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, search.ErrInvalidLanguage), errors.Is(err, search.ErrTooFewSynonyms),
+		errors.Is(err, search.ErrTooManySynonyms), errors.Is(err, search.ErrPhraseTooLong):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toSynonymResponse(set *search.SynonymSet) synonymResponse {
+	return synonymResponse{
+		ID:        set.ID,
+		Language:  set.Language,
+		Terms:     set.Terms,
+		UpdatedBy: set.UpdatedBy,
+		UpdatedAt: set.UpdatedAt.Format(time.RFC3339),
+	}
+}
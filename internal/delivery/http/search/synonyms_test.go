@@ -0,0 +1,102 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/search"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/search"
+)
+
+type fakeSynonyms struct {
+	sets     map[string]*search.SynonymSet
+	editorID string
+}
+
+func (f *fakeSynonyms) List(ctx context.Context, language string) ([]*search.SynonymSet, error) {
+	var sets []*search.SynonymSet
+	for _, set := range f.sets {
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+func (f *fakeSynonyms) Create(ctx context.Context, editorID, language string, terms []string) (*search.SynonymSet, error) {
+	set, err := search.NewSynonymSet("s1", language, terms, editorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	f.sets[set.ID], f.editorID = set, editorID
+	return set, nil
+}
+
+func (f *fakeSynonyms) Update(ctx context.Context, editorID, id string, terms []string) (*search.SynonymSet, error) {
+	set, ok := f.sets[id]
+	if !ok {
+		return nil, app.ErrSynonymsNotFound
+	}
+	f.editorID = editorID
+	return set, set.Redefine(terms, editorID, time.Now())
+}
+
+func (f *fakeSynonyms) Delete(ctx context.Context, id string) error {
+	if _, ok := f.sets[id]; !ok {
+		return app.ErrSynonymsNotFound
+	}
+	delete(f.sets, id)
+	return nil
+}
+
+func TestSynonymHandler(t *testing.T) {
+	synonyms := &fakeSynonyms{sets: map[string]*search.SynonymSet{}}
+	staff := func(r *http.Request) (string, bool) { return "editor-1", true }
+	router := openapitest.Handler(t, RegisterSpec, NewSynonymRouter(synonyms, staff))
+
+	// Steps run in order against the same store
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"create", http.MethodPost, "/admin/search/synonyms", `{"terms":["KPU","Komisi Pemilihan Umum"]}`, http.StatusCreated, `"language":"id"`},
+		{"create one term", http.MethodPost, "/admin/search/synonyms", `{"terms":["kpu"]}`, http.StatusUnprocessableEntity, "at least two"},
+		{"create invalid body", http.MethodPost, "/admin/search/synonyms", `{`, http.StatusBadRequest, "invalid request body"},
+		{"update", http.MethodPut, "/admin/search/synonyms/s1", `{"terms":["kpu","komisi pemilihan umum","penyelenggara pemilu"]}`, http.StatusOK, `"penyelenggara pemilu"`},
+		{"update unknown", http.MethodPut, "/admin/search/synonyms/s9", `{"terms":["a","b"]}`, http.StatusNotFound, "not found"},
+		{"list", http.MethodGet, "/admin/search/synonyms?lang=id", ``, http.StatusOK, `"updated_by":"editor-1"`},
+		{"delete", http.MethodDelete, "/admin/search/synonyms/s1", ``, http.StatusNoContent, ""},
+		{"delete twice", http.MethodDelete, "/admin/search/synonyms/s1", ``, http.StatusNotFound, "not found"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body)))
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tc.expectedBody) {
+				t.Errorf("expected body containing %q, got %s", tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSynonymHandler_RequiresStaff(t *testing.T) {
+	synonyms := &fakeSynonyms{sets: map[string]*search.SynonymSet{}}
+	router := openapitest.Handler(t, RegisterSpec, NewSynonymRouter(synonyms, func(r *http.Request) (string, bool) { return "", false }))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/search/synonyms", strings.NewReader(`{"terms":["kpu","komisi pemilihan umum"]}`)))
+	if rec.Code != http.StatusUnauthorized || len(synonyms.sets) != 0 {
+		t.Errorf("expected status 401 and nothing saved, got %d", rec.Code)
+	}
+}
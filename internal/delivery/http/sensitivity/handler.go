@@ -0,0 +1,148 @@
+package sensitivity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sensitivity"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Classifications is the part of the sensitivity service the endpoints need
+type Classifications interface {
+	Classify(ctx context.Context, editorID, articleID string, categories []string, at time.Time) (*sensitivity.Classification, error)
+	Classification(ctx context.Context, articleID string) (*sensitivity.Classification, error)
+}
+
+type classifyRequest struct {
+	Categories []string `json:"categories"`
+}
+
+type adsResponse struct {
+	Enabled      bool `json:"enabled"`
+	Personalized bool `json:"personalized"`
+	Political    bool `json:"political"`
+}
+
+type classificationResponse struct {
+	ArticleID  string   `json:"article_id"`
+	Categories []string `json:"categories"`
+	UpdatedBy  *string  `json:"updated_by,omitempty"`
+	UpdatedAt  *string  `json:"updated_at,omitempty"`
+}
+
+// pageResponse is what the article page and the ad server need
+type pageResponse struct {
+	Sensitive   bool        `json:"sensitive"`
+	Disclaimers []string    `json:"disclaimers"`
+	Ads         adsResponse `json:"ads"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	classifications Classifications
+	staff           StaffResolver
+}
+
+func NewHandler(classifications Classifications, staff StaffResolver) *Handler {
+	return &Handler{classifications: classifications, staff: staff}
+}
+
+// NewRouter mounts the disclaimers and ad restrictions of article pages
+func NewRouter(classifications Classifications) http.Handler {
+	h := NewHandler(classifications, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /articles/{id}/sensitivity", h.Page)
+	return mux
+}
+
+// NewAdminRouter mounts sensitive-content classification, it must sit behind admin authentication
+func NewAdminRouter(classifications Classifications, staff StaffResolver) http.Handler {
+	h := NewHandler(classifications, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/articles/{id}/sensitivity", h.Get)
+	mux.HandleFunc("PUT /admin/articles/{id}/sensitivity", h.Classify)
+	return mux
+}
+
+// Page handles GET /articles/{id}/sensitivity?lang=en
+func (h *Handler) Page(w http.ResponseWriter, r *http.Request) {
+	classification, err := h.classifications.Classification(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	policy := classification.AdPolicy()
+	writeJSON(w, http.StatusOK, pageResponse{
+		Sensitive:   classification.Sensitive(),
+		Disclaimers: classification.Disclaimers(r.URL.Query().Get("lang")),
+		Ads:         adsResponse{Enabled: policy.Ads, Personalized: policy.Personalized, Political: policy.Political},
+	})
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	classification, err := h.classifications.Classification(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toClassificationResponse(classification))
+}
+
+func (h *Handler) Classify(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req classifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	classification, err := h.classifications.Classify(r.Context(), staffID, r.PathValue("id"), req.Categories, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toClassificationResponse(classification))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, sensitivity.ErrInvalidCategory):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toClassificationResponse(c *sensitivity.Classification) classificationResponse {
+	resp := classificationResponse{ArticleID: c.ArticleID, Categories: make([]string, 0, len(c.Categories)), UpdatedBy: c.LastActionBy}
+	for _, category := range c.Categories {
+		resp.Categories = append(resp.Categories, string(category))
+	}
+	if !c.UpdatedAt.IsZero() {
+		at := c.UpdatedAt.Format(time.RFC3339)
+		resp.UpdatedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,90 @@
+package sensitivity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sensitivity"
+)
+
+type fakeClassifications struct {
+	categories []string
+	err        error
+}
+
+func (f *fakeClassifications) Classify(ctx context.Context, editorID, articleID string, categories []string, at time.Time) (*sensitivity.Classification, error) {
+	f.categories = categories
+	if f.err != nil {
+		return nil, f.err
+	}
+	selected := make([]sensitivity.Category, 0, len(categories))
+	for _, c := range categories {
+		selected = append(selected, sensitivity.Category(c))
+	}
+	return sensitivity.NewClassification(articleID, selected, editorID, at)
+}
+
+func (f *fakeClassifications) Classification(ctx context.Context, articleID string) (*sensitivity.Classification, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &sensitivity.Classification{ArticleID: articleID, Categories: []sensitivity.Category{sensitivity.CategorySuicide}}, nil
+}
+
+func TestHandler_Page(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakeClassifications{})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles/a1/sensitivity?lang=en", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp pageResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if !resp.Sensitive || resp.Ads.Enabled || len(resp.Disclaimers) != 1 || !strings.HasPrefix(resp.Disclaimers[0], "If you") {
+		t.Errorf("unexpected page %+v", resp)
+	}
+}
+
+func TestHandler_Classify(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "editor-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"ok", `{"categories":["court_case","minors"]}`, true, nil, http.StatusOK},
+		{"without session", `{"categories":[]}`, false, nil, http.StatusUnauthorized},
+		{"invalid body", `{`, true, nil, http.StatusBadRequest},
+		{"unknown category", `{"categories":["gossip"]}`, true, sensitivity.ErrInvalidCategory, http.StatusUnprocessableEntity},
+		{"store failure", `{"categories":[]}`, true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			classifications := &fakeClassifications{err: tc.err}
+			req := httptest.NewRequest(http.MethodPut, "/admin/articles/a1/sensitivity", strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(classifications, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusOK && len(classifications.categories) != 2 {
+				t.Errorf("expected categories passed through, got %v", classifications.categories)
+			}
+		})
+	}
+}
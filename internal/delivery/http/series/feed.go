@@ -0,0 +1,78 @@
+package series
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+)
+
+// feedItems caps the feed, readers only need the latest parts to notice a new one
+const feedItems = 50
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	Description string  `xml:"description,omitempty"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// Feed serves RSS 2.0 for one series, newest part first
+func (h *Handler) Feed(w http.ResponseWriter, r *http.Request) {
+	landing, err := h.series.Landing(r.Context(), r.PathValue("slug"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	channel := rssChannel{
+		Title:       landing.Series.Title,
+		Link:        h.baseURL + "/series/" + landing.Series.Slug.Value(),
+		Description: landing.Series.Description,
+	}
+	if channel.Description == "" {
+		channel.Description = landing.Series.Title
+	}
+	var latest time.Time
+	for i := len(landing.Parts) - 1; i >= 0 && len(channel.Items) < feedItems; i-- {
+		p := landing.Parts[i]
+		link := h.baseURL + p.Path
+		channel.Items = append(channel.Items, rssItem{
+			Title:       partLabel(p.Number, landing.Series.Total()) + ": " + p.Title,
+			Link:        link,
+			GUID:        rssGUID{Value: link, IsPermaLink: true},
+			Description: p.Excerpt,
+			PubDate:     p.PublishedAt.UTC().Format(time.RFC1123Z),
+		})
+		if p.PublishedAt.After(latest) {
+			latest = p.PublishedAt
+		}
+	}
+	if !latest.IsZero() {
+		channel.LastBuildDate = latest.UTC().Format(time.RFC1123Z)
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(rssFeed{Version: "2.0", Channel: channel})
+}
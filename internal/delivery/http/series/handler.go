@@ -0,0 +1,295 @@
+package series
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/series"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/series"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Series is the part of the series service the endpoints need
+type Series interface {
+	Create(ctx context.Context, staffID, slug, title, description string, plannedParts int) (*series.Series, error)
+	Update(ctx context.Context, staffID, id, title, description string, plannedParts int) (*series.Series, error)
+	SetParts(ctx context.Context, staffID, id string, articleIDs []string) (*series.Series, error)
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (*series.Series, error)
+	Landing(ctx context.Context, slug string) (*app.Landing, error)
+	CrossLinks(ctx context.Context, articleID string) (*app.CrossLinks, error)
+}
+
+type seriesRequest struct {
+	Slug         string `json:"slug"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	PlannedParts int    `json:"planned_parts"`
+}
+
+type partsRequest struct {
+	ArticleIDs []string `json:"article_ids"`
+}
+
+type seriesResponse struct {
+	ID           string   `json:"id"`
+	Slug         string   `json:"slug"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description,omitempty"`
+	ArticleIDs   []string `json:"article_ids"`
+	PlannedParts int      `json:"planned_parts"`
+	UpdatedAt    string   `json:"updated_at"`
+}
+
+type partResponse struct {
+	Number      int    `json:"number"`
+	ArticleID   string `json:"article_id"`
+	Title       string `json:"title"`
+	Excerpt     string `json:"excerpt,omitempty"`
+	URL         string `json:"url"`
+	PublishedAt string `json:"published_at"`
+	Current     bool   `json:"current,omitempty"`
+}
+
+type landingResponse struct {
+	Slug        string         `json:"slug"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Total       int            `json:"total"`
+	Parts       []partResponse `json:"parts"`
+	FeedURL     string         `json:"feed_url"`
+}
+
+// crossLinksResponse is the "part 3 of 5" block shown inside member articles
+type crossLinksResponse struct {
+	Slug     string         `json:"slug"`
+	Title    string         `json:"title"`
+	URL      string         `json:"url"`
+	Part     int            `json:"part"`
+	Total    int            `json:"total"`
+	Label    string         `json:"label"`
+	Previous *partResponse  `json:"previous,omitempty"`
+	Next     *partResponse  `json:"next,omitempty"`
+	Parts    []partResponse `json:"parts"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	series  Series
+	staff   StaffResolver
+	baseURL string
+}
+
+func NewHandler(seriesService Series, staff StaffResolver, baseURL string) *Handler {
+	return &Handler{series: seriesService, staff: staff, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// NewRouter mounts the series landing pages, feeds and cross-link blocks.
+// baseURL makes feed links absolute, e.g. "https://news.example.com".
+func NewRouter(seriesService Series, baseURL string) http.Handler {
+	h := NewHandler(seriesService, nil, baseURL)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /series/{slug}", h.Landing)
+	mux.HandleFunc("GET /series/{slug}/rss", h.Feed)
+	mux.HandleFunc("GET /articles/{id}/series", h.CrossLinks)
+	return mux
+}
+
+// NewAdminRouter mounts series management, it must sit behind admin authentication
+func NewAdminRouter(seriesService Series, staff StaffResolver) http.Handler {
+	h := NewHandler(seriesService, staff, "")
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/series", h.Create)
+	mux.HandleFunc("GET /admin/series/{id}", h.Get)
+	mux.HandleFunc("PUT /admin/series/{id}", h.Update)
+	mux.HandleFunc("PUT /admin/series/{id}/parts", h.SetParts)
+	mux.HandleFunc("DELETE /admin/series/{id}", h.Delete)
+	return mux
+}
+
+func (h *Handler) Landing(w http.ResponseWriter, r *http.Request) {
+	landing, err := h.series.Landing(r.Context(), r.PathValue("slug"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := landingResponse{
+		Slug:        landing.Series.Slug.Value(),
+		Title:       landing.Series.Title,
+		Description: landing.Series.Description,
+		Total:       landing.Series.Total(),
+		Parts:       h.toPartResponses(landing.Parts),
+		FeedURL:     h.baseURL + "/series/" + landing.Series.Slug.Value() + "/rss",
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) CrossLinks(w http.ResponseWriter, r *http.Request) {
+	links, err := h.series.CrossLinks(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := crossLinksResponse{
+		Slug:  links.Series.Slug.Value(),
+		Title: links.Series.Title,
+		URL:   h.baseURL + "/series/" + links.Series.Slug.Value(),
+		Part:  links.Position.Part,
+		Total: links.Position.Total,
+		Label: partLabel(links.Position.Part, links.Position.Total),
+		Parts: h.toPartResponses(links.Parts),
+	}
+	for i, p := range links.Parts {
+		if links.Position.Previous != nil && p.ArticleID == *links.Position.Previous {
+			resp.Previous = &resp.Parts[i]
+		}
+		if links.Position.Next != nil && p.ArticleID == *links.Position.Next {
+			resp.Next = &resp.Parts[i]
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req seriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	created, err := h.series.Create(r.Context(), staffID, req.Slug, req.Title, req.Description, req.PlannedParts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSeriesResponse(created))
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	found, err := h.series.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSeriesResponse(found))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req seriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	updated, err := h.series.Update(r.Context(), staffID, r.PathValue("id"), req.Title, req.Description, req.PlannedParts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSeriesResponse(updated))
+}
+
+// SetParts replaces the reading order, PUT /admin/series/{id}/parts {"article_ids":["a1","a2"]}
+func (h *Handler) SetParts(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req partsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	updated, err := h.series.SetParts(r.Context(), staffID, r.PathValue("id"), req.ArticleIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSeriesResponse(updated))
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.series.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) toPartResponses(parts []app.Part) []partResponse {
+	resp := make([]partResponse, 0, len(parts))
+	for _, p := range parts {
+		resp = append(resp, partResponse{
+			Number:      p.Number,
+			ArticleID:   p.ArticleID,
+			Title:       p.Title,
+			Excerpt:     p.Excerpt,
+			URL:         h.baseURL + p.Path,
+			PublishedAt: p.PublishedAt.Format(time.RFC3339),
+			Current:     p.Current,
+		})
+	}
+	return resp
+}
+
+func partLabel(part, total int) string {
+	return fmt.Sprintf("Part %d of %d", part, total)
+}
+
+func toSeriesResponse(s *series.Series) seriesResponse {
+	return seriesResponse{
+		ID:           s.ID,
+		Slug:         s.Slug.Value(),
+		Title:        s.Title,
+		Description:  s.Description,
+		ArticleIDs:   append([]string{}, s.ArticleIDs...),
+		PlannedParts: s.PlannedParts,
+		UpdatedAt:    s.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrSeriesNotFound), errors.Is(err, app.ErrNotInSeries):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrSlugTaken), errors.Is(err, app.ErrArticleInOtherSeries):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, series.ErrInvalidSlug), errors.Is(err, series.ErrTitleEmpty), errors.Is(err, series.ErrTitleTooLong),
+		errors.Is(err, series.ErrDuplicatePart), errors.Is(err, series.ErrTooManyParts),
+		errors.Is(err, series.ErrInvalidPlanned), errors.Is(err, series.ErrPlannedBelowParts):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,168 @@
+package series
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/series"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/series"
+)
+
+type fakeSeries struct {
+	series  *series.Series
+	parts   []app.Part
+	staffID string
+	err     error
+}
+
+func (f *fakeSeries) Create(ctx context.Context, staffID, slug, title, description string, plannedParts int) (*series.Series, error) {
+	f.staffID = staffID
+	return f.series, f.err
+}
+
+func (f *fakeSeries) Update(ctx context.Context, staffID, id, title, description string, plannedParts int) (*series.Series, error) {
+	return f.series, f.err
+}
+
+func (f *fakeSeries) SetParts(ctx context.Context, staffID, id string, articleIDs []string) (*series.Series, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.series, f.series.SetParts(articleIDs, staffID)
+}
+
+func (f *fakeSeries) Delete(ctx context.Context, id string) error {
+	return f.err
+}
+
+func (f *fakeSeries) Get(ctx context.Context, id string) (*series.Series, error) {
+	return f.series, f.err
+}
+
+func (f *fakeSeries) Landing(ctx context.Context, slug string) (*app.Landing, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.Landing{Series: f.series, Parts: f.parts}, nil
+}
+
+func (f *fakeSeries) CrossLinks(ctx context.Context, articleID string) (*app.CrossLinks, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	pos, _ := f.series.PositionOf(articleID, map[string]bool{"a1": true, "a2": true})
+	parts := append([]app.Part(nil), f.parts...)
+	for i := range parts {
+		parts[i].Current = parts[i].ArticleID == articleID
+	}
+	return &app.CrossLinks{Series: f.series, Position: *pos, Parts: parts}, nil
+}
+
+func newFakeSeries(t *testing.T) *fakeSeries {
+	t.Helper()
+	slug, _ := series.NewSlug("water-cartel")
+	s, _ := series.NewSeries("series-1", *slug, "The Water Cartel", "How the water was sold", 5, "editor-1")
+	_ = s.SetParts([]string{"a1", "a2"}, "editor-1")
+	return &fakeSeries{series: s, parts: []app.Part{
+		{Number: 1, ArticleID: "a1", Title: "The deal", Path: "/investigations/the-deal", PublishedAt: time.Date(2025, time.May, 1, 8, 0, 0, 0, time.UTC)},
+		{Number: 2, ArticleID: "a2", Title: "The money", Path: "/investigations/the-money", PublishedAt: time.Date(2025, time.May, 8, 8, 0, 0, 0, time.UTC)},
+	}}
+}
+
+func TestHandler_Landing(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(newFakeSeries(t), "https://news.example.com/")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/series/water-cartel", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var resp landingResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Total != 5 || len(resp.Parts) != 2 || resp.Parts[1].URL != "https://news.example.com/investigations/the-money" {
+		t.Errorf("unexpected landing %+v", resp)
+	}
+	if resp.FeedURL != "https://news.example.com/series/water-cartel/rss" {
+		t.Errorf("unexpected feed URL %s", resp.FeedURL)
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakeSeries{err: app.ErrSeriesNotFound}, "")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/series/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestHandler_CrossLinks(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(newFakeSeries(t), "https://news.example.com")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles/a2/series", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var resp crossLinksResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Label != "Part 2 of 5" || resp.Previous == nil || resp.Previous.ArticleID != "a1" || resp.Next != nil {
+		t.Errorf("unexpected cross-links %+v", resp)
+	}
+	if !resp.Parts[1].Current {
+		t.Error("expected the current article to be marked")
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakeSeries{err: app.ErrNotInSeries}, "")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/articles/x/series", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Feed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(newFakeSeries(t), "https://news.example.com")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/series/water-cartel/rss", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/rss+xml") {
+		t.Fatalf("expected RSS response, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	var feed rssFeed
+	if err := xml.NewDecoder(rec.Body).Decode(&feed); err != nil {
+		t.Fatalf("failed to decode feed: %v", err)
+	}
+	if len(feed.Channel.Items) != 2 || feed.Channel.Items[0].Title != "Part 2 of 5: The money" {
+		t.Errorf("expected newest part first, got %+v", feed.Channel.Items)
+	}
+	if feed.Channel.Items[0].Link != "https://news.example.com/investigations/the-money" || feed.Channel.LastBuildDate == "" {
+		t.Errorf("unexpected feed channel %+v", feed.Channel)
+	}
+}
+
+func TestHandler_SetParts(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"reordered", `{"article_ids":["a2","a1"]}`, nil, http.StatusOK},
+		{"duplicate part", `{"article_ids":["a1","a1"]}`, nil, http.StatusUnprocessableEntity},
+		{"article in other series", `{"article_ids":["a9"]}`, app.ErrArticleInOtherSeries, http.StatusConflict},
+		{"invalid body", `{`, nil, http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeSeries(t)
+			fake.err = tc.err
+			staff := func(r *http.Request) (string, bool) { return "editor-1", true }
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(fake, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/series/series-1/parts", strings.NewReader(tc.body)))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package serp
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/serp"
+)
+
+// Previewer is the part of the serp service the endpoint needs
+type Previewer interface {
+	Preview(headline, seoTitle, description string) []serp.Preview
+}
+
+type previewRequest struct {
+	Headline        string `json:"headline"`
+	SEOTitle        string `json:"seo_title"`
+	MetaDescription string `json:"meta_description"`
+}
+
+type warningResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type previewResponse struct {
+	Surface   string            `json:"surface"`
+	Field     string            `json:"field"`
+	Text      string            `json:"text"`
+	WidthPx   float64           `json:"width_px"`
+	MaxPx     float64           `json:"max_px"`
+	Display   string            `json:"display"`
+	Truncated bool              `json:"truncated"`
+	Warnings  []warningResponse `json:"warnings"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	previewer Previewer
+}
+
+func NewHandler(previewer Previewer) *Handler {
+	return &Handler{previewer: previewer}
+}
+
+// NewAdminRouter mounts the editor's headline preview, it must sit behind admin authentication
+func NewAdminRouter(previewer Previewer) http.Handler {
+	h := NewHandler(previewer)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/serp/preview", h.Preview)
+	return mux
+}
+
+// Preview is called as the editor types, it only measures and never stores
+func (h *Handler) Preview(w http.ResponseWriter, r *http.Request) {
+	var req previewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	previews := h.previewer.Preview(req.Headline, req.SEOTitle, req.MetaDescription)
+	resp := make([]previewResponse, 0, len(previews))
+	for _, p := range previews {
+		item := previewResponse{
+			Surface:   string(p.Surface),
+			Field:     string(p.Field),
+			Text:      p.Text,
+			WidthPx:   p.WidthPx,
+			MaxPx:     p.MaxPx,
+			Display:   p.Display,
+			Truncated: p.Truncated,
+			Warnings:  make([]warningResponse, 0, len(p.Warnings)),
+		}
+		for _, warning := range p.Warnings {
+			item.Warnings = append(item.Warnings, warningResponse{Code: string(warning.Code), Message: warning.Message})
+		}
+		resp = append(resp, item)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
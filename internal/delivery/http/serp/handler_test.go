@@ -0,0 +1,38 @@
+package serp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/serp"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+)
+
+func TestHandler_Preview(t *testing.T) {
+	router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(app.NewService(nil)))
+
+	body := `{"headline":"Banjir rendam ribuan rumah di Jakarta Timur","meta_description":""}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/serp/preview", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp []previewResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if len(resp) != 5 || resp[0].Surface != "google_title" || resp[0].WidthPx <= 0 || resp[0].Truncated {
+		t.Fatalf("unexpected previews %+v", resp)
+	}
+	if resp[1].Field != "description" || len(resp[1].Warnings) != 1 || resp[1].Warnings[0].Code != "empty" {
+		t.Errorf("expected an empty description warning, got %+v", resp[1])
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/serp/preview", strings.NewReader(`{`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rec.Code)
+	}
+}
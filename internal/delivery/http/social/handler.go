@@ -0,0 +1,225 @@
+package social
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/social"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/social"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Publishing is the part of the social publishing service the endpoints need
+type Publishing interface {
+	Posts(ctx context.Context, articleID string) ([]*social.Post, error)
+	Override(ctx context.Context, editorID, postID, text string, scheduledAt, at time.Time) (*social.Post, error)
+	Cancel(ctx context.Context, editorID, postID string, at time.Time) (*social.Post, error)
+	Retry(ctx context.Context, editorID, postID string, at time.Time) (*social.Post, error)
+	Templates(ctx context.Context) ([]*social.Template, error)
+	SaveTemplate(ctx context.Context, editorID, channel, body string, enabled bool, at time.Time) (*social.Template, error)
+}
+
+type overrideRequest struct {
+	Text        string `json:"text"`
+	ScheduledAt string `json:"scheduled_at"` // RFC 3339
+}
+
+type templateRequest struct {
+	Body    string `json:"body"`
+	Enabled bool   `json:"enabled"`
+}
+
+type postResponse struct {
+	ID          string  `json:"id"`
+	ArticleID   string  `json:"article_id"`
+	Channel     string  `json:"channel"`
+	Text        string  `json:"text"`
+	Link        string  `json:"link"`
+	ScheduledAt string  `json:"scheduled_at"`
+	Status      string  `json:"status"`
+	Overridden  bool    `json:"overridden"`
+	Attempts    int     `json:"attempts"`
+	ExternalID  *string `json:"external_id,omitempty"`
+	LastError   *string `json:"last_error,omitempty"`
+	SentAt      *string `json:"sent_at,omitempty"`
+}
+
+type templateResponse struct {
+	Channel   string  `json:"channel"`
+	Body      string  `json:"body"`
+	Enabled   bool    `json:"enabled"`
+	UpdatedBy *string `json:"updated_by,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	publishing Publishing
+	staff      StaffResolver
+}
+
+func NewHandler(publishing Publishing, staff StaffResolver) *Handler {
+	return &Handler{publishing: publishing, staff: staff}
+}
+
+// NewAdminRouter mounts the social post queue and channel templates, it must sit behind admin authentication
+func NewAdminRouter(publishing Publishing, staff StaffResolver) http.Handler {
+	h := NewHandler(publishing, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/articles/{id}/social-posts", h.Posts)
+	mux.HandleFunc("PUT /admin/social-posts/{id}", h.Override)
+	mux.HandleFunc("POST /admin/social-posts/{id}/cancel", h.Cancel)
+	mux.HandleFunc("POST /admin/social-posts/{id}/retry", h.Retry)
+	mux.HandleFunc("GET /admin/social-templates", h.Templates)
+	mux.HandleFunc("PUT /admin/social-templates/{channel}", h.SaveTemplate)
+	return mux
+}
+
+// Posts lists an article's posts with their delivery status
+func (h *Handler) Posts(w http.ResponseWriter, r *http.Request) {
+	posts, err := h.publishing.Posts(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]postResponse, 0, len(posts))
+	for _, p := range posts {
+		resp = append(resp, toPostResponse(p))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Override(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req overrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	scheduledAt, err := time.Parse(time.RFC3339, req.ScheduledAt)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "scheduled_at must be an RFC 3339 time"})
+		return
+	}
+
+	post, err := h.publishing.Override(r.Context(), editorID, r.PathValue("id"), req.Text, scheduledAt, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPostResponse(post))
+}
+
+func (h *Handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, h.publishing.Cancel)
+}
+
+func (h *Handler) Retry(w http.ResponseWriter, r *http.Request) {
+	h.change(w, r, h.publishing.Retry)
+}
+
+func (h *Handler) change(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, editorID, postID string, at time.Time) (*social.Post, error)) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	post, err := change(r.Context(), editorID, r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPostResponse(post))
+}
+
+func (h *Handler) Templates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.publishing.Templates(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]templateResponse, 0, len(templates))
+	for _, t := range templates {
+		resp = append(resp, toTemplateResponse(t))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) SaveTemplate(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req templateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	tmpl, err := h.publishing.SaveTemplate(r.Context(), editorID, r.PathValue("channel"), req.Body, req.Enabled, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTemplateResponse(tmpl))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrPostNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, social.ErrNotEditable), errors.Is(err, social.ErrNotCancellable), errors.Is(err, social.ErrNotRetryable):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, social.ErrInvalidChannel), errors.Is(err, social.ErrInvalidTemplate), errors.Is(err, social.ErrTooLong),
+		errors.Is(err, social.ErrEmptyPost), errors.Is(err, social.ErrScheduleInPast):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toPostResponse(p *social.Post) postResponse {
+	resp := postResponse{
+		ID:          p.ID,
+		ArticleID:   p.ArticleID,
+		Channel:     string(p.Channel),
+		Text:        p.Text,
+		Link:        p.Link,
+		ScheduledAt: p.ScheduledAt.Format(time.RFC3339),
+		Status:      string(p.Status),
+		Overridden:  p.Overridden,
+		Attempts:    p.Attempts,
+		ExternalID:  p.ExternalID,
+		LastError:   p.LastError,
+	}
+	if p.SentAt != nil {
+		at := p.SentAt.Format(time.RFC3339)
+		resp.SentAt = &at
+	}
+	return resp
+}
+
+func toTemplateResponse(t *social.Template) templateResponse {
+	return templateResponse{Channel: string(t.Channel), Body: t.Body, Enabled: t.Enabled, UpdatedBy: t.LastActionBy}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
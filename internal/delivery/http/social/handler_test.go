@@ -0,0 +1,117 @@
+package social
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/social"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/social"
+)
+
+type fakePublishing struct {
+	err error
+}
+
+func (f *fakePublishing) post() (*social.Post, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return social.NewPost("p1", "a1", social.ChannelX, "Harga BBM naik", "", time.Now().Add(time.Hour), time.Now())
+}
+
+func (f *fakePublishing) Posts(ctx context.Context, articleID string) ([]*social.Post, error) {
+	p, err := f.post()
+	if err != nil {
+		return nil, err
+	}
+	return []*social.Post{p}, nil
+}
+
+func (f *fakePublishing) Override(ctx context.Context, editorID, postID, text string, scheduledAt, at time.Time) (*social.Post, error) {
+	p, err := f.post()
+	if err != nil {
+		return nil, err
+	}
+	return p, p.Override(text, scheduledAt, editorID, at)
+}
+
+func (f *fakePublishing) Cancel(ctx context.Context, editorID, postID string, at time.Time) (*social.Post, error) {
+	p, err := f.post()
+	if err != nil {
+		return nil, err
+	}
+	return p, p.Cancel(editorID, at)
+}
+
+func (f *fakePublishing) Retry(ctx context.Context, editorID, postID string, at time.Time) (*social.Post, error) {
+	p, err := f.post()
+	if err != nil {
+		return nil, err
+	}
+	return p, p.Retry(editorID, at)
+}
+
+func (f *fakePublishing) Templates(ctx context.Context) ([]*social.Template, error) {
+	return []*social.Template{social.DefaultTemplate(social.ChannelX)}, f.err
+}
+
+func (f *fakePublishing) SaveTemplate(ctx context.Context, editorID, channel, body string, enabled bool, at time.Time) (*social.Template, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	c, err := social.ParseChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	return social.NewTemplate(c, body, enabled, editorID, at)
+}
+
+func TestHandler_Admin(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "editor-1", r.Header.Get("Authorization") != "" }
+	later := time.Now().Add(2 * time.Hour).Format(time.RFC3339)
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"list posts", http.MethodGet, "/admin/articles/a1/social-posts", "", true, nil, http.StatusOK},
+		{"override", http.MethodPut, "/admin/social-posts/p1", `{"text":"BREAKING","scheduled_at":"` + later + `"}`, true, nil, http.StatusOK},
+		{"override without session", http.MethodPut, "/admin/social-posts/p1", `{"text":"BREAKING","scheduled_at":"` + later + `"}`, false, nil, http.StatusUnauthorized},
+		{"override invalid time", http.MethodPut, "/admin/social-posts/p1", `{"text":"BREAKING","scheduled_at":"soon"}`, true, nil, http.StatusBadRequest},
+		{"override in the past", http.MethodPut, "/admin/social-posts/p1", `{"text":"BREAKING","scheduled_at":"2020-01-01T00:00:00Z"}`, true, nil, http.StatusUnprocessableEntity},
+		{"override too long", http.MethodPut, "/admin/social-posts/p1", `{"text":"` + strings.Repeat("x", 300) + `","scheduled_at":"` + later + `"}`, true, nil, http.StatusUnprocessableEntity},
+		{"cancel", http.MethodPost, "/admin/social-posts/p1/cancel", "", true, nil, http.StatusOK},
+		{"cancel missing", http.MethodPost, "/admin/social-posts/p9/cancel", "", true, app.ErrPostNotFound, http.StatusNotFound},
+		{"retry scheduled", http.MethodPost, "/admin/social-posts/p1/retry", "", true, nil, http.StatusConflict},
+		{"list templates", http.MethodGet, "/admin/social-templates", "", true, nil, http.StatusOK},
+		{"save template", http.MethodPut, "/admin/social-templates/telegram", `{"body":"{{.Title}} {{.URL}}","enabled":true}`, true, nil, http.StatusOK},
+		{"unknown channel", http.MethodPut, "/admin/social-templates/myspace", `{"body":"{{.Title}}","enabled":true}`, true, nil, http.StatusUnprocessableEntity},
+		{"store failure", http.MethodGet, "/admin/social-templates", "", true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakePublishing{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
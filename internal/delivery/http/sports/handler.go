@@ -0,0 +1,375 @@
+package sports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/sports"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sports"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Sports is the part of the sports service the endpoints need
+type Sports interface {
+	CreateCompetition(ctx context.Context, editorID, code, name, season, provider, providerRef string, at time.Time) (*sports.Competition, error)
+	SetActive(ctx context.Context, editorID, competitionID string, active bool, at time.Time) (*sports.Competition, error)
+	Competitions(ctx context.Context) ([]*sports.Competition, error)
+	Refresh(ctx context.Context, competitionID string, at time.Time) (*app.RefreshResult, error)
+	Fixtures(ctx context.Context, competitionID string, from, to time.Time) ([]*sports.Fixture, error)
+	Fixture(ctx context.Context, fixtureID string) (*sports.Fixture, error)
+	Table(ctx context.Context, competitionID string) (*sports.Table, error)
+	Scoreboards(ctx context.Context, fixtureIDs []string) ([]*sports.Fixture, error)
+}
+
+type competitionRequest struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Season      string `json:"season"`
+	Provider    string `json:"provider"`
+	ProviderRef string `json:"provider_ref"`
+}
+
+type activeRequest struct {
+	Active bool `json:"active"`
+}
+
+type competitionResponse struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Season string `json:"season"`
+	Active bool   `json:"active"`
+}
+
+type teamResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ShortName string `json:"short_name,omitempty"`
+}
+
+type scoreResponse struct {
+	Home int `json:"home"`
+	Away int `json:"away"`
+}
+
+type fixtureResponse struct {
+	ID            string         `json:"id"`
+	CompetitionID string         `json:"competition_id"`
+	Home          teamResponse   `json:"home"`
+	Away          teamResponse   `json:"away"`
+	KickoffAt     string         `json:"kickoff_at"`
+	Venue         string         `json:"venue,omitempty"`
+	Status        string         `json:"status"`
+	Score         *scoreResponse `json:"score,omitempty"`
+	Minute        *int           `json:"minute,omitempty"`
+	UpdatedAt     string         `json:"updated_at"`
+}
+
+type standingResponse struct {
+	Position       int          `json:"position"`
+	Team           teamResponse `json:"team"`
+	Played         int          `json:"played"`
+	Won            int          `json:"won"`
+	Drawn          int          `json:"drawn"`
+	Lost           int          `json:"lost"`
+	GoalsFor       int          `json:"goals_for"`
+	GoalsAgainst   int          `json:"goals_against"`
+	GoalDifference int          `json:"goal_difference"`
+	Points         int          `json:"points"`
+}
+
+type tableResponse struct {
+	CompetitionID string             `json:"competition_id"`
+	Rows          []standingResponse `json:"rows"`
+	UpdatedAt     string             `json:"updated_at"`
+}
+
+// scoreboardsResponse hydrates the scoreboard blocks of an article,
+// PollSeconds tells the page when to ask again
+type scoreboardsResponse struct {
+	Fixtures    []fixtureResponse `json:"fixtures"`
+	PollSeconds int               `json:"poll_seconds"`
+}
+
+type refreshResponse struct {
+	FixturesChanged int    `json:"fixtures_changed"`
+	TableChanged    bool   `json:"table_changed"`
+	NextRefreshAt   string `json:"next_refresh_at"`
+}
+
+type embedResponse struct {
+	Markup string `json:"markup"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	sports Sports
+	staff  StaffResolver
+}
+
+func NewHandler(sports Sports, staff StaffResolver) *Handler {
+	return &Handler{sports: sports, staff: staff}
+}
+
+// NewRouter mounts fixtures, tables and the scoreboards embedded in articles
+func NewRouter(sports Sports) http.Handler {
+	h := NewHandler(sports, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sports/competitions", h.Competitions)
+	mux.HandleFunc("GET /sports/competitions/{id}/fixtures", h.Fixtures)
+	mux.HandleFunc("GET /sports/competitions/{id}/table", h.Table)
+	mux.HandleFunc("GET /sports/fixtures/{id}", h.Fixture)
+	mux.HandleFunc("GET /sports/scoreboards", h.Scoreboards)
+	return mux
+}
+
+// NewAdminRouter mounts competition setup, manual refreshes and embed markup, it must sit behind admin authentication
+func NewAdminRouter(sports Sports, staff StaffResolver) http.Handler {
+	h := NewHandler(sports, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/sports/competitions", h.CreateCompetition)
+	mux.HandleFunc("PUT /admin/sports/competitions/{id}/active", h.SetActive)
+	mux.HandleFunc("POST /admin/sports/competitions/{id}/refresh", h.Refresh)
+	mux.HandleFunc("GET /admin/sports/fixtures/{id}/embed", h.Embed)
+	return mux
+}
+
+func (h *Handler) Competitions(w http.ResponseWriter, r *http.Request) {
+	competitions, err := h.sports.Competitions(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]competitionResponse, 0, len(competitions))
+	for _, c := range competitions {
+		resp = append(resp, toCompetitionResponse(c))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Fixtures handles GET /sports/competitions/{id}/fixtures?from=2026-03-01&to=2026-03-08,
+// defaulting to yesterday through the next seven days. to is inclusive.
+func (h *Handler) Fixtures(w http.ResponseWriter, r *http.Request) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from, err := dateParam(r.URL.Query().Get("from"), today.AddDate(0, 0, -1))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "from must be a date like 2026-03-01"})
+		return
+	}
+	to, err := dateParam(r.URL.Query().Get("to"), today.AddDate(0, 0, 7))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "to must be a date like 2026-03-08"})
+		return
+	}
+
+	fixtures, err := h.sports.Fixtures(r.Context(), r.PathValue("id"), from, to.AddDate(0, 0, 1))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]fixtureResponse, 0, len(fixtures))
+	for _, f := range fixtures {
+		resp = append(resp, toFixtureResponse(f))
+	}
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(pollSeconds(fixtures)))
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Table(w http.ResponseWriter, r *http.Request) {
+	table, err := h.sports.Table(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := tableResponse{CompetitionID: table.CompetitionID, Rows: make([]standingResponse, 0, len(table.Rows)), UpdatedAt: table.UpdatedAt.Format(time.RFC3339)}
+	for _, row := range table.Rows {
+		resp.Rows = append(resp.Rows, standingResponse{
+			Position:       row.Position,
+			Team:           toTeamResponse(row.Team),
+			Played:         row.Played,
+			Won:            row.Won,
+			Drawn:          row.Drawn,
+			Lost:           row.Lost,
+			GoalsFor:       row.GoalsFor,
+			GoalsAgainst:   row.GoalsAgainst,
+			GoalDifference: row.GoalDifference(),
+			Points:         row.Points,
+		})
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Fixture(w http.ResponseWriter, r *http.Request) {
+	fixture, err := h.sports.Fixture(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(pollSeconds([]*sports.Fixture{fixture})))
+	writeJSON(w, http.StatusOK, toFixtureResponse(fixture))
+}
+
+// Scoreboards handles GET /sports/scoreboards?ids=f1,f2 for the scoreboard
+// blocks embedded in an article
+func (h *Handler) Scoreboards(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	fixtures, err := h.sports.Scoreboards(r.Context(), ids)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := scoreboardsResponse{Fixtures: make([]fixtureResponse, 0, len(fixtures)), PollSeconds: pollSeconds(fixtures)}
+	for _, f := range fixtures {
+		resp.Fixtures = append(resp.Fixtures, toFixtureResponse(f))
+	}
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(resp.PollSeconds))
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) CreateCompetition(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req competitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	competition, err := h.sports.CreateCompetition(r.Context(), staffID, req.Code, req.Name, req.Season, req.Provider, req.ProviderRef, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toCompetitionResponse(competition))
+}
+
+func (h *Handler) SetActive(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req activeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	competition, err := h.sports.SetActive(r.Context(), staffID, r.PathValue("id"), req.Active, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toCompetitionResponse(competition))
+}
+
+// Refresh pulls from the provider right away instead of waiting for the scheduler
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	result, err := h.sports.Refresh(r.Context(), r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, refreshResponse{
+		FixturesChanged: result.FixturesChanged,
+		TableChanged:    result.TableChanged,
+		NextRefreshAt:   result.NextRefreshAt.Format(time.RFC3339),
+	})
+}
+
+// Embed returns the block editors paste into an article to show a live scoreboard
+func (h *Handler) Embed(w http.ResponseWriter, r *http.Request) {
+	fixture, err := h.sports.Fixture(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, embedResponse{Markup: sports.ScoreboardBlock(fixture.ID)})
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrCompetitionNotFound), errors.Is(err, app.ErrFixtureNotFound), errors.Is(err, app.ErrTableNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrCompetitionExists):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrTooManyScoreboards):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrUnknownProvider), errors.Is(err, sports.ErrInvalidCompetitionCode), errors.Is(err, sports.ErrInvalidCompetitionName),
+		errors.Is(err, sports.ErrInvalidSeason), errors.Is(err, sports.ErrInvalidProviderRef):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+// pollSeconds is short while any of the fixtures is in play
+func pollSeconds(fixtures []*sports.Fixture) int {
+	for _, f := range fixtures {
+		if f.Status.InPlay() {
+			return int(sports.RefreshLive.Seconds())
+		}
+	}
+	return int(sports.RefreshMatchday.Seconds())
+}
+
+func dateParam(raw string, fallback time.Time) (time.Time, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
+
+func toCompetitionResponse(c *sports.Competition) competitionResponse {
+	return competitionResponse{ID: c.ID, Name: c.Name, Season: c.Season, Active: c.Active}
+}
+
+func toTeamResponse(t sports.Team) teamResponse {
+	return teamResponse{ID: t.ID, Name: t.Name, ShortName: t.ShortName}
+}
+
+func toFixtureResponse(f *sports.Fixture) fixtureResponse {
+	resp := fixtureResponse{
+		ID:            f.ID,
+		CompetitionID: f.CompetitionID,
+		Home:          toTeamResponse(f.Home),
+		Away:          toTeamResponse(f.Away),
+		KickoffAt:     f.KickoffAt.Format(time.RFC3339),
+		Venue:         f.Venue,
+		Status:        string(f.Status),
+		Minute:        f.Minute,
+		UpdatedAt:     f.UpdatedAt.Format(time.RFC3339),
+	}
+	if f.Score != nil {
+		resp.Score = &scoreResponse{Home: f.Score.Home, Away: f.Score.Away}
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,171 @@
+package sports
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/sports"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sports"
+)
+
+type fakeSports struct {
+	ids      []string
+	from, to time.Time
+	err      error
+}
+
+func liveFixture() *sports.Fixture {
+	minute := 67
+	return &sports.Fixture{
+		ID: "f1", CompetitionID: "liga-1", Home: sports.Team{ID: "1", Name: "Persib"}, Away: sports.Team{ID: "2", Name: "Persija"},
+		KickoffAt: time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC), Status: sports.StatusLive, Score: &sports.Score{Home: 2, Away: 1}, Minute: &minute,
+	}
+}
+
+func (f *fakeSports) CreateCompetition(ctx context.Context, editorID, code, name, season, provider, providerRef string, at time.Time) (*sports.Competition, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return sports.NewCompetition(code, name, season, provider, providerRef, editorID, at)
+}
+
+func (f *fakeSports) SetActive(ctx context.Context, editorID, competitionID string, active bool, at time.Time) (*sports.Competition, error) {
+	return &sports.Competition{ID: competitionID, Active: active}, f.err
+}
+
+func (f *fakeSports) Competitions(ctx context.Context) ([]*sports.Competition, error) {
+	return nil, f.err
+}
+
+func (f *fakeSports) Refresh(ctx context.Context, competitionID string, at time.Time) (*app.RefreshResult, error) {
+	return &app.RefreshResult{FixturesChanged: 1, NextRefreshAt: at}, f.err
+}
+
+func (f *fakeSports) Fixtures(ctx context.Context, competitionID string, from, to time.Time) ([]*sports.Fixture, error) {
+	f.from, f.to = from, to
+	return []*sports.Fixture{liveFixture()}, f.err
+}
+
+func (f *fakeSports) Fixture(ctx context.Context, fixtureID string) (*sports.Fixture, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return liveFixture(), nil
+}
+
+func (f *fakeSports) Table(ctx context.Context, competitionID string) (*sports.Table, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &sports.Table{CompetitionID: competitionID, Rows: []sports.Standing{{Position: 1, Team: sports.Team{ID: "1", Name: "Persib"}, GoalsFor: 50, GoalsAgainst: 20, Points: 58}}}, nil
+}
+
+func (f *fakeSports) Scoreboards(ctx context.Context, fixtureIDs []string) ([]*sports.Fixture, error) {
+	f.ids = fixtureIDs
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*sports.Fixture{liveFixture()}, nil
+}
+
+func TestHandler_Scoreboards(t *testing.T) {
+	fake := &fakeSports{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(fake)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sports/scoreboards?ids=f1,,f2", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(fake.ids) != 2 {
+		t.Errorf("expected empty ids dropped, got %v", fake.ids)
+	}
+	if rec.Header().Get("Cache-Control") != "public, max-age=30" {
+		t.Errorf("expected short cache while live, got %q", rec.Header().Get("Cache-Control"))
+	}
+	var resp scoreboardsResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.PollSeconds != 30 || len(resp.Fixtures) != 1 || resp.Fixtures[0].Score.Home != 2 || *resp.Fixtures[0].Minute != 67 {
+		t.Errorf("unexpected scoreboards %+v", resp)
+	}
+}
+
+func TestHandler_Public(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+	}{
+		{"fixtures", "/sports/competitions/liga-1/fixtures?from=2026-03-01&to=2026-03-08", nil, http.StatusOK},
+		{"fixtures invalid date", "/sports/competitions/liga-1/fixtures?from=march", nil, http.StatusBadRequest},
+		{"fixtures unknown competition", "/sports/competitions/epl/fixtures", app.ErrCompetitionNotFound, http.StatusNotFound},
+		{"table", "/sports/competitions/liga-1/table", nil, http.StatusOK},
+		{"table not refreshed yet", "/sports/competitions/liga-1/table", app.ErrTableNotFound, http.StatusNotFound},
+		{"fixture", "/sports/fixtures/f1", nil, http.StatusOK},
+		{"too many scoreboards", "/sports/scoreboards?ids=a", app.ErrTooManyScoreboards, http.StatusBadRequest},
+		{"store failure", "/sports/competitions", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeSports{err: tc.err}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(fake)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.name == "fixtures" && (!fake.from.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || !fake.to.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC))) {
+				t.Errorf("expected inclusive date range, got %v to %v", fake.from, fake.to)
+			}
+		})
+	}
+}
+
+func TestHandler_Admin(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "editor-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"create", http.MethodPost, "/admin/sports/competitions", `{"code":"liga-1","name":"BRI Liga 1","season":"2025","provider":"api-football","provider_ref":"274"}`, true, nil, http.StatusCreated},
+		{"create duplicate", http.MethodPost, "/admin/sports/competitions", `{}`, true, app.ErrCompetitionExists, http.StatusConflict},
+		{"create unknown provider", http.MethodPost, "/admin/sports/competitions", `{}`, true, app.ErrUnknownProvider, http.StatusUnprocessableEntity},
+		{"create without session", http.MethodPost, "/admin/sports/competitions", `{}`, false, nil, http.StatusUnauthorized},
+		{"deactivate", http.MethodPut, "/admin/sports/competitions/liga-1/active", `{"active":false}`, true, nil, http.StatusOK},
+		{"refresh", http.MethodPost, "/admin/sports/competitions/liga-1/refresh", ``, true, nil, http.StatusOK},
+		{"embed", http.MethodGet, "/admin/sports/fixtures/f1/embed", ``, true, nil, http.StatusOK},
+		{"embed unknown fixture", http.MethodGet, "/admin/sports/fixtures/zz/embed", ``, true, app.ErrFixtureNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeSports{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.name == "embed" && !strings.Contains(rec.Body.String(), `data-fixture=\"f1\"`) {
+				t.Errorf("expected scoreboard markup, got %s", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package style
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/style"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/style"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Rulesets is the part of the style service the endpoints need
+type Rulesets interface {
+	Check(ctx context.Context, language, text string) (*style.Report, error)
+	Create(ctx context.Context, staffID, name, language string, rules []app.RuleInput) (*style.Ruleset, error)
+	Update(ctx context.Context, staffID, id, name, language string, rules []app.RuleInput, enabled bool) (*style.Ruleset, error)
+	Delete(ctx context.Context, staffID, id string) error
+	Get(ctx context.Context, id string) (*style.Ruleset, error)
+	List(ctx context.Context) ([]*style.Ruleset, error)
+}
+
+type checkRequest struct {
+	Language string `json:"language"`
+	Text     string `json:"text"`
+}
+
+type ruleRequest struct {
+	Kind       string `json:"kind"`
+	Pattern    string `json:"pattern,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Severity   string `json:"severity"`
+}
+
+type rulesetRequest struct {
+	Name     string        `json:"name"`
+	Language string        `json:"language"`
+	Rules    []ruleRequest `json:"rules"`
+	Enabled  *bool         `json:"enabled"`
+}
+
+type rulesetResponse struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Language  string        `json:"language,omitempty"`
+	Rules     []ruleRequest `json:"rules"`
+	Enabled   bool          `json:"enabled"`
+	UpdatedBy *string       `json:"updated_by,omitempty"`
+	UpdatedAt string        `json:"updated_at"`
+}
+
+type findingResponse struct {
+	RulesetID  string `json:"ruleset_id"`
+	Kind       string `json:"kind"`
+	Severity   string `json:"severity"`
+	Start      int    `json:"start"`
+	End        int    `json:"end"`
+	Text       string `json:"text"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+type reportResponse struct {
+	Blocked  bool              `json:"blocked"`
+	Blocking int               `json:"blocking"`
+	Warnings int               `json:"warnings"`
+	Findings []findingResponse `json:"findings"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	rulesets Rulesets
+	staff    StaffResolver
+}
+
+func NewHandler(rulesets Rulesets, staff StaffResolver) *Handler {
+	return &Handler{rulesets: rulesets, staff: staff}
+}
+
+// NewAdminRouter mounts the draft check and ruleset management, it must sit behind admin authentication
+func NewAdminRouter(rulesets Rulesets, staff StaffResolver) http.Handler {
+	h := NewHandler(rulesets, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/style/check", h.Check)
+	mux.HandleFunc("GET /admin/style/rulesets", h.List)
+	mux.HandleFunc("POST /admin/style/rulesets", h.Create)
+	mux.HandleFunc("GET /admin/style/rulesets/{id}", h.Get)
+	mux.HandleFunc("PUT /admin/style/rulesets/{id}", h.Update)
+	mux.HandleFunc("DELETE /admin/style/rulesets/{id}", h.Delete)
+	return mux
+}
+
+// Check lints a draft, offsets in the findings are rune positions in the submitted text
+func (h *Handler) Check(w http.ResponseWriter, r *http.Request) {
+	var req checkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	report, err := h.rulesets.Check(r.Context(), req.Language, req.Text)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := reportResponse{
+		Blocked:  report.Blocked(),
+		Blocking: report.Count(style.SeverityBlocking),
+		Warnings: report.Count(style.SeverityWarning),
+		Findings: make([]findingResponse, 0, len(report.Findings)),
+	}
+	for _, f := range report.Findings {
+		resp.Findings = append(resp.Findings, findingResponse{
+			RulesetID:  f.RulesetID,
+			Kind:       string(f.Kind),
+			Severity:   string(f.Severity),
+			Start:      f.Start,
+			End:        f.End,
+			Text:       f.Text,
+			Suggestion: f.Suggestion,
+			Message:    f.Message,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	rulesets, err := h.rulesets.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]rulesetResponse, 0, len(rulesets))
+	for _, rs := range rulesets {
+		resp = append(resp, toRulesetResponse(rs))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	ruleset, err := h.rulesets.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toRulesetResponse(ruleset))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req rulesetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	ruleset, err := h.rulesets.Create(r.Context(), staffID, req.Name, req.Language, req.toInputs())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toRulesetResponse(ruleset))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req rulesetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	enabled := req.Enabled == nil || *req.Enabled
+
+	ruleset, err := h.rulesets.Update(r.Context(), staffID, r.PathValue("id"), req.Name, req.Language, req.toInputs(), enabled)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toRulesetResponse(ruleset))
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	if err := h.rulesets.Delete(r.Context(), staffID, r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrRulesetNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrNotChiefEditor):
+		writeJSON(w, http.StatusForbidden, errorResponse{Error: err.Error()})
+	case errors.Is(err, style.ErrInvalidKind), errors.Is(err, style.ErrInvalidSeverity), errors.Is(err, style.ErrInvalidLanguage),
+		errors.Is(err, style.ErrPatternEmpty), errors.Is(err, style.ErrPatternTooLong), errors.Is(err, style.ErrRulesetNameEmpty),
+		errors.Is(err, style.ErrTooManyRules), errors.Is(err, style.ErrDuplicatePattern):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func (req rulesetRequest) toInputs() []app.RuleInput {
+	inputs := make([]app.RuleInput, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		inputs = append(inputs, app.RuleInput{Kind: rule.Kind, Pattern: rule.Pattern, Suggestion: rule.Suggestion, Message: rule.Message, Severity: rule.Severity})
+	}
+	return inputs
+}
+
+func toRulesetResponse(rs *style.Ruleset) rulesetResponse {
+	resp := rulesetResponse{
+		ID:        rs.ID,
+		Name:      rs.Name,
+		Language:  string(rs.Language),
+		Rules:     make([]ruleRequest, 0, len(rs.Rules)),
+		Enabled:   rs.Enabled,
+		UpdatedBy: rs.LastActionBy,
+		UpdatedAt: rs.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, rule := range rs.Rules {
+		resp.Rules = append(resp.Rules, ruleRequest{Kind: string(rule.Kind), Pattern: rule.Pattern, Suggestion: rule.Suggestion, Message: rule.Message, Severity: string(rule.Severity)})
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,126 @@
+package style
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/style"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/style"
+)
+
+type fakeRulesets struct {
+	enabled bool
+	inputs  []app.RuleInput
+	err     error
+}
+
+func (f *fakeRulesets) Check(ctx context.Context, language, text string) (*style.Report, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	rs, _ := style.NewRuleset("rs-1", "Gaya", style.LanguageIndonesian, []style.Rule{{Kind: style.KindBannedPhrase, Pattern: "analisa", Suggestion: "analisis", Severity: style.SeverityBlocking}}, "chief-1")
+	return style.Lint([]*style.Ruleset{rs}, text, style.Language(language)), nil
+}
+
+func (f *fakeRulesets) Create(ctx context.Context, staffID, name, language string, rules []app.RuleInput) (*style.Ruleset, error) {
+	f.inputs = rules
+	if f.err != nil {
+		return nil, f.err
+	}
+	return style.NewRuleset("rs-1", name, style.Language(language), nil, staffID)
+}
+
+func (f *fakeRulesets) Update(ctx context.Context, staffID, id, name, language string, rules []app.RuleInput, enabled bool) (*style.Ruleset, error) {
+	f.enabled = enabled
+	if f.err != nil {
+		return nil, f.err
+	}
+	return style.NewRuleset(id, name, style.Language(language), nil, staffID)
+}
+
+func (f *fakeRulesets) Delete(ctx context.Context, staffID, id string) error {
+	return f.err
+}
+
+func (f *fakeRulesets) Get(ctx context.Context, id string) (*style.Ruleset, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return style.NewRuleset(id, "Gaya", style.LanguageIndonesian, nil, "chief-1")
+}
+
+func (f *fakeRulesets) List(ctx context.Context) ([]*style.Ruleset, error) {
+	return nil, f.err
+}
+
+func staff(r *http.Request) (string, bool) {
+	return "chief-1", r.Header.Get("Authorization") != ""
+}
+
+func TestHandler_Check(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/style/check", strings.NewReader(`{"language":"id","text":"Hasil analisa"}`))
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeRulesets{}, staff)).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp reportResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if !resp.Blocked || resp.Blocking != 1 || len(resp.Findings) != 1 {
+		t.Fatalf("expected one blocking finding, got %+v", resp)
+	}
+	if f := resp.Findings[0]; f.Start != 6 || f.End != 13 || f.Suggestion != "analisis" {
+		t.Errorf("unexpected finding %+v", f)
+	}
+}
+
+func TestHandler_Rulesets(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"create", http.MethodPost, "/admin/style/rulesets", `{"name":"Gaya","language":"id","rules":[{"kind":"banned_phrase","pattern":"analisa","severity":"warning"}]}`, true, nil, http.StatusCreated},
+		{"create without session", http.MethodPost, "/admin/style/rulesets", `{"name":"Gaya"}`, false, nil, http.StatusUnauthorized},
+		{"create by reporter", http.MethodPost, "/admin/style/rulesets", `{"name":"Gaya"}`, true, app.ErrNotChiefEditor, http.StatusForbidden},
+		{"create invalid rule", http.MethodPost, "/admin/style/rulesets", `{"name":"Gaya"}`, true, fmt.Errorf("rule 1: %w", style.ErrInvalidSeverity), http.StatusUnprocessableEntity},
+		{"update", http.MethodPut, "/admin/style/rulesets/rs-1", `{"name":"Gaya","enabled":false}`, true, nil, http.StatusOK},
+		{"get missing", http.MethodGet, "/admin/style/rulesets/rs-9", ``, true, app.ErrRulesetNotFound, http.StatusNotFound},
+		{"delete", http.MethodDelete, "/admin/style/rulesets/rs-1", ``, true, nil, http.StatusNoContent},
+		{"list failure", http.MethodGet, "/admin/style/rulesets", ``, true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rulesets := &fakeRulesets{err: tc.err}
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(rulesets, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.name == "create" && (len(rulesets.inputs) != 1 || rulesets.inputs[0].Pattern != "analisa") {
+				t.Errorf("expected rules passed to the service, got %+v", rulesets.inputs)
+			}
+			if tc.name == "update" && rulesets.enabled {
+				t.Errorf("expected ruleset disabled")
+			}
+		})
+	}
+}
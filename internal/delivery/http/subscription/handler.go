@@ -0,0 +1,119 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+const dateLayout = "2006-01-02"
+
+// DefaultMetricsDays is the period shown when no range is requested
+const DefaultMetricsDays = 30
+
+// Dunning is the part of the dunning service the growth report needs
+type Dunning interface {
+	Metrics(ctx context.Context, from, to time.Time) (*app.RecoveryMetrics, error)
+}
+
+type metricsResponse struct {
+	From               string           `json:"from"`
+	To                 string           `json:"to"`
+	Opened             int              `json:"opened"`
+	Recovered          int              `json:"recovered"`
+	Exhausted          int              `json:"exhausted"`
+	Canceled           int              `json:"canceled"`
+	StillOpen          int              `json:"still_open"`
+	RecoveryRate       float64          `json:"recovery_rate"`
+	RecoveredAmount    map[string]int64 `json:"recovered_amount"`
+	RecoveredByAttempt map[string]int   `json:"recovered_by_attempt"`
+	AvgHoursToRecover  float64          `json:"avg_hours_to_recover"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	dunning Dunning
+}
+
+func NewHandler(dunning Dunning) *Handler {
+	return &Handler{dunning: dunning}
+}
+
+// NewAdminRouter mounts the dunning recovery report, it must sit behind admin authentication
+func NewAdminRouter(dunning Dunning) http.Handler {
+	h := NewHandler(dunning)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/billing/dunning/metrics", h.Metrics)
+	return mux
+}
+
+// Metrics handles GET /admin/billing/dunning/metrics?from=2025-05-01&to=2025-05-31, by the day the case opened
+func (h *Handler) Metrics(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if raw := q.Get("to"); raw != "" {
+		day, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "to must be a date (YYYY-MM-DD)"})
+			return
+		}
+		to = day.AddDate(0, 0, 1) // Inclusive of the requested day
+	}
+
+	from := to.AddDate(0, 0, -DefaultMetricsDays)
+	if raw := q.Get("from"); raw != "" {
+		day, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "from must be a date (YYYY-MM-DD)"})
+			return
+		}
+		from = day
+	}
+
+	m, err := h.dunning.Metrics(r.Context(), from, to)
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrInvalidMetricsRange):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		case shared.IsTimeout(err):
+			writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "report timed out, narrow the date range"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "failed to load dunning metrics"})
+		}
+		return
+	}
+
+	resp := metricsResponse{
+		From:               from.Format(dateLayout),
+		To:                 to.AddDate(0, 0, -1).Format(dateLayout),
+		Opened:             m.Opened,
+		Recovered:          m.Recovered,
+		Exhausted:          m.Exhausted,
+		Canceled:           m.Canceled,
+		StillOpen:          m.StillOpen,
+		RecoveryRate:       m.RecoveryRate,
+		RecoveredAmount:    m.RecoveredAmount,
+		RecoveredByAttempt: make(map[string]int, len(m.RecoveredByAttempt)),
+		AvgHoursToRecover:  m.AvgHoursToRecover,
+	}
+	for attempt, n := range m.RecoveredByAttempt {
+		resp.RecoveredByAttempt[strconv.Itoa(attempt)] = n
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,72 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+)
+
+type fakeDunning struct {
+	from, to time.Time
+	err      error
+}
+
+func (f *fakeDunning) Metrics(ctx context.Context, from, to time.Time) (*app.RecoveryMetrics, error) {
+	f.from, f.to = from, to
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.RecoveryMetrics{
+		Opened:             10,
+		Recovered:          6,
+		Exhausted:          2,
+		StillOpen:          2,
+		RecoveryRate:       0.75,
+		RecoveredAmount:    map[string]int64{"IDR": 294000},
+		RecoveredByAttempt: map[int]int{1: 4, 2: 2},
+	}, nil
+}
+
+func TestHandler_Metrics(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		err            error
+		expectedStatus int
+	}{
+		{"ok", "/admin/billing/dunning/metrics?from=2025-05-01&to=2025-05-31", nil, http.StatusOK},
+		{"invalid date", "/admin/billing/dunning/metrics?from=last-month", nil, http.StatusBadRequest},
+		{"range too long", "/admin/billing/dunning/metrics?from=2020-01-01", app.ErrInvalidMetricsRange, http.StatusBadRequest},
+		{"store failure", "/admin/billing/dunning/metrics", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dunning := &fakeDunning{err: tc.err}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(dunning)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			if !dunning.from.Equal(time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)) || !dunning.to.Equal(time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Errorf("expected inclusive date range, got %v to %v", dunning.from, dunning.to)
+			}
+			var resp metricsResponse
+			_ = json.NewDecoder(rec.Body).Decode(&resp)
+			if resp.RecoveryRate != 0.75 || resp.RecoveredByAttempt["1"] != 4 || resp.To != "2025-05-31" {
+				t.Errorf("unexpected metrics %+v", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package subscription
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Store notifications are small, anything larger is not from Apple or Google
+const maxNotificationBytes = 64 << 10
+
+// MemberResolver returns the signed-in member's account ID
+type MemberResolver func(r *http.Request) (string, bool)
+
+// Store is the part of the store service the purchase and webhook endpoints need
+type Store interface {
+	ConfirmPurchase(ctx context.Context, accountID string, channel subscription.Channel, receipt subscription.StoreReceipt, at time.Time) (*subscription.Subscription, error)
+	HandleNotification(ctx context.Context, channel subscription.Channel, payload []byte, at time.Time) (*subscription.Subscription, error)
+}
+
+type purchaseRequest struct {
+	Channel   string `json:"channel"`
+	ProductID string `json:"product_id"`
+	Receipt   string `json:"receipt"` // App Store signed transaction or Play purchase token
+}
+
+type subscriptionResponse struct {
+	ID               string `json:"id"`
+	Plan             string `json:"plan"`
+	Interval         string `json:"interval"`
+	Channel          string `json:"channel"`
+	Status           string `json:"status"`
+	PremiumAccess    bool   `json:"premium_access"`
+	CurrentPeriodEnd string `json:"current_period_end"`
+}
+
+type StoreHandler struct {
+	store     Store
+	member    MemberResolver
+	pushToken string
+}
+
+func NewStoreHandler(store Store, member MemberResolver, pushToken string) *StoreHandler {
+	return &StoreHandler{store: store, member: member, pushToken: pushToken}
+}
+
+// NewStoreRouter mounts the in-app purchase confirmation for the mobile apps
+func NewStoreRouter(store Store, member MemberResolver) http.Handler {
+	h := NewStoreHandler(store, member, "")
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /subscriptions/store/purchases", h.ConfirmPurchase)
+	return mux
+}
+
+// NewStoreWebhookRouter mounts the App Store and Play server notifications. App Store
+// payloads are signed by Apple; the Play Pub/Sub push subscription must be configured
+// with ?token=<pushToken> since its body is only trusted after re-reading the purchase.
+func NewStoreWebhookRouter(store Store, pushToken string) http.Handler {
+	h := NewStoreHandler(store, nil, pushToken)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhooks/appstore", h.AppStoreNotification)
+	mux.HandleFunc("POST /webhooks/playstore", h.PlayStoreNotification)
+	return mux
+}
+
+func (h *StoreHandler) ConfirmPurchase(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in required"})
+		return
+	}
+	var req purchaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	sub, err := h.store.ConfirmPurchase(r.Context(), accountID, subscription.Channel(req.Channel), subscription.StoreReceipt{ProductID: req.ProductID, Token: req.Receipt}, time.Now())
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSubscriptionResponse(sub))
+}
+
+func (h *StoreHandler) AppStoreNotification(w http.ResponseWriter, r *http.Request) {
+	h.notification(w, r, subscription.ChannelAppStore)
+}
+
+func (h *StoreHandler) PlayStoreNotification(w http.ResponseWriter, r *http.Request) {
+	if h.pushToken == "" || subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(h.pushToken)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid push token"})
+		return
+	}
+	h.notification(w, r, subscription.ChannelPlayStore)
+}
+
+// notification answers 200 once the event is applied, anything else makes the store retry
+func (h *StoreHandler) notification(w http.ResponseWriter, r *http.Request, channel subscription.Channel) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxNotificationBytes))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	if _, err := h.store.HandleNotification(r.Context(), channel, payload, time.Now()); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeStoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, subscription.ErrInvalidReceipt):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: subscription.ErrInvalidReceipt.Error()})
+	case errors.Is(err, app.ErrUnsupportedChannel), errors.Is(err, app.ErrUnknownProduct), errors.Is(err, subscription.ErrWrongChannel):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrReceiptInUse):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, subscription.ErrPurchasePending):
+		writeJSON(w, http.StatusAccepted, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toSubscriptionResponse(s *subscription.Subscription) subscriptionResponse {
+	return subscriptionResponse{
+		ID:               s.ID,
+		Plan:             s.Plan,
+		Interval:         string(s.Interval),
+		Channel:          string(s.Channel),
+		Status:           string(s.Status),
+		PremiumAccess:    s.HasPremiumAccess(),
+		CurrentPeriodEnd: s.CurrentPeriodEnd.Format(time.RFC3339),
+	}
+}
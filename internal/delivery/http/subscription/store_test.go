@@ -0,0 +1,116 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/subscription"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+)
+
+type fakeStore struct {
+	accountID string
+	channel   subscription.Channel
+	receipt   subscription.StoreReceipt
+	payload   string
+	err       error
+}
+
+func (f *fakeStore) ConfirmPurchase(ctx context.Context, accountID string, channel subscription.Channel, receipt subscription.StoreReceipt, at time.Time) (*subscription.Subscription, error) {
+	f.accountID, f.channel, f.receipt = accountID, channel, receipt
+	if f.err != nil {
+		return nil, f.err
+	}
+	price, _ := payment.NewMoney(4900, "USD")
+	return subscription.NewStoreSubscription("sub-1", accountID, "premium", subscription.IntervalMonthly, *price, channel, "2000000123", at.AddDate(0, 1, 0))
+}
+
+func (f *fakeStore) HandleNotification(ctx context.Context, channel subscription.Channel, payload []byte, at time.Time) (*subscription.Subscription, error) {
+	f.channel, f.payload = channel, string(payload)
+	return nil, f.err
+}
+
+func TestStoreHandler_ConfirmPurchase(t *testing.T) {
+	testCases := []struct {
+		name           string
+		signedIn       bool
+		err            error
+		expectedStatus int
+	}{
+		{"verified", true, nil, http.StatusOK},
+		{"signed out", false, nil, http.StatusUnauthorized},
+		{"forged receipt", true, subscription.ErrInvalidReceipt, http.StatusUnprocessableEntity},
+		{"unknown product", true, app.ErrUnknownProduct, http.StatusBadRequest},
+		{"other account", true, app.ErrReceiptInUse, http.StatusConflict},
+		{"pending", true, subscription.ErrPurchasePending, http.StatusAccepted},
+		{"store down", true, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &fakeStore{err: tc.err}
+			member := func(r *http.Request) (string, bool) { return "member-1", tc.signedIn }
+			body := `{"channel":"app_store","product_id":"premium.monthly","receipt":"signed-transaction"}`
+
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewStoreRouter(store, member)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/subscriptions/store/purchases", strings.NewReader(body)))
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			if store.accountID != "member-1" || store.channel != subscription.ChannelAppStore || store.receipt.Token != "signed-transaction" {
+				t.Errorf("unexpected purchase %s %s %+v", store.accountID, store.channel, store.receipt)
+			}
+			var resp subscriptionResponse
+			_ = json.NewDecoder(rec.Body).Decode(&resp)
+			if resp.Channel != "app_store" || !resp.PremiumAccess {
+				t.Errorf("unexpected response %+v", resp)
+			}
+		})
+	}
+}
+
+func TestStoreHandler_Notifications(t *testing.T) {
+	testCases := []struct {
+		name            string
+		url             string
+		err             error
+		expectedStatus  int
+		expectedChannel subscription.Channel
+	}{
+		{"app store", "/webhooks/appstore", nil, http.StatusOK, subscription.ChannelAppStore},
+		{"play store", "/webhooks/playstore?token=push-secret", nil, http.StatusOK, subscription.ChannelPlayStore},
+		{"play store without token", "/webhooks/playstore", nil, http.StatusUnauthorized, ""},
+		{"play store wrong token", "/webhooks/playstore?token=guess", nil, http.StatusUnauthorized, ""},
+		{"unverifiable payload", "/webhooks/appstore", subscription.ErrInvalidReceipt, http.StatusUnprocessableEntity, subscription.ChannelAppStore},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &fakeStore{err: tc.err}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewStoreWebhookRouter(store, "push-secret")).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tc.url, strings.NewReader(`{"signedPayload":"x"}`)))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+			if store.channel != tc.expectedChannel {
+				t.Errorf("expected channel %q, got %q", tc.expectedChannel, store.channel)
+			}
+			if tc.expectedChannel != "" && store.payload != `{"signedPayload":"x"}` {
+				t.Errorf("expected raw payload to be passed through, got %q", store.payload)
+			}
+		})
+	}
+}
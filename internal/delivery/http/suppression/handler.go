@@ -0,0 +1,271 @@
+package suppression
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the raw webhook body
+const SignatureHeader = "X-Webhook-Signature"
+
+// maxWebhookBody caps a provider callback batch
+const maxWebhookBody = 1 << 20
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Suppressions is the part of the suppression service the endpoints need
+type Suppressions interface {
+	Suppress(ctx context.Context, email account.Email, reason suppression.Reason, source, detail string, at time.Time) (*suppression.Entry, error)
+	Lift(ctx context.Context, email account.Email) error
+	List(ctx context.Context, reason *suppression.Reason, limit, offset int) ([]*suppression.Entry, int64, error)
+	HandleProviderEvents(ctx context.Context, provider string, events []app.ProviderEvent) (int, error)
+}
+
+type suppressRequest struct {
+	Email  string `json:"email"`
+	Detail string `json:"detail"`
+}
+
+// webhookEvent is the normalized callback format, the provider relay maps its own payload onto it
+type webhookEvent struct {
+	Type       string    `json:"type"`
+	BounceType string    `json:"bounce_type"` // hard or soft
+	Email      string    `json:"email"`
+	Detail     string    `json:"detail"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type webhookRequest struct {
+	Events []webhookEvent `json:"events"`
+}
+
+type webhookResponse struct {
+	Received   int `json:"received"`
+	Suppressed int `json:"suppressed"`
+}
+
+type entryResponse struct {
+	Email     string `json:"email"`
+	Reason    string `json:"reason"`
+	Source    string `json:"source"`
+	Detail    string `json:"detail,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Liftable  bool   `json:"liftable"`
+}
+
+type entryListResponse struct {
+	Items []entryResponse `json:"items"`
+	Total int64           `json:"total"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	list    Suppressions
+	staff   StaffResolver
+	secrets map[string][]byte
+}
+
+func NewHandler(list Suppressions, staff StaffResolver, secrets map[string][]byte) *Handler {
+	return &Handler{list: list, staff: staff, secrets: secrets}
+}
+
+// NewAdminRouter mounts suppression list management, it must sit behind admin authentication
+func NewAdminRouter(list Suppressions, staff StaffResolver) http.Handler {
+	h := NewHandler(list, staff, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/email/suppressions", h.List)
+	mux.HandleFunc("POST /admin/email/suppressions", h.Suppress)
+	mux.HandleFunc("DELETE /admin/email/suppressions/{email}", h.Lift)
+	return mux
+}
+
+// NewWebhookRouter mounts the provider bounce and complaint callbacks, one secret per provider
+func NewWebhookRouter(list Suppressions, secrets map[string][]byte) http.Handler {
+	h := NewHandler(list, nil, secrets)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhooks/email/{provider}", h.Webhook)
+	return mux
+}
+
+// List handles GET /admin/email/suppressions?reason=complaint&limit=20&offset=0
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var reason *suppression.Reason
+	if raw := q.Get("reason"); raw != "" {
+		rs := suppression.Reason(raw)
+		reason = &rs
+	}
+	limit, err := intParam(q.Get("limit"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit must be a number"})
+		return
+	}
+	offset, err := intParam(q.Get("offset"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "offset must be a number"})
+		return
+	}
+
+	entries, total, err := h.list.List(r.Context(), reason, limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := entryListResponse{Items: make([]entryResponse, 0, len(entries)), Total: total}
+	for _, e := range entries {
+		resp.Items = append(resp.Items, toEntryResponse(e))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Suppress(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req suppressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	email, err := account.NewEmail(req.Email)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	entry, err := h.list.Suppress(r.Context(), *email, suppression.ReasonManual, staffID, req.Detail, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toEntryResponse(entry))
+}
+
+func (h *Handler) Lift(w http.ResponseWriter, r *http.Request) {
+	email, err := account.NewEmail(r.PathValue("email"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	if err := h.list.Lift(r.Context(), *email); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Webhook verifies the provider signature before touching the list. Soft bounces
+// are acknowledged but ignored, providers retry those themselves.
+func (h *Handler) Webhook(w http.ResponseWriter, r *http.Request) {
+	provider := r.PathValue("provider")
+	secret, ok := h.secrets[provider]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "unknown provider"})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody+1))
+	if err != nil || len(body) > maxWebhookBody {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	if !validSignature(secret, body, r.Header.Get(SignatureHeader)) {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid signature"})
+		return
+	}
+
+	var req webhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	events := make([]app.ProviderEvent, 0, len(req.Events))
+	for _, e := range req.Events {
+		events = append(events, app.ProviderEvent{
+			Type:       app.ProviderEventType(e.Type),
+			Permanent:  e.BounceType == "hard",
+			Email:      e.Email,
+			Detail:     e.Detail,
+			OccurredAt: e.OccurredAt,
+		})
+	}
+
+	n, err := h.list.HandleProviderEvents(r.Context(), provider, events)
+	if err != nil {
+		// A 5xx makes the provider retry the batch, suppressing is idempotent
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, webhookResponse{Received: len(events), Suppressed: n})
+}
+
+func validSignature(secret, body []byte, signature string) bool {
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), given)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrNotSuppressed):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, suppression.ErrCannotLift):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, suppression.ErrInvalidReason):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toEntryResponse(e *suppression.Entry) entryResponse {
+	return entryResponse{
+		Email:     e.Email.String(),
+		Reason:    string(e.Reason),
+		Source:    e.Source,
+		Detail:    e.Detail,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: e.UpdatedAt.Format(time.RFC3339),
+		Liftable:  e.CanLift(),
+	}
+}
+
+func intParam(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
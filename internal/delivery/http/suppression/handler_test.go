@@ -0,0 +1,113 @@
+package suppression
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/suppression"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type fakeList struct {
+	Suppressions
+	provider string
+	events   []app.ProviderEvent
+	liftErr  error
+}
+
+func (f *fakeList) HandleProviderEvents(ctx context.Context, provider string, events []app.ProviderEvent) (int, error) {
+	f.provider, f.events = provider, events
+	return 1, nil
+}
+
+func (f *fakeList) Lift(ctx context.Context, email account.Email) error {
+	return f.liftErr
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+const bounceBody = `{"events":[
+	{"type":"bounce","bounce_type":"hard","email":"gone@example.com","detail":"550 no such user","occurred_at":"2025-09-01T09:00:00Z"},
+	{"type":"bounce","bounce_type":"soft","email":"full@example.com","occurred_at":"2025-09-01T09:00:00Z"}
+]}`
+
+func TestHandler_Webhook(t *testing.T) {
+	testCases := []struct {
+		name           string
+		provider       string
+		signature      string
+		expectedStatus int
+	}{
+		{"signed", "postmark", sign("postmark-secret", bounceBody), http.StatusOK},
+		{"wrong secret", "postmark", sign("other-secret", bounceBody), http.StatusUnauthorized},
+		{"missing signature", "postmark", "", http.StatusUnauthorized},
+		{"unknown provider", "mailgun", sign("postmark-secret", bounceBody), http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			list := &fakeList{}
+			router := openapitest.Handler(t, RegisterSpec, NewWebhookRouter(list, map[string][]byte{"postmark": []byte("postmark-secret")}))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/email/"+tc.provider, strings.NewReader(bounceBody))
+			req.Header.Set(SignatureHeader, tc.signature)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tc.expectedStatus != http.StatusOK {
+				if list.events != nil {
+					t.Error("expected unverified events to be dropped")
+				}
+				return
+			}
+			if list.provider != "postmark" || len(list.events) != 2 || !list.events[0].Permanent || list.events[1].Permanent {
+				t.Errorf("unexpected events from %s: %+v", list.provider, list.events)
+			}
+			if !list.events[0].OccurredAt.Equal(time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)) {
+				t.Errorf("unexpected occurred_at %v", list.events[0].OccurredAt)
+			}
+		})
+	}
+}
+
+func TestHandler_Lift(t *testing.T) {
+	testCases := []struct {
+		name           string
+		email          string
+		err            error
+		expectedStatus int
+	}{
+		{"lifted", "gone@example.com", nil, http.StatusNoContent},
+		{"complaint", "angry@example.com", suppression.ErrCannotLift, http.StatusConflict},
+		{"not suppressed", "reader@example.com", app.ErrNotSuppressed, http.StatusNotFound},
+		{"invalid email", "not-an-address", nil, http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeList{liftErr: tc.err}, func(r *http.Request) (string, bool) { return "staff1", true }))
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/email/suppressions/"+tc.email, nil))
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
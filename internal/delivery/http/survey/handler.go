@@ -0,0 +1,529 @@
+package survey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/survey"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/survey"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// ReaderResolver returns the reader from the visitor cookie and session,
+// false when there is neither
+type ReaderResolver func(r *http.Request) (survey.Reader, bool)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Surveys is the part of the survey service the endpoints need
+type Surveys interface {
+	Create(ctx context.Context, staffID, title string, questions []survey.Question, targeting survey.Targeting, frequency survey.Cap, anonymity survey.Anonymity) (*survey.Survey, error)
+	Edit(ctx context.Context, id, title string, questions []survey.Question, targeting survey.Targeting, frequency survey.Cap, anonymity survey.Anonymity) (*survey.Survey, error)
+	Get(ctx context.Context, id string) (*survey.Survey, error)
+	List(ctx context.Context) ([]*survey.Survey, error)
+	Open(ctx context.Context, id string) (*survey.Survey, error)
+	Close(ctx context.Context, id string) (*survey.Survey, error)
+	Prompt(ctx context.Context, reader survey.Reader, page survey.Page, pinnedID string) (*survey.Survey, error)
+	Respond(ctx context.Context, surveyID string, reader survey.Reader, page survey.Page, answers []survey.Answer) (*survey.Response, error)
+	Dismiss(ctx context.Context, surveyID string, reader survey.Reader) error
+	Results(ctx context.Context, id string, from, to time.Time) (*survey.Summary, error)
+	TextAnswers(ctx context.Context, id, questionID string, from, to time.Time) ([]app.TextAnswer, error)
+}
+
+type questionRequest struct {
+	ID       string   `json:"id"`
+	Kind     string   `json:"kind"`
+	Prompt   string   `json:"prompt"`
+	Choices  []string `json:"choices"`
+	Required bool     `json:"required"`
+}
+
+type targetingRequest struct {
+	Placements    []string `json:"placements"`
+	CategoryIDs   []string `json:"category_ids"`
+	Audience      string   `json:"audience"`
+	SamplePercent int      `json:"sample_percent"`
+}
+
+// surveyRequest leaves the frequency cap at 3 prompts 72 hours apart when
+// it is not given
+type surveyRequest struct {
+	Title      string            `json:"title"`
+	Questions  []questionRequest `json:"questions"`
+	Targeting  targetingRequest  `json:"targeting"`
+	MaxPrompts *int              `json:"max_prompts"`
+	GapHours   *int              `json:"gap_hours"`
+	Anonymity  string            `json:"anonymity"`
+}
+
+type answerRequest struct {
+	QuestionID string `json:"question_id"`
+	Score      *int   `json:"score"`
+	Choice     string `json:"choice"`
+	Text       string `json:"text"`
+}
+
+type respondRequest struct {
+	Placement string          `json:"placement"`
+	ArticleID string          `json:"article_id"`
+	Answers   []answerRequest `json:"answers"`
+}
+
+type questionResponse struct {
+	ID       string   `json:"id"`
+	Kind     string   `json:"kind"`
+	Prompt   string   `json:"prompt"`
+	Choices  []string `json:"choices,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// promptResponse is what the survey block renders, without the targeting
+type promptResponse struct {
+	ID        string             `json:"id"`
+	Title     string             `json:"title"`
+	Questions []questionResponse `json:"questions"`
+}
+
+type targetingResponse struct {
+	Placements    []string `json:"placements"`
+	CategoryIDs   []string `json:"category_ids"`
+	Audience      string   `json:"audience"`
+	SamplePercent int      `json:"sample_percent"`
+}
+
+type surveyResponse struct {
+	ID         string             `json:"id"`
+	Title      string             `json:"title"`
+	Questions  []questionResponse `json:"questions"`
+	Targeting  targetingResponse  `json:"targeting"`
+	MaxPrompts int                `json:"max_prompts"`
+	GapHours   int                `json:"gap_hours"`
+	Anonymity  string             `json:"anonymity"`
+	Status     string             `json:"status"`
+	OpenedAt   *string            `json:"opened_at,omitempty"`
+	ClosedAt   *string            `json:"closed_at,omitempty"`
+	CreatedBy  string             `json:"created_by"`
+	CreatedAt  string             `json:"created_at"`
+	UpdatedAt  string             `json:"updated_at"`
+}
+
+type respondResponse struct {
+	ID string `json:"id"`
+}
+
+type npsResponse struct {
+	Score      float64 `json:"score"`
+	Promoters  int     `json:"promoters"`
+	Passives   int     `json:"passives"`
+	Detractors int     `json:"detractors"`
+}
+
+type questionSummaryResponse struct {
+	QuestionID string         `json:"question_id"`
+	Kind       string         `json:"kind"`
+	Answers    int            `json:"answers"`
+	Counts     map[string]int `json:"counts"`
+	Average    float64        `json:"average,omitempty"`
+	NPS        *npsResponse   `json:"nps,omitempty"`
+}
+
+type resultsResponse struct {
+	SurveyID  string                    `json:"survey_id"`
+	Responses int                       `json:"responses"`
+	Questions []questionSummaryResponse `json:"questions"`
+}
+
+type textAnswerResponse struct {
+	Text        string `json:"text"`
+	SubmittedAt string `json:"submitted_at"`
+}
+
+type embedResponse struct {
+	Markup string `json:"markup"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	surveys Surveys
+	reader  ReaderResolver
+	staff   StaffResolver
+}
+
+func NewHandler(surveys Surveys, reader ReaderResolver, staff StaffResolver) *Handler {
+	return &Handler{surveys: surveys, reader: reader, staff: staff}
+}
+
+// NewRouter mounts the endpoints survey blocks on articles and the homepage call
+func NewRouter(surveys Surveys, reader ReaderResolver) http.Handler {
+	h := NewHandler(surveys, reader, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /surveys/prompt", h.Prompt)
+	mux.HandleFunc("POST /surveys/{id}/responses", h.Respond)
+	mux.HandleFunc("POST /surveys/{id}/dismiss", h.Dismiss)
+	return mux
+}
+
+// NewAdminRouter mounts survey setup, results and embed markup, it must sit behind admin authentication
+func NewAdminRouter(surveys Surveys, staff StaffResolver) http.Handler {
+	h := NewHandler(surveys, nil, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/surveys", h.List)
+	mux.HandleFunc("POST /admin/surveys", h.Create)
+	mux.HandleFunc("GET /admin/surveys/{id}", h.Get)
+	mux.HandleFunc("PUT /admin/surveys/{id}", h.Edit)
+	mux.HandleFunc("POST /admin/surveys/{id}/open", h.Open)
+	mux.HandleFunc("POST /admin/surveys/{id}/close", h.Close)
+	mux.HandleFunc("GET /admin/surveys/{id}/results", h.Results)
+	mux.HandleFunc("GET /admin/surveys/{id}/questions/{questionID}/answers", h.TextAnswers)
+	mux.HandleFunc("GET /admin/surveys/{id}/embed", h.Embed)
+	return mux
+}
+
+// Prompt handles GET /surveys/prompt?placement=article&article_id=...&category_ids=a,b
+// and answers 204 when the reader should not be asked anything. A block
+// embedded in an article passes its survey_id to show that survey.
+func (h *Handler) Prompt(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "private, no-store")
+	reader, ok := h.reader(r)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	q := r.URL.Query()
+	page := survey.Page{Placement: survey.Placement(q.Get("placement")), ArticleID: q.Get("article_id")}
+	for _, id := range strings.Split(q.Get("category_ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			page.CategoryIDs = append(page.CategoryIDs, id)
+		}
+	}
+	if page.Placement != survey.PlacementArticle && page.Placement != survey.PlacementHomepage {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "placement must be article or homepage"})
+		return
+	}
+
+	sv, err := h.surveys.Prompt(r.Context(), reader, page, q.Get("survey_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if sv == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, promptResponse{ID: sv.ID, Title: sv.Title, Questions: toQuestionResponses(sv.Questions)})
+}
+
+func (h *Handler) Respond(w http.ResponseWriter, r *http.Request) {
+	reader, ok := h.reader(r)
+	if !ok {
+		writeError(w, app.ErrUnknownReader)
+		return
+	}
+	var req respondRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	answers := make([]survey.Answer, 0, len(req.Answers))
+	for _, a := range req.Answers {
+		answers = append(answers, survey.Answer{QuestionID: a.QuestionID, Score: a.Score, Choice: a.Choice, Text: a.Text})
+	}
+	page := survey.Page{Placement: survey.Placement(req.Placement), ArticleID: req.ArticleID}
+
+	response, err := h.surveys.Respond(r.Context(), r.PathValue("id"), reader, page, answers)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, respondResponse{ID: response.ID})
+}
+
+func (h *Handler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	reader, ok := h.reader(r)
+	if !ok {
+		writeError(w, app.ErrUnknownReader)
+		return
+	}
+	if err := h.surveys.Dismiss(r.Context(), r.PathValue("id"), reader); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	all, err := h.surveys.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]surveyResponse, 0, len(all))
+	for _, sv := range all {
+		resp = append(resp, toSurveyResponse(sv))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req surveyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	questions, targeting, frequency, err := req.parse()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	sv, err := h.surveys.Create(r.Context(), staffID, req.Title, questions, targeting, frequency, survey.Anonymity(req.Anonymity))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSurveyResponse(sv))
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	sv, err := h.surveys.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSurveyResponse(sv))
+}
+
+// Edit replaces the whole survey, only drafts can be edited
+func (h *Handler) Edit(w http.ResponseWriter, r *http.Request) {
+	var req surveyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	questions, targeting, frequency, err := req.parse()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	sv, err := h.surveys.Edit(r.Context(), r.PathValue("id"), req.Title, questions, targeting, frequency, survey.Anonymity(req.Anonymity))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSurveyResponse(sv))
+}
+
+func (h *Handler) Open(w http.ResponseWriter, r *http.Request) {
+	sv, err := h.surveys.Open(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSurveyResponse(sv))
+}
+
+func (h *Handler) Close(w http.ResponseWriter, r *http.Request) {
+	sv, err := h.surveys.Close(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSurveyResponse(sv))
+}
+
+// Results aggregates responses, from and to are optional inclusive UTC days
+func (h *Handler) Results(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := dateRange(w, r)
+	if !ok {
+		return
+	}
+	summary, err := h.surveys.Results(r.Context(), r.PathValue("id"), from, to)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := resultsResponse{SurveyID: summary.SurveyID, Responses: summary.Responses, Questions: make([]questionSummaryResponse, 0, len(summary.Questions))}
+	for _, q := range summary.Questions {
+		qs := questionSummaryResponse{QuestionID: q.QuestionID, Kind: string(q.Kind), Answers: q.Answers, Counts: q.Counts, Average: q.Average}
+		if q.NPS != nil {
+			qs.NPS = &npsResponse{Score: q.NPS.Score(), Promoters: q.NPS.Promoters, Passives: q.NPS.Passives, Detractors: q.NPS.Detractors}
+		}
+		resp.Questions = append(resp.Questions, qs)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// TextAnswers lists the answers to a free text question, newest first
+func (h *Handler) TextAnswers(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := dateRange(w, r)
+	if !ok {
+		return
+	}
+	answers, err := h.surveys.TextAnswers(r.Context(), r.PathValue("id"), r.PathValue("questionID"), from, to)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]textAnswerResponse, 0, len(answers))
+	for _, a := range answers {
+		resp = append(resp, textAnswerResponse{Text: a.Text, SubmittedAt: a.SubmittedAt.Format(time.RFC3339)})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Embed returns the block editors paste into an article or homepage block to
+// show this survey instead of a targeted one
+func (h *Handler) Embed(w http.ResponseWriter, r *http.Request) {
+	sv, err := h.surveys.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, embedResponse{Markup: survey.SurveyBlock(sv.ID)})
+}
+
+func (req surveyRequest) parse() ([]survey.Question, survey.Targeting, survey.Cap, error) {
+	questions := make([]survey.Question, 0, len(req.Questions))
+	for _, q := range req.Questions {
+		question, err := survey.NewQuestion(q.ID, survey.QuestionKind(q.Kind), q.Prompt, q.Choices, q.Required)
+		if err != nil {
+			return nil, survey.Targeting{}, survey.Cap{}, err
+		}
+		questions = append(questions, question)
+	}
+
+	placements := make([]survey.Placement, 0, len(req.Targeting.Placements))
+	for _, p := range req.Targeting.Placements {
+		placements = append(placements, survey.Placement(p))
+	}
+	targeting, err := survey.NewTargeting(placements, req.Targeting.CategoryIDs, survey.Audience(req.Targeting.Audience), req.Targeting.SamplePercent)
+	if err != nil {
+		return nil, survey.Targeting{}, survey.Cap{}, err
+	}
+
+	maxPrompts, gap := survey.DefaultMaxPrompts, survey.DefaultPromptGap
+	if req.MaxPrompts != nil {
+		maxPrompts = *req.MaxPrompts
+	}
+	if req.GapHours != nil {
+		gap = time.Duration(*req.GapHours) * time.Hour
+	}
+	frequency, err := survey.NewCap(maxPrompts, gap)
+	if err != nil {
+		return nil, survey.Targeting{}, survey.Cap{}, err
+	}
+	return questions, targeting, frequency, nil
+}
+
+// dateRange reads the optional from and to days, writing the error itself
+func dateRange(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	var from, to time.Time
+	var err error
+	if raw := r.URL.Query().Get("from"); raw != "" {
+		if from, err = time.Parse(time.DateOnly, raw); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "from must be a date like 2026-03-01"})
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	to = time.Now().UTC().Truncate(24 * time.Hour)
+	if raw := r.URL.Query().Get("to"); raw != "" {
+		if to, err = time.Parse(time.DateOnly, raw); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "to must be a date like 2026-03-31"})
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	return from, to.AddDate(0, 0, 1), true
+}
+
+// writeError maps domain errors by kind, the code tells e.g. a closed survey
+// from one the reader already answered
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, app.ErrUnknownReader) {
+		// The request carried no visitor cookie or session, not a bad answer
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error(), Code: app.ErrUnknownReader.Code})
+		return
+	}
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error(), Code: de.Code})
+}
+
+func toQuestionResponses(questions []survey.Question) []questionResponse {
+	resp := make([]questionResponse, 0, len(questions))
+	for _, q := range questions {
+		resp = append(resp, questionResponse{ID: q.ID, Kind: string(q.Kind), Prompt: q.Prompt, Choices: q.Choices, Required: q.Required})
+	}
+	return resp
+}
+
+func toSurveyResponse(sv *survey.Survey) surveyResponse {
+	resp := surveyResponse{
+		ID:        sv.ID,
+		Title:     sv.Title,
+		Questions: toQuestionResponses(sv.Questions),
+		Targeting: targetingResponse{
+			Placements:    make([]string, 0, len(sv.Targeting.Placements)),
+			CategoryIDs:   append([]string{}, sv.Targeting.CategoryIDs...),
+			Audience:      string(sv.Targeting.Audience),
+			SamplePercent: sv.Targeting.SamplePercent,
+		},
+		MaxPrompts: sv.Cap.MaxPrompts,
+		GapHours:   int(sv.Cap.Gap / time.Hour),
+		Anonymity:  string(sv.Anonymity),
+		Status:     string(sv.Status),
+		OpenedAt:   formatTime(sv.OpenedAt),
+		ClosedAt:   formatTime(sv.ClosedAt),
+		CreatedBy:  sv.CreatedBy,
+		CreatedAt:  sv.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  sv.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, p := range sv.Targeting.Placements {
+		resp.Targeting.Placements = append(resp.Targeting.Placements, string(p))
+	}
+	return resp
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
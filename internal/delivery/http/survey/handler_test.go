@@ -0,0 +1,255 @@
+package survey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/survey"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/survey"
+)
+
+type fakeSurveys struct {
+	err       error
+	none      bool
+	frequency survey.Cap
+	page      survey.Page
+	pinnedID  string
+}
+
+func (f *fakeSurveys) survey() *survey.Survey {
+	now := time.Now()
+	nps, _ := survey.NewQuestion("nps", survey.KindNPS, "How likely are you to recommend us to a friend?", nil, true)
+	targeting, _ := survey.NewTargeting([]survey.Placement{survey.PlacementHomepage}, nil, survey.AudienceEveryone, 10)
+	frequency, _ := survey.NewCap(survey.DefaultMaxPrompts, survey.DefaultPromptGap)
+	s, _ := survey.NewSurvey("survey-1", "Reader NPS", []survey.Question{nps}, targeting, frequency, survey.AnonymityAnonymous, "pm-1", now)
+	_ = s.Open(now)
+	return s
+}
+
+func (f *fakeSurveys) Create(ctx context.Context, staffID, title string, questions []survey.Question, targeting survey.Targeting, frequency survey.Cap, anonymity survey.Anonymity) (*survey.Survey, error) {
+	f.frequency = frequency
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.survey(), nil
+}
+
+func (f *fakeSurveys) Edit(ctx context.Context, id, title string, questions []survey.Question, targeting survey.Targeting, frequency survey.Cap, anonymity survey.Anonymity) (*survey.Survey, error) {
+	f.frequency = frequency
+	return f.Get(ctx, id)
+}
+
+func (f *fakeSurveys) Get(ctx context.Context, id string) (*survey.Survey, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.survey(), nil
+}
+
+func (f *fakeSurveys) List(ctx context.Context) ([]*survey.Survey, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []*survey.Survey{f.survey()}, nil
+}
+
+func (f *fakeSurveys) Open(ctx context.Context, id string) (*survey.Survey, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeSurveys) Close(ctx context.Context, id string) (*survey.Survey, error) {
+	return f.Get(ctx, id)
+}
+
+func (f *fakeSurveys) Prompt(ctx context.Context, reader survey.Reader, page survey.Page, pinnedID string) (*survey.Survey, error) {
+	f.page, f.pinnedID = page, pinnedID
+	if f.err != nil || f.none {
+		return nil, f.err
+	}
+	return f.survey(), nil
+}
+
+func (f *fakeSurveys) Respond(ctx context.Context, surveyID string, reader survey.Reader, page survey.Page, answers []survey.Answer) (*survey.Response, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &survey.Response{ID: "response-1", SurveyID: surveyID, Answers: answers}, nil
+}
+
+func (f *fakeSurveys) Dismiss(ctx context.Context, surveyID string, reader survey.Reader) error {
+	return f.err
+}
+
+func (f *fakeSurveys) Results(ctx context.Context, id string, from, to time.Time) (*survey.Summary, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &survey.Summary{SurveyID: id, Responses: 4, Questions: []survey.QuestionSummary{{
+		QuestionID: "nps",
+		Kind:       survey.KindNPS,
+		Answers:    4,
+		Counts:     map[string]int{"10": 2, "7": 1, "2": 1},
+		Average:    7.25,
+		NPS:        &survey.NPS{Promoters: 2, Passives: 1, Detractors: 1},
+	}}}, nil
+}
+
+func (f *fakeSurveys) TextAnswers(ctx context.Context, id, questionID string, from, to time.Time) ([]app.TextAnswer, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []app.TextAnswer{{Text: "Fewer ads", SubmittedAt: time.Now()}}, nil
+}
+
+func reader(r *http.Request) (survey.Reader, bool) {
+	return survey.Reader{VisitorID: "v1"}, true
+}
+
+func noReader(r *http.Request) (survey.Reader, bool) {
+	return survey.Reader{}, false
+}
+
+func staff(r *http.Request) (string, bool) {
+	return "pm-1", true
+}
+
+func noStaff(r *http.Request) (string, bool) {
+	return "", false
+}
+
+const surveyBody = `{"title": "Reader NPS", "questions": [{"id": "nps", "kind": "nps", "prompt": "How likely are you to recommend us?", "required": true}], "targeting": {"placements": ["homepage"], "sample_percent": 10}, "anonymity": "anonymous"}`
+
+func TestRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		reader         ReaderResolver
+		err            error
+		none           bool
+		expectedStatus int
+	}{
+		{"prompt", http.MethodGet, "/surveys/prompt?placement=homepage", "", reader, nil, false, http.StatusOK},
+		{"prompt nothing to ask", http.MethodGet, "/surveys/prompt?placement=homepage", "", reader, nil, true, http.StatusNoContent},
+		{"prompt without visitor", http.MethodGet, "/surveys/prompt?placement=homepage", "", noReader, nil, false, http.StatusNoContent},
+		{"prompt unknown placement", http.MethodGet, "/surveys/prompt?placement=sidebar", "", reader, nil, false, http.StatusBadRequest},
+		{"respond", http.MethodPost, "/surveys/survey-1/responses", `{"placement": "homepage", "answers": [{"question_id": "nps", "score": 9}]}`, reader, nil, false, http.StatusCreated},
+		{"respond without visitor", http.MethodPost, "/surveys/survey-1/responses", `{}`, noReader, nil, false, http.StatusBadRequest},
+		{"respond invalid body", http.MethodPost, "/surveys/survey-1/responses", `{"answers":`, reader, nil, false, http.StatusBadRequest},
+		{"respond invalid answer", http.MethodPost, "/surveys/survey-1/responses", `{"answers": [{"question_id": "nps", "score": 11}]}`, reader, survey.ErrInvalidAnswer, false, http.StatusUnprocessableEntity},
+		{"respond twice", http.MethodPost, "/surveys/survey-1/responses", `{"answers": [{"question_id": "nps", "score": 9}]}`, reader, survey.ErrAlreadyAnswered, false, http.StatusConflict},
+		{"respond to closed survey", http.MethodPost, "/surveys/survey-1/responses", `{"answers": [{"question_id": "nps", "score": 9}]}`, reader, survey.ErrNotActive, false, http.StatusConflict},
+		{"respond to unknown survey", http.MethodPost, "/surveys/survey-9/responses", `{}`, reader, app.ErrSurveyNotFound, false, http.StatusNotFound},
+		{"dismiss", http.MethodPost, "/surveys/survey-1/dismiss", "", reader, nil, false, http.StatusNoContent},
+		{"timeout", http.MethodGet, "/surveys/prompt?placement=homepage", "", reader, context.DeadlineExceeded, false, http.StatusGatewayTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakeSurveys{err: tc.err, none: tc.none}, tc.reader)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_Prompt(t *testing.T) {
+	surveys := &fakeSurveys{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(surveys, reader)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/surveys/prompt?placement=article&article_id=a1&category_ids=bisnis,ekonomi&survey_id=survey-1", nil))
+
+	if surveys.page.ArticleID != "a1" || len(surveys.page.CategoryIDs) != 2 || surveys.pinnedID != "survey-1" {
+		t.Errorf("unexpected page %+v pinned %q", surveys.page, surveys.pinnedID)
+	}
+	if rec.Header().Get("Cache-Control") != "private, no-store" {
+		t.Errorf("expected prompts never cached, got %q", rec.Header().Get("Cache-Control"))
+	}
+	var resp map[string]any
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, ok := resp["targeting"]; ok {
+		t.Error("expected the targeting hidden from readers")
+	}
+}
+
+func TestAdminRouter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		staff          StaffResolver
+		err            error
+		expectedStatus int
+	}{
+		{"list", http.MethodGet, "/admin/surveys", "", staff, nil, http.StatusOK},
+		{"create", http.MethodPost, "/admin/surveys", surveyBody, staff, nil, http.StatusCreated},
+		{"create without session", http.MethodPost, "/admin/surveys", surveyBody, noStaff, nil, http.StatusUnauthorized},
+		{"create invalid body", http.MethodPost, "/admin/surveys", `{"title":`, staff, nil, http.StatusBadRequest},
+		{"create invalid question", http.MethodPost, "/admin/surveys", `{"title": "NPS", "questions": [{"id": "q", "kind": "slider", "prompt": "Slide"}], "targeting": {"placements": ["homepage"], "sample_percent": 10}}`, staff, nil, http.StatusUnprocessableEntity},
+		{"create without placements", http.MethodPost, "/admin/surveys", `{"title": "NPS", "targeting": {"sample_percent": 10}}`, staff, nil, http.StatusUnprocessableEntity},
+		{"create zero cap", http.MethodPost, "/admin/surveys", `{"title": "NPS", "targeting": {"placements": ["homepage"], "sample_percent": 10}, "max_prompts": 0}`, staff, nil, http.StatusUnprocessableEntity},
+		{"create invalid anonymity", http.MethodPost, "/admin/surveys", surveyBody, staff, survey.ErrInvalidAnonymity, http.StatusUnprocessableEntity},
+		{"get", http.MethodGet, "/admin/surveys/survey-1", "", staff, nil, http.StatusOK},
+		{"get unknown", http.MethodGet, "/admin/surveys/survey-9", "", staff, app.ErrSurveyNotFound, http.StatusNotFound},
+		{"edit", http.MethodPut, "/admin/surveys/survey-1", surveyBody, staff, nil, http.StatusOK},
+		{"edit open survey", http.MethodPut, "/admin/surveys/survey-1", surveyBody, staff, survey.ErrNotDraft, http.StatusConflict},
+		{"open", http.MethodPost, "/admin/surveys/survey-1/open", "", staff, nil, http.StatusOK},
+		{"close", http.MethodPost, "/admin/surveys/survey-1/close", "", staff, nil, http.StatusOK},
+		{"close closed", http.MethodPost, "/admin/surveys/survey-1/close", "", staff, survey.ErrNotActive, http.StatusConflict},
+		{"results", http.MethodGet, "/admin/surveys/survey-1/results?from=2026-03-01&to=2026-03-31", "", staff, nil, http.StatusOK},
+		{"results invalid date", http.MethodGet, "/admin/surveys/survey-1/results?from=March", "", staff, nil, http.StatusBadRequest},
+		{"text answers", http.MethodGet, "/admin/surveys/survey-1/questions/why/answers", "", staff, nil, http.StatusOK},
+		{"text answers of a score question", http.MethodGet, "/admin/surveys/survey-1/questions/nps/answers", "", staff, app.ErrQuestionNotText, http.StatusUnprocessableEntity},
+		{"embed", http.MethodGet, "/admin/surveys/survey-1/embed", "", staff, nil, http.StatusOK},
+		{"store failure", http.MethodGet, "/admin/surveys", "", staff, errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeSurveys{err: tc.err}, tc.staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminRouter_DefaultCap(t *testing.T) {
+	surveys := &fakeSurveys{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(surveys, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/surveys", strings.NewReader(surveyBody)))
+
+	if surveys.frequency.MaxPrompts != survey.DefaultMaxPrompts || surveys.frequency.Gap != survey.DefaultPromptGap {
+		t.Errorf("expected the default cap, got %+v", surveys.frequency)
+	}
+}
+
+func TestAdminRouter_Results(t *testing.T) {
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeSurveys{}, staff)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/surveys/survey-1/results", nil))
+
+	var resp resultsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Responses != 4 || resp.Questions[0].NPS == nil || resp.Questions[0].NPS.Score != 25 {
+		t.Errorf("expected an NPS of 25, got %+v", resp)
+	}
+}
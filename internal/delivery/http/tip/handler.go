@@ -0,0 +1,351 @@
+package tip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/tip"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/tip"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// maxSubmitBytes bounds the whole multipart body, files plus message and part headers
+const maxSubmitBytes = tip.MaxAttachments*tip.MaxAttachmentSize + 1<<20
+
+// identifyingHeaders are removed before the tip line handlers run
+var identifyingHeaders = []string{"Cookie", "Authorization", "X-Forwarded-For", "X-Real-Ip", "Forwarded", "User-Agent", "Referer", "Via"}
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Tips is the part of the tip service the endpoints need
+type Tips interface {
+	Submit(ctx context.Context, message string, attachments []app.AttachmentInput, at time.Time) (*app.Receipt, error)
+	Check(ctx context.Context, code string) (*app.Conversation, error)
+	Inbox(ctx context.Context, limit, offset int) ([]*tip.Tip, error)
+	Get(ctx context.Context, tipID string) (*tip.Tip, error)
+	Reply(ctx context.Context, editorID, tipID, body string, at time.Time) (*tip.Tip, error)
+	Close(ctx context.Context, editorID, tipID string, at time.Time) (*tip.Tip, error)
+}
+
+type checkRequest struct {
+	Code string `json:"code"`
+}
+
+type replyRequest struct {
+	Body string `json:"body"`
+}
+
+type receiptResponse struct {
+	Code string `json:"code"`
+}
+
+type conversationResponse struct {
+	Replies  []publicReplyResponse `json:"replies"`
+	Closed   bool                  `json:"closed"`
+	NextCode string                `json:"next_code"`
+}
+
+// publicReplyResponse leaves out which editor replied
+type publicReplyResponse struct {
+	Body string `json:"body"`
+	At   string `json:"at"`
+}
+
+type replyResponse struct {
+	Body     string `json:"body"`
+	EditorID string `json:"editor_id"`
+	At       string `json:"at"`
+}
+
+type attachmentResponse struct {
+	Index       int    `json:"index"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type tipResponse struct {
+	ID          string               `json:"id"`
+	Status      string               `json:"status"`
+	ReceivedOn  string               `json:"received_on"`
+	Message     []byte               `json:"sealed_message,omitempty"` // Base64, opened offline with the newsroom key
+	Attachments []attachmentResponse `json:"attachments"`
+	Replies     []replyResponse      `json:"replies"`
+	ClosedBy    *string              `json:"closed_by,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+type Handler struct {
+	tips  Tips
+	staff StaffResolver
+}
+
+func NewHandler(tips Tips, staff StaffResolver) *Handler {
+	return &Handler{tips: tips, staff: staff}
+}
+
+// NewRouter mounts the anonymous tip line. Mount it outside the session, CSRF,
+// rate-limit and access-log middlewares: it authenticates nobody, sets no cookies,
+// and strips the client address and identifying headers before its handlers run.
+func NewRouter(tips Tips) http.Handler {
+	h := NewHandler(tips, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /tips", h.Submit)
+	mux.HandleFunc("POST /tips/check", h.Check)
+	return isolate(mux)
+}
+
+// NewAdminRouter mounts the editors' inbox, it must sit behind admin authentication
+func NewAdminRouter(tips Tips, staff StaffResolver) http.Handler {
+	h := NewHandler(tips, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/tips", h.Inbox)
+	mux.HandleFunc("GET /admin/tips/{id}", h.Get)
+	mux.HandleFunc("GET /admin/tips/{id}/attachments/{index}", h.Attachment)
+	mux.HandleFunc("POST /admin/tips/{id}/replies", h.Reply)
+	mux.HandleFunc("POST /admin/tips/{id}/close", h.Close)
+	return mux
+}
+
+// isolate forgets who is asking, so nothing downstream can log or store it, and
+// keeps responses out of caches and referrers
+func isolate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.Clone(r.Context())
+		r.RemoteAddr = ""
+		for _, header := range identifyingHeaders {
+			r.Header.Del(header)
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Submit reads a multipart form with a "message" field and up to MaxAttachments
+// "attachment" files. Parts are streamed into memory, never spooled to disk
+// where an unstripped file could outlive the request. Filenames are ignored.
+func (h *Handler) Submit(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSubmitBytes)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	var message string
+	var attachments []app.AttachmentInput
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeBodyError(w, err)
+			return
+		}
+		switch part.FormName() {
+		case "message":
+			content, err := io.ReadAll(io.LimitReader(part, utf8.UTFMax*tip.MaxMessageLength+1))
+			if err != nil {
+				writeBodyError(w, err)
+				return
+			}
+			if len(content) > utf8.UTFMax*tip.MaxMessageLength {
+				writeError(w, tip.ErrMessageTooLong)
+				return
+			}
+			message = string(content)
+		case "attachment":
+			if len(attachments) == tip.MaxAttachments {
+				writeError(w, tip.ErrTooManyAttachments)
+				return
+			}
+			contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			content, err := io.ReadAll(io.LimitReader(part, tip.MaxAttachmentSize+1))
+			if err != nil {
+				writeBodyError(w, err)
+				return
+			}
+			attachments = append(attachments, app.AttachmentInput{ContentType: contentType, Content: content})
+		}
+	}
+
+	receipt, err := h.tips.Submit(r.Context(), message, attachments, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, receiptResponse{Code: receipt.Code})
+}
+
+// Check takes the code in the body rather than the URL so it never lands in logs
+func (h *Handler) Check(w http.ResponseWriter, r *http.Request) {
+	var req checkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	conversation, err := h.tips.Check(r.Context(), req.Code)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := conversationResponse{Replies: make([]publicReplyResponse, 0, len(conversation.Replies)), Closed: conversation.Closed, NextCode: conversation.NextCode}
+	for _, reply := range conversation.Replies {
+		resp.Replies = append(resp.Replies, publicReplyResponse{Body: reply.Body, At: reply.At.Format(time.RFC3339)})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Inbox(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	tips, err := h.tips.Inbox(r.Context(), limit, offset)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]tipResponse, 0, len(tips))
+	for _, t := range tips {
+		summary := toTipResponse(t)
+		summary.Message = nil
+		resp = append(resp, summary)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	t, err := h.tips.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTipResponse(t))
+}
+
+// Attachment downloads a sealed attachment as is, editors open it offline
+func (h *Handler) Attachment(w http.ResponseWriter, r *http.Request) {
+	t, err := h.tips.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil || index < 0 || index >= len(t.Attachments) {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "attachment not found"})
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="tip-%s-%d.sealed"`, t.ID, index))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(t.Attachments[index].Sealed)
+}
+
+func (h *Handler) Reply(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req replyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	t, err := h.tips.Reply(r.Context(), editorID, r.PathValue("id"), req.Body, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toTipResponse(t))
+}
+
+func (h *Handler) Close(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+
+	t, err := h.tips.Close(r.Context(), editorID, r.PathValue("id"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTipResponse(t))
+}
+
+func toTipResponse(t *tip.Tip) tipResponse {
+	resp := tipResponse{
+		ID:          t.ID,
+		Status:      string(t.Status),
+		ReceivedOn:  t.ReceivedOn.Format(time.DateOnly),
+		Message:     t.Message,
+		Attachments: make([]attachmentResponse, 0, len(t.Attachments)),
+		Replies:     make([]replyResponse, 0, len(t.Replies)),
+		ClosedBy:    t.ClosedBy,
+	}
+	for i, a := range t.Attachments {
+		resp.Attachments = append(resp.Attachments, attachmentResponse{Index: i, ContentType: a.ContentType, Size: a.Size})
+	}
+	for _, reply := range t.Replies {
+		resp.Replies = append(resp.Replies, replyResponse{Body: reply.Body, EditorID: reply.EditorID, At: reply.At.Format(time.RFC3339)})
+	}
+	return resp
+}
+
+// writeBodyError reports a multipart body that could not be read
+func writeBodyError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: "tip is too large"})
+		return
+	}
+	writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+}
+
+// writeError maps domain errors by kind, the code tells e.g. a closed tip from
+// an attachment the newsroom cannot take
+func writeError(w http.ResponseWriter, err error) {
+	if shared.IsTimeout(err) {
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+		return
+	}
+	de, ok := shared.AsDomainError(err)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+		return
+	}
+	status := http.StatusInternalServerError
+	switch de.Kind {
+	case shared.KindValidation:
+		status = http.StatusUnprocessableEntity
+	case shared.KindNotFound:
+		status = http.StatusNotFound
+	case shared.KindConflict, shared.KindState:
+		status = http.StatusConflict
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error(), Code: de.Code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
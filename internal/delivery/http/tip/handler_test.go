@@ -0,0 +1,243 @@
+package tip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/tip"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/tip"
+)
+
+type fakeTips struct {
+	message     string
+	attachments []app.AttachmentInput
+	code        string
+	tip         *tip.Tip
+	err         error
+}
+
+func (f *fakeTips) Submit(ctx context.Context, message string, attachments []app.AttachmentInput, at time.Time) (*app.Receipt, error) {
+	f.message, f.attachments = message, attachments
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.Receipt{Code: "ABCD-EFGH"}, nil
+}
+
+func (f *fakeTips) Check(ctx context.Context, code string) (*app.Conversation, error) {
+	f.code = code
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.Conversation{Replies: []tip.Reply{{Body: "Can you share the invoice?", EditorID: "editor-1", At: time.Now()}}, NextCode: "IJKL-MNOP"}, nil
+}
+
+func (f *fakeTips) Inbox(ctx context.Context, limit, offset int) ([]*tip.Tip, error) {
+	return []*tip.Tip{f.tip}, f.err
+}
+
+func (f *fakeTips) Get(ctx context.Context, tipID string) (*tip.Tip, error) {
+	return f.tip, f.err
+}
+
+func (f *fakeTips) Reply(ctx context.Context, editorID, tipID, body string, at time.Time) (*tip.Tip, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.tip, f.tip.Reply(editorID, body, at)
+}
+
+func (f *fakeTips) Close(ctx context.Context, editorID, tipID string, at time.Time) (*tip.Tip, error) {
+	return f.tip, f.err
+}
+
+func createTestTip(t *testing.T) *tip.Tip {
+	t.Helper()
+	attachments := []tip.Attachment{{ContentType: tip.ContentTypeJPEG, Size: 120, Sealed: []byte("sealed-photo")}}
+	created, err := tip.NewTip("tip-1", []byte("sealed-message"), attachments, "hash", time.Now())
+	if err != nil {
+		t.Fatalf("failed to create tip: %v", err)
+	}
+	return created
+}
+
+func submitRequest(t *testing.T, message string, files map[string]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField("message", message)
+	for contentType, content := range files {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="attachment"; filename="IMG_0421 budi.jpg"`)
+		header.Set("Content-Type", contentType)
+		part, _ := form.CreatePart(header)
+		_, _ = part.Write([]byte(content))
+	}
+	_ = form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/tips", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+func TestHandler_Submit(t *testing.T) {
+	tips := &fakeTips{}
+	req := submitRequest(t, "The tender was rigged", map[string]string{"image/jpeg; charset=binary": "pixels"})
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	var seen *http.Request
+	rec := httptest.NewRecorder()
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(tips))
+	http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ServeHTTP(w, r)
+		seen = r
+	}).ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp receiptResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.Code != "ABCD-EFGH" || rec.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected an uncached receipt, got %+v with %v", resp, rec.Header())
+	}
+	if tips.message != "The tender was rigged" || len(tips.attachments) != 1 || tips.attachments[0].ContentType != "image/jpeg" {
+		t.Errorf("expected the message and one JPEG, got %q and %+v", tips.message, tips.attachments)
+	}
+	if seen.Header.Get("X-Forwarded-For") == "" {
+		t.Error("expected the caller's request left untouched")
+	}
+
+	testCases := []struct {
+		name           string
+		req            *http.Request
+		err            error
+		expectedStatus int
+	}{
+		{"not multipart", httptest.NewRequest(http.MethodPost, "/tips", strings.NewReader(`{"message":"hi"}`)), nil, http.StatusBadRequest},
+		{"message too long", submitRequest(t, strings.Repeat("a", 4*tip.MaxMessageLength+1), nil), nil, http.StatusUnprocessableEntity},
+		{"unsupported file", submitRequest(t, "hello", map[string]string{"application/pdf": "%PDF"}), tip.ErrUnsupportedAttachment, http.StatusUnprocessableEntity},
+		{"sealer down", submitRequest(t, "hello", nil), errors.New("key not loaded"), http.StatusInternalServerError},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(&fakeTips{err: tc.err})).ServeHTTP(rec, tc.req)
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestIsolate(t *testing.T) {
+	var seen *http.Request
+	handler := isolate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/tips/check", nil)
+	req.Header.Set("X-Real-IP", "203.0.113.9")
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	req.Header.Set("Authorization", "Bearer token")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if seen.RemoteAddr != "" {
+		t.Errorf("expected the client address cleared, got %q", seen.RemoteAddr)
+	}
+	for _, header := range identifyingHeaders {
+		if seen.Header.Get(header) != "" {
+			t.Errorf("expected %s removed", header)
+		}
+	}
+	if rec.Header().Get("Referrer-Policy") != "no-referrer" {
+		t.Errorf("expected no-referrer, got %v", rec.Header())
+	}
+}
+
+func TestHandler_Check(t *testing.T) {
+	tips := &fakeTips{}
+	rec := httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(tips)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tips/check", strings.NewReader(`{"code":"ABCD-EFGH"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "editor-1") {
+		t.Errorf("expected the replying editor hidden from the tipster, got %s", rec.Body.String())
+	}
+	var resp conversationResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if tips.code != "ABCD-EFGH" || len(resp.Replies) != 1 || resp.NextCode != "IJKL-MNOP" {
+		t.Errorf("expected one reply and the next code, got %+v", resp)
+	}
+
+	rec = httptest.NewRecorder()
+	openapitest.Handler(t, RegisterSpec, NewRouter(&fakeTips{err: app.ErrInvalidCode})).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tips/check", strings.NewReader(`{"code":"used"}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestHandler_Admin(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) {
+		return "editor-1", r.Header.Get("X-Staff") != ""
+	}
+	tips := &fakeTips{tip: createTestTip(t)}
+	router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(tips, staff))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/tips", nil))
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "sealed_message") {
+		t.Errorf("expected an inbox without message bodies, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/tips/tip-1/attachments/0", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "sealed-photo" || rec.Header().Get("Content-Type") != "application/octet-stream" {
+		t.Errorf("expected the sealed attachment, got %d: %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/tips/tip-1/attachments/3", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", rec.Code)
+	}
+
+	testCases := []struct {
+		name           string
+		body           string
+		signedIn       bool
+		err            error
+		expectedStatus int
+	}{
+		{"replied", `{"body":"Can you share the invoice?"}`, true, nil, http.StatusCreated},
+		{"no staff session", `{"body":"Hello"}`, false, nil, http.StatusUnauthorized},
+		{"empty reply", `{"body":" "}`, true, nil, http.StatusUnprocessableEntity},
+		{"closed tip", `{"body":"Hello"}`, true, tip.ErrTipClosed, http.StatusConflict},
+		{"unknown tip", `{"body":"Hello"}`, true, app.ErrTipNotFound, http.StatusNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/admin/tips/tip-1/replies", strings.NewReader(tc.body))
+			if tc.signedIn {
+				req.Header.Set("X-Staff", "1")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeTips{tip: createTestTip(t), err: tc.err}, staff)).ServeHTTP(rec, req)
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
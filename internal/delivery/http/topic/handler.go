@@ -0,0 +1,360 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// MemberResolver returns the signed-in member, guests get ok false
+type MemberResolver func(r *http.Request) (string, bool)
+
+// Topics is the part of the topic service the endpoints need
+type Topics interface {
+	Create(ctx context.Context, staffID, slug, name, description string, tags, entities []string) (*topic.Topic, error)
+	Update(ctx context.Context, staffID, id, slug, name, description, metaDescription string, tags, entities []string) (*topic.Topic, error)
+	SetPinned(ctx context.Context, staffID, id string, articleIDs []string) (*topic.Topic, error)
+	Delete(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (*topic.Topic, error)
+	List(ctx context.Context) ([]*topic.Topic, error)
+	Page(ctx context.Context, slug, accountID string, limit, offset int) (*app.Page, error)
+	Follow(ctx context.Context, accountID, slug string, at time.Time) (*topic.Topic, error)
+	Unfollow(ctx context.Context, accountID, slug string) error
+}
+
+type topicRequest struct {
+	Slug            string   `json:"slug"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	MetaDescription string   `json:"meta_description"`
+	Tags            []string `json:"tags"`
+	Entities        []string `json:"entities"`
+}
+
+type pinnedRequest struct {
+	ArticleIDs []string `json:"article_ids"`
+}
+
+type topicResponse struct {
+	ID              string   `json:"id"`
+	Slug            string   `json:"slug"`
+	PreviousSlugs   []string `json:"previous_slugs,omitempty"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description,omitempty"`
+	MetaDescription string   `json:"meta_description,omitempty"`
+	Pinned          []string `json:"pinned"`
+	Tags            []string `json:"tags"`
+	Entities        []string `json:"entities"`
+	UpdatedAt       string   `json:"updated_at"`
+}
+
+type entryResponse struct {
+	ArticleID string `json:"article_id"`
+	Title     string `json:"title"`
+	Excerpt   string `json:"excerpt,omitempty"`
+	URL       string `json:"url"`
+}
+
+// seoResponse carries what the frontend puts in <head>
+type seoResponse struct {
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+	CanonicalURL string `json:"canonical_url"`
+	NextURL      string `json:"next_url,omitempty"`
+}
+
+type pageResponse struct {
+	Slug        string          `json:"slug"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Pinned      []entryResponse `json:"pinned"`
+	Articles    []entryResponse `json:"articles"`
+	Followers   int64           `json:"followers"`
+	Following   bool            `json:"following"`
+	SEO         seoResponse     `json:"seo"`
+}
+
+type followResponse struct {
+	Slug      string `json:"slug"`
+	Following bool   `json:"following"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	topics  Topics
+	staff   StaffResolver
+	member  MemberResolver
+	baseURL string
+}
+
+func NewHandler(topics Topics, staff StaffResolver, member MemberResolver, baseURL string) *Handler {
+	return &Handler{topics: topics, staff: staff, member: member, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// NewRouter mounts topic landing pages and follows.
+// baseURL makes canonical links absolute, e.g. "https://news.example.com".
+func NewRouter(topics Topics, member MemberResolver, baseURL string) http.Handler {
+	h := NewHandler(topics, nil, member, baseURL)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /topics/{slug}", h.Page)
+	mux.HandleFunc("POST /topics/{slug}/follow", h.Follow)
+	mux.HandleFunc("DELETE /topics/{slug}/follow", h.Unfollow)
+	return mux
+}
+
+// NewAdminRouter mounts topic management, it must sit behind admin authentication
+func NewAdminRouter(topics Topics, staff StaffResolver) http.Handler {
+	h := NewHandler(topics, staff, nil, "")
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/topics", h.List)
+	mux.HandleFunc("POST /admin/topics", h.Create)
+	mux.HandleFunc("GET /admin/topics/{id}", h.Get)
+	mux.HandleFunc("PUT /admin/topics/{id}", h.Update)
+	mux.HandleFunc("PUT /admin/topics/{id}/pinned", h.SetPinned)
+	mux.HandleFunc("DELETE /admin/topics/{id}", h.Delete)
+	return mux
+}
+
+// Page handles GET /topics/{slug}?page=2, old slugs redirect permanently to the current one
+func (h *Handler) Page(w http.ResponseWriter, r *http.Request) {
+	pageNumber := 1
+	if raw := r.URL.Query().Get("page"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "page must be a positive number"})
+			return
+		}
+		pageNumber = n
+	}
+	accountID, _ := h.member(r)
+
+	page, err := h.topics.Page(r.Context(), r.PathValue("slug"), accountID, pageSize, (pageNumber-1)*pageSize)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	slug := page.Topic.Slug.Value()
+	if slug != r.PathValue("slug") {
+		target := "/topics/" + slug
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return
+	}
+
+	resp := pageResponse{
+		Slug:        slug,
+		Name:        page.Topic.Name,
+		Description: page.Topic.Description,
+		Pinned:      toEntryResponses(h.baseURL, page.Pinned),
+		Articles:    toEntryResponses(h.baseURL, page.Stream),
+		Followers:   page.Followers,
+		Following:   page.Following,
+		SEO: seoResponse{
+			Title:        page.Topic.Name,
+			Description:  page.Topic.SearchDescription(),
+			CanonicalURL: h.pageURL(slug, pageNumber),
+		},
+	}
+	if len(page.Stream) == pageSize {
+		resp.SEO.NextURL = h.pageURL(slug, pageNumber+1)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Follow(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in required"})
+		return
+	}
+	followed, err := h.topics.Follow(r.Context(), accountID, r.PathValue("slug"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, followResponse{Slug: followed.Slug.Value(), Following: true})
+}
+
+func (h *Handler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	accountID, ok := h.member(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "sign in required"})
+		return
+	}
+	if err := h.topics.Unfollow(r.Context(), accountID, r.PathValue("slug")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	topics, err := h.topics.List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]topicResponse, 0, len(topics))
+	for _, t := range topics {
+		resp = append(resp, toTopicResponse(t))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	found, err := h.topics.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTopicResponse(found))
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req topicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	created, err := h.topics.Create(r.Context(), staffID, req.Slug, req.Name, req.Description, req.Tags, req.Entities)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toTopicResponse(created))
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req topicRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	updated, err := h.topics.Update(r.Context(), staffID, r.PathValue("id"), req.Slug, req.Name, req.Description, req.MetaDescription, req.Tags, req.Entities)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTopicResponse(updated))
+}
+
+// SetPinned replaces the pinned articles, in display order
+func (h *Handler) SetPinned(w http.ResponseWriter, r *http.Request) {
+	staffID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req pinnedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	updated, err := h.topics.SetPinned(r.Context(), staffID, r.PathValue("id"), req.ArticleIDs)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTopicResponse(updated))
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.topics.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+const pageSize = 20
+
+func (h *Handler) pageURL(slug string, page int) string {
+	url := h.baseURL + "/topics/" + slug
+	if page > 1 {
+		url += "?page=" + strconv.Itoa(page)
+	}
+	return url
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrTopicNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrSlugTaken):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, topic.ErrInvalidSlug), errors.Is(err, topic.ErrNameEmpty), errors.Is(err, topic.ErrNameTooLong),
+		errors.Is(err, topic.ErrMetaDescriptionLong), errors.Is(err, topic.ErrTooManyPinned), errors.Is(err, topic.ErrDuplicatePinned),
+		errors.Is(err, topic.ErrEmptyRule):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toEntryResponses(baseURL string, entries []app.Entry) []entryResponse {
+	resp := make([]entryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, entryResponse{
+			ArticleID: e.Summary.Ref.ID,
+			Title:     e.Summary.Title,
+			Excerpt:   e.Summary.Excerpt,
+			URL:       baseURL + e.Path,
+		})
+	}
+	return resp
+}
+
+func toTopicResponse(t *topic.Topic) topicResponse {
+	resp := topicResponse{
+		ID:              t.ID,
+		Slug:            t.Slug.Value(),
+		Name:            t.Name,
+		Description:     t.Description,
+		MetaDescription: t.MetaDescription,
+		Pinned:          append([]string{}, t.Pinned...),
+		Tags:            append([]string{}, t.Rule.Tags...),
+		Entities:        append([]string{}, t.Rule.Entities...),
+		UpdatedAt:       t.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, previous := range t.PreviousSlugs {
+		resp.PreviousSlugs = append(resp.PreviousSlugs, previous.Value())
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
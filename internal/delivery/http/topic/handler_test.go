@@ -0,0 +1,188 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/topic"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/topic"
+)
+
+type fakeTopics struct {
+	topic     *topic.Topic
+	page      *app.Page
+	accountID string
+	offset    int
+	err       error
+}
+
+func (f *fakeTopics) Create(ctx context.Context, staffID, slug, name, description string, tags, entities []string) (*topic.Topic, error) {
+	return f.topic, f.err
+}
+
+func (f *fakeTopics) Update(ctx context.Context, staffID, id, slug, name, description, metaDescription string, tags, entities []string) (*topic.Topic, error) {
+	return f.topic, f.err
+}
+
+func (f *fakeTopics) SetPinned(ctx context.Context, staffID, id string, articleIDs []string) (*topic.Topic, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.topic, f.topic.SetPinned(articleIDs, staffID)
+}
+
+func (f *fakeTopics) Delete(ctx context.Context, id string) error {
+	return f.err
+}
+
+func (f *fakeTopics) Get(ctx context.Context, id string) (*topic.Topic, error) {
+	return f.topic, f.err
+}
+
+func (f *fakeTopics) List(ctx context.Context) ([]*topic.Topic, error) {
+	return []*topic.Topic{f.topic}, f.err
+}
+
+func (f *fakeTopics) Page(ctx context.Context, slug, accountID string, limit, offset int) (*app.Page, error) {
+	f.accountID, f.offset = accountID, offset
+	return f.page, f.err
+}
+
+func (f *fakeTopics) Follow(ctx context.Context, accountID, slug string, at time.Time) (*topic.Topic, error) {
+	f.accountID = accountID
+	return f.topic, f.err
+}
+
+func (f *fakeTopics) Unfollow(ctx context.Context, accountID, slug string) error {
+	f.accountID = accountID
+	return f.err
+}
+
+func createTestTopic(t *testing.T) *topic.Topic {
+	t.Helper()
+	slug, _ := topic.NewSlug("climate")
+	rule, _ := topic.NewRule([]string{"climate"}, nil)
+	created, err := topic.NewTopic("topic-1", *slug, "Climate", "Coverage of the climate crisis", *rule, "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	return created
+}
+
+func member(r *http.Request) (string, bool) {
+	id := r.Header.Get("X-Member")
+	return id, id != ""
+}
+
+func TestHandler_Page(t *testing.T) {
+	created := createTestTopic(t)
+	entry := app.Entry{Summary: topic.ArticleSummary{Ref: permalink.ArticleRef{ID: "a1"}, Title: "Floods"}, Path: "/news/floods"}
+	topics := &fakeTopics{page: &app.Page{Topic: created, Pinned: []app.Entry{entry}, Followers: 12, Following: true}}
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(topics, member, "https://news.example.com/"))
+
+	req := httptest.NewRequest(http.MethodGet, "/topics/climate?page=2", nil)
+	req.Header.Set("X-Member", "member-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if topics.accountID != "member-1" || topics.offset != 20 {
+		t.Errorf("expected page 2 for member-1, got offset %d for '%s'", topics.offset, topics.accountID)
+	}
+	var resp pageResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if resp.SEO.CanonicalURL != "https://news.example.com/topics/climate?page=2" || resp.SEO.Description != "Coverage of the climate crisis" {
+		t.Errorf("unexpected seo %+v", resp.SEO)
+	}
+	if len(resp.Pinned) != 1 || resp.Pinned[0].URL != "https://news.example.com/news/floods" || !resp.Following {
+		t.Errorf("unexpected page %+v", resp)
+	}
+}
+
+func TestHandler_Page_RedirectsOldSlug(t *testing.T) {
+	created := createTestTopic(t)
+	renamed, _ := topic.NewSlug("climate-crisis")
+	_ = created.ChangeSlug(*renamed, "editor-2")
+	router := openapitest.Handler(t, RegisterSpec, NewRouter(&fakeTopics{page: &app.Page{Topic: created}}, member, ""))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/topics/climate?page=3", nil))
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("expected status 301, got %d", rec.Code)
+	}
+	if location := rec.Header().Get("Location"); location != "/topics/climate-crisis?page=3" {
+		t.Errorf("expected redirect to the current slug, got '%s'", location)
+	}
+}
+
+func TestHandler_Follow(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		memberID       string
+		err            error
+		expectedStatus int
+	}{
+		{"follow", http.MethodPost, "member-1", nil, http.StatusOK},
+		{"unfollow", http.MethodDelete, "member-1", nil, http.StatusNoContent},
+		{"guest", http.MethodPost, "", nil, http.StatusUnauthorized},
+		{"unknown topic", http.MethodPost, "member-1", app.ErrTopicNotFound, http.StatusNotFound},
+		{"store failure", http.MethodDelete, "member-1", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			topics := &fakeTopics{topic: createTestTopic(t), err: tc.err}
+			req := httptest.NewRequest(tc.method, "/topics/climate/follow", nil)
+			if tc.memberID != "" {
+				req.Header.Set("X-Member", tc.memberID)
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewRouter(topics, member, "")).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestHandler_SetPinned(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           string
+		err            error
+		expectedStatus int
+	}{
+		{"ok", `{"article_ids":["a1","a2"]}`, nil, http.StatusOK},
+		{"duplicate", `{"article_ids":["a1","a1"]}`, nil, http.StatusUnprocessableEntity},
+		{"too many", `{"article_ids":["a1","a2","a3","a4","a5","a6"]}`, nil, http.StatusUnprocessableEntity},
+		{"unknown topic", `{"article_ids":[]}`, app.ErrTopicNotFound, http.StatusNotFound},
+		{"invalid body", `{`, nil, http.StatusBadRequest},
+	}
+
+	staff := func(r *http.Request) (string, bool) { return "editor-1", true }
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			topics := &fakeTopics{topic: createTestTopic(t), err: tc.err}
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/admin/topics/topic-1/pinned", strings.NewReader(tc.body))
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(topics, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d", tc.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
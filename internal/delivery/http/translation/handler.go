@@ -0,0 +1,264 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/translation"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/translation"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Translations is the part of the translation service the endpoints need
+type Translations interface {
+	Prefill(ctx context.Context, editorID, articleID, target, provider string, at time.Time) (*translation.Draft, error)
+	Draft(ctx context.Context, id string) (*translation.Draft, error)
+	ConfirmBlock(ctx context.Context, editorID, draftID string, index int, at time.Time) (*translation.Draft, error)
+	EditBlock(ctx context.Context, editorID, draftID string, index int, text string, at time.Time) (*translation.Draft, error)
+	Glossary(ctx context.Context, source, target string) (*translation.Glossary, error)
+	SaveGlossary(ctx context.Context, editorID, source, target string, terms []translation.Term, at time.Time) (*translation.Glossary, error)
+}
+
+type prefillRequest struct {
+	Language string `json:"language"`
+	Provider string `json:"provider"` // Empty uses the default provider
+}
+
+type editRequest struct {
+	Text string `json:"text"`
+}
+
+type blockResponse struct {
+	Index             int     `json:"index"`
+	Source            string  `json:"source"`
+	Text              string  `json:"text"`
+	Origin            string  `json:"origin"`
+	NeedsConfirmation bool    `json:"needs_confirmation"`
+	ConfirmedBy       *string `json:"confirmed_by,omitempty"`
+}
+
+type draftResponse struct {
+	ID              string          `json:"id"`
+	GroupID         string          `json:"group_id"`
+	SourceArticleID string          `json:"source_article_id"`
+	SourceLanguage  string          `json:"source_language"`
+	TargetLanguage  string          `json:"target_language"`
+	Provider        string          `json:"provider"`
+	Blocks          []blockResponse `json:"blocks"`
+	Pending         int             `json:"pending"`
+	Body            string          `json:"body"`
+	UpdatedAt       string          `json:"updated_at"`
+}
+
+type termRequest struct {
+	Source string `json:"source"`
+	Target string `json:"target,omitempty"` // Empty keeps the source as written
+}
+
+type glossaryRequest struct {
+	Terms []termRequest `json:"terms"`
+}
+
+type glossaryResponse struct {
+	SourceLanguage string        `json:"source_language"`
+	TargetLanguage string        `json:"target_language"`
+	Terms          []termRequest `json:"terms"`
+	UpdatedBy      *string       `json:"updated_by,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	translations Translations
+	staff        StaffResolver
+}
+
+func NewHandler(translations Translations, staff StaffResolver) *Handler {
+	return &Handler{translations: translations, staff: staff}
+}
+
+// NewAdminRouter mounts machine translation assist and glossaries, it must sit behind admin authentication
+func NewAdminRouter(translations Translations, staff StaffResolver) http.Handler {
+	h := NewHandler(translations, staff)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/articles/{id}/translations", h.Prefill)
+	mux.HandleFunc("GET /admin/translations/{id}", h.Draft)
+	mux.HandleFunc("PUT /admin/translations/{id}/blocks/{index}", h.EditBlock)
+	mux.HandleFunc("POST /admin/translations/{id}/blocks/{index}/confirm", h.ConfirmBlock)
+	mux.HandleFunc("GET /admin/translation-glossaries/{source}/{target}", h.Glossary)
+	mux.HandleFunc("PUT /admin/translation-glossaries/{source}/{target}", h.SaveGlossary)
+	return mux
+}
+
+// Prefill handles POST /admin/articles/{id}/translations, {id} is the source article
+func (h *Handler) Prefill(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req prefillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	draft, err := h.translations.Prefill(r.Context(), editorID, r.PathValue("id"), req.Language, req.Provider, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toDraftResponse(draft))
+}
+
+func (h *Handler) Draft(w http.ResponseWriter, r *http.Request) {
+	draft, err := h.translations.Draft(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDraftResponse(draft))
+}
+
+func (h *Handler) ConfirmBlock(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid block index"})
+		return
+	}
+
+	draft, err := h.translations.ConfirmBlock(r.Context(), editorID, r.PathValue("id"), index, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDraftResponse(draft))
+}
+
+func (h *Handler) EditBlock(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid block index"})
+		return
+	}
+	var req editRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+
+	draft, err := h.translations.EditBlock(r.Context(), editorID, r.PathValue("id"), index, req.Text, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDraftResponse(draft))
+}
+
+func (h *Handler) Glossary(w http.ResponseWriter, r *http.Request) {
+	glossary, err := h.translations.Glossary(r.Context(), r.PathValue("source"), r.PathValue("target"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toGlossaryResponse(glossary))
+}
+
+func (h *Handler) SaveGlossary(w http.ResponseWriter, r *http.Request) {
+	editorID, ok := h.staff(r)
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	var req glossaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	terms := make([]translation.Term, 0, len(req.Terms))
+	for _, t := range req.Terms {
+		terms = append(terms, translation.Term{Source: t.Source, Target: t.Target})
+	}
+
+	glossary, err := h.translations.SaveGlossary(r.Context(), editorID, r.PathValue("source"), r.PathValue("target"), terms, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toGlossaryResponse(glossary))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrArticleNotFound), errors.Is(err, app.ErrDraftNotFound), errors.Is(err, translation.ErrBlockNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrDraftExists), errors.Is(err, translation.ErrNothingToConfirm):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case errors.Is(err, app.ErrUnknownProvider), errors.Is(err, translation.ErrInvalidLanguage), errors.Is(err, translation.ErrSameLanguage),
+		errors.Is(err, translation.ErrInvalidTerm), errors.Is(err, translation.ErrTooManyTerms), errors.Is(err, translation.ErrEmptyTranslation):
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toDraftResponse(d *translation.Draft) draftResponse {
+	resp := draftResponse{
+		ID:              d.ID,
+		GroupID:         d.GroupID,
+		SourceArticleID: d.SourceArticleID,
+		SourceLanguage:  d.SourceLanguage,
+		TargetLanguage:  d.TargetLanguage,
+		Provider:        d.Provider,
+		Blocks:          make([]blockResponse, 0, len(d.Blocks)),
+		Pending:         len(d.Pending()),
+		Body:            d.Body(),
+		UpdatedAt:       d.UpdatedAt.Format(time.RFC3339),
+	}
+	for i, b := range d.Blocks {
+		resp.Blocks = append(resp.Blocks, blockResponse{
+			Index:             i,
+			Source:            b.Source,
+			Text:              b.Text,
+			Origin:            string(b.Origin),
+			NeedsConfirmation: b.NeedsConfirmation(),
+			ConfirmedBy:       b.ConfirmedBy,
+		})
+	}
+	return resp
+}
+
+func toGlossaryResponse(g *translation.Glossary) glossaryResponse {
+	resp := glossaryResponse{SourceLanguage: g.SourceLanguage, TargetLanguage: g.TargetLanguage, Terms: make([]termRequest, 0, len(g.Terms)), UpdatedBy: g.LastActionBy}
+	for _, t := range g.Terms {
+		resp.Terms = append(resp.Terms, termRequest{Source: t.Source, Target: t.Target})
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
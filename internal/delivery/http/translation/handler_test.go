@@ -0,0 +1,111 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/translation"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/translation"
+)
+
+type fakeTranslations struct {
+	err error
+}
+
+func (f *fakeTranslations) draft(editorID string) (*translation.Draft, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	source := &translation.Source{ArticleID: "a1", GroupID: "g1", Language: "id", Body: "<p>Halo</p>"}
+	return translation.NewDraft("d1", source, "en", "deepl", []string{"<p>Hello</p>"}, editorID, time.Now())
+}
+
+func (f *fakeTranslations) Prefill(ctx context.Context, editorID, articleID, target, provider string, at time.Time) (*translation.Draft, error) {
+	return f.draft(editorID)
+}
+
+func (f *fakeTranslations) Draft(ctx context.Context, id string) (*translation.Draft, error) {
+	return f.draft("editor-1")
+}
+
+func (f *fakeTranslations) ConfirmBlock(ctx context.Context, editorID, draftID string, index int, at time.Time) (*translation.Draft, error) {
+	d, err := f.draft(editorID)
+	if err != nil {
+		return nil, err
+	}
+	return d, d.Confirm(index, editorID, at)
+}
+
+func (f *fakeTranslations) EditBlock(ctx context.Context, editorID, draftID string, index int, text string, at time.Time) (*translation.Draft, error) {
+	d, err := f.draft(editorID)
+	if err != nil {
+		return nil, err
+	}
+	return d, d.Edit(index, text, editorID, at)
+}
+
+func (f *fakeTranslations) Glossary(ctx context.Context, source, target string) (*translation.Glossary, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &translation.Glossary{SourceLanguage: source, TargetLanguage: target}, nil
+}
+
+func (f *fakeTranslations) SaveGlossary(ctx context.Context, editorID, source, target string, terms []translation.Term, at time.Time) (*translation.Glossary, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return translation.NewGlossary(source, target, terms, editorID, at)
+}
+
+func TestHandler_Admin(t *testing.T) {
+	staff := func(r *http.Request) (string, bool) { return "editor-1", r.Header.Get("Authorization") != "" }
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           bool
+		err            error
+		expectedStatus int
+	}{
+		{"prefill", http.MethodPost, "/admin/articles/a1/translations", `{"language":"en"}`, true, nil, http.StatusCreated},
+		{"prefill without session", http.MethodPost, "/admin/articles/a1/translations", `{"language":"en"}`, false, nil, http.StatusUnauthorized},
+		{"prefill invalid body", http.MethodPost, "/admin/articles/a1/translations", `{`, true, nil, http.StatusBadRequest},
+		{"draft exists", http.MethodPost, "/admin/articles/a1/translations", `{"language":"en"}`, true, app.ErrDraftExists, http.StatusConflict},
+		{"unknown provider", http.MethodPost, "/admin/articles/a1/translations", `{"language":"en","provider":"x"}`, true, app.ErrUnknownProvider, http.StatusUnprocessableEntity},
+		{"provider down", http.MethodPost, "/admin/articles/a1/translations", `{"language":"en"}`, true, errors.New("503"), http.StatusInternalServerError},
+		{"get draft", http.MethodGet, "/admin/translations/d1", "", true, nil, http.StatusOK},
+		{"missing draft", http.MethodGet, "/admin/translations/d9", "", true, app.ErrDraftNotFound, http.StatusNotFound},
+		{"confirm block", http.MethodPost, "/admin/translations/d1/blocks/0/confirm", "", true, nil, http.StatusOK},
+		{"confirm unknown block", http.MethodPost, "/admin/translations/d1/blocks/5/confirm", "", true, nil, http.StatusNotFound},
+		{"confirm invalid index", http.MethodPost, "/admin/translations/d1/blocks/first/confirm", "", true, nil, http.StatusBadRequest},
+		{"edit block", http.MethodPut, "/admin/translations/d1/blocks/0", `{"text":"<p>Hi</p>"}`, true, nil, http.StatusOK},
+		{"edit empty", http.MethodPut, "/admin/translations/d1/blocks/0", `{"text":" "}`, true, nil, http.StatusUnprocessableEntity},
+		{"get glossary", http.MethodGet, "/admin/translation-glossaries/id/en", "", true, nil, http.StatusOK},
+		{"save glossary", http.MethodPut, "/admin/translation-glossaries/id/en", `{"terms":[{"source":"Kompas"}]}`, true, nil, http.StatusOK},
+		{"same language glossary", http.MethodPut, "/admin/translation-glossaries/id/id", `{"terms":[]}`, true, nil, http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			if tc.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			rec := httptest.NewRecorder()
+			openapitest.Handler(t, RegisterSpec, NewAdminRouter(&fakeTranslations{err: tc.err}, staff)).ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
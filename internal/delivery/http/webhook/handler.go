@@ -0,0 +1,205 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/webhook"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/webhook"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// maxBody caps one inbound payload, wire stories with embedded media references included
+const maxBody = 5 << 20
+
+// StaffResolver returns the signed-in staff member
+type StaffResolver func(r *http.Request) (string, bool)
+
+// Receiver is the part of the webhook service the endpoints need
+type Receiver interface {
+	Receive(ctx context.Context, provider, eventID string, body []byte, at time.Time) (*app.Result, error)
+	Replay(ctx context.Context, id string, at time.Time) (*webhook.Delivery, error)
+	ReplayFailed(ctx context.Context, provider string, at time.Time) (*app.ReplayReport, error)
+	Failed(ctx context.Context, provider string, limit int) ([]*webhook.Delivery, error)
+}
+
+type receiveResponse struct {
+	Status string `json:"status"`
+}
+
+type deliveryResponse struct {
+	ID          string  `json:"id"`
+	Provider    string  `json:"provider"`
+	EventID     string  `json:"event_id"`
+	Status      string  `json:"status"`
+	Attempts    int     `json:"attempts"`
+	LastError   *string `json:"last_error,omitempty"`
+	ReceivedAt  string  `json:"received_at"`
+	ProcessedAt *string `json:"processed_at,omitempty"`
+}
+
+type replayResponse struct {
+	Replayed int `json:"replayed"`
+	Failed   int `json:"failed"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type Handler struct {
+	receiver  Receiver
+	staff     StaffResolver
+	verifiers map[string]Verifier
+}
+
+func NewHandler(receiver Receiver, staff StaffResolver, verifiers map[string]Verifier) *Handler {
+	return &Handler{receiver: receiver, staff: staff, verifiers: verifiers}
+}
+
+// NewWebhookRouter mounts the inbound callbacks, one verifier per provider
+func NewWebhookRouter(receiver Receiver, verifiers map[string]Verifier) http.Handler {
+	h := NewHandler(receiver, nil, verifiers)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhooks/inbound/{provider}", h.Receive)
+	return mux
+}
+
+// NewAdminRouter mounts the failed delivery list and replays, it must sit behind admin authentication
+func NewAdminRouter(receiver Receiver, staff StaffResolver) http.Handler {
+	h := NewHandler(receiver, staff, nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/webhooks/failed", h.Failed)
+	mux.HandleFunc("POST /admin/webhooks/replay", h.ReplayFailed)
+	mux.HandleFunc("POST /admin/webhooks/deliveries/{id}/replay", h.Replay)
+	return mux
+}
+
+// Receive verifies the signature before anything is stored. A processing
+// failure answers 500 so providers that retry do; the payload is archived
+// either way and can be replayed from the admin endpoints.
+func (h *Handler) Receive(w http.ResponseWriter, r *http.Request) {
+	provider := r.PathValue("provider")
+	verifier, ok := h.verifiers[provider]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "unknown provider"})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBody+1))
+	if err != nil || len(body) > maxBody {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body"})
+		return
+	}
+	now := time.Now()
+	eventID, err := verifier.Verify(r, body, now)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid signature"})
+		return
+	}
+
+	result, err := h.receiver.Receive(r.Context(), provider, eventID, body, now)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	status := "processed"
+	if result.Duplicate {
+		status = "duplicate"
+	}
+	writeJSON(w, http.StatusOK, receiveResponse{Status: status})
+}
+
+// Failed handles GET /admin/webhooks/failed?provider=payment-gateway&limit=50
+func (h *Handler) Failed(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "limit must be a number"})
+			return
+		}
+		limit = n
+	}
+
+	deliveries, err := h.receiver.Failed(r.Context(), r.URL.Query().Get("provider"), limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resp := make([]deliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, toDeliveryResponse(d))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ReplayFailed handles POST /admin/webhooks/replay?provider=payment-gateway, all providers without one
+func (h *Handler) ReplayFailed(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.staff(r); !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	report, err := h.receiver.ReplayFailed(r.Context(), r.URL.Query().Get("provider"), time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, replayResponse{Replayed: report.Replayed, Failed: report.Failed})
+}
+
+// Replay processes one failed delivery again. A failure is reported with the
+// delivery so staff can see the new error.
+func (h *Handler) Replay(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.staff(r); !ok {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "staff session required"})
+		return
+	}
+	delivery, err := h.receiver.Replay(r.Context(), r.PathValue("id"), time.Now())
+	if err != nil && (delivery == nil || errors.Is(err, webhook.ErrNotReplayable)) {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDeliveryResponse(delivery))
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, app.ErrUnknownProvider), errors.Is(err, app.ErrDeliveryNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+	case errors.Is(err, webhook.ErrNotReplayable):
+		writeJSON(w, http.StatusConflict, errorResponse{Error: err.Error()})
+	case shared.IsTimeout(err):
+		writeJSON(w, http.StatusGatewayTimeout, errorResponse{Error: "request timed out"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "something went wrong"})
+	}
+}
+
+func toDeliveryResponse(d *webhook.Delivery) deliveryResponse {
+	resp := deliveryResponse{
+		ID:         d.ID,
+		Provider:   d.Provider,
+		EventID:    d.EventID,
+		Status:     string(d.Status),
+		Attempts:   d.Attempts,
+		LastError:  d.LastError,
+		ReceivedAt: d.ReceivedAt.Format(time.RFC3339),
+	}
+	if d.ProcessedAt != nil {
+		at := d.ProcessedAt.Format(time.RFC3339)
+		resp.ProcessedAt = &at
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
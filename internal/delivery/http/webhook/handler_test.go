@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/webhook"
+	"github.com/jokosaputro95/news-portal-cms/internal/delivery/http/openapi/openapitest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/webhook"
+)
+
+type fakeReceiver struct {
+	Receiver
+	received  []string
+	duplicate bool
+	err       error
+	replayed  *webhook.Delivery
+}
+
+func (f *fakeReceiver) Receive(ctx context.Context, provider, eventID string, body []byte, at time.Time) (*app.Result, error) {
+	f.received = append(f.received, provider+"/"+eventID)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &app.Result{Duplicate: f.duplicate}, nil
+}
+
+func (f *fakeReceiver) Replay(ctx context.Context, id string, at time.Time) (*webhook.Delivery, error) {
+	return f.replayed, f.err
+}
+
+const paymentBody = `{"id":"evt_1","type":"payment.succeeded"}`
+
+func testVerifiers() map[string]Verifier {
+	return map[string]Verifier{
+		"wire-agency": HMACVerifier{Secret: []byte("wire-secret"), SignatureHeader: "X-Signature", Prefix: "sha256=", EventIDHeader: "X-Event-ID"},
+		"payments":    TimestampedHMACVerifier{Secret: []byte("pay-secret"), Header: "X-Payment-Signature", Tolerance: 5 * time.Minute},
+	}
+}
+
+func hmacHex(secret, content string) string {
+	return hex.EncodeToString(sign([]byte(secret), []byte(content)))
+}
+
+func TestHandler_Receive(t *testing.T) {
+	now := time.Now().Unix()
+	stamped := func(secret string, at int64) string {
+		return fmt.Sprintf("t=%d,v1=%s", at, hmacHex(secret, fmt.Sprintf("%d.%s", at, paymentBody)))
+	}
+
+	testCases := []struct {
+		name           string
+		provider       string
+		header         string
+		signature      string
+		receiveErr     error
+		duplicate      bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"hmac signed", "wire-agency", "X-Signature", "sha256=" + hmacHex("wire-secret", paymentBody), nil, false, http.StatusOK, `"processed"`},
+		{"redelivery", "wire-agency", "X-Signature", "sha256=" + hmacHex("wire-secret", paymentBody), nil, true, http.StatusOK, `"duplicate"`},
+		{"wrong secret", "wire-agency", "X-Signature", "sha256=" + hmacHex("other", paymentBody), nil, false, http.StatusUnauthorized, "invalid signature"},
+		{"missing prefix", "wire-agency", "X-Signature", hmacHex("wire-secret", paymentBody), nil, false, http.StatusUnauthorized, "invalid signature"},
+		{"timestamped", "payments", "X-Payment-Signature", stamped("pay-secret", now), nil, false, http.StatusOK, `"processed"`},
+		{"stale timestamp", "payments", "X-Payment-Signature", stamped("pay-secret", now-3600), nil, false, http.StatusUnauthorized, "invalid signature"},
+		{"unknown provider", "mailgun", "X-Signature", "sha256=" + hmacHex("wire-secret", paymentBody), nil, false, http.StatusNotFound, "unknown provider"},
+		{"processing failed", "wire-agency", "X-Signature", "sha256=" + hmacHex("wire-secret", paymentBody), errors.New("story import failed"), false, http.StatusInternalServerError, "something went wrong"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receiver := &fakeReceiver{err: tc.receiveErr, duplicate: tc.duplicate}
+			router := openapitest.Handler(t, RegisterSpec, NewWebhookRouter(receiver, testVerifiers()))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/inbound/"+tc.provider, strings.NewReader(paymentBody))
+			req.Header.Set(tc.header, tc.signature)
+			req.Header.Set("X-Event-ID", "story-42")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tc.expectedBody) {
+				t.Errorf("expected body to contain %q, got %s", tc.expectedBody, rec.Body.String())
+			}
+			if rec.Code == http.StatusUnauthorized && len(receiver.received) != 0 {
+				t.Error("expected unverified payloads to be dropped")
+			}
+		})
+	}
+}
+
+func TestHandler_EventID(t *testing.T) {
+	receiver := &fakeReceiver{}
+	router := openapitest.Handler(t, RegisterSpec, NewWebhookRouter(receiver, testVerifiers()))
+
+	stamp := time.Now().Unix()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/inbound/payments", strings.NewReader(paymentBody))
+	req.Header.Set("X-Payment-Signature", fmt.Sprintf("t=%d,v1=%s", stamp, hmacHex("pay-secret", fmt.Sprintf("%d.%s", stamp, paymentBody))))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodPost, "/webhooks/inbound/wire-agency", strings.NewReader(paymentBody))
+	req.Header.Set("X-Signature", "sha256="+hmacHex("wire-secret", paymentBody))
+	req.Header.Set("X-Event-ID", "story-42")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(receiver.received) != 2 || !strings.HasPrefix(receiver.received[0], "payments/sha256:") || receiver.received[1] != "wire-agency/story-42" {
+		t.Errorf("expected the body hash without an event ID header, got %v", receiver.received)
+	}
+}
+
+func TestHandler_Replay(t *testing.T) {
+	failed, _ := webhook.NewDelivery("d1", "payments", "evt_1", "payments/2025/09/01/d1", "", time.Now())
+	failed.Fail(errors.New("ledger unavailable"), time.Now())
+
+	testCases := []struct {
+		name           string
+		signedIn       bool
+		replayed       *webhook.Delivery
+		replayErr      error
+		expectedStatus int
+	}{
+		{"replayed", true, failed, nil, http.StatusOK},
+		{"failed again", true, failed, errors.New("ledger unavailable"), http.StatusOK},
+		{"no session", false, failed, nil, http.StatusUnauthorized},
+		{"not found", true, nil, app.ErrDeliveryNotFound, http.StatusNotFound},
+		{"already processed", true, failed, webhook.ErrNotReplayable, http.StatusConflict},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			receiver := &fakeReceiver{replayed: tc.replayed, err: tc.replayErr}
+			router := openapitest.Handler(t, RegisterSpec, NewAdminRouter(receiver, func(r *http.Request) (string, bool) { return "staff-1", tc.signedIn }))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/webhooks/deliveries/d1/replay", nil)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if rec.Code == http.StatusOK && !strings.Contains(rec.Body.String(), `"last_error":"ledger unavailable"`) {
+				t.Errorf("expected the delivery in the response, got %s", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package churn
+
+import (
+	"strings"
+	"time"
+)
+
+// Assessment is one scoring of a member. Every run adds a new one, so the
+// history shows how a member's risk moved over time.
+type Assessment struct {
+	ID             string
+	AccountID      string
+	SubscriptionID string
+	Score          int
+	Level          Level
+	Factors        []Factor
+	Signals        Signals
+	ScoredAt       time.Time
+}
+
+func NewAssessment(id string, member Member, signals Signals, at time.Time) (*Assessment, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(member.AccountID) == "" {
+		return nil, ErrEmptyAccountID
+	}
+
+	score, factors := Score(signals, at)
+	return &Assessment{
+		ID:             id,
+		AccountID:      member.AccountID,
+		SubscriptionID: member.SubscriptionID,
+		Score:          score,
+		Level:          LevelFor(score),
+		Factors:        factors,
+		Signals:        signals,
+		ScoredAt:       at,
+	}, nil
+}
+
+// Query Methods
+
+func (a *Assessment) IsHigh() bool {
+	return a.Level == LevelHigh
+}
+
+// Delta is the score change since the previous assessment, zero without one
+func (a *Assessment) Delta(previous *Assessment) int {
+	if previous == nil {
+		return 0
+	}
+	return a.Score - previous.Score
+}
+
+// WinBack records a win-back workflow started for a high-risk member
+type WinBack struct {
+	ID           string
+	AccountID    string
+	AssessmentID string
+	Score        int
+	StartedAt    time.Time
+}
+
+func NewWinBack(id string, assessment *Assessment, at time.Time) (*WinBack, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if !assessment.IsHigh() {
+		return nil, ErrNotHighRisk
+	}
+	return &WinBack{ID: id, AccountID: assessment.AccountID, AssessmentID: assessment.ID, Score: assessment.Score, StartedAt: at}, nil
+}
+
+// DueForWinBack reports whether a high-risk assessment should start a
+// workflow, given the member's most recent one (nil when there was none)
+func DueForWinBack(assessment *Assessment, last *WinBack, at time.Time) bool {
+	if !assessment.IsHigh() {
+		return false
+	}
+	return last == nil || at.Sub(last.StartedAt) >= WinBackCooldown
+}
@@ -0,0 +1,69 @@
+package churn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewAssessment(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	member := Member{AccountID: "account-1", SubscriptionID: "sub-1"}
+
+	a, err := NewAssessment("a1", member, Signals{}, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Score != 70 || a.Level != LevelHigh || !a.IsHigh() || len(a.Factors) != 2 || a.SubscriptionID != "sub-1" {
+		t.Errorf("unexpected assessment %+v", a)
+	}
+
+	previous, _ := NewAssessment("a0", member, Signals{Reads: 5, PriorReads: 5, LastLoginAt: &at}, at.AddDate(0, 0, -1))
+	if a.Delta(previous) != 70 || a.Delta(nil) != 0 {
+		t.Errorf("expected delta 70, got %d", a.Delta(previous))
+	}
+
+	if _, err := NewAssessment("", member, Signals{}, at); err != ErrEmptyID {
+		t.Errorf("expected ErrEmptyID, got %v", err)
+	}
+	if _, err := NewAssessment("a2", Member{}, Signals{}, at); err != ErrEmptyAccountID {
+		t.Errorf("expected ErrEmptyAccountID, got %v", err)
+	}
+}
+
+func TestWinBack(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	member := Member{AccountID: "account-1"}
+	high, _ := NewAssessment("a1", member, Signals{}, at)
+	low, _ := NewAssessment("a2", member, Signals{Reads: 10, PriorReads: 10, LastLoginAt: &at}, at)
+
+	if _, err := NewWinBack("w1", low, at); err != ErrNotHighRisk {
+		t.Errorf("expected ErrNotHighRisk, got %v", err)
+	}
+	winBack, err := NewWinBack("w1", high, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if winBack.AccountID != "account-1" || winBack.AssessmentID != "a1" || winBack.Score != 70 {
+		t.Errorf("unexpected win-back %+v", winBack)
+	}
+
+	testCases := []struct {
+		name       string
+		assessment *Assessment
+		last       *WinBack
+		at         time.Time
+		expected   bool
+	}{
+		{"high without win-back", high, nil, at, true},
+		{"low risk", low, nil, at, false},
+		{"within cooldown", high, winBack, at.Add(WinBackCooldown - time.Hour), false},
+		{"after cooldown", high, winBack, at.Add(WinBackCooldown), true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DueForWinBack(tc.assessment, tc.last, tc.at); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package churn
+
+import (
+	"context"
+	"time"
+)
+
+// Member is a membership account with a paying subscription
+type Member struct {
+	AccountID      string
+	SubscriptionID string
+}
+
+// Domain interface for listing members to score (implementation will be in infrastructure layer).
+// ScanMembers pages by account ID after the given cursor, active and past-due subscriptions only.
+type MemberSource interface {
+	ScanMembers(ctx context.Context, afterAccountID string, limit int) ([]Member, error)
+}
+
+// Domain interface for engagement signals (implementation will be in infrastructure layer).
+// Members without any activity are returned with zero signals.
+type SignalSource interface {
+	Signals(ctx context.Context, accountIDs []string, from, to time.Time) (map[string]Signals, error)
+}
+
+type AssessmentRepository interface {
+	// Commands
+	Create(ctx context.Context, assessment *Assessment) error
+
+	// Queries
+	FindLatest(ctx context.Context, accountID string) (*Assessment, error) // nil when never scored
+	FindHistory(ctx context.Context, accountID string, limit int) ([]*Assessment, error)
+	// ScanLatest pages the newest assessment of every member by account ID after the cursor
+	ScanLatest(ctx context.Context, afterAccountID string, limit int) ([]*Assessment, error)
+}
+
+type WinBackRepository interface {
+	// Commands
+	Create(ctx context.Context, winBack *WinBack) error
+
+	// Queries
+	FindLatest(ctx context.Context, accountID string) (*WinBack, error) // nil when none was started
+}
+
+// Domain interface for starting win-back emails (implementation will be in the notification layer)
+type WinBackNotifier interface {
+	StartWinBack(ctx context.Context, assessment *Assessment) error
+}
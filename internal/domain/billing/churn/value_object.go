@@ -0,0 +1,132 @@
+package churn
+
+import (
+	"errors"
+	"time"
+)
+
+// Window is the engagement period each score looks at. Reads are also
+// compared with the window before it to catch members drifting away.
+const Window = 28 * 24 * time.Hour
+
+// Score thresholds, scores run from 0 (engaged) to 100 (about to leave)
+const (
+	MediumThreshold = 40
+	HighThreshold   = 70
+	MaxScore        = 100
+)
+
+// WinBackCooldown is the minimum time between two win-back workflows for one member
+const WinBackCooldown = 60 * 24 * time.Hour
+
+// Domain errors
+var (
+	ErrEmptyID        = errors.New("ID cannot be empty")
+	ErrEmptyAccountID = errors.New("account ID cannot be empty")
+	ErrNotHighRisk    = errors.New("win-back is only started for high-risk members")
+)
+
+type Level string
+
+const (
+	LevelLow    Level = "low"
+	LevelMedium Level = "medium"
+	LevelHigh   Level = "high"
+)
+
+func LevelFor(score int) Level {
+	switch {
+	case score >= HighThreshold:
+		return LevelHigh
+	case score >= MediumThreshold:
+		return LevelMedium
+	}
+	return LevelLow
+}
+
+// Signals are one member's engagement counts for the current Window
+type Signals struct {
+	Reads           int
+	PriorReads      int // Reads in the Window before
+	Logins          int
+	LastLoginAt     *time.Time // nil when the member never signed in
+	NewslettersSent int
+	NewsletterOpens int
+}
+
+// Factor is one reason a score went up, kept so the CRM can show why
+type Factor struct {
+	Code   string
+	Points int
+}
+
+// Factor codes
+const (
+	FactorNoReads          = "no_reads"
+	FactorReadingDecline   = "reading_decline"
+	FactorFewReads         = "few_reads"
+	FactorLoginLapsed      = "login_lapsed"
+	FactorNoLogins         = "no_logins"
+	FactorNewsletterIgnore = "newsletter_ignored"
+)
+
+// Score combines the signals into a churn-risk score and the factors behind
+// it. Each signal contributes a bounded number of points so no single one can
+// make a member high risk on its own.
+func Score(s Signals, at time.Time) (int, []Factor) {
+	var factors []Factor
+	add := func(code string, points int) {
+		if points > 0 {
+			factors = append(factors, Factor{Code: code, Points: points})
+		}
+	}
+
+	// Reading, up to 40 points
+	switch {
+	case s.Reads == 0:
+		add(FactorNoReads, 40)
+	default:
+		if s.PriorReads > s.Reads {
+			drop := float64(s.PriorReads-s.Reads) / float64(s.PriorReads)
+			add(FactorReadingDecline, int(drop*30+0.5))
+		}
+		if s.Reads < 4 {
+			add(FactorFewReads, 10)
+		}
+	}
+
+	// Logins, up to 30 points
+	switch {
+	case s.LastLoginAt == nil:
+		add(FactorNoLogins, 30)
+	default:
+		days := int(at.Sub(*s.LastLoginAt) / (24 * time.Hour))
+		switch {
+		case days >= 30:
+			add(FactorLoginLapsed, 30)
+		case days >= 14:
+			add(FactorLoginLapsed, 20)
+		case days >= 7:
+			add(FactorLoginLapsed, 10)
+		}
+	}
+
+	// Newsletter opens, up to 30 points, only for members who get newsletters
+	if s.NewslettersSent > 0 {
+		rate := float64(s.NewsletterOpens) / float64(s.NewslettersSent)
+		switch {
+		case rate == 0:
+			add(FactorNewsletterIgnore, 30)
+		case rate < 0.1:
+			add(FactorNewsletterIgnore, 20)
+		case rate < 0.25:
+			add(FactorNewsletterIgnore, 10)
+		}
+	}
+
+	score := 0
+	for _, f := range factors {
+		score += f.Points
+	}
+	return min(score, MaxScore), factors
+}
@@ -0,0 +1,78 @@
+package churn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) *time.Time {
+		t := at.AddDate(0, 0, -n)
+		return &t
+	}
+
+	testCases := []struct {
+		name            string
+		signals         Signals
+		expectedScore   int
+		expectedLevel   Level
+		expectedFactors []string
+	}{
+		{"engaged member", Signals{Reads: 30, PriorReads: 25, Logins: 20, LastLoginAt: daysAgo(1), NewslettersSent: 8, NewsletterOpens: 6}, 0, LevelLow, nil},
+		{"no newsletters is not a signal", Signals{Reads: 10, PriorReads: 10, LastLoginAt: daysAgo(2)}, 0, LevelLow, nil},
+		{"reading halved", Signals{Reads: 10, PriorReads: 20, LastLoginAt: daysAgo(2)}, 15, LevelLow, []string{FactorReadingDecline}},
+		{
+			"drifting away",
+			Signals{Reads: 2, PriorReads: 20, LastLoginAt: daysAgo(15), NewslettersSent: 8, NewsletterOpens: 1},
+			27 + 10 + 20 + 10, LevelMedium,
+			[]string{FactorReadingDecline, FactorFewReads, FactorLoginLapsed, FactorNewsletterIgnore},
+		},
+		{
+			"gone quiet",
+			Signals{Reads: 0, PriorReads: 12, LastLoginAt: daysAgo(40), NewslettersSent: 8},
+			100, LevelHigh,
+			[]string{FactorNoReads, FactorLoginLapsed, FactorNewsletterIgnore},
+		},
+		{"never signed in", Signals{Reads: 0}, 70, LevelHigh, []string{FactorNoReads, FactorNoLogins}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			score, factors := Score(tc.signals, at)
+			if score != tc.expectedScore {
+				t.Errorf("expected score %d, got %d (%+v)", tc.expectedScore, score, factors)
+			}
+			if level := LevelFor(score); level != tc.expectedLevel {
+				t.Errorf("expected level %s, got %s", tc.expectedLevel, level)
+			}
+			if len(factors) != len(tc.expectedFactors) {
+				t.Fatalf("expected factors %v, got %+v", tc.expectedFactors, factors)
+			}
+			for i, f := range factors {
+				if f.Code != tc.expectedFactors[i] || f.Points <= 0 {
+					t.Errorf("expected factor %s, got %+v", tc.expectedFactors[i], f)
+				}
+			}
+		})
+	}
+}
+
+func TestLevelFor(t *testing.T) {
+	testCases := []struct {
+		score    int
+		expected Level
+	}{
+		{0, LevelLow},
+		{MediumThreshold - 1, LevelLow},
+		{MediumThreshold, LevelMedium},
+		{HighThreshold - 1, LevelMedium},
+		{HighThreshold, LevelHigh},
+		{MaxScore, LevelHigh},
+	}
+	for _, tc := range testCases {
+		if got := LevelFor(tc.score); got != tc.expected {
+			t.Errorf("expected %d to be %s, got %s", tc.score, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package experiment
+
+import (
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusDraft   Status = "draft"
+	StatusRunning Status = "running"
+	StatusEnded   Status = "ended"
+)
+
+// EndReason says why an experiment ended
+type EndReason string
+
+const (
+	EndScheduled EndReason = "scheduled" // Reached its end date
+	EndStopped   EndReason = "stopped"   // Stopped early by a product manager
+)
+
+// Experiment drops the paywall on some articles or categories for a holdout
+// share of visitors, so conversions with and without it can be compared.
+// Visitors are split by Bucket, the same visitor always lands in the same
+// group. An experiment stops holding anyone out at EndsAt, even before the
+// end job marks it ended.
+type Experiment struct {
+	ID             string
+	Name           string
+	Hypothesis     string
+	Targets        Targets
+	HoldoutPercent int
+	Status         Status
+	StartedAt      *time.Time
+	EndsAt         time.Time
+	EndedAt        *time.Time
+	EndReason      *EndReason
+
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewExperiment(id, name, hypothesis string, targets Targets, holdoutPercent int, endsAt time.Time, createdBy string, at time.Time) (*Experiment, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, ErrEmptyActorID
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if len(targets.ArticleIDs) == 0 && len(targets.CategoryIDs) == 0 {
+		return nil, ErrNoTargets
+	}
+	if holdoutPercent < 1 || holdoutPercent > MaxHoldoutPercent {
+		return nil, ErrInvalidHoldout
+	}
+	if !endsAt.After(at) {
+		return nil, ErrInvalidSchedule
+	}
+	return &Experiment{
+		ID:             id,
+		Name:           name,
+		Hypothesis:     strings.TrimSpace(hypothesis),
+		Targets:        targets,
+		HoldoutPercent: holdoutPercent,
+		Status:         StatusDraft,
+		EndsAt:         endsAt,
+		CreatedBy:      createdBy,
+		CreatedAt:      at,
+		UpdatedAt:      at,
+	}, nil
+}
+
+// Business Methods
+
+// Start begins holding visitors out. Overlap with other running experiments
+// is checked by the caller, who can see them.
+func (e *Experiment) Start(at time.Time) error {
+	if e.Status != StatusDraft {
+		return ErrNotDraft
+	}
+	if !e.EndsAt.After(at) || e.EndsAt.Sub(at) > MaxDuration {
+		return ErrInvalidSchedule
+	}
+	e.Status = StatusRunning
+	e.StartedAt = &at
+	e.UpdatedAt = at
+	return nil
+}
+
+func (e *Experiment) End(reason EndReason, at time.Time) error {
+	if e.Status != StatusRunning {
+		return ErrNotRunning
+	}
+	if reason == EndScheduled && e.EndsAt.Before(at) {
+		at = e.EndsAt // The job ran late, holding out stopped at EndsAt
+	}
+	e.Status = StatusEnded
+	e.EndedAt = &at
+	e.EndReason = &reason
+	e.UpdatedAt = at
+	return nil
+}
+
+// Query Methods
+
+// Active reports whether the experiment is holding visitors out at the time
+func (e *Experiment) Active(at time.Time) bool {
+	return e.Status == StatusRunning && at.Before(e.EndsAt)
+}
+
+// DueToEnd reports a running experiment past its end date
+func (e *Experiment) DueToEnd(at time.Time) bool {
+	return e.Status == StatusRunning && !at.Before(e.EndsAt)
+}
+
+// Assign returns the visitor's group
+func (e *Experiment) Assign(visitorID string) Group {
+	if Bucket(e.ID, visitorID) < e.HoldoutPercent*BucketCount/100 {
+		return GroupHoldout
+	}
+	return GroupControl
+}
+
+// Exposure is a visitor's first look at an article the experiment targets.
+// AccountID is empty for guests, a guest who subscribes later is matched by
+// VisitorID.
+type Exposure struct {
+	ExperimentID string
+	VisitorID    string
+	AccountID    string
+	Group        Group
+	ArticleID    string
+	At           time.Time
+}
+
+// Conversion is a subscription started by an exposed visitor within the ConversionWindow
+type Conversion struct {
+	ExperimentID   string
+	SubscriptionID string
+	VisitorID      string
+	AccountID      string
+	Group          Group
+	At             time.Time
+}
+
+// Convert credits a subscription to the exposure's group, false once the window has passed
+func (x Exposure) Convert(subscriptionID, accountID string, at time.Time) (Conversion, bool) {
+	if at.Before(x.At) || at.Sub(x.At) > ConversionWindow {
+		return Conversion{}, false
+	}
+	return Conversion{
+		ExperimentID:   x.ExperimentID,
+		SubscriptionID: subscriptionID,
+		VisitorID:      x.VisitorID,
+		AccountID:      accountID,
+		Group:          x.Group,
+		At:             at,
+	}, true
+}
@@ -0,0 +1,111 @@
+package experiment
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+func newTestExperiment(t *testing.T, holdout int, endsAt time.Time) *Experiment {
+	t.Helper()
+	targets, _ := NewTargets(nil, []string{"opini"})
+	e, err := NewExperiment("exp-1", "Free opinion", "Opinion drives few subscriptions", targets, holdout, endsAt, "pm-1", testNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return e
+}
+
+func TestNewExperiment(t *testing.T) {
+	targets, _ := NewTargets([]string{"a1"}, nil)
+	tests := []struct {
+		name    string
+		title   string
+		targets Targets
+		holdout int
+		endsAt  time.Time
+		err     error
+	}{
+		{"valid", "Free opinion", targets, 10, testNow.AddDate(0, 0, 14), nil},
+		{"empty name", " ", targets, 10, testNow.AddDate(0, 0, 14), ErrEmptyName},
+		{"no targets", "Free opinion", Targets{}, 10, testNow.AddDate(0, 0, 14), ErrNoTargets},
+		{"no holdout", "Free opinion", targets, 0, testNow.AddDate(0, 0, 14), ErrInvalidHoldout},
+		{"holdout too large", "Free opinion", targets, 60, testNow.AddDate(0, 0, 14), ErrInvalidHoldout},
+		{"ends in the past", "Free opinion", targets, 10, testNow, ErrInvalidSchedule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewExperiment("exp-1", tt.title, "", tt.targets, tt.holdout, tt.endsAt, "pm-1", testNow)
+			if err != tt.err {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestExperiment_Lifecycle(t *testing.T) {
+	tooLong := newTestExperiment(t, 10, testNow.Add(MaxDuration+time.Hour))
+	if err := tooLong.Start(testNow); err != ErrInvalidSchedule {
+		t.Errorf("expected ErrInvalidSchedule, got %v", err)
+	}
+
+	e := newTestExperiment(t, 10, testNow.AddDate(0, 0, 14))
+	if e.Active(testNow) {
+		t.Error("expected a draft to hold nobody out")
+	}
+	if err := e.Start(testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := e.Start(testNow); err != ErrNotDraft {
+		t.Errorf("expected ErrNotDraft, got %v", err)
+	}
+	if !e.Active(testNow.AddDate(0, 0, 13)) || e.Active(e.EndsAt) || !e.DueToEnd(e.EndsAt) {
+		t.Error("expected the experiment to stop holding out at its end date")
+	}
+
+	late := e.EndsAt.Add(6 * time.Hour)
+	if err := e.End(EndScheduled, late); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Status != StatusEnded || !e.EndedAt.Equal(e.EndsAt) || *e.EndReason != EndScheduled {
+		t.Errorf("expected the end recorded at the end date, got %+v", e)
+	}
+	if err := e.End(EndStopped, late); err != ErrNotRunning {
+		t.Errorf("expected ErrNotRunning, got %v", err)
+	}
+}
+
+func TestExperiment_Assign(t *testing.T) {
+	e := newTestExperiment(t, 20, testNow.AddDate(0, 0, 14))
+	held := 0
+	for i := 0; i < 5000; i++ {
+		visitor := fmt.Sprintf("visitor-%d", i)
+		group := e.Assign(visitor)
+		if group != e.Assign(visitor) {
+			t.Fatal("expected a visitor to stay in their group")
+		}
+		if group == GroupHoldout {
+			held++
+		}
+	}
+	if held < 900 || held > 1100 {
+		t.Errorf("expected about 20%% held out, got %d of 5000", held)
+	}
+}
+
+func TestExposure_Convert(t *testing.T) {
+	x := Exposure{ExperimentID: "exp-1", VisitorID: "v1", Group: GroupHoldout, ArticleID: "a1", At: testNow}
+
+	c, ok := x.Convert("sub-1", "member-1", testNow.AddDate(0, 0, 3))
+	if !ok || c.Group != GroupHoldout || c.AccountID != "member-1" || c.SubscriptionID != "sub-1" {
+		t.Errorf("unexpected conversion %+v", c)
+	}
+	if _, ok := x.Convert("sub-1", "member-1", testNow.Add(ConversionWindow+time.Hour)); ok {
+		t.Error("expected no conversion after the window")
+	}
+	if _, ok := x.Convert("sub-1", "member-1", testNow.Add(-time.Hour)); ok {
+		t.Error("expected no conversion before the exposure")
+	}
+}
@@ -0,0 +1,34 @@
+package experiment
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interface for paywall experiments (implementation will be in infrastructure layer)
+type ExperimentRepository interface {
+	Create(ctx context.Context, e *Experiment) error
+	Update(ctx context.Context, e *Experiment) error
+	FindByID(ctx context.Context, id string) (*Experiment, error) // nil when not found
+	// FindAll returns every experiment, newest first
+	FindAll(ctx context.Context) ([]*Experiment, error)
+	// FindRunning is read on every paywalled request, implementations should cache it briefly
+	FindRunning(ctx context.Context) ([]*Experiment, error)
+}
+
+// Domain interface for exposures (implementation will be in infrastructure layer)
+type ExposureRepository interface {
+	// Record keeps the first exposure of each visitor per experiment, later ones are ignored.
+	// A later exposure with an account fills in the account of a guest exposure.
+	Record(ctx context.Context, x Exposure) error
+	// FindForReader returns exposures since the time matching the visitor or the account
+	FindForReader(ctx context.Context, visitorID, accountID string, since time.Time) ([]Exposure, error)
+	CountVisitors(ctx context.Context, experimentID string) (map[Group]int, error)
+}
+
+// Domain interface for conversions (implementation will be in infrastructure layer)
+type ConversionRepository interface {
+	// Create ignores a second conversion of the same subscription in the same experiment
+	Create(ctx context.Context, c Conversion) error
+	CountConversions(ctx context.Context, experimentID string) (map[Group]int, error)
+}
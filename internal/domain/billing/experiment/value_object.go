@@ -0,0 +1,139 @@
+package experiment
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Experiment limits
+const (
+	MaxHoldoutPercent = 50                  // At least half the readers keep the normal paywall
+	MaxDuration       = 90 * 24 * time.Hour // Every experiment ends on its own, at the latest after this
+	BucketCount       = 10000               // Visitor buckets, a holdout percent covers 100 of them
+)
+
+// ConversionWindow is how long after first seeing an experiment's article a
+// new subscription still counts as a conversion of that experiment
+const ConversionWindow = 30 * 24 * time.Hour
+
+// Domain errors
+var (
+	ErrEmptyID         = shared.NewDomainError("experiment.id_required", shared.KindValidation, "experiment ID cannot be empty")
+	ErrEmptyActorID    = shared.NewDomainError("experiment.actor_required", shared.KindValidation, "actor ID cannot be empty")
+	ErrEmptyName       = shared.NewDomainError("experiment.name_required", shared.KindValidation, "experiment name cannot be empty")
+	ErrNoTargets       = shared.NewDomainError("experiment.targets_required", shared.KindValidation, "experiment must target at least one article or category")
+	ErrInvalidHoldout  = shared.NewDomainError("experiment.invalid_holdout", shared.KindValidation, "holdout must be between 1 and 50 percent of visitors")
+	ErrInvalidSchedule = shared.NewDomainError("experiment.invalid_schedule", shared.KindValidation, "experiment must end after it starts and run at most 90 days")
+	ErrNotDraft        = shared.NewDomainError("experiment.not_draft", shared.KindState, "experiment has already started")
+	ErrNotRunning      = shared.NewDomainError("experiment.not_running", shared.KindState, "experiment is not running")
+	ErrTargetTaken     = shared.NewDomainError("experiment.target_taken", shared.KindConflict, "another running experiment targets the same article or category")
+)
+
+// Group is the side of an experiment a visitor falls on
+type Group string
+
+const (
+	GroupControl Group = "control" // Paywall as usual
+	GroupHoldout Group = "holdout" // Targeted articles are free
+)
+
+// Bucket places a visitor in one of BucketCount buckets. It is salted with the
+// experiment so a visitor held out of one experiment is not held out of all.
+func Bucket(experimentID, visitorID string) int {
+	sum := sha256.Sum256([]byte(experimentID + ":" + visitorID))
+	return int(binary.BigEndian.Uint64(sum[:8]) % BucketCount)
+}
+
+// Target is the article a reader opened
+type Target struct {
+	ArticleID   string
+	CategoryIDs []string // The article's category and its ancestors
+}
+
+// Targets value object, the articles and whole categories an experiment makes free for its holdout
+type Targets struct {
+	ArticleIDs  []string
+	CategoryIDs []string
+}
+
+func NewTargets(articleIDs, categoryIDs []string) (Targets, error) {
+	t := Targets{ArticleIDs: compact(articleIDs), CategoryIDs: compact(categoryIDs)}
+	if len(t.ArticleIDs) == 0 && len(t.CategoryIDs) == 0 {
+		return Targets{}, ErrNoTargets
+	}
+	return t, nil
+}
+
+// Matches reports whether the article is targeted itself or through a category
+func (t Targets) Matches(target Target) bool {
+	if slices.Contains(t.ArticleIDs, target.ArticleID) {
+		return true
+	}
+	for _, id := range target.CategoryIDs {
+		if slices.Contains(t.CategoryIDs, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps reports a shared article or category. Articles of a targeted category
+// are not checked, the caller cannot list them all.
+func (t Targets) Overlaps(other Targets) bool {
+	for _, id := range other.ArticleIDs {
+		if slices.Contains(t.ArticleIDs, id) {
+			return true
+		}
+	}
+	for _, id := range other.CategoryIDs {
+		if slices.Contains(t.CategoryIDs, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupResult is how one group did
+type GroupResult struct {
+	Group       Group
+	Visitors    int // Distinct visitors exposed to a targeted article
+	Conversions int // Subscriptions started within the ConversionWindow
+}
+
+func (g GroupResult) Rate() float64 {
+	if g.Visitors == 0 {
+		return 0
+	}
+	return float64(g.Conversions) / float64(g.Visitors)
+}
+
+// Results compares the groups of one experiment
+type Results struct {
+	ExperimentID string
+	Control      GroupResult
+	Holdout      GroupResult
+}
+
+// Lift is the holdout's conversion rate relative to the control's, e.g. -0.25
+// when dropping the paywall cost a quarter of the conversions. Zero without a
+// control rate to compare with.
+func (r Results) Lift() float64 {
+	if r.Control.Rate() == 0 {
+		return 0
+	}
+	return r.Holdout.Rate()/r.Control.Rate() - 1
+}
+
+func compact(ids []string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" && !slices.Contains(out, id) {
+			out = append(out, id)
+		}
+	}
+	return out
+}
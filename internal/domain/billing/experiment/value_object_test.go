@@ -0,0 +1,82 @@
+package experiment
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestBucket(t *testing.T) {
+	if Bucket("exp-1", "visitor-1") != Bucket("exp-1", "visitor-1") {
+		t.Error("expected a visitor to keep their bucket")
+	}
+
+	// Buckets spread evenly and differ between experiments
+	low, moved := 0, 0
+	for i := 0; i < 10000; i++ {
+		visitor := fmt.Sprintf("visitor-%d", i)
+		b := Bucket("exp-1", visitor)
+		if b < 0 || b >= BucketCount {
+			t.Fatalf("bucket %d out of range", b)
+		}
+		if b < BucketCount/10 {
+			low++
+		}
+		if (b < BucketCount/10) != (Bucket("exp-2", visitor) < BucketCount/10) {
+			moved++
+		}
+	}
+	if math.Abs(float64(low)-1000) > 150 {
+		t.Errorf("expected about 10%% of visitors in the lowest tenth, got %d", low)
+	}
+	if moved == 0 {
+		t.Error("expected experiments to split visitors differently")
+	}
+}
+
+func TestTargets(t *testing.T) {
+	if _, err := NewTargets([]string{""}, nil); err != ErrNoTargets {
+		t.Errorf("expected ErrNoTargets, got %v", err)
+	}
+	targets, err := NewTargets([]string{"a1", "a1"}, []string{"opini"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targets.ArticleIDs) != 1 {
+		t.Errorf("expected duplicates dropped, got %v", targets.ArticleIDs)
+	}
+
+	tests := []struct {
+		name   string
+		target Target
+		want   bool
+	}{
+		{"targeted article", Target{ArticleID: "a1"}, true},
+		{"article in a targeted parent category", Target{ArticleID: "a2", CategoryIDs: []string{"kolom", "opini"}}, true},
+		{"other article", Target{ArticleID: "a3", CategoryIDs: []string{"nasional"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := targets.Matches(tt.target); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if !targets.Overlaps(Targets{CategoryIDs: []string{"opini"}}) || targets.Overlaps(Targets{ArticleIDs: []string{"a9"}}) {
+		t.Error("unexpected overlap result")
+	}
+}
+
+func TestResults_Lift(t *testing.T) {
+	r := Results{
+		Control: GroupResult{Group: GroupControl, Visitors: 9000, Conversions: 180},
+		Holdout: GroupResult{Group: GroupHoldout, Visitors: 1000, Conversions: 15},
+	}
+	if math.Abs(r.Lift()-(-0.25)) > 1e-9 {
+		t.Errorf("expected a lift of -25%%, got %v", r.Lift())
+	}
+	if (Results{}).Lift() != 0 {
+		t.Error("expected no lift without a control rate")
+	}
+}
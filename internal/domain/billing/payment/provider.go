@@ -0,0 +1,32 @@
+package payment
+
+import "context"
+
+type IntentStatus string
+
+const (
+	IntentStatusRequiresPayment IntentStatus = "requires_payment"
+	IntentStatusSucceeded       IntentStatus = "succeeded"
+	IntentStatusFailed          IntentStatus = "failed"
+	IntentStatusCanceled        IntentStatus = "canceled"
+)
+
+// PaymentIntent is the provider-side record of a pending charge
+type PaymentIntent struct {
+	ID           string
+	Reference    string // Our own identifier (listing ID, order ID, ...)
+	Amount       Money
+	Status       IntentStatus
+	ClientSecret string // Handed to the frontend to complete the payment
+}
+
+func (pi PaymentIntent) IsSucceeded() bool {
+	return pi.Status == IntentStatusSucceeded
+}
+
+// Domain interface for the payment gateway (implementation will be in infrastructure layer)
+type PaymentProvider interface {
+	CreateIntent(ctx context.Context, reference string, amount Money, description string) (*PaymentIntent, error)
+	GetIntent(ctx context.Context, intentID string) (*PaymentIntent, error)
+	CancelIntent(ctx context.Context, intentID string) error
+}
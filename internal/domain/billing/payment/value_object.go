@@ -0,0 +1,67 @@
+package payment
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Domain errors
+var (
+	ErrInvalidCurrency   = errors.New("currency must be a 3-letter ISO 4217 code")
+	ErrNegativeAmount    = errors.New("amount cannot be negative")
+	ErrCurrencyMismatch  = errors.New("cannot combine amounts with different currencies")
+	ErrInvalidMultiplier = errors.New("multiplier cannot be negative")
+)
+
+// Money value object, amount is stored in minor units (e.g. cents, sen)
+type Money struct {
+	amount   int64
+	currency string
+}
+
+func NewMoney(amount int64, currency string) (*Money, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+
+	if !currencyRegex.MatchString(currency) {
+		return nil, ErrInvalidCurrency
+	}
+
+	if amount < 0 {
+		return nil, ErrNegativeAmount
+	}
+
+	return &Money{amount: amount, currency: currency}, nil
+}
+
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() string {
+	return m.currency
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) Equals(other Money) bool {
+	return m.amount == other.amount && m.currency == other.currency
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
+}
+
+func (m Money) Multiply(n int64) (Money, error) {
+	if n < 0 {
+		return Money{}, ErrInvalidMultiplier
+	}
+	return Money{amount: m.amount * n, currency: m.currency}, nil
+}
@@ -0,0 +1,79 @@
+package payment
+
+import "testing"
+
+func TestNewMoney(t *testing.T) {
+	testCases := []struct {
+		name             string
+		amount           int64
+		currency         string
+		expectedCurrency string
+		expectedErr      error
+	}{
+		{"valid IDR", 150000, "IDR", "IDR", nil},
+		{"valid lowercase currency", 500, "usd", "USD", nil},
+		{"valid zero amount", 0, "IDR", "IDR", nil},
+		{"invalid - negative amount", -1, "IDR", "", ErrNegativeAmount},
+		{"invalid - empty currency", 100, "", "", ErrInvalidCurrency},
+		{"invalid - numeric currency", 100, "123", "", ErrInvalidCurrency},
+		{"invalid - long currency", 100, "RUPIAH", "", ErrInvalidCurrency},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewMoney(tc.amount, tc.currency)
+			if tc.expectedErr != nil {
+				if err != tc.expectedErr {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if m.Amount() != tc.amount {
+				t.Errorf("expected amount %d, got %d", tc.amount, m.Amount())
+			}
+			if m.Currency() != tc.expectedCurrency {
+				t.Errorf("expected currency '%s', got '%s'", tc.expectedCurrency, m.Currency())
+			}
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	idr, _ := NewMoney(10000, "IDR")
+	idr2, _ := NewMoney(2500, "IDR")
+	usd, _ := NewMoney(100, "USD")
+
+	sum, err := idr.Add(*idr2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sum.Amount() != 12500 {
+		t.Errorf("expected sum 12500, got %d", sum.Amount())
+	}
+
+	if _, err := idr.Add(*usd); err != ErrCurrencyMismatch {
+		t.Errorf("expected error '%v', got '%v'", ErrCurrencyMismatch, err)
+	}
+
+	product, err := idr.Multiply(7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.Amount() != 70000 {
+		t.Errorf("expected product 70000, got %d", product.Amount())
+	}
+
+	if _, err := idr.Multiply(-1); err != ErrInvalidMultiplier {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidMultiplier, err)
+	}
+
+	if !idr.Equals(Money{amount: 10000, currency: "IDR"}) {
+		t.Error("expected equal money to return true")
+	}
+	if idr.Equals(*usd) {
+		t.Error("expected different money to return false")
+	}
+}
@@ -0,0 +1,112 @@
+package purchase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // Waiting for the payment
+	StatusCompleted Status = "completed" // Paid, the article is unlocked for good
+	StatusCanceled  Status = "canceled"  // The payment failed or was abandoned
+)
+
+// Purchase is one member buying one article. A completed purchase is the
+// entitlement: it never expires and outlives any subscription.
+type Purchase struct {
+	ID              string
+	AccountID       string
+	ArticleID       string
+	ArticleTitle    string // At the time of purchase, for the history
+	Amount          payment.Money
+	Status          Status
+	PaymentIntentID *string
+
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	CanceledAt  *time.Time
+}
+
+// NewPurchase starts a purchase at the article's current price
+func NewPurchase(id, accountID string, article Article, price Price, at time.Time) (*Purchase, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return nil, ErrEmptyAccountID
+	}
+	if !article.Premium {
+		return nil, ErrNotPremium
+	}
+	if price.ArticleID != article.ID {
+		return nil, ErrPriceOfOtherArticle
+	}
+
+	return &Purchase{
+		ID:           id,
+		AccountID:    accountID,
+		ArticleID:    article.ID,
+		ArticleTitle: article.Title,
+		Amount:       price.Amount,
+		Status:       StatusPending,
+		CreatedAt:    at,
+	}, nil
+}
+
+// Business Methods
+
+// AttachPaymentIntent links the provider payment intent created for this purchase
+func (p *Purchase) AttachPaymentIntent(intentID string) error {
+	if p.Status != StatusPending {
+		return ErrNotPending
+	}
+	if strings.TrimSpace(intentID) == "" {
+		return ErrEmptyIntentID
+	}
+	p.PaymentIntentID = &intentID
+	return nil
+}
+
+// Complete unlocks the article once the provider reports the payment succeeded
+func (p *Purchase) Complete(intent payment.PaymentIntent, at time.Time) error {
+	if p.Status != StatusPending {
+		return ErrNotPending
+	}
+	if p.PaymentIntentID == nil || *p.PaymentIntentID != intent.ID {
+		return ErrIntentMismatch
+	}
+	if !intent.IsSucceeded() {
+		return ErrPaymentNotSucceeded
+	}
+	if !intent.Amount.Equals(p.Amount) {
+		return ErrAmountMismatch
+	}
+
+	p.Status = StatusCompleted
+	p.CompletedAt = &at
+	return nil
+}
+
+// Cancel closes a purchase whose payment failed or was abandoned, the member can start a new one
+func (p *Purchase) Cancel(at time.Time) error {
+	if p.Status != StatusPending {
+		return ErrNotPending
+	}
+	p.Status = StatusCanceled
+	p.CanceledAt = &at
+	return nil
+}
+
+// Query Methods
+
+func (p *Purchase) IsPending() bool {
+	return p.Status == StatusPending
+}
+
+func (p *Purchase) IsCompleted() bool {
+	return p.Status == StatusCompleted
+}
@@ -0,0 +1,82 @@
+package purchase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+var testNow = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+func createTestPurchase(t *testing.T) *Purchase {
+	t.Helper()
+	amount, _ := payment.NewMoney(5000, "IDR")
+	price, _ := NewPrice("article-1", *amount, "editor-1", testNow)
+	p, err := NewPurchase("p1", "member-1", Article{ID: "article-1", Title: "Inside the budget", Premium: true}, *price, testNow)
+	if err != nil {
+		t.Fatalf("failed to create purchase: %v", err)
+	}
+	return p
+}
+
+func TestNewPurchase(t *testing.T) {
+	p := createTestPurchase(t)
+	if !p.IsPending() || p.Amount.Amount() != 5000 || p.ArticleTitle != "Inside the budget" {
+		t.Errorf("unexpected purchase %+v", p)
+	}
+
+	amount, _ := payment.NewMoney(5000, "IDR")
+	price, _ := NewPrice("article-1", *amount, "editor-1", testNow)
+	if _, err := NewPurchase("p2", "member-1", Article{ID: "article-1"}, *price, testNow); err != ErrNotPremium {
+		t.Errorf("expected ErrNotPremium, got %v", err)
+	}
+	if _, err := NewPurchase("p2", "member-1", Article{ID: "article-2", Premium: true}, *price, testNow); err == nil {
+		t.Error("expected error for another article's price")
+	}
+}
+
+func TestPurchase_Complete(t *testing.T) {
+	paid, _ := payment.NewMoney(5000, "IDR")
+	short, _ := payment.NewMoney(500, "IDR")
+
+	testCases := []struct {
+		name        string
+		intent      payment.PaymentIntent
+		expectedErr error
+	}{
+		{"succeeded", payment.PaymentIntent{ID: "pi_1", Amount: *paid, Status: payment.IntentStatusSucceeded}, nil},
+		{"other intent", payment.PaymentIntent{ID: "pi_2", Amount: *paid, Status: payment.IntentStatusSucceeded}, ErrIntentMismatch},
+		{"still pending", payment.PaymentIntent{ID: "pi_1", Amount: *paid, Status: payment.IntentStatusRequiresPayment}, ErrPaymentNotSucceeded},
+		{"wrong amount", payment.PaymentIntent{ID: "pi_1", Amount: *short, Status: payment.IntentStatusSucceeded}, ErrAmountMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := createTestPurchase(t)
+			if err := p.AttachPaymentIntent("pi_1"); err != nil {
+				t.Fatalf("failed to attach intent: %v", err)
+			}
+			err := p.Complete(tc.intent, testNow)
+			if err != tc.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if (err == nil) != p.IsCompleted() {
+				t.Errorf("unexpected status %s", p.Status)
+			}
+		})
+	}
+}
+
+func TestPurchase_Cancel(t *testing.T) {
+	p := createTestPurchase(t)
+	if err := p.Cancel(testNow); err != nil || p.Status != StatusCanceled || p.CanceledAt == nil {
+		t.Fatalf("expected a canceled purchase, got %+v, %v", p, err)
+	}
+	if err := p.Cancel(testNow); err != ErrNotPending {
+		t.Errorf("expected ErrNotPending, got %v", err)
+	}
+	if err := p.AttachPaymentIntent("pi_1"); err != ErrNotPending {
+		t.Errorf("expected ErrNotPending, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package purchase
+
+import "context"
+
+type PriceRepository interface {
+	Save(ctx context.Context, price *Price) error
+	Delete(ctx context.Context, articleID string) error
+
+	// FindByArticleID returns nil when the article is not for sale
+	FindByArticleID(ctx context.Context, articleID string) (*Price, error)
+}
+
+type PurchaseRepository interface {
+	// Commands
+	Create(ctx context.Context, purchase *Purchase) error
+	Update(ctx context.Context, purchase *Purchase) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*Purchase, error)
+	// FindOpen returns the member's pending or completed purchase of the article, nil when there is none
+	FindOpen(ctx context.Context, accountID, articleID string) (*Purchase, error)
+
+	// HasPurchased reports a completed purchase, the paywall asks it on every premium read
+	HasPurchased(ctx context.Context, accountID, articleID string) (bool, error)
+
+	// FindCompleted lists the member's purchases, newest first
+	FindCompleted(ctx context.Context, accountID string, limit, offset int) ([]*Purchase, error)
+}
+
+// Domain interface for reading articles (implementation will be in infrastructure layer)
+type ArticleSource interface {
+	// FindArticle returns nil for unknown or unpublished articles
+	FindArticle(ctx context.Context, articleID string) (*Article, error)
+}
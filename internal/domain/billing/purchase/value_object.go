@@ -0,0 +1,56 @@
+package purchase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Domain errors
+var (
+	ErrEmptyID             = shared.NewDomainError("purchase.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrEmptyAccountID      = shared.NewDomainError("purchase.account_required", shared.KindValidation, "account ID cannot be empty")
+	ErrEmptyArticleID      = shared.NewDomainError("purchase.article_required", shared.KindValidation, "article ID cannot be empty")
+	ErrEmptyEditorID       = shared.NewDomainError("purchase.editor_required", shared.KindValidation, "editor ID cannot be empty")
+	ErrEmptyIntentID       = shared.NewDomainError("purchase.intent_required", shared.KindValidation, "payment intent ID cannot be empty")
+	ErrPriceOfOtherArticle = shared.NewDomainError("purchase.price_of_other_article", shared.KindValidation, "price belongs to another article")
+	ErrNotForSale          = shared.NewDomainError("purchase.not_for_sale", shared.KindNotFound, "article is not for sale")
+	ErrNotPremium          = shared.NewDomainError("purchase.not_premium", shared.KindValidation, "only premium articles can be sold individually")
+	ErrFreePrice           = shared.NewDomainError("purchase.free_price", shared.KindValidation, "price must be above zero")
+	ErrAlreadyPurchased    = shared.NewDomainError("purchase.already_purchased", shared.KindConflict, "article has already been purchased")
+	ErrNotPending          = shared.NewDomainError("purchase.not_pending", shared.KindState, "purchase is not pending payment")
+	ErrIntentMismatch      = shared.NewDomainError("purchase.intent_mismatch", shared.KindValidation, "payment intent does not belong to this purchase")
+	ErrPaymentNotSucceeded = shared.NewDomainError("purchase.payment_not_succeeded", shared.KindState, "payment has not succeeded")
+	ErrAmountMismatch      = shared.NewDomainError("purchase.amount_mismatch", shared.KindValidation, "paid amount does not match the purchase price")
+)
+
+// Price is what a premium article costs on its own. Changing it only
+// affects purchases started afterwards.
+type Price struct {
+	ArticleID string
+	Amount    payment.Money
+	SetBy     string
+	UpdatedAt time.Time
+}
+
+func NewPrice(articleID string, amount payment.Money, editorID string, at time.Time) (*Price, error) {
+	if strings.TrimSpace(articleID) == "" {
+		return nil, ErrEmptyArticleID
+	}
+	if amount.IsZero() {
+		return nil, ErrFreePrice
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, ErrEmptyEditorID
+	}
+	return &Price{ArticleID: articleID, Amount: amount, SetBy: editorID, UpdatedAt: at}, nil
+}
+
+// Article is the part of an article the purchase flow needs
+type Article struct {
+	ID      string
+	Title   string
+	Premium bool
+}
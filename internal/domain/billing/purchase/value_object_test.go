@@ -0,0 +1,43 @@
+package purchase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+func TestNewPrice(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	amount, _ := payment.NewMoney(5000, "IDR")
+	free, _ := payment.NewMoney(0, "IDR")
+
+	testCases := []struct {
+		name        string
+		articleID   string
+		amount      payment.Money
+		editorID    string
+		expectedErr bool
+	}{
+		{"valid", "article-1", *amount, "editor-1", false},
+		{"free", "article-1", *free, "editor-1", true},
+		{"empty article", " ", *amount, "editor-1", true},
+		{"empty editor", "article-1", *amount, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewPrice(tc.articleID, tc.amount, tc.editorID, at)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if err == nil && !p.Amount.Equals(*amount) {
+				t.Errorf("unexpected price %+v", p)
+			}
+		})
+	}
+
+	if _, err := NewPrice("article-1", *free, "editor-1", at); err != ErrFreePrice {
+		t.Errorf("expected ErrFreePrice, got %v", err)
+	}
+}
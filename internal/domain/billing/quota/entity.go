@@ -0,0 +1,180 @@
+package quota
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Source string
+
+const (
+	SourcePlan     Source = "plan"     // Self-serve plan tier
+	SourceContract Source = "contract" // Negotiated partner contract, overrides the plan
+)
+
+// Assignment attaches a tier to a partner account for a period of time
+type Assignment struct {
+	ID          string
+	AccountID   string
+	TierName    string
+	Source      Source
+	ContractRef *string
+	StartsAt    time.Time
+	EndsAt      *time.Time // Nil for open-ended plans
+
+	// Audit
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewAssignment(id, accountID, tierName string, source Source, contractRef *string, startsAt time.Time, endsAt *time.Time, createdBy string) (*Assignment, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("created by cannot be empty")
+	}
+
+	tierName = strings.ToLower(strings.TrimSpace(tierName))
+	if tierName == "" {
+		return nil, ErrEmptyTierName
+	}
+	if err := validateSource(source, contractRef); err != nil {
+		return nil, err
+	}
+	if endsAt != nil && !endsAt.After(startsAt) {
+		return nil, ErrInvalidAssignEnd
+	}
+
+	now := time.Now()
+	return &Assignment{
+		ID:          id,
+		AccountID:   accountID,
+		TierName:    tierName,
+		Source:      source,
+		ContractRef: contractRef,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Business Methods
+
+// EndAt closes the assignment, e.g. when a contract is terminated early
+func (a *Assignment) EndAt(at time.Time) error {
+	if !at.After(a.StartsAt) {
+		return ErrInvalidAssignEnd
+	}
+	if a.EndsAt != nil && a.EndsAt.Before(at) {
+		return errors.New("assignment has already ended")
+	}
+	a.EndsAt = &at
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// Query Methods
+
+func (a *Assignment) IsActiveAt(at time.Time) bool {
+	if at.Before(a.StartsAt) {
+		return false
+	}
+	return a.EndsAt == nil || at.Before(*a.EndsAt)
+}
+
+// ResolveAssignment picks the assignment in force at the given time.
+// Contracts win over plans, and the most recently started one wins within a source.
+func ResolveAssignment(assignments []*Assignment, at time.Time) *Assignment {
+	var current *Assignment
+	for _, a := range assignments {
+		if !a.IsActiveAt(at) {
+			continue
+		}
+		if current == nil || outranks(a, current) {
+			current = a
+		}
+	}
+	return current
+}
+
+func outranks(a, b *Assignment) bool {
+	if a.Source != b.Source {
+		return a.Source == SourceContract
+	}
+	return a.StartsAt.After(b.StartsAt)
+}
+
+// Usage is one counter value, as shown on the usage dashboards
+type Usage struct {
+	Key   CounterKey
+	Count int64
+}
+
+// Alert records a crossed consumption threshold until the partner has been emailed
+type Alert struct {
+	ID        string
+	Key       CounterKey
+	Level     Level
+	Used      int64
+	Limit     int64
+	CreatedAt time.Time
+	SentAt    *time.Time
+}
+
+func NewAlert(id string, key CounterKey, level Level, used, limit int64) (*Alert, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if level != LevelWarning && level != LevelExhausted {
+		return nil, errors.New("invalid alert level")
+	}
+	if limit <= 0 {
+		return nil, errors.New("alerts require a finite limit")
+	}
+
+	return &Alert{
+		ID:        id,
+		Key:       key,
+		Level:     level,
+		Used:      used,
+		Limit:     limit,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (a *Alert) MarkSent(at time.Time) {
+	a.SentAt = &at
+}
+
+func (a *Alert) IsSent() bool {
+	return a.SentAt != nil
+}
+
+// Percent returns the consumption that triggered the alert
+func (a *Alert) Percent() int64 {
+	return a.Used * 100 / a.Limit
+}
+
+// Domain Validation Functions
+
+func validateSource(source Source, contractRef *string) error {
+	switch source {
+	case SourcePlan:
+		return nil
+	case SourceContract:
+		if contractRef == nil || strings.TrimSpace(*contractRef) == "" {
+			return ErrMissingContract
+		}
+		return nil
+	default:
+		return ErrInvalidSource
+	}
+}
@@ -0,0 +1,118 @@
+package quota
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewAssignment(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	ref := "CTR-2025-014"
+	blank := " "
+
+	tests := []struct {
+		name        string
+		source      Source
+		contractRef *string
+		endsAt      *time.Time
+		wantErr     error
+	}{
+		{name: "plan", source: SourcePlan},
+		{name: "contract", source: SourceContract, contractRef: &ref, endsAt: &end},
+		{name: "contract without reference", source: SourceContract, contractRef: &blank, wantErr: ErrMissingContract},
+		{name: "unknown source", source: "trial", wantErr: ErrInvalidSource},
+		{name: "ends before start", source: SourcePlan, endsAt: &start, wantErr: ErrInvalidAssignEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAssignment("as-1", "acc-1", "Partner", tt.source, tt.contractRef, start, tt.endsAt, "admin-1")
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("expected error '%v', got '%v'", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if a.TierName != "partner" {
+				t.Errorf("expected tier 'partner', got '%s'", a.TierName)
+			}
+		})
+	}
+}
+
+func TestAssignment_EndAt(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	a, _ := NewAssignment("as-1", "acc-1", "partner", SourcePlan, nil, start, nil, "admin-1")
+
+	if err := a.EndAt(start); err != ErrInvalidAssignEnd {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidAssignEnd, err)
+	}
+
+	end := start.AddDate(0, 2, 0)
+	if err := a.EndAt(end); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.IsActiveAt(end) || !a.IsActiveAt(end.Add(-time.Second)) {
+		t.Error("expected assignment to be active until its end")
+	}
+	if err := a.EndAt(end.AddDate(0, 1, 0)); err == nil {
+		t.Error("expected error but got none")
+	}
+}
+
+func TestResolveAssignment(t *testing.T) {
+	jan := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	jun := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	ref := "CTR-7"
+
+	basic, _ := NewAssignment("as-1", "acc-1", "basic", SourcePlan, nil, jan, nil, "admin-1")
+	pro, _ := NewAssignment("as-2", "acc-1", "pro", SourcePlan, nil, mar, nil, "admin-1")
+	contract, _ := NewAssignment("as-3", "acc-1", "partner", SourceContract, &ref, jan, &jun, "admin-1")
+	all := []*Assignment{basic, pro, contract}
+
+	testCases := []struct {
+		name     string
+		at       time.Time
+		expected *Assignment
+	}{
+		{"before anything starts", jan.Add(-time.Hour), nil},
+		{"contract beats plans", mar.AddDate(0, 0, 1), contract},
+		{"latest plan after contract ends", jun, pro},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ResolveAssignment(all, tc.at); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewAlert(t *testing.T) {
+	key := CounterKey{AccountID: "acc-1", Period: PeriodDaily}
+
+	if _, err := NewAlert("al-1", key, LevelNone, 10, 100); err == nil {
+		t.Error("expected error but got none")
+	}
+	if _, err := NewAlert("al-1", key, LevelWarning, 10, 0); err == nil {
+		t.Error("expected error but got none")
+	}
+
+	alert, err := NewAlert("al-1", key, LevelWarning, 80, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if alert.Percent() != 80 || alert.IsSent() {
+		t.Errorf("expected unsent alert at 80%%, got %d%% sent=%v", alert.Percent(), alert.IsSent())
+	}
+	alert.MarkSent(time.Now())
+	if !alert.IsSent() {
+		t.Error("expected alert to be sent")
+	}
+}
@@ -0,0 +1,41 @@
+package quota
+
+import (
+	"context"
+	"time"
+)
+
+type TierRepository interface {
+	Save(ctx context.Context, tier *Tier) error
+
+	// FindByName returns nil when the tier does not exist
+	FindByName(ctx context.Context, name string) (*Tier, error)
+}
+
+type AssignmentRepository interface {
+	// Commands
+	Create(ctx context.Context, assignment *Assignment) error
+	Update(ctx context.Context, assignment *Assignment) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Assignment, error)
+	FindByAccount(ctx context.Context, accountID string) ([]*Assignment, error)
+}
+
+// Domain interface for persistent usage counters (implementation will be in infrastructure layer)
+type UsageRepository interface {
+	// Increment atomically adds n to every counter and returns the new values in key order
+	Increment(ctx context.Context, keys []CounterKey, n int64) ([]int64, error)
+
+	// Get returns the current values in key order, missing counters are zero
+	Get(ctx context.Context, keys []CounterKey) ([]int64, error)
+
+	// FindHistory returns every counter of the account whose window starts within [from, to)
+	FindHistory(ctx context.Context, accountID string, period Period, from, to time.Time) ([]Usage, error)
+}
+
+type AlertRepository interface {
+	Create(ctx context.Context, alert *Alert) error
+	Update(ctx context.Context, alert *Alert) error
+	FindUnsent(ctx context.Context, limit int) ([]*Alert, error)
+}
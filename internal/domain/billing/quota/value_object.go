@@ -0,0 +1,166 @@
+package quota
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Period string
+
+const (
+	PeriodDaily   Period = "daily"
+	PeriodMonthly Period = "monthly"
+)
+
+// Periods lists every accounting period, in the order counters are kept
+var Periods = []Period{PeriodDaily, PeriodMonthly}
+
+type Level string
+
+const (
+	LevelNone      Level = ""
+	LevelWarning   Level = "warning"   // 80% of the limit used
+	LevelExhausted Level = "exhausted" // 100% of the limit used
+)
+
+// WarningPercent is the consumption at which partners get the first email
+const WarningPercent = 80
+
+// Domain errors
+var (
+	ErrInvalidPeriod    = errors.New("invalid quota period")
+	ErrNegativeLimit    = errors.New("quota limit cannot be negative")
+	ErrEmptyTierName    = errors.New("tier name cannot be empty")
+	ErrEmptyRouteGroup  = errors.New("route group cannot be empty")
+	ErrInvalidSource    = errors.New("invalid quota source")
+	ErrMissingContract  = errors.New("contract reference is required for contract quotas")
+	ErrInvalidAssignEnd = errors.New("assignment must end after it starts")
+)
+
+func (p Period) IsValid() bool {
+	return p == PeriodDaily || p == PeriodMonthly
+}
+
+// WindowStart returns the start of the accounting window containing at, windows are aligned to UTC
+func (p Period) WindowStart(at time.Time) time.Time {
+	at = at.UTC()
+	if p == PeriodMonthly {
+		return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// WindowEnd returns when the counter for the window containing at resets
+func (p Period) WindowEnd(at time.Time) time.Time {
+	start := p.WindowStart(at)
+	if p == PeriodMonthly {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// LevelFor reports how far usage has progressed towards the limit, zero limits are unlimited
+func LevelFor(used, limit int64) Level {
+	switch {
+	case limit <= 0:
+		return LevelNone
+	case used >= limit:
+		return LevelExhausted
+	case used*100 >= limit*WarningPercent:
+		return LevelWarning
+	default:
+		return LevelNone
+	}
+}
+
+// Limits caps the number of calls per period, zero means unlimited
+type Limits struct {
+	Daily   int64
+	Monthly int64
+}
+
+func (l Limits) For(p Period) int64 {
+	if p == PeriodMonthly {
+		return l.Monthly
+	}
+	return l.Daily
+}
+
+func (l Limits) validate() error {
+	if l.Daily < 0 || l.Monthly < 0 {
+		return ErrNegativeLimit
+	}
+	return nil
+}
+
+// Tier value object, the quota package attached to a plan or contract
+type Tier struct {
+	name   string
+	limits Limits
+	routes map[string]Limits // Stricter limits for expensive route groups, e.g. "search"
+}
+
+func NewTier(name string, limits Limits, routes map[string]Limits) (*Tier, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil, ErrEmptyTierName
+	}
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+
+	normalized := make(map[string]Limits, len(routes))
+	for route, routeLimits := range routes {
+		route = strings.TrimSpace(route)
+		if route == "" {
+			return nil, ErrEmptyRouteGroup
+		}
+		if err := routeLimits.validate(); err != nil {
+			return nil, err
+		}
+		normalized[route] = routeLimits
+	}
+
+	return &Tier{name: name, limits: limits, routes: normalized}, nil
+}
+
+func (t Tier) Name() string {
+	return t.name
+}
+
+// Limits returns the account-wide limits across all routes
+func (t Tier) Limits() Limits {
+	return t.limits
+}
+
+func (t Tier) RouteLimits(route string) (Limits, bool) {
+	l, ok := t.routes[route]
+	return l, ok
+}
+
+// Routes returns the route groups with their own limits, sorted by name
+func (t Tier) Routes() []string {
+	routes := make([]string, 0, len(t.routes))
+	for route := range t.routes {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// CounterKey identifies one persistent usage counter.
+// An empty APIKeyID counts the whole account, an empty Route counts every route.
+type CounterKey struct {
+	AccountID   string
+	APIKeyID    string
+	Route       string
+	Period      Period
+	WindowStart time.Time
+}
+
+// IsEnforced reports whether limits are checked against this counter, per-key counters are for reporting only
+func (k CounterKey) IsEnforced() bool {
+	return k.APIKeyID == ""
+}
@@ -0,0 +1,104 @@
+package quota
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriod_Window(t *testing.T) {
+	at := time.Date(2025, time.January, 31, 23, 30, 0, 0, time.FixedZone("WIB", 7*3600))
+
+	testCases := []struct {
+		name          string
+		period        Period
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{"daily uses UTC day", PeriodDaily, time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC), time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"monthly uses UTC month", PeriodMonthly, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.period.WindowStart(at); !got.Equal(tc.expectedStart) {
+				t.Errorf("expected start %v, got %v", tc.expectedStart, got)
+			}
+			if got := tc.period.WindowEnd(at); !got.Equal(tc.expectedEnd) {
+				t.Errorf("expected end %v, got %v", tc.expectedEnd, got)
+			}
+		})
+	}
+}
+
+func TestLevelFor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		used     int64
+		limit    int64
+		expected Level
+	}{
+		{"unlimited", 1000000, 0, LevelNone},
+		{"below warning", 79, 100, LevelNone},
+		{"at warning", 80, 100, LevelWarning},
+		{"just below limit", 99, 100, LevelWarning},
+		{"at limit", 100, 100, LevelExhausted},
+		{"over limit", 150, 100, LevelExhausted},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := LevelFor(tc.used, tc.limit); got != tc.expected {
+				t.Errorf("expected level %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewTier(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tierName    string
+		limits      Limits
+		routes      map[string]Limits
+		expectedErr error
+	}{
+		{"valid", "Partner", Limits{Daily: 10000, Monthly: 200000}, map[string]Limits{"search": {Daily: 500}}, nil},
+		{"valid unlimited", "enterprise", Limits{}, nil, nil},
+		{"invalid - empty name", "  ", Limits{}, nil, ErrEmptyTierName},
+		{"invalid - negative limit", "partner", Limits{Daily: -1}, nil, ErrNegativeLimit},
+		{"invalid - negative route limit", "partner", Limits{}, map[string]Limits{"search": {Monthly: -5}}, ErrNegativeLimit},
+		{"invalid - empty route", "partner", Limits{}, map[string]Limits{" ": {Daily: 5}}, ErrEmptyRouteGroup},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tier, err := NewTier(tc.tierName, tc.limits, tc.routes)
+			if tc.expectedErr != nil {
+				if err != tc.expectedErr {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tier.Limits() != tc.limits {
+				t.Errorf("expected limits %+v, got %+v", tc.limits, tier.Limits())
+			}
+			if len(tier.Routes()) != len(tc.routes) {
+				t.Errorf("expected %d route groups, got %d", len(tc.routes), len(tier.Routes()))
+			}
+		})
+	}
+
+	tier, _ := NewTier("Partner", Limits{Daily: 10}, map[string]Limits{"search": {Daily: 5}})
+	if tier.Name() != "partner" {
+		t.Errorf("expected normalized name 'partner', got '%s'", tier.Name())
+	}
+	if l, ok := tier.RouteLimits("search"); !ok || l.For(PeriodDaily) != 5 {
+		t.Errorf("expected search daily limit 5, got %+v", l)
+	}
+	if _, ok := tier.RouteLimits("articles"); ok {
+		t.Error("expected no limits for an unconfigured route group")
+	}
+}
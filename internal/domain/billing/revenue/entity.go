@@ -0,0 +1,171 @@
+package revenue
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+// ArticleDay is the aggregated revenue of one article on one UTC day. Author
+// and desk are copied in when the day is computed, so a later reassignment
+// only shows up once the day is restated.
+type ArticleDay struct {
+	Day                 time.Time
+	ArticleID           string
+	AuthorID            string
+	Desk                string
+	Pageviews           int64
+	AdImpressions       int64
+	AdRevenue           payment.Money
+	SubscriptionRevenue payment.Money
+	Conversions         float64
+	ComputedAt          time.Time
+}
+
+func NewArticleDay(day time.Time, articleID, currency string, computedAt time.Time) (*ArticleDay, error) {
+	if articleID == "" {
+		return nil, ErrEmptyArticleID
+	}
+	zero, err := payment.NewMoney(0, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &ArticleDay{
+		Day:                 TruncateDay(day),
+		ArticleID:           articleID,
+		AdRevenue:           *zero,
+		SubscriptionRevenue: *zero,
+		ComputedAt:          computedAt,
+	}, nil
+}
+
+func (d *ArticleDay) AddAds(line AdLine) error {
+	total, err := d.AdRevenue.Add(line.Revenue)
+	if err != nil {
+		return err
+	}
+	d.AdRevenue = total
+	d.AdImpressions += line.Impressions
+	return nil
+}
+
+func (d *ArticleDay) AddCredit(credit Credit) error {
+	total, err := d.SubscriptionRevenue.Add(credit.Amount)
+	if err != nil {
+		return err
+	}
+	d.SubscriptionRevenue = total
+	d.Conversions += credit.Weight
+	return nil
+}
+
+// Total is ad and subscription revenue together, both are in the day's currency
+func (d *ArticleDay) Total() payment.Money {
+	total, _ := d.AdRevenue.Add(d.SubscriptionRevenue)
+	return total
+}
+
+// Line is one row of a revenue report
+type Line struct {
+	Key                 string
+	Articles            int
+	Pageviews           int64
+	AdImpressions       int64
+	AdRevenue           payment.Money
+	SubscriptionRevenue payment.Money
+	Total               payment.Money
+	Conversions         float64
+}
+
+// RPM is total revenue in minor units per thousand pageviews
+func (l Line) RPM() float64 {
+	if l.Pageviews == 0 {
+		return 0
+	}
+	return float64(l.Total.Amount()) * 1000 / float64(l.Pageviews)
+}
+
+// Rollup groups article days by the dimension and returns the lines with the
+// highest total first, along with the grand total. Articles without an author
+// or desk are grouped under an empty key.
+func Rollup(days []*ArticleDay, by Dimension, currency string) ([]Line, Line, error) {
+	if !by.Valid() {
+		return nil, Line{}, ErrInvalidDimension
+	}
+	zero, err := payment.NewMoney(0, currency)
+	if err != nil {
+		return nil, Line{}, err
+	}
+	empty := Line{AdRevenue: *zero, SubscriptionRevenue: *zero, Total: *zero}
+
+	lines := make(map[string]*Line)
+	articles := make(map[string]map[string]bool)
+	total := empty
+	allArticles := make(map[string]bool)
+	for _, d := range days {
+		key := d.ArticleID
+		switch by {
+		case DimensionAuthor:
+			key = d.AuthorID
+		case DimensionDesk:
+			key = d.Desk
+		}
+		line, ok := lines[key]
+		if !ok {
+			line = &Line{}
+			*line = empty
+			line.Key = key
+			lines[key] = line
+			articles[key] = make(map[string]bool)
+		}
+		if err := line.add(d); err != nil {
+			return nil, Line{}, err
+		}
+		if err := total.add(d); err != nil {
+			return nil, Line{}, err
+		}
+		articles[key][d.ArticleID] = true
+		allArticles[d.ArticleID] = true
+	}
+
+	result := make([]Line, 0, len(lines))
+	for key, line := range lines {
+		line.Articles = len(articles[key])
+		result = append(result, *line)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total.Amount() != result[j].Total.Amount() {
+			return result[i].Total.Amount() > result[j].Total.Amount()
+		}
+		return result[i].Key < result[j].Key
+	})
+	total.Articles = len(allArticles)
+	return result, total, nil
+}
+
+func (l *Line) add(d *ArticleDay) error {
+	ads, err := l.AdRevenue.Add(d.AdRevenue)
+	if err != nil {
+		return err
+	}
+	subscriptions, err := l.SubscriptionRevenue.Add(d.SubscriptionRevenue)
+	if err != nil {
+		return err
+	}
+	total, err := ads.Add(subscriptions)
+	if err != nil {
+		return err
+	}
+	l.AdRevenue, l.SubscriptionRevenue, l.Total = ads, subscriptions, total
+	l.Pageviews += d.Pageviews
+	l.AdImpressions += d.AdImpressions
+	l.Conversions += d.Conversions
+	return nil
+}
+
+// TruncateDay returns the start of the UTC day
+func TruncateDay(at time.Time) time.Time {
+	y, m, d := at.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,129 @@
+package revenue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+func articleDay(t *testing.T, day time.Time, articleID, authorID, desk string, views, ads, subscriptions int64) *ArticleDay {
+	t.Helper()
+	d, err := NewArticleDay(day, articleID, "IDR", day)
+	if err != nil {
+		t.Fatalf("failed to create article day: %v", err)
+	}
+	d.AuthorID, d.Desk, d.Pageviews = authorID, desk, views
+	if err := d.AddAds(AdLine{ArticleID: articleID, Impressions: views * 2, Revenue: money(t, ads, "IDR")}); err != nil {
+		t.Fatalf("failed to add ads: %v", err)
+	}
+	if err := d.AddCredit(Credit{ArticleID: articleID, Amount: money(t, subscriptions, "IDR"), Weight: 0.5}); err != nil {
+		t.Fatalf("failed to add credit: %v", err)
+	}
+	return d
+}
+
+func TestNewArticleDay(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 17, 30, 0, 0, time.FixedZone("WIB", 7*3600))
+
+	d, err := NewArticleDay(at, "article-1", "idr", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !d.Day.Equal(time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected day to be truncated to the UTC day, got %v", d.Day)
+	}
+	if d.Total().Currency() != "IDR" || !d.Total().IsZero() {
+		t.Errorf("expected a zero IDR total, got %d %s", d.Total().Amount(), d.Total().Currency())
+	}
+
+	if _, err := NewArticleDay(at, "", "IDR", at); err != ErrEmptyArticleID {
+		t.Errorf("expected ErrEmptyArticleID, got %v", err)
+	}
+	if _, err := NewArticleDay(at, "article-1", "rupiah", at); err != payment.ErrInvalidCurrency {
+		t.Errorf("expected ErrInvalidCurrency, got %v", err)
+	}
+}
+
+func TestArticleDay_RejectsOtherCurrencies(t *testing.T) {
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	d, _ := NewArticleDay(day, "article-1", "IDR", day)
+
+	if err := d.AddAds(AdLine{ArticleID: "article-1", Impressions: 10, Revenue: money(t, 5, "USD")}); !errors.Is(err, payment.ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if err := d.AddCredit(Credit{ArticleID: "article-1", Amount: money(t, 5, "USD"), Weight: 1}); !errors.Is(err, payment.ErrCurrencyMismatch) {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if d.AdImpressions != 0 || d.Conversions != 0 {
+		t.Errorf("expected a rejected line to leave the day untouched, got %+v", d)
+	}
+}
+
+func TestRollup(t *testing.T) {
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+	days := []*ArticleDay{
+		articleDay(t, day, "a", "alice", "politics", 1000, 2000, 10000),
+		articleDay(t, next, "a", "alice", "politics", 500, 1000, 0),
+		articleDay(t, day, "b", "alice", "sports", 4000, 6000, 0),
+		articleDay(t, day, "c", "bob", "sports", 100, 100, 0),
+		articleDay(t, day, "d", "", "", 10, 0, 0),
+	}
+
+	t.Run("by article", func(t *testing.T) {
+		lines, total, err := Rollup(days, DimensionArticle, "IDR")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(lines) != 4 || lines[0].Key != "a" || lines[1].Key != "b" {
+			t.Fatalf("expected a then b first, got %+v", lines)
+		}
+		if lines[0].Total.Amount() != 13000 || lines[0].Pageviews != 1500 || lines[0].Conversions != 1 {
+			t.Errorf("expected a to sum both days, got %+v", lines[0])
+		}
+		if total.Total.Amount() != 19100 || total.Articles != 4 || total.Pageviews != 5610 {
+			t.Errorf("unexpected grand total %+v", total)
+		}
+		if lines[1].RPM() != 1500 {
+			t.Errorf("expected RPM 1500, got %f", lines[1].RPM())
+		}
+	})
+
+	t.Run("by author", func(t *testing.T) {
+		lines, _, err := Rollup(days, DimensionAuthor, "IDR")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(lines) != 3 || lines[0].Key != "alice" || lines[0].Articles != 2 || lines[0].Total.Amount() != 19000 {
+			t.Fatalf("expected alice first with two articles, got %+v", lines)
+		}
+		if lines[2].Key != "" {
+			t.Errorf("expected the unassigned article last, got %+v", lines[2])
+		}
+	})
+
+	t.Run("by desk", func(t *testing.T) {
+		lines, _, err := Rollup(days, DimensionDesk, "IDR")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if lines[0].Key != "politics" || lines[1].Key != "sports" || lines[1].Articles != 2 || lines[1].AdImpressions != 8200 {
+			t.Fatalf("unexpected desk rollup %+v", lines)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		lines, total, err := Rollup(nil, DimensionDesk, "IDR")
+		if err != nil || len(lines) != 0 || total.Total.Currency() != "IDR" || total.RPM() != 0 {
+			t.Errorf("expected an empty IDR report, got %+v %+v %v", lines, total, err)
+		}
+	})
+
+	t.Run("invalid dimension", func(t *testing.T) {
+		if _, _, err := Rollup(days, Dimension("section"), "IDR"); err != ErrInvalidDimension {
+			t.Errorf("expected ErrInvalidDimension, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package revenue
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interface for subscription conversions (implementation will be in infrastructure layer)
+type ConversionSource interface {
+	// Conversions returns subscriptions started in [from, to) in the reporting
+	// currency, with the articles each reader opened in the Lookback before
+	Conversions(ctx context.Context, from, to time.Time) ([]Conversion, error)
+}
+
+// Domain interface for ad network reports (implementation will be in infrastructure layer)
+type AdSource interface {
+	// AdRevenue returns per-article impressions and revenue in [from, to) in the reporting currency
+	AdRevenue(ctx context.Context, from, to time.Time) ([]AdLine, error)
+}
+
+// Domain interface for pageview counts (implementation will be in infrastructure layer)
+type PageviewSource interface {
+	// Pageviews returns views per article ID in [from, to)
+	Pageviews(ctx context.Context, from, to time.Time) (map[string]int64, error)
+}
+
+// Domain interface for article metadata (implementation will be in infrastructure layer)
+type ArticleDirectory interface {
+	// Describe returns the metadata of the articles that exist, missing IDs are left out
+	Describe(ctx context.Context, articleIDs []string) (map[string]ArticleInfo, error)
+}
+
+// Domain interface for aggregated article revenue (implementation will be in infrastructure layer)
+type ArticleDayRepository interface {
+	// ReplaceDay swaps every row of the day in one go, so a day can be recomputed safely
+	ReplaceDay(ctx context.Context, day time.Time, rows []*ArticleDay) error
+
+	// FindRange returns the rows for days in [from, to)
+	FindRange(ctx context.Context, from, to time.Time) ([]*ArticleDay, error)
+}
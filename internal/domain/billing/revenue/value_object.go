@@ -0,0 +1,117 @@
+package revenue
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+// Lookback is how far before a subscription an article read still earns credit
+const Lookback = 30 * 24 * time.Hour
+
+// Domain errors
+var (
+	ErrInvalidDimension = errors.New("report dimension must be article, author or desk")
+	ErrEmptyArticleID   = errors.New("article ID cannot be empty")
+)
+
+// Dimension is what a report groups article revenue by
+type Dimension string
+
+const (
+	DimensionArticle Dimension = "article"
+	DimensionAuthor  Dimension = "author"
+	DimensionDesk    Dimension = "desk"
+)
+
+func (d Dimension) Valid() bool {
+	switch d {
+	case DimensionArticle, DimensionAuthor, DimensionDesk:
+		return true
+	}
+	return false
+}
+
+// Touch is one article a reader opened before subscribing
+type Touch struct {
+	ArticleID string
+	At        time.Time
+}
+
+// Conversion is a started subscription with the reader's recent reading history
+type Conversion struct {
+	SubscriptionID string
+	Value          payment.Money
+	ConvertedAt    time.Time
+	Touches        []Touch
+}
+
+// Credit is the share of a conversion one article earned
+type Credit struct {
+	ArticleID string
+	Amount    payment.Money
+	Weight    float64
+}
+
+// Attribute splits a conversion evenly across the distinct articles read in
+// the Lookback before it. Amounts are whole minor units, the remainder goes to
+// the article read last so the credits always add up to the conversion value.
+// A conversion without qualifying reads returns no credits.
+func Attribute(c Conversion) ([]Credit, error) {
+	since := c.ConvertedAt.Add(-Lookback)
+	lastRead := make(map[string]time.Time)
+	for _, t := range c.Touches {
+		if t.ArticleID == "" || t.At.Before(since) || t.At.After(c.ConvertedAt) {
+			continue
+		}
+		if prev, ok := lastRead[t.ArticleID]; !ok || t.At.After(prev) {
+			lastRead[t.ArticleID] = t.At
+		}
+	}
+	if len(lastRead) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(lastRead))
+	for id := range lastRead {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if !lastRead[ids[i]].Equal(lastRead[ids[j]]) {
+			return lastRead[ids[i]].Before(lastRead[ids[j]])
+		}
+		return ids[i] < ids[j]
+	})
+
+	n := int64(len(ids))
+	share, rest := c.Value.Amount()/n, c.Value.Amount()%n
+	credits := make([]Credit, 0, len(ids))
+	for i, id := range ids {
+		amount := share
+		if i == len(ids)-1 {
+			amount += rest
+		}
+		money, err := payment.NewMoney(amount, c.Value.Currency())
+		if err != nil {
+			return nil, err
+		}
+		credits = append(credits, Credit{ArticleID: id, Amount: *money, Weight: 1 / float64(n)})
+	}
+	return credits, nil
+}
+
+// AdLine is what an ad network paid for one article's impressions
+type AdLine struct {
+	ArticleID   string
+	Impressions int64
+	Revenue     payment.Money
+}
+
+// ArticleInfo is the article metadata reports are grouped by
+type ArticleInfo struct {
+	Title    string
+	AuthorID string
+	Desk     string
+}
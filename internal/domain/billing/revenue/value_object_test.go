@@ -0,0 +1,86 @@
+package revenue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+func money(t *testing.T, amount int64, currency string) payment.Money {
+	t.Helper()
+	m, err := payment.NewMoney(amount, currency)
+	if err != nil {
+		t.Fatalf("failed to create money: %v", err)
+	}
+	return *m
+}
+
+func TestAttribute(t *testing.T) {
+	converted := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		value    int64
+		touches  []Touch
+		expected map[string]int64
+	}{
+		{"no reads", 50000, nil, map[string]int64{}},
+		{"single article takes everything", 50000, []Touch{{"a", converted.Add(-time.Hour)}}, map[string]int64{"a": 50000}},
+		{
+			"remainder goes to the last read",
+			10000,
+			[]Touch{{"a", converted.Add(-3 * time.Hour)}, {"b", converted.Add(-time.Hour)}, {"c", converted.Add(-2 * time.Hour)}},
+			map[string]int64{"a": 3333, "c": 3333, "b": 3334},
+		},
+		{
+			"repeat reads count once",
+			10000,
+			[]Touch{{"a", converted.Add(-3 * time.Hour)}, {"b", converted.Add(-2 * time.Hour)}, {"a", converted.Add(-time.Minute)}},
+			map[string]int64{"a": 5000, "b": 5000},
+		},
+		{
+			"reads outside the lookback are ignored",
+			10000,
+			[]Touch{{"old", converted.Add(-Lookback - time.Hour)}, {"later", converted.Add(time.Hour)}, {"a", converted.Add(-Lookback)}},
+			map[string]int64{"a": 10000},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			credits, err := Attribute(Conversion{SubscriptionID: "sub-1", Value: money(t, tc.value, "IDR"), ConvertedAt: converted, Touches: tc.touches})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(credits) != len(tc.expected) {
+				t.Fatalf("expected %d credits, got %+v", len(tc.expected), credits)
+			}
+			var sum int64
+			var weight float64
+			for _, c := range credits {
+				if c.Amount.Amount() != tc.expected[c.ArticleID] {
+					t.Errorf("expected %s to get %d, got %d", c.ArticleID, tc.expected[c.ArticleID], c.Amount.Amount())
+				}
+				if c.Amount.Currency() != "IDR" {
+					t.Errorf("expected IDR, got %s", c.Amount.Currency())
+				}
+				sum += c.Amount.Amount()
+				weight += c.Weight
+			}
+			if len(credits) > 0 && (sum != tc.value || weight < 0.999 || weight > 1.001) {
+				t.Errorf("expected credits to add up to the conversion, got %d with weight %f", sum, weight)
+			}
+		})
+	}
+}
+
+func TestDimension_Valid(t *testing.T) {
+	for _, d := range []Dimension{DimensionArticle, DimensionAuthor, DimensionDesk} {
+		if !d.Valid() {
+			t.Errorf("expected %s to be valid", d)
+		}
+	}
+	if Dimension("section").Valid() {
+		t.Error("expected unknown dimension to be invalid")
+	}
+}
@@ -0,0 +1,169 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+// RetrySchedule is the delay before each retry, counted from the failed renewal.
+// The last retry ends the grace period.
+var RetrySchedule = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+}
+
+// GracePeriod is how long premium access continues after a failed renewal
+var GracePeriod = RetrySchedule[len(RetrySchedule)-1]
+
+type CaseStatus string
+
+const (
+	CaseOpen      CaseStatus = "open"
+	CaseRecovered CaseStatus = "recovered"
+	CaseExhausted CaseStatus = "exhausted" // Every retry failed, subscription downgraded
+	CaseCanceled  CaseStatus = "canceled"  // Member canceled while in dunning
+)
+
+type Reminder string
+
+const (
+	ReminderPaymentFailed Reminder = "payment_failed"
+	ReminderFinalNotice   Reminder = "final_notice" // Before the last retry
+	ReminderDowngraded    Reminder = "downgraded"
+	ReminderRecovered     Reminder = "recovered"
+)
+
+// Domain errors
+var (
+	ErrCaseClosed     = errors.New("dunning case is already closed")
+	ErrChargeDeclined = errors.New("charge was declined")
+)
+
+// DunningCase tracks one failed renewal through its retries
+type DunningCase struct {
+	ID             string
+	SubscriptionID string
+	AccountID      string
+	Amount         payment.Money
+	Status         CaseStatus
+	OpenedAt       time.Time
+	Attempts       int // Retries made, the failed renewal itself is not counted
+	NextRetryAt    *time.Time
+	LastError      *string
+	Reminders      []Reminder // Sent so far, in order
+	ClosedAt       *time.Time
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+func NewDunningCase(id string, sub *Subscription, reason string, at time.Time) (*DunningCase, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if sub == nil {
+		return nil, errors.New("subscription cannot be empty")
+	}
+
+	next := at.Add(RetrySchedule[0])
+	return &DunningCase{
+		ID:             id,
+		SubscriptionID: sub.ID,
+		AccountID:      sub.AccountID,
+		Amount:         sub.Price,
+		Status:         CaseOpen,
+		OpenedAt:       at,
+		NextRetryAt:    &next,
+		LastError:      optionalString(reason),
+		UpdatedAt:      at,
+	}, nil
+}
+
+// Business Methods
+
+// RecordFailure schedules the next retry and reports whether retries are exhausted
+func (c *DunningCase) RecordFailure(reason string, at time.Time) (bool, error) {
+	if c.Status != CaseOpen {
+		return false, ErrCaseClosed
+	}
+	c.Attempts++
+	c.LastError = optionalString(reason)
+	c.UpdatedAt = at
+
+	if c.Attempts >= len(RetrySchedule) {
+		c.close(CaseExhausted, at)
+		return true, nil
+	}
+	next := c.OpenedAt.Add(RetrySchedule[c.Attempts])
+	c.NextRetryAt = &next
+	return false, nil
+}
+
+func (c *DunningCase) Recover(at time.Time) error {
+	if c.Status != CaseOpen {
+		return ErrCaseClosed
+	}
+	c.Attempts++
+	c.close(CaseRecovered, at)
+	return nil
+}
+
+func (c *DunningCase) Cancel(at time.Time) error {
+	if c.Status != CaseOpen {
+		return ErrCaseClosed
+	}
+	c.close(CaseCanceled, at)
+	return nil
+}
+
+func (c *DunningCase) RecordReminder(reminder Reminder, at time.Time) {
+	c.Reminders = append(c.Reminders, reminder)
+	c.UpdatedAt = at
+}
+
+// Query Methods
+
+func (c *DunningCase) IsDue(at time.Time) bool {
+	return c.Status == CaseOpen && c.NextRetryAt != nil && !at.Before(*c.NextRetryAt)
+}
+
+// IsFinalRetryNext reports whether the upcoming retry is the last one
+func (c *DunningCase) IsFinalRetryNext() bool {
+	return c.Status == CaseOpen && c.Attempts == len(RetrySchedule)-1
+}
+
+// ChargeReference identifies the upcoming retry to the payment provider. Each attempt
+// gets its own reference so the provider does not replay an earlier decline, while a
+// retry of the same attempt after a provider outage reuses it.
+func (c *DunningCase) ChargeReference() string {
+	return fmt.Sprintf("%s-retry-%d", c.ID, c.Attempts+1)
+}
+
+func (c *DunningCase) HasSent(reminder Reminder) bool {
+	for _, sent := range c.Reminders {
+		if sent == reminder {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *DunningCase) close(status CaseStatus, at time.Time) {
+	c.Status = status
+	c.NextRetryAt = nil
+	c.ClosedAt = &at
+	c.UpdatedAt = at
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
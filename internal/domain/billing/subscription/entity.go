@@ -0,0 +1,171 @@
+package subscription
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+type Status string
+
+const (
+	StatusActive     Status = "active"
+	StatusPastDue    Status = "past_due"   // Renewal failed, access continues while dunning retries
+	StatusDowngraded Status = "downgraded" // Retries exhausted, back on the free tier
+	StatusExpired    Status = "expired"    // Store subscription not renewed, the store owns retries
+	StatusCanceled   Status = "canceled"
+)
+
+type Interval string
+
+const (
+	IntervalMonthly Interval = "monthly"
+	IntervalYearly  Interval = "yearly"
+)
+
+// Domain errors
+var (
+	ErrInvalidTransition = errors.New("subscription cannot move to that status")
+	ErrInvalidInterval   = errors.New("invalid billing interval")
+)
+
+// transitions is the subscription state machine, every status change goes through it
+var transitions = map[Status][]Status{
+	StatusActive:     {StatusPastDue, StatusExpired, StatusCanceled},
+	StatusPastDue:    {StatusActive, StatusDowngraded, StatusExpired, StatusCanceled},
+	StatusDowngraded: {StatusActive, StatusCanceled},
+	StatusExpired:    {StatusActive, StatusCanceled},
+	StatusCanceled:   {},
+}
+
+// Subscription is a member's paid premium plan
+type Subscription struct {
+	ID               string
+	AccountID        string
+	Plan             string
+	Interval         Interval
+	Price            payment.Money
+	Channel          Channel
+	ExternalID       *string // Store's original transaction ID or purchase token
+	Status           Status
+	CurrentPeriodEnd time.Time
+	PastDueSince     *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSubscription(id, accountID, plan string, interval Interval, price payment.Money, periodEnd time.Time) (*Subscription, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if strings.TrimSpace(plan) == "" {
+		return nil, errors.New("plan cannot be empty")
+	}
+	if interval != IntervalMonthly && interval != IntervalYearly {
+		return nil, ErrInvalidInterval
+	}
+
+	now := time.Now()
+	return &Subscription{
+		ID:               id,
+		AccountID:        accountID,
+		Plan:             strings.TrimSpace(plan),
+		Interval:         interval,
+		Price:            price,
+		Channel:          ChannelWeb,
+		Status:           StatusActive,
+		CurrentPeriodEnd: periodEnd,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}, nil
+}
+
+// Business Methods
+
+// MarkPastDue starts the grace period after a failed renewal
+func (s *Subscription) MarkPastDue(at time.Time) error {
+	if err := s.transition(StatusPastDue, at); err != nil {
+		return err
+	}
+	s.PastDueSince = &at
+	return nil
+}
+
+// Renew records a successful charge and extends the paid period
+func (s *Subscription) Renew(periodEnd, at time.Time) error {
+	if s.Status != StatusActive {
+		if err := s.transition(StatusActive, at); err != nil {
+			return err
+		}
+	}
+	s.CurrentPeriodEnd = periodEnd
+	s.PastDueSince = nil
+	s.UpdatedAt = at
+	return nil
+}
+
+func (s *Subscription) Downgrade(at time.Time) error {
+	if err := s.transition(StatusDowngraded, at); err != nil {
+		return err
+	}
+	s.PastDueSince = nil
+	return nil
+}
+
+func (s *Subscription) Cancel(at time.Time) error {
+	return s.transition(StatusCanceled, at)
+}
+
+// Expire ends a store subscription that was not renewed
+func (s *Subscription) Expire(at time.Time) error {
+	if err := s.transition(StatusExpired, at); err != nil {
+		return err
+	}
+	s.PastDueSince = nil
+	return nil
+}
+
+// Query Methods
+
+// HasPremiumAccess is true while paid up or inside the dunning grace period
+func (s *Subscription) HasPremiumAccess() bool {
+	return s.Status == StatusActive || s.Status == StatusPastDue
+}
+
+// IsStoreManaged reports whether an app store bills the subscription, the store then owns retries
+func (s *Subscription) IsStoreManaged() bool {
+	return s.Channel != ChannelWeb
+}
+
+// NextPeriodEnd keeps the billing anchor, a renewal recovered late does not shift the cycle
+func (s *Subscription) NextPeriodEnd() time.Time {
+	if s.Interval == IntervalYearly {
+		return s.CurrentPeriodEnd.AddDate(1, 0, 0)
+	}
+	return s.CurrentPeriodEnd.AddDate(0, 1, 0)
+}
+
+func (s *Subscription) CanTransitionTo(status Status) bool {
+	for _, next := range transitions[s.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Subscription) transition(status Status, at time.Time) error {
+	if !s.CanTransitionTo(status) {
+		return ErrInvalidTransition
+	}
+	s.Status = status
+	s.UpdatedAt = at
+	return nil
+}
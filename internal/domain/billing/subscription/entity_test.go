@@ -0,0 +1,87 @@
+package subscription
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+func createTestSubscription(t *testing.T) *Subscription {
+	price, _ := payment.NewMoney(49000, "IDR")
+	sub, err := NewSubscription("sub-1", "member-1", "premium-monthly", IntervalMonthly, *price, time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	return sub
+}
+
+func TestSubscription_StateMachine(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		steps       func(s *Subscription) error
+		expected    Status
+		access      bool
+		expectedErr error
+	}{
+		{"past due keeps access", func(s *Subscription) error { return s.MarkPastDue(at) }, StatusPastDue, true, nil},
+		{"recovered", func(s *Subscription) error {
+			_ = s.MarkPastDue(at)
+			return s.Renew(at.AddDate(0, 1, 0), at.Add(time.Hour))
+		}, StatusActive, true, nil},
+		{"downgraded", func(s *Subscription) error {
+			_ = s.MarkPastDue(at)
+			return s.Downgrade(at.Add(GracePeriod))
+		}, StatusDowngraded, false, nil},
+		{"active cannot be downgraded directly", func(s *Subscription) error { return s.Downgrade(at) }, StatusActive, true, ErrInvalidTransition},
+		{"canceled is final", func(s *Subscription) error {
+			_ = s.Cancel(at)
+			return s.Renew(at.AddDate(0, 1, 0), at)
+		}, StatusCanceled, false, ErrInvalidTransition},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := createTestSubscription(t)
+			if err := tc.steps(s); err != tc.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if s.Status != tc.expected || s.HasPremiumAccess() != tc.access {
+				t.Errorf("expected %s with access=%v, got %s with access=%v", tc.expected, tc.access, s.Status, s.HasPremiumAccess())
+			}
+		})
+	}
+}
+
+func TestDunningCase_Retries(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	c, err := NewDunningCase("case-1", createTestSubscription(t), "card_declined", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.IsDue(at.Add(time.Hour)) || !c.IsDue(at.Add(RetrySchedule[0])) {
+		t.Errorf("expected first retry at +%v, got %v", RetrySchedule[0], c.NextRetryAt)
+	}
+
+	for i := 1; i < len(RetrySchedule); i++ {
+		exhausted, err := c.RecordFailure("card_declined", at.Add(RetrySchedule[i-1]))
+		if err != nil || exhausted {
+			t.Fatalf("retry %d: expected another attempt, got exhausted=%v err=%v", i, exhausted, err)
+		}
+		if !c.NextRetryAt.Equal(at.Add(RetrySchedule[i])) {
+			t.Errorf("retry %d: expected next at %v, got %v", i, at.Add(RetrySchedule[i]), c.NextRetryAt)
+		}
+	}
+	if !c.IsFinalRetryNext() {
+		t.Error("expected the final retry to be next")
+	}
+
+	exhausted, _ := c.RecordFailure("insufficient_funds", at.Add(GracePeriod))
+	if !exhausted || c.Status != CaseExhausted || c.NextRetryAt != nil {
+		t.Errorf("expected exhausted case, got %s next=%v", c.Status, c.NextRetryAt)
+	}
+	if err := c.Recover(at.Add(GracePeriod)); err != ErrCaseClosed {
+		t.Errorf("expected error '%v', got '%v'", ErrCaseClosed, err)
+	}
+}
@@ -0,0 +1,49 @@
+package subscription
+
+import (
+	"context"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+type SubscriptionRepository interface {
+	// Commands
+	Create(ctx context.Context, subscription *Subscription) error
+	Update(ctx context.Context, subscription *Subscription) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Subscription, error)                                  // nil when not found
+	FindByExternalID(ctx context.Context, channel Channel, externalID string) (*Subscription, error) // nil when not found
+	FindByAccountID(ctx context.Context, accountID string) ([]*Subscription, error)
+}
+
+// Domain interface for validating purchases with one app store (implementation will be in infrastructure layer).
+// Both methods return ErrInvalidReceipt when the store's signature or answer cannot be trusted.
+type StoreVerifier interface {
+	VerifyPurchase(ctx context.Context, receipt StoreReceipt) (*StoreEvent, error)
+	// VerifyNotification returns nil for test notifications that carry no subscription
+	VerifyNotification(ctx context.Context, payload []byte) (*StoreEvent, error)
+}
+
+type DunningRepository interface {
+	// Commands
+	Create(ctx context.Context, c *DunningCase) error
+	Update(ctx context.Context, c *DunningCase) error
+
+	// Queries
+	FindOpenBySubscription(ctx context.Context, subscriptionID string) (*DunningCase, error) // nil when none is open
+	FindDue(ctx context.Context, at time.Time, limit int) ([]*DunningCase, error)
+	FindOpenedBetween(ctx context.Context, from, to time.Time) ([]*DunningCase, error)
+}
+
+// Domain interface for charging the member's saved payment method (implementation will be in infrastructure layer).
+// A declined charge returns a failed intent or ErrChargeDeclined, any other error means the provider could not be reached.
+type Charger interface {
+	Charge(ctx context.Context, accountID, reference string, amount payment.Money) (*payment.PaymentIntent, error)
+}
+
+// Domain interface for dunning emails (implementation will be in the notification layer)
+type DunningNotifier interface {
+	SendDunningReminder(ctx context.Context, c *DunningCase, reminder Reminder) error
+}
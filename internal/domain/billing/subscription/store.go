@@ -0,0 +1,104 @@
+package subscription
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+// Channel is where the subscription was bought
+type Channel string
+
+const (
+	ChannelWeb       Channel = "web"
+	ChannelAppStore  Channel = "app_store"
+	ChannelPlayStore Channel = "play_store"
+)
+
+// StoreState is the store's authoritative status of a subscription
+type StoreState string
+
+const (
+	StoreActive       StoreState = "active"        // Paid, including auto-renew turned off before expiry
+	StoreBillingRetry StoreState = "billing_retry" // Renewal failed, store grace period, access continues
+	StoreExpired      StoreState = "expired"       // Not renewed, on hold or paused
+	StoreRevoked      StoreState = "revoked"       // Refunded or revoked by the store
+)
+
+// Domain errors
+var (
+	ErrInvalidReceipt    = errors.New("store receipt could not be verified")
+	ErrWrongChannel      = errors.New("subscription belongs to another purchase channel")
+	ErrPurchasePending   = errors.New("store purchase is still pending")
+	ErrInvalidStoreState = errors.New("invalid store subscription state")
+)
+
+// StoreReceipt is what the app sends after a purchase: Apple's signed transaction or Google's purchase token
+type StoreReceipt struct {
+	ProductID string
+	Token     string
+}
+
+// StoreEvent is a verified purchase or server notification, normalized across stores
+type StoreEvent struct {
+	Channel            Channel
+	ExternalID         string  // Apple original transaction ID, Google purchase token
+	PreviousExternalID *string // Google linked purchase token after an upgrade or resubscribe
+	ProductID          string
+	State              StoreState
+	ExpiresAt          time.Time
+	AccountToken       *string // appAccountToken / obfuscatedExternalAccountId, our account ID when the app set it
+	OccurredAt         time.Time
+}
+
+func NewStoreSubscription(id, accountID, plan string, interval Interval, price payment.Money, channel Channel, externalID string, periodEnd time.Time) (*Subscription, error) {
+	if channel != ChannelAppStore && channel != ChannelPlayStore {
+		return nil, ErrWrongChannel
+	}
+	if strings.TrimSpace(externalID) == "" {
+		return nil, errors.New("external ID cannot be empty")
+	}
+	s, err := NewSubscription(id, accountID, plan, interval, price, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	s.Channel = channel
+	s.ExternalID = &externalID
+	return s, nil
+}
+
+// ApplyStoreEvent moves a store subscription to the store's state through the
+// same state machine web subscriptions use. Applying an event twice is harmless.
+func (s *Subscription) ApplyStoreEvent(event StoreEvent, at time.Time) error {
+	if s.Channel != event.Channel {
+		return ErrWrongChannel
+	}
+	if event.ExternalID != "" && (s.ExternalID == nil || *s.ExternalID != event.ExternalID) {
+		id := event.ExternalID
+		s.ExternalID = &id
+	}
+
+	switch event.State {
+	case StoreActive:
+		return s.Renew(event.ExpiresAt, at)
+	case StoreBillingRetry:
+		if s.Status == StatusPastDue {
+			return nil
+		}
+		return s.MarkPastDue(at)
+	case StoreExpired:
+		if s.Status == StatusExpired {
+			return nil
+		}
+		return s.Expire(at)
+	case StoreRevoked:
+		if s.Status == StatusCanceled {
+			return nil
+		}
+		return s.Cancel(at)
+	default:
+		return ErrInvalidStoreState
+	}
+}
@@ -0,0 +1,63 @@
+package subscription
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+func createTestStoreSubscription(t *testing.T) *Subscription {
+	price, _ := payment.NewMoney(59000, "IDR")
+	sub, err := NewStoreSubscription("sub-1", "member-1", "premium-monthly", IntervalMonthly, *price, ChannelAppStore, "2000000123", time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create subscription: %v", err)
+	}
+	return sub
+}
+
+func TestSubscription_ApplyStoreEvent(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC)
+	renewed := at.AddDate(0, 1, 0)
+	testCases := []struct {
+		name        string
+		states      []StoreState
+		expected    Status
+		access      bool
+		expectedErr error
+	}{
+		{"renewed", []StoreState{StoreActive}, StatusActive, true, nil},
+		{"billing retry keeps access", []StoreState{StoreBillingRetry, StoreBillingRetry}, StatusPastDue, true, nil},
+		{"recovered after retry", []StoreState{StoreBillingRetry, StoreActive}, StatusActive, true, nil},
+		{"expired", []StoreState{StoreBillingRetry, StoreExpired, StoreExpired}, StatusExpired, false, nil},
+		{"resubscribed after expiry", []StoreState{StoreExpired, StoreActive}, StatusActive, true, nil},
+		{"refunded", []StoreState{StoreRevoked, StoreRevoked}, StatusCanceled, false, nil},
+		{"unknown state", []StoreState{"paused"}, StatusActive, true, ErrInvalidStoreState},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := createTestStoreSubscription(t)
+			var err error
+			for _, state := range tc.states {
+				if err = s.ApplyStoreEvent(StoreEvent{Channel: ChannelAppStore, ExternalID: "2000000123", State: state, ExpiresAt: renewed}, at); err != nil {
+					break
+				}
+			}
+			if err != tc.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if s.Status != tc.expected || s.HasPremiumAccess() != tc.access {
+				t.Errorf("expected %s with access=%v, got %s with access=%v", tc.expected, tc.access, s.Status, s.HasPremiumAccess())
+			}
+		})
+	}
+
+	s := createTestStoreSubscription(t)
+	if err := s.ApplyStoreEvent(StoreEvent{Channel: ChannelPlayStore, State: StoreActive}, at); err != ErrWrongChannel {
+		t.Errorf("expected error '%v', got '%v'", ErrWrongChannel, err)
+	}
+	if !s.IsStoreManaged() {
+		t.Error("expected an App Store subscription to be store managed")
+	}
+}
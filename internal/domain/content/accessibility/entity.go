@@ -0,0 +1,97 @@
+package accessibility
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Policy is a site's accessibility configuration
+type Policy struct {
+	SiteID     string
+	Strictness Strictness
+
+	LastActionBy *string
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+// DefaultPolicy applies until a site saves its own
+func DefaultPolicy(siteID string) *Policy {
+	return &Policy{SiteID: siteID, Strictness: DefaultStrictness}
+}
+
+func NewPolicy(siteID string, strictness Strictness, editorID string, at time.Time) (*Policy, error) {
+	if strings.TrimSpace(siteID) == "" {
+		return nil, errors.New("site ID cannot be empty")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editorID cannot be empty")
+	}
+	if _, err := ParseStrictness(string(strictness)); err != nil {
+		return nil, err
+	}
+	return &Policy{SiteID: siteID, Strictness: strictness, LastActionBy: &editorID, UpdatedAt: at}, nil
+}
+
+// Query Methods
+
+// Audit runs every check against the content. The score is the weighted
+// share of passed checks; a check with nothing to look at passes.
+func (p *Policy) Audit(c Content) *Checklist {
+	list := &Checklist{Strictness: p.Strictness}
+	passed, total := 0, 0
+	for _, item := range checks {
+		var issues []Issue
+		switch item.check {
+		case CheckImageAlt:
+			issues = checkImageAlt(c.Images)
+		case CheckTableHeaders:
+			issues = checkTableHeaders(c.Tables)
+		case CheckHeadingOrder:
+			issues = checkHeadingOrder(c.Headings)
+		case CheckLinkText:
+			issues = checkLinkText(c.Links)
+		case CheckLanguage:
+			issues = checkLanguage(c.Language)
+		}
+		result := Result{Check: item.check, Passed: len(issues) == 0, Issues: issues}
+		result.Blocking = !result.Passed && p.Strictness.Blocks(item.check)
+		list.Results = append(list.Results, result)
+
+		total += item.weight
+		if result.Passed {
+			passed += item.weight
+		}
+	}
+	list.Score = (passed*100 + total/2) / total
+	return list
+}
+
+// Checklist is the audit shown to editors in review
+type Checklist struct {
+	Strictness Strictness
+	Results    []Result
+	Score      int // 0 to 100
+}
+
+func (c *Checklist) Blocked() bool {
+	for _, r := range c.Results {
+		if r.Blocking {
+			return true
+		}
+	}
+	return false
+}
+
+// Blocking lists the checks that stop publishing
+func (c *Checklist) Blocking() []Check {
+	var blocking []Check
+	for _, r := range c.Results {
+		if r.Blocking {
+			blocking = append(blocking, r.Check)
+		}
+	}
+	return blocking
+}
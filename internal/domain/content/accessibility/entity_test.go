@@ -0,0 +1,93 @@
+package accessibility
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStrictness(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		expectedErr error
+	}{
+		{"relaxed", "relaxed", nil},
+		{"strict upper case", "STRICT", nil},
+		{"empty", "", ErrInvalidStrictness},
+		{"unknown", "lenient", ErrInvalidStrictness},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseStrictness(tc.value); err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	if _, err := NewPolicy("site-1", Strictness("lenient"), "editor-1", time.Now()); err != ErrInvalidStrictness {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidStrictness, err)
+	}
+	if _, err := NewPolicy("", StrictnessStrict, "editor-1", time.Now()); err == nil {
+		t.Error("expected error for empty site ID")
+	}
+}
+
+func accessibleContent() Content {
+	return Content{
+		Title:    "Banjir Jakarta",
+		Language: "id",
+		Images: []Image{
+			{Src: "/media/banjir.jpg", Alt: "Warga mengungsi dengan perahu karet", HasAlt: true},
+			{Src: "/media/divider.png", HasAlt: true, Decorative: true},
+		},
+		Tables:   []Table{{HeaderCells: 3}},
+		Headings: []int{2, 3, 2},
+		Links:    []Link{{Text: "Data curah hujan BMKG", Href: "https://bmkg.go.id"}},
+	}
+}
+
+func TestPolicy_Audit(t *testing.T) {
+	testCases := []struct {
+		name          string
+		strictness    Strictness
+		modify        func(c *Content)
+		failed        Check
+		expectedBlock bool
+		expectedScore int
+	}{
+		{"all pass", StrictnessStandard, func(c *Content) {}, "", false, 100},
+		{"missing alt blocks standard", StrictnessStandard, func(c *Content) { c.Images[0].HasAlt = false }, CheckImageAlt, true, 63},
+		{"empty alt without decorative flag", StrictnessStandard, func(c *Content) { c.Images[1].Decorative = false }, CheckImageAlt, true, 63},
+		{"file name alt", StrictnessStandard, func(c *Content) { c.Images[0].Alt = "IMG_2041.jpg" }, CheckImageAlt, true, 63},
+		{"missing alt advisory when relaxed", StrictnessRelaxed, func(c *Content) { c.Images[0].Alt = "" }, CheckImageAlt, false, 63},
+		{"table without headers", StrictnessStandard, func(c *Content) { c.Tables[0].HeaderCells = 0 }, CheckTableHeaders, true, 75},
+		{"skipped heading warns at standard", StrictnessStandard, func(c *Content) { c.Headings = []int{2, 4} }, CheckHeadingOrder, false, 88},
+		{"skipped heading blocks strict", StrictnessStrict, func(c *Content) { c.Headings = []int{3} }, CheckHeadingOrder, true, 88},
+		{"vague link", StrictnessStrict, func(c *Content) { c.Links[0].Text = "Klik di sini" }, CheckLinkText, true, 88},
+		{"bare URL link", StrictnessStandard, func(c *Content) { c.Links[0].Text = "https://bmkg.go.id" }, CheckLinkText, false, 88},
+		{"no language", StrictnessStrict, func(c *Content) { c.Language = "" }, CheckLanguage, true, 88},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := accessibleContent()
+			tc.modify(&content)
+			list := (&Policy{SiteID: "site-1", Strictness: tc.strictness}).Audit(content)
+
+			if list.Blocked() != tc.expectedBlock {
+				t.Errorf("expected blocked %v, got %v", tc.expectedBlock, list.Blocked())
+			}
+			if list.Score != tc.expectedScore {
+				t.Errorf("expected score %d, got %d", tc.expectedScore, list.Score)
+			}
+			for _, r := range list.Results {
+				if failed := r.Check == tc.failed; r.Passed == failed {
+					t.Errorf("expected %s passed %v, got %v (%+v)", r.Check, !failed, r.Passed, r.Issues)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package accessibility
+
+import "context"
+
+type PolicyRepository interface {
+	Save(ctx context.Context, policy *Policy) error
+	// FindBySite returns nil when the site has no policy yet
+	FindBySite(ctx context.Context, siteID string) (*Policy, error)
+}
+
+// Domain interface for reading the structure of an article body (implementation will be in infrastructure layer)
+type Inspector interface {
+	Inspect(title, language, body string) Content
+}
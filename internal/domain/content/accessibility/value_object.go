@@ -0,0 +1,184 @@
+package accessibility
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Domain errors
+var (
+	ErrInvalidStrictness = errors.New("strictness must be relaxed, standard or strict")
+	ErrPublishBlocked    = errors.New("article fails required accessibility checks")
+)
+
+// Strictness decides which failed checks block publishing on a site
+type Strictness string
+
+const (
+	StrictnessRelaxed  Strictness = "relaxed"  // The checklist is advisory only
+	StrictnessStandard Strictness = "standard" // Alt text and table headers are required
+	StrictnessStrict   Strictness = "strict"   // Every check is required
+)
+
+// DefaultStrictness applies to sites that never configured a policy
+const DefaultStrictness = StrictnessStandard
+
+func ParseStrictness(value string) (Strictness, error) {
+	switch s := Strictness(strings.ToLower(strings.TrimSpace(value))); s {
+	case StrictnessRelaxed, StrictnessStandard, StrictnessStrict:
+		return s, nil
+	}
+	return "", ErrInvalidStrictness
+}
+
+// Check is one item on the checklist
+type Check string
+
+const (
+	CheckImageAlt     Check = "image_alt"     // WCAG 1.1.1 Non-text Content
+	CheckTableHeaders Check = "table_headers" // WCAG 1.3.1 Info and Relationships
+	CheckHeadingOrder Check = "heading_order" // WCAG 1.3.1, no skipped heading levels
+	CheckLinkText     Check = "link_text"     // WCAG 2.4.4 Link Purpose
+	CheckLanguage     Check = "language"      // WCAG 3.1.1 Language of Page
+)
+
+// checks lists every check in checklist order with its weight in the score
+var checks = []struct {
+	check  Check
+	weight int
+}{
+	{CheckImageAlt, 3},
+	{CheckTableHeaders, 2},
+	{CheckHeadingOrder, 1},
+	{CheckLinkText, 1},
+	{CheckLanguage, 1},
+}
+
+// Blocks reports whether a failed check stops publishing at this strictness
+func (s Strictness) Blocks(c Check) bool {
+	switch s {
+	case StrictnessStrict:
+		return true
+	case StrictnessStandard:
+		return c == CheckImageAlt || c == CheckTableHeaders
+	}
+	return false
+}
+
+// Image is a picture in the body
+type Image struct {
+	Src        string
+	Alt        string
+	HasAlt     bool // alt="" is allowed for decorative images, a missing attribute is not
+	Decorative bool // Marked role="presentation" or aria-hidden by the editor
+}
+
+// Table is a data table in the body
+type Table struct {
+	HeaderCells int
+	Caption     string
+}
+
+// Link is an anchor in the body
+type Link struct {
+	Text string
+	Href string
+}
+
+// Content is the structure of an article body the checks look at, extracted
+// by an Inspector so the rules stay independent of the body format
+type Content struct {
+	Title    string
+	Language string
+	Images   []Image
+	Tables   []Table
+	Headings []int // Levels in document order, the title counts as 1
+	Links    []Link
+}
+
+// Issue points at one failing element, Index is its position among elements of the same kind
+type Issue struct {
+	Index   int
+	Message string
+}
+
+// Result is the outcome of one check
+type Result struct {
+	Check    Check
+	Passed   bool
+	Blocking bool // Failed and required at the site's strictness
+	Issues   []Issue
+}
+
+var (
+	fileLikeAlt = regexp.MustCompile(`(?i)^(img|dsc|image|photo|foto)?[-_ ]?\d+$|\.(jpe?g|png|gif|webp|svg)$`)
+	vagueLinks  = map[string]bool{
+		"click here": true, "here": true, "read more": true, "more": true, "link": true, "this": true,
+		"klik di sini": true, "di sini": true, "selengkapnya": true, "baca selengkapnya": true, "baca juga": true,
+	}
+)
+
+// Domain Validation Functions
+
+func checkImageAlt(images []Image) []Issue {
+	var issues []Issue
+	for i, img := range images {
+		alt := strings.TrimSpace(img.Alt)
+		switch {
+		case img.Decorative && img.HasAlt && alt == "":
+		case !img.HasAlt || alt == "":
+			issues = append(issues, Issue{Index: i, Message: "image " + path.Base(img.Src) + " has no alt text"})
+		case fileLikeAlt.MatchString(alt) || strings.EqualFold(alt, path.Base(img.Src)):
+			issues = append(issues, Issue{Index: i, Message: "alt text of image " + path.Base(img.Src) + " looks like a file name"})
+		}
+	}
+	return issues
+}
+
+func checkTableHeaders(tables []Table) []Issue {
+	var issues []Issue
+	for i, t := range tables {
+		if t.HeaderCells == 0 {
+			issues = append(issues, Issue{Index: i, Message: "table has no header cells"})
+		}
+	}
+	return issues
+}
+
+func checkHeadingOrder(headings []int) []Issue {
+	var issues []Issue
+	previous := 1
+	for i, level := range headings {
+		if level > previous+1 {
+			issues = append(issues, Issue{Index: i, Message: "heading skips from level " + strconv.Itoa(previous) + " to " + strconv.Itoa(level)})
+		}
+		previous = level
+	}
+	return issues
+}
+
+func checkLinkText(links []Link) []Issue {
+	var issues []Issue
+	for i, l := range links {
+		text := strings.ToLower(strings.TrimSpace(strings.Trim(l.Text, ".:»›→ ")))
+		switch {
+		case text == "":
+			issues = append(issues, Issue{Index: i, Message: "link to " + l.Href + " has no text"})
+		case vagueLinks[text]:
+			issues = append(issues, Issue{Index: i, Message: `link text "` + l.Text + `" does not say where it goes`})
+		case strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://"):
+			issues = append(issues, Issue{Index: i, Message: "link text is a bare URL"})
+		}
+	}
+	return issues
+}
+
+func checkLanguage(language string) []Issue {
+	if strings.TrimSpace(language) == "" {
+		return []Issue{{Message: "article language is not set"}}
+	}
+	return nil
+}
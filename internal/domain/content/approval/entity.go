@@ -0,0 +1,116 @@
+package approval
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Action is the one decision a grant allows
+type Action string
+
+const (
+	ActionApprove Action = "approve"
+	ActionReject  Action = "reject"
+)
+
+// Grants are short-lived: a push that sat unread for long is better answered at a desk
+const (
+	DefaultTTL = 10 * time.Minute
+	MaxTTL     = 30 * time.Minute
+)
+
+// Domain errors
+var (
+	ErrEmptyID       = shared.NewDomainError("approval.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrInvalidAction = shared.NewDomainError("approval.invalid_action", shared.KindValidation, "action must be approve or reject")
+	ErrInvalidTTL    = shared.NewDomainError("approval.invalid_ttl", shared.KindValidation, "approval links must expire within 30 minutes")
+	ErrExpired       = shared.NewDomainError("approval.expired", shared.KindState, "approval link has expired")
+	ErrUsed          = shared.NewDomainError("approval.used", shared.KindConflict, "approval link was already used")
+	ErrRevoked       = shared.NewDomainError("approval.revoked", shared.KindState, "approval link was revoked")
+)
+
+// Grant lets one editor take one workflow action on one article, from the deep
+// link in a push notification. The token itself is never stored, only its hash.
+type Grant struct {
+	ID        string
+	TokenHash string
+	EditorID  string
+	ArticleID string
+	Action    Action
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time // Set when the article was decided another way
+
+	// Audit
+	CreatedAt time.Time
+}
+
+func NewGrant(id, tokenHash, editorID, articleID string, action Action, ttl time.Duration, at time.Time) (*Grant, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(tokenHash) == "" {
+		return nil, ErrEmptyID.WithMessage("token hash cannot be empty")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, ErrEmptyID.WithMessage("editor ID cannot be empty")
+	}
+	if strings.TrimSpace(articleID) == "" {
+		return nil, ErrEmptyID.WithMessage("article ID cannot be empty")
+	}
+	if !action.Valid() {
+		return nil, ErrInvalidAction
+	}
+	if ttl <= 0 || ttl > MaxTTL {
+		return nil, ErrInvalidTTL
+	}
+
+	return &Grant{
+		ID:        id,
+		TokenHash: tokenHash,
+		EditorID:  editorID,
+		ArticleID: articleID,
+		Action:    action,
+		ExpiresAt: at.Add(ttl),
+		CreatedAt: at,
+	}, nil
+}
+
+// Business Methods
+
+// Redeem uses the grant up, it works once and only before it expires
+func (g *Grant) Redeem(at time.Time) error {
+	if err := g.CheckUsable(at); err != nil {
+		return err
+	}
+	g.UsedAt = &at
+	return nil
+}
+
+// Revoke retires an unused grant, e.g. once the article was decided from the desk
+func (g *Grant) Revoke(at time.Time) {
+	if g.UsedAt == nil && g.RevokedAt == nil {
+		g.RevokedAt = &at
+	}
+}
+
+// Query Methods
+
+// CheckUsable reports why the grant can no longer be redeemed, nil when it can
+func (g *Grant) CheckUsable(at time.Time) error {
+	switch {
+	case g.UsedAt != nil:
+		return ErrUsed
+	case g.RevokedAt != nil:
+		return ErrRevoked
+	case !at.Before(g.ExpiresAt):
+		return ErrExpired
+	}
+	return nil
+}
+
+func (a Action) Valid() bool {
+	return a == ActionApprove || a == ActionReject
+}
@@ -0,0 +1,59 @@
+package approval
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewGrant(t *testing.T) {
+	at := time.Now()
+	testCases := []struct {
+		name          string
+		action        Action
+		ttl           time.Duration
+		expectedError error
+	}{
+		{"approve", ActionApprove, DefaultTTL, nil},
+		{"reject", ActionReject, MaxTTL, nil},
+		{"publish", "publish", DefaultTTL, ErrInvalidAction},
+		{"too long", ActionApprove, MaxTTL + time.Second, ErrInvalidTTL},
+		{"no expiry", ActionApprove, 0, ErrInvalidTTL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewGrant("grant-1", "hash", "editor-1", "article-1", tc.action, tc.ttl, at)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestGrant_Redeem(t *testing.T) {
+	at := time.Now()
+	grant, _ := NewGrant("grant-1", "hash", "editor-1", "article-1", ActionApprove, DefaultTTL, at)
+
+	if err := grant.Redeem(at.Add(DefaultTTL)); !errors.Is(err, ErrExpired) {
+		t.Errorf("expected error '%v', got '%v'", ErrExpired, err)
+	}
+	if err := grant.Redeem(at.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := grant.Redeem(at.Add(2 * time.Minute)); !errors.Is(err, ErrUsed) {
+		t.Errorf("expected error '%v', got '%v'", ErrUsed, err)
+	}
+
+	// A used grant stays used rather than revoked
+	grant.Revoke(at.Add(3 * time.Minute))
+	if grant.RevokedAt != nil {
+		t.Error("expected a used grant not to be revoked")
+	}
+
+	other, _ := NewGrant("grant-2", "hash-2", "editor-1", "article-1", ActionReject, DefaultTTL, at)
+	other.Revoke(at)
+	if err := other.Redeem(at.Add(time.Minute)); !errors.Is(err, ErrRevoked) {
+		t.Errorf("expected error '%v', got '%v'", ErrRevoked, err)
+	}
+}
@@ -0,0 +1,18 @@
+package approval
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interface for approval grants (implementation will be in infrastructure layer)
+type GrantRepository interface {
+	// Commands
+	Create(ctx context.Context, grants ...*Grant) error
+	Update(ctx context.Context, grant *Grant) error
+	// RevokeForArticle revokes every unused grant on the article and returns how many
+	RevokeForArticle(ctx context.Context, articleID string, at time.Time) (int64, error)
+
+	// Queries
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Grant, error)
+}
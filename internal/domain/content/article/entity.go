@@ -0,0 +1,391 @@
+package article
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Article length limits, in characters
+const (
+	MaxTitleLength   = 200
+	MaxSummaryLength = 500
+)
+
+// Workflow errors
+var (
+	ErrEmptyID           = shared.NewDomainError("article.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrEmptyActorID      = shared.NewDomainError("article.actor_required", shared.KindValidation, "actor ID cannot be empty")
+	ErrTitleEmpty        = shared.NewDomainError("article.title_required", shared.KindValidation, "article title cannot be empty")
+	ErrTitleTooLong      = shared.NewDomainError("article.title_too_long", shared.KindValidation, "article title cannot exceed 200 characters")
+	ErrSummaryTooLong    = shared.NewDomainError("article.summary_too_long", shared.KindValidation, "article summary cannot exceed 500 characters")
+	ErrBodyEmpty         = shared.NewDomainError("article.body_required", shared.KindValidation, "article body cannot be empty")
+	ErrEmptyReviewNote   = shared.NewDomainError("article.review_note_required", shared.KindValidation, "a rejected article needs a note for the author")
+	ErrScheduleInPast    = shared.NewDomainError("article.schedule_in_past", shared.KindValidation, "scheduled publish time must be in the future")
+	ErrInvalidTransition = shared.NewDomainError("article.invalid_transition", shared.KindState, "article cannot move to that status from its current one")
+	ErrNotEditable       = shared.NewDomainError("article.not_editable", shared.KindState, "only draft and in-review articles can be edited")
+	ErrSlugLocked        = shared.NewDomainError("article.slug_locked", shared.KindState, "slug cannot change once the article is published")
+	ErrNotDue            = shared.NewDomainError("article.not_due", shared.KindState, "article is scheduled for later")
+	ErrSelfApproval      = shared.NewDomainError("article.self_approval", shared.KindForbidden, "an article must be approved by someone other than its author")
+)
+
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusInReview  Status = "in_review"
+	StatusApproved  Status = "approved"
+	StatusPublished Status = "published"
+	StatusArchived  Status = "archived"
+)
+
+// transitions lists where each status can go, see the Business Methods for the rules on each move
+var transitions = map[Status][]Status{
+	StatusDraft:     {StatusInReview},
+	StatusInReview:  {StatusDraft, StatusApproved},
+	StatusApproved:  {StatusDraft, StatusPublished},
+	StatusPublished: {StatusArchived},
+}
+
+// Article is a story moving through the editorial workflow:
+// draft → in review → approved → published → archived
+type Article struct {
+	ID       string
+	Slug     Slug
+	Title    string
+	Summary  string
+	Body     string
+	AuthorID string
+
+	// Workflow
+	Status      Status
+	EditorID    *string // Editor who approved, cleared when the article goes back to draft
+	ReviewNote  *string // Why the last review sent it back
+	SubmittedAt *time.Time
+	ApprovedAt  *time.Time
+	ScheduledAt *time.Time // Publish time set by the editor, nil waits for a manual publish
+	PublishedAt *time.Time
+	ArchivedAt  *time.Time
+
+	Comments CommentSettings
+
+	// Revisions, see Revision
+	Version      int     // Bumped by every change to the copy or slug
+	RestoredFrom *string // Revision the current version was restored from, cleared by the next edit
+	RestoredBy   *string
+	RestoredAt   *time.Time
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	clock shared.Clock // Wall clock unless set, see SetClock
+}
+
+func NewArticle(id string, slug Slug, title, summary, body, authorID string) (*Article, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(authorID) == "" {
+		return nil, emptyActor("author")
+	}
+	title, err := validateTitle(title)
+	if err != nil {
+		return nil, err
+	}
+	summary, err = validateSummary(summary)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Article{
+		ID:           id,
+		Slug:         slug,
+		Title:        title,
+		Summary:      summary,
+		Body:         body,
+		AuthorID:     authorID,
+		Status:       StatusDraft,
+		Comments:     DefaultCommentSettings(),
+		Version:      1,
+		LastActionBy: &authorID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// SetClock makes the article read time from clock, e.g. a frozen clock in tests
+func (a *Article) SetClock(clock shared.Clock) {
+	a.clock = clock
+}
+
+func (a *Article) now() time.Time {
+	if a.clock == nil {
+		return time.Now()
+	}
+	return a.clock.Now()
+}
+
+// Business Methods
+
+// UpdateContent edits the copy, allowed while the article is a draft or in review
+func (a *Article) UpdateContent(title, summary, body, editorID string) error {
+	if !a.CanEdit() {
+		return ErrNotEditable
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return emptyActor("editor")
+	}
+	title, err := validateTitle(title)
+	if err != nil {
+		return err
+	}
+	summary, err = validateSummary(summary)
+	if err != nil {
+		return err
+	}
+
+	a.Title = title
+	a.Summary = summary
+	a.Body = body
+	a.newVersion()
+	a.touch(editorID)
+	return nil
+}
+
+// ChangeSlug is allowed until the article is published, after that the
+// permalink history owns the URL
+func (a *Article) ChangeSlug(slug Slug, editorID string) error {
+	if a.PublishedAt != nil {
+		return ErrSlugLocked
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return emptyActor("editor")
+	}
+	a.Slug = slug
+	a.newVersion()
+	a.touch(editorID)
+	return nil
+}
+
+// RestoreRevision brings back the copy and slug of an earlier version as a new
+// version, so the history keeps everything in between. Like any edit it is
+// allowed while the article is a draft or in review.
+func (a *Article) RestoreRevision(revision *Revision, editorID string) error {
+	if !a.CanEdit() {
+		return ErrNotEditable
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return emptyActor("editor")
+	}
+	if revision == nil || revision.ArticleID != a.ID {
+		return ErrRevisionMismatch
+	}
+	if revision.matches(a) {
+		return ErrNothingToRestore.With("version", strconv.Itoa(revision.Version))
+	}
+
+	a.Title = revision.Title
+	a.Summary = revision.Summary
+	a.Body = revision.Body
+	a.Slug = revision.Slug
+	a.newVersion()
+	a.touch(editorID)
+
+	restoredFrom := revision.ID
+	restoredAt := a.UpdatedAt
+	a.RestoredFrom = &restoredFrom
+	a.RestoredBy = &editorID
+	a.RestoredAt = &restoredAt
+	return nil
+}
+
+// SubmitForReview hands a complete draft to the editors
+func (a *Article) SubmitForReview(authorID string) error {
+	if err := a.checkTransition(StatusInReview); err != nil {
+		return err
+	}
+	if strings.TrimSpace(authorID) == "" {
+		return emptyActor("author")
+	}
+	if strings.TrimSpace(a.Body) == "" {
+		return ErrBodyEmpty
+	}
+
+	now := a.now()
+	a.Status = StatusInReview
+	a.SubmittedAt = &now
+	a.ReviewNote = nil
+	a.touch(authorID)
+	return nil
+}
+
+// Reject sends an article in review, or an approved one not yet published, back to draft
+func (a *Article) Reject(editorID, note string) error {
+	if err := a.checkTransition(StatusDraft); err != nil {
+		return err
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return emptyActor("editor")
+	}
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return ErrEmptyReviewNote
+	}
+
+	a.Status = StatusDraft
+	a.ReviewNote = &note
+	a.EditorID = nil
+	a.ApprovedAt = nil
+	a.ScheduledAt = nil
+	a.touch(editorID)
+	return nil
+}
+
+// Approve signs the article off for publishing, the author cannot approve their own work
+func (a *Article) Approve(editorID string) error {
+	if err := a.checkTransition(StatusApproved); err != nil {
+		return err
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return emptyActor("editor")
+	}
+	if editorID == a.AuthorID {
+		return ErrSelfApproval
+	}
+
+	now := a.now()
+	a.Status = StatusApproved
+	a.EditorID = &editorID
+	a.ApprovedAt = &now
+	a.touch(editorID)
+	return nil
+}
+
+// Schedule sets when an approved article goes live, nil clears the schedule
+func (a *Article) Schedule(at *time.Time, editorID string) error {
+	if a.Status != StatusApproved {
+		return ErrInvalidTransition.With("from", string(a.Status)).With("to", "scheduled")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return emptyActor("editor")
+	}
+	if at != nil && !at.After(a.now()) {
+		return ErrScheduleInPast
+	}
+
+	a.ScheduledAt = at
+	a.touch(editorID)
+	return nil
+}
+
+// Publish puts an approved article live, a scheduled one only once its time has come
+func (a *Article) Publish(publisherID string) error {
+	if err := a.checkTransition(StatusPublished); err != nil {
+		return err
+	}
+	if strings.TrimSpace(publisherID) == "" {
+		return emptyActor("publisher")
+	}
+	now := a.now()
+	if a.ScheduledAt != nil && now.Before(*a.ScheduledAt) {
+		return ErrNotDue.With("scheduled_at", a.ScheduledAt.Format(time.RFC3339))
+	}
+
+	a.Status = StatusPublished
+	a.PublishedAt = &now
+	a.touch(publisherID)
+	return nil
+}
+
+// Archive takes a published article off the site, it keeps its slug and publish date
+func (a *Article) Archive(archiverID string) error {
+	if err := a.checkTransition(StatusArchived); err != nil {
+		return err
+	}
+	if strings.TrimSpace(archiverID) == "" {
+		return emptyActor("archiver")
+	}
+
+	now := a.now()
+	a.Status = StatusArchived
+	a.ArchivedAt = &now
+	a.touch(archiverID)
+	return nil
+}
+
+// Query Methods
+
+func (a *Article) CanEdit() bool {
+	return a.Status == StatusDraft || a.Status == StatusInReview
+}
+
+func (a *Article) IsPublished() bool {
+	return a.Status == StatusPublished
+}
+
+// IsDue reports whether a scheduled article should be published at the given time
+func (a *Article) IsDue(at time.Time) bool {
+	return a.Status == StatusApproved && a.ScheduledAt != nil && !at.Before(*a.ScheduledAt)
+}
+
+func (a *Article) CanTransitionTo(status Status) bool {
+	for _, next := range transitions[a.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Article) checkTransition(to Status) error {
+	if !a.CanTransitionTo(to) {
+		return ErrInvalidTransition.With("from", string(a.Status)).With("to", string(to))
+	}
+	return nil
+}
+
+// newVersion starts a version, a restore sets its restore fields after calling it
+func (a *Article) newVersion() {
+	a.Version++
+	a.RestoredFrom = nil
+	a.RestoredBy = nil
+	a.RestoredAt = nil
+}
+
+func (a *Article) touch(actorID string) {
+	a.LastActionBy = &actorID
+	a.UpdatedAt = a.now()
+}
+
+// emptyActor names the missing actor, e.g. "editor ID cannot be empty", matching ErrEmptyActorID
+func emptyActor(role string) error {
+	return ErrEmptyActorID.WithMessage(role+" ID cannot be empty").With("role", role)
+}
+
+// Domain Validation Functions
+
+func validateTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", ErrTitleEmpty
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return "", ErrTitleTooLong
+	}
+	return title, nil
+}
+
+func validateSummary(summary string) (string, error) {
+	summary = strings.TrimSpace(summary)
+	if utf8.RuneCountInString(summary) > MaxSummaryLength {
+		return "", ErrSummaryTooLong
+	}
+	return summary, nil
+}
@@ -0,0 +1,141 @@
+package article
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+func newTestArticle(t *testing.T) *Article {
+	t.Helper()
+	slug, _ := NewSlug("budget-vote-delayed")
+	a, err := NewArticle("a1", *slug, "Budget vote delayed", "The council postponed the vote.", "Full story", "journalist-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return a
+}
+
+func TestNewArticle(t *testing.T) {
+	slug, _ := NewSlug("budget-vote-delayed")
+
+	tests := []struct {
+		name     string
+		id       string
+		title    string
+		summary  string
+		authorID string
+		errIs    error
+	}{
+		{"valid", "a1", "Budget vote delayed", "", "journalist-1", nil},
+		{"empty id", " ", "Budget vote delayed", "", "journalist-1", ErrEmptyID},
+		{"empty author", "a1", "Budget vote delayed", "", "", ErrEmptyActorID},
+		{"empty title", "a1", "  ", "", "journalist-1", ErrTitleEmpty},
+		{"long title", "a1", strings.Repeat("t", MaxTitleLength+1), "", "journalist-1", ErrTitleTooLong},
+		{"long summary", "a1", "Budget vote delayed", strings.Repeat("s", MaxSummaryLength+1), "journalist-1", ErrSummaryTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewArticle(tt.id, *slug, tt.title, tt.summary, "", tt.authorID)
+			if !errors.Is(err, tt.errIs) {
+				t.Fatalf("expected error '%v', got '%v'", tt.errIs, err)
+			}
+			if err == nil && (a.Status != StatusDraft || !a.Comments.Equals(DefaultCommentSettings())) {
+				t.Errorf("expected a new draft with default comment settings, got %+v", a)
+			}
+		})
+	}
+}
+
+func TestArticle_Workflow(t *testing.T) {
+	clock := shared.NewFrozenClock(time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+	a := newTestArticle(t)
+	a.SetClock(clock)
+
+	if err := a.Approve("editor-1"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTransition, err)
+	}
+	if err := a.SubmitForReview("journalist-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.Approve("journalist-1"); !errors.Is(err, ErrSelfApproval) {
+		t.Errorf("expected error '%v', got '%v'", ErrSelfApproval, err)
+	}
+	if err := a.Reject("editor-1", " "); !errors.Is(err, ErrEmptyReviewNote) {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptyReviewNote, err)
+	}
+	if err := a.Reject("editor-1", "needs a second source"); err != nil || a.Status != StatusDraft || *a.ReviewNote != "needs a second source" {
+		t.Fatalf("expected the article back in draft with a note, got %s %v", a.Status, err)
+	}
+
+	_ = a.SubmitForReview("journalist-1")
+	if a.ReviewNote != nil {
+		t.Error("expected the review note cleared on resubmission")
+	}
+	if err := a.Approve("editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.UpdateContent("New title", "", "Body", "journalist-1"); !errors.Is(err, ErrNotEditable) {
+		t.Errorf("expected error '%v', got '%v'", ErrNotEditable, err)
+	}
+
+	past := clock.Now().Add(-time.Minute)
+	if err := a.Schedule(&past, "editor-1"); !errors.Is(err, ErrScheduleInPast) {
+		t.Errorf("expected error '%v', got '%v'", ErrScheduleInPast, err)
+	}
+	at := clock.Now().Add(2 * time.Hour)
+	if err := a.Schedule(&at, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.Publish("scheduler"); !errors.Is(err, ErrNotDue) {
+		t.Errorf("expected error '%v', got '%v'", ErrNotDue, err)
+	}
+
+	clock.Advance(2 * time.Hour)
+	if !a.IsDue(clock.Now()) {
+		t.Error("expected the article due at its scheduled time")
+	}
+	if err := a.Publish("scheduler"); err != nil || !a.IsPublished() || !a.PublishedAt.Equal(at) {
+		t.Fatalf("expected the article published at %v, got %s %v", at, a.Status, err)
+	}
+
+	slug, _ := NewSlug("budget-vote-moved")
+	if err := a.ChangeSlug(*slug, "editor-1"); !errors.Is(err, ErrSlugLocked) {
+		t.Errorf("expected error '%v', got '%v'", ErrSlugLocked, err)
+	}
+	if err := a.Archive("editor-1"); err != nil || a.Status != StatusArchived || a.ArchivedAt == nil {
+		t.Fatalf("expected the article archived, got %s %v", a.Status, err)
+	}
+	if err := a.Publish("editor-1"); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTransition, err)
+	}
+}
+
+func TestArticle_SubmitRequiresBody(t *testing.T) {
+	a := newTestArticle(t)
+	if err := a.UpdateContent("Budget vote delayed", "", " ", "journalist-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.SubmitForReview("journalist-1"); !errors.Is(err, ErrBodyEmpty) {
+		t.Errorf("expected error '%v', got '%v'", ErrBodyEmpty, err)
+	}
+}
+
+func TestArticle_RejectApprovedClearsSchedule(t *testing.T) {
+	a := newTestArticle(t)
+	_ = a.SubmitForReview("journalist-1")
+	_ = a.Approve("editor-1")
+	at := time.Now().Add(time.Hour)
+	_ = a.Schedule(&at, "editor-1")
+
+	if err := a.Reject("editor-2", "legal wants another look"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.ScheduledAt != nil || a.EditorID != nil || a.ApprovedAt != nil {
+		t.Errorf("expected approval and schedule cleared, got %+v", a)
+	}
+}
@@ -0,0 +1,36 @@
+package article
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interface for articles (implementation will be in infrastructure layer)
+type ArticleRepository interface {
+	Create(ctx context.Context, article *Article) error
+	// Update saves the article, callers save a NewRevision with it when the Version changed
+	Update(ctx context.Context, article *Article) error
+	FindByID(ctx context.Context, id string) (*Article, error)
+	FindBySlug(ctx context.Context, slug string) (*Article, error)
+	FindByStatus(ctx context.Context, status Status, limit, offset int) ([]*Article, error)
+
+	// FindDue returns approved articles whose scheduled time is at or before at, oldest first
+	FindDue(ctx context.Context, at time.Time, limit int) ([]*Article, error)
+}
+
+// Domain interface for article revisions (implementation will be in infrastructure layer).
+// Revisions are never changed once saved.
+type RevisionRepository interface {
+	Create(ctx context.Context, revision *Revision) error
+	FindByID(ctx context.Context, id string) (*Revision, error)
+	// FindByArticle returns the history newest version first
+	FindByArticle(ctx context.Context, articleID string, limit, offset int) ([]*Revision, error)
+}
+
+type CommentSettingsRepository interface {
+	FindCommentSettings(ctx context.Context, articleID string) (*CommentSettings, error)
+	SaveCommentSettings(ctx context.Context, articleID string, settings CommentSettings) error
+
+	// BulkApplyCommentSettings patches every article in the category and returns the number updated
+	BulkApplyCommentSettings(ctx context.Context, categoryID string, patch CommentSettingsPatch) (int64, error)
+}
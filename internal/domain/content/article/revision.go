@@ -0,0 +1,71 @@
+package article
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Revision errors
+var (
+	ErrRevisionMismatch = shared.NewDomainError("article.revision_mismatch", shared.KindValidation, "revision belongs to another article")
+	ErrNothingToRestore = shared.NewDomainError("article.nothing_to_restore", shared.KindConflict, "article already matches that revision")
+	ErrUnversioned      = shared.NewDomainError("article.unversioned", shared.KindValidation, "article has no version to snapshot")
+)
+
+// Revision is a snapshot of an article's copy and metadata at one version. A
+// revision is saved for every version, so the history lists who changed what
+// and any version can be restored.
+type Revision struct {
+	ID        string
+	ArticleID string
+	Version   int
+	Title     string
+	Summary   string
+	Body      string
+	Slug      Slug
+	Status    Status // Workflow status when the snapshot was taken, for context only
+
+	EditorID     string
+	RestoredFrom *string // Revision this version restored, nil for a normal edit
+
+	// Audit
+	CreatedAt time.Time
+}
+
+// NewRevision snapshots the article as it is now. Call it after every change
+// that bumps the article's Version and save both together.
+func NewRevision(id string, a *Article) (*Revision, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if a.Version < 1 || a.LastActionBy == nil {
+		return nil, ErrUnversioned
+	}
+
+	r := &Revision{
+		ID:        id,
+		ArticleID: a.ID,
+		Version:   a.Version,
+		Title:     a.Title,
+		Summary:   a.Summary,
+		Body:      a.Body,
+		Slug:      a.Slug,
+		Status:    a.Status,
+		EditorID:  *a.LastActionBy,
+		CreatedAt: a.UpdatedAt,
+	}
+	if a.RestoredFrom != nil {
+		restoredFrom := *a.RestoredFrom
+		r.RestoredFrom = &restoredFrom
+	}
+	return r, nil
+}
+
+// Query Methods
+
+// matches reports whether the article's copy already equals the snapshot
+func (r *Revision) matches(a *Article) bool {
+	return r.Title == a.Title && r.Summary == a.Summary && r.Body == a.Body && r.Slug.Equals(a.Slug)
+}
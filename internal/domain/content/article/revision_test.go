@@ -0,0 +1,75 @@
+package article
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArticle_RestoreRevision(t *testing.T) {
+	a := newTestArticle(t)
+	first, err := NewRevision("r1", a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Version != 1 || first.EditorID != "journalist-1" || first.RestoredFrom != nil {
+		t.Errorf("expected version 1 by the author, got %+v", first)
+	}
+
+	if err := a.UpdateContent("Budget vote moved to Friday", "New date set.", "Updated story", "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Version != 2 {
+		t.Errorf("expected version 2 after an edit, got %d", a.Version)
+	}
+
+	if err := a.RestoreRevision(first, " "); !errors.Is(err, ErrEmptyActorID) {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptyActorID, err)
+	}
+	if err := a.RestoreRevision(&Revision{ID: "other", ArticleID: "a2"}, "editor-1"); !errors.Is(err, ErrRevisionMismatch) {
+		t.Errorf("expected error '%v', got '%v'", ErrRevisionMismatch, err)
+	}
+
+	if err := a.RestoreRevision(first, "editor-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Title != "Budget vote delayed" || a.Body != "Full story" || a.Version != 3 {
+		t.Errorf("expected version 1's copy as version 3, got %q at %d", a.Title, a.Version)
+	}
+	if *a.RestoredFrom != "r1" || *a.RestoredBy != "editor-2" || *a.LastActionBy != "editor-2" {
+		t.Errorf("expected the restore recorded, got %+v", a)
+	}
+	restored, _ := NewRevision("r3", a)
+	if restored.Version != 3 || *restored.RestoredFrom != "r1" {
+		t.Errorf("expected the snapshot to name the restored revision, got %+v", restored)
+	}
+
+	if err := a.RestoreRevision(first, "editor-2"); !errors.Is(err, ErrNothingToRestore) {
+		t.Errorf("expected error '%v', got '%v'", ErrNothingToRestore, err)
+	}
+
+	// The next edit is a normal one again
+	_ = a.UpdateContent("Budget vote delayed again", "", "Story", "editor-1")
+	if a.RestoredFrom != nil {
+		t.Error("expected restore fields cleared by the next edit")
+	}
+}
+
+func TestArticle_RestoreRevisionAfterPublish(t *testing.T) {
+	a := newTestArticle(t)
+	first, _ := NewRevision("r1", a)
+	moved, _ := NewSlug("budget-vote-moved")
+	_ = a.ChangeSlug(*moved, "editor-1")
+	_ = a.SubmitForReview("journalist-1")
+	_ = a.Approve("editor-1")
+	if err := a.Publish("editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The permalink history owns a published article's URL
+	if err := a.RestoreRevision(first, "editor-1"); !errors.Is(err, ErrNotEditable) {
+		t.Errorf("expected error '%v', got '%v'", ErrNotEditable, err)
+	}
+	if a.Slug.Value() != "budget-vote-moved" || a.Version != 2 {
+		t.Errorf("expected the published article untouched, got %s at %d", a.Slug.Value(), a.Version)
+	}
+}
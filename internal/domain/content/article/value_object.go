@@ -0,0 +1,177 @@
+package article
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// MaxAutoCloseDays is the longest period comments can stay open automatically
+const MaxAutoCloseDays = 365
+
+// Domain errors
+var (
+	ErrInvalidAutoCloseDays      = errors.New("auto-close days must be between 0 and 365")
+	ErrCommentsDisabled          = errors.New("comments are disabled for this article")
+	ErrCommentsClosed            = errors.New("comments are closed for this article")
+	ErrCommentsMembersOnly       = errors.New("comments are open to signed-in members only")
+	ErrCommentsNotPublished      = errors.New("comments open once the article is published")
+	ErrCommenterNotActive        = errors.New("commenter account is not active")
+	ErrEmptyCommentSettingsPatch = errors.New("comment settings patch has no changes")
+	ErrInvalidSlug               = errors.New("slug must be 3-120 lowercase letters, digits or single hyphens")
+)
+
+// Slug value object, the article's URL segment
+type Slug struct {
+	value string
+}
+
+func NewSlug(value string) (*Slug, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) < 3 || len(value) > 120 || !slugRegex.MatchString(value) {
+		return nil, ErrInvalidSlug
+	}
+	return &Slug{value: value}, nil
+}
+
+func (s Slug) String() string {
+	return s.value
+}
+
+func (s Slug) Value() string {
+	return s.value
+}
+
+func (s Slug) Equals(other Slug) bool {
+	return s.value == other.value
+}
+
+// CommentSettings value object, the comment controls stored on each article
+type CommentSettings struct {
+	enabled       bool
+	membersOnly   bool
+	autoCloseDays int // 0 keeps comments open indefinitely
+	preModeration bool
+}
+
+func NewCommentSettings(enabled, membersOnly bool, autoCloseDays int, preModeration bool) (*CommentSettings, error) {
+	if autoCloseDays < 0 || autoCloseDays > MaxAutoCloseDays {
+		return nil, ErrInvalidAutoCloseDays
+	}
+	return &CommentSettings{
+		enabled:       enabled,
+		membersOnly:   membersOnly,
+		autoCloseDays: autoCloseDays,
+		preModeration: preModeration,
+	}, nil
+}
+
+// DefaultCommentSettings applies to new articles: open to everyone, closing after 14 days
+func DefaultCommentSettings() CommentSettings {
+	return CommentSettings{enabled: true, autoCloseDays: 14}
+}
+
+func (c CommentSettings) Enabled() bool {
+	return c.enabled
+}
+
+func (c CommentSettings) MembersOnly() bool {
+	return c.membersOnly
+}
+
+func (c CommentSettings) AutoCloseDays() int {
+	return c.autoCloseDays
+}
+
+func (c CommentSettings) RequiresPreModeration() bool {
+	return c.preModeration
+}
+
+func (c CommentSettings) Equals(other CommentSettings) bool {
+	return c == other
+}
+
+// ClosesAt returns when comments close for an article published at the given time, nil if never
+func (c CommentSettings) ClosesAt(publishedAt time.Time) *time.Time {
+	if c.autoCloseDays == 0 {
+		return nil
+	}
+	closesAt := publishedAt.AddDate(0, 0, c.autoCloseDays)
+	return &closesAt
+}
+
+// IsOpen reports whether the article accepts comments at the given time
+func (c CommentSettings) IsOpen(publishedAt *time.Time, at time.Time) bool {
+	return c.checkOpen(publishedAt, at) == nil
+}
+
+// CheckCommenter is the rule the comment service enforces before accepting a comment.
+// A nil commenter is a guest.
+func (c CommentSettings) CheckCommenter(publishedAt *time.Time, commenter *account.UserAccount, at time.Time) error {
+	if err := c.checkOpen(publishedAt, at); err != nil {
+		return err
+	}
+	if commenter != nil && !commenter.CanLogin() {
+		return ErrCommenterNotActive
+	}
+	if c.membersOnly && commenter == nil {
+		return ErrCommentsMembersOnly
+	}
+	return nil
+}
+
+func (c CommentSettings) checkOpen(publishedAt *time.Time, at time.Time) error {
+	if !c.enabled {
+		return ErrCommentsDisabled
+	}
+	if publishedAt == nil {
+		return ErrCommentsNotPublished
+	}
+	if closesAt := c.ClosesAt(*publishedAt); closesAt != nil && !at.Before(*closesAt) {
+		return ErrCommentsClosed
+	}
+	return nil
+}
+
+// CommentSettingsPatch is a partial update used for bulk edits across a category
+type CommentSettingsPatch struct {
+	Enabled       *bool
+	MembersOnly   *bool
+	AutoCloseDays *int
+	PreModeration *bool
+}
+
+func (p CommentSettingsPatch) Validate() error {
+	if p.Enabled == nil && p.MembersOnly == nil && p.AutoCloseDays == nil && p.PreModeration == nil {
+		return ErrEmptyCommentSettingsPatch
+	}
+	if p.AutoCloseDays != nil && (*p.AutoCloseDays < 0 || *p.AutoCloseDays > MaxAutoCloseDays) {
+		return ErrInvalidAutoCloseDays
+	}
+	return nil
+}
+
+// Apply returns the settings with the patch applied
+func (p CommentSettingsPatch) Apply(settings CommentSettings) (CommentSettings, error) {
+	if err := p.Validate(); err != nil {
+		return settings, err
+	}
+	if p.Enabled != nil {
+		settings.enabled = *p.Enabled
+	}
+	if p.MembersOnly != nil {
+		settings.membersOnly = *p.MembersOnly
+	}
+	if p.AutoCloseDays != nil {
+		settings.autoCloseDays = *p.AutoCloseDays
+	}
+	if p.PreModeration != nil {
+		settings.preModeration = *p.PreModeration
+	}
+	return settings, nil
+}
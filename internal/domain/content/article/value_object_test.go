@@ -0,0 +1,121 @@
+package article
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestNewCommentSettings(t *testing.T) {
+	testCases := []struct {
+		name        string
+		days        int
+		expectedErr error
+	}{
+		{"valid never close", 0, nil},
+		{"valid one week", 7, nil},
+		{"valid max", MaxAutoCloseDays, nil},
+		{"invalid - negative", -1, ErrInvalidAutoCloseDays},
+		{"invalid - too long", MaxAutoCloseDays + 1, ErrInvalidAutoCloseDays},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewCommentSettings(true, false, tc.days, false)
+			if tc.expectedErr != nil {
+				if err != tc.expectedErr {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s.AutoCloseDays() != tc.days {
+				t.Errorf("expected %d days, got %d", tc.days, s.AutoCloseDays())
+			}
+		})
+	}
+}
+
+func TestCommentSettings_CheckCommenter(t *testing.T) {
+	published := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	withinWindow := published.AddDate(0, 0, 3)
+	afterWindow := published.AddDate(0, 0, 7)
+
+	member := createTestMember(t, true)
+	unverified := createTestMember(t, false)
+
+	open, _ := NewCommentSettings(true, false, 7, false)
+	membersOnly, _ := NewCommentSettings(true, true, 0, true)
+	disabled, _ := NewCommentSettings(false, false, 0, false)
+
+	testCases := []struct {
+		name        string
+		settings    CommentSettings
+		publishedAt *time.Time
+		commenter   *account.UserAccount
+		at          time.Time
+		expectedErr error
+	}{
+		{"guest on open article", *open, &published, nil, withinWindow, nil},
+		{"member on open article", *open, &published, member, withinWindow, nil},
+		{"auto closed", *open, &published, member, afterWindow, ErrCommentsClosed},
+		{"unpublished article", *open, nil, member, withinWindow, ErrCommentsNotPublished},
+		{"disabled", *disabled, &published, member, withinWindow, ErrCommentsDisabled},
+		{"guest on members only", *membersOnly, &published, nil, withinWindow, ErrCommentsMembersOnly},
+		{"member on members only never closes", *membersOnly, &published, member, published.AddDate(5, 0, 0), nil},
+		{"inactive account", *open, &published, unverified, withinWindow, ErrCommenterNotActive},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.settings.CheckCommenter(tc.publishedAt, tc.commenter, tc.at)
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+
+	if !membersOnly.RequiresPreModeration() {
+		t.Error("expected pre-moderation to be required")
+	}
+}
+
+func TestCommentSettingsPatch_Apply(t *testing.T) {
+	disable := false
+	days := 30
+	invalidDays := -5
+
+	patched, err := CommentSettingsPatch{Enabled: &disable, AutoCloseDays: &days}.Apply(DefaultCommentSettings())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patched.Enabled() || patched.AutoCloseDays() != 30 {
+		t.Errorf("expected disabled settings with 30 days, got %+v", patched)
+	}
+	if patched.MembersOnly() != DefaultCommentSettings().MembersOnly() {
+		t.Error("expected untouched fields to be preserved")
+	}
+
+	if _, err := (CommentSettingsPatch{}).Apply(DefaultCommentSettings()); err != ErrEmptyCommentSettingsPatch {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptyCommentSettingsPatch, err)
+	}
+	if _, err := (CommentSettingsPatch{AutoCloseDays: &invalidDays}).Apply(DefaultCommentSettings()); err != ErrInvalidAutoCloseDays {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidAutoCloseDays, err)
+	}
+}
+
+func createTestMember(t *testing.T, verified bool) *account.UserAccount {
+	acc, err := account.NewUserAccountForSelfRegistration("member123", "member_user", "member@example.com", "hashed_password")
+	if err != nil {
+		t.Fatalf("failed to create member: %v", err)
+	}
+	if verified {
+		if err := acc.SelfVerify(); err != nil {
+			t.Fatalf("failed to verify member: %v", err)
+		}
+	}
+	return acc
+}
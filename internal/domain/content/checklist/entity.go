@@ -0,0 +1,159 @@
+package checklist
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Template is a desk's pre-publish checklist. Desks without their own use
+// the DefaultDesk template, and DefaultTemplate until that is saved.
+type Template struct {
+	Desk  string
+	Items []Item
+
+	LastActionBy *string
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+// DefaultTemplate requires every item. Legal review only applies to
+// articles with sensitive tags or a sensitive-content classification.
+func DefaultTemplate() *Template {
+	return &Template{
+		Desk: DefaultDesk,
+		Items: []Item{
+			{Kind: ItemCategory, Required: true},
+			{Kind: ItemTags, Required: true, MinTags: 1},
+			{Kind: ItemHeroImage, Required: true},
+			{Kind: ItemSEOFields, Required: true},
+			{Kind: ItemAccessibility, Required: true},
+			{Kind: ItemLegalReview, Required: true},
+		},
+	}
+}
+
+func NewTemplate(desk string, items []Item, editorID string, at time.Time) (*Template, error) {
+	if err := ValidateDesk(desk); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editorID cannot be empty")
+	}
+	if len(items) == 0 {
+		return nil, ErrNoItems
+	}
+	seen := make(map[ItemKind]struct{}, len(items))
+	for _, item := range items {
+		if _, ok := seen[item.Kind]; ok {
+			return nil, ErrDuplicateItem
+		}
+		seen[item.Kind] = struct{}{}
+	}
+	return &Template{Desk: desk, Items: items, LastActionBy: &editorID, UpdatedAt: at}, nil
+}
+
+// Evidence is what the workflow gathers besides the article itself
+type Evidence struct {
+	AccessibilityBlocking []string // Failed required accessibility checks
+	Sensitive             []string // Sensitive-content categories the article is classified under
+	Approval              *Approval
+}
+
+// Query Methods
+
+// Evaluate runs the checklist. Publishing is blocked while a required item fails.
+func (t *Template) Evaluate(a Article, e Evidence) *Result {
+	result := &Result{Desk: t.Desk}
+	for _, item := range t.Items {
+		passed, message := item.evaluate(a, e)
+		result.Items = append(result.Items, ItemResult{Kind: item.Kind, Required: item.Required, Passed: passed, Message: message})
+	}
+	return result
+}
+
+func (i Item) evaluate(a Article, e Evidence) (bool, string) {
+	switch i.Kind {
+	case ItemCategory:
+		if strings.TrimSpace(a.CategoryID) == "" {
+			return false, "choose a category"
+		}
+	case ItemTags:
+		if len(a.Tags) < i.MinTags {
+			return false, "add at least " + strconv.Itoa(i.MinTags) + " tag(s)"
+		}
+	case ItemHeroImage:
+		switch {
+		case a.Hero == nil || strings.TrimSpace(a.Hero.URL) == "":
+			return false, "add a hero image"
+		case strings.TrimSpace(a.Hero.Credit) == "":
+			return false, "credit the hero image"
+		}
+	case ItemSEOFields:
+		var missing []string
+		if strings.TrimSpace(a.SEOTitle) == "" {
+			missing = append(missing, "SEO title")
+		}
+		if strings.TrimSpace(a.MetaDescription) == "" {
+			missing = append(missing, "meta description")
+		}
+		if len(missing) > 0 {
+			return false, "fill in " + strings.Join(missing, " and ")
+		}
+	case ItemAccessibility:
+		if len(e.AccessibilityBlocking) > 0 {
+			return false, "fix accessibility checks: " + strings.Join(e.AccessibilityBlocking, ", ")
+		}
+	case ItemLegalReview:
+		sensitive := append(i.sensitiveIn(a.Tags), e.Sensitive...)
+		if len(sensitive) == 0 {
+			return true, "nothing sensitive"
+		}
+		if e.Approval == nil || e.Approval.Revision != a.Revision {
+			return false, "legal review needed for " + strings.Join(sensitive, ", ")
+		}
+	}
+	return true, ""
+}
+
+// NeedsLegalReview reports whether the article carries a tag or
+// classification legal must approve
+func (t *Template) NeedsLegalReview(a Article, e Evidence) bool {
+	for _, item := range t.Items {
+		if item.Kind == ItemLegalReview && (len(item.sensitiveIn(a.Tags)) > 0 || len(e.Sensitive) > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// ItemResult is one evaluated line, Message says what to do when it failed
+type ItemResult struct {
+	Kind     ItemKind
+	Required bool
+	Passed   bool
+	Message  string
+}
+
+// Result is the evaluated checklist shown next to the publish button
+type Result struct {
+	Desk  string
+	Items []ItemResult
+}
+
+func (r *Result) Blocked() bool {
+	return len(r.Blocking()) > 0
+}
+
+// Blocking lists the failed required items
+func (r *Result) Blocking() []ItemKind {
+	var blocking []ItemKind
+	for _, item := range r.Items {
+		if item.Required && !item.Passed {
+			blocking = append(blocking, item.Kind)
+		}
+	}
+	return blocking
+}
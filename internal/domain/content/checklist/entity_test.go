@@ -0,0 +1,129 @@
+package checklist
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewItem(t *testing.T) {
+	testCases := []struct {
+		name        string
+		kind        ItemKind
+		minTags     int
+		expectedErr error
+	}{
+		{"category", ItemCategory, 0, nil},
+		{"tags", ItemTags, 3, nil},
+		{"tags zero", ItemTags, 0, ErrInvalidMinTags},
+		{"tags too many", ItemTags, 21, ErrInvalidMinTags},
+		{"unknown", ItemKind("byline"), 0, ErrInvalidItemKind},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewItem(tc.kind, true, tc.minTags, nil); err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+
+	item, _ := NewItem(ItemLegalReview, true, 0, []string{" Court-Case ", "court-case", "", "election"})
+	if len(item.SensitiveTags) != 2 || item.SensitiveTags[0] != "court-case" {
+		t.Errorf("expected normalized sensitive tags, got %v", item.SensitiveTags)
+	}
+}
+
+func TestNewTemplate(t *testing.T) {
+	items := []Item{{Kind: ItemCategory, Required: true}}
+	testCases := []struct {
+		name        string
+		desk        string
+		items       []Item
+		expectedErr error
+	}{
+		{"valid", "politics", items, nil},
+		{"invalid desk", "Politics Desk", items, ErrInvalidDesk},
+		{"no items", "politics", nil, ErrNoItems},
+		{"duplicate", "politics", append(items, Item{Kind: ItemCategory}), ErrDuplicateItem},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewTemplate(tc.desk, tc.items, "chief-1", time.Now()); err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func completeArticle() Article {
+	return Article{
+		ID:              "a1",
+		Revision:        "r2",
+		Desk:            "politics",
+		SEOTitle:        "Sidang korupsi",
+		MetaDescription: "Hakim membacakan putusan.",
+		CategoryID:      "cat-1",
+		Tags:            []string{"kpk", "Court-Case"},
+		Hero:            &HeroImage{URL: "https://cdn.example.com/a1.jpg", Credit: "Antara/Rina"},
+	}
+}
+
+func TestTemplate_Evaluate(t *testing.T) {
+	template := DefaultTemplate()
+	template.Items[1].MinTags = 2
+	template.Items[5].SensitiveTags = []string{"court-case"}
+	approved := &Approval{ArticleID: "a1", Revision: "r2", ReviewerID: "legal-1"}
+
+	testCases := []struct {
+		name     string
+		modify   func(a *Article)
+		evidence Evidence
+		blocking []ItemKind
+	}{
+		{"all done", func(a *Article) {}, Evidence{Approval: approved}, nil},
+		{"no category", func(a *Article) { a.CategoryID = "" }, Evidence{Approval: approved}, []ItemKind{ItemCategory}},
+		{"too few tags", func(a *Article) { a.Tags = []string{"court-case"} }, Evidence{Approval: approved}, []ItemKind{ItemTags}},
+		{"hero without credit", func(a *Article) { a.Hero.Credit = " " }, Evidence{Approval: approved}, []ItemKind{ItemHeroImage}},
+		{"no hero", func(a *Article) { a.Hero = nil }, Evidence{Approval: approved}, []ItemKind{ItemHeroImage}},
+		{"missing SEO", func(a *Article) { a.MetaDescription = "" }, Evidence{Approval: approved}, []ItemKind{ItemSEOFields}},
+		{"accessibility failed", func(a *Article) {}, Evidence{Approval: approved, AccessibilityBlocking: []string{"image_alt"}}, []ItemKind{ItemAccessibility}},
+		{"legal review missing", func(a *Article) {}, Evidence{}, []ItemKind{ItemLegalReview}},
+		{"approval of older revision", func(a *Article) { a.Revision = "r3" }, Evidence{Approval: approved}, []ItemKind{ItemLegalReview}},
+		{"no sensitive tags", func(a *Article) { a.Tags = []string{"kpk", "dpr"} }, Evidence{}, nil},
+		{"classified sensitive", func(a *Article) { a.Tags = []string{"kpk", "dpr"} }, Evidence{Sensitive: []string{"minors"}}, []ItemKind{ItemLegalReview}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := completeArticle()
+			a.Hero = &HeroImage{URL: a.Hero.URL, Credit: a.Hero.Credit}
+			tc.modify(&a)
+
+			result := template.Evaluate(a, tc.evidence)
+			blocking := result.Blocking()
+			if len(blocking) != len(tc.blocking) {
+				t.Fatalf("expected blocking %v, got %v", tc.blocking, blocking)
+			}
+			for i := range blocking {
+				if blocking[i] != tc.blocking[i] {
+					t.Errorf("expected blocking %v, got %v", tc.blocking, blocking)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplate_EvaluateOptionalItems(t *testing.T) {
+	template, _ := NewTemplate("opinion", []Item{{Kind: ItemCategory, Required: true}, {Kind: ItemHeroImage, Required: false}}, "chief-1", time.Now())
+	a := completeArticle()
+	a.Hero = nil
+
+	result := template.Evaluate(a, Evidence{})
+	if result.Blocked() {
+		t.Errorf("expected optional items not to block, got %v", result.Blocking())
+	}
+	if result.Items[1].Passed || result.Items[1].Message != "add a hero image" {
+		t.Errorf("expected the optional item shown as failed, got %+v", result.Items[1])
+	}
+}
@@ -0,0 +1,25 @@
+package checklist
+
+import "context"
+
+type TemplateRepository interface {
+	// Save replaces the desk's template
+	Save(ctx context.Context, template *Template) error
+	Delete(ctx context.Context, desk string) error
+
+	// FindByDesk returns nil when the desk has no variant
+	FindByDesk(ctx context.Context, desk string) (*Template, error)
+	FindAll(ctx context.Context) ([]*Template, error)
+}
+
+type ApprovalRepository interface {
+	Save(ctx context.Context, approval Approval) error
+	// FindLatest returns the newest approval of the article, nil when there is none
+	FindLatest(ctx context.Context, articleID string) (*Approval, error)
+}
+
+// ArticleSource loads articles for evaluation (implemented by the article module)
+type ArticleSource interface {
+	// FindForChecklist returns the current draft, nil when the article does not exist
+	FindForChecklist(ctx context.Context, articleID string) (*Article, error)
+}
@@ -0,0 +1,131 @@
+package checklist
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+// ScopeLegalReview is granted to the legal desk role
+const ScopeLegalReview entitlement.Scope = "articles:legal_review"
+
+// DefaultDesk holds the checklist for desks without their own variant
+const DefaultDesk = "default"
+
+const MaxSensitiveTags = 200
+
+var deskRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Domain errors
+var (
+	ErrInvalidItemKind  = errors.New("unknown checklist item")
+	ErrInvalidMinTags   = errors.New("minimum tags must be between 1 and 20")
+	ErrDuplicateItem    = errors.New("checklist has the same item twice")
+	ErrNoItems          = errors.New("checklist needs at least one item")
+	ErrTooManySensitive = errors.New("too many sensitive tags, the limit is 200")
+	ErrInvalidDesk      = errors.New("desk must be lowercase letters, digits and hyphens")
+	ErrPublishBlocked   = errors.New("required publish checklist items are not done")
+	ErrNothingToApprove = errors.New("article has no sensitive tags or classification to review")
+)
+
+// ItemKind is one thing the checklist verifies before publishing
+type ItemKind string
+
+const (
+	ItemCategory      ItemKind = "category"      // A primary category is set
+	ItemTags          ItemKind = "tags"          // At least MinTags tags
+	ItemHeroImage     ItemKind = "hero_image"    // A hero image with a photo credit
+	ItemSEOFields     ItemKind = "seo_fields"    // SEO title and meta description
+	ItemAccessibility ItemKind = "accessibility" // The accessibility checklist is not blocked
+	ItemLegalReview   ItemKind = "legal_review"  // Sensitive articles are approved by legal
+)
+
+// Item value object, one line of a checklist
+type Item struct {
+	Kind          ItemKind
+	Required      bool // Optional items are shown but never block
+	MinTags       int
+	SensitiveTags []string // Tag slugs that need legal review, e.g. "court-case"
+}
+
+func NewItem(kind ItemKind, required bool, minTags int, sensitiveTags []string) (Item, error) {
+	item := Item{Kind: kind, Required: required}
+	switch kind {
+	case ItemCategory, ItemHeroImage, ItemSEOFields, ItemAccessibility:
+	case ItemTags:
+		if minTags < 1 || minTags > 20 {
+			return Item{}, ErrInvalidMinTags
+		}
+		item.MinTags = minTags
+	case ItemLegalReview:
+		if len(sensitiveTags) > MaxSensitiveTags {
+			return Item{}, ErrTooManySensitive
+		}
+		seen := make(map[string]struct{}, len(sensitiveTags))
+		for _, tag := range sensitiveTags {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if _, ok := seen[tag]; tag == "" || ok {
+				continue
+			}
+			seen[tag] = struct{}{}
+			item.SensitiveTags = append(item.SensitiveTags, tag)
+		}
+	default:
+		return Item{}, ErrInvalidItemKind
+	}
+	return item, nil
+}
+
+func ValidateDesk(desk string) error {
+	if len(desk) > 50 || !deskRegex.MatchString(desk) {
+		return ErrInvalidDesk
+	}
+	return nil
+}
+
+// HeroImage is the article's lead picture
+type HeroImage struct {
+	URL    string
+	Credit string
+}
+
+// Approval records legal signing off one revision of an article
+type Approval struct {
+	ArticleID  string
+	Revision   string
+	ReviewerID string
+	Note       string
+	ApprovedAt time.Time
+}
+
+// Article is what the checklist looks at, loaded by the workflow before the publish transition
+type Article struct {
+	ID              string
+	Revision        string // An approval only covers the revision it was given for
+	SiteID          string
+	Desk            string
+	Title           string
+	SEOTitle        string
+	MetaDescription string
+	Language        string
+	Body            string
+	CategoryID      string
+	Tags            []string
+	Hero            *HeroImage
+}
+
+// sensitiveIn returns the article's tags the item flags for legal review
+func (i Item) sensitiveIn(tags []string) []string {
+	var found []string
+	for _, tag := range tags {
+		for _, sensitive := range i.SensitiveTags {
+			if strings.EqualFold(tag, sensitive) {
+				found = append(found, sensitive)
+			}
+		}
+	}
+	return found
+}
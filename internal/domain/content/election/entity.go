@@ -0,0 +1,245 @@
+package election
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Race is one contest covered live, e.g. "Pilpres 2029" or "Pilkada DKI".
+// Source names the feed adapter and FeedRef what the adapter fetches.
+type Race struct {
+	ID         string
+	Name       string
+	Source     string
+	FeedRef    string
+	Candidates []Candidate
+	Closed     bool // Closed races ignore the feed and refuse overrides
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewRace(id, name, source, feedRef string, candidates []Candidate, createdBy string, at time.Time) (*Race, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxRaceName {
+		return nil, ErrInvalidRaceName
+	}
+	if err := validateCandidates(candidates); err != nil {
+		return nil, err
+	}
+	return &Race{
+		ID:           id,
+		Name:         name,
+		Source:       strings.TrimSpace(source),
+		FeedRef:      strings.TrimSpace(feedRef),
+		Candidates:   candidates,
+		LastActionBy: &createdBy,
+		CreatedAt:    at,
+		UpdatedAt:    at,
+	}, nil
+}
+
+// Business Methods
+
+// Close freezes the results once they are certified
+func (r *Race) Close(editorID string, at time.Time) {
+	r.Closed = true
+	r.LastActionBy = &editorID
+	r.UpdatedAt = at
+}
+
+// Query Methods
+
+// CheckVotes rejects counts for candidates outside the race and negative counts
+func (r *Race) CheckVotes(votes Counts) error {
+	for id, count := range votes {
+		if !r.hasCandidate(id) {
+			return ErrUnknownCandidate
+		}
+		if count < 0 {
+			return ErrNegativeVotes
+		}
+	}
+	return nil
+}
+
+// CheckReport validates a feed report before it is applied
+func (r *Race) CheckReport(report Report) error {
+	if _, err := NormalizeRegion(report.Region); err != nil {
+		return err
+	}
+	if report.StationsReported < 0 || report.StationsTotal < 0 || report.StationsReported > report.StationsTotal {
+		return ErrInvalidStations
+	}
+	return r.CheckVotes(report.Votes)
+}
+
+func (r *Race) hasCandidate(id string) bool {
+	for _, c := range r.Candidates {
+		if c.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Override is an editor's correction of a region's votes, shown instead of
+// the feed until it is cleared
+type Override struct {
+	Votes    Counts
+	EditorID string
+	Reason   string
+	At       time.Time
+}
+
+// Tally is the current count of one region in a race. Version goes up on
+// every change and each change writes a Revision.
+type Tally struct {
+	RaceID   string
+	Region   string
+	Feed     Report
+	Override *Override
+	Version  int64
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+func NewTally(raceID, region string) *Tally {
+	return &Tally{RaceID: raceID, Region: region, Feed: Report{Region: region, Votes: Counts{}}}
+}
+
+// Business Methods
+
+// ApplyFeed stores the feed's numbers. It returns nil when nothing changed,
+// so polling an unchanged feed does not bump the version.
+func (t *Tally) ApplyFeed(report Report, at time.Time) *Revision {
+	if t.Version > 0 && t.Feed.Votes.Equals(report.Votes) &&
+		t.Feed.StationsReported == report.StationsReported && t.Feed.StationsTotal == report.StationsTotal {
+		return nil
+	}
+	report.Region = t.Region
+	report.Votes = report.Votes.clone()
+	t.Feed = report
+	return t.revise(OriginFeed, nil, "", at)
+}
+
+// SetOverride replaces the displayed votes with an editor's numbers
+func (t *Tally) SetOverride(votes Counts, editorID, reason string, at time.Time) (*Revision, error) {
+	reason, err := validateReason(reason)
+	if err != nil {
+		return nil, err
+	}
+	t.Override = &Override{Votes: votes.clone(), EditorID: editorID, Reason: reason, At: at}
+	return t.revise(OriginOverride, &editorID, reason, at), nil
+}
+
+// ClearOverride goes back to the feed's numbers
+func (t *Tally) ClearOverride(editorID, reason string, at time.Time) (*Revision, error) {
+	if t.Override == nil {
+		return nil, ErrNoOverride
+	}
+	reason, err := validateReason(reason)
+	if err != nil {
+		return nil, err
+	}
+	t.Override = nil
+	return t.revise(OriginOverrideCleared, &editorID, reason, at), nil
+}
+
+func (t *Tally) revise(origin Origin, editorID *string, reason string, at time.Time) *Revision {
+	t.Version++
+	t.UpdatedAt = at
+	return &Revision{
+		RaceID:           t.RaceID,
+		Region:           t.Region,
+		Version:          t.Version,
+		Origin:           origin,
+		Votes:            t.Votes().clone(),
+		StationsReported: t.Feed.StationsReported,
+		StationsTotal:    t.Feed.StationsTotal,
+		EditorID:         editorID,
+		Reason:           reason,
+		CreatedAt:        at,
+	}
+}
+
+// Query Methods
+
+// Votes returns what readers see: the override when set, else the feed
+func (t *Tally) Votes() Counts {
+	if t.Override != nil {
+		return t.Override.Votes
+	}
+	return t.Feed.Votes
+}
+
+func (t *Tally) Overridden() bool {
+	return t.Override != nil
+}
+
+// Results is a race's live count for coverage pages
+type Results struct {
+	Race             *Race
+	Totals           Counts
+	StationsReported int
+	StationsTotal    int
+	Regions          []*Tally  // Sorted by region code, only those changed since the requested time
+	UpdatedAt        time.Time // Latest change in any region
+}
+
+// Summarize totals the race's regions. Regions are the feed's reporting
+// units, so they never overlap. With a non-zero since, Regions only holds
+// tallies changed after it while totals still cover every region.
+func Summarize(race *Race, tallies []*Tally, since time.Time) *Results {
+	results := &Results{Race: race, Totals: Counts{}}
+	for _, c := range race.Candidates {
+		results.Totals[c.ID] = 0
+	}
+	for _, t := range tallies {
+		for id, votes := range t.Votes() {
+			results.Totals[id] += votes
+		}
+		results.StationsReported += t.Feed.StationsReported
+		results.StationsTotal += t.Feed.StationsTotal
+		if t.UpdatedAt.After(results.UpdatedAt) {
+			results.UpdatedAt = t.UpdatedAt
+		}
+		if since.IsZero() || t.UpdatedAt.After(since) {
+			results.Regions = append(results.Regions, t)
+		}
+	}
+	sort.Slice(results.Regions, func(i, j int) bool { return results.Regions[i].Region < results.Regions[j].Region })
+	return results
+}
+
+// Domain Validation Functions
+
+func validateCandidates(candidates []Candidate) error {
+	if len(candidates) == 0 || len(candidates) > MaxCandidates {
+		return ErrInvalidCandidates
+	}
+	seen := make(map[string]struct{}, len(candidates))
+	for _, c := range candidates {
+		if strings.TrimSpace(c.ID) == "" || strings.TrimSpace(c.Name) == "" {
+			return ErrInvalidCandidate
+		}
+		if _, ok := seen[c.ID]; ok {
+			return ErrDuplicateCandidate
+		}
+		seen[c.ID] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package election
+
+import (
+	"testing"
+	"time"
+)
+
+func createTestRace(t *testing.T) *Race {
+	t.Helper()
+	race, err := NewRace("race-1", "Pilkada DKI", "json", "dki-2029", []Candidate{{ID: "01", Name: "Calon A"}, {ID: "02", Name: "Calon B"}}, "editor-1", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return race
+}
+
+func TestNewRace(t *testing.T) {
+	testCases := []struct {
+		name        string
+		raceName    string
+		candidates  []Candidate
+		expectedErr error
+	}{
+		{"valid", "Pilpres", []Candidate{{ID: "01", Name: "A"}}, nil},
+		{"empty name", " ", []Candidate{{ID: "01", Name: "A"}}, ErrInvalidRaceName},
+		{"no candidates", "Pilpres", nil, ErrInvalidCandidates},
+		{"candidate without name", "Pilpres", []Candidate{{ID: "01"}}, ErrInvalidCandidate},
+		{"duplicate candidate", "Pilpres", []Candidate{{ID: "01", Name: "A"}, {ID: "01", Name: "B"}}, ErrDuplicateCandidate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRace("race-1", tc.raceName, "json", "ref", tc.candidates, "editor-1", time.Now())
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRace_CheckReport(t *testing.T) {
+	race := createTestRace(t)
+
+	testCases := []struct {
+		name        string
+		report      Report
+		expectedErr error
+	}{
+		{"valid", Report{Region: "jaksel", Votes: Counts{"01": 10, "02": 7}, StationsReported: 3, StationsTotal: 5}, nil},
+		{"unknown candidate", Report{Region: "jaksel", Votes: Counts{"03": 1}}, ErrUnknownCandidate},
+		{"negative votes", Report{Region: "jaksel", Votes: Counts{"01": -1}}, ErrNegativeVotes},
+		{"more stations than total", Report{Region: "jaksel", StationsReported: 6, StationsTotal: 5}, ErrInvalidStations},
+		{"invalid region", Report{Region: "Jakarta Selatan"}, ErrInvalidRegion},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := race.CheckReport(tc.report); err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestTally_Versioning(t *testing.T) {
+	at := time.Date(2029, 2, 14, 15, 0, 0, 0, time.UTC)
+	tally := NewTally("race-1", "jaksel")
+
+	rev := tally.ApplyFeed(Report{Votes: Counts{"01": 10, "02": 7}, StationsReported: 3, StationsTotal: 5}, at)
+	if rev == nil || rev.Version != 1 || rev.Origin != OriginFeed || rev.EditorID != nil {
+		t.Fatalf("expected first feed revision, got %+v", rev)
+	}
+	if again := tally.ApplyFeed(Report{Votes: Counts{"01": 10, "02": 7}, StationsReported: 3, StationsTotal: 5}, at.Add(time.Minute)); again != nil {
+		t.Errorf("expected unchanged feed to be ignored, got %+v", again)
+	}
+
+	if _, err := tally.SetOverride(Counts{"01": 12, "02": 7}, "editor-1", " ", at); err != ErrOverrideReasonRequired {
+		t.Errorf("expected error '%v', got '%v'", ErrOverrideReasonRequired, err)
+	}
+	rev, err := tally.SetOverride(Counts{"01": 12, "02": 7}, "editor-1", "Koreksi C1 TPS 004", at.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rev.Version != 2 || rev.Votes["01"] != 12 || *rev.EditorID != "editor-1" {
+		t.Errorf("expected override revision, got %+v", rev)
+	}
+
+	tally.ApplyFeed(Report{Votes: Counts{"01": 11, "02": 9}, StationsReported: 4, StationsTotal: 5}, at.Add(3*time.Minute))
+	if tally.Votes()["01"] != 12 || tally.Feed.Votes["02"] != 9 || tally.Version != 3 {
+		t.Errorf("expected the override to stay on top of feed updates, got %+v", tally)
+	}
+
+	rev, err = tally.ClearOverride("editor-1", "Feed sudah terkoreksi", at.Add(4*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rev.Origin != OriginOverrideCleared || rev.Votes["02"] != 9 || tally.Overridden() {
+		t.Errorf("expected feed numbers after clearing, got %+v", rev)
+	}
+	if _, err := tally.ClearOverride("editor-1", "again", at); err != ErrNoOverride {
+		t.Errorf("expected error '%v', got '%v'", ErrNoOverride, err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	race := createTestRace(t)
+	at := time.Date(2029, 2, 14, 15, 0, 0, 0, time.UTC)
+
+	south := NewTally(race.ID, "jaksel")
+	south.ApplyFeed(Report{Votes: Counts{"01": 10, "02": 7}, StationsReported: 3, StationsTotal: 5}, at)
+	north := NewTally(race.ID, "jakut")
+	north.ApplyFeed(Report{Votes: Counts{"01": 4}, StationsReported: 1, StationsTotal: 4}, at.Add(10*time.Minute))
+
+	results := Summarize(race, []*Tally{north, south}, time.Time{})
+	if results.Totals["01"] != 14 || results.Totals["02"] != 7 || results.StationsReported != 4 || results.StationsTotal != 9 {
+		t.Errorf("unexpected totals %+v", results)
+	}
+	if len(results.Regions) != 2 || results.Regions[0].Region != "jaksel" || !results.UpdatedAt.Equal(at.Add(10*time.Minute)) {
+		t.Errorf("expected sorted regions and latest change, got %+v", results)
+	}
+
+	changed := Summarize(race, []*Tally{north, south}, at)
+	if len(changed.Regions) != 1 || changed.Regions[0].Region != "jakut" || changed.Totals["01"] != 14 {
+		t.Errorf("expected only jakut changed with full totals, got %+v", changed)
+	}
+}
@@ -0,0 +1,28 @@
+package election
+
+import "context"
+
+type RaceRepository interface {
+	// Commands
+	Create(ctx context.Context, race *Race) error
+	Update(ctx context.Context, race *Race) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Race, error) // nil when not found
+	FindAll(ctx context.Context) ([]*Race, error)
+}
+
+type TallyRepository interface {
+	// Save stores the tally together with the revision that produced it
+	Save(ctx context.Context, tally *Tally, revision *Revision) error
+
+	FindByRegion(ctx context.Context, raceID, region string) (*Tally, error) // nil when not found
+	FindByRace(ctx context.Context, raceID string) ([]*Tally, error)
+	FindRevisions(ctx context.Context, raceID, region string) ([]*Revision, error) // Newest first
+}
+
+// Source fetches official results for a race, one adapter per feed
+// (implementations will be in infrastructure layer)
+type Source interface {
+	Fetch(ctx context.Context, race *Race) ([]Report, error)
+}
@@ -0,0 +1,121 @@
+package election
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	MaxCandidates   = 50
+	MaxRaceName     = 200
+	MaxReasonLength = 500
+)
+
+var codeRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,63}$`)
+
+// Domain errors
+var (
+	ErrInvalidRaceName        = errors.New("race name must be between 1 and 200 characters")
+	ErrInvalidCandidates      = errors.New("race needs between 1 and 50 candidates")
+	ErrInvalidCandidate       = errors.New("candidate needs a code and a name")
+	ErrDuplicateCandidate     = errors.New("race has the same candidate twice")
+	ErrUnknownCandidate       = errors.New("votes for a candidate not in the race")
+	ErrNegativeVotes          = errors.New("vote counts cannot be negative")
+	ErrInvalidStations        = errors.New("reported polling stations must be between 0 and the total")
+	ErrInvalidRegion          = errors.New("region code must be 1-64 lowercase letters, digits or dashes")
+	ErrOverrideReasonRequired = errors.New("overrides need a reason of at most 500 characters")
+	ErrNoOverride             = errors.New("region has no override to clear")
+	ErrRaceClosed             = errors.New("race is closed, results are final")
+)
+
+// Candidate value object, IDs are the codes the results feed uses
+type Candidate struct {
+	ID    string
+	Name  string
+	Party string
+}
+
+// Counts maps candidate IDs to votes
+type Counts map[string]int64
+
+func (c Counts) Total() int64 {
+	var total int64
+	for _, votes := range c {
+		total += votes
+	}
+	return total
+}
+
+func (c Counts) Equals(other Counts) bool {
+	if len(c) != len(other) {
+		return false
+	}
+	for id, votes := range c {
+		if v, ok := other[id]; !ok || v != votes {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Counts) clone() Counts {
+	copied := make(Counts, len(c))
+	for id, votes := range c {
+		copied[id] = votes
+	}
+	return copied
+}
+
+// Report value object, one region's numbers as a results feed publishes them
+type Report struct {
+	Region           string
+	Votes            Counts
+	StationsReported int
+	StationsTotal    int
+	ReportedAt       time.Time // The feed's own timestamp
+}
+
+// Origin says where a tally revision came from
+type Origin string
+
+const (
+	OriginFeed            Origin = "feed"
+	OriginOverride        Origin = "override"
+	OriginOverrideCleared Origin = "override_cleared"
+)
+
+// Revision is an immutable snapshot of a tally, written on every change so
+// overrides can be audited and coverage can show how the count moved
+type Revision struct {
+	RaceID           string
+	Region           string
+	Version          int64
+	Origin           Origin
+	Votes            Counts // What readers saw after this change
+	StationsReported int
+	StationsTotal    int
+	EditorID         *string // Nil for feed updates
+	Reason           string
+	CreatedAt        time.Time
+}
+
+// Domain Validation Functions
+
+// NormalizeRegion lowercases and validates a feed region code
+func NormalizeRegion(code string) (string, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !codeRegex.MatchString(code) {
+		return "", ErrInvalidRegion
+	}
+	return code, nil
+}
+
+func validateReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len([]rune(reason)) > MaxReasonLength {
+		return "", ErrOverrideReasonRequired
+	}
+	return reason, nil
+}
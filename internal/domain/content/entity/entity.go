@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Entity is a person, organisation or place the newsroom covers, e.g. the
+// minister "Budi Santoso". Namesakes are separate entities told apart by
+// their description, e.g. "Minister of Health" and "Persib midfielder".
+type Entity struct {
+	ID          string
+	Kind        Kind
+	Slug        Slug
+	Name        string
+	Description string   // Disambiguation note shown next to the name
+	Aliases     []string // Other names the extractor should match, normalized
+	MergedInto  *string  // Set when editors fold a duplicate into another entity
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Link ties an article to an entity it covers
+type Link struct {
+	ArticleID string
+	EntityID  string
+	Mentions  int
+	Source    LinkSource
+	CreatedAt time.Time
+}
+
+// Ambiguity is a mention that matched several entities and waits for an editor
+type Ambiguity struct {
+	ArticleID    string
+	Surface      string
+	Kind         Kind
+	CandidateIDs []string
+	Mentions     int
+	CreatedAt    time.Time
+}
+
+func NewEntity(id string, kind Kind, slug Slug, name, description string, aliases []string, createdBy string) (*Entity, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+	if _, err := ParseKind(string(kind)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	e := &Entity{
+		ID:        id,
+		Kind:      kind,
+		Slug:      slug,
+		CreatedAt: now,
+	}
+	if err := e.UpdateDetails(name, description, aliases, createdBy); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Business Methods
+
+func (e *Entity) UpdateDetails(name, description string, aliases []string, editorID string) error {
+	if e.IsMerged() {
+		return ErrAlreadyMerged
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return err
+	}
+	description = strings.TrimSpace(description)
+	if len([]rune(description)) > 200 {
+		return ErrDescriptionTooLong
+	}
+
+	normalized := make([]string, 0, len(aliases))
+	seen := map[string]struct{}{}
+	for _, alias := range aliases {
+		alias = NormalizeAlias(alias)
+		if alias == "" {
+			continue
+		}
+		if alias == NormalizeAlias(name) {
+			return ErrAliasTaken
+		}
+		if _, ok := seen[alias]; !ok {
+			seen[alias] = struct{}{}
+			normalized = append(normalized, alias)
+		}
+	}
+
+	e.Name = name
+	e.Description = description
+	e.Aliases = normalized
+	e.touch(editorID)
+	return nil
+}
+
+// MergeInto folds a duplicate into the surviving entity, its page redirects there
+func (e *Entity) MergeInto(targetID, editorID string) error {
+	if e.IsMerged() {
+		return ErrAlreadyMerged
+	}
+	if targetID == e.ID {
+		return ErrMergeIntoSelf
+	}
+	e.MergedInto = &targetID
+	e.touch(editorID)
+	return nil
+}
+
+// Query Methods
+
+func (e *Entity) IsMerged() bool {
+	return e.MergedInto != nil
+}
+
+// Key is how other modules refer to the entity, e.g. "org:kpk" in topic rules
+func (e *Entity) Key() string {
+	return string(e.Kind) + ":" + e.Slug.Value()
+}
+
+// Names is everything the extractor should match: the name and every alias
+func (e *Entity) Names() []string {
+	return append([]string{NormalizeAlias(e.Name)}, e.Aliases...)
+}
+
+// Label is the name with its disambiguation note, e.g. "Budi Santoso (Minister of Health)"
+func (e *Entity) Label() string {
+	if e.Description == "" {
+		return e.Name
+	}
+	return e.Name + " (" + e.Description + ")"
+}
+
+func (e *Entity) touch(editorID string) {
+	e.LastActionBy = &editorID
+	e.UpdatedAt = time.Now()
+}
+
+// IsCandidate reports whether the entity could be what the ambiguous mention means
+func (a *Ambiguity) IsCandidate(entityID string) bool {
+	for _, id := range a.CandidateIDs {
+		if id == entityID {
+			return true
+		}
+	}
+	return false
+}
+
+// Domain Validation Functions
+
+func validateName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", ErrNameEmpty
+	}
+	if len([]rune(name)) > 100 {
+		return "", ErrNameTooLong
+	}
+	return name, nil
+}
@@ -0,0 +1,80 @@
+package entity
+
+import "testing"
+
+func createTestEntity(t *testing.T) *Entity {
+	t.Helper()
+	slug, _ := NewSlug("budi-santoso-health")
+	e, err := NewEntity("entity-1", KindPerson, *slug, "Budi  Santoso", "Minister of Health", []string{"Pak Budi", "pak  budi", " "}, "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create entity: %v", err)
+	}
+	return e
+}
+
+func TestNewEntity(t *testing.T) {
+	e := createTestEntity(t)
+
+	if e.Name != "Budi Santoso" {
+		t.Errorf("expected collapsed name, got '%s'", e.Name)
+	}
+	if len(e.Aliases) != 1 || e.Aliases[0] != "pak budi" {
+		t.Errorf("expected one normalized alias, got %v", e.Aliases)
+	}
+	if e.Key() != "person:budi-santoso-health" {
+		t.Errorf("expected key 'person:budi-santoso-health', got '%s'", e.Key())
+	}
+	if e.Label() != "Budi Santoso (Minister of Health)" {
+		t.Errorf("unexpected label '%s'", e.Label())
+	}
+
+	slug, _ := NewSlug("kpk")
+	testCases := []struct {
+		name        string
+		kind        Kind
+		entityName  string
+		aliases     []string
+		expectedErr error
+	}{
+		{"invalid kind", Kind("company"), "KPK", nil, ErrInvalidKind},
+		{"empty name", KindOrganization, "  ", nil, ErrNameEmpty},
+		{"alias repeats name", KindOrganization, "KPK", []string{"kpk"}, ErrAliasTaken},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewEntity("entity-2", tc.kind, *slug, tc.entityName, "", tc.aliases, "editor-1")
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestEntity_MergeInto(t *testing.T) {
+	e := createTestEntity(t)
+
+	if err := e.MergeInto(e.ID, "editor-2"); err != ErrMergeIntoSelf {
+		t.Errorf("expected error '%v', got '%v'", ErrMergeIntoSelf, err)
+	}
+	if err := e.MergeInto("entity-9", "editor-2"); err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if !e.IsMerged() || *e.LastActionBy != "editor-2" {
+		t.Errorf("expected merged by editor-2, got %+v", e)
+	}
+	if err := e.MergeInto("entity-8", "editor-2"); err != ErrAlreadyMerged {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyMerged, err)
+	}
+	if err := e.UpdateDetails("Budi", "", nil, "editor-2"); err != ErrAlreadyMerged {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyMerged, err)
+	}
+}
+
+func TestAmbiguity_IsCandidate(t *testing.T) {
+	a := Ambiguity{ArticleID: "a1", Surface: "budi santoso", Kind: KindPerson, CandidateIDs: []string{"entity-1", "entity-2"}}
+
+	if !a.IsCandidate("entity-2") || a.IsCandidate("entity-3") {
+		t.Errorf("unexpected candidates %v", a.CandidateIDs)
+	}
+}
@@ -0,0 +1,58 @@
+package entity
+
+import "context"
+
+type EntityRepository interface {
+	// Commands
+	Create(ctx context.Context, entity *Entity) error
+	Update(ctx context.Context, entity *Entity) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Entity, error)
+	FindBySlug(ctx context.Context, slug string) (*Entity, error)
+	// FindByAlias returns unmerged entities of the kind whose name or an alias matches, namesakes return several
+	FindByAlias(ctx context.Context, kind Kind, alias string) ([]*Entity, error)
+	// FindAll returns unmerged entities, the dictionary extractor is built from them
+	FindAll(ctx context.Context) ([]*Entity, error)
+	Search(ctx context.Context, query string, limit int) ([]*Entity, error)
+}
+
+type LinkRepository interface {
+	// Commands
+	// ReplaceExtracted swaps the article's extracted links, editor links are kept
+	ReplaceExtracted(ctx context.Context, articleID string, links []Link) error
+	Save(ctx context.Context, link Link) error
+	Delete(ctx context.Context, articleID, entityID string) error
+	// MoveAll repoints every link from a merged entity to the survivor
+	MoveAll(ctx context.Context, fromEntityID, toEntityID string) error
+
+	// Queries
+	FindByArticle(ctx context.Context, articleID string) ([]Link, error)
+	// FindArticleIDs lists published coverage of an entity, newest first
+	FindArticleIDs(ctx context.Context, entityID string, limit, offset int) ([]string, error)
+	CountArticles(ctx context.Context, entityID string) (int64, error)
+}
+
+type AmbiguityRepository interface {
+	// Commands
+	ReplaceForArticle(ctx context.Context, articleID string, ambiguities []Ambiguity) error
+	Delete(ctx context.Context, articleID, surface string) error
+
+	// Queries
+	Find(ctx context.Context, articleID, surface string) (*Ambiguity, error)
+	// FindPending returns the editor queue, oldest first
+	FindPending(ctx context.Context, limit, offset int) ([]Ambiguity, int64, error)
+}
+
+// Domain interface for NLP providers (implementation will be in infrastructure layer)
+type Extractor interface {
+	Extract(ctx context.Context, text string) ([]Mention, error)
+}
+
+// ArticleSource reads articles for extraction and entity pages (implemented by the article module)
+type ArticleSource interface {
+	// FindText returns the headline and body as plain text
+	FindText(ctx context.Context, articleID string) (string, error)
+	// FindPublished returns summaries keyed by article ID, unpublished and unknown articles are left out
+	FindPublished(ctx context.Context, articleIDs []string) (map[string]ArticleSummary, error)
+}
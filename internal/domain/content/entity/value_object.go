@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Domain errors
+var (
+	ErrInvalidKind        = errors.New("entity kind must be person, org or place")
+	ErrInvalidSlug        = errors.New("slug must be 2-80 lowercase letters, digits or single hyphens")
+	ErrNameEmpty          = errors.New("entity name cannot be empty")
+	ErrNameTooLong        = errors.New("entity name cannot exceed 100 characters")
+	ErrDescriptionTooLong = errors.New("entity description cannot exceed 200 characters")
+	ErrAliasTaken         = errors.New("alias is already the entity's name")
+	ErrAlreadyMerged      = errors.New("entity has been merged into another entity")
+	ErrMergeIntoSelf      = errors.New("an entity cannot be merged into itself")
+	ErrNotCandidate       = errors.New("entity is not of the mention's kind")
+)
+
+// Kind is what sort of thing an entity is
+type Kind string
+
+const (
+	KindPerson       Kind = "person"
+	KindOrganization Kind = "org"
+	KindPlace        Kind = "place"
+)
+
+func ParseKind(value string) (Kind, error) {
+	switch k := Kind(strings.ToLower(strings.TrimSpace(value))); k {
+	case KindPerson, KindOrganization, KindPlace:
+		return k, nil
+	}
+	return "", ErrInvalidKind
+}
+
+// Slug value object, the entity page's URL segment
+type Slug struct {
+	value string
+}
+
+func NewSlug(value string) (*Slug, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) < 2 || len(value) > 80 || !slugRegex.MatchString(value) {
+		return nil, ErrInvalidSlug
+	}
+	return &Slug{value: value}, nil
+}
+
+func (s Slug) String() string {
+	return s.value
+}
+
+func (s Slug) Value() string {
+	return s.value
+}
+
+func (s Slug) Equals(other Slug) bool {
+	return s.value == other.value
+}
+
+// Mention is one name an extractor found in an article, e.g. "Jokowi" as a person
+type Mention struct {
+	Surface string
+	Kind    Kind
+	Offset  int // Byte offset in the text
+}
+
+// LinkSource records who tied an article to an entity
+type LinkSource string
+
+const (
+	SourceExtracted LinkSource = "extracted"
+	SourceEditor    LinkSource = "editor" // Survives re-extraction
+)
+
+// ArticleSummary is what entity pages show for each article
+type ArticleSummary struct {
+	Ref     permalink.ArticleRef
+	Title   string
+	Excerpt string
+}
+
+// NormalizeAlias folds case and spacing so "Joko  Widodo" and "joko widodo" match
+func NormalizeAlias(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
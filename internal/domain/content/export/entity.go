@@ -0,0 +1,48 @@
+package export
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Document is one rendered export, cached per article revision and format
+type Document struct {
+	ArticleID   string
+	Revision    string
+	Format      Format
+	Filename    string
+	Data        []byte
+	GeneratedAt time.Time
+}
+
+func NewDocument(a *Article, format Format, data []byte, at time.Time) (*Document, error) {
+	if strings.TrimSpace(a.ID) == "" {
+		return nil, errors.New("article ID cannot be empty")
+	}
+	if _, err := ParseFormat(string(format)); err != nil {
+		return nil, err
+	}
+	name := a.Slug
+	if name == "" {
+		name = a.ID
+	}
+	return &Document{
+		ArticleID:   a.ID,
+		Revision:    a.Revision,
+		Format:      format,
+		Filename:    name + format.Extension(),
+		Data:        data,
+		GeneratedAt: at,
+	}, nil
+}
+
+// Query Methods
+
+func (d *Document) ContentType() string {
+	return d.Format.ContentType()
+}
+
+func (d *Document) Key() string {
+	return CacheKey(d.ArticleID, d.Revision, d.Format)
+}
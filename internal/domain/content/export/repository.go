@@ -0,0 +1,30 @@
+package export
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Domain interface for loading articles to export (implemented by the article module)
+type ArticleSource interface {
+	// FindForExport returns the published article, nil when it does not exist or is unpublished
+	FindForExport(ctx context.Context, articleID string) (*Article, error)
+}
+
+// Domain interface for caching rendered exports (implementation will be in infrastructure layer).
+// Get returns nil on a miss.
+type DocumentCache interface {
+	Get(ctx context.Context, key string) (*Document, error)
+	Set(ctx context.Context, doc *Document, ttl time.Duration) error
+}
+
+// Domain interface for rendering one format (implementation will be in infrastructure layer)
+type Renderer interface {
+	Render(w io.Writer, a *Article) error
+}
+
+// Domain interface for image renditions (implementation will be in infrastructure layer)
+type ImageOptimizer interface {
+	Optimize(ctx context.Context, sourceURL string, maxWidth int) ([]byte, string, error)
+}
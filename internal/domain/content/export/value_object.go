@@ -0,0 +1,92 @@
+package export
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+const (
+	// ImageMaxWidth is the rendition width bundled into EPUB exports
+	ImageMaxWidth = 1200
+	// CacheTTL keeps renderings of popular articles warm, revisions make older ones unreachable anyway
+	CacheTTL = 7 * 24 * time.Hour
+)
+
+// Domain errors
+var (
+	ErrInvalidFormat = errors.New("export format must be epub, text or html")
+)
+
+// Format is the file an article is exported to
+type Format string
+
+const (
+	FormatEPUB Format = "epub" // E-readers and archival
+	FormatText Format = "text" // Plain UTF-8 text, e.g. for braille displays and text-to-speech
+	FormatHTML Format = "html" // Simplified, self-contained HTML for assistive technology
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(value))); f {
+	case FormatEPUB, FormatText, FormatHTML:
+		return f, nil
+	}
+	return "", ErrInvalidFormat
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatEPUB:
+		return "application/epub+zip"
+	case FormatText:
+		return "text/plain; charset=utf-8"
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+func (f Format) Extension() string {
+	switch f {
+	case FormatText:
+		return ".txt"
+	case FormatHTML:
+		return ".html"
+	}
+	return "." + string(f)
+}
+
+// CacheKey identifies one rendering. The revision changes with every edit, so
+// a stale export is never served and old entries simply age out of the cache.
+func CacheKey(articleID, revision string, format Format) string {
+	return articleID + ":" + revision + ":" + string(format)
+}
+
+// Image is a picture in the article body
+type Image struct {
+	Name        string // File name inside an EPUB, empty until bundled
+	SourceURL   string
+	Alt         string
+	ContentType string
+	Data        []byte
+}
+
+// Article is what an export is rendered from
+type Article struct {
+	ID          string
+	Revision    string // Changes whenever the article text or images change
+	Slug        string
+	Title       string
+	Byline      string
+	Section     string
+	URL         string
+	Language    string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+	Body        string // Sanitized HTML
+	Images      []Image
+	Access      entitlement.Article
+}
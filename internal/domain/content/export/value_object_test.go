@@ -0,0 +1,56 @@
+package export
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		expected    Format
+		expectedErr error
+	}{
+		{"epub", "epub", FormatEPUB, nil},
+		{"text upper case", " TEXT ", FormatText, nil},
+		{"html", "html", FormatHTML, nil},
+		{"empty", "", "", ErrInvalidFormat},
+		{"pdf", "pdf", "", ErrInvalidFormat},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := ParseFormat(tc.value)
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if f != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, f)
+			}
+		})
+	}
+}
+
+func TestNewDocument(t *testing.T) {
+	at := time.Date(2025, time.June, 3, 6, 0, 0, 0, time.UTC)
+
+	doc, err := NewDocument(&Article{ID: "a1", Revision: "r2", Slug: "banjir-jakarta"}, FormatText, []byte("Banjir"), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Filename != "banjir-jakarta.txt" || doc.ContentType() != "text/plain; charset=utf-8" {
+		t.Errorf("unexpected document %s %s", doc.Filename, doc.ContentType())
+	}
+	if doc.Key() != CacheKey("a1", "r2", FormatText) {
+		t.Errorf("expected key to follow the revision, got %s", doc.Key())
+	}
+
+	doc, _ = NewDocument(&Article{ID: "a1"}, FormatEPUB, nil, at)
+	if doc.Filename != "a1.epub" {
+		t.Errorf("expected article ID as fallback file name, got %s", doc.Filename)
+	}
+	if _, err := NewDocument(&Article{ID: "a1"}, Format("pdf"), nil, at); err != ErrInvalidFormat {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidFormat, err)
+	}
+}
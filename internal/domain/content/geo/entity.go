@@ -0,0 +1,59 @@
+package geo
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ArticleLocation is the geo-tag on an article. Only published articles show
+// on the map, so the tag tracks publication alongside the article.
+type ArticleLocation struct {
+	ArticleID   string
+	Location    Location
+	PublishedAt *time.Time
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Nearby is an article location with its distance from the query point
+type Nearby struct {
+	Location   *ArticleLocation
+	DistanceKm float64
+}
+
+func NewArticleLocation(articleID string, location Location, editorID string) (*ArticleLocation, error) {
+	if strings.TrimSpace(articleID) == "" {
+		return nil, errors.New("article ID cannot be empty")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editorID cannot be empty")
+	}
+
+	now := time.Now()
+	return &ArticleLocation{
+		ArticleID:    articleID,
+		Location:     location,
+		LastActionBy: &editorID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Business Methods
+
+func (a *ArticleLocation) Move(location Location, editorID string) {
+	a.Location = location
+	a.LastActionBy = &editorID
+	a.UpdatedAt = time.Now()
+}
+
+// Query Methods
+
+func (a *ArticleLocation) IsOnMap() bool {
+	return a.PublishedAt != nil
+}
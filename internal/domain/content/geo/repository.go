@@ -0,0 +1,31 @@
+package geo
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interface for article geo-tags (implementation will be in infrastructure layer).
+// since limits every map query to articles published at or after it.
+type LocationRepository interface {
+	// Commands
+	Save(ctx context.Context, location *ArticleLocation) error
+	Delete(ctx context.Context, articleID string) error
+	// SetPublished follows the article's publication, nil takes it off the map; a no-op for untagged articles
+	SetPublished(ctx context.Context, articleID string, at *time.Time) error
+
+	// Queries
+	FindByArticle(ctx context.Context, articleID string) (*ArticleLocation, error)
+	// FindWithin returns published locations touching the box, newest first
+	FindWithin(ctx context.Context, box BoundingBox, since time.Time, limit int) ([]*ArticleLocation, error)
+	// FindNear returns published locations within the radius, nearest first
+	FindNear(ctx context.Context, center Point, radiusKm float64, since time.Time, limit int) ([]Nearby, error)
+	// Clusters groups published locations in the box by their center on a grid of gridSize degrees
+	Clusters(ctx context.Context, box BoundingBox, gridSize float64, since time.Time) ([]Cluster, error)
+}
+
+// ArticleSource reads article summaries for map pins (implemented by the article module)
+type ArticleSource interface {
+	// FindPublished returns summaries keyed by article ID, unpublished and unknown articles are left out
+	FindPublished(ctx context.Context, articleIDs []string) (map[string]ArticleSummary, error)
+}
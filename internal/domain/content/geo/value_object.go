@@ -0,0 +1,187 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+const (
+	// MaxRegionPoints bounds hand-drawn regions, provinces simplified for the web fit easily
+	MaxRegionPoints = 1000
+	// MaxRadiusKm bounds "near me" queries so they stay on the index
+	MaxRadiusKm = 200
+	// MaxZoom matches the deepest web map tile level
+	MaxZoom = 20
+)
+
+// Domain errors
+var (
+	ErrInvalidLatitude     = errors.New("latitude must be between -90 and 90")
+	ErrInvalidLongitude    = errors.New("longitude must be between -180 and 180")
+	ErrInvalidBoundingBox  = errors.New("bounding box south must be below north and west below east")
+	ErrRegionTooSmall      = errors.New("a region needs at least 3 distinct points")
+	ErrRegionTooLarge      = errors.New("a region can have at most 1000 points")
+	ErrInvalidRadius       = errors.New("radius must be greater than 0 and at most 200 km")
+	ErrInvalidZoom         = errors.New("zoom must be between 0 and 20")
+	ErrPlaceNameTooLong    = errors.New("place name cannot exceed 100 characters")
+	ErrUnknownLocationKind = errors.New("location must be a point or a region")
+)
+
+// Point value object, WGS 84 degrees
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+func NewPoint(lat, lng float64) (*Point, error) {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return nil, ErrInvalidLatitude
+	}
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return nil, ErrInvalidLongitude
+	}
+	return &Point{Lat: lat, Lng: lng}, nil
+}
+
+// DistanceKm is the great-circle distance to another point
+func (p Point) DistanceKm(other Point) float64 {
+	const earthRadiusKm = 6371.0
+	lat1, lat2 := p.Lat*math.Pi/180, other.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (other.Lng - p.Lng) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox value object, the visible part of the map. Boxes crossing the
+// antimeridian are not supported; the map clients split them in two.
+type BoundingBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+func NewBoundingBox(south, west, north, east float64) (*BoundingBox, error) {
+	if _, err := NewPoint(south, west); err != nil {
+		return nil, err
+	}
+	if _, err := NewPoint(north, east); err != nil {
+		return nil, err
+	}
+	if south > north || west > east {
+		return nil, ErrInvalidBoundingBox
+	}
+	return &BoundingBox{South: south, West: west, North: north, East: east}, nil
+}
+
+func (b BoundingBox) Contains(p Point) bool {
+	return p.Lat >= b.South && p.Lat <= b.North && p.Lng >= b.West && p.Lng <= b.East
+}
+
+// LocationKind tells a single spot from an area
+type LocationKind string
+
+const (
+	KindPoint  LocationKind = "point"
+	KindRegion LocationKind = "region"
+)
+
+// Location value object, where a story happened
+type Location struct {
+	Kind      LocationKind
+	Center    Point   // The point itself, or the region's representative point for pins and clustering
+	Ring      []Point // Region outline, closed (first point repeated last); empty for points
+	PlaceName string  // e.g. "Kampung Pulo, East Jakarta"
+}
+
+func NewPointLocation(point Point, placeName string) (*Location, error) {
+	placeName, err := validatePlaceName(placeName)
+	if err != nil {
+		return nil, err
+	}
+	return &Location{Kind: KindPoint, Center: point, PlaceName: placeName}, nil
+}
+
+// NewRegionLocation builds a region from its outline, closing the ring if needed
+func NewRegionLocation(ring []Point, placeName string) (*Location, error) {
+	placeName, err := validatePlaceName(placeName)
+	if err != nil {
+		return nil, err
+	}
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(append([]Point(nil), ring...), ring[0])
+	}
+	if len(ring) > MaxRegionPoints {
+		return nil, ErrRegionTooLarge
+	}
+	distinct := map[Point]struct{}{}
+	for _, p := range ring {
+		if _, err := NewPoint(p.Lat, p.Lng); err != nil {
+			return nil, err
+		}
+		distinct[p] = struct{}{}
+	}
+	if len(distinct) < 3 {
+		return nil, ErrRegionTooSmall
+	}
+
+	// The vertex average stays inside the convex regions editors draw and is
+	// stable, unlike the area centroid of a sliver
+	var lat, lng float64
+	vertices := ring[:len(ring)-1]
+	for _, p := range vertices {
+		lat += p.Lat
+		lng += p.Lng
+	}
+	n := float64(len(vertices))
+	return &Location{Kind: KindRegion, Center: Point{Lat: lat / n, Lng: lng / n}, Ring: ring, PlaceName: placeName}, nil
+}
+
+// Bounds is the smallest box around the location
+func (l Location) Bounds() BoundingBox {
+	if l.Kind != KindRegion {
+		return BoundingBox{South: l.Center.Lat, West: l.Center.Lng, North: l.Center.Lat, East: l.Center.Lng}
+	}
+	b := BoundingBox{South: 90, West: 180, North: -90, East: -180}
+	for _, p := range l.Ring {
+		b.South, b.North = math.Min(b.South, p.Lat), math.Max(b.North, p.Lat)
+		b.West, b.East = math.Min(b.West, p.Lng), math.Max(b.East, p.Lng)
+	}
+	return b
+}
+
+// Cluster groups nearby articles for one map marker
+type Cluster struct {
+	Center    Point
+	Bounds    BoundingBox // Zooming to it splits the cluster
+	Count     int
+	ArticleID string // Set when the cluster is a single article
+}
+
+// GridSize is the clustering cell in degrees for a map zoom level. A cell is a
+// quarter of a 256 px tile, so markers end up about 64 px apart.
+func GridSize(zoom int) (float64, error) {
+	if zoom < 0 || zoom > MaxZoom {
+		return 0, ErrInvalidZoom
+	}
+	return 360 / math.Pow(2, float64(zoom)) / 4, nil
+}
+
+// ArticleSummary is what map pins show for each article
+type ArticleSummary struct {
+	Ref     permalink.ArticleRef
+	Title   string
+	Excerpt string
+}
+
+func validatePlaceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if len([]rune(name)) > 100 {
+		return "", ErrPlaceNameTooLong
+	}
+	return name, nil
+}
@@ -0,0 +1,92 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPoint(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lat, lng    float64
+		expectedErr error
+	}{
+		{"jakarta", -6.2, 106.8, nil},
+		{"latitude out of range", 91, 0, ErrInvalidLatitude},
+		{"longitude out of range", 0, -181, ErrInvalidLongitude},
+		{"not a number", math.NaN(), 0, ErrInvalidLatitude},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewPoint(tc.lat, tc.lng)
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPoint_DistanceKm(t *testing.T) {
+	jakarta := Point{Lat: -6.2088, Lng: 106.8456}
+	bandung := Point{Lat: -6.9175, Lng: 107.6191}
+
+	if d := jakarta.DistanceKm(bandung); d < 110 || d > 120 {
+		t.Errorf("expected about 116 km between Jakarta and Bandung, got %.1f", d)
+	}
+}
+
+func TestNewBoundingBox(t *testing.T) {
+	box, err := NewBoundingBox(-6.4, 106.6, -6.0, 107.0)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if !box.Contains(Point{Lat: -6.2, Lng: 106.8}) || box.Contains(Point{Lat: -6.9, Lng: 107.6}) {
+		t.Errorf("unexpected containment for %+v", box)
+	}
+
+	if _, err := NewBoundingBox(-6.0, 106.6, -6.4, 107.0); err != ErrInvalidBoundingBox {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidBoundingBox, err)
+	}
+	if _, err := NewBoundingBox(-6.4, 170, -6.0, -170); err != ErrInvalidBoundingBox {
+		t.Errorf("expected antimeridian box rejected, got '%v'", err)
+	}
+}
+
+func TestNewRegionLocation(t *testing.T) {
+	ring := []Point{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 2, Lng: 2}, {Lat: 2, Lng: 0}}
+
+	region, err := NewRegionLocation(ring, " Test square ")
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(region.Ring) != 5 || region.Ring[4] != ring[0] {
+		t.Errorf("expected the ring closed, got %v", region.Ring)
+	}
+	if region.Center != (Point{Lat: 1, Lng: 1}) || region.PlaceName != "Test square" {
+		t.Errorf("unexpected region %+v", region)
+	}
+	if b := region.Bounds(); b != (BoundingBox{South: 0, West: 0, North: 2, East: 2}) {
+		t.Errorf("unexpected bounds %+v", b)
+	}
+
+	if _, err := NewRegionLocation([]Point{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 1}, {Lat: 0, Lng: 0}}, ""); err != ErrRegionTooSmall {
+		t.Errorf("expected error '%v', got '%v'", ErrRegionTooSmall, err)
+	}
+	if _, err := NewRegionLocation([]Point{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 1}, {Lat: 95, Lng: 0}}, ""); err != ErrInvalidLatitude {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidLatitude, err)
+	}
+}
+
+func TestGridSize(t *testing.T) {
+	size, err := GridSize(0)
+	if err != nil || size != 90 {
+		t.Errorf("expected 90 degrees at zoom 0, got %v (%v)", size, err)
+	}
+	if deeper, _ := GridSize(10); deeper >= size {
+		t.Errorf("expected smaller cells when zoomed in, got %v", deeper)
+	}
+	if _, err := GridSize(21); err != ErrInvalidZoom {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidZoom, err)
+	}
+}
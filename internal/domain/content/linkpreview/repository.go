@@ -0,0 +1,34 @@
+package linkpreview
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Domain interface for caching cards by normalized URL (implementation will be in infrastructure layer).
+// Get returns nil on a miss.
+type Cache interface {
+	Get(ctx context.Context, key string) (*Card, error)
+	Set(ctx context.Context, key string, card *Card, ttl time.Duration) error
+}
+
+// Page is a fetched HTML document
+type Page struct {
+	URL  *url.URL // After redirects
+	Body []byte   // Truncated to the fetcher's size limit
+}
+
+// Fetcher downloads pages without reaching private networks (implementation
+// will be in infrastructure layer). It returns ErrBlockedHost, ErrNotHTML or
+// an error wrapping ErrFetchFailed.
+type Fetcher interface {
+	Fetch(ctx context.Context, u *url.URL) (*Page, error)
+}
+
+// ArticleCards builds cards for the portal's own URLs from the article store
+// (implemented by the article module)
+type ArticleCards interface {
+	// CardForPath returns nil when no published article lives at the path
+	CardForPath(ctx context.Context, path string) (*Card, error)
+}
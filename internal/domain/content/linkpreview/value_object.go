@@ -0,0 +1,189 @@
+package linkpreview
+
+import (
+	"errors"
+	"html"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits for fetched metadata, cards only show a line or two
+const (
+	MaxTitleLength       = 200
+	MaxDescriptionLength = 300
+)
+
+// CacheTTL is how long a card is reused before the page is fetched again
+const CacheTTL = 24 * time.Hour
+
+// Domain errors
+var (
+	ErrInvalidURL  = errors.New("URL must be an absolute http or https URL")
+	ErrBlockedHost = errors.New("URL points to a private or reserved address")
+	ErrNotHTML     = errors.New("URL is not an HTML page")
+	ErrFetchFailed = errors.New("failed to fetch the page")
+)
+
+// Card is the normalized preview the editor inserts as a link card
+type Card struct {
+	URL         string // Canonical URL when the page declares one
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+	ArticleID   *string // Set for internal links
+	FetchedAt   time.Time
+}
+
+// Internal reports whether the card points at one of the portal's own articles
+func (c *Card) Internal() bool {
+	return c.ArticleID != nil
+}
+
+// trackingParams are dropped so one page gets one cache entry
+var trackingParams = []string{"fbclid", "gclid", "igshid", "mc_cid", "mc_eid"}
+
+// NormalizeURL validates a pasted URL and returns it without fragment,
+// credentials, default port and tracking parameters
+func NormalizeURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, ErrInvalidURL
+	}
+	if u.User != nil {
+		// user@host URLs are a classic way to disguise the real host
+		return nil, ErrInvalidURL
+	}
+	u.Fragment, u.RawFragment = "", ""
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	switch {
+	case port != "":
+		u.Host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		u.Host = "[" + host + "]"
+	default:
+		u.Host = host
+	}
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(key, "utm_") {
+			query.Del(key)
+		}
+	}
+	for _, key := range trackingParams {
+		query.Del(key)
+	}
+	u.RawQuery = query.Encode()
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u, nil
+}
+
+var (
+	metaRegex      = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	linkRegex      = regexp.MustCompile(`(?is)<link\s[^>]*>`)
+	titleRegex     = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	attributeRegex = regexp.MustCompile(`(?is)([a-z:_-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	spaceRegex     = regexp.MustCompile(`\s+`)
+)
+
+// Parse reads Open Graph, Twitter card and plain HTML metadata from a page,
+// in that order of preference. Relative image and canonical URLs are resolved
+// against the page URL.
+func Parse(page []byte, pageURL *url.URL, fetchedAt time.Time) *Card {
+	meta := map[string]string{}
+	for _, tag := range metaRegex.FindAllString(string(page), -1) {
+		attrs := attributes(tag)
+		key := strings.ToLower(attrs["property"])
+		if key == "" {
+			key = strings.ToLower(attrs["name"])
+		}
+		if _, seen := meta[key]; key != "" && !seen {
+			meta[key] = attrs["content"]
+		}
+	}
+	canonical := ""
+	for _, tag := range linkRegex.FindAllString(string(page), -1) {
+		attrs := attributes(tag)
+		if strings.EqualFold(attrs["rel"], "canonical") {
+			canonical = attrs["href"]
+			break
+		}
+	}
+	title := ""
+	if m := titleRegex.FindStringSubmatch(string(page)); m != nil {
+		title = m[1]
+	}
+
+	card := &Card{
+		URL:         pageURL.String(),
+		Title:       clean(first(meta["og:title"], meta["twitter:title"], title), MaxTitleLength),
+		Description: clean(first(meta["og:description"], meta["twitter:description"], meta["description"]), MaxDescriptionLength),
+		SiteName:    clean(first(meta["og:site_name"], pageURL.Hostname()), MaxTitleLength),
+		FetchedAt:   fetchedAt,
+	}
+	if card.Title == "" {
+		card.Title = pageURL.Hostname()
+	}
+	if image := resolve(pageURL, first(meta["og:image:secure_url"], meta["og:image"], meta["twitter:image"], meta["twitter:image:src"])); image != "" {
+		card.ImageURL = image
+	}
+	if c := resolve(pageURL, canonical); c != "" {
+		card.URL = c
+	}
+	return card
+}
+
+func attributes(tag string) map[string]string {
+	attrs := map[string]string{}
+	for _, m := range attributeRegex.FindAllStringSubmatch(tag, -1) {
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(m[2] + m[3] + m[4])
+	}
+	return attrs
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// clean collapses whitespace and cuts at a word boundary with an ellipsis
+func clean(value string, limit int) string {
+	value = strings.TrimSpace(spaceRegex.ReplaceAllString(html.UnescapeString(value), " "))
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	runes := []rune(value)[:limit-1]
+	cut := string(runes)
+	if i := strings.LastIndexByte(cut, ' '); i > limit/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
+
+// resolve returns an absolute http(s) URL or empty, so a card never carries
+// a javascript: or data: image
+func resolve(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,67 @@
+package linkpreview
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	testCases := []struct {
+		name          string
+		raw           string
+		expected      string
+		expectedError error
+	}{
+		{"tracking stripped", " HTTPS://Example.com:443/news?id=1&utm_source=x&fbclid=abc#top ", "https://example.com/news?id=1", nil},
+		{"empty path", "http://example.com", "http://example.com/", nil},
+		{"custom port kept", "http://example.com:8080/a", "http://example.com:8080/a", nil},
+		{"ipv6 host", "http://[::1]:80/", "http://[::1]/", nil},
+		{"relative", "/news/a1", "", ErrInvalidURL},
+		{"ftp", "ftp://example.com/file", "", ErrInvalidURL},
+		{"javascript", "javascript:alert(1)", "", ErrInvalidURL},
+		{"credentials", "https://example.com@169.254.169.254/", "", ErrInvalidURL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := NormalizeURL(tc.raw)
+			if err != tc.expectedError {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if err == nil && u.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, u.String())
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	page := `<html><head>
+		<title>Fallback title</title>
+		<meta property="og:title" content="Flood &amp; rain   hit Jakarta">
+		<meta name="description" content='Plain description'>
+		<meta name="twitter:image" content="/img/flood.jpg">
+		<meta property="og:site_name" content="Example News">
+		<link rel="canonical" href="https://example.com/flood">
+	</head></html>`
+	base, _ := url.Parse("https://example.com/flood?ref=home")
+	at := time.Now()
+
+	card := Parse([]byte(page), base, at)
+	if card.Title != "Flood & rain hit Jakarta" || card.Description != "Plain description" || card.SiteName != "Example News" {
+		t.Errorf("unexpected text fields %+v", card)
+	}
+	if card.ImageURL != "https://example.com/img/flood.jpg" || card.URL != "https://example.com/flood" {
+		t.Errorf("expected resolved image and canonical URL, got %q %q", card.ImageURL, card.URL)
+	}
+
+	card = Parse([]byte(`<meta property="og:image" content="javascript:alert(1)"><meta name="description" content="`+strings.Repeat("word ", 100)+`">`), base, at)
+	if card.Title != "example.com" || card.ImageURL != "" || card.SiteName != "example.com" {
+		t.Errorf("expected host fallbacks and no unsafe image, got %+v", card)
+	}
+	if n := len([]rune(card.Description)); n > MaxDescriptionLength || !strings.HasSuffix(card.Description, "…") {
+		t.Errorf("expected truncated description, got %d runes", n)
+	}
+}
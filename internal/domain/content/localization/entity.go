@@ -0,0 +1,38 @@
+package localization
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ArticleSettings holds the editor's opt-out, e.g. for a markets report
+// where approximate conversions would be misleading
+type ArticleSettings struct {
+	ArticleID string
+	Disabled  bool
+
+	LastActionBy *string
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+func NewArticleSettings(articleID string, disabled bool, editorID string, at time.Time) (*ArticleSettings, error) {
+	if strings.TrimSpace(articleID) == "" {
+		return nil, errors.New("articleID cannot be empty")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editorID cannot be empty")
+	}
+	return &ArticleSettings{ArticleID: articleID, Disabled: disabled, LastActionBy: &editorID, UpdatedAt: at}, nil
+}
+
+// ReaderPreference is a member's saved preference
+type ReaderPreference struct {
+	AccountID  string
+	Preference Preference
+
+	// Audit
+	UpdatedAt time.Time
+}
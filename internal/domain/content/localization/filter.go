@@ -0,0 +1,368 @@
+package localization
+
+import (
+	"html"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind of token the filter converts
+type Kind string
+
+const (
+	KindCurrency    Kind = "currency"
+	KindLength      Kind = "length"
+	KindMass        Kind = "mass"
+	KindSpeed       Kind = "speed"
+	KindTemperature Kind = "temperature"
+	KindVolume      Kind = "volume"
+)
+
+// Token is an amount or measurement found in article text, Start and End
+// are byte offsets into the text it was found in
+type Token struct {
+	Start    int
+	End      int
+	Kind     Kind
+	Value    float64
+	Unit     string // Canonical unit, e.g. "km" or "lb", empty for currencies
+	Currency Currency
+}
+
+const numberPattern = `\d{1,3}(?:[.,]\d{3})+(?:[.,]\d+)?|\d+(?:[.,]\d+)?`
+
+var (
+	currencyRegex = regexp.MustCompile(`(Rp\.?|IDR|US\$|USD|\$)\s?(` + numberPattern + `)(?:\s?(ribu|rb|juta|jt|miliar|milyar|M|triliun|T|thousand|million|mn|billion|bn|trillion)\b)?`)
+	unitRegex     = regexp.MustCompile(`(` + numberPattern + `)\s?(km/jam|km/h|kph|mph|°C|°F|derajat Celsius|kilometer|km|cm|meter|metre|m|kilogram|kg|liter|litre|miles|mile|mil|mi|feet|foot|ft|kaki|inches|inch|inci|pounds|pound|pon|lbs|lb|gallons|gallon|galon)\b`)
+)
+
+var magnitudes = map[string]float64{
+	"ribu": 1e3, "rb": 1e3, "thousand": 1e3,
+	"juta": 1e6, "jt": 1e6, "million": 1e6, "mn": 1e6,
+	"miliar": 1e9, "milyar": 1e9, "M": 1e9, "billion": 1e9, "bn": 1e9,
+	"triliun": 1e12, "T": 1e12, "trillion": 1e12,
+}
+
+// unitAliases maps what writers type to canonical units
+var unitAliases = map[string]string{
+	"km/jam": "km/h", "km/h": "km/h", "kph": "km/h", "mph": "mph",
+	"°C": "°C", "derajat Celsius": "°C", "°F": "°F",
+	"kilometer": "km", "km": "km", "cm": "cm", "meter": "m", "metre": "m", "m": "m",
+	"kilogram": "kg", "kg": "kg", "liter": "L", "litre": "L",
+	"miles": "mi", "mile": "mi", "mil": "mi", "mi": "mi",
+	"feet": "ft", "foot": "ft", "ft": "ft", "kaki": "ft",
+	"inches": "in", "inch": "in", "inci": "in",
+	"pounds": "lb", "pound": "lb", "pon": "lb", "lbs": "lb", "lb": "lb",
+	"gallons": "gal", "gallon": "gal", "galon": "gal",
+}
+
+type unitDef struct {
+	kind    Kind
+	system  UnitSystem
+	partner string  // The matching unit in the other system
+	factor  float64 // Multiply to get the partner unit, unused for temperatures
+}
+
+var units = map[string]unitDef{
+	"km":   {KindLength, UnitsMetric, "mi", 1 / 1.609344},
+	"mi":   {KindLength, UnitsImperial, "km", 1.609344},
+	"m":    {KindLength, UnitsMetric, "ft", 1 / 0.3048},
+	"ft":   {KindLength, UnitsImperial, "m", 0.3048},
+	"cm":   {KindLength, UnitsMetric, "in", 1 / 2.54},
+	"in":   {KindLength, UnitsImperial, "cm", 2.54},
+	"kg":   {KindMass, UnitsMetric, "lb", 1 / 0.45359237},
+	"lb":   {KindMass, UnitsImperial, "kg", 0.45359237},
+	"km/h": {KindSpeed, UnitsMetric, "mph", 1 / 1.609344},
+	"mph":  {KindSpeed, UnitsImperial, "km/h", 1.609344},
+	"L":    {KindVolume, UnitsMetric, "gal", 1 / 3.785411784},
+	"gal":  {KindVolume, UnitsImperial, "L", 3.785411784},
+	"°C":   {KindTemperature, UnitsMetric, "°F", 0},
+	"°F":   {KindTemperature, UnitsImperial, "°C", 0},
+}
+
+// unitLabels are the Indonesian names where they differ from the symbols
+var unitLabels = map[string]string{"mi": "mil", "ft": "kaki", "in": "inci", "lb": "pon", "gal": "galon", "km/h": "km/jam", "L": "liter"}
+
+// Detect finds amounts and measurements in plain text. Rupiah amounts
+// always use Indonesian separators, the rest follow the article language.
+func Detect(text, language string) []Token {
+	var tokens []Token
+	for _, m := range currencyRegex.FindAllStringSubmatchIndex(text, -1) {
+		if !boundaryBefore(text, m[0]) {
+			continue
+		}
+		prefix := text[m[2]:m[3]]
+		currency, numberLang := CurrencyUSD, language
+		if strings.HasPrefix(prefix, "Rp") || prefix == "IDR" {
+			currency, numberLang = CurrencyIDR, "id"
+		}
+		value, ok := parseNumber(text[m[4]:m[5]], numberLang)
+		if !ok {
+			continue
+		}
+		if m[6] >= 0 {
+			value *= magnitudes[text[m[6]:m[7]]]
+		}
+		tokens = append(tokens, Token{Start: m[0], End: m[1], Kind: KindCurrency, Value: value, Currency: currency})
+	}
+	for _, m := range unitRegex.FindAllStringSubmatchIndex(text, -1) {
+		if !boundaryBefore(text, m[0]) {
+			continue
+		}
+		value, ok := parseNumber(text[m[2]:m[3]], language)
+		if !ok {
+			continue
+		}
+		unit := unitAliases[text[m[4]:m[5]]]
+		tokens = append(tokens, Token{Start: m[0], End: m[1], Kind: units[unit].kind, Value: value, Unit: unit})
+	}
+
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Start < tokens[j].Start })
+	kept := tokens[:0]
+	for _, t := range tokens {
+		if len(kept) > 0 && t.Start < kept[len(kept)-1].End {
+			continue
+		}
+		kept = append(kept, t)
+	}
+	return kept
+}
+
+// Convert returns the token as the reader prefers it, false when it is
+// already in their currency or unit system or no rate is available
+func Convert(t Token, pref Preference, rate *Rate, language string) (string, bool) {
+	if t.Kind == KindCurrency {
+		if t.Currency == pref.Currency || rate == nil {
+			return "", false
+		}
+		value, ok := rate.Convert(t.Value, t.Currency, pref.Currency)
+		if !ok {
+			return "", false
+		}
+		return formatAmount(value, pref.Currency, language), true
+	}
+
+	def := units[t.Unit]
+	if def.system == pref.Units {
+		return "", false
+	}
+	var value float64
+	switch t.Unit {
+	case "°C":
+		value = t.Value*9/5 + 32
+	case "°F":
+		value = (t.Value - 32) * 5 / 9
+	default:
+		value = t.Value * def.factor
+	}
+	decimals := 1
+	if math.Abs(value) >= 100 {
+		decimals = 0
+	}
+	label := def.partner
+	if l, ok := unitLabels[label]; ok && language == "id" {
+		label = l
+	}
+	separator := " "
+	if strings.HasPrefix(label, "°") {
+		separator = ""
+	}
+	return formatNumber(value, decimals, language) + separator + label, true
+}
+
+// Localize appends the reader's conversion after each amount and
+// measurement in the body's text, e.g. "Rp1,5 miliar <span
+// class="conversion">(≈ US$92.308)</span>". Scripts, styles and earlier
+// conversions are left alone. It returns the body and how many tokens it
+// converted.
+func Localize(body, language string, pref Preference, rate *Rate) (string, int) {
+	var b strings.Builder
+	count := 0
+	skipUntil := ""
+	for i := 0; i < len(body); {
+		if body[i] == '<' {
+			end := strings.IndexByte(body[i:], '>')
+			if end < 0 {
+				b.WriteString(body[i:])
+				break
+			}
+			tag := body[i : i+end+1]
+			b.WriteString(tag)
+			i += end + 1
+			if skipUntil == "" {
+				skipUntil = skippedUntil(tag)
+			} else if strings.EqualFold(tag, skipUntil) {
+				skipUntil = ""
+			}
+			continue
+		}
+
+		next := strings.IndexByte(body[i:], '<')
+		if next < 0 {
+			next = len(body) - i
+		}
+		text := body[i : i+next]
+		i += next
+		if skipUntil != "" {
+			b.WriteString(text)
+			continue
+		}
+
+		// A token right before a conversion span was localized already
+		localized := -1
+		if strings.HasPrefix(body[i:], `<span class="conversion"`) {
+			localized = len(strings.TrimRight(text, " "))
+		}
+
+		last := 0
+		for _, t := range Detect(text, language) {
+			if t.End == localized {
+				continue
+			}
+			display, ok := Convert(t, pref, rate, language)
+			if !ok {
+				continue
+			}
+			b.WriteString(text[last:t.End])
+			b.WriteString(` <span class="conversion">(≈ `)
+			b.WriteString(html.EscapeString(display))
+			b.WriteString(")</span>")
+			last = t.End
+			count++
+		}
+		b.WriteString(text[last:])
+	}
+	return b.String(), count
+}
+
+// skippedUntil returns the closing tag whose content the filter leaves alone
+func skippedUntil(tag string) string {
+	lower := strings.ToLower(tag)
+	switch {
+	case strings.HasPrefix(lower, "<script"):
+		return "</script>"
+	case strings.HasPrefix(lower, "<style"):
+		return "</style>"
+	case strings.HasPrefix(lower, "<code"):
+		return "</code>"
+	case strings.HasPrefix(lower, `<span class="conversion"`):
+		return "</span>"
+	}
+	return ""
+}
+
+func boundaryBefore(text string, at int) bool {
+	if at == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:at])
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != ','
+}
+
+// parseNumber reads both 1.500.000,50 and 1,500,000.50. A single separator
+// followed by exactly three digits is a thousands separator in the
+// language's own convention, otherwise it is the decimal point.
+func parseNumber(s, language string) (float64, bool) {
+	thousands, decimal := ",", "."
+	lastDot, lastComma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastComma > lastDot {
+			thousands, decimal = ".", ","
+		}
+	case lastDot >= 0 || lastComma >= 0:
+		sep := "."
+		if lastComma >= 0 {
+			sep = ","
+		}
+		idx := strings.LastIndex(s, sep)
+		groups := strings.Count(s, sep) > 1 || len(s)-idx-1 == 3 && sep == localThousands(language)
+		if groups {
+			thousands, decimal = sep, otherSeparator(sep)
+		} else {
+			thousands, decimal = otherSeparator(sep), sep
+		}
+	}
+	s = strings.ReplaceAll(s, thousands, "")
+	s = strings.Replace(s, decimal, ".", 1)
+	value, err := strconv.ParseFloat(s, 64)
+	return value, err == nil
+}
+
+func localThousands(language string) string {
+	if language == "id" {
+		return "."
+	}
+	return ","
+}
+
+func otherSeparator(sep string) string {
+	if sep == "." {
+		return ","
+	}
+	return "."
+}
+
+// formatAmount writes amounts the way each currency is usually printed,
+// with scale words from a million up
+func formatAmount(value float64, currency Currency, language string) string {
+	prefix := "Rp"
+	if currency == CurrencyUSD {
+		prefix = "US$"
+	}
+	scales := []struct {
+		value  float64
+		id, en string
+	}{{1e12, "triliun", "trillion"}, {1e9, "miliar", "billion"}, {1e6, "juta", "million"}}
+	for _, s := range scales {
+		if value >= s.value {
+			scaled := value / s.value
+			decimals := 1
+			if scaled < 10 {
+				decimals = 2
+			}
+			word := s.en
+			if language == "id" {
+				word = s.id
+			}
+			return prefix + formatNumber(scaled, decimals, language) + " " + word
+		}
+	}
+	decimals := 0
+	if currency == CurrencyUSD && value < 100 {
+		decimals = 2
+	}
+	return prefix + formatNumber(value, decimals, language)
+}
+
+// formatNumber groups thousands and trims trailing zero decimals
+func formatNumber(value float64, decimals int, language string) string {
+	thousands, decimal := ",", "."
+	if language == "id" {
+		thousands, decimal = ".", ","
+	}
+	s := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+	frac = strings.TrimRight(frac, "0")
+
+	var b strings.Builder
+	if value < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteString(decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
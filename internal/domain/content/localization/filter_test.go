@@ -0,0 +1,105 @@
+package localization
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		language string
+		kind     Kind
+		value    float64
+		unit     string
+	}{
+		{"rupiah with dots", "harga Rp15.000 per kg", "id", KindCurrency, 15000, ""},
+		{"rupiah with scale word", "anggaran Rp 1,5 triliun", "id", KindCurrency, 1.5e12, ""},
+		{"rupiah in English text", "a fine of Rp2.500.000", "en", KindCurrency, 2.5e6, ""},
+		{"dollars in English", "raised $2.5 million", "en", KindCurrency, 2.5e6, ""},
+		{"dollars in Indonesian", "senilai US$1,2 miliar", "id", KindCurrency, 1.2e9, ""},
+		{"kilometres", "sejauh 42,195 km", "id", KindLength, 42.195, "km"},
+		{"English thousands", "a 1,200 km trip", "en", KindLength, 1200, "km"},
+		{"temperature", "suhu mencapai 38°C", "id", KindTemperature, 38, "°C"},
+		{"speed", "angin 80 km/jam", "id", KindSpeed, 80, "km/h"},
+		{"imperial words", "he weighs 180 pounds", "en", KindMass, 180, "lb"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens := Detect(tc.text, tc.language)
+			if len(tokens) != 1 {
+				t.Fatalf("expected one token, got %+v", tokens)
+			}
+			tok := tokens[0]
+			if tok.Kind != tc.kind || tok.Value != tc.value || tok.Unit != tc.unit {
+				t.Errorf("expected %s %v %s, got %+v", tc.kind, tc.value, tc.unit, tok)
+			}
+		})
+	}
+
+	for _, text := range []string{"pada 15 menit", "model A320 m", "tahun 2024", "COVID-19"} {
+		if tokens := Detect(text, "id"); len(tokens) != 0 {
+			t.Errorf("expected nothing in %q, got %+v", text, tokens)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rate, _ := NewRate(CurrencyUSD, CurrencyIDR, 16250, time.Now())
+	usd := Preference{Currency: CurrencyUSD, Units: UnitsImperial}
+	idr := DefaultPreference()
+
+	testCases := []struct {
+		name     string
+		token    Token
+		pref     Preference
+		language string
+		expected string
+		ok       bool
+	}{
+		{"rupiah to dollars", Token{Kind: KindCurrency, Value: 1.5e9, Currency: CurrencyIDR}, usd, "id", "US$92.308", true},
+		{"large rupiah to dollars", Token{Kind: KindCurrency, Value: 1.5e12, Currency: CurrencyIDR}, usd, "en", "US$92.3 million", true},
+		{"dollars to rupiah", Token{Kind: KindCurrency, Value: 2.5e6, Currency: CurrencyUSD}, idr, "id", "Rp40,6 miliar", true},
+		{"already in currency", Token{Kind: KindCurrency, Value: 1, Currency: CurrencyIDR}, idr, "id", "", false},
+		{"kilometres to miles", Token{Kind: KindLength, Value: 42.195, Unit: "km"}, usd, "id", "26,2 mil", true},
+		{"celsius to fahrenheit", Token{Kind: KindTemperature, Value: 38, Unit: "°C"}, usd, "en", "100°F", true},
+		{"pounds to kilograms", Token{Kind: KindMass, Value: 180, Unit: "lb"}, idr, "en", "81.6 kg", true},
+		{"metric reader", Token{Kind: KindLength, Value: 10, Unit: "km"}, idr, "id", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := Convert(tc.token, tc.pref, rate, tc.language)
+			if ok != tc.ok || got != tc.expected {
+				t.Errorf("expected %q %v, got %q %v", tc.expected, tc.ok, got, ok)
+			}
+		})
+	}
+
+	if _, ok := Convert(Token{Kind: KindCurrency, Value: 1, Currency: CurrencyIDR}, usd, nil, "id"); ok {
+		t.Error("expected no currency conversion without a rate")
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	rate, _ := NewRate(CurrencyUSD, CurrencyIDR, 16000, time.Now())
+	pref := Preference{Currency: CurrencyUSD, Units: UnitsImperial}
+	body := `<p>Proyek senilai Rp1,6 triliun sepanjang 10 km.</p><script>var x = "Rp1.000";</script><code>10 km</code>`
+
+	got, count := Localize(body, "id", pref, rate)
+	expected := `<p>Proyek senilai Rp1,6 triliun <span class="conversion">(≈ US$100 juta)</span> sepanjang 10 km <span class="conversion">(≈ 6,2 mil)</span>.</p><script>var x = "Rp1.000";</script><code>10 km</code>`
+	if got != expected || count != 2 {
+		t.Errorf("unexpected output (%d conversions):\n%s", count, got)
+	}
+
+	again, count := Localize(got, "id", pref, rate)
+	if again != got || count != 0 {
+		t.Errorf("expected localizing twice to skip earlier conversions, got %d:\n%s", count, again)
+	}
+
+	if unchanged, count := Localize(body, "id", DefaultPreference(), rate); unchanged != body || count != 0 {
+		t.Errorf("expected no conversions for a default reader, got %s", unchanged)
+	}
+}
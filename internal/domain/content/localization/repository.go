@@ -0,0 +1,24 @@
+package localization
+
+import "context"
+
+type RateRepository interface {
+	Save(ctx context.Context, rate *Rate) error
+	// FindLatest returns the newest rate of the pair, nil when none was fetched yet
+	FindLatest(ctx context.Context, base, quote Currency) (*Rate, error)
+}
+
+type ArticleSettingsRepository interface {
+	Save(ctx context.Context, settings *ArticleSettings) error
+	FindByArticle(ctx context.Context, articleID string) (*ArticleSettings, error) // nil when never set
+}
+
+type PreferenceRepository interface {
+	Save(ctx context.Context, preference *ReaderPreference) error
+	FindByAccount(ctx context.Context, accountID string) (*ReaderPreference, error) // nil when never set
+}
+
+// RateProvider fetches current exchange rates (implementation will be in infrastructure layer)
+type RateProvider interface {
+	Latest(ctx context.Context, base, quote Currency) (*Rate, error)
+}
@@ -0,0 +1,98 @@
+package localization
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxRateAge is how old an exchange rate can get before currency
+// conversions are left out rather than shown with a stale rate
+const MaxRateAge = 48 * time.Hour
+
+// Domain errors
+var (
+	ErrInvalidCurrency   = errors.New("currency must be IDR or USD")
+	ErrInvalidUnitSystem = errors.New("unit system must be metric or imperial")
+	ErrInvalidRate       = errors.New("exchange rate must be positive")
+)
+
+// Currency a reader can see amounts in
+type Currency string
+
+const (
+	CurrencyIDR Currency = "IDR"
+	CurrencyUSD Currency = "USD"
+)
+
+func (c Currency) Valid() bool {
+	return c == CurrencyIDR || c == CurrencyUSD
+}
+
+// UnitSystem a reader can see measurements in
+type UnitSystem string
+
+const (
+	UnitsMetric   UnitSystem = "metric"
+	UnitsImperial UnitSystem = "imperial"
+)
+
+func (u UnitSystem) Valid() bool {
+	return u == UnitsMetric || u == UnitsImperial
+}
+
+// Preference value object, how a reader wants amounts and measurements shown
+type Preference struct {
+	Currency Currency
+	Units    UnitSystem
+}
+
+// DefaultPreference is what guests and readers who never chose get
+func DefaultPreference() Preference {
+	return Preference{Currency: CurrencyIDR, Units: UnitsMetric}
+}
+
+func NewPreference(currency Currency, units UnitSystem) (Preference, error) {
+	if !currency.Valid() {
+		return Preference{}, ErrInvalidCurrency
+	}
+	if !units.Valid() {
+		return Preference{}, ErrInvalidUnitSystem
+	}
+	return Preference{Currency: currency, Units: units}, nil
+}
+
+// Rate value object, Value units of Quote buy one unit of Base
+type Rate struct {
+	Base      Currency
+	Quote     Currency
+	Value     float64
+	FetchedAt time.Time
+}
+
+func NewRate(base, quote Currency, value float64, fetchedAt time.Time) (*Rate, error) {
+	if !base.Valid() || !quote.Valid() {
+		return nil, ErrInvalidCurrency
+	}
+	if value <= 0 {
+		return nil, ErrInvalidRate
+	}
+	return &Rate{Base: base, Quote: quote, Value: value, FetchedAt: fetchedAt}, nil
+}
+
+// Fresh reports whether the rate is recent enough to show conversions with
+func (r *Rate) Fresh(at time.Time) bool {
+	return at.Sub(r.FetchedAt) <= MaxRateAge
+}
+
+// Convert turns an amount between the rate's two currencies
+func (r *Rate) Convert(amount float64, from, to Currency) (float64, bool) {
+	switch {
+	case from == to:
+		return amount, true
+	case from == r.Base && to == r.Quote:
+		return amount * r.Value, true
+	case from == r.Quote && to == r.Base:
+		return amount / r.Value, true
+	}
+	return 0, false
+}
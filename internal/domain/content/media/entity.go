@@ -0,0 +1,136 @@
+package media
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+)
+
+type Document struct {
+	ID          string
+	Filename    string
+	ContentType string
+	Size        int64
+	Checksum    string // SHA-256 of the content, hex encoded
+	StorageKey  string
+	UploadedBy  string
+
+	// Scan
+	ScanStatus    ScanStatus
+	ScanAttempts  int
+	LastScanError *string
+	ScannedAt     *time.Time
+	ScannedBy     *string // Scanner name and version
+	Threat        *string
+	QuarantinedAt *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewDocument(id, filename, contentType string, size int64, checksum, storageKey, uploadedBy string) (*Document, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(storageKey) == "" {
+		return nil, errors.New("storage key cannot be empty")
+	}
+	if strings.TrimSpace(uploadedBy) == "" {
+		return nil, errors.New("uploaded by cannot be empty")
+	}
+
+	filename = path.Base(strings.TrimSpace(filename))
+	if filename == "" || filename == "." || filename == "/" {
+		return nil, errors.New("filename cannot be empty")
+	}
+	if !DocumentContentTypes[contentType] {
+		return nil, ErrUnsupportedContentType
+	}
+	if size <= 0 {
+		return nil, ErrEmptyDocument
+	}
+	if size > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	now := time.Now()
+	return &Document{
+		ID:          id,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    checksum,
+		StorageKey:  storageKey,
+		UploadedBy:  uploadedBy,
+		ScanStatus:  ScanPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Business Methods
+
+// RecordScan stores the scanner verdict, infected documents are quarantined
+func (d *Document) RecordScan(result ScanResult, at time.Time) error {
+	if d.ScanStatus != ScanPending {
+		return ErrScanNotPending
+	}
+
+	scanner := result.Scanner()
+	d.ScannedAt = &at
+	d.ScannedBy = &scanner
+	d.ScanAttempts++
+	d.LastScanError = nil
+
+	if result.IsClean() {
+		d.ScanStatus = ScanClean
+		d.Threat = nil
+		d.QuarantinedAt = nil
+	} else {
+		threat := result.Threat()
+		d.ScanStatus = ScanQuarantined
+		d.Threat = &threat
+		if d.QuarantinedAt == nil {
+			d.QuarantinedAt = &at
+		}
+	}
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+// RecordScanError keeps the document pending for a retry until MaxScanAttempts is reached
+func (d *Document) RecordScanError(message string, at time.Time) error {
+	if d.ScanStatus != ScanPending {
+		return ErrScanNotPending
+	}
+
+	d.ScanAttempts++
+	d.LastScanError = &message
+	if d.ScanAttempts >= MaxScanAttempts {
+		d.ScanStatus = ScanFailed
+	}
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+// RequestRescan queues the document for another scan, e.g. after a signature update.
+// Downloads stay blocked until the new verdict is in; quarantined blobs stay quarantined.
+func (d *Document) RequestRescan() {
+	d.ScanStatus = ScanPending
+	d.ScanAttempts = 0
+	d.LastScanError = nil
+	d.UpdatedAt = time.Now()
+}
+
+// Query Methods
+
+func (d *Document) IsDownloadable() bool {
+	return d.ScanStatus == ScanClean
+}
+
+// IsQuarantined reports whether the blob sits in quarantine storage
+func (d *Document) IsQuarantined() bool {
+	return d.QuarantinedAt != nil
+}
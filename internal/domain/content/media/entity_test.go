@@ -0,0 +1,106 @@
+package media
+
+import (
+	"testing"
+	"time"
+)
+
+func createTestDocument(t *testing.T) *Document {
+	d, err := NewDocument("doc-1", "report.pdf", "application/pdf", 2048, "abc123", "documents/doc-1", "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create document: %v", err)
+	}
+	return d
+}
+
+func TestNewDocument(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		contentType string
+		size        int64
+		wantErr     error
+	}{
+		{name: "valid pdf", filename: "report.pdf", contentType: "application/pdf", size: 1024},
+		{name: "path is stripped", filename: "../../etc/report.csv", contentType: "text/csv", size: 10},
+		{name: "unsupported type", filename: "run.exe", contentType: "application/x-msdownload", size: 10, wantErr: ErrUnsupportedContentType},
+		{name: "empty", filename: "report.pdf", contentType: "application/pdf", size: 0, wantErr: ErrEmptyDocument},
+		{name: "too large", filename: "report.pdf", contentType: "application/pdf", size: MaxDocumentSize + 1, wantErr: ErrDocumentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDocument("doc-1", tt.filename, tt.contentType, tt.size, "abc", "documents/doc-1", "editor-1")
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("expected error '%v', got '%v'", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.ScanStatus != ScanPending || d.IsDownloadable() {
+				t.Error("expected new document to be pending and blocked")
+			}
+			if d.Filename != "report.pdf" && d.Filename != "report.csv" {
+				t.Errorf("expected base filename, got '%s'", d.Filename)
+			}
+		})
+	}
+}
+
+func TestDocument_RecordScan(t *testing.T) {
+	at := time.Date(2025, time.July, 1, 10, 0, 0, 0, time.UTC)
+
+	clean := createTestDocument(t)
+	if err := clean.RecordScan(NewCleanResult("clamav 1.4"), at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !clean.IsDownloadable() || clean.ScannedBy == nil || *clean.ScannedBy != "clamav 1.4" {
+		t.Errorf("expected clean downloadable document, got %+v", clean)
+	}
+	if err := clean.RecordScan(NewCleanResult("clamav 1.4"), at); err != ErrScanNotPending {
+		t.Errorf("expected error '%v', got '%v'", ErrScanNotPending, err)
+	}
+
+	infected := createTestDocument(t)
+	result, _ := NewInfectedResult("clamav 1.4", "Win.Test.EICAR_HDB-1")
+	infected.RecordScan(*result, at)
+	if infected.ScanStatus != ScanQuarantined || !infected.IsQuarantined() || infected.IsDownloadable() {
+		t.Errorf("expected quarantined document, got %s", infected.ScanStatus)
+	}
+
+	// A re-scan with newer signatures clears a false positive
+	infected.RequestRescan()
+	if infected.IsDownloadable() || !infected.IsQuarantined() {
+		t.Error("expected re-scan to keep the document blocked and quarantined")
+	}
+	infected.RecordScan(NewCleanResult("clamav 1.5"), at.Add(time.Hour))
+	if !infected.IsDownloadable() || infected.IsQuarantined() || infected.Threat != nil {
+		t.Error("expected clean verdict to release the document")
+	}
+
+	if _, err := NewInfectedResult("clamav", " "); err == nil {
+		t.Error("expected error but got none")
+	}
+}
+
+func TestDocument_RecordScanError(t *testing.T) {
+	d := createTestDocument(t)
+	for i := 1; i < MaxScanAttempts; i++ {
+		d.RecordScanError("connection refused", time.Now())
+		if d.ScanStatus != ScanPending {
+			t.Fatalf("expected document to stay pending after %d errors", i)
+		}
+	}
+	d.RecordScanError("connection refused", time.Now())
+	if d.ScanStatus != ScanFailed {
+		t.Errorf("expected failed after %d errors, got %s", MaxScanAttempts, d.ScanStatus)
+	}
+
+	d.RequestRescan()
+	if d.ScanStatus != ScanPending || d.ScanAttempts != 0 {
+		t.Error("expected re-scan to reset attempts")
+	}
+}
@@ -0,0 +1,32 @@
+package media
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type DocumentRepository interface {
+	// Commands
+	Create(ctx context.Context, document *Document) error
+	Update(ctx context.Context, document *Document) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Document, error)
+	FindPendingScan(ctx context.Context, limit int) ([]*Document, error)
+
+	// MarkRescan sets every document uploaded since the given time back to pending and returns the number updated
+	MarkRescan(ctx context.Context, uploadedSince time.Time) (int64, error)
+}
+
+// Domain interface for document blob storage (implementation will be in infrastructure layer)
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+
+	// Open reads the blob from serving or quarantine storage, wherever it currently is
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Quarantine moves the blob out of the serving bucket, Release moves it back
+	Quarantine(ctx context.Context, key string) error
+	Release(ctx context.Context, key string) error
+}
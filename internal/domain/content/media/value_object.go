@@ -0,0 +1,80 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+type ScanStatus string
+
+const (
+	ScanPending     ScanStatus = "pending"     // Uploaded, waiting for the scanner
+	ScanClean       ScanStatus = "clean"       // Downloadable
+	ScanQuarantined ScanStatus = "quarantined" // Threat found, blob moved out of the public bucket
+	ScanFailed      ScanStatus = "failed"      // Scanner kept erroring, needs a manual re-scan
+)
+
+// MaxScanAttempts is how often a scanner error is retried before the document is marked failed
+const MaxScanAttempts = 5
+
+// MaxDocumentSize is the largest upload accepted into the pipeline, matches the scanner stream limit
+const MaxDocumentSize = 25 << 20
+
+// Content types accepted as documents
+var DocumentContentTypes = map[string]bool{
+	"application/pdf":    true,
+	"application/msword": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
+	"application/vnd.ms-excel": true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true,
+	"text/csv":   true,
+	"text/plain": true,
+}
+
+// Domain errors
+var (
+	ErrUnsupportedContentType = errors.New("document type is not supported")
+	ErrDocumentTooLarge       = errors.New("document exceeds the 25 MB upload limit")
+	ErrEmptyDocument          = errors.New("document is empty")
+	ErrNotDownloadable        = errors.New("document is not available until it has been scanned clean")
+	ErrScanNotPending         = errors.New("document is not waiting for a scan")
+)
+
+// ScanResult value object, the verdict of one scanner run
+type ScanResult struct {
+	clean   bool
+	threat  string // Signature name, e.g. "Win.Test.EICAR_HDB-1"
+	scanner string
+}
+
+func NewCleanResult(scanner string) ScanResult {
+	return ScanResult{clean: true, scanner: scanner}
+}
+
+func NewInfectedResult(scanner, threat string) (*ScanResult, error) {
+	threat = strings.TrimSpace(threat)
+	if threat == "" {
+		return nil, errors.New("threat name cannot be empty")
+	}
+	return &ScanResult{threat: threat, scanner: scanner}, nil
+}
+
+func (r ScanResult) IsClean() bool {
+	return r.clean
+}
+
+func (r ScanResult) Threat() string {
+	return r.threat
+}
+
+func (r ScanResult) Scanner() string {
+	return r.scanner
+}
+
+// Domain interface for virus scanning (implementation will be in infrastructure layer)
+type Scanner interface {
+	Name() string
+	Scan(ctx context.Context, content io.Reader) (ScanResult, error)
+}
@@ -0,0 +1,87 @@
+package permalink
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// RetiredPattern is a previous pattern of a category, kept so old links keep redirecting
+type RetiredPattern struct {
+	Pattern   Pattern
+	RetiredAt time.Time
+	RetiredBy string
+}
+
+// CategoryRoute binds a URL pattern to a category
+type CategoryRoute struct {
+	CategoryID   string
+	CategorySlug string
+	Pattern      Pattern
+	Retired      []RetiredPattern
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCategoryRoute(categoryID, categorySlug string, pattern Pattern, createdBy string) (*CategoryRoute, error) {
+	if strings.TrimSpace(categoryID) == "" {
+		return nil, errors.New("category ID cannot be empty")
+	}
+	if strings.TrimSpace(categorySlug) == "" {
+		return nil, errors.New("category slug cannot be empty")
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+
+	now := time.Now()
+	return &CategoryRoute{
+		CategoryID:   categoryID,
+		CategorySlug: categorySlug,
+		Pattern:      pattern,
+		LastActionBy: &createdBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Business Methods
+
+// ChangePattern switches the category to a new pattern and keeps the old one for redirects
+func (r *CategoryRoute) ChangePattern(pattern Pattern, editorID string) error {
+	if strings.TrimSpace(editorID) == "" {
+		return errors.New("editor ID cannot be empty")
+	}
+	if r.Pattern.Equals(pattern) {
+		return errors.New("new pattern is the same as current pattern")
+	}
+
+	now := time.Now()
+	retired := make([]RetiredPattern, 0, len(r.Retired)+1)
+	for _, old := range r.Retired {
+		// Switching back to a retired pattern makes it current again
+		if !old.Pattern.Equals(pattern) {
+			retired = append(retired, old)
+		}
+	}
+	r.Retired = append(retired, RetiredPattern{Pattern: r.Pattern, RetiredAt: now, RetiredBy: editorID})
+	r.Pattern = pattern
+	r.UpdatedAt = now
+	r.LastActionBy = &editorID
+	return nil
+}
+
+// Query Methods
+
+// Patterns returns the current pattern followed by retired ones, newest first
+func (r *CategoryRoute) Patterns() []Pattern {
+	patterns := []Pattern{r.Pattern}
+	for i := len(r.Retired) - 1; i >= 0; i-- {
+		patterns = append(patterns, r.Retired[i].Pattern)
+	}
+	return patterns
+}
@@ -0,0 +1,19 @@
+package permalink
+
+import "context"
+
+type CategoryRouteRepository interface {
+	// Commands
+	Save(ctx context.Context, route *CategoryRoute) error
+
+	// Queries
+	FindByCategoryID(ctx context.Context, categoryID string) (*CategoryRoute, error)
+	FindByCategorySlug(ctx context.Context, categorySlug string) (*CategoryRoute, error)
+	FindAll(ctx context.Context) ([]*CategoryRoute, error)
+}
+
+// ArticleLookup resolves matched identifiers to published articles (implemented by the article module)
+type ArticleLookup interface {
+	FindPublishedByID(ctx context.Context, id string) (*ArticleRef, error)
+	FindPublishedBySlug(ctx context.Context, slug string) (*ArticleRef, error)
+}
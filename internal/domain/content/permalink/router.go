@@ -0,0 +1,128 @@
+package permalink
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNotFound = errors.New("no article matches the requested path")
+
+// Resolution is the outcome of routing an inbound path
+type Resolution struct {
+	Article  ArticleRef
+	Redirect string // Canonical path to 301 to, empty when the request is already canonical
+}
+
+func (r Resolution) IsRedirect() bool {
+	return r.Redirect != ""
+}
+
+// Router generates canonical article paths and routes inbound paths back to articles,
+// redirecting links built from outdated patterns
+type Router struct {
+	routes   map[string]*CategoryRoute // By category slug
+	fallback Pattern
+	articles ArticleLookup
+}
+
+func NewRouter(routes []*CategoryRoute, fallback Pattern, articles ArticleLookup) *Router {
+	bySlug := make(map[string]*CategoryRoute, len(routes))
+	for _, route := range routes {
+		bySlug[route.CategorySlug] = route
+	}
+	return &Router{routes: bySlug, fallback: fallback, articles: articles}
+}
+
+// Path returns the canonical path of an article
+func (r *Router) Path(ref ArticleRef) (string, error) {
+	return r.patternFor(ref.CategorySlug).Build(ref)
+}
+
+// Resolve routes an inbound path. Candidates come from every known pattern,
+// current ones first, and are confirmed against the article store.
+func (r *Router) Resolve(ctx context.Context, path string) (*Resolution, error) {
+	for _, pattern := range r.candidatePatterns() {
+		match, ok := pattern.Match(path)
+		if !ok {
+			continue
+		}
+
+		ref, err := r.lookup(ctx, match)
+		if err != nil {
+			return nil, err
+		}
+		if ref == nil || !r.consistent(match, ref) {
+			continue
+		}
+
+		canonical, err := r.Path(*ref)
+		if err != nil {
+			return nil, err
+		}
+		res := &Resolution{Article: *ref}
+		if canonical != trimTrailingSlash(path) {
+			res.Redirect = canonical
+		}
+		return res, nil
+	}
+	return nil, ErrNotFound
+}
+
+func (r *Router) patternFor(categorySlug string) Pattern {
+	if route, ok := r.routes[categorySlug]; ok {
+		return route.Pattern
+	}
+	return r.fallback
+}
+
+func (r *Router) candidatePatterns() []Pattern {
+	seen := map[string]bool{}
+	var current, retired []Pattern
+	add := func(list *[]Pattern, p Pattern) {
+		if !seen[p.Value()] {
+			seen[p.Value()] = true
+			*list = append(*list, p)
+		}
+	}
+
+	add(&current, r.fallback)
+	for _, route := range r.routes {
+		add(&current, route.Pattern)
+	}
+	for _, route := range r.routes {
+		for _, p := range route.Patterns()[1:] {
+			add(&retired, p)
+		}
+	}
+	return append(current, retired...)
+}
+
+func (r *Router) lookup(ctx context.Context, match *Match) (*ArticleRef, error) {
+	if match.ID != "" {
+		return r.articles.FindPublishedByID(ctx, match.ID)
+	}
+	return r.articles.FindPublishedBySlug(ctx, match.Slug)
+}
+
+// consistent guards against a path matching a pattern by accident, e.g. the
+// category segment of one pattern being read as the slug of another. Paths
+// carrying the ID are trusted, a stale slug in them just leads to a redirect.
+func (r *Router) consistent(match *Match, ref *ArticleRef) bool {
+	if match.ID != "" {
+		return true
+	}
+	if match.Slug != "" && match.Slug != ref.Slug {
+		return false
+	}
+	if match.CategorySlug != "" && match.CategorySlug != ref.CategorySlug {
+		return false
+	}
+	return true
+}
+
+func trimTrailingSlash(path string) string {
+	if len(path) > 1 && path[len(path)-1] == '/' {
+		return path[:len(path)-1]
+	}
+	return path
+}
@@ -0,0 +1,116 @@
+package permalink
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type stubArticleLookup struct {
+	articles []ArticleRef
+}
+
+func (s *stubArticleLookup) FindPublishedByID(ctx context.Context, id string) (*ArticleRef, error) {
+	for _, a := range s.articles {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *stubArticleLookup) FindPublishedBySlug(ctx context.Context, slug string) (*ArticleRef, error) {
+	for _, a := range s.articles {
+		if a.Slug == slug {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestRouter(t *testing.T) {
+	fallback, _ := NewPattern(DefaultTemplate)
+	short, _ := NewPattern("/{slug}-{id}")
+
+	sportsRoute, err := NewCategoryRoute("cat-sports", "sports", *fallback, "admin1")
+	if err != nil {
+		t.Fatalf("failed to create route: %v", err)
+	}
+	if err := sportsRoute.ChangePattern(*short, "admin1"); err != nil {
+		t.Fatalf("failed to change pattern: %v", err)
+	}
+
+	published := time.Date(2026, 2, 5, 9, 0, 0, 0, time.UTC)
+	lookup := &stubArticleLookup{articles: []ArticleRef{
+		{ID: "s1", Slug: "derby-recap", CategorySlug: "sports", PublishedAt: published},
+		{ID: "m1", Slug: "flood-warning", CategorySlug: "metro", PublishedAt: published},
+	}}
+	router := NewRouter([]*CategoryRoute{sportsRoute}, *fallback, lookup)
+
+	path, err := router.Path(lookup.articles[0])
+	if err != nil || path != "/derby-recap-s1" {
+		t.Errorf("expected sports path '/derby-recap-s1', got '%s' (%v)", path, err)
+	}
+
+	testCases := []struct {
+		name             string
+		path             string
+		expectedArticle  string
+		expectedRedirect string
+		expectNotFound   bool
+	}{
+		{"canonical custom pattern", "/derby-recap-s1", "s1", "", false},
+		{"canonical default pattern", "/metro/2026/02/flood-warning", "m1", "", false},
+		{"retired pattern redirects", "/sports/2026/02/derby-recap", "s1", "/derby-recap-s1", false},
+		{"stale slug with id redirects", "/old-headline-s1", "s1", "/derby-recap-s1", false},
+		{"wrong category is not found", "/sports/2026/02/flood-warning", "", "", true},
+		{"unknown path", "/nothing/here", "", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := router.Resolve(context.Background(), tc.path)
+			if tc.expectNotFound {
+				if err != ErrNotFound {
+					t.Errorf("expected error '%v', got '%v'", ErrNotFound, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Article.ID != tc.expectedArticle {
+				t.Errorf("expected article %s, got %s", tc.expectedArticle, res.Article.ID)
+			}
+			if res.Redirect != tc.expectedRedirect {
+				t.Errorf("expected redirect '%s', got '%s'", tc.expectedRedirect, res.Redirect)
+			}
+		})
+	}
+}
+
+func TestCategoryRoute_ChangePattern(t *testing.T) {
+	first, _ := NewPattern(DefaultTemplate)
+	second, _ := NewPattern("/{slug}-{id}")
+
+	route, _ := NewCategoryRoute("cat-1", "metro", *first, "admin1")
+	if err := route.ChangePattern(*first, "admin1"); err == nil {
+		t.Error("expected error changing to the same pattern")
+	}
+	if err := route.ChangePattern(*second, ""); err == nil {
+		t.Error("expected error for empty editor")
+	}
+
+	_ = route.ChangePattern(*second, "admin1")
+	_ = route.ChangePattern(*first, "admin2")
+
+	if !route.Pattern.Equals(*first) {
+		t.Error("expected first pattern to be current again")
+	}
+	if len(route.Retired) != 1 || !route.Retired[0].Pattern.Equals(*second) {
+		t.Errorf("expected only the second pattern to be retired, got %v", route.Retired)
+	}
+	if len(route.Patterns()) != 2 {
+		t.Errorf("expected 2 patterns, got %d", len(route.Patterns()))
+	}
+}
@@ -0,0 +1,212 @@
+package permalink
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Supported template tokens
+const (
+	TokenCategory = "category"
+	TokenYear     = "yyyy"
+	TokenMonth    = "mm"
+	TokenDay      = "dd"
+	TokenSlug     = "slug"
+	TokenID       = "id"
+)
+
+// DefaultTemplate is used for categories without a custom pattern
+const DefaultTemplate = "/{category}/{yyyy}/{mm}/{slug}"
+
+var (
+	tokenRegex   = regexp.MustCompile(`\{([a-z]+)\}`)
+	literalRegex = regexp.MustCompile(`^[a-z0-9/_.-]*$`)
+
+	tokenPatterns = map[string]string{
+		TokenCategory: `[a-z0-9]+(?:-[a-z0-9]+)*`,
+		TokenYear:     `[0-9]{4}`,
+		TokenMonth:    `[0-9]{2}`,
+		TokenDay:      `[0-9]{2}`,
+		TokenSlug:     `[a-z0-9]+(?:-[a-z0-9]+)*`,
+		TokenID:       `[A-Za-z0-9_]+`,
+	}
+)
+
+// Domain errors
+var (
+	ErrTemplateEmpty           = errors.New("URL template cannot be empty")
+	ErrTemplateMustStartSlash  = errors.New("URL template must start with '/'")
+	ErrTemplateUnknownToken    = errors.New("URL template contains an unknown token")
+	ErrTemplateDuplicateToken  = errors.New("URL template contains a duplicate token")
+	ErrTemplateMissingIdentity = errors.New("URL template must contain {slug} or {id}")
+	ErrTemplateInvalidLiteral  = errors.New("URL template literals may only contain lowercase letters, numbers, '-', '_', '.' and '/'")
+	ErrTemplateAdjacentTokens  = errors.New("URL template tokens must be separated by a literal")
+)
+
+// Pattern value object, a compiled URL template such as /{category}/{yyyy}/{mm}/{slug}
+type Pattern struct {
+	template string
+	tokens   []string
+	matcher  *regexp.Regexp
+}
+
+func NewPattern(template string) (*Pattern, error) {
+	template = strings.TrimSpace(template)
+
+	if template == "" {
+		return nil, ErrTemplateEmpty
+	}
+	if !strings.HasPrefix(template, "/") {
+		return nil, ErrTemplateMustStartSlash
+	}
+
+	var (
+		expr   strings.Builder
+		tokens []string
+		seen   = map[string]bool{}
+		last   = 0
+	)
+	expr.WriteString("^")
+	for _, loc := range tokenRegex.FindAllStringSubmatchIndex(template, -1) {
+		literal := template[last:loc[0]]
+		if !literalRegex.MatchString(literal) {
+			return nil, ErrTemplateInvalidLiteral
+		}
+		if literal == "" && len(tokens) > 0 {
+			return nil, ErrTemplateAdjacentTokens
+		}
+		expr.WriteString(regexp.QuoteMeta(literal))
+
+		token := template[loc[2]:loc[3]]
+		sub, ok := tokenPatterns[token]
+		if !ok {
+			return nil, ErrTemplateUnknownToken
+		}
+		if seen[token] {
+			return nil, ErrTemplateDuplicateToken
+		}
+		seen[token] = true
+		tokens = append(tokens, token)
+		expr.WriteString("(" + sub + ")")
+		last = loc[1]
+	}
+
+	tail := template[last:]
+	if !literalRegex.MatchString(tail) {
+		return nil, ErrTemplateInvalidLiteral
+	}
+	expr.WriteString(regexp.QuoteMeta(tail))
+	expr.WriteString("/?$")
+
+	if !seen[TokenSlug] && !seen[TokenID] {
+		return nil, ErrTemplateMissingIdentity
+	}
+
+	return &Pattern{template: template, tokens: tokens, matcher: regexp.MustCompile(expr.String())}, nil
+}
+
+func (p Pattern) String() string {
+	return p.template
+}
+
+func (p Pattern) Value() string {
+	return p.template
+}
+
+func (p Pattern) Equals(other Pattern) bool {
+	return p.template == other.template
+}
+
+func (p Pattern) HasToken(token string) bool {
+	for _, t := range p.tokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// Build renders the path for an article
+func (p Pattern) Build(ref ArticleRef) (string, error) {
+	var err error
+	path := tokenRegex.ReplaceAllStringFunc(p.template, func(match string) string {
+		switch strings.Trim(match, "{}") {
+		case TokenCategory:
+			if ref.CategorySlug == "" {
+				err = errors.New("article has no category")
+			}
+			return ref.CategorySlug
+		case TokenYear:
+			return fmt.Sprintf("%04d", ref.PublishedAt.Year())
+		case TokenMonth:
+			return fmt.Sprintf("%02d", int(ref.PublishedAt.Month()))
+		case TokenDay:
+			return fmt.Sprintf("%02d", ref.PublishedAt.Day())
+		case TokenSlug:
+			if ref.Slug == "" {
+				err = errors.New("article has no slug")
+			}
+			return ref.Slug
+		case TokenID:
+			if ref.ID == "" {
+				err = errors.New("article has no ID")
+			}
+			return ref.ID
+		}
+		return match
+	})
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Match extracts the token values from an inbound path
+func (p Pattern) Match(path string) (*Match, bool) {
+	groups := p.matcher.FindStringSubmatch(path)
+	if groups == nil {
+		return nil, false
+	}
+
+	m := &Match{Pattern: p}
+	for i, token := range p.tokens {
+		value := groups[i+1]
+		switch token {
+		case TokenCategory:
+			m.CategorySlug = value
+		case TokenYear:
+			m.Year = value
+		case TokenMonth:
+			m.Month = value
+		case TokenDay:
+			m.Day = value
+		case TokenSlug:
+			m.Slug = value
+		case TokenID:
+			m.ID = value
+		}
+	}
+	return m, true
+}
+
+// ArticleRef carries the article attributes a permalink is built from
+type ArticleRef struct {
+	ID           string
+	Slug         string
+	CategorySlug string
+	PublishedAt  time.Time
+}
+
+// Match is the result of matching an inbound path against a pattern
+type Match struct {
+	Pattern      Pattern
+	CategorySlug string
+	Year         string
+	Month        string
+	Day          string
+	Slug         string
+	ID           string
+}
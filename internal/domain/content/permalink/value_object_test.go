@@ -0,0 +1,95 @@
+package permalink
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPattern(t *testing.T) {
+	testCases := []struct {
+		name        string
+		template    string
+		expectedErr error
+	}{
+		{"valid default", DefaultTemplate, nil},
+		{"valid slug with id", "/{slug}-{id}", nil},
+		{"valid with literal prefix", "/news/{yyyy}/{mm}/{dd}/{id}", nil},
+		{"valid with suffix", "/{category}/{slug}.html", nil},
+		{"invalid - empty", "  ", ErrTemplateEmpty},
+		{"invalid - no leading slash", "{slug}", ErrTemplateMustStartSlash},
+		{"invalid - unknown token", "/{author}/{slug}", ErrTemplateUnknownToken},
+		{"invalid - duplicate token", "/{slug}/{slug}", ErrTemplateDuplicateToken},
+		{"invalid - no identity", "/{category}/{yyyy}", ErrTemplateMissingIdentity},
+		{"invalid - uppercase literal", "/News/{slug}", ErrTemplateInvalidLiteral},
+		{"invalid - unbalanced brace", "/{slug}/{", ErrTemplateInvalidLiteral},
+		{"invalid - adjacent tokens", "/{slug}{id}", ErrTemplateAdjacentTokens},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := NewPattern(tc.template)
+			if tc.expectedErr != nil {
+				if err != tc.expectedErr {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.String() != tc.template {
+				t.Errorf("expected template '%s', got '%s'", tc.template, p.String())
+			}
+		})
+	}
+}
+
+func TestPattern_BuildAndMatch(t *testing.T) {
+	ref := ArticleRef{
+		ID:           "a1b2c3",
+		Slug:         "flood-warning-in-jakarta",
+		CategorySlug: "metro",
+		PublishedAt:  time.Date(2026, 2, 5, 9, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		{DefaultTemplate, "/metro/2026/02/flood-warning-in-jakarta"},
+		{"/{slug}-{id}", "/flood-warning-in-jakarta-a1b2c3"},
+		{"/news/{yyyy}/{mm}/{dd}/{id}", "/news/2026/02/05/a1b2c3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.template, func(t *testing.T) {
+			p, _ := NewPattern(tc.template)
+			path, err := p.Build(ref)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if path != tc.expected {
+				t.Errorf("expected path '%s', got '%s'", tc.expected, path)
+			}
+
+			match, ok := p.Match(path + "/")
+			if !ok {
+				t.Fatalf("expected built path to match its own pattern")
+			}
+			if p.HasToken(TokenSlug) && match.Slug != ref.Slug {
+				t.Errorf("expected slug '%s', got '%s'", ref.Slug, match.Slug)
+			}
+			if p.HasToken(TokenID) && match.ID != ref.ID {
+				t.Errorf("expected id '%s', got '%s'", ref.ID, match.ID)
+			}
+		})
+	}
+
+	p, _ := NewPattern(DefaultTemplate)
+	if _, ok := p.Match("/metro/26/02/flood"); ok {
+		t.Error("expected malformed year not to match")
+	}
+	if _, err := p.Build(ArticleRef{Slug: "x", PublishedAt: time.Now()}); err == nil {
+		t.Error("expected error building a path without category")
+	}
+}
@@ -0,0 +1,160 @@
+package region
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Region struct {
+	Code      Code
+	Name      string
+	Subdomain *string // e.g. "jabar" for jabar.example.com
+
+	// GeoIP subdivisions mapped to this region, e.g. "ID-JB"
+	Subdivisions []string
+
+	IsActive bool
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewRegion(code, name string, subdomain *string, subdivisions []string) (*Region, error) {
+	codeObj, err := NewCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if codeObj.IsNational() {
+		return nil, errors.New("national edition is built in and cannot be created")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+
+	if subdomain != nil {
+		normalized := strings.TrimSpace(strings.ToLower(*subdomain))
+		if _, err := NewCode(normalized); err != nil {
+			return nil, errors.New("invalid subdomain")
+		}
+		subdomain = &normalized
+	}
+
+	normalizedSubdivisions := make([]string, 0, len(subdivisions))
+	for _, s := range subdivisions {
+		s = strings.TrimSpace(strings.ToUpper(s))
+		if s == "" {
+			return nil, errors.New("subdivision code cannot be empty")
+		}
+		normalizedSubdivisions = append(normalizedSubdivisions, s)
+	}
+
+	now := time.Now()
+	return &Region{
+		Code:         *codeObj,
+		Name:         name,
+		Subdomain:    subdomain,
+		Subdivisions: normalizedSubdivisions,
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Business Methods
+
+func (r *Region) Deactivate() error {
+	if !r.IsActive {
+		return errors.New("region is already inactive")
+	}
+	r.IsActive = false
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *Region) Activate() error {
+	if r.IsActive {
+		return errors.New("region is already active")
+	}
+	r.IsActive = true
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Query Methods
+
+func (r *Region) CoversSubdivision(subdivision string) bool {
+	subdivision = strings.ToUpper(subdivision)
+	for _, s := range r.Subdivisions {
+		if s == subdivision {
+			return true
+		}
+	}
+	return false
+}
+
+// Pin keeps an article at a fixed position at the top of a regional listing
+type Pin struct {
+	ID        string
+	Region    Code
+	ArticleID string
+	Position  int // 1-based slot in the regional listing
+	PinnedBy  string
+	ExpiresAt *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewPin(id string, region Code, articleID string, position int, pinnedBy string, expiresAt *time.Time) (*Pin, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(articleID) == "" {
+		return nil, errors.New("article ID cannot be empty")
+	}
+	if position < 1 {
+		return nil, errors.New("position must be at least 1")
+	}
+	if strings.TrimSpace(pinnedBy) == "" {
+		return nil, errors.New("pinnedBy cannot be empty")
+	}
+
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	return &Pin{
+		ID:        id,
+		Region:    region,
+		ArticleID: articleID,
+		Position:  position,
+		PinnedBy:  pinnedBy,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Move changes the slot a pinned article occupies
+func (p *Pin) Move(position int, editorID string) error {
+	if position < 1 {
+		return errors.New("position must be at least 1")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return errors.New("editor ID cannot be empty")
+	}
+	p.Position = position
+	p.PinnedBy = editorID
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Pin) IsExpired(at time.Time) bool {
+	return p.ExpiresAt != nil && !at.Before(*p.ExpiresAt)
+}
@@ -0,0 +1,109 @@
+package region
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRegion(t *testing.T) {
+	badSub := "Bad Sub"
+
+	tests := []struct {
+		name      string
+		code      string
+		regName   string
+		subdomain *string
+		wantErr   bool
+		errMsg    string
+	}{
+		{name: "valid region", code: "Jabar", regName: "Jawa Barat", wantErr: false},
+		{name: "invalid code", code: "j", regName: "Jawa Barat", wantErr: true, errMsg: ErrInvalidRegionCode.Error()},
+		{name: "national is reserved", code: "national", regName: "National", wantErr: true, errMsg: "national edition is built in and cannot be created"},
+		{name: "empty name", code: "jabar", regName: " ", wantErr: true, errMsg: "name cannot be empty"},
+		{name: "invalid subdomain", code: "jabar", regName: "Jawa Barat", subdomain: &badSub, wantErr: true, errMsg: "invalid subdomain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRegion(tt.code, tt.regName, tt.subdomain, []string{"id-jb"})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if r.Code.Value() != "jabar" {
+				t.Errorf("expected code jabar, got %s", r.Code.Value())
+			}
+			if !r.CoversSubdivision("ID-JB") {
+				t.Error("expected region to cover ID-JB")
+			}
+		})
+	}
+}
+
+func TestPrioritize(t *testing.T) {
+	jabar, _ := NewCode("jabar")
+	jakarta, _ := NewCode("jakarta")
+	now := time.Now()
+
+	items := []Item{
+		{ArticleID: "national-old", PublishedAt: now.Add(-3 * time.Hour)},
+		{ArticleID: "jabar-new", Regions: []Code{*jabar}, PublishedAt: now.Add(-1 * time.Hour)},
+		{ArticleID: "national-new", PublishedAt: now.Add(-30 * time.Minute)},
+		{ArticleID: "jakarta-only", Regions: []Code{*jakarta}, PublishedAt: now},
+		{ArticleID: "jabar-old", Regions: []Code{*jabar}, PublishedAt: now.Add(-5 * time.Hour)},
+	}
+
+	pin, err := NewPin("pin-1", *jabar, "national-old", 1, "editor1", nil)
+	if err != nil {
+		t.Fatalf("failed to create pin: %v", err)
+	}
+	expired, _ := NewPin("pin-2", *jabar, "national-new", 2, "editor1", nil)
+	past := now.Add(-time.Minute)
+	expired.ExpiresAt = &past
+
+	got := Prioritize(items, *jabar, []*Pin{pin, expired}, now)
+	expected := []string{"national-old", "jabar-new", "jabar-old", "national-new"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d items, got %d", len(expected), len(got))
+	}
+	for i, id := range expected {
+		if got[i].ArticleID != id {
+			t.Errorf("position %d: expected %s, got %s", i, id, got[i].ArticleID)
+		}
+	}
+
+	national := Prioritize(items, NationalCode(), nil, now)
+	if len(national) != 2 || national[0].ArticleID != "national-new" {
+		t.Errorf("expected national edition to list national coverage only, got %v", national)
+	}
+}
+
+func TestNewPin(t *testing.T) {
+	jabar, _ := NewCode("jabar")
+	past := time.Now().Add(-time.Hour)
+
+	if _, err := NewPin("pin-1", *jabar, "art-1", 0, "editor1", nil); err == nil {
+		t.Error("expected error for position 0")
+	}
+	if _, err := NewPin("pin-1", *jabar, "art-1", 1, "editor1", &past); err == nil {
+		t.Error("expected error for expiry in the past")
+	}
+
+	pin, err := NewPin("pin-1", *jabar, "art-1", 1, "editor1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := pin.Move(3, "editor2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pin.Position != 3 || pin.PinnedBy != "editor2" {
+		t.Error("expected pin to move to position 3")
+	}
+}
@@ -0,0 +1,79 @@
+package region
+
+import (
+	"sort"
+	"time"
+)
+
+// Item is a listing entry that can be ranked for a regional edition
+type Item struct {
+	ArticleID   string
+	Regions     []Code // Empty means national coverage
+	PublishedAt time.Time
+}
+
+func (i Item) BelongsTo(region Code) bool {
+	for _, r := range i.Regions {
+		if r.Equals(region) {
+			return true
+		}
+	}
+	return false
+}
+
+// Prioritize orders a listing for the given edition: active pins in their slots,
+// then regional stories, then national coverage, each group newest first.
+// Stories tagged only for other regions are left out.
+func Prioritize(items []Item, region Code, pins []*Pin, at time.Time) []Item {
+	byArticle := make(map[string]Item, len(items))
+	for _, item := range items {
+		byArticle[item.ArticleID] = item
+	}
+
+	pinned := make([]*Pin, 0, len(pins))
+	pinnedIDs := make(map[string]bool)
+	for _, p := range pins {
+		if !p.Region.Equals(region) || p.IsExpired(at) {
+			continue
+		}
+		if _, ok := byArticle[p.ArticleID]; !ok {
+			continue
+		}
+		pinned = append(pinned, p)
+		pinnedIDs[p.ArticleID] = true
+	}
+	sort.SliceStable(pinned, func(a, b int) bool {
+		return pinned[a].Position < pinned[b].Position
+	})
+
+	var regional, national []Item
+	for _, item := range items {
+		if pinnedIDs[item.ArticleID] {
+			continue
+		}
+		switch {
+		case !region.IsNational() && item.BelongsTo(region):
+			regional = append(regional, item)
+		case len(item.Regions) == 0 || item.BelongsTo(NationalCode()):
+			national = append(national, item)
+		}
+	}
+
+	newestFirst := func(list []Item) {
+		sort.SliceStable(list, func(a, b int) bool {
+			return list[a].PublishedAt.After(list[b].PublishedAt)
+		})
+	}
+	newestFirst(regional)
+	newestFirst(national)
+
+	result := append(regional, national...)
+	for _, p := range pinned {
+		slot := p.Position - 1
+		if slot > len(result) {
+			slot = len(result)
+		}
+		result = append(result[:slot], append([]Item{byArticle[p.ArticleID]}, result[slot:]...)...)
+	}
+	return result
+}
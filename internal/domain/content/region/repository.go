@@ -0,0 +1,31 @@
+package region
+
+import (
+	"context"
+	"time"
+)
+
+type RegionRepository interface {
+	// Commands
+	Create(ctx context.Context, region *Region) error
+	Update(ctx context.Context, region *Region) error
+
+	// Queries
+	FindByCode(ctx context.Context, code string) (*Region, error)
+	FindBySubdomain(ctx context.Context, subdomain string) (*Region, error)
+	FindActive(ctx context.Context) ([]*Region, error)
+	ExistsByCode(ctx context.Context, code string) (bool, error)
+}
+
+type PinRepository interface {
+	// Commands
+	Create(ctx context.Context, pin *Pin) error
+	Update(ctx context.Context, pin *Pin) error
+	Delete(ctx context.Context, id string) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Pin, error)
+	FindActiveByRegion(ctx context.Context, region string, at time.Time) ([]*Pin, error)
+	FindByArticleID(ctx context.Context, articleID string) ([]*Pin, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
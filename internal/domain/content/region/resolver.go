@@ -0,0 +1,121 @@
+package region
+
+import (
+	"context"
+	"strings"
+)
+
+type Source string
+
+const (
+	SourceQuery     Source = "query"
+	SourceSubdomain Source = "subdomain"
+	SourceGeoIP     Source = "geoip"
+	SourceFallback  Source = "fallback"
+)
+
+// ResolutionInput carries the request attributes a region can be derived from
+type ResolutionInput struct {
+	Query    string // Explicit ?region= parameter
+	Host     string // Request host, used for subdomain editions
+	ClientIP string
+}
+
+// Resolution is the resolved edition and how it was determined
+type Resolution struct {
+	Region Code
+	Source Source
+}
+
+// Resolver determines the regional edition for a request.
+// Precedence is explicit query, then subdomain, then GeoIP, then national fallback.
+type Resolver struct {
+	regions    []*Region
+	baseDomain string
+	locator    GeoIPLocator // Optional
+}
+
+func NewResolver(regions []*Region, baseDomain string, locator GeoIPLocator) *Resolver {
+	active := make([]*Region, 0, len(regions))
+	for _, r := range regions {
+		if r.IsActive {
+			active = append(active, r)
+		}
+	}
+	return &Resolver{
+		regions:    active,
+		baseDomain: strings.TrimPrefix(strings.ToLower(baseDomain), "."),
+		locator:    locator,
+	}
+}
+
+func (r *Resolver) Resolve(ctx context.Context, in ResolutionInput) Resolution {
+	if code, ok := r.fromQuery(in.Query); ok {
+		return Resolution{Region: code, Source: SourceQuery}
+	}
+	if code, ok := r.fromHost(in.Host); ok {
+		return Resolution{Region: code, Source: SourceSubdomain}
+	}
+	if code, ok := r.fromGeoIP(ctx, in.ClientIP); ok {
+		return Resolution{Region: code, Source: SourceGeoIP}
+	}
+	return Resolution{Region: NationalCode(), Source: SourceFallback}
+}
+
+func (r *Resolver) fromQuery(query string) (Code, bool) {
+	code, err := NewCode(query)
+	if err != nil {
+		return Code{}, false
+	}
+	if code.IsNational() {
+		return *code, true
+	}
+	for _, region := range r.regions {
+		if region.Code.Equals(*code) {
+			return region.Code, true
+		}
+	}
+	return Code{}, false
+}
+
+func (r *Resolver) fromHost(host string) (Code, bool) {
+	if r.baseDomain == "" {
+		return Code{}, false
+	}
+
+	host = strings.ToLower(host)
+	if i := strings.LastIndex(host, ":"); i != -1 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+
+	suffix := "." + r.baseDomain
+	if !strings.HasSuffix(host, suffix) {
+		return Code{}, false
+	}
+	sub := strings.TrimSuffix(host, suffix)
+
+	for _, region := range r.regions {
+		if region.Subdomain != nil && *region.Subdomain == sub {
+			return region.Code, true
+		}
+	}
+	return Code{}, false
+}
+
+func (r *Resolver) fromGeoIP(ctx context.Context, ip string) (Code, bool) {
+	if r.locator == nil || strings.TrimSpace(ip) == "" {
+		return Code{}, false
+	}
+
+	location, err := r.locator.Locate(ctx, ip)
+	if err != nil || location == nil || location.SubdivisionCode == "" {
+		return Code{}, false
+	}
+
+	for _, region := range r.regions {
+		if region.CoversSubdivision(location.SubdivisionCode) {
+			return region.Code, true
+		}
+	}
+	return Code{}, false
+}
@@ -0,0 +1,59 @@
+package region
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type stubLocator struct {
+	location *GeoLocation
+	err      error
+}
+
+func (s *stubLocator) Locate(ctx context.Context, ip string) (*GeoLocation, error) {
+	return s.location, s.err
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	jabarSub := "jabar"
+	jabar, _ := NewRegion("jabar", "Jawa Barat", &jabarSub, []string{"ID-JB"})
+	jakarta, _ := NewRegion("jakarta", "DKI Jakarta", nil, []string{"id-jk"})
+	bali, _ := NewRegion("bali", "Bali", nil, []string{"ID-BA"})
+	_ = bali.Deactivate()
+
+	locator := &stubLocator{location: &GeoLocation{CountryCode: "ID", SubdivisionCode: "ID-JK"}}
+	resolver := NewResolver([]*Region{jabar, jakarta, bali}, "example.com", locator)
+
+	testCases := []struct {
+		name           string
+		input          ResolutionInput
+		expectedRegion string
+		expectedSource Source
+	}{
+		{"query wins over subdomain", ResolutionInput{Query: "jakarta", Host: "jabar.example.com"}, "jakarta", SourceQuery},
+		{"explicit national query", ResolutionInput{Query: "national", Host: "jabar.example.com"}, "national", SourceQuery},
+		{"unknown query falls through", ResolutionInput{Query: "mars", Host: "jabar.example.com"}, "jabar", SourceSubdomain},
+		{"subdomain with port", ResolutionInput{Host: "jabar.example.com:8080"}, "jabar", SourceSubdomain},
+		{"geoip subdivision", ResolutionInput{Host: "www.example.com", ClientIP: "203.0.113.5"}, "jakarta", SourceGeoIP},
+		{"inactive region ignored", ResolutionInput{Query: "bali", ClientIP: "203.0.113.5"}, "jakarta", SourceGeoIP},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resolver.Resolve(context.Background(), tc.input)
+			if got.Region.Value() != tc.expectedRegion {
+				t.Errorf("expected region %s, got %s", tc.expectedRegion, got.Region.Value())
+			}
+			if got.Source != tc.expectedSource {
+				t.Errorf("expected source %s, got %s", tc.expectedSource, got.Source)
+			}
+		})
+	}
+
+	failing := NewResolver([]*Region{jabar}, "example.com", &stubLocator{err: errors.New("lookup failed")})
+	got := failing.Resolve(context.Background(), ResolutionInput{ClientIP: "203.0.113.5"})
+	if !got.Region.IsNational() || got.Source != SourceFallback {
+		t.Errorf("expected national fallback, got %s from %s", got.Region.Value(), got.Source)
+	}
+}
@@ -0,0 +1,66 @@
+package region
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var codeRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{1,31}$`)
+
+// National is the fallback edition used when no region can be resolved
+const National = "national"
+
+// Domain errors
+var (
+	ErrInvalidRegionCode = errors.New("region code must be 2-32 lowercase letters, numbers or dashes")
+)
+
+// Code value object, identifies a regional edition (e.g. "jabar", "jakarta")
+type Code struct {
+	value string
+}
+
+func NewCode(value string) (*Code, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+
+	if !codeRegex.MatchString(value) {
+		return nil, ErrInvalidRegionCode
+	}
+
+	return &Code{value: value}, nil
+}
+
+// NationalCode returns the code of the national edition
+func NationalCode() Code {
+	return Code{value: National}
+}
+
+func (c Code) String() string {
+	return c.value
+}
+
+func (c Code) Value() string {
+	return c.value
+}
+
+func (c Code) IsNational() bool {
+	return c.value == National
+}
+
+func (c Code) Equals(other Code) bool {
+	return c.value == other.value
+}
+
+// GeoLocation is the result of a GeoIP lookup
+type GeoLocation struct {
+	CountryCode     string // ISO 3166-1 alpha-2
+	SubdivisionCode string // ISO 3166-2 subdivision, e.g. "ID-JB"
+	City            string
+}
+
+// Domain interface for GeoIP lookups (implementation will be in infrastructure layer)
+type GeoIPLocator interface {
+	Locate(ctx context.Context, ip string) (*GeoLocation, error)
+}
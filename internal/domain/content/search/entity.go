@@ -0,0 +1,165 @@
+package search
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var aliasRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// MaxErrorLength bounds the stored failure reason
+const MaxErrorLength = 1000
+
+type Status string
+
+const (
+	StatusPending   Status = "pending" // Requested, waiting for the worker
+	StatusIndexing  Status = "indexing"
+	StatusVerifying Status = "verifying" // Sampling the new index against the database
+	StatusCompleted Status = "completed" // The alias points at the new index
+	StatusFailed    Status = "failed"    // The alias was left on the previous index
+)
+
+// Domain errors
+var (
+	ErrInvalidAlias      = errors.New("alias must be lowercase words separated by dashes")
+	ErrEmptyRequester    = errors.New("requested by cannot be empty")
+	ErrReindexRunning    = errors.New("a reindex is already running")
+	ErrInvalidTransition = errors.New("reindex cannot move to this status")
+)
+
+// ReindexRun is one rebuild of a search index. Articles are written to a fresh
+// physical index while searches keep using the alias; the alias only moves to
+// the new index once verification passes, so a failed run changes nothing.
+type ReindexRun struct {
+	ID          string
+	Alias       string // What searches query, e.g. "articles"
+	Index       string // The physical index being built
+	Previous    string // The index the alias pointed at before, empty on the first build
+	RequestedBy string
+
+	Status     Status
+	Total      int // Published articles when indexing started
+	Indexed    int
+	Failed     int // Articles the backend rejected
+	Sampled    int
+	Mismatches int // Sampled articles missing or stale in the new index
+	LastError  *string
+
+	RequestedAt time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	UpdatedAt   time.Time
+}
+
+func NewReindexRun(id, alias, requestedBy string, at time.Time) (*ReindexRun, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if !aliasRegex.MatchString(alias) {
+		return nil, ErrInvalidAlias
+	}
+	if strings.TrimSpace(requestedBy) == "" {
+		return nil, ErrEmptyRequester
+	}
+
+	return &ReindexRun{
+		ID:          id,
+		Alias:       alias,
+		RequestedBy: requestedBy,
+		Status:      StatusPending,
+		RequestedAt: at,
+		UpdatedAt:   at,
+	}, nil
+}
+
+// IndexName names the physical index a run builds, unique per start time
+func IndexName(alias string, at time.Time) string {
+	return alias + "-" + strings.ToLower(at.UTC().Format("20060102t150405"))
+}
+
+// Business Methods
+
+func (r *ReindexRun) Start(index, previous string, total int, at time.Time) error {
+	if r.Status != StatusPending {
+		return ErrInvalidTransition
+	}
+	r.Status = StatusIndexing
+	r.Index = index
+	r.Previous = previous
+	r.Total = total
+	r.StartedAt = &at
+	r.UpdatedAt = at
+	return nil
+}
+
+// RecordBatch adds one bulk write to the progress
+func (r *ReindexRun) RecordBatch(indexed, failed int, at time.Time) {
+	r.Indexed += indexed
+	r.Failed += failed
+	r.UpdatedAt = at
+}
+
+func (r *ReindexRun) StartVerifying(at time.Time) error {
+	if r.Status != StatusIndexing {
+		return ErrInvalidTransition
+	}
+	r.Status = StatusVerifying
+	r.UpdatedAt = at
+	return nil
+}
+
+func (r *ReindexRun) RecordVerification(sampled, mismatches int, at time.Time) {
+	r.Sampled = sampled
+	r.Mismatches = mismatches
+	r.UpdatedAt = at
+}
+
+// Complete marks the alias as swapped to the new index
+func (r *ReindexRun) Complete(at time.Time) error {
+	if r.Status != StatusVerifying {
+		return ErrInvalidTransition
+	}
+	r.Status = StatusCompleted
+	r.FinishedAt = &at
+	r.UpdatedAt = at
+	return nil
+}
+
+func (r *ReindexRun) Fail(cause error, at time.Time) {
+	msg := cause.Error()
+	if len(msg) > MaxErrorLength {
+		msg = msg[:MaxErrorLength]
+	}
+	r.Status = StatusFailed
+	r.LastError = &msg
+	r.FinishedAt = &at
+	r.UpdatedAt = at
+}
+
+// Query Methods
+
+func (r *ReindexRun) IsFinished() bool {
+	return r.Status == StatusCompleted || r.Status == StatusFailed
+}
+
+// Progress is the share of articles written, 0 to 1
+func (r *ReindexRun) Progress() float64 {
+	if r.Total == 0 {
+		if r.Status == StatusPending || r.Status == StatusIndexing {
+			return 0
+		}
+		return 1
+	}
+	return min(float64(r.Indexed+r.Failed)/float64(r.Total), 1)
+}
+
+// FailureRatio is the share of written articles the backend rejected
+func (r *ReindexRun) FailureRatio() float64 {
+	if r.Indexed+r.Failed == 0 {
+		return 0
+	}
+	return float64(r.Failed) / float64(r.Indexed+r.Failed)
+}
@@ -0,0 +1,78 @@
+package search
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewReindexRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		alias       string
+		requestedBy string
+		wantErr     error
+	}{
+		{"valid", "articles", "staff-1", nil},
+		{"invalid alias", "Articles Index", "staff-1", ErrInvalidAlias},
+		{"no requester", "articles", " ", ErrEmptyRequester},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run, err := NewReindexRun("r1", tt.alias, tt.requestedBy, time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if err == nil && (run.Status != StatusPending || run.IsFinished()) {
+				t.Errorf("expected a pending run, got %+v", run)
+			}
+		})
+	}
+}
+
+func TestReindexRun_Lifecycle(t *testing.T) {
+	at := time.Date(2025, 9, 1, 2, 30, 0, 0, time.UTC)
+	run, _ := NewReindexRun("r1", "articles", "staff-1", at)
+
+	if err := run.StartVerifying(at); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTransition, err)
+	}
+	index := IndexName("articles", at)
+	if index != "articles-20250901t023000" {
+		t.Errorf("unexpected index name %s", index)
+	}
+	if err := run.Start(index, "articles-20250801t023000", 4, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	run.RecordBatch(2, 0, at)
+	run.RecordBatch(1, 1, at)
+	if run.Progress() != 1 || run.FailureRatio() != 0.25 {
+		t.Errorf("expected full progress with a quarter failed, got %v and %v", run.Progress(), run.FailureRatio())
+	}
+
+	if err := run.StartVerifying(at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	run.RecordVerification(3, 0, at)
+	if err := run.Complete(at); err != nil || !run.IsFinished() || run.FinishedAt == nil {
+		t.Errorf("expected a completed run, got %+v %v", run, err)
+	}
+}
+
+func TestReindexRun_Fail(t *testing.T) {
+	run, _ := NewReindexRun("r1", "articles", "staff-1", time.Now())
+	_ = run.Start("articles-new", "", 0, time.Now())
+	if run.Progress() != 0 {
+		t.Errorf("expected no progress while indexing an empty source, got %v", run.Progress())
+	}
+
+	run.Fail(errors.New("cluster unavailable"), time.Now())
+	if !run.IsFinished() || *run.LastError != "cluster unavailable" {
+		t.Errorf("expected a failed run, got %+v", run)
+	}
+	if err := run.Complete(time.Now()); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTransition, err)
+	}
+}
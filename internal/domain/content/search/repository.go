@@ -0,0 +1,27 @@
+package search
+
+import "context"
+
+// Domain interface for reindex runs (implementation will be in infrastructure layer)
+type ReindexRunRepository interface {
+	// Create returns ErrReindexRunning when the alias has an unfinished run
+	Create(ctx context.Context, run *ReindexRun) error
+	Update(ctx context.Context, run *ReindexRun) error
+
+	// Queries, nil when not found
+	FindByID(ctx context.Context, id string) (*ReindexRun, error)
+	// FindPending returns the oldest pending run of any alias
+	FindPending(ctx context.Context) (*ReindexRun, error)
+	FindRecent(ctx context.Context, alias string, limit int) ([]*ReindexRun, error)
+}
+
+// Domain interface for editor-managed synonyms (implementation will be in infrastructure layer)
+type SynonymRepository interface {
+	Create(ctx context.Context, set *SynonymSet) error
+	Update(ctx context.Context, set *SynonymSet) error
+	Delete(ctx context.Context, id string) error
+
+	// Queries, nil when not found
+	FindByID(ctx context.Context, id string) (*SynonymSet, error)
+	FindByLanguage(ctx context.Context, language string) ([]*SynonymSet, error)
+}
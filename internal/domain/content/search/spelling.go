@@ -0,0 +1,135 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MinCorrectableLength leaves short words alone, one edit turns most of them into another word
+	MinCorrectableLength = 4
+	// MinTermFrequency is how many articles must use a word before it counts as correctly
+	// spelt, rarer words are as likely to be typos that made it into print
+	MinTermFrequency = 3
+)
+
+// Vocabulary is the words of one language's published articles with the
+// number of articles using each, read from the search index
+type Vocabulary struct {
+	frequency map[string]int
+	byLength  map[int][]string
+}
+
+func NewVocabulary(frequency map[string]int) *Vocabulary {
+	v := &Vocabulary{frequency: make(map[string]int, len(frequency)), byLength: map[int][]string{}}
+	for word, n := range frequency {
+		word = strings.ToLower(word)
+		if n <= 0 || word == "" {
+			continue
+		}
+		if _, seen := v.frequency[word]; !seen {
+			length := utf8.RuneCountInString(word)
+			v.byLength[length] = append(v.byLength[length], word)
+		}
+		v.frequency[word] += n
+	}
+	return v
+}
+
+// Frequency returns how many articles use the word
+func (v *Vocabulary) Frequency(word string) int {
+	return v.frequency[word]
+}
+
+// Correct returns the likeliest intended word: the closest one by edit
+// distance, the more frequent on a tie. Known words, numbers and short words
+// are returned unchanged with false.
+func (v *Vocabulary) Correct(word string) (string, bool) {
+	length := utf8.RuneCountInString(word)
+	if length < MinCorrectableLength || v.frequency[word] >= MinTermFrequency || hasDigit(word) {
+		return word, false
+	}
+
+	maxDistance := 1
+	if length > 7 {
+		maxDistance = 2
+	}
+	best, bestDistance, bestFrequency := "", maxDistance+1, 0
+	for l := length - maxDistance; l <= length+maxDistance; l++ {
+		for _, candidate := range v.byLength[l] {
+			frequency := v.frequency[candidate]
+			if frequency < MinTermFrequency || frequency <= v.frequency[word] {
+				continue
+			}
+			d := editDistance(word, candidate, maxDistance)
+			if d > maxDistance {
+				continue
+			}
+			if d < bestDistance || (d == bestDistance && (frequency > bestFrequency || (frequency == bestFrequency && candidate < best))) {
+				best, bestDistance, bestFrequency = candidate, d, frequency
+			}
+		}
+	}
+	if best == "" {
+		return word, false
+	}
+	return best, true
+}
+
+// CorrectTerms corrects each term on its own and reports whether any changed
+func (v *Vocabulary) CorrectTerms(terms []string) ([]string, bool) {
+	corrected := make([]string, len(terms))
+	changed := false
+	for i, term := range terms {
+		var ok bool
+		corrected[i], ok = v.Correct(term)
+		changed = changed || ok
+	}
+	return corrected, changed
+}
+
+// Tokenize lowercases a query and splits it into words, punctuation separates words
+func Tokenize(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editDistance is the optimal string alignment distance, a swap of two
+// neighbouring letters counts as one edit. It gives up above limit and
+// returns limit+1.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return min(prev[len(rb)], limit+1)
+}
+
+func hasDigit(word string) bool {
+	return strings.IndexFunc(word, unicode.IsDigit) >= 0
+}
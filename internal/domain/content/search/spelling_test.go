@@ -0,0 +1,75 @@
+package search
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVocabulary_Correct(t *testing.T) {
+	v := NewVocabulary(map[string]int{
+		"pemilu":    120,
+		"pemilihan": 80,
+		"presiden":  300,
+		"president": 4,
+		"banjir":    95,
+		"banjar":    12,
+		"jakarta":   500,
+		"typo":      1,
+	})
+
+	tests := []struct {
+		name    string
+		word    string
+		want    string
+		changed bool
+	}{
+		{"known word", "jakarta", "jakarta", false},
+		{"one substitution", "pemulu", "pemilu", true},
+		{"swapped letters", "jakrata", "jakarta", true},
+		{"two edits on a long word", "presidan", "presiden", true},
+		{"tie goes to the frequent word", "banjor", "banjir", true},
+		{"short word left alone", "pmu", "pmu", false},
+		{"numbers left alone", "2024", "2024", false},
+		{"nothing close", "zzzzzz", "zzzzzz", false},
+		{"rare word with nothing close", "typo", "typo", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := v.Correct(tt.word)
+			if got != tt.want || changed != tt.changed {
+				t.Errorf("expected %q (%v), got %q (%v)", tt.want, tt.changed, got, changed)
+			}
+		})
+	}
+}
+
+func TestVocabulary_CorrectTerms(t *testing.T) {
+	v := NewVocabulary(map[string]int{"banjir": 95, "jakarta": 500})
+
+	corrected, changed := v.CorrectTerms(Tokenize("Banjr di JAKARTA!"))
+	if !changed || !slices.Equal(corrected, []string{"banjir", "di", "jakarta"}) {
+		t.Errorf("expected [banjir di jakarta], got %v (%v)", corrected, changed)
+	}
+	if _, changed := v.CorrectTerms([]string{"banjir", "jakarta"}); changed {
+		t.Error("expected known words unchanged")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"pemilu", "pemilu", 0},
+		{"pemilu", "pemulu", 1},
+		{"jakarta", "jakrata", 1},
+		{"pemilu", "pemilihan", 3}, // Above the limit of 2
+		{"kuda", "kudaa", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b, 2); got != tt.want {
+			t.Errorf("editDistance(%q, %q): expected %d, got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
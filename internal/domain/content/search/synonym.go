@@ -0,0 +1,133 @@
+package search
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var languageRegex = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+const (
+	MaxSynonymTerms = 20
+	MaxPhraseWords  = 3 // Longest term, e.g. "komisi pemilihan umum"
+)
+
+// Synonym errors
+var (
+	ErrInvalidLanguage = errors.New("language must be a two or three letter code")
+	ErrTooFewSynonyms  = errors.New("a synonym set needs at least two different terms")
+	ErrTooManySynonyms = errors.New("a synonym set cannot have more than 20 terms")
+	ErrPhraseTooLong   = errors.New("a synonym term cannot have more than 3 words")
+	ErrEmptyEditor     = errors.New("editor cannot be empty")
+)
+
+// SynonymSet is a group of terms editors made interchangeable in one
+// language's reader search, e.g. "kpu", "komisi pemilihan umum". A query
+// using any of them also matches articles using the others.
+type SynonymSet struct {
+	ID        string
+	Language  string
+	Terms     []string // Lowercase words or phrases, normalised like queries
+	UpdatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSynonymSet(id, language string, terms []string, editorID string, at time.Time) (*SynonymSet, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if !languageRegex.MatchString(language) {
+		return nil, ErrInvalidLanguage
+	}
+	set := &SynonymSet{ID: id, Language: language, CreatedAt: at}
+	if err := set.Redefine(terms, editorID, at); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Business Methods
+
+// Redefine replaces the terms, duplicates after normalisation count once
+func (s *SynonymSet) Redefine(terms []string, editorID string, at time.Time) error {
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyEditor
+	}
+	normalized := make([]string, 0, len(terms))
+	for _, term := range terms {
+		words := Tokenize(term)
+		if len(words) == 0 {
+			continue
+		}
+		if len(words) > MaxPhraseWords {
+			return ErrPhraseTooLong
+		}
+		if phrase := strings.Join(words, " "); !slices.Contains(normalized, phrase) {
+			normalized = append(normalized, phrase)
+		}
+	}
+	if len(normalized) < 2 {
+		return ErrTooFewSynonyms
+	}
+	if len(normalized) > MaxSynonymTerms {
+		return ErrTooManySynonyms
+	}
+
+	s.Terms = normalized
+	s.UpdatedBy = editorID
+	s.UpdatedAt = at
+	return nil
+}
+
+// SynonymIndex finds the synonym sets a query touches
+type SynonymIndex struct {
+	alternatives map[string][]string
+}
+
+// NewSynonymIndex merges the sets of one language, a term in two sets gets the terms of both
+func NewSynonymIndex(sets []*SynonymSet) *SynonymIndex {
+	idx := &SynonymIndex{alternatives: map[string][]string{}}
+	for _, set := range sets {
+		for _, term := range set.Terms {
+			for _, other := range set.Terms {
+				if other != term && !slices.Contains(idx.alternatives[term], other) {
+					idx.alternatives[term] = append(idx.alternatives[term], other)
+				}
+			}
+		}
+	}
+	return idx
+}
+
+// ExpandedTerm is one word or phrase of a query with its synonyms
+type ExpandedTerm struct {
+	Text     string
+	Synonyms []string
+}
+
+// Expand groups query words into terms, preferring the longest phrase with
+// synonyms at each position, so "komisi pemilihan umum" stays one term
+func (idx *SynonymIndex) Expand(words []string) []ExpandedTerm {
+	terms := make([]ExpandedTerm, 0, len(words))
+	for i := 0; i < len(words); {
+		matched := false
+		for n := min(MaxPhraseWords, len(words)-i); n > 1; n-- {
+			phrase := strings.Join(words[i:i+n], " ")
+			if alternatives, ok := idx.alternatives[phrase]; ok {
+				terms = append(terms, ExpandedTerm{Text: phrase, Synonyms: alternatives})
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			terms = append(terms, ExpandedTerm{Text: words[i], Synonyms: idx.alternatives[words[i]]})
+			i++
+		}
+	}
+	return terms
+}
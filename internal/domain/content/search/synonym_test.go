@@ -0,0 +1,59 @@
+package search
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNewSynonymSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		terms    []string
+		editor   string
+		wantErr  error
+	}{
+		{"valid", "id", []string{"KPU", "Komisi Pemilihan Umum"}, "editor-1", nil},
+		{"invalid language", "Bahasa", []string{"kpu", "komisi pemilihan umum"}, "editor-1", ErrInvalidLanguage},
+		{"duplicates collapse", "id", []string{"KPU", " kpu "}, "editor-1", ErrTooFewSynonyms},
+		{"phrase too long", "id", []string{"kpu", "komisi pemilihan umum republik indonesia"}, "editor-1", ErrPhraseTooLong},
+		{"no editor", "id", []string{"kpu", "komisi pemilihan umum"}, "", ErrEmptyEditor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := NewSynonymSet("s1", tt.language, tt.terms, tt.editor, time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if err == nil && !slices.Equal(set.Terms, []string{"kpu", "komisi pemilihan umum"}) {
+				t.Errorf("expected normalised terms, got %v", set.Terms)
+			}
+		})
+	}
+}
+
+func TestSynonymIndex_Expand(t *testing.T) {
+	at := time.Now()
+	kpu, _ := NewSynonymSet("s1", "id", []string{"kpu", "komisi pemilihan umum"}, "editor-1", at)
+	vote, _ := NewSynonymSet("s2", "id", []string{"pemilu", "pemilihan umum", "coblosan"}, "editor-1", at)
+	poll, _ := NewSynonymSet("s3", "id", []string{"pemilu", "pilpres"}, "editor-1", at)
+	idx := NewSynonymIndex([]*SynonymSet{kpu, vote, poll})
+
+	terms := idx.Expand(Tokenize("Komisi Pemilihan Umum jadwal pemilu"))
+	want := []ExpandedTerm{
+		{Text: "komisi pemilihan umum", Synonyms: []string{"kpu"}},
+		{Text: "jadwal"},
+		{Text: "pemilu", Synonyms: []string{"pemilihan umum", "coblosan", "pilpres"}},
+	}
+	if len(terms) != len(want) {
+		t.Fatalf("expected %d terms, got %+v", len(want), terms)
+	}
+	for i := range want {
+		if terms[i].Text != want[i].Text || !slices.Equal(terms[i].Synonyms, want[i].Synonyms) {
+			t.Errorf("term %d: expected %+v, got %+v", i, want[i], terms[i])
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package sensitivity
+
+import (
+	"errors"
+	"html"
+	"strings"
+	"time"
+)
+
+// Classification is the set of sensitive categories an editor marked on an article
+type Classification struct {
+	ArticleID  string
+	Categories []Category
+
+	LastActionBy *string
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+// NewClassification validates the categories and keeps them in display
+// order. An empty list clears the classification.
+func NewClassification(articleID string, categories []Category, editorID string, at time.Time) (*Classification, error) {
+	if strings.TrimSpace(articleID) == "" {
+		return nil, errors.New("articleID cannot be empty")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editorID cannot be empty")
+	}
+	selected := make(map[Category]struct{}, len(categories))
+	for _, c := range categories {
+		if !c.Valid() {
+			return nil, ErrInvalidCategory
+		}
+		selected[c] = struct{}{}
+	}
+
+	c := &Classification{ArticleID: articleID, LastActionBy: &editorID, UpdatedAt: at}
+	for _, category := range AllCategories {
+		if _, ok := selected[category]; ok {
+			c.Categories = append(c.Categories, category)
+		}
+	}
+	return c, nil
+}
+
+// Query Methods
+
+func (c *Classification) Sensitive() bool {
+	return len(c.Categories) > 0
+}
+
+func (c *Classification) Has(category Category) bool {
+	for _, existing := range c.Categories {
+		if existing == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Added lists the categories not in the previous classification, these
+// route the article to review again
+func (c *Classification) Added(previous *Classification) []Category {
+	var added []Category
+	for _, category := range c.Categories {
+		if previous == nil || !previous.Has(category) {
+			added = append(added, category)
+		}
+	}
+	return added
+}
+
+// Disclaimers returns the texts to show under the article
+func (c *Classification) Disclaimers(language string) []string {
+	texts := make([]string, 0, len(c.Categories))
+	for _, category := range c.Categories {
+		texts = append(texts, category.Disclaimer(language))
+	}
+	return texts
+}
+
+// AdPolicy is the strictest policy of the categories
+func (c *Classification) AdPolicy() AdPolicy {
+	policy := UnrestrictedAds()
+	for _, category := range c.Categories {
+		policy = policy.Restrict(category.AdPolicy())
+	}
+	return policy
+}
+
+// Render appends the disclaimers to the rendered article body as
+// <aside class="disclaimer"> blocks
+func (c *Classification) Render(body, language string) string {
+	if !c.Sensitive() {
+		return body
+	}
+	var b strings.Builder
+	b.WriteString(body)
+	for _, category := range c.Categories {
+		b.WriteString(`<aside class="disclaimer" data-sensitivity="`)
+		b.WriteString(string(category))
+		b.WriteString(`"><p>`)
+		b.WriteString(html.EscapeString(category.Disclaimer(language)))
+		b.WriteString("</p></aside>")
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package sensitivity
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClassification(t *testing.T) {
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	c, err := NewClassification("a1", []Category{CategoryElections, CategoryCourtCase, CategoryElections}, "editor-1", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.Categories) != 2 || c.Categories[0] != CategoryCourtCase || c.Categories[1] != CategoryElections {
+		t.Errorf("expected deduplicated categories in display order, got %v", c.Categories)
+	}
+
+	if _, err := NewClassification("a1", []Category{"gossip"}, "editor-1", at); err != ErrInvalidCategory {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidCategory, err)
+	}
+
+	cleared, err := NewClassification("a1", nil, "editor-1", at)
+	if err != nil || cleared.Sensitive() {
+		t.Errorf("expected empty classification, got %+v, %v", cleared, err)
+	}
+}
+
+func TestClassification_AdPolicy(t *testing.T) {
+	testCases := []struct {
+		name       string
+		categories []Category
+		expected   AdPolicy
+	}{
+		{"not sensitive", nil, AdPolicy{Ads: true, Personalized: true, Political: true}},
+		{"court case", []Category{CategoryCourtCase}, AdPolicy{Ads: true, Political: true}},
+		{"elections", []Category{CategoryElections}, AdPolicy{Ads: true}},
+		{"minors and elections", []Category{CategoryMinors, CategoryElections}, AdPolicy{Ads: true}},
+		{"suicide turns ads off", []Category{CategorySuicide, CategoryCourtCase}, AdPolicy{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Classification{ArticleID: "a1", Categories: tc.categories}
+			if got := c.AdPolicy(); got != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestClassification_Render(t *testing.T) {
+	c := &Classification{ArticleID: "a1", Categories: []Category{CategorySuicide}}
+
+	body := c.Render("<p>Isi berita</p>", "en")
+	if !strings.HasPrefix(body, "<p>Isi berita</p><aside class=\"disclaimer\" data-sensitivity=\"suicide\"><p>If you or someone") {
+		t.Errorf("expected English disclaimer after the body, got %s", body)
+	}
+	if got := c.Disclaimers("fr"); len(got) != 1 || !strings.HasPrefix(got[0], "Jika Anda") {
+		t.Errorf("expected fallback to Indonesian, got %v", got)
+	}
+	if got := (&Classification{}).Render("<p>x</p>", "id"); got != "<p>x</p>" {
+		t.Errorf("expected body unchanged, got %s", got)
+	}
+}
+
+func TestClassification_Added(t *testing.T) {
+	previous := &Classification{Categories: []Category{CategoryCourtCase}}
+	current := &Classification{Categories: []Category{CategoryCourtCase, CategoryMinors}}
+
+	if added := current.Added(previous); len(added) != 1 || added[0] != CategoryMinors {
+		t.Errorf("expected minors added, got %v", added)
+	}
+	if added := current.Added(nil); len(added) != 2 {
+		t.Errorf("expected every category added on first classification, got %v", added)
+	}
+}
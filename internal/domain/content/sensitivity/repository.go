@@ -0,0 +1,18 @@
+package sensitivity
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+type ClassificationRepository interface {
+	Save(ctx context.Context, classification *Classification) error
+	// FindByArticle returns nil when the article was never classified
+	FindByArticle(ctx context.Context, articleID string) (*Classification, error)
+}
+
+// ReviewRouter assigns articles to a reviewer role (implemented by the workflow engine)
+type ReviewRouter interface {
+	RouteForReview(ctx context.Context, articleID string, scope entitlement.Scope, categories []Category) error
+}
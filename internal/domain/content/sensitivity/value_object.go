@@ -0,0 +1,99 @@
+package sensitivity
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultLanguage is used for disclaimers when the article language has no text
+const DefaultLanguage = "id"
+
+// Domain errors
+var (
+	ErrInvalidCategory = errors.New("unknown sensitive-content category")
+)
+
+// Category is a sensitive topic that needs legal or standards review
+type Category string
+
+const (
+	CategoryCourtCase Category = "court_case" // Ongoing trials and criminal cases
+	CategoryMinors    Category = "minors"     // Children as victims, suspects or witnesses
+	CategorySuicide   Category = "suicide"    // Suicide and self-harm
+	CategoryElections Category = "elections"  // Candidates, parties and polling
+)
+
+// AllCategories is the display order
+var AllCategories = []Category{CategoryCourtCase, CategoryMinors, CategorySuicide, CategoryElections}
+
+func (c Category) Valid() bool {
+	for _, category := range AllCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// disclaimers are appended to the article body, keyed by language
+var disclaimers = map[Category]map[string]string{
+	CategoryCourtCase: {
+		"id": "Seluruh pihak yang disebut dalam berita ini dianggap tidak bersalah sampai ada putusan pengadilan yang berkekuatan hukum tetap.",
+		"en": "Everyone named in this report is presumed innocent until a final court ruling says otherwise.",
+	},
+	CategoryMinors: {
+		"id": "Identitas anak dalam berita ini disamarkan untuk melindungi kepentingan terbaik anak.",
+		"en": "The identity of children in this report has been withheld to protect them.",
+	},
+	CategorySuicide: {
+		"id": "Jika Anda atau orang terdekat memiliki pikiran untuk bunuh diri, jangan ragu mencari bantuan. Hubungi layanan kesehatan jiwa di 119 ext 8.",
+		"en": "If you or someone you know is having thoughts of suicide, please seek help. Call the mental health line at 119 ext 8.",
+	},
+	CategoryElections: {
+		"id": "Berita ini disajikan secara berimbang dan bukan materi kampanye peserta pemilu mana pun.",
+		"en": "This report is impartial and is not campaign material for any candidate or party.",
+	},
+}
+
+// Disclaimer returns the category's text in the language, falling back to DefaultLanguage
+func (c Category) Disclaimer(language string) string {
+	texts := disclaimers[c]
+	if text, ok := texts[strings.ToLower(strings.TrimSpace(language))]; ok {
+		return text
+	}
+	return texts[DefaultLanguage]
+}
+
+// AdPolicy value object, what the ad server may do on an article page
+type AdPolicy struct {
+	Ads          bool // Any ads at all
+	Personalized bool // Targeting on the reader's profile rather than the page
+	Political    bool // Political and issue ads
+}
+
+// UnrestrictedAds applies to articles without a sensitive classification
+func UnrestrictedAds() AdPolicy {
+	return AdPolicy{Ads: true, Personalized: true, Political: true}
+}
+
+// AdPolicy returns the category's restrictions
+func (c Category) AdPolicy() AdPolicy {
+	switch c {
+	case CategorySuicide:
+		return AdPolicy{}
+	case CategoryCourtCase, CategoryMinors:
+		return AdPolicy{Ads: true, Political: true}
+	case CategoryElections:
+		return AdPolicy{Ads: true}
+	}
+	return UnrestrictedAds()
+}
+
+// Restrict combines two policies, keeping the stricter setting of each
+func (p AdPolicy) Restrict(other AdPolicy) AdPolicy {
+	return AdPolicy{
+		Ads:          p.Ads && other.Ads,
+		Personalized: p.Personalized && other.Personalized && p.Ads && other.Ads,
+		Political:    p.Political && other.Political && p.Ads && other.Ads,
+	}
+}
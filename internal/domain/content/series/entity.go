@@ -0,0 +1,157 @@
+package series
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Series groups related articles in reading order, e.g. a multi-part investigation
+type Series struct {
+	ID           string
+	Slug         Slug
+	Title        string
+	Description  string
+	ArticleIDs   []string // Reading order, published or not
+	PlannedParts int      // Announced length, 0 when open-ended
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSeries(id string, slug Slug, title, description string, plannedParts int, createdBy string) (*Series, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+	title, err := validateTitle(title)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePlannedParts(plannedParts, 0); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Series{
+		ID:           id,
+		Slug:         slug,
+		Title:        title,
+		Description:  strings.TrimSpace(description),
+		PlannedParts: plannedParts,
+		LastActionBy: &createdBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Business Methods
+
+func (s *Series) UpdateDetails(title, description string, plannedParts int, editorID string) error {
+	title, err := validateTitle(title)
+	if err != nil {
+		return err
+	}
+	if err := validatePlannedParts(plannedParts, len(s.ArticleIDs)); err != nil {
+		return err
+	}
+	s.Title = title
+	s.Description = strings.TrimSpace(description)
+	s.PlannedParts = plannedParts
+	s.touch(editorID)
+	return nil
+}
+
+// SetParts replaces the reading order, used for adding, removing and reordering parts.
+// Going past the announced length extends it.
+func (s *Series) SetParts(articleIDs []string, editorID string) error {
+	if len(articleIDs) > MaxParts {
+		return ErrTooManyParts
+	}
+	seen := make(map[string]struct{}, len(articleIDs))
+	for _, id := range articleIDs {
+		if strings.TrimSpace(id) == "" {
+			return errors.New("article ID cannot be empty")
+		}
+		if _, ok := seen[id]; ok {
+			return ErrDuplicatePart
+		}
+		seen[id] = struct{}{}
+	}
+	if s.PlannedParts > 0 && len(articleIDs) > s.PlannedParts {
+		s.PlannedParts = len(articleIDs)
+	}
+	s.ArticleIDs = append([]string(nil), articleIDs...)
+	s.touch(editorID)
+	return nil
+}
+
+// Query Methods
+
+// Total is the series length shown to readers
+func (s *Series) Total() int {
+	return max(s.PlannedParts, len(s.ArticleIDs))
+}
+
+// PositionOf places an article in the series. Previous and next skip parts
+// that are not published yet.
+func (s *Series) PositionOf(articleID string, published map[string]bool) (*Position, bool) {
+	index := -1
+	for i, id := range s.ArticleIDs {
+		if id == articleID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, false
+	}
+
+	pos := &Position{Part: index + 1, Total: s.Total()}
+	for i := index - 1; i >= 0; i-- {
+		if published[s.ArticleIDs[i]] {
+			pos.Previous = &s.ArticleIDs[i]
+			break
+		}
+	}
+	for i := index + 1; i < len(s.ArticleIDs); i++ {
+		if published[s.ArticleIDs[i]] {
+			pos.Next = &s.ArticleIDs[i]
+			break
+		}
+	}
+	return pos, true
+}
+
+func (s *Series) touch(editorID string) {
+	s.LastActionBy = &editorID
+	s.UpdatedAt = time.Now()
+}
+
+// Domain Validation Functions
+
+func validateTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", ErrTitleEmpty
+	}
+	if len(title) > 150 {
+		return "", ErrTitleTooLong
+	}
+	return title, nil
+}
+
+func validatePlannedParts(planned, assigned int) error {
+	if planned < 0 || planned > MaxParts {
+		return ErrInvalidPlanned
+	}
+	if planned > 0 && planned < assigned {
+		return ErrPlannedBelowParts
+	}
+	return nil
+}
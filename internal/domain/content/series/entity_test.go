@@ -0,0 +1,99 @@
+package series
+
+import "testing"
+
+func createTestSeries(t *testing.T, planned int, parts ...string) *Series {
+	t.Helper()
+	slug, _ := NewSlug("water-cartel")
+	s, err := NewSeries("series-1", *slug, "The Water Cartel", "A five-part investigation", planned, "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create series: %v", err)
+	}
+	if err := s.SetParts(parts, "editor-1"); err != nil {
+		t.Fatalf("failed to set parts: %v", err)
+	}
+	return s
+}
+
+func TestNewSlug(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expectedError error
+	}{
+		{"water-cartel", nil},
+		{" Water-Cartel-2025 ", nil},
+		{"ab", ErrInvalidSlug},
+		{"water--cartel", ErrInvalidSlug},
+		{"water_cartel", ErrInvalidSlug},
+		{"-water", ErrInvalidSlug},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := NewSlug(tc.input)
+			if err != tc.expectedError {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestSeries_SetParts(t *testing.T) {
+	s := createTestSeries(t, 5, "a1", "a2")
+
+	if err := s.SetParts([]string{"a1", "a2", "a1"}, "editor-1"); err != ErrDuplicatePart {
+		t.Errorf("expected error '%v', got '%v'", ErrDuplicatePart, err)
+	}
+	if err := s.SetParts([]string{"a1", "a2", "a3", "a4", "a5", "a6"}, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Total() != 6 {
+		t.Errorf("expected series extended to 6 parts, got %d", s.Total())
+	}
+	if err := s.UpdateDetails("The Water Cartel", "", 4, "editor-1"); err != ErrPlannedBelowParts {
+		t.Errorf("expected error '%v', got '%v'", ErrPlannedBelowParts, err)
+	}
+}
+
+func TestSeries_PositionOf(t *testing.T) {
+	s := createTestSeries(t, 5, "a1", "a2", "a3", "a4")
+	published := map[string]bool{"a1": true, "a3": true, "a4": true}
+
+	testCases := []struct {
+		name             string
+		articleID        string
+		expectedPart     int
+		expectedPrevious string
+		expectedNext     string
+	}{
+		{"first part", "a1", 1, "", "a3"},
+		{"skips unpublished neighbour", "a3", 3, "a1", "a4"},
+		{"latest part", "a4", 4, "a3", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pos, ok := s.PositionOf(tc.articleID, published)
+			if !ok {
+				t.Fatal("expected article to be in the series")
+			}
+			if pos.Part != tc.expectedPart || pos.Total != 5 {
+				t.Errorf("expected part %d of 5, got %d of %d", tc.expectedPart, pos.Part, pos.Total)
+			}
+			if deref(pos.Previous) != tc.expectedPrevious || deref(pos.Next) != tc.expectedNext {
+				t.Errorf("expected previous %q next %q, got %q %q", tc.expectedPrevious, tc.expectedNext, deref(pos.Previous), deref(pos.Next))
+			}
+		})
+	}
+
+	if _, ok := s.PositionOf("other", published); ok {
+		t.Error("expected article outside the series not to be found")
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,21 @@
+package series
+
+import "context"
+
+type SeriesRepository interface {
+	// Commands
+	Create(ctx context.Context, series *Series) error
+	Update(ctx context.Context, series *Series) error
+	Delete(ctx context.Context, id string) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Series, error)
+	FindBySlug(ctx context.Context, slug string) (*Series, error)
+	FindByArticleID(ctx context.Context, articleID string) (*Series, error) // nil when the article is not in a series
+}
+
+// ArticleSummaries loads published articles for series pages (implemented by the article module)
+type ArticleSummaries interface {
+	// FindPublished returns summaries keyed by article ID, unpublished and unknown articles are left out
+	FindPublished(ctx context.Context, articleIDs []string) (map[string]ArticleSummary, error)
+}
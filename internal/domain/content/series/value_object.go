@@ -0,0 +1,65 @@
+package series
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// MaxParts keeps landing pages and cross-link blocks a sensible size
+const MaxParts = 50
+
+// Domain errors
+var (
+	ErrInvalidSlug       = errors.New("slug must be 3-80 lowercase letters, digits or single hyphens")
+	ErrTitleEmpty        = errors.New("series title cannot be empty")
+	ErrTitleTooLong      = errors.New("series title cannot exceed 150 characters")
+	ErrDuplicatePart     = errors.New("an article can appear only once in a series")
+	ErrTooManyParts      = errors.New("a series cannot have more than 50 parts")
+	ErrInvalidPlanned    = errors.New("planned parts must be between 0 and 50")
+	ErrPlannedBelowParts = errors.New("planned parts cannot be fewer than the parts already assigned")
+)
+
+// Slug value object, the series' public URL segment
+type Slug struct {
+	value string
+}
+
+func NewSlug(value string) (*Slug, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) < 3 || len(value) > 80 || !slugRegex.MatchString(value) {
+		return nil, ErrInvalidSlug
+	}
+	return &Slug{value: value}, nil
+}
+
+func (s Slug) String() string {
+	return s.value
+}
+
+func (s Slug) Value() string {
+	return s.value
+}
+
+func (s Slug) Equals(other Slug) bool {
+	return s.value == other.value
+}
+
+// ArticleSummary is what the series pages and feed show for each part
+type ArticleSummary struct {
+	Ref     permalink.ArticleRef
+	Title   string
+	Excerpt string
+}
+
+// Position is where an article sits in its series, e.g. part 3 of 5
+type Position struct {
+	Part     int
+	Total    int
+	Previous *string // Article ID of the previous published part
+	Next     *string // Article ID of the next published part
+}
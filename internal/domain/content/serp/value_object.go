@@ -0,0 +1,208 @@
+package serp
+
+import (
+	"math"
+	"strings"
+)
+
+// Field is the article text a surface shows
+type Field string
+
+const (
+	FieldTitle       Field = "title"       // The SEO title, falling back to the headline
+	FieldDescription Field = "description" // The meta description
+)
+
+// SurfaceName identifies where the text is shown
+type SurfaceName string
+
+const (
+	SurfaceGoogleTitle         SurfaceName = "google_title"
+	SurfaceGoogleDescription   SurfaceName = "google_description"
+	SurfaceFacebookTitle       SurfaceName = "facebook_title"
+	SurfaceFacebookDescription SurfaceName = "facebook_description"
+	SurfaceXTitle              SurfaceName = "x_title"
+)
+
+// Font is how a surface sets text. Every surface uses Arial or Helvetica,
+// which share metrics closely enough for an estimate.
+type Font struct {
+	SizePx float64
+	Bold   bool
+}
+
+// Surface is one place a headline or description appears, with its thresholds
+type Surface struct {
+	Name        SurfaceName
+	Field       Field
+	Font        Font
+	LineWidthPx float64
+	Lines       int     // Text beyond this many lines is cut with an ellipsis
+	MinPx       float64 // Shorter text wastes the space, 0 disables the warning
+}
+
+// DefaultSurfaces are the desktop layouts at the time of writing. Search
+// engines and networks change them without notice, so sites can override
+// the thresholds.
+func DefaultSurfaces() []Surface {
+	return []Surface{
+		{Name: SurfaceGoogleTitle, Field: FieldTitle, Font: Font{SizePx: 20}, LineWidthPx: 600, Lines: 1, MinPx: 250},
+		{Name: SurfaceGoogleDescription, Field: FieldDescription, Font: Font{SizePx: 14}, LineWidthPx: 920, Lines: 1, MinPx: 400},
+		{Name: SurfaceFacebookTitle, Field: FieldTitle, Font: Font{SizePx: 16, Bold: true}, LineWidthPx: 500, Lines: 2},
+		{Name: SurfaceFacebookDescription, Field: FieldDescription, Font: Font{SizePx: 14}, LineWidthPx: 500, Lines: 1},
+		{Name: SurfaceXTitle, Field: FieldTitle, Font: Font{SizePx: 15, Bold: true}, LineWidthPx: 520, Lines: 1},
+	}
+}
+
+// WarningCode is stable for the editor to map to its own copy
+type WarningCode string
+
+const (
+	WarningEmpty     WarningCode = "empty"
+	WarningTruncated WarningCode = "truncated"
+	WarningTooShort  WarningCode = "too_short"
+)
+
+type Warning struct {
+	Code    WarningCode
+	Message string
+}
+
+// Preview is how the text is estimated to render on one surface
+type Preview struct {
+	Surface   SurfaceName
+	Field     Field
+	Text      string
+	WidthPx   float64
+	MaxPx     float64
+	Display   string // What readers see, cut with an ellipsis when truncated
+	Truncated bool
+	Warnings  []Warning
+}
+
+// Measure estimates the rendering of text on the surface
+func (s Surface) Measure(text string) Preview {
+	text = strings.Join(strings.Fields(text), " ")
+	p := Preview{
+		Surface: s.Name,
+		Field:   s.Field,
+		Text:    text,
+		WidthPx: round(Width(text, s.Font)),
+		MaxPx:   s.LineWidthPx * float64(s.Lines),
+	}
+	p.Display, p.Truncated = s.layout(text)
+
+	switch {
+	case text == "":
+		p.Warnings = append(p.Warnings, Warning{Code: WarningEmpty, Message: string(s.Field) + " is empty"})
+	case p.Truncated:
+		p.Warnings = append(p.Warnings, Warning{Code: WarningTruncated, Message: string(s.Field) + " is cut off on " + string(s.Name)})
+	case p.WidthPx < s.MinPx:
+		p.Warnings = append(p.Warnings, Warning{Code: WarningTooShort, Message: string(s.Field) + " leaves space unused on " + string(s.Name)})
+	}
+	return p
+}
+
+// layout wraps words onto the surface's lines and cuts the overflow at a
+// word boundary, the way search results and cards do
+func (s Surface) layout(text string) (string, bool) {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && Width(candidate, s.Font) > s.LineWidthPx {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	cut := -1
+	for i, l := range lines {
+		if Width(l, s.Font) > s.LineWidthPx || (i == s.Lines-1 && len(lines) > s.Lines) {
+			cut = i
+			break
+		}
+	}
+	if cut < 0 {
+		return text, false
+	}
+	kept := append([]string{}, lines[:cut]...)
+	return strings.Join(append(kept, ellipsize(strings.Join(lines[cut:], " "), s.Font, s.LineWidthPx)), " "), true
+}
+
+const ellipsis = "…"
+
+func ellipsize(text string, font Font, maxPx float64) string {
+	runes := []rune(text)
+	n := 0
+	for n < len(runes) && Width(string(runes[:n+1])+ellipsis, font) <= maxPx {
+		n++
+	}
+	prefix := string(runes[:n])
+	if n < len(runes) && runes[n] != ' ' {
+		if space := strings.LastIndex(prefix, " "); space > 0 {
+			prefix = prefix[:space]
+		}
+	}
+	return strings.TrimRight(prefix, " ") + ellipsis
+}
+
+// Width estimates the rendered width in pixels from Helvetica metrics
+func Width(text string, font Font) float64 {
+	widths := &regularWidths
+	if font.Bold {
+		widths = &boldWidths
+	}
+	units := 0
+	for _, r := range text {
+		switch {
+		case r >= ' ' && r <= '~':
+			units += widths[r-' ']
+		case r == '…':
+			units += 1000
+		default:
+			units += averageWidth // Accented letters and other scripts
+		}
+	}
+	return float64(units) * font.SizePx / 1000
+}
+
+func round(px float64) float64 {
+	return math.Round(px*10) / 10
+}
+
+// averageWidth is used for characters outside the tables, close to a lowercase letter
+const averageWidth = 556
+
+// Glyph widths in 1/1000 em for ' ' through '~', from the Helvetica AFM files
+var regularWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // ' ' to '/'
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // '0' to '9'
+	278, 278, 584, 584, 584, 556, 1015, // ':' to '@'
+	667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // 'A' to 'M'
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // 'N' to 'Z'
+	278, 278, 278, 469, 556, 333, // '[' to '`'
+	556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // 'a' to 'm'
+	556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // 'n' to 'z'
+	334, 260, 334, 584, // '{' to '~'
+}
+
+var boldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556,
+	333, 333, 584, 584, 584, 611, 975,
+	722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833,
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611,
+	333, 278, 333, 584, 556, 333,
+	556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889,
+	611, 611, 611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500,
+	389, 280, 389, 584,
+}
@@ -0,0 +1,71 @@
+package serp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWidth(t *testing.T) {
+	regular := Font{SizePx: 20}
+	if w := Width("Wi", regular); w != (944+222)*20/1000.0 {
+		t.Errorf("expected width from glyph metrics, got %v", w)
+	}
+	if Width("iiii", regular) >= Width("WWWW", regular) {
+		t.Error("expected narrow letters to measure less than wide ones")
+	}
+	if Width("a", Font{SizePx: 20, Bold: true}) <= Width("b", regular)-1 {
+		t.Error("expected bold metrics to be used")
+	}
+	if w := Width("é", regular); w != averageWidth*20/1000.0 {
+		t.Errorf("expected average width for characters outside the table, got %v", w)
+	}
+}
+
+func TestSurface_Measure(t *testing.T) {
+	google := Surface{Name: SurfaceGoogleTitle, Field: FieldTitle, Font: Font{SizePx: 20}, LineWidthPx: 600, Lines: 1, MinPx: 250}
+	card := Surface{Name: SurfaceFacebookTitle, Field: FieldTitle, Font: Font{SizePx: 16, Bold: true}, LineWidthPx: 200, Lines: 2}
+
+	testCases := []struct {
+		name          string
+		surface       Surface
+		text          string
+		expectedCode  WarningCode
+		truncated     bool
+		expectedStart string
+	}{
+		{"fits", google, "Banjir rendam 3.000 rumah di Jakarta Timur, warga mengungsi", "", false, "Banjir rendam"},
+		{"empty", google, "  ", WarningEmpty, false, ""},
+		{"too short", google, "Banjir", WarningTooShort, false, "Banjir"},
+		{"truncated", google, strings.Repeat("Pemerintah umumkan kebijakan baru ", 4), WarningTruncated, true, "Pemerintah umumkan"},
+		{"wraps before truncating", card, "Banjir rendam ribuan rumah", "", false, "Banjir rendam ribuan rumah"},
+		{"wraps then truncates", card, "Banjir rendam ribuan rumah di Jakarta Timur dan Bekasi", WarningTruncated, true, "Banjir rendam"},
+		{"long word", card, strings.Repeat("W", 30), WarningTruncated, true, "WWW"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := tc.surface.Measure(tc.text)
+			if p.Truncated != tc.truncated {
+				t.Errorf("expected truncated %v, got %v (%s)", tc.truncated, p.Truncated, p.Display)
+			}
+			var code WarningCode
+			if len(p.Warnings) > 0 {
+				code = p.Warnings[0].Code
+			}
+			if code != tc.expectedCode {
+				t.Errorf("expected warning %q, got %q", tc.expectedCode, code)
+			}
+			if !strings.HasPrefix(p.Display, tc.expectedStart) {
+				t.Errorf("expected display to start with %q, got %q", tc.expectedStart, p.Display)
+			}
+			if p.Truncated {
+				if !strings.HasSuffix(p.Display, ellipsis) || strings.Contains(p.Display, " "+ellipsis) {
+					t.Errorf("expected a word-boundary ellipsis, got %q", p.Display)
+				}
+				if Width(p.Display, tc.surface.Font) > p.MaxPx+tc.surface.LineWidthPx {
+					t.Errorf("expected display to fit, got %q", p.Display)
+				}
+			}
+		})
+	}
+}
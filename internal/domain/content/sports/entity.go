@@ -0,0 +1,176 @@
+package sports
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Competition is a league or cup we cover, e.g. "liga-1" for the 2025/26
+// season. Provider names the adapter and ProviderRef its league ID.
+type Competition struct {
+	ID          string // Our code, used in URLs
+	Name        string
+	Season      string
+	Provider    string
+	ProviderRef string
+	Active      bool // Inactive competitions are no longer refreshed
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCompetition(code, name, season, provider, providerRef, createdBy string, at time.Time) (*Competition, error) {
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+	if err := ValidateCompetitionCode(code); err != nil {
+		return nil, err
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(season) == "" {
+		return nil, ErrInvalidSeason
+	}
+	if strings.TrimSpace(providerRef) == "" {
+		return nil, ErrInvalidProviderRef
+	}
+	return &Competition{
+		ID:           code,
+		Name:         name,
+		Season:       strings.TrimSpace(season),
+		Provider:     strings.TrimSpace(provider),
+		ProviderRef:  strings.TrimSpace(providerRef),
+		Active:       true,
+		LastActionBy: &createdBy,
+		CreatedAt:    at,
+		UpdatedAt:    at,
+	}, nil
+}
+
+// Business Methods
+
+func (c *Competition) SetActive(active bool, editorID string, at time.Time) {
+	c.Active = active
+	c.LastActionBy = &editorID
+	c.UpdatedAt = at
+}
+
+// Fixture is a match, as normalized from the provider. Score and Minute
+// stay nil until the match starts.
+type Fixture struct {
+	ID            string
+	CompetitionID string
+	ProviderRef   string
+	Home          Team
+	Away          Team
+	KickoffAt     time.Time
+	Venue         string
+	Status        Status
+	Score         *Score
+	Minute        *int
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+// Business Methods
+
+// Merge copies the provider's latest data onto the stored fixture and
+// reports whether anything readers see changed
+func (f *Fixture) Merge(incoming Fixture, at time.Time) bool {
+	if f.sameAs(incoming) {
+		return false
+	}
+	f.Home, f.Away = incoming.Home, incoming.Away
+	f.KickoffAt = incoming.KickoffAt
+	f.Venue = incoming.Venue
+	f.Status = incoming.Status
+	f.Score = incoming.Score
+	f.Minute = incoming.Minute
+	f.UpdatedAt = at
+	return true
+}
+
+func (f *Fixture) sameAs(other Fixture) bool {
+	return f.Home == other.Home && f.Away == other.Away && f.KickoffAt.Equal(other.KickoffAt) &&
+		f.Venue == other.Venue && f.Status == other.Status &&
+		equalPtr(f.Score, other.Score) && equalPtr(f.Minute, other.Minute)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Table is a competition's league table
+type Table struct {
+	CompetitionID string
+	Rows          []Standing
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+// Equals compares the rows, the refresh only stores tables that moved
+func (t *Table) Equals(other *Table) bool {
+	if other == nil || len(t.Rows) != len(other.Rows) {
+		return false
+	}
+	for i := range t.Rows {
+		if t.Rows[i] != other.Rows[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// NextRefresh picks how soon the competition should be refreshed again
+// given its current fixtures
+func NextRefresh(fixtures []*Fixture, at time.Time) time.Duration {
+	next := RefreshIdle
+	for _, f := range fixtures {
+		switch {
+		case f.Status.InPlay():
+			return RefreshLive
+		case f.Status == StatusScheduled && f.KickoffAt.After(at.Add(-matchdayWindow)) && f.KickoffAt.Before(at.Add(matchdayWindow)):
+			// Includes kickoffs the provider has not flipped to live yet
+			next = RefreshMatchday
+		}
+	}
+	return next
+}
+
+// Scoreboard embeds are placeholders in article bodies which the page
+// hydrates from the scoreboard endpoint, e.g.
+// <div data-block="scoreboard" data-fixture="..."></div>
+var scoreboardBlockRegex = regexp.MustCompile(`<div data-block="scoreboard" data-fixture="([^"]+)"></div>`)
+
+// ScoreboardBlock returns the markup editors insert to embed a live scoreboard
+func ScoreboardBlock(fixtureID string) string {
+	return `<div data-block="scoreboard" data-fixture="` + html.EscapeString(fixtureID) + `"></div>`
+}
+
+// EmbeddedFixtures lists the fixtures whose scoreboards an article body embeds
+func EmbeddedFixtures(body string) []string {
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, m := range scoreboardBlockRegex.FindAllStringSubmatch(body, -1) {
+		id := html.UnescapeString(m[1])
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,87 @@
+package sports
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCompetition(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		compName    string
+		season      string
+		ref         string
+		expectedErr error
+	}{
+		{"valid", "liga-1", "BRI Liga 1", "2025", "274", nil},
+		{"invalid code", "Liga 1", "BRI Liga 1", "2025", "274", ErrInvalidCompetitionCode},
+		{"empty name", "liga-1", " ", "2025", "274", ErrInvalidCompetitionName},
+		{"empty season", "liga-1", "BRI Liga 1", "", "274", ErrInvalidSeason},
+		{"empty provider ref", "liga-1", "BRI Liga 1", "2025", " ", ErrInvalidProviderRef},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewCompetition(tc.code, tc.compName, tc.season, "api-football", tc.ref, "editor-1", time.Now())
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestFixture_Merge(t *testing.T) {
+	kickoff := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
+	stored := &Fixture{ID: "f1", ProviderRef: "9001", Home: Team{ID: "1", Name: "Persib"}, Away: Team{ID: "2", Name: "Persija"}, KickoffAt: kickoff, Status: StatusScheduled}
+
+	same := *stored
+	if stored.Merge(same, kickoff) {
+		t.Error("expected no change for identical data")
+	}
+
+	minute := 23
+	live := same
+	live.Status, live.Score, live.Minute = StatusLive, &Score{Home: 1}, &minute
+	if !stored.Merge(live, kickoff.Add(23*time.Minute)) || stored.Score.String() != "1-0" || stored.ID != "f1" {
+		t.Errorf("expected live score merged keeping the ID, got %+v", stored)
+	}
+
+	sameScore := live
+	sameScore.Score = &Score{Home: 1}
+	if stored.Merge(sameScore, kickoff.Add(24*time.Minute)) {
+		t.Error("expected equal scores behind different pointers to count as unchanged")
+	}
+}
+
+func TestNextRefresh(t *testing.T) {
+	at := time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		fixtures []*Fixture
+		expected time.Duration
+	}{
+		{"nothing soon", []*Fixture{{Status: StatusScheduled, KickoffAt: at.Add(48 * time.Hour)}}, RefreshIdle},
+		{"kickoff in two hours", []*Fixture{{Status: StatusScheduled, KickoffAt: at.Add(2 * time.Hour)}}, RefreshMatchday},
+		{"kickoff passed, not live yet", []*Fixture{{Status: StatusScheduled, KickoffAt: at.Add(-10 * time.Minute)}}, RefreshMatchday},
+		{"match at half time", []*Fixture{{Status: StatusFinished}, {Status: StatusHalfTime}}, RefreshLive},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NextRefresh(tc.fixtures, at); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestEmbeddedFixtures(t *testing.T) {
+	body := "<p>Laga panas.</p>" + ScoreboardBlock("f1") + "<p>Sementara itu</p>" + ScoreboardBlock("f2") + ScoreboardBlock("f1")
+
+	ids := EmbeddedFixtures(body)
+	if len(ids) != 2 || ids[0] != "f1" || ids[1] != "f2" {
+		t.Errorf("expected f1 and f2 once each, got %v", ids)
+	}
+}
@@ -0,0 +1,41 @@
+package sports
+
+import (
+	"context"
+	"time"
+)
+
+type CompetitionRepository interface {
+	// Commands
+	Create(ctx context.Context, competition *Competition) error
+	Update(ctx context.Context, competition *Competition) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Competition, error) // nil when not found
+	FindAll(ctx context.Context) ([]*Competition, error)
+}
+
+type FixtureRepository interface {
+	// Save inserts or updates the fixture
+	Save(ctx context.Context, fixture *Fixture) error
+
+	FindByID(ctx context.Context, id string) (*Fixture, error) // nil when not found
+	// FindByCompetition returns fixtures kicking off in [from, to), earliest first
+	FindByCompetition(ctx context.Context, competitionID string, from, to time.Time) ([]*Fixture, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*Fixture, error)
+	// FindByProviderRefs matches fixtures the provider returned to stored ones
+	FindByProviderRefs(ctx context.Context, competitionID string, refs []string) ([]*Fixture, error)
+}
+
+type TableRepository interface {
+	Save(ctx context.Context, table *Table) error
+	FindByCompetition(ctx context.Context, competitionID string) (*Table, error) // nil when not found
+}
+
+// Provider fetches fixtures, live scores and tables from a sports data
+// vendor. Fixtures come back without ID or CompetitionID, identified by
+// ProviderRef. (implementations will be in infrastructure layer)
+type Provider interface {
+	Fixtures(ctx context.Context, competition *Competition, from, to time.Time) ([]Fixture, error)
+	Table(ctx context.Context, competition *Competition) (*Table, error)
+}
@@ -0,0 +1,107 @@
+package sports
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Refresh cadence: every few seconds while a match is on, more often on
+// matchdays, hourly otherwise
+const (
+	RefreshLive     = 30 * time.Second
+	RefreshMatchday = 5 * time.Minute
+	RefreshIdle     = time.Hour
+
+	// matchdayWindow is how close a kickoff has to be for the matchday cadence
+	matchdayWindow = 3 * time.Hour
+)
+
+var codeRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Domain errors
+var (
+	ErrInvalidCompetitionCode = errors.New("competition code must be lowercase letters, digits and hyphens")
+	ErrInvalidCompetitionName = errors.New("competition name must be between 1 and 100 characters")
+	ErrInvalidSeason          = errors.New("season cannot be empty")
+	ErrInvalidProviderRef     = errors.New("provider reference cannot be empty")
+	ErrInvalidStatus          = errors.New("unknown match status")
+)
+
+// Status of a fixture as normalized from the provider
+type Status string
+
+const (
+	StatusScheduled Status = "scheduled"
+	StatusLive      Status = "live"
+	StatusHalfTime  Status = "half_time"
+	StatusFinished  Status = "finished"
+	StatusPostponed Status = "postponed"
+	StatusCancelled Status = "cancelled"
+)
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusScheduled, StatusLive, StatusHalfTime, StatusFinished, StatusPostponed, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// InPlay is true between kickoff and the final whistle
+func (s Status) InPlay() bool {
+	return s == StatusLive || s == StatusHalfTime
+}
+
+// Team value object, IDs are the provider's
+type Team struct {
+	ID        string
+	Name      string
+	ShortName string
+}
+
+// Score value object
+type Score struct {
+	Home int
+	Away int
+}
+
+func (s Score) String() string {
+	return fmt.Sprintf("%d-%d", s.Home, s.Away)
+}
+
+// Standing is one row of a league table
+type Standing struct {
+	Position     int
+	Team         Team
+	Played       int
+	Won          int
+	Drawn        int
+	Lost         int
+	GoalsFor     int
+	GoalsAgainst int
+	Points       int
+}
+
+func (s Standing) GoalDifference() int {
+	return s.GoalsFor - s.GoalsAgainst
+}
+
+// Domain Validation Functions
+
+func ValidateCompetitionCode(code string) error {
+	if len(code) > 50 || !codeRegex.MatchString(code) {
+		return ErrInvalidCompetitionCode
+	}
+	return nil
+}
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > 100 {
+		return "", ErrInvalidCompetitionName
+	}
+	return name, nil
+}
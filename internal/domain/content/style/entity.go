@@ -0,0 +1,184 @@
+package style
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Ruleset is a named list of rules kept by the chief editors, e.g. "House
+// style" or "English desk"
+type Ruleset struct {
+	ID       string
+	Name     string
+	Language Language
+	Rules    []Rule
+	Enabled  bool
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewRuleset(id, name string, language Language, rules []Rule, createdBy string) (*Ruleset, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRules(rules); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Ruleset{
+		ID:           id,
+		Name:         name,
+		Language:     language,
+		Rules:        rules,
+		Enabled:      true,
+		LastActionBy: &createdBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Business Methods
+
+func (r *Ruleset) Update(name string, language Language, rules []Rule, enabled bool, editorID string) error {
+	name, err := validateName(name)
+	if err != nil {
+		return err
+	}
+	if err := validateRules(rules); err != nil {
+		return err
+	}
+	r.Name = name
+	r.Language = language
+	r.Rules = rules
+	r.Enabled = enabled
+	r.LastActionBy = &editorID
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Query Methods
+
+// AppliesTo reports whether the ruleset checks drafts in the language
+func (r *Ruleset) AppliesTo(language Language) bool {
+	return r.Enabled && (r.Language == LanguageAny || r.Language == language)
+}
+
+// Check runs every rule over the text. HTML tags are skipped, so a draft
+// body can be sent as is.
+func (r *Ruleset) Check(text string, language Language) []Finding {
+	masked := maskMarkup(text)
+	var findings []Finding
+	add := func(rule Rule, start, end int, suggestion string) {
+		findings = append(findings, Finding{
+			RulesetID:  r.ID,
+			Kind:       rule.Kind,
+			Severity:   rule.Severity,
+			Start:      utf8.RuneCountInString(text[:start]),
+			End:        utf8.RuneCountInString(text[:end]),
+			Text:       text[start:end],
+			Suggestion: suggestion,
+			Message:    rule.Message,
+		})
+	}
+
+	for _, rule := range r.Rules {
+		switch rule.Kind {
+		case KindBannedPhrase:
+			for _, m := range matchPhrase(masked, rule.Pattern) {
+				add(rule, m[0], m[1], rule.Suggestion)
+			}
+		case KindCapitalization:
+			for _, m := range matchPhrase(masked, rule.Pattern) {
+				if masked[m[0]:m[1]] != rule.Pattern {
+					add(rule, m[0], m[1], rule.Pattern)
+				}
+			}
+		case KindNumberFormat:
+			numberLanguage := r.Language
+			if numberLanguage == LanguageAny {
+				numberLanguage = language
+			}
+			for _, m := range numbers.FindAllStringIndex(masked, -1) {
+				if suggestion := numberSuggestion(masked[m[0]:m[1]], numberLanguage); suggestion != "" {
+					add(rule, m[0], m[1], suggestion)
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// Report is the result of checking a draft against every applicable ruleset
+type Report struct {
+	Findings []Finding
+}
+
+// Lint checks the text against each ruleset that applies to the language,
+// findings ordered by position
+func Lint(rulesets []*Ruleset, text string, language Language) *Report {
+	report := &Report{Findings: []Finding{}}
+	for _, r := range rulesets {
+		if r.AppliesTo(language) {
+			report.Findings = append(report.Findings, r.Check(text, language)...)
+		}
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Start < report.Findings[j].Start
+	})
+	return report
+}
+
+// Blocked reports whether any finding must be fixed before submitting
+func (r *Report) Blocked() bool {
+	return r.Count(SeverityBlocking) > 0
+}
+
+func (r *Report) Count(severity Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// Domain Validation Functions
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrRulesetNameEmpty
+	}
+	return name, nil
+}
+
+func validateRules(rules []Rule) error {
+	if len(rules) > MaxRules {
+		return ErrTooManyRules
+	}
+	seen := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		key := string(rule.Kind) + ":" + strings.ToLower(rule.Pattern)
+		if _, ok := seen[key]; ok {
+			return ErrDuplicatePattern
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
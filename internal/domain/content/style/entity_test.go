@@ -0,0 +1,152 @@
+package style
+
+import (
+	"testing"
+)
+
+func mustRule(t *testing.T, kind Kind, pattern, suggestion string, severity Severity) Rule {
+	t.Helper()
+	rule, err := NewRule(kind, pattern, suggestion, "", severity)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return rule
+}
+
+func TestNewRule(t *testing.T) {
+	testCases := []struct {
+		name        string
+		kind        Kind
+		pattern     string
+		severity    Severity
+		expectedErr error
+	}{
+		{"banned phrase", KindBannedPhrase, "  di  mana ", SeverityWarning, nil},
+		{"number format without pattern", KindNumberFormat, "", SeverityBlocking, nil},
+		{"unknown kind", Kind("regex"), "x", SeverityWarning, ErrInvalidKind},
+		{"unknown severity", KindBannedPhrase, "x", Severity("error"), ErrInvalidSeverity},
+		{"empty pattern", KindCapitalization, " ", SeverityWarning, ErrPatternEmpty},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := NewRule(tc.kind, tc.pattern, "", "", tc.severity)
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err == nil && tc.kind == KindBannedPhrase && rule.Pattern != "di mana" {
+				t.Errorf("expected normalized pattern, got %q", rule.Pattern)
+			}
+		})
+	}
+}
+
+func TestNewRuleset(t *testing.T) {
+	rule := mustRule(t, KindBannedPhrase, "analisa", "analisis", SeverityWarning)
+	if _, err := NewRuleset("rs-1", " ", LanguageIndonesian, nil, "chief-1"); err != ErrRulesetNameEmpty {
+		t.Errorf("expected error '%v', got '%v'", ErrRulesetNameEmpty, err)
+	}
+	duplicate := mustRule(t, KindBannedPhrase, "Analisa", "", SeverityBlocking)
+	if _, err := NewRuleset("rs-1", "Gaya", LanguageIndonesian, []Rule{rule, duplicate}, "chief-1"); err != ErrDuplicatePattern {
+		t.Errorf("expected error '%v', got '%v'", ErrDuplicatePattern, err)
+	}
+}
+
+func TestRuleset_Check(t *testing.T) {
+	testCases := []struct {
+		name     string
+		language Language
+		rule     Rule
+		text     string
+		expected []Finding
+	}{
+		{
+			"banned phrase whole word only",
+			LanguageIndonesian,
+			Rule{Kind: KindBannedPhrase, Pattern: "analisa", Suggestion: "analisis", Severity: SeverityWarning},
+			"Analisa pasar, bukan penganalisa.",
+			[]Finding{{Start: 0, End: 7, Text: "Analisa", Suggestion: "analisis"}},
+		},
+		{
+			"phrase across whitespace and tags",
+			LanguageIndonesian,
+			Rule{Kind: KindBannedPhrase, Pattern: "di mana", Severity: SeverityWarning},
+			"<p>Rumah <b>di</b> mana?</p>",
+			[]Finding{{Start: 12, End: 23, Text: "di</b> mana"}},
+		},
+		{
+			"rune offsets after non-ASCII text",
+			LanguageIndonesian,
+			Rule{Kind: KindBannedPhrase, Pattern: "kemaren", Suggestion: "kemarin", Severity: SeverityWarning},
+			"Café buka kemaren",
+			[]Finding{{Start: 10, End: 17, Text: "kemaren", Suggestion: "kemarin"}},
+		},
+		{
+			"capitalization",
+			LanguageAny,
+			Rule{Kind: KindCapitalization, Pattern: "iPhone", Severity: SeverityBlocking},
+			"Harga IPhone dan iPhone",
+			[]Finding{{Start: 6, End: 12, Text: "IPhone", Suggestion: "iPhone"}},
+		},
+		{
+			"indonesian numbers",
+			LanguageIndonesian,
+			Rule{Kind: KindNumberFormat, Severity: SeverityWarning},
+			"Rp 1,500,000 naik 2.5 persen dari 25000 tahun 2025",
+			[]Finding{
+				{Start: 3, End: 12, Text: "1,500,000", Suggestion: "1.500.000"},
+				{Start: 18, End: 21, Text: "2.5", Suggestion: "2,5"},
+				{Start: 34, End: 39, Text: "25000", Suggestion: "25.000"},
+			},
+		},
+		{
+			"english numbers",
+			LanguageEnglish,
+			Rule{Kind: KindNumberFormat, Severity: SeverityWarning},
+			"1.500.000 people, 2,5 percent, 1.5 and 1,000",
+			[]Finding{
+				{Start: 0, End: 9, Text: "1.500.000", Suggestion: "1,500,000"},
+				{Start: 18, End: 21, Text: "2,5", Suggestion: "2.5"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewRuleset("rs-1", "Gaya", tc.language, []Rule{tc.rule}, "chief-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			findings := r.Check(tc.text, tc.language)
+			if len(findings) != len(tc.expected) {
+				t.Fatalf("expected %d findings, got %+v", len(tc.expected), findings)
+			}
+			for i, f := range findings {
+				e := tc.expected[i]
+				if f.Start != e.Start || f.End != e.End || f.Text != e.Text || f.Suggestion != e.Suggestion {
+					t.Errorf("expected %+v, got %+v", e, f)
+				}
+			}
+		})
+	}
+}
+
+func TestLint(t *testing.T) {
+	id, _ := NewRuleset("rs-id", "Gaya", LanguageIndonesian, []Rule{{Kind: KindBannedPhrase, Pattern: "analisa", Severity: SeverityBlocking}}, "chief-1")
+	any, _ := NewRuleset("rs-any", "Merek", LanguageAny, []Rule{{Kind: KindCapitalization, Pattern: "GoTo", Severity: SeverityWarning}}, "chief-1")
+	off, _ := NewRuleset("rs-off", "Lama", LanguageAny, []Rule{{Kind: KindBannedPhrase, Pattern: "goto", Severity: SeverityBlocking}}, "chief-1")
+	off.Enabled = false
+
+	report := Lint([]*Ruleset{any, id, off}, "analisa saham Goto", LanguageIndonesian)
+	if len(report.Findings) != 2 || report.Findings[0].RulesetID != "rs-id" {
+		t.Fatalf("expected findings from enabled rulesets in order, got %+v", report.Findings)
+	}
+	if !report.Blocked() || report.Count(SeverityWarning) != 1 {
+		t.Errorf("expected one blocking and one warning finding")
+	}
+
+	report = Lint([]*Ruleset{any, id}, "analisa saham GoTo", LanguageEnglish)
+	if report.Blocked() || len(report.Findings) != 0 {
+		t.Errorf("expected Indonesian rules skipped for English drafts, got %+v", report.Findings)
+	}
+}
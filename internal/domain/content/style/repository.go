@@ -0,0 +1,16 @@
+package style
+
+import "context"
+
+type RulesetRepository interface {
+	// Commands
+	Create(ctx context.Context, ruleset *Ruleset) error
+	Update(ctx context.Context, ruleset *Ruleset) error
+	Delete(ctx context.Context, id string) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Ruleset, error)
+	FindAll(ctx context.Context) ([]*Ruleset, error)
+	// FindEnabled returns enabled rulesets for the language and for any language
+	FindEnabled(ctx context.Context, language Language) ([]*Ruleset, error)
+}
@@ -0,0 +1,191 @@
+package style
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+// ScopeManageRules is granted to the chief editor role
+const ScopeManageRules entitlement.Scope = "style:manage_rules"
+
+const (
+	MaxRules      = 500
+	MaxPatternLen = 100
+)
+
+// Domain errors
+var (
+	ErrInvalidKind      = errors.New("rule kind must be banned_phrase, capitalization or number_format")
+	ErrInvalidSeverity  = errors.New("severity must be warning or blocking")
+	ErrInvalidLanguage  = errors.New("language must be id, en or empty for both")
+	ErrPatternEmpty     = errors.New("rule pattern cannot be empty")
+	ErrPatternTooLong   = errors.New("rule pattern cannot exceed 100 characters")
+	ErrRulesetNameEmpty = errors.New("ruleset name cannot be empty")
+	ErrTooManyRules     = errors.New("a ruleset cannot have more than 500 rules")
+	ErrDuplicatePattern = errors.New("ruleset has the same pattern twice")
+)
+
+// Kind is what a rule looks for
+type Kind string
+
+const (
+	KindBannedPhrase   Kind = "banned_phrase"  // A word or phrase the desk avoids, with an optional replacement
+	KindCapitalization Kind = "capitalization" // A name with fixed spelling, e.g. "iPhone" or "GoTo"
+	KindNumberFormat   Kind = "number_format"  // Thousands and decimal separators of the ruleset language
+)
+
+// Severity decides whether a finding stops the draft from being submitted
+type Severity string
+
+const (
+	SeverityWarning  Severity = "warning"
+	SeverityBlocking Severity = "blocking"
+)
+
+// Language of a ruleset, empty applies to drafts in any language
+type Language string
+
+const (
+	LanguageAny        Language = ""
+	LanguageIndonesian Language = "id"
+	LanguageEnglish    Language = "en"
+)
+
+func ParseLanguage(value string) (Language, error) {
+	switch l := Language(strings.ToLower(strings.TrimSpace(value))); l {
+	case LanguageAny, LanguageIndonesian, LanguageEnglish:
+		return l, nil
+	}
+	return "", ErrInvalidLanguage
+}
+
+// Rule value object, one entry of a ruleset
+type Rule struct {
+	Kind       Kind
+	Pattern    string // Phrase or canonical spelling, unused for number format rules
+	Suggestion string
+	Message    string
+	Severity   Severity
+}
+
+func NewRule(kind Kind, pattern, suggestion, message string, severity Severity) (Rule, error) {
+	switch kind {
+	case KindBannedPhrase, KindCapitalization, KindNumberFormat:
+	default:
+		return Rule{}, ErrInvalidKind
+	}
+	switch severity {
+	case SeverityWarning, SeverityBlocking:
+	default:
+		return Rule{}, ErrInvalidSeverity
+	}
+	pattern = strings.Join(strings.Fields(pattern), " ")
+	if kind != KindNumberFormat {
+		if pattern == "" {
+			return Rule{}, ErrPatternEmpty
+		}
+		if utf8.RuneCountInString(pattern) > MaxPatternLen {
+			return Rule{}, ErrPatternTooLong
+		}
+	}
+	return Rule{Kind: kind, Pattern: pattern, Suggestion: strings.TrimSpace(suggestion), Message: strings.TrimSpace(message), Severity: severity}, nil
+}
+
+// Finding is one inline problem. Start and End are rune offsets into the
+// submitted text, which is what editors highlight.
+type Finding struct {
+	RulesetID  string
+	Kind       Kind
+	Severity   Severity
+	Start      int
+	End        int
+	Text       string
+	Suggestion string
+	Message    string
+}
+
+var (
+	markup  = regexp.MustCompile(`<[^>]*>`)
+	numbers = regexp.MustCompile(`\d[\d.,]*\d|\d`)
+)
+
+// maskMarkup blanks out tags so HTML drafts can be checked without shifting offsets
+func maskMarkup(text string) string {
+	return markup.ReplaceAllStringFunc(text, func(tag string) string {
+		return strings.Repeat(" ", len(tag))
+	})
+}
+
+// Domain Validation Functions
+
+// matchPhrase finds whole-word, case-insensitive occurrences as byte ranges
+func matchPhrase(text, phrase string) [][]int {
+	words := strings.Fields(phrase)
+	for i, w := range words {
+		words[i] = regexp.QuoteMeta(w)
+	}
+	pattern := regexp.MustCompile(`(?i)` + strings.Join(words, `\s+`))
+	var matches [][]int
+	for _, m := range pattern.FindAllStringIndex(text, -1) {
+		before, _ := utf8.DecodeLastRuneInString(text[:m[0]])
+		after, _ := utf8.DecodeRuneInString(text[m[1]:])
+		if isWordRune(before) || isWordRune(after) {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+var (
+	idThousandsComma = regexp.MustCompile(`^\d{1,3}(,\d{3})+$`)
+	idDecimalPoint   = regexp.MustCompile(`^\d+\.\d{1,2}$`)
+	enThousandsPoint = regexp.MustCompile(`^\d{1,3}(\.\d{3}){2,}$`)
+	enDecimalComma   = regexp.MustCompile(`^\d+,\d{1,2}$`)
+	ungrouped        = regexp.MustCompile(`^\d{5,}$`)
+)
+
+// numberSuggestion returns the house form of a number, "" when it is fine
+func numberSuggestion(number string, language Language) string {
+	switch language {
+	case LanguageIndonesian:
+		switch {
+		case idThousandsComma.MatchString(number):
+			return strings.ReplaceAll(number, ",", ".")
+		case idDecimalPoint.MatchString(number):
+			return strings.Replace(number, ".", ",", 1)
+		case ungrouped.MatchString(number):
+			return group(number, ".")
+		}
+	case LanguageEnglish:
+		switch {
+		case enThousandsPoint.MatchString(number):
+			return strings.ReplaceAll(number, ".", ",")
+		case enDecimalComma.MatchString(number):
+			return strings.Replace(number, ",", ".", 1)
+		case ungrouped.MatchString(number):
+			return group(number, ",")
+		}
+	}
+	return ""
+}
+
+func group(digits, separator string) string {
+	var sb strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteRune(d)
+	}
+	return sb.String()
+}
@@ -0,0 +1,220 @@
+package taxonomy
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Category is a section of the site, e.g. "News › Regional". Categories form a
+// tree at most MaxDepth levels deep and are shown in Position order among their
+// siblings. Each category stores the IDs of its ancestors, so depth and cycle
+// checks need no repository lookups.
+type Category struct {
+	ID            string
+	Slug          Slug
+	PreviousSlugs []Slug // Kept so old section links 301 to the current slug
+	Name          string
+	Description   string
+	ParentID      *string
+	Ancestors     []string // Root first, empty for a top-level category
+	Position      int      // Order among siblings, lowest first
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCategory creates a category under parent, or at the top level when parent is nil
+func NewCategory(id string, slug Slug, name, description string, parent *Category, position int, createdBy string) (*Category, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, ErrEmptyActorID
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	description, err = validateDescription(description)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Category{
+		ID:           id,
+		Slug:         slug,
+		Name:         name,
+		Description:  description,
+		Position:     max(position, 0),
+		LastActionBy: &createdBy,
+	}
+	if parent != nil {
+		if parent.Depth()+1 >= MaxDepth {
+			return nil, ErrTooDeep
+		}
+		c.ParentID = &parent.ID
+		c.Ancestors = parent.path()
+	}
+
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	return c, nil
+}
+
+// Business Methods
+
+func (c *Category) UpdateDetails(name, description, editorID string) error {
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyActorID
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return err
+	}
+	description, err = validateDescription(description)
+	if err != nil {
+		return err
+	}
+	c.Name = name
+	c.Description = description
+	c.touch(editorID)
+	return nil
+}
+
+// ChangeSlug moves the section, the old slug keeps redirecting
+func (c *Category) ChangeSlug(slug Slug, editorID string) error {
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyActorID
+	}
+	if c.Slug.Equals(slug) {
+		return ErrSameSlug
+	}
+	previous := make([]Slug, 0, len(c.PreviousSlugs)+1)
+	for _, s := range c.PreviousSlugs {
+		if !s.Equals(slug) {
+			previous = append(previous, s)
+		}
+	}
+	c.PreviousSlugs = append(previous, c.Slug)
+	c.Slug = slug
+	c.touch(editorID)
+	return nil
+}
+
+// MoveUnder re-parents the category, or makes it top-level when parent is nil.
+// descendants must be every category below this one; their ancestors are
+// rewritten too, so callers save them along with the category.
+func (c *Category) MoveUnder(parent *Category, descendants []*Category, editorID string) error {
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyActorID
+	}
+
+	var ancestors []string
+	var parentID *string
+	if parent != nil {
+		if parent.ID == c.ID || slices.Contains(parent.Ancestors, c.ID) {
+			return ErrCycle
+		}
+		ancestors = parent.path()
+		parentID = &parent.ID
+	}
+
+	// The deepest descendant must still fit once the subtree moves
+	height := 0
+	for _, d := range descendants {
+		if !d.IsDescendantOf(c.ID) {
+			return errors.New("descendants must sit below the category being moved")
+		}
+		height = max(height, d.Depth()-c.Depth())
+	}
+	if len(ancestors)+height >= MaxDepth {
+		return ErrTooDeep
+	}
+
+	oldDepth := c.Depth()
+	c.ParentID = parentID
+	c.Ancestors = ancestors
+	c.touch(editorID)
+	for _, d := range descendants {
+		// Keep the part of the path from this category down
+		d.Ancestors = append(c.path(), d.Ancestors[oldDepth+1:]...)
+		d.touch(editorID)
+	}
+	return nil
+}
+
+// CheckDeletable keeps the tree and published URLs intact: a category can only be
+// deleted once it has no subcategories and no published articles
+func (c *Category) CheckDeletable(subcategories int, publishedArticles int64) error {
+	if subcategories > 0 {
+		return ErrHasSubcategories
+	}
+	if publishedArticles > 0 {
+		return ErrHasPublishedArticles.With("published_articles", strconv.FormatInt(publishedArticles, 10))
+	}
+	return nil
+}
+
+// Reorder sets the position of siblings to their index in orderedIDs, which must
+// list each of them exactly once
+func Reorder(siblings []*Category, orderedIDs []string, editorID string) error {
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyActorID
+	}
+	if len(orderedIDs) != len(siblings) {
+		return ErrInvalidOrder
+	}
+	position := make(map[string]int, len(orderedIDs))
+	for i, id := range orderedIDs {
+		if _, ok := position[id]; ok {
+			return ErrInvalidOrder
+		}
+		position[id] = i
+	}
+	for _, s := range siblings {
+		if _, ok := position[s.ID]; !ok {
+			return ErrInvalidOrder
+		}
+	}
+
+	for _, s := range siblings {
+		if s.Position != position[s.ID] {
+			s.Position = position[s.ID]
+			s.touch(editorID)
+		}
+	}
+	return nil
+}
+
+// Query Methods
+
+// Depth is 0 for a top-level category
+func (c *Category) Depth() int {
+	return len(c.Ancestors)
+}
+
+func (c *Category) IsRoot() bool {
+	return c.ParentID == nil
+}
+
+// IsDescendantOf reports whether the category sits anywhere below ancestorID
+func (c *Category) IsDescendantOf(ancestorID string) bool {
+	return slices.Contains(c.Ancestors, ancestorID)
+}
+
+// path is the ancestors of a child of this category
+func (c *Category) path() []string {
+	return append(slices.Clone(c.Ancestors), c.ID)
+}
+
+func (c *Category) touch(editorID string) {
+	c.LastActionBy = &editorID
+	c.UpdatedAt = time.Now()
+}
@@ -0,0 +1,158 @@
+package taxonomy
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+func createTestCategory(t *testing.T, id, slug string, parent *Category) *Category {
+	t.Helper()
+	s, err := NewSlug(slug)
+	if err != nil {
+		t.Fatalf("failed to create slug: %v", err)
+	}
+	c, err := NewCategory(id, *s, "Category "+id, "", parent, 0, "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	return c
+}
+
+func TestNewCategory(t *testing.T) {
+	news := createTestCategory(t, "news", "news", nil)
+	regional := createTestCategory(t, "regional", "regional", news)
+	java := createTestCategory(t, "java", "java", regional)
+
+	if !news.IsRoot() || *regional.ParentID != "news" || !slices.Equal(java.Ancestors, []string{"news", "regional"}) {
+		t.Errorf("expected ancestors to follow the tree, got %v", java.Ancestors)
+	}
+	if java.Depth() != MaxDepth-1 || !java.IsDescendantOf("news") {
+		t.Errorf("expected java at the deepest level below news, got depth %d", java.Depth())
+	}
+
+	slug, _ := NewSlug("west-java")
+	if _, err := NewCategory("west-java", *slug, "West Java", "", java, 0, "editor-1"); !errors.Is(err, ErrTooDeep) {
+		t.Errorf("expected error '%v', got '%v'", ErrTooDeep, err)
+	}
+	if _, err := NewCategory("sports", *slug, " ", "", nil, 0, "editor-1"); !errors.Is(err, ErrNameEmpty) {
+		t.Errorf("expected error '%v', got '%v'", ErrNameEmpty, err)
+	}
+	if _, err := NewSlug("News & Politics"); !errors.Is(err, ErrInvalidSlug) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidSlug, err)
+	}
+}
+
+func TestCategory_MoveUnder(t *testing.T) {
+	news := createTestCategory(t, "news", "news", nil)
+	regional := createTestCategory(t, "regional", "regional", news)
+	java := createTestCategory(t, "java", "java", regional)
+	sports := createTestCategory(t, "sports", "sports", nil)
+	football := createTestCategory(t, "football", "football", sports)
+
+	if err := news.MoveUnder(java, []*Category{regional, java}, "editor-1"); !errors.Is(err, ErrCycle) {
+		t.Errorf("expected error '%v', got '%v'", ErrCycle, err)
+	}
+	if err := regional.MoveUnder(regional, []*Category{java}, "editor-1"); !errors.Is(err, ErrCycle) {
+		t.Errorf("expected error '%v', got '%v'", ErrCycle, err)
+	}
+	// Regional has a child, so it cannot go two levels down
+	if err := regional.MoveUnder(football, []*Category{java}, "editor-1"); !errors.Is(err, ErrTooDeep) {
+		t.Errorf("expected error '%v', got '%v'", ErrTooDeep, err)
+	}
+
+	if err := regional.MoveUnder(sports, []*Category{java}, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *regional.ParentID != "sports" || !slices.Equal(java.Ancestors, []string{"sports", "regional"}) {
+		t.Errorf("expected the subtree moved under sports, got %v", java.Ancestors)
+	}
+
+	if err := regional.MoveUnder(nil, []*Category{java}, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !regional.IsRoot() || !slices.Equal(java.Ancestors, []string{"regional"}) {
+		t.Errorf("expected regional at the top level, got %v", java.Ancestors)
+	}
+}
+
+func TestCategory_CheckDeletable(t *testing.T) {
+	news := createTestCategory(t, "news", "news", nil)
+
+	if err := news.CheckDeletable(1, 0); !errors.Is(err, ErrHasSubcategories) {
+		t.Errorf("expected error '%v', got '%v'", ErrHasSubcategories, err)
+	}
+	err := news.CheckDeletable(0, 12)
+	if !errors.Is(err, ErrHasPublishedArticles) {
+		t.Fatalf("expected error '%v', got '%v'", ErrHasPublishedArticles, err)
+	}
+	if de, _ := shared.AsDomainError(err); de.Meta["published_articles"] != "12" {
+		t.Errorf("expected the article count in the error, got %v", de.Meta)
+	}
+	if err := news.CheckDeletable(0, 0); err != nil {
+		t.Errorf("expected an empty category deletable, got %v", err)
+	}
+}
+
+func TestCategory_ChangeSlug(t *testing.T) {
+	news := createTestCategory(t, "news", "news", nil)
+	latest, _ := NewSlug("latest")
+
+	if err := news.ChangeSlug(news.Slug, "editor-1"); !errors.Is(err, ErrSameSlug) {
+		t.Errorf("expected error '%v', got '%v'", ErrSameSlug, err)
+	}
+	if err := news.ChangeSlug(*latest, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	old, _ := NewSlug("news")
+	if err := news.ChangeSlug(*old, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(news.PreviousSlugs) != 1 || news.PreviousSlugs[0].Value() != "latest" {
+		t.Errorf("expected only latest kept as a previous slug, got %v", news.PreviousSlugs)
+	}
+}
+
+func TestReorder(t *testing.T) {
+	a := createTestCategory(t, "a", "aa", nil)
+	b := createTestCategory(t, "b", "bb", nil)
+	c := createTestCategory(t, "c", "cc", nil)
+	siblings := []*Category{a, b, c}
+
+	testCases := []struct {
+		name    string
+		ordered []string
+	}{
+		{"missing sibling", []string{"c", "a"}},
+		{"duplicate", []string{"c", "a", "a"}},
+		{"stranger", []string{"c", "a", "x"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Reorder(siblings, tc.ordered, "editor-1"); !errors.Is(err, ErrInvalidOrder) {
+				t.Errorf("expected error '%v', got '%v'", ErrInvalidOrder, err)
+			}
+		})
+	}
+
+	if err := Reorder(siblings, []string{"c", "a", "b"}, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Position != 0 || a.Position != 1 || b.Position != 2 {
+		t.Errorf("expected positions c, a, b, got %d %d %d", c.Position, a.Position, b.Position)
+	}
+}
+
+func TestCheckSlugAvailable(t *testing.T) {
+	if err := CheckSlugAvailable("", "news"); err != nil {
+		t.Errorf("expected a free slug available, got %v", err)
+	}
+	if err := CheckSlugAvailable("news", "news"); err != nil {
+		t.Errorf("expected the owner to keep its slug, got %v", err)
+	}
+	if err := CheckSlugAvailable("news", "sports"); !errors.Is(err, ErrSlugTaken) {
+		t.Errorf("expected error '%v', got '%v'", ErrSlugTaken, err)
+	}
+}
@@ -0,0 +1,45 @@
+package taxonomy
+
+import "context"
+
+// Domain interface for categories (implementation will be in infrastructure layer)
+type CategoryRepository interface {
+	// Commands
+	Create(ctx context.Context, category *Category) error
+	// Update saves the categories together, a move rewrites a whole subtree
+	Update(ctx context.Context, categories ...*Category) error
+	Delete(ctx context.Context, id string) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*Category, error)
+	// FindBySlug also matches previous slugs, callers redirect when the slug differs
+	FindBySlug(ctx context.Context, slug string) (*Category, error)
+
+	// Query - Multiple
+	// FindChildren returns the direct subcategories in position order, parentID "" for the top level
+	FindChildren(ctx context.Context, parentID string) ([]*Category, error)
+	// FindDescendants returns every category below the given one
+	FindDescendants(ctx context.Context, id string) ([]*Category, error)
+	// FindTree returns all categories, parents before children and siblings in position order
+	FindTree(ctx context.Context) ([]*Category, error)
+}
+
+// Domain interface for tags (implementation will be in infrastructure layer)
+type TagRepository interface {
+	// Commands
+	Create(ctx context.Context, tag *Tag) error
+	Update(ctx context.Context, tag *Tag) error
+	Delete(ctx context.Context, id string) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Tag, error)
+	FindBySlug(ctx context.Context, slug string) (*Tag, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*Tag, error)
+	// Search matches names by prefix, for the editor's tag picker
+	Search(ctx context.Context, prefix string, limit int) ([]*Tag, error)
+}
+
+// PublishedArticleCounter guards category deletion (implemented by the article module)
+type PublishedArticleCounter interface {
+	CountPublishedInCategory(ctx context.Context, categoryID string) (int64, error)
+}
@@ -0,0 +1,87 @@
+package taxonomy
+
+import (
+	"strings"
+	"time"
+)
+
+// Tag is a flat label shared across sections, e.g. "flood" or "kpk". Unlike a
+// topic it has no page of its own beyond the tag listing.
+type Tag struct {
+	ID          string
+	Slug        Slug
+	Name        string
+	Description string
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewTag(id string, slug Slug, name, description, createdBy string) (*Tag, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, ErrEmptyActorID
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	description, err = validateDescription(description)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Tag{
+		ID:           id,
+		Slug:         slug,
+		Name:         name,
+		Description:  description,
+		LastActionBy: &createdBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Business Methods
+
+func (t *Tag) UpdateDetails(name, description, editorID string) error {
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyActorID
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return err
+	}
+	description, err = validateDescription(description)
+	if err != nil {
+		return err
+	}
+	t.Name = name
+	t.Description = description
+	t.touch(editorID)
+	return nil
+}
+
+// ChangeSlug renames the tag listing URL
+func (t *Tag) ChangeSlug(slug Slug, editorID string) error {
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyActorID
+	}
+	if t.Slug.Equals(slug) {
+		return ErrSameSlug
+	}
+	t.Slug = slug
+	t.touch(editorID)
+	return nil
+}
+
+func (t *Tag) touch(editorID string) {
+	t.LastActionBy = &editorID
+	t.UpdatedAt = time.Now()
+}
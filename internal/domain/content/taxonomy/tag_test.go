@@ -0,0 +1,52 @@
+package taxonomy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewTag(t *testing.T) {
+	slug, _ := NewSlug("flood")
+	testCases := []struct {
+		name          string
+		tagName       string
+		description   string
+		createdBy     string
+		expectedError error
+	}{
+		{"valid", " Flood ", "Coverage of floods", "editor-1", nil},
+		{"empty name", " ", "", "editor-1", ErrNameEmpty},
+		{"long name", strings.Repeat("a", MaxNameLength+1), "", "editor-1", ErrNameTooLong},
+		{"long description", "Flood", strings.Repeat("a", MaxDescriptionLength+1), "editor-1", ErrDescriptionTooLong},
+		{"no actor", "Flood", "", " ", ErrEmptyActorID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tag, err := NewTag("tag-1", *slug, tc.tagName, tc.description, tc.createdBy)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if err == nil && tag.Name != "Flood" {
+				t.Errorf("expected a trimmed name, got %q", tag.Name)
+			}
+		})
+	}
+}
+
+func TestTag_ChangeSlug(t *testing.T) {
+	slug, _ := NewSlug("banjir")
+	tag, _ := NewTag("tag-1", *slug, "Banjir", "", "editor-1")
+
+	if err := tag.ChangeSlug(*slug, "editor-1"); !errors.Is(err, ErrSameSlug) {
+		t.Errorf("expected error '%v', got '%v'", ErrSameSlug, err)
+	}
+	flood, _ := NewSlug("flood")
+	if err := tag.ChangeSlug(*flood, "editor-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tag.Slug.Value() != "flood" || *tag.LastActionBy != "editor-2" {
+		t.Errorf("expected slug flood changed by editor-2, got %+v", tag)
+	}
+}
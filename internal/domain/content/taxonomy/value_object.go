@@ -0,0 +1,90 @@
+package taxonomy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Taxonomy limits
+const (
+	MaxDepth             = 3 // Levels, e.g. News › Regional › Java
+	MaxNameLength        = 100
+	MaxDescriptionLength = 500
+)
+
+// Domain errors
+var (
+	ErrEmptyID              = shared.NewDomainError("taxonomy.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrEmptyActorID         = shared.NewDomainError("taxonomy.actor_required", shared.KindValidation, "actor ID cannot be empty")
+	ErrInvalidSlug          = shared.NewDomainError("taxonomy.invalid_slug", shared.KindValidation, "slug must be 2-80 lowercase letters, digits or single hyphens")
+	ErrNameEmpty            = shared.NewDomainError("taxonomy.name_required", shared.KindValidation, "name cannot be empty")
+	ErrNameTooLong          = shared.NewDomainError("taxonomy.name_too_long", shared.KindValidation, "name cannot exceed 100 characters")
+	ErrDescriptionTooLong   = shared.NewDomainError("taxonomy.description_too_long", shared.KindValidation, "description cannot exceed 500 characters")
+	ErrTooDeep              = shared.NewDomainError("taxonomy.too_deep", shared.KindValidation, "categories can nest at most 3 levels")
+	ErrCycle                = shared.NewDomainError("taxonomy.cycle", shared.KindValidation, "a category cannot be moved under itself or one of its subcategories")
+	ErrInvalidOrder         = shared.NewDomainError("taxonomy.invalid_order", shared.KindValidation, "the new order must list every sibling exactly once")
+	ErrSlugTaken            = shared.NewDomainError("taxonomy.slug_taken", shared.KindConflict, "slug is already in use")
+	ErrSameSlug             = shared.NewDomainError("taxonomy.same_slug", shared.KindConflict, "new slug is the same as current slug")
+	ErrHasSubcategories     = shared.NewDomainError("taxonomy.has_subcategories", shared.KindState, "category still has subcategories")
+	ErrHasPublishedArticles = shared.NewDomainError("taxonomy.has_published_articles", shared.KindState, "category still has published articles")
+)
+
+// Slug value object, the category or tag URL segment
+type Slug struct {
+	value string
+}
+
+func NewSlug(value string) (*Slug, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) < 2 || len(value) > 80 || !slugRegex.MatchString(value) {
+		return nil, ErrInvalidSlug
+	}
+	return &Slug{value: value}, nil
+}
+
+func (s Slug) String() string {
+	return s.value
+}
+
+func (s Slug) Value() string {
+	return s.value
+}
+
+func (s Slug) Equals(other Slug) bool {
+	return s.value == other.value
+}
+
+// CheckSlugAvailable enforces unique slugs. ownerID is whoever holds the slug now,
+// as found by the repository's FindBySlug, and empty when nobody does; id is the
+// category or tag that wants it.
+func CheckSlugAvailable(ownerID, id string) error {
+	if ownerID != "" && ownerID != id {
+		return ErrSlugTaken
+	}
+	return nil
+}
+
+// Domain Validation Functions
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrNameEmpty
+	}
+	if len([]rune(name)) > MaxNameLength {
+		return "", ErrNameTooLong
+	}
+	return name, nil
+}
+
+func validateDescription(description string) (string, error) {
+	description = strings.TrimSpace(description)
+	if len([]rune(description)) > MaxDescriptionLength {
+		return "", ErrDescriptionTooLong
+	}
+	return description, nil
+}
@@ -0,0 +1,155 @@
+package topic
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Topic is an editor-run landing page, e.g. "Corruption Eradication Commission".
+// Unlike a tag it has a curated description, pinned articles and followers.
+type Topic struct {
+	ID              string
+	Slug            Slug
+	PreviousSlugs   []Slug // Kept so old links 301 to the current slug
+	Name            string
+	Description     string
+	MetaDescription string // Search snippet, falls back to the description
+	Pinned          []string
+	Rule            Rule
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Follow is a member following a topic
+type Follow struct {
+	TopicID   string
+	AccountID string
+	CreatedAt time.Time
+}
+
+func NewTopic(id string, slug Slug, name, description string, rule Rule, createdBy string) (*Topic, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Topic{
+		ID:           id,
+		Slug:         slug,
+		Name:         name,
+		Description:  strings.TrimSpace(description),
+		Rule:         rule,
+		LastActionBy: &createdBy,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Business Methods
+
+func (t *Topic) UpdateDetails(name, description, metaDescription string, rule Rule, editorID string) error {
+	name, err := validateName(name)
+	if err != nil {
+		return err
+	}
+	metaDescription = strings.TrimSpace(metaDescription)
+	if len([]rune(metaDescription)) > 160 {
+		return ErrMetaDescriptionLong
+	}
+	t.Name = name
+	t.Description = strings.TrimSpace(description)
+	t.MetaDescription = metaDescription
+	t.Rule = rule
+	t.touch(editorID)
+	return nil
+}
+
+// ChangeSlug moves the landing page, the old slug keeps redirecting
+func (t *Topic) ChangeSlug(slug Slug, editorID string) error {
+	if t.Slug.Equals(slug) {
+		return errors.New("new slug is the same as current slug")
+	}
+	previous := make([]Slug, 0, len(t.PreviousSlugs)+1)
+	for _, s := range t.PreviousSlugs {
+		if !s.Equals(slug) {
+			previous = append(previous, s)
+		}
+	}
+	t.PreviousSlugs = append(previous, t.Slug)
+	t.Slug = slug
+	t.touch(editorID)
+	return nil
+}
+
+// SetPinned replaces the curated articles, in display order
+func (t *Topic) SetPinned(articleIDs []string, editorID string) error {
+	if len(articleIDs) > MaxPinned {
+		return ErrTooManyPinned
+	}
+	seen := make(map[string]struct{}, len(articleIDs))
+	for _, id := range articleIDs {
+		if strings.TrimSpace(id) == "" {
+			return errors.New("article ID cannot be empty")
+		}
+		if _, ok := seen[id]; ok {
+			return ErrDuplicatePinned
+		}
+		seen[id] = struct{}{}
+	}
+	t.Pinned = append([]string(nil), articleIDs...)
+	t.touch(editorID)
+	return nil
+}
+
+// Query Methods
+
+func (t *Topic) IsPinned(articleID string) bool {
+	for _, id := range t.Pinned {
+		if id == articleID {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchDescription is the meta description for the landing page
+func (t *Topic) SearchDescription() string {
+	if t.MetaDescription != "" {
+		return t.MetaDescription
+	}
+	runes := []rune(t.Description)
+	if len(runes) <= 160 {
+		return t.Description
+	}
+	return strings.TrimSpace(string(runes[:157])) + "..."
+}
+
+func (t *Topic) touch(editorID string) {
+	t.LastActionBy = &editorID
+	t.UpdatedAt = time.Now()
+}
+
+// Domain Validation Functions
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrNameEmpty
+	}
+	if len([]rune(name)) > 100 {
+		return "", ErrNameTooLong
+	}
+	return name, nil
+}
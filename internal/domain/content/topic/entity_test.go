@@ -0,0 +1,111 @@
+package topic
+
+import (
+	"strings"
+	"testing"
+)
+
+func createTestTopic(t *testing.T) *Topic {
+	t.Helper()
+	slug, _ := NewSlug("kpk")
+	rule, _ := NewRule([]string{"Corruption", "kpk"}, []string{"org:kpk"})
+	topic, err := NewTopic("topic-1", *slug, "Corruption Eradication Commission", "Coverage of the KPK.", *rule, "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	return topic
+}
+
+func TestRule_Matches(t *testing.T) {
+	rule, _ := NewRule([]string{" Corruption ", "kpk", "KPK"}, []string{"org:kpk"})
+
+	testCases := []struct {
+		name     string
+		tags     []string
+		entities []string
+		expected bool
+	}{
+		{"tag match ignores case", []string{"CORRUPTION"}, nil, true},
+		{"entity match", []string{"politics"}, []string{"org:kpk"}, true},
+		{"no overlap", []string{"sports"}, []string{"person:ronaldo"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := rule.Matches(tc.tags, tc.entities); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+	if len(rule.Tags) != 2 {
+		t.Errorf("expected tags deduplicated, got %v", rule.Tags)
+	}
+	if _, err := NewRule([]string{" "}, nil); err != ErrEmptyRule {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptyRule, err)
+	}
+}
+
+func TestTopic_ChangeSlug(t *testing.T) {
+	topic := createTestTopic(t)
+	renamed, _ := NewSlug("komisi-pemberantasan-korupsi")
+
+	if err := topic.ChangeSlug(*renamed, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(topic.PreviousSlugs) != 1 || topic.PreviousSlugs[0].Value() != "kpk" {
+		t.Errorf("expected kpk kept for redirects, got %v", topic.PreviousSlugs)
+	}
+
+	original, _ := NewSlug("kpk")
+	if err := topic.ChangeSlug(*original, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(topic.PreviousSlugs) != 1 || topic.PreviousSlugs[0].Value() != "komisi-pemberantasan-korupsi" {
+		t.Errorf("expected the current slug removed from previous slugs, got %v", topic.PreviousSlugs)
+	}
+}
+
+func TestTopic_SetPinned(t *testing.T) {
+	topic := createTestTopic(t)
+
+	testCases := []struct {
+		name          string
+		ids           []string
+		expectedError error
+	}{
+		{"valid", []string{"a1", "a2"}, nil},
+		{"duplicate", []string{"a1", "a1"}, ErrDuplicatePinned},
+		{"too many", []string{"a1", "a2", "a3", "a4", "a5", "a6"}, ErrTooManyPinned},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := topic.SetPinned(tc.ids, "editor-1"); err != tc.expectedError {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+	if !topic.IsPinned("a2") {
+		t.Error("expected a2 to stay pinned after rejected updates")
+	}
+}
+
+func TestTopic_SearchDescription(t *testing.T) {
+	topic := createTestTopic(t)
+	topic.Description = strings.Repeat("word ", 50)
+
+	if got := topic.SearchDescription(); len([]rune(got)) > 160 || !strings.HasSuffix(got, "...") {
+		t.Errorf("expected truncated description, got %q", got)
+	}
+
+	rule := topic.Rule
+	if err := topic.UpdateDetails(topic.Name, topic.Description, "Latest on the KPK", rule, "editor-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if topic.SearchDescription() != "Latest on the KPK" {
+		t.Errorf("expected meta description to win, got %q", topic.SearchDescription())
+	}
+	if err := topic.UpdateDetails(topic.Name, "", strings.Repeat("x", 161), rule, "editor-1"); err != ErrMetaDescriptionLong {
+		t.Errorf("expected error '%v', got '%v'", ErrMetaDescriptionLong, err)
+	}
+}
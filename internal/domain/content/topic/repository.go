@@ -0,0 +1,47 @@
+package topic
+
+import (
+	"context"
+	"time"
+)
+
+type TopicRepository interface {
+	// Commands
+	Create(ctx context.Context, topic *Topic) error
+	Update(ctx context.Context, topic *Topic) error
+	Delete(ctx context.Context, id string) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Topic, error)
+	// FindBySlug also matches previous slugs, callers redirect when the slug differs
+	FindBySlug(ctx context.Context, slug string) (*Topic, error)
+	FindAll(ctx context.Context) ([]*Topic, error)
+	// FindMatching returns topics whose rule shares a tag or entity with the article
+	FindMatching(ctx context.Context, tags, entities []string) ([]*Topic, error)
+}
+
+type FollowRepository interface {
+	// Commands, both idempotent
+	Follow(ctx context.Context, follow Follow) error
+	Unfollow(ctx context.Context, topicID, accountID string) error
+
+	// Queries
+	IsFollowing(ctx context.Context, topicID, accountID string) (bool, error)
+	CountFollowers(ctx context.Context, topicID string) (int64, error)
+	// FindFollowers pages through followers ordered by account ID, after "" starts from the beginning
+	FindFollowers(ctx context.Context, topicID, after string, limit int) ([]string, error)
+	FindTopicIDsByAccount(ctx context.Context, accountID string) ([]string, error)
+}
+
+// ArticleStream finds articles for the automatic part of topic pages (implemented by the article module)
+type ArticleStream interface {
+	// FindPublished returns summaries keyed by article ID, unpublished and unknown articles are left out
+	FindPublished(ctx context.Context, articleIDs []string) (map[string]ArticleSummary, error)
+	// FindMatching returns published articles matching the rule, newest first
+	FindMatching(ctx context.Context, rule Rule, excludeIDs []string, limit, offset int) ([]ArticleSummary, error)
+}
+
+// Domain interface for telling followers about new articles (implementation will be in the notification layer)
+type FollowerNotifier interface {
+	NotifyNewArticle(ctx context.Context, accountIDs []string, topic *Topic, article ArticleSummary, at time.Time) error
+}
@@ -0,0 +1,113 @@
+package topic
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// MaxPinned is how many curated articles sit above the automatic stream
+const MaxPinned = 5
+
+// Domain errors
+var (
+	ErrInvalidSlug         = errors.New("slug must be 2-80 lowercase letters, digits or single hyphens")
+	ErrNameEmpty           = errors.New("topic name cannot be empty")
+	ErrNameTooLong         = errors.New("topic name cannot exceed 100 characters")
+	ErrMetaDescriptionLong = errors.New("meta description cannot exceed 160 characters")
+	ErrTooManyPinned       = errors.New("a topic can pin at most 5 articles")
+	ErrDuplicatePinned     = errors.New("an article can be pinned only once")
+	ErrEmptyRule           = errors.New("a topic needs at least one tag or entity to match")
+)
+
+// Slug value object, the topic's landing page URL segment
+type Slug struct {
+	value string
+}
+
+func NewSlug(value string) (*Slug, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) < 2 || len(value) > 80 || !slugRegex.MatchString(value) {
+		return nil, ErrInvalidSlug
+	}
+	return &Slug{value: value}, nil
+}
+
+func (s Slug) String() string {
+	return s.value
+}
+
+func (s Slug) Value() string {
+	return s.value
+}
+
+func (s Slug) Equals(other Slug) bool {
+	return s.value == other.value
+}
+
+// Rule fills the automatic stream: articles carrying any of the tags or entities.
+// Tags are the newsroom's free-form labels, entities are extracted people,
+// organisations and places, e.g. "org:kpk".
+type Rule struct {
+	Tags     []string
+	Entities []string
+}
+
+func NewRule(tags, entities []string) (*Rule, error) {
+	rule := &Rule{Tags: normalize(tags), Entities: normalize(entities)}
+	if len(rule.Tags) == 0 && len(rule.Entities) == 0 {
+		return nil, ErrEmptyRule
+	}
+	return rule, nil
+}
+
+// Matches reports whether an article with the given tags and entities belongs in the stream
+func (r Rule) Matches(tags, entities []string) bool {
+	return overlaps(r.Tags, normalize(tags)) || overlaps(r.Entities, normalize(entities))
+}
+
+// ArticleSummary is what topic pages show for each article
+type ArticleSummary struct {
+	Ref     permalink.ArticleRef
+	Title   string
+	Excerpt string
+}
+
+// PublishedArticle is what the follower notification hook needs to know about a new article
+type PublishedArticle struct {
+	Summary  ArticleSummary
+	Tags     []string
+	Entities []string
+}
+
+func normalize(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+package translation
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Glossary holds the terms of one language pair, e.g. brand names that must
+// not be translated and desk terms with a fixed rendering
+type Glossary struct {
+	SourceLanguage string
+	TargetLanguage string
+	Terms          []Term
+
+	LastActionBy *string
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+func NewGlossary(source, target string, terms []Term, editorID string, at time.Time) (*Glossary, error) {
+	source, target, err := parsePair(source, target)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editor ID cannot be empty")
+	}
+	if len(terms) > MaxTerms {
+		return nil, ErrTooManyTerms
+	}
+	cleaned := make([]Term, 0, len(terms))
+	seen := map[string]bool{}
+	for _, t := range terms {
+		t.Source, t.Target = strings.TrimSpace(t.Source), strings.TrimSpace(t.Target)
+		if t.Source == "" || len(t.Source) > MaxTermLength || len(t.Target) > MaxTermLength {
+			return nil, ErrInvalidTerm
+		}
+		// The last entry wins, like a later line in a spreadsheet import
+		key := strings.ToLower(t.Source)
+		if seen[key] {
+			for i := range cleaned {
+				if strings.EqualFold(cleaned[i].Source, t.Source) {
+					cleaned[i] = t
+				}
+			}
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, t)
+	}
+	return &Glossary{SourceLanguage: source, TargetLanguage: target, Terms: cleaned, LastActionBy: &editorID, UpdatedAt: at}, nil
+}
+
+// Block is one top-level element of the target draft next to its source
+type Block struct {
+	Source      string
+	Text        string
+	Origin      Origin
+	ConfirmedBy *string
+	ConfirmedAt *time.Time
+}
+
+// NeedsConfirmation reports whether an editor still has to check the block
+func (b Block) NeedsConfirmation() bool {
+	return b.Origin == OriginMachine && b.ConfirmedBy == nil
+}
+
+// Draft is the target-language draft of a translation group, pre-filled from
+// the source article. It cannot be published while machine-translated blocks
+// are unconfirmed.
+type Draft struct {
+	ID              string
+	GroupID         string // Translation group of the source article
+	SourceArticleID string
+	SourceRevision  string
+	SourceLanguage  string
+	TargetLanguage  string
+	Provider        string
+	Blocks          []Block
+
+	LastActionBy *string
+
+	// Audit
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewDraft builds a draft from the source and the provider's translations of
+// its translatable blocks, in order
+func NewDraft(id string, source *Source, target, provider string, translated []string, editorID string, at time.Time) (*Draft, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editor ID cannot be empty")
+	}
+	sourceLanguage, target, err := parsePair(source.Language, target)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := SplitBlocks(source.Body)
+	d := &Draft{
+		ID:              id,
+		GroupID:         source.GroupID,
+		SourceArticleID: source.ArticleID,
+		SourceRevision:  source.Revision,
+		SourceLanguage:  sourceLanguage,
+		TargetLanguage:  target,
+		Provider:        provider,
+		Blocks:          make([]Block, 0, len(blocks)),
+		LastActionBy:    &editorID,
+		CreatedBy:       editorID,
+		CreatedAt:       at,
+		UpdatedAt:       at,
+	}
+	next := 0
+	for _, b := range blocks {
+		if !translatable(b) {
+			d.Blocks = append(d.Blocks, Block{Source: b, Text: b, Origin: OriginCopied})
+			continue
+		}
+		if next >= len(translated) {
+			return nil, ErrProviderMismatched
+		}
+		d.Blocks = append(d.Blocks, Block{Source: b, Text: restore(translated[next]), Origin: OriginMachine})
+		next++
+	}
+	if next != len(translated) {
+		return nil, ErrProviderMismatched
+	}
+	return d, nil
+}
+
+// Business Methods
+
+// Confirm records that an editor checked a machine-translated block as it is
+func (d *Draft) Confirm(index int, editorID string, at time.Time) error {
+	block, err := d.block(index)
+	if err != nil {
+		return err
+	}
+	if !block.NeedsConfirmation() {
+		return ErrNothingToConfirm
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return errors.New("editor ID cannot be empty")
+	}
+	block.ConfirmedBy = &editorID
+	block.ConfirmedAt = &at
+	d.touch(editorID, at)
+	return nil
+}
+
+// Edit replaces a block's text, an edited block counts as human translated
+func (d *Draft) Edit(index int, text, editorID string, at time.Time) error {
+	block, err := d.block(index)
+	if err != nil {
+		return err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ErrEmptyTranslation
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return errors.New("editor ID cannot be empty")
+	}
+	block.Text = text
+	block.Origin = OriginHuman
+	block.ConfirmedBy = &editorID
+	block.ConfirmedAt = &at
+	d.touch(editorID, at)
+	return nil
+}
+
+func (d *Draft) block(index int) (*Block, error) {
+	if index < 0 || index >= len(d.Blocks) {
+		return nil, ErrBlockNotFound
+	}
+	return &d.Blocks[index], nil
+}
+
+func (d *Draft) touch(editorID string, at time.Time) {
+	d.LastActionBy = &editorID
+	d.UpdatedAt = at
+}
+
+// Query Methods
+
+// Pending returns the indexes of blocks still waiting for an editor
+func (d *Draft) Pending() []int {
+	var pending []int
+	for i, b := range d.Blocks {
+		if b.NeedsConfirmation() {
+			pending = append(pending, i)
+		}
+	}
+	return pending
+}
+
+// CheckPublish is the guard the publish flow calls for translated drafts
+func (d *Draft) CheckPublish() error {
+	if len(d.Pending()) > 0 {
+		return ErrUnconfirmedBlocks
+	}
+	return nil
+}
+
+// Body joins the blocks into the target article body
+func (d *Draft) Body() string {
+	texts := make([]string, len(d.Blocks))
+	for i, b := range d.Blocks {
+		texts[i] = b.Text
+	}
+	return strings.Join(texts, "\n")
+}
+
+// Source is the source article as the article module hands it over
+type Source struct {
+	ArticleID string
+	GroupID   string
+	Revision  string
+	Language  string
+	Body      string
+}
+
+// Segments returns the blocks to send to a provider, with glossary terms
+// protected. Their translations go back into NewDraft in the same order.
+func (s *Source) Segments(glossary *Glossary) []string {
+	var terms []Term
+	if glossary != nil {
+		terms = glossary.Terms
+	}
+	var segments []string
+	for _, b := range SplitBlocks(s.Body) {
+		if translatable(b) {
+			segments = append(segments, protect(b, terms))
+		}
+	}
+	return segments
+}
+
+func parsePair(source, target string) (string, string, error) {
+	source, err := ParseLanguage(source)
+	if err != nil {
+		return "", "", err
+	}
+	if target, err = ParseLanguage(target); err != nil {
+		return "", "", err
+	}
+	if source == target {
+		return "", "", ErrSameLanguage
+	}
+	return source, target, nil
+}
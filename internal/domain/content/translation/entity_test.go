@@ -0,0 +1,80 @@
+package translation
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSource() *Source {
+	return &Source{
+		ArticleID: "a1",
+		GroupID:   "g1",
+		Revision:  "r3",
+		Language:  "id",
+		Body:      `<p>Harga beras naik.</p><figure><img src="beras.jpg"></figure><p>Bulog menambah stok.</p>`,
+	}
+}
+
+func TestNewDraft(t *testing.T) {
+	source := testSource()
+	segments := source.Segments(nil)
+	if len(segments) != 2 {
+		t.Fatalf("expected the image left out, got %q", segments)
+	}
+
+	d, err := NewDraft("d1", source, "en", "deepl", []string{"<p>Rice prices rise.</p>", "<p>Bulog adds stock.</p>"}, "editor-1", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.Blocks) != 3 || d.Blocks[1].Origin != OriginCopied || d.Blocks[2].Origin != OriginMachine {
+		t.Errorf("unexpected blocks %+v", d.Blocks)
+	}
+	if !strings.HasPrefix(d.Body(), "<p>Rice prices rise.</p>\n<figure>") {
+		t.Errorf("unexpected body %s", d.Body())
+	}
+
+	if _, err := NewDraft("d1", source, "en", "deepl", []string{"<p>Rice prices rise.</p>"}, "editor-1", time.Now()); err != ErrProviderMismatched {
+		t.Errorf("expected error '%v', got '%v'", ErrProviderMismatched, err)
+	}
+	if _, err := NewDraft("d1", source, "id", "deepl", nil, "editor-1", time.Now()); err != ErrSameLanguage {
+		t.Errorf("expected error '%v', got '%v'", ErrSameLanguage, err)
+	}
+}
+
+func TestDraft_ConfirmAndEdit(t *testing.T) {
+	at := time.Now()
+	d, _ := NewDraft("d1", testSource(), "en", "deepl", []string{"<p>Rice prices rise.</p>", "<p>Bulog adds stock.</p>"}, "editor-1", at)
+
+	if err := d.CheckPublish(); err != ErrUnconfirmedBlocks {
+		t.Fatalf("expected error '%v', got '%v'", ErrUnconfirmedBlocks, err)
+	}
+	if err := d.Confirm(0, "editor-2", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := d.Confirm(1, "editor-2", at); err != ErrNothingToConfirm {
+		t.Errorf("expected error '%v', got '%v'", ErrNothingToConfirm, err)
+	}
+	if err := d.Edit(2, "<p>Bulog increases its stock.</p>", "editor-2", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Blocks[2].Origin != OriginHuman || len(d.Pending()) != 0 || d.CheckPublish() != nil {
+		t.Errorf("expected every block confirmed, pending %v", d.Pending())
+	}
+	if err := d.Edit(7, "text", "editor-2", at); err != ErrBlockNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrBlockNotFound, err)
+	}
+}
+
+func TestNewGlossary(t *testing.T) {
+	g, err := NewGlossary("id", "en", []Term{{Source: "Pemilu", Target: "election"}, {Source: "pemilu", Target: "general election"}, {Source: " Kompas "}}, "editor-1", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(g.Terms) != 2 || g.Terms[0].Target != "general election" || g.Terms[1].Source != "Kompas" {
+		t.Errorf("unexpected terms %+v", g.Terms)
+	}
+	if _, err := NewGlossary("id", "en", []Term{{Source: ""}}, "editor-1", time.Now()); err != ErrInvalidTerm {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTerm, err)
+	}
+}
@@ -0,0 +1,28 @@
+package translation
+
+import "context"
+
+type DraftRepository interface {
+	Save(ctx context.Context, draft *Draft) error
+	FindByID(ctx context.Context, id string) (*Draft, error)
+	// FindByGroup returns the group's draft in the language, nil when there is none
+	FindByGroup(ctx context.Context, groupID, language string) (*Draft, error)
+}
+
+type GlossaryRepository interface {
+	Save(ctx context.Context, glossary *Glossary) error
+	Find(ctx context.Context, source, target string) (*Glossary, error) // nil when never set
+}
+
+// ArticleSource reads the source article (implemented by the article module)
+type ArticleSource interface {
+	// FindForTranslation returns nil when the article does not exist
+	FindForTranslation(ctx context.Context, articleID string) (*Source, error)
+}
+
+// Provider is a machine translation service, e.g. DeepL, Google or a self-hosted
+// NLLB (implementations will be in infrastructure layer). Segments are HTML and
+// come back in the same order.
+type Provider interface {
+	Translate(ctx context.Context, segments []string, source, target string) ([]string, error)
+}
@@ -0,0 +1,204 @@
+package translation
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Limits for glossaries
+const (
+	MaxTerms      = 500
+	MaxTermLength = 100
+)
+
+var languageRegex = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// Domain errors
+var (
+	ErrInvalidLanguage    = errors.New("language must be a two or three letter code")
+	ErrSameLanguage       = errors.New("target language must differ from the source language")
+	ErrInvalidTerm        = errors.New("glossary terms cannot be empty or longer than 100 characters")
+	ErrTooManyTerms       = errors.New("glossary cannot exceed 500 terms")
+	ErrBlockNotFound      = errors.New("block does not exist")
+	ErrNothingToConfirm   = errors.New("block does not need confirming")
+	ErrUnconfirmedBlocks  = errors.New("machine-translated blocks are not confirmed yet")
+	ErrEmptyTranslation   = errors.New("block translation cannot be empty")
+	ErrProviderMismatched = errors.New("provider returned a different number of blocks")
+)
+
+func ParseLanguage(value string) (string, error) {
+	l := strings.ToLower(strings.TrimSpace(value))
+	if !languageRegex.MatchString(l) {
+		return "", ErrInvalidLanguage
+	}
+	return l, nil
+}
+
+// Origin of a block's text in a draft
+type Origin string
+
+const (
+	OriginMachine Origin = "machine" // Needs an editor to confirm it
+	OriginHuman   Origin = "human"   // Written or edited by an editor
+	OriginCopied  Origin = "copied"  // Nothing to translate, e.g. an embed or image
+)
+
+// Term is a glossary entry. An empty Target keeps the source as written,
+// which is what brand and product names need.
+type Term struct {
+	Source string
+	Target string
+}
+
+func (t Term) output() string {
+	if t.Target == "" {
+		return t.Source
+	}
+	return t.Target
+}
+
+// glossaryMark wraps protected terms, providers leave translate="no" content alone in HTML mode
+const (
+	glossaryOpen  = `<span translate="no" data-glossary>`
+	glossaryClose = `</span>`
+)
+
+var glossaryRegex = regexp.MustCompile(`<span translate="no" data-glossary>(.*?)</span>`)
+
+// protect replaces glossary terms in the text outside tags with their output
+// wrapped so the provider leaves them alone. Longer terms win, so "Bank
+// Indonesia" is not split by a term "Indonesia".
+func protect(html string, terms []Term) string {
+	if len(terms) == 0 {
+		return html
+	}
+	sorted := slices.Clone(terms)
+	slices.SortStableFunc(sorted, func(a, b Term) int { return len(b.Source) - len(a.Source) })
+	patterns := make([]string, len(sorted))
+	for i, t := range sorted {
+		patterns[i] = regexp.QuoteMeta(t.Source)
+	}
+	re := regexp.MustCompile(`(?i)\b(?:` + strings.Join(patterns, "|") + `)\b`)
+
+	var b strings.Builder
+	for html != "" {
+		tag := strings.IndexByte(html, '<')
+		if tag < 0 {
+			tag = len(html)
+		}
+		b.WriteString(re.ReplaceAllStringFunc(html[:tag], func(match string) string {
+			for _, t := range sorted {
+				if strings.EqualFold(t.Source, match) {
+					return glossaryOpen + t.output() + glossaryClose
+				}
+			}
+			return match
+		}))
+		html = html[tag:]
+		end := strings.IndexByte(html, '>')
+		if end < 0 {
+			b.WriteString(html)
+			break
+		}
+		b.WriteString(html[:end+1])
+		html = html[end+1:]
+	}
+	return b.String()
+}
+
+// restore removes the marks protect added
+func restore(html string) string {
+	return glossaryRegex.ReplaceAllString(html, "$1")
+}
+
+// blockTags are the elements a body is split on, text between them is a block too
+var blockTags = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "ul": true, "ol": true, "figure": true, "table": true, "div": true, "aside": true, "pre": true,
+}
+
+// SplitBlocks splits an article body into its top-level blocks, joining them
+// back gives the body again apart from whitespace between blocks
+func SplitBlocks(body string) []string {
+	var blocks []string
+	rest := strings.TrimSpace(body)
+	for rest != "" {
+		name := openingTag(rest)
+		if !blockTags[name] {
+			// Loose text or inline markup runs until the next block element
+			next := nextBlockStart(rest)
+			blocks = append(blocks, strings.TrimSpace(rest[:next]))
+			rest = strings.TrimSpace(rest[next:])
+			continue
+		}
+		end := closingEnd(rest, name)
+		blocks = append(blocks, rest[:end])
+		rest = strings.TrimSpace(rest[end:])
+	}
+	return blocks
+}
+
+// openingTag returns the lowercased element name when s starts with an opening tag
+func openingTag(s string) string {
+	if len(s) < 2 || s[0] != '<' || s[1] == '/' {
+		return ""
+	}
+	end := strings.IndexAny(s[1:], " \t\n>/")
+	if end < 0 {
+		return ""
+	}
+	return strings.ToLower(s[1 : end+1])
+}
+
+func nextBlockStart(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '<' && blockTags[openingTag(s[i:])] {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// closingEnd returns the index after the tag closing the element s starts with,
+// counting nested elements of the same name. Unclosed elements run to the end.
+func closingEnd(s, name string) int {
+	lower := strings.ToLower(s)
+	depth := 0
+	for i := 0; i < len(lower); {
+		next := strings.IndexByte(lower[i:], '<')
+		if next < 0 {
+			break
+		}
+		i += next
+		switch {
+		case strings.HasPrefix(lower[i:], "</"+name+">"):
+			depth--
+			if depth == 0 {
+				return i + len(name) + 3
+			}
+		case openingTag(lower[i:]) == name:
+			depth++
+		}
+		i++
+	}
+	return len(s)
+}
+
+// translatable reports whether a block has text for a provider, embeds and
+// images are copied as they are
+func translatable(block string) bool {
+	inTag := false
+	for _, r := range block {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>':
+			inTag = false
+		case !inTag && r != ' ' && r != '\n' && r != '\t':
+			return true
+		}
+	}
+	return false
+}
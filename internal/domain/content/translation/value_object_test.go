@@ -0,0 +1,46 @@
+package translation
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitBlocks(t *testing.T) {
+	body := `Intro tanpa tag
+<p>Paragraf <b>satu</b>.</p>
+<div class="box"><div>nested</div> tail</div>
+<figure><img src="a.jpg"></figure><h2>Sub</h2>`
+
+	expected := []string{
+		"Intro tanpa tag",
+		"<p>Paragraf <b>satu</b>.</p>",
+		`<div class="box"><div>nested</div> tail</div>`,
+		`<figure><img src="a.jpg"></figure>`,
+		"<h2>Sub</h2>",
+	}
+	if got := SplitBlocks(body); !slices.Equal(got, expected) {
+		t.Errorf("unexpected blocks:\n%q", got)
+	}
+}
+
+func TestProtect(t *testing.T) {
+	terms := []Term{{Source: "Indonesia"}, {Source: "Bank Indonesia"}, {Source: "pemilu", Target: "general election"}}
+
+	got := protect(`<p title="Indonesia">Bank Indonesia menaikkan bunga jelang Pemilu.</p>`, terms)
+	expected := `<p title="Indonesia"><span translate="no" data-glossary>Bank Indonesia</span> menaikkan bunga jelang <span translate="no" data-glossary>general election</span>.</p>`
+	if got != expected {
+		t.Errorf("unexpected output:\n%s", got)
+	}
+	if restored := restore(got); restored != `<p title="Indonesia">Bank Indonesia menaikkan bunga jelang general election.</p>` {
+		t.Errorf("unexpected restore:\n%s", restored)
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	if l, err := ParseLanguage(" EN "); err != nil || l != "en" {
+		t.Errorf("expected en, got %q, %v", l, err)
+	}
+	if _, err := ParseLanguage("english"); err != ErrInvalidLanguage {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidLanguage, err)
+	}
+}
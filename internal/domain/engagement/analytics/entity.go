@@ -0,0 +1,68 @@
+package analytics
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventView         EventType = "view"
+	EventReadComplete EventType = "read_complete" // Reader scrolled past the end of the body
+	EventShare        EventType = "share"
+)
+
+// Event is one reader interaction with a published article
+type Event struct {
+	ID         string
+	Type       EventType
+	ArticleID  string
+	AuthorID   string
+	VisitorKey string // consent.Subject key of the reader
+	Region     string
+	Device     string
+	OccurredAt time.Time
+}
+
+func NewEvent(id string, eventType EventType, articleID, authorID, visitorKey string, occurredAt time.Time) (*Event, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if err := validateEventType(eventType); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(articleID) == "" {
+		return nil, errors.New("article ID cannot be empty")
+	}
+	if strings.TrimSpace(authorID) == "" {
+		return nil, errors.New("author ID cannot be empty")
+	}
+	if strings.TrimSpace(visitorKey) == "" {
+		return nil, errors.New("visitor key cannot be empty")
+	}
+	if occurredAt.IsZero() {
+		return nil, errors.New("occurred at cannot be empty")
+	}
+
+	return &Event{
+		ID:         id,
+		Type:       eventType,
+		ArticleID:  articleID,
+		AuthorID:   authorID,
+		VisitorKey: visitorKey,
+		OccurredAt: occurredAt.UTC(),
+	}, nil
+}
+
+// Domain Validation Functions
+
+func validateEventType(eventType EventType) error {
+	switch eventType {
+	case EventView, EventReadComplete, EventShare:
+		return nil
+	default:
+		return ErrInvalidEventType
+	}
+}
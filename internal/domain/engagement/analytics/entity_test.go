@@ -0,0 +1,50 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewEvent(t *testing.T) {
+	at := time.Now()
+
+	tests := []struct {
+		name       string
+		id         string
+		eventType  EventType
+		articleID  string
+		authorID   string
+		visitorKey string
+		occurredAt time.Time
+		wantErr    bool
+		errMsg     string
+	}{
+		{name: "valid", id: "e1", eventType: EventView, articleID: "a1", authorID: "u1", visitorKey: "visitor:1", occurredAt: at},
+		{name: "empty id", id: "", eventType: EventView, articleID: "a1", authorID: "u1", visitorKey: "visitor:1", occurredAt: at, wantErr: true, errMsg: "ID cannot be empty"},
+		{name: "invalid type", id: "e1", eventType: "click", articleID: "a1", authorID: "u1", visitorKey: "visitor:1", occurredAt: at, wantErr: true, errMsg: ErrInvalidEventType.Error()},
+		{name: "empty article", id: "e1", eventType: EventShare, articleID: "", authorID: "u1", visitorKey: "visitor:1", occurredAt: at, wantErr: true, errMsg: "article ID cannot be empty"},
+		{name: "empty author", id: "e1", eventType: EventShare, articleID: "a1", authorID: " ", visitorKey: "visitor:1", occurredAt: at, wantErr: true, errMsg: "author ID cannot be empty"},
+		{name: "empty visitor", id: "e1", eventType: EventShare, articleID: "a1", authorID: "u1", visitorKey: "", occurredAt: at, wantErr: true, errMsg: "visitor key cannot be empty"},
+		{name: "zero time", id: "e1", eventType: EventReadComplete, articleID: "a1", authorID: "u1", visitorKey: "visitor:1", wantErr: true, errMsg: "occurred at cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEvent(tt.id, tt.eventType, tt.articleID, tt.authorID, tt.visitorKey, tt.occurredAt)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e.OccurredAt.Location() != time.UTC {
+				t.Error("expected event time in UTC")
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package analytics
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interfaces for the analytics store (implementation will be in infrastructure layer)
+
+type EventWriter interface {
+	// WriteEvents persists a batch, implementations should prefer few large batches
+	WriteEvents(ctx context.Context, events []*Event) error
+}
+
+type TrendingQuery interface {
+	// Trending ranks articles by views in the window ending at 'at'
+	Trending(ctx context.Context, window time.Duration, at time.Time, limit int) ([]ArticleMetric, error)
+}
+
+type ArticleStatsQuery interface {
+	ArticleSeries(ctx context.Context, articleID string, r TimeRange, granularity Granularity) ([]Bucket, error)
+}
+
+type AuthorStatsQuery interface {
+	AuthorSummary(ctx context.Context, authorID string, r TimeRange, topLimit int) (*AuthorSummary, error)
+}
+
+// Store is the full analytics backend
+type Store interface {
+	EventWriter
+	TrendingQuery
+	ArticleStatsQuery
+	AuthorStatsQuery
+}
@@ -0,0 +1,97 @@
+package analytics
+
+import (
+	"errors"
+	"time"
+)
+
+// Domain errors
+var (
+	ErrInvalidEventType   = errors.New("invalid analytics event type")
+	ErrInvalidGranularity = errors.New("invalid granularity")
+	ErrInvalidRange       = errors.New("range start must be before range end")
+	ErrRangeTooLong       = errors.New("range cannot exceed 366 days")
+)
+
+type Granularity string
+
+const (
+	GranularityHour Granularity = "hour"
+	GranularityDay  Granularity = "day"
+)
+
+// MaxRange bounds per-article and per-author queries
+const MaxRange = 366 * 24 * time.Hour
+
+// TimeRange value object, a half-open interval [from, to)
+type TimeRange struct {
+	from time.Time
+	to   time.Time
+}
+
+func NewTimeRange(from, to time.Time) (*TimeRange, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+	if to.Sub(from) > MaxRange {
+		return nil, ErrRangeTooLong
+	}
+	return &TimeRange{from: from.UTC(), to: to.UTC()}, nil
+}
+
+func (r TimeRange) From() time.Time {
+	return r.from
+}
+
+func (r TimeRange) To() time.Time {
+	return r.to
+}
+
+func (r TimeRange) Contains(at time.Time) bool {
+	return !at.Before(r.from) && at.Before(r.to)
+}
+
+// Counters are the metrics every query returns
+type Counters struct {
+	Views          int64
+	UniqueVisitors int64
+	ReadCompletes  int64
+	Shares         int64
+}
+
+// CompletionRate is the share of views that were read to the end
+func (c Counters) CompletionRate() float64 {
+	if c.Views == 0 {
+		return 0
+	}
+	return float64(c.ReadCompletes) / float64(c.Views)
+}
+
+// ArticleMetric is one row of the trending list
+type ArticleMetric struct {
+	ArticleID string
+	Counters
+}
+
+// Bucket is one point of a time series
+type Bucket struct {
+	Start time.Time
+	Counters
+}
+
+// AuthorSummary aggregates an author's articles over a range
+type AuthorSummary struct {
+	AuthorID    string
+	Articles    int64
+	Totals      Counters
+	TopArticles []ArticleMetric
+}
+
+func ValidateGranularity(granularity Granularity) error {
+	switch granularity {
+	case GranularityHour, GranularityDay:
+		return nil
+	default:
+		return ErrInvalidGranularity
+	}
+}
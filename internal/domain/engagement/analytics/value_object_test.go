@@ -0,0 +1,51 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTimeRange(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		to          time.Time
+		expectedErr error
+	}{
+		{name: "valid", to: from.Add(24 * time.Hour)},
+		{name: "empty range", to: from, expectedErr: ErrInvalidRange},
+		{name: "reversed", to: from.Add(-time.Hour), expectedErr: ErrInvalidRange},
+		{name: "too long", to: from.Add(MaxRange + time.Hour), expectedErr: ErrRangeTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewTimeRange(from, tc.to)
+			if err != tc.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err == nil && (!r.Contains(from) || r.Contains(tc.to)) {
+				t.Error("expected half-open range")
+			}
+		})
+	}
+}
+
+func TestCounters_CompletionRate(t *testing.T) {
+	if rate := (Counters{}).CompletionRate(); rate != 0 {
+		t.Errorf("expected 0 for no views, got %f", rate)
+	}
+	if rate := (Counters{Views: 200, ReadCompletes: 50}).CompletionRate(); rate != 0.25 {
+		t.Errorf("expected 0.25, got %f", rate)
+	}
+}
+
+func TestValidateGranularity(t *testing.T) {
+	if err := ValidateGranularity(GranularityDay); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateGranularity("minute"); err != ErrInvalidGranularity {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidGranularity, err)
+	}
+}
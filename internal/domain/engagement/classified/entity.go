@@ -0,0 +1,297 @@
+package classified
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type ListingKind string
+
+const (
+	KindClassified ListingKind = "classified"
+	KindObituary   ListingKind = "obituary"
+)
+
+type ListingStatus string
+
+const (
+	StatusPendingPayment     ListingStatus = "pending_payment"
+	StatusAwaitingModeration ListingStatus = "awaiting_moderation"
+	StatusApproved           ListingStatus = "approved"
+	StatusRejected           ListingStatus = "rejected"
+	StatusPublished          ListingStatus = "published"
+	StatusExpired            ListingStatus = "expired"
+	StatusCancelled          ListingStatus = "cancelled"
+)
+
+type Listing struct {
+	ID        string
+	AccountID string
+	Kind      ListingKind
+	Title     string
+	Body      string
+	PhotoURL  *string
+
+	// Pricing & Payment
+	TierCode        string
+	Price           payment.Money
+	PaymentIntentID *string
+	PaidAt          *time.Time
+
+	// Moderation & Publication
+	Status          ListingStatus
+	Window          PublicationWindow
+	ModeratedBy     *string
+	ModeratedAt     *time.Time
+	RejectionReason *string
+	PublishedAt     *time.Time
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewListing creates a listing submitted by a membership account, priced by the given tier
+func NewListing(id string, submitter *account.UserAccount, kind ListingKind, title, body string, photoURL *string, tier PricingTier, window PublicationWindow) (*Listing, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if submitter == nil {
+		return nil, errors.New("submitter cannot be empty")
+	}
+	if !submitter.IsMembership() {
+		return nil, errors.New("only membership accounts can submit listings")
+	}
+	if !submitter.IsActive() {
+		return nil, errors.New("submitter account is not active")
+	}
+	if err := validateListingKind(kind); err != nil {
+		return nil, err
+	}
+	if tier.Kind() != kind {
+		return nil, ErrTierKindMismatch
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, errors.New("title cannot be empty")
+	}
+	if len(title) > 120 {
+		return nil, errors.New("title cannot exceed 120 characters")
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, errors.New("body cannot be empty")
+	}
+	if CountWords(body) > tier.MaxWords() {
+		return nil, ErrListingBodyTooLong
+	}
+
+	if photoURL != nil && !tier.AllowsPhoto() {
+		return nil, errors.New("pricing tier does not include a photo")
+	}
+
+	now := time.Now()
+	if window.Start().Before(now.Truncate(24 * time.Hour)) {
+		return nil, ErrWindowStartInThePast
+	}
+
+	price, err := tier.Quote(window)
+	if err != nil {
+		return nil, err
+	}
+
+	submitterID := submitter.ID
+	return &Listing{
+		ID:           id,
+		AccountID:    submitter.ID,
+		Kind:         kind,
+		Title:        title,
+		Body:         body,
+		PhotoURL:     photoURL,
+		TierCode:     tier.Code(),
+		Price:        price,
+		Status:       StatusPendingPayment,
+		Window:       window,
+		LastActionBy: &submitterID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Business Methods
+
+// AttachPaymentIntent links the provider payment intent created for this listing
+func (l *Listing) AttachPaymentIntent(intentID string) error {
+	if l.Status != StatusPendingPayment {
+		return errors.New("listing is not pending payment")
+	}
+	if strings.TrimSpace(intentID) == "" {
+		return errors.New("payment intent ID cannot be empty")
+	}
+
+	l.PaymentIntentID = &intentID
+	l.UpdatedAt = time.Now()
+	return nil
+}
+
+// ConfirmPayment moves a paid listing into the moderation queue
+func (l *Listing) ConfirmPayment(intent payment.PaymentIntent) error {
+	if l.Status != StatusPendingPayment {
+		return errors.New("listing is not pending payment")
+	}
+	if l.PaymentIntentID == nil || *l.PaymentIntentID != intent.ID {
+		return errors.New("payment intent does not belong to this listing")
+	}
+	if !intent.IsSucceeded() {
+		return errors.New("payment has not succeeded")
+	}
+	if !intent.Amount.Equals(l.Price) {
+		return errors.New("paid amount does not match listing price")
+	}
+
+	now := time.Now()
+	l.Status = StatusAwaitingModeration
+	l.PaidAt = &now
+	l.UpdatedAt = now
+	return nil
+}
+
+// Approve accepts a paid listing, it is published once its window starts
+func (l *Listing) Approve(moderatorID string) error {
+	if l.Status != StatusAwaitingModeration {
+		return errors.New("listing is not awaiting moderation")
+	}
+	if strings.TrimSpace(moderatorID) == "" {
+		return errors.New("moderator ID cannot be empty")
+	}
+
+	now := time.Now()
+	l.Status = StatusApproved
+	l.ModeratedBy = &moderatorID
+	l.ModeratedAt = &now
+	l.UpdatedAt = now
+	l.LastActionBy = &moderatorID
+	return nil
+}
+
+// Reject declines a paid listing, refunds are handled outside the aggregate
+func (l *Listing) Reject(moderatorID, reason string) error {
+	if l.Status != StatusAwaitingModeration {
+		return errors.New("listing is not awaiting moderation")
+	}
+	if strings.TrimSpace(moderatorID) == "" {
+		return errors.New("moderator ID cannot be empty")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return errors.New("reason cannot be empty")
+	}
+
+	now := time.Now()
+	l.Status = StatusRejected
+	l.ModeratedBy = &moderatorID
+	l.ModeratedAt = &now
+	l.RejectionReason = &reason
+	l.UpdatedAt = now
+	l.LastActionBy = &moderatorID
+	return nil
+}
+
+// Publish makes an approved listing visible, called by the publication scheduler
+func (l *Listing) Publish() error {
+	if l.Status != StatusApproved {
+		return errors.New("listing is not approved")
+	}
+
+	now := time.Now()
+	if !l.Window.HasStarted(now) {
+		return errors.New("publication window has not started")
+	}
+	if l.Window.HasEnded(now) {
+		return errors.New("publication window has already ended")
+	}
+
+	l.Status = StatusPublished
+	l.PublishedAt = &now
+	l.UpdatedAt = now
+	return nil
+}
+
+// Expire takes a published listing down once its window has ended
+func (l *Listing) Expire() error {
+	if l.Status != StatusPublished {
+		return errors.New("listing is not published")
+	}
+
+	now := time.Now()
+	if !l.Window.HasEnded(now) {
+		return errors.New("publication window has not ended")
+	}
+
+	l.Status = StatusExpired
+	l.UpdatedAt = now
+	return nil
+}
+
+// Cancel withdraws a listing before it has been paid
+func (l *Listing) Cancel(accountID string) error {
+	if l.Status != StatusPendingPayment {
+		return errors.New("only listings pending payment can be cancelled")
+	}
+	if accountID != l.AccountID {
+		return errors.New("only the submitter can cancel the listing")
+	}
+
+	l.Status = StatusCancelled
+	l.UpdatedAt = time.Now()
+	l.LastActionBy = &accountID
+	return nil
+}
+
+// Query Methods
+
+func (l *Listing) IsAwaitingModeration() bool {
+	return l.Status == StatusAwaitingModeration
+}
+
+func (l *Listing) IsPublished() bool {
+	return l.Status == StatusPublished
+}
+
+func (l *Listing) IsPaid() bool {
+	return l.PaidAt != nil
+}
+
+func (l *Listing) IsObituary() bool {
+	return l.Kind == KindObituary
+}
+
+// IsDueForPublication reports whether the scheduler should publish the listing now
+func (l *Listing) IsDueForPublication(at time.Time) bool {
+	return l.Status == StatusApproved && l.Window.Contains(at)
+}
+
+// IsDueForExpiry reports whether the scheduler should expire the listing now
+func (l *Listing) IsDueForExpiry(at time.Time) bool {
+	return l.Status == StatusPublished && l.Window.HasEnded(at)
+}
+
+// Domain Validation Functions
+
+func validateListingKind(kind ListingKind) error {
+	validKinds := map[ListingKind]bool{
+		KindClassified: true,
+		KindObituary:   true,
+	}
+	if !validKinds[kind] {
+		return errors.New("invalid listing kind")
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package classified
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestNewListing(t *testing.T) {
+	photo := "https://cdn.example.com/photo.jpg"
+
+	tests := []struct {
+		name      string
+		id        string
+		submitter func(t *testing.T) *account.UserAccount
+		kind      ListingKind
+		title     string
+		body      string
+		photoURL  *string
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name:      "valid obituary",
+			id:        "lst-1",
+			submitter: createTestMember,
+			kind:      KindObituary,
+			title:     "In memoriam John Doe",
+			body:      "Beloved father and grandfather",
+			wantErr:   false,
+		},
+		{
+			name:      "empty id",
+			id:        "",
+			submitter: createTestMember,
+			kind:      KindObituary,
+			title:     "In memoriam John Doe",
+			body:      "Beloved father",
+			wantErr:   true,
+			errMsg:    "ID cannot be empty",
+		},
+		{
+			name: "non membership submitter",
+			id:   "lst-1",
+			submitter: func(t *testing.T) *account.UserAccount {
+				acc, err := account.NewUserAccountForTesting("staff1", "staff_user", "staff@example.com", "StaffPass123!", account.TypeInternal, "admin123")
+				if err != nil {
+					t.Fatalf("failed to create staff account: %v", err)
+				}
+				return acc
+			},
+			kind:    KindObituary,
+			title:   "In memoriam John Doe",
+			body:    "Beloved father",
+			wantErr: true,
+			errMsg:  "only membership accounts can submit listings",
+		},
+		{
+			name: "unverified member",
+			id:   "lst-1",
+			submitter: func(t *testing.T) *account.UserAccount {
+				acc, err := account.NewUserAccountForSelfRegistration("member2", "member_two", "member2@example.com", "hashed_password")
+				if err != nil {
+					t.Fatalf("failed to create member account: %v", err)
+				}
+				return acc
+			},
+			kind:    KindObituary,
+			title:   "In memoriam John Doe",
+			body:    "Beloved father",
+			wantErr: true,
+			errMsg:  "submitter account is not active",
+		},
+		{
+			name:      "tier kind mismatch",
+			id:        "lst-1",
+			submitter: createTestMember,
+			kind:      KindClassified,
+			title:     "Car for sale",
+			body:      "Low mileage",
+			wantErr:   true,
+			errMsg:    ErrTierKindMismatch.Error(),
+		},
+		{
+			name:      "empty title",
+			id:        "lst-1",
+			submitter: createTestMember,
+			kind:      KindObituary,
+			title:     "   ",
+			body:      "Beloved father",
+			wantErr:   true,
+			errMsg:    "title cannot be empty",
+		},
+		{
+			name:      "body over word limit",
+			id:        "lst-1",
+			submitter: createTestMember,
+			kind:      KindObituary,
+			title:     "In memoriam John Doe",
+			body:      "one two three four five six",
+			wantErr:   true,
+			errMsg:    ErrListingBodyTooLong.Error(),
+		},
+		{
+			name:      "photo not included in tier",
+			id:        "lst-1",
+			submitter: createTestMember,
+			kind:      KindObituary,
+			title:     "In memoriam John Doe",
+			body:      "Beloved father",
+			photoURL:  &photo,
+			wantErr:   true,
+			errMsg:    "pricing tier does not include a photo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listing, err := NewListing(tt.id, tt.submitter(t), tt.kind, tt.title, tt.body, tt.photoURL, createTestTier(t), createTestWindow(t, 3))
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if listing.Status != StatusPendingPayment {
+				t.Errorf("expected status %s, got %s", StatusPendingPayment, listing.Status)
+			}
+			if listing.Price.Amount() != 150000 {
+				t.Errorf("expected price 150000, got %d", listing.Price.Amount())
+			}
+			if listing.TierCode != "obituary_basic" {
+				t.Errorf("expected tier code obituary_basic, got %s", listing.TierCode)
+			}
+		})
+	}
+}
+
+func TestListing_PaymentAndModeration(t *testing.T) {
+	listing := createTestListing(t)
+
+	if err := listing.Approve("mod1"); err == nil {
+		t.Error("expected error approving unpaid listing")
+	}
+
+	if err := listing.AttachPaymentIntent("pi_123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wrongAmount, _ := payment.NewMoney(1, "IDR")
+	err := listing.ConfirmPayment(payment.PaymentIntent{ID: "pi_123", Amount: *wrongAmount, Status: payment.IntentStatusSucceeded})
+	if err == nil || err.Error() != "paid amount does not match listing price" {
+		t.Errorf("expected amount mismatch error, got %v", err)
+	}
+
+	err = listing.ConfirmPayment(payment.PaymentIntent{ID: "pi_999", Amount: listing.Price, Status: payment.IntentStatusSucceeded})
+	if err == nil || err.Error() != "payment intent does not belong to this listing" {
+		t.Errorf("expected intent mismatch error, got %v", err)
+	}
+
+	err = listing.ConfirmPayment(payment.PaymentIntent{ID: "pi_123", Amount: listing.Price, Status: payment.IntentStatusFailed})
+	if err == nil || err.Error() != "payment has not succeeded" {
+		t.Errorf("expected unsucceeded payment error, got %v", err)
+	}
+
+	if err := listing.ConfirmPayment(payment.PaymentIntent{ID: "pi_123", Amount: listing.Price, Status: payment.IntentStatusSucceeded}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !listing.IsAwaitingModeration() || !listing.IsPaid() {
+		t.Error("expected paid listing to await moderation")
+	}
+
+	if err := listing.Reject("mod1", ""); err == nil || err.Error() != "reason cannot be empty" {
+		t.Errorf("expected empty reason error, got %v", err)
+	}
+
+	if err := listing.Approve("mod1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listing.Status != StatusApproved {
+		t.Errorf("expected status %s, got %s", StatusApproved, listing.Status)
+	}
+	if listing.ModeratedBy == nil || *listing.ModeratedBy != "mod1" {
+		t.Error("expected moderatedBy to be set")
+	}
+}
+
+func TestListing_Reject(t *testing.T) {
+	listing := createPaidListing(t)
+
+	if err := listing.Reject("mod1", "inappropriate content"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listing.Status != StatusRejected {
+		t.Errorf("expected status %s, got %s", StatusRejected, listing.Status)
+	}
+	if listing.RejectionReason == nil || *listing.RejectionReason != "inappropriate content" {
+		t.Error("expected rejection reason to be set")
+	}
+}
+
+func TestListing_PublicationSchedule(t *testing.T) {
+	listing := createPaidListing(t)
+	if err := listing.Approve("mod1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now()
+	if !listing.IsDueForPublication(now) {
+		t.Error("expected listing to be due for publication")
+	}
+	if err := listing.Publish(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !listing.IsPublished() || listing.PublishedAt == nil {
+		t.Error("expected listing to be published")
+	}
+
+	if err := listing.Expire(); err == nil || err.Error() != "publication window has not ended" {
+		t.Errorf("expected window not ended error, got %v", err)
+	}
+
+	// Simulate the window elapsing
+	start := now.Add(-72 * time.Hour)
+	window, _ := NewPublicationWindow(start, start.Add(48*time.Hour))
+	listing.Window = *window
+
+	if !listing.IsDueForExpiry(now) {
+		t.Error("expected listing to be due for expiry")
+	}
+	if err := listing.Expire(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listing.Status != StatusExpired {
+		t.Errorf("expected status %s, got %s", StatusExpired, listing.Status)
+	}
+}
+
+func TestListing_Cancel(t *testing.T) {
+	listing := createTestListing(t)
+
+	if err := listing.Cancel("someone_else"); err == nil || err.Error() != "only the submitter can cancel the listing" {
+		t.Errorf("expected submitter error, got %v", err)
+	}
+	if err := listing.Cancel("member123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listing.Status != StatusCancelled {
+		t.Errorf("expected status %s, got %s", StatusCancelled, listing.Status)
+	}
+}
+
+// Helper functions
+
+func createTestMember(t *testing.T) *account.UserAccount {
+	acc, err := account.NewUserAccountForSelfRegistration("member123", "member_user", "member@example.com", "hashed_password")
+	if err != nil {
+		t.Fatalf("failed to create member account: %v", err)
+	}
+	if err := acc.SelfVerify(); err != nil {
+		t.Fatalf("failed to verify member account: %v", err)
+	}
+	return acc
+}
+
+func createTestTier(t *testing.T) PricingTier {
+	price, err := payment.NewMoney(50000, "IDR")
+	if err != nil {
+		t.Fatalf("failed to create price: %v", err)
+	}
+	tier, err := NewPricingTier("obituary_basic", KindObituary, *price, 7, 5, false)
+	if err != nil {
+		t.Fatalf("failed to create tier: %v", err)
+	}
+	return *tier
+}
+
+func createTestWindow(t *testing.T, days int) PublicationWindow {
+	start := time.Now().Add(-time.Minute)
+	window, err := NewPublicationWindow(start, start.Add(time.Duration(days)*24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to create window: %v", err)
+	}
+	return *window
+}
+
+func createTestListing(t *testing.T) *Listing {
+	listing, err := NewListing("lst-1", createTestMember(t), KindObituary, "In memoriam John Doe", "Beloved father", nil, createTestTier(t), createTestWindow(t, 3))
+	if err != nil {
+		t.Fatalf("failed to create listing: %v", err)
+	}
+	return listing
+}
+
+func createPaidListing(t *testing.T) *Listing {
+	listing := createTestListing(t)
+	if err := listing.AttachPaymentIntent("pi_123"); err != nil {
+		t.Fatalf("failed to attach payment intent: %v", err)
+	}
+	if err := listing.ConfirmPayment(payment.PaymentIntent{ID: "pi_123", Amount: listing.Price, Status: payment.IntentStatusSucceeded}); err != nil {
+		t.Fatalf("failed to confirm payment: %v", err)
+	}
+	return listing
+}
@@ -0,0 +1,90 @@
+package classified
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type ListingFilter struct {
+	AccountID *string
+	Kind      *ListingKind
+	Status    *ListingStatus
+	TierCode  *string
+
+	// Publication window filters
+	ActiveAt *time.Time
+
+	// Pagination
+	Limit  int
+	Offset int
+
+	// Sorting
+	OrderBy   string
+	SortOrder string
+}
+
+// Validate filter parameters
+func (f *ListingFilter) Validate() error {
+	if f.Limit <= 0 || f.Limit > 100 {
+		return errors.New("limit must be between 1 and 100")
+	}
+	if f.Offset < 0 {
+		return errors.New("offset must be non-negative")
+	}
+
+	validOrderBy := map[string]bool{
+		"created_at":   true,
+		"updated_at":   true,
+		"paid_at":      true,
+		"window_start": true,
+	}
+	if f.OrderBy != "" && !validOrderBy[f.OrderBy] {
+		return errors.New("invalid order_by field")
+	}
+
+	if f.SortOrder != "" && f.SortOrder != "asc" && f.SortOrder != "desc" {
+		return errors.New("sort_order must be 'asc' or 'desc'")
+	}
+
+	return nil
+}
+
+// Set default values for pagination and sorting
+func (f *ListingFilter) SetDefaults() {
+	if f.Limit == 0 {
+		f.Limit = 20
+	}
+	if f.OrderBy == "" {
+		f.OrderBy = "created_at"
+	}
+	if f.SortOrder == "" {
+		f.SortOrder = "desc"
+	}
+}
+
+type ListingRepository interface {
+	// Commands
+	Create(ctx context.Context, listing *Listing) error
+	Update(ctx context.Context, listing *Listing) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*Listing, error)
+	FindByPaymentIntentID(ctx context.Context, intentID string) (*Listing, error)
+
+	// Query - Multiple with filters
+	Find(ctx context.Context, filter *ListingFilter) ([]*Listing, error)
+	Count(ctx context.Context, filter *ListingFilter) (int64, error)
+
+	// Moderation queue, paid listings ordered by payment time (oldest first)
+	FindModerationQueue(ctx context.Context, limit, offset int) ([]*Listing, error)
+
+	// Scheduler queries
+	FindDueForPublication(ctx context.Context, at time.Time) ([]*Listing, error)
+	FindDueForExpiry(ctx context.Context, at time.Time) ([]*Listing, error)
+}
+
+type PricingTierRepository interface {
+	FindByCode(ctx context.Context, code string) (*PricingTier, error)
+	FindByKind(ctx context.Context, kind ListingKind) ([]*PricingTier, error)
+}
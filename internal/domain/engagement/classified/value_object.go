@@ -0,0 +1,144 @@
+package classified
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+// Domain errors
+var (
+	ErrInvalidTierCode       = errors.New("pricing tier code cannot be empty")
+	ErrInvalidTierMaxDays    = errors.New("pricing tier max days must be greater than 0")
+	ErrInvalidTierMaxWords   = errors.New("pricing tier max words must be greater than 0")
+	ErrWindowEndBeforeStart  = errors.New("publication window end must be after start")
+	ErrWindowTooLong         = errors.New("publication window exceeds the pricing tier limit")
+	ErrListingBodyTooLong    = errors.New("listing body exceeds the pricing tier word limit")
+	ErrTierKindMismatch      = errors.New("pricing tier does not apply to this listing kind")
+	ErrWindowStartInThePast  = errors.New("publication window cannot start in the past")
+	ErrInvalidWindowDuration = errors.New("publication window must span at least one day")
+)
+
+// PricingTier value object, describes what a listing of a given kind costs per day
+type PricingTier struct {
+	code        string
+	kind        ListingKind
+	pricePerDay payment.Money
+	maxDays     int
+	maxWords    int
+	withPhoto   bool
+}
+
+func NewPricingTier(code string, kind ListingKind, pricePerDay payment.Money, maxDays, maxWords int, withPhoto bool) (*PricingTier, error) {
+	code = strings.TrimSpace(strings.ToLower(code))
+
+	if code == "" {
+		return nil, ErrInvalidTierCode
+	}
+	if err := validateListingKind(kind); err != nil {
+		return nil, err
+	}
+	if maxDays <= 0 {
+		return nil, ErrInvalidTierMaxDays
+	}
+	if maxWords <= 0 {
+		return nil, ErrInvalidTierMaxWords
+	}
+
+	return &PricingTier{
+		code:        code,
+		kind:        kind,
+		pricePerDay: pricePerDay,
+		maxDays:     maxDays,
+		maxWords:    maxWords,
+		withPhoto:   withPhoto,
+	}, nil
+}
+
+func (p PricingTier) Code() string {
+	return p.code
+}
+
+func (p PricingTier) Kind() ListingKind {
+	return p.kind
+}
+
+func (p PricingTier) PricePerDay() payment.Money {
+	return p.pricePerDay
+}
+
+func (p PricingTier) MaxDays() int {
+	return p.maxDays
+}
+
+func (p PricingTier) MaxWords() int {
+	return p.maxWords
+}
+
+func (p PricingTier) AllowsPhoto() bool {
+	return p.withPhoto
+}
+
+// Quote returns the total price for running a listing over the given window
+func (p PricingTier) Quote(window PublicationWindow) (payment.Money, error) {
+	if window.Days() > p.maxDays {
+		return payment.Money{}, ErrWindowTooLong
+	}
+	return p.pricePerDay.Multiply(int64(window.Days()))
+}
+
+// PublicationWindow value object, the period a listing is visible to readers
+type PublicationWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+func NewPublicationWindow(start, end time.Time) (*PublicationWindow, error) {
+	if !end.After(start) {
+		return nil, ErrWindowEndBeforeStart
+	}
+	if end.Sub(start) < 24*time.Hour {
+		return nil, ErrInvalidWindowDuration
+	}
+	return &PublicationWindow{start: start, end: end}, nil
+}
+
+func (w PublicationWindow) Start() time.Time {
+	return w.start
+}
+
+func (w PublicationWindow) End() time.Time {
+	return w.end
+}
+
+// Days returns the number of started days covered by the window
+func (w PublicationWindow) Days() int {
+	days := int(w.end.Sub(w.start) / (24 * time.Hour))
+	if w.end.Sub(w.start)%(24*time.Hour) != 0 {
+		days++
+	}
+	return days
+}
+
+func (w PublicationWindow) Contains(t time.Time) bool {
+	return !t.Before(w.start) && t.Before(w.end)
+}
+
+func (w PublicationWindow) HasStarted(t time.Time) bool {
+	return !t.Before(w.start)
+}
+
+func (w PublicationWindow) HasEnded(t time.Time) bool {
+	return !t.Before(w.end)
+}
+
+func (w PublicationWindow) Equals(other PublicationWindow) bool {
+	return w.start.Equal(other.start) && w.end.Equal(other.end)
+}
+
+// CountWords counts whitespace separated words, used for tier word limits
+func CountWords(text string) int {
+	return len(strings.Fields(text))
+}
@@ -0,0 +1,100 @@
+package classified
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+func TestNewPricingTier(t *testing.T) {
+	price, _ := payment.NewMoney(25000, "IDR")
+
+	testCases := []struct {
+		name        string
+		code        string
+		kind        ListingKind
+		maxDays     int
+		maxWords    int
+		expectedErr error
+	}{
+		{"valid tier", " Classified_Standard ", KindClassified, 14, 50, nil},
+		{"invalid - empty code", "  ", KindClassified, 14, 50, ErrInvalidTierCode},
+		{"invalid - zero days", "basic", KindClassified, 0, 50, ErrInvalidTierMaxDays},
+		{"invalid - zero words", "basic", KindClassified, 14, 0, ErrInvalidTierMaxWords},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tier, err := NewPricingTier(tc.code, tc.kind, *price, tc.maxDays, tc.maxWords, false)
+			if tc.expectedErr != nil {
+				if err != tc.expectedErr {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tier.Code() != "classified_standard" {
+				t.Errorf("expected normalized code, got '%s'", tier.Code())
+			}
+		})
+	}
+
+	if _, err := NewPricingTier("basic", "invalid_kind", *price, 1, 1, false); err == nil {
+		t.Error("expected error for invalid listing kind")
+	}
+}
+
+func TestPricingTier_Quote(t *testing.T) {
+	price, _ := payment.NewMoney(25000, "IDR")
+	tier, _ := NewPricingTier("basic", KindClassified, *price, 7, 50, false)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	window, _ := NewPublicationWindow(start, start.Add(3*24*time.Hour))
+	quote, err := tier.Quote(*window)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quote.Amount() != 75000 {
+		t.Errorf("expected quote 75000, got %d", quote.Amount())
+	}
+
+	// A partial day is charged as a full day
+	window, _ = NewPublicationWindow(start, start.Add(3*24*time.Hour+time.Hour))
+	quote, _ = tier.Quote(*window)
+	if quote.Amount() != 100000 {
+		t.Errorf("expected quote 100000, got %d", quote.Amount())
+	}
+
+	window, _ = NewPublicationWindow(start, start.Add(8*24*time.Hour))
+	if _, err := tier.Quote(*window); err != ErrWindowTooLong {
+		t.Errorf("expected error '%v', got '%v'", ErrWindowTooLong, err)
+	}
+}
+
+func TestNewPublicationWindow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := NewPublicationWindow(start, start); err != ErrWindowEndBeforeStart {
+		t.Errorf("expected error '%v', got '%v'", ErrWindowEndBeforeStart, err)
+	}
+	if _, err := NewPublicationWindow(start, start.Add(time.Hour)); err != ErrInvalidWindowDuration {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidWindowDuration, err)
+	}
+
+	window, err := NewPublicationWindow(start, start.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !window.Contains(start) {
+		t.Error("expected window to contain its start")
+	}
+	if window.Contains(start.Add(48 * time.Hour)) {
+		t.Error("expected window to exclude its end")
+	}
+	if !window.HasEnded(start.Add(48 * time.Hour)) {
+		t.Error("expected window to have ended at its end")
+	}
+}
@@ -0,0 +1,275 @@
+package comment
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxBodyLength keeps a single comment readable
+const MaxBodyLength = 5000
+
+// EditWindow is how long after posting an author can still fix their comment
+const EditWindow = 15 * time.Minute
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // Waiting for screening or a moderator, only the author sees it
+	StatusApproved Status = "approved" // Shown in the thread
+	StatusRejected Status = "rejected" // Removed by a moderator, replies stay visible
+	StatusSpam     Status = "spam"     // Removed as spam, kept for the spam filters
+	StatusDeleted  Status = "deleted"  // Removed by its author, replies stay visible
+)
+
+// transitions is the moderation state machine, every status change goes through it.
+// Moderators can overturn each other, but nobody brings back a deleted comment.
+var transitions = map[Status][]Status{
+	StatusPending:  {StatusApproved, StatusRejected, StatusSpam, StatusDeleted},
+	StatusApproved: {StatusRejected, StatusSpam, StatusDeleted},
+	StatusRejected: {StatusApproved, StatusSpam, StatusDeleted},
+	StatusSpam:     {StatusApproved, StatusDeleted},
+	StatusDeleted:  {},
+}
+
+// Comment is one node of an article's thread. Counts are denormalized so pages
+// can show "N replies" without counting subtrees.
+type Comment struct {
+	ID        string
+	ArticleID string
+	Author    Author
+	ParentID  *string
+	Path      Path
+	Body      string
+	Status    Status
+
+	// Denormalized counters
+	ReplyCount      int // Direct replies
+	DescendantCount int // Whole subtree
+	Upvotes         int
+	Downvotes       int
+	Score           float64 // WilsonScore, stored for the "best" index
+
+	// Moderation
+	ModeratedBy *string // Staff ID, or moderation.AutoModeratorID when screening decided
+	ModeratedAt *time.Time
+	EditedAt    *time.Time
+
+	// ImportRef is where a migrated comment came from, e.g. "disqus:1234567" or
+	// "archive:<original ID>", so running an import again skips it
+	ImportRef *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewComment creates a top-level comment, seq is the next free top-level sequence of the article
+func NewComment(id, articleID string, author Author, body string, seq uint32) (*Comment, error) {
+	path, err := NewRootPath(seq)
+	if err != nil {
+		return nil, err
+	}
+	return newComment(id, articleID, author, body, nil, *path)
+}
+
+// NewReply creates a reply, seq is the next free sequence under the parent
+func NewReply(id string, parent *Comment, author Author, body string, seq uint32) (*Comment, error) {
+	if parent == nil {
+		return nil, errors.New("parent comment cannot be empty")
+	}
+	if parent.Path.Depth()+1 > MaxDepth {
+		return nil, errors.New("reply is nested too deeply")
+	}
+
+	path, err := parent.Path.Child(seq)
+	if err != nil {
+		return nil, err
+	}
+	parentID := parent.ID
+	return newComment(id, parent.ArticleID, author, body, &parentID, *path)
+}
+
+// newComment starts every comment pending, screening approves or holds it
+func newComment(id, articleID string, author Author, body string, parentID *string, path Path) (*Comment, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(articleID) == "" {
+		return nil, errors.New("article ID cannot be empty")
+	}
+	if err := author.validate(); err != nil {
+		return nil, err
+	}
+	body, err := validateBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Comment{
+		ID:        id,
+		ArticleID: articleID,
+		Author:    author,
+		ParentID:  parentID,
+		Path:      path,
+		Body:      body,
+		Status:    StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// NewImportedComment creates a migrated comment with its original posting time,
+// a reply when parent is set. It starts pending like any other comment.
+func NewImportedComment(id, articleID string, author Author, body string, parent *Comment, seq uint32, ref string, postedAt time.Time) (*Comment, error) {
+	if strings.TrimSpace(ref) == "" {
+		return nil, errors.New("import reference cannot be empty")
+	}
+	var c *Comment
+	var err error
+	if parent == nil {
+		c, err = NewComment(id, articleID, author, body, seq)
+	} else {
+		if parent.ArticleID != articleID {
+			return nil, errors.New("parent comment belongs to another article")
+		}
+		c, err = NewReply(id, parent, author, body, seq)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.ImportRef = &ref
+	c.CreatedAt = postedAt
+	c.UpdatedAt = postedAt
+	return c, nil
+}
+
+func validateBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", ErrBodyEmpty
+	}
+	if len(body) > MaxBodyLength {
+		return "", ErrBodyTooLong
+	}
+	return body, nil
+}
+
+// Business Methods
+
+// CanReceiveReplies reports whether the reply button should be shown
+func (c *Comment) CanReceiveReplies() bool {
+	return c.Path.Depth() < MaxDepth && c.Status == StatusApproved
+}
+
+// Vote applies a vote delta, e.g. (+1, 0) for a new upvote or (-1, +1) for a switched vote
+func (c *Comment) Vote(upDelta, downDelta int) error {
+	if c.Upvotes+upDelta < 0 || c.Downvotes+downDelta < 0 {
+		return errors.New("vote count cannot be negative")
+	}
+	c.Upvotes += upDelta
+	c.Downvotes += downDelta
+	c.Score = WilsonScore(c.Upvotes, c.Downvotes)
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// Approve publishes the comment, moderatorID is moderation.AutoModeratorID when screening let it through
+func (c *Comment) Approve(moderatorID string, at time.Time) error {
+	return c.moderate(StatusApproved, moderatorID, at)
+}
+
+func (c *Comment) Reject(moderatorID string, at time.Time) error {
+	return c.moderate(StatusRejected, moderatorID, at)
+}
+
+func (c *Comment) MarkSpam(moderatorID string, at time.Time) error {
+	return c.moderate(StatusSpam, moderatorID, at)
+}
+
+func (c *Comment) moderate(status Status, moderatorID string, at time.Time) error {
+	if strings.TrimSpace(moderatorID) == "" {
+		return errors.New("moderator ID cannot be empty")
+	}
+	if err := c.transition(status, at); err != nil {
+		return err
+	}
+	c.ModeratedBy = &moderatorID
+	c.ModeratedAt = &at
+	return nil
+}
+
+// Edit replaces the body within the EditWindow. Only signed-in authors can
+// edit, guests have no way to prove it is them. An approved comment goes back
+// to pending so the new text is screened again.
+func (c *Comment) Edit(accountID, body string, at time.Time) error {
+	if c.Status != StatusPending && c.Status != StatusApproved {
+		return ErrNotEditable
+	}
+	if !c.IsAuthoredBy(accountID) {
+		return ErrNotAuthor
+	}
+	if at.Sub(c.CreatedAt) > EditWindow {
+		return ErrEditWindowClosed
+	}
+	body, err := validateBody(body)
+	if err != nil {
+		return err
+	}
+
+	if c.Status == StatusApproved {
+		c.Status = StatusPending
+		c.ModeratedBy, c.ModeratedAt = nil, nil
+	}
+	c.Body = body
+	c.EditedAt = &at
+	c.UpdatedAt = at
+	return nil
+}
+
+// Delete blanks the body but keeps the node so the thread structure survives
+func (c *Comment) Delete(accountID string) error {
+	if c.Status == StatusDeleted {
+		return errors.New("comment is already deleted")
+	}
+	if !c.IsAuthoredBy(accountID) {
+		return errors.New("only the author can delete the comment")
+	}
+	if err := c.transition(StatusDeleted, time.Now()); err != nil {
+		return err
+	}
+	c.Body = ""
+	return nil
+}
+
+func (c *Comment) transition(to Status, at time.Time) error {
+	if !slices.Contains(transitions[c.Status], to) {
+		return ErrInvalidTransition
+	}
+	c.Status = to
+	c.UpdatedAt = at
+	return nil
+}
+
+// Query Methods
+
+func (c *Comment) IsTopLevel() bool {
+	return c.ParentID == nil
+}
+
+// IsVisible reports whether readers see the comment in the thread
+func (c *Comment) IsVisible() bool {
+	return c.Status == StatusApproved
+}
+
+// IsRemoved reports whether the comment shows as a placeholder for its replies
+func (c *Comment) IsRemoved() bool {
+	return c.Status == StatusRejected || c.Status == StatusSpam || c.Status == StatusDeleted
+}
+
+// IsAuthoredBy reports whether the signed-in account wrote the comment, never true for guests
+func (c *Comment) IsAuthoredBy(accountID string) bool {
+	return !c.Author.IsGuest() && accountID != "" && c.Author.AccountID == accountID
+}
@@ -0,0 +1,254 @@
+package comment
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		articleID string
+		authorID  string
+		body      string
+		wantErr   bool
+		errMsg    string
+	}{
+		{name: "valid", id: "c1", articleID: "a1", authorID: "u1", body: "Great reporting"},
+		{name: "empty id", id: "", articleID: "a1", authorID: "u1", body: "x", wantErr: true, errMsg: "ID cannot be empty"},
+		{name: "empty article", id: "c1", articleID: "", authorID: "u1", body: "x", wantErr: true, errMsg: "article ID cannot be empty"},
+		{name: "empty author", id: "c1", articleID: "a1", authorID: " ", body: "x", wantErr: true, errMsg: "author ID cannot be empty"},
+		{name: "empty body", id: "c1", articleID: "a1", authorID: "u1", body: "  ", wantErr: true, errMsg: ErrBodyEmpty.Error()},
+		{name: "body too long", id: "c1", articleID: "a1", authorID: "u1", body: strings.Repeat("a", MaxBodyLength+1), wantErr: true, errMsg: ErrBodyTooLong.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewComment(tt.id, tt.articleID, AccountAuthor(tt.authorID), tt.body, 1)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !c.IsTopLevel() || c.Status != StatusPending || c.IsVisible() {
+				t.Error("expected pending top-level comment")
+			}
+		})
+	}
+}
+
+func TestNewReply_MaxDepth(t *testing.T) {
+	parent, _ := NewComment("c0", "a1", AccountAuthor("u1"), "root", 1)
+
+	for depth := 1; depth <= MaxDepth; depth++ {
+		reply, err := NewReply(fmt.Sprintf("c%d", depth), parent, AccountAuthor("u2"), "reply", 1)
+		if err != nil {
+			t.Fatalf("depth %d: unexpected error: %v", depth, err)
+		}
+		_ = reply.Approve("mod-1", time.Now())
+		if *reply.ParentID != parent.ID || reply.ArticleID != "a1" || reply.Path.Depth() != depth {
+			t.Errorf("depth %d: unexpected reply %+v", depth, reply)
+		}
+		parent = reply
+	}
+
+	if parent.CanReceiveReplies() {
+		t.Error("expected deepest comment not to accept replies")
+	}
+	if _, err := NewReply("too-deep", parent, AccountAuthor("u2"), "reply", 1); err == nil {
+		t.Error("expected error for reply beyond max depth")
+	}
+}
+
+func TestComment_VoteAndModeration(t *testing.T) {
+	c, _ := NewComment("c1", "a1", AccountAuthor("u1"), "Great reporting", 1)
+	at := time.Now()
+
+	if c.CanReceiveReplies() {
+		t.Error("expected pending comment not to accept replies")
+	}
+	if err := c.Approve("mod-1", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.CanReceiveReplies() || *c.ModeratedBy != "mod-1" {
+		t.Error("expected approved comment to accept replies")
+	}
+
+	if err := c.Vote(3, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Score != WilsonScore(3, 1) {
+		t.Error("expected score to be recomputed")
+	}
+	if err := c.Vote(0, -2); err == nil {
+		t.Error("expected error for negative vote count")
+	}
+
+	if err := c.Reject("mod-2", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.CanReceiveReplies() || !c.IsRemoved() {
+		t.Error("expected rejected comment not to accept replies")
+	}
+	if err := c.Approve("mod-1", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Delete("u2"); err == nil {
+		t.Error("expected error deleting someone else's comment")
+	}
+	if err := c.Delete("u1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Body != "" || c.Status != StatusDeleted {
+		t.Error("expected body to be blanked")
+	}
+	if err := c.Approve("mod-1", at); err != ErrInvalidTransition {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTransition, err)
+	}
+}
+
+func TestComment_Transitions(t *testing.T) {
+	at := time.Now()
+	tests := []struct {
+		name    string
+		from    Status
+		apply   func(c *Comment) error
+		want    Status
+		wantErr error
+	}{
+		{"pending to approved", StatusPending, func(c *Comment) error { return c.Approve("mod-1", at) }, StatusApproved, nil},
+		{"pending to spam", StatusPending, func(c *Comment) error { return c.MarkSpam("mod-1", at) }, StatusSpam, nil},
+		{"approved to rejected", StatusApproved, func(c *Comment) error { return c.Reject("mod-1", at) }, StatusRejected, nil},
+		{"rejected overturned", StatusRejected, func(c *Comment) error { return c.Approve("mod-1", at) }, StatusApproved, nil},
+		{"spam released", StatusSpam, func(c *Comment) error { return c.Approve("mod-1", at) }, StatusApproved, nil},
+		{"spam cannot be rejected", StatusSpam, func(c *Comment) error { return c.Reject("mod-1", at) }, StatusSpam, ErrInvalidTransition},
+		{"approved twice", StatusApproved, func(c *Comment) error { return c.Approve("mod-1", at) }, StatusApproved, ErrInvalidTransition},
+		{"deleted is final", StatusDeleted, func(c *Comment) error { return c.Approve("mod-1", at) }, StatusDeleted, ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := NewComment("c1", "a1", AccountAuthor("u1"), "text", 1)
+			c.Status = tt.from
+			if err := tt.apply(c); err != tt.wantErr {
+				t.Fatalf("expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if c.Status != tt.want {
+				t.Errorf("expected status %s, got %s", tt.want, c.Status)
+			}
+		})
+	}
+
+	c, _ := NewComment("c1", "a1", AccountAuthor("u1"), "text", 1)
+	if err := c.Approve(" ", at); err == nil {
+		t.Error("expected error for an empty moderator")
+	}
+}
+
+func TestComment_Edit(t *testing.T) {
+	c, _ := NewComment("c1", "a1", AccountAuthor("u1"), "Grat reporting", 1)
+	posted := c.CreatedAt
+	_ = c.Approve("mod-1", posted)
+
+	if err := c.Edit("u2", "Great reporting", posted.Add(time.Minute)); err != ErrNotAuthor {
+		t.Errorf("expected error '%v', got '%v'", ErrNotAuthor, err)
+	}
+	if err := c.Edit("u1", " ", posted.Add(time.Minute)); err != ErrBodyEmpty {
+		t.Errorf("expected error '%v', got '%v'", ErrBodyEmpty, err)
+	}
+	if err := c.Edit("u1", "Great reporting", posted.Add(EditWindow+time.Second)); err != ErrEditWindowClosed {
+		t.Errorf("expected error '%v', got '%v'", ErrEditWindowClosed, err)
+	}
+	if err := c.Edit("u1", "Great reporting", posted.Add(EditWindow)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Body != "Great reporting" || c.Status != StatusPending || c.ModeratedBy != nil || c.EditedAt == nil {
+		t.Errorf("expected the edit to go back to screening, got %+v", c)
+	}
+
+	_ = c.MarkSpam("mod-1", posted)
+	if err := c.Edit("u1", "Great reporting!", posted.Add(time.Minute)); err != ErrNotEditable {
+		t.Errorf("expected error '%v', got '%v'", ErrNotEditable, err)
+	}
+}
+
+func TestComment_GuestAuthor(t *testing.T) {
+	guest, err := NewGuestAuthor(" Sari ", "Sari@Example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c, err := NewComment("c1", "a1", *guest, "Posted as a guest", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.Author.IsGuest() || c.Author.GuestName != "Sari" || c.Author.Key() != "guest:sari@example.com" {
+		t.Errorf("unexpected guest author %+v", c.Author)
+	}
+	// Guests cannot prove who they are later, so they can neither edit nor delete
+	if c.IsAuthoredBy("") {
+		t.Error("expected a guest comment not to match an empty account")
+	}
+	if err := c.Edit("", "Edited", c.CreatedAt); err != ErrNotAuthor {
+		t.Errorf("expected error '%v', got '%v'", ErrNotAuthor, err)
+	}
+	if err := c.Delete(""); err == nil {
+		t.Error("expected error deleting a guest comment")
+	}
+
+	tests := []struct {
+		name    string
+		author  Author
+		wantErr bool
+	}{
+		{"empty guest name", Author{GuestEmail: "sari@example.com"}, true},
+		{"long guest name", Author{GuestName: strings.Repeat("a", MaxGuestNameLength+1), GuestEmail: "sari@example.com"}, true},
+		{"invalid guest email", Author{GuestName: "Sari", GuestEmail: "sari"}, true},
+		{"no author", Author{}, true},
+		{"account", AccountAuthor("u1"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewComment("c1", "a1", tt.author, "text", 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewImportedComment(t *testing.T) {
+	postedAt := time.Date(2019, time.May, 2, 8, 30, 0, 0, time.UTC)
+
+	root, err := NewImportedComment("c1", "a1", AccountAuthor("u1"), "First!", nil, 1, RefDisqus+"1001", postedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !root.CreatedAt.Equal(postedAt) || *root.ImportRef != "disqus:1001" || root.Status != StatusPending {
+		t.Errorf("unexpected imported comment %+v", root)
+	}
+
+	reply, err := NewImportedComment("c2", "a1", AccountAuthor("u2"), "Agreed", root, 1, RefDisqus+"1002", postedAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *reply.ParentID != "c1" || reply.Path.Depth() != 1 {
+		t.Errorf("expected a reply under c1, got %+v", reply)
+	}
+
+	if _, err := NewImportedComment("c3", "a2", AccountAuthor("u2"), "Agreed", root, 2, RefDisqus+"1003", postedAt); err == nil {
+		t.Error("expected error for a parent on another article")
+	}
+	if _, err := NewImportedComment("c3", "a1", AccountAuthor("u2"), "Agreed", nil, 2, " ", postedAt); err == nil {
+		t.Error("expected error for an empty reference")
+	}
+}
@@ -0,0 +1,171 @@
+package comment
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// ImportModeratorID approves migrated comments that were already public on the legacy platform
+const ImportModeratorID = "comment-import"
+
+// ImportedGuestDomain is the placeholder mail domain for legacy authors who left no
+// email. It is reserved (RFC 2606), mail sent to it never leaves the building.
+const ImportedGuestDomain = "imported.invalid"
+
+// Import reference prefixes
+const (
+	RefArchive = "archive:" // A member's own archive, followed by the original comment ID
+	RefDisqus  = "disqus:"  // A Disqus export, followed by the post ID
+)
+
+// Archive is a member's comments and reactions, what data portability hands out
+// and takes back. The wire format is documented with the HTTP handler.
+type Archive struct {
+	AccountID  string
+	ExportedAt time.Time
+	Comments   []ArchivedComment
+	Reactions  []ArchivedReaction
+}
+
+// ArchivedComment is a comment as exported, deleted comments keep their place without a body
+type ArchivedComment struct {
+	ID        string
+	ArticleID string
+	ParentID  *string
+	Body      string
+	Status    Status
+	CreatedAt time.Time
+	EditedAt  *time.Time
+}
+
+type ArchivedReaction struct {
+	CommentID string
+	ArticleID string
+	Kind      ReactionKind
+	CreatedAt time.Time
+}
+
+// NewArchive collects the member's comments and reactions
+func NewArchive(accountID string, comments []*Comment, reactions []*Reaction, at time.Time) *Archive {
+	a := &Archive{
+		AccountID:  accountID,
+		ExportedAt: at,
+		Comments:   make([]ArchivedComment, 0, len(comments)),
+		Reactions:  make([]ArchivedReaction, 0, len(reactions)),
+	}
+	for _, c := range comments {
+		a.Comments = append(a.Comments, ArchivedComment{
+			ID:        c.ID,
+			ArticleID: c.ArticleID,
+			ParentID:  c.ParentID,
+			Body:      c.Body,
+			Status:    c.Status,
+			CreatedAt: c.CreatedAt,
+			EditedAt:  c.EditedAt,
+		})
+	}
+	for _, r := range reactions {
+		a.Reactions = append(a.Reactions, ArchivedReaction{CommentID: r.CommentID, ArticleID: r.ArticleID, Kind: r.Kind, CreatedAt: r.CreatedAt})
+	}
+	return a
+}
+
+// Importable reports whether the comment is worth bringing back, removed
+// comments have no body or were taken down for a reason
+func (c ArchivedComment) Importable() bool {
+	return c.Status == StatusApproved || c.Status == StatusPending
+}
+
+// LegacyExport is a comment dump from another platform, e.g. a Disqus XML export
+type LegacyExport struct {
+	Threads []LegacyThread
+	Posts   []LegacyPost
+}
+
+// LegacyThread is the legacy platform's page a discussion belonged to
+type LegacyThread struct {
+	ID    string
+	Link  string
+	Title string
+}
+
+type LegacyPost struct {
+	ID        string
+	ThreadID  string
+	ParentID  string // Empty for top-level posts
+	Body      string // Plain text
+	CreatedAt time.Time
+	Deleted   bool
+	Spam      bool
+	Author    LegacyAuthor
+}
+
+type LegacyAuthor struct {
+	Name      string
+	Email     string // Often missing, Disqus leaves it out for anonymous posts
+	Username  string
+	Anonymous bool
+}
+
+// MatchKind records how a legacy author was matched, reported so staff can review the weak matches
+type MatchKind string
+
+const (
+	MatchEmail    MatchKind = "email"    // Same address as an account
+	MatchUsername MatchKind = "username" // Same username as an account, see MatchAuthor
+	MatchGuest    MatchKind = "guest"    // No account, kept as a guest author
+)
+
+var unsafeLocalPart = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// MatchAuthor picks the author of a legacy post. byEmail is the account with
+// the post's email address, byUsername the account with the legacy username,
+// either nil when there is none.
+//
+// An email match wins. A username on its own is weak, someone else may have
+// registered the name since, so it only counts when the post has no email or
+// the email's local part is that username. Everyone else becomes a guest.
+func MatchAuthor(a LegacyAuthor, byEmail, byUsername *account.UserAccount) (Author, MatchKind) {
+	email := strings.ToLower(strings.TrimSpace(a.Email))
+	if !a.Anonymous {
+		if byEmail != nil && !byEmail.IsSoftDeleted() && strings.EqualFold(byEmail.Email.String(), email) {
+			return AccountAuthor(byEmail.ID), MatchEmail
+		}
+		username := strings.ToLower(strings.TrimSpace(a.Username))
+		if byUsername != nil && !byUsername.IsSoftDeleted() && username != "" && strings.EqualFold(byUsername.Username.String(), username) {
+			local, _, _ := strings.Cut(email, "@")
+			if email == "" || local == username {
+				return AccountAuthor(byUsername.ID), MatchUsername
+			}
+		}
+	}
+	return legacyGuest(a), MatchGuest
+}
+
+// legacyGuest keeps the legacy name and, when it is usable, the address. A
+// stable placeholder keeps an author's posts together otherwise.
+func legacyGuest(a LegacyAuthor) Author {
+	name := strings.TrimSpace(a.Name)
+	if name == "" {
+		name = strings.TrimSpace(a.Username)
+	}
+	if name == "" {
+		name = "Anonymous"
+	}
+	if utf8.RuneCountInString(name) > MaxGuestNameLength {
+		name = string([]rune(name)[:MaxGuestNameLength])
+	}
+
+	if email, err := account.NewEmail(a.Email); err == nil {
+		return Author{GuestName: name, GuestEmail: email.String()}
+	}
+	local := unsafeLocalPart.ReplaceAllString(strings.ToLower(a.Username), "")
+	if local == "" {
+		local = "anonymous"
+	}
+	return Author{GuestName: name, GuestEmail: local + "@" + ImportedGuestDomain}
+}
@@ -0,0 +1,77 @@
+package comment
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func createTestAccount(t *testing.T, id, username, email string) *account.UserAccount {
+	t.Helper()
+	acc, err := account.NewUserAccountForTesting(id, username, email, "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return acc
+}
+
+func TestMatchAuthor(t *testing.T) {
+	sari := createTestAccount(t, "u1", "sari", "sari@example.com")
+	budi := createTestAccount(t, "u2", "budi", "budi@example.com")
+
+	testCases := []struct {
+		name          string
+		author        LegacyAuthor
+		byEmail       *account.UserAccount
+		byUsername    *account.UserAccount
+		expectedKind  MatchKind
+		expectedKey   string
+		expectedGuest string
+	}{
+		{"email", LegacyAuthor{Name: "Sari", Email: "SARI@example.com", Username: "sari_w"}, sari, nil, MatchEmail, "u1", ""},
+		{"email beats username", LegacyAuthor{Name: "Sari", Email: "sari@example.com", Username: "budi"}, sari, budi, MatchEmail, "u1", ""},
+		{"username without email", LegacyAuthor{Name: "Budi", Username: "Budi"}, nil, budi, MatchUsername, "u2", ""},
+		{"username with the same local part", LegacyAuthor{Name: "Budi", Email: "budi@old-mail.com", Username: "budi"}, nil, budi, MatchUsername, "u2", ""},
+		{"username with another email", LegacyAuthor{Name: "Budi", Email: "bs1980@old-mail.com", Username: "budi"}, nil, budi, MatchGuest, "guest:bs1980@old-mail.com", "Budi"},
+		{"anonymous", LegacyAuthor{Name: "Sari", Email: "sari@example.com", Anonymous: true}, sari, nil, MatchGuest, "guest:sari@example.com", "Sari"},
+		{"no email", LegacyAuthor{Name: "Pembaca Setia", Username: "Pembaca Setia!"}, nil, nil, MatchGuest, "guest:pembacasetia@imported.invalid", "Pembaca Setia"},
+		{"nothing at all", LegacyAuthor{Anonymous: true}, nil, nil, MatchGuest, "guest:anonymous@imported.invalid", "Anonymous"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			author, kind := MatchAuthor(tc.author, tc.byEmail, tc.byUsername)
+			if kind != tc.expectedKind || author.Key() != tc.expectedKey || author.GuestName != tc.expectedGuest {
+				t.Errorf("expected %s %s %q, got %s %s %q", tc.expectedKind, tc.expectedKey, tc.expectedGuest, kind, author.Key(), author.GuestName)
+			}
+			if err := author.validate(); err != nil {
+				t.Errorf("expected a valid author, got %v", err)
+			}
+		})
+	}
+
+	long, _ := MatchAuthor(LegacyAuthor{Name: strings.Repeat("x", 80)}, nil, nil)
+	if len(long.GuestName) != MaxGuestNameLength {
+		t.Errorf("expected the name cut to %d characters, got %d", MaxGuestNameLength, len(long.GuestName))
+	}
+}
+
+func TestNewArchive(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	kept, _ := NewComment("c1", "a1", AccountAuthor("u1"), "Great reporting", 1)
+	_ = kept.Approve("mod-1", at)
+	deleted, _ := NewComment("c2", "a1", AccountAuthor("u1"), "Oops", 2)
+	_ = deleted.Delete("u1")
+	other, _ := NewComment("c3", "a1", AccountAuthor("u2"), "Thanks", 3)
+	reaction, _ := NewReaction(other, "u1", ReactionUp, at)
+
+	a := NewArchive("u1", []*Comment{kept, deleted}, []*Reaction{reaction}, at)
+	if len(a.Comments) != 2 || len(a.Reactions) != 1 || a.Reactions[0].CommentID != "c3" {
+		t.Fatalf("unexpected archive %+v", a)
+	}
+	if !a.Comments[0].Importable() || a.Comments[1].Importable() || a.Comments[1].Body != "" {
+		t.Errorf("expected only the approved comment importable, got %+v", a.Comments)
+	}
+}
@@ -0,0 +1,59 @@
+package comment
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidReaction = errors.New("reaction must be up or down")
+	ErrOwnComment      = errors.New("authors cannot react to their own comments")
+)
+
+// ReactionKind is how a member reacted to a comment
+type ReactionKind string
+
+const (
+	ReactionUp   ReactionKind = "up"
+	ReactionDown ReactionKind = "down"
+)
+
+func ParseReactionKind(value string) (ReactionKind, error) {
+	switch k := ReactionKind(strings.ToLower(strings.TrimSpace(value))); k {
+	case ReactionUp, ReactionDown:
+		return k, nil
+	}
+	return "", ErrInvalidReaction
+}
+
+// Reaction is one member's vote on a comment, the comment keeps the counts
+type Reaction struct {
+	CommentID string
+	ArticleID string
+	AccountID string
+	Kind      ReactionKind
+	CreatedAt time.Time
+}
+
+// NewReaction checks the member is not voting on their own comment
+func NewReaction(c *Comment, accountID string, kind ReactionKind, at time.Time) (*Reaction, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if _, err := ParseReactionKind(string(kind)); err != nil {
+		return nil, err
+	}
+	if c.IsAuthoredBy(accountID) {
+		return nil, ErrOwnComment
+	}
+	return &Reaction{CommentID: c.ID, ArticleID: c.ArticleID, AccountID: accountID, Kind: kind, CreatedAt: at}, nil
+}
+
+// Deltas are the vote count changes the reaction adds to its comment
+func (r *Reaction) Deltas() (up, down int) {
+	if r.Kind == ReactionUp {
+		return 1, 0
+	}
+	return 0, 1
+}
@@ -0,0 +1,48 @@
+package comment
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewReaction(t *testing.T) {
+	c, _ := NewComment("c1", "a1", AccountAuthor("u1"), "Great reporting", 1)
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		accountID     string
+		kind          ReactionKind
+		expectedError error
+	}{
+		{"upvote", "u2", ReactionUp, nil},
+		{"downvote", "u2", ReactionDown, nil},
+		{"own comment", "u1", ReactionUp, ErrOwnComment},
+		{"unknown kind", "u2", "love", ErrInvalidReaction},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewReaction(c, tc.accountID, tc.kind, at)
+			if err != tc.expectedError {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			up, down := r.Deltas()
+			if r.ArticleID != "a1" || (tc.kind == ReactionUp) != (up == 1 && down == 0) {
+				t.Errorf("unexpected reaction %+v with deltas %d/%d", r, up, down)
+			}
+		})
+	}
+}
+
+func TestParseReactionKind(t *testing.T) {
+	if k, err := ParseReactionKind(" UP "); err != nil || k != ReactionUp {
+		t.Errorf("expected up, got %q %v", k, err)
+	}
+	if _, err := ParseReactionKind("like"); err != ErrInvalidReaction {
+		t.Errorf("expected ErrInvalidReaction, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// PageQuery selects one page of siblings
+type PageQuery struct {
+	ArticleID string
+	ParentID  *string // nil selects top-level comments
+	Sort      SortMode
+	Limit     int
+	Offset    int
+}
+
+func (q *PageQuery) Validate() error {
+	if q.Limit < 1 || q.Limit > 100 {
+		return errors.New("limit must be between 1 and 100")
+	}
+	if q.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
+	if _, err := ParseSortMode(string(q.Sort)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (q *PageQuery) SetDefaults() {
+	if q.Limit == 0 {
+		q.Limit = 20
+	}
+	if q.Sort == "" {
+		q.Sort = SortBest
+	}
+}
+
+// QueueQuery selects one page of the moderation queue, oldest first so
+// nothing waits forever
+type QueueQuery struct {
+	Status    Status  // Pending by default, spam and rejected for reviewing past calls
+	ArticleID *string // nil for every article
+	Limit     int
+	Offset    int
+}
+
+func (q *QueueQuery) Validate() error {
+	if q.Status != StatusPending && q.Status != StatusRejected && q.Status != StatusSpam {
+		return errors.New("queue status must be pending, rejected or spam")
+	}
+	if q.Limit < 1 || q.Limit > 100 {
+		return errors.New("limit must be between 1 and 100")
+	}
+	if q.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
+	return nil
+}
+
+func (q *QueueQuery) SetDefaults() {
+	if q.Limit == 0 {
+		q.Limit = 20
+	}
+	if q.Status == "" {
+		q.Status = StatusPending
+	}
+}
+
+// Domain interface for comment storage (implementation will be in infrastructure layer).
+// Implementations index (article_id, parent_id, created_at), (article_id, parent_id, score),
+// (article_id, path) and (status, created_at) so every page is a bounded index scan.
+type CommentRepository interface {
+	// Commands
+	Create(ctx context.Context, comment *Comment) error
+	Update(ctx context.Context, comment *Comment) error
+
+	// NextSequence reserves the next child sequence under the parent, nil for top-level
+	NextSequence(ctx context.Context, articleID string, parentID *string) (uint32, error)
+
+	// IncrementCounts bumps the denormalized counters after a reply at replyPath is added:
+	// ReplyCount on the parent, DescendantCount on every ancestor
+	IncrementCounts(ctx context.Context, articleID string, replyPath Path) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*Comment, error)
+
+	// Query - Multiple
+	FindPage(ctx context.Context, query PageQuery) ([]*Comment, error)
+
+	// FindReplyPreviews returns up to perParent oldest direct replies of each parent in one query
+	FindReplyPreviews(ctx context.Context, parentIDs []string, perParent int) (map[string][]*Comment, error)
+
+	// FindSubtree returns the subtree under a path in thread order
+	FindSubtree(ctx context.Context, articleID string, root Path, limit int) ([]*Comment, error)
+	CountTopLevel(ctx context.Context, articleID string) (int, error)
+
+	// Query - Portability
+	// FindByAuthor returns a page of the account's comments oldest first, every status included
+	FindByAuthor(ctx context.Context, accountID string, limit, offset int) ([]*Comment, error)
+	// FindByImportRef returns the comment migrated from the reference, nil when none was
+	FindByImportRef(ctx context.Context, ref string) (*Comment, error)
+
+	// Query - Subscriptions
+	// FindApprovedSince returns up to limit comments approved after the given time, under
+	// root when it is set, oldest approval first
+	FindApprovedSince(ctx context.Context, articleID string, root *Path, since time.Time, limit int) ([]*Comment, error)
+
+	// Query - Moderation
+	FindQueue(ctx context.Context, query QueueQuery) ([]*Comment, error)
+	// CountByStatus counts comments per status, across every article when articleID is nil
+	CountByStatus(ctx context.Context, articleID *string) (map[Status]int, error)
+}
+
+type ThreadSummaryCache interface {
+	Get(ctx context.Context, articleID string) (*ThreadSummary, error)
+	Set(ctx context.Context, summary ThreadSummary) error
+	Invalidate(ctx context.Context, articleID string) error
+}
+
+// ThreadSummarySource computes a summary from storage when the cache misses
+type ThreadSummarySource interface {
+	Summary(ctx context.Context, articleID string) (*ThreadSummary, error)
+}
+
+// Domain interface for members' reactions to comments (implementation will be in infrastructure layer)
+type ReactionRepository interface {
+	Create(ctx context.Context, r *Reaction) error
+	Exists(ctx context.Context, commentID, accountID string) (bool, error)
+	FindByAccount(ctx context.Context, accountID string) ([]*Reaction, error)
+}
+
+// Domain interface for the articles imported comments attach to (implementation will be in infrastructure layer)
+type ArticleLocator interface {
+	Exists(ctx context.Context, articleID string) (bool, error)
+
+	// FindByURL resolves a legacy thread link to an article by its slug or
+	// canonical URL, "" when no article matches
+	FindByURL(ctx context.Context, link string) (string, error)
+}
+
+// Domain interface for members' thread subscriptions (implementation will be in infrastructure layer).
+// Implementations keep (account_id, article_id, comment_id) and unsubscribe_token unique.
+type SubscriptionRepository interface {
+	// Commands
+	Create(ctx context.Context, s *Subscription) error
+	Update(ctx context.Context, s *Subscription) error
+	Delete(ctx context.Context, id string) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*Subscription, error)
+	FindByUnsubscribeToken(ctx context.Context, token string) (*Subscription, error)
+	// Find returns the account's subscription to the discussion, or to the thread when commentID is set
+	Find(ctx context.Context, accountID, articleID string, commentID *string) (*Subscription, error)
+
+	// Query - Multiple
+	FindByAccount(ctx context.Context, accountID string) ([]*Subscription, error)
+	CountByAccount(ctx context.Context, accountID string) (int, error)
+	// FindPendingAccounts pages through the accounts with a subscription that has comments
+	// approved after its NotifiedThrough, ordered by account ID, after "" starts from the beginning
+	FindPendingAccounts(ctx context.Context, after string, limit int) ([]string, error)
+}
+
+// Domain interface for the reply emails sent to each account, it backs MaxNotificationsPerDay
+type DeliveryLog interface {
+	Record(ctx context.Context, accountID string, at time.Time) error
+	CountSince(ctx context.Context, accountID string, since time.Time) (int, error)
+}
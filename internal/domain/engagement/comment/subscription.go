@@ -0,0 +1,166 @@
+package comment
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Per-account caps on thread subscriptions
+const (
+	MaxSubscriptionsPerAccount = 100
+	MaxNotificationsPerDay     = 12 // Reply emails in a rolling day, replies past it wait for the next one
+	MaxRepliesPerThread        = 20 // Replies listed per thread in one email, the rest go out next time
+)
+
+// DailyInterval is the least time between two emails of a daily subscription
+const DailyInterval = 24 * time.Hour
+
+var (
+	ErrInvalidFrequency     = errors.New("frequency must be instant or daily")
+	ErrNotSubscribable      = errors.New("only approved comments can be subscribed to")
+	ErrTooManySubscriptions = errors.New("comment subscription limit reached")
+)
+
+// Frequency is how often a subscriber hears about new comments
+type Frequency string
+
+const (
+	FrequencyInstant Frequency = "instant" // On the next notification run
+	FrequencyDaily   Frequency = "daily"   // At most one email a day
+)
+
+func ParseFrequency(value string) (Frequency, error) {
+	switch f := Frequency(strings.ToLower(strings.TrimSpace(value))); f {
+	case FrequencyInstant, FrequencyDaily:
+		return f, nil
+	}
+	return "", ErrInvalidFrequency
+}
+
+// Subscription follows new approved comments on an article's discussion, or
+// the replies under one comment when CommentID is set
+type Subscription struct {
+	ID        string
+	AccountID string
+	ArticleID string
+	CommentID *string // Thread root, nil for the whole discussion
+	RootPath  *Path
+	Frequency Frequency
+
+	// UnsubscribeToken is the secret in the emails' unsubscribe link, it works without signing in
+	UnsubscribeToken string
+
+	// NotifiedThrough is when the last comment the subscriber heard about was
+	// approved, comments approved later are pending
+	NotifiedThrough time.Time
+	LastSentAt      *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewArticleSubscription follows every new comment on the article
+func NewArticleSubscription(id, accountID, articleID string, frequency Frequency, token string, at time.Time) (*Subscription, error) {
+	if strings.TrimSpace(articleID) == "" {
+		return nil, errors.New("article ID cannot be empty")
+	}
+	return newSubscription(id, accountID, articleID, nil, frequency, token, at)
+}
+
+// NewThreadSubscription follows the replies under an approved comment
+func NewThreadSubscription(id, accountID string, root *Comment, frequency Frequency, token string, at time.Time) (*Subscription, error) {
+	if root == nil {
+		return nil, errors.New("comment cannot be empty")
+	}
+	if !root.IsVisible() {
+		return nil, ErrNotSubscribable
+	}
+	return newSubscription(id, accountID, root.ArticleID, root, frequency, token, at)
+}
+
+func newSubscription(id, accountID, articleID string, root *Comment, frequency Frequency, token string, at time.Time) (*Subscription, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if _, err := ParseFrequency(string(frequency)); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New("unsubscribe token cannot be empty")
+	}
+
+	s := &Subscription{
+		ID:               id,
+		AccountID:        accountID,
+		ArticleID:        articleID,
+		Frequency:        frequency,
+		UnsubscribeToken: token,
+		NotifiedThrough:  at,
+		CreatedAt:        at,
+		UpdatedAt:        at,
+	}
+	if root != nil {
+		rootID, rootPath := root.ID, root.Path
+		s.CommentID, s.RootPath = &rootID, &rootPath
+	}
+	return s, nil
+}
+
+// Business Methods
+
+func (s *Subscription) ChangeFrequency(frequency Frequency, at time.Time) error {
+	if _, err := ParseFrequency(string(frequency)); err != nil {
+		return err
+	}
+	s.Frequency = frequency
+	s.UpdatedAt = at
+	return nil
+}
+
+// MarkNotified moves past the comments approved up to through, sent tells
+// whether an email went out or there was nothing the subscriber should hear about
+func (s *Subscription) MarkNotified(through time.Time, sent bool, at time.Time) {
+	if through.After(s.NotifiedThrough) {
+		s.NotifiedThrough = through
+	}
+	if sent {
+		s.LastSentAt = &at
+	}
+	s.UpdatedAt = at
+}
+
+// Query Methods
+
+func (s *Subscription) IsThread() bool {
+	return s.CommentID != nil
+}
+
+// IsDue reports whether the subscription may send at the given time, a daily
+// one waits a DailyInterval after its last email or after it was created
+func (s *Subscription) IsDue(at time.Time) bool {
+	if s.Frequency != FrequencyDaily {
+		return true
+	}
+	last := s.CreatedAt
+	if s.LastSentAt != nil {
+		last = *s.LastSentAt
+	}
+	return !at.Before(last.Add(DailyInterval))
+}
+
+// Covers reports whether the subscriber should hear about the comment: approved
+// since the last notification, in the followed discussion and not their own
+func (s *Subscription) Covers(c *Comment) bool {
+	if c.ArticleID != s.ArticleID || !c.IsVisible() || c.ModeratedAt == nil || !c.ModeratedAt.After(s.NotifiedThrough) {
+		return false
+	}
+	if c.IsAuthoredBy(s.AccountID) {
+		return false
+	}
+	return s.RootPath == nil || s.RootPath.IsAncestorOf(c.Path)
+}
@@ -0,0 +1,106 @@
+package comment
+
+import (
+	"testing"
+	"time"
+)
+
+// approvedComment posts a comment, a reply when parent is set, and approves it at the given time
+func approvedComment(t *testing.T, id string, parent *Comment, accountID string, seq uint32, at time.Time) *Comment {
+	t.Helper()
+	var c *Comment
+	var err error
+	if parent == nil {
+		c, err = NewComment(id, "a1", AccountAuthor(accountID), "Sharp analysis", seq)
+	} else {
+		c, err = NewReply(id, parent, AccountAuthor(accountID), "Agreed", seq)
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Approve("mod-1", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestNewThreadSubscription(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	root, _ := NewComment("c1", "a1", AccountAuthor("u1"), "Sharp analysis", 1)
+
+	if _, err := NewThreadSubscription("s1", "u2", root, FrequencyInstant, "token", at); err != ErrNotSubscribable {
+		t.Errorf("expected ErrNotSubscribable for a pending comment, got %v", err)
+	}
+	_ = root.Approve("mod-1", at)
+	if _, err := NewThreadSubscription("s1", "u2", root, "hourly", "token", at); err != ErrInvalidFrequency {
+		t.Errorf("expected ErrInvalidFrequency, got %v", err)
+	}
+	s, err := NewThreadSubscription("s1", "u2", root, FrequencyDaily, "token", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.IsThread() || s.ArticleID != "a1" || !s.RootPath.Equals(root.Path) || !s.NotifiedThrough.Equal(at) {
+		t.Errorf("unexpected subscription %+v", s)
+	}
+}
+
+func TestSubscription_Covers(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	root := approvedComment(t, "c1", nil, "u1", 1, at)
+	other := approvedComment(t, "c2", nil, "u3", 2, at)
+	thread, _ := NewThreadSubscription("s1", "u1", root, FrequencyInstant, "token", at)
+	discussion, _ := NewArticleSubscription("s2", "u1", "a1", FrequencyInstant, "token-2", at)
+
+	later := at.Add(time.Minute)
+	reply := approvedComment(t, "c3", root, "u2", 1, later)
+	nested := approvedComment(t, "c4", reply, "u3", 1, later)
+	own := approvedComment(t, "c5", root, "u1", 2, later)
+	elsewhere := approvedComment(t, "c6", other, "u2", 1, later)
+	pending, _ := NewReply("c7", root, AccountAuthor("u2"), "Waiting", 3)
+	earlier := approvedComment(t, "c8", root, "u2", 4, at.Add(-time.Minute))
+
+	testCases := []struct {
+		name       string
+		comment    *Comment
+		thread     bool
+		discussion bool
+	}{
+		{"direct reply", reply, true, true},
+		{"nested reply", nested, true, true},
+		{"own reply", own, false, false},
+		{"other thread", elsewhere, false, true},
+		{"pending reply", pending, false, false},
+		{"approved before subscribing", earlier, false, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := thread.Covers(tc.comment); got != tc.thread {
+				t.Errorf("expected thread coverage %v, got %v", tc.thread, got)
+			}
+			if got := discussion.Covers(tc.comment); got != tc.discussion {
+				t.Errorf("expected discussion coverage %v, got %v", tc.discussion, got)
+			}
+		})
+	}
+
+	thread.MarkNotified(later, true, later)
+	if thread.Covers(reply) || !thread.LastSentAt.Equal(later) {
+		t.Error("expected notified replies no longer covered")
+	}
+}
+
+func TestSubscription_IsDue(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	s, _ := NewArticleSubscription("s1", "u1", "a1", FrequencyDaily, "token", at)
+	if s.IsDue(at.Add(time.Hour)) || !s.IsDue(at.Add(DailyInterval)) {
+		t.Error("expected a daily subscription due a day after it was created")
+	}
+	s.MarkNotified(at.Add(DailyInterval), true, at.Add(DailyInterval))
+	if s.IsDue(at.Add(DailyInterval + time.Hour)) {
+		t.Error("expected a daily subscription to wait a day after its last email")
+	}
+	_ = s.ChangeFrequency(FrequencyInstant, at.Add(DailyInterval+time.Hour))
+	if !s.IsDue(at.Add(DailyInterval + time.Hour)) {
+		t.Error("expected an instant subscription always due")
+	}
+}
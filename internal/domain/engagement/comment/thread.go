@@ -0,0 +1,67 @@
+package comment
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/badge"
+)
+
+// ThreadSummary is the cached header of an article's comment section
+type ThreadSummary struct {
+	ArticleID    string
+	Total        int // Visible comments, all depths
+	TopLevel     int
+	Participants int
+	LatestAt     *time.Time
+}
+
+// Summarize builds the summary from a full thread, used when the cache is cold
+func Summarize(articleID string, comments []*Comment) ThreadSummary {
+	summary := ThreadSummary{ArticleID: articleID}
+	authors := make(map[string]struct{})
+
+	for _, c := range comments {
+		if !c.IsVisible() {
+			continue
+		}
+		summary.Total++
+		if c.IsTopLevel() {
+			summary.TopLevel++
+		}
+		authors[c.Author.Key()] = struct{}{}
+		if summary.LatestAt == nil || c.CreatedAt.After(*summary.LatestAt) {
+			latest := c.CreatedAt
+			summary.LatestAt = &latest
+		}
+	}
+
+	summary.Participants = len(authors)
+	return summary
+}
+
+// Node is a comment on a page with a preview of its first replies
+type Node struct {
+	Comment     *Comment
+	Replies     []*Comment
+	MoreReplies int                   // Replies not included in the preview
+	Badges      map[string]badge.Kind // Verified badges of the comment's and previewed replies' authors
+}
+
+// SortComments orders siblings by the sort mode, ties broken by path for stable pages
+func SortComments(comments []*Comment, mode SortMode) {
+	sort.SliceStable(comments, func(i, j int) bool {
+		a, b := comments[i], comments[j]
+		switch mode {
+		case SortNewest:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case SortBest:
+			if a.Score != b.Score {
+				return a.Score > b.Score
+			}
+		}
+		return a.Path.Value() < b.Path.Value()
+	})
+}
@@ -0,0 +1,124 @@
+package comment
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	base := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	c1, _ := NewComment("c1", "a1", AccountAuthor("u1"), "first", 1)
+	c1.CreatedAt = base
+	_ = c1.Approve("mod-1", base)
+	r1, _ := NewReply("r1", c1, AccountAuthor("u2"), "reply", 1)
+	r1.CreatedAt = base.Add(time.Hour)
+	_ = r1.Approve("mod-1", base)
+	c2, _ := NewComment("c2", "a1", AccountAuthor("u1"), "second", 2)
+	c2.CreatedAt = base.Add(2 * time.Hour)
+	_ = c2.Reject("mod-1", base)
+	pending, _ := NewComment("c3", "a1", AccountAuthor("u3"), "third", 3)
+
+	summary := Summarize("a1", []*Comment{c1, r1, c2, pending})
+	if summary.Total != 2 || summary.TopLevel != 1 || summary.Participants != 2 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if summary.LatestAt == nil || !summary.LatestAt.Equal(r1.CreatedAt) {
+		t.Errorf("expected latest at %v, got %v", r1.CreatedAt, summary.LatestAt)
+	}
+}
+
+func TestSortComments(t *testing.T) {
+	base := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	var comments []*Comment
+	for i, votes := range [][2]int{{2, 0}, {40, 5}, {0, 3}} {
+		c, _ := NewComment(fmt.Sprintf("c%d", i+1), "a1", AccountAuthor("u1"), "text", uint32(i+1))
+		c.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		_ = c.Vote(votes[0], votes[1])
+		comments = append(comments, c)
+	}
+
+	tests := []struct {
+		mode     SortMode
+		expected []string
+	}{
+		{mode: SortOldest, expected: []string{"c1", "c2", "c3"}},
+		{mode: SortNewest, expected: []string{"c3", "c2", "c1"}},
+		{mode: SortBest, expected: []string{"c2", "c1", "c3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			sorted := append([]*Comment(nil), comments...)
+			SortComments(sorted, tt.mode)
+			for i, id := range tt.expected {
+				if sorted[i].ID != id {
+					t.Fatalf("expected order %v, got %s at %d", tt.expected, sorted[i].ID, i)
+				}
+			}
+		})
+	}
+}
+
+// createLargeThread builds n comments, a third of them top-level, replies spread over random parents
+func createLargeThread(b *testing.B, n int) []*Comment {
+	rng := rand.New(rand.NewSource(1))
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	comments := make([]*Comment, 0, n)
+	nextSeq := map[string]uint32{}
+
+	for i := 0; i < n; i++ {
+		var c *Comment
+		var err error
+		if i%3 == 0 || len(comments) == 0 {
+			nextSeq[""]++
+			c, err = NewComment(fmt.Sprintf("c%d", i), "a1", AccountAuthor(fmt.Sprintf("u%d", rng.Intn(5000))), "text", nextSeq[""])
+		} else {
+			parent := comments[rng.Intn(len(comments))]
+			if !parent.CanReceiveReplies() {
+				parent = comments[0]
+			}
+			nextSeq[parent.ID]++
+			c, err = NewReply(fmt.Sprintf("c%d", i), parent, AccountAuthor(fmt.Sprintf("u%d", rng.Intn(5000))), "text", nextSeq[parent.ID])
+		}
+		if err != nil {
+			b.Fatalf("failed to create comment: %v", err)
+		}
+		c.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		_ = c.Approve("mod-1", c.CreatedAt)
+		_ = c.Vote(rng.Intn(50), rng.Intn(10))
+		comments = append(comments, c)
+	}
+	return comments
+}
+
+func BenchmarkSummarize50k(b *testing.B) {
+	comments := createLargeThread(b, 50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Summarize("a1", comments)
+	}
+}
+
+func BenchmarkSortTopLevelBest50k(b *testing.B) {
+	var topLevel []*Comment
+	for _, c := range createLargeThread(b, 50000) {
+		if c.IsTopLevel() {
+			topLevel = append(topLevel, c)
+		}
+	}
+	page := make([]*Comment, len(topLevel))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(page, topLevel)
+		SortComments(page, SortBest)
+	}
+}
+
+func BenchmarkPathChild(b *testing.B) {
+	root, _ := NewRootPath(12345)
+	for i := 0; i < b.N; i++ {
+		_, _ = root.Child(uint32(i%1000 + 1))
+	}
+}
@@ -0,0 +1,210 @@
+package comment
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Materialized path layout: one fixed-width base36 segment per level, joined by dots.
+// Fixed width keeps byte order equal to thread order, so a single index on
+// (article_id, path) serves "whole subtree" and "oldest first" reads.
+const (
+	segmentWidth = 6
+	maxSegment   = 2176782335 // 36^6 - 1
+	pathSep      = "."
+
+	// MaxDepth caps nesting, top-level comments are depth 0
+	MaxDepth = 6
+)
+
+// Domain errors
+var (
+	ErrInvalidPath     = errors.New("invalid comment path")
+	ErrSegmentOverflow = errors.New("too many comments at this level")
+	ErrInvalidSort     = errors.New("invalid sort mode")
+	ErrBodyEmpty       = errors.New("comment body cannot be empty")
+	ErrBodyTooLong     = errors.New("comment body cannot exceed 5000 characters")
+
+	ErrInvalidTransition = errors.New("comment cannot move to that status")
+	ErrNotEditable       = errors.New("comment can no longer be edited")
+	ErrNotAuthor         = errors.New("only the author can edit the comment")
+	ErrEditWindowClosed  = errors.New("comment can only be edited within 15 minutes of posting")
+	ErrGuestNameEmpty    = errors.New("guest name cannot be empty")
+	ErrGuestNameTooLong  = errors.New("guest name cannot exceed 50 characters")
+)
+
+// MaxGuestNameLength bounds the name shown next to a guest comment
+const MaxGuestNameLength = 50
+
+// Author value object, a signed-in account or a guest who left a name and email.
+// The guest email is never shown, it is only used to reach the guest.
+type Author struct {
+	AccountID  string // Empty for guests
+	GuestName  string
+	GuestEmail string
+}
+
+func AccountAuthor(accountID string) Author {
+	return Author{AccountID: accountID}
+}
+
+func NewGuestAuthor(name, email string) (*Author, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrGuestNameEmpty
+	}
+	if utf8.RuneCountInString(name) > MaxGuestNameLength {
+		return nil, ErrGuestNameTooLong
+	}
+	address, err := account.NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return &Author{GuestName: name, GuestEmail: address.String()}, nil
+}
+
+func (a Author) IsGuest() bool {
+	return a.AccountID == ""
+}
+
+// Key identifies the author across comments, guests by their email
+func (a Author) Key() string {
+	if a.IsGuest() {
+		return "guest:" + strings.ToLower(a.GuestEmail)
+	}
+	return a.AccountID
+}
+
+func (a Author) validate() error {
+	if a.IsGuest() && (a.GuestName != "" || a.GuestEmail != "") {
+		_, err := NewGuestAuthor(a.GuestName, a.GuestEmail)
+		return err
+	}
+	if strings.TrimSpace(a.AccountID) == "" {
+		return errors.New("author ID cannot be empty")
+	}
+	return nil
+}
+
+// Path value object, the position of a comment in its article's thread
+type Path struct {
+	value string
+}
+
+func NewRootPath(seq uint32) (*Path, error) {
+	segment, err := encodeSegment(seq)
+	if err != nil {
+		return nil, err
+	}
+	return &Path{value: segment}, nil
+}
+
+func ParsePath(value string) (*Path, error) {
+	if value == "" {
+		return nil, ErrInvalidPath
+	}
+	for _, segment := range strings.Split(value, pathSep) {
+		if len(segment) != segmentWidth {
+			return nil, ErrInvalidPath
+		}
+		if _, err := strconv.ParseUint(segment, 36, 32); err != nil {
+			return nil, ErrInvalidPath
+		}
+	}
+	return &Path{value: value}, nil
+}
+
+// Child returns the path of the seq-th reply under this path
+func (p Path) Child(seq uint32) (*Path, error) {
+	segment, err := encodeSegment(seq)
+	if err != nil {
+		return nil, err
+	}
+	return &Path{value: p.value + pathSep + segment}, nil
+}
+
+// Parent returns nil for a top-level comment
+func (p Path) Parent() *Path {
+	i := strings.LastIndex(p.value, pathSep)
+	if i < 0 {
+		return nil
+	}
+	return &Path{value: p.value[:i]}
+}
+
+// Root returns the top-level ancestor path
+func (p Path) Root() Path {
+	return Path{value: p.value[:segmentWidth]}
+}
+
+// Depth is 0 for top-level comments
+func (p Path) Depth() int {
+	return strings.Count(p.value, pathSep)
+}
+
+// IsAncestorOf reports whether other lies in this path's subtree
+func (p Path) IsAncestorOf(other Path) bool {
+	return strings.HasPrefix(other.value, p.value+pathSep)
+}
+
+// SubtreeUpperBound is the exclusive upper bound for a range scan over the subtree,
+// '/' sorts right after '.'
+func (p Path) SubtreeUpperBound() string {
+	return p.value + "/"
+}
+
+func (p Path) String() string {
+	return p.value
+}
+
+func (p Path) Value() string {
+	return p.value
+}
+
+func (p Path) Equals(other Path) bool {
+	return p.value == other.value
+}
+
+func encodeSegment(seq uint32) (string, error) {
+	if seq == 0 || seq > maxSegment {
+		return "", ErrSegmentOverflow
+	}
+	s := strconv.FormatUint(uint64(seq), 36)
+	return strings.Repeat("0", segmentWidth-len(s)) + s, nil
+}
+
+type SortMode string
+
+const (
+	SortNewest SortMode = "newest"
+	SortOldest SortMode = "oldest"
+	SortBest   SortMode = "best"
+)
+
+func ParseSortMode(value string) (SortMode, error) {
+	switch mode := SortMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return SortBest, nil
+	case SortNewest, SortOldest, SortBest:
+		return mode, nil
+	default:
+		return "", ErrInvalidSort
+	}
+}
+
+// WilsonScore is the lower bound of the 95% confidence interval of the upvote ratio.
+// It ranks 40 up / 5 down above 2 up / 0 down, unlike a plain ratio.
+func WilsonScore(upvotes, downvotes int) float64 {
+	n := float64(upvotes + downvotes)
+	if n == 0 {
+		return 0
+	}
+	const z = 1.96
+	phat := float64(upvotes) / n
+	return (phat + z*z/(2*n) - z*math.Sqrt((phat*(1-phat)+z*z/(4*n))/n)) / (1 + z*z/n)
+}
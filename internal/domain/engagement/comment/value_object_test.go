@@ -0,0 +1,129 @@
+package comment
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	root, err := NewRootPath(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if root.String() != "000001" || root.Depth() != 0 || root.Parent() != nil {
+		t.Errorf("unexpected root path %s", root)
+	}
+
+	child, _ := root.Child(36)
+	if child.String() != "000001.000010" || child.Depth() != 1 {
+		t.Errorf("unexpected child path %s", child)
+	}
+	if !root.IsAncestorOf(*child) || child.IsAncestorOf(*root) {
+		t.Error("expected root to be ancestor of child only")
+	}
+	if !child.Parent().Equals(*root) || !child.Root().Equals(*root) {
+		t.Error("expected parent and root to be the root path")
+	}
+
+	// A sibling with a shared prefix is not a descendant
+	sibling, _ := NewRootPath(10)
+	if root.IsAncestorOf(*sibling) {
+		t.Error("expected sibling not to be a descendant")
+	}
+
+	if _, err := NewRootPath(0); err != ErrSegmentOverflow {
+		t.Errorf("expected error '%v', got '%v'", ErrSegmentOverflow, err)
+	}
+}
+
+func TestPath_OrderIsThreadOrder(t *testing.T) {
+	p1, _ := NewRootPath(1)
+	p2, _ := NewRootPath(2)
+	p10, _ := NewRootPath(10)
+	p1a, _ := p1.Child(1)
+	p1b, _ := p1.Child(2)
+	p1a1, _ := p1a.Child(1)
+
+	paths := []string{p10.String(), p1b.String(), p2.String(), p1a1.String(), p1.String(), p1a.String()}
+	sort.Strings(paths)
+
+	expected := []string{p1.String(), p1a.String(), p1a1.String(), p1b.String(), p2.String(), p10.String()}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Fatalf("expected thread order %v, got %v", expected, paths)
+		}
+	}
+
+	if !(p1b.String() < p1.SubtreeUpperBound() && p2.String() > p1.SubtreeUpperBound()) {
+		t.Error("expected subtree upper bound between last descendant and next sibling")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "root", input: "000001"},
+		{name: "nested", input: "000001.00000a.zzzzzz"},
+		{name: "empty", input: "", wantErr: true},
+		{name: "short segment", input: "0001", wantErr: true},
+		{name: "trailing separator", input: "000001.", wantErr: true},
+		{name: "invalid character", input: "00000!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePath(tt.input)
+			if tt.wantErr && err != ErrInvalidPath {
+				t.Errorf("expected error '%v', got '%v'", ErrInvalidPath, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseSortMode(t *testing.T) {
+	if mode, _ := ParseSortMode(""); mode != SortBest {
+		t.Errorf("expected default best, got %s", mode)
+	}
+	if mode, _ := ParseSortMode(" Newest "); mode != SortNewest {
+		t.Errorf("expected newest, got %s", mode)
+	}
+	if _, err := ParseSortMode("hot"); err != ErrInvalidSort {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidSort, err)
+	}
+}
+
+func TestWilsonScore(t *testing.T) {
+	if WilsonScore(0, 0) != 0 {
+		t.Error("expected zero score without votes")
+	}
+	if WilsonScore(40, 5) <= WilsonScore(2, 0) {
+		t.Error("expected many mostly positive votes to beat few positive votes")
+	}
+	if WilsonScore(10, 10) >= WilsonScore(10, 1) {
+		t.Error("expected downvotes to lower the score")
+	}
+}
+
+func TestQueueQuery(t *testing.T) {
+	q := QueueQuery{}
+	q.SetDefaults()
+	if q.Status != StatusPending || q.Limit != 20 || q.Validate() != nil {
+		t.Errorf("expected the pending queue by default, got %+v", q)
+	}
+
+	for _, invalid := range []QueueQuery{
+		{Status: StatusApproved, Limit: 20},
+		{Status: StatusSpam, Limit: 101},
+		{Status: StatusRejected, Limit: 20, Offset: -1},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("expected error for %+v", invalid)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package letter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Domain errors
+var (
+	ErrEmptyID             = shared.NewDomainError("letter.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrEmptySubject        = shared.NewDomainError("letter.subject_required", shared.KindValidation, "subject cannot be empty")
+	ErrSubjectTooLong      = shared.NewDomainError("letter.subject_too_long", shared.KindValidation, "subject cannot exceed 150 characters")
+	ErrEmptyBody           = shared.NewDomainError("letter.body_required", shared.KindValidation, "body cannot be empty")
+	ErrBodyTooLong         = shared.NewDomainError("letter.body_too_long", shared.KindValidation, "body cannot exceed 500 words")
+	ErrBlankAccountID      = shared.NewDomainError("letter.account_blank", shared.KindValidation, "account ID cannot be blank")
+	ErrEmptySubmitter      = shared.NewDomainError("letter.submitter_required", shared.KindValidation, "submitter cannot be empty")
+	ErrSubmitterInactive   = shared.NewDomainError("letter.submitter_inactive", shared.KindForbidden, "submitter account is not active")
+	ErrEmptyEditorID       = shared.NewDomainError("letter.editor_required", shared.KindValidation, "editor ID cannot be empty")
+	ErrEmptyDeclineReason  = shared.NewDomainError("letter.reason_required", shared.KindValidation, "reason cannot be empty")
+	ErrEmptyArticleID      = shared.NewDomainError("letter.article_required", shared.KindValidation, "article ID cannot be empty")
+	ErrNotReceived         = shared.NewDomainError("letter.not_received", shared.KindState, "only received letters can be shortlisted")
+	ErrNotDeclinable       = shared.NewDomainError("letter.not_declinable", shared.KindState, "only received or shortlisted letters can be declined")
+	ErrNotShortlisted      = shared.NewDomainError("letter.not_shortlisted", shared.KindState, "only shortlisted letters can be published")
+	ErrAlreadyAcknowledged = shared.NewDomainError("letter.already_acknowledged", shared.KindConflict, "letter has already been acknowledged")
+)
+
+type LetterStatus string
+
+const (
+	StatusReceived    LetterStatus = "received"
+	StatusShortlisted LetterStatus = "shortlisted"
+	StatusPublished   LetterStatus = "published"
+	StatusDeclined    LetterStatus = "declined"
+)
+
+// Editorial limits for letters to the editor
+const (
+	MaxSubjectLength = 150
+	MaxBodyWords     = 500
+)
+
+type LetterSubmission struct {
+	ID        string
+	AccountID *string // Set when submitted by a signed-in reader
+	Author    Author
+	Subject   string
+	Body      string
+
+	// Editorial workflow
+	Status        LetterStatus
+	ReviewedBy    *string
+	ReviewedAt    *time.Time
+	DeclineReason *string
+	ArticleID     *string // Article the letter was published as
+	PublishedAt   *time.Time
+
+	AcknowledgedAt *time.Time
+	LastActionBy   *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewLetterSubmission creates a letter received from the public submission form
+func NewLetterSubmission(id string, author Author, subject, body string, accountID *string) (*LetterSubmission, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return nil, ErrEmptySubject
+	}
+	if len(subject) > MaxSubjectLength {
+		return nil, ErrSubjectTooLong
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyBody
+	}
+	if len(strings.Fields(body)) > MaxBodyWords {
+		return nil, ErrBodyTooLong
+	}
+
+	if accountID != nil && strings.TrimSpace(*accountID) == "" {
+		return nil, ErrBlankAccountID
+	}
+
+	now := time.Now()
+	return &LetterSubmission{
+		ID:        id,
+		AccountID: accountID,
+		Author:    author,
+		Subject:   subject,
+		Body:      body,
+		Status:    StatusReceived,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// NewLetterSubmissionFromAccount creates a letter for a signed-in reader, using the account email
+func NewLetterSubmissionFromAccount(id string, submitter *account.UserAccount, name, city, subject, body string) (*LetterSubmission, error) {
+	if submitter == nil {
+		return nil, ErrEmptySubmitter
+	}
+	if !submitter.IsActive() {
+		return nil, ErrSubmitterInactive
+	}
+
+	author, err := NewAuthor(name, submitter.Email.String(), city)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID := submitter.ID
+	return NewLetterSubmission(id, *author, subject, body, &accountID)
+}
+
+// Business Methods
+
+// Shortlist marks a received letter as a candidate for publication
+func (l *LetterSubmission) Shortlist(editorID string) error {
+	if l.Status != StatusReceived {
+		return ErrNotReceived
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyEditorID
+	}
+
+	now := time.Now()
+	l.Status = StatusShortlisted
+	l.ReviewedBy = &editorID
+	l.ReviewedAt = &now
+	l.UpdatedAt = now
+	l.LastActionBy = &editorID
+	return nil
+}
+
+// Decline rejects a letter that has not been published yet
+func (l *LetterSubmission) Decline(editorID, reason string) error {
+	if l.Status != StatusReceived && l.Status != StatusShortlisted {
+		return ErrNotDeclinable
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyEditorID
+	}
+	if strings.TrimSpace(reason) == "" {
+		return ErrEmptyDeclineReason
+	}
+
+	now := time.Now()
+	l.Status = StatusDeclined
+	l.ReviewedBy = &editorID
+	l.ReviewedAt = &now
+	l.DeclineReason = &reason
+	l.UpdatedAt = now
+	l.LastActionBy = &editorID
+	return nil
+}
+
+// Publish records the article a shortlisted letter was converted into
+func (l *LetterSubmission) Publish(editorID, articleID string) error {
+	if l.Status != StatusShortlisted {
+		return ErrNotShortlisted
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyEditorID
+	}
+	if strings.TrimSpace(articleID) == "" {
+		return ErrEmptyArticleID
+	}
+
+	now := time.Now()
+	l.Status = StatusPublished
+	l.ArticleID = &articleID
+	l.PublishedAt = &now
+	l.UpdatedAt = now
+	l.LastActionBy = &editorID
+	return nil
+}
+
+// MarkAcknowledged records that the acknowledgment email was sent
+func (l *LetterSubmission) MarkAcknowledged() error {
+	if l.AcknowledgedAt != nil {
+		return ErrAlreadyAcknowledged
+	}
+
+	now := time.Now()
+	l.AcknowledgedAt = &now
+	l.UpdatedAt = now
+	return nil
+}
+
+// ToArticleDraft converts a shortlisted letter into the content of a letters-page article
+func (l *LetterSubmission) ToArticleDraft() (*ArticleDraft, error) {
+	if l.Status != StatusShortlisted {
+		return nil, ErrNotShortlisted.WithMessage("only shortlisted letters can be converted into articles")
+	}
+	return &ArticleDraft{
+		Title:    l.Subject,
+		Body:     l.Body,
+		Byline:   l.Author.Attribution(),
+		LetterID: l.ID,
+	}, nil
+}
+
+// Query Methods
+
+func (l *LetterSubmission) IsReceived() bool {
+	return l.Status == StatusReceived
+}
+
+func (l *LetterSubmission) IsShortlisted() bool {
+	return l.Status == StatusShortlisted
+}
+
+func (l *LetterSubmission) IsPublished() bool {
+	return l.Status == StatusPublished
+}
+
+func (l *LetterSubmission) IsDeclined() bool {
+	return l.Status == StatusDeclined
+}
+
+func (l *LetterSubmission) IsAcknowledged() bool {
+	return l.AcknowledgedAt != nil
+}
+
+func (l *LetterSubmission) IsFromAccount() bool {
+	return l.AccountID != nil
+}
+
+// ArticleDraft is the content handed to the article workflow when a letter is accepted
+type ArticleDraft struct {
+	Title    string
+	Body     string
+	Byline   string
+	LetterID string
+}
@@ -0,0 +1,215 @@
+package letter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestNewLetterSubmission(t *testing.T) {
+	blank := "  "
+
+	tests := []struct {
+		name      string
+		id        string
+		subject   string
+		body      string
+		accountID *string
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name:    "valid anonymous letter",
+			id:      "ltr-1",
+			subject: "Traffic in the city center",
+			body:    "The new one-way system has made things worse.",
+			wantErr: false,
+		},
+		{
+			name:    "empty id",
+			id:      "",
+			subject: "Traffic",
+			body:    "Body",
+			wantErr: true,
+			errMsg:  "ID cannot be empty",
+		},
+		{
+			name:    "empty subject",
+			id:      "ltr-1",
+			subject: "   ",
+			body:    "Body",
+			wantErr: true,
+			errMsg:  "subject cannot be empty",
+		},
+		{
+			name:    "empty body",
+			id:      "ltr-1",
+			subject: "Traffic",
+			body:    "",
+			wantErr: true,
+			errMsg:  "body cannot be empty",
+		},
+		{
+			name:    "body over word limit",
+			id:      "ltr-1",
+			subject: "Traffic",
+			body:    strings.Repeat("word ", MaxBodyWords+1),
+			wantErr: true,
+			errMsg:  "body cannot exceed 500 words",
+		},
+		{
+			name:      "blank account id",
+			id:        "ltr-1",
+			subject:   "Traffic",
+			body:      "Body",
+			accountID: &blank,
+			wantErr:   true,
+			errMsg:    "account ID cannot be blank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			letter, err := NewLetterSubmission(tt.id, createTestAuthor(t), tt.subject, tt.body, tt.accountID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if letter.Status != StatusReceived {
+				t.Errorf("expected status %s, got %s", StatusReceived, letter.Status)
+			}
+			if letter.IsFromAccount() {
+				t.Error("expected anonymous letter")
+			}
+		})
+	}
+}
+
+func TestNewLetterSubmissionFromAccount(t *testing.T) {
+	member, err := account.NewUserAccountForSelfRegistration("member123", "member_user", "Member@Example.com", "hashed_password")
+	if err != nil {
+		t.Fatalf("failed to create member: %v", err)
+	}
+
+	if _, err := NewLetterSubmissionFromAccount("ltr-1", member, "Jane Doe", "Bandung", "Subject", "Body"); err == nil {
+		t.Error("expected error for inactive submitter")
+	}
+
+	if err := member.SelfVerify(); err != nil {
+		t.Fatalf("failed to verify member: %v", err)
+	}
+	letter, err := NewLetterSubmissionFromAccount("ltr-1", member, "Jane Doe", "Bandung", "Subject", "Body")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if letter.AccountID == nil || *letter.AccountID != "member123" {
+		t.Error("expected account ID to be set")
+	}
+	if letter.Author.Email().String() != "member@example.com" {
+		t.Errorf("expected account email, got %s", letter.Author.Email().String())
+	}
+}
+
+func TestLetterSubmission_Workflow(t *testing.T) {
+	letter := createTestLetter(t)
+
+	if err := letter.Publish("editor1", "art-1"); err == nil {
+		t.Error("expected error publishing a letter that is not shortlisted")
+	}
+	if _, err := letter.ToArticleDraft(); err == nil {
+		t.Error("expected error converting a letter that is not shortlisted")
+	}
+
+	if err := letter.Shortlist(""); err == nil || err.Error() != "editor ID cannot be empty" {
+		t.Errorf("expected empty editor error, got %v", err)
+	}
+	if err := letter.Shortlist("editor1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !letter.IsShortlisted() || letter.ReviewedBy == nil {
+		t.Error("expected letter to be shortlisted with reviewer")
+	}
+
+	draft, err := letter.ToArticleDraft()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if draft.Byline != "Jane Doe, Bandung" {
+		t.Errorf("expected byline 'Jane Doe, Bandung', got %q", draft.Byline)
+	}
+	if draft.LetterID != letter.ID {
+		t.Errorf("expected letter ID %s, got %s", letter.ID, draft.LetterID)
+	}
+
+	if err := letter.Publish("editor1", ""); err == nil || err.Error() != "article ID cannot be empty" {
+		t.Errorf("expected empty article error, got %v", err)
+	}
+	if err := letter.Publish("editor1", "art-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !letter.IsPublished() || letter.ArticleID == nil || *letter.ArticleID != "art-1" {
+		t.Error("expected letter to be published as art-1")
+	}
+
+	if err := letter.Decline("editor1", "too late"); err == nil {
+		t.Error("expected error declining a published letter")
+	}
+}
+
+func TestLetterSubmission_Decline(t *testing.T) {
+	letter := createTestLetter(t)
+
+	if err := letter.Decline("editor1", " "); err == nil || err.Error() != "reason cannot be empty" {
+		t.Errorf("expected empty reason error, got %v", err)
+	}
+	if err := letter.Decline("editor1", "off topic"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !letter.IsDeclined() || letter.DeclineReason == nil {
+		t.Error("expected letter to be declined with a reason")
+	}
+	if err := letter.Shortlist("editor1"); err == nil {
+		t.Error("expected error shortlisting a declined letter")
+	}
+}
+
+func TestLetterSubmission_MarkAcknowledged(t *testing.T) {
+	letter := createTestLetter(t)
+
+	if err := letter.MarkAcknowledged(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !letter.IsAcknowledged() {
+		t.Error("expected letter to be acknowledged")
+	}
+	if err := letter.MarkAcknowledged(); err == nil {
+		t.Error("expected error acknowledging twice")
+	}
+}
+
+// Helper functions
+
+func createTestAuthor(t *testing.T) Author {
+	author, err := NewAuthor("Jane  Doe", "jane@example.com", " Bandung ")
+	if err != nil {
+		t.Fatalf("failed to create author: %v", err)
+	}
+	return *author
+}
+
+func createTestLetter(t *testing.T) *LetterSubmission {
+	letter, err := NewLetterSubmission("ltr-1", createTestAuthor(t), "Traffic in the city center", "The new one-way system has made things worse.", nil)
+	if err != nil {
+		t.Fatalf("failed to create letter: %v", err)
+	}
+	return letter
+}
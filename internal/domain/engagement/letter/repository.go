@@ -0,0 +1,87 @@
+package letter
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type LetterFilter struct {
+	SearchQuery *string // Search in subject, body and author name
+	Status      *LetterStatus
+	AccountID   *string
+
+	// Date range filters
+	ReceivedAfter  *time.Time
+	ReceivedBefore *time.Time
+
+	// Pagination
+	Limit  int
+	Offset int
+
+	// Sorting
+	OrderBy   string
+	SortOrder string
+}
+
+// Validate filter parameters
+func (f *LetterFilter) Validate() error {
+	if f.Limit <= 0 || f.Limit > 100 {
+		return errors.New("limit must be between 1 and 100")
+	}
+	if f.Offset < 0 {
+		return errors.New("offset must be non-negative")
+	}
+
+	validOrderBy := map[string]bool{
+		"created_at":  true,
+		"updated_at":  true,
+		"reviewed_at": true,
+	}
+	if f.OrderBy != "" && !validOrderBy[f.OrderBy] {
+		return errors.New("invalid order_by field")
+	}
+
+	if f.SortOrder != "" && f.SortOrder != "asc" && f.SortOrder != "desc" {
+		return errors.New("sort_order must be 'asc' or 'desc'")
+	}
+
+	if f.ReceivedAfter != nil && f.ReceivedBefore != nil {
+		if f.ReceivedAfter.After(*f.ReceivedBefore) {
+			return errors.New("received_after must be before received_before")
+		}
+	}
+
+	return nil
+}
+
+// Set default values for pagination and sorting
+func (f *LetterFilter) SetDefaults() {
+	if f.Limit == 0 {
+		f.Limit = 20
+	}
+	if f.OrderBy == "" {
+		f.OrderBy = "created_at"
+	}
+	if f.SortOrder == "" {
+		f.SortOrder = "desc"
+	}
+}
+
+type LetterSubmissionRepository interface {
+	// Commands
+	Create(ctx context.Context, letter *LetterSubmission) error
+	Update(ctx context.Context, letter *LetterSubmission) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*LetterSubmission, error)
+	FindByArticleID(ctx context.Context, articleID string) (*LetterSubmission, error)
+
+	// Query - Multiple with filters
+	Find(ctx context.Context, filter *LetterFilter) ([]*LetterSubmission, error)
+	Count(ctx context.Context, filter *LetterFilter) (int64, error)
+
+	// Editorial queue, received and shortlisted letters oldest first
+	FindEditorialQueue(ctx context.Context, limit, offset int) ([]*LetterSubmission, error)
+	FindUnacknowledged(ctx context.Context, limit int) ([]*LetterSubmission, error)
+}
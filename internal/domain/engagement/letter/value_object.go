@@ -0,0 +1,74 @@
+package letter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Domain errors
+var (
+	ErrAuthorNameEmpty   = shared.NewDomainError("letter.author_name_required", shared.KindValidation, "author name cannot be empty")
+	ErrAuthorNameTooLong = shared.NewDomainError("letter.author_name_too_long", shared.KindValidation, "author name cannot exceed 100 characters")
+	ErrAuthorCityTooLong = shared.NewDomainError("letter.author_city_too_long", shared.KindValidation, "author city cannot exceed 100 characters")
+)
+
+// Author value object, the letter writer's details as printed in the attribution
+type Author struct {
+	name  string
+	email account.Email
+	city  string
+}
+
+func NewAuthor(name, email, city string) (*Author, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	city = strings.Join(strings.Fields(city), " ")
+
+	if name == "" {
+		return nil, ErrAuthorNameEmpty
+	}
+	if len(name) > 100 {
+		return nil, ErrAuthorNameTooLong
+	}
+	if len(city) > 100 {
+		return nil, ErrAuthorCityTooLong
+	}
+
+	emailObj, err := account.NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Author{name: name, email: *emailObj, city: city}, nil
+}
+
+func (a Author) Name() string {
+	return a.name
+}
+
+func (a Author) Email() account.Email {
+	return a.email
+}
+
+func (a Author) City() string {
+	return a.city
+}
+
+// Attribution returns the byline printed under a published letter, e.g. "Jane Doe, Bandung"
+func (a Author) Attribution() string {
+	if a.city == "" {
+		return a.name
+	}
+	return a.name + ", " + a.city
+}
+
+func (a Author) Equals(other Author) bool {
+	return a.name == other.name && a.email.Equals(other.email) && a.city == other.city
+}
+
+// Domain interface for acknowledgment emails (implementation will be in infrastructure layer)
+type AcknowledgmentNotifier interface {
+	SendAcknowledgment(ctx context.Context, letter *LetterSubmission) error
+}
@@ -0,0 +1,45 @@
+package letter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestNewAuthor(t *testing.T) {
+	testCases := []struct {
+		name                string
+		authorName          string
+		email               string
+		city                string
+		expectedAttribution string
+		expectedErr         error
+	}{
+		{"valid author with city", "Jane Doe", "jane@example.com", "Bandung", "Jane Doe, Bandung", nil},
+		{"valid author without city", "Jane Doe", "jane@example.com", "", "Jane Doe", nil},
+		{"collapses whitespace", "  Jane   Doe ", "jane@example.com", " South  Jakarta ", "Jane Doe, South Jakarta", nil},
+		{"invalid - empty name", "  ", "jane@example.com", "", "", ErrAuthorNameEmpty},
+		{"invalid - long name", strings.Repeat("a", 101), "jane@example.com", "", "", ErrAuthorNameTooLong},
+		{"invalid - long city", "Jane", "jane@example.com", strings.Repeat("a", 101), "", ErrAuthorCityTooLong},
+		{"invalid - email", "Jane", "not-an-email", "", "", account.ErrInvalidEmail},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			author, err := NewAuthor(tc.authorName, tc.email, tc.city)
+			if tc.expectedErr != nil {
+				if err != tc.expectedErr {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if author.Attribution() != tc.expectedAttribution {
+				t.Errorf("expected attribution '%s', got '%s'", tc.expectedAttribution, author.Attribution())
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package moderation
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Profile is the moderation part of a staff profile: the languages the
+// moderator reads well enough to judge comments in
+type Profile struct {
+	StaffID   string
+	Languages []Language
+
+	LastActionBy *string
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+func NewProfile(staffID string, languages []string, editorID string, at time.Time) (*Profile, error) {
+	if strings.TrimSpace(staffID) == "" {
+		return nil, errors.New("staff ID cannot be empty")
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editor ID cannot be empty")
+	}
+	var parsed []Language
+	for _, value := range languages {
+		l, err := ParseLanguage(value)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(parsed, l) {
+			parsed = append(parsed, l)
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, ErrNoLanguages
+	}
+	return &Profile{StaffID: staffID, Languages: parsed, LastActionBy: &editorID, UpdatedAt: at}, nil
+}
+
+// Query Methods
+
+func (p *Profile) Speaks(language Language) bool {
+	return slices.Contains(p.Languages, language)
+}
+
+// Route picks the moderator for a comment: among those who speak its language,
+// the one with the fewest open reviews. It returns nil for unknown languages or
+// when nobody speaks it, the comment then waits in the general queue.
+func Route(language Language, profiles []*Profile, open map[string]int) *Profile {
+	if language == LanguageUnknown {
+		return nil
+	}
+	var picked *Profile
+	for _, p := range profiles {
+		if !p.Speaks(language) {
+			continue
+		}
+		if picked == nil || open[p.StaffID] < open[picked.StaffID] ||
+			(open[p.StaffID] == open[picked.StaffID] && p.StaffID < picked.StaffID) {
+			picked = p
+		}
+	}
+	return picked
+}
+
+// Dictionary is the banned-word list of one language. Comments that match are
+// held (hidden) until a moderator has looked at them.
+type Dictionary struct {
+	Language Language
+	Words    []string // Lowercased, a word or phrase each
+
+	LastActionBy *string
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+func NewDictionary(language Language, words []string, editorID string, at time.Time) (*Dictionary, error) {
+	if _, err := ParseLanguage(string(language)); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editor ID cannot be empty")
+	}
+	var cleaned []string
+	for _, w := range words {
+		w = strings.Join(strings.Fields(strings.ToLower(w)), " ")
+		if w == "" || len(w) > MaxWordLength {
+			return nil, ErrInvalidWord
+		}
+		if !slices.Contains(cleaned, w) {
+			cleaned = append(cleaned, w)
+		}
+	}
+	if len(cleaned) > MaxWords {
+		return nil, ErrTooManyWords
+	}
+	slices.Sort(cleaned)
+	return &Dictionary{Language: language, Words: cleaned, LastActionBy: &editorID, UpdatedAt: at}, nil
+}
+
+// Query Methods
+
+// Match returns the banned words in the text, matched as whole words so
+// "kasar" does not flag "kasaran" or a word inside a URL slug
+func (d *Dictionary) Match(text string) []string {
+	padded := " " + strings.Join(words(text), " ") + " "
+	var matched []string
+	for _, w := range d.Words {
+		if strings.Contains(padded, " "+strings.Join(words(w), " ")+" ") {
+			matched = append(matched, w)
+		}
+	}
+	return matched
+}
+
+// Review is a comment waiting in a moderator's queue
+type Review struct {
+	ID         string
+	CommentID  string
+	ArticleID  string
+	Language   Language
+	Matches    []string // Banned words found, empty for routine review
+	Scores     Scores
+	Held       bool    // Comment was hidden on arrival because of Matches, its toxicity or pre-moderation
+	AssigneeID *string // Nil in the general queue
+
+	Status     ReviewStatus
+	ResolvedBy *string
+	ResolvedAt *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewReview(id, commentID, articleID string, language Language, matches []string, at time.Time) (*Review, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(commentID) == "" {
+		return nil, errors.New("comment ID cannot be empty")
+	}
+	return &Review{
+		ID:        id,
+		CommentID: commentID,
+		ArticleID: articleID,
+		Language:  language,
+		Matches:   matches,
+		Held:      len(matches) > 0,
+		Status:    ReviewPending,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}, nil
+}
+
+// Business Methods
+
+// ApplyScores holds the comment when it scores at or over the hold threshold, and
+// approves it straight away when the provider scored it harmless. Heuristic
+// scores and banned-word matches always leave the review to a moderator.
+func (r *Review) ApplyScores(scores Scores, thresholds Thresholds, at time.Time) {
+	r.Scores = scores
+	r.UpdatedAt = at
+	if scores.Toxicity >= thresholds.HoldAt {
+		r.Held = true
+		return
+	}
+	if !r.Held && scores.Source == SourceProvider && scores.Toxicity < thresholds.AutoApproveBelow {
+		_ = r.Resolve(AutoModeratorID, true, at)
+	}
+}
+
+func (r *Review) Assign(staffID string, at time.Time) error {
+	if r.Status != ReviewPending {
+		return ErrAlreadyResolved
+	}
+	if strings.TrimSpace(staffID) == "" {
+		return errors.New("staff ID cannot be empty")
+	}
+	r.AssigneeID = &staffID
+	r.UpdatedAt = at
+	return nil
+}
+
+// Resolve records the moderator's decision, any moderator can resolve a
+// review so the queue does not stall while its assignee is away
+func (r *Review) Resolve(staffID string, approve bool, at time.Time) error {
+	if r.Status != ReviewPending {
+		return ErrAlreadyResolved
+	}
+	if strings.TrimSpace(staffID) == "" {
+		return errors.New("staff ID cannot be empty")
+	}
+	r.Status = ReviewRemoved
+	if approve {
+		r.Status = ReviewApproved
+	}
+	r.ResolvedBy = &staffID
+	r.ResolvedAt = &at
+	r.UpdatedAt = at
+	return nil
+}
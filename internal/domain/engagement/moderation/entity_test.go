@@ -0,0 +1,117 @@
+package moderation
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRoute(t *testing.T) {
+	at := time.Now()
+	ana, _ := NewProfile("ana", []string{"id", "jv"}, "lead-1", at)
+	budi, _ := NewProfile("budi", []string{"id", "en"}, "lead-1", at)
+	profiles := []*Profile{ana, budi}
+
+	testCases := []struct {
+		name     string
+		language Language
+		open     map[string]int
+		expected string
+	}{
+		{"only speaker", LanguageJavanese, map[string]int{"ana": 9}, "ana"},
+		{"fewest open", LanguageIndonesian, map[string]int{"ana": 3, "budi": 1}, "budi"},
+		{"tie by staff ID", LanguageIndonesian, nil, "ana"},
+		{"nobody speaks it", LanguageSundanese, nil, ""},
+		{"unknown language", LanguageUnknown, nil, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			picked := Route(tc.language, profiles, tc.open)
+			got := ""
+			if picked != nil {
+				got = picked.StaffID
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewProfile(t *testing.T) {
+	if _, err := NewProfile("ana", nil, "lead-1", time.Now()); err != ErrNoLanguages {
+		t.Errorf("expected error '%v', got '%v'", ErrNoLanguages, err)
+	}
+	if _, err := NewProfile("ana", []string{"fr"}, "lead-1", time.Now()); err != ErrInvalidLanguage {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidLanguage, err)
+	}
+	p, err := NewProfile("ana", []string{"id", "ID", "en"}, "lead-1", time.Now())
+	if err != nil || len(p.Languages) != 2 {
+		t.Errorf("expected duplicates dropped, got %+v, %v", p, err)
+	}
+}
+
+func TestDictionary_Match(t *testing.T) {
+	d, err := NewDictionary(LanguageIndonesian, []string{"Bodoh", "dasar  kampret", "bodoh"}, "lead-1", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.Words) != 2 {
+		t.Fatalf("expected words normalized and deduplicated, got %v", d.Words)
+	}
+
+	if got := d.Match("Dasar kampret, BODOH sekali!"); !slices.Equal(got, []string{"bodoh", "dasar kampret"}) {
+		t.Errorf("unexpected matches %v", got)
+	}
+	if got := d.Match("kebodohan struktural"); len(got) != 0 {
+		t.Errorf("expected whole words only, got %v", got)
+	}
+
+	if _, err := NewDictionary(LanguageIndonesian, []string{" "}, "lead-1", time.Now()); err != ErrInvalidWord {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidWord, err)
+	}
+}
+
+func TestReview_Resolve(t *testing.T) {
+	at := time.Now()
+	r, _ := NewReview("r1", "c1", "a1", LanguageIndonesian, []string{"bodoh"}, at)
+	if !r.Held {
+		t.Fatal("expected a review with matches to be held")
+	}
+	if err := r.Resolve("ana", true, at); err != nil || r.Status != ReviewApproved {
+		t.Fatalf("expected approved, got %s, %v", r.Status, err)
+	}
+	if err := r.Resolve("budi", false, at); err != ErrAlreadyResolved {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyResolved, err)
+	}
+	if err := r.Assign("budi", at); err != ErrAlreadyResolved {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyResolved, err)
+	}
+}
+
+func TestReview_ApplyScores(t *testing.T) {
+	at := time.Now()
+	tests := []struct {
+		name       string
+		matches    []string
+		scores     Scores
+		wantHeld   bool
+		wantStatus ReviewStatus
+	}{
+		{"harmless from provider", nil, Scores{Toxicity: 0.05, Source: SourceProvider}, false, ReviewApproved},
+		{"harmless from heuristic", nil, Scores{Toxicity: 0, Source: SourceHeuristic}, false, ReviewPending},
+		{"harmless with banned words", []string{"bodoh"}, Scores{Toxicity: 0.05, Source: SourceProvider}, true, ReviewPending},
+		{"borderline", nil, Scores{Toxicity: 0.5, Source: SourceProvider}, false, ReviewPending},
+		{"toxic", nil, Scores{Toxicity: 0.8, Source: SourceProvider}, true, ReviewPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := NewReview("r1", "c1", "a1", LanguageIndonesian, tt.matches, at)
+			r.ApplyScores(tt.scores, DefaultThresholds, at)
+			if r.Held != tt.wantHeld || r.Status != tt.wantStatus {
+				t.Errorf("expected held %v and %s, got %v and %s", tt.wantHeld, tt.wantStatus, r.Held, r.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package moderation
+
+import (
+	"context"
+	"time"
+)
+
+type ProfileRepository interface {
+	Save(ctx context.Context, profile *Profile) error
+	FindByStaff(ctx context.Context, staffID string) (*Profile, error) // nil when never set
+	FindByLanguage(ctx context.Context, language Language) ([]*Profile, error)
+	FindAll(ctx context.Context) ([]*Profile, error)
+}
+
+type DictionaryRepository interface {
+	Save(ctx context.Context, dictionary *Dictionary) error
+	FindByLanguage(ctx context.Context, language Language) (*Dictionary, error) // nil when never set
+}
+
+// Domain interface for the moderation queue (implementation will be in infrastructure layer)
+type ReviewRepository interface {
+	// Commands
+	Create(ctx context.Context, review *Review) error
+	Update(ctx context.Context, review *Review) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*Review, error)
+
+	// FindPending returns pending reviews most toxic first, then oldest first,
+	// assigned to the staff member or, with a nil assignee, the general queue
+	FindPending(ctx context.Context, assigneeID *string, limit, offset int) ([]*Review, error)
+
+	// CountPending returns the open reviews per assignee, for routing
+	CountPending(ctx context.Context, staffIDs []string) (map[string]int, error)
+
+	// FindScoresSince returns the scores of every review created since the given time, per article
+	FindScoresSince(ctx context.Context, since time.Time) (map[string][]Scores, error)
+}
+
+// Domain interface for toxicity scoring (implementation will be in infrastructure layer)
+type ToxicityScorer interface {
+	// Toxicity returns 0 for harmless to 1 for certainly toxic. Languages the
+	// provider does not support return an error so the heuristic takes over.
+	Toxicity(ctx context.Context, text string, language Language) (float64, error)
+}
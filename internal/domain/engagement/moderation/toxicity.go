@@ -0,0 +1,142 @@
+package moderation
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ScoreSource tells how a comment was scored
+type ScoreSource string
+
+const (
+	SourceProvider  ScoreSource = "provider"  // The toxicity provider, e.g. Perspective
+	SourceHeuristic ScoreSource = "heuristic" // The fallback when the provider is down or lacks the language
+)
+
+// AutoModeratorID resolves reviews that passed the auto-approval threshold
+const AutoModeratorID = "auto-moderator"
+
+// Heated discussion thresholds, over the comments of one article in a window
+const (
+	MinHeatedComments   = 10
+	HeatedMeanToxicity  = 0.35
+	HeatedNegativeShare = 0.6
+)
+
+var ErrInvalidThresholds = errors.New("thresholds must satisfy 0 <= auto-approve < hold <= 1")
+
+// Scores is how a comment reads to the scorer
+type Scores struct {
+	Toxicity  float64 // 0 harmless to 1 certainly toxic
+	Sentiment float64 // -1 negative to 1 positive
+	Source    ScoreSource
+}
+
+func (s Scores) IsNegative() bool {
+	return s.Sentiment < 0
+}
+
+// Thresholds gate what toxicity does to a new comment
+type Thresholds struct {
+	AutoApproveBelow float64 // Provider scores under this skip the queue
+	HoldAt           float64 // Scores at or over this hide the comment until reviewed
+}
+
+var DefaultThresholds = Thresholds{AutoApproveBelow: 0.15, HoldAt: 0.8}
+
+func (t Thresholds) Validate() error {
+	if t.AutoApproveBelow < 0 || t.AutoApproveBelow >= t.HoldAt || t.HoldAt > 1 {
+		return ErrInvalidThresholds
+	}
+	return nil
+}
+
+// Lexicons of the heuristic, Indonesian and English only: the other languages
+// are rare enough that the banned-word dictionaries cover them
+var (
+	insults       = set("goblok", "tolol", "bego", "bangsat", "brengsek", "anjing", "bajingan", "idiot", "stupid", "moron", "dumb", "loser")
+	negativeWords = set("buruk", "jelek", "bohong", "hoaks", "hoax", "kecewa", "benci", "salah", "parah", "payah", "bad", "terrible", "hate", "lie", "liar", "wrong", "awful", "shame")
+	positiveWords = set("bagus", "mantap", "setuju", "keren", "terima", "kasih", "hebat", "semangat", "good", "great", "agree", "thanks", "love", "nice", "helpful")
+)
+
+// HeuristicScore scores a comment from its banned-word matches, a small insult
+// lexicon and shouting. It is cruder than the provider, so callers never
+// auto-approve on it.
+func HeuristicScore(text string, matches []string) Scores {
+	toxicity := 0.45 * float64(len(matches))
+	for _, w := range words(text) {
+		if insults[w] {
+			toxicity += 0.3
+		}
+	}
+	if isShouting(text) {
+		toxicity += 0.15
+	}
+	if strings.Contains(text, "!!!") {
+		toxicity += 0.05
+	}
+	return Scores{Toxicity: min(toxicity, 1), Sentiment: Sentiment(text), Source: SourceHeuristic}
+}
+
+// Sentiment is (positive - negative) / (positive + negative) over lexicon words, 0 when none occur
+func Sentiment(text string) float64 {
+	var positive, negative int
+	for _, w := range words(text) {
+		switch {
+		case positiveWords[w]:
+			positive++
+		case negativeWords[w], insults[w]:
+			negative++
+		}
+	}
+	if positive+negative == 0 {
+		return 0
+	}
+	return float64(positive-negative) / float64(positive+negative)
+}
+
+// isShouting reports mostly-uppercase text, short exclamations like "OK" aside
+func isShouting(text string) bool {
+	var letters, upper int
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 12 && float64(upper) >= 0.7*float64(letters)
+}
+
+// DiscussionHeat tells editors how an article's comment section is going
+type DiscussionHeat struct {
+	ArticleID     string
+	Comments      int
+	MeanToxicity  float64
+	NegativeShare float64
+	Heated        bool
+}
+
+// AssessHeat flags a discussion as heated when enough comments arrived and they
+// are, on average, toxic or mostly negative
+func AssessHeat(articleID string, scores []Scores) DiscussionHeat {
+	heat := DiscussionHeat{ArticleID: articleID, Comments: len(scores)}
+	if len(scores) == 0 {
+		return heat
+	}
+	var toxicity float64
+	var negative int
+	for _, s := range scores {
+		toxicity += s.Toxicity
+		if s.IsNegative() {
+			negative++
+		}
+	}
+	heat.MeanToxicity = toxicity / float64(len(scores))
+	heat.NegativeShare = float64(negative) / float64(len(scores))
+	heat.Heated = heat.Comments >= MinHeatedComments &&
+		(heat.MeanToxicity >= HeatedMeanToxicity || heat.NegativeShare >= HeatedNegativeShare)
+	return heat
+}
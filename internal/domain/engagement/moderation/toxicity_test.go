@@ -0,0 +1,72 @@
+package moderation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeuristicScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		matches       []string
+		wantToxicity  float64
+		wantSentiment float64
+	}{
+		{"neutral", "Jam berapa sidangnya dimulai besok?", nil, 0, 0},
+		{"polite", "Liputan yang bagus, terima kasih", nil, 0, 1},
+		{"insult", "Penulisnya goblok", nil, 0.3, -1},
+		{"banned word and insult", "Dasar bodoh dan tolol", []string{"bodoh"}, 0.75, -1},
+		{"shouting", "BERITA INI SALAH SEMUA!!!", nil, 0.2, -1},
+		{"capped", "goblok tolol bego bangsat", []string{"a", "b"}, 1, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := HeuristicScore(tt.text, tt.matches)
+			if math.Abs(scores.Toxicity-tt.wantToxicity) > 1e-9 || scores.Sentiment != tt.wantSentiment || scores.Source != SourceHeuristic {
+				t.Errorf("expected toxicity %.2f and sentiment %.2f, got %+v", tt.wantToxicity, tt.wantSentiment, scores)
+			}
+		})
+	}
+}
+
+func TestThresholds_Validate(t *testing.T) {
+	if err := DefaultThresholds.Validate(); err != nil {
+		t.Errorf("expected default thresholds valid, got %v", err)
+	}
+	for _, th := range []Thresholds{{AutoApproveBelow: 0.5, HoldAt: 0.5}, {AutoApproveBelow: -0.1, HoldAt: 0.8}, {AutoApproveBelow: 0.1, HoldAt: 1.2}} {
+		if err := th.Validate(); err != ErrInvalidThresholds {
+			t.Errorf("expected error '%v' for %+v, got '%v'", ErrInvalidThresholds, th, err)
+		}
+	}
+}
+
+func TestAssessHeat(t *testing.T) {
+	calm := make([]Scores, MinHeatedComments)
+	toxic := make([]Scores, MinHeatedComments)
+	negative := make([]Scores, MinHeatedComments)
+	for i := range MinHeatedComments {
+		calm[i] = Scores{Toxicity: 0.1, Sentiment: 0.5}
+		toxic[i] = Scores{Toxicity: 0.5}
+		negative[i] = Scores{Toxicity: 0.1, Sentiment: -0.5}
+	}
+
+	tests := []struct {
+		name       string
+		scores     []Scores
+		wantHeated bool
+	}{
+		{"no comments", nil, false},
+		{"calm", calm, false},
+		{"toxic", toxic, true},
+		{"mostly negative", negative, true},
+		{"too few comments", toxic[:MinHeatedComments-1], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if heat := AssessHeat("a1", tt.scores); heat.Heated != tt.wantHeated || heat.Comments != len(tt.scores) {
+				t.Errorf("expected heated %v over %d comments, got %+v", tt.wantHeated, len(tt.scores), heat)
+			}
+		})
+	}
+}
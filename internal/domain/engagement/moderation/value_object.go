@@ -0,0 +1,113 @@
+package moderation
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// Detection thresholds, short comments like "mantap" stay undetected rather than guessed
+const (
+	minHits   = 2
+	minMargin = 1
+)
+
+// Limits for banned-word dictionaries
+const (
+	MaxWords      = 2000
+	MaxWordLength = 50
+)
+
+// Domain errors
+var (
+	ErrInvalidLanguage = errors.New("language must be id, en, jv or su")
+	ErrNoLanguages     = errors.New("moderator needs at least one language")
+	ErrInvalidWord     = errors.New("banned words cannot be empty or longer than 50 characters")
+	ErrTooManyWords    = errors.New("dictionary cannot exceed 2000 words")
+	ErrAlreadyResolved = errors.New("review is already resolved")
+)
+
+// Language of a comment, the ones the portal has readers and moderators for
+type Language string
+
+const (
+	LanguageIndonesian Language = "id"
+	LanguageEnglish    Language = "en"
+	LanguageJavanese   Language = "jv"
+	LanguageSundanese  Language = "su"
+	LanguageUnknown    Language = "und" // Too short or mixed to tell, goes to the general queue
+)
+
+// Languages in detection tie-break order, Indonesian first as the portal's main language
+var Languages = []Language{LanguageIndonesian, LanguageEnglish, LanguageJavanese, LanguageSundanese}
+
+func ParseLanguage(value string) (Language, error) {
+	l := Language(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range Languages {
+		if l == known {
+			return l, nil
+		}
+	}
+	return "", ErrInvalidLanguage
+}
+
+// stopwords are frequent function words that rarely appear in the other languages
+var stopwords = map[Language]map[string]bool{
+	LanguageIndonesian: set("yang", "dan", "di", "ini", "itu", "tidak", "dengan", "untuk", "ada", "dari", "saya", "kita", "akan", "sudah", "juga", "bisa", "karena", "tapi", "apa", "mereka", "harus", "lebih", "gak", "nggak", "kalau", "sama", "seperti", "banyak"),
+	LanguageEnglish:    set("the", "and", "is", "are", "was", "this", "that", "with", "for", "not", "of", "to", "you", "it", "have", "be", "they", "what", "but", "would", "should", "very", "just"),
+	LanguageJavanese:   set("ora", "iku", "karo", "sing", "aku", "kowe", "wis", "ing", "lan", "opo", "piye", "ngene", "ngono", "mung", "uga", "dadi", "kudu", "wong", "arep", "ra", "iso", "neng"),
+	LanguageSundanese:  set("teu", "nu", "jeung", "abdi", "anjeun", "naon", "kumaha", "geus", "mah", "ieu", "eta", "tos", "pisan", "urang", "oge", "bae", "kudu", "keur", "moal"),
+}
+
+// Detect guesses a comment's language from stopword counts. Comments too short
+// or too mixed to call are LanguageUnknown.
+func Detect(text string) Language {
+	hits := map[Language]int{}
+	for _, word := range words(text) {
+		for _, l := range Languages {
+			if stopwords[l][word] {
+				hits[l]++
+			}
+		}
+	}
+
+	best := Languages[0]
+	for _, l := range Languages[1:] {
+		if hits[l] > hits[best] {
+			best = l
+		}
+	}
+	runnerUp := 0
+	for _, l := range Languages {
+		if l != best && hits[l] > runnerUp {
+			runnerUp = hits[l]
+		}
+	}
+	if hits[best] < minHits || hits[best]-runnerUp < minMargin {
+		return LanguageUnknown
+	}
+	return best
+}
+
+// words lowercases and splits on anything that is not a letter or digit
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func set(values ...string) map[string]bool {
+	m := make(map[string]bool, len(values))
+	for _, v := range values {
+		m[v] = true
+	}
+	return m
+}
+
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved" // Comment stays or is restored
+	ReviewRemoved  ReviewStatus = "removed"  // Comment is hidden
+)
@@ -0,0 +1,35 @@
+package moderation
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected Language
+	}{
+		{"indonesian", "Saya tidak setuju dengan kebijakan ini, harus dikaji ulang!", LanguageIndonesian},
+		{"english", "This is not what the minister said, you should read it again.", LanguageEnglish},
+		{"javanese", "Aku ora ngerti piye carane, kudu takon wong liyo", LanguageJavanese},
+		{"sundanese", "Abdi teu satuju pisan jeung kaputusan eta", LanguageSundanese},
+		{"too short", "Mantap!", LanguageUnknown},
+		{"mixed evenly", "the yang", LanguageUnknown},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Detect(tc.text); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	if l, err := ParseLanguage(" JV "); err != nil || l != LanguageJavanese {
+		t.Errorf("expected jv, got %s, %v", l, err)
+	}
+	if _, err := ParseLanguage("und"); err != ErrInvalidLanguage {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidLanguage, err)
+	}
+}
@@ -0,0 +1,64 @@
+package offline
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Package is one offline download for a member. A delta package only carries
+// the articles the app lacks; Manifest always describes the full queue so the
+// app can replace its own.
+type Package struct {
+	AccountID   string
+	Format      Format
+	Articles    []Article
+	Removed     []string // Articles the app should delete
+	Locked      []string // Queued articles the member cannot read in full, e.g. premium without a subscription
+	Manifest    Manifest
+	Delta       bool
+	GeneratedAt time.Time
+}
+
+func NewPackage(accountID string, format Format, delta bool, at time.Time) (*Package, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if delta && format != FormatJSON {
+		return nil, ErrDeltaNeedsJSON
+	}
+	return &Package{AccountID: accountID, Format: format, Delta: delta, Manifest: Manifest{}, GeneratedAt: at}, nil
+}
+
+// Business Methods
+
+func (p *Package) Add(a Article) {
+	p.Articles = append(p.Articles, a)
+	p.Manifest[a.ID] = a.Revision
+}
+
+// Keep records an article the app already holds at the current revision
+func (p *Package) Keep(articleID, revision string) {
+	p.Manifest[articleID] = revision
+}
+
+func (p *Package) Lock(articleID string) {
+	p.Locked = append(p.Locked, articleID)
+}
+
+// Query Methods
+
+func (p *Package) IsEmpty() bool {
+	return len(p.Articles) == 0 && len(p.Removed) == 0
+}
+
+// Size is the bundled image payload in bytes, text is negligible next to it
+func (p *Package) Size() int {
+	size := 0
+	for _, a := range p.Articles {
+		for _, img := range a.Images {
+			size += len(img.Data)
+		}
+	}
+	return size
+}
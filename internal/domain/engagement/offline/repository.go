@@ -0,0 +1,28 @@
+package offline
+
+import (
+	"context"
+	"io"
+)
+
+// ReadingQueue lists a member's bookmarked and unread articles, newest saved first (implemented by the bookmarks module)
+type ReadingQueue interface {
+	FindQueue(ctx context.Context, accountID string, limit int) ([]QueueEntry, error)
+}
+
+// ArticleSource loads full articles for packaging (implemented by the article module).
+// Images come back with SourceURL and Alt only, the packager fetches renditions.
+type ArticleSource interface {
+	FindForOffline(ctx context.Context, articleIDs []string) ([]Article, error)
+}
+
+// Domain interface for image renditions (implementation will be in the media pipeline)
+type ImageOptimizer interface {
+	// Optimize returns the image scaled to at most maxWidth, re-encoded for size
+	Optimize(ctx context.Context, sourceURL string, maxWidth int) (data []byte, contentType string, err error)
+}
+
+// Domain interface for writing a package as an e-book (implementation will be in infrastructure layer)
+type BookEncoder interface {
+	EncodePackage(w io.Writer, p *Package) error
+}
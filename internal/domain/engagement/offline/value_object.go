@@ -0,0 +1,103 @@
+package offline
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/entitlement"
+)
+
+const (
+	// MaxArticles bounds one package, the app asks again for the rest
+	MaxArticles = 50
+	// ImageMaxWidth is the rendition width phones get, larger images are scaled down
+	ImageMaxWidth = 800
+)
+
+// Domain errors
+var (
+	ErrInvalidFormat  = errors.New("package format must be json or epub")
+	ErrDeltaNeedsJSON = errors.New("delta sync is only available for json packages")
+)
+
+// Format is how the package is delivered
+type Format string
+
+const (
+	FormatJSON Format = "json" // Supports delta sync, used by the mobile app
+	FormatEPUB Format = "epub" // One self-contained book for e-readers
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(value))); f {
+	case "":
+		return FormatJSON, nil
+	case FormatJSON, FormatEPUB:
+		return f, nil
+	}
+	return "", ErrInvalidFormat
+}
+
+// Manifest is what the app already holds: article ID to revision
+type Manifest map[string]string
+
+// QueueEntry is one article waiting in the member's offline queue
+type QueueEntry struct {
+	ArticleID string
+	Revision  string // Changes whenever the article text or images change
+	SavedAt   time.Time
+}
+
+// Delta is what the app must download and delete to match the queue
+type Delta struct {
+	Fetch     []string
+	Remove    []string
+	Unchanged int
+}
+
+// Plan compares the app's manifest with the current queue
+func Plan(have Manifest, queue []QueueEntry) Delta {
+	var d Delta
+	current := make(map[string]struct{}, len(queue))
+	for _, entry := range queue {
+		current[entry.ArticleID] = struct{}{}
+		if revision, ok := have[entry.ArticleID]; ok && revision == entry.Revision {
+			d.Unchanged++
+			continue
+		}
+		d.Fetch = append(d.Fetch, entry.ArticleID)
+	}
+	for id := range have {
+		if _, ok := current[id]; !ok {
+			d.Remove = append(d.Remove, id)
+		}
+	}
+	sort.Strings(d.Remove)
+	return d
+}
+
+// Image is an optimized picture bundled with an article
+type Image struct {
+	Name        string // File name inside the package, e.g. "a1-0.jpg"
+	SourceURL   string
+	Alt         string
+	ContentType string
+	Data        []byte
+}
+
+// Article is one article as the app stores it offline
+type Article struct {
+	ID          string
+	Revision    string
+	Title       string
+	Byline      string
+	Section     string
+	URL         string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+	Body        string // Sanitized HTML, image sources point at bundled image names
+	Images      []Image
+	Access      entitlement.Article
+}
@@ -0,0 +1,70 @@
+package offline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		value       string
+		expected    Format
+		expectedErr error
+	}{
+		{"", FormatJSON, nil},
+		{"EPUB", FormatEPUB, nil},
+		{"pdf", "", ErrInvalidFormat},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			format, err := ParseFormat(tc.value)
+			if err != tc.expectedErr || format != tc.expected {
+				t.Errorf("expected %q and error '%v', got %q and '%v'", tc.expected, tc.expectedErr, format, err)
+			}
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	have := Manifest{"a1": "r1", "a2": "r1", "a3": "r1"}
+	queue := []QueueEntry{
+		{ArticleID: "a1", Revision: "r1"},
+		{ArticleID: "a2", Revision: "r2"},
+		{ArticleID: "a4", Revision: "r1"},
+	}
+
+	d := Plan(have, queue)
+	if d.Unchanged != 1 {
+		t.Errorf("expected 1 unchanged, got %d", d.Unchanged)
+	}
+	if len(d.Fetch) != 2 || d.Fetch[0] != "a2" || d.Fetch[1] != "a4" {
+		t.Errorf("expected a2 and a4 fetched in queue order, got %v", d.Fetch)
+	}
+	if len(d.Remove) != 1 || d.Remove[0] != "a3" {
+		t.Errorf("expected a3 removed, got %v", d.Remove)
+	}
+
+	if full := Plan(nil, queue); len(full.Fetch) != 3 || len(full.Remove) != 0 {
+		t.Errorf("expected everything fetched without a manifest, got %+v", full)
+	}
+}
+
+func TestNewPackage(t *testing.T) {
+	if _, err := NewPackage("member-1", FormatEPUB, true, time.Now()); err != ErrDeltaNeedsJSON {
+		t.Errorf("expected error '%v', got '%v'", ErrDeltaNeedsJSON, err)
+	}
+
+	p, err := NewPackage("member-1", FormatJSON, true, time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if !p.IsEmpty() {
+		t.Error("expected a new package to be empty")
+	}
+	p.Add(Article{ID: "a1", Revision: "r2", Images: []Image{{Data: make([]byte, 300)}}})
+	p.Keep("a2", "r1")
+	if p.IsEmpty() || p.Size() != 300 || len(p.Manifest) != 2 || p.Manifest["a1"] != "r2" {
+		t.Errorf("unexpected package %+v", p)
+	}
+}
@@ -0,0 +1,193 @@
+package puzzle
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindCrossword Kind = "crossword"
+	KindSudoku    Kind = "sudoku"
+)
+
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+type Status string
+
+const (
+	StatusDraft     Status = "draft"
+	StatusScheduled Status = "scheduled"
+	StatusRetired   Status = "retired"
+)
+
+// Puzzle is one daily game, published by scheduling it for a day
+type Puzzle struct {
+	ID         string
+	Kind       Kind
+	Title      string
+	Difficulty Difficulty
+	Solution   Grid
+	Givens     *Grid  // Sudoku only, the pre-filled cells
+	Clues      []Clue // Crossword only
+	Status     Status
+	PlayDate   *time.Time // UTC day the puzzle is the daily puzzle
+	AuthorID   string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCrossword(id, authorID, title string, difficulty Difficulty, solution Grid, clues []Clue) (*Puzzle, error) {
+	if err := validateCrosswordSolution(solution); err != nil {
+		return nil, err
+	}
+	if len(clues) == 0 {
+		return nil, errors.New("crossword must have clues")
+	}
+	for _, c := range clues {
+		if err := validateClue(c, solution); err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := newPuzzle(id, authorID, title, KindCrossword, difficulty, solution)
+	if err != nil {
+		return nil, err
+	}
+	p.Clues = append([]Clue(nil), clues...)
+	return p, nil
+}
+
+func NewSudoku(id, authorID, title string, difficulty Difficulty, solution, givens Grid) (*Puzzle, error) {
+	if err := validateSudokuSolution(solution); err != nil {
+		return nil, err
+	}
+	if err := validateGivens(givens, solution); err != nil {
+		return nil, err
+	}
+
+	p, err := newPuzzle(id, authorID, title, KindSudoku, difficulty, solution)
+	if err != nil {
+		return nil, err
+	}
+	p.Givens = &givens
+	return p, nil
+}
+
+func newPuzzle(id, authorID, title string, kind Kind, difficulty Difficulty, solution Grid) (*Puzzle, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(authorID) == "" {
+		return nil, errors.New("author ID cannot be empty")
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, errors.New("title cannot be empty")
+	}
+	if err := validateDifficulty(difficulty); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Puzzle{
+		ID:         id,
+		Kind:       kind,
+		Title:      title,
+		Difficulty: difficulty,
+		Solution:   solution,
+		Status:     StatusDraft,
+		AuthorID:   authorID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// Business Methods
+
+// Schedule makes the puzzle the daily puzzle of the given day, only future days can be scheduled
+func (p *Puzzle) Schedule(day time.Time) error {
+	if p.Status == StatusRetired {
+		return errors.New("cannot schedule retired puzzle")
+	}
+	if p.IsPlayable(time.Now()) {
+		return errors.New("cannot reschedule a puzzle that is already live")
+	}
+	day = Day(day)
+	if day.Before(Day(time.Now())) {
+		return errors.New("cannot schedule puzzle in the past")
+	}
+
+	p.PlayDate = &day
+	p.Status = StatusScheduled
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Puzzle) Unschedule() error {
+	if p.Status != StatusScheduled {
+		return errors.New("puzzle is not scheduled")
+	}
+	if p.IsPlayable(time.Now()) {
+		return errors.New("cannot unschedule a puzzle that is already live")
+	}
+
+	p.PlayDate = nil
+	p.Status = StatusDraft
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+func (p *Puzzle) Retire() error {
+	if p.Status == StatusRetired {
+		return errors.New("puzzle is already retired")
+	}
+	p.Status = StatusRetired
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Board returns the grid a new player starts from
+func (p *Puzzle) Board() Grid {
+	if p.Givens != nil {
+		return *p.Givens
+	}
+	return p.Solution.Masked()
+}
+
+// Query Methods
+
+// IsPlayable reports whether the puzzle has been released by the given time.
+// Past daily puzzles stay playable as an archive.
+func (p *Puzzle) IsPlayable(at time.Time) bool {
+	return p.Status == StatusScheduled && p.PlayDate != nil && !at.Before(*p.PlayDate)
+}
+
+// IsDailyOn reports whether the puzzle is the daily puzzle of the day of 'at'
+func (p *Puzzle) IsDailyOn(at time.Time) bool {
+	return p.Status == StatusScheduled && p.PlayDate != nil && p.PlayDate.Equal(Day(at))
+}
+
+// IsSolvedBy reports whether the entries match the solution
+func (p *Puzzle) IsSolvedBy(entries Grid) bool {
+	return p.Solution.Equals(entries)
+}
+
+func validateDifficulty(difficulty Difficulty) error {
+	switch difficulty {
+	case DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return nil
+	default:
+		return errors.New("invalid difficulty")
+	}
+}
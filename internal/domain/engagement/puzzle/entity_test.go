@@ -0,0 +1,149 @@
+package puzzle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCrossword(t *testing.T) {
+	solution := createTestCrosswordGrid(t)
+	clues := []Clue{
+		{Number: 1, Direction: DirectionAcross, Row: 0, Col: 0, Text: "Feline pet"},
+		{Number: 1, Direction: DirectionDown, Row: 0, Col: 0, Text: "Looks after"},
+	}
+
+	tests := []struct {
+		name    string
+		title   string
+		diff    Difficulty
+		clues   []Clue
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "valid", title: "Monday Mini", diff: DifficultyEasy, clues: clues},
+		{name: "empty title", title: " ", diff: DifficultyEasy, clues: clues, wantErr: true, errMsg: "title cannot be empty"},
+		{name: "invalid difficulty", title: "Mini", diff: "expert", clues: clues, wantErr: true, errMsg: "invalid difficulty"},
+		{name: "no clues", title: "Mini", diff: DifficultyEasy, wantErr: true, errMsg: "crossword must have clues"},
+		{name: "invalid clue", title: "Mini", diff: DifficultyEasy, clues: []Clue{{Number: 1, Direction: "diagonal", Text: "x"}}, wantErr: true, errMsg: ErrInvalidClue.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewCrossword("p1", "editor1", tt.title, tt.diff, solution, tt.clues)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Status != StatusDraft {
+				t.Errorf("expected draft status, got %s", p.Status)
+			}
+			if p.Board().Equals(p.Solution) {
+				t.Error("expected board not to reveal the solution")
+			}
+		})
+	}
+
+	bad := append([]string(nil), testCrosswordCells...)
+	bad[0] = "1"
+	badGrid, _ := NewGrid(5, 5, bad)
+	if _, err := NewCrossword("p1", "editor1", "Mini", DifficultyEasy, *badGrid, clues); err != ErrInvalidCell {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidCell, err)
+	}
+}
+
+func TestNewSudoku(t *testing.T) {
+	solution := createTestSudokuGrid(t, testSudoku)
+
+	givens := make([]string, 81)
+	for i := 0; i < 81; i += 3 {
+		givens[i] = solution.cells[i]
+	}
+	givensGrid, _ := NewGrid(9, 9, givens)
+
+	p, err := NewSudoku("s1", "editor1", "Daily Sudoku", DifficultyMedium, solution, *givensGrid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !p.Board().Equals(*givensGrid) {
+		t.Error("expected board to be the givens")
+	}
+
+	givens[1] = "9"
+	wrongGivens, _ := NewGrid(9, 9, givens)
+	if _, err := NewSudoku("s1", "editor1", "Daily Sudoku", DifficultyMedium, solution, *wrongGivens); err != ErrGivensMismatch {
+		t.Errorf("expected error '%v', got '%v'", ErrGivensMismatch, err)
+	}
+}
+
+func TestPuzzle_Schedule(t *testing.T) {
+	p := createTestCrossword(t, DifficultyEasy)
+	today := Day(time.Now())
+
+	if err := p.Schedule(today.AddDate(0, 0, -1)); err == nil {
+		t.Error("expected error scheduling in the past")
+	}
+
+	if err := p.Schedule(today.AddDate(0, 0, 2)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.IsPlayable(time.Now()) {
+		t.Error("expected future puzzle not to be playable")
+	}
+	if !p.IsDailyOn(today.AddDate(0, 0, 2).Add(15 * time.Hour)) {
+		t.Error("expected puzzle to be the daily puzzle on its day")
+	}
+	if !p.IsPlayable(today.AddDate(0, 0, 5)) || p.IsDailyOn(today.AddDate(0, 0, 5)) {
+		t.Error("expected past daily puzzle to stay playable as archive")
+	}
+
+	if err := p.Unschedule(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Status != StatusDraft || p.PlayDate != nil {
+		t.Error("expected puzzle back in draft")
+	}
+
+	if err := p.Schedule(today); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.Schedule(today.AddDate(0, 0, 1)); err == nil || err.Error() != "cannot reschedule a puzzle that is already live" {
+		t.Errorf("expected live puzzle error, got %v", err)
+	}
+	if err := p.Unschedule(); err == nil {
+		t.Error("expected error unscheduling a live puzzle")
+	}
+
+	_ = p.Retire()
+	if err := p.Retire(); err == nil {
+		t.Error("expected error retiring twice")
+	}
+	if p.IsPlayable(time.Now()) {
+		t.Error("expected retired puzzle not to be playable")
+	}
+}
+
+func createTestCrossword(t *testing.T, difficulty Difficulty) *Puzzle {
+	p, err := NewCrossword("p1", "editor1", "Mini", difficulty, createTestCrosswordGrid(t), []Clue{
+		{Number: 1, Direction: DirectionAcross, Row: 0, Col: 0, Text: "Feline pet"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create puzzle: %v", err)
+	}
+	return p
+}
+
+// createLiveCrossword returns a crossword that went live today
+func createLiveCrossword(t *testing.T, difficulty Difficulty) *Puzzle {
+	p := createTestCrossword(t, difficulty)
+	if err := p.Schedule(time.Now()); err != nil {
+		t.Fatalf("failed to schedule puzzle: %v", err)
+	}
+	return p
+}
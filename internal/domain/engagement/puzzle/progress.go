@@ -0,0 +1,151 @@
+package puzzle
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Anti-cheat limits
+const (
+	// MinSaveInterval throttles progress saves, bots submitting guesses save far faster than people
+	MinSaveInterval = time.Second
+
+	// ClockSlack tolerates the client timer running slightly ahead of the server
+	ClockSlack = 5 * time.Second
+)
+
+// minSolveTimes are the fastest plausible solves, anything quicker is kept off the leaderboard
+var minSolveTimes = map[Kind]map[Difficulty]time.Duration{
+	KindCrossword: {DifficultyEasy: 45 * time.Second, DifficultyMedium: 90 * time.Second, DifficultyHard: 3 * time.Minute},
+	KindSudoku:    {DifficultyEasy: 40 * time.Second, DifficultyMedium: 80 * time.Second, DifficultyHard: 2 * time.Minute},
+}
+
+var (
+	ErrSavingTooFast   = errors.New("progress is being saved too quickly")
+	ErrAlreadySolved   = errors.New("puzzle is already solved")
+	ErrGridMismatch    = errors.New("entries do not match the puzzle grid")
+	ErrGivenOverridden = errors.New("given cells cannot be changed")
+	ErrClockRewound    = errors.New("elapsed time cannot go backwards")
+	ErrNotPlayable     = errors.New("puzzle is not playable")
+)
+
+// Progress is a member's saved state on one puzzle
+type Progress struct {
+	ID        string
+	PuzzleID  string
+	AccountID string
+	Entries   Grid
+	Elapsed   time.Duration // Active play time reported by the client, pauses excluded
+	Saves     int
+
+	// Completion
+	CompletedAt *time.Time
+	Flagged     bool // Implausible solve, kept off the leaderboard
+	FlagReason  *string
+
+	// Audit
+	StartedAt   time.Time
+	LastSavedAt time.Time
+}
+
+func NewProgress(id string, p *Puzzle, accountID string, at time.Time) (*Progress, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if !p.IsPlayable(at) {
+		return nil, ErrNotPlayable
+	}
+
+	return &Progress{
+		ID:          id,
+		PuzzleID:    p.ID,
+		AccountID:   accountID,
+		Entries:     p.Board(),
+		StartedAt:   at,
+		LastSavedAt: at,
+	}, nil
+}
+
+// Business Methods
+
+// Save stores the current entries and play time
+func (pr *Progress) Save(p *Puzzle, entries Grid, elapsed time.Duration, at time.Time) error {
+	if pr.IsCompleted() {
+		return ErrAlreadySolved
+	}
+	if pr.Saves > 0 && at.Sub(pr.LastSavedAt) < MinSaveInterval {
+		return ErrSavingTooFast
+	}
+	if err := pr.checkEntries(p, entries); err != nil {
+		return err
+	}
+	if elapsed < pr.Elapsed {
+		return ErrClockRewound
+	}
+
+	pr.Entries = entries
+	pr.Elapsed = elapsed
+	pr.Saves++
+	pr.LastSavedAt = at
+	return nil
+}
+
+// Submit saves the entries and completes the progress when they solve the puzzle.
+// Implausibly fast solves still count for the player but are flagged.
+func (pr *Progress) Submit(p *Puzzle, entries Grid, elapsed time.Duration, at time.Time) (bool, error) {
+	if err := pr.Save(p, entries, elapsed, at); err != nil {
+		return false, err
+	}
+	if !p.IsSolvedBy(entries) {
+		return false, nil
+	}
+
+	pr.CompletedAt = &at
+	if reason := pr.implausibility(p, at); reason != "" {
+		pr.Flagged = true
+		pr.FlagReason = &reason
+	}
+	return true, nil
+}
+
+func (pr *Progress) checkEntries(p *Puzzle, entries Grid) error {
+	if !entries.SameShape(p.Solution) {
+		return ErrGridMismatch
+	}
+	board := p.Board()
+	for i, c := range board.cells {
+		if p.Kind == KindSudoku && c != EmptyCell && entries.cells[i] != c {
+			return ErrGivenOverridden
+		}
+		if (c == BlockCell) != (entries.cells[i] == BlockCell) {
+			return ErrGridMismatch
+		}
+	}
+	return nil
+}
+
+func (pr *Progress) implausibility(p *Puzzle, at time.Time) string {
+	wall := at.Sub(pr.StartedAt)
+	if pr.Elapsed > wall+ClockSlack {
+		return "reported time exceeds time since start"
+	}
+	if minimum := minSolveTimes[p.Kind][p.Difficulty]; pr.Elapsed < minimum || wall < minimum {
+		return "solved faster than the minimum plausible time"
+	}
+	return ""
+}
+
+// Query Methods
+
+func (pr *Progress) IsCompleted() bool {
+	return pr.CompletedAt != nil
+}
+
+// IsRanked reports whether the solve belongs on the leaderboard
+func (pr *Progress) IsRanked() bool {
+	return pr.IsCompleted() && !pr.Flagged
+}
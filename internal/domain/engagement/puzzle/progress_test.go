@@ -0,0 +1,132 @@
+package puzzle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgress_SaveChecks(t *testing.T) {
+	p := createLiveCrossword(t, DifficultyEasy)
+	start := time.Now()
+
+	progress, err := NewProgress("pr1", p, "member1", start)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	partial := p.Board().Cells()
+	partial[0] = "C"
+	entries, _ := NewGrid(5, 5, partial)
+
+	if err := progress.Save(p, *entries, 10*time.Second, start.Add(10*time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name        string
+		cells       []string
+		elapsed     time.Duration
+		at          time.Time
+		expectedErr error
+	}{
+		{name: "too fast", cells: partial, elapsed: 11 * time.Second, at: start.Add(10*time.Second + 100*time.Millisecond), expectedErr: ErrSavingTooFast},
+		{name: "clock rewound", cells: partial, elapsed: 5 * time.Second, at: start.Add(20 * time.Second), expectedErr: ErrClockRewound},
+		{name: "block filled", cells: fill(partial, 3, "X"), elapsed: 20 * time.Second, at: start.Add(20 * time.Second), expectedErr: ErrGridMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, _ := NewGrid(5, 5, tc.cells)
+			if err := progress.Save(p, *g, tc.elapsed, tc.at); err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+
+	other, _ := NewGrid(3, 3, make([]string, 9))
+	if err := progress.Save(p, *other, 30*time.Second, start.Add(30*time.Second)); err != ErrGridMismatch {
+		t.Errorf("expected error '%v', got '%v'", ErrGridMismatch, err)
+	}
+}
+
+func TestProgress_SudokuGivensLocked(t *testing.T) {
+	solution := createTestSudokuGrid(t, testSudoku)
+	givens := make([]string, 81)
+	givens[0] = solution.cells[0]
+	givensGrid, _ := NewGrid(9, 9, givens)
+	p, _ := NewSudoku("s1", "editor1", "Sudoku", DifficultyEasy, solution, *givensGrid)
+	_ = p.Schedule(time.Now())
+
+	progress, _ := NewProgress("pr1", p, "member1", time.Now())
+	tampered := append([]string(nil), givens...)
+	tampered[0] = "1"
+	g, _ := NewGrid(9, 9, tampered)
+	if err := progress.Save(p, *g, time.Second, time.Now()); err != ErrGivenOverridden {
+		t.Errorf("expected error '%v', got '%v'", ErrGivenOverridden, err)
+	}
+}
+
+func TestProgress_Submit(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		wall    time.Duration
+		solved  bool
+		ranked  bool
+		flagged string
+	}{
+		{name: "plausible solve", elapsed: 2 * time.Minute, wall: 3 * time.Minute, solved: true, ranked: true},
+		{name: "too fast", elapsed: 10 * time.Second, wall: 3 * time.Minute, solved: true, flagged: "solved faster than the minimum plausible time"},
+		{name: "wall clock too short", elapsed: time.Minute, wall: 30 * time.Second, solved: true, flagged: "reported time exceeds time since start"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createLiveCrossword(t, DifficultyEasy)
+			start := time.Now()
+			progress, _ := NewProgress("pr1", p, "member1", start)
+
+			solved, err := progress.Submit(p, p.Solution, tt.elapsed, start.Add(tt.wall))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if solved != tt.solved || progress.IsRanked() != tt.ranked {
+				t.Errorf("expected solved %v ranked %v, got %v %v", tt.solved, tt.ranked, solved, progress.IsRanked())
+			}
+			if tt.flagged != "" && (progress.FlagReason == nil || *progress.FlagReason != tt.flagged) {
+				t.Errorf("expected flag %q, got %v", tt.flagged, progress.FlagReason)
+			}
+
+			if _, err := progress.Submit(p, p.Solution, tt.elapsed, start.Add(tt.wall+time.Minute)); err != ErrAlreadySolved {
+				t.Errorf("expected error '%v', got '%v'", ErrAlreadySolved, err)
+			}
+		})
+	}
+}
+
+func TestProgress_SubmitWrongEntries(t *testing.T) {
+	p := createLiveCrossword(t, DifficultyEasy)
+	start := time.Now()
+	progress, _ := NewProgress("pr1", p, "member1", start)
+
+	solved, err := progress.Submit(p, p.Board(), time.Minute, start.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if solved || progress.IsCompleted() {
+		t.Error("expected unsolved progress")
+	}
+}
+
+func TestNewProgress_NotPlayable(t *testing.T) {
+	p := createTestCrossword(t, DifficultyEasy)
+	if _, err := NewProgress("pr1", p, "member1", time.Now()); err != ErrNotPlayable {
+		t.Errorf("expected error '%v', got '%v'", ErrNotPlayable, err)
+	}
+}
+
+func fill(cells []string, index int, value string) []string {
+	result := append([]string(nil), cells...)
+	result[index] = value
+	return result
+}
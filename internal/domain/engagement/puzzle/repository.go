@@ -0,0 +1,34 @@
+package puzzle
+
+import (
+	"context"
+	"time"
+)
+
+type PuzzleRepository interface {
+	// Commands
+	Create(ctx context.Context, p *Puzzle) error
+	Update(ctx context.Context, p *Puzzle) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Puzzle, error)
+
+	// FindDaily returns the puzzle scheduled for the UTC day, nil when none
+	FindDaily(ctx context.Context, kind Kind, day time.Time) (*Puzzle, error)
+	FindArchive(ctx context.Context, kind Kind, before time.Time, limit, offset int) ([]*Puzzle, error)
+}
+
+type ProgressRepository interface {
+	Save(ctx context.Context, progress *Progress) error
+	FindByAccountAndPuzzle(ctx context.Context, accountID, puzzleID string) (*Progress, error)
+
+	// FindLeaderboard returns ranked (completed, unflagged) progress, fastest first
+	FindLeaderboard(ctx context.Context, puzzleID string, limit int) ([]*Progress, error)
+}
+
+type StreakRepository interface {
+	Save(ctx context.Context, streak *Streak) error
+
+	// Find returns nil when the member has never solved a daily puzzle of the kind
+	Find(ctx context.Context, accountID string, kind Kind) (*Streak, error)
+}
@@ -0,0 +1,57 @@
+package puzzle
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Streak counts consecutive days a member solved the daily puzzle of a kind
+type Streak struct {
+	AccountID    string
+	Kind         Kind
+	Current      int
+	Longest      int
+	LastSolvedOn *time.Time
+}
+
+func NewStreak(accountID string, kind Kind) (*Streak, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if kind != KindCrossword && kind != KindSudoku {
+		return nil, errors.New("invalid puzzle kind")
+	}
+	return &Streak{AccountID: accountID, Kind: kind}, nil
+}
+
+// RecordSolve counts a daily solve on the given day, solving the same day twice is a no-op
+func (s *Streak) RecordSolve(day time.Time) {
+	day = Day(day)
+	switch {
+	case s.LastSolvedOn == nil:
+		s.Current = 1
+	case !day.After(*s.LastSolvedOn):
+		return
+	case day.Equal(s.LastSolvedOn.AddDate(0, 0, 1)):
+		s.Current++
+	default:
+		s.Current = 1
+	}
+
+	s.LastSolvedOn = &day
+	if s.Current > s.Longest {
+		s.Longest = s.Current
+	}
+}
+
+// CurrentAt returns the streak as seen on the given day, it stays alive until a full day is missed
+func (s *Streak) CurrentAt(at time.Time) int {
+	if s.LastSolvedOn == nil {
+		return 0
+	}
+	if Day(at).After(s.LastSolvedOn.AddDate(0, 0, 1)) {
+		return 0
+	}
+	return s.Current
+}
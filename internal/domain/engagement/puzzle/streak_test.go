@@ -0,0 +1,56 @@
+package puzzle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreak_RecordSolve(t *testing.T) {
+	streak, err := NewStreak("member1", KindCrossword)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	day := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name    string
+		at      time.Time
+		current int
+		longest int
+	}{
+		{name: "first solve", at: day, current: 1, longest: 1},
+		{name: "same day again", at: day.Add(5 * time.Hour), current: 1, longest: 1},
+		{name: "next day", at: day.AddDate(0, 0, 1), current: 2, longest: 2},
+		{name: "day after", at: day.AddDate(0, 0, 2), current: 3, longest: 3},
+		{name: "missed a day", at: day.AddDate(0, 0, 4), current: 1, longest: 3},
+		{name: "older day ignored", at: day.AddDate(0, 0, 3), current: 1, longest: 3},
+	}
+
+	for _, s := range steps {
+		streak.RecordSolve(s.at)
+		if streak.Current != s.current || streak.Longest != s.longest {
+			t.Errorf("%s: expected %d/%d, got %d/%d", s.name, s.current, s.longest, streak.Current, streak.Longest)
+		}
+	}
+}
+
+func TestStreak_CurrentAt(t *testing.T) {
+	streak, _ := NewStreak("member1", KindSudoku)
+	day := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	if streak.CurrentAt(day) != 0 {
+		t.Error("expected no streak before first solve")
+	}
+
+	streak.RecordSolve(day)
+	if streak.CurrentAt(day.AddDate(0, 0, 1)) != 1 {
+		t.Error("expected streak alive the next day")
+	}
+	if streak.CurrentAt(day.AddDate(0, 0, 2)) != 0 {
+		t.Error("expected streak broken after a missed day")
+	}
+
+	if _, err := NewStreak("member1", "chess"); err == nil {
+		t.Error("expected error for invalid kind")
+	}
+}
@@ -0,0 +1,199 @@
+package puzzle
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// BlockCell marks a black square in a crossword grid, EmptyCell an unfilled one
+const (
+	BlockCell = "#"
+	EmptyCell = ""
+)
+
+// Domain errors
+var (
+	ErrInvalidGridSize   = errors.New("grid must be between 3x3 and 25x25")
+	ErrCellCountMismatch = errors.New("cell count does not match grid size")
+	ErrInvalidCell       = errors.New("grid contains an invalid cell")
+	ErrInvalidSudoku     = errors.New("sudoku solution is not valid")
+	ErrGivensMismatch    = errors.New("givens do not match the solution")
+	ErrInvalidClue       = errors.New("invalid clue")
+)
+
+// Grid value object, cells stored row by row
+type Grid struct {
+	rows  int
+	cols  int
+	cells []string
+}
+
+func NewGrid(rows, cols int, cells []string) (*Grid, error) {
+	if rows < 3 || cols < 3 || rows > 25 || cols > 25 {
+		return nil, ErrInvalidGridSize
+	}
+	if len(cells) != rows*cols {
+		return nil, ErrCellCountMismatch
+	}
+
+	normalized := make([]string, len(cells))
+	for i, c := range cells {
+		normalized[i] = strings.ToUpper(strings.TrimSpace(c))
+	}
+	return &Grid{rows: rows, cols: cols, cells: normalized}, nil
+}
+
+func (g Grid) Rows() int {
+	return g.rows
+}
+
+func (g Grid) Cols() int {
+	return g.cols
+}
+
+func (g Grid) Cells() []string {
+	return append([]string(nil), g.cells...)
+}
+
+func (g Grid) At(row, col int) string {
+	return g.cells[row*g.cols+col]
+}
+
+func (g Grid) SameShape(other Grid) bool {
+	return g.rows == other.rows && g.cols == other.cols
+}
+
+func (g Grid) Equals(other Grid) bool {
+	if !g.SameShape(other) {
+		return false
+	}
+	for i := range g.cells {
+		if g.cells[i] != other.cells[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Masked returns a copy keeping only block cells, the empty board shown to players
+func (g Grid) Masked() Grid {
+	cells := make([]string, len(g.cells))
+	for i, c := range g.cells {
+		if c == BlockCell {
+			cells[i] = BlockCell
+		}
+	}
+	return Grid{rows: g.rows, cols: g.cols, cells: cells}
+}
+
+// Domain Validation Functions
+
+// validateCrosswordSolution requires every cell to be a block or a single letter A-Z
+func validateCrosswordSolution(g Grid) error {
+	for _, c := range g.cells {
+		if c == BlockCell {
+			continue
+		}
+		if len(c) != 1 || c[0] < 'A' || c[0] > 'Z' {
+			return ErrInvalidCell
+		}
+	}
+	return nil
+}
+
+// validateSudokuSolution requires a complete 9x9 grid with every row, column and box holding 1-9 once
+func validateSudokuSolution(g Grid) error {
+	if g.rows != 9 || g.cols != 9 {
+		return ErrInvalidSudoku
+	}
+	for i := 0; i < 9; i++ {
+		var row, col, box [10]bool
+		for j := 0; j < 9; j++ {
+			for _, check := range []struct {
+				seen *[10]bool
+				cell string
+			}{
+				{&row, g.At(i, j)},
+				{&col, g.At(j, i)},
+				{&box, g.At(3*(i/3)+j/3, 3*(i%3)+j%3)},
+			} {
+				if len(check.cell) != 1 || check.cell[0] < '1' || check.cell[0] > '9' {
+					return ErrInvalidSudoku
+				}
+				d := check.cell[0] - '0'
+				if check.seen[d] {
+					return ErrInvalidSudoku
+				}
+				check.seen[d] = true
+			}
+		}
+	}
+	return nil
+}
+
+// validateGivens requires every given to match the solution
+func validateGivens(givens, solution Grid) error {
+	if !givens.SameShape(solution) {
+		return ErrGivensMismatch
+	}
+	for i, c := range givens.cells {
+		if c != EmptyCell && c != solution.cells[i] {
+			return ErrGivensMismatch
+		}
+	}
+	return nil
+}
+
+type Direction string
+
+const (
+	DirectionAcross Direction = "across"
+	DirectionDown   Direction = "down"
+)
+
+// Clue is one crossword clue, positioned by the cell its answer starts at
+type Clue struct {
+	Number    int
+	Direction Direction
+	Row       int
+	Col       int
+	Text      string
+}
+
+// Answer reads the clue's answer from the solution, running until a block or the edge
+func (c Clue) Answer(solution Grid) string {
+	var b strings.Builder
+	row, col := c.Row, c.Col
+	for row < solution.rows && col < solution.cols && solution.At(row, col) != BlockCell {
+		b.WriteString(solution.At(row, col))
+		if c.Direction == DirectionAcross {
+			col++
+		} else {
+			row++
+		}
+	}
+	return b.String()
+}
+
+func validateClue(c Clue, solution Grid) error {
+	if c.Number <= 0 || strings.TrimSpace(c.Text) == "" {
+		return ErrInvalidClue
+	}
+	if c.Direction != DirectionAcross && c.Direction != DirectionDown {
+		return ErrInvalidClue
+	}
+	if c.Row < 0 || c.Col < 0 || c.Row >= solution.rows || c.Col >= solution.cols {
+		return ErrInvalidClue
+	}
+	if len(c.Answer(solution)) < 2 {
+		return ErrInvalidClue
+	}
+	return nil
+}
+
+// Day truncates a time to its UTC calendar day, puzzles are scheduled per day
+func Day(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
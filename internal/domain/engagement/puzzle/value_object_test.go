@@ -0,0 +1,126 @@
+package puzzle
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// 5x5 crossword, rows: CAT#S / A#OAK / RATES / E#ONE / SLOTS
+var testCrosswordCells = strings.Split("C,A,T,#,S,A,#,O,A,K,R,A,T,E,S,E,#,O,N,E,S,L,O,T,S", ",")
+
+const testSudoku = "534678912672195348198342567859761423426853791713924856961537284287419635345286179"
+
+func TestNewGrid(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rows, cols  int
+		cells       int
+		expectedErr error
+	}{
+		{name: "valid", rows: 5, cols: 5, cells: 25},
+		{name: "too small", rows: 2, cols: 5, cells: 10, expectedErr: ErrInvalidGridSize},
+		{name: "too large", rows: 26, cols: 5, cells: 130, expectedErr: ErrInvalidGridSize},
+		{name: "cell count mismatch", rows: 5, cols: 5, cells: 24, expectedErr: ErrCellCountMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewGrid(tc.rows, tc.cols, make([]string, tc.cells))
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestGrid_NormalizesAndMasks(t *testing.T) {
+	cells := append([]string(nil), testCrosswordCells...)
+	cells[0] = " c "
+	g, err := NewGrid(5, 5, cells)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.At(0, 0) != "C" {
+		t.Errorf("expected normalized cell C, got %q", g.At(0, 0))
+	}
+
+	masked := g.Masked()
+	if masked.At(0, 3) != BlockCell || masked.At(0, 0) != EmptyCell {
+		t.Error("expected mask to keep only blocks")
+	}
+	if masked.Equals(*g) {
+		t.Error("expected masked grid to differ from solution")
+	}
+}
+
+func TestValidateSudokuSolution(t *testing.T) {
+	valid := createTestSudokuGrid(t, testSudoku)
+	if err := validateSudokuSolution(valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Swapping two cells in a row keeps the row valid but breaks columns
+	swapped := []byte(testSudoku)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	if err := validateSudokuSolution(createTestSudokuGrid(t, string(swapped))); err != ErrInvalidSudoku {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidSudoku, err)
+	}
+
+	notNine, _ := NewGrid(3, 3, strings.Split("1,2,3,4,5,6,7,8,9", ","))
+	if err := validateSudokuSolution(*notNine); err != ErrInvalidSudoku {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidSudoku, err)
+	}
+}
+
+func TestClue_Answer(t *testing.T) {
+	solution := createTestCrosswordGrid(t)
+
+	tests := []struct {
+		name     string
+		clue     Clue
+		expected string
+	}{
+		{name: "across to block", clue: Clue{Number: 1, Direction: DirectionAcross, Row: 0, Col: 0}, expected: "CAT"},
+		{name: "across to edge", clue: Clue{Number: 5, Direction: DirectionAcross, Row: 2, Col: 0}, expected: "RATES"},
+		{name: "down", clue: Clue{Number: 1, Direction: DirectionDown, Row: 0, Col: 0}, expected: "CARES"},
+		{name: "down full column", clue: Clue{Number: 3, Direction: DirectionDown, Row: 0, Col: 4}, expected: "SKSES"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.clue.Answer(solution); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	if err := validateClue(Clue{Number: 2, Direction: DirectionAcross, Row: 0, Col: 4, Text: "Single letter"}, solution); err != ErrInvalidClue {
+		t.Errorf("expected error '%v' for one-letter answer, got '%v'", ErrInvalidClue, err)
+	}
+}
+
+func TestDay(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*3600)
+	at := time.Date(2024, 3, 10, 2, 0, 0, 0, jakarta)
+
+	if got := Day(at); !got.Equal(time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected UTC day 2024-03-09, got %v", got)
+	}
+}
+
+func createTestCrosswordGrid(t *testing.T) Grid {
+	g, err := NewGrid(5, 5, testCrosswordCells)
+	if err != nil {
+		t.Fatalf("failed to create grid: %v", err)
+	}
+	return *g
+}
+
+func createTestSudokuGrid(t *testing.T, digits string) Grid {
+	g, err := NewGrid(9, 9, strings.Split(digits, ""))
+	if err != nil {
+		t.Fatalf("failed to create grid: %v", err)
+	}
+	return *g
+}
@@ -0,0 +1,241 @@
+package survey
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusDraft  Status = "draft"
+	StatusActive Status = "active"
+	StatusClosed Status = "closed"
+)
+
+// Survey is a question set product shows readers in article and homepage
+// blocks. Questions can only change while it is a draft, so every response
+// answers the same questions.
+type Survey struct {
+	ID        string
+	Title     string
+	Questions []Question
+	Targeting Targeting
+	Cap       Cap
+	Anonymity Anonymity
+	Status    Status
+	OpenedAt  *time.Time
+	ClosedAt  *time.Time
+
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSurvey(id, title string, questions []Question, targeting Targeting, frequency Cap, anonymity Anonymity, createdBy string, at time.Time) (*Survey, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, ErrEmptyActorID
+	}
+	s := &Survey{ID: id, Status: StatusDraft, CreatedBy: createdBy, CreatedAt: at}
+	if err := s.Edit(title, questions, targeting, frequency, anonymity, at); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Business Methods
+
+func (s *Survey) Edit(title string, questions []Question, targeting Targeting, frequency Cap, anonymity Anonymity, at time.Time) error {
+	if s.Status != StatusDraft {
+		return ErrNotDraft
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return ErrEmptyTitle
+	}
+	if len(questions) == 0 || len(questions) > MaxQuestions {
+		return ErrNoQuestions
+	}
+	seen := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		if seen[q.ID] {
+			return fmt.Errorf("%w: duplicate question ID %q", ErrInvalidQuestion, q.ID)
+		}
+		seen[q.ID] = true
+	}
+	if len(targeting.Placements) == 0 {
+		return ErrInvalidTargeting
+	}
+	if frequency.MaxPrompts < 1 {
+		return ErrInvalidCap
+	}
+	if !anonymity.Valid() {
+		return ErrInvalidAnonymity
+	}
+
+	s.Title = title
+	s.Questions = questions
+	s.Targeting = targeting
+	s.Cap = frequency
+	s.Anonymity = anonymity
+	s.UpdatedAt = at
+	return nil
+}
+
+func (s *Survey) Open(at time.Time) error {
+	if s.Status != StatusDraft {
+		return ErrNotDraft
+	}
+	s.Status = StatusActive
+	s.OpenedAt = &at
+	s.UpdatedAt = at
+	return nil
+}
+
+// Close stops prompting readers and accepting responses, results stay
+func (s *Survey) Close(at time.Time) error {
+	if s.Status != StatusActive {
+		return ErrNotActive
+	}
+	s.Status = StatusClosed
+	s.ClosedAt = &at
+	s.UpdatedAt = at
+	return nil
+}
+
+// Query Methods
+
+// Targets reports whether the survey is offered to the reader on the page
+func (s *Survey) Targets(page Page, reader Reader) bool {
+	return s.Status == StatusActive && s.Targeting.Matches(page, reader) && s.Targeting.Sampled(s.ID, reader.Key())
+}
+
+func (s *Survey) Question(id string) (Question, bool) {
+	for _, q := range s.Questions {
+		if q.ID == id {
+			return q, true
+		}
+	}
+	return Question{}, false
+}
+
+// Participation tracks how often one reader was asked to take one survey
+type Participation struct {
+	SurveyID     string
+	ReaderKey    ReaderKey
+	Prompts      int
+	LastPromptAt *time.Time
+	FinishedAt   *time.Time // Answered or dismissed
+}
+
+func NewParticipation(surveyID string, key ReaderKey) *Participation {
+	return &Participation{SurveyID: surveyID, ReaderKey: key}
+}
+
+// Business Methods
+
+// Prompt counts a prompt shown at the time
+func (p *Participation) Prompt(at time.Time) {
+	p.Prompts++
+	p.LastPromptAt = &at
+}
+
+// Finish records that the reader answered or dismissed the survey
+func (p *Participation) Finish(at time.Time) error {
+	if p.FinishedAt != nil {
+		return ErrAlreadyAnswered
+	}
+	p.FinishedAt = &at
+	return nil
+}
+
+// Query Methods
+
+// CanPrompt checks the survey's frequency cap
+func (p *Participation) CanPrompt(frequency Cap, at time.Time) bool {
+	if p.FinishedAt != nil || p.Prompts >= frequency.MaxPrompts {
+		return false
+	}
+	return p.LastPromptAt == nil || at.Sub(*p.LastPromptAt) >= frequency.Gap
+}
+
+// Response is one reader's answers. What it keeps of the respondent depends
+// on the survey's Anonymity.
+type Response struct {
+	ID          string
+	SurveyID    string
+	Respondent  string // Account ID, per-survey hash or empty, see Anonymity
+	Answers     []Answer
+	Placement   Placement
+	ArticleID   string
+	SubmittedAt time.Time
+}
+
+// NewResponse validates the answers against the survey, unanswered optional
+// questions are left out
+func NewResponse(id string, s *Survey, answers []Answer, reader Reader, page Page, at time.Time) (*Response, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyResponseID
+	}
+	if s.Status != StatusActive {
+		return nil, ErrNotActive
+	}
+
+	r := &Response{ID: id, SurveyID: s.ID, Placement: page.Placement, ArticleID: page.ArticleID, SubmittedAt: at}
+	for _, a := range answers {
+		q, ok := s.Question(a.QuestionID)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown question %q", ErrInvalidAnswer, a.QuestionID)
+		}
+		if _, dup := r.Answer(q.ID); dup {
+			return nil, fmt.Errorf("%w: %q answered twice", ErrInvalidAnswer, q.ID)
+		}
+		if a.empty() {
+			continue
+		}
+		a.Text = strings.TrimSpace(a.Text)
+		if err := a.validate(q); err != nil {
+			return nil, err
+		}
+		r.Answers = append(r.Answers, a)
+	}
+	for _, q := range s.Questions {
+		if _, ok := r.Answer(q.ID); q.Required && !ok {
+			return nil, fmt.Errorf("%w: %q", ErrMissingAnswer, q.ID)
+		}
+	}
+	if len(r.Answers) == 0 {
+		return nil, ErrMissingAnswer
+	}
+
+	switch s.Anonymity {
+	case AnonymityIdentified:
+		r.Respondent = reader.AccountID
+	case AnonymityPseudonymous:
+		if key := reader.Key(); key != "" {
+			sum := sha256.Sum256([]byte(s.ID + ":" + string(key)))
+			r.Respondent = hex.EncodeToString(sum[:])
+		}
+	case AnonymityAnonymous:
+		day := at.UTC()
+		r.SubmittedAt = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return r, nil
+}
+
+// Query Methods
+
+func (r *Response) Answer(questionID string) (Answer, bool) {
+	for _, a := range r.Answers {
+		if a.QuestionID == questionID {
+			return a, true
+		}
+	}
+	return Answer{}, false
+}
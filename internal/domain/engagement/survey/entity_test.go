@@ -0,0 +1,129 @@
+package survey
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, time.March, 10, 9, 30, 0, 0, time.UTC)
+
+func newTestSurvey(t *testing.T, anonymity Anonymity) *Survey {
+	t.Helper()
+	nps, _ := NewQuestion("nps", KindNPS, "How likely are you to recommend us to a friend?", nil, true)
+	topic, _ := NewQuestion("topic", KindChoice, "What do you read most?", []string{"Politics", "Sport"}, false)
+	why, _ := NewQuestion("why", KindText, "What should we improve?", nil, false)
+	targeting, _ := NewTargeting([]Placement{PlacementArticle, PlacementHomepage}, nil, AudienceEveryone, 100)
+	frequency, _ := NewCap(DefaultMaxPrompts, DefaultPromptGap)
+	s, err := NewSurvey("survey-1", "Reader NPS", []Question{nps, topic, why}, targeting, frequency, anonymity, "pm-1", testNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return s
+}
+
+func TestSurvey_Lifecycle(t *testing.T) {
+	s := newTestSurvey(t, AnonymityIdentified)
+	page, reader := Page{Placement: PlacementHomepage}, Reader{VisitorID: "v1"}
+	if s.Targets(page, reader) {
+		t.Error("expected a draft not to be offered")
+	}
+	dup, _ := NewQuestion("nps", KindText, "Again?", nil, false)
+	if err := s.Edit("Reader NPS", append(s.Questions, dup), s.Targeting, s.Cap, s.Anonymity, testNow); !errors.Is(err, ErrInvalidQuestion) {
+		t.Errorf("expected duplicate question IDs refused, got %v", err)
+	}
+
+	if err := s.Open(testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.Targets(page, reader) {
+		t.Error("expected an open survey to be offered")
+	}
+	if err := s.Edit("Renamed", s.Questions, s.Targeting, s.Cap, s.Anonymity, testNow); err != ErrNotDraft {
+		t.Errorf("expected ErrNotDraft, got %v", err)
+	}
+	if err := s.Close(testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Close(testNow); err != ErrNotActive {
+		t.Errorf("expected ErrNotActive, got %v", err)
+	}
+}
+
+func TestParticipation_CanPrompt(t *testing.T) {
+	frequency, _ := NewCap(2, 24*time.Hour)
+	p := NewParticipation("survey-1", Reader{VisitorID: "v1"}.Key())
+	if !p.CanPrompt(frequency, testNow) {
+		t.Fatal("expected a new reader to be asked")
+	}
+	p.Prompt(testNow)
+	if p.CanPrompt(frequency, testNow.Add(23*time.Hour)) {
+		t.Error("expected the gap to hold")
+	}
+	p.Prompt(testNow.Add(24 * time.Hour))
+	if p.CanPrompt(frequency, testNow.AddDate(0, 1, 0)) {
+		t.Error("expected no more than the cap of prompts")
+	}
+
+	dismissed := NewParticipation("survey-1", Reader{VisitorID: "v2"}.Key())
+	_ = dismissed.Finish(testNow)
+	if dismissed.CanPrompt(frequency, testNow.AddDate(0, 1, 0)) {
+		t.Error("expected a dismissed survey never asked again")
+	}
+	if err := dismissed.Finish(testNow); err != ErrAlreadyAnswered {
+		t.Errorf("expected ErrAlreadyAnswered, got %v", err)
+	}
+}
+
+func TestNewResponse(t *testing.T) {
+	s := newTestSurvey(t, AnonymityIdentified)
+	reader, page := Reader{VisitorID: "v1", AccountID: "member-1"}, Page{Placement: PlacementArticle, ArticleID: "a1"}
+	if _, err := NewResponse("r1", s, []Answer{{QuestionID: "nps", Score: score(9)}}, reader, page, testNow); err != ErrNotActive {
+		t.Errorf("expected ErrNotActive for a draft, got %v", err)
+	}
+	_ = s.Open(testNow)
+
+	tests := []struct {
+		name    string
+		answers []Answer
+		err     error
+	}{
+		{"nps only", []Answer{{QuestionID: "nps", Score: score(9)}}, nil},
+		{"all answered", []Answer{{QuestionID: "nps", Score: score(0)}, {QuestionID: "topic", Choice: "Sport"}, {QuestionID: "why", Text: " Fewer ads "}}, nil},
+		{"required missing", []Answer{{QuestionID: "topic", Choice: "Sport"}}, ErrMissingAnswer},
+		{"score out of range", []Answer{{QuestionID: "nps", Score: score(11)}}, ErrInvalidAnswer},
+		{"unknown choice", []Answer{{QuestionID: "nps", Score: score(5)}, {QuestionID: "topic", Choice: "Weather"}}, ErrInvalidAnswer},
+		{"unknown question", []Answer{{QuestionID: "nps", Score: score(5)}, {QuestionID: "age"}}, ErrInvalidAnswer},
+		{"answered twice", []Answer{{QuestionID: "nps", Score: score(5)}, {QuestionID: "nps", Score: score(6)}}, ErrInvalidAnswer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewResponse("r1", s, tt.answers, reader, page, testNow)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if err == nil && r.Respondent != "member-1" {
+				t.Errorf("expected an identified response, got %q", r.Respondent)
+			}
+		})
+	}
+}
+
+func TestNewResponse_Anonymity(t *testing.T) {
+	answers := []Answer{{QuestionID: "nps", Score: score(8)}}
+	reader, page := Reader{VisitorID: "v1", AccountID: "member-1"}, Page{Placement: PlacementHomepage}
+
+	pseudonymous := newTestSurvey(t, AnonymityPseudonymous)
+	_ = pseudonymous.Open(testNow)
+	r, _ := NewResponse("r1", pseudonymous, answers, reader, page, testNow)
+	if r.Respondent == "" || r.Respondent == "member-1" || r.Respondent == string(reader.Key()) {
+		t.Errorf("expected a per-survey hash, got %q", r.Respondent)
+	}
+
+	anonymous := newTestSurvey(t, AnonymityAnonymous)
+	_ = anonymous.Open(testNow)
+	r, _ = NewResponse("r2", anonymous, answers, reader, page, testNow)
+	if r.Respondent != "" || !r.SubmittedAt.Equal(time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected no respondent and only the day, got %q at %v", r.Respondent, r.SubmittedAt)
+	}
+}
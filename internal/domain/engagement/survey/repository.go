@@ -0,0 +1,32 @@
+package survey
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interface for surveys (implementation will be in infrastructure layer)
+type SurveyRepository interface {
+	Create(ctx context.Context, s *Survey) error
+	Update(ctx context.Context, s *Survey) error
+	FindByID(ctx context.Context, id string) (*Survey, error) // nil when not found
+	// FindAll returns every survey, newest first
+	FindAll(ctx context.Context) ([]*Survey, error)
+	// FindActive is read on every page with a survey block, implementations should cache it briefly
+	FindActive(ctx context.Context) ([]*Survey, error)
+}
+
+// Domain interface for frequency caps (implementation will be in infrastructure layer)
+type ParticipationRepository interface {
+	Save(ctx context.Context, p *Participation) error
+	Find(ctx context.Context, surveyID string, key ReaderKey) (*Participation, error) // nil when never prompted
+	// LastPromptAt is the reader's latest prompt of any survey, nil when never prompted
+	LastPromptAt(ctx context.Context, key ReaderKey) (*time.Time, error)
+}
+
+// Domain interface for responses (implementation will be in infrastructure layer)
+type ResponseRepository interface {
+	Create(ctx context.Context, r *Response) error
+	// FindBySurvey returns responses submitted in [from, to), oldest first
+	FindBySurvey(ctx context.Context, surveyID string, from, to time.Time) ([]*Response, error)
+}
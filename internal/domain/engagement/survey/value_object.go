@@ -0,0 +1,384 @@
+package survey
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Survey limits
+const (
+	MaxQuestions  = 10
+	MaxChoices    = 10
+	MaxPromptLen  = 300
+	MaxTextAnswer = 2000
+)
+
+// Frequency caps. A reader is asked to take at most one survey per
+// MinGapBetweenSurveys, whichever survey it is, on top of each survey's own cap.
+const (
+	MinGapBetweenSurveys = 7 * 24 * time.Hour
+	DefaultMaxPrompts    = 3
+	DefaultPromptGap     = 3 * 24 * time.Hour
+)
+
+// NPS buckets on the 0-10 scale
+const (
+	PromoterMin  = 9
+	DetractorMax = 6
+)
+
+// Domain errors
+var (
+	ErrEmptyID          = shared.NewDomainError("survey.id_required", shared.KindValidation, "survey ID cannot be empty")
+	ErrEmptyActorID     = shared.NewDomainError("survey.actor_required", shared.KindValidation, "actor ID cannot be empty")
+	ErrEmptyResponseID  = shared.NewDomainError("survey.response_id_required", shared.KindValidation, "response ID cannot be empty")
+	ErrEmptyTitle       = shared.NewDomainError("survey.title_required", shared.KindValidation, "survey title cannot be empty")
+	ErrNoQuestions      = shared.NewDomainError("survey.question_count", shared.KindValidation, "survey must have between 1 and 10 questions")
+	ErrInvalidQuestion  = shared.NewDomainError("survey.invalid_question", shared.KindValidation, "invalid question")
+	ErrInvalidTargeting = shared.NewDomainError("survey.invalid_targeting", shared.KindValidation, "invalid targeting")
+	ErrInvalidCap       = shared.NewDomainError("survey.invalid_cap", shared.KindValidation, "frequency cap must allow at least one prompt")
+	ErrInvalidAnonymity = shared.NewDomainError("survey.invalid_anonymity", shared.KindValidation, "anonymity must be identified, pseudonymous or anonymous")
+	ErrInvalidAnswer    = shared.NewDomainError("survey.invalid_answer", shared.KindValidation, "invalid answer")
+	ErrMissingAnswer    = shared.NewDomainError("survey.answer_required", shared.KindValidation, "a required question was not answered")
+	ErrNotDraft         = shared.NewDomainError("survey.not_draft", shared.KindState, "survey can only be edited as a draft")
+	ErrNotActive        = shared.NewDomainError("survey.not_active", shared.KindState, "survey is not active")
+	ErrAlreadyAnswered  = shared.NewDomainError("survey.already_answered", shared.KindConflict, "reader already answered or dismissed the survey")
+)
+
+// QuestionKind decides the answer a question takes
+type QuestionKind string
+
+const (
+	KindNPS    QuestionKind = "nps"    // 0-10, "how likely are you to recommend us"
+	KindRating QuestionKind = "rating" // 1-5 stars
+	KindChoice QuestionKind = "choice" // One of the listed choices
+	KindText   QuestionKind = "text"   // Free text
+)
+
+type Question struct {
+	ID       string
+	Kind     QuestionKind
+	Prompt   string
+	Choices  []string // Only for KindChoice
+	Required bool
+}
+
+func NewQuestion(id string, kind QuestionKind, prompt string, choices []string, required bool) (Question, error) {
+	id = strings.TrimSpace(id)
+	prompt = strings.TrimSpace(prompt)
+	if id == "" || prompt == "" || len(prompt) > MaxPromptLen {
+		return Question{}, fmt.Errorf("%w: %q needs an ID and a prompt of at most %d characters", ErrInvalidQuestion, id, MaxPromptLen)
+	}
+	q := Question{ID: id, Kind: kind, Prompt: prompt, Required: required}
+	switch kind {
+	case KindNPS, KindRating, KindText:
+		if len(choices) > 0 {
+			return Question{}, fmt.Errorf("%w: %q only choice questions take choices", ErrInvalidQuestion, id)
+		}
+	case KindChoice:
+		for _, c := range choices {
+			if c = strings.TrimSpace(c); c != "" && !slices.Contains(q.Choices, c) {
+				q.Choices = append(q.Choices, c)
+			}
+		}
+		if len(q.Choices) < 2 || len(q.Choices) > MaxChoices {
+			return Question{}, fmt.Errorf("%w: %q needs between 2 and %d choices", ErrInvalidQuestion, id, MaxChoices)
+		}
+	default:
+		return Question{}, fmt.Errorf("%w: %q has unknown kind %q", ErrInvalidQuestion, id, kind)
+	}
+	return q, nil
+}
+
+// Placement is where on the site a survey may be shown
+type Placement string
+
+const (
+	PlacementArticle  Placement = "article"
+	PlacementHomepage Placement = "homepage"
+)
+
+// Audience narrows a survey to some readers
+type Audience string
+
+const (
+	AudienceEveryone    Audience = "everyone"
+	AudienceGuests      Audience = "guests"      // Not signed in
+	AudienceMembers     Audience = "members"     // Signed in, not subscribed
+	AudienceSubscribers Audience = "subscribers" // Signed in with an active subscription
+)
+
+// Targeting decides which readers a survey is offered to. CategoryIDs only
+// narrow article placements, an empty list means every article.
+type Targeting struct {
+	Placements    []Placement
+	CategoryIDs   []string
+	Audience      Audience
+	SamplePercent int // Share of matching readers asked, 1-100
+}
+
+func NewTargeting(placements []Placement, categoryIDs []string, audience Audience, samplePercent int) (Targeting, error) {
+	t := Targeting{Audience: audience, SamplePercent: samplePercent}
+	for _, p := range placements {
+		if p != PlacementArticle && p != PlacementHomepage {
+			return Targeting{}, fmt.Errorf("%w: unknown placement %q", ErrInvalidTargeting, p)
+		}
+		if !slices.Contains(t.Placements, p) {
+			t.Placements = append(t.Placements, p)
+		}
+	}
+	if len(t.Placements) == 0 {
+		return Targeting{}, fmt.Errorf("%w: at least one placement is required", ErrInvalidTargeting)
+	}
+	for _, id := range categoryIDs {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(t.CategoryIDs, id) {
+			t.CategoryIDs = append(t.CategoryIDs, id)
+		}
+	}
+	switch audience {
+	case AudienceEveryone, AudienceGuests, AudienceMembers, AudienceSubscribers:
+	case "":
+		t.Audience = AudienceEveryone
+	default:
+		return Targeting{}, fmt.Errorf("%w: unknown audience %q", ErrInvalidTargeting, audience)
+	}
+	if samplePercent < 1 || samplePercent > 100 {
+		return Targeting{}, fmt.Errorf("%w: sample must be between 1 and 100 percent", ErrInvalidTargeting)
+	}
+	return t, nil
+}
+
+// Matches reports whether the page and reader fall in the targeting, the
+// sample is checked separately by Sampled
+func (t Targeting) Matches(page Page, reader Reader) bool {
+	if !slices.Contains(t.Placements, page.Placement) {
+		return false
+	}
+	if page.Placement == PlacementArticle && len(t.CategoryIDs) > 0 && !slices.ContainsFunc(page.CategoryIDs, func(id string) bool {
+		return slices.Contains(t.CategoryIDs, id)
+	}) {
+		return false
+	}
+	switch t.Audience {
+	case AudienceGuests:
+		return reader.AccountID == ""
+	case AudienceMembers:
+		return reader.AccountID != "" && !reader.Subscriber
+	case AudienceSubscribers:
+		return reader.AccountID != "" && reader.Subscriber
+	}
+	return true
+}
+
+// Sampled places the reader in or out of the sample, the same reader always
+// gets the same answer for a survey
+func (t Targeting) Sampled(surveyID string, key ReaderKey) bool {
+	sum := sha256.Sum256([]byte(surveyID + ":" + string(key)))
+	return int(binary.BigEndian.Uint64(sum[:8])%100) < t.SamplePercent
+}
+
+// Cap limits how often the same reader is asked to take one survey. A
+// reader who answers or dismisses it is never asked again.
+type Cap struct {
+	MaxPrompts int
+	Gap        time.Duration // Minimum time between two prompts
+}
+
+func NewCap(maxPrompts int, gap time.Duration) (Cap, error) {
+	if maxPrompts < 1 || gap < 0 {
+		return Cap{}, ErrInvalidCap
+	}
+	return Cap{MaxPrompts: maxPrompts, Gap: gap}, nil
+}
+
+// Anonymity decides how much of the respondent a response keeps
+type Anonymity string
+
+const (
+	// AnonymityIdentified keeps the account ID of signed-in respondents
+	AnonymityIdentified Anonymity = "identified"
+	// AnonymityPseudonymous keeps a per-survey hash of the reader, so repeat
+	// answers can be told apart without knowing who gave them
+	AnonymityPseudonymous Anonymity = "pseudonymous"
+	// AnonymityAnonymous keeps nothing about the respondent and only the day
+	// of the response, so it cannot be matched to a prompt by its time
+	AnonymityAnonymous Anonymity = "anonymous"
+)
+
+func (a Anonymity) Valid() bool {
+	return a == AnonymityIdentified || a == AnonymityPseudonymous || a == AnonymityAnonymous
+}
+
+// Reader is who is looking at the page. VisitorID comes from the visitor
+// cookie, AccountID is empty for guests.
+type Reader struct {
+	VisitorID  string
+	AccountID  string
+	Subscriber bool
+}
+
+// ReaderKey identifies a reader in frequency caps without storing their IDs
+type ReaderKey string
+
+// Key prefers the account so caps follow a member across devices, it is
+// empty for a guest without a visitor ID
+func (r Reader) Key() ReaderKey {
+	id := "account:" + r.AccountID
+	if r.AccountID == "" {
+		if r.VisitorID == "" {
+			return ""
+		}
+		id = "visitor:" + r.VisitorID
+	}
+	sum := sha256.Sum256([]byte(id))
+	return ReaderKey(hex.EncodeToString(sum[:]))
+}
+
+// Page is where the survey block sits
+type Page struct {
+	Placement   Placement
+	ArticleID   string
+	CategoryIDs []string // The article's category and its parents
+}
+
+// Answer to one question, Score for NPS and rating questions
+type Answer struct {
+	QuestionID string
+	Score      *int
+	Choice     string
+	Text       string
+}
+
+func (a Answer) empty() bool {
+	return a.Score == nil && a.Choice == "" && strings.TrimSpace(a.Text) == ""
+}
+
+func (a Answer) validate(q Question) error {
+	switch q.Kind {
+	case KindNPS:
+		if a.Score == nil || *a.Score < 0 || *a.Score > 10 || a.Choice != "" || a.Text != "" {
+			return fmt.Errorf("%w: %q takes a score from 0 to 10", ErrInvalidAnswer, q.ID)
+		}
+	case KindRating:
+		if a.Score == nil || *a.Score < 1 || *a.Score > 5 || a.Choice != "" || a.Text != "" {
+			return fmt.Errorf("%w: %q takes a rating from 1 to 5", ErrInvalidAnswer, q.ID)
+		}
+	case KindChoice:
+		if a.Score != nil || a.Text != "" || !slices.Contains(q.Choices, a.Choice) {
+			return fmt.Errorf("%w: %q takes one of its choices", ErrInvalidAnswer, q.ID)
+		}
+	case KindText:
+		if a.Score != nil || a.Choice != "" || len(a.Text) > MaxTextAnswer {
+			return fmt.Errorf("%w: %q takes text of at most %d characters", ErrInvalidAnswer, q.ID, MaxTextAnswer)
+		}
+	}
+	return nil
+}
+
+// NPS is the net promoter score of a set of 0-10 answers, from -100 to 100
+type NPS struct {
+	Promoters  int
+	Passives   int
+	Detractors int
+}
+
+func (n NPS) Responses() int {
+	return n.Promoters + n.Passives + n.Detractors
+}
+
+// Score is the share of promoters minus the share of detractors, 0 without responses
+func (n NPS) Score() float64 {
+	total := n.Responses()
+	if total == 0 {
+		return 0
+	}
+	return float64(n.Promoters-n.Detractors) * 100 / float64(total)
+}
+
+// QuestionSummary aggregates the answers to one question. Counts holds the
+// answers per score or choice, text answers are only counted.
+type QuestionSummary struct {
+	QuestionID string
+	Kind       QuestionKind
+	Answers    int
+	Counts     map[string]int
+	Average    float64 // Mean score of NPS and rating questions
+	NPS        *NPS    // Only for NPS questions
+}
+
+// Summary aggregates the responses to a survey
+type Summary struct {
+	SurveyID  string
+	Responses int
+	Questions []QuestionSummary
+}
+
+// Summarize aggregates responses question by question, answers to questions
+// no longer in the survey are ignored
+func Summarize(s *Survey, responses []*Response) Summary {
+	summary := Summary{SurveyID: s.ID, Responses: len(responses)}
+	for _, q := range s.Questions {
+		qs := QuestionSummary{QuestionID: q.ID, Kind: q.Kind, Counts: make(map[string]int)}
+		if q.Kind == KindNPS {
+			qs.NPS = &NPS{}
+		}
+		total := 0
+		for _, r := range responses {
+			a, ok := r.Answer(q.ID)
+			if !ok {
+				continue
+			}
+			qs.Answers++
+			switch q.Kind {
+			case KindNPS, KindRating:
+				qs.Counts[fmt.Sprint(*a.Score)]++
+				total += *a.Score
+			case KindChoice:
+				qs.Counts[a.Choice]++
+			}
+			if qs.NPS != nil {
+				switch {
+				case *a.Score >= PromoterMin:
+					qs.NPS.Promoters++
+				case *a.Score <= DetractorMax:
+					qs.NPS.Detractors++
+				default:
+					qs.NPS.Passives++
+				}
+			}
+		}
+		if (q.Kind == KindNPS || q.Kind == KindRating) && qs.Answers > 0 {
+			qs.Average = float64(total) / float64(qs.Answers)
+		}
+		summary.Questions = append(summary.Questions, qs)
+	}
+	return summary
+}
+
+// Survey embeds are placeholders in article bodies and homepage blocks which
+// the page hydrates from the prompt endpoint, e.g.
+// <div data-block="survey" data-survey="..."></div>
+var surveyBlockRegex = regexp.MustCompile(`<div data-block="survey" data-survey="([^"]+)"></div>`)
+
+// SurveyBlock returns the markup editors insert to embed a survey
+func SurveyBlock(surveyID string) string {
+	return `<div data-block="survey" data-survey="` + html.EscapeString(surveyID) + `"></div>`
+}
+
+// EmbeddedSurvey returns the first survey a body embeds, "" when none
+func EmbeddedSurvey(body string) string {
+	m := surveyBlockRegex.FindStringSubmatch(body)
+	if m == nil {
+		return ""
+	}
+	return html.UnescapeString(m[1])
+}
@@ -0,0 +1,152 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func score(n int) *int {
+	return &n
+}
+
+func TestNewQuestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    QuestionKind
+		prompt  string
+		choices []string
+		err     error
+	}{
+		{"nps", KindNPS, "How likely are you to recommend us to a friend?", nil, nil},
+		{"choice", KindChoice, "What do you read most?", []string{"Politics", "Sport", "Politics"}, nil},
+		{"empty prompt", KindText, " ", nil, ErrInvalidQuestion},
+		{"choices on a rating", KindRating, "Rate us", []string{"1", "2"}, ErrInvalidQuestion},
+		{"one choice", KindChoice, "Pick one", []string{"Only"}, ErrInvalidQuestion},
+		{"unknown kind", QuestionKind("slider"), "Slide", nil, ErrInvalidQuestion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewQuestion("q1", tt.kind, tt.prompt, tt.choices, true)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if tt.kind == KindChoice && err == nil && len(q.Choices) != 2 {
+				t.Errorf("expected duplicate choices dropped, got %v", q.Choices)
+			}
+		})
+	}
+}
+
+func TestTargeting_Matches(t *testing.T) {
+	targeting, err := NewTargeting([]Placement{PlacementArticle}, []string{"ekonomi"}, AudienceSubscribers, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	subscriber := Reader{VisitorID: "v1", AccountID: "member-1", Subscriber: true}
+
+	tests := []struct {
+		name   string
+		page   Page
+		reader Reader
+		want   bool
+	}{
+		{"subscriber on a targeted article", Page{Placement: PlacementArticle, CategoryIDs: []string{"bisnis", "ekonomi"}}, subscriber, true},
+		{"other category", Page{Placement: PlacementArticle, CategoryIDs: []string{"olahraga"}}, subscriber, false},
+		{"homepage", Page{Placement: PlacementHomepage}, subscriber, false},
+		{"member without subscription", Page{Placement: PlacementArticle, CategoryIDs: []string{"ekonomi"}}, Reader{VisitorID: "v2", AccountID: "member-2"}, false},
+		{"guest", Page{Placement: PlacementArticle, CategoryIDs: []string{"ekonomi"}}, Reader{VisitorID: "v3"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := targeting.Matches(tt.page, tt.reader); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := NewTargeting(nil, nil, AudienceEveryone, 100); !errors.Is(err, ErrInvalidTargeting) {
+		t.Errorf("expected ErrInvalidTargeting without placements, got %v", err)
+	}
+	if _, err := NewTargeting([]Placement{PlacementHomepage}, nil, "", 0); !errors.Is(err, ErrInvalidTargeting) {
+		t.Errorf("expected ErrInvalidTargeting without a sample, got %v", err)
+	}
+}
+
+func TestTargeting_Sampled(t *testing.T) {
+	targeting, _ := NewTargeting([]Placement{PlacementHomepage}, nil, AudienceEveryone, 25)
+	sampled := 0
+	for i := 0; i < 4000; i++ {
+		key := Reader{VisitorID: fmt.Sprintf("visitor-%d", i)}.Key()
+		in := targeting.Sampled("survey-1", key)
+		if in != targeting.Sampled("survey-1", key) {
+			t.Fatal("expected a reader to stay in or out of the sample")
+		}
+		if in {
+			sampled++
+		}
+	}
+	if sampled < 850 || sampled > 1150 {
+		t.Errorf("expected about a quarter sampled, got %d of 4000", sampled)
+	}
+}
+
+func TestReader_Key(t *testing.T) {
+	guest := Reader{VisitorID: "v1"}
+	member := Reader{VisitorID: "v1", AccountID: "member-1"}
+	if guest.Key() == "" || guest.Key() == member.Key() {
+		t.Error("expected guests keyed by visitor and members by account")
+	}
+	if member.Key() != (Reader{VisitorID: "v2", AccountID: "member-1"}).Key() {
+		t.Error("expected a member keyed the same on every device")
+	}
+	if (Reader{}).Key() != "" {
+		t.Error("expected no key without a visitor ID or account")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := newTestSurvey(t, AnonymityAnonymous)
+	_ = s.Open(testNow)
+
+	var responses []*Response
+	for i, n := range []int{10, 9, 8, 7, 3} {
+		answers := []Answer{{QuestionID: "nps", Score: score(n)}}
+		if i == 0 {
+			answers = append(answers, Answer{QuestionID: "topic", Choice: "Sport"}, Answer{QuestionID: "why", Text: "Great sport coverage"})
+		}
+		r, err := NewResponse(fmt.Sprintf("r%d", i), s, answers, Reader{VisitorID: "v"}, Page{Placement: PlacementHomepage}, testNow)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		responses = append(responses, r)
+	}
+
+	summary := Summarize(s, responses)
+	if summary.Responses != 5 || len(summary.Questions) != 3 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	nps := summary.Questions[0]
+	if nps.NPS == nil || nps.NPS.Promoters != 2 || nps.NPS.Passives != 2 || nps.NPS.Detractors != 1 || nps.NPS.Score() != 20 {
+		t.Errorf("expected an NPS of 20, got %+v", nps.NPS)
+	}
+	if nps.Average != 7.4 || nps.Counts["10"] != 1 {
+		t.Errorf("unexpected score breakdown %+v", nps)
+	}
+	if topic := summary.Questions[1]; topic.Answers != 1 || topic.Counts["Sport"] != 1 {
+		t.Errorf("unexpected choice breakdown %+v", topic)
+	}
+	if why := summary.Questions[2]; why.Answers != 1 || len(why.Counts) != 0 {
+		t.Errorf("expected text answers only counted, got %+v", why)
+	}
+}
+
+func TestEmbeddedSurvey(t *testing.T) {
+	body := "<p>Intro</p>" + SurveyBlock("survey-1") + "<p>More</p>"
+	if got := EmbeddedSurvey(body); got != "survey-1" {
+		t.Errorf("expected survey-1, got %q", got)
+	}
+	if got := EmbeddedSurvey("<p>No survey</p>"); got != "" {
+		t.Errorf("expected none, got %q", got)
+	}
+}
@@ -0,0 +1,159 @@
+package tip
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type TipStatus string
+
+const (
+	StatusOpen   TipStatus = "open"
+	StatusClosed TipStatus = "closed"
+)
+
+// Limits of the tip line
+const (
+	MaxMessageLength  = 20000 // Characters, before sealing
+	MaxAttachments    = 5
+	MaxAttachmentSize = 10 << 20 // Bytes, before stripping
+	MaxReplyLength    = 4000
+)
+
+// Domain errors
+var (
+	ErrEmptyID               = shared.NewDomainError("tip.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrEmptyAttachment       = shared.NewDomainError("tip.attachment_empty", shared.KindValidation, "attachment cannot be empty")
+	ErrEmptyCodeHash         = shared.NewDomainError("tip.code_hash_required", shared.KindValidation, "code hash cannot be empty")
+	ErrEmptyEditorID         = shared.NewDomainError("tip.editor_required", shared.KindValidation, "editor ID cannot be empty")
+	ErrEmptyMessage          = shared.NewDomainError("tip.message_required", shared.KindValidation, "message cannot be empty")
+	ErrMessageTooLong        = shared.NewDomainError("tip.message_too_long", shared.KindValidation, "message cannot exceed 20000 characters")
+	ErrTooManyAttachments    = shared.NewDomainError("tip.too_many_attachments", shared.KindValidation, "a tip can carry at most 5 attachments")
+	ErrAttachmentTooLarge    = shared.NewDomainError("tip.attachment_too_large", shared.KindValidation, "attachments cannot exceed 10 MB")
+	ErrUnsupportedAttachment = shared.NewDomainError("tip.unsupported_attachment", shared.KindValidation, "attachments must be JPEG, PNG or plain text")
+	ErrUnreadableAttachment  = shared.NewDomainError("tip.unreadable_attachment", shared.KindValidation, "attachment could not be read")
+	ErrTipClosed             = shared.NewDomainError("tip.closed", shared.KindState, "tip is closed")
+	ErrEmptyReply            = shared.NewDomainError("tip.reply_required", shared.KindValidation, "reply cannot be empty")
+	ErrReplyTooLong          = shared.NewDomainError("tip.reply_too_long", shared.KindValidation, "reply cannot exceed 4000 characters")
+)
+
+// Tip is an anonymous submission to the newsroom. The message and attachments are
+// sealed to the newsroom's public key before they reach the tip, so the server can
+// store them but never read them. Nothing about the sender is kept: no account, no
+// address, no user agent, and only the day it arrived, which is too coarse to match
+// against access logs.
+type Tip struct {
+	ID          string
+	Message     []byte // Sealed
+	Attachments []Attachment
+	CodeHash    string // SHA-256 of the tipster's current check code
+	Status      TipStatus
+	Replies     []Reply
+
+	ReceivedOn time.Time // Truncated to the UTC day
+	ClosedBy   *string
+	ClosedAt   *time.Time
+}
+
+// Reply is an editor's answer, shown to the tipster the next time they check in
+type Reply struct {
+	Body     string
+	EditorID string
+	At       time.Time
+}
+
+// ValidateMessage checks the plaintext message before it is sealed
+func ValidateMessage(message string) error {
+	if strings.TrimSpace(message) == "" {
+		return ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(message) > MaxMessageLength {
+		return ErrMessageTooLong
+	}
+	return nil
+}
+
+// NewTip stores an already sealed message and attachments under the tipster's check code
+func NewTip(id string, message []byte, attachments []Attachment, codeHash string, at time.Time) (*Tip, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if len(message) == 0 {
+		return nil, ErrEmptyMessage
+	}
+	if len(attachments) > MaxAttachments {
+		return nil, ErrTooManyAttachments
+	}
+	for _, a := range attachments {
+		if len(a.Sealed) == 0 {
+			return nil, ErrEmptyAttachment
+		}
+	}
+	if strings.TrimSpace(codeHash) == "" {
+		return nil, ErrEmptyCodeHash
+	}
+
+	return &Tip{
+		ID:          id,
+		Message:     message,
+		Attachments: attachments,
+		CodeHash:    codeHash,
+		Status:      StatusOpen,
+		ReceivedOn:  at.UTC().Truncate(24 * time.Hour),
+	}, nil
+}
+
+// Business Methods
+
+// Reply answers the tipster, closed tips take no more replies
+func (t *Tip) Reply(editorID, body string, at time.Time) error {
+	if t.IsClosed() {
+		return ErrTipClosed
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyEditorID
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return ErrEmptyReply
+	}
+	if utf8.RuneCountInString(body) > MaxReplyLength {
+		return ErrReplyTooLong
+	}
+
+	t.Replies = append(t.Replies, Reply{Body: body, EditorID: editorID, At: at})
+	return nil
+}
+
+// Close ends the conversation, the tipster still sees the replies until the tip is purged
+func (t *Tip) Close(editorID string, at time.Time) error {
+	if t.IsClosed() {
+		return ErrTipClosed
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return ErrEmptyEditorID
+	}
+
+	t.Status = StatusClosed
+	t.ClosedBy = &editorID
+	t.ClosedAt = &at
+	return nil
+}
+
+// RotateCode replaces the check code once it has been used
+func (t *Tip) RotateCode(codeHash string) error {
+	if strings.TrimSpace(codeHash) == "" {
+		return ErrEmptyCodeHash
+	}
+	t.CodeHash = codeHash
+	return nil
+}
+
+// Query Methods
+
+func (t *Tip) IsClosed() bool {
+	return t.Status == StatusClosed
+}
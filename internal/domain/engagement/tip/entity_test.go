@@ -0,0 +1,89 @@
+package tip
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTestTip(t *testing.T) *Tip {
+	t.Helper()
+	tip, err := NewTip("tip-1", []byte("sealed"), []Attachment{{ContentType: ContentTypePNG, Size: 10, Sealed: []byte("sealed")}}, "hash-1", time.Now())
+	if err != nil {
+		t.Fatalf("failed to create tip: %v", err)
+	}
+	return tip
+}
+
+func TestValidateMessage(t *testing.T) {
+	testCases := []struct {
+		name          string
+		message       string
+		expectedError error
+	}{
+		{"valid", "The contract was signed before the tender closed", nil},
+		{"blank", " \n ", ErrEmptyMessage},
+		{"too long", strings.Repeat("é", MaxMessageLength+1), ErrMessageTooLong},
+		{"at the limit", strings.Repeat("é", MaxMessageLength), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateMessage(tc.message); err != tc.expectedError {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestNewTip(t *testing.T) {
+	at := time.Date(2026, 3, 4, 22, 47, 13, 0, time.FixedZone("WIB", 7*3600))
+	tip, err := NewTip("tip-1", []byte("sealed"), nil, "hash-1", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tip.ReceivedOn.Equal(time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)) || tip.Status != StatusOpen {
+		t.Errorf("expected an open tip received on the UTC day, got %+v", tip)
+	}
+
+	attachments := make([]Attachment, MaxAttachments+1)
+	if _, err := NewTip("tip-1", []byte("sealed"), attachments, "hash-1", at); err != ErrTooManyAttachments {
+		t.Errorf("expected error '%v', got '%v'", ErrTooManyAttachments, err)
+	}
+	if _, err := NewTip("tip-1", nil, nil, "hash-1", at); err != ErrEmptyMessage {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptyMessage, err)
+	}
+	if _, err := NewTip("tip-1", []byte("sealed"), nil, "", at); err == nil {
+		t.Error("expected an error without a code hash")
+	}
+}
+
+func TestTip_Reply(t *testing.T) {
+	tip := createTestTip(t)
+
+	if err := tip.Reply("editor-1", "  ", time.Now()); err != ErrEmptyReply {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptyReply, err)
+	}
+	if err := tip.Reply("editor-1", strings.Repeat("a", MaxReplyLength+1), time.Now()); err != ErrReplyTooLong {
+		t.Errorf("expected error '%v', got '%v'", ErrReplyTooLong, err)
+	}
+	if err := tip.Reply("editor-1", " Can you share the invoice? ", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tip.Replies) != 1 || tip.Replies[0].Body != "Can you share the invoice?" {
+		t.Errorf("expected one trimmed reply, got %+v", tip.Replies)
+	}
+
+	if err := tip.Close("editor-1", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tip.IsClosed() || *tip.ClosedBy != "editor-1" {
+		t.Errorf("expected tip closed by editor-1, got %+v", tip)
+	}
+	if err := tip.Reply("editor-1", "One more thing", time.Now()); err != ErrTipClosed {
+		t.Errorf("expected error '%v', got '%v'", ErrTipClosed, err)
+	}
+	if err := tip.Close("editor-1", time.Now()); err != ErrTipClosed {
+		t.Errorf("expected error '%v', got '%v'", ErrTipClosed, err)
+	}
+}
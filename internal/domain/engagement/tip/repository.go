@@ -0,0 +1,28 @@
+package tip
+
+import "context"
+
+type TipRepository interface {
+	// Commands
+	Create(ctx context.Context, tip *Tip) error
+	Update(ctx context.Context, tip *Tip) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*Tip, error)
+	FindByCodeHash(ctx context.Context, codeHash string) (*Tip, error)
+
+	// Inbox, open tips first, newest first
+	FindInbox(ctx context.Context, limit, offset int) ([]*Tip, error)
+}
+
+// Domain interface for sealing tips to the newsroom's public key (implementation will be in infrastructure layer).
+// Only the newsroom's offline private key can open what it seals.
+type Sealer interface {
+	Seal(plaintext []byte) ([]byte, error)
+}
+
+// Domain interface for removing identifying metadata from uploads (implementation will be in infrastructure layer).
+// It returns ErrUnsupportedAttachment for types it cannot clean and ErrUnreadableAttachment for malformed files.
+type MetadataStripper interface {
+	Strip(contentType string, content []byte) ([]byte, error)
+}
@@ -0,0 +1,23 @@
+package tip
+
+// Supported attachment types, the only ones whose metadata can be reliably stripped
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeText = "text/plain"
+)
+
+// Attachment is a stripped and sealed file. The original filename is never kept.
+type Attachment struct {
+	ContentType string
+	Size        int64 // Bytes after stripping, before sealing
+	Sealed      []byte
+}
+
+func IsSupportedContentType(contentType string) bool {
+	switch contentType {
+	case ContentTypeJPEG, ContentTypePNG, ContentTypeText:
+		return true
+	}
+	return false
+}
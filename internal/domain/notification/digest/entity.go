@@ -0,0 +1,165 @@
+package digest
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily    Frequency = "daily"
+	FrequencyWeekly   Frequency = "weekly"
+	FrequencyDisabled Frequency = "disabled"
+)
+
+// Weekly digests go out on Monday mornings
+const WeeklySendDay = time.Monday
+
+// Subscription holds a staff member's digest preferences
+type Subscription struct {
+	ID         string
+	AccountID  string
+	Frequency  Frequency
+	SendHour   int    // Local hour of day, 0-23
+	Timezone   string // IANA zone, e.g. "Asia/Jakarta"
+	LastSentAt *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSubscription(id string, staff *account.UserAccount, frequency Frequency, sendHour int, timezone string) (*Subscription, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if staff == nil {
+		return nil, errors.New("account cannot be empty")
+	}
+	if !staff.IsInternal() {
+		return nil, errors.New("activity digests are only available to internal accounts")
+	}
+	if err := validateFrequency(frequency); err != nil {
+		return nil, err
+	}
+	if err := validateSendHour(sendHour); err != nil {
+		return nil, err
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || strings.TrimSpace(timezone) == "" {
+		return nil, errors.New("invalid timezone")
+	}
+
+	now := time.Now()
+	return &Subscription{
+		ID:        id,
+		AccountID: staff.ID,
+		Frequency: frequency,
+		SendHour:  sendHour,
+		Timezone:  timezone,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Business Methods
+
+func (s *Subscription) ChangeSchedule(frequency Frequency, sendHour int) error {
+	if err := validateFrequency(frequency); err != nil {
+		return err
+	}
+	if err := validateSendHour(sendHour); err != nil {
+		return err
+	}
+	s.Frequency = frequency
+	s.SendHour = sendHour
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// MarkSent records a delivered digest so the next one covers only newer activity
+func (s *Subscription) MarkSent(at time.Time) {
+	s.LastSentAt = &at
+	s.UpdatedAt = time.Now()
+}
+
+// Query Methods
+
+func (s *Subscription) IsEnabled() bool {
+	return s.Frequency != FrequencyDisabled
+}
+
+// IsDue reports whether the most recent scheduled send time has passed without a digest being sent
+func (s *Subscription) IsDue(at time.Time) bool {
+	if !s.IsEnabled() {
+		return false
+	}
+	scheduled := s.lastScheduledAt(at)
+	if at.Before(scheduled) {
+		return false
+	}
+	return s.LastSentAt == nil || s.LastSentAt.Before(scheduled)
+}
+
+// PeriodStart is the beginning of the activity window a digest sent at the given time covers
+func (s *Subscription) PeriodStart(at time.Time) time.Time {
+	if s.LastSentAt != nil {
+		return *s.LastSentAt
+	}
+	if s.Frequency == FrequencyWeekly {
+		return at.AddDate(0, 0, -7)
+	}
+	return at.AddDate(0, 0, -1)
+}
+
+func (s *Subscription) location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (s *Subscription) lastScheduledAt(at time.Time) time.Time {
+	if s.Frequency == FrequencyWeekly {
+		day := WeeklySendDay
+		return lastSlot(at.In(s.location()), s.SendHour, &day)
+	}
+	return lastSlot(at.In(s.location()), s.SendHour, nil)
+}
+
+// lastSlot is the latest send time at or before local, on the given weekday when one is set
+func lastSlot(local time.Time, hour int, weekday *time.Weekday) time.Time {
+	scheduled := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, local.Location())
+	if scheduled.After(local) {
+		scheduled = scheduled.AddDate(0, 0, -1)
+	}
+	for weekday != nil && scheduled.Weekday() != *weekday {
+		scheduled = scheduled.AddDate(0, 0, -1)
+	}
+	return scheduled
+}
+
+// Domain Validation Functions
+
+func validateFrequency(frequency Frequency) error {
+	validFrequencies := map[Frequency]bool{
+		FrequencyDaily:    true,
+		FrequencyWeekly:   true,
+		FrequencyDisabled: true,
+	}
+	if !validFrequencies[frequency] {
+		return errors.New("invalid digest frequency")
+	}
+	return nil
+}
+
+func validateSendHour(hour int) error {
+	if hour < 0 || hour > 23 {
+		return errors.New("send hour must be between 0 and 23")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestNewSubscription(t *testing.T) {
+	staff := createTestStaff(t, account.TypeInternal)
+	member := createTestStaff(t, account.TypeMembership)
+
+	tests := []struct {
+		name      string
+		acc       *account.UserAccount
+		frequency Frequency
+		sendHour  int
+		timezone  string
+		wantErr   bool
+		errMsg    string
+	}{
+		{name: "valid daily", acc: staff, frequency: FrequencyDaily, sendHour: 7, timezone: "Asia/Jakarta"},
+		{name: "non internal account", acc: member, frequency: FrequencyDaily, sendHour: 7, timezone: "UTC", wantErr: true, errMsg: "activity digests are only available to internal accounts"},
+		{name: "invalid frequency", acc: staff, frequency: "hourly", sendHour: 7, timezone: "UTC", wantErr: true, errMsg: "invalid digest frequency"},
+		{name: "invalid hour", acc: staff, frequency: FrequencyDaily, sendHour: 24, timezone: "UTC", wantErr: true, errMsg: "send hour must be between 0 and 23"},
+		{name: "invalid timezone", acc: staff, frequency: FrequencyDaily, sendHour: 7, timezone: "Mars/Base", wantErr: true, errMsg: "invalid timezone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := NewSubscription("sub-1", tt.acc, tt.frequency, tt.sendHour, tt.timezone)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sub.AccountID != tt.acc.ID {
+				t.Errorf("expected account ID %s, got %s", tt.acc.ID, sub.AccountID)
+			}
+		})
+	}
+}
+
+func TestSubscription_IsDue(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	// Wednesday 2026-01-07
+	beforeSend := time.Date(2026, 1, 7, 6, 30, 0, 0, jakarta)
+	afterSend := time.Date(2026, 1, 7, 7, 15, 0, 0, jakarta)
+
+	daily := &Subscription{Frequency: FrequencyDaily, SendHour: 7, Timezone: "Asia/Jakarta"}
+	if !daily.IsDue(beforeSend) {
+		t.Error("expected never-sent daily digest to be due for yesterday's slot")
+	}
+
+	sentYesterday := time.Date(2026, 1, 6, 7, 1, 0, 0, jakarta)
+	daily.LastSentAt = &sentYesterday
+	if daily.IsDue(beforeSend) {
+		t.Error("expected daily digest not to be due before send hour")
+	}
+	if !daily.IsDue(afterSend) {
+		t.Error("expected daily digest to be due after send hour")
+	}
+
+	daily.MarkSent(afterSend)
+	if daily.IsDue(afterSend.Add(time.Hour)) {
+		t.Error("expected daily digest not to be due after being sent")
+	}
+	if !daily.PeriodStart(afterSend.Add(24 * time.Hour)).Equal(afterSend) {
+		t.Error("expected period to start at the last send")
+	}
+
+	sentMonday := time.Date(2026, 1, 5, 7, 5, 0, 0, jakarta)
+	weekly := &Subscription{Frequency: FrequencyWeekly, SendHour: 7, Timezone: "Asia/Jakarta", LastSentAt: &sentMonday}
+	if weekly.IsDue(afterSend) {
+		t.Error("expected weekly digest not to be due mid-week")
+	}
+	nextMonday := time.Date(2026, 1, 12, 8, 0, 0, 0, jakarta)
+	if !weekly.IsDue(nextMonday) {
+		t.Error("expected weekly digest to be due next Monday")
+	}
+
+	disabled := &Subscription{Frequency: FrequencyDisabled, SendHour: 7, Timezone: "UTC"}
+	if disabled.IsDue(afterSend) {
+		t.Error("expected disabled digest never to be due")
+	}
+}
+
+func TestDigest_IsEmpty(t *testing.T) {
+	d := Digest{Sections: []Section{{Key: SectionFlaggedComments}, {Key: SectionPublishFailures}}}
+	if !d.IsEmpty() {
+		t.Error("expected digest with empty sections to be empty")
+	}
+	d.Sections[1].Count = 2
+	if d.IsEmpty() {
+		t.Error("expected digest with counts not to be empty")
+	}
+}
+
+func createTestStaff(t *testing.T, accountType account.UserAccountType) *account.UserAccount {
+	acc, err := account.NewUserAccountForTesting("staff123", "staff_user", "staff@example.com", "StaffPass123!", accountType, "admin123")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return acc
+}
@@ -0,0 +1,132 @@
+package digest
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Reader digests go out on Saturdays, at an hour learned from when each member opens them
+const (
+	ReaderSendDay         = time.Saturday
+	DefaultReaderSendHour = 7
+	MinOpensToOptimize    = 4  // Opens needed before the learned hour replaces the default
+	MaxOpenSamples        = 40 // Counts are halved past this so a changed routine takes over
+)
+
+// ReaderSchedule holds a member's "weekly digest for you" preferences and open history
+type ReaderSchedule struct {
+	AccountID  string
+	Enabled    bool
+	Timezone   string  // IANA zone, e.g. "Asia/Jakarta"
+	OpenHours  [24]int // Digest opens per local hour of day
+	LastSentAt *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewReaderSchedule starts a member's schedule disabled, the digest is opt-in
+// and only goes out once the member enables it
+func NewReaderSchedule(member *account.UserAccount, timezone string) (*ReaderSchedule, error) {
+	if member == nil {
+		return nil, errors.New("account cannot be empty")
+	}
+	if !member.IsMembership() {
+		return nil, errors.New("reader digests are only available to member accounts")
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || strings.TrimSpace(timezone) == "" {
+		return nil, errors.New("invalid timezone")
+	}
+
+	now := time.Now()
+	return &ReaderSchedule{
+		AccountID: member.ID,
+		Timezone:  timezone,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Business Methods
+
+func (s *ReaderSchedule) Enable() {
+	s.Enabled = true
+	s.UpdatedAt = time.Now()
+}
+
+func (s *ReaderSchedule) Disable() {
+	s.Enabled = false
+	s.UpdatedAt = time.Now()
+}
+
+// RecordOpen counts a digest open towards the member's send hour
+func (s *ReaderSchedule) RecordOpen(at time.Time) {
+	s.OpenHours[at.In(s.location()).Hour()]++
+	if s.opens() > MaxOpenSamples {
+		for hour := range s.OpenHours {
+			s.OpenHours[hour] /= 2
+		}
+	}
+	s.UpdatedAt = time.Now()
+}
+
+// MarkSent records a delivered digest so the next one covers only newer articles
+func (s *ReaderSchedule) MarkSent(at time.Time) {
+	s.LastSentAt = &at
+	s.UpdatedAt = time.Now()
+}
+
+// Query Methods
+
+// SendHour is the local hour the member opens digests most often, the earliest
+// on a tie, or DefaultReaderSendHour until enough opens are recorded
+func (s *ReaderSchedule) SendHour() int {
+	if s.opens() < MinOpensToOptimize {
+		return DefaultReaderSendHour
+	}
+	best := 0
+	for hour, count := range s.OpenHours {
+		if count > s.OpenHours[best] {
+			best = hour
+		}
+	}
+	return best
+}
+
+// IsDue reports whether this week's send time has passed without a digest being sent
+func (s *ReaderSchedule) IsDue(at time.Time) bool {
+	if !s.Enabled {
+		return false
+	}
+	day := ReaderSendDay
+	scheduled := lastSlot(at.In(s.location()), s.SendHour(), &day)
+	return s.LastSentAt == nil || s.LastSentAt.Before(scheduled)
+}
+
+// PeriodStart is the beginning of the publishing window a digest sent at the given time covers
+func (s *ReaderSchedule) PeriodStart(at time.Time) time.Time {
+	if s.LastSentAt != nil {
+		return *s.LastSentAt
+	}
+	return at.AddDate(0, 0, -7)
+}
+
+func (s *ReaderSchedule) opens() int {
+	total := 0
+	for _, count := range s.OpenHours {
+		total += count
+	}
+	return total
+}
+
+func (s *ReaderSchedule) location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
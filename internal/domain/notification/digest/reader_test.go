@@ -0,0 +1,110 @@
+package digest
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestNewReaderSchedule(t *testing.T) {
+	member := createTestStaff(t, account.TypeMembership)
+	staff := createTestStaff(t, account.TypeInternal)
+
+	if _, err := NewReaderSchedule(staff, "Asia/Jakarta"); err == nil || err.Error() != "reader digests are only available to member accounts" {
+		t.Errorf("expected staff accounts rejected, got %v", err)
+	}
+	if _, err := NewReaderSchedule(member, "Mars/Base"); err == nil || err.Error() != "invalid timezone" {
+		t.Errorf("expected invalid timezone, got %v", err)
+	}
+	schedule, err := NewReaderSchedule(member, "Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.Enabled || schedule.SendHour() != DefaultReaderSendHour {
+		t.Errorf("expected a disabled schedule at the default hour, got %+v", schedule)
+	}
+	if schedule.IsDue(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)) {
+		t.Error("expected no digest due before the member opts in")
+	}
+}
+
+func TestReaderSchedule_SendHour(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	schedule := &ReaderSchedule{Enabled: true, Timezone: "Asia/Jakarta"}
+
+	// Opens at 21:xx Jakarta time, recorded in UTC
+	for i := 0; i < MinOpensToOptimize-1; i++ {
+		schedule.RecordOpen(time.Date(2026, 1, 3+i, 21, 10, 0, 0, jakarta).UTC())
+	}
+	if schedule.SendHour() != DefaultReaderSendHour {
+		t.Errorf("expected the default hour before enough opens, got %d", schedule.SendHour())
+	}
+	schedule.RecordOpen(time.Date(2026, 1, 10, 21, 40, 0, 0, jakarta))
+	schedule.RecordOpen(time.Date(2026, 1, 11, 6, 5, 0, 0, jakarta))
+	if schedule.SendHour() != 21 {
+		t.Errorf("expected the most common local open hour 21, got %d", schedule.SendHour())
+	}
+
+	// A new routine takes over once old counts decay
+	for i := 0; i < MaxOpenSamples; i++ {
+		schedule.RecordOpen(time.Date(2026, 2, 1, 6, 0, 0, 0, jakarta))
+	}
+	if schedule.SendHour() != 6 || schedule.opens() > MaxOpenSamples {
+		t.Errorf("expected hour 6 with at most %d samples, got %d with %d", MaxOpenSamples, schedule.SendHour(), schedule.opens())
+	}
+}
+
+func TestReaderSchedule_IsDue(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	// Saturday 2026-01-10
+	beforeSend := time.Date(2026, 1, 10, 6, 30, 0, 0, jakarta)
+	afterSend := time.Date(2026, 1, 10, 7, 15, 0, 0, jakarta)
+
+	sentLastWeek := time.Date(2026, 1, 3, 7, 2, 0, 0, jakarta)
+	schedule := &ReaderSchedule{Enabled: true, Timezone: "Asia/Jakarta", LastSentAt: &sentLastWeek}
+	if schedule.IsDue(beforeSend) {
+		t.Error("expected digest not to be due before the send hour")
+	}
+	if !schedule.IsDue(afterSend) {
+		t.Error("expected digest to be due after the send hour")
+	}
+	if !schedule.PeriodStart(afterSend).Equal(sentLastWeek) {
+		t.Error("expected period to start at the last send")
+	}
+
+	schedule.MarkSent(afterSend)
+	if schedule.IsDue(time.Date(2026, 1, 14, 9, 0, 0, 0, jakarta)) {
+		t.Error("expected digest not to be due mid-week after being sent")
+	}
+
+	schedule.Disable()
+	if schedule.IsDue(time.Date(2026, 1, 17, 9, 0, 0, 0, jakarta)) {
+		t.Error("expected disabled digest never to be due")
+	}
+}
+
+func TestSelectPicks(t *testing.T) {
+	pick := func(id string, reason PickReason) Pick {
+		return Pick{Ref: permalink.ArticleRef{ID: id}, Reason: reason}
+	}
+	followed := []Pick{pick("a1", ReasonFollowedTopic), pick("a2", ReasonFollowedTopic), pick("a3", ReasonFollowedTopic), pick("a4", ReasonFollowedTopic)}
+	history := []Pick{pick("a2", ReasonReadingHistory), pick("b1", ReasonReadingHistory)}
+	trending := []Pick{pick("c1", ReasonTrending), pick("a1", ReasonTrending), pick("c2", ReasonTrending)}
+	read := map[string]bool{"c1": true}
+
+	picks := SelectPicks(read, 6, followed, history, trending)
+
+	var ids []string
+	for _, p := range picks {
+		ids = append(ids, p.Ref.ID)
+	}
+	if want := []string{"a1", "a2", "c2", "a3", "b1", "a4"}; !slices.Equal(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+	if picks[1].Reason != ReasonReadingHistory {
+		t.Errorf("expected the source that reached a shared article first to keep it, got %s", picks[1].Reason)
+	}
+}
@@ -0,0 +1,24 @@
+package digest
+
+import "context"
+
+type SubscriptionRepository interface {
+	// Commands
+	Create(ctx context.Context, subscription *Subscription) error
+	Update(ctx context.Context, subscription *Subscription) error
+
+	// Queries
+	FindByAccountID(ctx context.Context, accountID string) (*Subscription, error)
+	FindEnabled(ctx context.Context) ([]*Subscription, error)
+}
+
+type ReaderScheduleRepository interface {
+	// Commands
+	Create(ctx context.Context, schedule *ReaderSchedule) error
+	Update(ctx context.Context, schedule *ReaderSchedule) error
+
+	// Queries
+	FindByAccountID(ctx context.Context, accountID string) (*ReaderSchedule, error)
+	// FindEnabled pages through enabled schedules ordered by account ID, after "" starts from the beginning
+	FindEnabled(ctx context.Context, after string, limit int) ([]*ReaderSchedule, error)
+}
@@ -0,0 +1,101 @@
+package digest
+
+import (
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/permalink"
+)
+
+// Section keys for the standard digest contents
+const (
+	SectionPendingVerifications   = "pending_verifications"
+	SectionArticlesAwaitingReview = "articles_awaiting_review"
+	SectionFlaggedComments        = "flagged_comments"
+	SectionPublishFailures        = "publish_failures"
+)
+
+// Section is one block of the digest, e.g. "12 registrations awaiting verification"
+type Section struct {
+	Key   string
+	Title string
+	Count int64
+	Items []string // Optional short list of highlights
+}
+
+func (s Section) IsEmpty() bool {
+	return s.Count == 0 && len(s.Items) == 0
+}
+
+// Digest is the assembled summary for one recipient
+type Digest struct {
+	AccountID   string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Sections    []Section
+}
+
+func (d Digest) IsEmpty() bool {
+	for _, s := range d.Sections {
+		if !s.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+type PickReason string
+
+const (
+	ReasonFollowedTopic  PickReason = "followed_topic"
+	ReasonReadingHistory PickReason = "reading_history"
+	ReasonTrending       PickReason = "trending"
+)
+
+// Pick is one recommended article in a reader digest
+type Pick struct {
+	Ref     permalink.ArticleRef
+	Title   string
+	Excerpt string
+	Reason  PickReason
+	Context string // What led to the pick, e.g. the followed topic's name
+}
+
+// ReaderDigest is the "weekly digest for you" for one member
+type ReaderDigest struct {
+	AccountID   string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Picks       []Pick
+}
+
+func (d ReaderDigest) IsEmpty() bool {
+	return len(d.Picks) == 0
+}
+
+// SelectPicks takes candidates from each source in turn so no single source fills
+// the digest, skipping articles the member already read and repeats across sources
+func SelectPicks(read map[string]bool, limit int, sources ...[]Pick) []Pick {
+	picks := make([]Pick, 0, limit)
+	seen := make(map[string]bool)
+	next := make([]int, len(sources))
+	for len(picks) < limit {
+		progressed := false
+		for i, candidates := range sources {
+			for next[i] < len(candidates) && len(picks) < limit {
+				c := candidates[next[i]]
+				next[i]++
+				if read[c.Ref.ID] || seen[c.Ref.ID] {
+					continue
+				}
+				seen[c.Ref.ID] = true
+				picks = append(picks, c)
+				progressed = true
+				break
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+	return picks
+}
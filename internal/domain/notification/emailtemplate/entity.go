@@ -0,0 +1,149 @@
+package emailtemplate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var keyRegex = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// Limits for template content
+const (
+	MaxSubjectLength = 200
+	MaxBodyLength    = 200_000
+	MaxNoteLength    = 500
+)
+
+// Domain errors
+var (
+	ErrInvalidKey        = errors.New("template key must be lowercase words separated by underscores")
+	ErrVersionNotFound   = errors.New("template version does not exist")
+	ErrAlreadyActive     = errors.New("version is already active")
+	ErrNothingToRollback = errors.New("no previous version to roll back to")
+)
+
+// Template is one transactional or marketing email, e.g. "welcome" or "password_reset".
+// Its content lives in immutable versions; exactly one version is live at a time.
+type Template struct {
+	Key         string
+	Description string
+	SampleData  map[string]any // Preview data, mirrors what the sending code passes in
+
+	LatestVersion   int  // 0 until the first draft
+	ActiveVersion   *int // Nil until a version is activated
+	PreviousVersion *int // Version that was live before the current one, for rollback
+	ActivatedBy     *string
+	ActivatedAt     *time.Time
+
+	// Audit
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewTemplate(key, description string, sampleData map[string]any, createdBy string) (*Template, error) {
+	key = strings.TrimSpace(key)
+	if !keyRegex.MatchString(key) {
+		return nil, ErrInvalidKey
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+	if sampleData == nil {
+		sampleData = map[string]any{}
+	}
+
+	now := time.Now()
+	return &Template{
+		Key:         key,
+		Description: strings.TrimSpace(description),
+		SampleData:  sampleData,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Business Methods
+
+// Draft creates the next version. Drafts are not sent to readers until activated.
+func (t *Template) Draft(content Content, authorID, note string) (*Version, error) {
+	if strings.TrimSpace(authorID) == "" {
+		return nil, errors.New("author ID cannot be empty")
+	}
+	note = strings.TrimSpace(note)
+	if len(note) > MaxNoteLength {
+		return nil, fmt.Errorf("%w: version note cannot exceed 500 characters", ErrInvalidContent)
+	}
+	if err := content.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	t.LatestVersion++
+	t.UpdatedAt = now
+	return &Version{
+		TemplateKey: t.Key,
+		Number:      t.LatestVersion,
+		Content:     content,
+		Note:        note,
+		AuthorID:    authorID,
+		CreatedAt:   now,
+	}, nil
+}
+
+// Activate makes the version live, the one it replaces is kept for rollback
+func (t *Template) Activate(number int, staffID string) error {
+	if number < 1 || number > t.LatestVersion {
+		return ErrVersionNotFound
+	}
+	if strings.TrimSpace(staffID) == "" {
+		return errors.New("staff ID cannot be empty")
+	}
+	if t.ActiveVersion != nil && *t.ActiveVersion == number {
+		return ErrAlreadyActive
+	}
+
+	now := time.Now()
+	t.PreviousVersion = t.ActiveVersion
+	t.ActiveVersion = &number
+	t.ActivatedBy = &staffID
+	t.ActivatedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// Rollback re-activates the version that was live before the current one
+func (t *Template) Rollback(staffID string) error {
+	if t.PreviousVersion == nil {
+		return ErrNothingToRollback
+	}
+	return t.Activate(*t.PreviousVersion, staffID)
+}
+
+func (t *Template) UpdateSampleData(sampleData map[string]any) {
+	if sampleData == nil {
+		sampleData = map[string]any{}
+	}
+	t.SampleData = sampleData
+	t.UpdatedAt = time.Now()
+}
+
+// Query Methods
+
+func (t *Template) IsLive() bool {
+	return t.ActiveVersion != nil
+}
+
+// Version is an immutable snapshot of a template's content
+type Version struct {
+	TemplateKey string
+	Number      int
+	Content     Content
+	Note        string // What changed, e.g. "new hero image for Ramadan campaign"
+	AuthorID    string
+	CreatedAt   time.Time
+}
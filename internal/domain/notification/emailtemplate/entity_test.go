@@ -0,0 +1,125 @@
+package emailtemplate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func createTestTemplate(t *testing.T) *Template {
+	tmpl, err := NewTemplate("welcome", "Sent after sign-up", map[string]any{"Name": "Sari"}, "staff1")
+	if err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+	return tmpl
+}
+
+func testContent(subject string) Content {
+	return Content{Subject: subject, HTMLBody: "<p>Hi {{.Name}}</p>", TextBody: "Hi {{.Name}}"}
+}
+
+func TestNewTemplate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		key         string
+		createdBy   string
+		expectedErr error
+		wantErr     bool
+	}{
+		{name: "valid", key: "password_reset", createdBy: "staff1"},
+		{name: "invalid key", key: "Password Reset", createdBy: "staff1", expectedErr: ErrInvalidKey, wantErr: true},
+		{name: "missing creator", key: "welcome", createdBy: " ", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewTemplate(tc.key, "", nil, tc.createdBy)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if tc.expectedErr != nil && err != tc.expectedErr {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTemplate_VersioningAndRollback(t *testing.T) {
+	tmpl := createTestTemplate(t)
+
+	v1, err := tmpl.Draft(testContent("Welcome, {{.Name}}"), "staff1", "first cut")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	v2, _ := tmpl.Draft(testContent("Selamat datang, {{.Name}}"), "staff2", "localized subject")
+	if v1.Number != 1 || v2.Number != 2 || tmpl.IsLive() {
+		t.Fatalf("expected two drafts and nothing live, got %d, %d", v1.Number, v2.Number)
+	}
+
+	if err := tmpl.Rollback("staff1"); err != ErrNothingToRollback {
+		t.Errorf("expected error '%v', got '%v'", ErrNothingToRollback, err)
+	}
+	if err := tmpl.Activate(1, "staff1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tmpl.Activate(2, "staff1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tmpl.Activate(2, "staff1"); err != ErrAlreadyActive {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyActive, err)
+	}
+	if err := tmpl.Activate(3, "staff1"); err != ErrVersionNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrVersionNotFound, err)
+	}
+
+	if err := tmpl.Rollback("staff3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *tmpl.ActiveVersion != 1 || *tmpl.PreviousVersion != 2 || *tmpl.ActivatedBy != "staff3" {
+		t.Errorf("expected version 1 live after rollback, got %d (previous %d)", *tmpl.ActiveVersion, *tmpl.PreviousVersion)
+	}
+}
+
+func TestTemplate_DraftRejectsBrokenTemplates(t *testing.T) {
+	tmpl := createTestTemplate(t)
+
+	_, err := tmpl.Draft(Content{Subject: "Hi {{.Name", HTMLBody: "<p>x</p>"}, "staff1", "")
+	if err == nil || !strings.Contains(err.Error(), "invalid subject template") {
+		t.Errorf("expected subject parse error, got %v", err)
+	}
+	if tmpl.LatestVersion != 0 {
+		t.Errorf("expected no version to be allocated, got %d", tmpl.LatestVersion)
+	}
+}
+
+func TestContent_Render(t *testing.T) {
+	content := Content{
+		Subject:  "Welcome,\r\nBcc: x@example.com {{.Name}}",
+		HTMLBody: `<p>Hi {{.Name}}</p>`,
+		TextBody: "Hi {{.Name}}",
+	}
+
+	rendered, err := content.Render(map[string]any{"Name": "<b>Sari</b>"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rendered.HTMLBody != "<p>Hi &lt;b&gt;Sari&lt;/b&gt;</p>" {
+		t.Errorf("expected escaped HTML, got %q", rendered.HTMLBody)
+	}
+	if strings.ContainsAny(rendered.Subject, "\r\n") {
+		t.Errorf("expected single-line subject, got %q", rendered.Subject)
+	}
+	if rendered.TextBody != "Hi <b>Sari</b>" {
+		t.Errorf("expected raw text body, got %q", rendered.TextBody)
+	}
+
+	if _, err := content.Render(map[string]any{}); !errors.Is(err, ErrRenderFailed) {
+		t.Errorf("expected error '%v', got '%v'", ErrRenderFailed, err)
+	}
+}
@@ -0,0 +1,20 @@
+package emailtemplate
+
+import "context"
+
+type TemplateRepository interface {
+	// Commands
+	Create(ctx context.Context, template *Template) error
+	Update(ctx context.Context, template *Template) error
+
+	// Queries
+	FindByKey(ctx context.Context, key string) (*Template, error) // nil when not found
+	FindAll(ctx context.Context) ([]*Template, error)
+}
+
+// VersionRepository stores versions append-only, they are never updated
+type VersionRepository interface {
+	Create(ctx context.Context, version *Version) error
+	FindByNumber(ctx context.Context, key string, number int) (*Version, error) // nil when not found
+	FindByTemplate(ctx context.Context, key string) ([]*Version, error)         // Newest first
+}
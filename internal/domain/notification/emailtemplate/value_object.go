@@ -0,0 +1,104 @@
+package emailtemplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+var (
+	ErrRenderFailed   = errors.New("failed to render template")
+	ErrInvalidContent = errors.New("invalid template content")
+)
+
+// Content is the subject and bodies of one version, written with Go template syntax, e.g. "Hi {{.Name}}"
+type Content struct {
+	Subject  string
+	HTMLBody string
+	TextBody string // Optional plain-text alternative
+}
+
+// Rendered is a template filled in with data, ready to send
+type Rendered struct {
+	Subject  string
+	HTMLBody string
+	TextBody string
+}
+
+// Validate checks the limits and that every part parses
+func (c Content) Validate() error {
+	if err := c.check(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidContent, err)
+	}
+	return nil
+}
+
+func (c Content) check() error {
+	if strings.TrimSpace(c.Subject) == "" {
+		return errors.New("subject cannot be empty")
+	}
+	if len(c.Subject) > MaxSubjectLength {
+		return errors.New("subject cannot exceed 200 characters")
+	}
+	if strings.TrimSpace(c.HTMLBody) == "" {
+		return errors.New("HTML body cannot be empty")
+	}
+	if len(c.HTMLBody) > MaxBodyLength || len(c.TextBody) > MaxBodyLength {
+		return errors.New("body cannot exceed 200000 characters")
+	}
+
+	if _, err := texttemplate.New("subject").Parse(c.Subject); err != nil {
+		return fmt.Errorf("invalid subject template: %w", err)
+	}
+	if _, err := htmltemplate.New("html").Parse(c.HTMLBody); err != nil {
+		return fmt.Errorf("invalid HTML template: %w", err)
+	}
+	if _, err := texttemplate.New("text").Parse(c.TextBody); err != nil {
+		return fmt.Errorf("invalid text template: %w", err)
+	}
+	return nil
+}
+
+// Render fills in the content. A variable missing from data is an error rather than
+// "<no value>", so previews catch sample data that drifted from the sending code.
+// The HTML body is escaped contextually, the subject and text body are not.
+func (c Content) Render(data map[string]any) (*Rendered, error) {
+	subject, err := renderText("subject", c.Subject, data)
+	if err != nil {
+		return nil, err
+	}
+	text, err := renderText("text", c.TextBody, data)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := htmltemplate.New("html").Option("missingkey=error").Parse(c.HTMLBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRenderFailed, err)
+	}
+	var html bytes.Buffer
+	if err := tmpl.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRenderFailed, err)
+	}
+
+	return &Rendered{
+		Subject:  strings.Join(strings.Fields(subject), " "), // No header injection through newlines
+		HTMLBody: html.String(),
+		TextBody: text,
+	}, nil
+}
+
+func renderText(name, source string, data map[string]any) (string, error) {
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRenderFailed, err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRenderFailed, err)
+	}
+	return out.String(), nil
+}
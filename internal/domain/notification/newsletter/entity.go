@@ -0,0 +1,105 @@
+package newsletter
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const MaxSegmentNameLength = 100
+
+// Domain errors
+var (
+	ErrSegmentNameEmpty   = errors.New("segment name cannot be empty")
+	ErrSegmentNameTooLong = errors.New("segment name cannot exceed 100 characters")
+)
+
+// Segment is a saved audience, e.g. "Engaged tech readers": follows tech and read at least 3 articles this week
+type Segment struct {
+	ID          string
+	Name        string
+	Description string
+	Rules       Group
+
+	// Last preview count, shown in the segment list
+	LastCount     *int64
+	LastCountedAt *time.Time
+
+	// Audit
+	CreatedBy string
+	UpdatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSegment(id, name, description string, rules Group, createdBy string) (*Segment, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(createdBy) == "" {
+		return nil, errors.New("createdBy cannot be empty")
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Segment{
+		ID:          id,
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		Rules:       rules,
+		CreatedBy:   createdBy,
+		UpdatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Business Methods
+
+// Redefine replaces the name and rules, the previous count no longer applies
+func (s *Segment) Redefine(name, description string, rules Group, staffID string) error {
+	if strings.TrimSpace(staffID) == "" {
+		return errors.New("staff ID cannot be empty")
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return err
+	}
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+
+	s.Name = name
+	s.Description = strings.TrimSpace(description)
+	s.Rules = rules
+	s.LastCount = nil
+	s.LastCountedAt = nil
+	s.UpdatedBy = staffID
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Segment) RecordCount(count int64, at time.Time) {
+	s.LastCount = &count
+	s.LastCountedAt = &at
+	s.UpdatedAt = time.Now()
+}
+
+// Domain Validation Functions
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrSegmentNameEmpty
+	}
+	if len(name) > MaxSegmentNameLength {
+		return "", ErrSegmentNameTooLong
+	}
+	return name, nil
+}
@@ -0,0 +1,23 @@
+package newsletter
+
+import "testing"
+
+func TestSegment_Redefine(t *testing.T) {
+	segment, err := NewSegment("seg1", "Engaged tech readers", "", engagedTech, "staff1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	segment.RecordCount(1200, testNow)
+
+	premiumOnly := Group{Match: MatchAll, Rules: []Rule{{Field: FieldPremium, Operator: OpEquals, Values: []string{"true"}}}}
+	if err := segment.Redefine("Premium readers", "", premiumOnly, "staff2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if segment.LastCount != nil || segment.UpdatedBy != "staff2" {
+		t.Error("expected stale count to be cleared")
+	}
+
+	if err := segment.Redefine("", "", premiumOnly, "staff2"); err == nil {
+		t.Error("expected error but got none")
+	}
+}
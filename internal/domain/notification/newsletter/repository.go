@@ -0,0 +1,35 @@
+package newsletter
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type SegmentRepository interface {
+	// Commands
+	Create(ctx context.Context, segment *Segment) error
+	Update(ctx context.Context, segment *Segment) error
+	Delete(ctx context.Context, id string) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Segment, error) // nil when not found
+	FindAll(ctx context.Context) ([]*Segment, error)
+}
+
+// Domain interface for reading subscriber profiles (implementation will be in infrastructure layer).
+// ScanProfiles pages by account ID after the given cursor, subscribed readers only.
+type ProfileSource interface {
+	ScanProfiles(ctx context.Context, hints ScanHints, afterAccountID string, limit int) ([]Profile, error)
+}
+
+type SubscriberRepository interface {
+	// Commands
+	Create(ctx context.Context, subscriber *Subscriber) error
+	Update(ctx context.Context, subscriber *Subscriber) error
+
+	// Queries
+	FindByEmail(ctx context.Context, email account.Email) (*Subscriber, error)  // nil when not found
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Subscriber, error) // nil when not found
+	FindAll(ctx context.Context, status *SubscriberStatus, limit, offset int) ([]*Subscriber, int64, error)
+}
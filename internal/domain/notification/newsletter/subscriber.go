@@ -0,0 +1,140 @@
+package newsletter
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// ConfirmationTTL is how long a double opt-in link stays valid
+const ConfirmationTTL = 48 * time.Hour
+
+type SubscriberStatus string
+
+const (
+	SubscriberPending      SubscriberStatus = "pending" // Signed up, confirmation link not clicked yet
+	SubscriberConfirmed    SubscriberStatus = "confirmed"
+	SubscriberUnsubscribed SubscriberStatus = "unsubscribed"
+)
+
+// Domain errors
+var (
+	ErrInvalidConfirmation = errors.New("confirmation link is invalid")
+	ErrConfirmationExpired = errors.New("confirmation link has expired")
+	ErrAlreadyConfirmed    = errors.New("subscription is already confirmed")
+)
+
+// OptIn is the proof kept for compliance: who asked, from where, and when they confirmed
+type OptIn struct {
+	Source      string // Signup form, e.g. "footer" or "article-inline"
+	RequestedAt time.Time
+	RequestIP   *string
+	ConfirmedAt *time.Time
+	ConfirmIP   *string
+}
+
+// Subscriber is one newsletter address going through double opt-in
+type Subscriber struct {
+	ID        string
+	Email     account.Email
+	AccountID *string // Set when the address belongs to a reader account
+	Status    SubscriberStatus
+	OptIn     OptIn
+
+	// Hash of the pending confirmation token, the token itself is only ever emailed
+	TokenHash      string
+	TokenExpiresAt *time.Time
+
+	UnsubscribedAt *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewSubscriber(id string, email account.Email, source, ip, tokenHash string, at time.Time) (*Subscriber, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(tokenHash) == "" {
+		return nil, errors.New("token hash cannot be empty")
+	}
+
+	s := &Subscriber{
+		ID:        id,
+		Email:     email,
+		CreatedAt: at,
+	}
+	s.request(source, ip, tokenHash, at)
+	return s, nil
+}
+
+// Business Methods
+
+// Resubscribe starts a new opt-in for a pending or unsubscribed address, the old proof is replaced
+func (s *Subscriber) Resubscribe(source, ip, tokenHash string, at time.Time) error {
+	if s.Status == SubscriberConfirmed {
+		return ErrAlreadyConfirmed
+	}
+	if strings.TrimSpace(tokenHash) == "" {
+		return errors.New("token hash cannot be empty")
+	}
+	s.request(source, ip, tokenHash, at)
+	return nil
+}
+
+// Confirm completes double opt-in when the hash of the clicked token matches
+func (s *Subscriber) Confirm(tokenHash, ip string, at time.Time) error {
+	if s.Status == SubscriberConfirmed {
+		return ErrAlreadyConfirmed
+	}
+	if s.Status != SubscriberPending || s.TokenHash == "" || subtle.ConstantTimeCompare([]byte(s.TokenHash), []byte(tokenHash)) != 1 {
+		return ErrInvalidConfirmation
+	}
+	if s.TokenExpiresAt != nil && !at.Before(*s.TokenExpiresAt) {
+		return ErrConfirmationExpired
+	}
+
+	s.Status = SubscriberConfirmed
+	s.OptIn.ConfirmedAt = &at
+	s.OptIn.ConfirmIP = optionalString(ip)
+	s.TokenHash = ""
+	s.TokenExpiresAt = nil
+	s.UpdatedAt = at
+	return nil
+}
+
+func (s *Subscriber) Unsubscribe(at time.Time) {
+	s.Status = SubscriberUnsubscribed
+	s.TokenHash = ""
+	s.TokenExpiresAt = nil
+	s.UnsubscribedAt = &at
+	s.UpdatedAt = at
+}
+
+// Query Methods
+
+// IsSendable reports whether newsletters may go to this address
+func (s *Subscriber) IsSendable() bool {
+	return s.Status == SubscriberConfirmed
+}
+
+func (s *Subscriber) request(source, ip, tokenHash string, at time.Time) {
+	expires := at.Add(ConfirmationTTL)
+	s.Status = SubscriberPending
+	s.OptIn = OptIn{Source: strings.TrimSpace(source), RequestedAt: at, RequestIP: optionalString(ip)}
+	s.TokenHash = tokenHash
+	s.TokenExpiresAt = &expires
+	s.UnsubscribedAt = nil
+	s.UpdatedAt = at
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
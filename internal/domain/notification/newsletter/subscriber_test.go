@@ -0,0 +1,76 @@
+package newsletter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func createTestSubscriber(t *testing.T, at time.Time) *Subscriber {
+	email, _ := account.NewEmail("reader@example.com")
+	s, err := NewSubscriber("sub-1", *email, "footer", "203.0.113.7", "hash-1", at)
+	if err != nil {
+		t.Fatalf("failed to create subscriber: %v", err)
+	}
+	return s
+}
+
+func TestSubscriber_Confirm(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		tokenHash   string
+		confirmAt   time.Time
+		expectedErr error
+	}{
+		{"valid", "hash-1", at.Add(time.Hour), nil},
+		{"wrong token", "hash-2", at.Add(time.Hour), ErrInvalidConfirmation},
+		{"expired", "hash-1", at.Add(ConfirmationTTL), ErrConfirmationExpired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := createTestSubscriber(t, at)
+			err := s.Confirm(tc.tokenHash, "198.51.100.2", tc.confirmAt)
+			if err != tc.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err != nil {
+				if s.IsSendable() {
+					t.Error("expected pending subscriber not to be sendable")
+				}
+				return
+			}
+			if !s.IsSendable() || s.TokenHash != "" {
+				t.Errorf("expected confirmed subscriber with token cleared, got %s", s.Status)
+			}
+			if *s.OptIn.RequestIP != "203.0.113.7" || *s.OptIn.ConfirmIP != "198.51.100.2" || !s.OptIn.ConfirmedAt.Equal(tc.confirmAt) {
+				t.Errorf("unexpected opt-in proof %+v", s.OptIn)
+			}
+			if err := s.Confirm("hash-1", "", tc.confirmAt); err != ErrAlreadyConfirmed {
+				t.Errorf("expected error '%v', got '%v'", ErrAlreadyConfirmed, err)
+			}
+		})
+	}
+}
+
+func TestSubscriber_Resubscribe(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	s := createTestSubscriber(t, at)
+	_ = s.Confirm("hash-1", "", at)
+	if err := s.Resubscribe("footer", "", "hash-2", at); err != ErrAlreadyConfirmed {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyConfirmed, err)
+	}
+
+	s.Unsubscribe(at.Add(time.Hour))
+	if err := s.Confirm("hash-1", "", at.Add(time.Hour)); err != ErrInvalidConfirmation {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidConfirmation, err)
+	}
+	if err := s.Resubscribe("article-inline", "", "hash-2", at.Add(2*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Status != SubscriberPending || s.OptIn.Source != "article-inline" || s.OptIn.ConfirmedAt != nil || s.UnsubscribedAt != nil {
+		t.Errorf("expected a fresh pending opt-in, got %s %+v", s.Status, s.OptIn)
+	}
+}
@@ -0,0 +1,343 @@
+package newsletter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Field is a subscriber attribute or behavior a rule can test
+type Field string
+
+const (
+	FieldAccountType     Field = "account_type"
+	FieldPremium         Field = "premium"
+	FieldRegion          Field = "region"
+	FieldFollows         Field = "follows"          // Topic slugs the reader follows, e.g. "tech"
+	FieldArticlesRead7d  Field = "articles_read_7d" // Behavioral, loaded only when a segment uses it
+	FieldDaysSinceSignup Field = "days_since_signup"
+)
+
+type Operator string
+
+const (
+	OpEquals      Operator = "eq"
+	OpNotEquals   Operator = "neq"
+	OpIn          Operator = "in"
+	OpNotIn       Operator = "not_in"
+	OpAtLeast     Operator = "gte"
+	OpAtMost      Operator = "lte"
+	OpContains    Operator = "contains"     // follows includes the value
+	OpNotContains Operator = "not_contains" // follows excludes the value
+)
+
+type Match string
+
+const (
+	MatchAll Match = "all"
+	MatchAny Match = "any"
+)
+
+// Rule limits keep send-time evaluation cheap
+const (
+	MaxRulesPerSegment = 50
+	MaxGroupDepth      = 3
+	MaxRuleValues      = 100
+)
+
+var ErrInvalidRule = errors.New("invalid segment rule")
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindBool
+	kindNumber
+	kindSet
+)
+
+var fieldKinds = map[Field]fieldKind{
+	FieldAccountType:     kindString,
+	FieldPremium:         kindBool,
+	FieldRegion:          kindString,
+	FieldFollows:         kindSet,
+	FieldArticlesRead7d:  kindNumber,
+	FieldDaysSinceSignup: kindNumber,
+}
+
+var operatorsByKind = map[fieldKind][]Operator{
+	kindString: {OpEquals, OpNotEquals, OpIn, OpNotIn},
+	kindBool:   {OpEquals},
+	kindNumber: {OpEquals, OpAtLeast, OpAtMost},
+	kindSet:    {OpContains, OpNotContains, OpIn},
+}
+
+// Rule tests one field, e.g. {follows contains tech} or {articles_read_7d gte 3}
+type Rule struct {
+	Field    Field
+	Operator Operator
+	Values   []string // One value, or several for in / not_in
+}
+
+// Group combines rules and nested groups with all (AND) or any (OR)
+type Group struct {
+	Match  Match
+	Rules  []Rule
+	Groups []Group
+}
+
+// Profile is the subscriber snapshot rules are evaluated against
+type Profile struct {
+	AccountID      string
+	Email          string
+	AccountType    account.UserAccountType
+	Premium        bool
+	Region         string
+	Follows        []string
+	ArticlesRead7d int // Zero unless the scan loaded behavior
+	SignedUpAt     time.Time
+}
+
+// ScanHints let a profile source narrow its query. Every hint comes from a top-level
+// "all" rule, so a source may ignore them; the compiled matcher re-checks every profile.
+// Regions are trimmed and lowercase, a source must compare them against the lowercased
+// profile region as the matcher does.
+type ScanHints struct {
+	WithBehavior bool
+	Premium      *bool
+	AccountTypes []account.UserAccountType
+	Regions      []string
+}
+
+// Matcher is a compiled rule tree
+type Matcher func(p *Profile) bool
+
+// Validate checks fields, operators, values and the size of the tree
+func (g Group) Validate() error {
+	count := 0
+	if err := g.validate(1, &count); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: segment needs at least one rule", ErrInvalidRule)
+	}
+	return nil
+}
+
+func (g Group) validate(depth int, count *int) error {
+	if depth > MaxGroupDepth {
+		return fmt.Errorf("groups cannot nest deeper than %d levels", MaxGroupDepth)
+	}
+	if g.Match != MatchAll && g.Match != MatchAny {
+		return errors.New("group match must be all or any")
+	}
+	for _, r := range g.Rules {
+		if err := r.validate(); err != nil {
+			return err
+		}
+		*count++
+	}
+	for _, child := range g.Groups {
+		if err := child.validate(depth+1, count); err != nil {
+			return err
+		}
+	}
+	if *count > MaxRulesPerSegment {
+		return fmt.Errorf("segment cannot have more than %d rules", MaxRulesPerSegment)
+	}
+	return nil
+}
+
+func (r Rule) validate() error {
+	kind, ok := fieldKinds[r.Field]
+	if !ok {
+		return fmt.Errorf("unknown field %q", r.Field)
+	}
+
+	allowed := false
+	for _, op := range operatorsByKind[kind] {
+		allowed = allowed || op == r.Operator
+	}
+	if !allowed {
+		return fmt.Errorf("operator %q cannot be used with %s", r.Operator, r.Field)
+	}
+
+	multi := r.Operator == OpIn || r.Operator == OpNotIn
+	switch {
+	case len(r.Values) == 0:
+		return fmt.Errorf("%s rule needs a value", r.Field)
+	case !multi && len(r.Values) > 1:
+		return fmt.Errorf("%s %s takes a single value", r.Field, r.Operator)
+	case len(r.Values) > MaxRuleValues:
+		return fmt.Errorf("rule cannot have more than %d values", MaxRuleValues)
+	}
+
+	for _, v := range r.Values {
+		switch kind {
+		case kindBool:
+			if _, err := strconv.ParseBool(v); err != nil {
+				return fmt.Errorf("%s must be true or false", r.Field)
+			}
+		case kindNumber:
+			if n, err := strconv.Atoi(v); err != nil || n < 0 {
+				return fmt.Errorf("%s must be a non-negative whole number", r.Field)
+			}
+		default:
+			if strings.TrimSpace(v) == "" {
+				return fmt.Errorf("%s values cannot be blank", r.Field)
+			}
+		}
+	}
+	return nil
+}
+
+// UsesBehavior reports whether any rule needs reading activity, which is costly to load
+func (g Group) UsesBehavior() bool {
+	for _, r := range g.Rules {
+		if r.Field == FieldArticlesRead7d {
+			return true
+		}
+	}
+	for _, child := range g.Groups {
+		if child.UsesBehavior() {
+			return true
+		}
+	}
+	return false
+}
+
+func (g Group) Hints() ScanHints {
+	hints := ScanHints{WithBehavior: g.UsesBehavior()}
+	if g.Match != MatchAll {
+		return hints
+	}
+	for _, r := range g.Rules {
+		switch {
+		case r.Field == FieldPremium:
+			premium, _ := strconv.ParseBool(r.Values[0])
+			hints.Premium = &premium
+		case r.Field == FieldAccountType && (r.Operator == OpEquals || r.Operator == OpIn):
+			for _, v := range r.Values {
+				hints.AccountTypes = append(hints.AccountTypes, account.UserAccountType(v))
+			}
+		case r.Field == FieldRegion && (r.Operator == OpEquals || r.Operator == OpIn):
+			for _, v := range r.Values {
+				hints.Regions = append(hints.Regions, strings.ToLower(strings.TrimSpace(v)))
+			}
+		}
+	}
+	return hints
+}
+
+// Compile validates the tree and turns it into a matcher. Within a group attribute rules run
+// before behavioral ones so the common rejections stay cheap. days_since_signup is measured at 'at'.
+func (g Group) Compile(at time.Time) (Matcher, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	return g.compile(at), nil
+}
+
+func (g Group) compile(at time.Time) Matcher {
+	var cheap, costly []Matcher
+	for _, r := range g.Rules {
+		if r.Field == FieldArticlesRead7d {
+			costly = append(costly, r.compile(at))
+		} else {
+			cheap = append(cheap, r.compile(at))
+		}
+	}
+	for _, child := range g.Groups {
+		costly = append(costly, child.compile(at))
+	}
+	matchers := append(cheap, costly...)
+
+	if g.Match == MatchAny {
+		return func(p *Profile) bool {
+			for _, m := range matchers {
+				if m(p) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return func(p *Profile) bool {
+		for _, m := range matchers {
+			if !m(p) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func (r Rule) compile(at time.Time) Matcher {
+	set := make(map[string]bool, len(r.Values))
+	for _, v := range r.Values {
+		set[strings.ToLower(strings.TrimSpace(v))] = true
+	}
+	first := strings.ToLower(strings.TrimSpace(r.Values[0]))
+
+	switch r.Field {
+	case FieldAccountType:
+		return stringMatcher(r.Operator, first, set, func(p *Profile) string { return string(p.AccountType) })
+	case FieldRegion:
+		return stringMatcher(r.Operator, first, set, func(p *Profile) string { return p.Region })
+	case FieldPremium:
+		want, _ := strconv.ParseBool(first)
+		return func(p *Profile) bool { return p.Premium == want }
+	case FieldArticlesRead7d:
+		n, _ := strconv.Atoi(first)
+		return numberMatcher(r.Operator, n, func(p *Profile) int { return p.ArticlesRead7d })
+	case FieldDaysSinceSignup:
+		n, _ := strconv.Atoi(first)
+		return numberMatcher(r.Operator, n, func(p *Profile) int { return int(at.Sub(p.SignedUpAt) / (24 * time.Hour)) })
+	default: // FieldFollows
+		return func(p *Profile) bool {
+			found := false
+			for _, topic := range p.Follows {
+				topic = strings.ToLower(topic)
+				if (r.Operator == OpIn && set[topic]) || topic == first {
+					found = true
+					break
+				}
+			}
+			return found != (r.Operator == OpNotContains)
+		}
+	}
+}
+
+func stringMatcher(op Operator, first string, set map[string]bool, get func(p *Profile) string) Matcher {
+	return func(p *Profile) bool {
+		v := strings.ToLower(get(p))
+		switch op {
+		case OpEquals:
+			return v == first
+		case OpNotEquals:
+			return v != first
+		case OpIn:
+			return set[v]
+		default: // OpNotIn
+			return !set[v]
+		}
+	}
+}
+
+func numberMatcher(op Operator, n int, get func(p *Profile) int) Matcher {
+	return func(p *Profile) bool {
+		v := get(p)
+		switch op {
+		case OpAtLeast:
+			return v >= n
+		case OpAtMost:
+			return v <= n
+		default: // OpEquals
+			return v == n
+		}
+	}
+}
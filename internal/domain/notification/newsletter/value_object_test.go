@@ -0,0 +1,115 @@
+package newsletter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var testNow = time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+
+// engagedTech is "follows Tech, read at least 3 articles this week, premium subscriber"
+var engagedTech = Group{
+	Match: MatchAll,
+	Rules: []Rule{
+		{Field: FieldArticlesRead7d, Operator: OpAtLeast, Values: []string{"3"}},
+		{Field: FieldFollows, Operator: OpContains, Values: []string{"Tech"}},
+		{Field: FieldPremium, Operator: OpEquals, Values: []string{"true"}},
+	},
+}
+
+func TestGroup_Compile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rules    Group
+		profile  Profile
+		expected bool
+	}{
+		{"engaged premium tech reader", engagedTech, Profile{Premium: true, Follows: []string{"tech", "sport"}, ArticlesRead7d: 5}, true},
+		{"not premium", engagedTech, Profile{Follows: []string{"tech"}, ArticlesRead7d: 5}, false},
+		{"reads too little", engagedTech, Profile{Premium: true, Follows: []string{"tech"}, ArticlesRead7d: 2}, false},
+		{
+			"any group",
+			Group{Match: MatchAny, Rules: []Rule{
+				{Field: FieldRegion, Operator: OpIn, Values: []string{"jabar", "jatim"}},
+				{Field: FieldAccountType, Operator: OpEquals, Values: []string{"partner"}},
+			}},
+			Profile{Region: "jatim", AccountType: account.TypeMembership},
+			true,
+		},
+		{
+			"nested group with exclusion",
+			Group{Match: MatchAll, Rules: []Rule{{Field: FieldFollows, Operator: OpNotContains, Values: []string{"politics"}}}, Groups: []Group{{
+				Match: MatchAny,
+				Rules: []Rule{{Field: FieldDaysSinceSignup, Operator: OpAtMost, Values: []string{"7"}}},
+			}}},
+			Profile{Follows: []string{"tech"}, SignedUpAt: testNow.AddDate(0, 0, -3)},
+			true,
+		},
+		{
+			"excluded topic",
+			Group{Match: MatchAll, Rules: []Rule{{Field: FieldFollows, Operator: OpNotContains, Values: []string{"politics"}}}},
+			Profile{Follows: []string{"Politics"}},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			match, err := tc.rules.Compile(testNow)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := match(&tc.profile); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGroup_Validate(t *testing.T) {
+	deep := Group{Match: MatchAll, Rules: []Rule{{Field: FieldPremium, Operator: OpEquals, Values: []string{"true"}}}}
+	for i := 0; i < MaxGroupDepth; i++ {
+		deep = Group{Match: MatchAll, Groups: []Group{deep}}
+	}
+
+	testCases := []struct {
+		name  string
+		rules Group
+	}{
+		{"empty", Group{Match: MatchAll}},
+		{"unknown field", Group{Match: MatchAll, Rules: []Rule{{Field: "age", Operator: OpEquals, Values: []string{"30"}}}}},
+		{"operator not allowed", Group{Match: MatchAll, Rules: []Rule{{Field: FieldPremium, Operator: OpAtLeast, Values: []string{"1"}}}}},
+		{"bad number", Group{Match: MatchAll, Rules: []Rule{{Field: FieldArticlesRead7d, Operator: OpAtLeast, Values: []string{"many"}}}}},
+		{"several values for eq", Group{Match: MatchAll, Rules: []Rule{{Field: FieldRegion, Operator: OpEquals, Values: []string{"a", "b"}}}}},
+		{"invalid match", Group{Match: "most", Rules: engagedTech.Rules}},
+		{"too deep", deep},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.rules.Validate(); !errors.Is(err, ErrInvalidRule) {
+				t.Errorf("expected error '%v', got '%v'", ErrInvalidRule, err)
+			}
+		})
+	}
+}
+
+func TestGroup_Hints(t *testing.T) {
+	hints := engagedTech.Hints()
+	if !hints.WithBehavior || hints.Premium == nil || !*hints.Premium {
+		t.Errorf("expected behavior and premium hints, got %+v", hints)
+	}
+
+	anyGroup := Group{Match: MatchAny, Rules: []Rule{{Field: FieldPremium, Operator: OpEquals, Values: []string{"true"}}}}
+	if hints := anyGroup.Hints(); hints.Premium != nil || hints.WithBehavior {
+		t.Errorf("expected no narrowing for an any group, got %+v", hints)
+	}
+
+	regions := Group{Match: MatchAll, Rules: []Rule{{Field: FieldRegion, Operator: OpIn, Values: []string{"JaBar", " jatim "}}}}
+	if hints := regions.Hints(); len(hints.Regions) != 2 || hints.Regions[0] != "jabar" || hints.Regions[1] != "jatim" {
+		t.Errorf("expected region hints normalized like the matcher, got %v", hints.Regions)
+	}
+}
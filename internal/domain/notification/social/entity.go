@@ -0,0 +1,215 @@
+package social
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Template is the post format of one channel
+type Template struct {
+	Channel Channel
+	Body    string // Go template syntax, e.g. "{{.Title}}\n\n{{.URL}}"
+	Enabled bool   // Disabled channels get no posts
+
+	LastActionBy *string
+
+	// Audit
+	UpdatedAt time.Time
+}
+
+func NewTemplate(channel Channel, body string, enabled bool, editorID string, at time.Time) (*Template, error) {
+	if _, err := ParseChannel(string(channel)); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return nil, errors.New("editor ID cannot be empty")
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyPost
+	}
+	t := &Template{Channel: channel, Body: body, Enabled: enabled, LastActionBy: &editorID, UpdatedAt: at}
+	// A sample render catches unknown fields now rather than at publish time
+	if _, _, err := t.Render(Article{Title: "Judul", Summary: "Ringkasan", URL: "https://example.com/a", Section: "News", Tags: []string{"tag"}}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DefaultTemplate is used for channels nobody configured yet
+func DefaultTemplate(channel Channel) *Template {
+	return &Template{Channel: channel, Body: "{{.Title}}\n\n{{.URL}}", Enabled: true}
+}
+
+// Query Methods
+
+// Render fills the template for an article and returns the text with the
+// UTM-tagged link it contains
+func (t *Template) Render(a Article) (string, string, error) {
+	tmpl, err := parseTemplate(t.Body)
+	if err != nil {
+		return "", "", err
+	}
+	link, err := TagURL(a.URL, t.Channel, a.Campaign)
+	if err != nil {
+		return "", "", err
+	}
+	text, err := render(tmpl, templateData{Title: a.Title, Summary: a.Summary, URL: link, Section: a.Section, Hashtags: hashtags(a.Tags)})
+	if err != nil {
+		return "", "", err
+	}
+	if text == "" {
+		return "", "", ErrEmptyPost
+	}
+	return text, link, nil
+}
+
+// Post is one queued delivery of an article to a channel
+type Post struct {
+	ID          string
+	ArticleID   string
+	Channel     Channel
+	Text        string
+	Link        string // UTM-tagged article URL
+	ScheduledAt time.Time
+	Status      PostStatus
+	Overridden  bool // Edited by hand, later article reschedules leave the text alone
+
+	// Delivery
+	Attempts   int
+	ExternalID *string // ID of the post on the network
+	LastError  *string
+	SentAt     *time.Time
+
+	LastActionBy *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewPost(id, articleID string, channel Channel, text, link string, scheduledAt, at time.Time) (*Post, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(articleID) == "" {
+		return nil, errors.New("article ID cannot be empty")
+	}
+	if err := channel.CheckLength(text, link); err != nil {
+		return nil, err
+	}
+	return &Post{
+		ID:          id,
+		ArticleID:   articleID,
+		Channel:     channel,
+		Text:        text,
+		Link:        link,
+		ScheduledAt: scheduledAt,
+		Status:      PostScheduled,
+		CreatedAt:   at,
+		UpdatedAt:   at,
+	}, nil
+}
+
+// Business Methods
+
+// Refresh follows a change of the article's text or publish time. Hand-edited
+// text is kept, only the time moves.
+func (p *Post) Refresh(text, link string, scheduledAt, at time.Time) error {
+	if p.Status != PostScheduled {
+		return ErrNotEditable
+	}
+	if !p.Overridden {
+		if err := p.Channel.CheckLength(text, link); err != nil {
+			return err
+		}
+		p.Text, p.Link = text, link
+	}
+	p.ScheduledAt = scheduledAt
+	p.UpdatedAt = at
+	return nil
+}
+
+// Override replaces the text and time by hand
+func (p *Post) Override(text string, scheduledAt time.Time, editorID string, at time.Time) error {
+	if p.Status != PostScheduled {
+		return ErrNotEditable
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return errors.New("editor ID cannot be empty")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ErrEmptyPost
+	}
+	if scheduledAt.Before(at) {
+		return ErrScheduleInPast
+	}
+	if err := p.Channel.CheckLength(text, p.Link); err != nil {
+		return err
+	}
+	p.Text = text
+	p.ScheduledAt = scheduledAt
+	p.Overridden = true
+	p.LastActionBy = &editorID
+	p.UpdatedAt = at
+	return nil
+}
+
+func (p *Post) Cancel(editorID string, at time.Time) error {
+	if p.Status != PostScheduled && p.Status != PostFailed {
+		return ErrNotCancellable
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return errors.New("editor ID cannot be empty")
+	}
+	p.Status = PostCancelled
+	p.LastActionBy = &editorID
+	p.UpdatedAt = at
+	return nil
+}
+
+// Retry puts a failed post back in the queue with fresh attempts
+func (p *Post) Retry(editorID string, at time.Time) error {
+	if p.Status != PostFailed {
+		return ErrNotRetryable
+	}
+	if strings.TrimSpace(editorID) == "" {
+		return errors.New("editor ID cannot be empty")
+	}
+	p.Status = PostScheduled
+	p.Attempts = 0
+	p.ScheduledAt = at
+	p.LastActionBy = &editorID
+	p.UpdatedAt = at
+	return nil
+}
+
+func (p *Post) MarkSent(externalID string, at time.Time) {
+	p.Status = PostSent
+	p.Attempts++
+	p.ExternalID = &externalID
+	p.LastError = nil
+	p.SentAt = &at
+	p.UpdatedAt = at
+}
+
+// MarkFailed records a failed delivery, the post is retried with a growing
+// delay until it runs out of attempts
+func (p *Post) MarkFailed(reason string, at time.Time) {
+	p.Attempts++
+	p.LastError = &reason
+	p.UpdatedAt = at
+	if p.Attempts >= MaxAttempts {
+		p.Status = PostFailed
+		return
+	}
+	p.ScheduledAt = at.Add(time.Duration(p.Attempts) * RetryBackoff)
+}
+
+// Query Methods
+
+func (p *Post) Due(at time.Time) bool {
+	return p.Status == PostScheduled && !p.ScheduledAt.After(at)
+}
@@ -0,0 +1,72 @@
+package social
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := NewTemplate(ChannelTelegram, "<b>{{.Title}}</b>\n{{.Summary}}\n{{.URL}} {{.Hashtags}}", true, "editor-1", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text, link, err := tmpl.Render(Article{Title: "Harga BBM", Summary: "Naik mulai besok.", URL: "https://example.com/a1", Tags: []string{"energi"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(text, link) || !strings.Contains(link, "utm_source=telegram") || !strings.HasSuffix(text, "#Energi") {
+		t.Errorf("unexpected post %q", text)
+	}
+
+	if _, err := NewTemplate(ChannelX, "{{.Headline}}", true, "editor-1", time.Now()); !errors.Is(err, ErrInvalidTemplate) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTemplate, err)
+	}
+}
+
+func TestPost_Lifecycle(t *testing.T) {
+	at := time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	p, err := NewPost("p1", "a1", ChannelX, "Harga BBM https://x.co/a", "https://x.co/a", at.Add(time.Hour), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Due(at) || !p.Due(at.Add(time.Hour)) {
+		t.Error("expected the post due at its scheduled time")
+	}
+
+	if err := p.Override("Harga BBM naik https://x.co/a", at.Add(2*time.Hour), "editor-1", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.Refresh("new text", "https://x.co/a", at.Add(3*time.Hour), at); err != nil || p.Text != "Harga BBM naik https://x.co/a" || !p.ScheduledAt.Equal(at.Add(3*time.Hour)) {
+		t.Errorf("expected an overridden text kept and the time moved, got %q at %s", p.Text, p.ScheduledAt)
+	}
+	if err := p.Override("text", at.Add(-time.Minute), "editor-1", at); err != ErrScheduleInPast {
+		t.Errorf("expected error '%v', got '%v'", ErrScheduleInPast, err)
+	}
+
+	for i := 0; i < MaxAttempts; i++ {
+		p.MarkFailed("rate limited", at)
+	}
+	if p.Status != PostFailed || p.Attempts != MaxAttempts {
+		t.Fatalf("expected failed after %d attempts, got %s/%d", MaxAttempts, p.Status, p.Attempts)
+	}
+	if err := p.Retry("editor-1", at); err != nil || p.Status != PostScheduled || p.Attempts != 0 {
+		t.Fatalf("expected a retried post back in the queue, got %s, %v", p.Status, err)
+	}
+
+	p.MarkSent("1799", at)
+	if err := p.Cancel("editor-1", at); err != ErrNotCancellable {
+		t.Errorf("expected error '%v', got '%v'", ErrNotCancellable, err)
+	}
+}
+
+func TestPost_MarkFailed_Backoff(t *testing.T) {
+	at := time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	p, _ := NewPost("p1", "a1", ChannelTelegram, "text", "", at, at)
+
+	p.MarkFailed("timeout", at)
+	if p.Status != PostScheduled || !p.ScheduledAt.Equal(at.Add(RetryBackoff)) {
+		t.Errorf("expected a retry after %s, got %s at %s", RetryBackoff, p.Status, p.ScheduledAt)
+	}
+}
@@ -0,0 +1,25 @@
+package social
+
+import (
+	"context"
+	"time"
+)
+
+type TemplateRepository interface {
+	Save(ctx context.Context, template *Template) error
+	FindByChannel(ctx context.Context, channel Channel) (*Template, error) // nil when never set
+}
+
+type PostRepository interface {
+	Save(ctx context.Context, post *Post) error
+	FindByID(ctx context.Context, id string) (*Post, error)
+	FindByArticle(ctx context.Context, articleID string) ([]*Post, error)
+	// FindDue returns scheduled posts due at the time, oldest first
+	FindDue(ctx context.Context, at time.Time, limit int) ([]*Post, error)
+}
+
+// Publisher delivers a post to one network and returns the post's ID there
+// (implementations will be in infrastructure layer)
+type Publisher interface {
+	Publish(ctx context.Context, post *Post) (string, error)
+}
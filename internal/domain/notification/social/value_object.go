@@ -0,0 +1,169 @@
+package social
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// Delivery retries, a post that fails MaxAttempts times stays failed until an editor reschedules it
+const (
+	MaxAttempts  = 3
+	RetryBackoff = 5 * time.Minute
+)
+
+// xURLLength is what X counts any link as, it wraps them all in t.co
+const xURLLength = 23
+
+// Domain errors
+var (
+	ErrInvalidChannel  = errors.New("channel must be x, facebook, telegram or whatsapp")
+	ErrInvalidTemplate = errors.New("invalid post template")
+	ErrTooLong         = errors.New("post is too long for the channel")
+	ErrEmptyPost       = errors.New("post text cannot be empty")
+	ErrNotEditable     = errors.New("only scheduled posts can be changed")
+	ErrNotCancellable  = errors.New("post is already sent or cancelled")
+	ErrScheduleInPast  = errors.New("post cannot be scheduled in the past")
+	ErrNotRetryable    = errors.New("only failed posts can be retried")
+)
+
+type Channel string
+
+const (
+	ChannelX        Channel = "x"
+	ChannelFacebook Channel = "facebook"
+	ChannelTelegram Channel = "telegram"
+	ChannelWhatsApp Channel = "whatsapp" // WhatsApp channel, not chats
+)
+
+var AllChannels = []Channel{ChannelX, ChannelFacebook, ChannelTelegram, ChannelWhatsApp}
+
+// maxLengths in characters as each network counts them
+var maxLengths = map[Channel]int{
+	ChannelX:        280,
+	ChannelFacebook: 63206,
+	ChannelTelegram: 4096,
+	ChannelWhatsApp: 4096,
+}
+
+func ParseChannel(value string) (Channel, error) {
+	c := Channel(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := maxLengths[c]; !ok {
+		return "", ErrInvalidChannel
+	}
+	return c, nil
+}
+
+// Length is the post's length as the channel counts it
+func (c Channel) Length(text, link string) int {
+	if c == ChannelX {
+		n := utf8.RuneCountInString(strings.ReplaceAll(text, link, ""))
+		if link != "" && strings.Contains(text, link) {
+			n += xURLLength
+		}
+		return n
+	}
+	return utf8.RuneCountInString(text)
+}
+
+// CheckLength returns ErrTooLong with the limit when the post does not fit
+func (c Channel) CheckLength(text, link string) error {
+	if n := c.Length(text, link); n > maxLengths[c] {
+		return fmt.Errorf("%w: %d of %d characters on %s", ErrTooLong, n, maxLengths[c], c)
+	}
+	return nil
+}
+
+// utmSource is how each channel shows up in analytics
+func (c Channel) utmSource() string {
+	if c == ChannelX {
+		return "twitter" // What analytics tools and past campaigns already use
+	}
+	return string(c)
+}
+
+// TagURL adds UTM parameters for the channel. Parameters already on the link
+// are kept, so an editor's hand-tagged campaign link is not overwritten.
+func TagURL(rawURL string, channel Channel, campaign string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid article URL %q", rawURL)
+	}
+	if campaign == "" {
+		campaign = "article"
+	}
+	query := u.Query()
+	for key, value := range map[string]string{"utm_source": channel.utmSource(), "utm_medium": "social", "utm_campaign": campaign} {
+		if query.Get(key) == "" {
+			query.Set(key, value)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Article is what the publish flow hands over when an article is published or scheduled
+type Article struct {
+	ID       string
+	Title    string
+	Summary  string
+	URL      string
+	Section  string
+	Tags     []string
+	Campaign string // utm_campaign, empty uses "article"
+}
+
+// templateData is what templates see, e.g. "{{.Title}} {{.URL}} {{.Hashtags}}"
+type templateData struct {
+	Title    string
+	Summary  string
+	URL      string
+	Section  string
+	Hashtags string
+}
+
+func parseTemplate(body string) (*template.Template, error) {
+	tmpl, err := template.New("post").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// hashtags turns tags into "#PemiluDamai #Jakarta"
+func hashtags(tags []string) string {
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		var b strings.Builder
+		for _, word := range strings.FieldsFunc(tag, func(r rune) bool { return r == ' ' || r == '-' || r == '_' }) {
+			r, size := utf8.DecodeRuneInString(word)
+			b.WriteString(strings.ToUpper(string(r)) + word[size:])
+		}
+		if b.Len() > 0 {
+			out = append(out, "#"+b.String())
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+func render(tmpl *template.Template, data templateData) (string, error) {
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+type PostStatus string
+
+const (
+	PostScheduled PostStatus = "scheduled"
+	PostSent      PostStatus = "sent"
+	PostFailed    PostStatus = "failed"
+	PostCancelled PostStatus = "cancelled"
+)
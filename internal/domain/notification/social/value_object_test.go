@@ -0,0 +1,45 @@
+package social
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestTagURL(t *testing.T) {
+	got, err := TagURL("https://example.com/news/a1?utm_campaign=pemilu&ref=home", ChannelX, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u, _ := url.Parse(got)
+	q := u.Query()
+	if q.Get("utm_source") != "twitter" || q.Get("utm_medium") != "social" || q.Get("utm_campaign") != "pemilu" || q.Get("ref") != "home" {
+		t.Errorf("unexpected tagged URL %s", got)
+	}
+
+	if _, err := TagURL("/news/a1", ChannelX, ""); err == nil {
+		t.Error("expected a relative URL to be rejected")
+	}
+}
+
+func TestChannel_CheckLength(t *testing.T) {
+	link := "https://example.com/" + strings.Repeat("a", 200)
+
+	// X counts the long link as 23 characters
+	if err := ChannelX.CheckLength(strings.Repeat("x", 250)+" "+link, link); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ChannelX.CheckLength(strings.Repeat("x", 260)+" "+link, link); !errors.Is(err, ErrTooLong) {
+		t.Errorf("expected error '%v', got '%v'", ErrTooLong, err)
+	}
+	if err := ChannelTelegram.CheckLength(strings.Repeat("x", 4000)+" "+link, link); !errors.Is(err, ErrTooLong) {
+		t.Errorf("expected error '%v', got '%v'", ErrTooLong, err)
+	}
+}
+
+func TestHashtags(t *testing.T) {
+	if got := hashtags([]string{"pemilu damai", "jakarta", "hari-raya", " "}); got != "#PemiluDamai #Jakarta #HariRaya" {
+		t.Errorf("unexpected hashtags %q", got)
+	}
+}
@@ -0,0 +1,105 @@
+package suppression
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type Reason string
+
+const (
+	ReasonHardBounce Reason = "hard_bounce" // Reported by the provider, the mailbox does not exist
+	ReasonComplaint  Reason = "complaint"   // Recipient marked a message as spam
+	ReasonManual     Reason = "manual"      // Added by staff, e.g. on a removal request
+)
+
+// Domain errors
+var (
+	ErrInvalidReason = errors.New("invalid suppression reason")
+	ErrCannotLift    = errors.New("complaint suppressions cannot be lifted")
+
+	// ErrRecipientSuppressed is returned by guarded senders instead of delivering;
+	// jobs count it as skipped, not as a failure to retry
+	ErrRecipientSuppressed = errors.New("recipient is on the suppression list")
+)
+
+// Entry blocks every email to one address, whatever the send path
+type Entry struct {
+	Email  account.Email
+	Reason Reason
+	Source string // Provider name for callbacks, staff ID for manual entries
+	Detail string // Provider diagnostic or staff note
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewEntry(email account.Email, reason Reason, source, detail string, at time.Time) (*Entry, error) {
+	if err := validateReason(reason); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(source) == "" {
+		return nil, errors.New("source cannot be empty")
+	}
+
+	return &Entry{
+		Email:     email,
+		Reason:    reason,
+		Source:    strings.TrimSpace(source),
+		Detail:    strings.TrimSpace(detail),
+		CreatedAt: at,
+		UpdatedAt: at,
+	}, nil
+}
+
+// Business Methods
+
+// Escalate records a stronger reason for an address that is already suppressed,
+// a complaint outranks a bounce which outranks a manual entry
+func (e *Entry) Escalate(reason Reason, source, detail string, at time.Time) (bool, error) {
+	if err := validateReason(reason); err != nil {
+		return false, err
+	}
+	if severity(reason) <= severity(e.Reason) {
+		return false, nil
+	}
+	e.Reason = reason
+	e.Source = strings.TrimSpace(source)
+	e.Detail = strings.TrimSpace(detail)
+	e.UpdatedAt = at
+	return true, nil
+}
+
+// Query Methods
+
+// CanLift reports whether staff may remove the entry. Complaints stay,
+// mailing someone who reported spam again risks the sending reputation.
+func (e *Entry) CanLift() bool {
+	return e.Reason != ReasonComplaint
+}
+
+// Domain Validation Functions
+
+func validateReason(reason Reason) error {
+	switch reason {
+	case ReasonHardBounce, ReasonComplaint, ReasonManual:
+		return nil
+	default:
+		return ErrInvalidReason
+	}
+}
+
+func severity(reason Reason) int {
+	switch reason {
+	case ReasonComplaint:
+		return 3
+	case ReasonHardBounce:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,64 @@
+package suppression
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func createTestEmail(t *testing.T) account.Email {
+	email, err := account.NewEmail("Reader@Example.com")
+	if err != nil {
+		t.Fatalf("failed to create email: %v", err)
+	}
+	return *email
+}
+
+func TestNewEntry(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		reason      Reason
+		source      string
+		expectedErr error
+	}{
+		{"hard bounce", ReasonHardBounce, "postmark", nil},
+		{"manual", ReasonManual, "staff-1", nil},
+		{"invalid reason", Reason("soft_bounce"), "postmark", ErrInvalidReason},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry, err := NewEntry(createTestEmail(t), tc.reason, tc.source, "", at)
+			if err != tc.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err == nil && entry.Email.String() != "reader@example.com" {
+				t.Errorf("expected normalized email, got %s", entry.Email.String())
+			}
+		})
+	}
+
+	if _, err := NewEntry(createTestEmail(t), ReasonManual, " ", "", at); err == nil {
+		t.Error("expected error but got none")
+	}
+}
+
+func TestEntry_Escalate(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	entry, _ := NewEntry(createTestEmail(t), ReasonManual, "staff-1", "removal request", at)
+
+	changed, err := entry.Escalate(ReasonComplaint, "postmark", "spam report", at.Add(time.Hour))
+	if err != nil || !changed {
+		t.Fatalf("expected escalation to complaint, got %v %v", changed, err)
+	}
+	if entry.CanLift() {
+		t.Error("expected complaint to be permanent")
+	}
+
+	changed, _ = entry.Escalate(ReasonHardBounce, "postmark", "550 no such user", at.Add(2*time.Hour))
+	if changed || entry.Reason != ReasonComplaint || entry.Source != "postmark" {
+		t.Errorf("expected complaint to be kept, got %s from %s", entry.Reason, entry.Source)
+	}
+}
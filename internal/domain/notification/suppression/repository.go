@@ -0,0 +1,22 @@
+package suppression
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type Repository interface {
+	// Commands
+	Save(ctx context.Context, entry *Entry) error // Insert or replace by email
+	Delete(ctx context.Context, email account.Email) error
+
+	// Queries
+	FindByEmail(ctx context.Context, email account.Email) (*Entry, error) // nil when not suppressed
+	FindAll(ctx context.Context, reason *Reason, limit, offset int) ([]*Entry, int64, error)
+}
+
+// Checker is the hook every email send path consults before handing a message to the provider
+type Checker interface {
+	IsSuppressed(ctx context.Context, email account.Email) (bool, error)
+}
@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Entry is one audited action. Entries are append-only and each one carries
+// the hash of its predecessor, so changing, removing or reordering any entry
+// breaks every hash after it.
+type Entry struct {
+	Seq        uint64 // 1-based and gapless
+	ID         string
+	ActorID    string // Staff member or "system" for jobs
+	Action     string // e.g. "article.publish", "account.role_change"
+	TargetType string
+	TargetID   string
+	IP         string
+	Details    map[string]string
+	Changes    []Change // Fields the action changed on the target, see Diff
+	At         time.Time
+
+	PrevHash string
+	Hash     string
+}
+
+// NewEntry appends to the chain after prev, nil for the first entry
+func NewEntry(prev *Entry, id, actorID, action, targetType, targetID, ip string, details map[string]string, changes []Change, at time.Time) (*Entry, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(actorID) == "" {
+		return nil, errors.New("actor ID cannot be empty")
+	}
+	if strings.TrimSpace(action) == "" {
+		return nil, errors.New("action cannot be empty")
+	}
+
+	e := &Entry{
+		Seq:        1,
+		ID:         id,
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IP:         ip,
+		Details:    details,
+		Changes:    changes,
+		At:         at.UTC().Truncate(Precision),
+		PrevHash:   GenesisHash,
+	}
+	if prev != nil {
+		e.Seq = prev.Seq + 1
+		e.PrevHash = prev.Hash
+	}
+	e.Hash = chainHash(e.PrevHash, contentHash(e))
+	return e, nil
+}
+
+// Query Methods
+
+// Intact reports whether the entry's content still matches its hash
+func (e *Entry) Intact() bool {
+	return e.Hash == chainHash(e.PrevHash, contentHash(e))
+}
+
+// Anchor is a chain head copied to storage outside the database. Rewriting
+// the whole chain from some point on, hashes included, is only detectable
+// against a head the attacker could not also rewrite.
+type Anchor struct {
+	Seq        uint64
+	Hash       string
+	Location   string // Where the external copy lives, e.g. an object key
+	AnchoredAt time.Time
+}
+
+// Verifier checks entries in sequence order, starting after a known
+// predecessor. Feed it the whole log or a range with the entry before it.
+type Verifier struct {
+	nextSeq  uint64
+	prevHash string
+	anchors  map[uint64]string
+	checked  int
+}
+
+// NewVerifier starts at the first entry; anchors are checked as their entries pass
+func NewVerifier(anchors []Anchor) *Verifier {
+	return NewVerifierAfter(nil, anchors)
+}
+
+// NewVerifierAfter starts after prev, which the caller trusts, e.g. from an
+// earlier verification or an anchor
+func NewVerifierAfter(prev *Entry, anchors []Anchor) *Verifier {
+	v := &Verifier{nextSeq: 1, prevHash: GenesisHash, anchors: make(map[uint64]string, len(anchors))}
+	if prev != nil {
+		v.nextSeq, v.prevHash = prev.Seq+1, prev.Hash
+	}
+	for _, a := range anchors {
+		v.anchors[a.Seq] = a.Hash
+	}
+	return v
+}
+
+// Check verifies the next entry, errors name the sequence number that failed
+func (v *Verifier) Check(e *Entry) error {
+	switch {
+	case e.Seq != v.nextSeq:
+		return fmt.Errorf("%w: expected entry %d, found %d", ErrGap, v.nextSeq, e.Seq)
+	case e.PrevHash != v.prevHash:
+		return fmt.Errorf("%w at entry %d", ErrBrokenChain, e.Seq)
+	case !e.Intact():
+		return fmt.Errorf("%w at entry %d", ErrTampered, e.Seq)
+	}
+	if anchored, ok := v.anchors[e.Seq]; ok && anchored != e.Hash {
+		return fmt.Errorf("%w at entry %d", ErrAnchorMismatch, e.Seq)
+	}
+	v.nextSeq, v.prevHash = e.Seq+1, e.Hash
+	v.checked++
+	return nil
+}
+
+// Checked is how many entries passed
+func (v *Verifier) Checked() int {
+	return v.checked
+}
+
+// Finish reports anchors beyond the verified head: the log lost anchored
+// entries at its end, which no single entry check can see
+func (v *Verifier) Finish() error {
+	for seq := range v.anchors {
+		if seq >= v.nextSeq {
+			return fmt.Errorf("%w: anchored entry %d is missing", ErrAnchorMismatch, seq)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func testChain(t *testing.T, n int) []*Entry {
+	t.Helper()
+	at := time.Date(2025, 3, 1, 10, 0, 0, 123456789, time.UTC)
+	var chain []*Entry
+	var prev *Entry
+	for i := 0; i < n; i++ {
+		var changes []Change
+		if i == 1 {
+			changes = Diff(map[string]string{"status": "draft"}, map[string]string{"status": "published"})
+		}
+		e, err := NewEntry(prev, fmt.Sprintf("e%d", i+1), "editor-1", "article.publish", "article", fmt.Sprintf("a%d", i), "198.51.100.7",
+			map[string]string{"title": "Flood", "section": "metro"}, changes, at.Add(time.Duration(i)*time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		chain = append(chain, e)
+		prev = e
+	}
+	return chain
+}
+
+func verify(chain []*Entry, anchors []Anchor) error {
+	v := NewVerifier(anchors)
+	for _, e := range chain {
+		if err := v.Check(e); err != nil {
+			return err
+		}
+	}
+	return v.Finish()
+}
+
+func TestNewEntry(t *testing.T) {
+	chain := testChain(t, 2)
+	if chain[0].Seq != 1 || chain[0].PrevHash != GenesisHash || chain[1].Seq != 2 || chain[1].PrevHash != chain[0].Hash {
+		t.Errorf("expected linked entries, got %+v %+v", chain[0], chain[1])
+	}
+	if chain[0].At.Nanosecond() != 123456000 {
+		t.Errorf("expected the timestamp truncated to microseconds, got %d", chain[0].At.Nanosecond())
+	}
+	if _, err := NewEntry(nil, "e1", "", "article.publish", "", "", "", nil, nil, time.Now()); err == nil {
+		t.Error("expected error for empty actor")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := map[string]string{"status": "active", "is_verified": "false", "deleted_at": ""}
+	after := map[string]string{"status": "disabled", "is_verified": "false", "reason": "spam"}
+
+	changes := Diff(before, after)
+	expected := []Change{{Field: "reason", Before: "", After: "spam"}, {Field: "status", Before: "active", After: "disabled"}}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], changes[i])
+		}
+	}
+	if Diff(before, before) != nil {
+		t.Error("expected no changes between equal snapshots")
+	}
+}
+
+func TestVerifier(t *testing.T) {
+	testCases := []struct {
+		name          string
+		tamper        func(chain []*Entry) []*Entry
+		anchors       func(chain []*Entry) []Anchor
+		expectedError error
+	}{
+		{"intact", func(c []*Entry) []*Entry { return c }, nil, nil},
+		{"edited detail", func(c []*Entry) []*Entry {
+			c[2].Details = map[string]string{"title": "Rewritten", "section": "metro"}
+			return c
+		}, nil, ErrTampered},
+		{"edited change", func(c []*Entry) []*Entry {
+			c[1].Changes[0].Before = "scheduled"
+			return c
+		}, nil, ErrTampered},
+		{"dropped changes", func(c []*Entry) []*Entry {
+			c[1].Changes = nil
+			return c
+		}, nil, ErrTampered},
+		{"edited and rehashed", func(c []*Entry) []*Entry {
+			c[2].ActorID = "intruder"
+			c[2].Hash = chainHash(c[2].PrevHash, contentHash(c[2]))
+			return c
+		}, nil, ErrBrokenChain},
+		{"removed entry", func(c []*Entry) []*Entry { return append(c[:2], c[3:]...) }, nil, ErrGap},
+		{"swapped entries", func(c []*Entry) []*Entry {
+			c[1], c[2] = c[2], c[1]
+			return c
+		}, nil, ErrGap},
+		{"rewritten after anchor", func(c []*Entry) []*Entry {
+			// A full rewrite from entry 3 on keeps the chain consistent, only the anchor notices
+			prev := c[1]
+			for i := 2; i < len(c); i++ {
+				e, _ := NewEntry(prev, c[i].ID, "intruder", c[i].Action, c[i].TargetType, c[i].TargetID, c[i].IP, c[i].Details, c[i].Changes, c[i].At)
+				c[i], prev = e, e
+			}
+			return c
+		}, func(c []*Entry) []Anchor { return []Anchor{{Seq: 4, Hash: c[3].Hash}} }, ErrAnchorMismatch},
+		{"truncated after anchor", func(c []*Entry) []*Entry { return c[:3] }, func(c []*Entry) []Anchor {
+			return []Anchor{{Seq: 5, Hash: c[4].Hash}}
+		}, ErrAnchorMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chain := testChain(t, 5)
+			var anchors []Anchor
+			if tc.anchors != nil {
+				anchors = tc.anchors(chain)
+			}
+			err := verify(tc.tamper(chain), anchors)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestVerifierAfter(t *testing.T) {
+	chain := testChain(t, 5)
+	v := NewVerifierAfter(chain[2], nil)
+	for _, e := range chain[3:] {
+		if err := v.Check(e); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if v.Checked() != 2 {
+		t.Errorf("expected 2 entries checked, got %d", v.Checked())
+	}
+}
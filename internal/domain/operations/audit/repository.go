@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+type EntryRepository interface {
+	// Append stores the entry, ErrSequenceTaken when another writer got its sequence number first
+	Append(ctx context.Context, entry *Entry) error
+
+	// Head returns the latest entry, nil for an empty log
+	Head(ctx context.Context) (*Entry, error)
+
+	// FindBySeq returns nil when the entry does not exist
+	FindBySeq(ctx context.Context, seq uint64) (*Entry, error)
+
+	// Range returns up to limit entries from fromSeq on, in sequence order
+	Range(ctx context.Context, fromSeq uint64, limit int) ([]*Entry, error)
+
+	// FindByTarget returns up to limit entries about the target before beforeSeq, newest first.
+	// A beforeSeq of 0 starts at the head.
+	FindByTarget(ctx context.Context, targetType, targetID string, beforeSeq uint64, limit int) ([]*Entry, error)
+
+	// FindByActor returns up to limit entries by the actor before beforeSeq, newest first
+	FindByActor(ctx context.Context, actorID string, beforeSeq uint64, limit int) ([]*Entry, error)
+}
+
+// Trail is how application services record the administrative actions they
+// perform (implemented by the audit service)
+type Trail interface {
+	Emit(ctx context.Context, event Event, at time.Time) error
+}
+
+type AnchorRepository interface {
+	Save(ctx context.Context, anchor *Anchor) error
+	FindAll(ctx context.Context) ([]*Anchor, error)
+}
+
+// Domain interface for write-once storage outside the database (implementation will be in
+// infrastructure layer, e.g. a bucket with object lock)
+type AnchorStore interface {
+	// Put stores the anchor and returns its location, it never overwrites
+	Put(ctx context.Context, anchor *Anchor) (string, error)
+	Get(ctx context.Context, location string) (*Anchor, error)
+}
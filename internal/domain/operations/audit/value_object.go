@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Domain errors
+var (
+	ErrSequenceTaken  = errors.New("audit sequence number already taken")
+	ErrGap            = errors.New("audit log has a gap")
+	ErrTampered       = errors.New("audit entry content does not match its hash")
+	ErrBrokenChain    = errors.New("audit entry does not link to the previous entry")
+	ErrAnchorMismatch = errors.New("audit log does not match an anchored head")
+)
+
+// Event is an action to audit, application services emit it through a Trail
+type Event struct {
+	ActorID    string
+	Action     string
+	TargetType string
+	TargetID   string
+	IP         string // Taken from the context when empty, see WithIP
+	Details    map[string]string
+	Changes    []Change
+}
+
+// Change is one field of the target before and after the action
+type Change struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// Diff lists the fields whose values differ between two snapshots of a
+// target, by field name. A field missing from one side counts as "".
+func Diff(before, after map[string]string) []Change {
+	fields := make(map[string]struct{}, len(before)+len(after))
+	for k := range before {
+		fields[k] = struct{}{}
+	}
+	for k := range after {
+		fields[k] = struct{}{}
+	}
+	var changes []Change
+	for k := range fields {
+		if before[k] != after[k] {
+			changes = append(changes, Change{Field: k, Before: before[k], After: after[k]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+type ipContextKey struct{}
+
+// WithIP carries the client IP of a request down to the services that audit it
+func WithIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ipContextKey{}, ip)
+}
+
+// IPFromContext returns the client IP set by WithIP, "" for jobs and other
+// work outside a request
+func IPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(ipContextKey{}).(string)
+	return ip
+}
+
+// GenesisHash is the previous hash of the first entry
+var GenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// Precision is what the database keeps of timestamps, entries are truncated
+// to it before hashing so a stored entry hashes the same when read back
+const Precision = time.Microsecond
+
+// contentHash hashes the entry's fields length-prefixed, so no two different
+// entries can produce the same byte stream
+func contentHash(e *Entry) string {
+	h := sha256.New()
+	number := func(n uint64) {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], n)
+		h.Write(b[:])
+	}
+	field := func(s string) {
+		number(uint64(len(s)))
+		h.Write([]byte(s))
+	}
+
+	number(e.Seq)
+	field(e.ID)
+	field(e.ActorID)
+	field(e.Action)
+	field(e.TargetType)
+	field(e.TargetID)
+	field(e.IP)
+	field(e.At.UTC().Format(time.RFC3339Nano))
+
+	keys := make([]string, 0, len(e.Details))
+	for k := range e.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	number(uint64(len(keys)))
+	for _, k := range keys {
+		field(k)
+		field(e.Details[k])
+	}
+
+	// Entries from before changes were recorded hash as they always did
+	if len(e.Changes) > 0 {
+		number(uint64(len(e.Changes)))
+		for _, c := range e.Changes {
+			field(c.Field)
+			field(c.Before)
+			field(c.After)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// chainHash links an entry's content to its predecessor
+func chainHash(prevHash, content string) string {
+	sum := sha256.Sum256([]byte(prevHash + content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,119 @@
+package crm
+
+import (
+	"strings"
+	"time"
+)
+
+type ChangeStatus string
+
+const (
+	ChangePending ChangeStatus = "pending"
+	ChangeSynced  ChangeStatus = "synced"
+	ChangeFailed  ChangeStatus = "failed"
+)
+
+// Change is one lifecycle event waiting to reach the CRM. The sync always
+// sends the account's current record, so several pending changes of one
+// account go out as a single contact.
+type Change struct {
+	ID            string
+	AccountID     string
+	Event         Event
+	Status        ChangeStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	CreatedAt     time.Time
+	SyncedAt      *time.Time
+}
+
+func NewChange(id, accountID string, event Event, at time.Time) (*Change, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return nil, ErrEmptyAccountID
+	}
+	if !event.Valid() {
+		return nil, ErrUnknownEvent
+	}
+	return &Change{ID: id, AccountID: accountID, Event: event, Status: ChangePending, NextAttemptAt: at, CreatedAt: at}, nil
+}
+
+// Business Methods
+
+func (c *Change) MarkSynced(at time.Time) {
+	c.Status = ChangeSynced
+	c.Attempts++
+	c.LastError = nil
+	c.SyncedAt = &at
+}
+
+// MarkFailed records a rejected sync, the change is retried with a growing
+// delay until it runs out of attempts
+func (c *Change) MarkFailed(reason string, at time.Time) {
+	c.Attempts++
+	c.LastError = &reason
+	if c.Attempts >= MaxAttempts {
+		c.Status = ChangeFailed
+		return
+	}
+	c.NextAttemptAt = at.Add(time.Duration(c.Attempts) * RetryBackoff)
+}
+
+// Defer pushes the change back without using an attempt, for rate limits
+func (c *Change) Defer(until time.Time) {
+	c.NextAttemptAt = until
+}
+
+// Query Methods
+
+func (c *Change) Due(at time.Time) bool {
+	return c.Status == ChangePending && !c.NextAttemptAt.After(at)
+}
+
+// AccountDrift lists the properties of one contact the CMS will overwrite
+type AccountDrift struct {
+	AccountID string
+	Drifts    []Drift
+}
+
+// Report is the outcome of comparing every CMS member with the CRM
+type Report struct {
+	ID           string
+	Provider     string
+	Checked      int
+	InSync       int
+	Drifted      []AccountDrift
+	MissingInCRM []string // Members with no CRM contact
+	UnknownInCRM []string // CRM contacts whose account no longer exists
+	Queued       int      // Changes queued to repair the differences
+	Error        *string
+	StartedAt    time.Time
+	FinishedAt   time.Time
+}
+
+func NewReport(id, provider string, at time.Time) (*Report, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	return &Report{ID: id, Provider: provider, StartedAt: at}, nil
+}
+
+func (r *Report) Finish(at time.Time) {
+	r.FinishedAt = at
+}
+
+// Fail keeps what was compared so far, a partial report still shows drift
+func (r *Report) Fail(err error, at time.Time) {
+	reason := err.Error()
+	r.Error = &reason
+	r.FinishedAt = at
+}
+
+// Query Methods
+
+func (r *Report) Differences() int {
+	return len(r.Drifted) + len(r.MissingInCRM) + len(r.UnknownInCRM)
+}
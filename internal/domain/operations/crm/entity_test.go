@@ -0,0 +1,70 @@
+package crm
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewChange(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	c, err := NewChange("c1", "account-1", EventSubscriptionStarted, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Status != ChangePending || !c.Due(at) {
+		t.Errorf("expected a pending change due now, got %+v", c)
+	}
+	if _, err := NewChange("c1", "account-1", "renewed", at); err != ErrUnknownEvent {
+		t.Errorf("expected ErrUnknownEvent, got %v", err)
+	}
+	if _, err := NewChange("c1", " ", EventAccountUpdated, at); err == nil {
+		t.Error("expected error for an empty account")
+	}
+}
+
+func TestChange_Retries(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	c, _ := NewChange("c1", "account-1", EventAccountUpdated, at)
+
+	c.Defer(at.Add(time.Minute))
+	if c.Due(at) || c.Attempts != 0 {
+		t.Errorf("expected a deferred change to keep its attempts, got %+v", c)
+	}
+
+	for i := 1; i < MaxAttempts; i++ {
+		c.MarkFailed("invalid email", at)
+		if c.Status != ChangePending || !c.NextAttemptAt.Equal(at.Add(time.Duration(i)*RetryBackoff)) {
+			t.Fatalf("attempt %d: expected a growing backoff, got %+v", i, c)
+		}
+	}
+	c.MarkFailed("invalid email", at)
+	if c.Status != ChangeFailed || c.Due(at.Add(24*time.Hour)) {
+		t.Errorf("expected the change to give up, got %+v", c)
+	}
+
+	synced, _ := NewChange("c2", "account-1", EventAccountUpdated, at)
+	synced.MarkFailed("timeout", at)
+	synced.MarkSynced(at.Add(RetryBackoff))
+	if synced.Status != ChangeSynced || synced.LastError != nil || synced.Attempts != 2 {
+		t.Errorf("unexpected synced change %+v", synced)
+	}
+}
+
+func TestReport(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	r, err := NewReport("r1", "hubspot", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Drifted = []AccountDrift{{AccountID: "a"}}
+	r.MissingInCRM = []string{"b"}
+	r.Fail(errors.New("CRM unavailable"), at.Add(time.Minute))
+	if r.Differences() != 2 || r.Error == nil || !r.FinishedAt.Equal(at.Add(time.Minute)) {
+		t.Errorf("unexpected report %+v", r)
+	}
+	if _, err := NewReport("", "hubspot", at); err == nil {
+		t.Error("expected error for an empty ID")
+	}
+}
@@ -0,0 +1,47 @@
+package crm
+
+import (
+	"context"
+	"time"
+)
+
+// Provider talks to one CRM such as HubSpot or Salesforce (implementations will be in infrastructure layer).
+// Any call may return a *RateLimitError when the CRM asks to slow down.
+type Provider interface {
+	Name() string
+
+	// MaxBatch is the most contacts one Upsert, Delete or Fetch call takes
+	MaxBatch() int
+
+	// Upsert creates or updates contacts matched on KeyProperty. Contacts the
+	// CRM rejected come back keyed by account ID, the rest were written.
+	Upsert(ctx context.Context, contacts []Contact) (map[string]error, error)
+	Delete(ctx context.Context, keys []string) error
+	Fetch(ctx context.Context, keys []string) (map[string]Contact, error)
+
+	// Scan pages through every contact that has a KeyProperty, an empty cursor ends the scan
+	Scan(ctx context.Context, cursor string, limit int) ([]Contact, string, error)
+}
+
+// Domain interface for reading members (implementation will be in infrastructure layer)
+type RecordSource interface {
+	// FindRecords returns the members that exist, deleted accounts are left out
+	FindRecords(ctx context.Context, accountIDs []string) (map[string]Record, error)
+	// ScanRecords pages by account ID after the given cursor
+	ScanRecords(ctx context.Context, afterAccountID string, limit int) ([]Record, error)
+}
+
+type ChangeRepository interface {
+	// Commands
+	Create(ctx context.Context, change *Change) error
+	Update(ctx context.Context, change *Change) error
+
+	// Queries
+	// FindDue returns pending changes due at the time, oldest first
+	FindDue(ctx context.Context, at time.Time, limit int) ([]*Change, error)
+}
+
+type ReportRepository interface {
+	Save(ctx context.Context, report *Report) error
+	FindRecent(ctx context.Context, limit int) ([]*Report, error)
+}
@@ -0,0 +1,270 @@
+package crm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Sync retries, a change that fails MaxAttempts times stays failed until the
+// next reconciliation queues the account again
+const (
+	MaxAttempts  = 5
+	RetryBackoff = 10 * time.Minute
+)
+
+// KeyProperty holds the CMS account ID on every CRM contact, both sides are matched on it
+const KeyProperty = "cms_account_id"
+
+// Domain errors
+var (
+	ErrEmptyID           = shared.NewDomainError("crm.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrEmptyAccountID    = shared.NewDomainError("crm.account_required", shared.KindValidation, "account ID cannot be empty")
+	ErrUnknownEvent      = shared.NewDomainError("crm.unknown_event", shared.KindValidation, "unknown lifecycle event")
+	ErrUnknownField      = shared.NewDomainError("crm.unknown_field", shared.KindValidation, "unknown CMS field")
+	ErrEmptyProperty     = shared.NewDomainError("crm.property_required", shared.KindValidation, "CRM property name cannot be empty")
+	ErrDuplicateProperty = shared.NewDomainError("crm.duplicate_property", shared.KindValidation, "CRM property is mapped more than once")
+	ErrReservedProperty  = shared.NewDomainError("crm.reserved_property", shared.KindValidation, "CRM property is reserved for the account key")
+	ErrMissingEmail      = shared.NewDomainError("crm.email_required", shared.KindValidation, "mapping must include the email field")
+	ErrRateLimited       = shared.NewDomainError("crm.rate_limited", shared.KindRateLimited, "CRM rate limit reached")
+)
+
+// Event is a membership lifecycle change that makes an account sync
+type Event string
+
+const (
+	EventAccountRegistered   Event = "account_registered"
+	EventAccountUpdated      Event = "account_updated"
+	EventAccountDeactivated  Event = "account_deactivated"
+	EventAccountDeleted      Event = "account_deleted"
+	EventSubscriptionStarted Event = "subscription_started"
+	EventSubscriptionChanged Event = "subscription_changed"
+	EventSubscriptionEnded   Event = "subscription_ended"
+	EventReconciled          Event = "reconciled" // Queued by reconciliation to repair drift
+)
+
+func (e Event) Valid() bool {
+	switch e {
+	case EventAccountRegistered, EventAccountUpdated, EventAccountDeactivated, EventAccountDeleted,
+		EventSubscriptionStarted, EventSubscriptionChanged, EventSubscriptionEnded, EventReconciled:
+		return true
+	}
+	return false
+}
+
+// Field is a member attribute the CMS can send to the CRM
+type Field string
+
+const (
+	FieldEmail              Field = "email"
+	FieldUsername           Field = "username"
+	FieldFullName           Field = "full_name"
+	FieldAccountStatus      Field = "account_status"
+	FieldSignedUpAt         Field = "signed_up_at"
+	FieldSubscriptionStatus Field = "subscription_status"
+	FieldPlan               Field = "plan"
+	FieldPeriodEnd          Field = "current_period_end"
+	FieldChurnRisk          Field = "churn_risk"
+)
+
+// Fields lists every field that can be mapped
+var Fields = []Field{
+	FieldEmail, FieldUsername, FieldFullName, FieldAccountStatus, FieldSignedUpAt,
+	FieldSubscriptionStatus, FieldPlan, FieldPeriodEnd, FieldChurnRisk,
+}
+
+func (f Field) Valid() bool {
+	for _, known := range Fields {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Record is the CMS side of a member, what the CRM contact should look like
+type Record struct {
+	AccountID          string
+	Email              string
+	Username           string
+	FullName           string
+	AccountStatus      string
+	SignedUpAt         time.Time
+	SubscriptionStatus string // Empty for free members
+	Plan               string
+	PeriodEnd          *time.Time
+	ChurnRisk          string // Empty before the first score
+}
+
+// Value renders a field the way it is stored in the CRM, dates as YYYY-MM-DD
+func (r Record) Value(f Field) string {
+	switch f {
+	case FieldEmail:
+		return r.Email
+	case FieldUsername:
+		return r.Username
+	case FieldFullName:
+		return r.FullName
+	case FieldAccountStatus:
+		return r.AccountStatus
+	case FieldSignedUpAt:
+		return r.SignedUpAt.UTC().Format(time.DateOnly)
+	case FieldSubscriptionStatus:
+		return r.SubscriptionStatus
+	case FieldPlan:
+		return r.Plan
+	case FieldPeriodEnd:
+		if r.PeriodEnd == nil {
+			return ""
+		}
+		return r.PeriodEnd.UTC().Format(time.DateOnly)
+	case FieldChurnRisk:
+		return r.ChurnRisk
+	}
+	return ""
+}
+
+// Contact is the CRM side of a member
+type Contact struct {
+	Key        string // CMS account ID, stored in KeyProperty
+	Properties map[string]string
+}
+
+// Mapping value object, the CRM property each CMS field is written to.
+// Fields left out are not synced.
+type Mapping struct {
+	properties map[Field]string
+}
+
+func NewMapping(properties map[Field]string) (*Mapping, error) {
+	if _, ok := properties[FieldEmail]; !ok {
+		return nil, ErrMissingEmail
+	}
+	seen := make(map[string]Field, len(properties))
+	clean := make(map[Field]string, len(properties))
+	for field, property := range properties {
+		if !field.Valid() {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownField, field)
+		}
+		property = strings.TrimSpace(property)
+		if property == "" {
+			return nil, fmt.Errorf("%w: %s", ErrEmptyProperty, field)
+		}
+		if property == KeyProperty {
+			return nil, ErrReservedProperty
+		}
+		if other, ok := seen[property]; ok {
+			return nil, fmt.Errorf("%w: %s is used by %s and %s", ErrDuplicateProperty, property, other, field)
+		}
+		seen[property] = field
+		clean[field] = property
+	}
+	return &Mapping{properties: clean}, nil
+}
+
+// DefaultHubSpotMapping writes to HubSpot's email property and cms_ custom properties
+func DefaultHubSpotMapping() *Mapping {
+	m, _ := NewMapping(map[Field]string{
+		FieldEmail:              "email",
+		FieldUsername:           "cms_username",
+		FieldFullName:           "cms_full_name",
+		FieldAccountStatus:      "cms_account_status",
+		FieldSignedUpAt:         "cms_signed_up_at",
+		FieldSubscriptionStatus: "cms_subscription_status",
+		FieldPlan:               "cms_plan",
+		FieldPeriodEnd:          "cms_period_end",
+		FieldChurnRisk:          "cms_churn_risk",
+	})
+	return m
+}
+
+// DefaultSalesforceMapping writes to the Contact Email field and CMS_ custom fields
+func DefaultSalesforceMapping() *Mapping {
+	m, _ := NewMapping(map[Field]string{
+		FieldEmail:              "Email",
+		FieldUsername:           "CMS_Username__c",
+		FieldFullName:           "CMS_Full_Name__c",
+		FieldAccountStatus:      "CMS_Account_Status__c",
+		FieldSignedUpAt:         "CMS_Signed_Up_At__c",
+		FieldSubscriptionStatus: "CMS_Subscription_Status__c",
+		FieldPlan:               "CMS_Plan__c",
+		FieldPeriodEnd:          "CMS_Period_End__c",
+		FieldChurnRisk:          "CMS_Churn_Risk__c",
+	})
+	return m
+}
+
+// LoadMapping reads a JSON object of CMS field to CRM property, e.g. {"email": "email"}
+func LoadMapping(r io.Reader) (*Mapping, error) {
+	var properties map[Field]string
+	if err := json.NewDecoder(r).Decode(&properties); err != nil {
+		return nil, fmt.Errorf("failed to parse CRM field mapping: %w", err)
+	}
+	return NewMapping(properties)
+}
+
+// Fields returns the mapped fields in the order of Fields
+func (m *Mapping) Fields() []Field {
+	fields := make([]Field, 0, len(m.properties))
+	for _, f := range Fields {
+		if _, ok := m.properties[f]; ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func (m *Mapping) Property(f Field) (string, bool) {
+	property, ok := m.properties[f]
+	return property, ok
+}
+
+// Contact renders the record as the CRM contact it should be
+func (m *Mapping) Contact(r Record) Contact {
+	properties := make(map[string]string, len(m.properties)+1)
+	for field, property := range m.properties {
+		properties[property] = r.Value(field)
+	}
+	properties[KeyProperty] = r.AccountID
+	return Contact{Key: r.AccountID, Properties: properties}
+}
+
+// Drift is a mapped property where the CRM disagrees with the CMS
+type Drift struct {
+	Property string
+	CMS      string
+	CRM      string
+}
+
+// Compare applies the conflict policy: the CMS is the source of truth for
+// every property it maps, so each difference is overwritten on the next sync.
+// Properties the mapping does not cover belong to the CRM and are ignored.
+func Compare(want, have Contact) []Drift {
+	var drifts []Drift
+	for property, value := range want.Properties {
+		if current := have.Properties[property]; current != value {
+			drifts = append(drifts, Drift{Property: property, CMS: value, CRM: current})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Property < drifts[j].Property })
+	return drifts
+}
+
+// RateLimitError is returned by providers when the CRM asks to slow down
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrRateLimited, e.RetryAfter)
+}
+
+// Unwrap makes the error match ErrRateLimited and carry its kind
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
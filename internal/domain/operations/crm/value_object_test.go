@@ -0,0 +1,106 @@
+package crm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewMapping(t *testing.T) {
+	testCases := []struct {
+		name        string
+		properties  map[Field]string
+		expectedErr error
+	}{
+		{"valid", map[Field]string{FieldEmail: "email", FieldPlan: " plan "}, nil},
+		{"missing email", map[Field]string{FieldPlan: "plan"}, ErrMissingEmail},
+		{"unknown field", map[Field]string{FieldEmail: "email", "birthday": "dob"}, ErrUnknownField},
+		{"empty property", map[Field]string{FieldEmail: "email", FieldPlan: " "}, ErrEmptyProperty},
+		{"reserved property", map[Field]string{FieldEmail: "email", FieldPlan: KeyProperty}, ErrReservedProperty},
+		{"duplicate property", map[Field]string{FieldEmail: "email", FieldUsername: "email"}, ErrDuplicateProperty},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewMapping(tc.properties)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if property, _ := m.Property(FieldPlan); property != "plan" {
+				t.Errorf("expected trimmed property, got %q", property)
+			}
+		})
+	}
+}
+
+func TestDefaultMappings(t *testing.T) {
+	for name, m := range map[string]*Mapping{"hubspot": DefaultHubSpotMapping(), "salesforce": DefaultSalesforceMapping()} {
+		if m == nil || len(m.Fields()) != len(Fields) {
+			t.Errorf("expected %s to map every field", name)
+		}
+	}
+}
+
+func TestLoadMapping(t *testing.T) {
+	m, err := LoadMapping(strings.NewReader(`{"email": "Email", "churn_risk": "Risk__c"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fields := m.Fields(); len(fields) != 2 || fields[0] != FieldEmail || fields[1] != FieldChurnRisk {
+		t.Errorf("expected email and churn risk in field order, got %v", fields)
+	}
+	if _, err := LoadMapping(strings.NewReader(`{"email":`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestMapping_ContactAndCompare(t *testing.T) {
+	periodEnd := time.Date(2026, time.April, 1, 23, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+	record := Record{
+		AccountID:          "account-1",
+		Email:              "sari@example.com",
+		Username:           "sari",
+		AccountStatus:      "active",
+		SignedUpAt:         time.Date(2025, time.June, 2, 0, 0, 0, 0, time.UTC),
+		SubscriptionStatus: "active",
+		Plan:               "premium",
+		PeriodEnd:          &periodEnd,
+	}
+	m, _ := NewMapping(map[Field]string{FieldEmail: "email", FieldPlan: "cms_plan", FieldPeriodEnd: "cms_period_end", FieldChurnRisk: "cms_churn_risk"})
+
+	want := m.Contact(record)
+	if want.Key != "account-1" || want.Properties[KeyProperty] != "account-1" || len(want.Properties) != 5 {
+		t.Fatalf("unexpected contact %+v", want)
+	}
+	if want.Properties["cms_period_end"] != "2026-04-01" || want.Properties["cms_churn_risk"] != "" {
+		t.Errorf("expected dates in UTC and empty values kept, got %+v", want.Properties)
+	}
+
+	have := Contact{Key: "account-1", Properties: map[string]string{
+		KeyProperty:      "account-1",
+		"email":          "sari@example.com",
+		"cms_plan":       "basic",
+		"cms_period_end": "2026-04-01",
+		"cms_churn_risk": "high",     // Stale, the CMS clears it
+		"lifecycle":      "customer", // Owned by the CRM
+	}}
+	drifts := Compare(want, have)
+	if len(drifts) != 2 || drifts[0].Property != "cms_churn_risk" || drifts[1] != (Drift{Property: "cms_plan", CMS: "premium", CRM: "basic"}) {
+		t.Errorf("expected only mapped differences, got %+v", drifts)
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	var err error = &RateLimitError{RetryAfter: 10 * time.Second}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("expected a rate limit error to match ErrRateLimited")
+	}
+	var limited *RateLimitError
+	if !errors.As(err, &limited) || limited.RetryAfter != 10*time.Second {
+		t.Errorf("expected the retry delay, got %+v", limited)
+	}
+}
@@ -0,0 +1,149 @@
+package freshness
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DeskTarget is how many articles a desk is expected to publish per newsroom day
+type DeskTarget struct {
+	Desk        string
+	DailyTarget int
+	AlertedDay  *time.Time // Last day a missed target was alerted, so each day alerts once
+	UpdatedBy   string
+	UpdatedAt   time.Time
+}
+
+func NewDeskTarget(desk string, dailyTarget int, staffID string, at time.Time) (*DeskTarget, error) {
+	if err := ValidateDesk(desk); err != nil {
+		return nil, err
+	}
+	t := &DeskTarget{Desk: desk}
+	if err := t.SetTarget(dailyTarget, staffID, at); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Business Methods
+
+func (t *DeskTarget) SetTarget(dailyTarget int, staffID string, at time.Time) error {
+	if dailyTarget < 1 || dailyTarget > MaxDailyTarget {
+		return ErrInvalidTarget
+	}
+	t.DailyTarget = dailyTarget
+	t.UpdatedBy = staffID
+	t.UpdatedAt = at
+	return nil
+}
+
+func (t *DeskTarget) MarkAlerted(day time.Time) {
+	t.AlertedDay = &day
+}
+
+// Query Methods
+
+// NeedsAlert reports whether a missed target on the day has not been alerted yet.
+// A day the target was set or changed on is judged from the next day.
+func (t *DeskTarget) NeedsAlert(day time.Time) bool {
+	if t.UpdatedAt.After(day) {
+		return false
+	}
+	return t.AlertedDay == nil || t.AlertedDay.Before(day)
+}
+
+// Rail is a section front on the homepage, fed by the newest articles of its
+// categories and owned by one desk
+type Rail struct {
+	Slug        string
+	Name        string
+	Desk        string
+	CategoryIDs []string
+	MaxAge      time.Duration // The rail is stale when its newest article is older
+	AlertedAt   *time.Time    // Set while a stale alert is outstanding
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewRail(slug, name, desk string, categoryIDs []string, maxAge time.Duration, at time.Time) (*Rail, error) {
+	slug = strings.TrimSpace(slug)
+	if len(slug) > 50 || !slugRegex.MatchString(slug) {
+		return nil, ErrInvalidRailSlug
+	}
+	r := &Rail{Slug: slug, CreatedAt: at}
+	if err := r.Update(name, desk, categoryIDs, maxAge, at); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Business Methods
+
+// Update changes the rail, an outstanding alert is kept until fresh content arrives
+func (r *Rail) Update(name, desk string, categoryIDs []string, maxAge time.Duration, at time.Time) error {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return ErrInvalidRailName
+	}
+	if err := ValidateDesk(desk); err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(categoryIDs))
+	seen := map[string]bool{}
+	for _, id := range categoryIDs {
+		id = strings.TrimSpace(id)
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 || len(ids) > MaxRailCategories {
+		return ErrInvalidCategories
+	}
+	if maxAge < MinRailMaxAge || maxAge > MaxRailMaxAge {
+		return ErrInvalidMaxAge
+	}
+
+	r.Name = name
+	r.Desk = desk
+	r.CategoryIDs = ids
+	r.MaxAge = maxAge
+	r.UpdatedAt = at
+	return nil
+}
+
+func (r *Rail) MarkAlerted(at time.Time) {
+	r.AlertedAt = &at
+}
+
+// RecordFresh returns true when the rail recovers from an outstanding alert
+func (r *Rail) RecordFresh() bool {
+	recovered := r.AlertedAt != nil
+	r.AlertedAt = nil
+	return recovered
+}
+
+// Query Methods
+
+// StaleAt is when the rail goes stale without another article. A rail that
+// never had one counts from when it was set up.
+func (r *Rail) StaleAt(latest *time.Time) time.Time {
+	since := r.CreatedAt
+	if latest != nil && latest.After(since) {
+		since = *latest
+	}
+	return since.Add(r.MaxAge)
+}
+
+func (r *Rail) IsStale(latest *time.Time, at time.Time) bool {
+	return !at.Before(r.StaleAt(latest))
+}
+
+// NeedsAlert reports whether a stale alert should go out, repeating every realert while it stays stale
+func (r *Rail) NeedsAlert(latest *time.Time, at time.Time, realert time.Duration) bool {
+	if !r.IsStale(latest, at) {
+		return false
+	}
+	return r.AlertedAt == nil || at.Sub(*r.AlertedAt) >= realert
+}
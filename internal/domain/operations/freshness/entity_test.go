@@ -0,0 +1,112 @@
+package freshness
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wib returns a time on 10 March 2026 at the hour in Western Indonesia
+func wib(hour, minute int) time.Time {
+	return time.Date(2026, 3, 10, hour, minute, 0, 0, DefaultRules.Location)
+}
+
+func TestNewDeskTarget(t *testing.T) {
+	testCases := []struct {
+		name        string
+		desk        string
+		target      int
+		expectedErr error
+	}{
+		{"valid", "metro", 12, nil},
+		{"invalid desk", "Metro Desk", 12, ErrInvalidDesk},
+		{"zero target", "metro", 0, ErrInvalidTarget},
+		{"target too high", "metro", MaxDailyTarget + 1, ErrInvalidTarget},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewDeskTarget(tc.desk, tc.target, "s1", wib(9, 0))
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDeskTarget_NeedsAlert(t *testing.T) {
+	yesterday := DefaultRules.DayOf(wib(9, 0)).AddDate(0, 0, -1)
+	target, _ := NewDeskTarget("metro", 12, "s1", yesterday.Add(-time.Hour))
+
+	if !target.NeedsAlert(yesterday) {
+		t.Error("expected a missed day to be alerted")
+	}
+	target.MarkAlerted(yesterday)
+	if target.NeedsAlert(yesterday) {
+		t.Error("expected each day alerted once")
+	}
+
+	_ = target.SetTarget(20, "s1", wib(8, 0))
+	if target.NeedsAlert(DefaultRules.DayOf(wib(8, 0))) {
+		t.Error("expected the day the target changed on judged from the next day")
+	}
+}
+
+func TestNewRail(t *testing.T) {
+	testCases := []struct {
+		name        string
+		slug        string
+		rail        string
+		desk        string
+		categoryIDs []string
+		maxAge      time.Duration
+		expectedErr error
+	}{
+		{"valid", "sports", "Sports", "sports", []string{"c1", "c2"}, 4 * time.Hour, nil},
+		{"invalid slug", "Sports Rail", "Sports", "sports", []string{"c1"}, 4 * time.Hour, ErrInvalidRailSlug},
+		{"empty name", "sports", " ", "sports", []string{"c1"}, 4 * time.Hour, ErrInvalidRailName},
+		{"name too long", "sports", strings.Repeat("a", 101), "sports", []string{"c1"}, 4 * time.Hour, ErrInvalidRailName},
+		{"invalid desk", "sports", "Sports", "", []string{"c1"}, 4 * time.Hour, ErrInvalidDesk},
+		{"no categories", "sports", "Sports", "sports", []string{" "}, 4 * time.Hour, ErrInvalidCategories},
+		{"max age too short", "sports", "Sports", "sports", []string{"c1"}, 30 * time.Minute, ErrInvalidMaxAge},
+		{"max age too long", "sports", "Sports", "sports", []string{"c1"}, MaxRailMaxAge + time.Hour, ErrInvalidMaxAge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRail(tc.slug, tc.rail, tc.desk, tc.categoryIDs, tc.maxAge, wib(9, 0))
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRail_Staleness(t *testing.T) {
+	rail, _ := NewRail("metro", "Metro", "metro", []string{"c1", "c1"}, 3*time.Hour, wib(6, 0))
+	if len(rail.CategoryIDs) != 1 {
+		t.Errorf("expected duplicate categories dropped, got %v", rail.CategoryIDs)
+	}
+
+	if rail.IsStale(nil, wib(8, 59)) || !rail.IsStale(nil, wib(9, 0)) {
+		t.Error("expected a rail without articles stale MaxAge after it was set up")
+	}
+
+	latest := wib(10, 0)
+	if rail.IsStale(&latest, wib(12, 59)) {
+		t.Error("expected the rail fresh within MaxAge of its newest article")
+	}
+	if !rail.NeedsAlert(&latest, wib(13, 0), 6*time.Hour) {
+		t.Error("expected a stale rail alerted")
+	}
+
+	rail.MarkAlerted(wib(13, 0))
+	if rail.NeedsAlert(&latest, wib(18, 59), 6*time.Hour) || !rail.NeedsAlert(&latest, wib(19, 0), 6*time.Hour) {
+		t.Error("expected the alert repeated after realert")
+	}
+
+	if !rail.RecordFresh() || rail.RecordFresh() {
+		t.Error("expected recovery reported once")
+	}
+}
@@ -0,0 +1,37 @@
+package freshness
+
+import (
+	"context"
+	"time"
+)
+
+type DeskTargetRepository interface {
+	Save(ctx context.Context, target *DeskTarget) error
+	Delete(ctx context.Context, desk string) error
+
+	// FindByDesk returns nil for desks without a target
+	FindByDesk(ctx context.Context, desk string) (*DeskTarget, error)
+	FindAll(ctx context.Context) ([]*DeskTarget, error)
+}
+
+type RailRepository interface {
+	// Commands
+	Create(ctx context.Context, rail *Rail) error
+	Update(ctx context.Context, rail *Rail) error
+	Delete(ctx context.Context, slug string) error
+
+	// Queries
+	// FindBySlug returns nil when no rail has the slug
+	FindBySlug(ctx context.Context, slug string) (*Rail, error)
+	FindAll(ctx context.Context) ([]*Rail, error)
+}
+
+// PublishLog reads first publications of articles (implemented by the article module).
+// Republishing an updated article does not count as new content.
+type PublishLog interface {
+	// CountByDesk returns the number of articles each desk published in [from, to)
+	CountByDesk(ctx context.Context, from, to time.Time) (map[string]int, error)
+	// LatestInCategories returns when the newest published article of the categories
+	// went out, nil when none is live
+	LatestInCategories(ctx context.Context, categoryIDs []string) (*time.Time, error)
+}
@@ -0,0 +1,103 @@
+package freshness
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+const (
+	MaxDailyTarget    = 500
+	MinRailMaxAge     = time.Hour
+	MaxRailMaxAge     = 72 * time.Hour
+	MaxRailCategories = 20
+)
+
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Domain errors
+var (
+	ErrInvalidDesk       = errors.New("desk must be lowercase letters, digits and hyphens")
+	ErrInvalidTarget     = errors.New("daily target must be between 1 and 500")
+	ErrInvalidRailSlug   = errors.New("rail slug must be lowercase letters, digits and hyphens")
+	ErrInvalidRailName   = errors.New("rail name must be between 1 and 100 characters")
+	ErrInvalidMaxAge     = errors.New("max age must be between 1 and 72 hours")
+	ErrInvalidCategories = errors.New("rail needs between 1 and 20 categories")
+	ErrInvalidDay        = errors.New("date must be formatted as YYYY-MM-DD")
+)
+
+// Rules are when the newsroom works. Days are counted in Location, and stale
+// rails are not alerted during QuietHours when no desk is expected to publish.
+type Rules struct {
+	Location   *time.Location
+	QuietHours [2]int // [from, to) in Location
+}
+
+// DefaultRules fit a newsroom in Western Indonesia
+var DefaultRules = Rules{
+	Location:   time.FixedZone("WIB", 7*60*60),
+	QuietHours: [2]int{0, 6},
+}
+
+// DayOf returns the start of the newsroom day the time falls in
+func (r Rules) DayOf(at time.Time) time.Time {
+	local := at.In(r.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, r.Location)
+}
+
+// ParseDay reads a YYYY-MM-DD date as a newsroom day
+func (r Rules) ParseDay(date string) (time.Time, error) {
+	day, err := time.ParseInLocation(time.DateOnly, date, r.Location)
+	if err != nil {
+		return time.Time{}, ErrInvalidDay
+	}
+	return day, nil
+}
+
+func (r Rules) Quiet(at time.Time) bool {
+	hour := at.In(r.Location).Hour()
+	from, to := r.QuietHours[0], r.QuietHours[1]
+	if from <= to {
+		return hour >= from && hour < to
+	}
+	return hour >= from || hour < to // Quiet hours spanning midnight
+}
+
+// DeskDay is one desk's publishing on one newsroom day against its target,
+// Target is zero for desks without one
+type DeskDay struct {
+	Desk      string
+	Day       time.Time
+	Published int
+	Target    int
+}
+
+// ExpectedBy is the share of the target that should be out by the given time,
+// assuming publishes spread evenly over the day
+func (d DeskDay) ExpectedBy(at time.Time) int {
+	end := d.Day.AddDate(0, 0, 1)
+	switch {
+	case !at.Before(end):
+		return d.Target
+	case !at.After(d.Day):
+		return 0
+	}
+	return int(float64(d.Target) * float64(at.Sub(d.Day)) / float64(end.Sub(d.Day)))
+}
+
+// OnPace reports whether the desk keeps up with its target so far
+func (d DeskDay) OnPace(at time.Time) bool {
+	return d.Published >= d.ExpectedBy(at)
+}
+
+// Missed reports whether a finished day fell short of the target
+func (d DeskDay) Missed() bool {
+	return d.Target > 0 && d.Published < d.Target
+}
+
+func ValidateDesk(desk string) error {
+	if len(desk) > 50 || !slugRegex.MatchString(desk) {
+		return ErrInvalidDesk
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package freshness
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRules_DayOf(t *testing.T) {
+	// 23:30 UTC is already the next morning in Jakarta
+	day := DefaultRules.DayOf(time.Date(2026, 3, 9, 23, 30, 0, 0, time.UTC))
+	if !day.Equal(wib(0, 0)) {
+		t.Errorf("expected 10 March WIB, got %s", day)
+	}
+
+	parsed, err := DefaultRules.ParseDay("2026-03-10")
+	if err != nil || !parsed.Equal(day) {
+		t.Errorf("expected the parsed day to match, got %s, %v", parsed, err)
+	}
+	if _, err := DefaultRules.ParseDay("10/03/2026"); !errors.Is(err, ErrInvalidDay) {
+		t.Errorf("expected ErrInvalidDay, got %v", err)
+	}
+}
+
+func TestRules_Quiet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		quiet    [2]int
+		at       time.Time
+		expected bool
+	}{
+		{"inside", [2]int{0, 6}, wib(3, 0), true},
+		{"end is exclusive", [2]int{0, 6}, wib(6, 0), false},
+		{"spanning midnight, late", [2]int{22, 5}, wib(23, 0), true},
+		{"spanning midnight, early", [2]int{22, 5}, wib(4, 59), true},
+		{"spanning midnight, day", [2]int{22, 5}, wib(12, 0), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := Rules{Location: DefaultRules.Location, QuietHours: tc.quiet}
+			if got := rules.Quiet(tc.at); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDeskDay(t *testing.T) {
+	d := DeskDay{Desk: "metro", Day: wib(0, 0), Published: 5, Target: 12}
+
+	testCases := []struct {
+		name     string
+		at       time.Time
+		expected int
+	}{
+		{"start of day", wib(0, 0), 0},
+		{"midday", wib(12, 0), 6},
+		{"next day", wib(0, 0).AddDate(0, 0, 1), 12},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := d.ExpectedBy(tc.at); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+
+	if d.OnPace(wib(12, 0)) || !d.OnPace(wib(9, 0)) {
+		t.Error("expected 5 publishes on pace at 09:00 but not at noon")
+	}
+	if !d.Missed() {
+		t.Error("expected 5 of 12 missed")
+	}
+	if (DeskDay{Desk: "metro", Published: 3}).Missed() {
+		t.Error("expected desks without a target never to miss it")
+	}
+}
@@ -0,0 +1,141 @@
+package jobwatch
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var jobNameRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type Status string
+
+const (
+	StatusPending Status = "pending" // Registered, first run not due yet
+	StatusOK      Status = "ok"
+	StatusFailing Status = "failing" // Last run failed, still inside its window
+	StatusOverdue Status = "overdue" // No success within interval + grace
+)
+
+// Domain errors
+var (
+	ErrInvalidJobName  = errors.New("job name must be lowercase words separated by dashes")
+	ErrInvalidInterval = errors.New("interval must be at least one minute")
+	ErrNegativeGrace   = errors.New("grace period cannot be negative")
+)
+
+// Job is the expected schedule of a critical background job and its last reported runs
+type Job struct {
+	Name     string
+	Interval time.Duration // How often the job must succeed
+	Grace    time.Duration // Slack for slow runs before alerting
+
+	LastSuccessAt *time.Time
+	LastDuration  time.Duration
+	LastFailureAt *time.Time
+	LastError     *string
+	AlertedAt     *time.Time // Set while an overdue alert is outstanding
+	RegisteredAt  time.Time
+	UpdatedAt     time.Time
+}
+
+func NewJob(name string, interval, grace time.Duration, at time.Time) (*Job, error) {
+	name = strings.TrimSpace(name)
+	if !jobNameRegex.MatchString(name) {
+		return nil, ErrInvalidJobName
+	}
+	if err := validateSchedule(interval, grace); err != nil {
+		return nil, err
+	}
+
+	return &Job{
+		Name:         name,
+		Interval:     interval,
+		Grace:        grace,
+		RegisteredAt: at,
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+// Business Methods
+
+// Reschedule changes the expected schedule, run history is kept
+func (j *Job) Reschedule(interval, grace time.Duration) error {
+	if err := validateSchedule(interval, grace); err != nil {
+		return err
+	}
+	j.Interval = interval
+	j.Grace = grace
+	j.UpdatedAt = time.Now()
+	return nil
+}
+
+// RecordSuccess returns true when the job recovers from an outstanding alert
+func (j *Job) RecordSuccess(at time.Time, duration time.Duration) bool {
+	recovered := j.AlertedAt != nil
+	j.LastSuccessAt = &at
+	j.LastDuration = duration
+	j.AlertedAt = nil
+	j.UpdatedAt = time.Now()
+	return recovered
+}
+
+func (j *Job) RecordFailure(at time.Time, message string) {
+	j.LastFailureAt = &at
+	j.LastError = &message
+	j.UpdatedAt = time.Now()
+}
+
+func (j *Job) MarkAlerted(at time.Time) {
+	j.AlertedAt = &at
+	j.UpdatedAt = time.Now()
+}
+
+// Query Methods
+
+// DeadlineAt is when the job becomes overdue without another success
+func (j *Job) DeadlineAt() time.Time {
+	since := j.RegisteredAt
+	if j.LastSuccessAt != nil {
+		since = *j.LastSuccessAt
+	}
+	return since.Add(j.Interval + j.Grace)
+}
+
+func (j *Job) IsOverdue(at time.Time) bool {
+	return !at.Before(j.DeadlineAt())
+}
+
+// NeedsAlert reports whether an overdue alert should go out, repeating every realert while it stays overdue
+func (j *Job) NeedsAlert(at time.Time, realert time.Duration) bool {
+	if !j.IsOverdue(at) {
+		return false
+	}
+	return j.AlertedAt == nil || at.Sub(*j.AlertedAt) >= realert
+}
+
+func (j *Job) StatusAt(at time.Time) Status {
+	switch {
+	case j.IsOverdue(at):
+		return StatusOverdue
+	case j.LastFailureAt != nil && (j.LastSuccessAt == nil || j.LastFailureAt.After(*j.LastSuccessAt)):
+		return StatusFailing
+	case j.LastSuccessAt == nil:
+		return StatusPending
+	default:
+		return StatusOK
+	}
+}
+
+// Domain Validation Functions
+
+func validateSchedule(interval, grace time.Duration) error {
+	if interval < time.Minute {
+		return ErrInvalidInterval
+	}
+	if grace < 0 {
+		return ErrNegativeGrace
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package jobwatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewJob(t *testing.T) {
+	at := time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		jobName     string
+		interval    time.Duration
+		grace       time.Duration
+		expectedErr error
+	}{
+		{"valid", "sitemap-rebuild", time.Hour, 10 * time.Minute, nil},
+		{"invalid - uppercase", "SitemapRebuild", time.Hour, 0, ErrInvalidJobName},
+		{"invalid - spaces", "sitemap rebuild", time.Hour, 0, ErrInvalidJobName},
+		{"invalid - interval too short", "cache-purge", 30 * time.Second, 0, ErrInvalidInterval},
+		{"invalid - negative grace", "cache-purge", time.Hour, -time.Minute, ErrNegativeGrace},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewJob(tc.jobName, tc.interval, tc.grace, at)
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestJob_Lifecycle(t *testing.T) {
+	registered := time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC)
+	job, _ := NewJob("newsletter-send", 24*time.Hour, time.Hour, registered)
+
+	if job.StatusAt(registered.Add(time.Hour)) != StatusPending {
+		t.Errorf("expected pending, got %s", job.StatusAt(registered.Add(time.Hour)))
+	}
+	if !job.IsOverdue(registered.Add(25*time.Hour)) || job.StatusAt(registered.Add(25*time.Hour)) != StatusOverdue {
+		t.Error("expected a job that never ran to be overdue after interval + grace")
+	}
+
+	ran := registered.Add(6 * time.Hour)
+	if job.RecordSuccess(ran, 90*time.Second) {
+		t.Error("expected no recovery without an alert")
+	}
+	if job.StatusAt(ran) != StatusOK || !job.DeadlineAt().Equal(ran.Add(25*time.Hour)) {
+		t.Errorf("expected ok until %v, got %s", ran.Add(25*time.Hour), job.StatusAt(ran))
+	}
+
+	job.RecordFailure(ran.Add(24*time.Hour), "smtp: 421 too many connections")
+	if job.StatusAt(ran.Add(24*time.Hour)) != StatusFailing {
+		t.Errorf("expected failing, got %s", job.StatusAt(ran.Add(24*time.Hour)))
+	}
+
+	overdue := ran.Add(25 * time.Hour)
+	if !job.NeedsAlert(overdue, time.Hour) {
+		t.Fatal("expected overdue job to need an alert")
+	}
+	job.MarkAlerted(overdue)
+	if job.NeedsAlert(overdue.Add(30*time.Minute), time.Hour) {
+		t.Error("expected no repeat alert within the re-alert interval")
+	}
+	if !job.NeedsAlert(overdue.Add(time.Hour), time.Hour) {
+		t.Error("expected a repeat alert after the re-alert interval")
+	}
+
+	if !job.RecordSuccess(overdue.Add(2*time.Hour), time.Minute) {
+		t.Error("expected recovery after an alert")
+	}
+	if job.AlertedAt != nil || job.StatusAt(overdue.Add(2*time.Hour)) != StatusOK {
+		t.Error("expected alert state to clear on success")
+	}
+}
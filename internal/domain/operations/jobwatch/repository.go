@@ -0,0 +1,11 @@
+package jobwatch
+
+import "context"
+
+type JobRepository interface {
+	Save(ctx context.Context, job *Job) error
+
+	// FindByName returns nil when the job was never registered
+	FindByName(ctx context.Context, name string) (*Job, error)
+	FindAll(ctx context.Context) ([]*Job, error)
+}
@@ -0,0 +1,204 @@
+package migration
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// IDMapping links a legacy record to the CMS entity it became. Mappings make
+// a run idempotent, a record that already has one is skipped, and they let
+// old URLs and references be translated after the cutover.
+type IDMapping struct {
+	Kind      Kind
+	LegacyID  string
+	NewID     string
+	RunID     string
+	Matched   bool // Mapped to an entity that already existed, e.g. a staff account with the same email
+	CreatedAt time.Time
+}
+
+func NewIDMapping(kind Kind, legacyID, newID, runID string, matched bool, at time.Time) (*IDMapping, error) {
+	if !kind.Valid() {
+		return nil, ErrUnknownKind
+	}
+	if strings.TrimSpace(legacyID) == "" {
+		return nil, errors.New("legacy ID cannot be empty")
+	}
+	if strings.TrimSpace(newID) == "" {
+		return nil, errors.New("new ID cannot be empty")
+	}
+	return &IDMapping{Kind: kind, LegacyID: legacyID, NewID: newID, RunID: runID, Matched: matched, CreatedAt: at}, nil
+}
+
+type RunStatus string
+
+const (
+	RunRunning   RunStatus = "running"
+	RunCompleted RunStatus = "completed"
+	RunFailed    RunStatus = "failed" // Stopped by an error or cancelled, a real run resumes from its cursors
+)
+
+// Stats counts what a run did with the records of one kind
+type Stats struct {
+	Fetched  int
+	Migrated int // Created, or would be in a dry run
+	Matched  int // Mapped to an entity that already existed
+	Skipped  int // Already mapped by an earlier run
+	Invalid  int // Rejected, see the run's issues
+}
+
+// Run is one pass over the legacy database. It moves through the kinds in
+// migration order and keeps a cursor per kind, the legacy ID of the last
+// record handled. A dry run validates every record and resolves references
+// without writing anything but the run itself.
+type Run struct {
+	ID      string
+	DryRun  bool
+	Status  RunStatus
+	Cursors map[Kind]string
+	Stats   map[Kind]*Stats
+
+	Issues        []Issue // The first MaxIssues
+	DroppedIssues int     // Issues past MaxIssues, only counted
+
+	Error      *string
+	StartedBy  string
+	StartedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+func NewRun(id, startedBy string, dryRun bool, at time.Time) (*Run, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(startedBy) == "" {
+		return nil, errors.New("actor ID cannot be empty")
+	}
+	return &Run{
+		ID:        id,
+		DryRun:    dryRun,
+		Status:    RunRunning,
+		Cursors:   make(map[Kind]string),
+		Stats:     make(map[Kind]*Stats),
+		StartedBy: startedBy,
+		StartedAt: at,
+		UpdatedAt: at,
+	}, nil
+}
+
+// Business Methods
+
+// Stat returns the counters of a kind, creating them on first use
+func (r *Run) Stat(kind Kind) *Stats {
+	if r.Stats == nil {
+		r.Stats = make(map[Kind]*Stats)
+	}
+	s, ok := r.Stats[kind]
+	if !ok {
+		s = &Stats{}
+		r.Stats[kind] = s
+	}
+	return s
+}
+
+// Advance moves the kind's cursor past a handled batch
+func (r *Run) Advance(kind Kind, cursor string, at time.Time) {
+	if r.Cursors == nil {
+		r.Cursors = make(map[Kind]string)
+	}
+	r.Cursors[kind] = cursor
+	r.UpdatedAt = at
+}
+
+// Reject records a legacy record that could not be migrated
+func (r *Run) Reject(kind Kind, legacyID string, reason error) {
+	r.Stat(kind).Invalid++
+	if len(r.Issues) >= MaxIssues {
+		r.DroppedIssues++
+		return
+	}
+	r.Issues = append(r.Issues, Issue{Kind: kind, LegacyID: legacyID, Reason: reason.Error()})
+}
+
+func (r *Run) Complete(at time.Time) error {
+	if r.Status != RunRunning {
+		return ErrRunFinished
+	}
+	r.Status = RunCompleted
+	r.UpdatedAt = at
+	r.FinishedAt = &at
+	return nil
+}
+
+// Fail stops the run, cursors and counts so far are kept
+func (r *Run) Fail(err error, at time.Time) {
+	reason := err.Error()
+	r.Status = RunFailed
+	r.Error = &reason
+	r.UpdatedAt = at
+	r.FinishedAt = &at
+}
+
+// Resume restarts a failed run from its cursors
+func (r *Run) Resume(at time.Time) error {
+	if r.DryRun {
+		return ErrDryRunNotResumable
+	}
+	if r.Status != RunFailed {
+		return ErrRunFinished
+	}
+	r.Status = RunRunning
+	r.Error = nil
+	r.UpdatedAt = at
+	r.FinishedAt = nil
+	return nil
+}
+
+// Query Methods
+
+func (r *Run) Cursor(kind Kind) string {
+	return r.Cursors[kind]
+}
+
+func (r *Run) Invalid() int {
+	n := 0
+	for _, s := range r.Stats {
+		n += s.Invalid
+	}
+	return n
+}
+
+// KindReport compares one kind between the legacy database and the CMS
+type KindReport struct {
+	Kind        Kind
+	SourceCount int64 // Records the legacy query selects now
+	Mapped      int64 // Records with an ID mapping
+	Invalid     int   // Records the latest run rejected
+}
+
+// Pending is how many legacy records still have no CMS counterpart, records
+// added to the legacy CMS since the last run show up here
+func (k KindReport) Pending() int64 {
+	return max(k.SourceCount-k.Mapped, 0)
+}
+
+// CutoverReport tells whether the CMS holds everything the legacy system
+// does, generated before switching the newsroom over
+type CutoverReport struct {
+	RunID       string // Latest run, its issues explain the pending records
+	Kinds       []KindReport
+	Issues      []Issue
+	GeneratedAt time.Time
+}
+
+// Ready reports whether every legacy record of every kind is mapped
+func (c *CutoverReport) Ready() bool {
+	for _, k := range c.Kinds {
+		if k.Pending() > 0 {
+			return false
+		}
+	}
+	return true
+}
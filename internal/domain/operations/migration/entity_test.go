@@ -0,0 +1,78 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRun_Lifecycle(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	run, err := NewRun("r1", "admin-1", false, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	run.Stat(KindUser).Migrated += 2
+	run.Advance(KindUser, "42", at)
+	run.Fail(errors.New("connection reset"), at.Add(time.Minute))
+	if run.Status != RunFailed || run.Error == nil || run.Cursor(KindUser) != "42" {
+		t.Errorf("expected a failed run keeping its cursor, got %+v", run)
+	}
+	if err := run.Resume(at.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Status != RunRunning || run.Error != nil || run.Stat(KindUser).Migrated != 2 {
+		t.Errorf("expected a running run with its progress, got %+v", run)
+	}
+	if err := run.Complete(at.Add(2 * time.Hour)); err != nil || run.FinishedAt == nil {
+		t.Fatalf("expected the run completed, got %v", err)
+	}
+	if err := run.Resume(at); err != ErrRunFinished {
+		t.Errorf("expected ErrRunFinished, got %v", err)
+	}
+
+	dry, _ := NewRun("r2", "admin-1", true, at)
+	dry.Fail(errors.New("cancelled"), at)
+	if err := dry.Resume(at); err != ErrDryRunNotResumable {
+		t.Errorf("expected ErrDryRunNotResumable, got %v", err)
+	}
+}
+
+func TestRun_Reject(t *testing.T) {
+	run, _ := NewRun("r1", "admin-1", true, time.Now())
+	for i := 0; i < MaxIssues+5; i++ {
+		run.Reject(KindArticle, fmt.Sprint(i), errors.New("author is not migrated"))
+	}
+	run.Reject(KindTag, "t1", errors.New("invalid slug"))
+	if len(run.Issues) != MaxIssues || run.DroppedIssues != 6 {
+		t.Errorf("expected %d issues kept and 6 dropped, got %d and %d", MaxIssues, len(run.Issues), run.DroppedIssues)
+	}
+	if run.Invalid() != MaxIssues+6 || run.Stat(KindTag).Invalid != 1 {
+		t.Errorf("unexpected invalid counts %d", run.Invalid())
+	}
+}
+
+func TestCutoverReport_Ready(t *testing.T) {
+	report := &CutoverReport{Kinds: []KindReport{
+		{Kind: KindUser, SourceCount: 120, Mapped: 120},
+		{Kind: KindArticle, SourceCount: 5000, Mapped: 4998, Invalid: 2},
+	}}
+	if report.Ready() || report.Kinds[1].Pending() != 2 {
+		t.Errorf("expected 2 pending articles to block the cutover, got %+v", report.Kinds)
+	}
+	report.Kinds[1].Mapped = 5000
+	if !report.Ready() {
+		t.Error("expected the cutover ready")
+	}
+}
+
+func TestNewIDMapping(t *testing.T) {
+	if _, err := NewIDMapping("comments", "1", "c1", "r1", false, time.Now()); err != ErrUnknownKind {
+		t.Errorf("expected ErrUnknownKind, got %v", err)
+	}
+	if _, err := NewIDMapping(KindUser, "", "u1", "r1", false, time.Now()); err == nil {
+		t.Error("expected error for an empty legacy ID")
+	}
+}
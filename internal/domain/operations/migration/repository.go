@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"context"
+	"io"
+)
+
+// RecordSource reads the legacy database through a Config (implementations will be in infrastructure layer)
+type RecordSource interface {
+	Count(ctx context.Context, kind Kind) (int64, error)
+	// Fetch returns up to limit records with a legacy ID after the cursor, in ID order.
+	// An empty cursor starts at the beginning.
+	Fetch(ctx context.Context, kind Kind, afterID string, limit int) ([]Record, error)
+}
+
+// FileSource opens media from the legacy file store by FieldLocation (implementations will be in infrastructure layer)
+type FileSource interface {
+	Open(ctx context.Context, location string) (io.ReadCloser, error)
+}
+
+type IDMappingRepository interface {
+	Create(ctx context.Context, mapping *IDMapping) error
+	// FindNewID returns the CMS ID of a legacy record, "" when it is not mapped
+	FindNewID(ctx context.Context, kind Kind, legacyID string) (string, error)
+	Count(ctx context.Context, kind Kind) (int64, error)
+}
+
+type RunRepository interface {
+	Create(ctx context.Context, run *Run) error
+	Update(ctx context.Context, run *Run) error
+	FindByID(ctx context.Context, id string) (*Run, error)     // nil when not found
+	FindLatest(ctx context.Context, dryRun bool) (*Run, error) // nil before the first run
+}
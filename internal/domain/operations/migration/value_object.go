@@ -0,0 +1,250 @@
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Batching, a run saves its cursor after every batch so an interrupted run
+// resumes where it stopped
+const (
+	DefaultBatchSize = 500
+	MaxBatchSize     = 5000
+)
+
+// MaxIssues bounds the issues kept on a run, the rest are only counted
+const MaxIssues = 1000
+
+// Domain errors
+var (
+	ErrUnknownKind        = errors.New("unknown migration kind")
+	ErrUnknownField       = errors.New("unknown migration field")
+	ErrMissingField       = errors.New("required field is not mapped")
+	ErrEmptyQuery         = errors.New("source query cannot be empty")
+	ErrEmptyColumn        = errors.New("legacy column name cannot be empty")
+	ErrEmptyConfig        = errors.New("mapping config must map at least one kind")
+	ErrInvalidValue       = errors.New("legacy value is invalid")
+	ErrRunNotFound        = errors.New("migration run not found")
+	ErrRunFinished        = errors.New("migration run has already finished")
+	ErrDryRunNotResumable = errors.New("a dry run cannot be resumed, start a new one")
+)
+
+// Kind is one type of legacy content
+type Kind string
+
+const (
+	KindUser     Kind = "users"
+	KindCategory Kind = "categories"
+	KindTag      Kind = "tags"
+	KindMedia    Kind = "media"
+	KindArticle  Kind = "articles"
+)
+
+// Kinds lists every kind in migration order, each only refers to kinds before it:
+// categories to their parent, media to the uploader, articles to the author
+var Kinds = []Kind{KindUser, KindCategory, KindTag, KindMedia, KindArticle}
+
+func (k Kind) Valid() bool {
+	for _, known := range Kinds {
+		if k == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Field is a target attribute a legacy column is mapped to
+type Field string
+
+const (
+	FieldID          Field = "id" // Legacy primary key, also the batch cursor
+	FieldUsername    Field = "username"
+	FieldEmail       Field = "email"
+	FieldPassword    Field = "password_hash"
+	FieldAccountType Field = "account_type"
+	FieldStatus      Field = "status"
+	FieldSlug        Field = "slug"
+	FieldName        Field = "name"
+	FieldDescription Field = "description"
+	FieldParentID    Field = "parent_id"
+	FieldPosition    Field = "position"
+	FieldFilename    Field = "filename"
+	FieldContentType Field = "content_type"
+	FieldLocation    Field = "location" // Where the file sits in the legacy file store
+	FieldUploadedBy  Field = "uploaded_by"
+	FieldTitle       Field = "title"
+	FieldSummary     Field = "summary"
+	FieldBody        Field = "body"
+	FieldAuthorID    Field = "author_id"
+	FieldPublishedAt Field = "published_at"
+	FieldCreatedAt   Field = "created_at"
+)
+
+// kindFields lists the fields of each kind, required ones first
+var kindFields = map[Kind]struct{ required, optional []Field }{
+	KindUser: {
+		required: []Field{FieldID, FieldUsername, FieldEmail, FieldPassword},
+		optional: []Field{FieldAccountType, FieldStatus, FieldCreatedAt},
+	},
+	KindCategory: {
+		required: []Field{FieldID, FieldSlug, FieldName},
+		optional: []Field{FieldDescription, FieldParentID, FieldPosition, FieldCreatedAt},
+	},
+	KindTag: {
+		required: []Field{FieldID, FieldSlug, FieldName},
+		optional: []Field{FieldDescription, FieldCreatedAt},
+	},
+	KindMedia: {
+		required: []Field{FieldID, FieldFilename, FieldContentType, FieldLocation},
+		optional: []Field{FieldUploadedBy, FieldCreatedAt},
+	},
+	KindArticle: {
+		required: []Field{FieldID, FieldSlug, FieldTitle, FieldBody, FieldAuthorID},
+		optional: []Field{FieldSummary, FieldStatus, FieldPublishedAt, FieldCreatedAt},
+	},
+}
+
+// Fields returns the fields a kind can map, required ones first
+func (k Kind) Fields() []Field {
+	f := kindFields[k]
+	return append(append([]Field(nil), f.required...), f.optional...)
+}
+
+// Required reports whether every record of the kind needs the field
+func (k Kind) Required(field Field) bool {
+	return slices.Contains(kindFields[k].required, field)
+}
+
+// Source value object, how one kind is read from the legacy database. The
+// query selects the rows, the source adapter pages through it by the column
+// mapped to FieldID.
+type Source struct {
+	Query     string           `json:"query"`
+	Columns   map[Field]string `json:"columns"`    // Target field to legacy column
+	NumericID bool             `json:"numeric_id"` // Page by number rather than text, e.g. auto-increment keys
+}
+
+// IDColumn is the legacy column holding the primary key
+func (s Source) IDColumn() string {
+	return s.Columns[FieldID]
+}
+
+func (s Source) validate(kind Kind) error {
+	if strings.TrimSpace(s.Query) == "" {
+		return fmt.Errorf("%s: %w", kind, ErrEmptyQuery)
+	}
+	for field, column := range s.Columns {
+		if !kind.Required(field) && !slices.Contains(kindFields[kind].optional, field) {
+			return fmt.Errorf("%s: %w: %q", kind, ErrUnknownField, field)
+		}
+		if strings.TrimSpace(column) == "" {
+			return fmt.Errorf("%s: %w: %s", kind, ErrEmptyColumn, field)
+		}
+	}
+	for _, field := range kindFields[kind].required {
+		if _, ok := s.Columns[field]; !ok {
+			return fmt.Errorf("%s: %w: %s", kind, ErrMissingField, field)
+		}
+	}
+	return nil
+}
+
+// Config value object, the legacy query and column mapping of each kind.
+// Kinds left out are not migrated.
+type Config struct {
+	sources map[Kind]Source
+}
+
+func NewConfig(sources map[Kind]Source) (*Config, error) {
+	if len(sources) == 0 {
+		return nil, ErrEmptyConfig
+	}
+	clean := make(map[Kind]Source, len(sources))
+	for kind, s := range sources {
+		if !kind.Valid() {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+		}
+		if err := s.validate(kind); err != nil {
+			return nil, err
+		}
+		s.Query = strings.TrimSpace(s.Query)
+		clean[kind] = s
+	}
+	return &Config{sources: clean}, nil
+}
+
+// LoadConfig reads a JSON object of kind to source, e.g.
+//
+//	{"users": {"query": "SELECT * FROM wp_users", "numeric_id": true,
+//	           "columns": {"id": "ID", "username": "user_login", ...}}}
+func LoadConfig(r io.Reader) (*Config, error) {
+	var sources map[Kind]Source
+	if err := json.NewDecoder(r).Decode(&sources); err != nil {
+		return nil, fmt.Errorf("failed to parse migration mapping: %w", err)
+	}
+	return NewConfig(sources)
+}
+
+// Kinds returns the configured kinds in migration order
+func (c *Config) Kinds() []Kind {
+	kinds := make([]Kind, 0, len(c.sources))
+	for _, k := range Kinds {
+		if _, ok := c.sources[k]; ok {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+func (c *Config) Source(kind Kind) (Source, bool) {
+	s, ok := c.sources[kind]
+	return s, ok
+}
+
+// Record is one legacy row with its columns already renamed to fields.
+// Values are text, empty when the column is NULL.
+type Record struct {
+	Kind     Kind
+	LegacyID string
+	Fields   map[Field]string
+}
+
+func (r Record) Value(f Field) string {
+	return strings.TrimSpace(r.Fields[f])
+}
+
+// legacyTimeLayouts are tried in order, legacy databases rarely agree on one
+var legacyTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	time.DateOnly,
+}
+
+// Time parses a timestamp field, nil when it is empty. Times without a zone
+// are UTC, and MySQL's zero date counts as empty.
+func (r Record) Time(f Field) (*time.Time, error) {
+	v := r.Value(f)
+	if v == "" || strings.HasPrefix(v, "0000-00-00") {
+		return nil, nil
+	}
+	for _, layout := range legacyTimeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s %q is not a time", ErrInvalidValue, f, v)
+}
+
+// Issue is a legacy record that could not be migrated
+type Issue struct {
+	Kind     Kind
+	LegacyID string
+	Reason   string
+}
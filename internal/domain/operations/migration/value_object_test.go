@@ -0,0 +1,110 @@
+package migration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const wordpressConfig = `{
+	"articles": {
+		"query": "SELECT * FROM wp_posts WHERE post_type = 'post'",
+		"numeric_id": true,
+		"columns": {"id": "ID", "slug": "post_name", "title": "post_title", "body": "post_content", "author_id": "post_author"}
+	},
+	"users": {
+		"query": "SELECT * FROM wp_users",
+		"numeric_id": true,
+		"columns": {"id": "ID", "username": "user_login", "email": "user_email", "password_hash": "user_pass"}
+	}
+}`
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig(strings.NewReader(wordpressConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kinds := config.Kinds(); len(kinds) != 2 || kinds[0] != KindUser || kinds[1] != KindArticle {
+		t.Errorf("expected users before articles, got %v", kinds)
+	}
+	if src, ok := config.Source(KindUser); !ok || src.IDColumn() != "ID" || !src.NumericID {
+		t.Errorf("unexpected users source %+v", src)
+	}
+	if _, ok := config.Source(KindMedia); ok {
+		t.Error("expected unmapped media left out")
+	}
+}
+
+func TestNewConfig_Invalid(t *testing.T) {
+	users := func() Source {
+		return Source{Query: "SELECT * FROM users", Columns: map[Field]string{
+			FieldID: "id", FieldUsername: "login", FieldEmail: "mail", FieldPassword: "pass",
+		}}
+	}
+	tests := []struct {
+		name    string
+		sources func() map[Kind]Source
+		err     error
+	}{
+		{"empty", func() map[Kind]Source { return nil }, ErrEmptyConfig},
+		{"unknown kind", func() map[Kind]Source { return map[Kind]Source{"comments": users()} }, ErrUnknownKind},
+		{"empty query", func() map[Kind]Source {
+			s := users()
+			s.Query = " "
+			return map[Kind]Source{KindUser: s}
+		}, ErrEmptyQuery},
+		{"field of another kind", func() map[Kind]Source {
+			s := users()
+			s.Columns[FieldTitle] = "title"
+			return map[Kind]Source{KindUser: s}
+		}, ErrUnknownField},
+		{"empty column", func() map[Kind]Source {
+			s := users()
+			s.Columns[FieldStatus] = ""
+			return map[Kind]Source{KindUser: s}
+		}, ErrEmptyColumn},
+		{"missing required", func() map[Kind]Source {
+			s := users()
+			delete(s.Columns, FieldPassword)
+			return map[Kind]Source{KindUser: s}
+		}, ErrMissingField},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewConfig(tt.sources()); !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRecord_Time(t *testing.T) {
+	tests := []struct {
+		value string
+		want  *time.Time
+	}{
+		{"", nil},
+		{"0000-00-00 00:00:00", nil},
+		{"2019-04-02 08:15:00", ptr(time.Date(2019, time.April, 2, 8, 15, 0, 0, time.UTC))},
+		{"2019-04-02T08:15:00+07:00", ptr(time.Date(2019, time.April, 2, 1, 15, 0, 0, time.UTC))},
+		{"2019-04-02", ptr(time.Date(2019, time.April, 2, 0, 0, 0, 0, time.UTC))},
+	}
+	for _, tt := range tests {
+		got, err := Record{Fields: map[Field]string{FieldCreatedAt: tt.value}}.Time(FieldCreatedAt)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.value, err)
+		}
+		if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+			t.Errorf("%q: expected %v, got %v", tt.value, tt.want, got)
+		}
+	}
+
+	if _, err := (Record{Fields: map[Field]string{FieldCreatedAt: "yesterday"}}).Time(FieldCreatedAt); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("expected ErrInvalidValue, got %v", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
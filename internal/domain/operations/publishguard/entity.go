@@ -0,0 +1,129 @@
+package publishguard
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Baseline is what normal looks like for one staff member: the addresses they
+// work from and how their actions spread over the hours of the day
+type Baseline struct {
+	StaffID   string
+	IPs       map[string]time.Time // Last use per address
+	Hours     [24]int              // Actions per hour of day in the rules' location
+	UpdatedAt time.Time
+}
+
+func NewBaseline(staffID string) *Baseline {
+	return &Baseline{StaffID: staffID, IPs: map[string]time.Time{}}
+}
+
+// Business Methods
+
+// Learn adds a trusted action to the baseline. Actions that raised signals
+// are only learned once security staff resolve them as legitimate, so an
+// intruder cannot teach the guard their own habits.
+func (b *Baseline) Learn(action Action, rules Rules) {
+	if action.IP != "" {
+		b.IPs[action.IP] = action.At
+	}
+	b.Hours[action.At.In(rules.Location).Hour()]++
+	b.UpdatedAt = action.At
+
+	// Forget addresses nobody has used for a while so the map stays small
+	for ip, lastUsed := range b.IPs {
+		if action.At.Sub(lastUsed) > rules.KnownIPFor {
+			delete(b.IPs, ip)
+		}
+	}
+}
+
+// Query Methods
+
+func (b *Baseline) KnowsIP(ip string, at time.Time, knownFor time.Duration) bool {
+	lastUsed, ok := b.IPs[ip]
+	return ok && at.Sub(lastUsed) <= knownFor
+}
+
+// OffHours reports whether the time is outside the staff member's usual hours
+func (b *Baseline) OffHours(at time.Time, rules Rules) bool {
+	hour := at.In(rules.Location).Hour()
+	total := 0
+	for _, n := range b.Hours {
+		total += n
+	}
+	if total < rules.MinBaseline {
+		from, to := rules.QuietHours[0], rules.QuietHours[1]
+		return hour >= from && hour < to
+	}
+	return float64(b.Hours[hour]) < rules.OffHoursShare*float64(total)
+}
+
+type IncidentStatus string
+
+const (
+	IncidentOpen        IncidentStatus = "open"
+	IncidentLegitimate  IncidentStatus = "legitimate"  // Confirmed with the staff member, e.g. travelling
+	IncidentCompromised IncidentStatus = "compromised" // Credentials reset and actions reverted outside the guard
+)
+
+// Incident is the evidence trail security staff review: the action that
+// raised the signals and the recent actions around it
+type Incident struct {
+	ID        string
+	StaffID   string
+	SessionID string
+	Signals   []Signal
+	Response  Response
+	Trigger   Action
+	Evidence  []Action
+	Status    IncidentStatus
+	CreatedAt time.Time
+
+	// Resolution
+	ResolvedBy *string
+	ResolvedAt *time.Time
+	Note       *string
+}
+
+func NewIncident(id string, trigger Action, evidence []Action, signals []Signal, at time.Time) (*Incident, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if len(signals) == 0 {
+		return nil, errors.New("incident needs at least one signal")
+	}
+	return &Incident{
+		ID:        id,
+		StaffID:   trigger.StaffID,
+		SessionID: trigger.SessionID,
+		Signals:   signals,
+		Response:  ResponseFor(signals),
+		Trigger:   trigger,
+		Evidence:  evidence,
+		Status:    IncidentOpen,
+		CreatedAt: at,
+	}, nil
+}
+
+// Business Methods
+
+func (i *Incident) Resolve(staffID string, compromised bool, note string, at time.Time) error {
+	if i.Status != IncidentOpen {
+		return ErrIncidentResolved
+	}
+	if strings.TrimSpace(staffID) == "" {
+		return errors.New("staff ID cannot be empty")
+	}
+	i.Status = IncidentLegitimate
+	if compromised {
+		i.Status = IncidentCompromised
+	}
+	i.ResolvedBy = &staffID
+	i.ResolvedAt = &at
+	if note = strings.TrimSpace(note); note != "" {
+		i.Note = &note
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package publishguard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBaseline_ForgetsOldIPs(t *testing.T) {
+	baseline := NewBaseline("s1")
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	baseline.Learn(Action{IP: "198.51.100.7", At: at}, DefaultRules)
+	baseline.Learn(Action{IP: "203.0.113.9", At: at.Add(100 * 24 * time.Hour)}, DefaultRules)
+
+	if len(baseline.IPs) != 1 || baseline.KnowsIP("198.51.100.7", at.Add(100*24*time.Hour), DefaultRules.KnownIPFor) {
+		t.Errorf("expected the stale address forgotten, got %v", baseline.IPs)
+	}
+}
+
+func TestIncident_Resolve(t *testing.T) {
+	at := time.Now()
+	trigger := Action{StaffID: "s1", SessionID: "sess-1", Kind: ActionUnpublish, ArticleID: "a1", At: at}
+
+	if _, err := NewIncident("i1", trigger, nil, nil, at); err == nil {
+		t.Error("expected error for an incident without signals")
+	}
+	incident, err := NewIncident("i1", trigger, nil, []Signal{SignalMassUnpublish}, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if incident.Response != ResponseSuspend || incident.SessionID != "sess-1" {
+		t.Errorf("unexpected incident %+v", incident)
+	}
+
+	if err := incident.Resolve("sec-1", true, " reset password ", at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if incident.Status != IncidentCompromised || *incident.Note != "reset password" {
+		t.Errorf("unexpected resolution %+v", incident)
+	}
+	if err := incident.Resolve("sec-1", false, "", at); err != ErrIncidentResolved {
+		t.Errorf("expected error '%v', got '%v'", ErrIncidentResolved, err)
+	}
+}
@@ -0,0 +1,40 @@
+package publishguard
+
+import (
+	"context"
+	"time"
+)
+
+type ActionRepository interface {
+	Record(ctx context.Context, action Action) error
+
+	// FindRecent returns the staff member's actions since the given time, oldest first
+	FindRecent(ctx context.Context, staffID string, since time.Time) ([]Action, error)
+}
+
+type BaselineRepository interface {
+	Save(ctx context.Context, baseline *Baseline) error
+
+	// FindByStaff returns nil for staff without recorded actions
+	FindByStaff(ctx context.Context, staffID string) (*Baseline, error)
+}
+
+type IncidentRepository interface {
+	// Commands
+	Create(ctx context.Context, incident *Incident) error
+	Update(ctx context.Context, incident *Incident) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Incident, error)
+	FindOpen(ctx context.Context) ([]*Incident, error)
+}
+
+// SessionControl acts on the staff session that raised an incident (implemented by the auth module)
+type SessionControl interface {
+	// RequireStepUp makes the session re-authenticate before its next sensitive action
+	RequireStepUp(ctx context.Context, sessionID string) error
+	// Suspend blocks the session until the given time or until security staff lift it
+	Suspend(ctx context.Context, sessionID string, until time.Time) error
+	// Restore lifts a suspension after the incident turned out legitimate
+	Restore(ctx context.Context, sessionID string) error
+}
@@ -0,0 +1,168 @@
+package publishguard
+
+import (
+	"errors"
+	"time"
+)
+
+// Domain errors
+var (
+	ErrInvalidActionKind = errors.New("action must be publish, unpublish, edit or delete")
+	ErrIncidentResolved  = errors.New("incident is already resolved")
+)
+
+// ActionKind is the kind of newsroom action the guard watches
+type ActionKind string
+
+const (
+	ActionPublish   ActionKind = "publish"
+	ActionUnpublish ActionKind = "unpublish"
+	ActionEdit      ActionKind = "edit"
+	ActionDelete    ActionKind = "delete"
+)
+
+func (k ActionKind) Valid() bool {
+	switch k {
+	case ActionPublish, ActionUnpublish, ActionEdit, ActionDelete:
+		return true
+	}
+	return false
+}
+
+// removes reports whether the action takes an article off the site
+func (k ActionKind) removes() bool {
+	return k == ActionUnpublish || k == ActionDelete
+}
+
+// Action is one staff action as the guard sees it
+type Action struct {
+	StaffID            string
+	SessionID          string
+	Kind               ActionKind
+	ArticleID          string
+	ArticlePublishedAt *time.Time // First publication, nil for drafts
+	IP                 string
+	At                 time.Time
+	StepUpAt           *time.Time // Last re-authentication of the session
+}
+
+// Signal is one kind of unusual behaviour
+type Signal string
+
+const (
+	SignalMassUnpublish Signal = "mass_unpublish"   // Many articles taken down in a short time
+	SignalOffHoursNewIP Signal = "off_hours_new_ip" // Publishing at an hour the staff member never works, from an unknown address
+	SignalBulkOldEdits  Signal = "bulk_old_edits"   // Many edits of long-published articles, e.g. planting links
+)
+
+// Response is what the guard does about the signals of an action
+type Response string
+
+const (
+	ResponseAllow   Response = "allow"
+	ResponseStepUp  Response = "step_up" // Re-authenticate before the action goes through
+	ResponseSuspend Response = "suspend" // The session is suspended until security staff review it
+)
+
+// severity orders responses so the strongest one wins
+func (r Response) severity() int {
+	switch r {
+	case ResponseStepUp:
+		return 1
+	case ResponseSuspend:
+		return 2
+	}
+	return 0
+}
+
+// Decision is the guard's verdict on one action
+type Decision struct {
+	Response Response
+	Incident *Incident // Set when the action raised signals
+}
+
+// Burst is a count of matching actions within a sliding window
+type Burst struct {
+	Count  int
+	Window time.Duration
+}
+
+// Rules are the thresholds of the guard
+type Rules struct {
+	MassUnpublish Burst
+	BulkOldEdits  Burst
+	OldArticleAge time.Duration // Edits of articles published longer ago count as old
+
+	// An hour is unusual when fewer than OffHoursShare of the staff member's
+	// actions fall into it. Until MinBaseline actions are known, QuietHours apply.
+	OffHoursShare float64
+	MinBaseline   int
+	QuietHours    [2]int // [from, to) in Location
+	Location      *time.Location
+
+	KnownIPFor     time.Duration // An address stays known this long after its last use
+	StepUpValidity time.Duration // A re-authentication covers step-up signals this long
+	SuspendFor     time.Duration
+}
+
+// DefaultRules fit a newsroom in Western Indonesia
+var DefaultRules = Rules{
+	MassUnpublish:  Burst{Count: 10, Window: 10 * time.Minute},
+	BulkOldEdits:   Burst{Count: 20, Window: 30 * time.Minute},
+	OldArticleAge:  180 * 24 * time.Hour,
+	OffHoursShare:  0.02,
+	MinBaseline:    50,
+	QuietHours:     [2]int{1, 5},
+	Location:       time.FixedZone("WIB", 7*60*60),
+	KnownIPFor:     90 * 24 * time.Hour,
+	StepUpValidity: 15 * time.Minute,
+	SuspendFor:     24 * time.Hour,
+}
+
+// ResponseFor maps signals to a response: a single step-up signal asks for
+// re-authentication, mass takedowns or several signals at once suspend
+func ResponseFor(signals []Signal) Response {
+	if len(signals) == 0 {
+		return ResponseAllow
+	}
+	if len(signals) > 1 {
+		return ResponseSuspend
+	}
+	if signals[0] == SignalMassUnpublish {
+		return ResponseSuspend
+	}
+	return ResponseStepUp
+}
+
+// Evaluate returns the signals the action raises given the staff member's
+// recent actions (newest last, without the action itself) and baseline
+func Evaluate(action Action, recent []Action, baseline *Baseline, rules Rules) []Signal {
+	var signals []Signal
+
+	if action.Kind.removes() && burst(action, recent, rules.MassUnpublish, func(a Action) bool { return a.Kind.removes() }) {
+		signals = append(signals, SignalMassUnpublish)
+	}
+
+	if action.Kind == ActionPublish && !baseline.KnowsIP(action.IP, action.At, rules.KnownIPFor) && baseline.OffHours(action.At, rules) {
+		signals = append(signals, SignalOffHoursNewIP)
+	}
+
+	old := func(a Action) bool {
+		return a.Kind == ActionEdit && a.ArticlePublishedAt != nil && a.At.Sub(*a.ArticlePublishedAt) > rules.OldArticleAge
+	}
+	if old(action) && burst(action, recent, rules.BulkOldEdits, old) {
+		signals = append(signals, SignalBulkOldEdits)
+	}
+	return signals
+}
+
+// burst counts the action plus matching recent actions on distinct articles within the window
+func burst(action Action, recent []Action, b Burst, match func(Action) bool) bool {
+	articles := map[string]bool{action.ArticleID: true}
+	for _, a := range recent {
+		if match(a) && action.At.Sub(a.At) <= b.Window {
+			articles[a.ArticleID] = true
+		}
+	}
+	return len(articles) >= b.Count
+}
@@ -0,0 +1,107 @@
+package publishguard
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// wib returns a time on 1 March 2025 at the hour in Western Indonesia
+func wib(hour, minute int) time.Time {
+	return time.Date(2025, 3, 1, hour, minute, 0, 0, DefaultRules.Location)
+}
+
+func TestEvaluate_MassUnpublish(t *testing.T) {
+	baseline := NewBaseline("s1")
+	var recent []Action
+	for i := 0; i < 8; i++ {
+		recent = append(recent, Action{StaffID: "s1", Kind: ActionUnpublish, ArticleID: fmt.Sprintf("a%d", i), At: wib(14, i)})
+	}
+	// Unpublishing the same article twice is one takedown
+	recent = append(recent, Action{StaffID: "s1", Kind: ActionDelete, ArticleID: "a0", At: wib(14, 8)})
+
+	action := Action{StaffID: "s1", Kind: ActionDelete, ArticleID: "a8", At: wib(14, 9)}
+	if signals := Evaluate(action, recent, baseline, DefaultRules); len(signals) != 0 {
+		t.Errorf("expected 9 takedowns allowed, got %v", signals)
+	}
+
+	recent = append(recent, action)
+	action = Action{StaffID: "s1", Kind: ActionUnpublish, ArticleID: "a9", At: wib(14, 10)}
+	signals := Evaluate(action, recent, baseline, DefaultRules)
+	if len(signals) != 1 || signals[0] != SignalMassUnpublish || ResponseFor(signals) != ResponseSuspend {
+		t.Errorf("expected mass unpublish suspending the session, got %v", signals)
+	}
+
+	action.At = wib(15, 0)
+	if signals := Evaluate(action, recent, baseline, DefaultRules); len(signals) != 0 {
+		t.Errorf("expected takedowns outside the window ignored, got %v", signals)
+	}
+}
+
+func TestEvaluate_OffHoursNewIP(t *testing.T) {
+	baseline := NewBaseline("s1")
+	baseline.Learn(Action{IP: "198.51.100.7", At: wib(10, 0)}, DefaultRules)
+
+	testCases := []struct {
+		name     string
+		ip       string
+		at       time.Time
+		expected bool
+	}{
+		{"office hours, new ip", "203.0.113.9", wib(10, 30), false},
+		{"quiet hours, known ip", "198.51.100.7", wib(3, 0), false},
+		{"quiet hours, new ip", "203.0.113.9", wib(3, 0), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signals := Evaluate(Action{StaffID: "s1", Kind: ActionPublish, ArticleID: "a1", IP: tc.ip, At: tc.at}, nil, baseline, DefaultRules)
+			if got := len(signals) == 1 && signals[0] == SignalOffHoursNewIP; got != tc.expected {
+				t.Errorf("expected signal %v, got %v", tc.expected, signals)
+			}
+		})
+	}
+}
+
+func TestBaseline_OffHoursLearned(t *testing.T) {
+	// A night-shift editor publishing mostly between 1 and 5 is not unusual at 3
+	baseline := NewBaseline("s1")
+	for i := 0; i < 60; i++ {
+		baseline.Learn(Action{IP: "198.51.100.7", At: wib(1+i%4, 0)}, DefaultRules)
+	}
+	if baseline.OffHours(wib(3, 0), DefaultRules) {
+		t.Error("expected the learned hours to replace the quiet hours")
+	}
+	if !baseline.OffHours(wib(14, 0), DefaultRules) {
+		t.Error("expected an afternoon unusual for the night shift")
+	}
+}
+
+func TestEvaluate_BulkOldEdits(t *testing.T) {
+	published := wib(10, 0).AddDate(-2, 0, 0)
+	fresh := wib(9, 0)
+	var recent []Action
+	for i := 0; i < 19; i++ {
+		recent = append(recent, Action{Kind: ActionEdit, ArticleID: fmt.Sprintf("a%d", i), ArticlePublishedAt: &published, At: wib(10, i)})
+	}
+
+	action := Action{Kind: ActionEdit, ArticleID: "new", ArticlePublishedAt: &fresh, At: wib(10, 20)}
+	if signals := Evaluate(action, recent, NewBaseline("s1"), DefaultRules); len(signals) != 0 {
+		t.Errorf("expected an edit of a fresh article ignored, got %v", signals)
+	}
+
+	action.ArticlePublishedAt = &published
+	signals := Evaluate(action, recent, NewBaseline("s1"), DefaultRules)
+	if len(signals) != 1 || signals[0] != SignalBulkOldEdits || ResponseFor(signals) != ResponseStepUp {
+		t.Errorf("expected bulk old edits asking for step-up, got %v", signals)
+	}
+}
+
+func TestResponseFor(t *testing.T) {
+	if ResponseFor(nil) != ResponseAllow {
+		t.Error("expected no signals allowed")
+	}
+	if ResponseFor([]Signal{SignalOffHoursNewIP, SignalBulkOldEdits}) != ResponseSuspend {
+		t.Error("expected several signals to suspend")
+	}
+}
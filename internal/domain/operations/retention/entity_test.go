@@ -0,0 +1,49 @@
+package retention
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewHold(t *testing.T) {
+	subject := "account-1"
+	blank := " "
+	testCases := []struct {
+		name          string
+		category      Category
+		subjectID     *string
+		reason        string
+		wholeCategory bool
+		expectedError error
+	}{
+		{"valid - subject", CategoryLoginAttempts, &subject, "Case 12/2025", false, nil},
+		{"valid - blank subject covers the category", CategoryAuditLog, &blank, "Regulator inquiry", true, nil},
+		{"invalid - category", "comments", nil, "Case 12/2025", false, ErrUnknownCategory},
+		{"invalid - reason", CategoryAuditLog, nil, "  ", false, ErrEmptyReason},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hold, err := NewHold("h1", tc.category, tc.subjectID, tc.reason, "legal-1", time.Now())
+			if err != tc.expectedError {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if err == nil && hold.CoversCategory() != tc.wholeCategory {
+				t.Errorf("expected whole category %v, got %v", tc.wholeCategory, hold.CoversCategory())
+			}
+		})
+	}
+}
+
+func TestHold_Release(t *testing.T) {
+	hold, _ := NewHold("h1", CategoryAuditLog, nil, "Regulator inquiry", "legal-1", time.Now())
+	if err := hold.Release("legal-2", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hold.IsActive() || hold.CoversCategory() {
+		t.Error("expected released hold inactive")
+	}
+	if err := hold.Release("legal-2", time.Now()); err != ErrHoldReleased {
+		t.Errorf("expected error '%v', got '%v'", ErrHoldReleased, err)
+	}
+}
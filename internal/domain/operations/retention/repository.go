@@ -0,0 +1,26 @@
+package retention
+
+import "context"
+
+// Purger removes expired records of one category (implementation will be in infrastructure layer)
+type Purger interface {
+	Count(ctx context.Context, target Target) (Counts, error)
+
+	// Purge deletes up to limit expired, unheld records and returns how many went
+	Purge(ctx context.Context, target Target, limit int) (int, error)
+}
+
+type HoldRepository interface {
+	Save(ctx context.Context, hold *Hold) error
+
+	// FindByID returns nil when the hold does not exist
+	FindByID(ctx context.Context, id string) (*Hold, error)
+	FindActive(ctx context.Context) ([]*Hold, error)
+}
+
+type RunRepository interface {
+	Save(ctx context.Context, run *Run) error
+
+	// FindRecent returns the newest runs first
+	FindRecent(ctx context.Context, limit int) ([]*Run, error)
+}
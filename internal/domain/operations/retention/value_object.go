@@ -0,0 +1,133 @@
+package retention
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type Category string
+
+const (
+	CategoryLoginAttempts   Category = "login_attempts"
+	CategoryAuditLog        Category = "audit_log"
+	CategoryAnalyticsEvents Category = "analytics_events"
+	CategoryCSPReports      Category = "csp_reports"
+	CategoryPublishActions  Category = "publish_actions"
+)
+
+// Domain errors
+var (
+	ErrUnknownCategory = errors.New("unknown data category")
+	ErrInvalidPeriod   = errors.New("retention period must look like 90d, 13m or 7y")
+	ErrBelowMinimum    = errors.New("retention period is shorter than the category's minimum")
+	ErrEmptyReason     = errors.New("legal hold reason cannot be empty")
+	ErrHoldReleased    = errors.New("legal hold is already released")
+)
+
+// Period is a calendar length, months and years follow the calendar rather than a fixed number of days
+type Period struct {
+	Days   int
+	Months int
+	Years  int
+}
+
+var periodRegex = regexp.MustCompile(`^([1-9][0-9]*)([dmy])$`)
+
+func ParsePeriod(s string) (Period, error) {
+	m := periodRegex.FindStringSubmatch(s)
+	if m == nil {
+		return Period{}, ErrInvalidPeriod
+	}
+	n, _ := strconv.Atoi(m[1])
+	switch m[2] {
+	case "d":
+		return Period{Days: n}, nil
+	case "m":
+		return Period{Months: n}, nil
+	default:
+		return Period{Years: n}, nil
+	}
+}
+
+func (p Period) String() string {
+	switch {
+	case p.Years > 0:
+		return fmt.Sprintf("%dy", p.Years)
+	case p.Months > 0:
+		return fmt.Sprintf("%dm", p.Months)
+	default:
+		return fmt.Sprintf("%dd", p.Days)
+	}
+}
+
+// Cutoff is the oldest moment still kept at the given time, records before it expire
+func (p Period) Cutoff(at time.Time) time.Time {
+	return at.UTC().AddDate(-p.Years, -p.Months, -p.Days)
+}
+
+// shorterThan compares by cutoff at a fixed date, which is exact for whole units
+func (p Period) shorterThan(other Period) bool {
+	ref := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	return p.Cutoff(ref).After(other.Cutoff(ref))
+}
+
+// Policy is how long one category is kept
+type Policy struct {
+	Category Category
+	Keep     Period
+	Minimum  Period // Legal floor, configuration cannot go below it
+	Reason   string // Shown in reports, e.g. the regulation the period comes from
+}
+
+// DefaultPolicies are the retention periods declared in code, config may lengthen them
+func DefaultPolicies() []Policy {
+	return []Policy{
+		{Category: CategoryLoginAttempts, Keep: Period{Days: 90}, Minimum: Period{Days: 30}, Reason: "security investigations"},
+		{Category: CategoryAuditLog, Keep: Period{Years: 7}, Minimum: Period{Years: 7}, Reason: "statutory record keeping"},
+		{Category: CategoryAnalyticsEvents, Keep: Period{Months: 13}, Minimum: Period{Months: 13}, Reason: "year-over-year reporting"},
+		{Category: CategoryCSPReports, Keep: Period{Days: 30}, Minimum: Period{Days: 7}, Reason: "debugging the content security policy"},
+		{Category: CategoryPublishActions, Keep: Period{Days: 180}, Minimum: Period{Days: 30}, Reason: "publish guard baselines and incidents"},
+	}
+}
+
+// LoadPolicies applies a JSON file of periods by category, e.g.
+// {"login_attempts": "180d"}, to the default policies
+func LoadPolicies(r io.Reader) ([]Policy, error) {
+	var overrides map[Category]string
+	if err := json.NewDecoder(r).Decode(&overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse retention policies: %w", err)
+	}
+
+	policies := DefaultPolicies()
+	index := make(map[Category]int, len(policies))
+	for i, p := range policies {
+		index[p.Category] = i
+	}
+
+	categories := make([]Category, 0, len(overrides))
+	for category := range overrides {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i] < categories[j] })
+	for _, category := range categories {
+		i, ok := index[category]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownCategory, category)
+		}
+		keep, err := ParsePeriod(overrides[category])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", category, err)
+		}
+		if keep.shorterThan(policies[i].Minimum) {
+			return nil, fmt.Errorf("%w: %s must be kept at least %s", ErrBelowMinimum, category, policies[i].Minimum)
+		}
+		policies[i].Keep = keep
+	}
+	return policies, nil
+}
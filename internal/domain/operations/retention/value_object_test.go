@@ -0,0 +1,80 @@
+package retention
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expected      Period
+		expectedError error
+	}{
+		{"valid - days", "90d", Period{Days: 90}, nil},
+		{"valid - months", "13m", Period{Months: 13}, nil},
+		{"valid - years", "7y", Period{Years: 7}, nil},
+		{"invalid - zero", "0d", Period{}, ErrInvalidPeriod},
+		{"invalid - unit", "12w", Period{}, ErrInvalidPeriod},
+		{"invalid - empty", "", Period{}, ErrInvalidPeriod},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParsePeriod(tc.input)
+			if err != tc.expectedError {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if p != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, p)
+			}
+			if err == nil && p.String() != tc.input {
+				t.Errorf("expected %s to round-trip, got %s", tc.input, p)
+			}
+		})
+	}
+}
+
+func TestPeriod_Cutoff(t *testing.T) {
+	at := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	if cutoff := (Period{Months: 13}).Cutoff(at); !cutoff.Equal(time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected cutoff %s", cutoff)
+	}
+	if cutoff := (Period{Days: 90}).Cutoff(at); !cutoff.Equal(time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected cutoff %s", cutoff)
+	}
+}
+
+func TestLoadPolicies(t *testing.T) {
+	testCases := []struct {
+		name          string
+		config        string
+		expectedError error
+	}{
+		{"valid - lengthened", `{"login_attempts": "180d", "audit_log": "10y"}`, nil},
+		{"valid - empty", `{}`, nil},
+		{"invalid - unknown category", `{"comments": "30d"}`, ErrUnknownCategory},
+		{"invalid - below minimum", `{"audit_log": "5y"}`, ErrBelowMinimum},
+		{"invalid - period", `{"login_attempts": "90 days"}`, ErrInvalidPeriod},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policies, err := LoadPolicies(strings.NewReader(tc.config))
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if err == nil && len(policies) != len(DefaultPolicies()) {
+				t.Errorf("expected every category, got %d", len(policies))
+			}
+		})
+	}
+
+	policies, _ := LoadPolicies(strings.NewReader(`{"login_attempts": "180d"}`))
+	if policies[0].Keep != (Period{Days: 180}) || policies[1].Keep != (Period{Years: 7}) {
+		t.Errorf("expected only login attempts changed, got %+v", policies[:2])
+	}
+}
@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var providerRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// MaxErrorLength bounds the stored processing error
+const MaxErrorLength = 1000
+
+type Status string
+
+const (
+	StatusReceived  Status = "received" // Archived, processing not finished
+	StatusProcessed Status = "processed"
+	StatusFailed    Status = "failed" // Processing failed, can be replayed from the archive
+)
+
+// Domain errors
+var (
+	ErrInvalidProvider   = errors.New("provider must be lowercase words separated by dashes")
+	ErrEmptyEventID      = errors.New("event ID cannot be empty")
+	ErrEmptyArchiveKey   = errors.New("archive key cannot be empty")
+	ErrDuplicateDelivery = errors.New("delivery was already received")
+	ErrNotReplayable     = errors.New("only failed deliveries can be replayed")
+)
+
+// Delivery is one inbound callback from an integration, e.g. a payment
+// provider event or a wire agency story. The raw payload lives in the archive
+// under ArchiveKey; EventID is the provider's own ID and makes redelivery of
+// the same event a no-op.
+type Delivery struct {
+	ID          string
+	Provider    string
+	EventID     string
+	ArchiveKey  string
+	PayloadHash string // SHA-256 of the archived body, hex
+
+	Status      Status
+	Attempts    int
+	LastError   *string
+	ReceivedAt  time.Time
+	ProcessedAt *time.Time
+	UpdatedAt   time.Time
+}
+
+func NewDelivery(id, provider, eventID, archiveKey, payloadHash string, at time.Time) (*Delivery, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if !providerRegex.MatchString(provider) {
+		return nil, ErrInvalidProvider
+	}
+	if strings.TrimSpace(eventID) == "" {
+		return nil, ErrEmptyEventID
+	}
+	if strings.TrimSpace(archiveKey) == "" {
+		return nil, ErrEmptyArchiveKey
+	}
+
+	return &Delivery{
+		ID:          id,
+		Provider:    provider,
+		EventID:     eventID,
+		ArchiveKey:  archiveKey,
+		PayloadHash: payloadHash,
+		Status:      StatusReceived,
+		ReceivedAt:  at,
+		UpdatedAt:   at,
+	}, nil
+}
+
+// Business Methods
+
+func (d *Delivery) Succeed(at time.Time) {
+	d.Attempts++
+	d.Status = StatusProcessed
+	d.LastError = nil
+	d.ProcessedAt = &at
+	d.UpdatedAt = at
+}
+
+func (d *Delivery) Fail(cause error, at time.Time) {
+	msg := cause.Error()
+	if len(msg) > MaxErrorLength {
+		msg = msg[:MaxErrorLength]
+	}
+	d.Attempts++
+	d.Status = StatusFailed
+	d.LastError = &msg
+	d.UpdatedAt = at
+}
+
+// Query Methods
+
+func (d *Delivery) IsProcessed() bool {
+	return d.Status == StatusProcessed
+}
+
+// CanReplay reports whether the archived payload may be processed again. A
+// delivery stuck in received, e.g. after a crash mid-processing, counts as failed.
+func (d *Delivery) CanReplay() bool {
+	return d.Status == StatusFailed || d.Status == StatusReceived
+}
@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewDelivery(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		eventID  string
+		key      string
+		wantErr  error
+	}{
+		{"valid", "payment-gateway", "evt_1", "payment-gateway/2025/06/01/d1", nil},
+		{"invalid provider", "Payment Gateway", "evt_1", "k", ErrInvalidProvider},
+		{"empty event id", "wire", " ", "k", ErrEmptyEventID},
+		{"empty archive key", "wire", "evt_1", "", ErrEmptyArchiveKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDelivery("d1", tt.provider, tt.eventID, tt.key, "hash", time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if err == nil && (d.Status != StatusReceived || !d.CanReplay()) {
+				t.Errorf("expected a received delivery, got %+v", d)
+			}
+		})
+	}
+}
+
+func TestDelivery_FailAndSucceed(t *testing.T) {
+	at := time.Now()
+	d, _ := NewDelivery("d1", "wire", "story-1", "wire/d1", "hash", at)
+
+	d.Fail(errors.New(strings.Repeat("x", MaxErrorLength+10)), at)
+	if !d.CanReplay() || len(*d.LastError) != MaxErrorLength || d.Attempts != 1 {
+		t.Errorf("expected a replayable failure with a truncated error, got %+v", d)
+	}
+
+	d.Succeed(at.Add(time.Minute))
+	if !d.IsProcessed() || d.CanReplay() || d.LastError != nil || d.Attempts != 2 {
+		t.Errorf("expected a processed delivery, got %+v", d)
+	}
+}
@@ -0,0 +1,23 @@
+package webhook
+
+import "context"
+
+// Domain interface for inbound deliveries (implementation will be in infrastructure layer)
+type DeliveryRepository interface {
+	// Create returns ErrDuplicateDelivery when the provider's event ID is already stored
+	Create(ctx context.Context, delivery *Delivery) error
+	Update(ctx context.Context, delivery *Delivery) error
+
+	// Queries, nil when not found
+	FindByID(ctx context.Context, id string) (*Delivery, error)
+	FindByEventID(ctx context.Context, provider, eventID string) (*Delivery, error)
+	// FindReplayable lists failed deliveries oldest first, provider "" lists all providers
+	FindReplayable(ctx context.Context, provider string, limit int) ([]*Delivery, error)
+}
+
+// Domain interface for the raw payload archive (implementation will be in infrastructure layer).
+// Archived payloads are kept as received, they are the evidence of what a provider sent.
+type PayloadArchive interface {
+	Put(ctx context.Context, key string, body []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
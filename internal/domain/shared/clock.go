@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Entities and services take one instead of
+// calling time.Now, so lockouts, embargoes and schedules can be tested at
+// any moment without sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the wall clock
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FrozenClock stands still until it is set or advanced, for tests
+type FrozenClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFrozenClock(at time.Time) *FrozenClock {
+	return &FrozenClock{now: at}
+}
+
+func (c *FrozenClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FrozenClock) Set(at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = at
+}
+
+func (c *FrozenClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// OffsetClock runs at the speed of another clock shifted by a fixed offset,
+// e.g. to preview a staging site as it will look when an embargo lifts
+type OffsetClock struct {
+	base   Clock
+	offset time.Duration
+}
+
+func NewOffsetClock(base Clock, offset time.Duration) *OffsetClock {
+	return &OffsetClock{base: base, offset: offset}
+}
+
+func (c *OffsetClock) Now() time.Time {
+	return c.base.Now().Add(c.offset)
+}
@@ -0,0 +1,33 @@
+package shared
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrozenClock(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	clock := NewFrozenClock(start)
+
+	if !clock.Now().Equal(start) || !clock.Now().Equal(start) {
+		t.Errorf("expected the clock to stand still at %s", start)
+	}
+	clock.Advance(90 * time.Minute)
+	if !clock.Now().Equal(start.Add(90 * time.Minute)) {
+		t.Errorf("unexpected time after advance: %s", clock.Now())
+	}
+	clock.Set(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("unexpected time after set: %s", clock.Now())
+	}
+}
+
+func TestOffsetClock(t *testing.T) {
+	base := NewFrozenClock(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	clock := NewOffsetClock(base, 24*time.Hour)
+
+	base.Advance(time.Minute)
+	if expected := time.Date(2025, 6, 2, 9, 1, 0, 0, time.UTC); !clock.Now().Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, clock.Now())
+	}
+}
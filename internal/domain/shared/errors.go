@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"errors"
+	"maps"
+)
+
+// ErrorKind is the class of a domain error, what delivery layers map to a status
+type ErrorKind string
+
+const (
+	KindValidation  ErrorKind = "validation" // Input breaks a rule
+	KindState       ErrorKind = "state"      // The entity is in the wrong state for the operation
+	KindConflict    ErrorKind = "conflict"   // The operation was already done or changes nothing
+	KindForbidden   ErrorKind = "forbidden"  // Not allowed for this entity or actor
+	KindNotFound    ErrorKind = "not_found"
+	KindRateLimited ErrorKind = "rate_limited" // Too many attempts, try again later
+)
+
+// DomainError carries a stable code clients can rely on, while the message is
+// for humans and may change. Errors with the same code match with errors.Is,
+// so a sentinel can be returned with a more specific message or metadata.
+type DomainError struct {
+	Code    string // e.g. "account.already_verified"
+	Kind    ErrorKind
+	Message string
+	Meta    map[string]string
+	Err     error // Underlying cause, if any
+}
+
+func NewDomainError(code string, kind ErrorKind, message string) *DomainError {
+	return &DomainError{Code: code, Kind: kind, Message: message}
+}
+
+func (e *DomainError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *DomainError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches any DomainError with the same code
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
+// With returns a copy carrying an extra metadata entry
+func (e *DomainError) With(key, value string) *DomainError {
+	c := *e
+	c.Meta = maps.Clone(e.Meta)
+	if c.Meta == nil {
+		c.Meta = map[string]string{}
+	}
+	c.Meta[key] = value
+	return &c
+}
+
+// WithMessage returns a copy with a more specific message and the same code
+func (e *DomainError) WithMessage(message string) *DomainError {
+	c := *e
+	c.Message = message
+	return &c
+}
+
+// Wrap returns a copy with err as its cause
+func (e *DomainError) Wrap(err error) *DomainError {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// AsDomainError finds the outermost DomainError in err's chain
+func AsDomainError(err error) (*DomainError, bool) {
+	var de *DomainError
+	if errors.As(err, &de) {
+		return de, true
+	}
+	return nil, false
+}
+
+// ErrorKindOf returns the kind of the domain error in err's chain, empty for other errors
+func ErrorKindOf(err error) ErrorKind {
+	if de, ok := AsDomainError(err); ok {
+		return de.Kind
+	}
+	return ""
+}
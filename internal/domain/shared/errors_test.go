@@ -0,0 +1,44 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errTestAlreadyDone = NewDomainError("test.already_done", KindConflict, "already done")
+
+func TestDomainError_Is(t *testing.T) {
+	specific := errTestAlreadyDone.WithMessage("article is already published").With("article_id", "a1")
+	wrapped := fmt.Errorf("failed to publish: %w", specific)
+
+	if !errors.Is(wrapped, errTestAlreadyDone) {
+		t.Error("expected errors with the same code to match")
+	}
+	if errors.Is(wrapped, NewDomainError("test.other", KindConflict, "already done")) {
+		t.Error("expected errors with another code not to match")
+	}
+
+	de, ok := AsDomainError(wrapped)
+	if !ok || de.Code != "test.already_done" || de.Meta["article_id"] != "a1" || de.Message != "article is already published" {
+		t.Errorf("unexpected domain error %+v", de)
+	}
+	if errTestAlreadyDone.Meta != nil || errTestAlreadyDone.Message != "already done" {
+		t.Error("expected the sentinel untouched")
+	}
+	if ErrorKindOf(wrapped) != KindConflict || ErrorKindOf(errors.New("plain")) != "" {
+		t.Error("unexpected kind")
+	}
+}
+
+func TestDomainError_Wrap(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := errTestAlreadyDone.Wrap(cause)
+
+	if !errors.Is(err, cause) || !errors.Is(err, errTestAlreadyDone) {
+		t.Error("expected both the cause and the code to match")
+	}
+	if err.Error() != "already done: connection reset" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
@@ -0,0 +1,220 @@
+package shared
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IDStrategy is how an ID is generated
+type IDStrategy string
+
+const (
+	StrategyUUIDv4 IDStrategy = "uuidv4" // Random, the default
+	StrategyUUIDv7 IDStrategy = "uuidv7" // Time-ordered, friendlier to B-tree indexes
+	StrategyULID   IDStrategy = "ulid"   // Time-ordered, 26 characters of Crockford base32
+)
+
+var (
+	ErrInvalidID       = errors.New("invalid ID")
+	ErrIDGeneration    = errors.New("failed to generate ID")
+	ErrUnknownStrategy = errors.New("unknown ID strategy")
+	ErrInvalidPrefix   = errors.New("ID prefix must be 2 to 8 lowercase letters")
+	ErrDuplicatePrefix = errors.New("ID prefix is used by another entity type")
+)
+
+var prefixRegex = regexp.MustCompile(`^[a-z]{2,8}$`)
+
+// IDGenerator creates IDs of one format and validates IDs claiming to be of it.
+// Parse failures are ErrInvalidID, generation failures ErrIDGeneration.
+type IDGenerator interface {
+	NewID() (string, error)
+
+	// Parse returns the canonical form of an ID, e.g. lowercased for UUIDs
+	Parse(value string) (string, error)
+}
+
+// NewIDGenerator returns a generator for the strategy; with a prefix IDs look like acc_<id>
+func NewIDGenerator(strategy IDStrategy, prefix string) (IDGenerator, error) {
+	var g IDGenerator
+	switch strategy {
+	case StrategyUUIDv4:
+		g = uuidGenerator{version: 4}
+	case StrategyUUIDv7:
+		g = uuidGenerator{version: 7}
+	case StrategyULID:
+		g = ulidGenerator{clock: SystemClock{}}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, strategy)
+	}
+	if prefix == "" {
+		return g, nil
+	}
+	if !prefixRegex.MatchString(prefix) {
+		return nil, ErrInvalidPrefix
+	}
+	return prefixedGenerator{prefix: prefix + "_", base: g}, nil
+}
+
+type uuidGenerator struct {
+	version uuid.Version
+}
+
+func (g uuidGenerator) NewID() (string, error) {
+	var id uuid.UUID
+	var err error
+	if g.version == 7 {
+		id, err = uuid.NewV7()
+	} else {
+		id, err = uuid.NewRandom()
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrIDGeneration, err)
+	}
+	return id.String(), nil
+}
+
+func (g uuidGenerator) Parse(value string) (string, error) {
+	id, err := uuid.Parse(value)
+	if err != nil || id.Version() != g.version || len(value) != 36 {
+		return "", fmt.Errorf("%w: not a UUIDv%d", ErrInvalidID, g.version)
+	}
+	return id.String(), nil
+}
+
+// crockford is the ULID alphabet, without I, L, O and U
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+type ulidGenerator struct {
+	clock Clock
+}
+
+// NewID packs 48 bits of milliseconds and 80 random bits into 26 characters
+func (g ulidGenerator) NewID() (string, error) {
+	var b [16]byte
+	ms := uint64(g.clock.Now().UnixMilli())
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrIDGeneration, err)
+	}
+
+	var out [26]byte
+	// 128 bits as 26 five-bit groups, the first group holds only the top 3 bits
+	hi := uint64(b[0])<<56 | uint64(b[1])<<48 | uint64(b[2])<<40 | uint64(b[3])<<32 | uint64(b[4])<<24 | uint64(b[5])<<16 | uint64(b[6])<<8 | uint64(b[7])
+	lo := uint64(b[8])<<56 | uint64(b[9])<<48 | uint64(b[10])<<40 | uint64(b[11])<<32 | uint64(b[12])<<24 | uint64(b[13])<<16 | uint64(b[14])<<8 | uint64(b[15])
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:]), nil
+}
+
+func (g ulidGenerator) Parse(value string) (string, error) {
+	value = strings.ToUpper(value)
+	if len(value) != 26 || value[0] > '7' {
+		return "", fmt.Errorf("%w: not a ULID", ErrInvalidID)
+	}
+	for i := 0; i < len(value); i++ {
+		if strings.IndexByte(crockford, value[i]) < 0 {
+			return "", fmt.Errorf("%w: not a ULID", ErrInvalidID)
+		}
+	}
+	return value, nil
+}
+
+// ULIDTime is when a ULID was generated
+func ULIDTime(id string) (time.Time, error) {
+	id, err := (ulidGenerator{}).Parse(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var ms int64
+	for i := 0; i < 10; i++ {
+		ms = ms<<5 | int64(strings.IndexByte(crockford, id[i]))
+	}
+	return time.UnixMilli(ms).UTC(), nil
+}
+
+type prefixedGenerator struct {
+	prefix string
+	base   IDGenerator
+}
+
+func (g prefixedGenerator) NewID() (string, error) {
+	id, err := g.base.NewID()
+	if err != nil {
+		return "", err
+	}
+	return g.prefix + id, nil
+}
+
+func (g prefixedGenerator) Parse(value string) (string, error) {
+	rest, ok := strings.CutPrefix(value, g.prefix)
+	if !ok {
+		return "", fmt.Errorf("%w: missing prefix %q", ErrInvalidID, g.prefix)
+	}
+	id, err := g.base.Parse(rest)
+	if err != nil {
+		return "", err
+	}
+	return g.prefix + id, nil
+}
+
+// EntityType names what an ID identifies, e.g. "account" or "article"
+type EntityType string
+
+// IDSpec configures the IDs of one entity type
+type IDSpec struct {
+	Strategy IDStrategy
+	Prefix   string
+}
+
+// IDRegistry hands out the generator configured for each entity type,
+// entity types without a spec get the fallback
+type IDRegistry struct {
+	fallback IDGenerator
+	byType   map[EntityType]IDGenerator
+}
+
+func NewIDRegistry(fallback IDSpec, specs map[EntityType]IDSpec) (*IDRegistry, error) {
+	g, err := NewIDGenerator(fallback.Strategy, fallback.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("fallback: %w", err)
+	}
+	r := &IDRegistry{fallback: g, byType: make(map[EntityType]IDGenerator, len(specs))}
+
+	prefixes := map[string]EntityType{}
+	for entity, spec := range specs {
+		if other, ok := prefixes[spec.Prefix]; ok && spec.Prefix != "" {
+			return nil, fmt.Errorf("%w: %s and %s both use %q", ErrDuplicatePrefix, other, entity, spec.Prefix)
+		}
+		prefixes[spec.Prefix] = entity
+		if r.byType[entity], err = NewIDGenerator(spec.Strategy, spec.Prefix); err != nil {
+			return nil, fmt.Errorf("%s: %w", entity, err)
+		}
+	}
+	return r, nil
+}
+
+func (r *IDRegistry) For(entity EntityType) IDGenerator {
+	if g, ok := r.byType[entity]; ok {
+		return g
+	}
+	return r.fallback
+}
+
+func (r *IDRegistry) NewID(entity EntityType) (string, error) {
+	return r.For(entity).NewID()
+}
+
+func (r *IDRegistry) Parse(entity EntityType, value string) (string, error) {
+	return r.For(entity).Parse(strings.TrimSpace(value))
+}
@@ -0,0 +1,137 @@
+package shared
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIDGenerator(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy IDStrategy
+		prefix   string
+		length   int
+	}{
+		{"uuidv4", StrategyUUIDv4, "", 36},
+		{"uuidv7", StrategyUUIDv7, "", 36},
+		{"ulid", StrategyULID, "", 26},
+		{"prefixed ulid", StrategyULID, "acc", 30},
+		{"prefixed uuidv7", StrategyUUIDv7, "art", 40},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := NewIDGenerator(tc.strategy, tc.prefix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			id, err := g.NewID()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(id) != tc.length || !strings.HasPrefix(id, tc.prefix) {
+				t.Errorf("unexpected ID %q", id)
+			}
+			if parsed, err := g.Parse(id); err != nil || parsed != id {
+				t.Errorf("expected %q to parse as itself, got %q, %v", id, parsed, err)
+			}
+			if other, _ := g.NewID(); other == id {
+				t.Error("expected distinct IDs")
+			}
+		})
+	}
+}
+
+func TestIDGenerator_Parse(t *testing.T) {
+	v4, _ := NewIDGenerator(StrategyUUIDv4, "")
+	v7, _ := NewIDGenerator(StrategyUUIDv7, "")
+	ulid, _ := NewIDGenerator(StrategyULID, "")
+	accounts, _ := NewIDGenerator(StrategyULID, "acc")
+
+	testCases := []struct {
+		name      string
+		generator IDGenerator
+		value     string
+		expected  string
+		valid     bool
+	}{
+		{"uuidv4 normalized", v4, "6BA7B810-9DAD-41D1-80B4-00C04FD430C8", "6ba7b810-9dad-41d1-80b4-00c04fd430c8", true},
+		{"uuidv4 wrong version", v4, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "", false},
+		{"uuidv7 given a v4", v7, "6ba7b810-9dad-41d1-80b4-00c04fd430c8", "", false},
+		{"ulid lowercase", ulid, "01arz3ndektsv4rrffq69g5fav", "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"ulid overflow", ulid, "81ARZ3NDEKTSV4RRFFQ69G5FAV", "", false},
+		{"ulid bad character", ulid, "01ARZ3NDEKTSV4RRFFQ69G5FAU", "", false},
+		{"prefixed", accounts, "acc_01ARZ3NDEKTSV4RRFFQ69G5FAV", "acc_01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"wrong prefix", accounts, "art_01ARZ3NDEKTSV4RRFFQ69G5FAV", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := tc.generator.Parse(tc.value)
+			if !tc.valid {
+				if !errors.Is(err, ErrInvalidID) {
+					t.Errorf("expected error '%v', got '%v'", ErrInvalidID, err)
+				}
+				return
+			}
+			if err != nil || parsed != tc.expected {
+				t.Errorf("expected %q, got %q, %v", tc.expected, parsed, err)
+			}
+		})
+	}
+}
+
+func TestULIDTime(t *testing.T) {
+	at := time.Date(2025, 6, 1, 9, 0, 0, 123000000, time.UTC)
+	id, _ := ulidGenerator{clock: NewFrozenClock(at)}.NewID()
+
+	got, err := ULIDTime(id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Equal(at) {
+		t.Errorf("expected %s, got %s", at, got)
+	}
+
+	later, _ := ulidGenerator{clock: NewFrozenClock(at.Add(time.Millisecond))}.NewID()
+	if later <= id {
+		t.Errorf("expected ULIDs to sort by time, %s before %s", later, id)
+	}
+}
+
+func TestIDRegistry(t *testing.T) {
+	registry, err := NewIDRegistry(IDSpec{Strategy: StrategyUUIDv4}, map[EntityType]IDSpec{
+		"account": {Strategy: StrategyULID, Prefix: "acc"},
+		"article": {Strategy: StrategyUUIDv7, Prefix: "art"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	id, _ := registry.NewID("account")
+	if !strings.HasPrefix(id, "acc_") {
+		t.Errorf("expected an account ID, got %q", id)
+	}
+	if _, err := registry.Parse("article", id); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("expected an account ID rejected as article ID, got %v", err)
+	}
+	if id, _ := registry.NewID("comment"); len(id) != 36 {
+		t.Errorf("expected fallback UUID, got %q", id)
+	}
+
+	_, err = NewIDRegistry(IDSpec{Strategy: StrategyUUIDv4}, map[EntityType]IDSpec{
+		"account": {Strategy: StrategyULID, Prefix: "acc"},
+		"accrual": {Strategy: StrategyULID, Prefix: "acc"},
+	})
+	if !errors.Is(err, ErrDuplicatePrefix) {
+		t.Errorf("expected error '%v', got '%v'", ErrDuplicatePrefix, err)
+	}
+	if _, err := NewIDGenerator("snowflake", ""); !errors.Is(err, ErrUnknownStrategy) {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownStrategy, err)
+	}
+	if _, err := NewIDGenerator(StrategyULID, "Acc"); err != ErrInvalidPrefix {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidPrefix, err)
+	}
+}
@@ -0,0 +1,25 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrLocked = errors.New("lock is held by another process")
+
+// Locker runs fn while holding a lease on key across replicas, so work that
+// must not overlap, e.g. a migration or a scheduled job, runs once at a time.
+// A key held elsewhere returns ErrLocked without running fn. The lease is kept
+// alive while fn runs; the context passed to fn is cancelled if it is lost.
+// Domain interface (implementation will be in infrastructure layer)
+type Locker interface {
+	Run(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) error) error
+}
+
+// NoLock runs fn directly, for a single process and for tests
+type NoLock struct{}
+
+func (NoLock) Run(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,197 @@
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Keyring holds the signing keys of one scope.
+// URLs signed by a key below MinGeneration are revoked, whatever their expiry.
+type Keyring struct {
+	Scope         Scope
+	Keys          []Key
+	MinGeneration int
+	UpdatedAt     time.Time
+}
+
+func NewKeyring(scope Scope) (*Keyring, error) {
+	if !scope.IsValid() {
+		return nil, ErrInvalidScope
+	}
+	return &Keyring{Scope: scope, UpdatedAt: time.Now()}, nil
+}
+
+// Business Methods
+
+// Rotate makes the secret the active signing key. Keys retired longer than the
+// scope's MaxTTL ago are dropped since no URL they signed can still be valid.
+func (kr *Keyring) Rotate(keyID string, secret []byte, at time.Time) (*Key, error) {
+	if strings.TrimSpace(keyID) == "" {
+		return nil, errors.New("key ID cannot be empty")
+	}
+	if len(secret) < MinSecretLength {
+		return nil, ErrSecretTooShort
+	}
+
+	if kr.findKey(keyID) != nil {
+		return nil, errors.New("key ID is already in use")
+	}
+
+	generation := 1
+	kept := kr.Keys[:0]
+	for _, k := range kr.Keys {
+		if k.Generation >= generation {
+			generation = k.Generation + 1
+		}
+		if k.IsActive() {
+			k.RetiredAt = &at
+		}
+		if at.Sub(*k.RetiredAt) <= MaxTTL[kr.Scope] {
+			kept = append(kept, k)
+		}
+	}
+
+	key := Key{ID: keyID, Generation: generation, Secret: secret, CreatedAt: at}
+	kr.Keys = append(kept, key)
+	kr.UpdatedAt = time.Now()
+	return &key, nil
+}
+
+// RevokeThrough invalidates every URL signed with a key of the given generation or older.
+// The active key cannot be revoked this way; rotate first.
+func (kr *Keyring) RevokeThrough(generation int) error {
+	active := kr.ActiveKey()
+	if active == nil || generation < 1 || generation >= active.Generation {
+		return ErrInvalidGeneration
+	}
+	if generation+1 > kr.MinGeneration {
+		kr.MinGeneration = generation + 1
+	}
+	kr.UpdatedAt = time.Now()
+	return nil
+}
+
+// Sign returns rawURL with an expiry, key ID and signature appended.
+// A non-empty ip binds the URL to that client address.
+func (kr *Keyring) Sign(rawURL string, ttl time.Duration, ip string, at time.Time) (string, error) {
+	if ttl <= 0 || ttl > MaxTTL[kr.Scope] {
+		return "", ErrInvalidTTL
+	}
+	key := kr.ActiveKey()
+	if key == nil {
+		return "", ErrNoActiveKey
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	q := u.Query()
+	for _, param := range []string{ParamExpires, ParamKeyID, ParamBind, ParamSignature} {
+		q.Del(param)
+	}
+	q.Set(ParamExpires, strconv.FormatInt(at.Add(ttl).Unix(), 10))
+	q.Set(ParamKeyID, key.ID)
+	if ip != "" {
+		q.Set(ParamBind, "ip")
+	}
+	q.Set(ParamSignature, kr.signature(*key, u.Path, q, ip))
+
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// Query Methods
+
+// Verify checks a signed URL as received, clientIP is the address of the caller
+func (kr *Keyring) Verify(rawURL, clientIP string, at time.Time) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ErrMalformed
+	}
+	q := u.Query()
+
+	expires, err := strconv.ParseInt(q.Get(ParamExpires), 10, 64)
+	if err != nil || q.Get(ParamSignature) == "" {
+		return ErrMalformed
+	}
+
+	key := kr.findKey(q.Get(ParamKeyID))
+	if key == nil {
+		return ErrUnknownKey
+	}
+
+	ip := ""
+	if q.Get(ParamBind) == "ip" {
+		ip = clientIP
+	}
+
+	expected := kr.signature(*key, u.Path, q, ip)
+	if !hmac.Equal([]byte(expected), []byte(q.Get(ParamSignature))) {
+		if ip != "" {
+			return ErrIPMismatch
+		}
+		return ErrInvalidSignature
+	}
+
+	// Only reported once the signature proves the URL was issued by us
+	if key.Generation < kr.MinGeneration {
+		return ErrRevoked
+	}
+	if !at.Before(time.Unix(expires, 0)) {
+		return ErrExpired
+	}
+	return nil
+}
+
+func (kr *Keyring) ActiveKey() *Key {
+	for i := range kr.Keys {
+		if kr.Keys[i].IsActive() {
+			return &kr.Keys[i]
+		}
+	}
+	return nil
+}
+
+func (kr *Keyring) findKey(id string) *Key {
+	for i := range kr.Keys {
+		if kr.Keys[i].ID == id {
+			return &kr.Keys[i]
+		}
+	}
+	return nil
+}
+
+// signature is the HMAC-SHA256 over the scope, path, sorted query without the signature, and bound IP
+func (kr *Keyring) signature(key Key, path string, q url.Values, ip string) string {
+	names := make([]string, 0, len(q))
+	for name := range q {
+		if name != ParamSignature {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	canonical.WriteString(string(kr.Scope) + "\n" + path + "\n")
+	for _, name := range names {
+		values := append([]string(nil), q[name]...)
+		sort.Strings(values)
+		for _, v := range values {
+			canonical.WriteString(url.QueryEscape(name) + "=" + url.QueryEscape(v) + "&")
+		}
+	}
+	canonical.WriteString("\n" + ip)
+
+	mac := hmac.New(sha256.New, key.Secret)
+	mac.Write([]byte(canonical.String()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
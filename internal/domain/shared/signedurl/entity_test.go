@@ -0,0 +1,140 @@
+package signedurl
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func secret(b byte) []byte {
+	return bytes.Repeat([]byte{b}, MinSecretLength)
+}
+
+func createTestKeyring(t *testing.T, at time.Time) *Keyring {
+	kr, err := NewKeyring(ScopeExport)
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+	if _, err := kr.Rotate("k1", secret('a'), at); err != nil {
+		t.Fatalf("failed to rotate key: %v", err)
+	}
+	return kr
+}
+
+func TestNewKeyring(t *testing.T) {
+	if _, err := NewKeyring("thumbnails"); err != ErrInvalidScope {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidScope, err)
+	}
+
+	kr, _ := NewKeyring(ScopeMedia)
+	if _, err := kr.Sign("/media/1.jpg", time.Hour, "", time.Now()); err != ErrNoActiveKey {
+		t.Errorf("expected error '%v', got '%v'", ErrNoActiveKey, err)
+	}
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	at := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	kr := createTestKeyring(t, at)
+
+	if _, err := kr.Rotate("k2", []byte("short"), at); err != ErrSecretTooShort {
+		t.Errorf("expected error '%v', got '%v'", ErrSecretTooShort, err)
+	}
+	if _, err := kr.Rotate("k1", secret('b'), at); err == nil {
+		t.Error("expected error but got none")
+	}
+
+	k2, err := kr.Rotate("k2", secret('b'), at.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if k2.Generation != 2 || kr.ActiveKey().ID != "k2" || len(kr.Keys) != 2 {
+		t.Errorf("expected k2 active at generation 2, got %+v", kr.ActiveKey())
+	}
+
+	// k1 retired more than the export MaxTTL ago is pruned
+	if _, err := kr.Rotate("k3", secret('c'), at.Add(8*24*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if kr.findKey("k1") != nil || kr.findKey("k2") == nil {
+		t.Error("expected k1 to be pruned and k2 kept")
+	}
+}
+
+func TestKeyring_SignAndVerify(t *testing.T) {
+	at := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+	kr := createTestKeyring(t, at)
+
+	signed, err := kr.Sign("https://cdn.example.com/exports/42.csv?format=csv", time.Hour, "", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bound, err := kr.Sign("https://cdn.example.com/exports/42.csv", time.Hour, "203.0.113.7", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tampered := strings.Replace(signed, "42.csv", "43.csv", 1)
+	extraParam := signed + "&format=xlsx"
+	u, _ := url.Parse(signed)
+	q := u.Query()
+	q.Del(ParamSignature)
+	u.RawQuery = q.Encode()
+	unsigned := u.String()
+
+	testCases := []struct {
+		name        string
+		url         string
+		ip          string
+		at          time.Time
+		expectedErr error
+	}{
+		{"valid", signed, "198.51.100.1", at.Add(30 * time.Minute), nil},
+		{"valid bound", bound, "203.0.113.7", at, nil},
+		{"expired", signed, "", at.Add(time.Hour), ErrExpired},
+		{"tampered path", tampered, "", at, ErrInvalidSignature},
+		{"tampered query", extraParam, "", at, ErrInvalidSignature},
+		{"missing signature", unsigned, "", at, ErrMalformed},
+		{"other IP", bound, "198.51.100.1", at, ErrIPMismatch},
+		{"unknown key", strings.Replace(signed, "kid=k1", "kid=k9", 1), "", at, ErrUnknownKey},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := kr.Verify(tc.url, tc.ip, tc.at); err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+
+	if _, err := kr.Sign("/exports/42.csv", 8*24*time.Hour, "", at); err != ErrInvalidTTL {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTTL, err)
+	}
+}
+
+func TestKeyring_RevokeThrough(t *testing.T) {
+	at := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.UTC)
+	kr := createTestKeyring(t, at)
+
+	old, _ := kr.Sign("/preview/abc", 24*time.Hour, "", at)
+	if err := kr.RevokeThrough(1); err != ErrInvalidGeneration {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidGeneration, err)
+	}
+
+	kr.Rotate("k2", secret('b'), at)
+	rotated, _ := kr.Sign("/preview/abc", 24*time.Hour, "", at)
+	if err := kr.Verify(old, "", at); err != nil {
+		t.Errorf("expected URLs from the retired key to stay valid, got '%v'", err)
+	}
+
+	if err := kr.RevokeThrough(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := kr.Verify(old, "", at); err != ErrRevoked {
+		t.Errorf("expected error '%v', got '%v'", ErrRevoked, err)
+	}
+	if err := kr.Verify(rotated, "", at); err != nil {
+		t.Errorf("expected current URLs to stay valid, got '%v'", err)
+	}
+}
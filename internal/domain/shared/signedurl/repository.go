@@ -0,0 +1,11 @@
+package signedurl
+
+import "context"
+
+// Domain interface for keyring storage, secrets must be encrypted at rest (implementation will be in infrastructure layer)
+type KeyringRepository interface {
+	Save(ctx context.Context, keyring *Keyring) error
+
+	// FindByScope returns nil when the scope has never been keyed
+	FindByScope(ctx context.Context, scope Scope) (*Keyring, error)
+}
@@ -0,0 +1,67 @@
+package signedurl
+
+import (
+	"errors"
+	"time"
+)
+
+type Scope string
+
+const (
+	ScopeMedia   Scope = "media"   // Private media downloads
+	ScopeExport  Scope = "export"  // Data exports
+	ScopePrint   Scope = "print"   // Print packages for the press
+	ScopePreview Scope = "preview" // Unpublished article previews
+)
+
+// Query parameters added to a signed URL
+const (
+	ParamExpires   = "expires"
+	ParamKeyID     = "kid"
+	ParamBind      = "bind"
+	ParamSignature = "sig"
+)
+
+// MinSecretLength is the shortest HMAC secret a key may use, in bytes
+const MinSecretLength = 32
+
+// Domain errors
+var (
+	ErrInvalidScope      = errors.New("invalid signed URL scope")
+	ErrSecretTooShort    = errors.New("signing secret must be at least 32 bytes")
+	ErrInvalidTTL        = errors.New("TTL must be positive and within the scope maximum")
+	ErrNoActiveKey       = errors.New("no active signing key")
+	ErrMalformed         = errors.New("signed URL is malformed")
+	ErrExpired           = errors.New("signed URL has expired")
+	ErrUnknownKey        = errors.New("signed URL key is unknown")
+	ErrRevoked           = errors.New("signed URL has been revoked")
+	ErrInvalidSignature  = errors.New("signed URL signature is invalid")
+	ErrIPMismatch        = errors.New("signed URL is bound to another IP address")
+	ErrInvalidGeneration = errors.New("only generations older than the active key can be revoked")
+)
+
+// MaxTTL is the longest a URL in the scope may stay valid
+var MaxTTL = map[Scope]time.Duration{
+	ScopeMedia:   24 * time.Hour,
+	ScopeExport:  7 * 24 * time.Hour,
+	ScopePrint:   72 * time.Hour,
+	ScopePreview: 7 * 24 * time.Hour,
+}
+
+func (s Scope) IsValid() bool {
+	_, ok := MaxTTL[s]
+	return ok
+}
+
+// Key is one HMAC secret, identified in URLs by its ID
+type Key struct {
+	ID         string
+	Generation int
+	Secret     []byte
+	CreatedAt  time.Time
+	RetiredAt  *time.Time // Set once a newer key takes over signing
+}
+
+func (k Key) IsActive() bool {
+	return k.RetiredAt == nil
+}
@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// OperationKind groups storage calls that share a time budget
+type OperationKind string
+
+const (
+	OperationRead   OperationKind = "read"
+	OperationWrite  OperationKind = "write"
+	OperationReport OperationKind = "report"
+)
+
+var ErrTimeout = errors.New("operation timed out")
+
+// TimeoutError reports which operation ran out of time and the budget it had
+type TimeoutError struct {
+	Operation string
+	Kind      OperationKind
+	Limit     time.Duration
+	Err       error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s %s timed out after %s", e.Kind, e.Operation, e.Limit)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrTimeout) and errors.Is(err, context.DeadlineExceeded) both match
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout || target == context.DeadlineExceeded
+}
+
+// IsTimeout reports whether err comes from a missed deadline, typed or raw from a context
+func IsTimeout(err error) bool {
+	return errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded)
+}
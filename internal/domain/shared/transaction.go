@@ -0,0 +1,17 @@
+package shared
+
+import "context"
+
+// Transactor runs a unit of work in one database transaction. Repositories called
+// with the context handed to fn join it; an error from fn rolls everything back.
+// Domain interface (implementation will be in infrastructure layer)
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// NoTransaction runs fn directly, for stores without transactions and for tests
+type NoTransaction struct{}
+
+func (NoTransaction) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,29 @@
+package shared
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidUUID is the ErrInvalidID of ParseUUID
+var ErrInvalidUUID = fmt.Errorf("%w: not a UUID", ErrInvalidID)
+
+// GenerateUUID returns a new random (v4) UUID string used as entity ID
+func GenerateUUID() (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrIDGeneration, err)
+	}
+	return id.String(), nil
+}
+
+// ParseUUID validates and normalizes a UUID string of any version. Entities
+// with a configured ID strategy should use their IDGenerator's Parse instead.
+func ParseUUID(value string) (string, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return "", ErrInvalidUUID
+	}
+	return id.String(), nil
+}
@@ -0,0 +1,35 @@
+package shared
+
+import "testing"
+
+func TestGenerateUUID(t *testing.T) {
+	id, err := GenerateUUID()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := ParseUUID(id)
+	if err != nil {
+		t.Fatalf("expected generated UUID to parse: %v", err)
+	}
+	if parsed != id {
+		t.Errorf("expected %s, got %s", id, parsed)
+	}
+
+	other, _ := GenerateUUID()
+	if other == id {
+		t.Error("expected distinct UUIDs")
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	parsed, err := ParseUUID("6BA7B810-9DAD-11D1-80B4-00C04FD430C8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parsed != "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("expected normalized UUID, got %s", parsed)
+	}
+	if _, err := ParseUUID("not-a-uuid"); err != ErrInvalidUUID {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidUUID, err)
+	}
+}
@@ -0,0 +1,113 @@
+package account
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// redactedHash is what a password hash looks like in JSON, whatever it is
+const redactedHash = "[redacted]"
+
+var ErrPasswordHashJSON = shared.NewDomainError("account.password_hash_from_json", shared.KindForbidden, "password hash cannot be set from JSON")
+
+// JSON decoding goes through the constructors, so a request body is validated
+// the same way as any other input. Scanning from the database does not, stored
+// values are trusted like in ReconstituteUserAccount. Each type is a
+// driver.Valuer, so repositories pass it to a query as is.
+
+func (u Username) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.value)
+}
+
+func (u *Username) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := NewUsername(value)
+	if err != nil {
+		return err
+	}
+	*u = *parsed
+	return nil
+}
+
+func (u Username) Value() (driver.Value, error) {
+	return u.value, nil
+}
+
+func (u *Username) Scan(src any) error {
+	value, err := scanString(src, "username")
+	if err != nil {
+		return err
+	}
+	*u = ReconstituteUsername(value)
+	return nil
+}
+
+func (e Email) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.value)
+}
+
+func (e *Email) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := NewEmail(value)
+	if err != nil {
+		return err
+	}
+	*e = *parsed
+	return nil
+}
+
+func (e Email) Value() (driver.Value, error) {
+	return e.value, nil
+}
+
+func (e *Email) Scan(src any) error {
+	value, err := scanString(src, "email")
+	if err != nil {
+		return err
+	}
+	*e = ReconstituteEmail(value)
+	return nil
+}
+
+// MarshalJSON never writes the hash, an account can be logged or returned as is
+func (h PasswordHash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redactedHash)
+}
+
+// UnmarshalJSON refuses, hashes are only ever set through UpdatePasswordHash
+func (h *PasswordHash) UnmarshalJSON(data []byte) error {
+	return ErrPasswordHashJSON
+}
+
+func (h PasswordHash) Value() (driver.Value, error) {
+	return h.value, nil
+}
+
+func (h *PasswordHash) Scan(src any) error {
+	value, err := scanString(src, "password hash")
+	if err != nil {
+		return err
+	}
+	*h = NewPasswordHash(value)
+	return nil
+}
+
+func scanString(src any, name string) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("cannot scan %T into %s", src, name)
+	}
+}
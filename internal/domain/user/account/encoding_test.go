@@ -0,0 +1,96 @@
+package account
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValueObjects_JSON(t *testing.T) {
+	account, _ := NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "$argon2id$secret")
+
+	data, err := json.Marshal(account)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), "secret") || !strings.Contains(string(data), `"PasswordHash":"[redacted]"`) {
+		t.Errorf("expected the password hash redacted, got %s", data)
+	}
+	if !strings.Contains(string(data), `"Username":"member_user"`) || !strings.Contains(string(data), `"Email":"member@example.com"`) {
+		t.Errorf("expected username and email as strings, got %s", data)
+	}
+
+	var body struct {
+		Username Username
+		Email    Email
+	}
+	if err := json.Unmarshal([]byte(`{"Username":"new_name","Email":"New@Example.com"}`), &body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Username.String() != "new_name" || body.Email.String() != "new@example.com" {
+		t.Errorf("unexpected decoded values %+v", body)
+	}
+
+	tests := []struct {
+		name  string
+		json  string
+		errIs error
+	}{
+		{"short username", `{"Username":"ab"}`, ErrUsernameTooShort},
+		{"invalid email", `{"Email":"not-an-email"}`, ErrInvalidEmail},
+		{"password hash", `{"PasswordHash":"$argon2id$forged"}`, ErrPasswordHashJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded UserAccount
+			if err := json.Unmarshal([]byte(tt.json), &decoded); !errors.Is(err, tt.errIs) {
+				t.Errorf("expected error '%v', got '%v'", tt.errIs, err)
+			}
+		})
+	}
+}
+
+func TestValueObjects_Scan(t *testing.T) {
+	var username Username
+	var email Email
+	var hash PasswordHash
+
+	// Stored values are not re-validated
+	if err := username.Scan([]byte("ab")); err != nil || username.String() != "ab" {
+		t.Errorf("expected legacy username scanned, got %q %v", username.String(), err)
+	}
+	if err := email.Scan("member@example.com"); err != nil || email.String() != "member@example.com" {
+		t.Errorf("expected email scanned, got %q %v", email.String(), err)
+	}
+	if err := hash.Scan("$argon2id$secret"); err != nil || hash.Encoded() != "$argon2id$secret" {
+		t.Errorf("expected hash scanned, got %v", err)
+	}
+	if err := username.Scan(nil); err == nil {
+		t.Error("expected NULL rejected")
+	}
+}
+
+func TestValueObjects_Value(t *testing.T) {
+	email := ReconstituteEmail("member@example.com")
+	testCases := []struct {
+		name     string
+		valuer   driver.Valuer
+		expected string
+	}{
+		{"username", ReconstituteUsername("member_user"), "member_user"},
+		{"email", email, "member@example.com"},
+		{"email pointer", &email, "member@example.com"},
+		{"password hash", NewPasswordHash("$argon2id$secret"), "$argon2id$secret"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := tc.valuer.Value()
+			if err != nil || value != tc.expected {
+				t.Errorf("expected %q, got %v %v", tc.expected, value, err)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package account
 
 import (
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
 )
 
 func TestNewUserAccountWithHash(t *testing.T) {
@@ -15,7 +18,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 		accountType    UserAccountType
 		registeredBy   string
 		wantErr        bool
-		errMsg         string
+		errIs          error
 	}{
 		// Test all account types
 		{
@@ -78,7 +81,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			accountType:    TypeInternal,
 			registeredBy:   "admin123",
 			wantErr:        true,
-			errMsg:         "ID cannot be empty",
+			errIs:          ErrEmptyID,
 		},
 		{
 			name:           "invalid username - too short",
@@ -89,7 +92,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			accountType:    TypeInternal,
 			registeredBy:   "admin123",
 			wantErr:        true,
-			errMsg:         "username must be at least 3 characters",
+			errIs:          ErrUsernameTooShort,
 		},
 		{
 			name:           "invalid username - too long",
@@ -100,7 +103,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			accountType:    TypeInternal,
 			registeredBy:   "admin123",
 			wantErr:        true,
-			errMsg:         "username cannot exceed 30 characters",
+			errIs:          ErrUsernameTooLong,
 		},
 		{
 			name:           "invalid username - special chars",
@@ -111,7 +114,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			accountType:    TypeInternal,
 			registeredBy:   "admin123",
 			wantErr:        true,
-			errMsg:         "username can only contain letters, numbers, and underscore",
+			errIs:          ErrUsernameInvalidChars,
 		},
 		{
 			name:           "invalid email",
@@ -122,7 +125,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			accountType:    TypeInternal,
 			registeredBy:   "admin123",
 			wantErr:        true,
-			errMsg:         "invalid email format",
+			errIs:          ErrInvalidEmail,
 		},
 		{
 			name:           "empty password hash",
@@ -133,7 +136,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			accountType:    TypeInternal,
 			registeredBy:   "admin123",
 			wantErr:        true,
-			errMsg:         "password hash cannot be empty",
+			errIs:          ErrEmptyPasswordHash,
 		},
 		{
 			name:           "invalid account type",
@@ -144,7 +147,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			accountType:    "invalid_type",
 			registeredBy:   "admin123",
 			wantErr:        true,
-			errMsg:         "invalid account type",
+			errIs:          ErrInvalidAccountType,
 		},
 		{
 			name:           "empty registeredBy",
@@ -155,7 +158,7 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			accountType:    TypeInternal,
 			registeredBy:   "",
 			wantErr:        true,
-			errMsg:         "registeredBy cannot be empty",
+			errIs:          ErrEmptyRegisteredBy,
 		},
 	}
 
@@ -166,8 +169,8 @@ func TestNewUserAccountWithHash(t *testing.T) {
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
-				} else if err.Error() != tt.errMsg {
-					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				} else if !errors.Is(err, tt.errIs) {
+					t.Errorf("expected error '%v', got '%v'", tt.errIs, err)
 				}
 			} else {
 				if err != nil {
@@ -206,7 +209,7 @@ func TestNewUserAccountForTesting(t *testing.T) {
 		accountType  UserAccountType
 		registeredBy string
 		wantErr      bool
-		errMsg       string
+		errIs        error
 	}{
 		{
 			name:         "valid password",
@@ -227,7 +230,7 @@ func TestNewUserAccountForTesting(t *testing.T) {
 			accountType:  TypeInternal,
 			registeredBy: "admin123",
 			wantErr:      true,
-			errMsg:       "password must be at least 8 characters",
+			errIs:        ErrPasswordTooShort,
 		},
 		{
 			name:         "password missing uppercase",
@@ -238,7 +241,7 @@ func TestNewUserAccountForTesting(t *testing.T) {
 			accountType:  TypeInternal,
 			registeredBy: "admin123",
 			wantErr:      true,
-			errMsg:       "password must contain uppercase, lowercase, number, and special character",
+			errIs:        ErrPasswordTooWeak,
 		},
 		{
 			name:         "password missing lowercase",
@@ -249,7 +252,7 @@ func TestNewUserAccountForTesting(t *testing.T) {
 			accountType:  TypeInternal,
 			registeredBy: "admin123",
 			wantErr:      true,
-			errMsg:       "password must contain uppercase, lowercase, number, and special character",
+			errIs:        ErrPasswordTooWeak,
 		},
 		{
 			name:         "password missing number",
@@ -260,7 +263,7 @@ func TestNewUserAccountForTesting(t *testing.T) {
 			accountType:  TypeInternal,
 			registeredBy: "admin123",
 			wantErr:      true,
-			errMsg:       "password must contain uppercase, lowercase, number, and special character",
+			errIs:        ErrPasswordTooWeak,
 		},
 		{
 			name:         "password missing special char",
@@ -271,7 +274,7 @@ func TestNewUserAccountForTesting(t *testing.T) {
 			accountType:  TypeInternal,
 			registeredBy: "admin123",
 			wantErr:      true,
-			errMsg:       "password must contain uppercase, lowercase, number, and special character",
+			errIs:        ErrPasswordTooWeak,
 		},
 	}
 
@@ -282,8 +285,8 @@ func TestNewUserAccountForTesting(t *testing.T) {
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
-				} else if err.Error() != tt.errMsg {
-					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				} else if !errors.Is(err, tt.errIs) {
+					t.Errorf("expected error '%v', got '%v'", tt.errIs, err)
 				}
 			} else {
 				if err != nil {
@@ -294,8 +297,8 @@ func TestNewUserAccountForTesting(t *testing.T) {
 				}
 				// Verify password hash format for testing
 				expectedHash := "hashed_" + tt.password
-				if account.PasswordHash.Value() != expectedHash {
-					t.Errorf("expected password hash %s, got %s", expectedHash, account.PasswordHash.Value())
+				if account.PasswordHash.Encoded() != expectedHash {
+					t.Errorf("expected password hash %s, got %s", expectedHash, account.PasswordHash.Encoded())
 				}
 			}
 		})
@@ -333,7 +336,7 @@ func TestUserAccount_Verify(t *testing.T) {
 		setup      func(*UserAccount)
 		verifierID string
 		wantErr    bool
-		errMsg     string
+		errIs      error
 	}{
 		{
 			name:       "successful verification",
@@ -348,7 +351,7 @@ func TestUserAccount_Verify(t *testing.T) {
 			},
 			verifierID: "admin123",
 			wantErr:    true,
-			errMsg:     "user account is not pending verification",
+			errIs:      ErrNotPendingVerification,
 		},
 		{
 			name: "already verified account",
@@ -357,14 +360,14 @@ func TestUserAccount_Verify(t *testing.T) {
 			},
 			verifierID: "admin123",
 			wantErr:    true,
-			errMsg:     "user account is already verified",
+			errIs:      ErrAlreadyVerified,
 		},
 		{
 			name:       "empty verifier ID",
 			setup:      func(ua *UserAccount) {},
 			verifierID: "",
 			wantErr:    true,
-			errMsg:     "verifier ID cannot be empty",
+			errIs:      ErrEmptyActorID,
 		},
 	}
 
@@ -378,8 +381,8 @@ func TestUserAccount_Verify(t *testing.T) {
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
-				} else if err.Error() != tt.errMsg {
-					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				} else if !errors.Is(err, tt.errIs) {
+					t.Errorf("expected error '%v', got '%v'", tt.errIs, err)
 				}
 			} else {
 				if err != nil {
@@ -408,7 +411,7 @@ func TestUserAccount_SelfVerify(t *testing.T) {
 		accountType UserAccountType
 		setup       func(*UserAccount)
 		wantErr     bool
-		errMsg      string
+		errIs       error
 	}{
 		{
 			name:        "successful self-verification for membership",
@@ -421,14 +424,14 @@ func TestUserAccount_SelfVerify(t *testing.T) {
 			accountType: TypeInternal,
 			setup:       func(ua *UserAccount) {},
 			wantErr:     true,
-			errMsg:      "self-verification only allowed for membership accounts",
+			errIs:       ErrSelfVerificationNotAllowed,
 		},
 		{
 			name:        "self-verification not allowed for external",
 			accountType: TypeExternal,
 			setup:       func(ua *UserAccount) {},
 			wantErr:     true,
-			errMsg:      "self-verification only allowed for membership accounts",
+			errIs:       ErrSelfVerificationNotAllowed,
 		},
 		{
 			name:        "already verified",
@@ -437,7 +440,7 @@ func TestUserAccount_SelfVerify(t *testing.T) {
 				ua.IsVerified = true
 			},
 			wantErr: true,
-			errMsg:  "user account is already verified",
+			errIs:   ErrAlreadyVerified,
 		},
 		{
 			name:        "not pending verification",
@@ -446,7 +449,7 @@ func TestUserAccount_SelfVerify(t *testing.T) {
 				ua.Status = StatusActive
 			},
 			wantErr: true,
-			errMsg:  "user account is not pending verification",
+			errIs:   ErrNotPendingVerification,
 		},
 	}
 
@@ -460,8 +463,8 @@ func TestUserAccount_SelfVerify(t *testing.T) {
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
-				} else if err.Error() != tt.errMsg {
-					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				} else if !errors.Is(err, tt.errIs) {
+					t.Errorf("expected error '%v', got '%v'", tt.errIs, err)
 				}
 			} else {
 				if err != nil {
@@ -490,7 +493,7 @@ func TestUserAccount_Disable(t *testing.T) {
 		disabilityType DisabilityType
 		reason         string
 		wantErr        bool
-		errMsg         string
+		errIs          error
 	}{
 		{
 			name:           "successful disable internal account",
@@ -538,7 +541,7 @@ func TestUserAccount_Disable(t *testing.T) {
 			disabilityType: DisabilityTypeSuspended,
 			reason:         "test",
 			wantErr:        true,
-			errMsg:         "cannot disable deleted account",
+			errIs:          ErrAccountDeleted,
 		},
 		{
 			name:           "disable unverified account",
@@ -550,7 +553,7 @@ func TestUserAccount_Disable(t *testing.T) {
 			disabilityType: DisabilityTypeSuspended,
 			reason:         "test",
 			wantErr:        true,
-			errMsg:         "cannot disable unverified account",
+			errIs:          ErrAccountUnverified,
 		},
 		{
 			name:           "already disabled with same type",
@@ -564,7 +567,7 @@ func TestUserAccount_Disable(t *testing.T) {
 			disabilityType: DisabilityTypeSuspended,
 			reason:         "test again",
 			wantErr:        true,
-			errMsg:         "user account is already disabled with the same type",
+			errIs:          ErrAlreadyDisabled,
 		},
 		{
 			name:           "empty disabler ID",
@@ -577,7 +580,7 @@ func TestUserAccount_Disable(t *testing.T) {
 			disabilityType: DisabilityTypeSuspended,
 			reason:         "test",
 			wantErr:        true,
-			errMsg:         "disabler ID cannot be empty",
+			errIs:          ErrEmptyActorID,
 		},
 		{
 			name:           "empty reason",
@@ -590,7 +593,7 @@ func TestUserAccount_Disable(t *testing.T) {
 			disabilityType: DisabilityTypeSuspended,
 			reason:         "",
 			wantErr:        true,
-			errMsg:         "reason cannot be empty",
+			errIs:          ErrEmptyReason,
 		},
 		{
 			name:           "invalid disability type",
@@ -603,7 +606,7 @@ func TestUserAccount_Disable(t *testing.T) {
 			disabilityType: "invalid_type",
 			reason:         "test",
 			wantErr:        true,
-			errMsg:         "invalid disability type",
+			errIs:          ErrInvalidDisabilityType,
 		},
 	}
 
@@ -617,8 +620,8 @@ func TestUserAccount_Disable(t *testing.T) {
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
-				} else if err.Error() != tt.errMsg {
-					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				} else if !errors.Is(err, tt.errIs) {
+					t.Errorf("expected error '%v', got '%v'", tt.errIs, err)
 				}
 			} else {
 				if err != nil {
@@ -633,6 +636,9 @@ func TestUserAccount_Disable(t *testing.T) {
 				if account.IssuedReason == nil || *account.IssuedReason != tt.reason {
 					t.Error("expected reason to be set")
 				}
+				if account.DisabledAt == nil {
+					t.Error("expected disabledAt to be set")
+				}
 			}
 		})
 	}
@@ -645,7 +651,7 @@ func TestUserAccount_Reactivate(t *testing.T) {
 		setup         func(*UserAccount)
 		reactivatorID string
 		wantErr       bool
-		errMsg        string
+		errIs         error
 	}{
 		{
 			name:        "successful reactivation internal",
@@ -688,7 +694,7 @@ func TestUserAccount_Reactivate(t *testing.T) {
 			},
 			reactivatorID: "admin123",
 			wantErr:       true,
-			errMsg:        "user account is not disabled, cannot be reactivated",
+			errIs:         ErrNotDisabled,
 		},
 		{
 			name:        "empty reactivator ID",
@@ -698,7 +704,7 @@ func TestUserAccount_Reactivate(t *testing.T) {
 			},
 			reactivatorID: "",
 			wantErr:       true,
-			errMsg:        "reactivator ID cannot be empty",
+			errIs:         ErrEmptyActorID,
 		},
 	}
 
@@ -712,8 +718,8 @@ func TestUserAccount_Reactivate(t *testing.T) {
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
-				} else if err.Error() != tt.errMsg {
-					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				} else if !errors.Is(err, tt.errIs) {
+					t.Errorf("expected error '%v', got '%v'", tt.errIs, err)
 				}
 			} else {
 				if err != nil {
@@ -728,6 +734,9 @@ func TestUserAccount_Reactivate(t *testing.T) {
 				if account.IssuedReason != nil {
 					t.Error("expected reason to be cleared")
 				}
+				if account.DisabledAt != nil {
+					t.Error("expected disabledAt to be cleared")
+				}
 			}
 		})
 	}
@@ -740,7 +749,7 @@ func TestUserAccount_Delete(t *testing.T) {
 		setup       func(*UserAccount)
 		deleterID   string
 		wantErr     bool
-		errMsg      string
+		errIs       error
 	}{
 		{
 			name:        "successful deletion internal",
@@ -771,7 +780,7 @@ func TestUserAccount_Delete(t *testing.T) {
 			},
 			deleterID: "admin123",
 			wantErr:   true,
-			errMsg:    "user account is already deleted",
+			errIs:     ErrAlreadyDeleted,
 		},
 		{
 			name:        "empty deleter ID",
@@ -779,7 +788,7 @@ func TestUserAccount_Delete(t *testing.T) {
 			setup:       func(ua *UserAccount) {},
 			deleterID:   "",
 			wantErr:     true,
-			errMsg:      "deleter ID cannot be empty",
+			errIs:       ErrEmptyActorID,
 		},
 	}
 
@@ -793,8 +802,8 @@ func TestUserAccount_Delete(t *testing.T) {
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
-				} else if err.Error() != tt.errMsg {
-					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				} else if !errors.Is(err, tt.errIs) {
+					t.Errorf("expected error '%v', got '%v'", tt.errIs, err)
 				}
 			} else {
 				if err != nil {
@@ -888,7 +897,7 @@ func TestUserAccount_UpdateMethods(t *testing.T) {
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
-		if account.Username.Value() != "newusername" {
+		if account.Username.String() != "newusername" {
 			t.Error("expected username to be updated")
 		}
 		
@@ -912,7 +921,7 @@ func TestUserAccount_UpdateMethods(t *testing.T) {
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
-		if account.Email.Value() != "newemail@example.com" {
+		if account.Email.String() != "newemail@example.com" {
 			t.Error("expected email to be updated")
 		}
 		
@@ -1181,6 +1190,111 @@ func TestUserAccount_ConvenienceMethods(t *testing.T) {
 	}
 }
 
+func TestUserAccount_SuspendFor(t *testing.T) {
+	account := createTestAccount(t, TypeMembership)
+	if err := account.SelfVerify(); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+
+	if err := account.SuspendFor("mod1", "spam", 0); err == nil {
+		t.Error("expected error for zero duration")
+	}
+
+	if err := account.SuspendFor("mod1", "spam", 7*24*time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !account.IsSuspended() || account.SuspendedUntil == nil {
+		t.Fatal("expected timed suspension")
+	}
+	firstUntil := *account.SuspendedUntil
+
+	if account.IsSuspensionElapsed(time.Now()) {
+		t.Error("expected suspension not to have elapsed")
+	}
+	if err := account.ExpireSuspension("system", time.Now()); err == nil {
+		t.Error("expected error expiring a running suspension")
+	}
+
+	// A longer suspension extends the running one, a shorter one does not shorten it
+	if err := account.SuspendFor("mod2", "repeat spam", 30*24*time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !account.SuspendedUntil.After(firstUntil) {
+		t.Error("expected suspension to be extended")
+	}
+	extended := *account.SuspendedUntil
+	_ = account.SuspendFor("mod2", "minor", time.Hour)
+	if !account.SuspendedUntil.Equal(extended) {
+		t.Error("expected shorter suspension not to shorten the running one")
+	}
+	if *account.IssuedReason != "minor" {
+		t.Errorf("expected latest reason, got %s", *account.IssuedReason)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	account.SuspendedUntil = &past
+	if !account.IsSuspensionElapsed(time.Now()) {
+		t.Error("expected suspension to have elapsed")
+	}
+	if err := account.ExpireSuspension("system", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !account.IsActive() || account.SuspendedUntil != nil {
+		t.Error("expected account to be reactivated with suspension cleared")
+	}
+
+	if err := account.Suspend("mod1", "indefinite"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := account.ExpireSuspension("system", time.Now()); !errors.Is(err, ErrSuspensionIndefinite) {
+		t.Errorf("expected error '%v', got '%v'", ErrSuspensionIndefinite, err)
+	}
+
+	if err := account.Block("mod1", "escalated"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if account.SuspendedUntil != nil {
+		t.Error("expected block to clear the suspension end")
+	}
+}
+
+func TestUserAccount_Clock(t *testing.T) {
+	clock := shared.NewFrozenClock(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	account := createTestAccount(t, TypeMembership)
+	account.SetClock(clock)
+	_ = account.SelfVerify()
+
+	for i := 0; i < 3; i++ {
+		_ = account.RecordFailedLogin("10.0.0.1", 3, 30*time.Minute)
+	}
+	if !account.IsLocked() || account.CanLogin() {
+		t.Fatal("expected account locked after 3 failures")
+	}
+	clock.Advance(29 * time.Minute)
+	if !account.IsLocked() {
+		t.Error("expected account still locked a minute before the lock ends")
+	}
+	clock.Advance(time.Minute)
+	if account.IsLocked() || !account.CanLogin() {
+		t.Error("expected lock over once the clock reaches its end")
+	}
+
+	if err := account.SuspendFor("mod1", "spam", 24*time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !account.DisabledAt.Equal(clock.Now()) {
+		t.Errorf("expected the suspension stamped with the clock, got %s", account.DisabledAt)
+	}
+	clock.Advance(23 * time.Hour)
+	if err := account.ExpireSuspension("system", clock.Now()); err == nil {
+		t.Error("expected suspension not yet elapsed")
+	}
+	clock.Advance(time.Hour)
+	if err := account.ExpireSuspension("system", clock.Now()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 // Helper function to create test account with specific type
 func createTestAccount(t *testing.T, accountType UserAccountType) *UserAccount {
 	var registeredBy string
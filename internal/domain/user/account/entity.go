@@ -1,11 +1,45 @@
 package account
 
 import (
-	"errors"
 	"strings"
 	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Domain errors
+var (
+	ErrEmptyID                    = shared.NewDomainError("account.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrEmptyPasswordHash          = shared.NewDomainError("account.password_hash_required", shared.KindValidation, "password hash cannot be empty")
+	ErrEmptyRegisteredBy          = shared.NewDomainError("account.registered_by_required", shared.KindValidation, "registeredBy cannot be empty")
+	ErrEmptyActorID               = shared.NewDomainError("account.actor_required", shared.KindValidation, "actor ID cannot be empty")
+	ErrEmptyReason                = shared.NewDomainError("account.reason_required", shared.KindValidation, "reason cannot be empty")
+	ErrEmptyIPAddress             = shared.NewDomainError("account.ip_required", shared.KindValidation, "IP address cannot be empty")
+	ErrInvalidAccountType         = shared.NewDomainError("account.invalid_type", shared.KindValidation, "invalid account type")
+	ErrInvalidDisabilityType      = shared.NewDomainError("account.invalid_disability_type", shared.KindValidation, "invalid disability type")
+	ErrInvalidSuspensionDuration  = shared.NewDomainError("account.invalid_suspension_duration", shared.KindValidation, "suspension duration must be greater than 0")
+	ErrInvalidMaxAttempts         = shared.NewDomainError("account.invalid_max_attempts", shared.KindValidation, "max attempts must be greater than 0")
+	ErrNotPendingVerification     = shared.NewDomainError("account.not_pending_verification", shared.KindState, "user account is not pending verification")
+	ErrNotDisabled                = shared.NewDomainError("account.not_disabled", shared.KindState, "user account is not disabled")
+	ErrNotSuspended               = shared.NewDomainError("account.not_suspended", shared.KindState, "user account is not suspended")
+	ErrSuspensionIndefinite       = shared.NewDomainError("account.suspension_indefinite", shared.KindState, "suspension has no end date")
+	ErrSuspensionNotElapsed       = shared.NewDomainError("account.suspension_not_elapsed", shared.KindState, "suspension has not elapsed yet")
+	ErrAccountDeleted             = shared.NewDomainError("account.deleted", shared.KindState, "cannot disable deleted account")
+	ErrAccountUnverified          = shared.NewDomainError("account.unverified", shared.KindState, "cannot disable unverified account")
+	ErrSelfVerificationNotAllowed = shared.NewDomainError("account.self_verification_not_allowed", shared.KindForbidden, "self-verification only allowed for membership accounts")
+	ErrAlreadyVerified            = shared.NewDomainError("account.already_verified", shared.KindConflict, "user account is already verified")
+	ErrAlreadyDisabled            = shared.NewDomainError("account.already_disabled", shared.KindConflict, "user account is already disabled with the same type")
+	ErrAlreadyDeleted             = shared.NewDomainError("account.already_deleted", shared.KindConflict, "user account is already deleted")
+	ErrUsernameUnchanged          = shared.NewDomainError("account.username_unchanged", shared.KindConflict, "new username is the same as current username")
+	ErrEmailUnchanged             = shared.NewDomainError("account.email_unchanged", shared.KindConflict, "new email is the same as current email")
+	ErrTypeUnchanged              = shared.NewDomainError("account.type_unchanged", shared.KindConflict, "new type is the same as current type")
 )
 
+// emptyActor names the missing actor, e.g. "verifier ID cannot be empty", matching ErrEmptyActorID
+func emptyActor(role string) error {
+	return ErrEmptyActorID.WithMessage(role+" ID cannot be empty").With("role", role)
+}
+
 type UserAccountStatus string
 
 const (
@@ -58,6 +92,8 @@ type UserAccount struct {
 	VerifiedBy     *string
 	VerifiedAt     *time.Time
 	IssuedReason   *string
+	DisabledAt     *time.Time
+	SuspendedUntil *time.Time // End of a time-boxed suspension, nil when indefinite
 
 	LastActionBy *string
 
@@ -68,17 +104,31 @@ type UserAccount struct {
 	LastFailedLoginIP      *string
 	LockedUntil            *time.Time
 
+	// Sessions created up to this moment are no longer valid, see Session.ValidFor
+	SessionsRevokedAt *time.Time
+
+	// Two-factor authentication, pending from EnableTOTP until TOTPConfirmedAt is set
+	TOTPSecret      *TOTPSecret
+	TOTPConfirmedAt *time.Time
+	TOTPLastStep    int64 // Time step of the last accepted code, each code works once
+	BackupCodes     BackupCodes
+
+	// Roles loaded with the account from its role assignments, see RoleRepository
+	Roles []Role
+
 	// Audit
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 	DeletedBy *string
+
+	clock shared.Clock // Wall clock unless set, see SetClock
 }
 
 // Constructor for production (receives pre-generated ID and hashed password)
 func NewUserAccountWithHash(id, username, email, hashedPassword string, accountType UserAccountType, registeredBy string) (*UserAccount, error) {
 	if strings.TrimSpace(id) == "" {
-		return nil, errors.New("ID cannot be empty")
+		return nil, ErrEmptyID
 	}
 
 	usernameObj, err := NewUsername(username)
@@ -92,7 +142,7 @@ func NewUserAccountWithHash(id, username, email, hashedPassword string, accountT
 	}
 
 	if strings.TrimSpace(hashedPassword) == "" {
-		return nil, errors.New("password hash cannot be empty")
+		return nil, ErrEmptyPasswordHash
 	}
 
 	if err := validateAccountType(accountType); err != nil {
@@ -100,7 +150,7 @@ func NewUserAccountWithHash(id, username, email, hashedPassword string, accountT
 	}
 
 	if strings.TrimSpace(registeredBy) == "" {
-		return nil, errors.New("registeredBy cannot be empty")
+		return nil, ErrEmptyRegisteredBy
 	}
 
 	now := time.Now()
@@ -121,7 +171,7 @@ func NewUserAccountWithHash(id, username, email, hashedPassword string, accountT
 // Constructor for testing (receives raw password)
 func NewUserAccountForTesting(id, username, email, rawPassword string, accountType UserAccountType, registeredBy string) (*UserAccount, error) {
 	if strings.TrimSpace(id) == "" {
-		return nil, errors.New("ID cannot be empty")
+		return nil, ErrEmptyID
 	}
 
 	usernameObj, err := NewUsername(username)
@@ -134,7 +184,7 @@ func NewUserAccountForTesting(id, username, email, rawPassword string, accountTy
 		return nil, err
 	}
 
-	if err := ValidatePassword(rawPassword); err != nil {
+	if err := ValidatePassword(rawPassword, DefaultPasswordPolicy); err != nil {
 		return nil, err
 	}
 
@@ -143,7 +193,7 @@ func NewUserAccountForTesting(id, username, email, rawPassword string, accountTy
 	}
 
 	if strings.TrimSpace(registeredBy) == "" {
-		return nil, errors.New("registeredBy cannot be empty")
+		return nil, ErrEmptyRegisteredBy
 	}
 
 	now := time.Now()
@@ -166,21 +216,34 @@ func NewUserAccountForSelfRegistration(id, username, email, hashedPassword strin
 	return NewUserAccountWithHash(id, username, email, hashedPassword, TypeMembership, SelfRegistration)
 }
 
+// SetClock makes the account read time from clock, e.g. a frozen clock in
+// tests. Services that load accounts pass on their own clock.
+func (ua *UserAccount) SetClock(clock shared.Clock) {
+	ua.clock = clock
+}
+
+func (ua *UserAccount) now() time.Time {
+	if ua.clock == nil {
+		return time.Now()
+	}
+	return ua.clock.Now()
+}
+
 // Business Methods
 
 // Verify marks account as verified and active
 func (ua *UserAccount) Verify(verifierID string) error {
 	if ua.Status != StatusPendingVerification {
-		return errors.New("user account is not pending verification")
+		return ErrNotPendingVerification
 	}
 	if ua.IsVerified {
-		return errors.New("user account is already verified")
+		return ErrAlreadyVerified
 	}
 	if strings.TrimSpace(verifierID) == "" {
-		return errors.New("verifier ID cannot be empty")
+		return emptyActor("verifier")
 	}
 
-	now := time.Now()
+	now := ua.now()
 	ua.IsVerified = true
 	ua.VerifiedBy = &verifierID
 	ua.VerifiedAt = &now
@@ -193,17 +256,17 @@ func (ua *UserAccount) Verify(verifierID string) error {
 // SelfVerify for email verification or similar self-service verification
 func (ua *UserAccount) SelfVerify() error {
 	if ua.Status != StatusPendingVerification {
-		return errors.New("user account is not pending verification")
+		return ErrNotPendingVerification
 	}
 	if ua.IsVerified {
-		return errors.New("user account is already verified")
+		return ErrAlreadyVerified
 	}
 	// Self-verification only allowed for membership type
 	if ua.Type != TypeMembership {
-		return errors.New("self-verification only allowed for membership accounts")
+		return ErrSelfVerificationNotAllowed
 	}
 
-	now := time.Now()
+	now := ua.now()
 	verifier := SelfRegistration
 	ua.IsVerified = true
 	ua.VerifiedBy = &verifier
@@ -217,16 +280,18 @@ func (ua *UserAccount) SelfVerify() error {
 // Activate activates a disabled account
 func (ua *UserAccount) Activate(activatorID string) error {
 	if ua.Status != StatusDisabled {
-		return errors.New("user account is not disabled")
+		return ErrNotDisabled
 	}
 	if strings.TrimSpace(activatorID) == "" {
-		return errors.New("activator ID cannot be empty")
+		return emptyActor("activator")
 	}
 
 	ua.Status = StatusActive
 	ua.DisabilityType = nil
 	ua.IssuedReason = nil
-	ua.UpdatedAt = time.Now()
+	ua.DisabledAt = nil
+	ua.SuspendedUntil = nil
+	ua.UpdatedAt = ua.now()
 	ua.LastActionBy = &activatorID
 	return nil
 }
@@ -234,28 +299,31 @@ func (ua *UserAccount) Activate(activatorID string) error {
 // Disable disables account with specific type and reason
 func (ua *UserAccount) Disable(disablerID string, disabilityType DisabilityType, reason string) error {
 	if ua.Status == StatusDeleted {
-		return errors.New("cannot disable deleted account")
+		return ErrAccountDeleted
 	}
 	if ua.Status == StatusPendingVerification {
-		return errors.New("cannot disable unverified account")
+		return ErrAccountUnverified
 	}
 	if ua.Status == StatusDisabled && ua.DisabilityType != nil && *ua.DisabilityType == disabilityType {
-		return errors.New("user account is already disabled with the same type")
+		return ErrAlreadyDisabled
 	}
 	if strings.TrimSpace(disablerID) == "" {
-		return errors.New("disabler ID cannot be empty")
+		return emptyActor("disabler")
 	}
 	if strings.TrimSpace(reason) == "" {
-		return errors.New("reason cannot be empty")
+		return ErrEmptyReason
 	}
 	if err := validateDisabilityType(disabilityType); err != nil {
 		return err
 	}
 
-	now := time.Now()
+	now := ua.now()
 	ua.Status = StatusDisabled
 	ua.DisabilityType = &disabilityType
 	ua.IssuedReason = &reason
+	ua.DisabledAt = &now
+	ua.SuspendedUntil = nil
+	ua.SessionsRevokedAt = &now
 	ua.UpdatedAt = now
 	ua.LastActionBy = &disablerID
 	return nil
@@ -270,6 +338,56 @@ func (ua *UserAccount) Suspend(userID string, reason string) error {
 	return ua.Disable(userID, DisabilityTypeSuspended, reason)
 }
 
+// SuspendFor suspends the account until the duration elapses.
+// A running timed suspension is extended, an indefinite one stays indefinite.
+func (ua *UserAccount) SuspendFor(userID string, reason string, duration time.Duration) error {
+	if duration <= 0 {
+		return ErrInvalidSuspensionDuration
+	}
+
+	if !ua.IsSuspended() {
+		if err := ua.Disable(userID, DisabilityTypeSuspended, reason); err != nil {
+			return err
+		}
+		until := ua.DisabledAt.Add(duration)
+		ua.SuspendedUntil = &until
+		return nil
+	}
+
+	if strings.TrimSpace(userID) == "" {
+		return emptyActor("disabler")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return ErrEmptyReason
+	}
+
+	now := ua.now()
+	if ua.SuspendedUntil != nil {
+		until := now.Add(duration)
+		if until.After(*ua.SuspendedUntil) {
+			ua.SuspendedUntil = &until
+		}
+	}
+	ua.IssuedReason = &reason
+	ua.UpdatedAt = now
+	ua.LastActionBy = &userID
+	return nil
+}
+
+// ExpireSuspension reactivates an account whose timed suspension has elapsed at the given time
+func (ua *UserAccount) ExpireSuspension(systemID string, at time.Time) error {
+	if !ua.IsSuspended() {
+		return ErrNotSuspended
+	}
+	if ua.SuspendedUntil == nil {
+		return ErrSuspensionIndefinite
+	}
+	if !ua.IsSuspensionElapsed(at) {
+		return ErrSuspensionNotElapsed
+	}
+	return ua.Reactivate(systemID)
+}
+
 func (ua *UserAccount) Block(userID string, reason string) error {
 	return ua.Disable(userID, DisabilityTypeBlocked, reason)
 }
@@ -289,16 +407,18 @@ func (ua *UserAccount) DisableManually(userID string, reason string) error {
 // Reactivate reactivates a disabled account
 func (ua *UserAccount) Reactivate(reactivatorID string) error {
 	if ua.Status != StatusDisabled {
-		return errors.New("user account is not disabled, cannot be reactivated")
+		return ErrNotDisabled.WithMessage("user account is not disabled, cannot be reactivated")
 	}
 	if strings.TrimSpace(reactivatorID) == "" {
-		return errors.New("reactivator ID cannot be empty")
+		return emptyActor("reactivator")
 	}
 
-	now := time.Now()
+	now := ua.now()
 	ua.Status = StatusActive
 	ua.DisabilityType = nil
 	ua.IssuedReason = nil
+	ua.DisabledAt = nil
+	ua.SuspendedUntil = nil
 	ua.UpdatedAt = now
 	ua.LastActionBy = &reactivatorID
 	return nil
@@ -307,21 +427,59 @@ func (ua *UserAccount) Reactivate(reactivatorID string) error {
 // Delete soft deletes the account
 func (ua *UserAccount) Delete(deleterID string) error {
 	if ua.Status == StatusDeleted {
-		return errors.New("user account is already deleted")
+		return ErrAlreadyDeleted
 	}
 	if strings.TrimSpace(deleterID) == "" {
-		return errors.New("deleter ID cannot be empty")
+		return emptyActor("deleter")
 	}
 
-	now := time.Now()
+	now := ua.now()
 	ua.Status = StatusDeleted
 	ua.DeletedAt = &now
 	ua.DeletedBy = &deleterID
+	ua.SessionsRevokedAt = &now
 	ua.UpdatedAt = now
 	ua.LastActionBy = &deleterID
 	return nil
 }
 
+// AssignRole gives the account a role its type allows
+func (ua *UserAccount) AssignRole(role Role, assignerID string) error {
+	if ua.Status == StatusDeleted {
+		return ErrAccountDeleted.WithMessage("cannot assign roles to deleted account")
+	}
+	if strings.TrimSpace(assignerID) == "" {
+		return emptyActor("assigner")
+	}
+	if !role.AllowsType(ua.Type) {
+		return ErrRoleNotAllowed.With("role", role.Name).With("type", string(ua.Type))
+	}
+	if ua.roleIndex(role.ID) >= 0 {
+		return ErrRoleAlreadyAssigned.With("role", role.Name)
+	}
+
+	ua.Roles = append(ua.Roles, role)
+	ua.UpdatedAt = ua.now()
+	ua.LastActionBy = &assignerID
+	return nil
+}
+
+// RevokeRole takes an assigned role away
+func (ua *UserAccount) RevokeRole(roleID string, revokerID string) error {
+	if strings.TrimSpace(revokerID) == "" {
+		return emptyActor("revoker")
+	}
+	i := ua.roleIndex(roleID)
+	if i < 0 {
+		return ErrRoleNotAssigned.With("role", roleID)
+	}
+
+	ua.Roles = append(ua.Roles[:i:i], ua.Roles[i+1:]...)
+	ua.UpdatedAt = ua.now()
+	ua.LastActionBy = &revokerID
+	return nil
+}
+
 // Update Methods
 
 func (ua *UserAccount) UpdateUsername(newUsername string) error {
@@ -330,10 +488,10 @@ func (ua *UserAccount) UpdateUsername(newUsername string) error {
 		return err
 	}
 	if ua.Username.Equals(*newUsernameObj) {
-		return errors.New("new username is the same as current username")
+		return ErrUsernameUnchanged
 	}
 	ua.Username = *newUsernameObj
-	ua.UpdatedAt = time.Now()
+	ua.UpdatedAt = ua.now()
 	return nil
 }
 
@@ -343,31 +501,58 @@ func (ua *UserAccount) UpdateEmail(newEmail string) error {
 		return err
 	}
 	if ua.Email.Equals(*newEmailObj) {
-		return errors.New("new email is the same as current email")
+		return ErrEmailUnchanged
 	}
 	ua.Email = *newEmailObj
-	ua.UpdatedAt = time.Now()
+	ua.UpdatedAt = ua.now()
 	return nil
 }
 
+// UpdatePasswordHash changes the password and signs the account out everywhere
 func (ua *UserAccount) UpdatePasswordHash(hashedPassword string) error {
 	if strings.TrimSpace(hashedPassword) == "" {
-		return errors.New("password hash cannot be empty")
+		return ErrEmptyPasswordHash
 	}
+	now := ua.now()
 	ua.PasswordHash = NewPasswordHash(hashedPassword)
-	ua.UpdatedAt = time.Now()
+	ua.SessionsRevokedAt = &now
+	ua.UpdatedAt = now
+	return nil
+}
+
+// ResetPasswordHash sets a new password through a reset, which also lifts a
+// failed-login lockout: whoever reset it has proven they own the email
+func (ua *UserAccount) ResetPasswordHash(hashedPassword string, actorID string) error {
+	if strings.TrimSpace(hashedPassword) == "" {
+		return ErrEmptyPasswordHash
+	}
+	if strings.TrimSpace(actorID) == "" {
+		return emptyActor("actor")
+	}
+	now := ua.now()
+	ua.PasswordHash = NewPasswordHash(hashedPassword)
+	ua.FailedLoginAttempts = 0
+	ua.LockedUntil = nil
+	ua.SessionsRevokedAt = &now
+	ua.UpdatedAt = now
+	ua.LastActionBy = &actorID
 	return nil
 }
 
 func (ua *UserAccount) UpdateType(newType UserAccountType) error {
 	if ua.Type == newType {
-		return errors.New("new type is the same as current type")
+		return ErrTypeUnchanged
 	}
 	if err := validateAccountType(newType); err != nil {
 		return err
 	}
+	for _, r := range ua.Roles {
+		if !r.AllowsType(newType) {
+			return ErrRoleBlocksType.With("role", r.Name)
+		}
+	}
 	ua.Type = newType
-	ua.UpdatedAt = time.Now()
+	ua.UpdatedAt = ua.now()
 	return nil
 }
 
@@ -375,9 +560,9 @@ func (ua *UserAccount) UpdateType(newType UserAccountType) error {
 
 func (ua *UserAccount) RecordSuccessfulLogin(ipAddress string) error {
 	if strings.TrimSpace(ipAddress) == "" {
-		return errors.New("IP address cannot be empty")
+		return ErrEmptyIPAddress
 	}
-	now := time.Now()
+	now := ua.now()
 	ua.LastLoginAt = &now
 	ua.LastLoginIP = &ipAddress
 	ua.FailedLoginAttempts = 0
@@ -388,13 +573,13 @@ func (ua *UserAccount) RecordSuccessfulLogin(ipAddress string) error {
 
 func (ua *UserAccount) RecordFailedLogin(ipAddress string, maxAttempts int, lockDuration time.Duration) error {
 	if strings.TrimSpace(ipAddress) == "" {
-		return errors.New("IP address cannot be empty")
+		return ErrEmptyIPAddress
 	}
 	if maxAttempts <= 0 {
-		return errors.New("max attempts must be greater than 0")
+		return ErrInvalidMaxAttempts
 	}
 
-	now := time.Now()
+	now := ua.now()
 	ua.FailedLoginAttempts++
 	ua.LastFailedLoginAttempt = &now
 	ua.LastFailedLoginIP = &ipAddress
@@ -411,7 +596,7 @@ func (ua *UserAccount) RecordFailedLogin(ipAddress string, maxAttempts int, lock
 func (ua *UserAccount) UnlockAccount() {
 	ua.FailedLoginAttempts = 0
 	ua.LockedUntil = nil
-	ua.UpdatedAt = time.Now()
+	ua.UpdatedAt = ua.now()
 }
 
 // Query Methods
@@ -420,14 +605,32 @@ func (ua *UserAccount) CanLogin() bool {
 	if ua.Status != StatusActive || !ua.IsVerified {
 		return false
 	}
-	if ua.LockedUntil != nil && time.Now().Before(*ua.LockedUntil) {
+	if ua.LockedUntil != nil && ua.now().Before(*ua.LockedUntil) {
 		return false
 	}
 	return true
 }
 
+// HasPermission reports whether one of the account's roles grants the
+// permission. Roles stay assigned while an account is disabled but grant nothing.
+func (ua *UserAccount) HasPermission(permission Permission) bool {
+	if ua.Status != StatusActive {
+		return false
+	}
+	for _, r := range ua.Roles {
+		if r.Grants(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ua *UserAccount) HasRole(roleID string) bool {
+	return ua.roleIndex(roleID) >= 0
+}
+
 func (ua *UserAccount) IsLocked() bool {
-	return ua.LockedUntil != nil && time.Now().Before(*ua.LockedUntil)
+	return ua.LockedUntil != nil && ua.now().Before(*ua.LockedUntil)
 }
 
 func (ua *UserAccount) IsActive() bool {
@@ -455,6 +658,11 @@ func (ua *UserAccount) IsSuspended() bool {
 	return ua.Status == StatusDisabled && ua.DisabilityType != nil && *ua.DisabilityType == DisabilityTypeSuspended
 }
 
+// IsSuspensionElapsed reports whether a timed suspension has run out at the given time
+func (ua *UserAccount) IsSuspensionElapsed(at time.Time) bool {
+	return ua.IsSuspended() && ua.SuspendedUntil != nil && !at.Before(*ua.SuspendedUntil)
+}
+
 func (ua *UserAccount) IsBlocked() bool {
 	return ua.Status == StatusDisabled && ua.DisabilityType != nil && *ua.DisabilityType == DisabilityTypeBlocked
 }
@@ -509,6 +717,15 @@ func (ua *UserAccount) GetDisabilityReason() *string {
 	return nil
 }
 
+func (ua *UserAccount) roleIndex(roleID string) int {
+	for i, r := range ua.Roles {
+		if r.ID == roleID {
+			return i
+		}
+	}
+	return -1
+}
+
 // Domain Validation Functions
 
 func validateAccountType(accountType UserAccountType) error {
@@ -520,7 +737,7 @@ func validateAccountType(accountType UserAccountType) error {
 		TypeDeveloper:  true,
 	}
 	if !validTypes[accountType] {
-		return errors.New("invalid account type")
+		return ErrInvalidAccountType
 	}
 	return nil
 }
@@ -535,7 +752,7 @@ func validateDisabilityType(disabilityType DisabilityType) error {
 		DisabilityTypeViolation: true,
 	}
 	if !validTypes[disabilityType] {
-		return errors.New("invalid disability type")
+		return ErrInvalidDisabilityType
 	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,16 @@
+package account
+
+import "time"
+
+// Event types, the names consumers subscribe to
+const EventVerificationRequested = "account.verification_requested"
+
+// VerificationRequested is emitted when a registration needs its email address
+// confirmed. It carries the raw token because the email is its only copy.
+type VerificationRequested struct {
+	AccountID  string
+	Username   string
+	Email      string
+	Token      string
+	OccurredAt time.Time
+}
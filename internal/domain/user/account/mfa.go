@@ -0,0 +1,266 @@
+package account
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Two-factor settings, the RFC 6238 defaults every authenticator app understands
+const (
+	TOTPDigits         = 6
+	TOTPPeriod         = 30 * time.Second
+	MinTOTPSecretBytes = 20 // 160 bits, what RFC 4226 recommends
+	BackupCodeCount    = 10
+	backupCodeLength   = 10
+)
+
+// Two-factor errors, a wrong and a reused code read the same to the user
+var (
+	ErrInvalidTOTPSecret = shared.NewDomainError("account.invalid_totp_secret", shared.KindValidation, "TOTP secret must be base32 and at least 160 bits")
+	ErrMFAAlreadyEnabled = shared.NewDomainError("account.mfa_already_enabled", shared.KindConflict, "two-factor authentication is already enabled")
+	ErrMFANotEnabled     = shared.NewDomainError("account.mfa_not_enabled", shared.KindState, "two-factor authentication is not enabled")
+	ErrMFANotPending     = shared.NewDomainError("account.mfa_not_pending", shared.KindState, "no two-factor setup to confirm")
+	ErrMFASecretJSON     = shared.NewDomainError("account.totp_secret_from_json", shared.KindForbidden, "TOTP secret cannot be set from JSON")
+	ErrInvalidMFACode    = shared.NewDomainError("account.invalid_mfa_code", shared.KindValidation, "authentication code is invalid")
+	ErrMFACodeReused     = ErrInvalidMFACode.With("reason", "reused")
+)
+
+// backupAlphabet leaves out 0, 1, l and o, codes get read off paper. Its 32
+// letters keep every random byte mapping evenly.
+const backupAlphabet = "23456789abcdefghijkmnpqrstuvwxyz"
+
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Domain interface for TOTP codes (implementation will be in infrastructure layer)
+type TOTPVerifier interface {
+	// Verify returns the time step the code was generated for, allowing for
+	// some clock drift, and false when it matches none
+	Verify(secret, code string, at time.Time) (step int64, ok bool, err error)
+}
+
+// TOTPSecret value object, the base32 key shared with the authenticator app
+type TOTPSecret struct {
+	value string
+}
+
+func NewTOTPSecret(value string) (*TOTPSecret, error) {
+	value = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+	value = strings.TrimRight(value, "=")
+	key, err := secretEncoding.DecodeString(value)
+	if err != nil || len(key) < MinTOTPSecretBytes {
+		return nil, ErrInvalidTOTPSecret
+	}
+	return &TOTPSecret{value: value}, nil
+}
+
+// ReconstituteTOTPSecret restores a stored secret without validation
+func ReconstituteTOTPSecret(value string) TOTPSecret {
+	return TOTPSecret{value: value}
+}
+
+func (s TOTPSecret) Value() string {
+	return s.value
+}
+
+// MarshalJSON never writes the secret, like the password hash
+func (s TOTPSecret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redactedHash)
+}
+
+// UnmarshalJSON refuses, secrets are only ever set through EnableTOTP
+func (s *TOTPSecret) UnmarshalJSON(data []byte) error {
+	return ErrMFASecretJSON
+}
+
+// BackupCodes value object, one-time codes that stand in for the
+// authenticator app. Only their hashes are kept, the codes are shown once.
+type BackupCodes struct {
+	hashes []string
+}
+
+// NewBackupCodes issues a fresh set and returns the codes to show the member
+func NewBackupCodes() (BackupCodes, []string, error) {
+	codes := make([]string, 0, BackupCodeCount)
+	hashes := make([]string, 0, BackupCodeCount)
+	buf := make([]byte, backupCodeLength)
+	for range BackupCodeCount {
+		if _, err := rand.Read(buf); err != nil {
+			return BackupCodes{}, nil, err
+		}
+		var code strings.Builder
+		for i, b := range buf {
+			if i == backupCodeLength/2 {
+				code.WriteByte('-')
+			}
+			code.WriteByte(backupAlphabet[int(b)%len(backupAlphabet)])
+		}
+		codes = append(codes, code.String())
+		hashes = append(hashes, hashBackupCode(code.String()))
+	}
+	return BackupCodes{hashes: hashes}, codes, nil
+}
+
+// ReconstituteBackupCodes restores the stored hashes of the unused codes
+func ReconstituteBackupCodes(hashes []string) BackupCodes {
+	return BackupCodes{hashes: append([]string(nil), hashes...)}
+}
+
+func (c BackupCodes) Hashes() []string {
+	return append([]string(nil), c.hashes...)
+}
+
+func (c BackupCodes) Remaining() int {
+	return len(c.hashes)
+}
+
+// Use returns the codes left after spending code, and false when it is not one of them
+func (c BackupCodes) Use(code string) (BackupCodes, bool) {
+	hash := hashBackupCode(code)
+	for i, h := range c.hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			return BackupCodes{hashes: append(c.hashes[:i:i], c.hashes[i+1:]...)}, true
+		}
+	}
+	return c, false
+}
+
+// hashBackupCode ignores case, spaces and the dash, however the member typed it
+func hashBackupCode(code string) string {
+	code = strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// Business Methods
+
+// EnableTOTP starts two-factor setup with a secret the member adds to their
+// authenticator app. Nothing changes at sign-in until ConfirmTOTP.
+func (ua *UserAccount) EnableTOTP(secret string) error {
+	if ua.Status == StatusDeleted {
+		return ErrAccountDeleted.WithMessage("cannot enable two-factor authentication on deleted account")
+	}
+	if ua.RequiresMFA() {
+		return ErrMFAAlreadyEnabled
+	}
+	s, err := NewTOTPSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	ua.TOTPSecret = s
+	ua.TOTPLastStep = 0
+	ua.UpdatedAt = ua.now()
+	return nil
+}
+
+// ConfirmTOTP turns two-factor on once the member proves their app produces
+// codes for the secret, and returns the backup codes to show them once
+func (ua *UserAccount) ConfirmTOTP(code string, verifier TOTPVerifier) ([]string, error) {
+	if ua.RequiresMFA() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if ua.TOTPSecret == nil {
+		return nil, ErrMFANotPending
+	}
+	now := ua.now()
+	step, ok, err := verifier.Verify(ua.TOTPSecret.Value(), code, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	backup, codes, err := NewBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	ua.TOTPConfirmedAt = &now
+	ua.TOTPLastStep = step
+	ua.BackupCodes = backup
+	ua.UpdatedAt = now
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor off, or abandons an unconfirmed setup. The
+// actor is the member themselves or staff helping a member who lost access.
+func (ua *UserAccount) DisableTOTP(actorID string) error {
+	if strings.TrimSpace(actorID) == "" {
+		return emptyActor("actor")
+	}
+	if ua.TOTPSecret == nil {
+		return ErrMFANotEnabled
+	}
+
+	ua.TOTPSecret = nil
+	ua.TOTPConfirmedAt = nil
+	ua.TOTPLastStep = 0
+	ua.BackupCodes = BackupCodes{}
+	ua.UpdatedAt = ua.now()
+	ua.LastActionBy = &actorID
+	return nil
+}
+
+// VerifyMFA checks the second sign-in step, an authenticator code or a
+// backup code. Each authenticator code works once, a backup code is used up.
+func (ua *UserAccount) VerifyMFA(code string, verifier TOTPVerifier) error {
+	if !ua.RequiresMFA() {
+		return ErrMFANotEnabled
+	}
+	code = strings.TrimSpace(code)
+	now := ua.now()
+
+	if len(code) == TOTPDigits {
+		step, ok, err := verifier.Verify(ua.TOTPSecret.Value(), code, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidMFACode
+		}
+		if step <= ua.TOTPLastStep {
+			return ErrMFACodeReused
+		}
+		ua.TOTPLastStep = step
+		ua.UpdatedAt = now
+		return nil
+	}
+
+	remaining, ok := ua.BackupCodes.Use(code)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	ua.BackupCodes = remaining
+	ua.UpdatedAt = now
+	return nil
+}
+
+// RegenerateBackupCodes replaces every backup code, used or not
+func (ua *UserAccount) RegenerateBackupCodes() ([]string, error) {
+	if !ua.RequiresMFA() {
+		return nil, ErrMFANotEnabled
+	}
+	backup, codes, err := NewBackupCodes()
+	if err != nil {
+		return nil, err
+	}
+	ua.BackupCodes = backup
+	ua.UpdatedAt = ua.now()
+	return codes, nil
+}
+
+// Query Methods
+
+// RequiresMFA reports whether signing in takes a second step after the
+// password, see CanLogin for whether the account may sign in at all
+func (ua *UserAccount) RequiresMFA() bool {
+	return ua.TOTPSecret != nil && ua.TOTPConfirmedAt != nil
+}
@@ -0,0 +1,228 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// secret is 20 bytes of base32, the length authenticator apps generate
+const secret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+// fakeVerifier accepts the step number's last six digits for the current step and the one before
+type fakeVerifier struct {
+	err error
+}
+
+func (f fakeVerifier) Verify(secret, code string, at time.Time) (int64, bool, error) {
+	if f.err != nil {
+		return 0, false, f.err
+	}
+	step := at.Unix() / int64(TOTPPeriod/time.Second)
+	for _, s := range []int64{step, step - 1} {
+		if code == stepCode(s) {
+			return s, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func stepCode(step int64) string {
+	return fmt.Sprintf("%06d", step%1000000)
+}
+
+func newMFAAccount(t *testing.T) (*UserAccount, *shared.FrozenClock) {
+	t.Helper()
+	acc, err := NewUserAccountForTesting("m1", "member_user", "member@example.com", "MemberPass123!", TypeMembership, SelfRegistration)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC))
+	acc.SetClock(clock)
+	return acc, clock
+}
+
+func currentCode(clock *shared.FrozenClock) string {
+	return stepCode(clock.Now().Unix() / int64(TOTPPeriod/time.Second))
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"app format", secret, true},
+		{"grouped lowercase", "jbsw y3dp ehpk 3pxp jbsw y3dp ehpk 3pxp", true},
+		{"padded", "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP====", true},
+		{"too short", "JBSWY3DPEHPK3PXP", false},
+		{"not base32", "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PX1", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewTOTPSecret(tt.value)
+			if tt.valid && (err != nil || s.Value() != secret) {
+				t.Errorf("expected %s, got %v, %v", secret, s, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidTOTPSecret) {
+				t.Errorf("expected ErrInvalidTOTPSecret, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBackupCodes(t *testing.T) {
+	backup, codes, err := NewBackupCodes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != BackupCodeCount || backup.Remaining() != BackupCodeCount {
+		t.Fatalf("expected %d codes, got %d", BackupCodeCount, len(codes))
+	}
+	if len(codes[0]) != backupCodeLength+1 || codes[0][5] != '-' {
+		t.Errorf("expected xxxxx-xxxxx, got %s", codes[0])
+	}
+	for _, h := range backup.Hashes() {
+		for _, c := range codes {
+			if h == c {
+				t.Fatal("expected only hashes kept")
+			}
+		}
+	}
+
+	left, ok := backup.Use(" " + strings.ToUpper(strings.ReplaceAll(codes[3], "-", "")) + " ")
+	if !ok || left.Remaining() != BackupCodeCount-1 || backup.Remaining() != BackupCodeCount {
+		t.Fatalf("expected the code used up in a new set, got %d left", left.Remaining())
+	}
+	if _, ok := left.Use(codes[3]); ok {
+		t.Error("expected a used code rejected")
+	}
+	if _, ok := ReconstituteBackupCodes(left.Hashes()).Use(codes[4]); !ok {
+		t.Error("expected restored codes to still work")
+	}
+}
+
+func TestUserAccount_TOTPSetup(t *testing.T) {
+	acc, clock := newMFAAccount(t)
+
+	if _, err := acc.ConfirmTOTP("000000", fakeVerifier{}); !errors.Is(err, ErrMFANotPending) {
+		t.Errorf("expected ErrMFANotPending, got %v", err)
+	}
+	if err := acc.EnableTOTP("short"); !errors.Is(err, ErrInvalidTOTPSecret) {
+		t.Errorf("expected ErrInvalidTOTPSecret, got %v", err)
+	}
+	if err := acc.EnableTOTP(secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acc.RequiresMFA() {
+		t.Error("expected sign-in unchanged until the setup is confirmed")
+	}
+	if _, err := acc.ConfirmTOTP("123456", fakeVerifier{}); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("expected ErrInvalidMFACode, got %v", err)
+	}
+	if _, err := acc.ConfirmTOTP(currentCode(clock), fakeVerifier{err: errors.New("clock unavailable")}); err == nil {
+		t.Error("expected the verifier's error")
+	}
+
+	codes, err := acc.ConfirmTOTP(currentCode(clock), fakeVerifier{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !acc.RequiresMFA() || len(codes) != BackupCodeCount || *acc.TOTPConfirmedAt != clock.Now() {
+		t.Errorf("expected two-factor on with backup codes, got %+v", acc)
+	}
+	if err := acc.EnableTOTP(secret); !errors.Is(err, ErrMFAAlreadyEnabled) {
+		t.Errorf("expected ErrMFAAlreadyEnabled, got %v", err)
+	}
+
+	if err := acc.DisableTOTP(""); !errors.Is(err, ErrEmptyActorID) {
+		t.Errorf("expected ErrEmptyActorID, got %v", err)
+	}
+	if err := acc.DisableTOTP("m1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acc.RequiresMFA() || acc.TOTPSecret != nil || acc.BackupCodes.Remaining() != 0 || *acc.LastActionBy != "m1" {
+		t.Errorf("expected two-factor cleared, got %+v", acc)
+	}
+	if err := acc.DisableTOTP("m1"); !errors.Is(err, ErrMFANotEnabled) {
+		t.Errorf("expected ErrMFANotEnabled, got %v", err)
+	}
+}
+
+func TestUserAccount_VerifyMFA(t *testing.T) {
+	acc, clock := newMFAAccount(t)
+	if err := acc.VerifyMFA("000000", fakeVerifier{}); !errors.Is(err, ErrMFANotEnabled) {
+		t.Errorf("expected ErrMFANotEnabled, got %v", err)
+	}
+	_ = acc.EnableTOTP(secret)
+	codes, _ := acc.ConfirmTOTP(currentCode(clock), fakeVerifier{})
+
+	// The code used to confirm cannot sign in
+	if err := acc.VerifyMFA(currentCode(clock), fakeVerifier{}); !errors.Is(err, ErrMFACodeReused) {
+		t.Errorf("expected ErrMFACodeReused, got %v", err)
+	}
+	clock.Advance(TOTPPeriod)
+	if err := acc.VerifyMFA(currentCode(clock), fakeVerifier{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := acc.VerifyMFA(currentCode(clock), fakeVerifier{}); !errors.Is(err, ErrMFACodeReused) {
+		t.Errorf("expected a code to work once, got %v", err)
+	}
+	if err := acc.VerifyMFA("999999", fakeVerifier{}); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("expected ErrInvalidMFACode, got %v", err)
+	}
+
+	if err := acc.VerifyMFA(codes[0], fakeVerifier{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acc.BackupCodes.Remaining() != BackupCodeCount-1 {
+		t.Errorf("expected the backup code used up, %d left", acc.BackupCodes.Remaining())
+	}
+	if err := acc.VerifyMFA(codes[0], fakeVerifier{}); !errors.Is(err, ErrInvalidMFACode) {
+		t.Errorf("expected a used backup code rejected, got %v", err)
+	}
+
+	fresh, err := acc.RegenerateBackupCodes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acc.BackupCodes.Remaining() != BackupCodeCount || acc.VerifyMFA(codes[1], fakeVerifier{}) == nil || acc.VerifyMFA(fresh[1], fakeVerifier{}) != nil {
+		t.Error("expected the old codes replaced by the new ones")
+	}
+}
+
+func TestUserAccount_MFAPersistence(t *testing.T) {
+	acc, clock := newMFAAccount(t)
+	_ = acc.EnableTOTP(secret)
+	codes, _ := acc.ConfirmTOTP(currentCode(clock), fakeVerifier{})
+
+	restored, err := ReconstituteUserAccount(acc.Snapshot())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	restored.SetClock(clock)
+	if !restored.RequiresMFA() || restored.TOTPSecret.Value() != secret || restored.TOTPLastStep != acc.TOTPLastStep {
+		t.Errorf("expected two-factor state restored, got %+v", restored)
+	}
+	if err := restored.VerifyMFA(codes[2], fakeVerifier{}); err != nil {
+		t.Errorf("expected backup codes restored, got %v", err)
+	}
+
+	data, err := json.Marshal(acc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), secret) {
+		t.Errorf("expected the secret redacted, got %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"TOTPSecret": "`+secret+`"}`), &UserAccount{}); !errors.Is(err, ErrMFASecretJSON) {
+		t.Errorf("expected ErrMFASecretJSON, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package account
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// PasswordResetTTL is how long an emailed reset link works
+const PasswordResetTTL = time.Hour
+
+// Password reset errors, an unknown, expired or used token all read the same to the user
+var (
+	ErrInvalidResetToken = shared.NewDomainError("account.invalid_reset_token", shared.KindValidation, "password reset link is invalid or has expired")
+	ErrResetTokenExpired = ErrInvalidResetToken.With("reason", "expired")
+	ErrResetTokenUsed    = ErrInvalidResetToken.With("reason", "used")
+)
+
+// PasswordReset is one emailed reset link. Only the token's hash is stored,
+// the token itself is only ever emailed.
+type PasswordReset struct {
+	ID          string
+	AccountID   string
+	TokenHash   string
+	RequestedIP string
+	ExpiresAt   time.Time
+	UsedAt      *time.Time
+	RevokedAt   *time.Time // Set when a newer request or a completed reset made the link obsolete
+	CreatedAt   time.Time
+}
+
+func NewPasswordReset(id, accountID, tokenHash, requestedIP string, at time.Time) (*PasswordReset, error) {
+	if strings.TrimSpace(id) == "" || strings.TrimSpace(accountID) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(tokenHash) == "" {
+		return nil, ErrInvalidResetToken.WithMessage("token hash cannot be empty")
+	}
+	return &PasswordReset{
+		ID:          id,
+		AccountID:   accountID,
+		TokenHash:   tokenHash,
+		RequestedIP: requestedIP,
+		ExpiresAt:   at.Add(PasswordResetTTL),
+		CreatedAt:   at,
+	}, nil
+}
+
+// Business Methods
+
+// Redeem uses the link up, it works once and only before it expires
+func (r *PasswordReset) Redeem(tokenHash string, at time.Time) error {
+	if err := r.Check(tokenHash, at); err != nil {
+		return err
+	}
+	r.UsedAt = &at
+	return nil
+}
+
+// Revoke makes an unused link obsolete
+func (r *PasswordReset) Revoke(at time.Time) {
+	if r.UsedAt == nil && r.RevokedAt == nil {
+		r.RevokedAt = &at
+	}
+}
+
+// Query Methods
+
+// Check reports whether the link can still be redeemed with the token
+func (r *PasswordReset) Check(tokenHash string, at time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(r.TokenHash), []byte(tokenHash)) != 1 {
+		return ErrInvalidResetToken
+	}
+	if r.UsedAt != nil || r.RevokedAt != nil {
+		return ErrResetTokenUsed
+	}
+	if !at.Before(r.ExpiresAt) {
+		return ErrResetTokenExpired
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+func TestPasswordReset_Redeem(t *testing.T) {
+	at := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	reset, err := NewPasswordReset("r1", "m1", "hash-1", "203.0.113.9", at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		hash   string
+		at     time.Time
+		reason string
+	}{
+		{"wrong token", "hash-2", at, ""},
+		{"expired", "hash-1", at.Add(PasswordResetTTL), "expired"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := reset.Redeem(tt.hash, tt.at)
+			de, ok := shared.AsDomainError(err)
+			if !ok || de.Code != ErrInvalidResetToken.Code || de.Meta["reason"] != tt.reason {
+				t.Errorf("expected invalid token with reason %q, got %v", tt.reason, err)
+			}
+		})
+	}
+
+	if err := reset.Redeem("hash-1", at.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := reset.Redeem("hash-1", at.Add(time.Minute)); err == nil {
+		t.Error("expected a used link rejected")
+	}
+}
@@ -0,0 +1,253 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Hard floors, a runtime policy can tighten the rules but never go below these
+const (
+	MinPasswordLengthFloor = 8
+	MaxBannedPasswords     = 10000
+	MaxLockoutAttempts     = 100
+	MinLockDuration        = time.Minute
+	MaxLockDuration        = 24 * time.Hour
+)
+
+var (
+	ErrPasswordTooLong       = errors.New("password is too long")
+	ErrPasswordBanned        = errors.New("password is too common, choose another one")
+	ErrInvalidSecurityPolicy = errors.New("invalid security policy")
+)
+
+// accountTypes lists every account type a security policy can be set for
+var accountTypes = []UserAccountType{TypeInternal, TypeExternal, TypeMembership, TypePartner, TypeDeveloper}
+
+// PasswordPolicy holds the complexity rules for new passwords
+type PasswordPolicy struct {
+	MinLength      int
+	MaxLength      int // 0 means no upper bound
+	RequireUpper   bool
+	RequireLower   bool
+	RequireNumber  bool
+	RequireSpecial bool
+	Banned         []string // Compared case-insensitively against the whole password
+}
+
+// LockoutPolicy holds how many failed logins lock an account and for how long
+type LockoutPolicy struct {
+	MaxAttempts  int
+	LockDuration time.Duration
+}
+
+// DefaultPasswordPolicy is the rule every account type used before policies became configurable
+var DefaultPasswordPolicy = PasswordPolicy{
+	MinLength:      8,
+	RequireUpper:   true,
+	RequireLower:   true,
+	RequireNumber:  true,
+	RequireSpecial: true,
+}
+
+var DefaultLockoutPolicy = LockoutPolicy{
+	MaxAttempts:  5,
+	LockDuration: 15 * time.Minute,
+}
+
+// PasswordPolicyError names the rule a password broke, it matches the domain error for that rule
+type PasswordPolicyError struct {
+	Err     error
+	Message string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return e.Message
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return e.Err
+}
+
+// SecurityPolicy is the runtime password and lockout policy for one account type,
+// managed by the security team through the settings store
+type SecurityPolicy struct {
+	AccountType UserAccountType
+	Password    PasswordPolicy
+	Lockout     LockoutPolicy
+
+	// Audit
+	UpdatedBy *string
+	UpdatedAt time.Time
+}
+
+func NewSecurityPolicy(accountType UserAccountType, password PasswordPolicy, lockout LockoutPolicy, updatedBy string) (*SecurityPolicy, error) {
+	if strings.TrimSpace(updatedBy) == "" {
+		return nil, errors.New("updatedBy cannot be empty")
+	}
+	if err := validateAccountType(accountType); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSecurityPolicy, err)
+	}
+	if err := validatePasswordPolicy(password); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSecurityPolicy, err)
+	}
+	if err := validateLockoutPolicy(lockout); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSecurityPolicy, err)
+	}
+
+	password.Banned = normalizeBanned(password.Banned)
+	return &SecurityPolicy{
+		AccountType: accountType,
+		Password:    password,
+		Lockout:     lockout,
+		UpdatedBy:   &updatedBy,
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// DefaultSecurityPolicy applies to account types the security team has not configured
+func DefaultSecurityPolicy(accountType UserAccountType) *SecurityPolicy {
+	return &SecurityPolicy{
+		AccountType: accountType,
+		Password:    DefaultPasswordPolicy,
+		Lockout:     DefaultLockoutPolicy,
+	}
+}
+
+// AccountTypes returns every account type, e.g. to list policies including defaults
+func AccountTypes() []UserAccountType {
+	return append([]UserAccountType(nil), accountTypes...)
+}
+
+// Query Methods
+
+// IsDefault reports whether the policy was never configured
+func (p *SecurityPolicy) IsDefault() bool {
+	return p.UpdatedBy == nil
+}
+
+// Validate checks raw against the policy, length first, then character classes, then the banned list
+func (p PasswordPolicy) Validate(raw string) error {
+	if strings.TrimSpace(raw) == "" {
+		return ErrInvalidPassword
+	}
+
+	if len(raw) < p.MinLength {
+		return &PasswordPolicyError{Err: ErrPasswordTooShort, Message: fmt.Sprintf("password must be at least %d characters", p.MinLength)}
+	}
+	if p.MaxLength > 0 && len(raw) > p.MaxLength {
+		return &PasswordPolicyError{Err: ErrPasswordTooLong, Message: fmt.Sprintf("password cannot exceed %d characters", p.MaxLength)}
+	}
+
+	if p.lacksRequiredClass(raw) {
+		return &PasswordPolicyError{Err: ErrPasswordTooWeak, Message: "password must contain " + joinClasses(p.requiredClasses())}
+	}
+
+	lowered := strings.ToLower(strings.TrimSpace(raw))
+	for _, banned := range p.Banned {
+		if lowered == banned {
+			return ErrPasswordBanned
+		}
+	}
+	return nil
+}
+
+// Requirements describes the policy for sign-up and change-password forms
+func (p PasswordPolicy) Requirements() []string {
+	requirements := []string{fmt.Sprintf("at least %d characters", p.MinLength)}
+	if p.MaxLength > 0 {
+		requirements = append(requirements, fmt.Sprintf("at most %d characters", p.MaxLength))
+	}
+	for _, class := range p.requiredClasses() {
+		requirements = append(requirements, "at least one "+class)
+	}
+	if len(p.Banned) > 0 {
+		requirements = append(requirements, "not a commonly used password")
+	}
+	return requirements
+}
+
+func (p PasswordPolicy) lacksRequiredClass(raw string) bool {
+	return (p.RequireUpper && !HasUppercase(raw)) ||
+		(p.RequireLower && !HasLowercase(raw)) ||
+		(p.RequireNumber && !HasNumber(raw)) ||
+		(p.RequireSpecial && !HasSpecialChar(raw))
+}
+
+func (p PasswordPolicy) requiredClasses() []string {
+	var classes []string
+	if p.RequireUpper {
+		classes = append(classes, "uppercase")
+	}
+	if p.RequireLower {
+		classes = append(classes, "lowercase")
+	}
+	if p.RequireNumber {
+		classes = append(classes, "number")
+	}
+	if p.RequireSpecial {
+		classes = append(classes, "special character")
+	}
+	return classes
+}
+
+// Domain interface for the settings store holding security policies (implementation will be in infrastructure layer)
+type SecurityPolicyRepository interface {
+	Save(ctx context.Context, policy *SecurityPolicy) error
+
+	// FindByAccountType returns nil when the account type has no configured policy
+	FindByAccountType(ctx context.Context, accountType UserAccountType) (*SecurityPolicy, error)
+}
+
+// Domain Validation Functions
+
+func validatePasswordPolicy(p PasswordPolicy) error {
+	if p.MinLength < MinPasswordLengthFloor {
+		return fmt.Errorf("minimum password length cannot be below %d", MinPasswordLengthFloor)
+	}
+	if p.MaxLength != 0 && p.MaxLength < p.MinLength {
+		return errors.New("maximum password length cannot be below the minimum")
+	}
+	if len(p.Banned) > MaxBannedPasswords {
+		return fmt.Errorf("banned password list cannot exceed %d entries", MaxBannedPasswords)
+	}
+	return nil
+}
+
+func validateLockoutPolicy(l LockoutPolicy) error {
+	if l.MaxAttempts < 1 || l.MaxAttempts > MaxLockoutAttempts {
+		return fmt.Errorf("max attempts must be between 1 and %d", MaxLockoutAttempts)
+	}
+	if l.LockDuration < MinLockDuration || l.LockDuration > MaxLockDuration {
+		return errors.New("lock duration must be between 1 minute and 24 hours")
+	}
+	return nil
+}
+
+func normalizeBanned(banned []string) []string {
+	seen := make(map[string]bool, len(banned))
+	normalized := make([]string, 0, len(banned))
+	for _, b := range banned {
+		b = strings.ToLower(strings.TrimSpace(b))
+		if b == "" || seen[b] {
+			continue
+		}
+		seen[b] = true
+		normalized = append(normalized, b)
+	}
+	return normalized
+}
+
+// joinClasses writes "a", "a and b" or "a, b, and c"
+func joinClasses(classes []string) string {
+	switch len(classes) {
+	case 1:
+		return classes[0]
+	case 2:
+		return classes[0] + " and " + classes[1]
+	default:
+		return strings.Join(classes[:len(classes)-1], ", ") + ", and " + classes[len(classes)-1]
+	}
+}
@@ -0,0 +1,110 @@
+package account
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	internal := PasswordPolicy{
+		MinLength:     14,
+		MaxLength:     64,
+		RequireUpper:  true,
+		RequireNumber: true,
+		Banned:        []string{"newsroom2025!!"},
+	}
+
+	testCases := []struct {
+		name        string
+		input       string
+		expectedErr error
+		expectedMsg string
+	}{
+		{"valid", "Correct horse 9 battery", nil, ""},
+		{"too short for the policy", "Password1!", ErrPasswordTooShort, "password must be at least 14 characters"},
+		{"too long", strings.Repeat("Newsroom 9 ", 7), ErrPasswordTooLong, "password cannot exceed 64 characters"},
+		{"missing required class", "correct horse battery 9", ErrPasswordTooWeak, "password must contain uppercase and number"},
+		{"banned case-insensitively", "NewsRoom2025!!", ErrPasswordBanned, ""},
+		{"empty", "  ", ErrInvalidPassword, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := internal.Validate(tc.input)
+			if tc.expectedErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if tc.expectedMsg != "" && err.Error() != tc.expectedMsg {
+				t.Errorf("expected message '%s', got '%s'", tc.expectedMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestDefaultPasswordPolicy_KeepsHistoricalMessages(t *testing.T) {
+	if err := DefaultPasswordPolicy.Validate("Pass1!"); err.Error() != "password must be at least 8 characters" {
+		t.Errorf("unexpected message '%v'", err)
+	}
+	if err := DefaultPasswordPolicy.Validate("password1!"); err.Error() != "password must contain uppercase, lowercase, number, and special character" {
+		t.Errorf("unexpected message '%v'", err)
+	}
+}
+
+func TestNewSecurityPolicy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		accountType UserAccountType
+		password    PasswordPolicy
+		lockout     LockoutPolicy
+		wantErr     bool
+	}{
+		{"tightened internal policy", TypeInternal, PasswordPolicy{MinLength: 14, RequireSpecial: true}, LockoutPolicy{MaxAttempts: 3, LockDuration: time.Hour}, false},
+		{"below length floor", TypeInternal, PasswordPolicy{MinLength: 6}, DefaultLockoutPolicy, true},
+		{"max below min", TypeInternal, PasswordPolicy{MinLength: 12, MaxLength: 10}, DefaultLockoutPolicy, true},
+		{"no attempts", TypePartner, DefaultPasswordPolicy, LockoutPolicy{MaxAttempts: 0, LockDuration: time.Hour}, true},
+		{"lock too short", TypePartner, DefaultPasswordPolicy, LockoutPolicy{MaxAttempts: 5, LockDuration: time.Second}, true},
+		{"unknown account type", UserAccountType("robot"), DefaultPasswordPolicy, DefaultLockoutPolicy, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := NewSecurityPolicy(tc.accountType, tc.password, tc.lockout, "admin123")
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidSecurityPolicy) {
+					t.Errorf("expected error '%v', got '%v'", ErrInvalidSecurityPolicy, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if policy.IsDefault() || *policy.UpdatedBy != "admin123" {
+				t.Error("expected configured policy to record who changed it")
+			}
+		})
+	}
+}
+
+func TestNewSecurityPolicy_NormalizesBannedList(t *testing.T) {
+	password := DefaultPasswordPolicy
+	password.Banned = []string{" Password1! ", "password1!", ""}
+
+	policy, err := NewSecurityPolicy(TypeMembership, password, DefaultLockoutPolicy, "admin123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(policy.Password.Banned) != 1 || policy.Password.Banned[0] != "password1!" {
+		t.Errorf("expected one normalized entry, got %q", policy.Password.Banned)
+	}
+	if !errors.Is(policy.Password.Validate("pASSWORD1!"), ErrPasswordBanned) {
+		t.Error("expected banned password to be rejected")
+	}
+}
@@ -102,6 +102,43 @@ type UserAccountRepository interface {
 	// Disability-specific queries
 	FindDisabledAccounts(ctx context.Context, disabilityType *DisabilityType) ([]*UserAccount, error)
 	FindSuspendedAccounts(ctx context.Context) ([]*UserAccount, error)
+	FindElapsedSuspensions(ctx context.Context, at time.Time) ([]*UserAccount, error)
 	FindBlockedAccounts(ctx context.Context) ([]*UserAccount, error)
 	FindInactiveAccounts(ctx context.Context, inactiveSince time.Time) ([]*UserAccount, error)
-}
\ No newline at end of file
+}
+// Domain interface for roles and their assignments (implementation will be in infrastructure layer)
+type RoleRepository interface {
+	Create(ctx context.Context, role *Role) error
+	Update(ctx context.Context, role *Role) error
+	FindByID(ctx context.Context, id string) (*Role, error)
+	FindAll(ctx context.Context) ([]*Role, error)
+
+	// Assignments, SaveAssignments replaces the account's roles with account.Roles
+	FindByAccountID(ctx context.Context, accountID string) ([]Role, error)
+	SaveAssignments(ctx context.Context, account *UserAccount) error
+	CountAssignments(ctx context.Context, roleID string) (int, error)
+}
+
+// Domain interface for password reset links (implementation will be in infrastructure layer)
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *PasswordReset) error
+	// MarkRedeemed stores the redeemed link only if it is still unused and unrevoked,
+	// in one conditional update; false means another request used or revoked it first
+	MarkRedeemed(ctx context.Context, reset *PasswordReset) (bool, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*PasswordReset, error) // nil when not found
+	// RevokeOutstanding revokes every unused link of the account
+	RevokeOutstanding(ctx context.Context, accountID string, at time.Time) error
+}
+
+// Domain interface for signed-in sessions (implementation will be in infrastructure layer)
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	Update(ctx context.Context, session *Session) error
+	FindByID(ctx context.Context, id string) (*Session, error) // nil when not found
+	FindActiveByAccountID(ctx context.Context, accountID string, at time.Time) ([]*Session, error)
+
+	// RevokeAllForAccount revokes every active session of the account and returns how many
+	RevokeAllForAccount(ctx context.Context, accountID string, reason RevocationReason, at time.Time) (int, error)
+	// DeleteExpired removes sessions that expired or were revoked before the cutoff
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
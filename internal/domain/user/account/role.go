@@ -0,0 +1,140 @@
+package account
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Permissions look like "articles:publish", the same form as entitlement scopes
+var permissionRegex = regexp.MustCompile(`^[a-z][a-z_]*(:[a-z][a-z_]*)+$`)
+
+// Role errors
+var (
+	ErrInvalidPermission   = shared.NewDomainError("role.invalid_permission", shared.KindValidation, "permission must look like resource:action, lowercase")
+	ErrEmptyRoleName       = shared.NewDomainError("role.name_required", shared.KindValidation, "role name cannot be empty")
+	ErrNoAccountTypes      = shared.NewDomainError("role.account_types_required", shared.KindValidation, "role must allow at least one account type")
+	ErrRoleNotAllowed      = shared.NewDomainError("role.not_allowed", shared.KindForbidden, "role cannot be assigned to this account type")
+	ErrRoleAlreadyAssigned = shared.NewDomainError("role.already_assigned", shared.KindConflict, "role is already assigned")
+	ErrRoleNotAssigned     = shared.NewDomainError("role.not_assigned", shared.KindState, "role is not assigned")
+	ErrRoleBlocksType      = shared.NewDomainError("role.blocks_type_change", shared.KindConflict, "assigned roles do not allow the new account type")
+)
+
+// Permission value object, one action a role allows
+type Permission string
+
+const (
+	PermissionWriteArticle     Permission = "articles:write"
+	PermissionEditArticle      Permission = "articles:edit"
+	PermissionPublishArticle   Permission = "articles:publish"
+	PermissionReadAllArticles  Permission = "articles:read_all"
+	PermissionModerateComments Permission = "comments:moderate"
+	PermissionManageAccounts   Permission = "accounts:manage"
+	PermissionManageRoles      Permission = "roles:manage"
+)
+
+func NewPermission(value string) (Permission, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !permissionRegex.MatchString(value) {
+		return "", ErrInvalidPermission.With("permission", value)
+	}
+	return Permission(value), nil
+}
+
+// Role is a named set of permissions and the account types it may be assigned to
+type Role struct {
+	ID           string
+	Name         string
+	Permissions  []Permission
+	AccountTypes []UserAccountType
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewRole(id, name string, permissions []Permission, accountTypes []UserAccountType) (*Role, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	role := &Role{ID: id, CreatedAt: time.Now()}
+	role.UpdatedAt = role.CreatedAt
+	if err := role.Redefine(name, permissions, accountTypes); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// Business Methods
+
+// Redefine replaces the role's name, permissions and account types. Accounts
+// already holding the role keep it, callers check them with AllowsType first.
+func (r *Role) Redefine(name string, permissions []Permission, accountTypes []UserAccountType) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyRoleName
+	}
+	for _, p := range permissions {
+		if _, err := NewPermission(string(p)); err != nil {
+			return err
+		}
+	}
+	if len(accountTypes) == 0 {
+		return ErrNoAccountTypes
+	}
+	for _, t := range accountTypes {
+		if err := validateAccountType(t); err != nil {
+			return err
+		}
+	}
+
+	r.Name = name
+	r.Permissions = append([]Permission(nil), permissions...)
+	r.AccountTypes = append([]UserAccountType(nil), accountTypes...)
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Query Methods
+
+func (r Role) Grants(permission Permission) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Role) AllowsType(accountType UserAccountType) bool {
+	for _, t := range r.AccountTypes {
+		if t == accountType {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRoles are the newsroom roles a fresh install starts with. Only
+// internal staff administer the site, freelancers on external accounts
+// can write and moderate but not publish.
+func DefaultRoles() []Role {
+	staff := []UserAccountType{TypeInternal}
+	contributors := []UserAccountType{TypeInternal, TypeExternal}
+	return []Role{
+		{ID: "admin", Name: "admin", AccountTypes: staff, Permissions: []Permission{
+			PermissionWriteArticle, PermissionEditArticle, PermissionPublishArticle, PermissionReadAllArticles,
+			PermissionModerateComments, PermissionManageAccounts, PermissionManageRoles,
+		}},
+		{ID: "editor", Name: "editor", AccountTypes: staff, Permissions: []Permission{
+			PermissionWriteArticle, PermissionEditArticle, PermissionPublishArticle, PermissionReadAllArticles, PermissionModerateComments,
+		}},
+		{ID: "journalist", Name: "journalist", AccountTypes: contributors, Permissions: []Permission{
+			PermissionWriteArticle, PermissionReadAllArticles,
+		}},
+		{ID: "moderator", Name: "moderator", AccountTypes: contributors, Permissions: []Permission{
+			PermissionModerateComments, PermissionReadAllArticles,
+		}},
+	}
+}
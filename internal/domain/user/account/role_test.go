@@ -0,0 +1,94 @@
+package account
+
+import (
+	"errors"
+	"testing"
+)
+
+func defaultRole(t *testing.T, id string) Role {
+	t.Helper()
+	for _, r := range DefaultRoles() {
+		if r.ID == id {
+			return r
+		}
+	}
+	t.Fatalf("no default role %q", id)
+	return Role{}
+}
+
+func TestNewRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		roleName    string
+		permissions []Permission
+		types       []UserAccountType
+		errIs       error
+	}{
+		{"valid", "copy desk", []Permission{PermissionEditArticle}, []UserAccountType{TypeInternal}, nil},
+		{"empty name", " ", nil, []UserAccountType{TypeInternal}, ErrEmptyRoleName},
+		{"invalid permission", "copy desk", []Permission{"Edit"}, []UserAccountType{TypeInternal}, ErrInvalidPermission},
+		{"no account types", "copy desk", nil, nil, ErrNoAccountTypes},
+		{"unknown account type", "copy desk", nil, []UserAccountType{"robot"}, ErrInvalidAccountType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, err := NewRole("r1", tt.roleName, tt.permissions, tt.types)
+			if !errors.Is(err, tt.errIs) {
+				t.Fatalf("expected error '%v', got '%v'", tt.errIs, err)
+			}
+			if err == nil && (!role.Grants(PermissionEditArticle) || !role.AllowsType(TypeInternal)) {
+				t.Errorf("unexpected role %+v", role)
+			}
+		})
+	}
+}
+
+func TestUserAccount_AssignRole(t *testing.T) {
+	staff, _ := NewUserAccountForTesting("s1", "staff_user", "staff@example.com", "Password123!", TypeInternal, "admin")
+	_ = staff.Verify("admin")
+	member, _ := NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed_password")
+
+	if err := staff.AssignRole(defaultRole(t, "editor"), "admin"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !staff.HasPermission(PermissionPublishArticle) || staff.HasPermission(PermissionManageRoles) {
+		t.Errorf("expected editor permissions only, got roles %+v", staff.Roles)
+	}
+	if err := staff.AssignRole(defaultRole(t, "editor"), "admin"); !errors.Is(err, ErrRoleAlreadyAssigned) {
+		t.Errorf("expected error '%v', got '%v'", ErrRoleAlreadyAssigned, err)
+	}
+	if err := staff.AssignRole(defaultRole(t, "moderator"), ""); !errors.Is(err, ErrEmptyActorID) {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptyActorID, err)
+	}
+	if err := member.AssignRole(defaultRole(t, "journalist"), "admin"); !errors.Is(err, ErrRoleNotAllowed) {
+		t.Errorf("expected error '%v', got '%v'", ErrRoleNotAllowed, err)
+	}
+
+	// Staff roles pin the account type
+	if err := staff.UpdateType(TypeExternal); !errors.Is(err, ErrRoleBlocksType) {
+		t.Errorf("expected error '%v', got '%v'", ErrRoleBlocksType, err)
+	}
+
+	_ = staff.Suspend("admin", "investigation")
+	if staff.HasPermission(PermissionPublishArticle) {
+		t.Error("expected a suspended account to have no permissions")
+	}
+}
+
+func TestUserAccount_RevokeRole(t *testing.T) {
+	staff, _ := NewUserAccountForTesting("s1", "staff_user", "staff@example.com", "Password123!", TypeInternal, "admin")
+	_ = staff.Verify("admin")
+	_ = staff.AssignRole(defaultRole(t, "journalist"), "admin")
+	_ = staff.AssignRole(defaultRole(t, "moderator"), "admin")
+
+	if err := staff.RevokeRole("journalist", "admin"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if staff.HasRole("journalist") || !staff.HasRole("moderator") || staff.HasPermission(PermissionWriteArticle) {
+		t.Errorf("expected only the moderator role left, got %+v", staff.Roles)
+	}
+	if err := staff.RevokeRole("journalist", "admin"); !errors.Is(err, ErrRoleNotAssigned) {
+		t.Errorf("expected error '%v', got '%v'", ErrRoleNotAssigned, err)
+	}
+}
@@ -0,0 +1,218 @@
+package account
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Search limits, fuzzy matching is expensive so queries and result sets are bounded
+const (
+	MinSearchQueryLength = 2
+	MaxSearchQueryLength = 100
+
+	// SimilarityThreshold matches pg_trgm's default for the % operator
+	SimilarityThreshold = 0.3
+)
+
+var (
+	ErrSearchQueryTooShort = shared.NewDomainError("account.search_query_too_short", shared.KindValidation, "search query must be at least 2 characters")
+	ErrSearchQueryTooLong  = shared.NewDomainError("account.search_query_too_long", shared.KindValidation, "search query cannot exceed 100 characters")
+)
+
+// SearchQuery value object, normalized for case-insensitive matching
+type SearchQuery struct {
+	value string
+}
+
+func NewSearchQuery(value string) (*SearchQuery, error) {
+	value = strings.ToLower(strings.Join(strings.Fields(value), " "))
+
+	if utf8.RuneCountInString(value) < MinSearchQueryLength {
+		return nil, ErrSearchQueryTooShort
+	}
+	if utf8.RuneCountInString(value) > MaxSearchQueryLength {
+		return nil, ErrSearchQueryTooLong
+	}
+
+	return &SearchQuery{value: value}, nil
+}
+
+func (q SearchQuery) String() string {
+	return q.value
+}
+
+func (q SearchQuery) Value() string {
+	return q.value
+}
+
+type SearchField string
+
+const (
+	SearchFieldUsername    SearchField = "username"
+	SearchFieldEmail       SearchField = "email"
+	SearchFieldDisplayName SearchField = "display_name"
+)
+
+// SearchCandidate is an account the store considers a possible match.
+// DisplayName comes from the profile and is nil when the account has none.
+type SearchCandidate struct {
+	Account     *UserAccount
+	DisplayName *string
+}
+
+// Highlight marks a matched byte range [Start, End) in a field value
+type Highlight struct {
+	Start int
+	End   int
+}
+
+type SearchResult struct {
+	Account     *UserAccount
+	DisplayName *string
+	Score       float64 // 0..1, best field similarity
+	Field       SearchField
+	Highlights  map[SearchField][]Highlight
+}
+
+// Domain interface for fuzzy account lookup (implementation will be in infrastructure layer).
+// Expected to use trigram indexes, e.g. pg_trgm GIN indexes on lower(username),
+// lower(email) and lower(display_name), returning at most limit candidates.
+// The filter's status/type/date conditions apply; Limit and Offset are applied after ranking.
+type AccountSearchRepository interface {
+	FindSearchCandidates(ctx context.Context, query SearchQuery, filter *UserAccountFilter, limit int) ([]SearchCandidate, error)
+}
+
+// RankSearchResults scores candidates against the query and keeps those above the threshold,
+// best first. Exact and prefix matches always rank above purely fuzzy ones.
+func RankSearchResults(candidates []SearchCandidate, query SearchQuery) []SearchResult {
+	results := make([]SearchResult, 0, len(candidates))
+
+	for _, c := range candidates {
+		fields := map[SearchField]string{
+			SearchFieldUsername: strings.ToLower(c.Account.Username.String()),
+			SearchFieldEmail:    strings.ToLower(c.Account.Email.String()),
+		}
+		if c.DisplayName != nil {
+			fields[SearchFieldDisplayName] = strings.ToLower(*c.DisplayName)
+		}
+
+		result := SearchResult{Account: c.Account, DisplayName: c.DisplayName, Highlights: map[SearchField][]Highlight{}}
+		for _, field := range []SearchField{SearchFieldUsername, SearchFieldDisplayName, SearchFieldEmail} {
+			value, ok := fields[field]
+			if !ok {
+				continue
+			}
+			score := fieldScore(value, query.value)
+			if score > result.Score {
+				result.Score = score
+				result.Field = field
+			}
+			if h := highlight(value, query.value); len(h) > 0 {
+				result.Highlights[field] = h
+			}
+		}
+
+		if result.Score >= SimilarityThreshold {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Account.Username.String() < results[j].Account.Username.String()
+	})
+	return results
+}
+
+// fieldScore boosts exact and prefix matches over trigram similarity
+func fieldScore(value, query string) float64 {
+	switch {
+	case value == query:
+		return 1
+	case strings.HasPrefix(value, query):
+		return 0.95
+	case strings.Contains(value, query):
+		return 0.9
+	}
+	return WordSimilarity(value, query)
+}
+
+// WordSimilarity is the best similarity between the query and the whole value or any
+// single word of it, so "santso" finds "maria.santos@example.com"
+func WordSimilarity(value, query string) float64 {
+	best := Similarity(value, query)
+	for _, word := range strings.FieldsFunc(strings.ToLower(value), isTrigramSeparator) {
+		best = max(best, Similarity(word, query))
+	}
+	return best
+}
+
+// Similarity is the trigram similarity of two strings, as pg_trgm computes it
+func Similarity(a, b string) float64 {
+	ta, tb := Trigrams(a), Trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// Trigrams splits each word, padded with two leading and one trailing space, into 3-rune windows
+func Trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), isTrigramSeparator) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+func isTrigramSeparator(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+}
+
+// highlight returns the exact occurrences of the query, or the best fuzzy window when there are none
+func highlight(value, query string) []Highlight {
+	var highlights []Highlight
+	for start := 0; ; {
+		i := strings.Index(value[start:], query)
+		if i < 0 {
+			break
+		}
+		highlights = append(highlights, Highlight{Start: start + i, End: start + i + len(query)})
+		start += i + len(query)
+	}
+	if len(highlights) > 0 || len(value) < len(query) {
+		return highlights
+	}
+
+	bestScore, bestStart := 0.0, -1
+	for i := 0; i+len(query) <= len(value); i++ {
+		end := i + len(query)
+		// Keep windows on rune boundaries for non-ASCII display names
+		if !utf8.RuneStart(value[i]) || (end < len(value) && !utf8.RuneStart(value[end])) {
+			continue
+		}
+		if score := Similarity(value[i:end], query); score > bestScore {
+			bestScore, bestStart = score, i
+		}
+	}
+	if bestStart < 0 || bestScore < SimilarityThreshold {
+		return nil
+	}
+	return []Highlight{{Start: bestStart, End: bestStart + len(query)}}
+}
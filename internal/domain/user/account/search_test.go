@@ -0,0 +1,97 @@
+package account
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewSearchQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{name: "normalized", input: "  John   DOE ", expected: "john doe"},
+		{name: "too short", input: " j ", expectedErr: ErrSearchQueryTooShort},
+		{name: "too long", input: strings.Repeat("a", MaxSearchQueryLength+1), expectedErr: ErrSearchQueryTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := NewSearchQuery(tt.input)
+			if err != tt.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tt.expectedErr, err)
+			}
+			if err == nil && q.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, q.String())
+			}
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if Similarity("john", "john") != 1 {
+		t.Error("expected identical strings to have similarity 1")
+	}
+	if Similarity("john", "xyz") != 0 {
+		t.Error("expected unrelated strings to have similarity 0")
+	}
+	if s := Similarity("jonathan", "jonatan"); s < SimilarityThreshold {
+		t.Errorf("expected typo to stay above threshold, got %f", s)
+	}
+	if len(Trigrams("ab")) != 3 {
+		t.Errorf("expected 3 padded trigrams, got %v", Trigrams("ab"))
+	}
+}
+
+func TestRankSearchResults(t *testing.T) {
+	jonathan := createSearchAccount(t, "u1", "jonathan_editor", "jon@example.com")
+	maria := createSearchAccount(t, "u2", "maria", "maria.santos@example.com")
+	jon := createSearchAccount(t, "u3", "jon", "j.smith@example.com")
+	displayName := "Jonathan Wijaya"
+
+	candidates := []SearchCandidate{
+		{Account: maria},
+		{Account: jonathan, DisplayName: &displayName},
+		{Account: jon},
+	}
+
+	query, _ := NewSearchQuery("jonatan")
+	results := RankSearchResults(candidates, *query)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 fuzzy matches, got %d", len(results))
+	}
+	if results[0].Account.ID != "u1" || results[0].Field != SearchFieldUsername {
+		t.Errorf("expected jonathan matched on username first, got %s on %s", results[0].Account.ID, results[0].Field)
+	}
+	if len(results[0].Highlights[SearchFieldDisplayName]) != 1 {
+		t.Error("expected fuzzy highlight on display name")
+	}
+
+	query, _ = NewSearchQuery("jon")
+	results = RankSearchResults(candidates, *query)
+	if results[0].Account.ID != "u3" || results[0].Score != 1 {
+		t.Errorf("expected exact username match first, got %s (%f)", results[0].Account.ID, results[0].Score)
+	}
+	if h := results[1].Highlights[SearchFieldUsername]; len(h) != 1 || h[0] != (Highlight{Start: 0, End: 3}) {
+		t.Errorf("expected prefix highlight, got %v", h)
+	}
+
+	query, _ = NewSearchQuery("santso")
+	results = RankSearchResults(candidates, *query)
+	if len(results) != 1 || results[0].Account.ID != "u2" || results[0].Field != SearchFieldEmail {
+		t.Fatalf("expected typo match on email, got %+v", results)
+	}
+	if len(results[0].Highlights[SearchFieldEmail]) != 1 {
+		t.Error("expected fuzzy highlight on email")
+	}
+}
+
+func createSearchAccount(t *testing.T, id, username, email string) *UserAccount {
+	acc, err := NewUserAccountForTesting(id, username, email, "TestPassword123!", TypeMembership, SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	return acc
+}
@@ -0,0 +1,119 @@
+package account
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Session errors
+var (
+	ErrInvalidSessionTTL = shared.NewDomainError("session.invalid_ttl", shared.KindValidation, "session lifetime must be greater than 0")
+	ErrSessionRevoked    = shared.NewDomainError("session.revoked", shared.KindState, "session has been revoked")
+	ErrSessionExpired    = shared.NewDomainError("session.expired", shared.KindState, "session has expired")
+	ErrDeviceMismatch    = shared.NewDomainError("session.device_mismatch", shared.KindForbidden, "session was created on another device")
+)
+
+type RevocationReason string
+
+const (
+	RevokedByLogout         RevocationReason = "logout"
+	RevokedByPasswordChange RevocationReason = "password_change"
+	RevokedByAccountDisable RevocationReason = "account_disabled" // Disabled, suspended, blocked or deleted
+	RevokedByStaff          RevocationReason = "staff"
+	RevokedByTokenReuse     RevocationReason = "token_reuse" // A rotated refresh token came back, it was likely stolen
+	RevokedByDeviceMismatch RevocationReason = "device_mismatch"
+)
+
+// Session is one signed-in device. The lifetime is absolute, Touch records
+// activity but does not extend it.
+type Session struct {
+	ID                string
+	AccountID         string
+	DeviceFingerprint string
+	IPAddress         string // Last seen
+	UserAgent         string
+
+	CreatedAt     time.Time
+	LastSeenAt    time.Time
+	ExpiresAt     time.Time
+	RevokedAt     *time.Time
+	RevokedReason *RevocationReason
+}
+
+func NewSession(id, accountID, deviceFingerprint, ipAddress, userAgent string, ttl time.Duration, at time.Time) (*Session, error) {
+	if strings.TrimSpace(id) == "" || strings.TrimSpace(accountID) == "" {
+		return nil, ErrEmptyID
+	}
+	if strings.TrimSpace(ipAddress) == "" {
+		return nil, ErrEmptyIPAddress
+	}
+	if ttl <= 0 {
+		return nil, ErrInvalidSessionTTL
+	}
+	return &Session{
+		ID:                id,
+		AccountID:         accountID,
+		DeviceFingerprint: deviceFingerprint,
+		IPAddress:         ipAddress,
+		UserAgent:         userAgent,
+		CreatedAt:         at,
+		LastSeenAt:        at,
+		ExpiresAt:         at.Add(ttl),
+	}, nil
+}
+
+// Business Methods
+
+func (s *Session) Revoke(reason RevocationReason, at time.Time) error {
+	if s.RevokedAt != nil {
+		return ErrSessionRevoked
+	}
+	s.RevokedAt = &at
+	s.RevokedReason = &reason
+	return nil
+}
+
+// Touch records a request made with the session. A different device
+// fingerprint means the session token was copied elsewhere and is refused.
+func (s *Session) Touch(deviceFingerprint, ipAddress string, at time.Time) error {
+	if s.RevokedAt != nil {
+		return ErrSessionRevoked
+	}
+	if s.IsExpired(at) {
+		return ErrSessionExpired
+	}
+	if s.DeviceFingerprint != "" && deviceFingerprint != s.DeviceFingerprint {
+		return ErrDeviceMismatch
+	}
+	if strings.TrimSpace(ipAddress) != "" {
+		s.IPAddress = ipAddress
+	}
+	s.LastSeenAt = at
+	return nil
+}
+
+// Query Methods
+
+func (s *Session) IsExpired(at time.Time) bool {
+	return !at.Before(s.ExpiresAt)
+}
+
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+func (s *Session) IsActive(at time.Time) bool {
+	return !s.IsRevoked() && !s.IsExpired(at)
+}
+
+// ValidFor reports whether the session may act for the account. Changing the
+// password and disabling or deleting the account set SessionsRevokedAt, which
+// ends every earlier session even before the stored ones are revoked.
+func (s *Session) ValidFor(account *UserAccount, at time.Time) bool {
+	if !s.IsActive(at) || account == nil || account.ID != s.AccountID || !account.IsActive() {
+		return false
+	}
+	return account.SessionsRevokedAt == nil || s.CreatedAt.After(*account.SessionsRevokedAt)
+}
@@ -0,0 +1,95 @@
+package account
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+func TestSession_Touch(t *testing.T) {
+	at := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	session, err := NewSession("s1", "m1", "device-1", "203.0.113.9", "Firefox", 24*time.Hour, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		at          time.Time
+		wantErr     error
+	}{
+		{"same device", "device-1", at.Add(time.Hour), nil},
+		{"copied token", "device-2", at.Add(time.Hour), ErrDeviceMismatch},
+		{"expired", "device-1", at.Add(24 * time.Hour), ErrSessionExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := session.Touch(tt.fingerprint, "198.51.100.4", tt.at); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error '%v', got '%v'", tt.wantErr, err)
+			}
+		})
+	}
+	if session.IPAddress != "198.51.100.4" || !session.LastSeenAt.Equal(at.Add(time.Hour)) || !session.ExpiresAt.Equal(at.Add(24*time.Hour)) {
+		t.Errorf("expected activity recorded without extending the lifetime, got %+v", session)
+	}
+
+	if err := session.Revoke(RevokedByLogout, at.Add(2*time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := session.Revoke(RevokedByStaff, at.Add(3*time.Hour)); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("expected error '%v', got '%v'", ErrSessionRevoked, err)
+	}
+	if err := session.Touch("device-1", "", at.Add(3*time.Hour)); !errors.Is(err, ErrSessionRevoked) || *session.RevokedReason != RevokedByLogout {
+		t.Errorf("expected the logout to stick, got %v", err)
+	}
+
+	if _, err := NewSession("s2", "m1", "", "203.0.113.9", "", 0, at); !errors.Is(err, ErrInvalidSessionTTL) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidSessionTTL, err)
+	}
+}
+
+func TestSession_ValidForAccountLifecycle(t *testing.T) {
+	clock := shared.NewFrozenClock(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	newAccount := func() *UserAccount {
+		account, _ := NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed_password")
+		account.SetClock(clock)
+		_ = account.SelfVerify()
+		return account
+	}
+
+	tests := []struct {
+		name   string
+		change func(account *UserAccount) error
+	}{
+		{"password change", func(account *UserAccount) error { return account.UpdatePasswordHash("new_hash") }},
+		{"password reset", func(account *UserAccount) error { return account.ResetPasswordHash("new_hash", "m1") }},
+		{"suspension", func(account *UserAccount) error { return account.Suspend("moderator-1", "spam") }},
+		{"deletion", func(account *UserAccount) error { return account.Delete("m1") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newAccount()
+			before, _ := NewSession("s1", "m1", "", "203.0.113.9", "", time.Hour, clock.Now())
+			if !before.ValidFor(account, clock.Now()) {
+				t.Fatal("expected the session valid before the change")
+			}
+
+			clock.Advance(time.Minute)
+			if err := tt.change(account); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if before.ValidFor(account, clock.Now()) {
+				t.Error("expected the earlier session ended by the change")
+			}
+
+			clock.Advance(time.Minute)
+			after, _ := NewSession("s2", "m1", "", "203.0.113.9", "", time.Hour, clock.Now())
+			if after.ValidFor(account, clock.Now()) != account.IsActive() {
+				t.Errorf("expected a later session valid only while the account is active")
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package account
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var ErrInvalidSnapshot = shared.NewDomainError("account.invalid_snapshot", shared.KindValidation, "stored user account is inconsistent")
+
+// UserAccountSnapshot is the persisted state of a UserAccount in plain
+// values, one field per column. Repositories scan rows into it and write it back.
+type UserAccountSnapshot struct {
+	ID           string
+	Username     string
+	Email        string
+	PasswordHash string
+
+	Status         UserAccountStatus
+	Type           UserAccountType
+	RegisteredBy   *string
+	DisabilityType *DisabilityType
+	IsVerified     bool
+	VerifiedBy     *string
+	VerifiedAt     *time.Time
+	IssuedReason   *string
+	DisabledAt     *time.Time
+	SuspendedUntil *time.Time
+
+	LastActionBy *string
+
+	LastLoginAt            *time.Time
+	LastLoginIP            *string
+	FailedLoginAttempts    int
+	LastFailedLoginAttempt *time.Time
+	LastFailedLoginIP      *string
+	LockedUntil            *time.Time
+	SessionsRevokedAt      *time.Time
+
+	TOTPSecret      *string
+	TOTPConfirmedAt *time.Time
+	TOTPLastStep    int64
+	BackupCodes     []string // Hashes of the unused codes
+
+	Roles []Role // From the role assignments rather than a column
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+	DeletedBy *string
+}
+
+// ReconstituteUserAccount rehydrates an account from storage. Creation rules
+// (pending status, username and password format) are not re-run, only the
+// consistency a corrupt or hand-edited row would break is checked.
+func ReconstituteUserAccount(s UserAccountSnapshot) (*UserAccount, error) {
+	if err := s.validate(); err != nil {
+		return nil, ErrInvalidSnapshot.Wrap(err).With("account_id", s.ID)
+	}
+
+	ua := &UserAccount{
+		ID:                     s.ID,
+		Username:               ReconstituteUsername(s.Username),
+		Email:                  ReconstituteEmail(s.Email),
+		PasswordHash:           NewPasswordHash(s.PasswordHash),
+		Status:                 s.Status,
+		Type:                   s.Type,
+		RegisteredBy:           s.RegisteredBy,
+		DisabilityType:         s.DisabilityType,
+		IsVerified:             s.IsVerified,
+		VerifiedBy:             s.VerifiedBy,
+		VerifiedAt:             s.VerifiedAt,
+		IssuedReason:           s.IssuedReason,
+		DisabledAt:             s.DisabledAt,
+		SuspendedUntil:         s.SuspendedUntil,
+		LastActionBy:           s.LastActionBy,
+		LastLoginAt:            s.LastLoginAt,
+		LastLoginIP:            s.LastLoginIP,
+		FailedLoginAttempts:    s.FailedLoginAttempts,
+		LastFailedLoginAttempt: s.LastFailedLoginAttempt,
+		LastFailedLoginIP:      s.LastFailedLoginIP,
+		LockedUntil:            s.LockedUntil,
+		SessionsRevokedAt:      s.SessionsRevokedAt,
+		TOTPConfirmedAt:        s.TOTPConfirmedAt,
+		TOTPLastStep:           s.TOTPLastStep,
+		BackupCodes:            ReconstituteBackupCodes(s.BackupCodes),
+		Roles:                  s.Roles,
+		CreatedAt:              s.CreatedAt,
+		UpdatedAt:              s.UpdatedAt,
+		DeletedAt:              s.DeletedAt,
+		DeletedBy:              s.DeletedBy,
+	}
+	if s.TOTPSecret != nil {
+		secret := ReconstituteTOTPSecret(*s.TOTPSecret)
+		ua.TOTPSecret = &secret
+	}
+	return ua, nil
+}
+
+// Snapshot is the account's state for persisting
+func (ua *UserAccount) Snapshot() UserAccountSnapshot {
+	s := UserAccountSnapshot{
+		ID:                     ua.ID,
+		Username:               ua.Username.String(),
+		Email:                  ua.Email.String(),
+		PasswordHash:           ua.PasswordHash.Encoded(),
+		Status:                 ua.Status,
+		Type:                   ua.Type,
+		RegisteredBy:           ua.RegisteredBy,
+		DisabilityType:         ua.DisabilityType,
+		IsVerified:             ua.IsVerified,
+		VerifiedBy:             ua.VerifiedBy,
+		VerifiedAt:             ua.VerifiedAt,
+		IssuedReason:           ua.IssuedReason,
+		DisabledAt:             ua.DisabledAt,
+		SuspendedUntil:         ua.SuspendedUntil,
+		LastActionBy:           ua.LastActionBy,
+		LastLoginAt:            ua.LastLoginAt,
+		LastLoginIP:            ua.LastLoginIP,
+		FailedLoginAttempts:    ua.FailedLoginAttempts,
+		LastFailedLoginAttempt: ua.LastFailedLoginAttempt,
+		LastFailedLoginIP:      ua.LastFailedLoginIP,
+		LockedUntil:            ua.LockedUntil,
+		SessionsRevokedAt:      ua.SessionsRevokedAt,
+		TOTPConfirmedAt:        ua.TOTPConfirmedAt,
+		TOTPLastStep:           ua.TOTPLastStep,
+		BackupCodes:            ua.BackupCodes.Hashes(),
+		Roles:                  ua.Roles,
+		CreatedAt:              ua.CreatedAt,
+		UpdatedAt:              ua.UpdatedAt,
+		DeletedAt:              ua.DeletedAt,
+		DeletedBy:              ua.DeletedBy,
+	}
+	if ua.TOTPSecret != nil {
+		secret := ua.TOTPSecret.Value()
+		s.TOTPSecret = &secret
+	}
+	return s
+}
+
+func (s UserAccountSnapshot) validate() error {
+	switch {
+	case strings.TrimSpace(s.ID) == "":
+		return ErrEmptyID
+	case s.Username == "" || s.Email == "":
+		return fmt.Errorf("username and email are required")
+	case validateAccountType(s.Type) != nil:
+		return fmt.Errorf("unknown account type %q", s.Type)
+	case s.FailedLoginAttempts < 0:
+		return fmt.Errorf("negative failed login attempts")
+	case s.TOTPConfirmedAt != nil && s.TOTPSecret == nil:
+		return fmt.Errorf("two-factor is confirmed without a secret")
+	}
+
+	switch s.Status {
+	case StatusPendingVerification:
+		if s.IsVerified {
+			return fmt.Errorf("pending account is marked verified")
+		}
+	case StatusActive:
+	case StatusDisabled:
+		if s.DisabilityType == nil {
+			return fmt.Errorf("disabled account has no disability type")
+		}
+		if err := validateDisabilityType(*s.DisabilityType); err != nil {
+			return err
+		}
+	case StatusDeleted:
+		if s.DeletedAt == nil {
+			return fmt.Errorf("deleted account has no deletion time")
+		}
+	default:
+		return fmt.Errorf("unknown status %q", s.Status)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package account
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReconstituteUserAccount_RoundTrip(t *testing.T) {
+	account, err := NewUserAccountForSelfRegistration("member123", "member_user", "member@example.com", "hashed_password")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := account.SelfVerify(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	account.RecordSuccessfulLogin("203.0.113.9")
+
+	restored, err := ReconstituteUserAccount(account.Snapshot())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored.Status != StatusActive || !restored.IsVerified || restored.Username != account.Username || *restored.LastLoginIP != "203.0.113.9" {
+		t.Errorf("expected the active verified account back, got %+v", restored)
+	}
+}
+
+func TestReconstituteUserAccount_SkipsCreationRules(t *testing.T) {
+	// "ab" is shorter than NewUsername allows today but was accepted when the account was created
+	if _, err := NewUsername("ab"); err == nil {
+		t.Fatal("expected the legacy username rejected by NewUsername")
+	}
+	suspended := DisabilityTypeSuspended
+	disabledAt := time.Now()
+
+	account, err := ReconstituteUserAccount(UserAccountSnapshot{
+		ID:             "legacy-1",
+		Username:       "ab",
+		Email:          "ab@example.com",
+		PasswordHash:   "hashed_password",
+		Status:         StatusDisabled,
+		Type:           TypeMembership,
+		DisabilityType: &suspended,
+		IsVerified:     true,
+		DisabledAt:     &disabledAt,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if account.Username.String() != "ab" || account.Status != StatusDisabled {
+		t.Errorf("expected the stored state unchanged, got %+v", account)
+	}
+}
+
+func TestReconstituteUserAccount_Inconsistent(t *testing.T) {
+	valid := UserAccountSnapshot{ID: "u1", Username: "member_user", Email: "member@example.com", Status: StatusActive, Type: TypeMembership}
+
+	tests := []struct {
+		name   string
+		modify func(s *UserAccountSnapshot)
+	}{
+		{"empty id", func(s *UserAccountSnapshot) { s.ID = "" }},
+		{"unknown status", func(s *UserAccountSnapshot) { s.Status = "archived" }},
+		{"unknown type", func(s *UserAccountSnapshot) { s.Type = "robot" }},
+		{"disabled without type", func(s *UserAccountSnapshot) { s.Status = StatusDisabled }},
+		{"deleted without time", func(s *UserAccountSnapshot) { s.Status = StatusDeleted }},
+		{"verified while pending", func(s *UserAccountSnapshot) { s.Status, s.IsVerified = StatusPendingVerification, true }},
+		{"two-factor without secret", func(s *UserAccountSnapshot) { now := time.Now(); s.TOTPConfirmedAt = &now }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.modify(&s)
+			if _, err := ReconstituteUserAccount(s); !errors.Is(err, ErrInvalidSnapshot) {
+				t.Errorf("expected error '%v', got '%v'", ErrInvalidSnapshot, err)
+			}
+		})
+	}
+}
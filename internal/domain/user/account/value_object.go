@@ -1,10 +1,11 @@
 package account
 
 import (
-	"errors"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
 )
 
 // Compile regex once for better performance
@@ -15,13 +16,13 @@ var (
 
 // Domain errors
 var (
-	ErrUsernameTooShort     = errors.New("username must be at least 3 characters")
-	ErrUsernameTooLong      = errors.New("username cannot exceed 30 characters")
-	ErrUsernameInvalidChars = errors.New("username can only contain letters, numbers, and underscore")
-	ErrInvalidEmail         = errors.New("invalid email format")
-	ErrInvalidPassword      = errors.New("invalid password")
-	ErrPasswordTooShort     = errors.New("password must be at least 8 characters")
-	ErrPasswordTooWeak      = errors.New("password must contain uppercase, lowercase, number, and special character")
+	ErrUsernameTooShort     = shared.NewDomainError("account.username_too_short", shared.KindValidation, "username must be at least 3 characters")
+	ErrUsernameTooLong      = shared.NewDomainError("account.username_too_long", shared.KindValidation, "username cannot exceed 30 characters")
+	ErrUsernameInvalidChars = shared.NewDomainError("account.username_invalid_chars", shared.KindValidation, "username can only contain letters, numbers, and underscore")
+	ErrInvalidEmail         = shared.NewDomainError("account.invalid_email", shared.KindValidation, "invalid email format")
+	ErrInvalidPassword      = shared.NewDomainError("account.invalid_password", shared.KindValidation, "invalid password")
+	ErrPasswordTooShort     = shared.NewDomainError("account.password_too_short", shared.KindValidation, "password must be at least 8 characters")
+	ErrPasswordTooWeak      = shared.NewDomainError("account.password_too_weak", shared.KindValidation, "password must contain uppercase, lowercase, number, and special character")
 )
 
 // Username value object
@@ -47,11 +48,13 @@ func NewUsername(value string) (*Username, error) {
 	return &Username{value: value}, nil
 }
 
-func (u Username) String() string {
-	return u.value
+// ReconstituteUsername restores a stored username without validation, rules
+// tightened since it was chosen must not make the account unloadable
+func ReconstituteUsername(value string) Username {
+	return Username{value: value}
 }
 
-func (u Username) Value() string {
+func (u Username) String() string {
 	return u.value
 }
 
@@ -74,11 +77,12 @@ func NewEmail(value string) (*Email, error) {
 	return &Email{value: value}, nil
 }
 
-func (e Email) String() string {
-	return e.value
+// ReconstituteEmail restores a stored email address without validation
+func ReconstituteEmail(value string) Email {
+	return Email{value: value}
 }
 
-func (e Email) Value() string {
+func (e Email) String() string {
 	return e.value
 }
 
@@ -101,7 +105,8 @@ func NewPasswordHash(value string) PasswordHash {
 	return PasswordHash{value: value}
 }
 
-func (h PasswordHash) Value() string {
+// Encoded returns the hash as the hasher produced it, kept out of String so it is never printed
+func (h PasswordHash) Encoded() string {
 	return h.value
 }
 
@@ -114,36 +119,9 @@ func (h PasswordHash) Compare(raw string, hasher PasswordHasher) (bool, error) {
 	return hasher.Compare(raw, h.value)
 }
 
-// Password validation function (domain rule)
-func ValidatePassword(raw string) error {
-	if strings.TrimSpace(raw) == "" {
-		return ErrInvalidPassword
-	}
-
-	if len(raw) < 8 {
-		return ErrPasswordTooShort
-	}
-
-	var hasUpper, hasLower, hasNumber, hasSpecial bool
-
-	for _, r := range raw {
-		switch {
-		case unicode.IsUpper(r):
-			hasUpper = true
-		case unicode.IsLower(r):
-			hasLower = true
-		case unicode.IsNumber(r):
-			hasNumber = true
-		case unicode.IsPunct(r) || unicode.IsSymbol(r):
-			hasSpecial = true
-		}
-	}
-
-	if !hasUpper || !hasLower || !hasNumber || !hasSpecial {
-		return ErrPasswordTooWeak
-	}
-
-	return nil
+// Password validation function (domain rule), the policy comes from the account type's SecurityPolicy
+func ValidatePassword(raw string, policy PasswordPolicy) error {
+	return policy.Validate(raw)
 }
 
 // Helper functions for password strength checking
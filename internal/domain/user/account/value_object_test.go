@@ -1,6 +1,7 @@
 package account
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -46,8 +47,8 @@ func TestNewUsername(t *testing.T) {
 			if u.String() != tc.expected {
 				t.Errorf("expected username '%s', got '%s'", tc.expected, u.String())
 			}
-			if u.Value() != tc.expected {
-				t.Errorf("expected username value '%s', got '%s'", tc.expected, u.Value())
+			if u.String() != tc.expected {
+				t.Errorf("expected username value '%s', got '%s'", tc.expected, u.String())
 			}
 		})
 	}
@@ -111,8 +112,8 @@ func TestNewEmail(t *testing.T) {
 			if e.String() != tc.expected {
 				t.Errorf("expected email '%s', got '%s'", tc.expected, e.String())
 			}
-			if e.Value() != tc.expected {
-				t.Errorf("expected email value '%s', got '%s'", tc.expected, e.Value())
+			if e.String() != tc.expected {
+				t.Errorf("expected email value '%s', got '%s'", tc.expected, e.String())
 			}
 		})
 	}
@@ -158,13 +159,13 @@ func TestValidatePassword(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := ValidatePassword(tc.input)
+			err := ValidatePassword(tc.input, DefaultPasswordPolicy)
 			if tc.expectError {
 				if err == nil {
 					t.Errorf("expected error, but got none")
 					return
 				}
-				if tc.expectedErr != nil && err != tc.expectedErr {
+				if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
 					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
 				}
 				return
@@ -181,8 +182,8 @@ func TestNewPasswordHash_Value_And_Equals(t *testing.T) {
 	hashStr := "$2a$10$somerandomhashvalue"
 	ph := NewPasswordHash(hashStr)
 
-	if ph.Value() != hashStr {
-		t.Errorf("expected value '%s', got '%s'", hashStr, ph.Value())
+	if ph.Encoded() != hashStr {
+		t.Errorf("expected value '%s', got '%s'", hashStr, ph.Encoded())
 	}
 
 	ph2 := NewPasswordHash(hashStr)
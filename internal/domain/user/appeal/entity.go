@@ -0,0 +1,231 @@
+package appeal
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var (
+	ErrEmptyID              = shared.NewDomainError("appeal.id_required", shared.KindValidation, "ID cannot be empty")
+	ErrEmptyAccount         = shared.NewDomainError("appeal.account_required", shared.KindValidation, "account cannot be empty")
+	ErrAccountNotDisabled   = shared.NewDomainError("appeal.account_not_disabled", shared.KindState, "only disabled accounts can file an appeal")
+	ErrStatementTooShort    = shared.NewDomainError("appeal.statement_too_short", shared.KindValidation, "statement must be at least 20 characters")
+	ErrStatementTooLong     = shared.NewDomainError("appeal.statement_too_long", shared.KindValidation, "statement cannot exceed 4000 characters")
+	ErrBlankTicketReference = shared.NewDomainError("appeal.ticket_reference_blank", shared.KindValidation, "ticket reference cannot be blank")
+	ErrNotOpen              = shared.NewDomainError("appeal.not_open", shared.KindState, "appeal is not open")
+	ErrEmptyReviewerID      = shared.NewDomainError("appeal.reviewer_required", shared.KindValidation, "reviewer ID cannot be empty")
+	ErrNotUnderReview       = shared.NewDomainError("appeal.not_under_review", shared.KindState, "appeal is not under review")
+	ErrNotAssignedReviewer  = shared.NewDomainError("appeal.not_assigned_reviewer", shared.KindForbidden, "only the assigned reviewer can decide the appeal")
+	ErrEmptyDecisionNote    = shared.NewDomainError("appeal.decision_note_required", shared.KindValidation, "decision note cannot be empty")
+	ErrInvalidOutcome       = shared.NewDomainError("appeal.invalid_outcome", shared.KindValidation, "invalid appeal outcome")
+	ErrNotPending           = shared.NewDomainError("appeal.not_pending", shared.KindState, "only pending appeals can be withdrawn")
+	ErrNotAppellant         = shared.NewDomainError("appeal.not_appellant", shared.KindForbidden, "only the appellant can withdraw the appeal")
+	ErrEmptyTicketReference = shared.NewDomainError("appeal.ticket_reference_required", shared.KindValidation, "ticket reference cannot be empty")
+	ErrNotDecided           = shared.NewDomainError("appeal.not_decided", shared.KindState, "appeal has not been decided")
+	ErrAlreadyNotified      = shared.NewDomainError("appeal.already_notified", shared.KindConflict, "decision has already been notified")
+)
+
+type AppealStatus string
+
+const (
+	StatusOpen        AppealStatus = "open"
+	StatusUnderReview AppealStatus = "under_review"
+	StatusUpheld      AppealStatus = "upheld"   // Disable stands
+	StatusReversed    AppealStatus = "reversed" // Account is reactivated
+	StatusWithdrawn   AppealStatus = "withdrawn"
+)
+
+type Outcome string
+
+const (
+	OutcomeUphold  Outcome = "uphold"
+	OutcomeReverse Outcome = "reverse"
+)
+
+// Limits for the reader's statement
+const (
+	MinStatementLength = 20
+	MaxStatementLength = 4000
+)
+
+type Appeal struct {
+	ID        string
+	AccountID string
+
+	// Snapshot of the disable being appealed
+	DisabledAt     time.Time
+	DisabilityType account.DisabilityType
+	DisableReason  string
+
+	Statement       string
+	TicketReference *string // External support ticket, e.g. "ZD-10234"
+
+	// Review
+	Status        AppealStatus
+	ReviewerID    *string
+	ReviewStarted *time.Time
+	DecisionNote  *string
+	DecidedAt     *time.Time
+	NotifiedAt    *time.Time
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewAppeal files an appeal against the account's current disable
+func NewAppeal(id string, disabled *account.UserAccount, statement string, ticketReference *string) (*Appeal, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	if disabled == nil {
+		return nil, ErrEmptyAccount
+	}
+	if !disabled.IsDisabled() || disabled.DisabilityType == nil || disabled.DisabledAt == nil {
+		return nil, ErrAccountNotDisabled
+	}
+
+	statement = strings.TrimSpace(statement)
+	if len(statement) < MinStatementLength {
+		return nil, ErrStatementTooShort
+	}
+	if len(statement) > MaxStatementLength {
+		return nil, ErrStatementTooLong
+	}
+
+	if ticketReference != nil {
+		ref := strings.TrimSpace(*ticketReference)
+		if ref == "" {
+			return nil, ErrBlankTicketReference
+		}
+		ticketReference = &ref
+	}
+
+	reason := ""
+	if disabled.IssuedReason != nil {
+		reason = *disabled.IssuedReason
+	}
+
+	now := time.Now()
+	return &Appeal{
+		ID:              id,
+		AccountID:       disabled.ID,
+		DisabledAt:      *disabled.DisabledAt,
+		DisabilityType:  *disabled.DisabilityType,
+		DisableReason:   reason,
+		Statement:       statement,
+		TicketReference: ticketReference,
+		Status:          StatusOpen,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// Business Methods
+
+// StartReview assigns the appeal to a staff reviewer
+func (a *Appeal) StartReview(reviewerID string) error {
+	if a.Status != StatusOpen {
+		return ErrNotOpen
+	}
+	if strings.TrimSpace(reviewerID) == "" {
+		return ErrEmptyReviewerID
+	}
+
+	now := time.Now()
+	a.Status = StatusUnderReview
+	a.ReviewerID = &reviewerID
+	a.ReviewStarted = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+// Decide records the outcome, the caller reactivates the account when the disable is reversed
+func (a *Appeal) Decide(reviewerID string, outcome Outcome, note string) error {
+	if a.Status != StatusUnderReview {
+		return ErrNotUnderReview
+	}
+	if a.ReviewerID == nil || *a.ReviewerID != reviewerID {
+		return ErrNotAssignedReviewer
+	}
+	if strings.TrimSpace(note) == "" {
+		return ErrEmptyDecisionNote
+	}
+
+	switch outcome {
+	case OutcomeUphold:
+		a.Status = StatusUpheld
+	case OutcomeReverse:
+		a.Status = StatusReversed
+	default:
+		return ErrInvalidOutcome
+	}
+
+	now := time.Now()
+	a.DecisionNote = &note
+	a.DecidedAt = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+// Withdraw lets the reader retract an appeal before a decision
+func (a *Appeal) Withdraw(accountID string) error {
+	if !a.IsPending() {
+		return ErrNotPending
+	}
+	if accountID != a.AccountID {
+		return ErrNotAppellant
+	}
+
+	a.Status = StatusWithdrawn
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+// LinkTicket attaches the support ticket tracking the conversation with the reader
+func (a *Appeal) LinkTicket(reference string) error {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return ErrEmptyTicketReference
+	}
+	a.TicketReference = &reference
+	a.UpdatedAt = time.Now()
+	return nil
+}
+
+func (a *Appeal) MarkNotified() error {
+	if !a.IsDecided() {
+		return ErrNotDecided
+	}
+	if a.NotifiedAt != nil {
+		return ErrAlreadyNotified
+	}
+
+	now := time.Now()
+	a.NotifiedAt = &now
+	a.UpdatedAt = now
+	return nil
+}
+
+// Query Methods
+
+// IsPending reports whether the appeal still counts as the open appeal for its disable
+func (a *Appeal) IsPending() bool {
+	return a.Status == StatusOpen || a.Status == StatusUnderReview
+}
+
+func (a *Appeal) IsDecided() bool {
+	return a.Status == StatusUpheld || a.Status == StatusReversed
+}
+
+func (a *Appeal) IsReversed() bool {
+	return a.Status == StatusReversed
+}
+
+// AppliesTo reports whether the appeal concerns the account's current disable
+func (a *Appeal) AppliesTo(acc *account.UserAccount) bool {
+	return acc.ID == a.AccountID && acc.IsDisabled() && acc.DisabledAt != nil && acc.DisabledAt.Equal(a.DisabledAt)
+}
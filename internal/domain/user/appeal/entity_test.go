@@ -0,0 +1,130 @@
+package appeal
+
+import (
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+const testStatement = "I believe my comment was misread, it quoted the article."
+
+func TestNewAppeal(t *testing.T) {
+	blank := " "
+	ticket := " ZD-1001 "
+
+	tests := []struct {
+		name      string
+		setup     func(*account.UserAccount)
+		statement string
+		ticket    *string
+		wantErr   bool
+		errMsg    string
+	}{
+		{name: "valid appeal with ticket", setup: func(ua *account.UserAccount) {}, statement: testStatement, ticket: &ticket},
+		{name: "active account", setup: func(ua *account.UserAccount) { _ = ua.Reactivate("admin1") }, statement: testStatement, wantErr: true, errMsg: "only disabled accounts can file an appeal"},
+		{name: "short statement", setup: func(ua *account.UserAccount) {}, statement: "please", wantErr: true, errMsg: "statement must be at least 20 characters"},
+		{name: "blank ticket", setup: func(ua *account.UserAccount) {}, statement: testStatement, ticket: &blank, wantErr: true, errMsg: "ticket reference cannot be blank"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := createDisabledMember(t)
+			tt.setup(acc)
+
+			a, err := NewAppeal("apl-1", acc, tt.statement, tt.ticket)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if a.Status != StatusOpen {
+				t.Errorf("expected status %s, got %s", StatusOpen, a.Status)
+			}
+			if a.DisabilityType != account.DisabilityTypeViolation || a.DisableReason != "abusive comment" {
+				t.Error("expected disable snapshot to be recorded")
+			}
+			if !a.DisabledAt.Equal(*acc.DisabledAt) {
+				t.Error("expected disabledAt snapshot")
+			}
+			if a.TicketReference == nil || *a.TicketReference != "ZD-1001" {
+				t.Error("expected trimmed ticket reference")
+			}
+			if !a.AppliesTo(acc) {
+				t.Error("expected appeal to apply to the current disable")
+			}
+		})
+	}
+}
+
+func TestAppeal_Review(t *testing.T) {
+	a, _ := NewAppeal("apl-1", createDisabledMember(t), testStatement, nil)
+
+	if err := a.Decide("mod1", OutcomeReverse, "note"); err == nil {
+		t.Error("expected error deciding an appeal not under review")
+	}
+	if err := a.StartReview("mod1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.StartReview("mod2"); err == nil {
+		t.Error("expected error starting review twice")
+	}
+	if err := a.Decide("mod2", OutcomeReverse, "note"); err == nil || err.Error() != "only the assigned reviewer can decide the appeal" {
+		t.Errorf("expected reviewer error, got %v", err)
+	}
+	if err := a.Decide("mod1", "maybe", "note"); err == nil || err.Error() != "invalid appeal outcome" {
+		t.Errorf("expected outcome error, got %v", err)
+	}
+	if err := a.Decide("mod1", OutcomeReverse, " "); err == nil {
+		t.Error("expected error for empty decision note")
+	}
+	if err := a.MarkNotified(); err == nil {
+		t.Error("expected error notifying an undecided appeal")
+	}
+
+	if err := a.Decide("mod1", OutcomeReverse, "Quote was taken out of context"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !a.IsReversed() || a.IsPending() || a.DecidedAt == nil {
+		t.Error("expected reversed, decided appeal")
+	}
+	if err := a.MarkNotified(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := a.MarkNotified(); err == nil {
+		t.Error("expected error notifying twice")
+	}
+}
+
+func TestAppeal_Withdraw(t *testing.T) {
+	a, _ := NewAppeal("apl-1", createDisabledMember(t), testStatement, nil)
+
+	if err := a.Withdraw("someone_else"); err == nil {
+		t.Error("expected error withdrawing someone else's appeal")
+	}
+	if err := a.Withdraw("member123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Status != StatusWithdrawn || a.IsPending() {
+		t.Error("expected appeal to be withdrawn")
+	}
+}
+
+func createDisabledMember(t *testing.T) *account.UserAccount {
+	acc, err := account.NewUserAccountForSelfRegistration("member123", "member_user", "member@example.com", "hashed_password")
+	if err != nil {
+		t.Fatalf("failed to create member: %v", err)
+	}
+	if err := acc.SelfVerify(); err != nil {
+		t.Fatalf("failed to verify member: %v", err)
+	}
+	if err := acc.SetViolation("mod1", "abusive comment"); err != nil {
+		t.Fatalf("failed to disable member: %v", err)
+	}
+	return acc
+}
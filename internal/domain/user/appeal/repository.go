@@ -0,0 +1,54 @@
+package appeal
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type AppealFilter struct {
+	Status     *AppealStatus
+	ReviewerID *string
+	AccountID  *string
+
+	// Pagination
+	Limit  int
+	Offset int
+}
+
+// Validate filter parameters
+func (f *AppealFilter) Validate() error {
+	if f.Limit <= 0 || f.Limit > 100 {
+		return errors.New("limit must be between 1 and 100")
+	}
+	if f.Offset < 0 {
+		return errors.New("offset must be non-negative")
+	}
+	return nil
+}
+
+// Set default values for pagination
+func (f *AppealFilter) SetDefaults() {
+	if f.Limit == 0 {
+		f.Limit = 20
+	}
+}
+
+type AppealRepository interface {
+	// Commands
+	Create(ctx context.Context, appeal *Appeal) error
+	Update(ctx context.Context, appeal *Appeal) error
+
+	// Query - Single
+	FindByID(ctx context.Context, id string) (*Appeal, error)
+
+	// Query - Multiple
+	Find(ctx context.Context, filter *AppealFilter) ([]*Appeal, error)
+	FindByAccountID(ctx context.Context, accountID string) ([]*Appeal, error)
+
+	// Review queue, open and under-review appeals oldest first
+	FindReviewQueue(ctx context.Context, limit, offset int) ([]*Appeal, error)
+
+	// One open appeal per disable
+	ExistsPendingForDisable(ctx context.Context, accountID string, disabledAt time.Time) (bool, error)
+}
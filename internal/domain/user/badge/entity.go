@@ -0,0 +1,91 @@
+package badge
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Badge marks a notable author or commenter as verified by the newsroom.
+// It is unrelated to account verification, which only proves the email address.
+type Badge struct {
+	AccountID    string
+	Kind         Kind
+	Skeleton     string // Skeleton of the holder's username, to stop look-alike registrations
+	Reason       string
+	GrantedBy    string
+	GrantedAt    time.Time
+	RevokedBy    *string
+	RevokedAt    *time.Time
+	RevokeReason *string
+}
+
+// AuditEntry records every grant and revocation, badges are never edited silently
+type AuditEntry struct {
+	ID        string
+	AccountID string
+	Kind      Kind
+	Action    Action
+	ActorID   string
+	Reason    string
+	At        time.Time
+}
+
+func NewBadge(accountID, username string, kind Kind, grantedBy, reason string, at time.Time) (*Badge, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if _, err := ParseKind(string(kind)); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(grantedBy) == "" {
+		return nil, errors.New("granted by cannot be empty")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, ErrReasonRequired
+	}
+
+	return &Badge{
+		AccountID: accountID,
+		Kind:      kind,
+		Skeleton:  Skeleton(username),
+		Reason:    strings.TrimSpace(reason),
+		GrantedBy: grantedBy,
+		GrantedAt: at,
+	}, nil
+}
+
+// Business Methods
+
+func (b *Badge) Revoke(revokedBy, reason string, at time.Time) error {
+	if !b.IsActive() {
+		return ErrAlreadyRevoked
+	}
+	if strings.TrimSpace(reason) == "" {
+		return ErrReasonRequired
+	}
+	reason = strings.TrimSpace(reason)
+	b.RevokedBy = &revokedBy
+	b.RevokedAt = &at
+	b.RevokeReason = &reason
+	return nil
+}
+
+// Audit returns the trail entry for the badge's latest change
+func (b *Badge) Audit(id string) AuditEntry {
+	if b.IsActive() {
+		return AuditEntry{ID: id, AccountID: b.AccountID, Kind: b.Kind, Action: ActionGrant, ActorID: b.GrantedBy, Reason: b.Reason, At: b.GrantedAt}
+	}
+	return AuditEntry{ID: id, AccountID: b.AccountID, Kind: b.Kind, Action: ActionRevoke, ActorID: *b.RevokedBy, Reason: *b.RevokeReason, At: *b.RevokedAt}
+}
+
+// Query Methods
+
+func (b *Badge) IsActive() bool {
+	return b.RevokedAt == nil
+}
+
+// Impersonates reports whether another account's username looks like this badge holder's
+func (b *Badge) Impersonates(accountID, username string) bool {
+	return b.IsActive() && accountID != b.AccountID && Skeleton(username) == b.Skeleton
+}
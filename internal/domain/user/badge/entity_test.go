@@ -0,0 +1,75 @@
+package badge
+
+import (
+	"testing"
+	"time"
+)
+
+func createTestBadge(t *testing.T) *Badge {
+	t.Helper()
+	b, err := NewBadge("acc-1", "jokosaputro", KindAuthor, "staff-1", "Senior columnist", time.Now())
+	if err != nil {
+		t.Fatalf("failed to create badge: %v", err)
+	}
+	return b
+}
+
+func TestNewBadge(t *testing.T) {
+	testCases := []struct {
+		name          string
+		kind          Kind
+		reason        string
+		expectedError error
+	}{
+		{"valid", KindAuthor, "Senior columnist", nil},
+		{"invalid kind", "celebrity", "Famous", ErrInvalidKind},
+		{"missing reason", KindCommenter, "  ", ErrReasonRequired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewBadge("acc-1", "jokosaputro", tc.kind, "staff-1", tc.reason, time.Now())
+			if err != tc.expectedError {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestBadge_Revoke(t *testing.T) {
+	b := createTestBadge(t)
+	if entry := b.Audit("audit-1"); entry.Action != ActionGrant || entry.ActorID != "staff-1" {
+		t.Errorf("expected grant entry by staff-1, got %+v", entry)
+	}
+
+	if err := b.Revoke("staff-2", "", time.Now()); err != ErrReasonRequired {
+		t.Errorf("expected error '%v', got '%v'", ErrReasonRequired, err)
+	}
+	if err := b.Revoke("staff-2", "Left the newsroom", time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.IsActive() {
+		t.Error("expected badge to be revoked")
+	}
+	if entry := b.Audit("audit-2"); entry.Action != ActionRevoke || entry.ActorID != "staff-2" || entry.Reason != "Left the newsroom" {
+		t.Errorf("expected revoke entry by staff-2, got %+v", entry)
+	}
+	if err := b.Revoke("staff-2", "again", time.Now()); err != ErrAlreadyRevoked {
+		t.Errorf("expected error '%v', got '%v'", ErrAlreadyRevoked, err)
+	}
+}
+
+func TestBadge_Impersonates(t *testing.T) {
+	b := createTestBadge(t)
+
+	if !b.Impersonates("acc-2", "j0ko_saputr0") {
+		t.Error("expected look-alike username on another account to be flagged")
+	}
+	if b.Impersonates("acc-1", "j0kosaputro") {
+		t.Error("expected the holder's own rename not to be flagged")
+	}
+	_ = b.Revoke("staff-1", "Left the newsroom", time.Now())
+	if b.Impersonates("acc-2", "jokosaputro") {
+		t.Error("expected revoked badge not to block look-alikes")
+	}
+}
@@ -0,0 +1,29 @@
+package badge
+
+import "context"
+
+// Lookup finds active badges to decorate profiles and comment threads
+type Lookup interface {
+	// FindActiveByAccountIDs returns badges keyed by account ID, accounts without one are left out
+	FindActiveByAccountIDs(ctx context.Context, accountIDs []string) (map[string]*Badge, error)
+}
+
+// Domain interface for badge storage (implementation will be in infrastructure layer).
+// Implementations index skeleton for active badges, it is checked on every registration.
+type BadgeRepository interface {
+	Lookup
+
+	// Commands
+	Save(ctx context.Context, badge *Badge) error
+
+	// Queries
+	FindActiveByAccountID(ctx context.Context, accountID string) (*Badge, error) // nil when none
+	FindActiveBySkeleton(ctx context.Context, skeleton string) ([]*Badge, error)
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry AuditEntry) error
+
+	// FindByAccountID returns the account's trail, newest first
+	FindByAccountID(ctx context.Context, accountID string) ([]AuditEntry, error)
+}
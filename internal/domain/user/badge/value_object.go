@@ -0,0 +1,60 @@
+package badge
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kind is what the badge vouches for, shown next to the username
+type Kind string
+
+const (
+	KindAuthor    Kind = "author"    // Notable author or columnist
+	KindCommenter Kind = "commenter" // Notable commenter, e.g. a public figure or subject expert
+)
+
+// Action is an entry in the badge audit trail
+type Action string
+
+const (
+	ActionGrant  Action = "grant"
+	ActionRevoke Action = "revoke"
+)
+
+// Domain errors
+var (
+	ErrInvalidKind    = errors.New("invalid badge kind")
+	ErrReasonRequired = errors.New("a reason is required for badge changes")
+	ErrAlreadyRevoked = errors.New("badge is already revoked")
+	ErrImpersonation  = errors.New("username is too similar to a verified account")
+)
+
+func (k Kind) Label() string {
+	switch k {
+	case KindAuthor:
+		return "Verified author"
+	case KindCommenter:
+		return "Verified commenter"
+	}
+	return ""
+}
+
+func ParseKind(value string) (Kind, error) {
+	switch k := Kind(strings.ToLower(strings.TrimSpace(value))); k {
+	case KindAuthor, KindCommenter:
+		return k, nil
+	}
+	return "", ErrInvalidKind
+}
+
+// Usernames are limited to letters, digits and underscore, so spoofing uses
+// look-alike ASCII: "j0ko_saputr0" for "jokosaputro", "rn" for "m".
+var (
+	confusableChars = strings.NewReplacer("0", "o", "1", "l", "i", "l", "3", "e", "4", "a", "5", "s", "7", "t", "8", "b", "_", "")
+	confusablePairs = strings.NewReplacer("rn", "m", "vv", "w", "cl", "d")
+)
+
+// Skeleton reduces a username to the form two look-alike usernames share
+func Skeleton(username string) string {
+	return confusablePairs.Replace(confusableChars.Replace(strings.ToLower(strings.TrimSpace(username))))
+}
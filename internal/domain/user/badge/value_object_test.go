@@ -0,0 +1,52 @@
+package badge
+
+import "testing"
+
+func TestSkeleton(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"case", "JokoSaputro", "jokosaputro", true},
+		{"digits for letters", "j0ko_saputr0", "jokosaputro", true},
+		{"one and l", "daniel", "dan1el", true},
+		{"rn for m", "rnaria", "maria", true},
+		{"vv for w", "vvatson", "watson", true},
+		{"underscore", "joko_saputro", "jokosaputro", true},
+		{"different name", "jokosaputri", "jokosaputro", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Skeleton(tc.a) == Skeleton(tc.b); got != tc.expected {
+				t.Errorf("expected %q and %q to match=%v, skeletons %q and %q", tc.a, tc.b, tc.expected, Skeleton(tc.a), Skeleton(tc.b))
+			}
+		})
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expected      Kind
+		expectedError error
+	}{
+		{"author", KindAuthor, nil},
+		{" Commenter ", KindCommenter, nil},
+		{"celebrity", "", ErrInvalidKind},
+		{"", "", ErrInvalidKind},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			kind, err := ParseKind(tc.input)
+			if err != tc.expectedError {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if kind != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, kind)
+			}
+		})
+	}
+}
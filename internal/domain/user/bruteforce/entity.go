@@ -0,0 +1,68 @@
+package bruteforce
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Ban blocks logins from an address or AS number until it expires or staff lift it
+type Ban struct {
+	Subject   Subject
+	Failures  int // Count that tripped the threshold
+	Strike    int // 1 for the first ban within StrikeMemory, escalates the duration
+	BannedAt  time.Time
+	ExpiresAt time.Time
+
+	// Set when staff lift the ban early
+	LiftedBy *string
+	LiftedAt *time.Time
+}
+
+func NewBan(subject Subject, failures, strike int, duration time.Duration, at time.Time) (*Ban, error) {
+	if strike < 1 {
+		return nil, errors.New("strike must be at least 1")
+	}
+	if duration <= 0 {
+		return nil, errors.New("ban duration must be positive")
+	}
+	return &Ban{Subject: subject, Failures: failures, Strike: strike, BannedAt: at, ExpiresAt: at.Add(duration)}, nil
+}
+
+// Business Methods
+
+// Lift ends the ban now, e.g. for a newsroom behind a carrier NAT. Lifting
+// keeps the strike so a returning attack still escalates.
+func (b *Ban) Lift(staffID string, at time.Time) error {
+	if strings.TrimSpace(staffID) == "" {
+		return errors.New("staff ID cannot be empty")
+	}
+	if !b.IsActive(at) {
+		return errors.New("ban is no longer active")
+	}
+	b.LiftedBy = &staffID
+	b.LiftedAt = &at
+	return nil
+}
+
+// Query Methods
+
+func (b *Ban) IsActive(at time.Time) bool {
+	return b.LiftedAt == nil && at.Before(b.ExpiresAt)
+}
+
+// RetryAfter is how long the banned client has to wait
+func (b *Ban) RetryAfter(at time.Time) time.Duration {
+	if !b.IsActive(at) {
+		return 0
+	}
+	return b.ExpiresAt.Sub(at)
+}
+
+// NextStrike is the strike a new ban gets given this earlier one
+func (b *Ban) NextStrike(policy Policy, at time.Time) int {
+	if at.Sub(b.BannedAt) > policy.StrikeMemory {
+		return 1
+	}
+	return b.Strike + 1
+}
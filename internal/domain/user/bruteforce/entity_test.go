@@ -0,0 +1,49 @@
+package bruteforce
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBan_Lifecycle(t *testing.T) {
+	subject, _ := NewSubject(ScopeIP, "203.0.113.7")
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	ban, err := NewBan(subject, 20, 1, 15*time.Minute, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ban.IsActive(at.Add(time.Minute)) || ban.RetryAfter(at.Add(5*time.Minute)) != 10*time.Minute {
+		t.Errorf("expected active ban with 10 minutes left, got %+v", ban)
+	}
+	if ban.IsActive(at.Add(15 * time.Minute)) {
+		t.Error("expected ban expired")
+	}
+
+	if err := ban.Lift("admin-1", at.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ban.IsActive(at.Add(2*time.Minute)) || *ban.LiftedBy != "admin-1" {
+		t.Errorf("expected lifted ban, got %+v", ban)
+	}
+	if err := ban.Lift("admin-1", at.Add(2*time.Minute)); err == nil {
+		t.Error("expected error lifting a lifted ban")
+	}
+
+	if strike := ban.NextStrike(DefaultPolicy, at.Add(time.Hour)); strike != 2 {
+		t.Errorf("expected lifted ban to still escalate, got strike %d", strike)
+	}
+	if strike := ban.NextStrike(DefaultPolicy, at.Add(25*time.Hour)); strike != 1 {
+		t.Errorf("expected strikes forgotten after a day, got %d", strike)
+	}
+}
+
+func TestNewBan_Errors(t *testing.T) {
+	subject, _ := NewSubject(ScopeASN, "64500")
+	if _, err := NewBan(subject, 1, 0, time.Minute, time.Now()); err == nil {
+		t.Error("expected error for zero strike")
+	}
+	if _, err := NewBan(subject, 1, 1, 0, time.Now()); err == nil {
+		t.Error("expected error for zero duration")
+	}
+}
@@ -0,0 +1,35 @@
+package bruteforce
+
+import (
+	"context"
+	"net/netip"
+	"time"
+)
+
+// Domain interface for failure counters shared by every instance (implementation will be
+// in infrastructure layer, Redis INCR with an expiry in production)
+type FailureCounter interface {
+	// Increment adds one failure and returns the count within the window that
+	// started with the subject's first failure
+	Increment(ctx context.Context, subject Subject, window time.Duration) (int, error)
+	Reset(ctx context.Context, subject Subject) error
+}
+
+// Domain interface for ban state shared by every instance (implementation will be in
+// infrastructure layer, Redis keys expiring after the ban plus StrikeMemory)
+type BanRepository interface {
+	// Save stores the ban and keeps it for ttl, past its expiry so strikes can escalate
+	Save(ctx context.Context, ban *Ban, ttl time.Duration) error
+
+	// FindLatest returns the subject's most recent ban, also when expired or lifted, nil when none is kept
+	FindLatest(ctx context.Context, subject Subject) (*Ban, error)
+
+	// FindActive returns every ban in force at the given time
+	FindActive(ctx context.Context, at time.Time) ([]*Ban, error)
+}
+
+// ASNResolver maps an address to its autonomous system (implementation will be in
+// infrastructure layer, e.g. a GeoLite2 ASN database). It returns 0 for unknown addresses.
+type ASNResolver interface {
+	Lookup(ctx context.Context, ip netip.Addr) (uint32, error)
+}
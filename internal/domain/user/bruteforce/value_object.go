@@ -0,0 +1,164 @@
+package bruteforce
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Domain errors
+var (
+	ErrInvalidScope     = errors.New("scope must be ip or asn")
+	ErrInvalidSubject   = errors.New("subject must be an IP address or an AS number")
+	ErrInvalidThreshold = errors.New("threshold needs at least one failure, a window and a ban duration")
+	ErrInvalidRange     = errors.New("allowlist entries must be IP addresses or CIDR ranges")
+)
+
+// Scope is the network level failures are counted at
+type Scope string
+
+const (
+	ScopeIP  Scope = "ip"
+	ScopeASN Scope = "asn" // The autonomous system, catches botnets rotating addresses within one provider
+)
+
+func ParseScope(value string) (Scope, error) {
+	switch s := Scope(strings.ToLower(strings.TrimSpace(value))); s {
+	case ScopeIP, ScopeASN:
+		return s, nil
+	}
+	return "", ErrInvalidScope
+}
+
+// Subject is an address or AS number failures are counted against
+type Subject struct {
+	Scope Scope
+	Value string // Canonical IP or decimal AS number
+}
+
+func NewSubject(scope Scope, value string) (Subject, error) {
+	value = strings.TrimSpace(value)
+	switch scope {
+	case ScopeIP:
+		ip, err := netip.ParseAddr(value)
+		if err != nil {
+			return Subject{}, ErrInvalidSubject
+		}
+		return Subject{Scope: ScopeIP, Value: ip.Unmap().String()}, nil
+	case ScopeASN:
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(value), "AS"), 10, 32)
+		if err != nil || asn == 0 {
+			return Subject{}, ErrInvalidSubject
+		}
+		return Subject{Scope: ScopeASN, Value: strconv.FormatUint(asn, 10)}, nil
+	}
+	return Subject{}, ErrInvalidScope
+}
+
+// Key is the counter and ban key, e.g. "ip:203.0.113.7" or "asn:64500"
+func (s Subject) Key() string {
+	return string(s.Scope) + ":" + s.Value
+}
+
+// Threshold bans a subject after Failures failed logins within Window
+type Threshold struct {
+	Failures int
+	Window   time.Duration
+	BanFor   time.Duration // First ban, doubled for every repeat within StrikeMemory
+}
+
+func (t Threshold) validate() error {
+	if t.Failures < 1 || t.Window <= 0 || t.BanFor <= 0 {
+		return ErrInvalidThreshold
+	}
+	return nil
+}
+
+// Policy is the network-level throttle on top of the per-account lockout
+type Policy struct {
+	IP           Threshold
+	ASN          Threshold
+	MaxBanFor    time.Duration
+	StrikeMemory time.Duration // How long an expired ban still counts towards escalation
+	Allowlist    Allowlist
+}
+
+// DefaultPolicy bans an address that fails more logins than a forgetful
+// newsroom ever would, and a provider only at a level shared NAT cannot reach
+var DefaultPolicy = Policy{
+	IP:           Threshold{Failures: 20, Window: 10 * time.Minute, BanFor: 15 * time.Minute},
+	ASN:          Threshold{Failures: 300, Window: 10 * time.Minute, BanFor: 15 * time.Minute},
+	MaxBanFor:    24 * time.Hour,
+	StrikeMemory: 24 * time.Hour,
+}
+
+func NewPolicy(ip, asn Threshold, maxBanFor, strikeMemory time.Duration, allowlist Allowlist) (*Policy, error) {
+	if err := ip.validate(); err != nil {
+		return nil, fmt.Errorf("ip: %w", err)
+	}
+	if err := asn.validate(); err != nil {
+		return nil, fmt.Errorf("asn: %w", err)
+	}
+	if maxBanFor < ip.BanFor || maxBanFor < asn.BanFor {
+		return nil, errors.New("max ban duration cannot be shorter than the first ban")
+	}
+	if strikeMemory < 0 {
+		return nil, errors.New("strike memory cannot be negative")
+	}
+	return &Policy{IP: ip, ASN: asn, MaxBanFor: maxBanFor, StrikeMemory: strikeMemory, Allowlist: allowlist}, nil
+}
+
+func (p Policy) Threshold(scope Scope) Threshold {
+	if scope == ScopeASN {
+		return p.ASN
+	}
+	return p.IP
+}
+
+// BanDuration doubles the first ban for every earlier strike, capped at MaxBanFor
+func (p Policy) BanDuration(scope Scope, strike int) time.Duration {
+	d := p.Threshold(scope).BanFor
+	for i := 1; i < strike && d < p.MaxBanFor; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxBanFor)
+}
+
+// Allowlist holds office and VPN ranges that are never counted or banned
+type Allowlist struct {
+	prefixes []netip.Prefix
+}
+
+func NewAllowlist(entries []string) (Allowlist, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip, err := netip.ParseAddr(entry)
+			if err != nil {
+				return Allowlist{}, fmt.Errorf("%w: %q", ErrInvalidRange, entry)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(ip.Unmap(), ip.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return Allowlist{}, fmt.Errorf("%w: %q", ErrInvalidRange, entry)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return Allowlist{prefixes: prefixes}, nil
+}
+
+func (a Allowlist) Contains(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, prefix := range a.prefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package bruteforce
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestNewSubject(t *testing.T) {
+	testCases := []struct {
+		name          string
+		scope         Scope
+		value         string
+		expectedKey   string
+		expectedError error
+	}{
+		{"ipv4", ScopeIP, " 203.0.113.7 ", "ip:203.0.113.7", nil},
+		{"mapped ipv4", ScopeIP, "::ffff:203.0.113.7", "ip:203.0.113.7", nil},
+		{"ipv6", ScopeIP, "2001:DB8::1", "ip:2001:db8::1", nil},
+		{"asn with prefix", ScopeASN, "as64500", "asn:64500", nil},
+		{"asn number", ScopeASN, "64500", "asn:64500", nil},
+		{"invalid ip", ScopeIP, "example.com", "", ErrInvalidSubject},
+		{"zero asn", ScopeASN, "0", "", ErrInvalidSubject},
+		{"unknown scope", "country", "ID", "", ErrInvalidScope},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subject, err := NewSubject(tc.scope, tc.value)
+			if err != tc.expectedError {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if err == nil && subject.Key() != tc.expectedKey {
+				t.Errorf("expected key %q, got %q", tc.expectedKey, subject.Key())
+			}
+		})
+	}
+}
+
+func TestPolicy_BanDuration(t *testing.T) {
+	policy := DefaultPolicy
+	expected := []time.Duration{15 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour}
+	for i, d := range expected {
+		if got := policy.BanDuration(ScopeIP, i+1); got != d {
+			t.Errorf("strike %d: expected %s, got %s", i+1, d, got)
+		}
+	}
+	if got := policy.BanDuration(ScopeIP, 20); got != policy.MaxBanFor {
+		t.Errorf("expected cap at %s, got %s", policy.MaxBanFor, got)
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	if _, err := NewPolicy(Threshold{}, DefaultPolicy.ASN, time.Hour, time.Hour, Allowlist{}); err == nil {
+		t.Error("expected error for empty IP threshold")
+	}
+	if _, err := NewPolicy(DefaultPolicy.IP, DefaultPolicy.ASN, time.Minute, time.Hour, Allowlist{}); err == nil {
+		t.Error("expected error for max ban shorter than the first ban")
+	}
+	if _, err := NewPolicy(DefaultPolicy.IP, DefaultPolicy.ASN, time.Hour, time.Hour, Allowlist{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	allowlist, err := NewAllowlist([]string{"198.51.100.0/24", "203.0.113.7", "2001:db8:1::/48"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		ip       string
+		expected bool
+	}{
+		{"198.51.100.42", true},
+		{"::ffff:198.51.100.42", true},
+		{"203.0.113.7", true},
+		{"203.0.113.8", false},
+		{"2001:db8:1:2::5", true},
+		{"2001:db8:2::5", false},
+	}
+	for _, tc := range testCases {
+		if got := allowlist.Contains(netip.MustParseAddr(tc.ip)); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.ip, tc.expected, got)
+		}
+	}
+
+	if _, err := NewAllowlist([]string{"office"}); err == nil {
+		t.Error("expected error for invalid entry")
+	}
+}
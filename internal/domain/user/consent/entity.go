@@ -0,0 +1,97 @@
+package consent
+
+import "time"
+
+// Record holds the current consent choices of one subject.
+// Purposes never granted are denied, nothing is opt-out by default.
+type Record struct {
+	Subject Subject
+	Grants  map[Purpose]bool // Explicit choices, denials are kept so a deny-all counts as decided
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Change is one audit trail entry, written for every purpose chosen for the first time or whose value changed
+type Change struct {
+	ID        string
+	Subject   Subject
+	Purpose   Purpose
+	Granted   bool
+	Source    Source
+	IPAddress *string
+	ChangedAt time.Time
+}
+
+func NewRecord(subject Subject) *Record {
+	now := time.Now()
+	return &Record{
+		Subject:   subject,
+		Grants:    make(map[Purpose]bool),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Business Methods
+
+// Apply updates the given purposes and returns the audit entries for the ones that changed.
+// A first explicit choice always counts as a change, even when it matches the deny default.
+// Entry IDs are left empty for the caller to assign.
+func (r *Record) Apply(choices map[Purpose]bool, source Source, ipAddress string) ([]*Change, error) {
+	if len(choices) == 0 {
+		return nil, ErrNoChoices
+	}
+	if err := validateSource(source); err != nil {
+		return nil, err
+	}
+	for purpose := range choices {
+		if err := validatePurpose(purpose); err != nil {
+			return nil, err
+		}
+	}
+
+	var ip *string
+	if ipAddress != "" {
+		ip = &ipAddress
+	}
+
+	now := time.Now()
+	var changes []*Change
+	// Iterate in a fixed order so the audit trail is deterministic
+	for _, purpose := range AllPurposes {
+		granted, ok := choices[purpose]
+		if !ok {
+			continue
+		}
+		if current, decided := r.Grants[purpose]; decided && current == granted {
+			continue
+		}
+		r.Grants[purpose] = granted
+		changes = append(changes, &Change{
+			Subject:   r.Subject,
+			Purpose:   purpose,
+			Granted:   granted,
+			Source:    source,
+			IPAddress: ip,
+			ChangedAt: now,
+		})
+	}
+
+	if len(changes) > 0 {
+		r.UpdatedAt = now
+	}
+	return changes, nil
+}
+
+// Query Methods
+
+func (r *Record) Allows(purpose Purpose) bool {
+	return r.Grants[purpose]
+}
+
+// IsDecided reports whether the subject has made any choice yet, used to decide whether to show the banner
+func (r *Record) IsDecided() bool {
+	return len(r.Grants) > 0
+}
@@ -0,0 +1,104 @@
+package consent
+
+import "testing"
+
+func TestRecord_Apply(t *testing.T) {
+	record := NewRecord(createTestSubject(t))
+
+	if record.IsDecided() {
+		t.Error("expected new record to be undecided")
+	}
+	for _, purpose := range AllPurposes {
+		if record.Allows(purpose) {
+			t.Errorf("expected %s to be denied by default", purpose)
+		}
+	}
+
+	changes, err := record.Apply(map[Purpose]bool{
+		PurposeAnalytics:     true,
+		PurposeThirdPartyAds: false,
+	}, SourceBanner, "203.0.113.7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Purpose != PurposeAnalytics || !changes[0].Granted || changes[1].Purpose != PurposeThirdPartyAds || changes[1].Granted {
+		t.Fatalf("expected both first choices recorded, got %+v", changes)
+	}
+	if changes[0].IPAddress == nil || *changes[0].IPAddress != "203.0.113.7" {
+		t.Error("expected IP address on audit entry")
+	}
+	if !record.Allows(PurposeAnalytics) || !record.IsDecided() {
+		t.Error("expected analytics to be allowed")
+	}
+
+	changes, _ = record.Apply(map[Purpose]bool{PurposeAnalytics: true}, SourcePreferences, "")
+	if len(changes) != 0 {
+		t.Errorf("expected no changes when value is unchanged, got %d", len(changes))
+	}
+
+	changes, _ = record.Apply(map[Purpose]bool{PurposeAnalytics: false, PurposeMarketingEmail: true}, SourcePreferences, "")
+	if len(changes) != 2 || changes[0].Purpose != PurposeAnalytics || changes[1].Purpose != PurposeMarketingEmail {
+		t.Errorf("expected changes in purpose order, got %+v", changes)
+	}
+	if changes[0].IPAddress != nil {
+		t.Error("expected no IP address when none given")
+	}
+}
+
+func TestRecord_ApplyDenyAll(t *testing.T) {
+	record := NewRecord(createTestSubject(t))
+
+	denyAll := map[Purpose]bool{}
+	for _, purpose := range AllPurposes {
+		denyAll[purpose] = false
+	}
+	changes, err := record.Apply(denyAll, SourceBanner, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != len(AllPurposes) {
+		t.Errorf("expected every denial audited, got %d", len(changes))
+	}
+	if !record.IsDecided() {
+		t.Error("expected a deny-all choice to count as decided")
+	}
+
+	changes, _ = record.Apply(denyAll, SourceBanner, "")
+	if len(changes) != 0 {
+		t.Errorf("expected no changes when repeating the choice, got %d", len(changes))
+	}
+}
+
+func TestRecord_ApplyValidation(t *testing.T) {
+	record := NewRecord(createTestSubject(t))
+
+	testCases := []struct {
+		name        string
+		choices     map[Purpose]bool
+		source      Source
+		expectedErr error
+	}{
+		{name: "no choices", choices: nil, source: SourceAPI, expectedErr: ErrNoChoices},
+		{name: "unknown purpose", choices: map[Purpose]bool{"fingerprinting": true}, source: SourceAPI, expectedErr: ErrInvalidPurpose},
+		{name: "unknown source", choices: map[Purpose]bool{PurposeAnalytics: true}, source: "email", expectedErr: ErrInvalidSource},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := record.Apply(tc.choices, tc.source, ""); err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+	if record.IsDecided() {
+		t.Error("expected failed updates to leave the record untouched")
+	}
+}
+
+func createTestSubject(t *testing.T) Subject {
+	s, err := NewAccountSubject("user-1")
+	if err != nil {
+		t.Fatalf("failed to create subject: %v", err)
+	}
+	return *s
+}
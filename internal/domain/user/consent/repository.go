@@ -0,0 +1,32 @@
+package consent
+
+import "context"
+
+type RecordRepository interface {
+	// Save inserts or replaces the record for its subject
+	Save(ctx context.Context, record *Record) error
+
+	// FindBySubject returns nil when the subject has no record yet
+	FindBySubject(ctx context.Context, subject Subject) (*Record, error)
+
+	// FindBySubjects loads many records in one query, subjects without a record are left out
+	FindBySubjects(ctx context.Context, subjects []Subject) ([]*Record, error)
+}
+
+// AuditRepository is append-only
+type AuditRepository interface {
+	Append(ctx context.Context, changes []*Change) error
+	FindBySubject(ctx context.Context, subject Subject, limit, offset int) ([]*Change, error)
+}
+
+// Checker is the enforcement hook for modules that track or contact readers,
+// analytics and newsletter sends must check it before acting on a subject
+type Checker interface {
+	Allows(ctx context.Context, subject Subject, purpose Purpose) (bool, error)
+}
+
+// BatchChecker is Checker for a whole page of subjects at once, bulk sends use it
+// instead of one lookup per recipient
+type BatchChecker interface {
+	FilterAllowed(ctx context.Context, purpose Purpose, subjects []Subject) ([]Subject, error)
+}
@@ -0,0 +1,108 @@
+package consent
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+type Purpose string
+
+const (
+	PurposeAnalytics       Purpose = "analytics"
+	PurposePersonalization Purpose = "personalization"
+	PurposeMarketingEmail  Purpose = "marketing_email"
+	PurposeThirdPartyAds   Purpose = "third_party_ads"
+)
+
+// AllPurposes lists every purpose in display order
+var AllPurposes = []Purpose{PurposeAnalytics, PurposePersonalization, PurposeMarketingEmail, PurposeThirdPartyAds}
+
+type SubjectKind string
+
+const (
+	SubjectAccount SubjectKind = "account"
+	SubjectVisitor SubjectKind = "visitor" // Anonymous reader identified by a cookie ID
+)
+
+// Source records where a consent change came from
+type Source string
+
+const (
+	SourceBanner      Source = "banner"
+	SourcePreferences Source = "preferences"
+	SourceAPI         Source = "api"
+	SourceImport      Source = "import"
+)
+
+// Domain errors
+var (
+	ErrInvalidPurpose   = errors.New("invalid consent purpose")
+	ErrInvalidSource    = errors.New("invalid consent source")
+	ErrEmptySubjectID   = errors.New("subject ID cannot be empty")
+	ErrInvalidVisitorID = errors.New("visitor ID must be a UUID")
+	ErrNoChoices        = errors.New("no consent choices given")
+)
+
+// Subject value object, the account or anonymous visitor the consent belongs to
+type Subject struct {
+	kind SubjectKind
+	id   string
+}
+
+func NewAccountSubject(accountID string) (*Subject, error) {
+	accountID = strings.TrimSpace(accountID)
+	if accountID == "" {
+		return nil, ErrEmptySubjectID
+	}
+	return &Subject{kind: SubjectAccount, id: accountID}, nil
+}
+
+func NewVisitorSubject(visitorID string) (*Subject, error) {
+	if strings.TrimSpace(visitorID) == "" {
+		return nil, ErrEmptySubjectID
+	}
+	id, err := shared.ParseUUID(strings.TrimSpace(visitorID))
+	if err != nil {
+		return nil, ErrInvalidVisitorID
+	}
+	return &Subject{kind: SubjectVisitor, id: id}, nil
+}
+
+func (s Subject) Kind() SubjectKind {
+	return s.kind
+}
+
+func (s Subject) ID() string {
+	return s.id
+}
+
+// String returns the storage key, e.g. "visitor:5f0c..."
+func (s Subject) String() string {
+	return string(s.kind) + ":" + s.id
+}
+
+func (s Subject) Equals(other Subject) bool {
+	return s.kind == other.kind && s.id == other.id
+}
+
+// Domain Validation Functions
+
+func validatePurpose(purpose Purpose) error {
+	for _, p := range AllPurposes {
+		if p == purpose {
+			return nil
+		}
+	}
+	return ErrInvalidPurpose
+}
+
+func validateSource(source Source) error {
+	switch source {
+	case SourceBanner, SourcePreferences, SourceAPI, SourceImport:
+		return nil
+	default:
+		return ErrInvalidSource
+	}
+}
@@ -0,0 +1,47 @@
+package consent
+
+import "testing"
+
+func TestNewVisitorSubject(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{name: "valid", input: "5F0C2B6E-9A47-4C1B-8E0D-3A1F6B2C7D90", expected: "visitor:5f0c2b6e-9a47-4c1b-8e0d-3a1f6b2c7d90"},
+		{name: "empty", input: "  ", expectedErr: ErrEmptySubjectID},
+		{name: "not a uuid", input: "visitor-123", expectedErr: ErrInvalidVisitorID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewVisitorSubject(tt.input)
+			if err != tt.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tt.expectedErr, err)
+			}
+			if err == nil && s.String() != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, s.String())
+			}
+		})
+	}
+}
+
+func TestNewAccountSubject(t *testing.T) {
+	s, err := NewAccountSubject(" user-1 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Kind() != SubjectAccount || s.ID() != "user-1" {
+		t.Errorf("unexpected subject %s", s.String())
+	}
+
+	other, _ := NewAccountSubject("user-1")
+	if !s.Equals(*other) {
+		t.Error("expected subjects to be equal")
+	}
+
+	if _, err := NewAccountSubject(""); err != ErrEmptySubjectID {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptySubjectID, err)
+	}
+}
@@ -0,0 +1,210 @@
+package entitlement
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// FreeArticlesPerMonth is how many metered articles a signed-in member without a subscription can read each calendar month
+const FreeArticlesPerMonth = 5
+
+// Tier is the paywall setting of an article
+type Tier string
+
+const (
+	TierFree    Tier = "free"    // Open to everyone
+	TierMetered Tier = "metered" // Counts against the monthly free allowance
+	TierPremium Tier = "premium" // Subscribers only
+)
+
+// Subject is everything the rules know about who is asking, nil Account is a guest
+type Subject struct {
+	AccountID string
+	Account   *account.UserAccount
+	Roles     []Role
+	Premium   bool // Holds a subscription with premium access
+
+	// Institution is the licensed campus or library the request came from, it
+	// unlocks reading only and is never cached with the account
+	Institution string
+}
+
+// Article is the part of an article the rules need
+type Article struct {
+	ID          string
+	Tier        Tier
+	PublishedAt *time.Time
+	Comments    article.CommentSettings
+
+	// Holdout is the paywall experiment that makes the article free for this
+	// reader, set by the caller per request, see the experiment service
+	Holdout string
+}
+
+// MeterUsage is the member's free article allowance this month
+type MeterUsage struct {
+	Used        int
+	Limit       int
+	AlreadyRead bool // Re-reading an article does not use the allowance again
+}
+
+// Business Methods
+
+// ReadArticle decides whether the subject can read the full article. A suspension
+// stops participation, not reading: suspended members keep what they paid for,
+// including articles they bought one by one. An institution licence lets guests
+// and members read everything without using the meter. A paywall experiment's
+// holdout group reads its articles as if they were free.
+func (s *Subject) ReadArticle(a Article, meter MeterUsage, purchased bool, at time.Time) Decision {
+	d := s.trace()
+	if a.Tier == TierFree {
+		return d.allow(ReasonFreeArticle, "")
+	}
+	if a.Holdout != "" {
+		return d.allow(ReasonHoldout, a.Holdout)
+	}
+	if s.Account != nil && !s.Account.IsSuspended() {
+		if reason, detail := s.standing(at); reason != "" {
+			return d.deny(reason, detail)
+		}
+	}
+	if role, ok := s.grantedBy(ScopeReadAllArticles); ok {
+		return d.allow(ReasonRole, role)
+	}
+	if s.Premium {
+		return d.allow(ReasonSubscription, "")
+	}
+	d.Trace = append(d.Trace, "subscription: none")
+	if purchased && s.Account != nil {
+		return d.allow(ReasonPurchase, "")
+	}
+	if s.Institution != "" {
+		return d.allow(ReasonInstitution, s.Institution)
+	}
+	if a.Tier == TierPremium {
+		return d.deny(ReasonPremiumRequired, "")
+	}
+
+	if s.Account == nil {
+		return d.deny(ReasonSignInRequired, "metered articles need a free account")
+	}
+	d.Trace = append(d.Trace, fmt.Sprintf("meter: %d of %d used", meter.Used, meter.Limit))
+	if meter.AlreadyRead {
+		return d.allow(ReasonMeter, "already read this month")
+	}
+	if meter.Used < meter.Limit {
+		return d.allow(ReasonMeter, fmt.Sprintf("%d free articles left this month", meter.Limit-meter.Used-1))
+	}
+	return d.deny(ReasonMeterExhausted, "")
+}
+
+// Comment decides whether the subject can comment on the article, on top of the article's comment settings
+func (s *Subject) Comment(a Article, at time.Time) Decision {
+	d := s.trace()
+	if s.Account != nil {
+		if reason, detail := s.standing(at); reason != "" {
+			return d.deny(reason, detail)
+		}
+	}
+
+	err := a.Comments.CheckCommenter(a.PublishedAt, s.Account, at)
+	if role, ok := s.grantedBy(ScopeModerateComment); ok && (errors.Is(err, article.ErrCommentsClosed) || errors.Is(err, article.ErrCommentsMembersOnly)) {
+		return d.allow(ReasonRole, role)
+	}
+	switch {
+	case errors.Is(err, article.ErrCommentsMembersOnly):
+		return d.deny(ReasonSignInRequired, err.Error())
+	case errors.Is(err, article.ErrCommenterNotActive):
+		return d.deny(ReasonAccountInactive, err.Error())
+	case err != nil:
+		return d.deny(ReasonCommentsClosed, err.Error())
+	}
+
+	// Premium discussions are part of what subscribers pay for
+	if a.Tier == TierPremium {
+		if role, ok := s.grantedBy(ScopeReadAllArticles); ok {
+			return d.allow(ReasonRole, role)
+		}
+		if !s.Premium {
+			return d.deny(ReasonPremiumRequired, "")
+		}
+	}
+	return d.allow(ReasonCommentsOpen, "")
+}
+
+// UseAPI decides whether the subject can call an API endpoint that requires the scope
+func (s *Subject) UseAPI(scope Scope, at time.Time) Decision {
+	d := s.trace()
+	if s.Account == nil {
+		return d.deny(ReasonSignInRequired, "")
+	}
+	if reason, detail := s.standing(at); reason != "" {
+		return d.deny(reason, detail)
+	}
+	if role, ok := s.grantedBy(scope); ok {
+		return d.allow(ReasonRole, role)
+	}
+	return d.deny(ReasonScopeMissing, string(scope))
+}
+
+// Query Methods
+
+// standing returns why the account cannot take part, sanctions first
+func (s *Subject) standing(at time.Time) (Reason, string) {
+	acc := s.Account
+	switch {
+	case acc.IsSuspensionElapsed(at):
+		// Lifted by the expiry job, until then the account stays disabled everywhere
+		return ReasonSanctioned, "suspension ended, reactivation pending"
+	case acc.IsSuspended() && acc.SuspendedUntil != nil:
+		return ReasonSanctioned, "suspended until " + acc.SuspendedUntil.UTC().Format(time.RFC3339)
+	case acc.IsSuspended(), acc.IsBlocked(), acc.HasViolation():
+		return ReasonSanctioned, string(*acc.DisabilityType)
+	case !acc.CanLogin():
+		return ReasonAccountInactive, string(acc.Status)
+	}
+	return "", ""
+}
+
+func (s *Subject) grantedBy(scope Scope) (string, bool) {
+	for _, role := range s.Roles {
+		if role.Grants(scope) {
+			return role.Name + " grants " + string(scope), true
+		}
+	}
+	return "", false
+}
+
+func (s *Subject) trace() *Decision {
+	d := &Decision{}
+	if s.Account == nil {
+		d.Trace = append(d.Trace, "account: guest")
+		return d
+	}
+	d.Trace = append(d.Trace, fmt.Sprintf("account: %s %s", s.AccountID, s.Account.Status))
+	for _, role := range s.Roles {
+		d.Trace = append(d.Trace, "role: "+role.Name)
+	}
+	if s.Premium {
+		d.Trace = append(d.Trace, "subscription: premium")
+	}
+	return d
+}
+
+func (d *Decision) allow(reason Reason, detail string) Decision {
+	return d.decide(true, reason, detail)
+}
+
+func (d *Decision) deny(reason Reason, detail string) Decision {
+	return d.decide(false, reason, detail)
+}
+
+func (d *Decision) decide(allowed bool, reason Reason, detail string) Decision {
+	d.Allowed, d.Reason, d.Detail = allowed, reason, detail
+	d.Trace = append(d.Trace, d.String())
+	return *d
+}
@@ -0,0 +1,211 @@
+package entitlement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/article"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var testNow = time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)
+
+func createTestAccount(t *testing.T) *account.UserAccount {
+	t.Helper()
+	acc, err := account.NewUserAccountForTesting("member-1", "member1", "member1@example.com", "MemberPass123!", account.TypeMembership, account.SelfRegistration)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+	return acc
+}
+
+func createTestArticle(tier Tier) Article {
+	published := testNow.Add(-time.Hour)
+	return Article{ID: "article-1", Tier: tier, PublishedAt: &published, Comments: article.DefaultCommentSettings()}
+}
+
+func TestSubject_ReadArticle(t *testing.T) {
+	editor, _ := NewRole("editor", []Scope{ScopeReadAllArticles})
+
+	suspended := createTestAccount(t)
+	_ = suspended.SuspendFor("mod-1", "spam", 48*time.Hour)
+	blocked := createTestAccount(t)
+	_ = blocked.Block("mod-1", "spam")
+
+	testCases := []struct {
+		name            string
+		subject         Subject
+		tier            Tier
+		meter           MeterUsage
+		expectedAllowed bool
+		expectedReason  Reason
+	}{
+		{"guest reads free article", Subject{}, TierFree, MeterUsage{}, true, ReasonFreeArticle},
+		{"guest hits metered article", Subject{}, TierMetered, MeterUsage{}, false, ReasonSignInRequired},
+		{"member within meter", Subject{Account: createTestAccount(t)}, TierMetered, MeterUsage{Used: 2, Limit: 5}, true, ReasonMeter},
+		{"member meter exhausted", Subject{Account: createTestAccount(t)}, TierMetered, MeterUsage{Used: 5, Limit: 5}, false, ReasonMeterExhausted},
+		{"member rereads after meter ran out", Subject{Account: createTestAccount(t)}, TierMetered, MeterUsage{Used: 5, Limit: 5, AlreadyRead: true}, true, ReasonMeter},
+		{"member hits premium article", Subject{Account: createTestAccount(t)}, TierPremium, MeterUsage{}, false, ReasonPremiumRequired},
+		{"subscriber reads premium article", Subject{Account: createTestAccount(t), Premium: true}, TierPremium, MeterUsage{}, true, ReasonSubscription},
+		{"editor bypasses paywall", Subject{Account: createTestAccount(t), Roles: []Role{*editor}}, TierPremium, MeterUsage{}, true, ReasonRole},
+		{"suspended subscriber keeps reading", Subject{Account: suspended, Premium: true}, TierPremium, MeterUsage{}, true, ReasonSubscription},
+		{"blocked subscriber", Subject{Account: blocked, Premium: true}, TierPremium, MeterUsage{}, false, ReasonSanctioned},
+		{"guest on campus reads premium article", Subject{Institution: "inst-1"}, TierPremium, MeterUsage{}, true, ReasonInstitution},
+		{"member on campus skips the meter", Subject{Account: createTestAccount(t), Institution: "inst-1"}, TierMetered, MeterUsage{Used: 5, Limit: 5}, true, ReasonInstitution},
+		{"blocked member on campus", Subject{Account: blocked, Institution: "inst-1"}, TierPremium, MeterUsage{}, false, ReasonSanctioned},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := tc.subject.ReadArticle(createTestArticle(tc.tier), tc.meter, false, testNow)
+			if d.Allowed != tc.expectedAllowed || d.Reason != tc.expectedReason {
+				t.Errorf("expected allowed=%v (%s), got %s", tc.expectedAllowed, tc.expectedReason, d)
+			}
+			if len(d.Trace) == 0 || d.Trace[len(d.Trace)-1] != d.String() {
+				t.Errorf("expected trace to end with the decision, got %v", d.Trace)
+			}
+		})
+	}
+}
+
+func TestSubject_ReadArticle_Purchased(t *testing.T) {
+	suspended := createTestAccount(t)
+	_ = suspended.SuspendFor("mod-1", "spam", 48*time.Hour)
+	blocked := createTestAccount(t)
+	_ = blocked.Block("mod-1", "spam")
+
+	testCases := []struct {
+		name            string
+		subject         Subject
+		expectedAllowed bool
+		expectedReason  Reason
+	}{
+		{"buyer reads premium article", Subject{Account: createTestAccount(t)}, true, ReasonPurchase},
+		{"subscription decides first", Subject{Account: createTestAccount(t), Premium: true}, true, ReasonSubscription},
+		{"suspended buyer keeps reading", Subject{Account: suspended}, true, ReasonPurchase},
+		{"blocked buyer", Subject{Account: blocked}, false, ReasonSanctioned},
+		{"guest", Subject{}, false, ReasonPremiumRequired},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := tc.subject.ReadArticle(createTestArticle(TierPremium), MeterUsage{}, true, testNow)
+			if d.Allowed != tc.expectedAllowed || d.Reason != tc.expectedReason {
+				t.Errorf("expected allowed=%v (%s), got %s", tc.expectedAllowed, tc.expectedReason, d)
+			}
+		})
+	}
+}
+
+func TestSubject_ReadArticle_Holdout(t *testing.T) {
+	blocked := createTestAccount(t)
+	_ = blocked.Block("mod-1", "spam")
+
+	for _, subject := range []Subject{{}, {Account: createTestAccount(t)}, {Account: blocked}} {
+		a := createTestArticle(TierPremium)
+		a.Holdout = "exp-1"
+		d := subject.ReadArticle(a, MeterUsage{Used: 5, Limit: 5}, false, testNow)
+		if !d.Allowed || d.Reason != ReasonHoldout || d.Detail != "exp-1" {
+			t.Errorf("expected the holdout to read for free, got %s", d)
+		}
+	}
+}
+
+func TestSubject_Comment(t *testing.T) {
+	moderator, _ := NewRole("moderator", []Scope{ScopeModerateComment})
+	closed, _ := article.NewCommentSettings(true, false, 1, false)
+
+	suspended := createTestAccount(t)
+	_ = suspended.SuspendFor("mod-1", "spam", 48*time.Hour)
+	elapsed := createTestAccount(t)
+	_ = elapsed.SuspendFor("mod-1", "spam", time.Hour)
+
+	testCases := []struct {
+		name            string
+		subject         Subject
+		tier            Tier
+		settings        *article.CommentSettings
+		at              time.Time
+		expectedAllowed bool
+		expectedReason  Reason
+	}{
+		{"member on open thread", Subject{Account: createTestAccount(t)}, TierMetered, nil, testNow, true, ReasonCommentsOpen},
+		{"suspended member", Subject{Account: suspended}, TierMetered, nil, testNow, false, ReasonSanctioned},
+		{"suspension ran out, not yet lifted", Subject{Account: elapsed}, TierMetered, nil, time.Now().Add(2 * time.Hour), false, ReasonSanctioned},
+		{"closed thread", Subject{Account: createTestAccount(t)}, TierMetered, closed, testNow.AddDate(0, 0, 2), false, ReasonCommentsClosed},
+		{"moderator on closed thread", Subject{Account: createTestAccount(t), Roles: []Role{*moderator}}, TierMetered, closed, testNow.AddDate(0, 0, 2), true, ReasonRole},
+		{"member on premium discussion", Subject{Account: createTestAccount(t)}, TierPremium, nil, testNow, false, ReasonPremiumRequired},
+		{"subscriber on premium discussion", Subject{Account: createTestAccount(t), Premium: true}, TierPremium, nil, testNow, true, ReasonCommentsOpen},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := createTestArticle(tc.tier)
+			if tc.settings != nil {
+				a.Comments = *tc.settings
+			}
+			published := tc.at.Add(-time.Hour)
+			if tc.settings != nil {
+				published = testNow
+			}
+			a.PublishedAt = &published
+
+			d := tc.subject.Comment(a, tc.at)
+			if d.Allowed != tc.expectedAllowed || d.Reason != tc.expectedReason {
+				t.Errorf("expected allowed=%v (%s), got %s", tc.expectedAllowed, tc.expectedReason, d)
+			}
+		})
+	}
+}
+
+func TestSubject_UseAPI(t *testing.T) {
+	partner, _ := NewRole("partner", []Scope{"api:search", "api:articles"})
+	disabled := createTestAccount(t)
+	_ = disabled.SetInactive("admin-1", "dormant")
+
+	testCases := []struct {
+		name            string
+		subject         Subject
+		expectedAllowed bool
+		expectedReason  Reason
+	}{
+		{"guest", Subject{}, false, ReasonSignInRequired},
+		{"partner with scope", Subject{Account: createTestAccount(t), Roles: []Role{*partner}}, true, ReasonRole},
+		{"member without scope", Subject{Account: createTestAccount(t)}, false, ReasonScopeMissing},
+		{"inactive partner", Subject{Account: disabled, Roles: []Role{*partner}}, false, ReasonAccountInactive},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := tc.subject.UseAPI("api:search", testNow)
+			if d.Allowed != tc.expectedAllowed || d.Reason != tc.expectedReason {
+				t.Errorf("expected allowed=%v (%s), got %s", tc.expectedAllowed, tc.expectedReason, d)
+			}
+		})
+	}
+}
+
+func TestNewScope(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expectedError error
+	}{
+		{"api:search", nil},
+		{" Articles:Read_All ", nil},
+		{"search", ErrInvalidScope},
+		{"api:", ErrInvalidScope},
+		{"api:search-v2", ErrInvalidScope},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := NewScope(tc.input)
+			if err != tc.expectedError {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package entitlement
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interface for role assignments (implementation will be in infrastructure layer)
+type RoleRepository interface {
+	FindByAccountID(ctx context.Context, accountID string) ([]Role, error)
+}
+
+// Domain interface for the metered paywall's read log (implementation will be in infrastructure layer)
+type MeterRepository interface {
+	// FindUsage counts distinct metered articles read since the start of the month and whether articleID is one of them
+	FindUsage(ctx context.Context, accountID, articleID string, since time.Time) (used int, alreadyRead bool, err error)
+	RecordRead(ctx context.Context, accountID, articleID string, at time.Time) error
+}
+
+// Domain interface for single-article purchases (implementation will be in infrastructure layer)
+type PurchaseRepository interface {
+	// HasPurchased reports a completed purchase, it is never cached so a purchase unlocks the article at once
+	HasPurchased(ctx context.Context, accountID, articleID string) (bool, error)
+}
+
+// Domain interface for caching subjects between requests (implementation will be in infrastructure layer).
+// Get returns nil on a miss. Meter usage is never cached, it changes with every read.
+type SubjectCache interface {
+	Get(ctx context.Context, accountID string) (*Subject, error)
+	Set(ctx context.Context, subject *Subject, ttl time.Duration) error
+	Invalidate(ctx context.Context, accountID string) error
+}
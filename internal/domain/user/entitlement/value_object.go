@@ -0,0 +1,104 @@
+package entitlement
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Scopes look like "articles:read_all" or "api:search"
+var scopeRegex = regexp.MustCompile(`^[a-z][a-z_]*(:[a-z][a-z_]*)+$`)
+
+// Scopes the entitlement rules themselves look at, any other scope is an API scope
+const (
+	ScopeReadAllArticles Scope = "articles:read_all" // Staff bypass of the paywall and meter
+	ScopeModerateComment Scope = "comments:moderate" // Comment on closed and members-only threads
+)
+
+// Domain errors
+var (
+	ErrInvalidScope    = errors.New("scope must look like resource:action, lowercase")
+	ErrInvalidRoleName = errors.New("role name cannot be empty")
+)
+
+// Scope value object, one permission granted through a role
+type Scope string
+
+func NewScope(value string) (Scope, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !scopeRegex.MatchString(value) {
+		return "", ErrInvalidScope
+	}
+	return Scope(value), nil
+}
+
+// Role is a named set of scopes assigned to accounts
+type Role struct {
+	Name   string
+	Scopes []Scope
+}
+
+func NewRole(name string, scopes []Scope) (*Role, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidRoleName
+	}
+	for _, scope := range scopes {
+		if _, err := NewScope(string(scope)); err != nil {
+			return nil, err
+		}
+	}
+	return &Role{Name: name, Scopes: append([]Scope(nil), scopes...)}, nil
+}
+
+func (r Role) Grants(scope Scope) bool {
+	for _, s := range r.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Reason says which rule decided, stable enough to log and to show in support tooling
+type Reason string
+
+const (
+	ReasonFreeArticle     Reason = "free_article"
+	ReasonRole            Reason = "role"
+	ReasonSubscription    Reason = "subscription"
+	ReasonMeter           Reason = "meter"
+	ReasonPurchase        Reason = "purchase"
+	ReasonInstitution     Reason = "institution"
+	ReasonHoldout         Reason = "experiment_holdout"
+	ReasonCommentsOpen    Reason = "comments_open"
+	ReasonSignInRequired  Reason = "sign_in_required"
+	ReasonAccountInactive Reason = "account_inactive"
+	ReasonSanctioned      Reason = "sanctioned"
+	ReasonPremiumRequired Reason = "premium_required"
+	ReasonMeterExhausted  Reason = "meter_exhausted"
+	ReasonCommentsClosed  Reason = "comments_closed"
+	ReasonScopeMissing    Reason = "scope_missing"
+)
+
+// Decision is the answer to one access question. Trace lists every check in
+// the order it ran, so support can see why a member was let in or turned away.
+type Decision struct {
+	Allowed bool
+	Reason  Reason
+	Detail  string
+	Trace   []string
+}
+
+func (d Decision) String() string {
+	verdict := "deny"
+	if d.Allowed {
+		verdict = "allow"
+	}
+	s := fmt.Sprintf("%s (%s)", verdict, d.Reason)
+	if d.Detail != "" {
+		s += ": " + d.Detail
+	}
+	return s
+}
@@ -0,0 +1,255 @@
+package institution
+
+import (
+	"crypto/subtle"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// Status of an institution licence
+type Status string
+
+const (
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended" // Staff paused access, e.g. while an abuse alert is investigated
+)
+
+// Institution is a campus, library or company whose readers get premium access
+// without individual accounts, from its IP ranges or through its proxy servers
+type Institution struct {
+	ID       string
+	Name     string
+	Ranges   []netip.Prefix
+	Proxies  []ProxyToken
+	Contract Contract
+	Status   Status
+
+	SuspendedReason *string
+
+	CreatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ProxyToken lets a library proxy (EZproxy and the like) vouch for off-campus
+// readers. Only the hash is kept, the token is shown once when issued.
+type ProxyToken struct {
+	ID        string
+	Label     string
+	TokenHash string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+func (t ProxyToken) IsActive() bool {
+	return t.RevokedAt == nil
+}
+
+func NewInstitution(id, name string, contract Contract, createdBy string, at time.Time) (*Institution, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyID
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if contract.Reference == "" {
+		return nil, ErrInvalidContract
+	}
+	return &Institution{
+		ID:        id,
+		Name:      name,
+		Contract:  contract,
+		Status:    StatusActive,
+		CreatedBy: createdBy,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}, nil
+}
+
+// Business Methods
+
+// SetRanges replaces the configured ranges. Overlaps with other institutions
+// are checked by the caller, who can see them.
+func (i *Institution) SetRanges(values []string, at time.Time) error {
+	ranges := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		prefix, err := ParseRange(v)
+		if err != nil {
+			return err
+		}
+		for _, existing := range ranges {
+			if existing.Overlaps(prefix) {
+				return ErrOverlappingRange
+			}
+		}
+		ranges = append(ranges, prefix)
+	}
+	i.Ranges = ranges
+	i.UpdatedAt = at
+	return nil
+}
+
+// Renew replaces the contract, access follows the new dates at once
+func (i *Institution) Renew(contract Contract, at time.Time) error {
+	if contract.Reference == "" {
+		return ErrInvalidContract
+	}
+	i.Contract = contract
+	i.UpdatedAt = at
+	return nil
+}
+
+func (i *Institution) IssueProxyToken(id, label, tokenHash string, at time.Time) (*ProxyToken, error) {
+	if strings.TrimSpace(id) == "" || tokenHash == "" {
+		return nil, ErrInvalidProxyToken
+	}
+	active := 0
+	for _, t := range i.Proxies {
+		if t.IsActive() {
+			active++
+		}
+	}
+	if active >= MaxProxyTokens {
+		return nil, ErrTooManyTokens
+	}
+	i.Proxies = append(i.Proxies, ProxyToken{ID: id, Label: strings.TrimSpace(label), TokenHash: tokenHash, CreatedAt: at})
+	i.UpdatedAt = at
+	return &i.Proxies[len(i.Proxies)-1], nil
+}
+
+func (i *Institution) RevokeProxyToken(id string, at time.Time) error {
+	for n := range i.Proxies {
+		if i.Proxies[n].ID == id && i.Proxies[n].IsActive() {
+			i.Proxies[n].RevokedAt = &at
+			i.UpdatedAt = at
+			return nil
+		}
+	}
+	return ErrTokenNotFound
+}
+
+func (i *Institution) Suspend(reason string, at time.Time) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrEmptySuspensionReason
+	}
+	if i.Status != StatusActive {
+		return ErrNotActive
+	}
+	i.Status = StatusSuspended
+	i.SuspendedReason = &reason
+	i.UpdatedAt = at
+	return nil
+}
+
+func (i *Institution) Reinstate(at time.Time) error {
+	if i.Status != StatusSuspended {
+		return ErrNotSuspended
+	}
+	i.Status = StatusActive
+	i.SuspendedReason = nil
+	i.UpdatedAt = at
+	return nil
+}
+
+// Query Methods
+
+// GrantsAccess reports whether readers get premium access now: the licence
+// is active and the contract covers the time
+func (i *Institution) GrantsAccess(at time.Time) bool {
+	return i.Status == StatusActive && i.Contract.Covers(at)
+}
+
+// MatchIP returns the configured range containing the address
+func (i *Institution) MatchIP(ip netip.Addr) (netip.Prefix, bool) {
+	ip = ip.Unmap()
+	for _, prefix := range i.Ranges {
+		if prefix.Contains(ip) {
+			return prefix, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// MatchToken returns the active proxy token with the hash
+func (i *Institution) MatchToken(tokenHash string) (*ProxyToken, bool) {
+	for n := range i.Proxies {
+		t := &i.Proxies[n]
+		if t.IsActive() && subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(tokenHash)) == 1 {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// Overlaps returns the first of its ranges that overlaps one of the given ranges
+func (i *Institution) Overlaps(ranges []netip.Prefix) (netip.Prefix, bool) {
+	for _, mine := range i.Ranges {
+		for _, other := range ranges {
+			if mine.Overlaps(other) {
+				return mine, true
+			}
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// AlertStatus of an abuse alert
+type AlertStatus string
+
+const (
+	AlertOpen      AlertStatus = "open"
+	AlertDismissed AlertStatus = "dismissed" // Staff judged the spike legitimate, e.g. exam week
+	AlertActioned  AlertStatus = "actioned"  // Staff suspended the institution or revoked the token
+)
+
+// Alert records a source whose hourly volume was anomalous against its baseline
+type Alert struct {
+	ID         string
+	Source     Source
+	Hour       time.Time
+	Requests   int
+	Baseline   float64 // Mean requests in the same hour on the baseline days
+	Status     AlertStatus
+	DetectedAt time.Time
+
+	ResolvedBy *string
+	ResolvedAt *time.Time
+}
+
+func NewAlert(id string, volume Volume, baseline float64, at time.Time) (*Alert, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, ErrEmptyAlertID
+	}
+	if volume.Source.InstitutionID == "" {
+		return nil, ErrEmptyAlertInstitution
+	}
+	return &Alert{
+		ID:         id,
+		Source:     volume.Source,
+		Hour:       volume.Hour,
+		Requests:   volume.Requests,
+		Baseline:   baseline,
+		Status:     AlertOpen,
+		DetectedAt: at,
+	}, nil
+}
+
+// Resolve closes the alert, actioned says whether staff took measures
+func (a *Alert) Resolve(staffID string, actioned bool, at time.Time) error {
+	if strings.TrimSpace(staffID) == "" {
+		return ErrEmptyStaffID
+	}
+	if a.Status != AlertOpen {
+		return ErrAlertNotOpen
+	}
+	a.Status = AlertDismissed
+	if actioned {
+		a.Status = AlertActioned
+	}
+	a.ResolvedBy = &staffID
+	a.ResolvedAt = &at
+	return nil
+}
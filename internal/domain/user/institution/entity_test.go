@@ -0,0 +1,146 @@
+package institution
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+func createTestInstitution(t *testing.T) *Institution {
+	t.Helper()
+	contract, _ := NewContract("UI-2026-014", testNow.AddDate(0, -2, 0), testNow.AddDate(0, 10, 0))
+	i, err := NewInstitution("inst-1", " Universitas Indonesia ", contract, "admin-1", testNow)
+	if err != nil {
+		t.Fatalf("failed to create institution: %v", err)
+	}
+	return i
+}
+
+func TestNewInstitution(t *testing.T) {
+	i := createTestInstitution(t)
+	if i.Name != "Universitas Indonesia" || i.Status != StatusActive || !i.GrantsAccess(testNow) {
+		t.Errorf("unexpected institution %+v", i)
+	}
+	if _, err := NewInstitution("inst-2", " ", i.Contract, "admin-1", testNow); err != ErrEmptyName {
+		t.Errorf("expected ErrEmptyName, got %v", err)
+	}
+	if _, err := NewInstitution("inst-2", "ITB", Contract{}, "admin-1", testNow); err != ErrInvalidContract {
+		t.Errorf("expected ErrInvalidContract, got %v", err)
+	}
+}
+
+func TestInstitution_SetRanges(t *testing.T) {
+	i := createTestInstitution(t)
+
+	if err := i.SetRanges([]string{"152.118.0.0/16", "2001:db8:10::/48"}, testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prefix, ok := i.MatchIP(netip.MustParseAddr("::ffff:152.118.24.7")); !ok || prefix.String() != "152.118.0.0/16" {
+		t.Errorf("expected a mapped campus address to match, got %v %v", prefix, ok)
+	}
+	if _, ok := i.MatchIP(netip.MustParseAddr("152.119.0.1")); ok {
+		t.Error("expected an outside address not to match")
+	}
+
+	if err := i.SetRanges([]string{"152.118.0.0/16", "152.118.24.0/24"}, testNow); err != ErrOverlappingRange {
+		t.Errorf("expected ErrOverlappingRange, got %v", err)
+	}
+	if len(i.Ranges) != 2 {
+		t.Error("expected a rejected update to keep the old ranges")
+	}
+
+	if _, ok := i.Overlaps([]netip.Prefix{netip.MustParsePrefix("152.118.128.0/17")}); !ok {
+		t.Error("expected an overlap with a range inside ours")
+	}
+}
+
+func TestInstitution_ProxyTokens(t *testing.T) {
+	i := createTestInstitution(t)
+
+	token, err := i.IssueProxyToken("tok-1", "EZproxy", "hash-1", testNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found, ok := i.MatchToken("hash-1"); !ok || found.ID != token.ID {
+		t.Errorf("expected the token to match, got %+v", found)
+	}
+
+	if err := i.RevokeProxyToken("tok-1", testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := i.MatchToken("hash-1"); ok {
+		t.Error("expected a revoked token not to match")
+	}
+	if err := i.RevokeProxyToken("tok-1", testNow); err != ErrTokenNotFound {
+		t.Errorf("expected ErrTokenNotFound revoking twice, got %v", err)
+	}
+
+	for n := 0; n < MaxProxyTokens; n++ {
+		if _, err := i.IssueProxyToken(fmt.Sprintf("tok-%d", n+2), "", fmt.Sprintf("hash-%d", n+2), testNow); err != nil {
+			t.Fatalf("unexpected error issuing token %d: %v", n, err)
+		}
+	}
+	if _, err := i.IssueProxyToken("tok-99", "", "hash-99", testNow); err != ErrTooManyTokens {
+		t.Errorf("expected ErrTooManyTokens, got %v", err)
+	}
+}
+
+func TestInstitution_GrantsAccess(t *testing.T) {
+	i := createTestInstitution(t)
+
+	if i.GrantsAccess(i.Contract.End) {
+		t.Error("expected access to end with the contract")
+	}
+
+	if err := i.Suspend("scraping from the library range", testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if i.GrantsAccess(testNow) {
+		t.Error("expected a suspended institution to grant nothing")
+	}
+	if err := i.Suspend("again", testNow); err != ErrNotActive {
+		t.Errorf("expected ErrNotActive, got %v", err)
+	}
+
+	if err := i.Reinstate(testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !i.GrantsAccess(testNow) || i.SuspendedReason != nil {
+		t.Errorf("expected access back, got %+v", i)
+	}
+	if err := i.Reinstate(testNow); err != ErrNotSuspended {
+		t.Errorf("expected ErrNotSuspended, got %v", err)
+	}
+
+	renewed, _ := NewContract("UI-2027-003", i.Contract.End, i.Contract.End.AddDate(1, 0, 0))
+	if err := i.Renew(renewed, testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !i.GrantsAccess(renewed.Start.AddDate(0, 6, 0)) {
+		t.Error("expected the renewal to extend access")
+	}
+}
+
+func TestAlert_Resolve(t *testing.T) {
+	volume := Volume{Source: Source{InstitutionID: "inst-1", Kind: SourceRange, Value: "152.118.0.0/16"}, Hour: Hour(testNow), Requests: 3000}
+	a, err := NewAlert("alert-1", volume, 40, testNow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Status != AlertOpen {
+		t.Errorf("expected an open alert, got %s", a.Status)
+	}
+
+	if err := a.Resolve("admin-1", true, testNow); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Status != AlertActioned || *a.ResolvedBy != "admin-1" {
+		t.Errorf("unexpected alert %+v", a)
+	}
+	if err := a.Resolve("admin-1", false, testNow); err != ErrAlertNotOpen {
+		t.Errorf("expected ErrAlertNotOpen, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package institution
+
+import (
+	"context"
+	"net/netip"
+	"time"
+)
+
+// Domain interface for institution persistence (implementation will be in infrastructure layer)
+type InstitutionRepository interface {
+	Create(ctx context.Context, i *Institution) error
+	Update(ctx context.Context, i *Institution) error
+	FindByID(ctx context.Context, id string) (*Institution, error)
+	FindAll(ctx context.Context) ([]*Institution, error)
+
+	// FindByIP returns the institution with a range containing the address, nil when none does
+	FindByIP(ctx context.Context, ip netip.Addr) (*Institution, error)
+
+	// FindByTokenHash returns the institution holding the active proxy token, nil when none does
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Institution, error)
+}
+
+// Domain interface for institutional reads (implementation will be in infrastructure layer)
+type UsageRepository interface {
+	RecordRead(ctx context.Context, institutionID, articleID string, at time.Time) error
+
+	// FindDaily returns one entry per day in [from, to) with reads, days without reads are left out
+	FindDaily(ctx context.Context, institutionID string, from, to time.Time) ([]DailyUsage, error)
+}
+
+// Domain interface for hourly request counters (implementation will be in infrastructure
+// layer, Redis INCR with an expiry past the baseline days in production)
+type VolumeRepository interface {
+	Increment(ctx context.Context, source Source, hour time.Time) error
+
+	// FindHour returns the volume of every source that made requests in the hour
+	FindHour(ctx context.Context, hour time.Time) ([]Volume, error)
+}
+
+// Domain interface for abuse alerts (implementation will be in infrastructure layer)
+type AlertRepository interface {
+	Create(ctx context.Context, a *Alert) error
+	Update(ctx context.Context, a *Alert) error
+	FindByID(ctx context.Context, id string) (*Alert, error)
+
+	// FindBySource returns the alert raised for the source in the hour, nil when none was
+	FindBySource(ctx context.Context, source Source, hour time.Time) (*Alert, error)
+
+	FindOpen(ctx context.Context) ([]*Alert, error)
+}
@@ -0,0 +1,179 @@
+package institution
+
+import (
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Domain errors
+var (
+	ErrEmptyID               = shared.NewDomainError("institution.id_required", shared.KindValidation, "institution ID cannot be empty")
+	ErrInvalidProxyToken     = shared.NewDomainError("institution.invalid_proxy_token", shared.KindValidation, "proxy token needs an ID and a hash")
+	ErrEmptySuspensionReason = shared.NewDomainError("institution.suspension_reason_required", shared.KindValidation, "suspension reason cannot be empty")
+	ErrEmptyAlertID          = shared.NewDomainError("institution.alert_id_required", shared.KindValidation, "alert ID cannot be empty")
+	ErrEmptyAlertInstitution = shared.NewDomainError("institution.alert_institution_required", shared.KindValidation, "alert needs an institution")
+	ErrEmptyStaffID          = shared.NewDomainError("institution.staff_required", shared.KindValidation, "staff ID cannot be empty")
+	ErrEmptyName             = shared.NewDomainError("institution.name_required", shared.KindValidation, "institution name cannot be empty")
+	ErrInvalidRange          = shared.NewDomainError("institution.invalid_range", shared.KindValidation, "ranges must be IP addresses or CIDR ranges")
+	ErrRangeTooBroad         = shared.NewDomainError("institution.range_too_broad", shared.KindValidation, "range is too broad, IPv4 ranges need at least /16 and IPv6 ranges /32")
+	ErrOverlappingRange      = shared.NewDomainError("institution.overlapping_range", shared.KindValidation, "ranges cannot overlap")
+	ErrInvalidContract       = shared.NewDomainError("institution.invalid_contract", shared.KindValidation, "contract needs a reference and must end after it starts")
+	ErrTooManyTokens         = shared.NewDomainError("institution.too_many_tokens", shared.KindConflict, "institution already has the maximum number of proxy tokens")
+	ErrTokenNotFound         = shared.NewDomainError("institution.token_not_found", shared.KindNotFound, "proxy token not found")
+	ErrNotActive             = shared.NewDomainError("institution.not_active", shared.KindState, "institution is not active")
+	ErrNotSuspended          = shared.NewDomainError("institution.not_suspended", shared.KindState, "institution is not suspended")
+	ErrAlertNotOpen          = shared.NewDomainError("institution.alert_not_open", shared.KindState, "alert is not open")
+	ErrInvalidPolicy         = shared.NewDomainError("institution.invalid_policy", shared.KindValidation, "anomaly policy needs a factor above 1, a minimum volume and at least one baseline day")
+)
+
+// Range size limits, anything broader would hand premium access to an ISP
+const (
+	MinIPv4Bits = 16
+	MinIPv6Bits = 32
+)
+
+// MaxProxyTokens bounds the active tokens per institution, one per proxy server is the norm
+const MaxProxyTokens = 10
+
+// ParseRange accepts a CIDR range or a single address and returns it masked
+func ParseRange(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	var prefix netip.Prefix
+	if strings.Contains(value, "/") {
+		p, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, ErrInvalidRange
+		}
+		prefix = p
+	} else {
+		ip, err := netip.ParseAddr(value)
+		if err != nil {
+			return netip.Prefix{}, ErrInvalidRange
+		}
+		prefix = netip.PrefixFrom(ip, ip.BitLen())
+	}
+	if prefix.Addr().Is4In6() {
+		return netip.Prefix{}, ErrInvalidRange
+	}
+	if prefix.Addr().Is4() && prefix.Bits() < MinIPv4Bits || prefix.Addr().Is6() && prefix.Bits() < MinIPv6Bits {
+		return netip.Prefix{}, ErrRangeTooBroad
+	}
+	return prefix.Masked(), nil
+}
+
+// Contract is the licence agreement access is tied to, it covers [Start, End)
+type Contract struct {
+	Reference string // The signed agreement, e.g. "UI-2026-014"
+	Start     time.Time
+	End       time.Time
+}
+
+func NewContract(reference string, start, end time.Time) (Contract, error) {
+	reference = strings.TrimSpace(reference)
+	if reference == "" || !end.After(start) {
+		return Contract{}, ErrInvalidContract
+	}
+	return Contract{Reference: reference, Start: start.UTC(), End: end.UTC()}, nil
+}
+
+func (c Contract) Covers(at time.Time) bool {
+	return !at.Before(c.Start) && at.Before(c.End)
+}
+
+// DaysLeft is how many days of the contract remain, 0 once it has ended
+func (c Contract) DaysLeft(at time.Time) int {
+	if !at.Before(c.End) {
+		return 0
+	}
+	return int(c.End.Sub(at).Hours()/24) + 1
+}
+
+// SourceKind is how a request was matched to the institution
+type SourceKind string
+
+const (
+	SourceRange SourceKind = "range" // The client address is inside a configured range
+	SourceProxy SourceKind = "proxy" // A library proxy presented its token
+)
+
+// Source is the range or proxy token traffic is counted against, abuse shows up per source
+type Source struct {
+	InstitutionID string
+	Kind          SourceKind
+	Value         string // The range in CIDR notation or the proxy token ID
+}
+
+func (s Source) String() string {
+	return s.InstitutionID + "/" + string(s.Kind) + "/" + s.Value
+}
+
+// Volume is the number of requests a source made in one hour
+type Volume struct {
+	Source   Source
+	Hour     time.Time
+	Requests int
+}
+
+// DailyUsage is what an institution read on one day, reported to the librarian
+type DailyUsage struct {
+	Day            time.Time
+	Reads          int
+	UniqueArticles int
+}
+
+// AnomalyPolicy decides when a source's hourly volume looks like abuse, e.g. a
+// compromised proxy or a scraper inside a campus range. The baseline is the
+// same hour on the previous days, so lecture peaks are not flagged.
+type AnomalyPolicy struct {
+	Factor       float64 // Times the baseline that counts as anomalous
+	MinRequests  int     // Volumes below this are never flagged, whatever the baseline
+	BaselineDays int
+}
+
+func DefaultAnomalyPolicy() AnomalyPolicy {
+	return AnomalyPolicy{Factor: 5, MinRequests: 500, BaselineDays: 7}
+}
+
+func NewAnomalyPolicy(factor float64, minRequests, baselineDays int) (AnomalyPolicy, error) {
+	if factor <= 1 || minRequests < 1 || baselineDays < 1 {
+		return AnomalyPolicy{}, ErrInvalidPolicy
+	}
+	return AnomalyPolicy{Factor: factor, MinRequests: minRequests, BaselineDays: baselineDays}, nil
+}
+
+// BaselineHours are the hours the baseline for hour is taken from
+func (p AnomalyPolicy) BaselineHours(hour time.Time) []time.Time {
+	hours := make([]time.Time, 0, p.BaselineDays)
+	for day := 1; day <= p.BaselineDays; day++ {
+		hours = append(hours, hour.AddDate(0, 0, -day))
+	}
+	return hours
+}
+
+// Anomalous compares the volume with the mean of the baseline volumes, days
+// without traffic count as zero
+func (p AnomalyPolicy) Anomalous(requests int, baseline []int) (bool, float64) {
+	mean := 0.0
+	if len(baseline) > 0 {
+		total := 0
+		for _, v := range baseline {
+			total += v
+		}
+		mean = float64(total) / float64(len(baseline))
+	}
+	return requests >= p.MinRequests && float64(requests) > p.Factor*mean, mean
+}
+
+// Hour truncates to the UTC hour volumes are counted in
+func Hour(at time.Time) time.Time {
+	return at.UTC().Truncate(time.Hour)
+}
+
+// Day truncates to the UTC day usage is reported in
+func Day(at time.Time) time.Time {
+	at = at.UTC()
+	return time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,107 @@
+package institution
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expected      string
+		expectedError error
+	}{
+		{"152.118.0.0/16", "152.118.0.0/16", nil},
+		{" 152.118.24.7/24 ", "152.118.24.0/24", nil},
+		{"152.118.24.7", "152.118.24.7/32", nil},
+		{"2001:db8:10::/48", "2001:db8:10::/48", nil},
+		{"10.0.0.0/8", "", ErrRangeTooBroad},
+		{"2001:db8::/16", "", ErrRangeTooBroad},
+		{"::ffff:152.118.24.7", "", ErrInvalidRange},
+		{"campus", "", ErrInvalidRange},
+		{"152.118.0.0/33", "", ErrInvalidRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			prefix, err := ParseRange(tc.input)
+			if err != tc.expectedError {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if err == nil && prefix.String() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, prefix)
+			}
+		})
+	}
+}
+
+func TestContract(t *testing.T) {
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	c, err := NewContract(" UI-2026-014 ", start, end)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Reference != "UI-2026-014" {
+		t.Errorf("expected trimmed reference, got %q", c.Reference)
+	}
+	if c.Covers(start.Add(-time.Second)) || !c.Covers(start) || c.Covers(end) {
+		t.Error("expected the contract to cover [start, end)")
+	}
+	if days := c.DaysLeft(end.Add(-36 * time.Hour)); days != 2 {
+		t.Errorf("expected 2 days left, got %d", days)
+	}
+	if days := c.DaysLeft(end); days != 0 {
+		t.Errorf("expected 0 days left after the end, got %d", days)
+	}
+
+	if _, err := NewContract("", start, end); err != ErrInvalidContract {
+		t.Errorf("expected ErrInvalidContract for a missing reference, got %v", err)
+	}
+	if _, err := NewContract("UI-2026-014", end, start); err != ErrInvalidContract {
+		t.Errorf("expected ErrInvalidContract for reversed dates, got %v", err)
+	}
+}
+
+func TestAnomalyPolicy_Anomalous(t *testing.T) {
+	p := DefaultAnomalyPolicy()
+
+	testCases := []struct {
+		name      string
+		requests  int
+		baseline  []int
+		anomalous bool
+	}{
+		{"normal lecture peak", 2400, []int{2000, 2200, 1900, 2100, 2300, 0, 0}, false},
+		{"scraper at night", 3000, []int{40, 35, 50, 20, 45, 10, 0}, true},
+		{"small range spikes below the minimum", 400, []int{5, 5, 5, 5, 5, 5, 5}, false},
+		{"new source without baseline", 600, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			anomalous, _ := p.Anomalous(tc.requests, tc.baseline)
+			if anomalous != tc.anomalous {
+				t.Errorf("expected anomalous=%v", tc.anomalous)
+			}
+		})
+	}
+
+	if _, mean := p.Anomalous(0, []int{10, 20}); mean != 15 {
+		t.Errorf("expected a mean of 15, got %v", mean)
+	}
+	if _, err := NewAnomalyPolicy(1, 100, 7); err != ErrInvalidPolicy {
+		t.Errorf("expected ErrInvalidPolicy, got %v", err)
+	}
+}
+
+func TestAnomalyPolicy_BaselineHours(t *testing.T) {
+	p, _ := NewAnomalyPolicy(5, 500, 3)
+	hour := time.Date(2026, time.March, 10, 14, 0, 0, 0, time.UTC)
+
+	hours := p.BaselineHours(hour)
+	if len(hours) != 3 || !hours[0].Equal(hour.AddDate(0, 0, -1)) || !hours[2].Equal(hour.AddDate(0, 0, -3)) {
+		t.Errorf("expected the same hour on the three previous days, got %v", hours)
+	}
+}
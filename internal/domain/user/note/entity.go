@@ -0,0 +1,150 @@
+package note
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// AccountNote is an internal staff annotation on an account, never shown to its owner
+type AccountNote struct {
+	ID        string
+	AccountID string // Account the note is about
+	AuthorID  string
+	Body      Body
+	IsPinned  bool
+	PinnedBy  *string
+
+	// Audit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	EditedAt  *time.Time
+	DeletedAt *time.Time
+	DeletedBy *string
+}
+
+func NewAccountNote(id, accountID string, author *account.UserAccount, body string) (*AccountNote, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if !CanAccessNotes(author) {
+		return nil, errors.New("only active staff can write account notes")
+	}
+
+	bodyObj, err := NewBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &AccountNote{
+		ID:        id,
+		AccountID: accountID,
+		AuthorID:  author.ID,
+		Body:      *bodyObj,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// Business Methods
+
+// Edit replaces the note text, only the original author may edit
+func (n *AccountNote) Edit(editorID, body string) error {
+	if n.IsDeleted() {
+		return errors.New("cannot edit deleted note")
+	}
+	if editorID != n.AuthorID {
+		return errors.New("only the author can edit the note")
+	}
+
+	bodyObj, err := NewBody(body)
+	if err != nil {
+		return err
+	}
+	if n.Body.Equals(*bodyObj) {
+		return errors.New("new body is the same as current body")
+	}
+
+	now := time.Now()
+	n.Body = *bodyObj
+	n.EditedAt = &now
+	n.UpdatedAt = now
+	return nil
+}
+
+func (n *AccountNote) Pin(staffID string) error {
+	if n.IsDeleted() {
+		return errors.New("cannot pin deleted note")
+	}
+	if n.IsPinned {
+		return errors.New("note is already pinned")
+	}
+	if strings.TrimSpace(staffID) == "" {
+		return errors.New("staff ID cannot be empty")
+	}
+
+	n.IsPinned = true
+	n.PinnedBy = &staffID
+	n.UpdatedAt = time.Now()
+	return nil
+}
+
+func (n *AccountNote) Unpin() error {
+	if !n.IsPinned {
+		return errors.New("note is not pinned")
+	}
+
+	n.IsPinned = false
+	n.PinnedBy = nil
+	n.UpdatedAt = time.Now()
+	return nil
+}
+
+// Delete soft deletes the note so the support history stays auditable
+func (n *AccountNote) Delete(deleterID string) error {
+	if n.IsDeleted() {
+		return errors.New("note is already deleted")
+	}
+	if strings.TrimSpace(deleterID) == "" {
+		return errors.New("deleter ID cannot be empty")
+	}
+
+	now := time.Now()
+	n.DeletedAt = &now
+	n.DeletedBy = &deleterID
+	n.IsPinned = false
+	n.PinnedBy = nil
+	n.UpdatedAt = now
+	return nil
+}
+
+// Query Methods
+
+func (n *AccountNote) IsDeleted() bool {
+	return n.DeletedAt != nil
+}
+
+func (n *AccountNote) IsEdited() bool {
+	return n.EditedAt != nil
+}
+
+func (n *AccountNote) Mentions(username string) bool {
+	username = strings.ToLower(username)
+	for _, m := range n.Body.Mentions() {
+		if m == username {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessNotes is the visibility rule for the notes thread: active internal staff only
+func CanAccessNotes(viewer *account.UserAccount) bool {
+	return viewer != nil && viewer.IsInternal() && viewer.CanLogin()
+}
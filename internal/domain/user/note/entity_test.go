@@ -0,0 +1,122 @@
+package note
+
+import (
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestNewAccountNote(t *testing.T) {
+	staff := createTestAccount(t, "staff1", "staff_user", account.TypeInternal, true)
+	partner := createTestAccount(t, "partner1", "partner_user", account.TypePartner, true)
+	pending := createTestAccount(t, "staff2", "pending_staff", account.TypeInternal, false)
+
+	tests := []struct {
+		name      string
+		id        string
+		accountID string
+		author    *account.UserAccount
+		body      string
+		wantErr   bool
+		errMsg    string
+	}{
+		{name: "valid note", id: "note-1", accountID: "member1", author: staff, body: "Refund issued, see @support_lead"},
+		{name: "empty id", id: "", accountID: "member1", author: staff, body: "text", wantErr: true, errMsg: "ID cannot be empty"},
+		{name: "empty account", id: "note-1", accountID: " ", author: staff, body: "text", wantErr: true, errMsg: "account ID cannot be empty"},
+		{name: "non staff author", id: "note-1", accountID: "member1", author: partner, body: "text", wantErr: true, errMsg: "only active staff can write account notes"},
+		{name: "inactive staff author", id: "note-1", accountID: "member1", author: pending, body: "text", wantErr: true, errMsg: "only active staff can write account notes"},
+		{name: "empty body", id: "note-1", accountID: "member1", author: staff, body: "", wantErr: true, errMsg: ErrBodyEmpty.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := NewAccountNote(tt.id, tt.accountID, tt.author, tt.body)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n.AuthorID != tt.author.ID {
+				t.Errorf("expected author %s, got %s", tt.author.ID, n.AuthorID)
+			}
+			if !n.Mentions("Support_Lead") {
+				t.Error("expected note to mention support_lead")
+			}
+		})
+	}
+}
+
+func TestAccountNote_Lifecycle(t *testing.T) {
+	staff := createTestAccount(t, "staff1", "staff_user", account.TypeInternal, true)
+	n, err := NewAccountNote("note-1", "member1", staff, "First contact")
+	if err != nil {
+		t.Fatalf("failed to create note: %v", err)
+	}
+
+	if err := n.Edit("someone_else", "Changed"); err == nil || err.Error() != "only the author can edit the note" {
+		t.Errorf("expected author error, got %v", err)
+	}
+	if err := n.Edit("staff1", "First contact"); err == nil {
+		t.Error("expected error editing with the same body")
+	}
+	if err := n.Edit("staff1", "First contact by phone"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !n.IsEdited() {
+		t.Error("expected note to be marked edited")
+	}
+
+	if err := n.Unpin(); err == nil {
+		t.Error("expected error unpinning a note that is not pinned")
+	}
+	if err := n.Pin("staff2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := n.Pin("staff2"); err == nil {
+		t.Error("expected error pinning twice")
+	}
+
+	if err := n.Delete("staff2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !n.IsDeleted() || n.IsPinned {
+		t.Error("expected deleted note to be unpinned")
+	}
+	if err := n.Edit("staff1", "After delete"); err == nil {
+		t.Error("expected error editing a deleted note")
+	}
+	if err := n.Delete("staff2"); err == nil {
+		t.Error("expected error deleting twice")
+	}
+}
+
+func TestCanAccessNotes(t *testing.T) {
+	if CanAccessNotes(nil) {
+		t.Error("expected anonymous viewer to be denied")
+	}
+	if !CanAccessNotes(createTestAccount(t, "staff1", "staff_user", account.TypeInternal, true)) {
+		t.Error("expected active staff to be allowed")
+	}
+	if CanAccessNotes(createTestAccount(t, "member1", "member_user", account.TypeMembership, true)) {
+		t.Error("expected member to be denied")
+	}
+}
+
+func createTestAccount(t *testing.T, id, username string, accountType account.UserAccountType, verified bool) *account.UserAccount {
+	acc, err := account.NewUserAccountForTesting(id, username, username+"@example.com", "TestPassword123!", accountType, "admin123")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if verified {
+		if err := acc.Verify("admin123"); err != nil {
+			t.Fatalf("failed to verify account: %v", err)
+		}
+	}
+	return acc
+}
@@ -0,0 +1,17 @@
+package note
+
+import "context"
+
+type AccountNoteRepository interface {
+	// Commands
+	Create(ctx context.Context, note *AccountNote) error
+	Update(ctx context.Context, note *AccountNote) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*AccountNote, error)
+
+	// FindByAccountID returns the thread for an account, pinned notes first, then newest first
+	FindByAccountID(ctx context.Context, accountID string, includeDeleted bool) ([]*AccountNote, error)
+	FindMentioning(ctx context.Context, username string, limit int) ([]*AccountNote, error)
+	CountByAccountID(ctx context.Context, accountID string) (int64, error)
+}
@@ -0,0 +1,69 @@
+package note
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Mentions use account usernames, e.g. "@jane_editor please check"
+var mentionRegex = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_@])@([a-zA-Z0-9_]{3,30})\b`)
+
+// MaxBodyLength keeps notes short enough to scan in the account sidebar
+const MaxBodyLength = 5000
+
+// Domain errors
+var (
+	ErrBodyEmpty   = errors.New("note body cannot be empty")
+	ErrBodyTooLong = errors.New("note body cannot exceed 5000 characters")
+)
+
+// Body value object, the note text and the usernames it mentions
+type Body struct {
+	value    string
+	mentions []string
+}
+
+func NewBody(value string) (*Body, error) {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return nil, ErrBodyEmpty
+	}
+	if len(value) > MaxBodyLength {
+		return nil, ErrBodyTooLong
+	}
+
+	return &Body{value: value, mentions: ParseMentions(value)}, nil
+}
+
+func (b Body) String() string {
+	return b.value
+}
+
+func (b Body) Value() string {
+	return b.value
+}
+
+// Mentions returns the distinct mentioned usernames, lowercased, in order of appearance
+func (b Body) Mentions() []string {
+	return append([]string(nil), b.mentions...)
+}
+
+func (b Body) Equals(other Body) bool {
+	return b.value == other.value
+}
+
+// ParseMentions extracts distinct @username mentions from text
+func ParseMentions(text string) []string {
+	var mentions []string
+	seen := map[string]bool{}
+	for _, m := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		username := strings.ToLower(m[1])
+		if !seen[username] {
+			seen[username] = true
+			mentions = append(mentions, username)
+		}
+	}
+	return mentions
+}
@@ -0,0 +1,42 @@
+package note
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewBody(t *testing.T) {
+	testCases := []struct {
+		name             string
+		input            string
+		expectedMentions []string
+		expectedErr      error
+	}{
+		{"plain note", "Called the reader about billing", nil, nil},
+		{"single mention", "@Jane_Editor please follow up", []string{"jane_editor"}, nil},
+		{"multiple distinct mentions", "cc @moderator1, @support_lead and @moderator1 again", []string{"moderator1", "support_lead"}, nil},
+		{"email is not a mention", "reader wrote from reader@example.com", nil, nil},
+		{"too short mention ignored", "ping @ab", nil, nil},
+		{"invalid - empty", "   ", nil, ErrBodyEmpty},
+		{"invalid - too long", strings.Repeat("a", MaxBodyLength+1), nil, ErrBodyTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := NewBody(tc.input)
+			if tc.expectedErr != nil {
+				if err != tc.expectedErr {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(body.Mentions(), tc.expectedMentions) {
+				t.Errorf("expected mentions %v, got %v", tc.expectedMentions, body.Mentions())
+			}
+		})
+	}
+}
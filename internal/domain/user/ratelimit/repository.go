@@ -0,0 +1,13 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Domain interface for request counters (implementation will be in infrastructure layer,
+// in memory for a single instance or Redis when every instance must share the count)
+type Limiter interface {
+	// Take counts one request for key and decides it against the limit
+	Take(ctx context.Context, key string, limit Limit, at time.Time) (Result, error)
+}
@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Domain errors
+var (
+	ErrTooManyRequests = shared.NewDomainError("ratelimit.too_many_requests", shared.KindRateLimited, "too many attempts, try again later")
+	ErrInvalidLimit    = shared.NewDomainError("ratelimit.invalid_limit", shared.KindValidation, "limit needs at least one request and a window of a second or more")
+)
+
+// MetaRetryAfter is the ErrTooManyRequests metadata entry with the wait in whole seconds
+const MetaRetryAfter = "retry_after"
+
+// Limit allows Requests within any sliding Window. The window is approximated
+// from two fixed windows: the previous one counts in proportion to how much
+// of it the sliding window still covers.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+func NewLimit(requests int, window time.Duration) (Limit, error) {
+	l := Limit{Requests: requests, Window: window}
+	if err := l.validate(); err != nil {
+		return Limit{}, err
+	}
+	return l, nil
+}
+
+func (l Limit) validate() error {
+	if l.Requests < 1 || l.Window < time.Second {
+		return ErrInvalidLimit
+	}
+	return nil
+}
+
+// WindowStart returns the start of the fixed window holding at
+func (l Limit) WindowStart(at time.Time) time.Time {
+	ms := at.UnixMilli()
+	return time.UnixMilli(ms - ms%l.Window.Milliseconds()).UTC()
+}
+
+// Counts are the requests seen in the previous and current fixed windows,
+// Current includes the request being decided
+type Counts struct {
+	Previous int
+	Current  int
+}
+
+// Result is the decision on one request
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration // Zero when allowed
+}
+
+// Decide applies the limit elapsed into the current fixed window. Rejected
+// requests are counted too, so a client that keeps hammering stays limited.
+func (l Limit) Decide(c Counts, elapsed time.Duration) Result {
+	window := float64(l.Window)
+	remainder := window - float64(elapsed)
+	estimate := float64(c.Previous)*remainder/window + float64(c.Current)
+	if estimate <= float64(l.Requests) {
+		return Result{Allowed: true, Remaining: int(math.Floor(float64(l.Requests) - estimate))}
+	}
+
+	// The next request counts one more, find when the estimate leaves room for it
+	var wait float64
+	if c.Current < l.Requests {
+		wait = remainder - float64(l.Requests-c.Current-1)*window/float64(c.Previous)
+	} else {
+		wait = remainder + window*(1-float64(l.Requests-1)/float64(c.Current))
+	}
+	return Result{RetryAfter: time.Duration(math.Ceil(wait))}
+}
+
+// Err returns ErrTooManyRequests carrying the wait for a rejected request, nil otherwise
+func (r Result) Err() error {
+	if r.Allowed {
+		return nil
+	}
+	seconds := int64(math.Ceil(r.RetryAfter.Seconds()))
+	return ErrTooManyRequests.With(MetaRetryAfter, strconv.FormatInt(max(seconds, 1), 10))
+}
+
+// Bucket is one counter a request is charged to
+type Bucket struct {
+	Key   string
+	Limit Limit
+}
+
+// Rule limits one endpoint per client address, and per address and username
+// so one address cannot work through a single account at the full address rate
+type Rule struct {
+	Name    string
+	PerIP   Limit
+	PerUser Limit
+}
+
+// Rules for the public auth endpoints. Shared office NAT is why the address
+// limits are far above what one person needs.
+var (
+	LoginRule        = Rule{Name: "login", PerIP: Limit{Requests: 60, Window: time.Minute}, PerUser: Limit{Requests: 5, Window: time.Minute}}
+	RegistrationRule = Rule{Name: "register", PerIP: Limit{Requests: 20, Window: time.Hour}, PerUser: Limit{Requests: 3, Window: time.Hour}}
+)
+
+func NewRule(name string, perIP, perUser Limit) (Rule, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Rule{}, ErrInvalidLimit.WithMessage("rule name cannot be empty")
+	}
+	if err := perIP.validate(); err != nil {
+		return Rule{}, err
+	}
+	if err := perUser.validate(); err != nil {
+		return Rule{}, err
+	}
+	return Rule{Name: name, PerIP: perIP, PerUser: perUser}, nil
+}
+
+// Buckets returns the counters a request is charged to, e.g. "login:ip:203.0.113.7"
+// and "login:user:203.0.113.7:sari". Usernames are compared case-insensitively.
+// Without a username only the address is limited.
+func (r Rule) Buckets(clientIP, username string) []Bucket {
+	buckets := []Bucket{{Key: r.Name + ":ip:" + clientIP, Limit: r.PerIP}}
+	if username = strings.ToLower(strings.TrimSpace(username)); username != "" {
+		buckets = append(buckets, Bucket{Key: r.Name + ":user:" + clientIP + ":" + username, Limit: r.PerUser})
+	}
+	return buckets
+}
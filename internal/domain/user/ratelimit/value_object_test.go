@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+func TestNewLimit(t *testing.T) {
+	testCases := []struct {
+		name        string
+		requests    int
+		window      time.Duration
+		expectedErr error
+	}{
+		{"valid", 5, time.Minute, nil},
+		{"no requests", 0, time.Minute, ErrInvalidLimit},
+		{"window too short", 5, time.Millisecond, ErrInvalidLimit},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewLimit(tc.requests, tc.window); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestLimit_Decide(t *testing.T) {
+	limit := Limit{Requests: 5, Window: time.Minute}
+
+	testCases := []struct {
+		name              string
+		counts            Counts
+		elapsed           time.Duration
+		expectedAllowed   bool
+		expectedRemaining int
+		expectedRetry     time.Duration
+	}{
+		{"first request", Counts{Current: 1}, 10 * time.Second, true, 4, 0},
+		{"last request", Counts{Current: 5}, 10 * time.Second, true, 0, 0},
+		{"over the limit", Counts{Current: 6}, 10 * time.Second, false, 0, 70 * time.Second},
+		{"previous window still counts", Counts{Previous: 6, Current: 1}, 10 * time.Second, false, 0, 20 * time.Second},
+		{"previous window has faded", Counts{Previous: 6, Current: 1}, 40 * time.Second, true, 2, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := limit.Decide(tc.counts, tc.elapsed)
+			if r.Allowed != tc.expectedAllowed || r.Remaining != tc.expectedRemaining || r.RetryAfter != tc.expectedRetry {
+				t.Errorf("expected allowed=%v remaining=%d retry=%s, got %+v", tc.expectedAllowed, tc.expectedRemaining, tc.expectedRetry, r)
+			}
+		})
+	}
+}
+
+func TestLimit_WindowStart(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 9, 30, 42, 500, time.UTC)
+	if start := (Limit{Requests: 1, Window: time.Minute}).WindowStart(at); !start.Equal(time.Date(2026, time.March, 10, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected window start %s", start)
+	}
+}
+
+func TestResult_Err(t *testing.T) {
+	if err := (Result{Allowed: true}).Err(); err != nil {
+		t.Errorf("expected no error when allowed, got %v", err)
+	}
+
+	err := Result{RetryAfter: 1500 * time.Millisecond}.Err()
+	de, ok := shared.AsDomainError(err)
+	if !errors.Is(err, ErrTooManyRequests) || !ok || de.Kind != shared.KindRateLimited {
+		t.Fatalf("expected ErrTooManyRequests, got %v", err)
+	}
+	if de.Meta[MetaRetryAfter] != "2" {
+		t.Errorf("expected the wait rounded up, got %q", de.Meta[MetaRetryAfter])
+	}
+}
+
+func TestRule_Buckets(t *testing.T) {
+	buckets := LoginRule.Buckets("203.0.113.7", " Sari ")
+	if len(buckets) != 2 || buckets[0].Key != "login:ip:203.0.113.7" || buckets[1].Key != "login:user:203.0.113.7:sari" {
+		t.Errorf("unexpected buckets %+v", buckets)
+	}
+	if buckets[1].Limit != LoginRule.PerUser {
+		t.Errorf("expected the per-user limit, got %+v", buckets[1].Limit)
+	}
+	if buckets := RegistrationRule.Buckets("203.0.113.7", ""); len(buckets) != 1 {
+		t.Errorf("expected only the address bucket without a username, got %+v", buckets)
+	}
+
+	if _, err := NewRule(" ", LoginRule.PerIP, LoginRule.PerUser); !errors.Is(err, ErrInvalidLimit) {
+		t.Errorf("expected ErrInvalidLimit, got %v", err)
+	}
+}
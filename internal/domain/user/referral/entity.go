@@ -0,0 +1,155 @@
+package referral
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Reward and fraud limits
+const (
+	RewardDays         = 30             // Free premium days for the referrer per qualified signup
+	MaxRewardsPerMonth = 10             // Rewards per referrer in any rolling 30 days
+	ClusterWindow      = 24 * time.Hour // Lookback for signups sharing an IP or device
+	MaxClusterSize     = 3              // Signups from one IP or device in the window before flagging
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // Signed up, not yet eligible for a reward
+	StatusRewarded Status = "rewarded" // Referrer received the premium days
+	StatusFlagged  Status = "flagged"  // Held by a fraud check, never rewarded automatically
+	StatusCapped   Status = "capped"   // Qualified after the monthly reward cap was reached
+)
+
+type FlagReason string
+
+const (
+	FlagSelfReferral FlagReason = "self_referral"  // Same IP or device as the referrer
+	FlagCluster      FlagReason = "signup_cluster" // Too many signups from one IP or device
+)
+
+// Domain errors
+var (
+	ErrNotPending = errors.New("referral is no longer pending")
+)
+
+// Member holds a member's referral code
+type Member struct {
+	AccountID   string
+	Code        Code
+	Fingerprint Fingerprint // Where the member was when the code was issued
+
+	// Audit
+	CreatedAt time.Time
+}
+
+// Referral attributes one new signup to the member whose code brought them in
+type Referral struct {
+	ID          string
+	ReferrerID  string
+	RefereeID   string
+	Code        Code
+	Fingerprint Fingerprint // Where the referee signed up
+	Status      Status
+	FlagReason  *FlagReason
+	RewardDays  int
+
+	// Audit
+	CreatedAt  time.Time
+	RewardedAt *time.Time
+	UpdatedAt  time.Time
+}
+
+func NewMember(accountID string, code Code, fingerprint Fingerprint, at time.Time) (*Member, error) {
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	return &Member{AccountID: accountID, Code: code, Fingerprint: fingerprint, CreatedAt: at}, nil
+}
+
+func NewReferral(id string, referrer *Member, refereeID string, fingerprint Fingerprint, at time.Time) (*Referral, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if referrer == nil {
+		return nil, errors.New("referrer cannot be empty")
+	}
+	if strings.TrimSpace(refereeID) == "" {
+		return nil, errors.New("referee ID cannot be empty")
+	}
+
+	return &Referral{
+		ID:          id,
+		ReferrerID:  referrer.AccountID,
+		RefereeID:   refereeID,
+		Code:        referrer.Code,
+		Fingerprint: fingerprint,
+		Status:      StatusPending,
+		CreatedAt:   at,
+		UpdatedAt:   at,
+	}, nil
+}
+
+// Business Methods
+
+func (r *Referral) Flag(reason FlagReason, at time.Time) error {
+	if r.Status != StatusPending {
+		return ErrNotPending
+	}
+	r.Status = StatusFlagged
+	r.FlagReason = &reason
+	r.UpdatedAt = at
+	return nil
+}
+
+func (r *Referral) MarkRewarded(days int, at time.Time) error {
+	if r.Status != StatusPending {
+		return ErrNotPending
+	}
+	r.Status = StatusRewarded
+	r.RewardDays = days
+	r.RewardedAt = &at
+	r.UpdatedAt = at
+	return nil
+}
+
+func (r *Referral) MarkCapped(at time.Time) error {
+	if r.Status != StatusPending {
+		return ErrNotPending
+	}
+	r.Status = StatusCapped
+	r.UpdatedAt = at
+	return nil
+}
+
+// Query Methods
+
+func (r *Referral) IsPending() bool {
+	return r.Status == StatusPending
+}
+
+// DetectFraud checks a new referral against its referrer and the recent signups
+// sharing its IP or device, returning nil when nothing looks wrong
+func DetectFraud(r *Referral, referrer *Member, recent []*Referral) *FlagReason {
+	if r.RefereeID == referrer.AccountID || r.Fingerprint.Overlaps(referrer.Fingerprint) {
+		reason := FlagSelfReferral
+		return &reason
+	}
+
+	cluster := 0
+	for _, other := range recent {
+		if other.ID == r.ID || r.CreatedAt.Sub(other.CreatedAt) > ClusterWindow {
+			continue
+		}
+		if r.Fingerprint.Overlaps(other.Fingerprint) {
+			cluster++
+		}
+	}
+	if cluster >= MaxClusterSize {
+		reason := FlagCluster
+		return &reason
+	}
+	return nil
+}
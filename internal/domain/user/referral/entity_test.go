@@ -0,0 +1,81 @@
+package referral
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func createTestMember(t *testing.T) *Member {
+	code, _ := NewCode("K7QM4XPA")
+	member, err := NewMember("member-1", *code, Fingerprint{IPAddress: "203.0.113.7", DeviceID: "device-1"}, time.Now())
+	if err != nil {
+		t.Fatalf("failed to create member: %v", err)
+	}
+	return member
+}
+
+func TestDetectFraud(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	member := createTestMember(t)
+
+	var cluster []*Referral
+	for i := 0; i < MaxClusterSize; i++ {
+		r, _ := NewReferral(fmt.Sprintf("ref-%d", i), member, fmt.Sprintf("reader-%d", i), Fingerprint{IPAddress: "198.51.100.9"}, at.Add(-time.Hour))
+		cluster = append(cluster, r)
+	}
+	stale, _ := NewReferral("ref-old", member, "reader-old", Fingerprint{IPAddress: "198.51.100.9"}, at.Add(-48*time.Hour))
+
+	testCases := []struct {
+		name        string
+		refereeID   string
+		fingerprint Fingerprint
+		recent      []*Referral
+		expected    *FlagReason
+	}{
+		{"clean", "reader-new", Fingerprint{IPAddress: "192.0.2.1", DeviceID: "device-9"}, nil, nil},
+		{"own account", "member-1", Fingerprint{IPAddress: "192.0.2.1"}, nil, ptr(FlagSelfReferral)},
+		{"referrer's device", "reader-new", Fingerprint{IPAddress: "192.0.2.1", DeviceID: "device-1"}, nil, ptr(FlagSelfReferral)},
+		{"cluster", "reader-new", Fingerprint{IPAddress: "198.51.100.9"}, cluster, ptr(FlagCluster)},
+		{"outside window", "reader-new", Fingerprint{IPAddress: "198.51.100.9"}, append(cluster[:MaxClusterSize-1:MaxClusterSize-1], stale), nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, _ := NewReferral("ref-new", member, tc.refereeID, tc.fingerprint, at)
+			got := DetectFraud(r, member, tc.recent)
+			if (got == nil) != (tc.expected == nil) || (got != nil && *got != *tc.expected) {
+				t.Errorf("expected %v, got %v", deref(tc.expected), deref(got))
+			}
+		})
+	}
+}
+
+func TestReferral_Transitions(t *testing.T) {
+	at := time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC)
+	r, _ := NewReferral("ref-1", createTestMember(t), "reader-1", Fingerprint{}, at)
+
+	if err := r.MarkRewarded(RewardDays, at.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Status != StatusRewarded || r.RewardDays != RewardDays {
+		t.Errorf("expected rewarded with %d days, got %s %d", RewardDays, r.Status, r.RewardDays)
+	}
+	if err := r.Flag(FlagCluster, at.Add(2*time.Hour)); err != ErrNotPending {
+		t.Errorf("expected error '%v', got '%v'", ErrNotPending, err)
+	}
+	if err := r.MarkRewarded(RewardDays, at.Add(2*time.Hour)); err != ErrNotPending {
+		t.Errorf("expected error '%v', got '%v'", ErrNotPending, err)
+	}
+}
+
+func ptr(reason FlagReason) *FlagReason {
+	return &reason
+}
+
+func deref(reason *FlagReason) string {
+	if reason == nil {
+		return "<nil>"
+	}
+	return string(*reason)
+}
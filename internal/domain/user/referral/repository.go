@@ -0,0 +1,30 @@
+package referral
+
+import (
+	"context"
+	"time"
+)
+
+type MemberRepository interface {
+	Create(ctx context.Context, member *Member) error
+	FindByAccountID(ctx context.Context, accountID string) (*Member, error) // nil when not found
+	FindByCode(ctx context.Context, code Code) (*Member, error)             // nil when not found
+}
+
+type ReferralRepository interface {
+	// Commands
+	Create(ctx context.Context, referral *Referral) error
+	Update(ctx context.Context, referral *Referral) error
+
+	// Queries
+	FindByReferee(ctx context.Context, refereeID string) (*Referral, error) // nil when not found
+	FindByReferrer(ctx context.Context, referrerID string) ([]*Referral, error)
+	// FindOverlapping returns referrals since the given time sharing the IP address or device
+	FindOverlapping(ctx context.Context, fingerprint Fingerprint, since time.Time) ([]*Referral, error)
+	CountRewardedSince(ctx context.Context, referrerID string, since time.Time) (int, error)
+}
+
+// Domain interface for crediting premium time (implementation will be in the billing layer)
+type RewardIssuer interface {
+	GrantPremiumDays(ctx context.Context, accountID string, days int, referralID string) error
+}
@@ -0,0 +1,58 @@
+package referral
+
+import (
+	"errors"
+	"strings"
+)
+
+// CodeAlphabet leaves out 0/O and 1/I so codes survive being read aloud
+const (
+	CodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	CodeLength   = 8
+)
+
+var ErrInvalidCode = errors.New("referral code must be 8 characters from the referral alphabet")
+
+// Code value object, the member's shareable referral code
+type Code struct {
+	value string
+}
+
+func NewCode(value string) (*Code, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) != CodeLength {
+		return nil, ErrInvalidCode
+	}
+	for _, c := range value {
+		if !strings.ContainsRune(CodeAlphabet, c) {
+			return nil, ErrInvalidCode
+		}
+	}
+	return &Code{value: value}, nil
+}
+
+func (c Code) String() string {
+	return c.value
+}
+
+func (c Code) Value() string {
+	return c.value
+}
+
+func (c Code) Equals(other Code) bool {
+	return c.value == other.value
+}
+
+// Fingerprint identifies where a signup or code request came from, used to spot clusters
+type Fingerprint struct {
+	IPAddress string
+	DeviceID  string // First-party device cookie, empty when the client sent none
+}
+
+// Overlaps reports whether two fingerprints share an IP address or device
+func (f Fingerprint) Overlaps(other Fingerprint) bool {
+	if f.IPAddress != "" && f.IPAddress == other.IPAddress {
+		return true
+	}
+	return f.DeviceID != "" && f.DeviceID == other.DeviceID
+}
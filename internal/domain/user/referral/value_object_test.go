@@ -0,0 +1,50 @@
+package referral
+
+import "testing"
+
+func TestNewCode(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{"valid", "K7QM4XPA", "K7QM4XPA", nil},
+		{"lowercase is normalized", " k7qm4xpa ", "K7QM4XPA", nil},
+		{"too short", "K7QM4XP", "", ErrInvalidCode},
+		{"ambiguous characters", "K7QM0XPO", "", ErrInvalidCode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := NewCode(tc.input)
+			if err != tc.expectedErr {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if err == nil && code.Value() != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, code.Value())
+			}
+		})
+	}
+}
+
+func TestFingerprint_Overlaps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a, b     Fingerprint
+		expected bool
+	}{
+		{"same IP", Fingerprint{IPAddress: "203.0.113.7"}, Fingerprint{IPAddress: "203.0.113.7", DeviceID: "d2"}, true},
+		{"same device", Fingerprint{IPAddress: "203.0.113.7", DeviceID: "d1"}, Fingerprint{IPAddress: "198.51.100.2", DeviceID: "d1"}, true},
+		{"different", Fingerprint{IPAddress: "203.0.113.7", DeviceID: "d1"}, Fingerprint{IPAddress: "198.51.100.2", DeviceID: "d2"}, false},
+		{"empty never matches", Fingerprint{}, Fingerprint{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.a.Overlaps(tc.b); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package sanction
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Violation is one confirmed rule breach by an account and the sanction it drew
+type Violation struct {
+	ID          string
+	AccountID   string
+	Category    Category
+	Description string
+	ReportedBy  string // Moderator who confirmed the violation
+
+	// Sanction applied
+	Action         *Action
+	SuspendedUntil *time.Time
+
+	// Audit
+	CreatedAt time.Time
+}
+
+func NewViolation(id, accountID string, category Category, reportedBy, description string) (*Violation, error) {
+	if strings.TrimSpace(id) == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+	if strings.TrimSpace(accountID) == "" {
+		return nil, errors.New("account ID cannot be empty")
+	}
+	if strings.TrimSpace(reportedBy) == "" {
+		return nil, errors.New("reporter ID cannot be empty")
+	}
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil, errors.New("description cannot be empty")
+	}
+
+	return &Violation{
+		ID:          id,
+		AccountID:   accountID,
+		Category:    category,
+		Description: description,
+		ReportedBy:  reportedBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// Business Methods
+
+// Escalate picks the ladder step from the account's history in the same category
+// and applies it to the account. Never downgrades an account that is already blocked.
+func (v *Violation) Escalate(acc *account.UserAccount, ladder Ladder, history []*Violation) (Step, error) {
+	if v.IsSanctioned() {
+		return Step{}, errors.New("violation has already been sanctioned")
+	}
+	if acc == nil || acc.ID != v.AccountID {
+		return Step{}, errors.New("account does not match violation")
+	}
+	if !ladder.Category().Equals(v.Category) {
+		return Step{}, errors.New("ladder does not match violation category")
+	}
+	if acc.IsBlocked() {
+		return Step{}, errors.New("account is already blocked")
+	}
+
+	prior := 0
+	for _, h := range history {
+		if h.ID == v.ID || h.AccountID != v.AccountID || !h.Category.Equals(v.Category) {
+			continue
+		}
+		if ladder.Counts(h.CreatedAt, v.CreatedAt) {
+			prior++
+		}
+	}
+
+	step := ladder.StepFor(prior)
+	reason := v.Category.String() + ": " + v.Description
+
+	switch step.Action {
+	case ActionSuspend:
+		if err := acc.SuspendFor(v.ReportedBy, reason, step.Duration); err != nil {
+			return Step{}, err
+		}
+		v.SuspendedUntil = acc.SuspendedUntil
+	case ActionBlock:
+		if err := acc.Block(v.ReportedBy, reason); err != nil {
+			return Step{}, err
+		}
+	}
+
+	action := step.Action
+	v.Action = &action
+	return step, nil
+}
+
+// Query Methods
+
+func (v *Violation) IsSanctioned() bool {
+	return v.Action != nil
+}
@@ -0,0 +1,132 @@
+package sanction
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestNewViolation(t *testing.T) {
+	category := createTestCategory(t, "spam")
+
+	tests := []struct {
+		name        string
+		id          string
+		accountID   string
+		reportedBy  string
+		description string
+		wantErr     bool
+		errMsg      string
+	}{
+		{name: "valid", id: "v1", accountID: "member1", reportedBy: "mod1", description: "Link spam in comments"},
+		{name: "empty id", id: "", accountID: "member1", reportedBy: "mod1", description: "x", wantErr: true, errMsg: "ID cannot be empty"},
+		{name: "empty account", id: "v1", accountID: "", reportedBy: "mod1", description: "x", wantErr: true, errMsg: "account ID cannot be empty"},
+		{name: "empty reporter", id: "v1", accountID: "member1", reportedBy: " ", description: "x", wantErr: true, errMsg: "reporter ID cannot be empty"},
+		{name: "empty description", id: "v1", accountID: "member1", reportedBy: "mod1", description: "", wantErr: true, errMsg: "description cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewViolation(tt.id, tt.accountID, category, tt.reportedBy, tt.description)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				} else if err.Error() != tt.errMsg {
+					t.Errorf("expected error message %q, got %q", tt.errMsg, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v.IsSanctioned() {
+				t.Error("expected new violation to be unsanctioned")
+			}
+		})
+	}
+}
+
+func TestViolation_EscalateLadder(t *testing.T) {
+	category := createTestCategory(t, "spam")
+	ladder := DefaultLadder(category)
+	member := createTestAccount(t, "member1")
+
+	var history []*Violation
+	expected := []Action{ActionWarning, ActionSuspend, ActionSuspend, ActionBlock}
+
+	for i, want := range expected {
+		if member.IsSuspended() {
+			past := time.Now().Add(-time.Minute)
+			member.SuspendedUntil = &past
+			if err := member.ExpireSuspension("system", time.Now()); err != nil {
+				t.Fatalf("failed to expire suspension: %v", err)
+			}
+		}
+
+		v, _ := NewViolation(fmt.Sprintf("v%d", i), member.ID, category, "mod1", "Link spam")
+		step, err := v.Escalate(member, *ladder, history)
+		if err != nil {
+			t.Fatalf("violation %d: unexpected error: %v", i, err)
+		}
+		if step.Action != want {
+			t.Errorf("violation %d: expected %s, got %s", i, want, step.Action)
+		}
+		history = append(history, v)
+	}
+
+	if !member.IsBlocked() {
+		t.Error("expected account to be blocked at the top of the ladder")
+	}
+	if history[1].SuspendedUntil == nil || history[2].SuspendedUntil == nil {
+		t.Error("expected suspensions to record their end")
+	}
+
+	v, _ := NewViolation("v9", member.ID, category, "mod1", "More spam")
+	if _, err := v.Escalate(member, *ladder, history); err == nil || err.Error() != "account is already blocked" {
+		t.Errorf("expected already blocked error, got %v", err)
+	}
+}
+
+func TestViolation_EscalateIgnoresForgivenAndOtherCategories(t *testing.T) {
+	spam := createTestCategory(t, "spam")
+	harassment := createTestCategory(t, "harassment")
+	ladder := DefaultLadder(spam)
+	member := createTestAccount(t, "member1")
+
+	old := &Violation{ID: "old", AccountID: member.ID, Category: spam, CreatedAt: time.Now().AddDate(-1, 0, 0)}
+	other := &Violation{ID: "other", AccountID: member.ID, Category: harassment, CreatedAt: time.Now()}
+
+	v, _ := NewViolation("v1", member.ID, spam, "mod1", "Link spam")
+	step, err := v.Escalate(member, *ladder, []*Violation{old, other, v})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if step.Action != ActionWarning {
+		t.Errorf("expected warning, got %s", step.Action)
+	}
+	if !member.IsActive() {
+		t.Error("expected warning to leave the account active")
+	}
+
+	if _, err := v.Escalate(member, *ladder, nil); err == nil {
+		t.Error("expected error escalating an already sanctioned violation")
+	}
+
+	mismatched, _ := NewViolation("v2", member.ID, harassment, "mod1", "Abuse")
+	if _, err := mismatched.Escalate(member, *ladder, nil); err == nil {
+		t.Error("expected error for ladder of another category")
+	}
+}
+
+func createTestAccount(t *testing.T, id string) *account.UserAccount {
+	acc, err := account.NewUserAccountForTesting(id, "test_"+id, id+"@example.com", "TestPassword123!", account.TypeMembership, "admin123")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := acc.Verify("admin123"); err != nil {
+		t.Fatalf("failed to verify account: %v", err)
+	}
+	return acc
+}
@@ -0,0 +1,25 @@
+package sanction
+
+import (
+	"context"
+	"time"
+)
+
+type ViolationRepository interface {
+	// Commands
+	Create(ctx context.Context, violation *Violation) error
+
+	// Queries
+	FindByID(ctx context.Context, id string) (*Violation, error)
+
+	// FindByAccountID returns an account's violations in a category recorded at or after since
+	FindByAccountID(ctx context.Context, accountID string, category Category, since time.Time) ([]*Violation, error)
+}
+
+// LadderRepository holds per-category ladder overrides
+type LadderRepository interface {
+	Save(ctx context.Context, ladder *Ladder) error
+
+	// FindByCategory returns nil when the category has no override
+	FindByCategory(ctx context.Context, category Category) (*Ladder, error)
+}
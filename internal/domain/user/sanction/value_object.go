@@ -0,0 +1,151 @@
+package sanction
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Categories are lowercase slugs, e.g. "spam", "harassment", "doxxing"
+var categoryRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// Domain errors
+var (
+	ErrInvalidCategory    = errors.New("category must be 2-50 lowercase letters, digits or underscores")
+	ErrEmptyLadder        = errors.New("ladder must have at least one step")
+	ErrInvalidAction      = errors.New("invalid sanction action")
+	ErrInvalidDuration    = errors.New("suspend step must have a duration greater than 0")
+	ErrUnexpectedDuration = errors.New("only suspend steps can have a duration")
+	ErrBlockNotLast       = errors.New("block must be the last step of the ladder")
+	ErrNegativeLookback   = errors.New("lookback cannot be negative")
+)
+
+// Category value object, the kind of violation a ladder applies to
+type Category struct {
+	value string
+}
+
+func NewCategory(value string) (*Category, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if !categoryRegex.MatchString(value) {
+		return nil, ErrInvalidCategory
+	}
+
+	return &Category{value: value}, nil
+}
+
+func (c Category) String() string {
+	return c.value
+}
+
+func (c Category) Value() string {
+	return c.value
+}
+
+func (c Category) Equals(other Category) bool {
+	return c.value == other.value
+}
+
+type Action string
+
+const (
+	ActionWarning Action = "warning"
+	ActionSuspend Action = "suspend"
+	ActionBlock   Action = "block"
+)
+
+// Step is one rung of the ladder
+type Step struct {
+	Action   Action
+	Duration time.Duration // Suspension length, only for ActionSuspend
+}
+
+// Ladder is the escalation policy for one violation category
+type Ladder struct {
+	category Category
+	steps    []Step
+	lookback time.Duration // Violations older than this are forgiven, 0 keeps them forever
+}
+
+func NewLadder(category Category, steps []Step, lookback time.Duration) (*Ladder, error) {
+	if len(steps) == 0 {
+		return nil, ErrEmptyLadder
+	}
+	if lookback < 0 {
+		return nil, ErrNegativeLookback
+	}
+
+	for i, step := range steps {
+		switch step.Action {
+		case ActionWarning:
+			if step.Duration != 0 {
+				return nil, ErrUnexpectedDuration
+			}
+		case ActionSuspend:
+			if step.Duration <= 0 {
+				return nil, ErrInvalidDuration
+			}
+		case ActionBlock:
+			if step.Duration != 0 {
+				return nil, ErrUnexpectedDuration
+			}
+			if i != len(steps)-1 {
+				return nil, ErrBlockNotLast
+			}
+		default:
+			return nil, ErrInvalidAction
+		}
+	}
+
+	return &Ladder{
+		category: category,
+		steps:    append([]Step(nil), steps...),
+		lookback: lookback,
+	}, nil
+}
+
+// DefaultLadder is warning, 7-day suspension, 30-day suspension, then block,
+// counting violations from the last 180 days
+func DefaultLadder(category Category) *Ladder {
+	ladder, _ := NewLadder(category, []Step{
+		{Action: ActionWarning},
+		{Action: ActionSuspend, Duration: 7 * 24 * time.Hour},
+		{Action: ActionSuspend, Duration: 30 * 24 * time.Hour},
+		{Action: ActionBlock},
+	}, 180*24*time.Hour)
+	return ladder
+}
+
+func (l Ladder) Category() Category {
+	return l.category
+}
+
+func (l Ladder) Steps() []Step {
+	return append([]Step(nil), l.steps...)
+}
+
+func (l Ladder) Lookback() time.Duration {
+	return l.lookback
+}
+
+// StepFor returns the step for an account with the given number of prior
+// violations in the category, repeat offenders stay on the last step
+func (l Ladder) StepFor(priorViolations int) Step {
+	if priorViolations < 0 {
+		priorViolations = 0
+	}
+	if priorViolations >= len(l.steps) {
+		return l.steps[len(l.steps)-1]
+	}
+	return l.steps[priorViolations]
+}
+
+// Counts reports whether a violation recorded at the given time still counts at 'at'
+func (l Ladder) Counts(recordedAt, at time.Time) bool {
+	if l.lookback == 0 {
+		return true
+	}
+	return recordedAt.After(at.Add(-l.lookback))
+}
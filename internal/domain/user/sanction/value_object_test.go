@@ -0,0 +1,113 @@
+package sanction
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "valid", input: "spam", expected: "spam"},
+		{name: "normalized", input: "  Hate_Speech ", expected: "hate_speech"},
+		{name: "empty", input: "", wantErr: true},
+		{name: "too short", input: "a", wantErr: true},
+		{name: "leading digit", input: "1spam", wantErr: true},
+		{name: "invalid character", input: "hate-speech", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCategory(tt.input)
+			if tt.wantErr {
+				if err != ErrInvalidCategory {
+					t.Errorf("expected error '%v', got '%v'", ErrInvalidCategory, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.String() != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, c.String())
+			}
+		})
+	}
+}
+
+func TestNewLadder(t *testing.T) {
+	category := createTestCategory(t, "spam")
+	day := 24 * time.Hour
+
+	testCases := []struct {
+		name        string
+		steps       []Step
+		lookback    time.Duration
+		expectedErr error
+	}{
+		{name: "valid", steps: []Step{{Action: ActionWarning}, {Action: ActionSuspend, Duration: day}, {Action: ActionBlock}}},
+		{name: "suspend only", steps: []Step{{Action: ActionSuspend, Duration: day}}, lookback: 30 * day},
+		{name: "no steps", steps: nil, expectedErr: ErrEmptyLadder},
+		{name: "negative lookback", steps: []Step{{Action: ActionWarning}}, lookback: -day, expectedErr: ErrNegativeLookback},
+		{name: "suspend without duration", steps: []Step{{Action: ActionSuspend}}, expectedErr: ErrInvalidDuration},
+		{name: "warning with duration", steps: []Step{{Action: ActionWarning, Duration: day}}, expectedErr: ErrUnexpectedDuration},
+		{name: "block not last", steps: []Step{{Action: ActionBlock}, {Action: ActionWarning}}, expectedErr: ErrBlockNotLast},
+		{name: "unknown action", steps: []Step{{Action: "ban"}}, expectedErr: ErrInvalidAction},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewLadder(category, tc.steps, tc.lookback)
+			if err != tc.expectedErr {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestLadder_StepFor(t *testing.T) {
+	ladder := DefaultLadder(createTestCategory(t, "spam"))
+
+	expected := []Step{
+		{Action: ActionWarning},
+		{Action: ActionSuspend, Duration: 7 * 24 * time.Hour},
+		{Action: ActionSuspend, Duration: 30 * 24 * time.Hour},
+		{Action: ActionBlock},
+		{Action: ActionBlock},
+	}
+	for prior, want := range expected {
+		if got := ladder.StepFor(prior); got != want {
+			t.Errorf("prior %d: expected %+v, got %+v", prior, want, got)
+		}
+	}
+}
+
+func TestLadder_Counts(t *testing.T) {
+	category := createTestCategory(t, "spam")
+	at := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	windowed, _ := NewLadder(category, []Step{{Action: ActionWarning}}, 30*24*time.Hour)
+	if !windowed.Counts(at.Add(-29*24*time.Hour), at) {
+		t.Error("expected violation inside lookback to count")
+	}
+	if windowed.Counts(at.Add(-31*24*time.Hour), at) {
+		t.Error("expected violation outside lookback to be forgiven")
+	}
+
+	forever, _ := NewLadder(category, []Step{{Action: ActionWarning}}, 0)
+	if !forever.Counts(at.AddDate(-5, 0, 0), at) {
+		t.Error("expected zero lookback to count every violation")
+	}
+}
+
+func createTestCategory(t *testing.T, value string) Category {
+	c, err := NewCategory(value)
+	if err != nil {
+		t.Fatalf("failed to create category: %v", err)
+	}
+	return *c
+}
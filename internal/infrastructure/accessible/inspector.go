@@ -0,0 +1,73 @@
+package accessible
+
+import (
+	"html"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+)
+
+// Inspector reads the structure of a body for the accessibility checklist
+type Inspector struct{}
+
+var _ accessibility.Inspector = Inspector{}
+
+func (Inspector) Inspect(title, language, body string) accessibility.Content {
+	content := accessibility.Content{Title: title, Language: language}
+	var table *accessibility.Table
+	var link *accessibility.Link
+	inCaption := false
+
+	for _, t := range tokenize(body) {
+		if t.kind == textToken {
+			text := html.UnescapeString(t.text)
+			if link != nil {
+				link.Text += text
+			}
+			if inCaption && table != nil {
+				table.Caption += text
+			}
+			continue
+		}
+		switch t.name {
+		case "img":
+			alt, hasAlt := t.attrs["alt"]
+			content.Images = append(content.Images, accessibility.Image{
+				Src:        t.attrs["src"],
+				Alt:        alt,
+				HasAlt:     hasAlt,
+				Decorative: t.attrs["role"] == "presentation" || t.attrs["role"] == "none" || t.attrs["aria-hidden"] == "true",
+			})
+			if link != nil {
+				link.Text += alt
+			}
+		case "table":
+			if t.kind == startToken {
+				table = &accessibility.Table{}
+			} else if t.kind == endToken && table != nil {
+				table.Caption = strings.TrimSpace(table.Caption)
+				content.Tables = append(content.Tables, *table)
+				table = nil
+			}
+		case "th":
+			if t.kind == startToken && table != nil {
+				table.HeaderCells++
+			}
+		case "caption":
+			inCaption = t.kind == startToken
+		case "a":
+			if t.kind == startToken {
+				link = &accessibility.Link{Href: t.attrs["href"], Text: t.attrs["aria-label"]}
+			} else if t.kind == endToken && link != nil {
+				link.Text = strings.Join(strings.Fields(link.Text), " ")
+				content.Links = append(content.Links, *link)
+				link = nil
+			}
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			if t.kind == startToken {
+				content.Headings = append(content.Headings, int(t.name[1]-'0'))
+			}
+		}
+	}
+	return content
+}
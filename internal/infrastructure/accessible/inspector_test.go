@@ -0,0 +1,37 @@
+package accessible
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/accessibility"
+)
+
+func TestInspector_Inspect(t *testing.T) {
+	body := `<h2>Wilayah</h2><p>Lihat <a href="/data">data <b>BMKG</b></a> dan <a href="/x"><img src="icon.png" alt="Peta"></a>.</p>` +
+		`<img src="banjir.jpg"><img src="garis.png" alt="" role="presentation">` +
+		`<table><caption>Curah hujan</caption><tr><th scope="col">Kota</th><th>mm</th></tr><tr><td>Jakarta</td><td>120</td></tr></table>` +
+		`<table><tr><td>a</td></tr></table><h4>Detail</h4>`
+
+	content := (Inspector{}).Inspect("Banjir", "id", body)
+
+	expectedImages := []accessibility.Image{
+		{Src: "icon.png", Alt: "Peta", HasAlt: true},
+		{Src: "banjir.jpg"},
+		{Src: "garis.png", HasAlt: true, Decorative: true},
+	}
+	if !reflect.DeepEqual(content.Images, expectedImages) {
+		t.Errorf("expected images %+v, got %+v", expectedImages, content.Images)
+	}
+	expectedTables := []accessibility.Table{{HeaderCells: 2, Caption: "Curah hujan"}, {}}
+	if !reflect.DeepEqual(content.Tables, expectedTables) {
+		t.Errorf("expected tables %+v, got %+v", expectedTables, content.Tables)
+	}
+	expectedLinks := []accessibility.Link{{Text: "data BMKG", Href: "/data"}, {Text: "Peta", Href: "/x"}}
+	if !reflect.DeepEqual(content.Links, expectedLinks) {
+		t.Errorf("expected links %+v, got %+v", expectedLinks, content.Links)
+	}
+	if !reflect.DeepEqual(content.Headings, []int{2, 4}) || content.Language != "id" {
+		t.Errorf("unexpected headings %v or language %s", content.Headings, content.Language)
+	}
+}
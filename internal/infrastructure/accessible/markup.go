@@ -0,0 +1,175 @@
+// Package accessible renders articles for assistive technology, a simplified
+// HTML page and plain text, and inspects bodies for the accessibility
+// checklist. Bodies are the sanitized HTML the CMS stores, so a
+// small tokenizer is enough; it is not a general HTML parser.
+package accessible
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	textToken tokenKind = iota
+	startToken
+	endToken
+	selfClosingToken
+)
+
+type token struct {
+	kind  tokenKind
+	name  string
+	attrs map[string]string
+	text  string
+}
+
+var (
+	tagPattern  = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)((?:\s+[^>]*?)?)\s*(/?)>|<!--[\s\S]*?-->`)
+	attrPattern = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`)
+)
+
+var voidElements = map[string]bool{"area": true, "br": true, "col": true, "hr": true, "img": true, "source": true, "track": true, "wbr": true}
+
+func tokenize(markup string) []token {
+	var tokens []token
+	last := 0
+	for _, m := range tagPattern.FindAllStringSubmatchIndex(markup, -1) {
+		if m[0] > last {
+			tokens = append(tokens, token{kind: textToken, text: markup[last:m[0]]})
+		}
+		last = m[1]
+		if m[4] < 0 {
+			continue // Comment
+		}
+		name := strings.ToLower(markup[m[4]:m[5]])
+		switch {
+		case m[3] > m[2]:
+			tokens = append(tokens, token{kind: endToken, name: name})
+		case m[9] > m[8] || voidElements[name]:
+			tokens = append(tokens, token{kind: selfClosingToken, name: name, attrs: parseAttrs(markup[m[6]:m[7]])})
+		default:
+			tokens = append(tokens, token{kind: startToken, name: name, attrs: parseAttrs(markup[m[6]:m[7]])})
+		}
+	}
+	if last < len(markup) {
+		tokens = append(tokens, token{kind: textToken, text: markup[last:]})
+	}
+	return tokens
+}
+
+func parseAttrs(raw string) map[string]string {
+	attrs := map[string]string{}
+	for _, m := range attrPattern.FindAllStringSubmatch(raw, -1) {
+		value := m[2]
+		if value == "" {
+			value = m[3]
+		}
+		if value == "" {
+			value = m[4]
+		}
+		attrs[strings.ToLower(m[1])] = html.UnescapeString(value)
+	}
+	return attrs
+}
+
+// keptElements are the semantic elements the simplified page keeps, with the
+// attributes each may carry. Everything else is unwrapped to its content.
+var keptElements = map[string][]string{
+	"p": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"ul": nil, "ol": {"start"}, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+	"blockquote": nil, "q": nil, "cite": nil, "figure": nil, "figcaption": nil,
+	"a": {"href"}, "img": {"src", "alt"}, "br": nil, "hr": nil,
+	"em": nil, "strong": nil, "abbr": {"title"}, "code": nil, "pre": nil, "sub": nil, "sup": nil,
+	"table": nil, "caption": nil, "thead": nil, "tbody": nil, "tfoot": nil, "tr": nil,
+	"th": {"scope", "colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+}
+
+// renamed maps presentational elements to their semantic equivalent. The
+// article title is the page's only h1, so body headings start at h2.
+var renamed = map[string]string{"b": "strong", "i": "em", "h1": "h2"}
+
+// droppedElements are removed with their content: scripts, embeds and
+// anything that is not part of the story
+var droppedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "iframe": true, "object": true, "embed": true,
+	"form": true, "button": true, "input": true, "select": true, "textarea": true, "svg": true, "template": true,
+}
+
+// isAdvertising reports ad slots and sponsored inserts by their metadata
+func isAdvertising(attrs map[string]string) bool {
+	for name := range attrs {
+		if strings.HasPrefix(name, "data-ad") || strings.HasPrefix(name, "data-sponsor") {
+			return true
+		}
+	}
+	for _, class := range strings.Fields(attrs["class"]) {
+		switch {
+		case class == "ad", strings.HasPrefix(class, "ad-"), strings.HasPrefix(class, "advert"), strings.HasPrefix(class, "sponsor"):
+			return true
+		}
+	}
+	return false
+}
+
+// Simplify reduces a body to semantic markup without classes, styles, ads or
+// embeds. Images keep an alt attribute, empty when the editor gave none, so
+// screen readers skip them instead of reading the file name.
+func Simplify(body string) string {
+	var sb strings.Builder
+	skipping, depth := "", 0 // The dropped element being skipped and how deep it nests
+	for _, t := range tokenize(body) {
+		if depth > 0 {
+			switch {
+			case t.kind == startToken && t.name == skipping:
+				depth++
+			case t.kind == endToken && t.name == skipping:
+				depth--
+			}
+			continue
+		}
+		if t.kind == textToken {
+			sb.WriteString(t.text)
+			continue
+		}
+		if (droppedElements[t.name] || isAdvertising(t.attrs)) && t.kind != endToken {
+			if t.kind == startToken {
+				skipping, depth = t.name, 1
+			}
+			continue
+		}
+		name := t.name
+		if to, ok := renamed[name]; ok {
+			name = to
+		}
+		allowed, kept := keptElements[name]
+		if !kept {
+			continue
+		}
+		switch t.kind {
+		case endToken:
+			sb.WriteString("</" + name + ">")
+		default:
+			sb.WriteString("<" + name)
+			for _, attr := range allowed {
+				value, ok := t.attrs[attr]
+				if !ok && !(name == "img" && attr == "alt") {
+					continue
+				}
+				if attr == "href" && !safeURL(value) {
+					continue
+				}
+				sb.WriteString(" " + attr + `="` + html.EscapeString(value) + `"`)
+			}
+			sb.WriteString(">")
+		}
+	}
+	return sb.String()
+}
+
+func safeURL(value string) bool {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	return !strings.HasPrefix(lower, "javascript:") && !strings.HasPrefix(lower, "data:")
+}
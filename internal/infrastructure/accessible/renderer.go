@@ -0,0 +1,134 @@
+package accessible
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/export"
+)
+
+// HTMLRenderer writes a self-contained page: one h1, landmarks, no scripts
+type HTMLRenderer struct{}
+
+var _ export.Renderer = HTMLRenderer{}
+
+func (HTMLRenderer) Render(w io.Writer, a *export.Article) error {
+	lang := a.Language
+	if lang == "" {
+		lang = "id"
+	}
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"" + html.EscapeString(lang) + "\">\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	sb.WriteString("<title>" + html.EscapeString(a.Title) + "</title>\n")
+	if a.URL != "" {
+		sb.WriteString("<link rel=\"canonical\" href=\"" + html.EscapeString(a.URL) + "\">\n")
+	}
+	sb.WriteString("</head>\n<body>\n<main>\n<article>\n<header>\n<h1>" + html.EscapeString(a.Title) + "</h1>\n")
+	if a.Byline != "" {
+		sb.WriteString("<p>" + html.EscapeString(a.Byline) + "</p>\n")
+	}
+	if !a.PublishedAt.IsZero() {
+		sb.WriteString("<p><time datetime=\"" + a.PublishedAt.Format("2006-01-02T15:04:05Z07:00") + "\">" + a.PublishedAt.Format("2 January 2006") + "</time></p>\n")
+	}
+	sb.WriteString("</header>\n" + Simplify(a.Body) + "\n</article>\n</main>\n</body>\n</html>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// TextRenderer writes plain text: headings and paragraphs separated by blank
+// lines, list items numbered or dashed, images as their alt text
+type TextRenderer struct{}
+
+var _ export.Renderer = TextRenderer{}
+
+var spaces = regexp.MustCompile(`[\s\p{Zs}]+`)
+
+var blockElements = map[string]bool{
+	"p": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "ul": true, "ol": true, "dl": true,
+	"blockquote": true, "figure": true, "figcaption": true, "pre": true, "table": true, "caption": true, "hr": true,
+}
+
+func (TextRenderer) Render(w io.Writer, a *export.Article) error {
+	var sb strings.Builder
+	sb.WriteString(a.Title + "\n")
+	if a.Byline != "" {
+		sb.WriteString(a.Byline + "\n")
+	}
+	if !a.PublishedAt.IsZero() {
+		sb.WriteString(a.PublishedAt.Format("2 January 2006") + "\n")
+	}
+	if a.URL != "" {
+		sb.WriteString(a.URL + "\n")
+	}
+	sb.WriteString("\n" + toText(Simplify(a.Body)) + "\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func toText(markup string) string {
+	var line strings.Builder
+	var lines []string
+	flush := func() {
+		if text := strings.TrimSpace(line.String()); text != "" {
+			lines = append(lines, text)
+		}
+		line.Reset()
+	}
+	paragraph := func() {
+		flush()
+		if len(lines) > 0 && lines[len(lines)-1] != "" {
+			lines = append(lines, "")
+		}
+	}
+
+	var lists []int // Next number per open list, -1 for bullets
+	for _, t := range tokenize(markup) {
+		switch {
+		case t.kind == textToken:
+			line.WriteString(spaces.ReplaceAllString(html.UnescapeString(t.text), " "))
+		case t.name == "br" || t.name == "tr":
+			flush()
+		case t.name == "img":
+			if alt := strings.TrimSpace(t.attrs["alt"]); alt != "" {
+				line.WriteString(" [Image: " + alt + "] ")
+			}
+		case t.name == "ul" || t.name == "ol":
+			paragraph()
+			if t.kind == startToken {
+				next := -1
+				if t.name == "ol" {
+					next = 1
+				}
+				lists = append(lists, next)
+			} else if t.kind == endToken && len(lists) > 0 {
+				lists = lists[:len(lists)-1]
+			}
+		case t.name == "li" && t.kind == startToken:
+			flush()
+			marker := "- "
+			if n := len(lists); n > 0 && lists[n-1] > 0 {
+				marker = fmt.Sprintf("%d. ", lists[n-1])
+				lists[n-1]++
+			}
+			line.WriteString(strings.Repeat("  ", max(len(lists)-1, 0)) + marker)
+		case t.name == "li" || t.name == "td" || t.name == "th" || t.name == "dt" || t.name == "dd":
+			if t.kind == endToken {
+				line.WriteString(" ")
+			}
+			if t.name == "li" || t.name == "dd" {
+				flush()
+			}
+		case blockElements[t.name]:
+			paragraph()
+		}
+	}
+	flush()
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
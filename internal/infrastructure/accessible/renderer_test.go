@@ -0,0 +1,96 @@
+package accessible
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/export"
+)
+
+func TestSimplify(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"strips attributes", `<p class="lead" style="color:red" id="x">Teks</p>`, `<p>Teks</p>`},
+		{"unwraps layout", `<div class="row"><span>Teks</span></div>`, `Teks`},
+		{"renames presentational", `<b>tebal</b> <i>miring</i>`, `<strong>tebal</strong> <em>miring</em>`},
+		{"body h1 becomes h2", `<h1>Sub</h1>`, `<h2>Sub</h2>`},
+		{"drops ad slot", `<p>A</p><div data-ad-slot="top"><div>Iklan</div></div><p>B</p>`, `<p>A</p><p>B</p>`},
+		{"drops sponsored class", `<aside class="sponsored-box">Promo</aside><p>B</p>`, `<p>B</p>`},
+		{"drops scripts and embeds", `<script>track()</script><iframe src="x"></iframe><p>B</p>`, `<p>B</p>`},
+		{"image keeps empty alt", `<img src="a.jpg" class="wide">`, `<img src="a.jpg" alt="">`},
+		{"image keeps alt", `<img src="a.jpg" alt="Banjir &amp; longsor">`, `<img src="a.jpg" alt="Banjir &amp; longsor">`},
+		{"unsafe link", `<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"table headers", `<table><tr><th scope="col" class="h">Kota</th></tr></table>`, `<table><tr><th scope="col">Kota</th></tr></table>`},
+		{"comments removed", `<p>A<!-- ad --></p>`, `<p>A</p>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Simplify(tc.input); got != tc.expected {
+				t.Errorf("expected '%s', got '%s'", tc.expected, got)
+			}
+		})
+	}
+}
+
+func testArticle() *export.Article {
+	return &export.Article{
+		ID:          "a1",
+		Title:       "Banjir Jakarta",
+		Byline:      "Rina Wulandari",
+		URL:         "https://news.example.com/banjir-jakarta",
+		PublishedAt: time.Date(2025, time.June, 3, 6, 0, 0, 0, time.UTC),
+		Body: `<p>Hujan&nbsp;deras   sejak pagi.</p><div class="ad-slot">Iklan</div>` +
+			`<h2>Wilayah terdampak</h2><ol><li>Kampung Melayu</li><li>Bukit Duri</li></ol>` +
+			`<figure><img src="a.jpg" alt="Warga mengungsi"><figcaption>Foto: Rina</figcaption></figure>`,
+	}
+}
+
+func TestTextRenderer_Render(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (TextRenderer{}).Render(&buf, testArticle()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `Banjir Jakarta
+Rina Wulandari
+3 June 2025
+https://news.example.com/banjir-jakarta
+
+Hujan deras sejak pagi.
+
+Wilayah terdampak
+
+1. Kampung Melayu
+2. Bukit Duri
+
+[Image: Warga mengungsi]
+
+Foto: Rina
+`
+	if got := buf.String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestHTMLRenderer_Render(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (HTMLRenderer{}).Render(&buf, testArticle()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	page := buf.String()
+	for _, want := range []string{`<html lang="id">`, `<main>`, `<h1>Banjir Jakarta</h1>`, `<time datetime="2025-06-03T06:00:00Z">`, `<img src="a.jpg" alt="Warga mengungsi">`, `<link rel="canonical"`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("expected page to contain %s", want)
+		}
+	}
+	if strings.Contains(page, "Iklan") || strings.Contains(page, "class=") {
+		t.Errorf("expected ads and classes removed, got %s", page)
+	}
+}
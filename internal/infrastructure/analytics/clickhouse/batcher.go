@@ -0,0 +1,110 @@
+package clickhouse
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+)
+
+// Batching defaults, ClickHouse prefers a few large inserts per second over many small ones
+const (
+	DefaultBatchSize     = 5000
+	DefaultFlushInterval = 2 * time.Second
+
+	maxPendingBatches = 20
+
+	// Budget for the last flush on shutdown, after the run context is already cancelled
+	finalFlushTimeout = 10 * time.Second
+)
+
+// Batcher buffers events in memory and hands them to the underlying writer in batches.
+// Events buffered when the process dies are lost, which is acceptable for analytics.
+type Batcher struct {
+	writer    analytics.EventWriter
+	batchSize int
+
+	mu     sync.Mutex
+	buffer []*analytics.Event
+}
+
+func NewBatcher(writer analytics.EventWriter, batchSize int) *Batcher {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	return &Batcher{writer: writer, batchSize: batchSize}
+}
+
+var _ analytics.EventWriter = (*Batcher)(nil)
+
+// WriteEvents buffers the events and flushes once a full batch is ready
+func (b *Batcher) WriteEvents(ctx context.Context, events []*analytics.Event) error {
+	b.mu.Lock()
+	b.buffer = append(b.buffer, events...)
+	full := len(b.buffer) >= b.batchSize
+	b.mu.Unlock()
+
+	if full {
+		return b.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush writes everything buffered so far. On failure the events are put back for the next
+// flush, keeping at most maxPendingBatches batches so an outage cannot exhaust memory.
+func (b *Batcher) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	batch := b.buffer
+	b.buffer = nil
+	b.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := b.writer.WriteEvents(ctx, batch); err != nil {
+		b.mu.Lock()
+		b.buffer = append(batch, b.buffer...)
+		if limit := b.batchSize * maxPendingBatches; len(b.buffer) > limit {
+			b.buffer = b.buffer[len(b.buffer)-limit:]
+		}
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Pending returns the number of buffered events
+func (b *Batcher) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.buffer)
+}
+
+// Run flushes on every tick until the context is cancelled, then flushes once more.
+// onError receives flush failures so the caller can log them.
+func (b *Batcher) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), finalFlushTimeout)
+			err := b.Flush(flushCtx)
+			cancel()
+			if err != nil && onError != nil {
+				onError(err)
+			}
+			return
+		case <-ticker.C:
+			if err := b.Flush(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package clickhouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+)
+
+type fakeWriter struct {
+	batches [][]*analytics.Event
+	err     error
+}
+
+func (f *fakeWriter) WriteEvents(ctx context.Context, events []*analytics.Event) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.batches = append(f.batches, events)
+	return nil
+}
+
+func TestBatcher_FlushesFullBatches(t *testing.T) {
+	writer := &fakeWriter{}
+	batcher := NewBatcher(writer, 3)
+	ctx := context.Background()
+
+	_ = batcher.WriteEvents(ctx, createTestEvents(t, 2))
+	if len(writer.batches) != 0 {
+		t.Fatal("expected no flush before batch is full")
+	}
+
+	_ = batcher.WriteEvents(ctx, createTestEvents(t, 1))
+	if len(writer.batches) != 1 || len(writer.batches[0]) != 3 {
+		t.Fatalf("expected one batch of 3, got %v", writer.batches)
+	}
+	if batcher.Pending() != 0 {
+		t.Errorf("expected empty buffer, got %d", batcher.Pending())
+	}
+}
+
+func TestBatcher_RequeuesOnFailure(t *testing.T) {
+	writer := &fakeWriter{err: errors.New("clickhouse unavailable")}
+	batcher := NewBatcher(writer, 2)
+	ctx := context.Background()
+
+	if err := batcher.WriteEvents(ctx, createTestEvents(t, 2)); err == nil {
+		t.Fatal("expected write error")
+	}
+	if batcher.Pending() != 2 {
+		t.Fatalf("expected events to be kept, got %d", batcher.Pending())
+	}
+
+	// Outage longer than the buffer allows drops the oldest events
+	for i := 0; i < maxPendingBatches; i++ {
+		_ = batcher.WriteEvents(ctx, createTestEvents(t, 2))
+	}
+	if batcher.Pending() != 2*maxPendingBatches {
+		t.Errorf("expected buffer capped at %d, got %d", 2*maxPendingBatches, batcher.Pending())
+	}
+
+	writer.err = nil
+	if err := batcher.Flush(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batcher.Pending() != 0 || len(writer.batches) != 1 {
+		t.Error("expected buffered events to be written after recovery")
+	}
+}
+
+func TestBatcher_RunFlushesOnShutdown(t *testing.T) {
+	writer := &fakeWriter{}
+	batcher := NewBatcher(writer, 100)
+	_ = batcher.WriteEvents(context.Background(), createTestEvents(t, 5))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		batcher.Run(ctx, time.Hour, nil)
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	if len(writer.batches) != 1 || len(writer.batches[0]) != 5 {
+		t.Errorf("expected final flush of 5 events, got %v", writer.batches)
+	}
+}
+
+func TestSeriesQueries(t *testing.T) {
+	for _, g := range []analytics.Granularity{analytics.GranularityHour, analytics.GranularityDay} {
+		if _, ok := seriesQueries[g]; !ok {
+			t.Errorf("missing series query for %s", g)
+		}
+	}
+}
+
+func createTestEvents(t *testing.T, n int) []*analytics.Event {
+	events := make([]*analytics.Event, 0, n)
+	for i := 0; i < n; i++ {
+		e, err := analytics.NewEvent(fmt.Sprintf("e%d", i), analytics.EventView, "article-1", "author-1", "visitor:1", time.Now())
+		if err != nil {
+			t.Fatalf("failed to create event: %v", err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
@@ -0,0 +1,74 @@
+// Package clickhouse stores article analytics in ClickHouse.
+//
+// Raw events land in article_events. Materialized views roll them up into an
+// hourly per-article table (trending, per-article series) and a daily
+// per-author table (author summaries), so API queries never scan raw events.
+//
+// The store talks database/sql; the composition root registers the
+// clickhouse-go driver and opens the *sql.DB.
+package clickhouse
+
+// Migrations create the schema, each statement is idempotent
+var Migrations = []string{
+	`CREATE TABLE IF NOT EXISTS article_events (
+		event_id    UUID,
+		event_type  LowCardinality(String),
+		article_id  String,
+		author_id   String,
+		visitor_key String,
+		region      LowCardinality(String),
+		device      LowCardinality(String),
+		occurred_at DateTime64(3, 'UTC')
+	) ENGINE = MergeTree
+	PARTITION BY toYYYYMM(occurred_at)
+	ORDER BY (article_id, occurred_at)
+	TTL toDateTime(occurred_at) + INTERVAL 13 MONTH`,
+
+	`CREATE TABLE IF NOT EXISTS article_stats_hourly (
+		hour           DateTime('UTC'),
+		article_id     String,
+		author_id      String,
+		views          SimpleAggregateFunction(sum, UInt64),
+		read_completes SimpleAggregateFunction(sum, UInt64),
+		shares         SimpleAggregateFunction(sum, UInt64),
+		visitors       AggregateFunction(uniq, String)
+	) ENGINE = AggregatingMergeTree
+	PARTITION BY toYYYYMM(hour)
+	ORDER BY (hour, article_id, author_id)`,
+
+	`CREATE MATERIALIZED VIEW IF NOT EXISTS article_stats_hourly_mv TO article_stats_hourly AS
+	SELECT
+		toStartOfHour(occurred_at)              AS hour,
+		article_id,
+		author_id,
+		countIf(event_type = 'view')            AS views,
+		countIf(event_type = 'read_complete')   AS read_completes,
+		countIf(event_type = 'share')           AS shares,
+		uniqStateIf(visitor_key, event_type = 'view') AS visitors
+	FROM article_events
+	GROUP BY hour, article_id, author_id`,
+
+	`CREATE TABLE IF NOT EXISTS author_stats_daily (
+		day            Date,
+		author_id      String,
+		article_id     String,
+		views          SimpleAggregateFunction(sum, UInt64),
+		read_completes SimpleAggregateFunction(sum, UInt64),
+		shares         SimpleAggregateFunction(sum, UInt64),
+		visitors       AggregateFunction(uniq, String)
+	) ENGINE = AggregatingMergeTree
+	PARTITION BY toYYYYMM(day)
+	ORDER BY (author_id, day, article_id)`,
+
+	`CREATE MATERIALIZED VIEW IF NOT EXISTS author_stats_daily_mv TO author_stats_daily AS
+	SELECT
+		toDate(occurred_at)                     AS day,
+		author_id,
+		article_id,
+		countIf(event_type = 'view')            AS views,
+		countIf(event_type = 'read_complete')   AS read_completes,
+		countIf(event_type = 'share')           AS shares,
+		uniqStateIf(visitor_key, event_type = 'view') AS visitors
+	FROM article_events
+	GROUP BY day, author_id, article_id`,
+}
@@ -0,0 +1,170 @@
+package clickhouse
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+)
+
+const insertEvents = `INSERT INTO article_events
+	(event_id, event_type, article_id, author_id, visitor_key, region, device, occurred_at)`
+
+const trendingQuery = `SELECT article_id,
+		sum(views), uniqMerge(visitors), sum(read_completes), sum(shares)
+	FROM article_stats_hourly
+	WHERE hour >= toStartOfHour(?) AND hour < ?
+	GROUP BY article_id
+	ORDER BY sum(views) DESC, article_id
+	LIMIT ?`
+
+// Series queries keyed by granularity, the bucket expression cannot be a bind parameter
+var seriesQueries = map[analytics.Granularity]string{
+	analytics.GranularityHour: seriesQuery("hour"),
+	analytics.GranularityDay:  seriesQuery("toStartOfDay(hour)"),
+}
+
+func seriesQuery(bucket string) string {
+	return `SELECT ` + bucket + ` AS bucket,
+		sum(views), uniqMerge(visitors), sum(read_completes), sum(shares)
+	FROM article_stats_hourly
+	WHERE article_id = ? AND hour >= ? AND hour < ?
+	GROUP BY bucket
+	ORDER BY bucket`
+}
+
+const authorTotalsQuery = `SELECT uniqExact(article_id),
+		sum(views), uniqMerge(visitors), sum(read_completes), sum(shares)
+	FROM author_stats_daily
+	WHERE author_id = ? AND day >= toDate(?) AND day <= toDate(?)`
+
+const authorTopQuery = `SELECT article_id,
+		sum(views), uniqMerge(visitors), sum(read_completes), sum(shares)
+	FROM author_stats_daily
+	WHERE author_id = ? AND day >= toDate(?) AND day <= toDate(?)
+	GROUP BY article_id
+	ORDER BY sum(views) DESC, article_id
+	LIMIT ?`
+
+// Store implements analytics.Store on ClickHouse
+type Store struct {
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+var _ analytics.Store = (*Store)(nil)
+
+// Migrate applies the schema, safe to run on every start
+func (s *Store) Migrate(ctx context.Context) error {
+	for _, stmt := range Migrations {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to apply analytics migration: %w", err)
+		}
+	}
+	return nil
+}
+
+// WriteEvents sends the batch as one ClickHouse insert; the driver buffers the
+// prepared statement rows and ships them on commit
+func (s *Store) WriteEvents(ctx context.Context, events []*analytics.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin batch: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, insertEvents)
+	if err != nil {
+		return fmt.Errorf("failed to prepare batch: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, e := range events {
+		if _, err := stmt.ExecContext(ctx, e.ID, string(e.Type), e.ArticleID, e.AuthorID, e.VisitorKey, e.Region, e.Device, e.OccurredAt); err != nil {
+			return fmt.Errorf("failed to append event %s: %w", e.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to send batch: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Trending(ctx context.Context, window time.Duration, at time.Time, limit int) ([]analytics.ArticleMetric, error) {
+	rows, err := s.db.QueryContext(ctx, trendingQuery, at.Add(-window).UTC(), at.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trending: %w", err)
+	}
+	return scanArticleMetrics(rows)
+}
+
+func (s *Store) ArticleSeries(ctx context.Context, articleID string, r analytics.TimeRange, granularity analytics.Granularity) ([]analytics.Bucket, error) {
+	query, ok := seriesQueries[granularity]
+	if !ok {
+		return nil, analytics.ErrInvalidGranularity
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, articleID, r.From(), r.To())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query article series: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []analytics.Bucket
+	for rows.Next() {
+		var b analytics.Bucket
+		if err := rows.Scan(&b.Start, &b.Views, &b.UniqueVisitors, &b.ReadCompletes, &b.Shares); err != nil {
+			return nil, fmt.Errorf("failed to scan bucket: %w", err)
+		}
+		buckets = append(buckets, b)
+	}
+	return buckets, rows.Err()
+}
+
+// AuthorSummary reads the daily rollup, so the range is widened to whole UTC days
+func (s *Store) AuthorSummary(ctx context.Context, authorID string, r analytics.TimeRange, topLimit int) (*analytics.AuthorSummary, error) {
+	summary := &analytics.AuthorSummary{AuthorID: authorID}
+
+	// Daily rows are inclusive of the day the range ends in, unless it ends exactly at midnight
+	lastInstant := r.To().Add(-time.Nanosecond)
+	row := s.db.QueryRowContext(ctx, authorTotalsQuery, authorID, r.From(), lastInstant)
+	t := &summary.Totals
+	if err := row.Scan(&summary.Articles, &t.Views, &t.UniqueVisitors, &t.ReadCompletes, &t.Shares); err != nil {
+		return nil, fmt.Errorf("failed to query author totals: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, authorTopQuery, authorID, r.From(), lastInstant, topLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query author top articles: %w", err)
+	}
+	top, err := scanArticleMetrics(rows)
+	if err != nil {
+		return nil, err
+	}
+	summary.TopArticles = top
+	return summary, nil
+}
+
+func scanArticleMetrics(rows *sql.Rows) ([]analytics.ArticleMetric, error) {
+	defer rows.Close()
+
+	var metrics []analytics.ArticleMetric
+	for rows.Next() {
+		var m analytics.ArticleMetric
+		if err := rows.Scan(&m.ArticleID, &m.Views, &m.UniqueVisitors, &m.ReadCompletes, &m.Shares); err != nil {
+			return nil, fmt.Errorf("failed to scan article metric: %w", err)
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, rows.Err()
+}
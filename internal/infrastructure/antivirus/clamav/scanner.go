@@ -0,0 +1,105 @@
+package clamav
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+)
+
+// chunkSize is the INSTREAM chunk length, well below clamd's StreamMaxLength
+const chunkSize = 64 << 10
+
+// DefaultTimeout bounds one scan including the upload to clamd
+const DefaultTimeout = 2 * time.Minute
+
+var ErrUnexpectedReply = errors.New("clamd: unexpected reply")
+
+// Scanner talks to clamd over its INSTREAM protocol
+type Scanner struct {
+	network string // "tcp" or "unix"
+	address string
+	timeout time.Duration
+	dialer  net.Dialer
+}
+
+func NewScanner(network, address string) *Scanner {
+	return &Scanner{network: network, address: address, timeout: DefaultTimeout}
+}
+
+func (s *Scanner) Name() string {
+	return "clamav"
+}
+
+// Scan streams the content to clamd and parses its verdict
+func (s *Scanner) Scan(ctx context.Context, content io.Reader) (media.ScanResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	conn, err := s.dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return media.ScanResult{}, fmt.Errorf("clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return media.ScanResult{}, fmt.Errorf("clamd: %w", err)
+	}
+
+	buf := make([]byte, chunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(append(size, buf[:n]...)); err != nil {
+				return media.ScanResult{}, fmt.Errorf("clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return media.ScanResult{}, readErr
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return media.ScanResult{}, fmt.Errorf("clamd: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil && !(errors.Is(err, io.EOF) && len(reply) > 0) {
+		return media.ScanResult{}, fmt.Errorf("clamd: %w", err)
+	}
+	return s.parseReply(string(bytes.TrimRight(reply, "\x00\n")))
+}
+
+// parseReply handles "stream: OK", "stream: <signature> FOUND" and "<message> ERROR"
+func (s *Scanner) parseReply(reply string) (media.ScanResult, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case reply == "OK":
+		return media.NewCleanResult(s.Name()), nil
+	case strings.HasSuffix(reply, " FOUND"):
+		result, err := media.NewInfectedResult(s.Name(), strings.TrimSuffix(reply, " FOUND"))
+		if err != nil {
+			return media.ScanResult{}, err
+		}
+		return *result, nil
+	case strings.HasSuffix(reply, " ERROR"):
+		return media.ScanResult{}, fmt.Errorf("clamd: %s", strings.TrimSuffix(reply, " ERROR"))
+	default:
+		return media.ScanResult{}, fmt.Errorf("%w: %q", ErrUnexpectedReply, reply)
+	}
+}
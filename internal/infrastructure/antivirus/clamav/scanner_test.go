@@ -0,0 +1,106 @@
+package clamav
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeClamd accepts one INSTREAM session and replies based on the streamed content
+func fakeClamd(t *testing.T, reply func(content string) string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		command, _ := r.ReadString(0)
+		if command != "zINSTREAM\x00" {
+			conn.Write([]byte("UNKNOWN COMMAND\x00"))
+			return
+		}
+
+		var content strings.Builder
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(r, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			chunk := make([]byte, n)
+			io.ReadFull(r, chunk)
+			content.Write(chunk)
+		}
+		conn.Write([]byte(reply(content.String()) + "\x00"))
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestScanner_Scan(t *testing.T) {
+	testCases := []struct {
+		name           string
+		content        string
+		reply          string
+		expectedClean  bool
+		expectedThreat string
+		expectErr      bool
+	}{
+		{"clean", "quarterly report", "stream: OK", true, "", false},
+		{"infected", "X5O!P%@AP EICAR", "stream: Eicar-Signature FOUND", false, "Eicar-Signature", false},
+		{"size limit", strings.Repeat("a", chunkSize*2+10), "INSTREAM size limit exceeded. ERROR", false, "", true},
+		{"garbage", "report", "PONG", false, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var received string
+			addr := fakeClamd(t, func(content string) string {
+				received = content
+				return tc.reply
+			})
+
+			result, err := NewScanner("tcp", addr).Scan(context.Background(), strings.NewReader(tc.content))
+			if tc.expectErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if received != tc.content {
+				t.Errorf("expected clamd to receive %d bytes, got %d", len(tc.content), len(received))
+			}
+			if result.IsClean() != tc.expectedClean || result.Threat() != tc.expectedThreat || result.Scanner() != "clamav" {
+				t.Errorf("unexpected result clean=%v threat=%q", result.IsClean(), result.Threat())
+			}
+		})
+	}
+}
+
+func TestScanner_Unreachable(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := listener.Addr().String()
+	listener.Close()
+
+	if _, err := NewScanner("tcp", addr).Scan(context.Background(), strings.NewReader("x")); err == nil {
+		t.Error("expected error but got none")
+	}
+}
@@ -0,0 +1,211 @@
+package apple
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+)
+
+// Marker extensions Apple puts on its App Store signing certificates
+var (
+	oidLeafMarker         = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 11, 1}
+	oidIntermediateMarker = asn1.ObjectIdentifier{1, 2, 840, 113635, 100, 6, 2, 1}
+)
+
+// Subscription statuses in App Store Server Notifications V2
+const (
+	statusActive       = 1
+	statusExpired      = 2
+	statusBillingRetry = 3
+	statusGracePeriod  = 4
+	statusRevoked      = 5
+)
+
+// Verifier checks StoreKit 2 signed transactions and App Store Server
+// Notifications V2. Both are JWS signed by Apple, so no call to Apple is needed.
+type Verifier struct {
+	roots    *x509.CertPool // Apple Root CA - G3
+	bundleID string
+	now      func() time.Time
+}
+
+func NewVerifier(roots *x509.CertPool, bundleID string) *Verifier {
+	return &Verifier{roots: roots, bundleID: bundleID, now: time.Now}
+}
+
+var _ subscription.StoreVerifier = (*Verifier)(nil)
+
+type transactionPayload struct {
+	OriginalTransactionID string `json:"originalTransactionId"`
+	BundleID              string `json:"bundleId"`
+	ProductID             string `json:"productId"`
+	ExpiresDate           int64  `json:"expiresDate"`    // Unix milliseconds
+	RevocationDate        int64  `json:"revocationDate"` // Unix milliseconds, set on refund
+	AppAccountToken       string `json:"appAccountToken"`
+	SignedDate            int64  `json:"signedDate"`
+}
+
+type notificationPayload struct {
+	NotificationType string `json:"notificationType"`
+	Data             *struct {
+		BundleID              string `json:"bundleId"`
+		SignedTransactionInfo string `json:"signedTransactionInfo"`
+		Status                int    `json:"status"`
+	} `json:"data"`
+	SignedDate int64 `json:"signedDate"`
+}
+
+// VerifyPurchase takes the signed transaction the app got from StoreKit 2
+func (v *Verifier) VerifyPurchase(ctx context.Context, receipt subscription.StoreReceipt) (*subscription.StoreEvent, error) {
+	return v.transactionEvent(receipt.Token, 0)
+}
+
+// VerifyNotification takes the raw notification body, {"signedPayload": "..."}
+func (v *Verifier) VerifyNotification(ctx context.Context, payload []byte) (*subscription.StoreEvent, error) {
+	var body struct {
+		SignedPayload string `json:"signedPayload"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+	}
+
+	var n notificationPayload
+	if err := v.verify(body.SignedPayload, &n); err != nil {
+		return nil, err
+	}
+	if n.NotificationType == "TEST" || n.Data == nil || n.Data.SignedTransactionInfo == "" {
+		return nil, nil
+	}
+	if n.Data.BundleID != v.bundleID {
+		return nil, fmt.Errorf("%w: notification for bundle %q", subscription.ErrInvalidReceipt, n.Data.BundleID)
+	}
+	return v.transactionEvent(n.Data.SignedTransactionInfo, n.Data.Status)
+}
+
+// transactionEvent maps a signed transaction, using the notification status when there is one
+func (v *Verifier) transactionEvent(signed string, status int) (*subscription.StoreEvent, error) {
+	var tx transactionPayload
+	if err := v.verify(signed, &tx); err != nil {
+		return nil, err
+	}
+	if tx.BundleID != v.bundleID {
+		return nil, fmt.Errorf("%w: transaction for bundle %q", subscription.ErrInvalidReceipt, tx.BundleID)
+	}
+
+	event := &subscription.StoreEvent{
+		Channel:    subscription.ChannelAppStore,
+		ExternalID: tx.OriginalTransactionID,
+		ProductID:  tx.ProductID,
+		ExpiresAt:  time.UnixMilli(tx.ExpiresDate).UTC(),
+		OccurredAt: time.UnixMilli(tx.SignedDate).UTC(),
+	}
+	if tx.AppAccountToken != "" {
+		token := tx.AppAccountToken
+		event.AccountToken = &token
+	}
+
+	switch {
+	case tx.RevocationDate != 0 || status == statusRevoked:
+		event.State = subscription.StoreRevoked
+	case status == statusBillingRetry || status == statusGracePeriod:
+		event.State = subscription.StoreBillingRetry
+	case status == statusExpired || (status != statusActive && !event.ExpiresAt.After(v.now())):
+		event.State = subscription.StoreExpired
+	default:
+		event.State = subscription.StoreActive
+	}
+	return event, nil
+}
+
+// verify checks the JWS certificate chain up to the Apple root and the ES256 signature, then decodes the payload
+func (v *Verifier) verify(jws string, into any) error {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: malformed JWS", subscription.ErrInvalidReceipt)
+	}
+
+	var header struct {
+		Alg string   `json:"alg"`
+		X5C []string `json:"x5c"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != "ES256" || len(header.X5C) < 2 {
+		return fmt.Errorf("%w: unexpected JWS header", subscription.ErrInvalidReceipt)
+	}
+
+	chain := make([]*x509.Certificate, 0, len(header.X5C))
+	for _, raw := range header.X5C {
+		der, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil {
+			return fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+		}
+		chain = append(chain, cert)
+	}
+	leaf, intermediate := chain[0], chain[1]
+	if !hasExtension(leaf, oidLeafMarker) || !hasExtension(intermediate, oidIntermediateMarker) {
+		return fmt.Errorf("%w: not an App Store signing certificate", subscription.ErrInvalidReceipt)
+	}
+
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(intermediate)
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   v.now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+	}
+
+	key, ok := leaf.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: signing key is not ECDSA", subscription.ErrInvalidReceipt)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(sig) != 64 {
+		return fmt.Errorf("%w: malformed signature", subscription.ErrInvalidReceipt)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	if !ecdsa.Verify(key, digest[:], r, s) {
+		return fmt.Errorf("%w: signature mismatch", subscription.ErrInvalidReceipt)
+	}
+
+	return decodeSegment(parts[1], into)
+}
+
+func decodeSegment(segment string, into any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+	}
+	if err := json.Unmarshal(raw, into); err != nil {
+		return fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+	}
+	return nil
+}
+
+func hasExtension(cert *x509.Certificate, oid asn1.ObjectIdentifier) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oid) {
+			return true
+		}
+	}
+	return false
+}
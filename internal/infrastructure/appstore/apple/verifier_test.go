@@ -0,0 +1,192 @@
+package apple
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+)
+
+// testSigner plays Apple: a root, an intermediate and a leaf carrying the App Store markers
+type testSigner struct {
+	roots *x509.CertPool
+	chain []string
+	key   *ecdsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T, markLeaf bool) *testSigner {
+	t.Helper()
+	issue := func(template, parent *x509.Certificate, pub, signer any) *x509.Certificate {
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, signer)
+		if err != nil {
+			t.Fatalf("failed to create certificate: %v", err)
+		}
+		cert, _ := x509.ParseCertificate(der)
+		return cert
+	}
+	newKey := func() *ecdsa.PrivateKey {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		return key
+	}
+	marker := func(oid asn1.ObjectIdentifier) []pkix.Extension {
+		return []pkix.Extension{{Id: oid, Value: []byte{0x05, 0x00}}}
+	}
+	validity := func(serial int64, cn string, ca bool) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  ca,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		}
+	}
+
+	rootKey, intermediateKey, leafKey := newKey(), newKey(), newKey()
+	rootTemplate := validity(1, "Test Root", true)
+	root := issue(rootTemplate, rootTemplate, &rootKey.PublicKey, rootKey)
+
+	intermediateTemplate := validity(2, "Test WWDR", true)
+	intermediateTemplate.ExtraExtensions = marker(oidIntermediateMarker)
+	intermediate := issue(intermediateTemplate, root, &intermediateKey.PublicKey, rootKey)
+
+	leafTemplate := validity(3, "Test App Store Signing", false)
+	if markLeaf {
+		leafTemplate.ExtraExtensions = marker(oidLeafMarker)
+	}
+	leaf := issue(leafTemplate, intermediate, &leafKey.PublicKey, intermediateKey)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	return &testSigner{
+		roots: roots,
+		chain: []string{
+			base64.StdEncoding.EncodeToString(leaf.Raw),
+			base64.StdEncoding.EncodeToString(intermediate.Raw),
+			base64.StdEncoding.EncodeToString(root.Raw),
+		},
+		key: leafKey,
+	}
+}
+
+func (s *testSigner) sign(t *testing.T, payload any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]any{"alg": "ES256", "x5c": s.chain})
+	body, _ := json.Marshal(payload)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	r, sv, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	sv.FillBytes(sig[32:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func testTransaction(expires time.Time) transactionPayload {
+	return transactionPayload{
+		OriginalTransactionID: "2000000123",
+		BundleID:              "com.example.news",
+		ProductID:             "premium.monthly",
+		ExpiresDate:           expires.UnixMilli(),
+		AppAccountToken:       "member-1",
+		SignedDate:            time.Now().UnixMilli(),
+	}
+}
+
+func TestVerifier_VerifyPurchase(t *testing.T) {
+	signer := newTestSigner(t, true)
+	verifier := NewVerifier(signer.roots, "com.example.news")
+
+	event, err := verifier.VerifyPurchase(context.Background(), subscription.StoreReceipt{Token: signer.sign(t, testTransaction(time.Now().Add(24*time.Hour)))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.ExternalID != "2000000123" || event.State != subscription.StoreActive || *event.AccountToken != "member-1" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	expired, _ := verifier.VerifyPurchase(context.Background(), subscription.StoreReceipt{Token: signer.sign(t, testTransaction(time.Now().Add(-time.Hour)))})
+	if expired.State != subscription.StoreExpired {
+		t.Errorf("expected expired, got %s", expired.State)
+	}
+}
+
+func TestVerifier_RejectsUntrusted(t *testing.T) {
+	signer := newTestSigner(t, true)
+	otherBundle := testTransaction(time.Now().Add(time.Hour))
+	otherBundle.BundleID = "com.example.other"
+	valid := signer.sign(t, testTransaction(time.Now().Add(time.Hour)))
+
+	testCases := []struct {
+		name  string
+		roots *x509.CertPool
+		token string
+	}{
+		{"unknown root", newTestSigner(t, true).roots, valid},
+		{"leaf without App Store marker", newTestSigner(t, false).roots, newTestSigner(t, false).sign(t, testTransaction(time.Now()))},
+		{"tampered payload", signer.roots, valid[:len(valid)-4] + "AAAA"},
+		{"other bundle", signer.roots, signer.sign(t, otherBundle)},
+		{"not a JWS", signer.roots, "receipt"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewVerifier(tc.roots, "com.example.news").VerifyPurchase(context.Background(), subscription.StoreReceipt{Token: tc.token})
+			if !errors.Is(err, subscription.ErrInvalidReceipt) {
+				t.Errorf("expected error '%v', got '%v'", subscription.ErrInvalidReceipt, err)
+			}
+		})
+	}
+}
+
+func TestVerifier_VerifyNotification(t *testing.T) {
+	signer := newTestSigner(t, true)
+	verifier := NewVerifier(signer.roots, "com.example.news")
+	tx := signer.sign(t, testTransaction(time.Now().Add(time.Hour)))
+
+	testCases := []struct {
+		name     string
+		payload  map[string]any
+		expected subscription.StoreState
+	}{
+		{"billing retry", map[string]any{"notificationType": "DID_FAIL_TO_RENEW", "data": map[string]any{"bundleId": "com.example.news", "signedTransactionInfo": tx, "status": statusBillingRetry}}, subscription.StoreBillingRetry},
+		{"refund", map[string]any{"notificationType": "REFUND", "data": map[string]any{"bundleId": "com.example.news", "signedTransactionInfo": tx, "status": statusRevoked}}, subscription.StoreRevoked},
+		{"test notification", map[string]any{"notificationType": "TEST"}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"signedPayload": signer.sign(t, tc.payload)})
+			event, err := verifier.VerifyNotification(context.Background(), body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expected == "" {
+				if event != nil {
+					t.Errorf("expected no event, got %+v", event)
+				}
+				return
+			}
+			if event.State != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, event.State)
+			}
+		})
+	}
+}
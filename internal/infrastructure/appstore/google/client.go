@@ -0,0 +1,191 @@
+package google
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+)
+
+const DefaultBaseURL = "https://androidpublisher.googleapis.com"
+
+// Subscription notification type for a revoked purchase (refund or chargeback)
+const notificationRevoked = 12
+
+// TokenSource returns an OAuth access token for the Play Developer API service account
+type TokenSource func(ctx context.Context) (string, error)
+
+// Client verifies Play purchase tokens with the Google Play Developer API and
+// turns real-time developer notifications into store events. Notifications only
+// say that something changed, so the purchase is always re-read from Google.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     string
+	packageName string
+	token       TokenSource
+}
+
+func NewClient(httpClient *http.Client, baseURL, packageName string, token TokenSource) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpClient, baseURL: strings.TrimRight(baseURL, "/"), packageName: packageName, token: token}
+}
+
+var _ subscription.StoreVerifier = (*Client)(nil)
+
+// subscriptionPurchase is the part of SubscriptionPurchaseV2 we use
+type subscriptionPurchase struct {
+	SubscriptionState   string `json:"subscriptionState"`
+	LinkedPurchaseToken string `json:"linkedPurchaseToken"`
+	LineItems           []struct {
+		ProductID  string `json:"productId"`
+		ExpiryTime string `json:"expiryTime"`
+	} `json:"lineItems"`
+	ExternalAccountIdentifiers *struct {
+		ObfuscatedExternalAccountID string `json:"obfuscatedExternalAccountId"`
+	} `json:"externalAccountIdentifiers"`
+}
+
+// pushEnvelope is the Pub/Sub push request wrapping a developer notification
+type pushEnvelope struct {
+	Message struct {
+		Data string `json:"data"` // Base64 encoded developerNotification
+	} `json:"message"`
+}
+
+type developerNotification struct {
+	PackageName              string `json:"packageName"`
+	EventTimeMillis          string `json:"eventTimeMillis"`
+	SubscriptionNotification *struct {
+		NotificationType int    `json:"notificationType"`
+		PurchaseToken    string `json:"purchaseToken"`
+	} `json:"subscriptionNotification"`
+	TestNotification *struct{} `json:"testNotification"`
+}
+
+// VerifyPurchase takes the purchase token the app got from Play Billing
+func (c *Client) VerifyPurchase(ctx context.Context, receipt subscription.StoreReceipt) (*subscription.StoreEvent, error) {
+	if strings.TrimSpace(receipt.Token) == "" {
+		return nil, fmt.Errorf("%w: empty purchase token", subscription.ErrInvalidReceipt)
+	}
+	return c.purchaseEvent(ctx, receipt.Token)
+}
+
+// VerifyNotification takes the Pub/Sub push body of a real-time developer notification
+func (c *Client) VerifyNotification(ctx context.Context, payload []byte) (*subscription.StoreEvent, error) {
+	var envelope pushEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+	}
+	data, err := base64.StdEncoding.DecodeString(envelope.Message.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+	}
+	var n developerNotification
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+	}
+	if n.TestNotification != nil || n.SubscriptionNotification == nil {
+		return nil, nil
+	}
+	if n.PackageName != c.packageName {
+		return nil, fmt.Errorf("%w: notification for package %q", subscription.ErrInvalidReceipt, n.PackageName)
+	}
+
+	// The notification body is unauthenticated, reading the purchase from Google is what makes it trustworthy
+	event, err := c.purchaseEvent(ctx, n.SubscriptionNotification.PurchaseToken)
+	if err != nil {
+		return nil, err
+	}
+	if n.SubscriptionNotification.NotificationType == notificationRevoked {
+		event.State = subscription.StoreRevoked
+	}
+	return event, nil
+}
+
+func (c *Client) purchaseEvent(ctx context.Context, purchaseToken string) (*subscription.StoreEvent, error) {
+	purchase, err := c.getPurchase(ctx, purchaseToken)
+	if err != nil {
+		return nil, err
+	}
+	if len(purchase.LineItems) == 0 {
+		return nil, fmt.Errorf("%w: purchase has no line items", subscription.ErrInvalidReceipt)
+	}
+
+	event := &subscription.StoreEvent{
+		Channel:    subscription.ChannelPlayStore,
+		ExternalID: purchaseToken,
+		ProductID:  purchase.LineItems[0].ProductID,
+		OccurredAt: time.Now().UTC(),
+	}
+	if event.ExpiresAt, err = time.Parse(time.RFC3339Nano, purchase.LineItems[0].ExpiryTime); err != nil {
+		return nil, fmt.Errorf("%w: %v", subscription.ErrInvalidReceipt, err)
+	}
+	if purchase.LinkedPurchaseToken != "" {
+		linked := purchase.LinkedPurchaseToken
+		event.PreviousExternalID = &linked
+	}
+	if ids := purchase.ExternalAccountIdentifiers; ids != nil && ids.ObfuscatedExternalAccountID != "" {
+		token := ids.ObfuscatedExternalAccountID
+		event.AccountToken = &token
+	}
+
+	switch purchase.SubscriptionState {
+	case "SUBSCRIPTION_STATE_ACTIVE", "SUBSCRIPTION_STATE_CANCELED":
+		// Canceled only turns auto-renew off, access runs until expiry
+		event.State = subscription.StoreActive
+	case "SUBSCRIPTION_STATE_IN_GRACE_PERIOD":
+		event.State = subscription.StoreBillingRetry
+	case "SUBSCRIPTION_STATE_ON_HOLD", "SUBSCRIPTION_STATE_PAUSED", "SUBSCRIPTION_STATE_EXPIRED":
+		event.State = subscription.StoreExpired
+	case "SUBSCRIPTION_STATE_PENDING":
+		return nil, subscription.ErrPurchasePending
+	default:
+		return nil, fmt.Errorf("%w: %q", subscription.ErrInvalidStoreState, purchase.SubscriptionState)
+	}
+	return event, nil
+}
+
+func (c *Client) getPurchase(ctx context.Context, purchaseToken string) (*subscriptionPurchase, error) {
+	accessToken, err := c.token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/androidpublisher/v3/applications/%s/purchases/subscriptionsv2/tokens/%s",
+		c.baseURL, url.PathEscape(c.packageName), url.PathEscape(purchaseToken))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone || resp.StatusCode == http.StatusBadRequest:
+		return nil, fmt.Errorf("%w: Play returned %d", subscription.ErrInvalidReceipt, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to get purchase: Play returned %d", resp.StatusCode)
+	}
+
+	var purchase subscriptionPurchase
+	if err := json.NewDecoder(resp.Body).Decode(&purchase); err != nil {
+		return nil, fmt.Errorf("failed to decode purchase: %w", err)
+	}
+	return &purchase, nil
+}
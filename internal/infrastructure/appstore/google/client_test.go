@@ -0,0 +1,110 @@
+package google
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/subscription"
+)
+
+func newTestClient(t *testing.T, state string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/androidpublisher/v3/applications/com.example.news/purchases/subscriptionsv2/tokens/token-2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"subscriptionState":          state,
+			"linkedPurchaseToken":        "token-1",
+			"lineItems":                  []map[string]string{{"productId": "premium.monthly", "expiryTime": "2025-07-01T10:00:00.123Z"}},
+			"externalAccountIdentifiers": map[string]string{"obfuscatedExternalAccountId": "member-1"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	token := func(ctx context.Context) (string, error) { return "access-token", nil }
+	return NewClient(server.Client(), server.URL, "com.example.news", token)
+}
+
+func pushBody(t *testing.T, notification map[string]any) []byte {
+	t.Helper()
+	data, _ := json.Marshal(notification)
+	body, _ := json.Marshal(map[string]any{"message": map[string]string{"data": base64.StdEncoding.EncodeToString(data)}})
+	return body
+}
+
+func TestClient_VerifyPurchase(t *testing.T) {
+	testCases := []struct {
+		name          string
+		state         string
+		token         string
+		expected      subscription.StoreState
+		expectedError error
+	}{
+		{"active", "SUBSCRIPTION_STATE_ACTIVE", "token-2", subscription.StoreActive, nil},
+		{"auto-renew off", "SUBSCRIPTION_STATE_CANCELED", "token-2", subscription.StoreActive, nil},
+		{"grace period", "SUBSCRIPTION_STATE_IN_GRACE_PERIOD", "token-2", subscription.StoreBillingRetry, nil},
+		{"on hold", "SUBSCRIPTION_STATE_ON_HOLD", "token-2", subscription.StoreExpired, nil},
+		{"pending", "SUBSCRIPTION_STATE_PENDING", "token-2", "", subscription.ErrPurchasePending},
+		{"unknown token", "SUBSCRIPTION_STATE_ACTIVE", "forged", "", subscription.ErrInvalidReceipt},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := newTestClient(t, tc.state).VerifyPurchase(context.Background(), subscription.StoreReceipt{Token: tc.token})
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.State != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, event.State)
+			}
+			if event.ProductID != "premium.monthly" || *event.PreviousExternalID != "token-1" || *event.AccountToken != "member-1" {
+				t.Errorf("unexpected event %+v", event)
+			}
+		})
+	}
+}
+
+func TestClient_VerifyNotification(t *testing.T) {
+	client := newTestClient(t, "SUBSCRIPTION_STATE_ACTIVE")
+
+	event, err := client.VerifyNotification(context.Background(), pushBody(t, map[string]any{
+		"packageName":              "com.example.news",
+		"subscriptionNotification": map[string]any{"notificationType": notificationRevoked, "purchaseToken": "token-2"},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.State != subscription.StoreRevoked || event.ExternalID != "token-2" {
+		t.Errorf("expected revoked token-2, got %+v", event)
+	}
+
+	event, err = client.VerifyNotification(context.Background(), pushBody(t, map[string]any{"packageName": "com.example.news", "testNotification": map[string]any{}}))
+	if err != nil || event != nil {
+		t.Errorf("expected test notification to be ignored, got %+v, %v", event, err)
+	}
+
+	_, err = client.VerifyNotification(context.Background(), pushBody(t, map[string]any{
+		"packageName":              "com.example.other",
+		"subscriptionNotification": map[string]any{"notificationType": 2, "purchaseToken": "token-2"},
+	}))
+	if !errors.Is(err, subscription.ErrInvalidReceipt) {
+		t.Errorf("expected error '%v', got '%v'", subscription.ErrInvalidReceipt, err)
+	}
+}
@@ -0,0 +1,76 @@
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+)
+
+var ErrAnchorNotFound = errors.New("anchor not found")
+
+// FileAnchorStore writes anchors to a directory that should live outside the
+// database host, e.g. a bucket with object lock mounted read-write-once.
+// Files are created exclusively and read-only, an existing anchor is never replaced.
+type FileAnchorStore struct {
+	root string
+}
+
+func NewFileAnchorStore(root string) *FileAnchorStore {
+	return &FileAnchorStore{root: root}
+}
+
+var _ audit.AnchorStore = (*FileAnchorStore)(nil)
+
+type anchorFile struct {
+	Seq        uint64 `json:"seq"`
+	Hash       string `json:"hash"`
+	AnchoredAt string `json:"anchored_at"`
+}
+
+func (s *FileAnchorStore) Put(ctx context.Context, anchor *audit.Anchor) (string, error) {
+	if err := os.MkdirAll(s.root, 0o700); err != nil {
+		return "", err
+	}
+	key := fmt.Sprintf("%020d.json", anchor.Seq)
+	f, err := os.OpenFile(filepath.Join(s.root, key), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o444)
+	if err != nil {
+		return "", err
+	}
+	err = json.NewEncoder(f).Encode(anchorFile{Seq: anchor.Seq, Hash: anchor.Hash, AnchoredAt: anchor.AnchoredAt.UTC().Format(timeLayout)})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (s *FileAnchorStore) Get(ctx context.Context, location string) (*audit.Anchor, error) {
+	if location == "" || strings.ContainsAny(location, `/\`) {
+		return nil, ErrAnchorNotFound
+	}
+	raw, err := os.ReadFile(filepath.Join(s.root, location))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrAnchorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var file anchorFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to decode anchor %s: %w", location, err)
+	}
+	anchor := &audit.Anchor{Seq: file.Seq, Hash: file.Hash, Location: location}
+	if anchor.AnchoredAt, err = parseTime(file.AnchoredAt); err != nil {
+		return nil, fmt.Errorf("failed to decode anchor %s: %w", location, err)
+	}
+	return anchor, nil
+}
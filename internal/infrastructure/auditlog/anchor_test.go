@@ -0,0 +1,35 @@
+package auditlog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+)
+
+func TestFileAnchorStore(t *testing.T) {
+	store := NewFileAnchorStore(t.TempDir())
+	ctx := context.Background()
+	anchor := &audit.Anchor{Seq: 42, Hash: audit.GenesisHash, AnchoredAt: time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)}
+
+	location, err := store.Put(ctx, anchor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := store.Get(ctx, location)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Seq != 42 || got.Hash != anchor.Hash || !got.AnchoredAt.Equal(anchor.AnchoredAt) || got.Location != location {
+		t.Errorf("unexpected anchor %+v", got)
+	}
+
+	if _, err := store.Put(ctx, &audit.Anchor{Seq: 42, Hash: "forged", AnchoredAt: anchor.AnchoredAt}); err == nil {
+		t.Error("expected an existing anchor never to be replaced")
+	}
+	if _, err := store.Get(ctx, "../"+location); !errors.Is(err, ErrAnchorNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrAnchorNotFound, err)
+	}
+}
@@ -0,0 +1,248 @@
+package auditlog
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+)
+
+// BundleFormat identifies the layout below, bump it when the layout changes
+const BundleFormat = "news-portal-cms/audit-bundle/v1"
+
+// Files in a bundle. The manifest lists the SHA-256 of every other file and
+// manifest.sig is the Ed25519 signature over the manifest's exact bytes, so a
+// recipient with the public key can check the bundle with standard tools.
+const (
+	entriesFile   = "entries.jsonl"
+	anchorsFile   = "anchors.json"
+	manifestFile  = "manifest.json"
+	signatureFile = "manifest.sig"
+)
+
+var ErrInvalidBundle = errors.New("invalid audit bundle")
+
+const timeLayout = "2006-01-02T15:04:05.000000Z"
+
+// Manifest describes a bundle
+type Manifest struct {
+	Format       string            `json:"format"`
+	CreatedAt    string            `json:"created_at"`
+	FromSeq      uint64            `json:"from_seq"`
+	ToSeq        uint64            `json:"to_seq"`
+	PreviousHash string            `json:"previous_hash"` // Hash of entry from_seq-1, the genesis hash when from_seq is 1
+	HeadHash     string            `json:"head_hash"`
+	PublicKey    string            `json:"public_key"` // Base64, for identification only: verify against a key obtained out of band
+	Files        map[string]string `json:"files"`
+}
+
+type entryRecord struct {
+	Seq        uint64            `json:"seq"`
+	ID         string            `json:"id"`
+	ActorID    string            `json:"actor_id"`
+	Action     string            `json:"action"`
+	TargetType string            `json:"target_type"`
+	TargetID   string            `json:"target_id"`
+	IP         string            `json:"ip"`
+	Details    map[string]string `json:"details"`
+	Changes    []changeRecord    `json:"changes,omitempty"`
+	At         string            `json:"at"`
+	PrevHash   string            `json:"prev_hash"`
+	Hash       string            `json:"hash"`
+}
+
+type changeRecord struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type anchorRecord struct {
+	Seq        uint64 `json:"seq"`
+	Hash       string `json:"hash"`
+	Location   string `json:"location"`
+	AnchoredAt string `json:"anchored_at"`
+}
+
+func toChangeRecords(changes []audit.Change) []changeRecord {
+	var records []changeRecord
+	for _, c := range changes {
+		records = append(records, changeRecord{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return records
+}
+
+func fromChangeRecords(records []changeRecord) []audit.Change {
+	var changes []audit.Change
+	for _, r := range records {
+		changes = append(changes, audit.Change{Field: r.Field, Before: r.Before, After: r.After})
+	}
+	return changes
+}
+
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(timeLayout, s)
+}
+
+// WriteBundle writes a signed zip of a verified export
+func WriteBundle(w io.Writer, bundle *app.Bundle, key ed25519.PrivateKey, at time.Time) (*Manifest, error) {
+	if len(bundle.Entries) == 0 {
+		return nil, fmt.Errorf("%w: no entries", ErrInvalidBundle)
+	}
+
+	var entries bytes.Buffer
+	encoder := json.NewEncoder(&entries)
+	for _, e := range bundle.Entries {
+		if err := encoder.Encode(entryRecord{
+			Seq: e.Seq, ID: e.ID, ActorID: e.ActorID, Action: e.Action, TargetType: e.TargetType, TargetID: e.TargetID,
+			IP: e.IP, Details: e.Details, Changes: toChangeRecords(e.Changes), At: e.At.UTC().Format(timeLayout), PrevHash: e.PrevHash, Hash: e.Hash,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	anchorRecords := make([]anchorRecord, 0, len(bundle.Anchors))
+	for _, a := range bundle.Anchors {
+		anchorRecords = append(anchorRecords, anchorRecord{Seq: a.Seq, Hash: a.Hash, Location: a.Location, AnchoredAt: a.AnchoredAt.UTC().Format(timeLayout)})
+	}
+	anchors, err := json.MarshalIndent(anchorRecords, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := bundle.Entries[0], bundle.Entries[len(bundle.Entries)-1]
+	manifest := &Manifest{
+		Format:       BundleFormat,
+		CreatedAt:    at.UTC().Format(timeLayout),
+		FromSeq:      first.Seq,
+		ToSeq:        last.Seq,
+		PreviousHash: first.PrevHash,
+		HeadHash:     last.Hash,
+		PublicKey:    base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Files:        map[string]string{entriesFile: sha256Hex(entries.Bytes()), anchorsFile: sha256Hex(anchors)},
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, manifestBytes))
+
+	archive := zip.NewWriter(w)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{entriesFile, entries.Bytes()},
+		{anchorsFile, anchors},
+		{manifestFile, manifestBytes},
+		{signatureFile, []byte(signature + "\n")},
+	} {
+		f, err := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: at.UTC()})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write(file.data); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, archive.Close()
+}
+
+// VerifyBundle checks the signature, the file hashes and the chain inside a
+// bundle. The chain check starts from the manifest's previous hash, which the
+// recipient can compare with the head hash of the bundle before it.
+func VerifyBundle(r io.ReaderAt, size int64, publicKey ed25519.PublicKey) (*Manifest, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	files := map[string][]byte{}
+	for _, f := range archive.File {
+		if _, ok := files[f.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate file %s", ErrInvalidBundle, f.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+		files[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(files[signatureFile])))
+	if err != nil || !ed25519.Verify(publicKey, files[manifestFile], signature) {
+		return nil, fmt.Errorf("%w: signature does not match", ErrInvalidBundle)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(files[manifestFile], &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	if manifest.Format != BundleFormat {
+		return nil, fmt.Errorf("%w: unknown format %q", ErrInvalidBundle, manifest.Format)
+	}
+	for _, name := range []string{entriesFile, anchorsFile} {
+		if data, ok := files[name]; !ok || manifest.Files[name] != sha256Hex(data) {
+			return nil, fmt.Errorf("%w: %s does not match the manifest", ErrInvalidBundle, name)
+		}
+	}
+
+	var anchorRecords []anchorRecord
+	if err := json.Unmarshal(files[anchorsFile], &anchorRecords); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	anchors := make([]audit.Anchor, 0, len(anchorRecords))
+	for _, a := range anchorRecords {
+		anchors = append(anchors, audit.Anchor{Seq: a.Seq, Hash: a.Hash})
+	}
+
+	var previous *audit.Entry
+	if manifest.FromSeq > 1 {
+		previous = &audit.Entry{Seq: manifest.FromSeq - 1, Hash: manifest.PreviousHash}
+	} else if manifest.PreviousHash != audit.GenesisHash {
+		return nil, fmt.Errorf("%w: first entry must follow the genesis hash", ErrInvalidBundle)
+	}
+	verifier := audit.NewVerifierAfter(previous, anchors)
+	decoder := json.NewDecoder(bytes.NewReader(files[entriesFile]))
+	var last *audit.Entry
+	for decoder.More() {
+		var record entryRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+		at, err := parseTime(record.At)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+		last = &audit.Entry{
+			Seq: record.Seq, ID: record.ID, ActorID: record.ActorID, Action: record.Action, TargetType: record.TargetType,
+			TargetID: record.TargetID, IP: record.IP, Details: record.Details, Changes: fromChangeRecords(record.Changes), At: at, PrevHash: record.PrevHash, Hash: record.Hash,
+		}
+		if err := verifier.Check(last); err != nil {
+			return nil, err
+		}
+	}
+	if err := verifier.Finish(); err != nil {
+		return nil, err
+	}
+	if last == nil || last.Seq != manifest.ToSeq || last.Hash != manifest.HeadHash {
+		return nil, fmt.Errorf("%w: entries do not end at the manifest's head", ErrInvalidBundle)
+	}
+	return &manifest, nil
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,188 @@
+package auditlog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/audit"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/postgres"
+)
+
+// Schema creates the tables. The application role should only be granted
+// INSERT and SELECT on them; the chain makes changes detectable, the grants
+// make them harder.
+const Schema = `
+CREATE TABLE IF NOT EXISTS audit_entries (
+	seq         BIGINT PRIMARY KEY,
+	id          UUID NOT NULL UNIQUE,
+	actor_id    TEXT NOT NULL,
+	action      TEXT NOT NULL,
+	target_type TEXT NOT NULL,
+	target_id   TEXT NOT NULL,
+	ip          TEXT NOT NULL,
+	details     JSONB NOT NULL,
+	changes     JSONB NOT NULL DEFAULT '[]',
+	at          TIMESTAMPTZ NOT NULL,
+	prev_hash   TEXT NOT NULL,
+	hash        TEXT NOT NULL
+);
+ALTER TABLE audit_entries ADD COLUMN IF NOT EXISTS changes JSONB NOT NULL DEFAULT '[]';
+CREATE INDEX IF NOT EXISTS audit_entries_target ON audit_entries (target_type, target_id, seq DESC);
+CREATE INDEX IF NOT EXISTS audit_entries_actor ON audit_entries (actor_id, seq DESC);
+CREATE TABLE IF NOT EXISTS audit_anchors (
+	seq         BIGINT PRIMARY KEY,
+	hash        TEXT NOT NULL,
+	location    TEXT NOT NULL,
+	anchored_at TIMESTAMPTZ NOT NULL
+);`
+
+// SQLRepository stores the chain in PostgreSQL through database/sql, the driver is registered by the caller.
+// Statements join the transaction in ctx, so an entry commits or rolls back with the change it records.
+type SQLRepository struct {
+	db *sql.DB
+}
+
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+var (
+	_ audit.EntryRepository  = (*SQLRepository)(nil)
+	_ audit.AnchorRepository = (*SQLRepository)(nil)
+)
+
+const entryColumns = "seq, id, actor_id, action, target_type, target_id, ip, details, changes, at, prev_hash, hash"
+
+// changeRow is how a change is kept in the changes column
+type changeRow struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Append relies on the primary key: of two writers with the same head only one insert lands
+func (r *SQLRepository) Append(ctx context.Context, e *audit.Entry) error {
+	details, err := json.Marshal(e.Details)
+	if err != nil {
+		return err
+	}
+	rows := make([]changeRow, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		rows = append(rows, changeRow{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	changes, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+	result, err := postgres.Conn(ctx, r.db).ExecContext(ctx,
+		"INSERT INTO audit_entries ("+entryColumns+") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) ON CONFLICT (seq) DO NOTHING",
+		e.Seq, e.ID, e.ActorID, e.Action, e.TargetType, e.TargetID, e.IP, details, changes, e.At, e.PrevHash, e.Hash)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return audit.ErrSequenceTaken
+	}
+	return nil
+}
+
+func (r *SQLRepository) Head(ctx context.Context) (*audit.Entry, error) {
+	return r.findOne(ctx, "SELECT "+entryColumns+" FROM audit_entries ORDER BY seq DESC LIMIT 1")
+}
+
+func (r *SQLRepository) FindBySeq(ctx context.Context, seq uint64) (*audit.Entry, error) {
+	return r.findOne(ctx, "SELECT "+entryColumns+" FROM audit_entries WHERE seq = $1", seq)
+}
+
+func (r *SQLRepository) Range(ctx context.Context, fromSeq uint64, limit int) ([]*audit.Entry, error) {
+	return r.findMany(ctx, "SELECT "+entryColumns+" FROM audit_entries WHERE seq >= $1 ORDER BY seq LIMIT $2", fromSeq, limit)
+}
+
+func (r *SQLRepository) FindByTarget(ctx context.Context, targetType, targetID string, beforeSeq uint64, limit int) ([]*audit.Entry, error) {
+	return r.findMany(ctx, "SELECT "+entryColumns+" FROM audit_entries WHERE target_type = $1 AND target_id = $2 AND ($3 = 0 OR seq < $3) ORDER BY seq DESC LIMIT $4",
+		targetType, targetID, beforeSeq, limit)
+}
+
+func (r *SQLRepository) FindByActor(ctx context.Context, actorID string, beforeSeq uint64, limit int) ([]*audit.Entry, error) {
+	return r.findMany(ctx, "SELECT "+entryColumns+" FROM audit_entries WHERE actor_id = $1 AND ($2 = 0 OR seq < $2) ORDER BY seq DESC LIMIT $3",
+		actorID, beforeSeq, limit)
+}
+
+func (r *SQLRepository) findMany(ctx context.Context, query string, args ...any) ([]*audit.Entry, error) {
+	rows, err := postgres.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*audit.Entry
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *SQLRepository) findOne(ctx context.Context, query string, args ...any) (*audit.Entry, error) {
+	e, err := scanEntry(postgres.Conn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return e, err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanEntry(row scanner) (*audit.Entry, error) {
+	var e audit.Entry
+	var details, changes []byte
+	if err := row.Scan(&e.Seq, &e.ID, &e.ActorID, &e.Action, &e.TargetType, &e.TargetID, &e.IP, &details, &changes, &e.At, &e.PrevHash, &e.Hash); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(details, &e.Details); err != nil {
+		return nil, fmt.Errorf("failed to decode details of entry %d: %w", e.Seq, err)
+	}
+	var rows []changeRow
+	if err := json.Unmarshal(changes, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode changes of entry %d: %w", e.Seq, err)
+	}
+	for _, c := range rows {
+		e.Changes = append(e.Changes, audit.Change{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	e.At = e.At.UTC()
+	return &e, nil
+}
+
+func (r *SQLRepository) Save(ctx context.Context, a *audit.Anchor) error {
+	_, err := postgres.Conn(ctx, r.db).ExecContext(ctx, "INSERT INTO audit_anchors (seq, hash, location, anchored_at) VALUES ($1, $2, $3, $4)",
+		a.Seq, a.Hash, a.Location, a.AnchoredAt)
+	return err
+}
+
+func (r *SQLRepository) FindAll(ctx context.Context) ([]*audit.Anchor, error) {
+	rows, err := postgres.Conn(ctx, r.db).QueryContext(ctx, "SELECT seq, hash, location, anchored_at FROM audit_anchors ORDER BY seq")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var anchors []*audit.Anchor
+	for rows.Next() {
+		var a audit.Anchor
+		if err := rows.Scan(&a.Seq, &a.Hash, &a.Location, &a.AnchoredAt); err != nil {
+			return nil, err
+		}
+		anchors = append(anchors, &a)
+	}
+	return anchors, rows.Err()
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultLeeway absorbs clock skew between the issuing and verifying hosts
+const DefaultLeeway = 30 * time.Second
+
+var (
+	ErrInvalidToken = errors.New("invalid access token")
+	ErrTokenExpired = errors.New("access token has expired")
+)
+
+// Claims is what an access token says about the signed-in account
+type Claims struct {
+	TokenID     string
+	Issuer      string
+	Audience    string
+	AccountID   string
+	SessionID   string
+	AccountType string
+	Roles       []string // Role names
+	Verified    bool
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+}
+
+func (c *Claims) HasRole(name string) bool {
+	return slices.Contains(c.Roles, name)
+}
+
+type header struct {
+	Algorithm Algorithm `json:"alg"`
+	KeyID     string    `json:"kid"`
+	Type      string    `json:"typ"`
+}
+
+type payload struct {
+	ID          string   `json:"jti"`
+	Issuer      string   `json:"iss"`
+	Audience    string   `json:"aud"`
+	Subject     string   `json:"sub"`
+	SessionID   string   `json:"sid"`
+	AccountType string   `json:"account_type"`
+	Roles       []string `json:"roles"`
+	Verified    bool     `json:"verified"`
+	IssuedAt    int64    `json:"iat"`
+	NotBefore   int64    `json:"nbf"`
+	ExpiresAt   int64    `json:"exp"`
+}
+
+// encode signs claims with the active key as a compact JWS
+func encode(keys *KeySet, c Claims) (string, error) {
+	key := keys.active
+	if !key.CanSign() {
+		return "", ErrCannotSign
+	}
+	h, err := json.Marshal(header{Algorithm: key.Algorithm, KeyID: key.ID, Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	p, err := json.Marshal(payload{
+		ID:          c.TokenID,
+		Issuer:      c.Issuer,
+		Audience:    c.Audience,
+		Subject:     c.AccountID,
+		SessionID:   c.SessionID,
+		AccountType: c.AccountType,
+		Roles:       c.Roles,
+		Verified:    c.Verified,
+		IssuedAt:    c.IssuedAt.Unix(),
+		NotBefore:   c.IssuedAt.Unix(),
+		ExpiresAt:   c.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(p)
+	signature, err := key.sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// decode checks the signature against the key named in the header. The key's
+// own algorithm must match the header, so an RS256 public key can never be
+// used as an HS256 secret.
+func decode(keys *KeySet, token string) (*payload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+	key, err := keys.get(h.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if h.Algorithm != key.Algorithm {
+		return nil, fmt.Errorf("%w: algorithm %q does not match key %s", ErrInvalidToken, h.Algorithm, key.ID)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return nil, ErrInvalidToken
+	}
+
+	var p payload
+	if err := decodeSegment(parts[1], &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrInvalidToken
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if err := dec.Decode(v); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// TokenVerifier checks access tokens without any storage lookup, which is
+// what request middleware needs. A revoked session keeps working until its
+// access token expires, so access tokens are short-lived.
+type TokenVerifier struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+	leeway   time.Duration
+}
+
+func NewTokenVerifier(keys *KeySet, issuer, audience string) *TokenVerifier {
+	return &TokenVerifier{keys: keys, issuer: issuer, audience: audience, leeway: DefaultLeeway}
+}
+
+func (v *TokenVerifier) Verify(token string, at time.Time) (*Claims, error) {
+	p, err := decode(v.keys, token)
+	if err != nil {
+		return nil, err
+	}
+	if p.Issuer != v.issuer || p.Audience != v.audience || p.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	if at.After(time.Unix(p.ExpiresAt, 0).Add(v.leeway)) {
+		return nil, ErrTokenExpired
+	}
+	if at.Add(v.leeway).Before(time.Unix(p.NotBefore, 0)) {
+		return nil, ErrInvalidToken
+	}
+
+	return &Claims{
+		TokenID:     p.ID,
+		Issuer:      p.Issuer,
+		Audience:    p.Audience,
+		AccountID:   p.Subject,
+		SessionID:   p.SessionID,
+		AccountType: p.AccountType,
+		Roles:       p.Roles,
+		Verified:    p.Verified,
+		IssuedAt:    time.Unix(p.IssuedAt, 0),
+		ExpiresAt:   time.Unix(p.ExpiresAt, 0),
+	}, nil
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testHMACKey(t *testing.T, id string) Key {
+	t.Helper()
+	key, err := NewHMACKey(id, []byte(strings.Repeat(id, 32)[:32]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key
+}
+
+func testClaims(at time.Time) Claims {
+	return Claims{
+		TokenID:     "t1",
+		Issuer:      "news-portal",
+		Audience:    "cms",
+		AccountID:   "a1",
+		SessionID:   "s1",
+		AccountType: "internal",
+		Roles:       []string{"editor"},
+		Verified:    true,
+		IssuedAt:    at,
+		ExpiresAt:   at.Add(DefaultAccessTTL),
+	}
+}
+
+func TestTokenVerifier_HS256(t *testing.T) {
+	at := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	keys, _ := NewKeySet(testHMACKey(t, "k1"))
+	token, err := encode(keys, testClaims(at))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifier := NewTokenVerifier(keys, "news-portal", "cms")
+
+	tests := []struct {
+		name    string
+		token   string
+		at      time.Time
+		wantErr error
+	}{
+		{"valid", token, at.Add(time.Minute), nil},
+		{"inside leeway", token, at.Add(DefaultAccessTTL + DefaultLeeway), nil},
+		{"expired", token, at.Add(DefaultAccessTTL + DefaultLeeway + time.Second), ErrTokenExpired},
+		{"not yet valid", token, at.Add(-time.Hour), ErrInvalidToken},
+		{"tampered", token[:len(token)-2] + "xx", at, ErrInvalidToken},
+		{"garbage", "not-a-token", at, ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tt.token, tt.at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error '%v', got '%v'", tt.wantErr, err)
+			}
+			if err == nil && (claims.AccountID != "a1" || claims.SessionID != "s1" || !claims.HasRole("editor") || !claims.Verified) {
+				t.Errorf("unexpected claims %+v", claims)
+			}
+		})
+	}
+
+	if _, err := NewTokenVerifier(keys, "news-portal", "mobile").Verify(token, at); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected another audience rejected, got '%v'", err)
+	}
+	if _, err := NewHMACKey("short", []byte("secret")); !errors.Is(err, ErrWeakKey) {
+		t.Errorf("expected error '%v', got '%v'", ErrWeakKey, err)
+	}
+}
+
+func TestTokenVerifier_RS256AndRotation(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	der := x509.MarshalPKCS1PrivateKey(private)
+	rsaKey, err := ParseRSAPrivateKey("r1", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	publicDER, _ := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	publicKey, err := ParseRSAPublicKey("r1", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	at := time.Now()
+	old, _ := NewKeySet(testHMACKey(t, "k1"))
+	oldToken, _ := encode(old, testClaims(at))
+
+	// r1 is rolled out as the active key, k1 still verifies until its tokens expire
+	rotated, _ := NewKeySet(rsaKey, testHMACKey(t, "k1"))
+	token, err := encode(rotated, testClaims(at))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tok := range []string{oldToken, token} {
+		if _, err := NewTokenVerifier(rotated, "news-portal", "cms").Verify(tok, at); err != nil {
+			t.Errorf("expected both keys accepted during rotation, got '%v'", err)
+		}
+	}
+
+	// A verify-only service holds just the public key
+	verifyOnly, _ := NewVerifyingKeySet(publicKey)
+	if _, err := NewTokenVerifier(verifyOnly, "news-portal", "cms").Verify(token, at); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewTokenVerifier(verifyOnly, "news-portal", "cms").Verify(oldToken, at); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownKey, err)
+	}
+	if _, err := NewKeySet(publicKey); !errors.Is(err, ErrCannotSign) {
+		t.Errorf("expected error '%v', got '%v'", ErrCannotSign, err)
+	}
+
+	// The public key must not pass as an HS256 secret for a forged token
+	forgedHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","kid":"r1","typ":"JWT"}`))
+	forged := forgedHeader + token[strings.Index(token, "."):]
+	if _, err := NewTokenVerifier(verifyOnly, "news-portal", "cms").Verify(forged, at); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected the algorithm switch rejected, got '%v'", err)
+	}
+}
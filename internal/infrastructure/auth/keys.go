@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+type Algorithm string
+
+const (
+	HS256 Algorithm = "HS256"
+	RS256 Algorithm = "RS256"
+)
+
+const (
+	minHMACSecret = 32 // Bytes, as long as the SHA-256 output
+	minRSABits    = 2048
+)
+
+var (
+	ErrWeakKey     = errors.New("signing key is too short")
+	ErrUnknownKey  = errors.New("token signed with an unknown key")
+	ErrCannotSign  = errors.New("key can only verify")
+	ErrInvalidPEM  = errors.New("no RSA key found in PEM data")
+	ErrEmptyKeyID  = errors.New("key ID cannot be empty")
+	ErrDuplicateID = errors.New("key ID is used twice")
+)
+
+// Key is one signing key, named by the "kid" header of the tokens it signs.
+// An RSA key with only the public half verifies but cannot sign, which is how
+// services that only check tokens are configured.
+type Key struct {
+	ID        string
+	Algorithm Algorithm
+	secret    []byte
+	private   *rsa.PrivateKey
+	public    *rsa.PublicKey
+}
+
+func NewHMACKey(id string, secret []byte) (Key, error) {
+	if id == "" {
+		return Key{}, ErrEmptyKeyID
+	}
+	if len(secret) < minHMACSecret {
+		return Key{}, ErrWeakKey
+	}
+	return Key{ID: id, Algorithm: HS256, secret: secret}, nil
+}
+
+func NewRSAKey(id string, private *rsa.PrivateKey) (Key, error) {
+	key, err := NewRSAPublicKey(id, &private.PublicKey)
+	if err != nil {
+		return Key{}, err
+	}
+	key.private = private
+	return key, nil
+}
+
+func NewRSAPublicKey(id string, public *rsa.PublicKey) (Key, error) {
+	if id == "" {
+		return Key{}, ErrEmptyKeyID
+	}
+	if public.N.BitLen() < minRSABits {
+		return Key{}, ErrWeakKey
+	}
+	return Key{ID: id, Algorithm: RS256, public: public}, nil
+}
+
+// ParseRSAPrivateKey reads a PKCS #1 or PKCS #8 PEM block
+func ParseRSAPrivateKey(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, ErrInvalidPEM
+	}
+	if private, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewRSAKey(id, private)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return Key{}, fmt.Errorf("%w: %v", ErrInvalidPEM, err)
+	}
+	private, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return Key{}, ErrInvalidPEM
+	}
+	return NewRSAKey(id, private)
+}
+
+// ParseRSAPublicKey reads a PKIX public key PEM block
+func ParseRSAPublicKey(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, ErrInvalidPEM
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return Key{}, fmt.Errorf("%w: %v", ErrInvalidPEM, err)
+	}
+	public, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return Key{}, ErrInvalidPEM
+	}
+	return NewRSAPublicKey(id, public)
+}
+
+func (k Key) CanSign() bool {
+	return k.secret != nil || k.private != nil
+}
+
+func (k Key) sign(content []byte) ([]byte, error) {
+	switch {
+	case k.secret != nil:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(content)
+		return mac.Sum(nil), nil
+	case k.private != nil:
+		digest := sha256.Sum256(content)
+		return rsa.SignPKCS1v15(nil, k.private, crypto.SHA256, digest[:])
+	default:
+		return nil, ErrCannotSign
+	}
+}
+
+func (k Key) verify(content, signature []byte) bool {
+	if k.Algorithm == HS256 {
+		expected, _ := k.sign(content)
+		return hmac.Equal(expected, signature)
+	}
+	digest := sha256.Sum256(content)
+	return rsa.VerifyPKCS1v15(k.public, crypto.SHA256, digest[:], signature) == nil
+}
+
+// KeySet signs with the active key and verifies with any key, so a new key can
+// be rolled out while tokens signed by the previous one are still in use
+type KeySet struct {
+	active Key
+	keys   map[string]Key
+}
+
+func NewKeySet(active Key, previous ...Key) (*KeySet, error) {
+	if !active.CanSign() {
+		return nil, ErrCannotSign
+	}
+	set := &KeySet{active: active, keys: map[string]Key{active.ID: active}}
+	for _, key := range previous {
+		if _, ok := set.keys[key.ID]; ok {
+			return nil, ErrDuplicateID
+		}
+		set.keys[key.ID] = key
+	}
+	return set, nil
+}
+
+// NewVerifyingKeySet is for services that check tokens but never issue them
+func NewVerifyingKeySet(keys ...Key) (*KeySet, error) {
+	set := &KeySet{keys: map[string]Key{}}
+	for _, key := range keys {
+		if _, ok := set.keys[key.ID]; ok {
+			return nil, ErrDuplicateID
+		}
+		set.keys[key.ID] = key
+	}
+	return set, nil
+}
+
+func (s *KeySet) get(id string) (Key, error) {
+	key, ok := s.keys[id]
+	if !ok {
+		return Key{}, ErrUnknownKey
+	}
+	return key, nil
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryRefreshStore keeps refresh tokens in process, for tests and single-instance setups
+type MemoryRefreshStore struct {
+	mu     sync.Mutex
+	tokens map[string]*RefreshToken
+}
+
+func NewMemoryRefreshStore() *MemoryRefreshStore {
+	return &MemoryRefreshStore{tokens: map[string]*RefreshToken{}}
+}
+
+var _ RefreshTokenStore = (*MemoryRefreshStore)(nil)
+
+func (m *MemoryRefreshStore) Create(ctx context.Context, token *RefreshToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *token
+	m.tokens[token.ID] = &stored
+	return nil
+}
+
+func (m *MemoryRefreshStore) FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MemoryRefreshStore) MarkUsed(ctx context.Context, id string, at time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[id]
+	if !ok || token.UsedAt != nil {
+		return false, nil
+	}
+	token.UsedAt = &at
+	return true, nil
+}
+
+func (m *MemoryRefreshStore) DeleteBySession(ctx context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, token := range m.tokens {
+		if token.SessionID == sessionID {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	app "github.com/jokosaputro95/news-portal-cms/internal/application/account"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+const (
+	DefaultAccessTTL  = 15 * time.Minute
+	DefaultRefreshTTL = 14 * 24 * time.Hour
+	// ReuseGrace tolerates a refresh token sent twice in quick succession,
+	// e.g. by two tabs refreshing together; later reuse revokes the session
+	ReuseGrace = 10 * time.Second
+)
+
+// Refresh errors are forbidden-kind domain errors, the client has to sign in again
+var (
+	ErrInvalidRefreshToken = shared.NewDomainError("auth.invalid_refresh_token", shared.KindForbidden, "refresh token is invalid or has expired")
+	ErrRefreshTokenReused  = shared.NewDomainError("auth.refresh_token_reused", shared.KindForbidden, "refresh token was already used, the session has been revoked")
+	ErrInvalidSession      = shared.NewDomainError("auth.invalid_session", shared.KindForbidden, "session is revoked, expired or belongs to an inactive account")
+)
+
+// Config names the tokens' issuer and audience and their lifetimes
+type Config struct {
+	Issuer     string
+	Audience   string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration // Also capped by the session's expiry
+}
+
+// TokenPair is what a sign-in or a refresh hands to the client, shared with
+// the account service so TokenService satisfies its TokenIssuer port
+type TokenPair = app.TokenPair
+
+// RefreshToken is one link in a session's rotation chain. Only the hash of
+// the token is stored.
+type RefreshToken struct {
+	ID        string
+	SessionID string
+	AccountID string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time // Set once it was exchanged for a new pair
+	CreatedAt time.Time
+}
+
+// RefreshTokenStore keeps refresh tokens (implementation will be in a SQL or Redis adapter)
+type RefreshTokenStore interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) // nil when not found
+	// MarkUsed sets UsedAt if it is still unset, false when another request got there first
+	MarkUsed(ctx context.Context, id string, at time.Time) (bool, error)
+	DeleteBySession(ctx context.Context, sessionID string) error
+}
+
+// TokenService issues access tokens and rotates refresh tokens. Each refresh
+// token works once; presenting a used one again outside ReuseGrace means it
+// was copied, and the whole session is revoked.
+type TokenService struct {
+	keys     *KeySet
+	config   Config
+	verifier *TokenVerifier
+	refresh  RefreshTokenStore
+	accounts account.UserAccountRepository
+	sessions account.SessionRepository
+	clock    shared.Clock
+}
+
+func NewTokenService(keys *KeySet, config Config, refresh RefreshTokenStore, accounts account.UserAccountRepository, sessions account.SessionRepository, clock shared.Clock) *TokenService {
+	if config.AccessTTL <= 0 {
+		config.AccessTTL = DefaultAccessTTL
+	}
+	if config.RefreshTTL <= 0 {
+		config.RefreshTTL = DefaultRefreshTTL
+	}
+	return &TokenService{
+		keys:     keys,
+		config:   config,
+		verifier: NewTokenVerifier(keys, config.Issuer, config.Audience),
+		refresh:  refresh,
+		accounts: accounts,
+		sessions: sessions,
+		clock:    clock,
+	}
+}
+
+var _ app.TokenIssuer = (*TokenService)(nil)
+
+// Verifier checks the access tokens this service issues
+func (s *TokenService) Verifier() *TokenVerifier {
+	return s.verifier
+}
+
+// Issue signs a pair for a session that was just created at sign-in
+func (s *TokenService) Issue(ctx context.Context, acc *account.UserAccount, session *account.Session) (*TokenPair, error) {
+	now := s.clock.Now()
+	if !session.ValidFor(acc, now) {
+		return nil, ErrInvalidSession
+	}
+	return s.issue(ctx, acc, session, now)
+}
+
+// Refresh exchanges a refresh token for a new pair. The account is reloaded,
+// so role and status changes reach the next access token.
+func (s *TokenService) Refresh(ctx context.Context, rawToken, deviceFingerprint, ipAddress string) (*TokenPair, error) {
+	now := s.clock.Now()
+	token, err := s.refresh.FindByHash(ctx, hashToken(rawToken))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load refresh token: %w", err)
+	}
+	if token == nil || !now.Before(token.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if token.UsedAt != nil {
+		if now.Sub(*token.UsedAt) <= ReuseGrace {
+			return nil, ErrInvalidRefreshToken
+		}
+		if err := s.revokeSession(ctx, token.SessionID, account.RevokedByTokenReuse, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	session, err := s.sessions.FindByID(ctx, token.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	acc, err := s.accounts.FindByID(ctx, token.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	if session == nil || !session.ValidFor(acc, now) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err := session.Touch(deviceFingerprint, ipAddress, now); err != nil {
+		if errors.Is(err, account.ErrDeviceMismatch) {
+			if revokeErr := s.revokeSession(ctx, session.ID, account.RevokedByDeviceMismatch, now); revokeErr != nil {
+				return nil, revokeErr
+			}
+		}
+		return nil, err
+	}
+
+	used, err := s.refresh.MarkUsed(ctx, token.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use refresh token: %w", err)
+	}
+	if !used {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err := s.sessions.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+	return s.issue(ctx, acc, session, now)
+}
+
+// Revoke signs the session behind a refresh token out, unknown tokens are ignored
+func (s *TokenService) Revoke(ctx context.Context, rawToken string) error {
+	token, err := s.refresh.FindByHash(ctx, hashToken(rawToken))
+	if err != nil {
+		return fmt.Errorf("failed to load refresh token: %w", err)
+	}
+	if token == nil {
+		return nil
+	}
+	return s.revokeSession(ctx, token.SessionID, account.RevokedByLogout, s.clock.Now())
+}
+
+func (s *TokenService) issue(ctx context.Context, acc *account.UserAccount, session *account.Session, now time.Time) (*TokenPair, error) {
+	tokenID, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token ID: %w", err)
+	}
+	roles := make([]string, 0, len(acc.Roles))
+	for _, role := range acc.Roles {
+		roles = append(roles, role.Name)
+	}
+	accessExpiresAt := now.Add(s.config.AccessTTL)
+	access, err := encode(s.keys, Claims{
+		TokenID:     tokenID,
+		Issuer:      s.config.Issuer,
+		Audience:    s.config.Audience,
+		AccountID:   acc.ID,
+		SessionID:   session.ID,
+		AccountType: string(acc.Type),
+		Roles:       roles,
+		Verified:    acc.IsVerified,
+		IssuedAt:    now,
+		ExpiresAt:   accessExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	rawRefresh := base64.RawURLEncoding.EncodeToString(raw)
+	refreshID, err := shared.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token ID: %w", err)
+	}
+	refresh := &RefreshToken{
+		ID:        refreshID,
+		SessionID: session.ID,
+		AccountID: acc.ID,
+		TokenHash: hashToken(rawRefresh),
+		ExpiresAt: now.Add(s.config.RefreshTTL),
+		CreatedAt: now,
+	}
+	if session.ExpiresAt.Before(refresh.ExpiresAt) {
+		refresh.ExpiresAt = session.ExpiresAt
+	}
+	if err := s.refresh.Create(ctx, refresh); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken:      access,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     rawRefresh,
+		RefreshExpiresAt: refresh.ExpiresAt,
+	}, nil
+}
+
+func (s *TokenService) revokeSession(ctx context.Context, sessionID string, reason account.RevocationReason, at time.Time) error {
+	session, err := s.sessions.FindByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if session != nil && session.Revoke(reason, at) == nil {
+		if err := s.sessions.Update(ctx, session); err != nil {
+			return fmt.Errorf("failed to revoke session: %w", err)
+		}
+	}
+	if err := s.refresh.DeleteBySession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to delete refresh tokens: %w", err)
+	}
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+type memoryAccounts struct {
+	account.UserAccountRepository
+	byID map[string]*account.UserAccount
+}
+
+func (m *memoryAccounts) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return m.byID[id], nil
+}
+
+type memorySessions struct {
+	account.SessionRepository
+	byID map[string]*account.Session
+}
+
+func (m *memorySessions) FindByID(ctx context.Context, id string) (*account.Session, error) {
+	return m.byID[id], nil
+}
+
+func (m *memorySessions) Update(ctx context.Context, session *account.Session) error {
+	m.byID[session.ID] = session
+	return nil
+}
+
+type tokenFixture struct {
+	service  *TokenService
+	clock    *shared.FrozenClock
+	account  *account.UserAccount
+	session  *account.Session
+	sessions *memorySessions
+}
+
+func newTokenFixture(t *testing.T) *tokenFixture {
+	t.Helper()
+	clock := shared.NewFrozenClock(time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC))
+	acc, _ := account.NewUserAccountForSelfRegistration("m1", "member_user", "member@example.com", "hashed_password")
+	acc.SetClock(clock)
+	_ = acc.SelfVerify()
+	clock.Advance(time.Second)
+	session, _ := account.NewSession("s1", "m1", "device-1", "203.0.113.9", "Firefox", 30*24*time.Hour, clock.Now())
+
+	keys, _ := NewKeySet(testHMACKey(t, "k1"))
+	sessions := &memorySessions{byID: map[string]*account.Session{"s1": session}}
+	service := NewTokenService(keys, Config{Issuer: "news-portal", Audience: "cms"}, NewMemoryRefreshStore(),
+		&memoryAccounts{byID: map[string]*account.UserAccount{"m1": acc}}, sessions, clock)
+	return &tokenFixture{service: service, clock: clock, account: acc, session: session, sessions: sessions}
+}
+
+func TestTokenService_Rotation(t *testing.T) {
+	f := newTokenFixture(t)
+	ctx := context.Background()
+
+	pair, err := f.service.Issue(ctx, f.account, f.session)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claims, err := f.service.Verifier().Verify(pair.AccessToken, f.clock.Now())
+	if err != nil || claims.AccountID != "m1" || claims.AccountType != "membership" || !claims.Verified {
+		t.Fatalf("expected the account in the access token, got %+v %v", claims, err)
+	}
+
+	f.clock.Advance(20 * time.Minute)
+	next, err := f.service.Refresh(ctx, pair.RefreshToken, "device-1", "198.51.100.4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.RefreshToken == pair.RefreshToken || f.session.IPAddress != "198.51.100.4" {
+		t.Errorf("expected a rotated refresh token and the session touched")
+	}
+
+	// Within the grace period a repeat is refused without revoking anything
+	if _, err := f.service.Refresh(ctx, pair.RefreshToken, "device-1", ""); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidRefreshToken, err)
+	}
+	if f.session.IsRevoked() {
+		t.Fatal("expected a quick repeat to leave the session alone")
+	}
+
+	// Later, the old token coming back means it was stolen
+	f.clock.Advance(time.Minute)
+	if _, err := f.service.Refresh(ctx, pair.RefreshToken, "device-1", ""); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("expected error '%v', got '%v'", ErrRefreshTokenReused, err)
+	}
+	if !f.session.IsRevoked() || *f.session.RevokedReason != account.RevokedByTokenReuse {
+		t.Errorf("expected the session revoked, got %+v", f.session)
+	}
+	if _, err := f.service.Refresh(ctx, next.RefreshToken, "device-1", ""); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expected the current token revoked with the session, got '%v'", err)
+	}
+}
+
+func TestTokenService_RefreshRejected(t *testing.T) {
+	testCases := []struct {
+		name          string
+		change        func(f *tokenFixture)
+		fingerprint   string
+		wantErr       error
+		expectRevoked bool
+	}{
+		{"password changed", func(f *tokenFixture) { _ = f.account.UpdatePasswordHash("new_hash") }, "device-1", ErrInvalidRefreshToken, false},
+		{"account suspended", func(f *tokenFixture) { _ = f.account.Suspend("moderator-1", "spam") }, "device-1", ErrInvalidRefreshToken, false},
+		{"expired", func(f *tokenFixture) { f.clock.Advance(DefaultRefreshTTL) }, "device-1", ErrInvalidRefreshToken, false},
+		{"other device", func(f *tokenFixture) {}, "device-2", account.ErrDeviceMismatch, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newTokenFixture(t)
+			pair, _ := f.service.Issue(context.Background(), f.account, f.session)
+			f.clock.Advance(time.Minute)
+			tc.change(f)
+
+			if _, err := f.service.Refresh(context.Background(), pair.RefreshToken, tc.fingerprint, ""); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected error '%v', got '%v'", tc.wantErr, err)
+			}
+			if f.session.IsRevoked() != tc.expectRevoked {
+				t.Errorf("expected revoked %v, got %+v", tc.expectRevoked, f.session)
+			}
+		})
+	}
+}
+
+func TestTokenService_Revoke(t *testing.T) {
+	f := newTokenFixture(t)
+	ctx := context.Background()
+	pair, _ := f.service.Issue(ctx, f.account, f.session)
+
+	if err := f.service.Revoke(ctx, pair.RefreshToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !f.session.IsRevoked() || *f.session.RevokedReason != account.RevokedByLogout {
+		t.Errorf("expected the session signed out, got %+v", f.session)
+	}
+	if _, err := f.service.Refresh(ctx, pair.RefreshToken, "device-1", ""); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidRefreshToken, err)
+	}
+	if _, err := f.service.Issue(ctx, f.account, f.session); !errors.Is(err, ErrInvalidSession) {
+		t.Errorf("expected no tokens for a revoked session, got '%v'", err)
+	}
+}
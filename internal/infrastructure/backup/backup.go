@@ -0,0 +1,413 @@
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	idLayout     = "20060102T150405Z"
+	archiveName  = "data.enc"
+	manifestName = "manifest.json"
+	maxLineSize  = 16 << 20
+)
+
+var (
+	ErrIntegrity      = errors.New("backup failed its integrity check")
+	ErrBackupNotFound = errors.New("backup not found")
+	ErrPIIKeyRequired = errors.New("policy encrypts PII columns but no PII key was given")
+)
+
+// Source is the database being backed up
+type Source interface {
+	// Snapshot opens a read-only view; every Dump through it sees the same point in time
+	Snapshot(ctx context.Context) (Snapshot, error)
+}
+
+type Snapshot interface {
+	Dump(ctx context.Context, table string, fn func(row map[string]any) error) error
+	Close() error
+}
+
+// Sink is the database being restored into
+type Sink interface {
+	// Begin empties the tables; nothing is visible until Commit
+	Begin(ctx context.Context, tables []string) (SinkTx, error)
+}
+
+type SinkTx interface {
+	Insert(ctx context.Context, table string, row map[string]any) error
+	Commit() error
+	Rollback() error
+}
+
+type TableStats struct {
+	Rows     int64  `json:"rows"`
+	Checksum string `json:"checksum"` // SHA-256 over the table's archive lines
+}
+
+// Manifest describes one backup, it is uploaded after the archive and signed with the archive key
+type Manifest struct {
+	ID              string                `json:"id"`
+	CreatedAt       time.Time             `json:"created_at"`
+	PolicyVersion   int                   `json:"policy_version"`
+	KeyID           string                `json:"key_id"`
+	PIIEncrypted    bool                  `json:"pii_encrypted"`
+	Tables          []string              `json:"tables"`
+	Stats           map[string]TableStats `json:"stats"`
+	ArchiveSize     int64                 `json:"archive_size"`
+	ArchiveChecksum string                `json:"archive_checksum"`
+	MAC             string                `json:"mac"`
+}
+
+type record struct {
+	Table string         `json:"t"`
+	Row   map[string]any `json:"r"`
+}
+
+// Create dumps every table of the policy from one snapshot into an encrypted archive
+func Create(ctx context.Context, source Source, store ObjectStore, policy *Policy, keys *Keys, piiKey []byte, at time.Time) (*Manifest, error) {
+	if policy.NeedsPIIKey() && len(piiKey) != KeySize {
+		return nil, ErrPIIKeyRequired
+	}
+
+	m := &Manifest{
+		ID:            at.UTC().Format(idLayout),
+		CreatedAt:     at.UTC(),
+		PolicyVersion: policy.Version,
+		KeyID:         keys.ActiveID,
+		PIIEncrypted:  policy.NeedsPIIKey(),
+		Tables:        policy.IncludedTables(),
+		Stats:         map[string]TableStats{},
+	}
+
+	snapshot, err := source.Snapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer snapshot.Close()
+
+	pr, pw := io.Pipe()
+	uploaded := make(chan error, 1)
+	go func() {
+		err := store.Put(ctx, m.ID+"/"+archiveName, pr)
+		pr.CloseWithError(err)
+		uploaded <- err
+	}()
+
+	archive := &hashingWriter{w: pw, h: sha256.New()}
+	if err := writeArchive(ctx, snapshot, archive, policy, keys, piiKey, m); err != nil {
+		pw.CloseWithError(err)
+		<-uploaded
+		return nil, err
+	}
+	pw.Close()
+	if err := <-uploaded; err != nil {
+		return nil, fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	m.ArchiveSize = archive.n
+	m.ArchiveChecksum = hex.EncodeToString(archive.h.Sum(nil))
+	if err := m.sign(keys); err != nil {
+		return nil, err
+	}
+
+	body, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Put(ctx, m.ID+"/"+manifestName, strings.NewReader(string(body))); err != nil {
+		return nil, fmt.Errorf("failed to upload manifest: %w", err)
+	}
+	return m, nil
+}
+
+func writeArchive(ctx context.Context, snapshot Snapshot, w io.Writer, policy *Policy, keys *Keys, piiKey []byte, m *Manifest) error {
+	enc, err := newEncryptWriter(w, keys)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(enc)
+
+	for _, table := range m.Tables {
+		stats := TableStats{}
+		h := sha256.New()
+		err := snapshot.Dump(ctx, table, func(row map[string]any) error {
+			if err := policy.apply(table, row, piiKey); err != nil {
+				return err
+			}
+			line, err := json.Marshal(record{Table: table, Row: row})
+			if err != nil {
+				return err
+			}
+			line = append(line, '\n')
+			h.Write(line)
+			stats.Rows++
+			_, err = gz.Write(line)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to dump %s: %w", table, err)
+		}
+		stats.Checksum = hex.EncodeToString(h.Sum(nil))
+		m.Stats[table] = stats
+	}
+
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Restore loads a backup into the sink after checking it end to end.
+// The sink transaction is only committed when every checksum and row count matches.
+// PII columns stay sealed unless the PII key is given.
+func Restore(ctx context.Context, store ObjectStore, id string, keys *Keys, piiKey []byte, sink Sink) (*Manifest, error) {
+	m, err := LoadManifest(ctx, store, id, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := store.Get(ctx, m.ID+"/"+archiveName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer body.Close()
+
+	archive := &hashingReader{r: body, h: sha256.New()}
+	plain, err := newDecryptReader(archive, keys)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(plain)
+	if err != nil {
+		if errors.Is(err, ErrTruncated) {
+			return nil, err
+		}
+		return nil, ErrCorruptArchive
+	}
+
+	tx, err := sink.Begin(ctx, m.Tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start restore: %w", err)
+	}
+	if err := restoreRecords(ctx, gz, archive, m, piiKey, tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return m, nil
+}
+
+func restoreRecords(ctx context.Context, gz io.Reader, archive *hashingReader, m *Manifest, piiKey []byte, tx SinkTx) error {
+	hashes := map[string]hash.Hash{}
+	rows := map[string]int64{}
+	for _, table := range m.Tables {
+		hashes[table] = sha256.New()
+	}
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64<<10), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		var rec record
+		decoder := json.NewDecoder(strings.NewReader(string(line)))
+		decoder.UseNumber()
+		if err := decoder.Decode(&rec); err != nil {
+			return ErrCorruptArchive
+		}
+		h, ok := hashes[rec.Table]
+		if !ok {
+			return fmt.Errorf("%w: unexpected table %q", ErrIntegrity, rec.Table)
+		}
+		h.Write(line)
+		h.Write([]byte{'\n'})
+		rows[rec.Table]++
+
+		if piiKey != nil {
+			if err := unsealRow(rec.Row, piiKey); err != nil {
+				return err
+			}
+		}
+		if err := tx.Insert(ctx, rec.Table, rec.Row); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", rec.Table, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, ErrTruncated) || errors.Is(err, ErrCorruptArchive) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrCorruptArchive, err)
+	}
+
+	// Read the final chunk marker and anything after it so the archive hash covers every byte
+	if _, err := io.Copy(io.Discard, archive); err != nil {
+		return err
+	}
+	if archive.n != m.ArchiveSize || hex.EncodeToString(archive.h.Sum(nil)) != m.ArchiveChecksum {
+		return fmt.Errorf("%w: archive checksum mismatch", ErrIntegrity)
+	}
+	for _, table := range m.Tables {
+		stats := m.Stats[table]
+		if rows[table] != stats.Rows || hex.EncodeToString(hashes[table].Sum(nil)) != stats.Checksum {
+			return fmt.Errorf("%w: table %s has %d rows, expected %d", ErrIntegrity, table, rows[table], stats.Rows)
+		}
+	}
+	return nil
+}
+
+func unsealRow(row map[string]any, piiKey []byte) error {
+	for column, value := range row {
+		s, ok := value.(string)
+		if !ok || !strings.HasPrefix(s, piiPrefix) {
+			continue
+		}
+		plain, err := decryptValue(piiKey, s)
+		if err != nil {
+			return err
+		}
+		row[column] = plain
+	}
+	return nil
+}
+
+// LoadManifest fetches a manifest and checks its signature
+func LoadManifest(ctx context.Context, store ObjectStore, id string, keys *Keys) (*Manifest, error) {
+	body, err := store.Get(ctx, id+"/"+manifestName)
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil, ErrBackupNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download manifest: %w", err)
+	}
+	defer body.Close()
+
+	var m Manifest
+	if err := json.NewDecoder(body).Decode(&m); err != nil {
+		return nil, ErrCorruptArchive
+	}
+	if err := m.verify(keys); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// List returns the complete backups in the store, oldest first.
+// Archives without a manifest are uploads that never finished and are ignored.
+func List(ctx context.Context, store ObjectStore, keys *Keys) ([]*Manifest, error) {
+	objects, err := store.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []*Manifest
+	for _, key := range objects {
+		id, ok := strings.CutSuffix(key, "/"+manifestName)
+		if !ok {
+			continue
+		}
+		m, err := LoadManifest(ctx, store, id, keys)
+		if err != nil {
+			return nil, fmt.Errorf("backup %s: %w", id, err)
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests, nil
+}
+
+func (m *Manifest) sign(keys *Keys) error {
+	mac, err := m.computeMAC(keys)
+	if err != nil {
+		return err
+	}
+	m.MAC = mac
+	return nil
+}
+
+func (m *Manifest) verify(keys *Keys) error {
+	expected, err := m.computeMAC(keys)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(expected), []byte(m.MAC)) {
+		return fmt.Errorf("%w: manifest signature mismatch", ErrIntegrity)
+	}
+	return nil
+}
+
+func (m *Manifest) computeMAC(keys *Keys) (string, error) {
+	key, err := keys.get(m.KeyID)
+	if err != nil {
+		return "", err
+	}
+	unsigned := *m
+	unsigned.MAC = ""
+	body, err := json.Marshal(unsigned)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+type hashingWriter struct {
+	w io.Writer
+	h hash.Hash
+	n int64
+}
+
+func (w *hashingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.h.Write(p[:n])
+	w.n += int64(n)
+	return n, err
+}
+
+type hashingReader struct {
+	r io.Reader
+	h hash.Hash
+	n int64
+}
+
+func (r *hashingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.h.Write(p[:n])
+	r.n += int64(n)
+	return n, err
+}
+
+// DiscardSink checks a backup without writing anything, used by verify and DR drills
+type DiscardSink struct{}
+
+func (DiscardSink) Begin(ctx context.Context, tables []string) (SinkTx, error) {
+	return discardTx{}, nil
+}
+
+type discardTx struct{}
+
+func (discardTx) Insert(ctx context.Context, table string, row map[string]any) error {
+	return nil
+}
+
+func (discardTx) Commit() error {
+	return nil
+}
+
+func (discardTx) Rollback() error {
+	return nil
+}
@@ -0,0 +1,286 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memorySource struct {
+	tables map[string][]map[string]any
+}
+
+func (m *memorySource) Snapshot(ctx context.Context) (Snapshot, error) {
+	return m, nil
+}
+
+func (m *memorySource) Dump(ctx context.Context, table string, fn func(row map[string]any) error) error {
+	for _, row := range m.tables[table] {
+		copied := map[string]any{}
+		for k, v := range row {
+			copied[k] = v
+		}
+		if err := fn(copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memorySource) Close() error {
+	return nil
+}
+
+type memorySink struct {
+	truncated []string
+	rows      map[string][]map[string]any
+	committed bool
+}
+
+func (m *memorySink) Begin(ctx context.Context, tables []string) (SinkTx, error) {
+	m.truncated = tables
+	m.rows = map[string][]map[string]any{}
+	return m, nil
+}
+
+func (m *memorySink) Insert(ctx context.Context, table string, row map[string]any) error {
+	m.rows[table] = append(m.rows[table], row)
+	return nil
+}
+
+func (m *memorySink) Commit() error {
+	m.committed = true
+	return nil
+}
+
+func (m *memorySink) Rollback() error {
+	m.rows = nil
+	return nil
+}
+
+func testKeys(t *testing.T, ids ...string) *Keys {
+	var parts []string
+	for _, id := range ids {
+		key := sha256.Sum256([]byte(id))
+		parts = append(parts, id+"="+base64.StdEncoding.EncodeToString(key[:]))
+	}
+	keys, err := ParseKeys(strings.Join(parts, ","))
+	if err != nil {
+		t.Fatalf("failed to parse keys: %v", err)
+	}
+	return keys
+}
+
+func testPolicy() *Policy {
+	return &Policy{
+		Version: 3,
+		Tables:  []string{"user_accounts", "articles", "sessions"},
+		Rules: map[string]TablePolicy{
+			"user_accounts": {Columns: map[string]ColumnAction{"email": ColumnEncrypt, "password_hash": ColumnDrop}},
+			"sessions":      {Exclude: true},
+		},
+	}
+}
+
+func testSource() *memorySource {
+	return &memorySource{tables: map[string][]map[string]any{
+		"user_accounts": {
+			{"id": "u1", "username": "editor", "email": "editor@example.com", "password_hash": "$argon2id$..."},
+			{"id": "u2", "username": "reader", "email": nil, "password_hash": "$argon2id$..."},
+		},
+		"articles": {
+			{"id": "a1", "title": strings.Repeat("Banjir Jakarta ", 10000), "views": 1250},
+		},
+		"sessions": {{"id": "s1", "token": "secret"}},
+	}}
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewFileStore(dir)
+	keys := testKeys(t, "2025-07")
+	piiKey := bytes.Repeat([]byte{'p'}, KeySize)
+	at := time.Date(2025, time.July, 1, 2, 0, 0, 0, time.UTC)
+
+	if _, err := Create(ctx, testSource(), store, testPolicy(), keys, nil, at); err != ErrPIIKeyRequired {
+		t.Errorf("expected error '%v', got '%v'", ErrPIIKeyRequired, err)
+	}
+
+	m, err := Create(ctx, testSource(), store, testPolicy(), keys, piiKey, at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.ID != "20250701T020000Z" || len(m.Tables) != 2 || m.Stats["user_accounts"].Rows != 2 {
+		t.Errorf("unexpected manifest %+v", m)
+	}
+
+	raw, _ := os.ReadFile(filepath.Join(dir, m.ID, archiveName))
+	if bytes.Contains(raw, []byte("editor")) {
+		t.Error("expected archive to be encrypted")
+	}
+
+	// Drill restore without the PII key keeps emails sealed
+	drill := &memorySink{}
+	if _, err := Restore(ctx, store, m.ID, keys, nil, drill); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	editor := drill.rows["user_accounts"][0]
+	if !strings.HasPrefix(editor["email"].(string), piiPrefix) || editor["password_hash"] != nil {
+		t.Errorf("expected sealed email and dropped hash, got %+v", editor)
+	}
+	if _, ok := drill.rows["sessions"]; ok {
+		t.Error("expected excluded table to be absent")
+	}
+
+	// Disaster recovery restore with the PII key
+	dr := &memorySink{}
+	if _, err := Restore(ctx, store, m.ID, keys, piiKey, dr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !dr.committed || dr.rows["user_accounts"][0]["email"] != "editor@example.com" || dr.rows["user_accounts"][1]["email"] != nil {
+		t.Errorf("expected decrypted emails, got %+v", dr.rows["user_accounts"])
+	}
+	if dr.rows["articles"][0]["views"].(interface{ String() string }).String() != "1250" {
+		t.Errorf("expected numbers to round trip, got %v", dr.rows["articles"][0]["views"])
+	}
+
+	// Old backups stay readable after the archive key rotates
+	rotated := testKeys(t, "2025-08", "2025-07")
+	if _, err := Restore(ctx, store, m.ID, rotated, nil, DiscardSink{}); err != nil {
+		t.Errorf("expected restore with rotated keys to succeed, got '%v'", err)
+	}
+	if _, err := Restore(ctx, store, m.ID, testKeys(t, "2025-08"), nil, DiscardSink{}); err != ErrUnknownKey {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownKey, err)
+	}
+	if _, err := Restore(ctx, store, "20990101T000000Z", keys, nil, DiscardSink{}); err != ErrBackupNotFound {
+		t.Errorf("expected error '%v', got '%v'", ErrBackupNotFound, err)
+	}
+}
+
+func TestRestore_DetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	keys := testKeys(t, "k1")
+	piiKey := bytes.Repeat([]byte{'p'}, KeySize)
+	at := time.Date(2025, time.July, 1, 2, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		corrupt     func(dir, id string)
+		expectedErr error
+	}{
+		{"flipped archive byte", func(dir, id string) {
+			path := filepath.Join(dir, id, archiveName)
+			raw, _ := os.ReadFile(path)
+			raw[len(raw)/2] ^= 0xff
+			os.WriteFile(path, raw, 0o600)
+		}, ErrCorruptArchive},
+		{"truncated archive", func(dir, id string) {
+			path := filepath.Join(dir, id, archiveName)
+			raw, _ := os.ReadFile(path)
+			os.WriteFile(path, raw[:len(raw)-40], 0o600)
+		}, ErrTruncated},
+		{"edited manifest", func(dir, id string) {
+			path := filepath.Join(dir, id, manifestName)
+			raw, _ := os.ReadFile(path)
+			os.WriteFile(path, bytes.Replace(raw, []byte(`"rows": 2`), []byte(`"rows": 3`), 1), 0o600)
+		}, ErrIntegrity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store := NewFileStore(dir)
+			m, err := Create(ctx, testSource(), store, testPolicy(), keys, piiKey, at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tc.corrupt(dir, m.ID)
+
+			sink := &memorySink{}
+			_, err = Restore(ctx, store, m.ID, keys, nil, sink)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error '%v', got '%v'", tc.expectedErr, err)
+			}
+			if sink.committed {
+				t.Error("expected restore not to commit")
+			}
+		})
+	}
+}
+
+func TestEncryptStream_RoundTrip(t *testing.T) {
+	keys := testKeys(t, "k1")
+	for _, size := range []int{0, 1, chunkSize, chunkSize*3 + 17} {
+		var buf bytes.Buffer
+		w, err := newEncryptWriter(&buf, keys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		plain := bytes.Repeat([]byte{'x'}, size)
+		w.Write(plain)
+		w.Close()
+
+		r, err := newDecryptReader(&buf, keys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("size %d: unexpected error: %v", size, err)
+		}
+		if !bytes.Equal(got, plain) {
+			t.Errorf("size %d: round trip mismatch", size)
+		}
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	testCases := []struct {
+		name      string
+		raw       string
+		expectErr bool
+	}{
+		{"valid", "k1=" + base64.StdEncoding.EncodeToString(make([]byte, KeySize)), false},
+		{"missing id", "=" + base64.StdEncoding.EncodeToString(make([]byte, KeySize)), true},
+		{"short key", "k1=" + base64.StdEncoding.EncodeToString(make([]byte, 16)), true},
+		{"not base64", "k1=???", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseKeys(tc.raw)
+			if tc.expectErr && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	p, err := LoadPolicy(strings.NewReader(`{"version":1,"tables":["a","b"],"rules":{"b":{"exclude":true}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := p.IncludedTables(); len(got) != 1 || got[0] != "a" {
+		t.Errorf("expected only table a, got %v", got)
+	}
+
+	if _, err := LoadPolicy(strings.NewReader(`{"version":1,"tables":[]}`)); err != ErrEmptyPolicy {
+		t.Errorf("expected error '%v', got '%v'", ErrEmptyPolicy, err)
+	}
+	if _, err := LoadPolicy(strings.NewReader(`{"version":1,"tables":["a"],"rules":{"a":{"columns":{"email":"hash"}}}}`)); err == nil {
+		t.Error("expected error but got none")
+	}
+}
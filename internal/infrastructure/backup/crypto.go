@@ -0,0 +1,293 @@
+package backup
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Archive stream layout: magic, key ID, nonce prefix, then length-prefixed AES-GCM chunks.
+// The high bit of a chunk length marks the final chunk so truncated archives are detected.
+const (
+	magic          = "NPCB1"
+	chunkSize      = 64 << 10
+	noncePrefixLen = 8
+	finalFlag      = 1 << 31
+	piiPrefix      = "enc:v1:"
+)
+
+// KeySize is the AES-256 key length in bytes
+const KeySize = 32
+
+var (
+	ErrUnknownKey     = errors.New("backup encrypted with an unknown key")
+	ErrCorruptArchive = errors.New("backup archive is corrupt or was tampered with")
+	ErrTruncated      = errors.New("backup archive is truncated")
+	ErrInvalidKey     = errors.New("backup keys must be 32 bytes")
+)
+
+// Keys holds the archive keys by ID, the first one encrypts new backups
+type Keys struct {
+	ActiveID string
+	keys     map[string][]byte
+}
+
+// ParseKeys reads "id=base64key,id2=base64key", listing the active key first
+func ParseKeys(raw string) (*Keys, error) {
+	k := &Keys{keys: map[string][]byte{}}
+	for _, part := range strings.Split(raw, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || id == "" || len(id) > 255 {
+			return nil, fmt.Errorf("invalid backup key entry %q", part)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != KeySize {
+			return nil, ErrInvalidKey
+		}
+		if k.ActiveID == "" {
+			k.ActiveID = id
+		}
+		k.keys[id] = key
+	}
+	return k, nil
+}
+
+func (k *Keys) get(id string) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return key, nil
+}
+
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+}
+
+// newEncryptWriter encrypts everything written with the active key, Close writes the final chunk
+func newEncryptWriter(w io.Writer, keys *Keys) (io.WriteCloser, error) {
+	key, err := keys.get(keys.ActiveID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, noncePrefixLen)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(magic), byte(len(keys.ActiveID)))
+	header = append(header, keys.ActiveID...)
+	header = append(header, prefix...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, prefix: prefix, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(chunkSize-len(e.buf), len(p))
+		e.buf = append(e.buf, p[:n]...)
+		p = p[n:]
+		written += n
+		if len(e.buf) == chunkSize {
+			if err := e.flush(false); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (e *encryptWriter) Close() error {
+	return e.flush(true)
+}
+
+func (e *encryptWriter) flush(final bool) error {
+	aad := []byte{0}
+	if final {
+		aad[0] = 1
+	}
+	sealed := e.aead.Seal(nil, e.nonce(), e.buf, aad)
+	e.counter++
+	e.buf = e.buf[:0]
+
+	length := uint32(len(sealed))
+	if final {
+		length |= finalFlag
+	}
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint32(header, length)
+	if _, err := e.w.Write(header); err != nil {
+		return err
+	}
+	_, err := e.w.Write(sealed)
+	return err
+}
+
+func (e *encryptWriter) nonce() []byte {
+	nonce := make([]byte, noncePrefixLen+4)
+	copy(nonce, e.prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixLen:], e.counter)
+	return nonce
+}
+
+type decryptReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	plain   []byte
+	done    bool
+}
+
+// newDecryptReader authenticates and decrypts an archive stream chunk by chunk
+func newDecryptReader(r io.Reader, keys *Keys) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(br, header); err != nil || string(header[:len(magic)]) != magic {
+		return nil, ErrCorruptArchive
+	}
+
+	id := make([]byte, header[len(magic)])
+	if _, err := io.ReadFull(br, id); err != nil {
+		return nil, ErrCorruptArchive
+	}
+	key, err := keys.get(string(id))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, noncePrefixLen)
+	if _, err := io.ReadFull(br, prefix); err != nil {
+		return nil, ErrCorruptArchive
+	}
+	return &decryptReader{r: br, aead: aead, prefix: prefix}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *decryptReader) next() error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(d.r, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrTruncated
+		}
+		return err
+	}
+	length := binary.BigEndian.Uint32(header)
+	final := length&finalFlag != 0
+	length &^= finalFlag
+	if length > chunkSize+uint32(d.aead.Overhead()) {
+		return ErrCorruptArchive
+	}
+
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return ErrTruncated
+	}
+
+	aad := []byte{0}
+	if final {
+		aad[0] = 1
+	}
+	nonce := make([]byte, noncePrefixLen+4)
+	copy(nonce, d.prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixLen:], d.counter)
+
+	plain, err := d.aead.Open(nil, nonce, sealed, aad)
+	if err != nil {
+		return ErrCorruptArchive
+	}
+	d.counter++
+	d.plain = plain
+
+	if final {
+		d.done = true
+		if _, err := d.r.ReadByte(); err != io.EOF {
+			return ErrCorruptArchive
+		}
+	}
+	return nil
+}
+
+// encryptValue seals one PII column value so it can only be read with the PII key
+func encryptValue(key []byte, value string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), nil)
+	return piiPrefix + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptValue(key []byte, value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, piiPrefix)
+	if !ok {
+		return value, nil
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrCorruptArchive
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", ErrCorruptArchive
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", ErrCorruptArchive
+	}
+	return string(plain), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
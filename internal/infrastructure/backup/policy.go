@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+type ColumnAction string
+
+const (
+	ColumnKeep    ColumnAction = "keep"
+	ColumnDrop    ColumnAction = "drop"    // Never leaves the database, restored as NULL
+	ColumnEncrypt ColumnAction = "encrypt" // Sealed with the PII key, separate from the archive key
+)
+
+// TablePolicy decides what of a table goes into the backup
+type TablePolicy struct {
+	Exclude bool                    `json:"exclude,omitempty"`
+	Columns map[string]ColumnAction `json:"columns,omitempty"` // Unlisted columns are kept
+}
+
+// Policy lists the tables to back up, in restore order (parents before children)
+type Policy struct {
+	Version int                    `json:"version"`
+	Tables  []string               `json:"tables"`
+	Rules   map[string]TablePolicy `json:"rules,omitempty"`
+}
+
+var ErrEmptyPolicy = errors.New("backup policy lists no tables")
+
+// LoadPolicy reads a JSON policy file
+func LoadPolicy(r io.Reader) (*Policy, error) {
+	var p Policy
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse backup policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (p *Policy) Validate() error {
+	if len(p.Tables) == 0 {
+		return ErrEmptyPolicy
+	}
+	for table, rule := range p.Rules {
+		for column, action := range rule.Columns {
+			if action != ColumnKeep && action != ColumnDrop && action != ColumnEncrypt {
+				return fmt.Errorf("invalid action %q for %s.%s", action, table, column)
+			}
+		}
+	}
+	return nil
+}
+
+// IncludedTables returns the tables that are dumped, in policy order
+func (p *Policy) IncludedTables() []string {
+	var tables []string
+	for _, table := range p.Tables {
+		if !p.Rules[table].Exclude {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// NeedsPIIKey reports whether any column is encrypted
+func (p *Policy) NeedsPIIKey() bool {
+	for _, rule := range p.Rules {
+		for _, action := range rule.Columns {
+			if action == ColumnEncrypt {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apply drops and encrypts the row's columns in place
+func (p *Policy) apply(table string, row map[string]any, piiKey []byte) error {
+	for column, action := range p.Rules[table].Columns {
+		value, ok := row[column]
+		if !ok || value == nil {
+			continue
+		}
+		switch action {
+		case ColumnDrop:
+			row[column] = nil
+		case ColumnEncrypt:
+			sealed, err := encryptValue(piiKey, fmt.Sprint(value))
+			if err != nil {
+				return err
+			}
+			row[column] = sealed
+		}
+	}
+	return nil
+}
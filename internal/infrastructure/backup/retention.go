@@ -0,0 +1,60 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Retention keeps every backup of the last KeepDays calendar days (today included) plus the first backup of each of the last KeepMonths months.
+// The newest backup is always kept.
+type Retention struct {
+	KeepDays   int
+	KeepMonths int
+}
+
+// Expired returns the backups the retention rules no longer cover
+func (r Retention) Expired(manifests []*Manifest, now time.Time) []*Manifest {
+	sorted := append([]*Manifest(nil), manifests...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].CreatedAt.Before(sorted[j].CreatedAt) })
+
+	now = now.UTC()
+	dailyCutoff := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1-r.KeepDays)
+	monthlyCutoff := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1-r.KeepMonths, 0)
+
+	firstOfMonth := map[string]bool{}
+	var expired []*Manifest
+	for i, m := range sorted {
+		month := m.CreatedAt.Format("2006-01")
+		monthly := !firstOfMonth[month] && r.KeepMonths > 0 && !m.CreatedAt.Before(monthlyCutoff)
+		firstOfMonth[month] = true
+
+		daily := r.KeepDays > 0 && !m.CreatedAt.Before(dailyCutoff)
+		if i == len(sorted)-1 || monthly || daily {
+			continue
+		}
+		expired = append(expired, m)
+	}
+	return expired
+}
+
+// Prune deletes expired backups. The manifest goes first so a half-deleted backup is never listed.
+func Prune(ctx context.Context, store ObjectStore, keys *Keys, retention Retention, now time.Time) ([]string, error) {
+	manifests, err := List(ctx, store, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, m := range retention.Expired(manifests, now) {
+		if err := store.Delete(ctx, m.ID+"/"+manifestName); err != nil {
+			return deleted, fmt.Errorf("failed to delete backup %s: %w", m.ID, err)
+		}
+		if err := store.Delete(ctx, m.ID+"/"+archiveName); err != nil {
+			return deleted, fmt.Errorf("failed to delete backup %s: %w", m.ID, err)
+		}
+		deleted = append(deleted, m.ID)
+	}
+	return deleted, nil
+}
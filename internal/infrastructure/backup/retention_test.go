@@ -0,0 +1,76 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRetention_Expired(t *testing.T) {
+	now := time.Date(2025, time.July, 15, 3, 0, 0, 0, time.UTC)
+	var manifests []*Manifest
+	// Daily backups from 1 April to 15 July
+	for day := time.Date(2025, time.April, 1, 2, 0, 0, 0, time.UTC); !day.After(now); day = day.AddDate(0, 0, 1) {
+		manifests = append(manifests, &Manifest{ID: day.Format(idLayout), CreatedAt: day})
+	}
+
+	expired := Retention{KeepDays: 7, KeepMonths: 3}.Expired(manifests, now)
+	kept := map[string]bool{}
+	for _, m := range manifests {
+		kept[m.ID] = true
+	}
+	for _, m := range expired {
+		delete(kept, m.ID)
+	}
+
+	// 7 recent days plus the first of May, June and July (1 July is also outside the daily window)
+	expectedKept := []string{"20250501T020000Z", "20250601T020000Z", "20250701T020000Z", "20250709T020000Z", "20250715T020000Z"}
+	for _, id := range expectedKept {
+		if !kept[id] {
+			t.Errorf("expected %s to be kept", id)
+		}
+	}
+	if kept["20250401T020000Z"] || kept["20250708T020000Z"] {
+		t.Error("expected April and 8 July backups to expire")
+	}
+	if len(kept) != 10 {
+		t.Errorf("expected 10 backups kept, got %d", len(kept))
+	}
+
+	// The newest backup survives even a zero retention
+	if got := (Retention{}).Expired(manifests[len(manifests)-2:], now); len(got) != 1 || got[0] != manifests[len(manifests)-2] {
+		t.Errorf("expected only the older backup to expire, got %d", len(got))
+	}
+}
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(t.TempDir())
+	keys := testKeys(t, "k1")
+	source := &memorySource{tables: map[string][]map[string]any{"articles": {{"id": "a1"}}}}
+	policy := &Policy{Version: 1, Tables: []string{"articles"}}
+	now := time.Date(2025, time.July, 15, 3, 0, 0, 0, time.UTC)
+
+	for _, at := range []time.Time{now.AddDate(0, 0, -30), now.AddDate(0, 0, -1), now} {
+		if _, err := Create(ctx, source, store, policy, keys, nil, at); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	deleted, err := Prune(ctx, store, keys, Retention{KeepDays: 7}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "20250615T030000Z" {
+		t.Errorf("expected the 30 day old backup to be pruned, got %v", deleted)
+	}
+
+	remaining, _ := List(ctx, store, keys)
+	if len(remaining) != 2 {
+		t.Errorf("expected 2 backups left, got %d", len(remaining))
+	}
+	objects, _ := store.List(ctx, "20250615T030000Z/")
+	if len(objects) != 0 {
+		t.Errorf("expected pruned objects to be gone, got %v", objects)
+	}
+}
@@ -0,0 +1,293 @@
+package backup
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// emptySHA256 is the payload hash of requests without a body
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config is an identity allowed to read, write, list and delete under the bucket prefix
+type S3Config struct {
+	Region          string
+	Bucket          string
+	Prefix          string // Optional, keys are stored as Prefix + "/" + key
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Set with temporary credentials, e.g. from an instance role
+}
+
+// S3Store keeps objects in an S3 compatible bucket with path-style requests signed
+// with Signature Version 4, so it also works with MinIO and R2. Uploads are a single
+// PUT, which limits an archive to 5 GiB.
+type S3Store struct {
+	httpClient *http.Client
+	endpoint   string
+	cfg        S3Config
+	clock      shared.Clock
+}
+
+// NewS3Store uses the region's public AWS endpoint when endpoint is empty
+func NewS3Store(httpClient *http.Client, endpoint string, cfg S3Config, clock shared.Clock) *S3Store {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if endpoint == "" {
+		endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+	return &S3Store{httpClient: httpClient, endpoint: strings.TrimRight(endpoint, "/"), cfg: cfg, clock: clock}
+}
+
+var _ ObjectStore = (*S3Store)(nil)
+
+// Put spools the content to a temporary file first, S3 needs the length and the
+// payload hash before the upload starts
+func (s *S3Store) Put(ctx context.Context, key string, content io.Reader) error {
+	tmp, err := os.CreateTemp("", "backup-upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), content)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, s.objectKey(key), nil, io.NopCloser(tmp), hex.EncodeToString(hash.Sum(nil)))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, s.objectKey(key), nil, nil, emptySHA256)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.objectKey(prefix)}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := s.newRequest(ctx, http.MethodGet, "", query, nil, emptySHA256)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %w", err)
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode backup listing: %w", err)
+		}
+
+		for _, object := range page.Contents {
+			key := object.Key
+			if s.cfg.Prefix != "" {
+				key = strings.TrimPrefix(key, s.cfg.Prefix+"/")
+			}
+			keys = append(keys, key)
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			break
+		}
+		token = page.NextContinuationToken
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, s.objectKey(key), nil, nil, emptySHA256)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		if isS3NotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) objectKey(key string) string {
+	if s.cfg.Prefix == "" {
+		return key
+	}
+	return s.cfg.Prefix + "/" + key
+}
+
+// newRequest builds a signed path-style request for an object, or for the bucket when key is empty
+func (s *S3Store) newRequest(ctx context.Context, method, key string, query url.Values, body io.ReadCloser, payloadHash string) (*http.Request, error) {
+	path := "/" + escapeS3Path(s.cfg.Bucket)
+	if key != "" {
+		path += "/" + escapeS3Path(key)
+	}
+	target := s.endpoint + path
+	if len(query) > 0 {
+		target += "?" + canonicalQuery(query)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, payloadHash, s.clock.Now().UTC())
+	return req, nil
+}
+
+// s3Error is the XML error body S3 answers failed requests with
+type s3Error struct {
+	Status  int
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (e *s3Error) Error() string {
+	return fmt.Sprintf("object store returned %d %s: %s", e.Status, e.Code, e.Message)
+}
+
+func isS3NotFound(err error) bool {
+	e, ok := err.(*s3Error)
+	return ok && e.Status == http.StatusNotFound
+}
+
+// do sends the request and turns any non-2xx answer into an s3Error
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	e := &s3Error{Status: resp.StatusCode}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(e)
+	return nil, e
+}
+
+// sign adds the Signature Version 4 Authorization header
+func (s *S3Store) sign(req *http.Request, payloadHash string, at time.Time) {
+	amzDate := at.Format("20060102T150405Z")
+	day := at.Format("20060102")
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + s.cfg.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:])}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(s3SigningKey(s.cfg.SecretAccessKey, day, s.cfg.Region), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func s3SigningKey(secret, day, region string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapeS3Path percent-encodes everything but unreserved characters and slashes, as SigV4 expects
+func escapeS3Path(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// canonicalQuery sorts and encodes the query the way SigV4 signs it, spaces as %20
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
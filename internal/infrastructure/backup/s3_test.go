@@ -0,0 +1,138 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// fakeS3 serves path-style object requests for one bucket from memory, one key per listing page
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	auth    []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+
+	key, ok := strings.CutPrefix(r.URL.Path+"/", "/backups/")
+	key = strings.TrimSuffix(key, "/")
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		start := 0
+		if token := r.URL.Query().Get("continuation-token"); token != "" {
+			start = sort.SearchStrings(keys, token)
+		}
+		fmt.Fprint(w, "<ListBucketResult>")
+		if start < len(keys) {
+			fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", keys[start])
+		}
+		if start+1 < len(keys) {
+			fmt.Fprintf(w, "<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>", keys[start+1])
+		}
+		fmt.Fprint(w, "</ListBucketResult>")
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+			writeS3Error(w, http.StatusBadRequest, "XAmzContentSHA256Mismatch")
+			return
+		}
+		f.objects[key] = body
+	case r.Method == http.MethodGet:
+		body, ok := f.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		_, _ = w.Write(body)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	cfg := S3Config{Region: "ap-southeast-1", Bucket: "backups", Prefix: "/cms/", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC))
+	store := NewS3Store(server.Client(), server.URL, cfg, clock)
+
+	for _, key := range []string{"20260309T020000Z/manifest.json", "20260310T020000Z/manifest.json", "20260310T020000Z/archive.bin"} {
+		if err := store.Put(ctx, key, strings.NewReader("content of "+key)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, ok := fake.objects["cms/20260310T020000Z/archive.bin"]; !ok {
+		t.Errorf("expected keys stored under the prefix, got %v", fake.objects)
+	}
+	if !strings.HasPrefix(fake.auth[0], "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260310/ap-southeast-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") {
+		t.Errorf("unexpected authorization %q", fake.auth[0])
+	}
+
+	keys, err := store.List(ctx, "20260310T020000Z/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "20260310T020000Z/archive.bin" || keys[1] != "20260310T020000Z/manifest.json" {
+		t.Errorf("expected both pages of the listing, got %v", keys)
+	}
+
+	r, err := store.Get(ctx, "20260309T020000Z/manifest.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(r)
+	r.Close()
+	if string(body) != "content of 20260309T020000Z/manifest.json" {
+		t.Errorf("unexpected content %q", body)
+	}
+
+	if err := store.Delete(ctx, "20260309T020000Z/manifest.json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Get(ctx, "20260309T020000Z/manifest.json"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("expected ErrObjectNotFound, got %v", err)
+	}
+
+	missing := NewS3Store(server.Client(), server.URL, S3Config{Region: "ap-southeast-1", Bucket: "other"}, clock)
+	if err := missing.Put(ctx, "key", strings.NewReader("x")); err == nil || !strings.Contains(err.Error(), "NoSuchBucket") {
+		t.Errorf("expected the S3 error code surfaced, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SQLSource dumps PostgreSQL tables through database/sql, the driver is registered by the caller
+type SQLSource struct {
+	db *sql.DB
+}
+
+func NewSQLSource(db *sql.DB) *SQLSource {
+	return &SQLSource{db: db}
+}
+
+// Snapshot uses a repeatable read transaction so all tables come from one point in time
+func (s *SQLSource) Snapshot(ctx context.Context) (Snapshot, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return &sqlSnapshot{tx: tx}, nil
+}
+
+type sqlSnapshot struct {
+	tx *sql.Tx
+}
+
+func (s *sqlSnapshot) Dump(ctx context.Context, table string, fn func(row map[string]any) error) error {
+	rows, err := s.tx.QueryContext(ctx, "SELECT * FROM "+quoteIdent(table)+" ORDER BY 1")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		row := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b) // Text protocol values
+			} else {
+				row[column] = values[i]
+			}
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *sqlSnapshot) Close() error {
+	return s.tx.Rollback()
+}
+
+// SQLSink restores into PostgreSQL. Foreign key triggers are disabled for the transaction,
+// which needs a superuser or the table owner, so rows load in any order. The restored tables
+// are truncated without CASCADE, a table outside the backup that references one of them makes
+// the restore fail rather than being emptied along with it.
+type SQLSink struct {
+	db *sql.DB
+}
+
+func NewSQLSink(db *sql.DB) *SQLSink {
+	return &SQLSink{db: db}
+}
+
+func (s *SQLSink) Begin(ctx context.Context, tables []string) (SinkTx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "SET LOCAL session_replication_role = replica"); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	quoted := make([]string, len(tables))
+	for i, table := range tables {
+		quoted[i] = quoteIdent(table)
+	}
+	if _, err := tx.ExecContext(ctx, "TRUNCATE "+strings.Join(quoted, ", ")); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	return &sqlSinkTx{tx: tx}, nil
+}
+
+type sqlSinkTx struct {
+	tx *sql.Tx
+}
+
+func (t *sqlSinkTx) Insert(ctx context.Context, table string, row map[string]any) error {
+	query, args := insertStatement(table, row)
+	_, err := t.tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (t *sqlSinkTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqlSinkTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// insertStatement builds a parameterized INSERT with columns in a stable order
+func insertStatement(table string, row map[string]any) (string, []any) {
+	columns := make([]string, 0, len(row))
+	for column := range row {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	names := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	args := make([]any, len(columns))
+	for i, column := range columns {
+		names[i] = quoteIdent(column)
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = row[column]
+		if n, ok := args[i].(json.Number); ok {
+			args[i] = n.String()
+		}
+	}
+	return "INSERT INTO " + quoteIdent(table) + " (" + strings.Join(names, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ")", args
+}
+
+// quoteIdent quotes a table or column name, schema-qualified names are quoted per part
+func quoteIdent(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,22 @@
+package backup
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInsertStatement(t *testing.T) {
+	query, args := insertStatement("public.user_accounts", map[string]any{
+		"username": "editor",
+		"id":       "u1",
+		"weird\"":  json.Number("42"),
+	})
+
+	expected := `INSERT INTO "public"."user_accounts" ("id", "username", "weird""") VALUES ($1, $2, $3)`
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+	if len(args) != 3 || args[0] != "u1" || args[2] != "42" {
+		t.Errorf("unexpected args %v", args)
+	}
+}
@@ -0,0 +1,209 @@
+package bruteforce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/bruteforce"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/lock"
+)
+
+// incrementScript counts a failure and starts the window on the first one, so
+// the window is fixed from the subject's first failure rather than sliding
+const incrementScript = `local failures = redis.call("incr", KEYS[1])
+if failures == 1 then redis.call("pexpire", KEYS[1], ARGV[1]) end
+return failures`
+
+// saveScript stores the ban and indexes it by expiry in one round trip
+const saveScript = `redis.call("set", KEYS[1], ARGV[1], "px", ARGV[2])
+redis.call("zadd", KEYS[2], ARGV[3], ARGV[4])
+return 1`
+
+// RedisFailureCounter shares login failure counts between every instance through one Redis
+type RedisFailureCounter struct {
+	client *lock.Client
+	prefix string
+}
+
+func NewRedisFailureCounter(client *lock.Client, prefix string) *RedisFailureCounter {
+	return &RedisFailureCounter{client: client, prefix: prefix}
+}
+
+var _ bruteforce.FailureCounter = (*RedisFailureCounter)(nil)
+
+func (c *RedisFailureCounter) Increment(ctx context.Context, subject bruteforce.Subject, window time.Duration) (int, error) {
+	reply, err := c.client.Do(ctx, "EVAL", incrementScript, "1", c.key(subject), strconv.FormatInt(window.Milliseconds(), 10))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count failure for %s: %w", subject.Key(), err)
+	}
+	failures, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected failure count reply %v", reply)
+	}
+	return int(failures), nil
+}
+
+func (c *RedisFailureCounter) Reset(ctx context.Context, subject bruteforce.Subject) error {
+	if _, err := c.client.Do(ctx, "DEL", c.key(subject)); err != nil {
+		return fmt.Errorf("failed to reset failures for %s: %w", subject.Key(), err)
+	}
+	return nil
+}
+
+func (c *RedisFailureCounter) key(subject bruteforce.Subject) string {
+	return c.prefix + "failures:" + subject.Key()
+}
+
+// RedisBanRepository keeps each subject's latest ban under its own expiring key.
+// A sorted set scored by ban expiry indexes them for FindActive, entries are
+// pruned once their ban has run out.
+type RedisBanRepository struct {
+	client *lock.Client
+	prefix string
+}
+
+func NewRedisBanRepository(client *lock.Client, prefix string) *RedisBanRepository {
+	return &RedisBanRepository{client: client, prefix: prefix}
+}
+
+var _ bruteforce.BanRepository = (*RedisBanRepository)(nil)
+
+// banRecord is the stored form of a ban
+type banRecord struct {
+	Scope     bruteforce.Scope `json:"scope"`
+	Value     string           `json:"value"`
+	Failures  int              `json:"failures"`
+	Strike    int              `json:"strike"`
+	BannedAt  time.Time        `json:"banned_at"`
+	ExpiresAt time.Time        `json:"expires_at"`
+	LiftedBy  *string          `json:"lifted_by,omitempty"`
+	LiftedAt  *time.Time       `json:"lifted_at,omitempty"`
+}
+
+// Save forgets the ban right away when ttl is not positive, nothing is left to keep
+func (r *RedisBanRepository) Save(ctx context.Context, ban *bruteforce.Ban, ttl time.Duration) error {
+	member := ban.Subject.Key()
+	if ttl <= 0 {
+		if _, err := r.client.Do(ctx, "DEL", r.banKey(member)); err != nil {
+			return fmt.Errorf("failed to delete ban for %s: %w", member, err)
+		}
+		if _, err := r.client.Do(ctx, "ZREM", r.indexKey(), member); err != nil {
+			return fmt.Errorf("failed to unindex ban for %s: %w", member, err)
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(banRecord{
+		Scope:     ban.Subject.Scope,
+		Value:     ban.Subject.Value,
+		Failures:  ban.Failures,
+		Strike:    ban.Strike,
+		BannedAt:  ban.BannedAt,
+		ExpiresAt: ban.ExpiresAt,
+		LiftedBy:  ban.LiftedBy,
+		LiftedAt:  ban.LiftedAt,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.client.Do(ctx, "EVAL", saveScript, "2", r.banKey(member), r.indexKey(),
+		string(data), strconv.FormatInt(max(ttl.Milliseconds(), 1), 10),
+		strconv.FormatInt(ban.ExpiresAt.UnixMilli(), 10), member)
+	if err != nil {
+		return fmt.Errorf("failed to save ban for %s: %w", member, err)
+	}
+	return nil
+}
+
+func (r *RedisBanRepository) FindLatest(ctx context.Context, subject bruteforce.Subject) (*bruteforce.Ban, error) {
+	reply, err := r.client.Do(ctx, "GET", r.banKey(subject.Key()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ban for %s: %w", subject.Key(), err)
+	}
+	if reply == nil {
+		return nil, nil
+	}
+	data, ok := reply.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ban reply %v", reply)
+	}
+	return decodeBan(data)
+}
+
+func (r *RedisBanRepository) FindActive(ctx context.Context, at time.Time) ([]*bruteforce.Ban, error) {
+	if _, err := r.client.Do(ctx, "ZREMRANGEBYSCORE", r.indexKey(), "-inf", strconv.FormatInt(at.UnixMilli(), 10)); err != nil {
+		return nil, fmt.Errorf("failed to prune ban index: %w", err)
+	}
+	reply, err := r.client.Do(ctx, "ZRANGE", r.indexKey(), "0", "-1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bans: %w", err)
+	}
+	members, ok := reply.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ban index reply %v", reply)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(members)+1)
+	keys = append(keys, "MGET")
+	for _, m := range members {
+		member, ok := m.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected ban index reply %v", reply)
+		}
+		keys = append(keys, r.banKey(member))
+	}
+	reply, err = r.client.Do(ctx, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bans: %w", err)
+	}
+	values, ok := reply.([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected bans reply %v", reply)
+	}
+
+	var bans []*bruteforce.Ban
+	for _, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue // Expired between the two calls
+		}
+		ban, err := decodeBan(data)
+		if err != nil {
+			return nil, err
+		}
+		if ban.IsActive(at) {
+			bans = append(bans, ban)
+		}
+	}
+	return bans, nil
+}
+
+func (r *RedisBanRepository) banKey(member string) string {
+	return r.prefix + "ban:" + member
+}
+
+func (r *RedisBanRepository) indexKey() string {
+	return r.prefix + "bans"
+}
+
+func decodeBan(data string) (*bruteforce.Ban, error) {
+	var rec banRecord
+	if err := json.Unmarshal([]byte(data), &rec); err != nil {
+		return nil, fmt.Errorf("failed to decode ban: %w", err)
+	}
+	return &bruteforce.Ban{
+		Subject:   bruteforce.Subject{Scope: rec.Scope, Value: rec.Value},
+		Failures:  rec.Failures,
+		Strike:    rec.Strike,
+		BannedAt:  rec.BannedAt,
+		ExpiresAt: rec.ExpiresAt,
+		LiftedBy:  rec.LiftedBy,
+		LiftedAt:  rec.LiftedAt,
+	}, nil
+}
@@ -0,0 +1,241 @@
+package bruteforce
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/bruteforce"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/lock"
+)
+
+// fakeRedis runs the commands and scripts the stores send against maps, expiry is recorded but not simulated
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	counts  map[string]int64
+	expires map[string]string
+	index   map[string]int64
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	f := &fakeRedis{values: map[string]string{}, counts: map[string]int64{}, expires: map[string]string{}, index: map[string]int64{}}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f, ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(rd)
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte(f.exec(args)))
+	}
+}
+
+// readCommand reads one array of bulk strings
+func readCommand(rd *bufio.Reader) ([]string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, 0, n)
+	for range n {
+		header, err := rd.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(rd, arg); err != nil {
+			return nil, err
+		}
+		args = append(args, string(arg[:size]))
+	}
+	return args, nil
+}
+
+func bulk(v string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+}
+
+func (f *fakeRedis) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case args[0] == "EVAL" && args[1] == incrementScript:
+		key := args[3]
+		f.counts[key]++
+		if f.counts[key] == 1 {
+			f.expires[key] = args[4]
+		}
+		return fmt.Sprintf(":%d\r\n", f.counts[key])
+	case args[0] == "EVAL" && args[1] == saveScript:
+		f.values[args[3]], f.expires[args[3]] = args[5], args[6]
+		f.index[args[8]], _ = strconv.ParseInt(args[7], 10, 64)
+		return ":1\r\n"
+	case args[0] == "DEL":
+		delete(f.counts, args[1])
+		delete(f.values, args[1])
+		return ":1\r\n"
+	case args[0] == "ZREM":
+		delete(f.index, args[2])
+		return ":1\r\n"
+	case args[0] == "GET":
+		v, ok := f.values[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return bulk(v)
+	case args[0] == "ZREMRANGEBYSCORE":
+		until, _ := strconv.ParseInt(args[3], 10, 64)
+		for member, score := range f.index {
+			if score <= until {
+				delete(f.index, member)
+			}
+		}
+		return ":0\r\n"
+	case args[0] == "ZRANGE":
+		members := make([]string, 0, len(f.index))
+		for member := range f.index {
+			members = append(members, member)
+		}
+		sort.Slice(members, func(i, j int) bool { return f.index[members[i]] < f.index[members[j]] })
+		reply := fmt.Sprintf("*%d\r\n", len(members))
+		for _, m := range members {
+			reply += bulk(m)
+		}
+		return reply
+	case args[0] == "MGET":
+		reply := fmt.Sprintf("*%d\r\n", len(args)-1)
+		for _, key := range args[1:] {
+			if v, ok := f.values[key]; ok {
+				reply += bulk(v)
+			} else {
+				reply += "$-1\r\n"
+			}
+		}
+		return reply
+	}
+	return "-ERR unknown command\r\n"
+}
+
+func TestRedisFailureCounter(t *testing.T) {
+	fake, addr := startFakeRedis(t)
+	client := lock.NewClient(addr, "", 0)
+	t.Cleanup(func() { _ = client.Close() })
+	counter := NewRedisFailureCounter(client, "cms:bruteforce:")
+
+	ctx := context.Background()
+	subject, _ := bruteforce.NewSubject(bruteforce.ScopeIP, "203.0.113.7")
+	for i := 1; i <= 3; i++ {
+		n, err := counter.Increment(ctx, subject, 10*time.Minute)
+		if err != nil || n != i {
+			t.Fatalf("expected count %d, got %d, %v", i, n, err)
+		}
+	}
+	if fake.expires["cms:bruteforce:failures:ip:203.0.113.7"] != "600000" {
+		t.Errorf("expected the window set on the first failure, got %q", fake.expires["cms:bruteforce:failures:ip:203.0.113.7"])
+	}
+
+	if err := counter.Reset(ctx, subject); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, _ := counter.Increment(ctx, subject, 10*time.Minute); n != 1 {
+		t.Errorf("expected the count to restart after a reset, got %d", n)
+	}
+}
+
+func TestRedisBanRepository(t *testing.T) {
+	_, addr := startFakeRedis(t)
+	client := lock.NewClient(addr, "", 0)
+	t.Cleanup(func() { _ = client.Close() })
+	repo := NewRedisBanRepository(client, "cms:bruteforce:")
+
+	ctx := context.Background()
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	ip, _ := bruteforce.NewSubject(bruteforce.ScopeIP, "203.0.113.7")
+	asn, _ := bruteforce.NewSubject(bruteforce.ScopeASN, "64500")
+
+	if ban, err := repo.FindLatest(ctx, ip); err != nil || ban != nil {
+		t.Fatalf("expected no ban yet, got %+v, %v", ban, err)
+	}
+
+	ipBan, _ := bruteforce.NewBan(ip, 20, 2, 30*time.Minute, at)
+	asnBan, _ := bruteforce.NewBan(asn, 300, 1, 15*time.Minute, at)
+	for _, ban := range []*bruteforce.Ban{ipBan, asnBan} {
+		if err := repo.Save(ctx, ban, ban.ExpiresAt.Sub(at)+24*time.Hour); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got, err := repo.FindLatest(ctx, ip)
+	if err != nil || got == nil || got.Strike != 2 || got.Subject != ip || !got.ExpiresAt.Equal(ipBan.ExpiresAt) {
+		t.Fatalf("expected the saved ban back, got %+v, %v", got, err)
+	}
+
+	active, err := repo.FindActive(ctx, at.Add(20*time.Minute))
+	if err != nil || len(active) != 1 || active[0].Subject != ip {
+		t.Errorf("expected only the IP ban still in force, got %+v, %v", active, err)
+	}
+
+	if err := ipBan.Lift("staff-1", at.Add(21*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(ctx, ipBan, 24*time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if active, _ := repo.FindActive(ctx, at.Add(22*time.Minute)); len(active) != 0 {
+		t.Errorf("expected a lifted ban left out, got %+v", active)
+	}
+	if got, _ := repo.FindLatest(ctx, ip); got == nil || got.LiftedBy == nil || *got.LiftedBy != "staff-1" {
+		t.Errorf("expected the lifted ban kept for strikes, got %+v", got)
+	}
+
+	if err := repo.Save(ctx, ipBan, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := repo.FindLatest(ctx, ip); got != nil {
+		t.Errorf("expected a ban without a ttl forgotten, got %+v", got)
+	}
+}
+
+func TestRedisFailureCounter_Unavailable(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	subject, _ := bruteforce.NewSubject(bruteforce.ScopeIP, "203.0.113.7")
+	counter := NewRedisFailureCounter(lock.NewClient(addr, "", 0), "")
+	if _, err := counter.Increment(context.Background(), subject, time.Minute); err == nil {
+		t.Error("expected an error without Redis")
+	}
+}
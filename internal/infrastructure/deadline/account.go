@@ -0,0 +1,150 @@
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// AccountRepository bounds every account storage call. Filtered listings and the
+// sweeps behind scheduled jobs scan many rows and get the report budget.
+type AccountRepository struct {
+	next     account.UserAccountRepository
+	timeouts Timeouts
+}
+
+func NewAccountRepository(next account.UserAccountRepository, timeouts Timeouts) *AccountRepository {
+	return &AccountRepository{next: next, timeouts: timeouts}
+}
+
+var _ account.UserAccountRepository = (*AccountRepository)(nil)
+
+// Commands
+
+func (r *AccountRepository) Create(ctx context.Context, acc *account.UserAccount) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "account.Create", func(ctx context.Context) error {
+		return r.next.Create(ctx, acc)
+	})
+}
+
+func (r *AccountRepository) Update(ctx context.Context, acc *account.UserAccount) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "account.Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, acc)
+	})
+}
+
+func (r *AccountRepository) Delete(ctx context.Context, id string) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "account.Delete", func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// Queries
+
+func (r *AccountRepository) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	return r.findOne(ctx, "account.FindByID", func(ctx context.Context) (*account.UserAccount, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+func (r *AccountRepository) FindByUsername(ctx context.Context, username string) (*account.UserAccount, error) {
+	return r.findOne(ctx, "account.FindByUsername", func(ctx context.Context) (*account.UserAccount, error) {
+		return r.next.FindByUsername(ctx, username)
+	})
+}
+
+func (r *AccountRepository) FindByEmail(ctx context.Context, email string) (*account.UserAccount, error) {
+	return r.findOne(ctx, "account.FindByEmail", func(ctx context.Context) (*account.UserAccount, error) {
+		return r.next.FindByEmail(ctx, email)
+	})
+}
+
+func (r *AccountRepository) FindActiveByEmail(ctx context.Context, email string) (*account.UserAccount, error) {
+	return r.findOne(ctx, "account.FindActiveByEmail", func(ctx context.Context) (*account.UserAccount, error) {
+		return r.next.FindActiveByEmail(ctx, email)
+	})
+}
+
+func (r *AccountRepository) FindVerifiedByUsername(ctx context.Context, username string) (*account.UserAccount, error) {
+	return r.findOne(ctx, "account.FindVerifiedByUsername", func(ctx context.Context) (*account.UserAccount, error) {
+		return r.next.FindVerifiedByUsername(ctx, username)
+	})
+}
+
+func (r *AccountRepository) ExistsByID(ctx context.Context, id string) (bool, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "account.ExistsByID", func(ctx context.Context) (bool, error) {
+		return r.next.ExistsByID(ctx, id)
+	})
+}
+
+func (r *AccountRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "account.ExistsByUsername", func(ctx context.Context) (bool, error) {
+		return r.next.ExistsByUsername(ctx, username)
+	})
+}
+
+func (r *AccountRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "account.ExistsByEmail", func(ctx context.Context) (bool, error) {
+		return r.next.ExistsByEmail(ctx, email)
+	})
+}
+
+func (r *AccountRepository) Find(ctx context.Context, filter *account.UserAccountFilter) ([]*account.UserAccount, error) {
+	return r.findMany(ctx, "account.Find", func(ctx context.Context) ([]*account.UserAccount, error) {
+		return r.next.Find(ctx, filter)
+	})
+}
+
+func (r *AccountRepository) Count(ctx context.Context, filter *account.UserAccountFilter) (int64, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "account.Count", func(ctx context.Context) (int64, error) {
+		return r.next.Count(ctx, filter)
+	})
+}
+
+func (r *AccountRepository) FindExpiredAccounts(ctx context.Context, expiredBefore time.Time) ([]*account.UserAccount, error) {
+	return r.findMany(ctx, "account.FindExpiredAccounts", func(ctx context.Context) ([]*account.UserAccount, error) {
+		return r.next.FindExpiredAccounts(ctx, expiredBefore)
+	})
+}
+
+func (r *AccountRepository) FindAccountsForCleanup(ctx context.Context, deletedBefore time.Time) ([]*account.UserAccount, error) {
+	return r.findMany(ctx, "account.FindAccountsForCleanup", func(ctx context.Context) ([]*account.UserAccount, error) {
+		return r.next.FindAccountsForCleanup(ctx, deletedBefore)
+	})
+}
+
+func (r *AccountRepository) FindDisabledAccounts(ctx context.Context, disabilityType *account.DisabilityType) ([]*account.UserAccount, error) {
+	return r.findMany(ctx, "account.FindDisabledAccounts", func(ctx context.Context) ([]*account.UserAccount, error) {
+		return r.next.FindDisabledAccounts(ctx, disabilityType)
+	})
+}
+
+func (r *AccountRepository) FindSuspendedAccounts(ctx context.Context) ([]*account.UserAccount, error) {
+	return r.findMany(ctx, "account.FindSuspendedAccounts", r.next.FindSuspendedAccounts)
+}
+
+func (r *AccountRepository) FindElapsedSuspensions(ctx context.Context, at time.Time) ([]*account.UserAccount, error) {
+	return r.findMany(ctx, "account.FindElapsedSuspensions", func(ctx context.Context) ([]*account.UserAccount, error) {
+		return r.next.FindElapsedSuspensions(ctx, at)
+	})
+}
+
+func (r *AccountRepository) FindBlockedAccounts(ctx context.Context) ([]*account.UserAccount, error) {
+	return r.findMany(ctx, "account.FindBlockedAccounts", r.next.FindBlockedAccounts)
+}
+
+func (r *AccountRepository) FindInactiveAccounts(ctx context.Context, inactiveSince time.Time) ([]*account.UserAccount, error) {
+	return r.findMany(ctx, "account.FindInactiveAccounts", func(ctx context.Context) ([]*account.UserAccount, error) {
+		return r.next.FindInactiveAccounts(ctx, inactiveSince)
+	})
+}
+
+func (r *AccountRepository) findOne(ctx context.Context, operation string, fn func(ctx context.Context) (*account.UserAccount, error)) (*account.UserAccount, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, operation, fn)
+}
+
+func (r *AccountRepository) findMany(ctx context.Context, operation string, fn func(ctx context.Context) ([]*account.UserAccount, error)) ([]*account.UserAccount, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, operation, fn)
+}
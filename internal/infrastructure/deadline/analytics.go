@@ -0,0 +1,45 @@
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/analytics"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// AnalyticsStore bounds the analytics backend, dashboard queries get the report budget
+type AnalyticsStore struct {
+	next     analytics.Store
+	timeouts Timeouts
+}
+
+func NewAnalyticsStore(next analytics.Store, timeouts Timeouts) *AnalyticsStore {
+	return &AnalyticsStore{next: next, timeouts: timeouts}
+}
+
+var _ analytics.Store = (*AnalyticsStore)(nil)
+
+func (s *AnalyticsStore) WriteEvents(ctx context.Context, events []*analytics.Event) error {
+	return s.timeouts.Run(ctx, shared.OperationWrite, "analytics.WriteEvents", func(ctx context.Context) error {
+		return s.next.WriteEvents(ctx, events)
+	})
+}
+
+func (s *AnalyticsStore) Trending(ctx context.Context, window time.Duration, at time.Time, limit int) ([]analytics.ArticleMetric, error) {
+	return Do(ctx, s.timeouts, shared.OperationReport, "analytics.Trending", func(ctx context.Context) ([]analytics.ArticleMetric, error) {
+		return s.next.Trending(ctx, window, at, limit)
+	})
+}
+
+func (s *AnalyticsStore) ArticleSeries(ctx context.Context, articleID string, r analytics.TimeRange, granularity analytics.Granularity) ([]analytics.Bucket, error) {
+	return Do(ctx, s.timeouts, shared.OperationReport, "analytics.ArticleSeries", func(ctx context.Context) ([]analytics.Bucket, error) {
+		return s.next.ArticleSeries(ctx, articleID, r, granularity)
+	})
+}
+
+func (s *AnalyticsStore) AuthorSummary(ctx context.Context, authorID string, r analytics.TimeRange, topLimit int) (*analytics.AuthorSummary, error) {
+	return Do(ctx, s.timeouts, shared.OperationReport, "analytics.AuthorSummary", func(ctx context.Context) (*analytics.AuthorSummary, error) {
+		return s.next.AuthorSummary(ctx, authorID, r, topLimit)
+	})
+}
@@ -0,0 +1,67 @@
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/appeal"
+)
+
+// AppealRepository bounds appeal storage, the review queue and filtered listings get the report budget
+type AppealRepository struct {
+	next     appeal.AppealRepository
+	timeouts Timeouts
+}
+
+func NewAppealRepository(next appeal.AppealRepository, timeouts Timeouts) *AppealRepository {
+	return &AppealRepository{next: next, timeouts: timeouts}
+}
+
+var _ appeal.AppealRepository = (*AppealRepository)(nil)
+
+func (r *AppealRepository) Create(ctx context.Context, a *appeal.Appeal) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "appeal.Create", func(ctx context.Context) error {
+		return r.next.Create(ctx, a)
+	})
+}
+
+func (r *AppealRepository) Update(ctx context.Context, a *appeal.Appeal) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "appeal.Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, a)
+	})
+}
+
+func (r *AppealRepository) FindByID(ctx context.Context, id string) (*appeal.Appeal, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "appeal.FindByID", func(ctx context.Context) (*appeal.Appeal, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+func (r *AppealRepository) Find(ctx context.Context, filter *appeal.AppealFilter) ([]*appeal.Appeal, error) {
+	return r.findMany(ctx, "appeal.Find", func(ctx context.Context) ([]*appeal.Appeal, error) {
+		return r.next.Find(ctx, filter)
+	})
+}
+
+func (r *AppealRepository) FindByAccountID(ctx context.Context, accountID string) ([]*appeal.Appeal, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "appeal.FindByAccountID", func(ctx context.Context) ([]*appeal.Appeal, error) {
+		return r.next.FindByAccountID(ctx, accountID)
+	})
+}
+
+func (r *AppealRepository) FindReviewQueue(ctx context.Context, limit, offset int) ([]*appeal.Appeal, error) {
+	return r.findMany(ctx, "appeal.FindReviewQueue", func(ctx context.Context) ([]*appeal.Appeal, error) {
+		return r.next.FindReviewQueue(ctx, limit, offset)
+	})
+}
+
+func (r *AppealRepository) ExistsPendingForDisable(ctx context.Context, accountID string, disabledAt time.Time) (bool, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "appeal.ExistsPendingForDisable", func(ctx context.Context) (bool, error) {
+		return r.next.ExistsPendingForDisable(ctx, accountID, disabledAt)
+	})
+}
+
+func (r *AppealRepository) findMany(ctx context.Context, operation string, fn func(ctx context.Context) ([]*appeal.Appeal, error)) ([]*appeal.Appeal, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, operation, fn)
+}
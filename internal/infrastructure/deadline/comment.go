@@ -0,0 +1,211 @@
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// CommentRepository bounds comment storage. Thread pages are served to readers and get
+// the read budget, portability exports, subscription sweeps and moderation counts the
+// report budget.
+type CommentRepository struct {
+	next     comment.CommentRepository
+	timeouts Timeouts
+}
+
+func NewCommentRepository(next comment.CommentRepository, timeouts Timeouts) *CommentRepository {
+	return &CommentRepository{next: next, timeouts: timeouts}
+}
+
+var _ comment.CommentRepository = (*CommentRepository)(nil)
+
+// Commands
+
+func (r *CommentRepository) Create(ctx context.Context, c *comment.Comment) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "comment.Create", func(ctx context.Context) error {
+		return r.next.Create(ctx, c)
+	})
+}
+
+func (r *CommentRepository) Update(ctx context.Context, c *comment.Comment) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "comment.Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, c)
+	})
+}
+
+func (r *CommentRepository) NextSequence(ctx context.Context, articleID string, parentID *string) (uint32, error) {
+	return Do(ctx, r.timeouts, shared.OperationWrite, "comment.NextSequence", func(ctx context.Context) (uint32, error) {
+		return r.next.NextSequence(ctx, articleID, parentID)
+	})
+}
+
+func (r *CommentRepository) IncrementCounts(ctx context.Context, articleID string, replyPath comment.Path) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "comment.IncrementCounts", func(ctx context.Context) error {
+		return r.next.IncrementCounts(ctx, articleID, replyPath)
+	})
+}
+
+// Queries
+
+func (r *CommentRepository) FindByID(ctx context.Context, id string) (*comment.Comment, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "comment.FindByID", func(ctx context.Context) (*comment.Comment, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+func (r *CommentRepository) FindPage(ctx context.Context, query comment.PageQuery) ([]*comment.Comment, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "comment.FindPage", func(ctx context.Context) ([]*comment.Comment, error) {
+		return r.next.FindPage(ctx, query)
+	})
+}
+
+func (r *CommentRepository) FindReplyPreviews(ctx context.Context, parentIDs []string, perParent int) (map[string][]*comment.Comment, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "comment.FindReplyPreviews", func(ctx context.Context) (map[string][]*comment.Comment, error) {
+		return r.next.FindReplyPreviews(ctx, parentIDs, perParent)
+	})
+}
+
+func (r *CommentRepository) FindSubtree(ctx context.Context, articleID string, root comment.Path, limit int) ([]*comment.Comment, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "comment.FindSubtree", func(ctx context.Context) ([]*comment.Comment, error) {
+		return r.next.FindSubtree(ctx, articleID, root, limit)
+	})
+}
+
+func (r *CommentRepository) CountTopLevel(ctx context.Context, articleID string) (int, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "comment.CountTopLevel", func(ctx context.Context) (int, error) {
+		return r.next.CountTopLevel(ctx, articleID)
+	})
+}
+
+func (r *CommentRepository) FindByAuthor(ctx context.Context, accountID string, limit, offset int) ([]*comment.Comment, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "comment.FindByAuthor", func(ctx context.Context) ([]*comment.Comment, error) {
+		return r.next.FindByAuthor(ctx, accountID, limit, offset)
+	})
+}
+
+func (r *CommentRepository) FindByImportRef(ctx context.Context, ref string) (*comment.Comment, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "comment.FindByImportRef", func(ctx context.Context) (*comment.Comment, error) {
+		return r.next.FindByImportRef(ctx, ref)
+	})
+}
+
+func (r *CommentRepository) FindApprovedSince(ctx context.Context, articleID string, root *comment.Path, since time.Time, limit int) ([]*comment.Comment, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "comment.FindApprovedSince", func(ctx context.Context) ([]*comment.Comment, error) {
+		return r.next.FindApprovedSince(ctx, articleID, root, since, limit)
+	})
+}
+
+func (r *CommentRepository) FindQueue(ctx context.Context, query comment.QueueQuery) ([]*comment.Comment, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "comment.FindQueue", func(ctx context.Context) ([]*comment.Comment, error) {
+		return r.next.FindQueue(ctx, query)
+	})
+}
+
+func (r *CommentRepository) CountByStatus(ctx context.Context, articleID *string) (map[comment.Status]int, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "comment.CountByStatus", func(ctx context.Context) (map[comment.Status]int, error) {
+		return r.next.CountByStatus(ctx, articleID)
+	})
+}
+
+// ReactionRepository bounds comment reactions
+type ReactionRepository struct {
+	next     comment.ReactionRepository
+	timeouts Timeouts
+}
+
+func NewReactionRepository(next comment.ReactionRepository, timeouts Timeouts) *ReactionRepository {
+	return &ReactionRepository{next: next, timeouts: timeouts}
+}
+
+var _ comment.ReactionRepository = (*ReactionRepository)(nil)
+
+func (r *ReactionRepository) Create(ctx context.Context, reaction *comment.Reaction) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "reaction.Create", func(ctx context.Context) error {
+		return r.next.Create(ctx, reaction)
+	})
+}
+
+func (r *ReactionRepository) Exists(ctx context.Context, commentID, accountID string) (bool, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "reaction.Exists", func(ctx context.Context) (bool, error) {
+		return r.next.Exists(ctx, commentID, accountID)
+	})
+}
+
+func (r *ReactionRepository) FindByAccount(ctx context.Context, accountID string) ([]*comment.Reaction, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "reaction.FindByAccount", func(ctx context.Context) ([]*comment.Reaction, error) {
+		return r.next.FindByAccount(ctx, accountID)
+	})
+}
+
+// SubscriptionRepository bounds reply notification subscriptions, the pending sweep gets the report budget
+type SubscriptionRepository struct {
+	next     comment.SubscriptionRepository
+	timeouts Timeouts
+}
+
+func NewSubscriptionRepository(next comment.SubscriptionRepository, timeouts Timeouts) *SubscriptionRepository {
+	return &SubscriptionRepository{next: next, timeouts: timeouts}
+}
+
+var _ comment.SubscriptionRepository = (*SubscriptionRepository)(nil)
+
+func (r *SubscriptionRepository) Create(ctx context.Context, s *comment.Subscription) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "subscription.Create", func(ctx context.Context) error {
+		return r.next.Create(ctx, s)
+	})
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, s *comment.Subscription) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "subscription.Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, s)
+	})
+}
+
+func (r *SubscriptionRepository) Delete(ctx context.Context, id string) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "subscription.Delete", func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+func (r *SubscriptionRepository) FindByID(ctx context.Context, id string) (*comment.Subscription, error) {
+	return r.findOne(ctx, "subscription.FindByID", func(ctx context.Context) (*comment.Subscription, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+func (r *SubscriptionRepository) FindByUnsubscribeToken(ctx context.Context, token string) (*comment.Subscription, error) {
+	return r.findOne(ctx, "subscription.FindByUnsubscribeToken", func(ctx context.Context) (*comment.Subscription, error) {
+		return r.next.FindByUnsubscribeToken(ctx, token)
+	})
+}
+
+func (r *SubscriptionRepository) Find(ctx context.Context, accountID, articleID string, commentID *string) (*comment.Subscription, error) {
+	return r.findOne(ctx, "subscription.Find", func(ctx context.Context) (*comment.Subscription, error) {
+		return r.next.Find(ctx, accountID, articleID, commentID)
+	})
+}
+
+func (r *SubscriptionRepository) FindByAccount(ctx context.Context, accountID string) ([]*comment.Subscription, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "subscription.FindByAccount", func(ctx context.Context) ([]*comment.Subscription, error) {
+		return r.next.FindByAccount(ctx, accountID)
+	})
+}
+
+func (r *SubscriptionRepository) CountByAccount(ctx context.Context, accountID string) (int, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "subscription.CountByAccount", func(ctx context.Context) (int, error) {
+		return r.next.CountByAccount(ctx, accountID)
+	})
+}
+
+func (r *SubscriptionRepository) FindPendingAccounts(ctx context.Context, after string, limit int) ([]string, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "subscription.FindPendingAccounts", func(ctx context.Context) ([]string, error) {
+		return r.next.FindPendingAccounts(ctx, after, limit)
+	})
+}
+
+func (r *SubscriptionRepository) findOne(ctx context.Context, operation string, fn func(ctx context.Context) (*comment.Subscription, error)) (*comment.Subscription, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, operation, fn)
+}
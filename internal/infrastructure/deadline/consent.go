@@ -0,0 +1,63 @@
+package deadline
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/consent"
+)
+
+// ConsentRecordRepository bounds consent records, they are checked before every tracked send
+type ConsentRecordRepository struct {
+	next     consent.RecordRepository
+	timeouts Timeouts
+}
+
+func NewConsentRecordRepository(next consent.RecordRepository, timeouts Timeouts) *ConsentRecordRepository {
+	return &ConsentRecordRepository{next: next, timeouts: timeouts}
+}
+
+var _ consent.RecordRepository = (*ConsentRecordRepository)(nil)
+
+func (r *ConsentRecordRepository) Save(ctx context.Context, record *consent.Record) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "consent.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, record)
+	})
+}
+
+func (r *ConsentRecordRepository) FindBySubject(ctx context.Context, subject consent.Subject) (*consent.Record, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "consent.FindBySubject", func(ctx context.Context) (*consent.Record, error) {
+		return r.next.FindBySubject(ctx, subject)
+	})
+}
+
+// FindBySubjects backs segment sends that check a whole page of subscribers at once
+func (r *ConsentRecordRepository) FindBySubjects(ctx context.Context, subjects []consent.Subject) ([]*consent.Record, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "consent.FindBySubjects", func(ctx context.Context) ([]*consent.Record, error) {
+		return r.next.FindBySubjects(ctx, subjects)
+	})
+}
+
+// ConsentAuditRepository bounds the consent change history
+type ConsentAuditRepository struct {
+	next     consent.AuditRepository
+	timeouts Timeouts
+}
+
+func NewConsentAuditRepository(next consent.AuditRepository, timeouts Timeouts) *ConsentAuditRepository {
+	return &ConsentAuditRepository{next: next, timeouts: timeouts}
+}
+
+var _ consent.AuditRepository = (*ConsentAuditRepository)(nil)
+
+func (r *ConsentAuditRepository) Append(ctx context.Context, changes []*consent.Change) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "consent.Append", func(ctx context.Context) error {
+		return r.next.Append(ctx, changes)
+	})
+}
+
+func (r *ConsentAuditRepository) FindBySubject(ctx context.Context, subject consent.Subject, limit, offset int) ([]*consent.Change, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "consent.FindChanges", func(ctx context.Context) ([]*consent.Change, error) {
+		return r.next.FindBySubject(ctx, subject, limit, offset)
+	})
+}
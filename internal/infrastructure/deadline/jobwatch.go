@@ -0,0 +1,36 @@
+package deadline
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/jobwatch"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// JobRepository bounds the scheduled job registry, every job run checks in through it
+type JobRepository struct {
+	next     jobwatch.JobRepository
+	timeouts Timeouts
+}
+
+func NewJobRepository(next jobwatch.JobRepository, timeouts Timeouts) *JobRepository {
+	return &JobRepository{next: next, timeouts: timeouts}
+}
+
+var _ jobwatch.JobRepository = (*JobRepository)(nil)
+
+func (r *JobRepository) Save(ctx context.Context, job *jobwatch.Job) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "jobwatch.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, job)
+	})
+}
+
+func (r *JobRepository) FindByName(ctx context.Context, name string) (*jobwatch.Job, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "jobwatch.FindByName", func(ctx context.Context) (*jobwatch.Job, error) {
+		return r.next.FindByName(ctx, name)
+	})
+}
+
+func (r *JobRepository) FindAll(ctx context.Context) ([]*jobwatch.Job, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "jobwatch.FindAll", r.next.FindAll)
+}
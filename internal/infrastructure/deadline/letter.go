@@ -0,0 +1,77 @@
+package deadline
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/letter"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// LetterRepository bounds letters to the editor, the editorial queue, filtered listings
+// and the acknowledgment sweep get the report budget
+type LetterRepository struct {
+	next     letter.LetterSubmissionRepository
+	timeouts Timeouts
+}
+
+func NewLetterRepository(next letter.LetterSubmissionRepository, timeouts Timeouts) *LetterRepository {
+	return &LetterRepository{next: next, timeouts: timeouts}
+}
+
+var _ letter.LetterSubmissionRepository = (*LetterRepository)(nil)
+
+func (r *LetterRepository) Create(ctx context.Context, l *letter.LetterSubmission) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "letter.Create", func(ctx context.Context) error {
+		return r.next.Create(ctx, l)
+	})
+}
+
+func (r *LetterRepository) Update(ctx context.Context, l *letter.LetterSubmission) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "letter.Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, l)
+	})
+}
+
+func (r *LetterRepository) FindByID(ctx context.Context, id string) (*letter.LetterSubmission, error) {
+	return r.findOne(ctx, "letter.FindByID", func(ctx context.Context) (*letter.LetterSubmission, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+func (r *LetterRepository) FindByArticleID(ctx context.Context, articleID string) (*letter.LetterSubmission, error) {
+	return r.findOne(ctx, "letter.FindByArticleID", func(ctx context.Context) (*letter.LetterSubmission, error) {
+		return r.next.FindByArticleID(ctx, articleID)
+	})
+}
+
+func (r *LetterRepository) Find(ctx context.Context, filter *letter.LetterFilter) ([]*letter.LetterSubmission, error) {
+	return r.findMany(ctx, "letter.Find", func(ctx context.Context) ([]*letter.LetterSubmission, error) {
+		return r.next.Find(ctx, filter)
+	})
+}
+
+func (r *LetterRepository) Count(ctx context.Context, filter *letter.LetterFilter) (int64, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "letter.Count", func(ctx context.Context) (int64, error) {
+		return r.next.Count(ctx, filter)
+	})
+}
+
+func (r *LetterRepository) FindEditorialQueue(ctx context.Context, limit, offset int) ([]*letter.LetterSubmission, error) {
+	return r.findMany(ctx, "letter.FindEditorialQueue", func(ctx context.Context) ([]*letter.LetterSubmission, error) {
+		return r.next.FindEditorialQueue(ctx, limit, offset)
+	})
+}
+
+func (r *LetterRepository) FindUnacknowledged(ctx context.Context, limit int) ([]*letter.LetterSubmission, error) {
+	return r.findMany(ctx, "letter.FindUnacknowledged", func(ctx context.Context) ([]*letter.LetterSubmission, error) {
+		return r.next.FindUnacknowledged(ctx, limit)
+	})
+}
+
+func (r *LetterRepository) findOne(ctx context.Context, operation string, fn func(ctx context.Context) (*letter.LetterSubmission, error)) (*letter.LetterSubmission, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, operation, fn)
+}
+
+func (r *LetterRepository) findMany(ctx context.Context, operation string, fn func(ctx context.Context) ([]*letter.LetterSubmission, error)) ([]*letter.LetterSubmission, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, operation, fn)
+}
@@ -0,0 +1,122 @@
+package deadline
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// DocumentRepository bounds the document metadata store, the scan sweeps get the report budget
+type DocumentRepository struct {
+	next     media.DocumentRepository
+	timeouts Timeouts
+}
+
+func NewDocumentRepository(next media.DocumentRepository, timeouts Timeouts) *DocumentRepository {
+	return &DocumentRepository{next: next, timeouts: timeouts}
+}
+
+var _ media.DocumentRepository = (*DocumentRepository)(nil)
+
+func (r *DocumentRepository) Create(ctx context.Context, document *media.Document) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "media.Create", func(ctx context.Context) error {
+		return r.next.Create(ctx, document)
+	})
+}
+
+func (r *DocumentRepository) Update(ctx context.Context, document *media.Document) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "media.Update", func(ctx context.Context) error {
+		return r.next.Update(ctx, document)
+	})
+}
+
+func (r *DocumentRepository) FindByID(ctx context.Context, id string) (*media.Document, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "media.FindByID", func(ctx context.Context) (*media.Document, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+func (r *DocumentRepository) FindPendingScan(ctx context.Context, limit int) ([]*media.Document, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "media.FindPendingScan", func(ctx context.Context) ([]*media.Document, error) {
+		return r.next.FindPendingScan(ctx, limit)
+	})
+}
+
+func (r *DocumentRepository) MarkRescan(ctx context.Context, uploadedSince time.Time) (int64, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "media.MarkRescan", func(ctx context.Context) (int64, error) {
+		return r.next.MarkRescan(ctx, uploadedSince)
+	})
+}
+
+// BlobStore bounds the document bucket. Uploads stream the whole file and get the
+// report budget. Open only bounds the wait for the blob, reading it afterwards is
+// limited by the caller's context alone so large downloads are not cut off.
+type BlobStore struct {
+	next     media.BlobStore
+	timeouts Timeouts
+}
+
+func NewBlobStore(next media.BlobStore, timeouts Timeouts) *BlobStore {
+	return &BlobStore{next: next, timeouts: timeouts}
+}
+
+var _ media.BlobStore = (*BlobStore)(nil)
+
+func (s *BlobStore) Put(ctx context.Context, key string, content io.Reader) error {
+	return s.timeouts.Run(ctx, shared.OperationReport, "media.Put", func(ctx context.Context) error {
+		return s.next.Put(ctx, key, content)
+	})
+}
+
+func (s *BlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	limit := s.timeouts.For(shared.OperationRead)
+	callCtx, cancel := context.WithCancel(ctx)
+	timer := time.AfterFunc(limit, cancel)
+
+	blob, err := s.next.Open(callCtx, key)
+	if !timer.Stop() {
+		// The budget ran out while opening, whatever came back is unusable
+		if blob != nil {
+			blob.Close()
+		}
+		cancel()
+		if err == nil {
+			err = context.DeadlineExceeded
+		}
+		return nil, &shared.TimeoutError{Operation: "media.Open", Kind: shared.OperationRead, Limit: limit, Err: err}
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &cancelOnClose{ReadCloser: blob, cancel: cancel}, nil
+}
+
+func (s *BlobStore) Quarantine(ctx context.Context, key string) error {
+	return s.timeouts.Run(ctx, shared.OperationWrite, "media.Quarantine", func(ctx context.Context) error {
+		return s.next.Quarantine(ctx, key)
+	})
+}
+
+func (s *BlobStore) Release(ctx context.Context, key string) error {
+	return s.timeouts.Run(ctx, shared.OperationWrite, "media.Release", func(ctx context.Context) error {
+		return s.next.Release(ctx, key)
+	})
+}
+
+// cancelOnClose releases the open call's context once the reader is done with
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+	once   sync.Once
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.once.Do(c.cancel)
+	return err
+}
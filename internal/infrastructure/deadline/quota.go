@@ -0,0 +1,39 @@
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/quota"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// UsageRepository bounds the quota counters, they sit on the hot path of every partner call
+type UsageRepository struct {
+	next     quota.UsageRepository
+	timeouts Timeouts
+}
+
+func NewUsageRepository(next quota.UsageRepository, timeouts Timeouts) *UsageRepository {
+	return &UsageRepository{next: next, timeouts: timeouts}
+}
+
+var _ quota.UsageRepository = (*UsageRepository)(nil)
+
+func (r *UsageRepository) Increment(ctx context.Context, keys []quota.CounterKey, n int64) ([]int64, error) {
+	return Do(ctx, r.timeouts, shared.OperationWrite, "quota.Increment", func(ctx context.Context) ([]int64, error) {
+		return r.next.Increment(ctx, keys, n)
+	})
+}
+
+func (r *UsageRepository) Get(ctx context.Context, keys []quota.CounterKey) ([]int64, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "quota.Get", func(ctx context.Context) ([]int64, error) {
+		return r.next.Get(ctx, keys)
+	})
+}
+
+func (r *UsageRepository) FindHistory(ctx context.Context, accountID string, period quota.Period, from, to time.Time) ([]quota.Usage, error) {
+	return Do(ctx, r.timeouts, shared.OperationReport, "quota.FindHistory", func(ctx context.Context) ([]quota.Usage, error) {
+		return r.next.FindHistory(ctx, accountID, period, from, to)
+	})
+}
@@ -0,0 +1,63 @@
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/sanction"
+)
+
+// ViolationRepository bounds the violation history the sanction ladder is evaluated on
+type ViolationRepository struct {
+	next     sanction.ViolationRepository
+	timeouts Timeouts
+}
+
+func NewViolationRepository(next sanction.ViolationRepository, timeouts Timeouts) *ViolationRepository {
+	return &ViolationRepository{next: next, timeouts: timeouts}
+}
+
+var _ sanction.ViolationRepository = (*ViolationRepository)(nil)
+
+func (r *ViolationRepository) Create(ctx context.Context, violation *sanction.Violation) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "sanction.Create", func(ctx context.Context) error {
+		return r.next.Create(ctx, violation)
+	})
+}
+
+func (r *ViolationRepository) FindByID(ctx context.Context, id string) (*sanction.Violation, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "sanction.FindByID", func(ctx context.Context) (*sanction.Violation, error) {
+		return r.next.FindByID(ctx, id)
+	})
+}
+
+func (r *ViolationRepository) FindByAccountID(ctx context.Context, accountID string, category sanction.Category, since time.Time) ([]*sanction.Violation, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "sanction.FindByAccountID", func(ctx context.Context) ([]*sanction.Violation, error) {
+		return r.next.FindByAccountID(ctx, accountID, category, since)
+	})
+}
+
+// LadderRepository bounds the per-category sanction ladder overrides
+type LadderRepository struct {
+	next     sanction.LadderRepository
+	timeouts Timeouts
+}
+
+func NewLadderRepository(next sanction.LadderRepository, timeouts Timeouts) *LadderRepository {
+	return &LadderRepository{next: next, timeouts: timeouts}
+}
+
+var _ sanction.LadderRepository = (*LadderRepository)(nil)
+
+func (r *LadderRepository) Save(ctx context.Context, ladder *sanction.Ladder) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "ladder.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, ladder)
+	})
+}
+
+func (r *LadderRepository) FindByCategory(ctx context.Context, category sanction.Category) (*sanction.Ladder, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "ladder.FindByCategory", func(ctx context.Context) (*sanction.Ladder, error) {
+		return r.next.FindByCategory(ctx, category)
+	})
+}
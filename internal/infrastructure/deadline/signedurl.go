@@ -0,0 +1,32 @@
+package deadline
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared/signedurl"
+)
+
+// KeyringRepository bounds the signing keyrings, they are loaded to verify every signed link
+type KeyringRepository struct {
+	next     signedurl.KeyringRepository
+	timeouts Timeouts
+}
+
+func NewKeyringRepository(next signedurl.KeyringRepository, timeouts Timeouts) *KeyringRepository {
+	return &KeyringRepository{next: next, timeouts: timeouts}
+}
+
+var _ signedurl.KeyringRepository = (*KeyringRepository)(nil)
+
+func (r *KeyringRepository) Save(ctx context.Context, keyring *signedurl.Keyring) error {
+	return r.timeouts.Run(ctx, shared.OperationWrite, "signedurl.Save", func(ctx context.Context) error {
+		return r.next.Save(ctx, keyring)
+	})
+}
+
+func (r *KeyringRepository) FindByScope(ctx context.Context, scope signedurl.Scope) (*signedurl.Keyring, error) {
+	return Do(ctx, r.timeouts, shared.OperationRead, "signedurl.FindByScope", func(ctx context.Context) (*signedurl.Keyring, error) {
+		return r.next.FindByScope(ctx, scope)
+	})
+}
@@ -0,0 +1,109 @@
+// Package deadline bounds storage calls with a time budget per operation kind.
+//
+// Decorators cover the account, consent, appeal and sanction repositories, comments with
+// their reactions and subscriptions, letters to the editor, media documents and blobs,
+// scheduled jobs, signing keyrings, the analytics store and the quota counters. Ports
+// without a decorator are bounded only by the request deadline of middleware.Deadline.
+package deadline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// Timeouts is the time budget per operation kind, a zero value falls back to the default
+type Timeouts struct {
+	Read   time.Duration
+	Write  time.Duration
+	Report time.Duration
+}
+
+// DefaultTimeouts keep single-row lookups snappy and give dashboards room to aggregate
+var DefaultTimeouts = Timeouts{
+	Read:   200 * time.Millisecond,
+	Write:  time.Second,
+	Report: 10 * time.Second,
+}
+
+// ParseTimeouts reads a spec like "read=200ms,write=1s,report=10s", omitted kinds keep their default
+func ParseTimeouts(spec string) (Timeouts, error) {
+	t := DefaultTimeouts
+	if strings.TrimSpace(spec) == "" {
+		return t, nil
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		kind, raw, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return Timeouts{}, fmt.Errorf("invalid timeout %q, expected kind=duration", pair)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil || d <= 0 {
+			return Timeouts{}, fmt.Errorf("invalid duration for %s timeout: %q", kind, raw)
+		}
+
+		switch shared.OperationKind(strings.TrimSpace(kind)) {
+		case shared.OperationRead:
+			t.Read = d
+		case shared.OperationWrite:
+			t.Write = d
+		case shared.OperationReport:
+			t.Report = d
+		default:
+			return Timeouts{}, fmt.Errorf("unknown operation kind %q", kind)
+		}
+	}
+	return t, nil
+}
+
+func (t Timeouts) For(kind shared.OperationKind) time.Duration {
+	switch kind {
+	case shared.OperationRead:
+		return orDefault(t.Read, DefaultTimeouts.Read)
+	case shared.OperationWrite:
+		return orDefault(t.Write, DefaultTimeouts.Write)
+	default:
+		return orDefault(t.Report, DefaultTimeouts.Report)
+	}
+}
+
+// Run calls fn under the kind's budget. The request deadline still wins when it is sooner.
+// A missed deadline, ours or the caller's, comes back as *shared.TimeoutError; a caller
+// cancellation is returned as is.
+func (t Timeouts) Run(ctx context.Context, kind shared.OperationKind, operation string, fn func(ctx context.Context) error) error {
+	limit := t.For(kind)
+	callCtx, cancel := context.WithTimeout(ctx, limit)
+	defer cancel()
+
+	err := fn(callCtx)
+	if err == nil {
+		return nil
+	}
+	if errors.Is(callCtx.Err(), context.DeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return &shared.TimeoutError{Operation: operation, Kind: kind, Limit: limit, Err: err}
+	}
+	return err
+}
+
+// Do is Run for calls that return a value
+func Do[T any](ctx context.Context, t Timeouts, kind shared.OperationKind, operation string, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := t.Run(ctx, kind, operation, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+	return result, err
+}
+
+func orDefault(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
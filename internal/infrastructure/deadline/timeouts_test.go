@@ -0,0 +1,171 @@
+package deadline
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/media"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+func TestParseTimeouts(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     string
+		expected Timeouts
+		wantErr  bool
+	}{
+		{name: "empty keeps defaults", spec: "", expected: DefaultTimeouts},
+		{name: "override one kind", spec: "report=30s", expected: Timeouts{Read: 200 * time.Millisecond, Write: time.Second, Report: 30 * time.Second}},
+		{name: "override all", spec: "read=50ms, write=500ms, report=5s", expected: Timeouts{Read: 50 * time.Millisecond, Write: 500 * time.Millisecond, Report: 5 * time.Second}},
+		{name: "unknown kind", spec: "delete=1s", wantErr: true},
+		{name: "missing duration", spec: "read", wantErr: true},
+		{name: "non-positive duration", spec: "read=0s", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTimeouts(tc.spec)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTimeouts_Run(t *testing.T) {
+	timeouts := Timeouts{Read: 10 * time.Millisecond, Write: time.Second, Report: time.Second}
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	err := timeouts.Run(context.Background(), shared.OperationRead, "account.FindByID", slow)
+	var timeoutErr *shared.TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected *shared.TimeoutError, got %v", err)
+	}
+	if timeoutErr.Kind != shared.OperationRead || timeoutErr.Limit != 10*time.Millisecond || timeoutErr.Operation != "account.FindByID" {
+		t.Errorf("unexpected timeout details %+v", timeoutErr)
+	}
+	if !errors.Is(err, shared.ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected timeout to match ErrTimeout and context.DeadlineExceeded")
+	}
+
+	// The request deadline is sooner than the write budget and still reports a timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := timeouts.Run(ctx, shared.OperationWrite, "account.Update", slow); !shared.IsTimeout(err) {
+		t.Errorf("expected timeout, got %v", err)
+	}
+
+	// A client that went away is not a timeout
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if err := timeouts.Run(cancelled, shared.OperationRead, "account.FindByID", slow); shared.IsTimeout(err) || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation to pass through, got %v", err)
+	}
+
+	plain := errors.New("connection refused")
+	if err := timeouts.Run(context.Background(), shared.OperationRead, "account.FindByID", func(ctx context.Context) error { return plain }); err != plain {
+		t.Errorf("expected error '%v', got '%v'", plain, err)
+	}
+}
+
+// blockingAccounts never answers FindByID and records the deadline it was given
+type blockingAccounts struct {
+	account.UserAccountRepository
+	deadline time.Time
+}
+
+func (b *blockingAccounts) FindByID(ctx context.Context, id string) (*account.UserAccount, error) {
+	b.deadline, _ = ctx.Deadline()
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (b *blockingAccounts) Find(ctx context.Context, filter *account.UserAccountFilter) ([]*account.UserAccount, error) {
+	b.deadline, _ = ctx.Deadline()
+	return nil, nil
+}
+
+func TestAccountRepository_AppliesBudgetPerOperation(t *testing.T) {
+	next := &blockingAccounts{}
+	repo := NewAccountRepository(next, Timeouts{Read: 20 * time.Millisecond, Report: time.Minute})
+
+	started := time.Now()
+	_, err := repo.FindByID(context.Background(), "acc-1")
+	if !shared.IsTimeout(err) {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+	if next.deadline.Sub(started) > time.Second {
+		t.Errorf("expected read budget, got deadline %v after start", next.deadline.Sub(started))
+	}
+
+	if _, err := repo.Find(context.Background(), &account.UserAccountFilter{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.deadline.Sub(started) < 30*time.Second {
+		t.Errorf("expected listings to get the report budget, got %v", next.deadline.Sub(started))
+	}
+}
+
+// slowBlobs takes delay to open a blob, its reader fails once the open context is done
+type slowBlobs struct {
+	media.BlobStore
+	delay time.Duration
+}
+
+func (s *slowBlobs) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	select {
+	case <-time.After(s.delay):
+		return io.NopCloser(&contextReader{ctx: ctx, r: strings.NewReader("document")}), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+func TestBlobStore_OpenBoundsOnlyTheWait(t *testing.T) {
+	timeouts := Timeouts{Read: 20 * time.Millisecond}
+
+	if _, err := NewBlobStore(&slowBlobs{delay: time.Second}, timeouts).Open(context.Background(), "doc"); !shared.IsTimeout(err) {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+
+	blob, err := NewBlobStore(&slowBlobs{}, timeouts).Open(context.Background(), "doc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer blob.Close()
+	time.Sleep(40 * time.Millisecond)
+	content, err := io.ReadAll(blob)
+	if err != nil || string(content) != "document" {
+		t.Errorf("expected the blob readable past the read budget, got %q, %v", content, err)
+	}
+}
@@ -0,0 +1,155 @@
+package disqus
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/comment"
+)
+
+// internals is the namespace of the dsq:id attributes that tie posts to threads and parents
+const internals = "http://disqus.com/disqus-internals"
+
+// Parse reads a Disqus XML export, the file the Disqus admin mails out:
+//
+//	<disqus xmlns="http://disqus.com" xmlns:dsq="http://disqus.com/disqus-internals">
+//	  <thread dsq:id="7301"><link>https://news.example.com/2019/05/budget</link><title>Budget</title></thread>
+//	  <post dsq:id="9120">
+//	    <message><![CDATA[<p>First</p>]]></message>
+//	    <createdAt>2019-05-02T08:00:00Z</createdAt>
+//	    <isDeleted>false</isDeleted><isSpam>false</isSpam>
+//	    <author><name>Sari</name><email>sari@example.com</email><username>sariw</username><isAnonymous>false</isAnonymous></author>
+//	    <thread dsq:id="7301"/><parent dsq:id="9119"/>
+//	  </post>
+//	</disqus>
+//
+// Exports run to hundreds of megabytes, so elements are decoded one at a time.
+// Messages are HTML and come back as plain text.
+func Parse(r io.Reader) (*comment.LegacyExport, error) {
+	dec := xml.NewDecoder(r)
+	export := &comment.LegacyExport{}
+	seenRoot := false
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read disqus export: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "disqus":
+			seenRoot = true
+		case "thread":
+			var t thread
+			if err := dec.DecodeElement(&t, &start); err != nil {
+				return nil, fmt.Errorf("failed to read disqus thread: %w", err)
+			}
+			export.Threads = append(export.Threads, comment.LegacyThread{ID: t.ID, Link: strings.TrimSpace(t.Link), Title: strings.TrimSpace(t.Title)})
+		case "post":
+			var p post
+			if err := dec.DecodeElement(&p, &start); err != nil {
+				return nil, fmt.Errorf("failed to read disqus post: %w", err)
+			}
+			legacy, err := p.legacy()
+			if err != nil {
+				return nil, err
+			}
+			export.Posts = append(export.Posts, legacy)
+		default:
+			// Categories and anything newer than this parser, skipped whole
+			if seenRoot {
+				if err := dec.Skip(); err != nil {
+					return nil, fmt.Errorf("failed to read disqus export: %w", err)
+				}
+			}
+		}
+	}
+	if !seenRoot {
+		return nil, errors.New("not a disqus export")
+	}
+	return export, nil
+}
+
+type ref struct {
+	ID string `xml:"http://disqus.com/disqus-internals id,attr"`
+}
+
+type thread struct {
+	ID    string `xml:"http://disqus.com/disqus-internals id,attr"`
+	Link  string `xml:"link"`
+	Title string `xml:"title"`
+}
+
+type post struct {
+	ID        string `xml:"http://disqus.com/disqus-internals id,attr"`
+	Message   string `xml:"message"`
+	CreatedAt string `xml:"createdAt"`
+	IsDeleted bool   `xml:"isDeleted"`
+	IsSpam    bool   `xml:"isSpam"`
+	Author    struct {
+		Name        string `xml:"name"`
+		Email       string `xml:"email"`
+		Username    string `xml:"username"`
+		IsAnonymous bool   `xml:"isAnonymous"`
+	} `xml:"author"`
+	Thread ref  `xml:"thread"`
+	Parent *ref `xml:"parent"`
+}
+
+func (p post) legacy() (comment.LegacyPost, error) {
+	if p.ID == "" || p.Thread.ID == "" {
+		return comment.LegacyPost{}, fmt.Errorf("disqus post %q has no ID or thread", p.ID)
+	}
+	createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(p.CreatedAt))
+	if err != nil {
+		return comment.LegacyPost{}, fmt.Errorf("disqus post %s has an invalid date: %w", p.ID, err)
+	}
+	legacy := comment.LegacyPost{
+		ID:        p.ID,
+		ThreadID:  p.Thread.ID,
+		Body:      PlainText(p.Message),
+		CreatedAt: createdAt.UTC(),
+		Deleted:   p.IsDeleted,
+		Spam:      p.IsSpam,
+		Author: comment.LegacyAuthor{
+			Name:      strings.TrimSpace(p.Author.Name),
+			Email:     strings.TrimSpace(p.Author.Email),
+			Username:  strings.TrimSpace(p.Author.Username),
+			Anonymous: p.Author.IsAnonymous,
+		},
+	}
+	if p.Parent != nil {
+		legacy.ParentID = p.Parent.ID
+	}
+	return legacy, nil
+}
+
+var (
+	breaks     = regexp.MustCompile(`(?i)<br\s*/?>|</p\s*>|</blockquote\s*>|</li\s*>`)
+	tags       = regexp.MustCompile(`<[^>]*>`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	spaces     = regexp.MustCompile(`[ \t]+`)
+)
+
+// PlainText turns a Disqus message into a comment body, paragraphs and line
+// breaks survive as newlines, everything else is dropped to its text
+func PlainText(message string) string {
+	text := breaks.ReplaceAllString(message, "\n")
+	text = html.UnescapeString(tags.ReplaceAllString(text, ""))
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
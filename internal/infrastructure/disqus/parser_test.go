@@ -0,0 +1,109 @@
+package disqus
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const export = `<?xml version="1.0" encoding="utf-8"?>
+<disqus xmlns="http://disqus.com" xmlns:dsq="http://disqus.com/disqus-internals">
+  <category dsq:id="1"><forum>newsportal</forum><title>General</title></category>
+  <thread dsq:id="7301">
+    <id/>
+    <forum>newsportal</forum>
+    <category dsq:id="1"/>
+    <link>https://news.example.com/2019/05/budget</link>
+    <title>Budget 2020</title>
+    <author><name>Desk</name></author>
+  </thread>
+  <post dsq:id="9120">
+    <id/>
+    <message><![CDATA[<p>Sharp &amp; fair.</p><p>Read <a href="https://example.org">this</a><br>too</p>]]></message>
+    <createdAt>2019-05-02T15:00:00+07:00</createdAt>
+    <isDeleted>false</isDeleted>
+    <isSpam>false</isSpam>
+    <author><name>Sari W</name><email>sari@example.com</email><isAnonymous>false</isAnonymous><username>sariw</username></author>
+    <thread dsq:id="7301"/>
+  </post>
+  <post dsq:id="9121">
+    <message><![CDATA[]]></message>
+    <createdAt>2019-05-02T09:00:00Z</createdAt>
+    <isDeleted>true</isDeleted>
+    <isSpam>false</isSpam>
+    <author><name>Guest</name><isAnonymous>true</isAnonymous></author>
+    <thread dsq:id="7301"/>
+    <parent dsq:id="9120"/>
+  </post>
+</disqus>`
+
+func TestParse(t *testing.T) {
+	parsed, err := Parse(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(parsed.Threads) != 1 || parsed.Threads[0].ID != "7301" || parsed.Threads[0].Link != "https://news.example.com/2019/05/budget" {
+		t.Fatalf("unexpected threads %+v", parsed.Threads)
+	}
+	if len(parsed.Posts) != 2 {
+		t.Fatalf("expected 2 posts, got %d", len(parsed.Posts))
+	}
+
+	first := parsed.Posts[0]
+	if first.ID != "9120" || first.ThreadID != "7301" || first.ParentID != "" {
+		t.Errorf("unexpected post %+v", first)
+	}
+	if first.Body != "Sharp & fair.\nRead this\ntoo" {
+		t.Errorf("unexpected body %q", first.Body)
+	}
+	if !first.CreatedAt.Equal(time.Date(2019, time.May, 2, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected time %v", first.CreatedAt)
+	}
+	if first.Author.Email != "sari@example.com" || first.Author.Username != "sariw" || first.Author.Anonymous {
+		t.Errorf("unexpected author %+v", first.Author)
+	}
+
+	reply := parsed.Posts[1]
+	if reply.ParentID != "9120" || !reply.Deleted || !reply.Author.Anonymous {
+		t.Errorf("unexpected reply %+v", reply)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		xml  string
+	}{
+		{"not xml", "id,message\n1,hello"},
+		{"other root", `<rss><channel/></rss>`},
+		{"bad date", `<disqus xmlns:dsq="http://disqus.com/disqus-internals"><post dsq:id="1"><createdAt>yesterday</createdAt><thread dsq:id="2"/></post></disqus>`},
+		{"post without thread", `<disqus xmlns:dsq="http://disqus.com/disqus-internals"><post dsq:id="1"><createdAt>2019-05-02T09:00:00Z</createdAt></post></disqus>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(tc.xml)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	testCases := []struct {
+		message  string
+		expected string
+	}{
+		{"plain", "plain"},
+		{"<b>bold</b> and <i>it</i>", "bold and it"},
+		{"<p>one</p>\n\n\n<p>two</p>", "one\n\ntwo"},
+		{"a &lt;script&gt; tag", "a <script> tag"},
+		{"<blockquote>quoted</blockquote>reply", "quoted\nreply"},
+	}
+
+	for _, tc := range testCases {
+		if got := PlainText(tc.message); got != tc.expected {
+			t.Errorf("PlainText(%q) = %q, want %q", tc.message, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package jsonfeed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/election"
+)
+
+func TestClient_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/pilkada/dki-2029" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"regions":[
+			{"code":"jaksel","stations_reported":3,"stations_total":5,"reported_at":"2029-02-14T15:00:00+07:00","votes":{"01":10,"02":7}},
+			{"code":"jakut","stations_total":4}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL+"/pilkada/", "secret")
+	reports, err := client.Fetch(context.Background(), &election.Race{FeedRef: "dki-2029"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reports) != 2 || reports[0].Votes["01"] != 10 || reports[0].StationsReported != 3 || reports[0].ReportedAt.IsZero() {
+		t.Errorf("unexpected reports %+v", reports)
+	}
+	if reports[1].Votes == nil || !reports[1].ReportedAt.IsZero() {
+		t.Errorf("expected empty votes for a region without results, got %+v", reports[1])
+	}
+
+	if _, err := NewClient(server.Client(), server.URL, "wrong").Fetch(context.Background(), &election.Race{FeedRef: "dki-2029"}); err == nil {
+		t.Error("expected error for a rejected request")
+	}
+}
@@ -0,0 +1,297 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+var from = mail.Address{Name: "Redaksi Portal", Address: "noreply@news.example.com"}
+
+func message(t *testing.T, attachments ...notification.Attachment) notification.EmailMessage {
+	t.Helper()
+	to, err := account.NewEmail("sari@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return notification.EmailMessage{
+		To:          *to,
+		Subject:     "Berita terkini: “Budget”",
+		TextBody:    "Read it here",
+		HTMLBody:    "<p>Read it <a href=\"https://news.example.com\">here</a></p>",
+		Attachments: attachments,
+	}
+}
+
+// parts reads every leaf part of the message, decoded
+func parts(t *testing.T, raw []byte) (*mail.Message, map[string]string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	found := map[string]string{}
+	var walk func(contentType string, body io.Reader)
+	walk = func(contentType string, body io.Reader) {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			t.Fatalf("invalid content type %q", contentType)
+		}
+		if !strings.HasPrefix(mediaType, "multipart/") {
+			data, _ := io.ReadAll(body)
+			found[mediaType] = string(data)
+			return
+		}
+		r := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := r.NextPart()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read part: %v", err)
+			}
+			if name := p.FileName(); name != "" {
+				data, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, p))
+				found[name] = string(data)
+				continue
+			}
+			walk(p.Header.Get("Content-Type"), p)
+		}
+	}
+	walk(msg.Header.Get("Content-Type"), msg.Body)
+	return msg, found
+}
+
+func TestBuildMessage(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	raw, err := buildMessage(from, message(t, notification.Attachment{Filename: "laporan tahunan.pdf", Data: []byte("%PDF-1.7 " + strings.Repeat("x", 200))}), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg, found := parts(t, raw)
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "Berita terkini: “Budget”" || msg.Header.Get("To") != "<sari@example.com>" || !strings.HasSuffix(msg.Header.Get("Message-ID"), "@news.example.com>") {
+		t.Errorf("unexpected headers %v", msg.Header)
+	}
+	if date, _ := msg.Header.Date(); !date.Equal(at) {
+		t.Errorf("unexpected date %v", date)
+	}
+	if found["text/plain"] != "Read it here" || !strings.Contains(found["text/html"], `href="https://news.example.com"`) {
+		t.Errorf("unexpected bodies %v", found)
+	}
+	if !strings.HasPrefix(found["laporan tahunan.pdf"], "%PDF-1.7") || len(found["laporan tahunan.pdf"]) != 209 {
+		t.Errorf("unexpected attachment %q", found["laporan tahunan.pdf"])
+	}
+}
+
+func TestBuildMessage_SingleBody(t *testing.T) {
+	msg := message(t)
+	msg.HTMLBody = ""
+	raw, err := buildMessage(from, msg, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, found := parts(t, raw)
+	if !strings.HasPrefix(parsed.Header.Get("Content-Type"), "text/plain") || found["text/plain"] != "Read it here" {
+		t.Errorf("expected a plain text message, got %v", found)
+	}
+
+	msg.TextBody = ""
+	if _, err := buildMessage(from, msg, time.Now()); err == nil {
+		t.Error("expected a message without a body rejected")
+	}
+}
+
+// fakeSMTP speaks just enough SMTP for one message and records the envelope
+type fakeSMTP struct {
+	listener net.Listener
+	reject   string // RCPT address answered with 550
+	from, to string
+	data     string
+}
+
+func startSMTP(t *testing.T, reject string) *fakeSMTP {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &fakeSMTP{listener: l, reject: reject}
+	t.Cleanup(func() { l.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.from = strings.TrimSpace(line)[len("MAIL FROM:"):]
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.to = strings.TrimSpace(line)[len("RCPT TO:"):]
+			if s.reject != "" && strings.Contains(s.to, s.reject) {
+				reply("550 mailbox unavailable")
+				continue
+			}
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.data = data.String()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (s *fakeSMTP) config() SMTPConfig {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return SMTPConfig{Host: "127.0.0.1", Port: p, From: from}
+}
+
+func TestSMTPSender(t *testing.T) {
+	server := startSMTP(t, "")
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := NewSMTPSender(server.config(), clock).SendEmail(ctx, message(t)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(server.from, "<noreply@news.example.com>") || !strings.Contains(server.to, "sari@example.com") {
+		t.Errorf("unexpected envelope %s -> %s", server.from, server.to)
+	}
+	if _, found := parts(t, []byte(server.data)); found["text/plain"] != "Read it here" {
+		t.Errorf("unexpected message %q", server.data)
+	}
+}
+
+func TestSMTPSender_Rejected(t *testing.T) {
+	server := startSMTP(t, "sari@example.com")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := NewSMTPSender(server.config(), shared.NewFrozenClock(time.Now())).SendEmail(ctx, message(t))
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("expected the rejection, got %v", err)
+	}
+}
+
+// AWS's documented example derivation for Signature Version 4
+func TestSigningKey(t *testing.T) {
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if got := hex.EncodeToString(key); got != "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d" {
+		t.Errorf("unexpected signing key %s", got)
+	}
+}
+
+func TestSESSender(t *testing.T) {
+	var got sesRequest
+	var auth, token string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, token = r.Header.Get("Authorization"), r.Header.Get("X-Amz-Security-Token")
+		if r.URL.Path != sesPath || r.Header.Get("X-Amz-Date") != "20260310T090000Z" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(`{"MessageId": "0100018e"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"message": "Email address is not verified."}`))
+	}))
+	defer server.Close()
+
+	cfg := SESConfig{Region: "ap-southeast-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret", SessionToken: "session", ConfigurationSet: "portal", From: from}
+	clock := shared.NewFrozenClock(time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC))
+	sender := NewSESSender(server.Client(), server.URL, cfg, clock)
+
+	if err := sender.SendEmail(context.Background(), message(t)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260310/ap-southeast-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token, Signature=") || token != "session" {
+		t.Errorf("unexpected authorization %q", auth)
+	}
+	if got.Destination.ToAddresses[0] != "sari@example.com" || got.ConfigurationSetName != "portal" {
+		t.Errorf("unexpected request %+v", got)
+	}
+	if _, found := parts(t, got.Content.Raw.Data); found["text/html"] == "" {
+		t.Error("expected the raw message in the request")
+	}
+
+	status = http.StatusBadRequest
+	if err := sender.SendEmail(context.Background(), message(t)); err == nil || !strings.Contains(err.Error(), "not verified") {
+		t.Errorf("expected the SES message in the error, got %v", err)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder()
+	ctx := context.Background()
+	_ = recorder.SendEmail(ctx, message(t))
+
+	recorder.FailWith(errors.New("provider down"))
+	if err := recorder.SendEmail(ctx, message(t)); err == nil {
+		t.Error("expected the configured failure")
+	}
+	if len(recorder.SentTo("sari@example.com")) != 1 || len(recorder.SentTo("budi@example.com")) != 0 {
+		t.Errorf("unexpected recorded emails %+v", recorder.Sent())
+	}
+	recorder.Reset()
+	if len(recorder.Sent()) != 0 {
+		t.Error("expected the recorder emptied")
+	}
+}
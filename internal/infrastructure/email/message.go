@@ -0,0 +1,173 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+)
+
+// buildMessage writes the message as RFC 5322 with MIME parts, the same bytes
+// go to the SMTP server and to SES as a raw message:
+//
+//	multipart/mixed             only with attachments
+//	  multipart/alternative     only with both bodies
+//	    text/plain
+//	    text/html
+//	  attachments, base64
+func buildMessage(from mail.Address, msg notification.EmailMessage, at time.Time) ([]byte, error) {
+	messageID, err := newMessageID(from.Address)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+	header("From", from.String())
+	header("To", (&mail.Address{Address: msg.To.String()}).String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", at.Format(time.RFC1123Z))
+	header("Message-ID", messageID)
+	header("MIME-Version", "1.0")
+
+	body, contentType, err := bodyPart(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg.Attachments) == 0 {
+		header("Content-Type", contentType)
+		if !strings.HasPrefix(contentType, "multipart/") {
+			header("Content-Transfer-Encoding", "quoted-printable")
+		}
+		buf.WriteString("\r\n")
+		buf.Write(body)
+		return buf.Bytes(), nil
+	}
+
+	mixed := multipart.NewWriter(&buf)
+	header("Content-Type", "multipart/mixed; boundary="+mixed.Boundary())
+	buf.WriteString("\r\n")
+	partHeader := textproto.MIMEHeader{"Content-Type": {contentType}}
+	if !strings.HasPrefix(contentType, "multipart/") {
+		partHeader.Set("Content-Transfer-Encoding", "quoted-printable")
+	}
+	part, err := mixed.CreatePart(partHeader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(body); err != nil {
+		return nil, err
+	}
+	for _, a := range msg.Attachments {
+		if err := writeAttachment(mixed, a); err != nil {
+			return nil, err
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bodyPart returns the text and HTML bodies as one part and its content type
+func bodyPart(msg notification.EmailMessage) ([]byte, string, error) {
+	switch {
+	case msg.TextBody == "" && msg.HTMLBody == "":
+		return nil, "", fmt.Errorf("email to %s has no body", msg.To)
+	case msg.HTMLBody == "":
+		body, err := quoted(msg.TextBody)
+		return body, "text/plain; charset=utf-8", err
+	case msg.TextBody == "":
+		body, err := quoted(msg.HTMLBody)
+		return body, "text/html; charset=utf-8", err
+	}
+
+	var buf bytes.Buffer
+	alt := multipart.NewWriter(&buf)
+	for _, p := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.TextBody},
+		{"text/html; charset=utf-8", msg.HTMLBody},
+	} {
+		part, err := alt.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		body, err := quoted(p.body)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(body); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := alt.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "multipart/alternative; boundary=" + alt.Boundary(), nil
+}
+
+func quoted(text string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(text)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeAttachment(w *multipart.Writer, a notification.Attachment) error {
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(a.Filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(a.Data)
+	for len(encoded) > 76 {
+		if _, err := part.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = part.Write([]byte(encoded + "\r\n"))
+	return err
+}
+
+func newMessageID(from string) (string, error) {
+	_, domain, ok := strings.Cut(from, "@")
+	if !ok {
+		return "", fmt.Errorf("sender address %q has no domain", from)
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return "<" + hex.EncodeToString(id) + "@" + domain + ">", nil
+}
@@ -0,0 +1,63 @@
+package email
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+)
+
+// Recorder keeps emails instead of sending them, for tests and local
+// development where nothing should leave the machine
+type Recorder struct {
+	mu   sync.Mutex
+	sent []notification.EmailMessage
+	err  error
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+var _ notification.EmailSender = (*Recorder)(nil)
+
+func (r *Recorder) SendEmail(ctx context.Context, msg notification.EmailMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, msg)
+	return nil
+}
+
+// FailWith makes every following send fail with err, nil to recover
+func (r *Recorder) FailWith(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+// Sent returns the recorded emails, oldest first
+func (r *Recorder) Sent() []notification.EmailMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]notification.EmailMessage(nil), r.sent...)
+}
+
+// SentTo returns the emails recorded for one address
+func (r *Recorder) SentTo(address string) []notification.EmailMessage {
+	var found []notification.EmailMessage
+	for _, msg := range r.Sent() {
+		if msg.To.String() == address {
+			found = append(found, msg)
+		}
+	}
+	return found
+}
+
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = nil
+}
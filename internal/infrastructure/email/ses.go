@@ -0,0 +1,160 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+const sesPath = "/v2/email/outbound-emails"
+
+// SESConfig is an AWS identity allowed to call ses:SendEmail in the region
+type SESConfig struct {
+	Region           string
+	AccessKeyID      string
+	SecretAccessKey  string
+	SessionToken     string // Set with temporary credentials, e.g. from an instance role
+	ConfigurationSet string // Optional, routes bounces and complaints to the suppression webhook
+	From             mail.Address
+}
+
+// SESSender sends raw messages through the SES v2 API, signed with Signature Version 4
+type SESSender struct {
+	httpClient *http.Client
+	endpoint   string
+	cfg        SESConfig
+	clock      shared.Clock
+}
+
+// NewSESSender uses the region's public endpoint when endpoint is empty
+func NewSESSender(httpClient *http.Client, endpoint string, cfg SESConfig, clock shared.Clock) *SESSender {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if endpoint == "" {
+		endpoint = "https://email." + cfg.Region + ".amazonaws.com"
+	}
+	return &SESSender{httpClient: httpClient, endpoint: strings.TrimRight(endpoint, "/"), cfg: cfg, clock: clock}
+}
+
+var _ notification.EmailSender = (*SESSender)(nil)
+
+type sesRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Raw struct {
+			Data []byte `json:"Data"` // Base64 in JSON
+		} `json:"Raw"`
+	} `json:"Content"`
+	ConfigurationSetName string `json:"ConfigurationSetName,omitempty"`
+}
+
+type sesResponse struct {
+	MessageID string `json:"MessageId"`
+	Message   string `json:"message"`
+}
+
+func (s *SESSender) SendEmail(ctx context.Context, msg notification.EmailMessage) error {
+	now := s.clock.Now().UTC()
+	raw, err := buildMessage(s.cfg.From, msg, now)
+	if err != nil {
+		return err
+	}
+	var payload sesRequest
+	payload.FromEmailAddress = s.cfg.From.String()
+	payload.Destination.ToAddresses = []string{msg.To.String()}
+	payload.Content.Raw.Data = raw
+	payload.ConfigurationSetName = s.cfg.ConfigurationSet
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+sesPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, body, now)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	defer resp.Body.Close()
+	var result sesResponse
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to send email: SES returned %d %s", resp.StatusCode, result.Message)
+	}
+	return nil
+}
+
+// sign adds the Signature Version 4 Authorization header
+func (s *SESSender) sign(req *http.Request, body []byte, at time.Time) {
+	amzDate := at.Format("20060102T150405Z")
+	day := at.Format("20060102")
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+	scope := day + "/" + s.cfg.Region + "/ses/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hexSHA256([]byte(canonicalRequest))}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(signingKey(s.cfg.SecretAccessKey, day, s.cfg.Region, "ses"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func signingKey(secret, day, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
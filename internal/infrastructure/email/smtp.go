@@ -0,0 +1,93 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// SMTPConfig is the relay the portal sends through, e.g. the newsroom's
+// Postfix or a provider's submission port
+type SMTPConfig struct {
+	Host     string
+	Port     int // 587 with STARTTLS is the usual submission port
+	Username string
+	Password string // PLAIN auth, only sent once the connection is encrypted
+	From     mail.Address
+}
+
+// SMTPSender delivers each email over its own SMTP connection
+type SMTPSender struct {
+	cfg   SMTPConfig
+	clock shared.Clock
+}
+
+func NewSMTPSender(cfg SMTPConfig, clock shared.Clock) *SMTPSender {
+	return &SMTPSender{cfg: cfg, clock: clock}
+}
+
+var _ notification.EmailSender = (*SMTPSender)(nil)
+
+// SendEmail upgrades to TLS whenever the server offers STARTTLS. The
+// context's deadline bounds the whole conversation.
+func (s *SMTPSender) SendEmail(ctx context.Context, msg notification.EmailMessage) error {
+	raw, err := buildMessage(s.cfg.From, msg, s.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(s.clock.Now().Add(time.Minute))
+	}
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		return fmt.Errorf("failed to greet %s: %w", addr, err)
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := c.Mail(s.cfg.From.Address); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := c.Rcpt(msg.To.String()); err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", msg.To, err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write(raw); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return c.Quit()
+}
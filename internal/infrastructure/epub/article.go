@@ -0,0 +1,64 @@
+package epub
+
+import (
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/export"
+)
+
+// ArticleRenderer writes a single article as a one-chapter book
+type ArticleRenderer struct {
+	language string
+}
+
+func NewArticleRenderer(language string) *ArticleRenderer {
+	return &ArticleRenderer{language: language}
+}
+
+var _ export.Renderer = (*ArticleRenderer)(nil)
+
+func (r *ArticleRenderer) Render(w io.Writer, a *export.Article) error {
+	id := a.URL
+	if id == "" {
+		id = "urn:news-portal:article:" + a.ID
+	}
+	language := a.Language
+	if language == "" {
+		language = r.language
+	}
+	modified := a.UpdatedAt
+	if modified.IsZero() {
+		modified = a.PublishedAt
+	}
+	book := &Book{
+		ID:       id,
+		Title:    a.Title,
+		Author:   a.Byline,
+		Language: language,
+		Modified: modified,
+		Chapters: []Chapter{{Title: a.Title, Body: articleBody(a.Title, a.Byline, a.PublishedAt, a.Body)}},
+	}
+	for _, img := range a.Images {
+		if img.Name != "" {
+			book.Images = append(book.Images, Image{Name: img.Name, ContentType: img.ContentType, Data: img.Data})
+		}
+	}
+	return book.Write(w)
+}
+
+// articleBody is the chapter markup shared by single-article and reading-list books
+func articleBody(title, byline string, publishedAt time.Time, body string) string {
+	var sb strings.Builder
+	sb.WriteString("<article>\n<header>\n<h1>" + html.EscapeString(title) + "</h1>\n")
+	if byline != "" {
+		sb.WriteString("<p>" + html.EscapeString(byline) + "</p>\n")
+	}
+	if !publishedAt.IsZero() {
+		sb.WriteString(`<p><time datetime="` + publishedAt.Format("2006-01-02T15:04:05Z07:00") + `">` + publishedAt.Format("2 January 2006") + "</time></p>\n")
+	}
+	sb.WriteString("</header>\n" + body + "\n</article>")
+	return sb.String()
+}
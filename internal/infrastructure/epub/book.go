@@ -0,0 +1,182 @@
+// Package epub writes EPUB 3 books from CMS content. Bodies are the sanitized
+// HTML the CMS stores; they are made XHTML-safe here (void elements closed,
+// HTML-only entities numbered) but otherwise assumed well formed.
+package epub
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Book is everything one EPUB file holds
+type Book struct {
+	ID       string // Unique identifier, e.g. "urn:uuid:..." or an article permalink
+	Title    string
+	Author   string
+	Language string // BCP 47, e.g. "id" or "en"
+	Modified time.Time
+	Chapters []Chapter
+	Images   []Image
+}
+
+// Chapter is one XHTML document in reading order
+type Chapter struct {
+	Title string
+	Body  string // HTML fragment, image sources relative to the book root, e.g. "images/a1-0.jpg"
+}
+
+// Image is bundled under images/ inside the book
+type Image struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+// Write encodes the book. The mimetype entry must come first and uncompressed
+// for readers to recognise the file.
+func (b *Book) Write(w io.Writer) error {
+	z := zip.NewWriter(w)
+
+	mimetype, err := z.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mimetype, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"META-INF/container.xml", []byte(containerXML)},
+		{"OEBPS/content.opf", []byte(b.opf())},
+		{"OEBPS/nav.xhtml", []byte(b.nav())},
+	}
+	for i, c := range b.Chapters {
+		files = append(files, struct {
+			name    string
+			content []byte
+		}{"OEBPS/" + chapterFile(i), []byte(b.chapter(c))})
+	}
+	for _, img := range b.Images {
+		files = append(files, struct {
+			name    string
+			content []byte
+		}{"OEBPS/images/" + img.Name, img.Data})
+	}
+
+	for _, f := range files {
+		entry, err := z.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(f.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	return z.Close()
+}
+
+func (b *Book) opf() string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+	fmt.Fprintf(&sb, "    <dc:identifier id=\"book-id\">%s</dc:identifier>\n", html.EscapeString(b.ID))
+	fmt.Fprintf(&sb, "    <dc:title>%s</dc:title>\n", html.EscapeString(b.Title))
+	fmt.Fprintf(&sb, "    <dc:language>%s</dc:language>\n", html.EscapeString(b.language()))
+	if b.Author != "" {
+		fmt.Fprintf(&sb, "    <dc:creator>%s</dc:creator>\n", html.EscapeString(b.Author))
+	}
+	fmt.Fprintf(&sb, "    <meta property=\"dcterms:modified\">%s</meta>\n", b.Modified.UTC().Format("2006-01-02T15:04:05Z"))
+	sb.WriteString("  </metadata>\n  <manifest>\n")
+	sb.WriteString("    <item id=\"nav\" href=\"nav.xhtml\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n")
+	for i := range b.Chapters {
+		fmt.Fprintf(&sb, "    <item id=\"chapter-%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, chapterFile(i))
+	}
+	for i, img := range b.Images {
+		fmt.Fprintf(&sb, "    <item id=\"image-%d\" href=\"images/%s\" media-type=\"%s\"/>\n", i+1, html.EscapeString(img.Name), html.EscapeString(img.ContentType))
+	}
+	sb.WriteString("  </manifest>\n  <spine>\n")
+	for i := range b.Chapters {
+		fmt.Fprintf(&sb, "    <itemref idref=\"chapter-%d\"/>\n", i+1)
+	}
+	sb.WriteString("  </spine>\n</package>\n")
+	return sb.String()
+}
+
+func (b *Book) nav() string {
+	var items strings.Builder
+	for i, c := range b.Chapters {
+		fmt.Fprintf(&items, "      <li><a href=\"%s\">%s</a></li>\n", chapterFile(i), html.EscapeString(c.Title))
+	}
+	return b.document(b.Title, "  <nav epub:type=\"toc\" id=\"toc\">\n    <h1>"+html.EscapeString(b.Title)+"</h1>\n    <ol>\n"+items.String()+"    </ol>\n  </nav>\n")
+}
+
+func (b *Book) chapter(c Chapter) string {
+	return b.document(c.Title, XHTML(c.Body)+"\n")
+}
+
+func (b *Book) document(title, body string) string {
+	lang := html.EscapeString(b.language())
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" lang="` + lang + `" xml:lang="` + lang + `">
+<head><meta charset="UTF-8"/><title>` + html.EscapeString(title) + `</title></head>
+<body>
+` + body + `</body>
+</html>
+`
+}
+
+func (b *Book) language() string {
+	if b.Language == "" {
+		return "id"
+	}
+	return b.Language
+}
+
+func chapterFile(i int) string {
+	return fmt.Sprintf("chapter-%03d.xhtml", i+1)
+}
+
+var (
+	voidElement = regexp.MustCompile(`(?i)<(area|br|col|hr|img|source|track|wbr)(\s[^>]*?)?\s*/?>`)
+	namedEntity = regexp.MustCompile(`&([a-zA-Z][a-zA-Z0-9]*);`)
+)
+
+// XHTML makes a sanitized HTML fragment parse as XML: void elements are
+// self-closed and entities other than XML's five become numeric references.
+func XHTML(fragment string) string {
+	fragment = voidElement.ReplaceAllString(fragment, "<$1$2 />")
+	return namedEntity.ReplaceAllStringFunc(fragment, func(entity string) string {
+		switch entity {
+		case "&amp;", "&lt;", "&gt;", "&quot;", "&apos;":
+			return entity
+		}
+		decoded := html.UnescapeString(entity)
+		if decoded == entity {
+			return "&amp;" + entity[1:]
+		}
+		var sb strings.Builder
+		for _, r := range decoded {
+			fmt.Fprintf(&sb, "&#%d;", r)
+		}
+		return sb.String()
+	})
+}
@@ -0,0 +1,128 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/export"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/offline"
+)
+
+func readZip(t *testing.T, data []byte) (*zip.Reader, map[string]string) {
+	t.Helper()
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("expected a zip archive, got %v", err)
+	}
+	files := make(map[string]string, len(z.File))
+	for _, f := range z.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+	return z, files
+}
+
+func TestPackageEncoder_EncodePackage(t *testing.T) {
+	at := time.Date(2025, time.June, 3, 6, 0, 0, 0, time.UTC)
+	pkg, _ := offline.NewPackage("acc-1", offline.FormatEPUB, false, at)
+	pkg.Add(offline.Article{
+		ID:          "a1",
+		Title:       "Banjir & longsor",
+		Byline:      "Rina",
+		PublishedAt: at,
+		Body:        `<p>Hujan&nbsp;deras.<br></p><img src="images/a1-0.jpg" alt="Sungai">`,
+		Images:      []offline.Image{{Name: "a1-0.jpg", ContentType: "image/jpeg", Data: []byte("jpeg")}},
+	})
+	pkg.Add(offline.Article{ID: "a2", Title: "Pemilu", Body: "<p>Hasil hitung cepat.</p>"})
+
+	var buf bytes.Buffer
+	if err := NewPackageEncoder("id").EncodePackage(&buf, pkg); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	z, files := readZip(t, buf.Bytes())
+
+	if first := z.File[0]; first.Name != "mimetype" || first.Method != zip.Store || files["mimetype"] != "application/epub+zip" {
+		t.Errorf("expected stored mimetype entry first, got %s (method %d)", first.Name, first.Method)
+	}
+	for _, name := range []string{"META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/chapter-001.xhtml", "OEBPS/chapter-002.xhtml", "OEBPS/images/a1-0.jpg"} {
+		content, ok := files[name]
+		if !ok {
+			t.Errorf("expected %s in the book", name)
+			continue
+		}
+		if strings.HasSuffix(name, ".xhtml") || strings.HasSuffix(name, ".opf") || strings.HasSuffix(name, ".xml") {
+			if err := xml.NewDecoder(strings.NewReader(content)).Decode(new(struct{})); err != nil {
+				t.Errorf("expected %s to be well-formed XML, got %v", name, err)
+			}
+		}
+	}
+	if !strings.Contains(files["OEBPS/content.opf"], `href="images/a1-0.jpg" media-type="image/jpeg"`) {
+		t.Errorf("expected image in the manifest, got %s", files["OEBPS/content.opf"])
+	}
+	if !strings.Contains(files["OEBPS/nav.xhtml"], "Banjir &amp; longsor") {
+		t.Errorf("expected escaped chapter title in the table of contents")
+	}
+}
+
+func TestXHTML(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"void elements", `<p>a<br>b</p><img src="x.jpg" alt="x">`, `<p>a<br />b</p><img src="x.jpg" alt="x" />`},
+		{"already closed", `<hr/>`, `<hr />`},
+		{"html entity", `a&nbsp;b&mdash;c`, `a&#160;b&#8212;c`},
+		{"xml entity kept", `a &amp; b &lt; c`, `a &amp; b &lt; c`},
+		{"unknown entity escaped", `&bogus;`, `&amp;bogus;`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := XHTML(tc.input); got != tc.expected {
+				t.Errorf("expected '%s', got '%s'", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestArticleRenderer_Render(t *testing.T) {
+	a := &export.Article{
+		ID:        "a1",
+		Title:     "Banjir Jakarta",
+		Byline:    "Rina",
+		URL:       "https://news.example.com/banjir-jakarta",
+		UpdatedAt: time.Date(2025, time.June, 3, 6, 0, 0, 0, time.UTC),
+		Body:      `<p>Teks</p><img src="images/a1-0.jpg" alt="Foto"><img src="https://cdn.example.com/online.jpg" alt="Online">`,
+		Images: []export.Image{
+			{Name: "a1-0.jpg", ContentType: "image/jpeg", Data: []byte("jpeg")},
+			{SourceURL: "https://cdn.example.com/online.jpg"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewArticleRenderer("id").Render(&buf, a); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_, files := readZip(t, buf.Bytes())
+
+	if _, ok := files["OEBPS/chapter-001.xhtml"]; !ok {
+		t.Errorf("expected one chapter")
+	}
+	if _, ok := files["OEBPS/images/a1-0.jpg"]; !ok || len(files) != 6 {
+		t.Errorf("expected only the bundled image packed, got %d files", len(files))
+	}
+	if !strings.Contains(files["OEBPS/content.opf"], "<dc:identifier id=\"book-id\">https://news.example.com/banjir-jakarta</dc:identifier>") {
+		t.Errorf("expected the permalink as book identifier, got %s", files["OEBPS/content.opf"])
+	}
+}
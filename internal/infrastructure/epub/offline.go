@@ -0,0 +1,34 @@
+package epub
+
+import (
+	"io"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/offline"
+)
+
+// PackageEncoder writes an offline reading package as one book, an article per chapter
+type PackageEncoder struct {
+	language string
+}
+
+func NewPackageEncoder(language string) *PackageEncoder {
+	return &PackageEncoder{language: language}
+}
+
+var _ offline.BookEncoder = (*PackageEncoder)(nil)
+
+func (e *PackageEncoder) EncodePackage(w io.Writer, p *offline.Package) error {
+	book := &Book{
+		ID:       "urn:news-portal:offline:" + p.AccountID + ":" + p.GeneratedAt.UTC().Format("20060102T150405Z"),
+		Title:    "Reading list, " + p.GeneratedAt.Format("2 January 2006"),
+		Language: e.language,
+		Modified: p.GeneratedAt,
+	}
+	for _, a := range p.Articles {
+		book.Chapters = append(book.Chapters, Chapter{Title: a.Title, Body: articleBody(a.Title, a.Byline, a.PublishedAt, a.Body)})
+		for _, img := range a.Images {
+			book.Images = append(book.Images, Image{Name: img.Name, ContentType: img.ContentType, Data: img.Data})
+		}
+	}
+	return book.Write(w)
+}
@@ -0,0 +1,139 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownEventType   = errors.New("event type is not registered")
+	ErrUnsupportedVersion = errors.New("event version is newer than this build understands")
+	ErrMissingUpcaster    = errors.New("no upcaster from this event version")
+)
+
+// Envelope is an event on the wire. Payload is JSON of the event type's
+// schema at Version; consumers upcast older versions through the Registry.
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	Key        string          `json:"key"` // Partitioning key, e.g. the article ID, keeps one aggregate's events in order
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// Upcaster turns a payload of one version into the next version's shape
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// Registry knows the current schema version of every event type and how to
+// bring older payloads up to it. Brokers with a schema registry register the
+// same types there; this one is what the code relies on.
+type Registry struct {
+	mu      sync.RWMutex
+	current map[string]int
+	upcasts map[string]map[int]Upcaster // type -> from version -> upcaster to version+1
+}
+
+func NewRegistry() *Registry {
+	return &Registry{current: map[string]int{}, upcasts: map[string]map[int]Upcaster{}}
+}
+
+// Register sets the current version of an event type, upcasters[v] converts version v to v+1
+func (r *Registry) Register(eventType string, version int, upcasters map[int]Upcaster) error {
+	if eventType == "" || version < 1 {
+		return fmt.Errorf("event type and a version of at least 1 are required, got %q v%d", eventType, version)
+	}
+	for v := 1; v < version; v++ {
+		if upcasters[v] == nil {
+			return fmt.Errorf("%w: %s v%d", ErrMissingUpcaster, eventType, v)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current[eventType] = version
+	r.upcasts[eventType] = upcasters
+	return nil
+}
+
+// Encode wraps a payload as the current version of its event type
+func (r *Registry) Encode(id, eventType, key string, payload any, at time.Time) (Envelope, error) {
+	r.mu.RLock()
+	version, ok := r.current[eventType]
+	r.mu.RUnlock()
+	if !ok {
+		return Envelope{}, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("failed to encode %s payload: %w", eventType, err)
+	}
+	return Envelope{ID: id, Type: eventType, Version: version, Key: key, OccurredAt: at, Payload: data}, nil
+}
+
+// Decode upcasts the envelope's payload to the current version and unmarshals it into v
+func (r *Registry) Decode(env Envelope, v any) error {
+	r.mu.RLock()
+	current, ok := r.current[env.Type]
+	upcasts := r.upcasts[env.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownEventType, env.Type)
+	}
+	if env.Version > current {
+		return fmt.Errorf("%w: %s v%d, current v%d", ErrUnsupportedVersion, env.Type, env.Version, current)
+	}
+
+	payload := env.Payload
+	for version := env.Version; version < current; version++ {
+		var err error
+		if payload, err = upcasts[version](payload); err != nil {
+			return fmt.Errorf("failed to upcast %s from v%d: %w", env.Type, version, err)
+		}
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("failed to decode %s payload: %w", env.Type, err)
+	}
+	return nil
+}
+
+// Handler processes one event. An error stops the subscription before the
+// event is committed, so it is delivered again: handlers must be idempotent.
+type Handler func(ctx context.Context, env Envelope) error
+
+// commitTimeout bounds committing an event whose handler already returned
+const commitTimeout = 5 * time.Second
+
+// commitContext outlives the subscription's context, so an event handled just
+// before a shutdown is still committed rather than delivered again
+func commitContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), commitTimeout)
+}
+
+// Publisher sends events to a topic
+type Publisher interface {
+	Publish(ctx context.Context, topic string, events ...Envelope) error
+}
+
+// Subscriber delivers a topic's events to a consumer group. Every group sees
+// every event once, members of one group share the work. Subscribe blocks
+// until ctx is done or the handler fails.
+type Subscriber interface {
+	Subscribe(ctx context.Context, topic, group string, handler Handler) error
+}
+
+// Replayer reads a topic from an offset without touching any group's
+// position, for rebuilding a projection from scratch
+type Replayer interface {
+	Replay(ctx context.Context, topic string, fromOffset int64, handler Handler) error
+}
+
+// GroupResetter moves a consumer group to an offset. Rebuilding a projection is
+// stopping its consumers, resetting their group to 0 and starting them again.
+type GroupResetter interface {
+	ResetGroup(ctx context.Context, topic, group string, offset int64) error
+}
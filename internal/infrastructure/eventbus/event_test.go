@@ -0,0 +1,117 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type articlePublished struct {
+	ArticleID string   `json:"article_id"`
+	Authors   []string `json:"authors"`
+}
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry := NewRegistry()
+	// v1 had a single author field, v2 allows several
+	err := registry.Register("article.published", 2, map[int]Upcaster{
+		1: func(payload json.RawMessage) (json.RawMessage, error) {
+			var v1 struct {
+				ArticleID string `json:"article_id"`
+				Author    string `json:"author"`
+			}
+			if err := json.Unmarshal(payload, &v1); err != nil {
+				return nil, err
+			}
+			return json.Marshal(articlePublished{ArticleID: v1.ArticleID, Authors: []string{v1.Author}})
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return registry
+}
+
+func TestRegistry(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	env, err := registry.Encode("e1", "article.published", "a1", articlePublished{ArticleID: "a1", Authors: []string{"j1"}}, time.Now())
+	if err != nil || env.Version != 2 {
+		t.Fatalf("expected a v2 envelope, got %+v %v", env, err)
+	}
+
+	old := Envelope{ID: "e0", Type: "article.published", Version: 1, Payload: json.RawMessage(`{"article_id":"a0","author":"j0"}`)}
+	var decoded articlePublished
+	if err := registry.Decode(old, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.ArticleID != "a0" || len(decoded.Authors) != 1 || decoded.Authors[0] != "j0" {
+		t.Errorf("expected the v1 payload upcast, got %+v", decoded)
+	}
+
+	future := Envelope{Type: "article.published", Version: 3, Payload: json.RawMessage(`{}`)}
+	if err := registry.Decode(future, &decoded); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected error '%v', got '%v'", ErrUnsupportedVersion, err)
+	}
+	if _, err := registry.Encode("e2", "article.deleted", "a1", nil, time.Now()); !errors.Is(err, ErrUnknownEventType) {
+		t.Errorf("expected error '%v', got '%v'", ErrUnknownEventType, err)
+	}
+	if err := registry.Register("article.archived", 2, nil); !errors.Is(err, ErrMissingUpcaster) {
+		t.Errorf("expected error '%v', got '%v'", ErrMissingUpcaster, err)
+	}
+}
+
+func TestMemoryLog_GroupsAndReplay(t *testing.T) {
+	log := NewMemoryLog()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_ = log.Publish(ctx, "articles", Envelope{ID: "e1"}, Envelope{ID: "e2"}, Envelope{ID: "e3"})
+
+	// A failing handler stops the subscription, the event is redelivered next time
+	failOnce := errors.New("search index unavailable")
+	var seen []string
+	err := log.Subscribe(ctx, "articles", "search", func(ctx context.Context, env Envelope) error {
+		if env.ID == "e2" && failOnce != nil {
+			err := failOnce
+			failOnce = nil
+			return err
+		}
+		seen = append(seen, env.ID)
+		if len(seen) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected the first subscription to stop on the handler error")
+	}
+	err = log.Subscribe(ctx, "articles", "search", func(ctx context.Context, env Envelope) error {
+		seen = append(seen, env.ID)
+		if len(seen) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || len(seen) != 3 || seen[1] != "e2" {
+		t.Errorf("expected e2 redelivered and all three seen, got %v %v", seen, err)
+	}
+
+	// Another group starts from the beginning, a replay leaves positions alone
+	var replayed int
+	if err := log.Replay(context.Background(), "articles", 1, func(ctx context.Context, env Envelope) error {
+		replayed++
+		return nil
+	}); err != nil || replayed != 2 {
+		t.Errorf("expected 2 events replayed from offset 1, got %d %v", replayed, err)
+	}
+	if log.Offset("articles", "search") != 3 || log.Offset("articles", "newsletter") != 0 {
+		t.Errorf("unexpected offsets %d and %d", log.Offset("articles", "search"), log.Offset("articles", "newsletter"))
+	}
+
+	if err := log.ResetGroup(context.Background(), "articles", "search", 0); err != nil || log.Offset("articles", "search") != 0 {
+		t.Errorf("expected the group reset to 0, got %d %v", log.Offset("articles", "search"), err)
+	}
+}
@@ -0,0 +1,250 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// JetStreamBus keeps each topic in a stream of its own, with the topic as its
+// only subject. A consumer group is a durable pull consumer, members pulling
+// from it share the work. Stream sequences start at 1: offset n is sequence n+1.
+type JetStreamBus struct {
+	js jetstream.JetStream
+
+	mu      sync.Mutex
+	streams map[string]jetstream.Stream // By topic
+}
+
+func NewJetStreamBus(nc *nats.Conn) (*JetStreamBus, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, err
+	}
+	return &JetStreamBus{js: js, streams: map[string]jetstream.Stream{}}, nil
+}
+
+var (
+	_ Publisher     = (*JetStreamBus)(nil)
+	_ Subscriber    = (*JetStreamBus)(nil)
+	_ Replayer      = (*JetStreamBus)(nil)
+	_ GroupResetter = (*JetStreamBus)(nil)
+)
+
+// Publish sends the envelope's ID as the message ID, the stream drops an event
+// published twice within its duplicate window
+func (b *JetStreamBus) Publish(ctx context.Context, topic string, events ...Envelope) error {
+	if _, err := b.stream(ctx, topic); err != nil {
+		return err
+	}
+	for _, env := range events {
+		data, err := json.Marshal(env)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %s: %w", env.ID, err)
+		}
+		msg := nats.NewMsg(topic)
+		msg.Data = data
+		msg.Header.Set(headerEventType, env.Type)
+		msg.Header.Set(headerEventVersion, strconv.Itoa(env.Version))
+		if _, err := b.js.PublishMsg(ctx, msg, jetstream.WithMsgID(env.ID)); err != nil {
+			return fmt.Errorf("failed to publish to %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// Subscribe pulls for the group's durable consumer, creating it at the start of
+// the stream the first time. An event is acknowledged once its handler returns;
+// a failure naks it and ends the subscription, along with the events this
+// member had pulled ahead so the group gets them again right away. Redelivered
+// events may overtake newer ones.
+func (b *JetStreamBus) Subscribe(ctx context.Context, topic, group string, handler Handler) error {
+	stream, err := b.stream(ctx, topic)
+	if err != nil {
+		return err
+	}
+	consumer, err := stream.Consumer(ctx, group)
+	if errors.Is(err, jetstream.ErrConsumerNotFound) {
+		consumer, err = stream.CreateConsumer(ctx, groupConfig(group, 0))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open consumer %s on %s: %w", group, topic, err)
+	}
+
+	msgs, err := consumer.Messages()
+	if err != nil {
+		return err
+	}
+	defer release(msgs)
+
+	for {
+		msg, err := msgs.Next(jetstream.NextContext(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		env, err := fromJetStreamMessage(msg)
+		if err == nil {
+			err = handler(ctx, env)
+		}
+		if err != nil {
+			_ = msg.Nak()
+			return err
+		}
+		ackCtx, cancel := commitContext(ctx)
+		err = msg.DoubleAck(ackCtx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to acknowledge %s on %s: %w", env.ID, topic, err)
+		}
+	}
+}
+
+// release stops pulling and naks what was pulled but not handled
+func release(msgs jetstream.MessagesContext) {
+	msgs.Drain()
+	for {
+		msg, err := msgs.Next(jetstream.NextMaxWait(time.Second))
+		if err != nil {
+			msgs.Stop()
+			return
+		}
+		_ = msg.Nak()
+	}
+}
+
+// Replay reads the stream from fromOffset up to its last event when the replay
+// started, through an ordered consumer that leaves every group alone
+func (b *JetStreamBus) Replay(ctx context.Context, topic string, fromOffset int64, handler Handler) error {
+	stream, err := b.stream(ctx, topic)
+	if err != nil {
+		return err
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to describe %s: %w", topic, err)
+	}
+	start := max(uint64(max(fromOffset, 0))+1, info.State.FirstSeq)
+	last := info.State.LastSeq
+	if info.State.Msgs == 0 || start > last {
+		return nil
+	}
+
+	consumer, err := stream.OrderedConsumer(ctx, jetstream.OrderedConsumerConfig{
+		DeliverPolicy: jetstream.DeliverByStartSequencePolicy,
+		OptStartSeq:   start,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open replay of %s: %w", topic, err)
+	}
+	msgs, err := consumer.Messages()
+	if err != nil {
+		return err
+	}
+	defer msgs.Stop()
+
+	for {
+		msg, err := msgs.Next(jetstream.NextContext(ctx))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		meta, err := msg.Metadata()
+		if err != nil {
+			return err
+		}
+		env, err := fromJetStreamMessage(msg)
+		if err != nil {
+			return err
+		}
+		if err := handler(ctx, env); err != nil {
+			return err
+		}
+		if meta.Sequence.Stream >= last {
+			return nil
+		}
+	}
+}
+
+// ResetGroup recreates the group's consumer at offset. JetStream does not know
+// whether members are running, stop them first: their pulls fail once the
+// consumer is gone.
+func (b *JetStreamBus) ResetGroup(ctx context.Context, topic, group string, offset int64) error {
+	stream, err := b.stream(ctx, topic)
+	if err != nil {
+		return err
+	}
+	if err := stream.DeleteConsumer(ctx, group); err != nil && !errors.Is(err, jetstream.ErrConsumerNotFound) {
+		return fmt.Errorf("failed to reset %s on %s: %w", group, topic, err)
+	}
+	if _, err := stream.CreateConsumer(ctx, groupConfig(group, offset)); err != nil {
+		return fmt.Errorf("failed to reset %s on %s: %w", group, topic, err)
+	}
+	return nil
+}
+
+// stream opens the topic's stream, creating it with file storage on first use
+func (b *JetStreamBus) stream(ctx context.Context, topic string) (jetstream.Stream, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if s, ok := b.streams[topic]; ok {
+		return s, nil
+	}
+
+	name := streamName(topic)
+	s, err := b.js.Stream(ctx, name)
+	if errors.Is(err, jetstream.ErrStreamNotFound) {
+		s, err = b.js.CreateStream(ctx, jetstream.StreamConfig{
+			Name:       name,
+			Subjects:   []string{topic},
+			Storage:    jetstream.FileStorage,
+			Duplicates: 2 * time.Minute,
+		})
+		if errors.Is(err, jetstream.ErrStreamNameAlreadyInUse) {
+			s, err = b.js.Stream(ctx, name)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stream for %s: %w", topic, err)
+	}
+	b.streams[topic] = s
+	return s, nil
+}
+
+func groupConfig(group string, offset int64) jetstream.ConsumerConfig {
+	cfg := jetstream.ConsumerConfig{
+		Durable:       group,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+	}
+	if offset > 0 {
+		cfg.DeliverPolicy = jetstream.DeliverByStartSequencePolicy
+		cfg.OptStartSeq = uint64(offset) + 1
+	}
+	return cfg
+}
+
+// streamName turns a topic into a stream name, which cannot hold dots or wildcards
+func streamName(topic string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_").Replace(topic))
+}
+
+func fromJetStreamMessage(msg jetstream.Msg) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(msg.Data(), &env); err != nil {
+		return Envelope{}, fmt.Errorf("failed to decode message on %s: %w", msg.Subject(), err)
+	}
+	return env, nil
+}
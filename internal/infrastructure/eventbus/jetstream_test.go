@@ -0,0 +1,121 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+)
+
+// newTestJetStream runs an in-process NATS server with JetStream on a random port
+func newTestJetStream(t *testing.T) *JetStreamBus {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{Host: "127.0.0.1", Port: -1, JetStream: true, StoreDir: t.TempDir(), NoLog: true, NoSigs: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats server did not start")
+	}
+
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	bus, err := NewJetStreamBus(nc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return bus
+}
+
+func TestJetStreamBus_GroupsAndReplay(t *testing.T) {
+	bus := newTestJetStream(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	events := []Envelope{
+		{ID: "e1", Type: "article.published", Version: 2, Key: "a1"},
+		{ID: "e2", Type: "article.published", Version: 2, Key: "a2"},
+		{ID: "e3", Type: "article.published", Version: 2, Key: "a1"},
+	}
+	if err := bus.Publish(ctx, "cms.articles", events...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Published again by a retrying producer, dropped as a duplicate
+	if err := bus.Publish(ctx, "cms.articles", events[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A failing handler stops the subscription, the event is redelivered next time
+	failOnce := errors.New("search index unavailable")
+	var seen []string
+	err := bus.Subscribe(ctx, "cms.articles", "search", func(ctx context.Context, env Envelope) error {
+		if env.ID == "e2" && failOnce != nil {
+			err := failOnce
+			failOnce = nil
+			return err
+		}
+		seen = append(seen, env.ID)
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected the first subscription to stop on the handler error")
+	}
+
+	subCtx, stop := context.WithCancel(ctx)
+	err = bus.Subscribe(subCtx, "cms.articles", "search", func(ctx context.Context, env Envelope) error {
+		seen = append(seen, env.ID)
+		if len(seen) == 3 {
+			stop()
+		}
+		return nil
+	})
+	slices.Sort(seen)
+	if !errors.Is(err, context.Canceled) || !slices.Equal(seen, []string{"e1", "e2", "e3"}) {
+		t.Errorf("expected e2 redelivered and all three seen once, got %v %v", seen, err)
+	}
+
+	// A replay reads from the offset to the end without touching the group
+	var replayed []string
+	if err := bus.Replay(ctx, "cms.articles", 1, func(ctx context.Context, env Envelope) error {
+		replayed = append(replayed, env.ID)
+		return nil
+	}); err != nil || len(replayed) != 2 || replayed[0] != "e2" || replayed[1] != "e3" {
+		t.Errorf("expected e2 and e3 replayed from offset 1, got %v %v", replayed, err)
+	}
+	if err := bus.Replay(ctx, "cms.articles", 3, func(ctx context.Context, env Envelope) error {
+		t.Errorf("expected nothing past the end, got %s", env.ID)
+		return nil
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Resetting the group to 2 hands e3 out again
+	if err := bus.ResetGroup(ctx, "cms.articles", "search", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	subCtx, stop = context.WithCancel(ctx)
+	var rebuilt []string
+	err = bus.Subscribe(subCtx, "cms.articles", "search", func(ctx context.Context, env Envelope) error {
+		rebuilt = append(rebuilt, env.ID)
+		stop()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || len(rebuilt) != 1 || rebuilt[0] != "e3" {
+		t.Errorf("expected the group to resume at e3, got %v %v", rebuilt, err)
+	}
+}
+
+func TestStreamName(t *testing.T) {
+	if got := streamName("cms.articles"); got != "CMS_ARTICLES" {
+		t.Errorf("expected CMS_ARTICLES, got %s", got)
+	}
+}
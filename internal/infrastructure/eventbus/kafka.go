@@ -0,0 +1,252 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers naming the envelope's schema, so tools can route a record without decoding it
+const (
+	headerEventType    = "event-type"
+	headerEventVersion = "event-version"
+)
+
+// KafkaBus keeps events as records whose value is the JSON envelope, keyed by
+// Envelope.Key so one aggregate's events share a partition and stay in order.
+// Topics are not created on publish, their partition count is chosen up front.
+type KafkaBus struct {
+	brokers []string
+	writer  *kafka.Writer
+	client  *kafka.Client
+}
+
+func NewKafkaBus(brokers []string) *KafkaBus {
+	addr := kafka.TCP(brokers...)
+	return &KafkaBus{
+		brokers: brokers,
+		writer: &kafka.Writer{
+			Addr:         addr,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchTimeout: 10 * time.Millisecond,
+		},
+		client: &kafka.Client{Addr: addr},
+	}
+}
+
+var (
+	_ Publisher     = (*KafkaBus)(nil)
+	_ Subscriber    = (*KafkaBus)(nil)
+	_ Replayer      = (*KafkaBus)(nil)
+	_ GroupResetter = (*KafkaBus)(nil)
+)
+
+// Close flushes and closes the producer
+func (b *KafkaBus) Close() error {
+	return b.writer.Close()
+}
+
+func (b *KafkaBus) Publish(ctx context.Context, topic string, events ...Envelope) error {
+	msgs := make([]kafka.Message, 0, len(events))
+	for _, env := range events {
+		msg, err := toKafkaMessage(topic, env)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := b.writer.WriteMessages(ctx, msgs...); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// Subscribe joins the consumer group, Kafka spreads the topic's partitions over
+// the members. A record is committed once its handler returns; a failure leaves
+// it uncommitted and ends the subscription, so the next member to own the
+// partition gets it again.
+func (b *KafkaBus) Subscribe(ctx context.Context, topic, group string, handler Handler) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     b.brokers,
+		GroupID:     group,
+		Topic:       topic,
+		StartOffset: kafka.FirstOffset,
+	})
+	defer reader.Close()
+
+	for {
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			return err
+		}
+		env, err := fromKafkaMessage(msg)
+		if err != nil {
+			return err
+		}
+		if err := handler(ctx, env); err != nil {
+			return err
+		}
+		commitCtx, cancel := commitContext(ctx)
+		err = reader.CommitMessages(commitCtx, msg)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to commit %s/%d at %d: %w", topic, msg.Partition, msg.Offset, err)
+		}
+	}
+}
+
+// Replay reads each partition from fromOffset up to where it ended when the
+// replay started, one partition after the other. Offsets count per partition,
+// so 0 replays everything; one key's events keep their order.
+func (b *KafkaBus) Replay(ctx context.Context, topic string, fromOffset int64, handler Handler) error {
+	partitions, err := b.partitionOffsets(ctx, topic)
+	if err != nil {
+		return err
+	}
+	for _, p := range partitions {
+		start := max(fromOffset, p.FirstOffset)
+		if start >= p.LastOffset {
+			continue
+		}
+		if err := b.replayPartition(ctx, topic, p.Partition, start, p.LastOffset, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *KafkaBus) replayPartition(ctx context.Context, topic string, partition int, start, end int64, handler Handler) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{Brokers: b.brokers, Topic: topic, Partition: partition})
+	defer reader.Close()
+	if err := reader.SetOffset(start); err != nil {
+		return err
+	}
+
+	for offset := start; offset < end; {
+		msg, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return err
+		}
+		env, err := fromKafkaMessage(msg)
+		if err != nil {
+			return err
+		}
+		if err := handler(ctx, env); err != nil {
+			return err
+		}
+		offset = msg.Offset + 1
+	}
+	return nil
+}
+
+// ResetGroup commits offset on every partition, clamped to what the partition
+// still holds. Kafka refuses the commit while the group has live members.
+func (b *KafkaBus) ResetGroup(ctx context.Context, topic, group string, offset int64) error {
+	partitions, err := b.partitionOffsets(ctx, topic)
+	if err != nil {
+		return err
+	}
+	commits := make([]kafka.OffsetCommit, 0, len(partitions))
+	for _, p := range partitions {
+		commits = append(commits, kafka.OffsetCommit{Partition: p.Partition, Offset: min(max(offset, p.FirstOffset), p.LastOffset)})
+	}
+
+	resp, err := b.client.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID:      group,
+		GenerationID: -1, // Outside any generation, only accepted for an empty group
+		Topics:       map[string][]kafka.OffsetCommit{topic: commits},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset %s on %s: %w", group, topic, err)
+	}
+	for _, p := range resp.Topics[topic] {
+		if p.Error != nil {
+			return fmt.Errorf("failed to reset %s on %s/%d: %w", group, topic, p.Partition, p.Error)
+		}
+	}
+	return nil
+}
+
+// partitionOffsets returns the first offset and the end, the offset the next
+// record will get, of every partition of the topic
+func (b *KafkaBus) partitionOffsets(ctx context.Context, topic string) ([]kafka.PartitionOffsets, error) {
+	meta, err := b.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe %s: %w", topic, err)
+	}
+	if len(meta.Topics) != 1 {
+		return nil, fmt.Errorf("topic %s not found", topic)
+	}
+	if err := meta.Topics[0].Error; err != nil {
+		return nil, fmt.Errorf("failed to describe %s: %w", topic, err)
+	}
+
+	var first, last []kafka.OffsetRequest
+	for _, p := range meta.Topics[0].Partitions {
+		first = append(first, kafka.FirstOffsetOf(p.ID))
+		last = append(last, kafka.LastOffsetOf(p.ID))
+	}
+	// One request each, brokers reject a partition listed twice
+	firsts, err := b.listOffsets(ctx, topic, first)
+	if err != nil {
+		return nil, err
+	}
+	lasts, err := b.listOffsets(ctx, topic, last)
+	if err != nil {
+		return nil, err
+	}
+
+	partitions := make([]kafka.PartitionOffsets, 0, len(firsts))
+	for id, p := range firsts {
+		p.LastOffset = lasts[id].LastOffset
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i].Partition < partitions[j].Partition })
+	return partitions, nil
+}
+
+func (b *KafkaBus) listOffsets(ctx context.Context, topic string, requests []kafka.OffsetRequest) (map[int]kafka.PartitionOffsets, error) {
+	resp, err := b.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{Topics: map[string][]kafka.OffsetRequest{topic: requests}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offsets of %s: %w", topic, err)
+	}
+	offsets := map[int]kafka.PartitionOffsets{}
+	for _, p := range resp.Topics[topic] {
+		if p.Error != nil {
+			return nil, fmt.Errorf("failed to list offsets of %s/%d: %w", topic, p.Partition, p.Error)
+		}
+		offsets[p.Partition] = p
+	}
+	return offsets, nil
+}
+
+func toKafkaMessage(topic string, env Envelope) (kafka.Message, error) {
+	value, err := json.Marshal(env)
+	if err != nil {
+		return kafka.Message{}, fmt.Errorf("failed to encode event %s: %w", env.ID, err)
+	}
+	return kafka.Message{
+		Topic: topic,
+		Key:   []byte(env.Key),
+		Value: value,
+		Time:  env.OccurredAt,
+		Headers: []kafka.Header{
+			{Key: headerEventType, Value: []byte(env.Type)},
+			{Key: headerEventVersion, Value: []byte(strconv.Itoa(env.Version))},
+		},
+	}, nil
+}
+
+func fromKafkaMessage(msg kafka.Message) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(msg.Value, &env); err != nil {
+		return Envelope{}, fmt.Errorf("failed to decode %s/%d at %d: %w", msg.Topic, msg.Partition, msg.Offset, err)
+	}
+	return env, nil
+}
@@ -0,0 +1,122 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestKafkaMessage(t *testing.T) {
+	at := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	env := Envelope{ID: "e1", Type: "article.published", Version: 2, Key: "a1", OccurredAt: at, Payload: json.RawMessage(`{"article_id":"a1"}`)}
+
+	msg, err := toKafkaMessage("cms.articles", env)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Topic != "cms.articles" || string(msg.Key) != "a1" || !msg.Time.Equal(at) {
+		t.Errorf("expected the record keyed by the aggregate, got %+v", msg)
+	}
+	if len(msg.Headers) != 2 || string(msg.Headers[0].Value) != "article.published" || string(msg.Headers[1].Value) != "2" {
+		t.Errorf("expected the schema in the headers, got %+v", msg.Headers)
+	}
+
+	decoded, err := fromKafkaMessage(msg)
+	if err != nil || decoded.ID != "e1" || decoded.Version != 2 || string(decoded.Payload) != `{"article_id":"a1"}` {
+		t.Errorf("expected the envelope back, got %+v %v", decoded, err)
+	}
+	if _, err := fromKafkaMessage(kafka.Message{Topic: "cms.articles", Value: []byte("not json")}); err == nil {
+		t.Error("expected a record that is not an envelope rejected")
+	}
+}
+
+// TestKafkaBus runs against the brokers in KAFKA_BROKERS, e.g. a local
+// single-node cluster, and is skipped without them
+func TestKafkaBus(t *testing.T) {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		t.Skip("KAFKA_BROKERS is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	topic := fmt.Sprintf("eventbus-test-%d", time.Now().UnixNano())
+	conn, err := kafka.DialContext(ctx, "tcp", strings.Split(brokers, ",")[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer conn.Close()
+	controller, err := conn.Controller()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	admin, err := kafka.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", controller.Host, controller.Port))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer admin.Close()
+	if err := admin.CreateTopics(kafka.TopicConfig{Topic: topic, NumPartitions: 1, ReplicationFactor: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bus := NewKafkaBus(strings.Split(brokers, ","))
+	defer bus.Close()
+	if err := bus.Publish(ctx, topic, Envelope{ID: "e1", Key: "a1"}, Envelope{ID: "e2", Key: "a1"}, Envelope{ID: "e3", Key: "a1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	failOnce := errors.New("search index unavailable")
+	var seen []string
+	if err := bus.Subscribe(ctx, topic, "search", func(ctx context.Context, env Envelope) error {
+		if env.ID == "e2" && failOnce != nil {
+			err := failOnce
+			failOnce = nil
+			return err
+		}
+		seen = append(seen, env.ID)
+		return nil
+	}); err == nil {
+		t.Fatal("expected the first subscription to stop on the handler error")
+	}
+	subCtx, stop := context.WithCancel(ctx)
+	err = bus.Subscribe(subCtx, topic, "search", func(ctx context.Context, env Envelope) error {
+		seen = append(seen, env.ID)
+		if len(seen) == 3 {
+			stop()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || !slices.Equal(seen, []string{"e1", "e2", "e3"}) {
+		t.Errorf("expected e2 redelivered and all three seen in order, got %v %v", seen, err)
+	}
+
+	var replayed []string
+	if err := bus.Replay(ctx, topic, 1, func(ctx context.Context, env Envelope) error {
+		replayed = append(replayed, env.ID)
+		return nil
+	}); err != nil || !slices.Equal(replayed, []string{"e2", "e3"}) {
+		t.Errorf("expected e2 and e3 replayed from offset 1, got %v %v", replayed, err)
+	}
+
+	if err := bus.ResetGroup(ctx, topic, "search", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	subCtx, stop = context.WithCancel(ctx)
+	var rebuilt []string
+	err = bus.Subscribe(subCtx, topic, "search", func(ctx context.Context, env Envelope) error {
+		rebuilt = append(rebuilt, env.ID)
+		stop()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || !slices.Equal(rebuilt, []string{"e3"}) {
+		t.Errorf("expected the group to resume at e3, got %v %v", rebuilt, err)
+	}
+}
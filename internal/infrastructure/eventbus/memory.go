@@ -0,0 +1,120 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+)
+
+type memoryGroup struct {
+	next   int64   // Next offset to hand out
+	failed []int64 // Offsets whose handler failed, handed out again first
+}
+
+// MemoryLog is an in-process, append-only log per topic with consumer groups,
+// for a single replica and for tests
+type MemoryLog struct {
+	mu      sync.Mutex
+	topics  map[string][]Envelope
+	groups  map[string]*memoryGroup // topic + "/" + group
+	changed chan struct{}           // Closed and replaced on every publish
+}
+
+func NewMemoryLog() *MemoryLog {
+	return &MemoryLog{topics: map[string][]Envelope{}, groups: map[string]*memoryGroup{}, changed: make(chan struct{})}
+}
+
+func (m *MemoryLog) Publish(ctx context.Context, topic string, events ...Envelope) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.topics[topic] = append(m.topics[topic], events...)
+	close(m.changed)
+	m.changed = make(chan struct{})
+	return nil
+}
+
+func (m *MemoryLog) Subscribe(ctx context.Context, topic, group string, handler Handler) error {
+	for {
+		offset, env, wait := m.claim(topic, group)
+		if wait != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-wait:
+				continue
+			}
+		}
+		if err := handler(ctx, env); err != nil {
+			m.fail(topic, group, offset)
+			return err
+		}
+	}
+}
+
+func (m *MemoryLog) Replay(ctx context.Context, topic string, fromOffset int64, handler Handler) error {
+	m.mu.Lock()
+	events := m.topics[topic][min(max(fromOffset, 0), int64(len(m.topics[topic]))):]
+	m.mu.Unlock()
+
+	for _, env := range events {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := handler(ctx, env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResetGroup moves the group to offset and forgets its failed events
+func (m *MemoryLog) ResetGroup(ctx context.Context, topic, group string, offset int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g := m.group(topic, group)
+	g.next = min(max(offset, 0), int64(len(m.topics[topic])))
+	g.failed = nil
+	return nil
+}
+
+// Offset is the group's position, the next offset it will be handed
+func (m *MemoryLog) Offset(topic, group string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.group(topic, group).next
+}
+
+// claim hands out the group's next event, or a channel to wait on when it is caught up
+func (m *MemoryLog) claim(topic, group string) (int64, Envelope, <-chan struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g := m.group(topic, group)
+	if len(g.failed) > 0 {
+		offset := g.failed[0]
+		g.failed = g.failed[1:]
+		return offset, m.topics[topic][offset], nil
+	}
+	if g.next >= int64(len(m.topics[topic])) {
+		return 0, Envelope{}, m.changed
+	}
+	offset := g.next
+	g.next++
+	return offset, m.topics[topic][offset], nil
+}
+
+func (m *MemoryLog) fail(topic, group string, offset int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g := m.group(topic, group)
+	g.failed = append(g.failed, offset)
+}
+
+func (m *MemoryLog) group(topic, group string) *memoryGroup {
+	key := topic + "/" + group
+	g, ok := m.groups[key]
+	if !ok {
+		g = &memoryGroup{}
+		m.groups[key] = g
+	}
+	return g
+}
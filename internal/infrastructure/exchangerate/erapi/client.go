@@ -0,0 +1,73 @@
+package erapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/localization"
+)
+
+const DefaultBaseURL = "https://open.er-api.com"
+
+// Client reads daily reference rates from ExchangeRate-API's open endpoint.
+// The rates update once a day, which is all approximate conversions need.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewClient(httpClient *http.Client, baseURL string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpClient, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+var _ localization.RateProvider = (*Client)(nil)
+
+type latestResponse struct {
+	Result             string             `json:"result"`
+	ErrorType          string             `json:"error-type"`
+	TimeLastUpdateUnix int64              `json:"time_last_update_unix"`
+	Rates              map[string]float64 `json:"rates"`
+}
+
+func (c *Client) Latest(ctx context.Context, base, quote localization.Currency) (*localization.Rate, error) {
+	endpoint := c.baseURL + "/v6/latest/" + url.PathEscape(string(base))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get rates: ExchangeRate-API returned %d", resp.StatusCode)
+	}
+
+	var body latestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode rates: %w", err)
+	}
+	// Errors come back as 200 with result "error"
+	if body.Result != "success" {
+		return nil, fmt.Errorf("failed to get rates: %s", body.ErrorType)
+	}
+	value, ok := body.Rates[string(quote)]
+	if !ok {
+		return nil, fmt.Errorf("failed to get rates: no %s rate", quote)
+	}
+	// The time of the rate itself, so a provider stuck on old data shows up as stale
+	return localization.NewRate(base, quote, value, time.Unix(body.TimeLastUpdateUnix, 0).UTC())
+}
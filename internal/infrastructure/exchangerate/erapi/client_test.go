@@ -0,0 +1,36 @@
+package erapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/localization"
+)
+
+func TestClient_Latest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v6/latest/USD":
+			_, _ = w.Write([]byte(`{"result":"success","time_last_update_unix":1777852801,"rates":{"USD":1,"IDR":16250.5}}`))
+		default:
+			_, _ = w.Write([]byte(`{"result":"error","error-type":"unsupported-code"}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.Client(), server.URL)
+
+	rate, err := client.Latest(context.Background(), localization.CurrencyUSD, localization.CurrencyIDR)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rate.Value != 16250.5 || !rate.FetchedAt.Equal(time.Unix(1777852801, 0)) {
+		t.Errorf("unexpected rate %+v", rate)
+	}
+
+	if _, err := client.Latest(context.Background(), localization.CurrencyIDR, localization.CurrencyUSD); err == nil {
+		t.Error("expected an error result to fail")
+	}
+}
@@ -0,0 +1,35 @@
+// Package postgis stores article geo-tags in PostgreSQL with PostGIS.
+//
+// Each tag keeps its full geometry (point or polygon) for area and radius
+// queries, and a center point for pins and grid clustering. Only published
+// articles are indexed, drafts never reach the map.
+//
+// The store talks database/sql; the composition root registers the pgx
+// driver and opens the *sql.DB.
+package postgis
+
+// Migrations create the schema, each statement is idempotent
+var Migrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS postgis`,
+
+	`CREATE TABLE IF NOT EXISTS article_locations (
+		article_id     TEXT PRIMARY KEY,
+		kind           TEXT NOT NULL,
+		geom           geometry(Geometry, 4326) NOT NULL,
+		center         geometry(Point, 4326) NOT NULL,
+		place_name     TEXT NOT NULL DEFAULT '',
+		published_at   TIMESTAMPTZ,
+		last_action_by TEXT,
+		created_at     TIMESTAMPTZ NOT NULL,
+		updated_at     TIMESTAMPTZ NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS article_locations_geom_idx
+		ON article_locations USING GIST (geom) WHERE published_at IS NOT NULL`,
+
+	`CREATE INDEX IF NOT EXISTS article_locations_geog_idx
+		ON article_locations USING GIST ((geom::geography)) WHERE published_at IS NOT NULL`,
+
+	`CREATE INDEX IF NOT EXISTS article_locations_center_idx
+		ON article_locations USING GIST (center) WHERE published_at IS NOT NULL`,
+}
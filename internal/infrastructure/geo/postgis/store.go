@@ -0,0 +1,261 @@
+package postgis
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/geo"
+)
+
+const locationColumns = `article_id, kind, ST_AsGeoJSON(geom), ST_X(center), ST_Y(center),
+	place_name, published_at, last_action_by, created_at, updated_at`
+
+const upsertLocation = `INSERT INTO article_locations
+		(article_id, kind, geom, center, place_name, published_at, last_action_by, created_at, updated_at)
+	VALUES ($1, $2, ST_SetSRID(ST_GeomFromGeoJSON($3), 4326), ST_SetSRID(ST_MakePoint($4, $5), 4326), $6, $7, $8, $9, $10)
+	ON CONFLICT (article_id) DO UPDATE SET
+		kind = EXCLUDED.kind,
+		geom = EXCLUDED.geom,
+		center = EXCLUDED.center,
+		place_name = EXCLUDED.place_name,
+		published_at = EXCLUDED.published_at,
+		last_action_by = EXCLUDED.last_action_by,
+		updated_at = EXCLUDED.updated_at`
+
+const withinQuery = `SELECT ` + locationColumns + `
+	FROM article_locations
+	WHERE published_at IS NOT NULL AND published_at >= $5
+		AND ST_Intersects(geom, ST_MakeEnvelope($1, $2, $3, $4, 4326))
+	ORDER BY published_at DESC, article_id
+	LIMIT $6`
+
+const nearQuery = `SELECT ` + locationColumns + `,
+		ST_Distance(geom::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography) / 1000 AS distance_km
+	FROM article_locations
+	WHERE published_at IS NOT NULL AND published_at >= $4
+		AND ST_DWithin(geom::geography, ST_SetSRID(ST_MakePoint($1, $2), 4326)::geography, $3)
+	ORDER BY distance_km, article_id
+	LIMIT $5`
+
+// clusterQuery snaps centers to the grid, the cluster marker sits at the
+// centroid of its articles rather than the cell corner
+const clusterQuery = `SELECT count(*),
+		ST_Y(ST_Centroid(ST_Collect(center))), ST_X(ST_Centroid(ST_Collect(center))),
+		ST_YMin(ST_Extent(center)), ST_XMin(ST_Extent(center)),
+		ST_YMax(ST_Extent(center)), ST_XMax(ST_Extent(center)),
+		min(article_id)
+	FROM article_locations
+	WHERE published_at IS NOT NULL AND published_at >= $5
+		AND center && ST_MakeEnvelope($1, $2, $3, $4, 4326)
+	GROUP BY ST_SnapToGrid(center, $6)
+	ORDER BY count(*) DESC`
+
+// Store implements geo.LocationRepository on PostGIS
+type Store struct {
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+var _ geo.LocationRepository = (*Store)(nil)
+
+// Migrate applies the schema, safe to run on every start
+func (s *Store) Migrate(ctx context.Context) error {
+	for _, stmt := range Migrations {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to apply geo migration: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) Save(ctx context.Context, l *geo.ArticleLocation) error {
+	geoJSON, err := encodeGeometry(l.Location)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, upsertLocation,
+		l.ArticleID, string(l.Location.Kind), geoJSON, l.Location.Center.Lng, l.Location.Center.Lat,
+		l.Location.PlaceName, l.PublishedAt, l.LastActionBy, l.CreatedAt, l.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save location: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, articleID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM article_locations WHERE article_id = $1`, articleID); err != nil {
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) SetPublished(ctx context.Context, articleID string, at *time.Time) error {
+	if _, err := s.db.ExecContext(ctx, `UPDATE article_locations SET published_at = $2 WHERE article_id = $1`, articleID, at); err != nil {
+		return fmt.Errorf("failed to update location publication: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) FindByArticle(ctx context.Context, articleID string) (*geo.ArticleLocation, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+locationColumns+` FROM article_locations WHERE article_id = $1`, articleID)
+	l, err := scanLocation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return l, err
+}
+
+func (s *Store) FindWithin(ctx context.Context, box geo.BoundingBox, since time.Time, limit int) ([]*geo.ArticleLocation, error) {
+	rows, err := s.db.QueryContext(ctx, withinQuery, box.West, box.South, box.East, box.North, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query locations in area: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []*geo.ArticleLocation
+	for rows.Next() {
+		l, err := scanLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, l)
+	}
+	return locations, rows.Err()
+}
+
+func (s *Store) FindNear(ctx context.Context, center geo.Point, radiusKm float64, since time.Time, limit int) ([]geo.Nearby, error) {
+	rows, err := s.db.QueryContext(ctx, nearQuery, center.Lng, center.Lat, radiusKm*1000, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nearby locations: %w", err)
+	}
+	defer rows.Close()
+
+	var nearby []geo.Nearby
+	for rows.Next() {
+		var n geo.Nearby
+		l, err := scanLocation(rows, &n.DistanceKm)
+		if err != nil {
+			return nil, err
+		}
+		n.Location = l
+		nearby = append(nearby, n)
+	}
+	return nearby, rows.Err()
+}
+
+func (s *Store) Clusters(ctx context.Context, box geo.BoundingBox, gridSize float64, since time.Time) ([]geo.Cluster, error) {
+	rows, err := s.db.QueryContext(ctx, clusterQuery, box.West, box.South, box.East, box.North, since, gridSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query clusters: %w", err)
+	}
+	defer rows.Close()
+
+	var clusters []geo.Cluster
+	for rows.Next() {
+		var c geo.Cluster
+		var firstArticleID string
+		b := &c.Bounds
+		if err := rows.Scan(&c.Count, &c.Center.Lat, &c.Center.Lng, &b.South, &b.West, &b.North, &b.East, &firstArticleID); err != nil {
+			return nil, fmt.Errorf("failed to scan cluster: %w", err)
+		}
+		if c.Count == 1 {
+			c.ArticleID = firstArticleID
+		}
+		clusters = append(clusters, c)
+	}
+	return clusters, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLocation(row scanner, extra ...any) (*geo.ArticleLocation, error) {
+	var (
+		l            geo.ArticleLocation
+		kind         string
+		geoJSON      string
+		published    sql.NullTime
+		lastActionBy sql.NullString
+	)
+	dest := []any{&l.ArticleID, &kind, &geoJSON, &l.Location.Center.Lng, &l.Location.Center.Lat,
+		&l.Location.PlaceName, &published, &lastActionBy, &l.CreatedAt, &l.UpdatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan location: %w", err)
+	}
+
+	l.Location.Kind = geo.LocationKind(kind)
+	if l.Location.Kind == geo.KindRegion {
+		ring, err := decodeRing(geoJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode region of %s: %w", l.ArticleID, err)
+		}
+		l.Location.Ring = ring
+	}
+	if published.Valid {
+		l.PublishedAt = &published.Time
+	}
+	if lastActionBy.Valid {
+		l.LastActionBy = &lastActionBy.String
+	}
+	return &l, nil
+}
+
+// geometry is the GeoJSON subset the store reads and writes, coordinates are [lng, lat]
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+func encodeGeometry(l geo.Location) (string, error) {
+	var g struct {
+		Type        string `json:"type"`
+		Coordinates any    `json:"coordinates"`
+	}
+	switch l.Kind {
+	case geo.KindPoint:
+		g.Type, g.Coordinates = "Point", [2]float64{l.Center.Lng, l.Center.Lat}
+	case geo.KindRegion:
+		ring := make([][2]float64, len(l.Ring))
+		for i, p := range l.Ring {
+			ring[i] = [2]float64{p.Lng, p.Lat}
+		}
+		g.Type, g.Coordinates = "Polygon", [][][2]float64{ring}
+	default:
+		return "", geo.ErrUnknownLocationKind
+	}
+	encoded, err := json.Marshal(g)
+	return string(encoded), err
+}
+
+func decodeRing(raw string) ([]geo.Point, error) {
+	var g geometry
+	if err := json.Unmarshal([]byte(raw), &g); err != nil {
+		return nil, err
+	}
+	if g.Type != "Polygon" {
+		return nil, fmt.Errorf("expected a Polygon, got %s", g.Type)
+	}
+	var rings [][][2]float64
+	if err := json.Unmarshal(g.Coordinates, &rings); err != nil {
+		return nil, err
+	}
+	if len(rings) == 0 {
+		return nil, errors.New("polygon has no rings")
+	}
+	ring := make([]geo.Point, len(rings[0]))
+	for i, c := range rings[0] {
+		ring[i] = geo.Point{Lat: c[1], Lng: c[0]}
+	}
+	return ring, nil
+}
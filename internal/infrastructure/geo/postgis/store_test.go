@@ -0,0 +1,38 @@
+package postgis
+
+import (
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/geo"
+)
+
+func TestEncodeGeometry(t *testing.T) {
+	point, _ := geo.NewPointLocation(geo.Point{Lat: -6.2, Lng: 106.8}, "")
+	encoded, err := encodeGeometry(*point)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if encoded != `{"type":"Point","coordinates":[106.8,-6.2]}` {
+		t.Errorf("expected lng, lat order, got %s", encoded)
+	}
+
+	region, _ := geo.NewRegionLocation([]geo.Point{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 2, Lng: 2}}, "")
+	encoded, err = encodeGeometry(*region)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	ring, err := decodeRing(encoded)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+	if len(ring) != len(region.Ring) || ring[1] != region.Ring[1] {
+		t.Errorf("expected the ring to round-trip, got %v", ring)
+	}
+
+	if _, err := encodeGeometry(geo.Location{Kind: "line"}); err != geo.ErrUnknownLocationKind {
+		t.Errorf("expected error '%v', got '%v'", geo.ErrUnknownLocationKind, err)
+	}
+	if _, err := decodeRing(`{"type":"Point","coordinates":[1,2]}`); err == nil {
+		t.Error("expected error decoding a point as a ring")
+	}
+}
@@ -0,0 +1,35 @@
+package legacy
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/migration"
+)
+
+// DirFiles opens legacy media from a copy of the old uploads directory,
+// locations are paths relative to it. Paths leaving the directory are
+// refused, also through symlinks.
+type DirFiles struct {
+	root *os.Root
+}
+
+func NewDirFiles(dir string) (*DirFiles, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &DirFiles{root: root}, nil
+}
+
+var _ migration.FileSource = (*DirFiles)(nil)
+
+func (f *DirFiles) Open(ctx context.Context, location string) (io.ReadCloser, error) {
+	return f.root.Open(strings.TrimPrefix(location, "/"))
+}
+
+func (f *DirFiles) Close() error {
+	return f.root.Close()
+}
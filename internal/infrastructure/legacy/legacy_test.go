@@ -0,0 +1,91 @@
+package legacy
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/migration"
+)
+
+func TestFetchStatement(t *testing.T) {
+	src := migration.Source{Query: "SELECT * FROM wp_users", NumericID: true, Columns: map[migration.Field]string{migration.FieldID: "ID"}}
+
+	mysql := &SQLSource{dialect: MySQL}
+	query, args, err := mysql.fetchStatement(src, "", 500)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query != "SELECT * FROM (SELECT * FROM wp_users) AS legacy ORDER BY legacy.`ID` LIMIT 500" || len(args) != 0 {
+		t.Errorf("unexpected first page %q %v", query, args)
+	}
+	query, args, _ = mysql.fetchStatement(src, "42", 500)
+	if query != "SELECT * FROM (SELECT * FROM wp_users) AS legacy WHERE legacy.`ID` > ? ORDER BY legacy.`ID` LIMIT 500" || args[0] != int64(42) {
+		t.Errorf("unexpected next page %q %v", query, args)
+	}
+	if _, _, err := mysql.fetchStatement(src, "abc", 500); err == nil {
+		t.Error("expected error for a text cursor on a numeric ID")
+	}
+
+	src.NumericID = false
+	src.Columns[migration.FieldID] = "node_uuid"
+	pg := &SQLSource{dialect: Postgres}
+	query, args, _ = pg.fetchStatement(src, "a1", 10)
+	if query != `SELECT * FROM (SELECT * FROM wp_users) AS legacy WHERE legacy."node_uuid" > $1 ORDER BY legacy."node_uuid" LIMIT 10` || args[0] != "a1" {
+		t.Errorf("unexpected postgres page %q %v", query, args)
+	}
+}
+
+func TestText(t *testing.T) {
+	at := time.Date(2019, time.April, 2, 8, 15, 0, 0, time.UTC)
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, ""},
+		{[]byte("kompas"), "kompas"},
+		{int64(42), "42"},
+		{at, "2019-04-02T08:15:00Z"},
+	}
+	for _, tt := range tests {
+		if got := text(tt.value); got != tt.want {
+			t.Errorf("text(%v): expected %q, got %q", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestDirFiles(t *testing.T) {
+	dir := t.TempDir()
+	uploads := filepath.Join(dir, "uploads")
+	if err := os.MkdirAll(filepath.Join(uploads, "2019", "04"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(uploads, "2019", "04", "laporan.pdf"), []byte("%PDF-1.4"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "wp-config.php"), []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := NewDirFiles(uploads)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer files.Close()
+
+	f, err := files.Open(context.Background(), "/2019/04/laporan.pdf")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := io.ReadAll(f)
+	f.Close()
+	if string(data) != "%PDF-1.4" {
+		t.Errorf("unexpected content %q", data)
+	}
+	if _, err := files.Open(context.Background(), "../wp-config.php"); err == nil {
+		t.Error("expected a path outside the directory refused")
+	}
+}
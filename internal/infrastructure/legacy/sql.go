@@ -0,0 +1,154 @@
+package legacy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/migration"
+)
+
+// Dialect is the SQL flavour of the legacy database
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	MySQL    Dialect = "mysql" // Also MariaDB, e.g. WordPress and Joomla sites
+)
+
+func (d Dialect) Valid() bool {
+	return d == Postgres || d == MySQL
+}
+
+// SQLSource reads legacy records through database/sql with the queries of a
+// migration config, the driver is registered by the caller. Each query is
+// wrapped as a subquery and paged by the column mapped to the ID field.
+type SQLSource struct {
+	db      *sql.DB
+	config  *migration.Config
+	dialect Dialect
+}
+
+func NewSQLSource(db *sql.DB, config *migration.Config, dialect Dialect) *SQLSource {
+	return &SQLSource{db: db, config: config, dialect: dialect}
+}
+
+var _ migration.RecordSource = (*SQLSource)(nil)
+
+func (s *SQLSource) Count(ctx context.Context, kind migration.Kind) (int64, error) {
+	src, ok := s.config.Source(kind)
+	if !ok {
+		return 0, fmt.Errorf("%s are not mapped", kind)
+	}
+	var n int64
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+src.Query+") AS legacy").Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *SQLSource) Fetch(ctx context.Context, kind migration.Kind, afterID string, limit int) ([]migration.Record, error) {
+	src, ok := s.config.Source(kind)
+	if !ok {
+		return nil, fmt.Errorf("%s are not mapped", kind)
+	}
+	query, args, err := s.fetchStatement(src, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(columns))
+	for i, column := range columns {
+		index[column] = i
+	}
+	for field, column := range src.Columns {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("%s: column %s mapped to %s is not selected by the query", kind, column, field)
+		}
+	}
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	var records []migration.Record
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		fields := make(map[migration.Field]string, len(src.Columns))
+		for field, column := range src.Columns {
+			fields[field] = text(values[index[column]])
+		}
+		id := fields[migration.FieldID]
+		if id == "" {
+			return nil, fmt.Errorf("%s: row without a value in %s", kind, src.IDColumn())
+		}
+		records = append(records, migration.Record{Kind: kind, LegacyID: id, Fields: fields})
+	}
+	return records, rows.Err()
+}
+
+func (s *SQLSource) fetchStatement(src migration.Source, afterID string, limit int) (string, []any, error) {
+	id := "legacy." + s.quoteIdent(src.IDColumn())
+	var b strings.Builder
+	b.WriteString("SELECT * FROM (" + src.Query + ") AS legacy")
+	var args []any
+	if afterID != "" {
+		var after any = afterID
+		if src.NumericID {
+			n, err := strconv.ParseInt(afterID, 10, 64)
+			if err != nil {
+				return "", nil, fmt.Errorf("cursor %q is not numeric", afterID)
+			}
+			after = n
+		}
+		b.WriteString(" WHERE " + id + " > " + s.placeholder(1))
+		args = append(args, after)
+	}
+	b.WriteString(" ORDER BY " + id + " LIMIT " + strconv.Itoa(limit))
+	return b.String(), args, nil
+}
+
+func (s *SQLSource) placeholder(n int) string {
+	if s.dialect == MySQL {
+		return "?"
+	}
+	return "$" + strconv.Itoa(n)
+}
+
+func (s *SQLSource) quoteIdent(name string) string {
+	if s.dialect == MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// text renders a scanned value the way migration.Record holds it
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v) // Text protocol values
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,128 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+var (
+	ErrNotAcquired = shared.ErrLocked
+	ErrLockLost    = errors.New("lock expired or was taken over")
+	ErrInvalidTTL  = errors.New("lock TTL must be at least a millisecond")
+)
+
+// Lease is a held lock. Token is a fencing token that grows with every
+// acquisition of the key: a store that remembers the highest token it has
+// seen can refuse a late write from a holder whose lease already ran out.
+type Lease struct {
+	Key        string
+	Token      uint64
+	AcquiredAt time.Time
+	ExpiresAt  time.Time
+
+	owner string // Random value proving the lease is still ours on extend and release
+}
+
+// Locker hands out leases that expire on their own, so a crashed replica
+// never holds a key forever
+type Locker interface {
+	// Acquire returns ErrNotAcquired when the key is held
+	Acquire(ctx context.Context, key string, ttl time.Duration) (*Lease, error)
+	// Extend and Release return ErrLockLost once the lease is no longer ours
+	Extend(ctx context.Context, lease *Lease, ttl time.Duration) error
+	Release(ctx context.Context, lease *Lease) error
+}
+
+// Observer receives metrics, e.g. to export contention and lost leases to the monitoring stack
+type Observer interface {
+	Acquired(key string)
+	Contended(key string)
+	Released(key string, held time.Duration)
+	Lost(key string)
+}
+
+// Run takes the lock, runs fn and releases the lock. While fn runs the lease
+// is extended every third of its TTL; if an extension fails the context passed
+// to fn is cancelled, fn must stop before another replica takes over. A held
+// key returns ErrNotAcquired without running fn.
+func Run(ctx context.Context, locker Locker, key string, ttl time.Duration, fn func(ctx context.Context, lease *Lease) error) error {
+	lease, err := locker.Acquire(ctx, key, ttl)
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := locker.Extend(runCtx, lease, ttl); err != nil {
+					cancel(fmt.Errorf("%w: %v", ErrLockLost, err))
+					return
+				}
+			}
+		}
+	}()
+
+	err = fn(runCtx, lease)
+	lost := context.Cause(runCtx)
+	cancel(nil)
+	<-done
+
+	if errors.Is(lost, ErrLockLost) {
+		return errors.Join(err, lost)
+	}
+	// Release on a fresh context, fn may have returned because ctx was cancelled
+	releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer releaseCancel()
+	if releaseErr := locker.Release(releaseCtx, lease); releaseErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to release lock %s: %w", key, releaseErr))
+	}
+	return err
+}
+
+// Runner is the shared.Locker application services take, each Run holds a
+// lease from the Locker it wraps
+type Runner struct {
+	locker Locker
+}
+
+var _ shared.Locker = (*Runner)(nil)
+
+func NewRunner(locker Locker) *Runner {
+	return &Runner{locker: locker}
+}
+
+func (r *Runner) Run(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	return Run(ctx, r.locker, key, ttl, func(ctx context.Context, _ *Lease) error {
+		return fn(ctx)
+	})
+}
+
+func validateTTL(ttl time.Duration) error {
+	if ttl < time.Millisecond {
+		return ErrInvalidTTL
+	}
+	return nil
+}
+
+func newOwner() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate lock owner: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,90 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+func TestRun(t *testing.T) {
+	locker := NewMemoryLocker(nil)
+	ctx := context.Background()
+
+	var token uint64
+	err := Run(ctx, locker, "account-merge", 60*time.Millisecond, func(ctx context.Context, lease *Lease) error {
+		token = lease.Token
+		// Outlives the TTL several times over, the lease is extended underneath
+		time.Sleep(200 * time.Millisecond)
+		if _, err := locker.Acquire(ctx, "account-merge", time.Second); !errors.Is(err, ErrNotAcquired) {
+			t.Errorf("expected error '%v', got '%v'", ErrNotAcquired, err)
+		}
+		return ctx.Err()
+	})
+	if err != nil || token != 1 {
+		t.Fatalf("expected a clean run with token 1, got %d %v", token, err)
+	}
+
+	// Released afterwards
+	lease, err := locker.Acquire(ctx, "account-merge", time.Second)
+	if err != nil || lease.Token != 2 {
+		t.Errorf("expected the key free with token 2, got %+v %v", lease, err)
+	}
+	if err := Run(ctx, locker, "account-merge", time.Second, func(ctx context.Context, lease *Lease) error {
+		t.Error("expected fn not run while the key is held")
+		return nil
+	}); !errors.Is(err, ErrNotAcquired) {
+		t.Errorf("expected error '%v', got '%v'", ErrNotAcquired, err)
+	}
+}
+
+func TestRun_LostLeaseCancels(t *testing.T) {
+	locker := NewMemoryLocker(nil)
+	var mu sync.Mutex
+	now := time.Now()
+	locker.now = func() time.Time { mu.Lock(); defer mu.Unlock(); return now }
+
+	err := Run(context.Background(), locker, "import-job", 30*time.Millisecond, func(ctx context.Context, lease *Lease) error {
+		// The process stalls past its TTL, e.g. a long GC pause
+		mu.Lock()
+		now = now.Add(time.Minute)
+		mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			t.Error("expected the run cancelled once the lease was lost")
+			return nil
+		}
+	})
+	if !errors.Is(err, ErrLockLost) {
+		t.Errorf("expected error '%v', got '%v'", ErrLockLost, err)
+	}
+}
+
+func TestMemoryLocker_InvalidTTL(t *testing.T) {
+	if _, err := NewMemoryLocker(nil).Acquire(context.Background(), "k", 0); err != ErrInvalidTTL {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidTTL, err)
+	}
+}
+
+func TestRunner(t *testing.T) {
+	locker := NewMemoryLocker(nil)
+	runner := NewRunner(locker)
+	ctx := context.Background()
+
+	ran := false
+	if err := runner.Run(ctx, "dunning-retries", time.Second, func(ctx context.Context) error {
+		ran = true
+		// Application services see the domain error while the key is held
+		if err := runner.Run(ctx, "dunning-retries", time.Second, func(ctx context.Context) error { return nil }); !errors.Is(err, shared.ErrLocked) {
+			t.Errorf("expected error '%v', got '%v'", shared.ErrLocked, err)
+		}
+		return nil
+	}); err != nil || !ran {
+		t.Fatalf("expected fn run under the lock, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// MemoryLocker locks within one process, for a single replica and for tests
+type MemoryLocker struct {
+	mu       sync.Mutex
+	held     map[string]memoryEntry
+	fences   map[string]uint64
+	observer Observer
+
+	now func() time.Time
+}
+
+func NewMemoryLocker(observer Observer) *MemoryLocker {
+	return &MemoryLocker{held: map[string]memoryEntry{}, fences: map[string]uint64{}, observer: observer, now: time.Now}
+}
+
+func (m *MemoryLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (*Lease, error) {
+	if err := validateTTL(ttl); err != nil {
+		return nil, err
+	}
+	owner, err := newOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if entry, ok := m.held[key]; ok && now.Before(entry.expiresAt) {
+		if m.observer != nil {
+			m.observer.Contended(key)
+		}
+		return nil, ErrNotAcquired
+	}
+
+	m.fences[key]++
+	lease := &Lease{Key: key, Token: m.fences[key], AcquiredAt: now, ExpiresAt: now.Add(ttl), owner: owner}
+	m.held[key] = memoryEntry{owner: owner, expiresAt: lease.ExpiresAt}
+	if m.observer != nil {
+		m.observer.Acquired(key)
+	}
+	return lease, nil
+}
+
+func (m *MemoryLocker) Extend(ctx context.Context, lease *Lease, ttl time.Duration) error {
+	if err := validateTTL(ttl); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if !m.holds(lease, now) {
+		if m.observer != nil {
+			m.observer.Lost(lease.Key)
+		}
+		return ErrLockLost
+	}
+	lease.ExpiresAt = now.Add(ttl)
+	m.held[lease.Key] = memoryEntry{owner: lease.owner, expiresAt: lease.ExpiresAt}
+	return nil
+}
+
+func (m *MemoryLocker) Release(ctx context.Context, lease *Lease) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	if !m.holds(lease, now) {
+		if m.observer != nil {
+			m.observer.Lost(lease.Key)
+		}
+		return ErrLockLost
+	}
+	delete(m.held, lease.Key)
+	if m.observer != nil {
+		m.observer.Released(lease.Key, now.Sub(lease.AcquiredAt))
+	}
+	return nil
+}
+
+func (m *MemoryLocker) holds(lease *Lease, now time.Time) bool {
+	entry, ok := m.held[lease.Key]
+	return ok && entry.owner == lease.owner && now.Before(entry.expiresAt)
+}
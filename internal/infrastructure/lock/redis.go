@@ -0,0 +1,105 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Only the owner may extend or delete a lock, checked and applied atomically on the server
+const (
+	extendScript  = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("pexpire", KEYS[1], ARGV[2]) else return 0 end`
+	releaseScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+)
+
+// RedisLocker locks on a single Redis instance. Locks expire through PX, the
+// fencing counter is a separate key that is never expired.
+type RedisLocker struct {
+	client   *Client
+	prefix   string
+	observer Observer
+
+	now func() time.Time
+}
+
+func NewRedisLocker(client *Client, prefix string, observer Observer) *RedisLocker {
+	return &RedisLocker{client: client, prefix: prefix, observer: observer, now: time.Now}
+}
+
+func (l *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (*Lease, error) {
+	if err := validateTTL(ttl); err != nil {
+		return nil, err
+	}
+	owner, err := newOwner()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := l.client.Do(ctx, "INCR", l.prefix+key+":fence")
+	if err != nil {
+		return nil, fmt.Errorf("failed to take fencing token: %w", err)
+	}
+	token, ok := reply.(int64)
+	if !ok || token < 1 {
+		return nil, fmt.Errorf("unexpected fencing token reply %v", reply)
+	}
+
+	now := l.now()
+	value := owner + ":" + strconv.FormatInt(token, 10)
+	reply, err = l.client.Do(ctx, "SET", l.prefix+key, value, "NX", "PX", millis(ttl))
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	if reply == nil {
+		if l.observer != nil {
+			l.observer.Contended(key)
+		}
+		return nil, ErrNotAcquired
+	}
+
+	if l.observer != nil {
+		l.observer.Acquired(key)
+	}
+	return &Lease{Key: key, Token: uint64(token), AcquiredAt: now, ExpiresAt: now.Add(ttl), owner: value}, nil
+}
+
+func (l *RedisLocker) Extend(ctx context.Context, lease *Lease, ttl time.Duration) error {
+	if err := validateTTL(ttl); err != nil {
+		return err
+	}
+	now := l.now()
+	if err := l.ownerScript(ctx, extendScript, lease, millis(ttl)); err != nil {
+		return err
+	}
+	lease.ExpiresAt = now.Add(ttl)
+	return nil
+}
+
+func (l *RedisLocker) Release(ctx context.Context, lease *Lease) error {
+	if err := l.ownerScript(ctx, releaseScript, lease); err != nil {
+		return err
+	}
+	if l.observer != nil {
+		l.observer.Released(lease.Key, l.now().Sub(lease.AcquiredAt))
+	}
+	return nil
+}
+
+func (l *RedisLocker) ownerScript(ctx context.Context, script string, lease *Lease, args ...string) error {
+	reply, err := l.client.Do(ctx, append([]string{"EVAL", script, "1", l.prefix + lease.Key, lease.owner}, args...)...)
+	if err != nil {
+		return fmt.Errorf("failed to run lock script on %s: %w", lease.Key, err)
+	}
+	if n, ok := reply.(int64); !ok || n == 0 {
+		if l.observer != nil {
+			l.observer.Lost(lease.Key)
+		}
+		return ErrLockLost
+	}
+	return nil
+}
+
+func millis(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10)
+}
@@ -0,0 +1,191 @@
+package lock
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis understands the commands the locker sends, with expiry on its own clock
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+	now     time.Time
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	f := &fakeRedis{values: map[string]string{}, expires: map[string]time.Time{}, now: time.Now()}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f, ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		cmd, err := readReply(rd)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, a := range cmd.([]any) {
+			args = append(args, a.(string))
+		}
+		_, _ = conn.Write([]byte(f.exec(args)))
+	}
+}
+
+func (f *fakeRedis) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func (f *fakeRedis) get(key string) (string, bool) {
+	if at, ok := f.expires[key]; ok && !f.now.Before(at) {
+		delete(f.values, key)
+		delete(f.expires, key)
+	}
+	v, ok := f.values[key]
+	return v, ok
+}
+
+func (f *fakeRedis) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch args[0] {
+	case "INCR":
+		v, _ := f.get(args[1])
+		n, _ := strconv.Atoi(v)
+		f.values[args[1]] = strconv.Itoa(n + 1)
+		return ":" + strconv.Itoa(n+1) + "\r\n"
+	case "SET": // SET key value NX PX ms
+		if _, ok := f.get(args[1]); ok {
+			return "$-1\r\n"
+		}
+		ms, _ := strconv.Atoi(args[5])
+		f.values[args[1]] = args[2]
+		f.expires[args[1]] = f.now.Add(time.Duration(ms) * time.Millisecond)
+		return "+OK\r\n"
+	case "EVAL": // EVAL script 1 key owner [ms]
+		if v, ok := f.get(args[3]); !ok || v != args[4] {
+			return ":0\r\n"
+		}
+		switch args[1] {
+		case extendScript:
+			ms, _ := strconv.Atoi(args[5])
+			f.expires[args[3]] = f.now.Add(time.Duration(ms) * time.Millisecond)
+		case releaseScript:
+			delete(f.values, args[3])
+			delete(f.expires, args[3])
+		}
+		return ":1\r\n"
+	}
+	return "-ERR unknown command '" + args[0] + "'\r\n"
+}
+
+type countingObserver struct {
+	mu                                  sync.Mutex
+	acquired, contended, released, lost int
+}
+
+func (o *countingObserver) Acquired(key string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.acquired++
+}
+
+func (o *countingObserver) Contended(key string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.contended++
+}
+
+func (o *countingObserver) Released(key string, held time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.released++
+}
+
+func (o *countingObserver) Lost(key string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.lost++
+}
+
+func TestRedisLocker(t *testing.T) {
+	server, addr := startFakeRedis(t)
+	observer := &countingObserver{}
+	client := NewClient(addr, "", 0)
+	defer client.Close()
+	locker := NewRedisLocker(client, "lock:", observer)
+	ctx := context.Background()
+
+	first, err := locker.Acquire(ctx, "publish-scheduler", time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := locker.Acquire(ctx, "publish-scheduler", time.Second); !errors.Is(err, ErrNotAcquired) {
+		t.Errorf("expected error '%v', got '%v'", ErrNotAcquired, err)
+	}
+	if err := locker.Extend(ctx, first, 2*time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The lease runs out and another replica takes over with a higher fencing token
+	server.advance(3 * time.Second)
+	second, err := locker.Acquire(ctx, "publish-scheduler", time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.Token <= first.Token {
+		t.Errorf("expected fencing token to grow, got %d then %d", first.Token, second.Token)
+	}
+	if err := locker.Release(ctx, first); !errors.Is(err, ErrLockLost) {
+		t.Errorf("expected error '%v', got '%v'", ErrLockLost, err)
+	}
+	if err := locker.Release(ctx, second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if observer.acquired != 2 || observer.contended != 1 || observer.lost != 1 || observer.released != 1 {
+		t.Errorf("unexpected metrics %+v", observer)
+	}
+}
+
+func TestClient_ErrorReplyKeepsConnection(t *testing.T) {
+	_, addr := startFakeRedis(t)
+	client := NewClient(addr, "", 0)
+	defer client.Close()
+
+	var redisErr RedisError
+	if _, err := client.Do(context.Background(), "FLUSHALL"); !errors.As(err, &redisErr) {
+		t.Fatalf("expected a redis error reply, got %v", err)
+	}
+	conn := client.conn
+	if _, err := client.Do(context.Background(), "INCR", "n"); err != nil || client.conn != conn {
+		t.Errorf("expected the connection reused after an error reply, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package lock
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RedisError is an error reply from the server
+type RedisError string
+
+func (e RedisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// Client is a minimal RESP client, enough for the lock and rate limit commands.
+// Calls are serialised over one connection, which is redialled after any I/O error.
+type Client struct {
+	addr     string
+	password string
+	db       int
+	dialer   net.Dialer
+
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+func NewClient(addr, password string, db int) *Client {
+	return &Client{addr: addr, password: password, db: db, dialer: net.Dialer{Timeout: 5 * time.Second}}
+}
+
+// Do sends one command and returns its reply: string, int64, nil, []any or a RedisError
+func (c *Client) Do(ctx context.Context, args ...string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		if err := c.dial(ctx); err != nil {
+			return nil, err
+		}
+	}
+	reply, err := c.roundTrip(ctx, args)
+	if err != nil {
+		var redisErr RedisError
+		if !errors.As(err, &redisErr) {
+			c.closeLocked()
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closeLocked()
+}
+
+func (c *Client) dial(ctx context.Context) error {
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	c.conn, c.rd = conn, bufio.NewReader(conn)
+
+	if c.password != "" {
+		if _, err := c.roundTrip(ctx, []string{"AUTH", c.password}); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("failed to authenticate to redis: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := c.roundTrip(ctx, []string{"SELECT", strconv.Itoa(c.db)}); err != nil {
+			c.closeLocked()
+			return fmt.Errorf("failed to select redis database: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) closeLocked() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn, c.rd = nil, nil
+	return err
+}
+
+func (c *Client) roundTrip(ctx context.Context, args []string) (any, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(5 * time.Second)
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if _, err := c.conn.Write(encodeCommand(args)); err != nil {
+		return nil, fmt.Errorf("failed to write redis command: %w", err)
+	}
+	return readReply(c.rd)
+}
+
+func encodeCommand(args []string) []byte {
+	buf := fmt.Appendf(nil, "*%d\r\n", len(args))
+	for _, arg := range args {
+		buf = fmt.Appendf(buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return buf
+}
+
+func readReply(rd *bufio.Reader) (any, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redis reply: %w", err)
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed redis reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, RedisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed redis bulk length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(rd, data); err != nil {
+			return nil, fmt.Errorf("failed to read redis reply: %w", err)
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed redis array length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			item, err := readReply(rd)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unknown redis reply type %q", kind)
+	}
+}
@@ -0,0 +1,149 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/tip"
+)
+
+// JPEG markers, see ITU T.81 Annex B
+const (
+	markerSOI   = 0xD8
+	markerEOI   = 0xD9
+	markerSOS   = 0xDA
+	markerAPP0  = 0xE0
+	markerAPP14 = 0xEE // Adobe colour transform, needed to decode CMYK images
+	markerAPP15 = 0xEF
+	markerCOM   = 0xFE
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngChunks are the chunks kept: the critical ones and those that only affect rendering.
+// Text, timestamps, EXIF and ICC profiles (which carry device names) are dropped.
+var pngChunks = map[string]bool{
+	"IHDR": true, "PLTE": true, "IDAT": true, "IEND": true,
+	"tRNS": true, "gAMA": true, "cHRM": true, "sRGB": true, "sBIT": true, "bKGD": true, "pHYs": true,
+}
+
+// Stripper removes metadata that can identify a source: EXIF (camera serial, GPS,
+// timestamps), XMP and IPTC (editing software, author), comments, embedded
+// thumbnails that may show the picture before it was cropped, and bytes hidden
+// after the end of the image. It rewrites containers without decoding pixels, so
+// images are not recompressed.
+type Stripper struct{}
+
+var _ tip.MetadataStripper = Stripper{}
+
+func NewStripper() Stripper {
+	return Stripper{}
+}
+
+func (Stripper) Strip(contentType string, content []byte) ([]byte, error) {
+	switch contentType {
+	case tip.ContentTypeJPEG:
+		return stripJPEG(content)
+	case tip.ContentTypePNG:
+		return stripPNG(content)
+	case tip.ContentTypeText:
+		if !utf8.Valid(content) {
+			return nil, tip.ErrUnreadableAttachment
+		}
+		return content, nil
+	default:
+		return nil, tip.ErrUnsupportedAttachment
+	}
+}
+
+// stripJPEG keeps every segment but APPn (APP14 aside) and COM, and stops at EOI
+func stripJPEG(content []byte) ([]byte, error) {
+	if len(content) < 4 || content[0] != 0xFF || content[1] != markerSOI {
+		return nil, tip.ErrUnreadableAttachment
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(content)))
+	out.Write(content[:2])
+
+	i := 2
+	for {
+		// Markers may be preceded by any number of 0xFF fill bytes
+		if i >= len(content) || content[i] != 0xFF {
+			return nil, tip.ErrUnreadableAttachment
+		}
+		for i < len(content) && content[i] == 0xFF {
+			i++
+		}
+		if i >= len(content) {
+			return nil, tip.ErrUnreadableAttachment
+		}
+		marker := content[i]
+		i++
+		if marker == markerEOI {
+			out.Write([]byte{0xFF, markerEOI})
+			return out.Bytes(), nil
+		}
+
+		if i+2 > len(content) {
+			return nil, tip.ErrUnreadableAttachment
+		}
+		length := int(binary.BigEndian.Uint16(content[i:]))
+		if length < 2 || i+length > len(content) {
+			return nil, tip.ErrUnreadableAttachment
+		}
+		segment := content[i : i+length]
+		i += length
+
+		drop := marker == markerCOM || (marker >= markerAPP0 && marker <= markerAPP15 && marker != markerAPP14)
+		if !drop {
+			out.Write([]byte{0xFF, marker})
+			out.Write(segment)
+		}
+		if marker != markerSOS {
+			continue
+		}
+
+		// Entropy-coded data runs until a marker other than a stuffed 0xFF00 or a restart
+		start := i
+		for i+1 < len(content) && !(content[i] == 0xFF && content[i+1] != 0x00 && (content[i+1] < 0xD0 || content[i+1] > 0xD7)) {
+			i++
+		}
+		if i+1 >= len(content) {
+			return nil, tip.ErrUnreadableAttachment
+		}
+		out.Write(content[start:i])
+	}
+}
+
+// stripPNG keeps the allowed chunks and stops at IEND
+func stripPNG(content []byte) ([]byte, error) {
+	if !bytes.HasPrefix(content, pngSignature) {
+		return nil, tip.ErrUnreadableAttachment
+	}
+	out := bytes.NewBuffer(make([]byte, 0, len(content)))
+	out.Write(pngSignature)
+
+	i := len(pngSignature)
+	for {
+		if i+8 > len(content) {
+			return nil, tip.ErrUnreadableAttachment
+		}
+		length := int(binary.BigEndian.Uint32(content[i:]))
+		kind := string(content[i+4 : i+8])
+		end := i + 12 + length // Length, type, data and CRC
+		if end > len(content) || end < i {
+			return nil, tip.ErrUnreadableAttachment
+		}
+
+		if pngChunks[kind] {
+			out.Write(content[i:end])
+		} else if kind[0] >= 'A' && kind[0] <= 'Z' {
+			// An unknown critical chunk means the image cannot be decoded without it
+			return nil, tip.ErrUnreadableAttachment
+		}
+		i = end
+		if kind == "IEND" {
+			return out.Bytes(), nil
+		}
+	}
+}
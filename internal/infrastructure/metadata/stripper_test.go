@@ -0,0 +1,108 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/tip"
+)
+
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for x := 0; x < 16; x++ {
+		img.Set(x, x, color.RGBA{R: 200, A: 255})
+	}
+	return img
+}
+
+// jpegSegment builds a marker segment, the length counts itself but not the marker
+func jpegSegment(marker byte, payload string) []byte {
+	segment := []byte{0xFF, marker}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func pngChunk(kind, data string) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, kind...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE([]byte(kind+data)))
+}
+
+func TestStripper_JPEG(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, testImage(), nil); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	raw := encoded.Bytes()
+
+	var tagged []byte
+	tagged = append(tagged, raw[:2]...)
+	tagged = append(tagged, jpegSegment(0xE1, "Exif\x00\x00GPS -6.2088,106.8456 Canon EOS serial 0421")...)
+	tagged = append(tagged, jpegSegment(0xFE, "shot by budi")...)
+	tagged = append(tagged, raw[2:]...)
+	tagged = append(tagged, "hidden trailer"...)
+
+	stripped, err := NewStripper().Strip(tip.ContentTypeJPEG, tagged)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, leak := range []string{"GPS", "serial", "budi", "hidden trailer"} {
+		if bytes.Contains(stripped, []byte(leak)) {
+			t.Errorf("expected %q stripped", leak)
+		}
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Errorf("expected a decodable image, got %v", err)
+	}
+
+	if _, err := NewStripper().Strip(tip.ContentTypeJPEG, raw[:len(raw)/2]); err != tip.ErrUnreadableAttachment {
+		t.Errorf("expected error '%v' for a truncated image, got '%v'", tip.ErrUnreadableAttachment, err)
+	}
+}
+
+func TestStripper_PNG(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, testImage()); err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	raw := encoded.Bytes()
+
+	// The header chunk is 25 bytes after the signature
+	headerEnd := len(pngSignature) + 25
+	var tagged []byte
+	tagged = append(tagged, raw[:headerEnd]...)
+	tagged = append(tagged, pngChunk("tEXt", "Author\x00Budi")...)
+	tagged = append(tagged, pngChunk("tIME", "\x07\xea\x03\x04\x16\x2f\x0d")...)
+	tagged = append(tagged, raw[headerEnd:]...)
+
+	stripped, err := NewStripper().Strip(tip.ContentTypePNG, tagged)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(stripped, raw) {
+		t.Errorf("expected only the metadata chunks removed")
+	}
+	if _, err := png.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Errorf("expected a decodable image, got %v", err)
+	}
+}
+
+func TestStripper_Types(t *testing.T) {
+	stripper := NewStripper()
+	if out, err := stripper.Strip(tip.ContentTypeText, []byte("plain notes")); err != nil || string(out) != "plain notes" {
+		t.Errorf("expected text passed through, got %q, %v", out, err)
+	}
+	if _, err := stripper.Strip(tip.ContentTypeText, []byte{0xff, 0xfe}); err != tip.ErrUnreadableAttachment {
+		t.Errorf("expected error '%v', got '%v'", tip.ErrUnreadableAttachment, err)
+	}
+	if _, err := stripper.Strip("application/pdf", []byte("%PDF")); err != tip.ErrUnsupportedAttachment {
+		t.Errorf("expected error '%v', got '%v'", tip.ErrUnsupportedAttachment, err)
+	}
+}
@@ -0,0 +1,168 @@
+package dictionary
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/entity"
+)
+
+// Extractor finds known entity names in text by dictionary lookup. It is the
+// fallback provider: no model, only the names and aliases editors maintain.
+// Matches are case-insensitive but must start with a capital letter or digit,
+// so "budi" in running text does not count while "Budi" does.
+type Extractor struct {
+	mu    sync.RWMutex
+	terms map[string][]term // Keyed by the first token
+}
+
+type term struct {
+	tokens []string
+	kinds  []entity.Kind
+}
+
+type token struct {
+	value      string // Lowercased
+	start, end int
+}
+
+var _ entity.Extractor = (*Extractor)(nil)
+
+func NewExtractor(entities []*entity.Entity) *Extractor {
+	x := &Extractor{}
+	x.Load(entities)
+	return x
+}
+
+// Load replaces the dictionary, call it after editors change names or aliases
+func (x *Extractor) Load(entities []*entity.Entity) {
+	byPhrase := map[string]*term{}
+	for _, e := range entities {
+		if e.IsMerged() {
+			continue
+		}
+		for _, name := range e.Names() {
+			tokens := tokenize(name)
+			if len(tokens) == 0 {
+				continue
+			}
+			phrase := joinTokens(tokens)
+			t, ok := byPhrase[phrase]
+			if !ok {
+				t = &term{tokens: make([]string, len(tokens))}
+				for i, tok := range tokens {
+					t.tokens[i] = tok.value
+				}
+				byPhrase[phrase] = t
+			}
+			if !hasKind(t.kinds, e.Kind) {
+				t.kinds = append(t.kinds, e.Kind)
+			}
+		}
+	}
+
+	terms := make(map[string][]term, len(byPhrase))
+	for _, t := range byPhrase {
+		terms[t.tokens[0]] = append(terms[t.tokens[0]], *t)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.terms = terms
+}
+
+// Extract returns every dictionary name in the text, preferring the longest
+// match so "Bank Indonesia" is not also reported as the place "Indonesia".
+func (x *Extractor) Extract(ctx context.Context, text string) ([]entity.Mention, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	tokens := tokenize(text)
+	var mentions []entity.Mention
+	for i := 0; i < len(tokens); {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		best := x.longestMatch(tokens[i:])
+		if best == nil || !capitalized(text[tokens[i].start:]) {
+			i++
+			continue
+		}
+		n := len(best.tokens)
+		surface := text[tokens[i].start:tokens[i+n-1].end]
+		for _, kind := range best.kinds {
+			mentions = append(mentions, entity.Mention{Surface: surface, Kind: kind, Offset: tokens[i].start})
+		}
+		i += n
+	}
+	return mentions, nil
+}
+
+func (x *Extractor) longestMatch(tokens []token) *term {
+	var best *term
+	candidates := x.terms[tokens[0].value]
+	for i := range candidates {
+		t := &candidates[i]
+		if len(t.tokens) > len(tokens) || (best != nil && len(t.tokens) <= len(best.tokens)) {
+			continue
+		}
+		matched := true
+		for j, value := range t.tokens {
+			if tokens[j].value != value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			best = t
+		}
+	}
+	return best
+}
+
+// tokenize splits text into words of letters and digits with their byte offsets
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, token{value: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{value: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+func joinTokens(tokens []token) string {
+	values := make([]string, len(tokens))
+	for i, t := range tokens {
+		values[i] = t.value
+	}
+	return strings.Join(values, " ")
+}
+
+func capitalized(text string) bool {
+	r, _ := utf8.DecodeRuneInString(text)
+	return unicode.IsUpper(r) || unicode.IsDigit(r)
+}
+
+func hasKind(kinds []entity.Kind, kind entity.Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package dictionary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/entity"
+)
+
+func createTestEntity(t *testing.T, id string, kind entity.Kind, slug, name string, aliases ...string) *entity.Entity {
+	t.Helper()
+	s, _ := entity.NewSlug(slug)
+	e, err := entity.NewEntity(id, kind, *s, name, "", aliases, "editor-1")
+	if err != nil {
+		t.Fatalf("failed to create entity: %v", err)
+	}
+	return e
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	merged := createTestEntity(t, "e4", entity.KindPerson, "old-jokowi", "Joko Widodo")
+	_ = merged.MergeInto("e1", "editor-1")
+	x := NewExtractor([]*entity.Entity{
+		createTestEntity(t, "e1", entity.KindPerson, "joko-widodo", "Joko Widodo", "Jokowi"),
+		createTestEntity(t, "e2", entity.KindPlace, "indonesia", "Indonesia"),
+		createTestEntity(t, "e3", entity.KindOrganization, "bank-indonesia", "Bank Indonesia"),
+		merged,
+	})
+
+	text := "Jokowi met Bank Indonesia officials. Growth in Indonesia slowed, said joko widodo."
+	mentions, err := x.Extract(context.Background(), text)
+	if err != nil {
+		t.Fatalf("expected no error, got '%v'", err)
+	}
+
+	expected := []entity.Mention{
+		{Surface: "Jokowi", Kind: entity.KindPerson, Offset: 0},
+		{Surface: "Bank Indonesia", Kind: entity.KindOrganization, Offset: 11},
+		{Surface: "Indonesia", Kind: entity.KindPlace, Offset: 47},
+	}
+	if len(mentions) != len(expected) {
+		t.Fatalf("expected %d mentions, got %+v", len(expected), mentions)
+	}
+	for i, m := range mentions {
+		if m != expected[i] {
+			t.Errorf("expected %+v, got %+v", expected[i], m)
+		}
+	}
+}
+
+func TestExtractor_Load(t *testing.T) {
+	x := NewExtractor(nil)
+	if mentions, _ := x.Extract(context.Background(), "KPK named a suspect"); len(mentions) != 0 {
+		t.Fatalf("expected no mentions from an empty dictionary, got %+v", mentions)
+	}
+
+	x.Load([]*entity.Entity{createTestEntity(t, "e1", entity.KindOrganization, "kpk", "KPK")})
+	if mentions, _ := x.Extract(context.Background(), "KPK named a suspect"); len(mentions) != 1 {
+		t.Errorf("expected one mention after reload, got %+v", mentions)
+	}
+}
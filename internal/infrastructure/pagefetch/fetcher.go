@@ -0,0 +1,45 @@
+package pagefetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/linkpreview"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/safehttp"
+)
+
+// Fetcher downloads pages for link cards through a safehttp client, built
+// from safehttp.FeatureLinkPreview outside of tests
+type Fetcher struct {
+	client *safehttp.Client
+}
+
+func NewFetcher(client *safehttp.Client) *Fetcher {
+	return &Fetcher{client: client}
+}
+
+var _ linkpreview.Fetcher = (*Fetcher)(nil)
+
+func (f *Fetcher) Fetch(ctx context.Context, u *url.URL) (*linkpreview.Page, error) {
+	resp, err := f.client.Get(ctx, u.String(), nil)
+	switch {
+	case errors.Is(err, safehttp.ErrBlockedAddress), errors.Is(err, safehttp.ErrBlockedPort):
+		return nil, linkpreview.ErrBlockedHost
+	case errors.Is(err, safehttp.ErrScheme):
+		return nil, linkpreview.ErrInvalidURL
+	case errors.Is(err, safehttp.ErrContentType):
+		return nil, linkpreview.ErrNotHTML
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return nil, err
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", linkpreview.ErrFetchFailed, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: page returned %d", linkpreview.ErrFetchFailed, resp.StatusCode)
+	}
+	return &linkpreview.Page{URL: resp.URL, Body: resp.Body}, nil
+}
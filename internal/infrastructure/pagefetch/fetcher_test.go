@@ -0,0 +1,100 @@
+package pagefetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/linkpreview"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/safehttp"
+)
+
+// newTestFetcher allows loopback and any port so the httptest server is reachable
+func newTestFetcher(t *testing.T) *Fetcher {
+	t.Helper()
+	policy := safehttp.DefaultPolicies[safehttp.FeatureLinkPreview]
+	policy.Name = "test"
+	policy.AllowedPorts = nil
+	policy.AllowedNetworks = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+	client, err := safehttp.New(policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return NewFetcher(client)
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<title>Hello</title>" + strings.Repeat("x", 1<<20)))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/article", http.StatusFound)
+	})
+	mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	})
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		path          string
+		expectedError error
+	}{
+		{"page", "/article", nil},
+		{"redirect", "/moved", nil},
+		{"redirect to metadata service", "/metadata", linkpreview.ErrBlockedHost},
+		{"redirect to file", "/file", linkpreview.ErrInvalidURL},
+		{"redirect loop", "/loop", linkpreview.ErrFetchFailed},
+		{"not html", "/image.png", linkpreview.ErrNotHTML},
+		{"not found", "/missing", linkpreview.ErrFetchFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.Parse(server.URL + tc.path)
+			page, err := newTestFetcher(t).Fetch(context.Background(), u)
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("expected error '%v', got '%v'", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if page.URL.Path != "/article" || len(page.Body) != 1<<20 || !strings.HasPrefix(string(page.Body), "<title>Hello") {
+				t.Errorf("unexpected page %s with %d bytes", page.URL, len(page.Body))
+			}
+		})
+	}
+}
+
+func TestFetcher_DefaultPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no request to reach the server")
+	}))
+	defer server.Close()
+
+	client, _ := safehttp.ForFeature(safehttp.FeatureLinkPreview)
+	u, _ := url.Parse(server.URL)
+	if _, err := NewFetcher(client).Fetch(context.Background(), u); !errors.Is(err, linkpreview.ErrBlockedHost) {
+		t.Errorf("expected error '%v', got '%v'", linkpreview.ErrBlockedHost, err)
+	}
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/shared"
+)
+
+// MaxAttempts bounds how often a unit of work is run again after a
+// serialization failure or deadlock
+const MaxAttempts = 3
+
+// retryable are the SQLSTATEs of a transaction PostgreSQL aborted to keep
+// concurrent ones consistent, running it again usually succeeds
+var retryable = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+}
+
+// DBTX is what repositories run statements on, *sql.DB and *sql.Tx both have it
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// Conn returns the transaction carried by ctx, or db outside of one. Repositories
+// call it for every statement so they join whatever unit of work is running.
+func Conn(ctx context.Context, db *sql.DB) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// TxManager runs units of work in PostgreSQL transactions through database/sql,
+// the driver is registered by the caller
+type TxManager struct {
+	db   *sql.DB
+	opts *sql.TxOptions
+}
+
+// NewTxManager uses the server's default isolation (READ COMMITTED) when opts is nil
+func NewTxManager(db *sql.DB, opts *sql.TxOptions) *TxManager {
+	return &TxManager{db: db, opts: opts}
+}
+
+var _ shared.Transactor = (*TxManager)(nil)
+
+// WithinTransaction commits when fn returns nil and rolls back otherwise, also
+// when fn panics. A call inside a running unit of work joins it instead of
+// nesting. A unit of work aborted for serialization or a deadlock is run again,
+// so fn must not have effects outside the database.
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	var err error
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		if err = m.run(ctx, fn); !isRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (m *TxManager) run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx, err := m.db.BeginTx(ctx, m.opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return errors.Join(err, fmt.Errorf("failed to roll back: %w", rbErr))
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// isRetryable looks for the SQLSTATE through the error chain, pgx's PgError
+// and lib/pq's Error both report it with a SQLState method
+func isRetryable(err error) bool {
+	var coded interface{ SQLState() string }
+	return errors.As(err, &coded) && retryable[coded.SQLState()]
+}
@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// recordingDriver logs begin, exec, commit and rollback calls in order
+type recordingDriver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (d *recordingDriver) log(event string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, event)
+}
+
+func (d *recordingDriver) reset() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	events := d.events
+	d.events = nil
+	return events
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) {
+	return &recordingConn{driver: d}, nil
+}
+
+type recordingConn struct {
+	driver *recordingDriver
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	c.driver.log("begin")
+	return recordingTx{driver: c.driver}, nil
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.driver.log(query)
+	return driver.RowsAffected(1), nil
+}
+
+type recordingTx struct {
+	driver *recordingDriver
+}
+
+func (t recordingTx) Commit() error {
+	t.driver.log("commit")
+	return nil
+}
+
+func (t recordingTx) Rollback() error {
+	t.driver.log("rollback")
+	return nil
+}
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "pq: " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+var (
+	testDriver   = &recordingDriver{}
+	registerOnce sync.Once
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	registerOnce.Do(func() { sql.Register("recording", testDriver) })
+	db, err := sql.Open("recording", "")
+	if err != nil {
+		t.Fatalf("failed to open: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+	testDriver.reset()
+	return db
+}
+
+func TestTxManager_WithinTransaction(t *testing.T) {
+	db := openTestDB(t)
+	manager := NewTxManager(db, nil)
+	ctx := context.Background()
+	exec := func(ctx context.Context, query string) error {
+		_, err := Conn(ctx, db).ExecContext(ctx, query)
+		return err
+	}
+
+	// Nested units of work join the outer transaction
+	err := manager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := exec(ctx, "insert account"); err != nil {
+			return err
+		}
+		return manager.WithinTransaction(ctx, func(ctx context.Context) error {
+			return exec(ctx, "insert audit entry")
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if events := testDriver.reset(); !slices.Equal(events, []string{"begin", "insert account", "insert audit entry", "commit"}) {
+		t.Errorf("expected one committed transaction, got %v", events)
+	}
+
+	failure := errors.New("profile rejected")
+	err = manager.WithinTransaction(ctx, func(ctx context.Context) error {
+		_ = exec(ctx, "insert account")
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("expected error '%v', got '%v'", failure, err)
+	}
+	if events := testDriver.reset(); !slices.Equal(events, []string{"begin", "insert account", "rollback"}) {
+		t.Errorf("expected a rollback, got %v", events)
+	}
+
+	if err := exec(ctx, "outside"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if events := testDriver.reset(); !slices.Equal(events, []string{"outside"}) {
+		t.Errorf("expected statements outside a unit of work to run on the pool, got %v", events)
+	}
+}
+
+func TestTxManager_RetriesSerializationFailures(t *testing.T) {
+	db := openTestDB(t)
+	manager := NewTxManager(db, nil)
+
+	attempts := 0
+	err := manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts < 2 {
+			return sqlStateError("40001")
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("expected success on the second attempt, got %v after %d", err, attempts)
+	}
+
+	attempts = 0
+	err = manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return sqlStateError("40P01")
+	})
+	if err == nil || attempts != MaxAttempts {
+		t.Errorf("expected to give up after %d attempts, got %v after %d", MaxAttempts, err, attempts)
+	}
+
+	attempts = 0
+	_ = manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		attempts++
+		return sqlStateError("23505") // unique_violation is not retried
+	})
+	if attempts != 1 {
+		t.Errorf("expected one attempt, got %d", attempts)
+	}
+}
+
+func TestTxManager_RollsBackOnPanic(t *testing.T) {
+	db := openTestDB(t)
+	manager := NewTxManager(db, nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected the panic to propagate")
+		}
+		if events := testDriver.reset(); !slices.Equal(events, []string{"begin", "rollback"}) {
+			t.Errorf("expected a rollback, got %v", events)
+		}
+	}()
+	_ = manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		panic("boom")
+	})
+}
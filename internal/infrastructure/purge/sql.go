@@ -0,0 +1,126 @@
+package purge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/retention"
+)
+
+// Table is where one category's records live
+type Table struct {
+	Name          string
+	TimeColumn    string // The timestamp retention counts from
+	SubjectColumn string // Matched against legal holds, empty when any subject hold must keep the whole table
+	Bound         string // Extra condition on what may be deleted, $1 is the cutoff
+}
+
+// DefaultTables maps every category of retention.DefaultPolicies to its table
+var DefaultTables = map[retention.Category]Table{
+	retention.CategoryLoginAttempts:   {Name: "login_attempts", TimeColumn: "attempted_at", SubjectColumn: "account_id"},
+	retention.CategoryAnalyticsEvents: {Name: "analytics_events", TimeColumn: "occurred_at", SubjectColumn: "article_id"},
+	retention.CategoryCSPReports:      {Name: "csp_reports", TimeColumn: "received_at"},
+	retention.CategoryPublishActions:  {Name: "publish_actions", TimeColumn: "at", SubjectColumn: "staff_id"},
+
+	// The hash chain may only be cut right after an anchored entry, so verification can resume from
+	// the anchor; a gap in the middle would read as tampering, hence no per-subject holds either
+	retention.CategoryAuditLog: {
+		Name:       "audit_entries",
+		TimeColumn: "at",
+		Bound:      "seq <= (SELECT coalesce(max(a.seq), 0) FROM audit_anchors a JOIN audit_entries e ON e.seq = a.seq WHERE e.at < $1)",
+	},
+}
+
+// SQLPurger deletes expired rows of one table in PostgreSQL through database/sql
+type SQLPurger struct {
+	db    *sql.DB
+	table Table
+}
+
+func NewSQLPurger(db *sql.DB, table Table) *SQLPurger {
+	return &SQLPurger{db: db, table: table}
+}
+
+// NewSQLPurgers builds a purger for every table
+func NewSQLPurgers(db *sql.DB, tables map[retention.Category]Table) map[retention.Category]retention.Purger {
+	purgers := make(map[retention.Category]retention.Purger, len(tables))
+	for category, table := range tables {
+		purgers[category] = NewSQLPurger(db, table)
+	}
+	return purgers
+}
+
+var _ retention.Purger = (*SQLPurger)(nil)
+
+func (p *SQLPurger) Count(ctx context.Context, target retention.Target) (retention.Counts, error) {
+	var counts retention.Counts
+	query := "SELECT count(*) FROM " + quoteIdent(p.table.Name) + " WHERE " + quoteIdent(p.table.TimeColumn) + " < $1"
+	if err := p.db.QueryRowContext(ctx, query, target.Cutoff).Scan(&counts.Expired); err != nil {
+		return counts, err
+	}
+
+	switch {
+	case len(target.HeldSubjects) == 0:
+	case p.table.SubjectColumn == "":
+		counts.Held = counts.Expired
+	default:
+		placeholders, args := subjectList(target.HeldSubjects, 2)
+		query += " AND " + quoteIdent(p.table.SubjectColumn) + " IN (" + placeholders + ")"
+		if err := p.db.QueryRowContext(ctx, query, append([]any{target.Cutoff}, args...)...).Scan(&counts.Held); err != nil {
+			return counts, err
+		}
+	}
+	return counts, nil
+}
+
+func (p *SQLPurger) Purge(ctx context.Context, target retention.Target, limit int) (int, error) {
+	if len(target.HeldSubjects) > 0 && p.table.SubjectColumn == "" {
+		return 0, nil
+	}
+	query, args := deleteStatement(p.table, target, limit)
+	result, err := p.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+// deleteStatement deletes by ctid, PostgreSQL has no DELETE ... LIMIT
+func deleteStatement(table Table, target retention.Target, limit int) (string, []any) {
+	name := quoteIdent(table.Name)
+	conditions := []string{quoteIdent(table.TimeColumn) + " < $1"}
+	if table.Bound != "" {
+		conditions = append(conditions, "("+table.Bound+")")
+	}
+	args := []any{target.Cutoff}
+	if len(target.HeldSubjects) > 0 {
+		placeholders, subjects := subjectList(target.HeldSubjects, 2)
+		conditions = append(conditions, quoteIdent(table.SubjectColumn)+" NOT IN ("+placeholders+")")
+		args = append(args, subjects...)
+	}
+	args = append(args, limit)
+
+	return fmt.Sprintf("DELETE FROM %s WHERE ctid IN (SELECT ctid FROM %s WHERE %s LIMIT $%d)",
+		name, name, strings.Join(conditions, " AND "), len(args)), args
+}
+
+func subjectList(subjects []string, first int) (string, []any) {
+	placeholders := make([]string, len(subjects))
+	args := make([]any, len(subjects))
+	for i, s := range subjects {
+		placeholders[i] = fmt.Sprintf("$%d", first+i)
+		args[i] = s
+	}
+	return strings.Join(placeholders, ", "), args
+}
+
+func quoteIdent(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,38 @@
+package purge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/retention"
+)
+
+func TestDeleteStatement(t *testing.T) {
+	cutoff := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	query, args := deleteStatement(DefaultTables[retention.CategoryLoginAttempts], retention.Target{Cutoff: cutoff, HeldSubjects: []string{"a1", "a2"}}, 500)
+	expected := `DELETE FROM "login_attempts" WHERE ctid IN (SELECT ctid FROM "login_attempts" WHERE "attempted_at" < $1 AND "account_id" NOT IN ($2, $3) LIMIT $4)`
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+	if len(args) != 4 || args[1] != "a1" || args[3] != 500 {
+		t.Errorf("unexpected args %v", args)
+	}
+
+	query, args = deleteStatement(DefaultTables[retention.CategoryAuditLog], retention.Target{Cutoff: cutoff}, 500)
+	expected = `DELETE FROM "audit_entries" WHERE ctid IN (SELECT ctid FROM "audit_entries" WHERE "at" < $1 AND (` + DefaultTables[retention.CategoryAuditLog].Bound + `) LIMIT $2)`
+	if query != expected {
+		t.Errorf("expected %q, got %q", expected, query)
+	}
+	if len(args) != 2 {
+		t.Errorf("unexpected args %v", args)
+	}
+}
+
+func TestDefaultTables(t *testing.T) {
+	for _, p := range retention.DefaultPolicies() {
+		if _, ok := DefaultTables[p.Category]; !ok {
+			t.Errorf("expected a table for %s", p.Category)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+)
+
+// pruneEvery is how many calls pass between sweeps of idle counters
+const pruneEvery = 1024
+
+type memoryWindow struct {
+	start    time.Time
+	size     time.Duration
+	previous int
+	current  int
+}
+
+// MemoryLimiter counts within one process, for a single instance and for tests
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*memoryWindow
+	calls   int
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{windows: map[string]*memoryWindow{}}
+}
+
+func (m *MemoryLimiter) Take(ctx context.Context, key string, limit ratelimit.Limit, at time.Time) (ratelimit.Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.calls%pruneEvery == 0 {
+		m.prune(at)
+	}
+
+	start := limit.WindowStart(at)
+	w, ok := m.windows[key]
+	switch {
+	case !ok || w.size != limit.Window:
+		w = &memoryWindow{start: start, size: limit.Window}
+		m.windows[key] = w
+	case start.Equal(w.start.Add(w.size)):
+		w.start, w.previous, w.current = start, w.current, 0
+	case start.After(w.start):
+		w.start, w.previous, w.current = start, 0, 0
+	}
+	w.current++
+	return limit.Decide(ratelimit.Counts{Previous: w.previous, Current: w.current}, at.Sub(start)), nil
+}
+
+// prune drops counters no sliding window covers any more
+func (m *MemoryLimiter) prune(at time.Time) {
+	for key, w := range m.windows {
+		if !at.Before(w.start.Add(2 * w.size)) {
+			delete(m.windows, key)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	ctx := context.Background()
+	limit := ratelimit.Limit{Requests: 3, Window: time.Minute}
+	start := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		r, _ := limiter.Take(ctx, "login:ip:a", limit, start.Add(time.Duration(i)*time.Second))
+		if !r.Allowed || r.Remaining != 2-i {
+			t.Fatalf("request %d: expected allowed with %d left, got %+v", i+1, 2-i, r)
+		}
+	}
+	if r, _ := limiter.Take(ctx, "login:ip:a", limit, start.Add(5*time.Second)); r.Allowed || r.RetryAfter <= 55*time.Second {
+		t.Errorf("expected the fourth request rejected until the next window, got %+v", r)
+	}
+	if r, _ := limiter.Take(ctx, "login:ip:b", limit, start.Add(5*time.Second)); !r.Allowed {
+		t.Errorf("expected other keys to have their own count, got %+v", r)
+	}
+
+	// Early in the next window the four requests before still weigh in
+	if r, _ := limiter.Take(ctx, "login:ip:a", limit, start.Add(70*time.Second)); r.Allowed {
+		t.Errorf("expected the previous window to count, got %+v", r)
+	}
+	if r, _ := limiter.Take(ctx, "login:ip:a", limit, start.Add(3*time.Minute)); !r.Allowed || r.Remaining != 2 {
+		t.Errorf("expected a fresh count after an idle window, got %+v", r)
+	}
+}
+
+func TestMemoryLimiter_Prune(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	limit := ratelimit.Limit{Requests: 3, Window: time.Minute}
+	start := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	_, _ = limiter.Take(context.Background(), "idle", limit, start)
+	limiter.prune(start.Add(2 * time.Minute))
+	if len(limiter.windows) != 0 {
+		t.Errorf("expected the idle counter dropped, got %d", len(limiter.windows))
+	}
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/lock"
+)
+
+// takeScript counts the request in the current window and reads the previous
+// one in a single round trip. A window key lives for two windows, as long as
+// a sliding window can still cover it.
+const takeScript = `local current = redis.call("incr", KEYS[1])
+if current == 1 then redis.call("pexpire", KEYS[1], ARGV[1]) end
+return {current, tonumber(redis.call("get", KEYS[2]) or "0")}`
+
+// RedisLimiter shares counters between every instance through one Redis
+type RedisLimiter struct {
+	client *lock.Client
+	prefix string
+}
+
+func NewRedisLimiter(client *lock.Client, prefix string) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: prefix}
+}
+
+func (l *RedisLimiter) Take(ctx context.Context, key string, limit ratelimit.Limit, at time.Time) (ratelimit.Result, error) {
+	start := limit.WindowStart(at)
+	current := l.prefix + key + ":" + strconv.FormatInt(start.UnixMilli(), 10)
+	previous := l.prefix + key + ":" + strconv.FormatInt(start.Add(-limit.Window).UnixMilli(), 10)
+	ttl := strconv.FormatInt((2 * limit.Window).Milliseconds(), 10)
+
+	reply, err := l.client.Do(ctx, "EVAL", takeScript, "2", current, previous, ttl)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("failed to count request for %s: %w", key, err)
+	}
+	counts, ok := reply.([]any)
+	if !ok || len(counts) != 2 {
+		return ratelimit.Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+	cur, ok1 := counts[0].(int64)
+	prev, ok2 := counts[1].(int64)
+	if !ok1 || !ok2 {
+		return ratelimit.Result{}, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+	return limit.Decide(ratelimit.Counts{Previous: int(prev), Current: int(cur)}, at.Sub(start)), nil
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/ratelimit"
+	"github.com/jokosaputro95/news-portal-cms/internal/infrastructure/lock"
+)
+
+// fakeRedis runs the take script against a map, expiry is not simulated
+type fakeRedis struct {
+	mu      sync.Mutex
+	counts  map[string]int64
+	expires map[string]string
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	f := &fakeRedis{counts: map[string]int64{}, expires: map[string]string{}}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f, ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(rd)
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte(f.exec(args)))
+	}
+}
+
+// readCommand reads one array of bulk strings
+func readCommand(rd *bufio.Reader) ([]string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, 0, n)
+	for range n {
+		header, err := rd.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(rd, arg); err != nil {
+			return nil, err
+		}
+		args = append(args, string(arg[:size]))
+	}
+	return args, nil
+}
+
+func (f *fakeRedis) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if args[0] != "EVAL" || args[1] != takeScript || args[2] != "2" {
+		return "-ERR unknown command\r\n"
+	}
+	current, previous, ttl := args[3], args[4], args[5]
+	f.counts[current]++
+	if f.counts[current] == 1 {
+		f.expires[current] = ttl
+	}
+	return fmt.Sprintf("*2\r\n:%d\r\n:%d\r\n", f.counts[current], f.counts[previous])
+}
+
+func TestRedisLimiter(t *testing.T) {
+	fake, addr := startFakeRedis(t)
+	client := lock.NewClient(addr, "", 0)
+	t.Cleanup(func() { _ = client.Close() })
+	limiter := NewRedisLimiter(client, "cms:ratelimit:")
+
+	ctx := context.Background()
+	limit := ratelimit.Limit{Requests: 2, Window: time.Minute}
+	start := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	for i := range 2 {
+		r, err := limiter.Take(ctx, "login:ip:a", limit, start.Add(time.Duration(i)*time.Second))
+		if err != nil || !r.Allowed {
+			t.Fatalf("request %d: expected allowed, got %+v, %v", i+1, r, err)
+		}
+	}
+	if r, _ := limiter.Take(ctx, "login:ip:a", limit, start.Add(2*time.Second)); r.Allowed {
+		t.Errorf("expected the third request rejected, got %+v", r)
+	}
+	if r, _ := limiter.Take(ctx, "login:ip:a", limit, start.Add(65*time.Second)); r.Allowed {
+		t.Errorf("expected the previous window to count, got %+v", r)
+	}
+
+	key := "cms:ratelimit:login:ip:a:" + strconv.FormatInt(start.UnixMilli(), 10)
+	if fake.counts[key] != 3 || fake.expires[key] != "120000" {
+		t.Errorf("expected the window counted and kept for two windows, got %d %q", fake.counts[key], fake.expires[key])
+	}
+}
+
+func TestRedisLimiter_Unavailable(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	limiter := NewRedisLimiter(lock.NewClient(addr, "", 0), "")
+	if _, err := limiter.Take(context.Background(), "login:ip:a", ratelimit.LoginRule.PerIP, time.Now()); err == nil {
+		t.Error("expected an error without Redis")
+	}
+}
@@ -0,0 +1,49 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/notification"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/digest"
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// DigestSender guards the email provider behind the activity digest job.
+// When the breaker is open the job counts the digest as failed and retries it next run.
+type DigestSender struct {
+	next   notification.DigestSender
+	policy *Policy
+}
+
+func NewDigestSender(next notification.DigestSender, policy *Policy) *DigestSender {
+	return &DigestSender{next: next, policy: policy}
+}
+
+func (s *DigestSender) SendDigest(ctx context.Context, recipient account.Email, d *digest.Digest) error {
+	return s.policy.Execute(ctx, func(ctx context.Context) error {
+		return s.next.SendDigest(ctx, recipient, d)
+	})
+}
+
+// EmailSender guards the SMTP or SES sender. Wrap it before NewSuppressingSender
+// so a suppressed address never reaches the breaker. A message the provider
+// could never accept is not retried.
+type EmailSender struct {
+	next   notification.EmailSender
+	policy *Policy
+}
+
+func NewEmailSender(next notification.EmailSender, policy *Policy) *EmailSender {
+	return &EmailSender{next: next, policy: policy}
+}
+
+func (s *EmailSender) SendEmail(ctx context.Context, message notification.EmailMessage) error {
+	return s.policy.Execute(ctx, func(ctx context.Context) error {
+		err := s.next.SendEmail(ctx, message)
+		if errors.Is(err, notification.ErrNoRecipients) || errors.Is(err, notification.ErrInvalidAttachment) || errors.Is(err, notification.ErrAttachmentsTooLarge) {
+			return Permanent(err)
+		}
+		return err
+	})
+}
@@ -0,0 +1,40 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+// PaymentProvider guards the payment gateway. Creating an intent is never retried
+// since the gateway may have charged the first attempt.
+type PaymentProvider struct {
+	next   payment.PaymentProvider
+	policy *Policy
+}
+
+func NewPaymentProvider(next payment.PaymentProvider, policy *Policy) *PaymentProvider {
+	return &PaymentProvider{next: next, policy: policy}
+}
+
+func (p *PaymentProvider) CreateIntent(ctx context.Context, reference string, amount payment.Money, description string) (*payment.PaymentIntent, error) {
+	var intent *payment.PaymentIntent
+	err := p.policy.Once(ctx, func(ctx context.Context) error {
+		var err error
+		intent, err = p.next.CreateIntent(ctx, reference, amount, description)
+		return err
+	})
+	return intent, err
+}
+
+func (p *PaymentProvider) GetIntent(ctx context.Context, intentID string) (*payment.PaymentIntent, error) {
+	return Do(ctx, p.policy, func(ctx context.Context) (*payment.PaymentIntent, error) {
+		return p.next.GetIntent(ctx, intentID)
+	})
+}
+
+func (p *PaymentProvider) CancelIntent(ctx context.Context, intentID string) error {
+	return p.policy.Execute(ctx, func(ctx context.Context) error {
+		return p.next.CancelIntent(ctx, intentID)
+	})
+}
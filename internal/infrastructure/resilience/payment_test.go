@@ -0,0 +1,50 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/billing/payment"
+)
+
+type flakyGateway struct {
+	creates int
+	gets    int
+}
+
+func (g *flakyGateway) CreateIntent(ctx context.Context, reference string, amount payment.Money, description string) (*payment.PaymentIntent, error) {
+	g.creates++
+	return nil, errProvider
+}
+
+func (g *flakyGateway) GetIntent(ctx context.Context, intentID string) (*payment.PaymentIntent, error) {
+	g.gets++
+	if g.gets < 2 {
+		return nil, errProvider
+	}
+	return &payment.PaymentIntent{ID: intentID, Status: payment.IntentStatusSucceeded}, nil
+}
+
+func (g *flakyGateway) CancelIntent(ctx context.Context, intentID string) error {
+	return nil
+}
+
+func TestPaymentProvider(t *testing.T) {
+	policy, _ := createTestPolicy(t, baseConfig(), nil)
+	gateway := &flakyGateway{}
+	provider := NewPaymentProvider(gateway, policy)
+	ctx := context.Background()
+
+	amount, _ := payment.NewMoney(50000, "IDR")
+	if _, err := provider.CreateIntent(ctx, "listing-1", *amount, "Classified ad"); err == nil {
+		t.Error("expected error but got none")
+	}
+	if gateway.creates != 1 {
+		t.Errorf("expected charges never to be retried, got %d attempts", gateway.creates)
+	}
+
+	intent, err := provider.GetIntent(ctx, "pi_1")
+	if err != nil || !intent.IsSucceeded() || gateway.gets != 2 {
+		t.Errorf("expected lookup to succeed on retry, got %v after %d calls", err, gateway.gets)
+	}
+}
@@ -0,0 +1,380 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+type State string
+
+const (
+	StateClosed   State = "closed"    // Calls flow normally
+	StateOpen     State = "open"      // Calls are rejected until OpenFor has passed
+	StateHalfOpen State = "half_open" // A few probe calls decide whether to close again
+)
+
+type Outcome string
+
+const (
+	OutcomeSuccess   Outcome = "success"
+	OutcomeFailure   Outcome = "failure"
+	OutcomeTimeout   Outcome = "timeout"
+	OutcomePermanent Outcome = "permanent" // Rejected by the provider, e.g. a 4xx; does not trip the breaker
+	OutcomeRejected  Outcome = "rejected"  // Not attempted: breaker open or bulkhead full
+)
+
+var (
+	ErrOpen         = errors.New("circuit breaker is open")
+	ErrBulkheadFull = errors.New("too many concurrent calls")
+	ErrTimeout      = errors.New("call timed out")
+)
+
+// Config tunes one integration
+type Config struct {
+	Name        string
+	Timeout     time.Duration // Per attempt
+	MaxAttempts int           // 1 disables retries
+	BaseDelay   time.Duration // Backoff before the second attempt, doubled per attempt with full jitter
+	MaxDelay    time.Duration
+
+	FailureThreshold int // Consecutive failures that open the breaker
+	OpenFor          time.Duration
+	HalfOpenProbes   int
+
+	MaxConcurrent int           // Bulkhead size, 0 is unlimited
+	MaxWait       time.Duration // How long a call may wait for a bulkhead slot
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.Name == "":
+		return errors.New("integration name cannot be empty")
+	case c.Timeout <= 0:
+		return errors.New("timeout must be positive")
+	case c.MaxAttempts < 1:
+		return errors.New("max attempts must be at least 1")
+	case c.MaxAttempts > 1 && (c.BaseDelay <= 0 || c.MaxDelay < c.BaseDelay):
+		return errors.New("retry delays must be positive with max delay at least the base delay")
+	case c.FailureThreshold < 1 || c.OpenFor <= 0 || c.HalfOpenProbes < 1:
+		return errors.New("breaker needs a failure threshold, open duration and at least one probe")
+	case c.MaxConcurrent < 0:
+		return errors.New("max concurrent cannot be negative")
+	}
+	return nil
+}
+
+// Observer receives metrics, e.g. to export breaker state to the monitoring stack
+type Observer interface {
+	StateChanged(name string, from, to State)
+	CallFinished(name string, outcome Outcome, duration time.Duration)
+	FallbackUsed(name string)
+}
+
+// Stats is a point-in-time view of one policy
+type Stats struct {
+	Name                string
+	State               State
+	ConsecutiveFailures int
+	Calls               map[Outcome]int64
+	Fallbacks           int64
+	OpenedAt            *time.Time
+}
+
+// Policy wraps calls to one external provider with a breaker, retries, timeouts and a bulkhead
+type Policy struct {
+	cfg      Config
+	observer Observer
+	slots    chan struct{}
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	openedAt  time.Time
+	probes    int
+	calls     map[Outcome]int64
+	fallbacks int64
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func New(cfg Config, observer Observer) (*Policy, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Policy{
+		cfg:      cfg,
+		observer: observer,
+		state:    StateClosed,
+		calls:    map[Outcome]int64{},
+		now:      time.Now,
+		sleep:    sleepContext,
+	}
+	if cfg.MaxConcurrent > 0 {
+		p.slots = make(chan struct{}, cfg.MaxConcurrent)
+	}
+	return p, nil
+}
+
+func (p *Policy) Name() string {
+	return p.cfg.Name
+}
+
+// Execute runs fn with retries. Use it for idempotent calls only.
+func (p *Policy) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	return p.run(ctx, p.cfg.MaxAttempts, fn)
+}
+
+// Once runs fn a single time through the breaker, bulkhead and timeout, for calls that are unsafe to repeat
+func (p *Policy) Once(ctx context.Context, fn func(ctx context.Context) error) error {
+	return p.run(ctx, 1, fn)
+}
+
+// Do is Execute for calls returning a value
+func Do[T any](ctx context.Context, p *Policy, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := p.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+	return result, err
+}
+
+// DoWithFallback calls fallback when the provider is unavailable. Permanent errors and
+// cancellation by the caller are returned as they are.
+func DoWithFallback[T any](ctx context.Context, p *Policy, fn func(ctx context.Context) (T, error), fallback func(ctx context.Context, cause error) (T, error)) (T, error) {
+	result, err := Do(ctx, p, fn)
+	if err == nil || IsPermanent(err) || ctx.Err() != nil {
+		return result, err
+	}
+
+	p.mu.Lock()
+	p.fallbacks++
+	p.mu.Unlock()
+	if p.observer != nil {
+		p.observer.FallbackUsed(p.cfg.Name)
+	}
+	return fallback(ctx, err)
+}
+
+func (p *Policy) State() State {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.currentState()
+}
+
+func (p *Policy) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := Stats{
+		Name:                p.cfg.Name,
+		State:               p.currentState(),
+		ConsecutiveFailures: p.failures,
+		Calls:               make(map[Outcome]int64, len(p.calls)),
+		Fallbacks:           p.fallbacks,
+	}
+	for outcome, n := range p.calls {
+		stats.Calls[outcome] = n
+	}
+	if stats.State != StateClosed {
+		opened := p.openedAt
+		stats.OpenedAt = &opened
+	}
+	return stats
+}
+
+func (p *Policy) run(ctx context.Context, attempts int, fn func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = p.attempt(ctx, fn)
+		if err == nil || IsPermanent(err) || errors.Is(err, ErrOpen) || errors.Is(err, ErrBulkheadFull) || ctx.Err() != nil {
+			return err
+		}
+		if attempt < attempts {
+			if sleepErr := p.sleep(ctx, p.backoff(attempt)); sleepErr != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+func (p *Policy) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := p.acquire(ctx); err != nil {
+		p.finish(OutcomeRejected, 0)
+		return err
+	}
+	defer p.release()
+
+	if !p.allow() {
+		p.finish(OutcomeRejected, 0)
+		return fmt.Errorf("%s: %w", p.cfg.Name, ErrOpen)
+	}
+
+	started := p.now()
+	callCtx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	err := fn(callCtx)
+	timedOut := errors.Is(callCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	cancel()
+
+	switch {
+	case err == nil:
+		p.record(OutcomeSuccess, p.now().Sub(started))
+	case IsPermanent(err):
+		p.record(OutcomePermanent, p.now().Sub(started))
+	case timedOut:
+		p.record(OutcomeTimeout, p.now().Sub(started))
+		err = fmt.Errorf("%s: %w: %w", p.cfg.Name, ErrTimeout, err)
+	case ctx.Err() != nil:
+		// Cancelled by the caller, says nothing about the provider
+		p.releaseProbe()
+		p.finish(OutcomeRejected, p.now().Sub(started))
+	default:
+		p.record(OutcomeFailure, p.now().Sub(started))
+	}
+	return err
+}
+
+// allow reports whether a call may go through and reserves a probe slot when half-open
+func (p *Policy) allow() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch p.currentState() {
+	case StateOpen:
+		return false
+	case StateHalfOpen:
+		if p.state == StateOpen {
+			p.transition(StateHalfOpen)
+		}
+		if p.probes >= p.cfg.HalfOpenProbes {
+			return false
+		}
+		p.probes++
+	}
+	return true
+}
+
+func (p *Policy) record(outcome Outcome, duration time.Duration) {
+	p.mu.Lock()
+	wasProbe := p.state == StateHalfOpen
+	if wasProbe {
+		p.probes--
+	}
+
+	switch outcome {
+	case OutcomeSuccess, OutcomePermanent:
+		p.failures = 0
+		if wasProbe {
+			p.transition(StateClosed)
+		}
+	default:
+		p.failures++
+		if wasProbe || (p.state == StateClosed && p.failures >= p.cfg.FailureThreshold) {
+			p.openedAt = p.now()
+			p.transition(StateOpen)
+		}
+	}
+	p.mu.Unlock()
+
+	p.finish(outcome, duration)
+}
+
+func (p *Policy) releaseProbe() {
+	p.mu.Lock()
+	if p.state == StateHalfOpen && p.probes > 0 {
+		p.probes--
+	}
+	p.mu.Unlock()
+}
+
+func (p *Policy) finish(outcome Outcome, duration time.Duration) {
+	p.mu.Lock()
+	p.calls[outcome]++
+	p.mu.Unlock()
+	if p.observer != nil {
+		p.observer.CallFinished(p.cfg.Name, outcome, duration)
+	}
+}
+
+// currentState moves open to half-open once OpenFor has passed, callers hold the lock
+func (p *Policy) currentState() State {
+	if p.state == StateOpen && p.now().Sub(p.openedAt) >= p.cfg.OpenFor {
+		return StateHalfOpen
+	}
+	return p.state
+}
+
+// transition is called with the lock held; the observer must not call back into the policy
+func (p *Policy) transition(to State) {
+	from := p.state
+	if from == to {
+		return
+	}
+	p.state = to
+	p.probes = 0
+	if to == StateClosed {
+		p.failures = 0
+	}
+	if p.observer != nil {
+		p.observer.StateChanged(p.cfg.Name, from, to)
+	}
+}
+
+func (p *Policy) acquire(ctx context.Context) error {
+	if p.slots == nil {
+		return nil
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if p.cfg.MaxWait <= 0 {
+		return fmt.Errorf("%s: %w", p.cfg.Name, ErrBulkheadFull)
+	}
+
+	timer := time.NewTimer(p.cfg.MaxWait)
+	defer timer.Stop()
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%s: %w", p.cfg.Name, ErrBulkheadFull)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Policy) release() {
+	if p.slots != nil {
+		<-p.slots
+	}
+}
+
+// backoff returns the full-jitter delay before the next attempt
+func (p *Policy) backoff(attempt int) time.Duration {
+	ceiling := p.cfg.BaseDelay << (attempt - 1)
+	if ceiling <= 0 || ceiling > p.cfg.MaxDelay {
+		ceiling = p.cfg.MaxDelay
+	}
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,293 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errProvider = errors.New("503 service unavailable")
+
+type recordingObserver struct {
+	mu          sync.Mutex
+	transitions []State
+	fallbacks   int
+}
+
+func (o *recordingObserver) StateChanged(name string, from, to State) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.transitions = append(o.transitions, to)
+}
+
+func (o *recordingObserver) CallFinished(name string, outcome Outcome, duration time.Duration) {}
+
+func (o *recordingObserver) FallbackUsed(name string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.fallbacks++
+}
+
+type testClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func createTestPolicy(t *testing.T, cfg Config, observer Observer) (*Policy, *testClock) {
+	if cfg.Name == "" {
+		cfg.Name = "test"
+	}
+	p, err := New(cfg, observer)
+	if err != nil {
+		t.Fatalf("failed to create policy: %v", err)
+	}
+	clock := &testClock{now: time.Date(2025, time.August, 1, 9, 0, 0, 0, time.UTC)}
+	p.now = func() time.Time { return clock.now }
+	p.sleep = func(ctx context.Context, d time.Duration) error {
+		clock.sleeps = append(clock.sleeps, d)
+		return nil
+	}
+	return p, clock
+}
+
+func baseConfig() Config {
+	return Config{
+		Timeout: time.Second, MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second,
+		FailureThreshold: 3, OpenFor: 30 * time.Second, HalfOpenProbes: 1,
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+	}{
+		{name: "no name", modify: func(c *Config) { c.Name = "" }},
+		{name: "no timeout", modify: func(c *Config) { c.Timeout = 0 }},
+		{name: "no attempts", modify: func(c *Config) { c.MaxAttempts = 0 }},
+		{name: "retries without delay", modify: func(c *Config) { c.BaseDelay = 0 }},
+		{name: "no probes", modify: func(c *Config) { c.HalfOpenProbes = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.Name = "test"
+			tt.modify(&cfg)
+			if _, err := New(cfg, nil); err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+
+	for integration, cfg := range DefaultConfigs {
+		cfg.Name = string(integration)
+		if _, err := New(cfg, nil); err != nil {
+			t.Errorf("default config for %s is invalid: %v", integration, err)
+		}
+	}
+}
+
+func TestPolicy_Retries(t *testing.T) {
+	p, clock := createTestPolicy(t, baseConfig(), nil)
+	ctx := context.Background()
+
+	calls := 0
+	err := p.Execute(ctx, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errProvider
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("expected success on third attempt, got %v after %d calls", err, calls)
+	}
+	if len(clock.sleeps) != 2 || clock.sleeps[0] > 100*time.Millisecond || clock.sleeps[1] > 200*time.Millisecond {
+		t.Errorf("expected jittered backoff within 100ms then 200ms, got %v", clock.sleeps)
+	}
+
+	calls = 0
+	err = p.Execute(ctx, func(ctx context.Context) error {
+		calls++
+		return Permanent(errors.New("400 invalid recipient"))
+	})
+	if !IsPermanent(err) || calls != 1 {
+		t.Errorf("expected permanent error without retry, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	p.Once(ctx, func(ctx context.Context) error {
+		calls++
+		return errProvider
+	})
+	if calls != 1 {
+		t.Errorf("expected Once to make a single call, got %d", calls)
+	}
+}
+
+func TestPolicy_Breaker(t *testing.T) {
+	observer := &recordingObserver{}
+	cfg := baseConfig()
+	cfg.MaxAttempts = 1
+	p, clock := createTestPolicy(t, cfg, observer)
+	ctx := context.Background()
+	failing := func(ctx context.Context) error { return errProvider }
+	healthy := func(ctx context.Context) error { return nil }
+
+	// Permanent errors do not count
+	for i := 0; i < 5; i++ {
+		p.Execute(ctx, func(ctx context.Context) error { return Permanent(errProvider) })
+	}
+	if p.State() != StateClosed {
+		t.Fatalf("expected closed breaker, got %s", p.State())
+	}
+
+	for i := 0; i < 3; i++ {
+		p.Execute(ctx, failing)
+	}
+	if p.State() != StateOpen {
+		t.Fatalf("expected open breaker after 3 failures, got %s", p.State())
+	}
+
+	called := false
+	err := p.Execute(ctx, func(ctx context.Context) error { called = true; return nil })
+	if !errors.Is(err, ErrOpen) || called {
+		t.Errorf("expected call to be rejected while open, got %v", err)
+	}
+
+	// After OpenFor a failed probe re-opens the breaker
+	clock.now = clock.now.Add(30 * time.Second)
+	if p.State() != StateHalfOpen {
+		t.Fatalf("expected half-open breaker, got %s", p.State())
+	}
+	p.Execute(ctx, failing)
+	if p.State() != StateOpen {
+		t.Fatalf("expected failed probe to re-open, got %s", p.State())
+	}
+
+	clock.now = clock.now.Add(30 * time.Second)
+	if err := p.Execute(ctx, healthy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.State() != StateClosed {
+		t.Errorf("expected successful probe to close, got %s", p.State())
+	}
+
+	expected := []State{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen, StateClosed}
+	if len(observer.transitions) != len(expected) {
+		t.Fatalf("expected transitions %v, got %v", expected, observer.transitions)
+	}
+	for i, state := range expected {
+		if observer.transitions[i] != state {
+			t.Errorf("expected transitions %v, got %v", expected, observer.transitions)
+			break
+		}
+	}
+
+	stats := p.Stats()
+	if stats.Calls[OutcomeFailure] != 4 || stats.Calls[OutcomeRejected] != 1 || stats.Calls[OutcomePermanent] != 5 {
+		t.Errorf("unexpected stats %+v", stats.Calls)
+	}
+}
+
+func TestPolicy_Timeout(t *testing.T) {
+	cfg := baseConfig()
+	cfg.Timeout = 10 * time.Millisecond
+	cfg.MaxAttempts = 1
+	p, _ := createTestPolicy(t, cfg, nil)
+	p.now = time.Now
+
+	err := p.Execute(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected error '%v', got '%v'", ErrTimeout, err)
+	}
+	if p.Stats().Calls[OutcomeTimeout] != 1 {
+		t.Error("expected timeout to be counted")
+	}
+
+	// Caller cancellation is not the provider's fault
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.Execute(ctx, func(ctx context.Context) error { return ctx.Err() })
+	if p.Stats().ConsecutiveFailures != 1 {
+		t.Errorf("expected cancellation not to count as failure, got %d", p.Stats().ConsecutiveFailures)
+	}
+}
+
+func TestPolicy_Bulkhead(t *testing.T) {
+	cfg := baseConfig()
+	cfg.MaxConcurrent = 1
+	p, _ := createTestPolicy(t, cfg, nil)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		p.Execute(context.Background(), func(ctx context.Context) error {
+			close(entered)
+			<-release
+			return nil
+		})
+		close(done)
+	}()
+	<-entered
+
+	err := p.Execute(context.Background(), func(ctx context.Context) error { return nil })
+	if !errors.Is(err, ErrBulkheadFull) {
+		t.Errorf("expected error '%v', got '%v'", ErrBulkheadFull, err)
+	}
+	close(release)
+	<-done
+
+	if err := p.Execute(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+		t.Errorf("expected slot to be free again, got %v", err)
+	}
+}
+
+func TestDoWithFallback(t *testing.T) {
+	observer := &recordingObserver{}
+	cfg := baseConfig()
+	cfg.MaxAttempts = 1
+	p, _ := createTestPolicy(t, cfg, observer)
+	ctx := context.Background()
+	fallback := func(ctx context.Context, cause error) ([]string, error) {
+		return []string{"from database"}, nil
+	}
+
+	got, err := DoWithFallback(ctx, p, func(ctx context.Context) ([]string, error) { return nil, errProvider }, fallback)
+	if err != nil || got[0] != "from database" || observer.fallbacks != 1 {
+		t.Errorf("expected fallback result, got %v %v", got, err)
+	}
+
+	_, err = DoWithFallback(ctx, p, func(ctx context.Context) ([]string, error) { return nil, Permanent(errProvider) }, fallback)
+	if !IsPermanent(err) || p.Stats().Fallbacks != 1 {
+		t.Errorf("expected permanent error to skip the fallback, got %v", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(nil)
+	email, err := r.Policy(IntegrationEmail)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, _ := r.Policy(IntegrationEmail)
+	if email != again {
+		t.Error("expected the same policy instance per integration")
+	}
+	if _, err := r.Policy("fax"); err == nil {
+		t.Error("expected error but got none")
+	}
+
+	r.Policy(IntegrationPayment)
+	snapshot := r.Snapshot()
+	if len(snapshot) != 2 || snapshot[0].Name != "email" || snapshot[1].State != StateClosed {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+}
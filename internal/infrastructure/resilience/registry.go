@@ -0,0 +1,90 @@
+package resilience
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Integration names the external providers wrapped by a policy
+type Integration string
+
+const (
+	IntegrationEmail   Integration = "email"
+	IntegrationSearch  Integration = "search"
+	IntegrationPayment Integration = "payment"
+)
+
+// DefaultConfigs are the starting points per integration. Fallbacks:
+//   - email: no fallback, the sending job leaves the message pending and retries next run
+//   - search: ArticleSearcher falls back to the database search with reduced ranking
+//   - payment: no fallback and no retry of charges, the reader is asked to try again
+var DefaultConfigs = map[Integration]Config{
+	IntegrationEmail: {
+		Timeout: 10 * time.Second, MaxAttempts: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 5 * time.Second,
+		FailureThreshold: 5, OpenFor: time.Minute, HalfOpenProbes: 1, MaxConcurrent: 20, MaxWait: time.Second,
+	},
+	IntegrationSearch: {
+		Timeout: 800 * time.Millisecond, MaxAttempts: 2, BaseDelay: 50 * time.Millisecond, MaxDelay: 200 * time.Millisecond,
+		FailureThreshold: 10, OpenFor: 15 * time.Second, HalfOpenProbes: 3, MaxConcurrent: 100,
+	},
+	IntegrationPayment: {
+		Timeout: 15 * time.Second, MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 2 * time.Second,
+		FailureThreshold: 5, OpenFor: 30 * time.Second, HalfOpenProbes: 2, MaxConcurrent: 50, MaxWait: 2 * time.Second,
+	},
+}
+
+// Registry keeps one policy per integration so their state can be reported
+type Registry struct {
+	mu       sync.Mutex
+	policies map[string]*Policy
+	observer Observer
+}
+
+func NewRegistry(observer Observer) *Registry {
+	return &Registry{policies: map[string]*Policy{}, observer: observer}
+}
+
+// Policy returns the integration's policy, created from DefaultConfigs on first use
+func (r *Registry) Policy(integration Integration) (*Policy, error) {
+	cfg, ok := DefaultConfigs[integration]
+	if !ok {
+		return nil, fmt.Errorf("unknown integration %q", integration)
+	}
+	cfg.Name = string(integration)
+	return r.PolicyFor(cfg)
+}
+
+// PolicyFor returns the policy registered under cfg.Name, creating it with cfg if needed
+func (r *Registry) PolicyFor(cfg Config) (*Policy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.policies[cfg.Name]; ok {
+		return p, nil
+	}
+	p, err := New(cfg, r.observer)
+	if err != nil {
+		return nil, err
+	}
+	r.policies[cfg.Name] = p
+	return p, nil
+}
+
+// Snapshot returns the stats of every policy, sorted by name
+func (r *Registry) Snapshot() []Stats {
+	r.mu.Lock()
+	policies := make([]*Policy, 0, len(r.policies))
+	for _, p := range r.policies {
+		policies = append(policies, p)
+	}
+	r.mu.Unlock()
+
+	stats := make([]Stats, 0, len(policies))
+	for _, p := range policies {
+		stats = append(stats, p.Stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
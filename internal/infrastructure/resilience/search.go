@@ -0,0 +1,36 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/search"
+)
+
+// ArticleSearcher guards the search backend. While it is unavailable queries
+// go to the fallback, the database search with reduced ranking.
+type ArticleSearcher struct {
+	next     search.ArticleSearcher
+	fallback search.ArticleSearcher
+	policy   *Policy
+}
+
+func NewArticleSearcher(next, fallback search.ArticleSearcher, policy *Policy) *ArticleSearcher {
+	return &ArticleSearcher{next: next, fallback: fallback, policy: policy}
+}
+
+// searchPage carries both results of SearchArticles through the policy
+type searchPage struct {
+	hits  []search.Hit
+	total int
+}
+
+func (s *ArticleSearcher) SearchArticles(ctx context.Context, query search.ArticleQuery) ([]search.Hit, int, error) {
+	page, err := DoWithFallback(ctx, s.policy, func(ctx context.Context) (searchPage, error) {
+		hits, total, err := s.next.SearchArticles(ctx, query)
+		return searchPage{hits: hits, total: total}, err
+	}, func(ctx context.Context, cause error) (searchPage, error) {
+		hits, total, err := s.fallback.SearchArticles(ctx, query)
+		return searchPage{hits: hits, total: total}, err
+	})
+	return page.hits, page.total, err
+}
@@ -0,0 +1,38 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/application/search"
+)
+
+type stubSearcher struct {
+	hits  []search.Hit
+	err   error
+	calls int
+}
+
+func (s *stubSearcher) SearchArticles(ctx context.Context, query search.ArticleQuery) ([]search.Hit, int, error) {
+	s.calls++
+	return s.hits, len(s.hits), s.err
+}
+
+func TestArticleSearcher(t *testing.T) {
+	policy, _ := createTestPolicy(t, baseConfig(), nil)
+	index := &stubSearcher{hits: []search.Hit{{ID: "a1"}, {ID: "a2"}}}
+	database := &stubSearcher{hits: []search.Hit{{ID: "a1"}}}
+	searcher := NewArticleSearcher(index, database, policy)
+	ctx := context.Background()
+
+	hits, total, err := searcher.SearchArticles(ctx, search.ArticleQuery{Language: "id"})
+	if err != nil || total != 2 || len(hits) != 2 || database.calls != 0 {
+		t.Errorf("expected the index results, got %d hits of %d with %v", len(hits), total, err)
+	}
+
+	index.err = errProvider
+	hits, total, err = searcher.SearchArticles(ctx, search.ArticleQuery{Language: "id"})
+	if err != nil || total != 1 || len(hits) != 1 || database.calls != 1 {
+		t.Errorf("expected the database results while the index fails, got %d hits of %d with %v", len(hits), total, err)
+	}
+}
@@ -0,0 +1,31 @@
+package safehttp
+
+import "net/netip"
+
+// blockedPrefixes are ranges no fetch may reach besides the ones netip
+// classifies: shared address space, benchmarking, reserved and NAT64
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// BlockedIP reports whether an address is off limits by default: loopback,
+// private, link-local (cloud metadata at 169.254.169.254), multicast and
+// reserved ranges, also when written as IPv4-mapped IPv6
+func BlockedIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package safehttp
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestBlockedIP(t *testing.T) {
+	testCases := []struct {
+		ip       string
+		expected bool
+	}{
+		{"93.184.216.34", false},
+		{"2606:2800:220:1::1", false},
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"fd00::1", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:10.0.0.1", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.ip, func(t *testing.T) {
+			if got := BlockedIP(netip.MustParseAddr(tc.ip)); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const userAgent = "NewsPortalFetcher/1.0"
+
+var (
+	ErrBlockedAddress   = errors.New("address is private or reserved")
+	ErrBlockedPort      = errors.New("port is not allowed")
+	ErrScheme           = errors.New("only http and https URLs can be fetched")
+	ErrTooManyRedirects = errors.New("too many redirects")
+	ErrBodyTooLarge     = errors.New("response body is too large")
+	ErrContentType      = errors.New("content type is not allowed")
+)
+
+// Response is a fully read response, the connection is already released
+type Response struct {
+	URL        *url.URL // After redirects
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Truncated  bool
+}
+
+// Client fetches user-supplied URLs under one policy. The address check runs
+// in the dialer after DNS resolution, so every redirect hop is covered and a
+// host that resolves to a public address for validation and a private one
+// for the connection (DNS rebinding) is still refused.
+type Client struct {
+	policy     Policy
+	httpClient *http.Client
+}
+
+func New(policy Policy) (*Client, error) {
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	c := &Client{policy: policy}
+	dialer := &net.Dialer{Timeout: policy.Timeout, Control: c.control}
+	c.httpClient = &http.Client{
+		Timeout: policy.Timeout,
+		Transport: &http.Transport{
+			Proxy:                 nil, // A proxy would connect on our behalf and skip the address check
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   policy.Timeout,
+			ResponseHeaderTimeout: policy.Timeout,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: c.checkRedirect,
+	}
+	return c, nil
+}
+
+// ForFeature builds a client from the feature's default policy
+func ForFeature(feature Feature) (*Client, error) {
+	policy, ok := DefaultPolicies[feature]
+	if !ok {
+		return nil, fmt.Errorf("unknown feature %q", feature)
+	}
+	policy.Name = string(feature)
+	return New(policy)
+}
+
+func (c *Client) Name() string {
+	return c.policy.Name
+}
+
+func (c *Client) control(network, address string, _ syscall.RawConn) error {
+	addr, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return ErrBlockedAddress
+	}
+	ip := addr.Addr().Unmap()
+	allowed := false
+	for _, prefix := range c.policy.AllowedNetworks {
+		if prefix.Contains(ip) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed && BlockedIP(ip) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+// checkURL runs before the first request and on every redirect hop, the
+// address itself is checked later by the dialer
+func (c *Client) checkURL(u *url.URL) error {
+	switch {
+	case u.Scheme == "https":
+	case u.Scheme == "http" && !c.policy.RequireHTTPS:
+	default:
+		return ErrScheme
+	}
+	if u.Hostname() == "" {
+		return ErrScheme
+	}
+	if len(c.policy.AllowedPorts) == 0 {
+		return nil
+	}
+	port := 80
+	if u.Scheme == "https" {
+		port = 443
+	}
+	if p := u.Port(); p != "" {
+		var err error
+		if port, err = strconv.Atoi(p); err != nil {
+			return ErrBlockedPort
+		}
+	}
+	if !slices.Contains(c.policy.AllowedPorts, port) {
+		return ErrBlockedPort
+	}
+	return nil
+}
+
+func (c *Client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if c.policy.MaxRedirects == 0 {
+		return http.ErrUseLastResponse
+	}
+	if len(via) > c.policy.MaxRedirects {
+		return ErrTooManyRedirects
+	}
+	return c.checkURL(req.URL)
+}
+
+// Get fetches rawURL with the given extra headers
+func (c *Client) Get(ctx context.Context, rawURL string, header http.Header) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrScheme, err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	return c.Do(req)
+}
+
+// Do sends req and reads the body within the policy's limits. Errors from
+// the policy are returned unwrapped so callers can compare them directly.
+func (c *Client) Do(req *http.Request) (*Response, error) {
+	if err := c.checkURL(req.URL); err != nil {
+		return nil, err
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		for _, target := range []error{ErrBlockedAddress, ErrBlockedPort, ErrScheme, ErrTooManyRedirects} {
+			if errors.Is(err, target) {
+				return nil, target
+			}
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 && len(c.policy.ContentTypes) > 0 {
+		mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		if !slices.Contains(c.policy.ContentTypes, mediaType) {
+			return nil, ErrContentType
+		}
+	}
+
+	// Read one byte past the limit to tell a body of exactly the limit from a longer one
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.policy.MaxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	truncated := int64(len(body)) > c.policy.MaxBodySize
+	if truncated {
+		if !c.policy.TruncateBody {
+			return nil, ErrBodyTooLarge
+		}
+		body = body[:c.policy.MaxBodySize]
+	}
+	return &Response{URL: resp.Request.URL, StatusCode: resp.StatusCode, Header: resp.Header, Body: body, Truncated: truncated}, nil
+}
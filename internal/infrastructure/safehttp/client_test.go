@@ -0,0 +1,121 @@
+package safehttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+var loopback = []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(strings.Repeat("x", 2048)))
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusFound)
+	})
+	mux.HandleFunc("/metadata", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	})
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testPolicy() Policy {
+	return Policy{
+		Name: "test", Timeout: time.Second, MaxRedirects: 3, MaxBodySize: 1024, TruncateBody: true,
+		ContentTypes: []string{"text/html"}, AllowedNetworks: loopback,
+	}
+}
+
+func TestClient_Get(t *testing.T) {
+	server := newTestServer(t)
+
+	testCases := []struct {
+		name          string
+		path          string
+		policy        func(p *Policy)
+		expectedError error
+	}{
+		{"page", "/page", nil, nil},
+		{"redirect", "/moved", nil, nil},
+		{"loopback not allowed", "/page", func(p *Policy) { p.AllowedNetworks = nil }, ErrBlockedAddress},
+		{"redirect to metadata service", "/metadata", nil, ErrBlockedAddress},
+		{"redirect to file", "/file", nil, ErrScheme},
+		{"redirect loop", "/loop", nil, ErrTooManyRedirects},
+		{"content type", "/image.png", nil, ErrContentType},
+		{"port", "/page", func(p *Policy) { p.AllowedPorts = webPorts }, ErrBlockedPort},
+		{"https required", "/page", func(p *Policy) { p.RequireHTTPS = true }, ErrScheme},
+		{"body too large", "/page", func(p *Policy) { p.TruncateBody = false }, ErrBodyTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := testPolicy()
+			if tc.policy != nil {
+				tc.policy(&policy)
+			}
+			client, err := New(policy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := client.Get(context.Background(), server.URL+tc.path, nil)
+			if err != tc.expectedError {
+				t.Fatalf("expected error '%v', got '%v'", tc.expectedError, err)
+			}
+			if err == nil && (resp.URL.Path != "/page" || len(resp.Body) != 1024 || !resp.Truncated) {
+				t.Errorf("expected truncated page, got %s with %d bytes", resp.URL, len(resp.Body))
+			}
+		})
+	}
+}
+
+func TestClient_NoRedirects(t *testing.T) {
+	server := newTestServer(t)
+	policy := testPolicy()
+	policy.MaxRedirects = 0
+	client, _ := New(policy)
+
+	resp, err := client.Get(context.Background(), server.URL+"/metadata", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusFound || resp.Header.Get("Location") == "" {
+		t.Errorf("expected the redirect returned as is, got %d", resp.StatusCode)
+	}
+}
+
+func TestNew_InvalidPolicy(t *testing.T) {
+	policy := testPolicy()
+	policy.MaxBodySize = 0
+	if _, err := New(policy); err == nil {
+		t.Error("expected error for zero body size")
+	}
+	if _, err := ForFeature("ftp"); err == nil {
+		t.Error("expected error for unknown feature")
+	}
+	for feature := range DefaultPolicies {
+		if _, err := ForFeature(feature); err != nil {
+			t.Errorf("expected valid default policy for %s, got %v", feature, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package safehttp
+
+import (
+	"errors"
+	"net/netip"
+	"time"
+)
+
+// Feature names the parts of the CMS that fetch user-supplied URLs
+type Feature string
+
+const (
+	FeatureLinkPreview Feature = "link_preview"
+	FeatureOEmbed      Feature = "oembed"
+	FeatureLinkCheck   Feature = "link_check"
+	FeatureWebhook     Feature = "webhook"
+)
+
+// Policy limits what one feature may fetch. Private and reserved addresses
+// are always refused unless listed in AllowedNetworks.
+type Policy struct {
+	Name         string
+	Timeout      time.Duration // Whole request including reading the body
+	MaxRedirects int           // 0 returns redirects to the caller instead of following them
+	MaxBodySize  int64
+	TruncateBody bool     // Cut the body at MaxBodySize instead of failing with ErrBodyTooLarge
+	ContentTypes []string // Media types accepted on 2xx responses, empty accepts any
+	AllowedPorts []int    // Empty allows any port
+	RequireHTTPS bool
+
+	// AllowedNetworks opens specific internal ranges, e.g. a webhook receiver
+	// inside the cluster. Keep it empty for anything editors or readers type in.
+	AllowedNetworks []netip.Prefix
+}
+
+func (p Policy) validate() error {
+	switch {
+	case p.Name == "":
+		return errors.New("policy name cannot be empty")
+	case p.Timeout <= 0:
+		return errors.New("timeout must be positive")
+	case p.MaxRedirects < 0:
+		return errors.New("max redirects cannot be negative")
+	case p.MaxBodySize <= 0:
+		return errors.New("max body size must be positive")
+	}
+	for _, port := range p.AllowedPorts {
+		if port < 1 || port > 65535 {
+			return errors.New("allowed ports must be between 1 and 65535")
+		}
+	}
+	return nil
+}
+
+var webPorts = []int{80, 443, 8080, 8443}
+
+// DefaultPolicies are the starting points per feature:
+//   - link_preview: HTML only, the body is cut after the <head> has surely been read
+//   - oembed: JSON or XML provider responses, which are small
+//   - link_check: only the status matters, so the body limit is tiny and any type is fine
+//   - webhook: HTTPS only and no redirects, a receiver that moves must be re-registered
+var DefaultPolicies = map[Feature]Policy{
+	FeatureLinkPreview: {
+		Timeout: 5 * time.Second, MaxRedirects: 5, MaxBodySize: 1 << 20, TruncateBody: true,
+		ContentTypes: []string{"text/html", "application/xhtml+xml"}, AllowedPorts: webPorts,
+	},
+	FeatureOEmbed: {
+		Timeout: 5 * time.Second, MaxRedirects: 3, MaxBodySize: 256 << 10,
+		ContentTypes: []string{"application/json", "text/json", "text/xml", "application/xml"}, AllowedPorts: webPorts,
+	},
+	FeatureLinkCheck: {
+		Timeout: 10 * time.Second, MaxRedirects: 10, MaxBodySize: 64 << 10, TruncateBody: true, AllowedPorts: webPorts,
+	},
+	FeatureWebhook: {
+		Timeout: 5 * time.Second, MaxRedirects: 0, MaxBodySize: 64 << 10, TruncateBody: true, RequireHTTPS: true,
+	},
+}
@@ -0,0 +1,127 @@
+package sealbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/tip"
+)
+
+// Sealed layout: version, wrapped key length, RSA-OAEP wrapped AES-256 key, GCM nonce, ciphertext.
+// A fresh AES key per box keeps RSA to a single block whatever the payload size.
+const (
+	version = 1
+	keySize = 32
+
+	// minBits is above the JWT signing minimum: tips must stay sealed for years
+	minBits = 3072
+)
+
+var (
+	ErrWeakKey    = errors.New("newsroom key must be at least 3072 bits")
+	ErrInvalidPEM = errors.New("no RSA key found in PEM data")
+	ErrCorrupt    = errors.New("sealed box is corrupt or was sealed to another key")
+)
+
+// Sealer encrypts to the newsroom's public key. The matching private key stays
+// offline with the editors, so nothing on the server can open a sealed tip.
+type Sealer struct {
+	public *rsa.PublicKey
+}
+
+var _ tip.Sealer = (*Sealer)(nil)
+
+func NewSealer(public *rsa.PublicKey) (*Sealer, error) {
+	if public.N.BitLen() < minBits {
+		return nil, ErrWeakKey
+	}
+	return &Sealer{public: public}, nil
+}
+
+// ParsePublicKey reads the newsroom key from a PKIX public key PEM block
+func ParsePublicKey(data []byte) (*Sealer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPEM
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPEM, err)
+	}
+	public, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, ErrInvalidPEM
+	}
+	return NewSealer(public)
+}
+
+func (s *Sealer) Seal(plaintext []byte) ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, s.public, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	box := []byte{version}
+	box = binary.BigEndian.AppendUint16(box, uint16(len(wrapped)))
+	box = append(box, wrapped...)
+	box = append(box, nonce...)
+	return aead.Seal(box, nonce, plaintext, box[:1]), nil
+}
+
+// Open decrypts a sealed box with the newsroom's private key. The server never
+// calls it; it is what the editors' offline tooling runs.
+func Open(private *rsa.PrivateKey, box []byte) ([]byte, error) {
+	if len(box) < 3 || box[0] != version {
+		return nil, ErrCorrupt
+	}
+	wrappedLen := int(binary.BigEndian.Uint16(box[1:3]))
+	rest := box[3:]
+	if len(rest) < wrappedLen {
+		return nil, ErrCorrupt
+	}
+	key, err := rsa.DecryptOAEP(sha256.New(), nil, private, rest[:wrappedLen], nil)
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	rest = rest[wrappedLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrCorrupt
+	}
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], box[:1])
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
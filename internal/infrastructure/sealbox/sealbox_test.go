@@ -0,0 +1,59 @@
+package sealbox
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestSealer_SealAndOpen(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, minBits)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, _ := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	sealer, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plaintext := []byte("The minister's aide signed the permit")
+	box, err := sealer.Seal(plaintext)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Contains(box, plaintext) {
+		t.Error("expected the plaintext not to appear in the box")
+	}
+	again, _ := sealer.Seal(plaintext)
+	if bytes.Equal(box, again) {
+		t.Error("expected every box to use a fresh key")
+	}
+
+	opened, err := Open(private, box)
+	if err != nil || !bytes.Equal(opened, plaintext) {
+		t.Fatalf("expected the plaintext back, got %q, %v", opened, err)
+	}
+
+	box[len(box)-1] ^= 1
+	if _, err := Open(private, box); err != ErrCorrupt {
+		t.Errorf("expected error '%v', got '%v'", ErrCorrupt, err)
+	}
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := Open(other, again); err != ErrCorrupt {
+		t.Errorf("expected error '%v' with another key, got '%v'", ErrCorrupt, err)
+	}
+}
+
+func TestNewSealer_RejectsWeakKeys(t *testing.T) {
+	private, _ := rsa.GenerateKey(rand.Reader, 2048)
+	if _, err := NewSealer(&private.PublicKey); err != ErrWeakKey {
+		t.Errorf("expected error '%v', got '%v'", ErrWeakKey, err)
+	}
+	if _, err := ParsePublicKey([]byte("not a key")); err != ErrInvalidPEM {
+		t.Errorf("expected error '%v', got '%v'", ErrInvalidPEM, err)
+	}
+}
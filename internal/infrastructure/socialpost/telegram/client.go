@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/social"
+)
+
+const DefaultBaseURL = "https://api.telegram.org"
+
+// Client posts to a Telegram channel through the Bot API. The bot must be an
+// administrator of the channel. Templates may use Telegram's HTML subset.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+	chatID     string // e.g. "@portalberita"
+}
+
+func NewClient(httpClient *http.Client, baseURL, token, chatID string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpClient, baseURL: strings.TrimRight(baseURL, "/"), token: token, chatID: chatID}
+}
+
+var _ social.Publisher = (*Client)(nil)
+
+type sendMessageRequest struct {
+	ChatID    string `json:"chat_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode"`
+}
+
+type sendMessageResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+	Result      struct {
+		MessageID int64 `json:"message_id"`
+	} `json:"result"`
+}
+
+func (c *Client) Publish(ctx context.Context, post *social.Post) (string, error) {
+	payload, err := json.Marshal(sendMessageRequest{ChatID: c.chatID, Text: post.Text, ParseMode: "HTML"})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/bot"+c.token+"/sendMessage", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The URL holds the bot token, keep it out of post errors
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return "", fmt.Errorf("failed to send telegram message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body sendMessageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode telegram response: %w", err)
+	}
+	if !body.OK {
+		return "", fmt.Errorf("failed to send telegram message: %s", body.Description)
+	}
+	return strconv.FormatInt(body.Result.MessageID, 10), nil
+}
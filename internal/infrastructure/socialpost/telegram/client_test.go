@@ -0,0 +1,34 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/social"
+)
+
+func TestClient_Publish(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req sendMessageRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/botsecret/sendMessage" || req.ChatID != "@portal" {
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":512}}`))
+	}))
+	defer server.Close()
+	post := &social.Post{ID: "p1", Channel: social.ChannelTelegram, Text: "<b>Harga BBM naik</b>"}
+
+	id, err := NewClient(server.Client(), server.URL, "secret", "@portal").Publish(context.Background(), post)
+	if err != nil || id != "512" {
+		t.Errorf("expected message 512, got %q, %v", id, err)
+	}
+
+	if _, err := NewClient(server.Client(), server.URL, "revoked", "@portal").Publish(context.Background(), post); err == nil {
+		t.Error("expected a rejected token to fail")
+	}
+}
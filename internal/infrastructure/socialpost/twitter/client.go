@@ -0,0 +1,75 @@
+package twitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/social"
+)
+
+const DefaultBaseURL = "https://api.x.com"
+
+// TokenSource returns an OAuth 2.0 user access token of the portal's X account
+type TokenSource func(ctx context.Context) (string, error)
+
+// Client posts to X with the API v2 create post endpoint
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      TokenSource
+}
+
+func NewClient(httpClient *http.Client, baseURL string, token TokenSource) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpClient, baseURL: strings.TrimRight(baseURL, "/"), token: token}
+}
+
+var _ social.Publisher = (*Client)(nil)
+
+type createResponse struct {
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+	Detail string `json:"detail"`
+}
+
+func (c *Client) Publish(ctx context.Context, post *social.Post) (string, error) {
+	accessToken, err := c.token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	payload, err := json.Marshal(map[string]string{"text": post.Text})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/2/tweets", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body createResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to create post: X returned %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusCreated || body.Data.ID == "" {
+		return "", fmt.Errorf("failed to create post: X returned %d %s", resp.StatusCode, body.Detail)
+	}
+	return body.Data.ID, nil
+}
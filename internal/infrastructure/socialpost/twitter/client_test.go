@@ -0,0 +1,35 @@
+package twitter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/notification/social"
+)
+
+func TestClient_Publish(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2/tweets" || r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail":"Unauthorized"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data":{"id":"1799","text":"Harga BBM naik"}}`))
+	}))
+	defer server.Close()
+	post := &social.Post{ID: "p1", Channel: social.ChannelX, Text: "Harga BBM naik"}
+
+	token := func(ctx context.Context) (string, error) { return "access-token", nil }
+	id, err := NewClient(server.Client(), server.URL, token).Publish(context.Background(), post)
+	if err != nil || id != "1799" {
+		t.Errorf("expected post 1799, got %q, %v", id, err)
+	}
+
+	expired := func(ctx context.Context) (string, error) { return "expired", nil }
+	if _, err := NewClient(server.Client(), server.URL, expired).Publish(context.Background(), post); err == nil {
+		t.Error("expected an expired token to fail")
+	}
+}
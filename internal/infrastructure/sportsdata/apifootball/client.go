@@ -0,0 +1,214 @@
+package apifootball
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sports"
+)
+
+const DefaultBaseURL = "https://v3.football.api-sports.io"
+
+// ProviderName is what competitions set as Provider to use this adapter
+const ProviderName = "api-football"
+
+// Client reads fixtures and standings from API-Football v3. Competitions
+// use the league ID as ProviderRef and the season's start year as Season.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+}
+
+func NewClient(httpClient *http.Client, baseURL, apiKey string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpClient, baseURL: strings.TrimRight(baseURL, "/"), apiKey: apiKey}
+}
+
+var _ sports.Provider = (*Client)(nil)
+
+type team struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+type fixturesResponse struct {
+	Errors   json.RawMessage `json:"errors"`
+	Response []struct {
+		Fixture struct {
+			ID     int    `json:"id"`
+			Date   string `json:"date"`
+			Status struct {
+				Short   string `json:"short"`
+				Elapsed *int   `json:"elapsed"`
+			} `json:"status"`
+			Venue struct {
+				Name string `json:"name"`
+			} `json:"venue"`
+		} `json:"fixture"`
+		Teams struct {
+			Home team `json:"home"`
+			Away team `json:"away"`
+		} `json:"teams"`
+		Goals struct {
+			Home *int `json:"home"`
+			Away *int `json:"away"`
+		} `json:"goals"`
+	} `json:"response"`
+}
+
+type standingsResponse struct {
+	Errors   json.RawMessage `json:"errors"`
+	Response []struct {
+		League struct {
+			Standings [][]struct {
+				Rank   int  `json:"rank"`
+				Team   team `json:"team"`
+				Points int  `json:"points"`
+				All    struct {
+					Played int `json:"played"`
+					Win    int `json:"win"`
+					Draw   int `json:"draw"`
+					Lose   int `json:"lose"`
+					Goals  struct {
+						For     int `json:"for"`
+						Against int `json:"against"`
+					} `json:"goals"`
+				} `json:"all"`
+			} `json:"standings"`
+		} `json:"league"`
+	} `json:"response"`
+}
+
+func (c *Client) Fixtures(ctx context.Context, competition *sports.Competition, from, to time.Time) ([]sports.Fixture, error) {
+	query := url.Values{
+		"league": {competition.ProviderRef},
+		"season": {competition.Season},
+		"from":   {from.UTC().Format(time.DateOnly)},
+		"to":     {to.UTC().Format(time.DateOnly)},
+	}
+	var body fixturesResponse
+	if err := c.get(ctx, "/fixtures", query, &body); err != nil {
+		return nil, err
+	}
+	if hasErrors(body.Errors) {
+		return nil, fmt.Errorf("failed to get fixtures: %s", body.Errors)
+	}
+
+	fixtures := make([]sports.Fixture, 0, len(body.Response))
+	for _, r := range body.Response {
+		kickoff, err := time.Parse(time.RFC3339, r.Fixture.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode fixture %d: %w", r.Fixture.ID, err)
+		}
+		f := sports.Fixture{
+			ProviderRef: strconv.Itoa(r.Fixture.ID),
+			Home:        toTeam(r.Teams.Home),
+			Away:        toTeam(r.Teams.Away),
+			KickoffAt:   kickoff.UTC(),
+			Venue:       r.Fixture.Venue.Name,
+			Status:      toStatus(r.Fixture.Status.Short),
+		}
+		if r.Goals.Home != nil && r.Goals.Away != nil {
+			f.Score = &sports.Score{Home: *r.Goals.Home, Away: *r.Goals.Away}
+		}
+		if f.Status == sports.StatusLive {
+			f.Minute = r.Fixture.Status.Elapsed
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
+
+func (c *Client) Table(ctx context.Context, competition *sports.Competition) (*sports.Table, error) {
+	query := url.Values{"league": {competition.ProviderRef}, "season": {competition.Season}}
+	var body standingsResponse
+	if err := c.get(ctx, "/standings", query, &body); err != nil {
+		return nil, err
+	}
+	if hasErrors(body.Errors) {
+		return nil, fmt.Errorf("failed to get standings: %s", body.Errors)
+	}
+
+	table := &sports.Table{}
+	if len(body.Response) == 0 || len(body.Response[0].League.Standings) == 0 {
+		return table, nil
+	}
+	// Cups return one table per group, league tables come as the first one
+	for _, row := range body.Response[0].League.Standings[0] {
+		table.Rows = append(table.Rows, sports.Standing{
+			Position:     row.Rank,
+			Team:         toTeam(row.Team),
+			Played:       row.All.Played,
+			Won:          row.All.Win,
+			Drawn:        row.All.Draw,
+			Lost:         row.All.Lose,
+			GoalsFor:     row.All.Goals.For,
+			GoalsAgainst: row.All.Goals.Against,
+			Points:       row.Points,
+		})
+	}
+	return table, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-apisports-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get %s: API-Football returned %d", path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// hasErrors reports a non-empty errors field, which API-Football sends as
+// [] when there are none and as an object otherwise
+func hasErrors(raw json.RawMessage) bool {
+	s := strings.TrimSpace(string(raw))
+	return s != "" && s != "[]" && s != "{}" && s != "null"
+}
+
+func toTeam(t team) sports.Team {
+	return sports.Team{ID: strconv.Itoa(t.ID), Name: t.Name, ShortName: t.Code}
+}
+
+// toStatus maps API-Football's short status codes
+func toStatus(short string) sports.Status {
+	switch short {
+	case "1H", "2H", "ET", "BT", "P", "LIVE", "INT", "SUSP":
+		return sports.StatusLive
+	case "HT":
+		return sports.StatusHalfTime
+	case "FT", "AET", "PEN", "AWD", "WO":
+		return sports.StatusFinished
+	case "PST":
+		return sports.StatusPostponed
+	case "CANC", "ABD":
+		return sports.StatusCancelled
+	default:
+		return sports.StatusScheduled
+	}
+}
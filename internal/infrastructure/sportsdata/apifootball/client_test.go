@@ -0,0 +1,79 @@
+package apifootball
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/sports"
+)
+
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-apisports-key") != "key-1" {
+			_, _ = w.Write([]byte(`{"errors":{"token":"Error/Missing application key."},"response":[]}`))
+			return
+		}
+		if r.URL.Query().Get("league") != "274" || r.URL.Query().Get("season") != "2025" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/fixtures":
+			_, _ = w.Write([]byte(`{"errors":[],"response":[
+				{"fixture":{"id":9001,"date":"2026-03-07T19:00:00+07:00","status":{"short":"2H","elapsed":67},"venue":{"name":"GBLA"}},
+				 "teams":{"home":{"id":1,"name":"Persib","code":"PSB"},"away":{"id":2,"name":"Persija","code":"PSJ"}},"goals":{"home":2,"away":1}},
+				{"fixture":{"id":9002,"date":"2026-03-10T19:00:00+07:00","status":{"short":"NS","elapsed":null},"venue":{"name":"Kanjuruhan"}},
+				 "teams":{"home":{"id":3,"name":"Arema"},"away":{"id":4,"name":"Bali United"}},"goals":{"home":null,"away":null}}
+			]}`))
+		case "/standings":
+			_, _ = w.Write([]byte(`{"errors":[],"response":[{"league":{"standings":[[
+				{"rank":1,"team":{"id":1,"name":"Persib"},"points":58,"all":{"played":25,"win":18,"draw":4,"lose":3,"goals":{"for":50,"against":20}}}
+			]]}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(server.Client(), server.URL, "key-1")
+}
+
+func TestClient_Fixtures(t *testing.T) {
+	client := newTestClient(t)
+	competition := &sports.Competition{ID: "liga-1", Season: "2025", ProviderRef: "274"}
+
+	fixtures, err := client.Fixtures(context.Background(), competition, time.Now(), time.Now().AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fixtures) != 2 {
+		t.Fatalf("expected 2 fixtures, got %d", len(fixtures))
+	}
+	live := fixtures[0]
+	if live.ProviderRef != "9001" || live.Status != sports.StatusLive || live.Score.String() != "2-1" || *live.Minute != 67 || live.Home.ShortName != "PSB" {
+		t.Errorf("unexpected live fixture %+v", live)
+	}
+	if !live.KickoffAt.Equal(time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected kickoff in UTC, got %v", live.KickoffAt)
+	}
+	if fixtures[1].Status != sports.StatusScheduled || fixtures[1].Score != nil || fixtures[1].Minute != nil {
+		t.Errorf("expected a scheduled fixture without score, got %+v", fixtures[1])
+	}
+
+	if _, err := NewClient(http.DefaultClient, client.baseURL, "wrong").Fixtures(context.Background(), competition, time.Now(), time.Now()); err == nil {
+		t.Error("expected error for an API error payload")
+	}
+}
+
+func TestClient_Table(t *testing.T) {
+	table, err := newTestClient(t).Table(context.Background(), &sports.Competition{Season: "2025", ProviderRef: "274"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(table.Rows) != 1 || table.Rows[0].Points != 58 || table.Rows[0].GoalDifference() != 30 {
+		t.Errorf("unexpected table %+v", table)
+	}
+}
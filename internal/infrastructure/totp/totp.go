@@ -0,0 +1,155 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// Algorithm is the HMAC hash, authenticator apps other than the big ones only do SHA1
+type Algorithm string
+
+const (
+	SHA1   Algorithm = "SHA1"
+	SHA256 Algorithm = "SHA256"
+	SHA512 Algorithm = "SHA512"
+)
+
+// DefaultSkew accepts the code of one step either side, a phone clock a little off
+const DefaultSkew = 1
+
+var (
+	ErrInvalidSecret    = errors.New("TOTP secret is not valid base32")
+	ErrInvalidAlgorithm = errors.New("TOTP algorithm must be SHA1, SHA256 or SHA512")
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP generates and checks time-based one-time passwords (RFC 6238), the
+// HOTP of RFC 4226 with the counter taken from the clock
+type TOTP struct {
+	Algorithm Algorithm
+	Digits    int
+	Period    time.Duration
+	Skew      int // Steps accepted either side of the current one
+}
+
+// New returns the settings account.TOTPDigits and account.TOTPPeriod describe
+func New() *TOTP {
+	return &TOTP{Algorithm: SHA1, Digits: account.TOTPDigits, Period: account.TOTPPeriod, Skew: DefaultSkew}
+}
+
+var _ account.TOTPVerifier = (*TOTP)(nil)
+
+// NewSecret returns a random base32 secret of account.MinTOTPSecretBytes
+func NewSecret() (string, error) {
+	key := make([]byte, account.MinTOTPSecretBytes)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+	return encoding.EncodeToString(key), nil
+}
+
+// Step is the time step the moment falls in
+func (t *TOTP) Step(at time.Time) int64 {
+	return at.Unix() / int64(t.Period/time.Second)
+}
+
+// Generate returns the code for the moment
+func (t *TOTP) Generate(secret string, at time.Time) (string, error) {
+	key, err := decode(secret)
+	if err != nil {
+		return "", err
+	}
+	return t.hotp(key, t.Step(at))
+}
+
+// Verify checks the code against the steps within Skew of the moment
+func (t *TOTP) Verify(secret, code string, at time.Time) (int64, bool, error) {
+	key, err := decode(secret)
+	if err != nil {
+		return 0, false, err
+	}
+	code = strings.TrimSpace(code)
+	if len(code) != t.Digits {
+		return 0, false, nil
+	}
+	current := t.Step(at)
+	for step := current - int64(t.Skew); step <= current+int64(t.Skew); step++ {
+		expected, err := t.hotp(key, step)
+		if err != nil {
+			return 0, false, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// URI is the otpauth:// link authenticator apps scan from a QR code
+func (t *TOTP) URI(issuer, accountName, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", string(t.Algorithm))
+	q.Set("digits", fmt.Sprint(t.Digits))
+	q.Set("period", fmt.Sprint(int(t.Period/time.Second)))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(accountName)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// hotp is RFC 4226 section 5.3: HMAC the counter, then dynamic truncation
+func (t *TOTP) hotp(key []byte, counter int64) (string, error) {
+	newHash, err := t.hash()
+	if err != nil {
+		return "", err
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(newHash, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for range t.Digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", t.Digits, value%mod), nil
+}
+
+func (t *TOTP) hash() (func() hash.Hash, error) {
+	switch t.Algorithm {
+	case SHA1, "":
+		return sha1.New, nil
+	case SHA256:
+		return sha256.New, nil
+	case SHA512:
+		return sha512.New, nil
+	}
+	return nil, ErrInvalidAlgorithm
+}
+
+func decode(secret string) ([]byte, error) {
+	secret = strings.TrimRight(strings.ToUpper(strings.ReplaceAll(secret, " ", "")), "=")
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
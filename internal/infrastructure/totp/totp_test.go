@@ -0,0 +1,107 @@
+package totp
+
+import (
+	"encoding/base32"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/user/account"
+)
+
+// RFC 6238 appendix B, the seeds are ASCII and the codes eight digits
+func TestGenerate_RFC6238(t *testing.T) {
+	seeds := map[Algorithm]string{
+		SHA1:   "12345678901234567890",
+		SHA256: "12345678901234567890123456789012",
+		SHA512: "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+	vectors := []struct {
+		unix     int64
+		expected map[Algorithm]string
+	}{
+		{59, map[Algorithm]string{SHA1: "94287082", SHA256: "46119246", SHA512: "90693936"}},
+		{1111111109, map[Algorithm]string{SHA1: "07081804", SHA256: "68084774", SHA512: "25091201"}},
+		{1111111111, map[Algorithm]string{SHA1: "14050471", SHA256: "67062674", SHA512: "99943326"}},
+		{1234567890, map[Algorithm]string{SHA1: "89005924", SHA256: "91819424", SHA512: "93441116"}},
+		{2000000000, map[Algorithm]string{SHA1: "69279037", SHA256: "90698825", SHA512: "38618901"}},
+		{20000000000, map[Algorithm]string{SHA1: "65353130", SHA256: "77737706", SHA512: "47863826"}},
+	}
+
+	for _, v := range vectors {
+		for alg, expected := range v.expected {
+			totp := &TOTP{Algorithm: alg, Digits: 8, Period: 30 * time.Second}
+			secret := base32.StdEncoding.EncodeToString([]byte(seeds[alg]))
+			code, err := totp.Generate(secret, time.Unix(v.unix, 0))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if code != expected {
+				t.Errorf("%s at %d: expected %s, got %s", alg, v.unix, expected, code)
+			}
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	totp := New()
+	secret, err := NewSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := account.NewTOTPSecret(secret); err != nil {
+		t.Fatalf("expected the account to accept the secret, got %v", err)
+	}
+	at := time.Date(2026, time.March, 10, 9, 0, 10, 0, time.UTC)
+	code, _ := totp.Generate(secret, at)
+
+	tests := []struct {
+		name   string
+		code   string
+		at     time.Time
+		ok     bool
+		offset int64
+	}{
+		{"same step", code, at, true, 0},
+		{"clock behind", code, at.Add(-30 * time.Second), true, 1},
+		{"clock ahead", code, at.Add(30 * time.Second), true, -1},
+		{"too late", code, at.Add(90 * time.Second), false, 0},
+		{"with spaces", " " + code + " ", at, true, 0},
+		{"wrong length", code[:5], at, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok, err := totp.Verify(secret, tt.code, tt.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tt.ok {
+				t.Fatalf("expected %v, got %v", tt.ok, ok)
+			}
+			if ok && step != totp.Step(tt.at)+tt.offset {
+				t.Errorf("expected the code's own step %d, got %d", totp.Step(at), step)
+			}
+		})
+	}
+
+	if _, _, err := totp.Verify("not base32!", code, at); !errors.Is(err, ErrInvalidSecret) {
+		t.Errorf("expected ErrInvalidSecret, got %v", err)
+	}
+	if _, err := (&TOTP{Algorithm: "MD5", Digits: 6, Period: 30 * time.Second}).Generate(secret, at); !errors.Is(err, ErrInvalidAlgorithm) {
+		t.Errorf("expected ErrInvalidAlgorithm, got %v", err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := New().URI("News Portal", "sari@example.com", "JBSWY3DPEHPK3PXP")
+	if !strings.HasPrefix(uri, "otpauth://totp/News%20Portal:sari@example.com?") {
+		t.Errorf("unexpected label in %s", uri)
+	}
+	for _, param := range []string{"secret=JBSWY3DPEHPK3PXP", "issuer=News+Portal", "algorithm=SHA1", "digits=6", "period=30"} {
+		if !strings.Contains(uri, param) {
+			t.Errorf("expected %s in %s", param, uri)
+		}
+	}
+}
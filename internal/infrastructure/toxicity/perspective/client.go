@@ -0,0 +1,102 @@
+package perspective
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/moderation"
+)
+
+const DefaultBaseURL = "https://commentanalyzer.googleapis.com"
+
+var ErrUnsupportedLanguage = errors.New("perspective does not score this language")
+
+// supported are the portal's languages Perspective has a TOXICITY model for
+var supported = map[moderation.Language]bool{moderation.LanguageIndonesian: true, moderation.LanguageEnglish: true}
+
+// Client scores comments with the Perspective API's TOXICITY attribute.
+// Comments are sent with doNotStore, they are not kept to train models.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+}
+
+func NewClient(httpClient *http.Client, baseURL, apiKey string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpClient, baseURL: strings.TrimRight(baseURL, "/"), apiKey: apiKey}
+}
+
+var _ moderation.ToxicityScorer = (*Client)(nil)
+
+type analyzeRequest struct {
+	Comment struct {
+		Text string `json:"text"`
+	} `json:"comment"`
+	Languages           []string            `json:"languages,omitempty"`
+	RequestedAttributes map[string]struct{} `json:"requestedAttributes"`
+	DoNotStore          bool                `json:"doNotStore"`
+}
+
+type analyzeResponse struct {
+	AttributeScores map[string]struct {
+		SummaryScore struct {
+			Value float64 `json:"value"`
+		} `json:"summaryScore"`
+	} `json:"attributeScores"`
+}
+
+// Toxicity scores the text. An undetected language is left for Perspective to
+// detect; Javanese and Sundanese are refused before calling out.
+func (c *Client) Toxicity(ctx context.Context, text string, language moderation.Language) (float64, error) {
+	body := analyzeRequest{RequestedAttributes: map[string]struct{}{"TOXICITY": {}}, DoNotStore: true}
+	body.Comment.Text = text
+	switch {
+	case supported[language]:
+		body.Languages = []string{string(language)}
+	case language != moderation.LanguageUnknown:
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, language)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+	endpoint := c.baseURL + "/v1alpha1/comments:analyze?key=" + url.QueryEscape(c.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to score comment: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// 400 also covers a detected language Perspective cannot score
+		return 0, fmt.Errorf("failed to score comment: Perspective returned %d", resp.StatusCode)
+	}
+
+	var analyzed analyzeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&analyzed); err != nil {
+		return 0, fmt.Errorf("failed to decode toxicity: %w", err)
+	}
+	toxicity, ok := analyzed.AttributeScores["TOXICITY"]
+	if !ok {
+		return 0, errors.New("failed to score comment: no TOXICITY score returned")
+	}
+	return toxicity.SummaryScore.Value, nil
+}
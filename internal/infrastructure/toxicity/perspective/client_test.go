@@ -0,0 +1,50 @@
+package perspective
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/engagement/moderation"
+)
+
+func TestClient_Toxicity(t *testing.T) {
+	var requests []analyzeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1alpha1/comments:analyze" || r.URL.Query().Get("key") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var req analyzeRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+		_, _ = w.Write([]byte(`{"attributeScores":{"TOXICITY":{"summaryScore":{"value":0.87,"type":"PROBABILITY"}}},"languages":["id"]}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.Client(), server.URL, "secret")
+	ctx := context.Background()
+
+	toxicity, err := client.Toxicity(ctx, "Penulisnya goblok", moderation.LanguageIndonesian)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if toxicity != 0.87 || !requests[0].DoNotStore || !slices.Equal(requests[0].Languages, []string{"id"}) {
+		t.Errorf("expected 0.87 from a doNotStore request in Indonesian, got %v from %+v", toxicity, requests[0])
+	}
+
+	if _, err := client.Toxicity(ctx, "Goblok!", moderation.LanguageUnknown); err != nil || requests[1].Languages != nil {
+		t.Errorf("expected Perspective left to detect the language, got %v and %+v", err, requests[1])
+	}
+
+	if _, err := client.Toxicity(ctx, "Aku ora setuju", moderation.LanguageJavanese); !errors.Is(err, ErrUnsupportedLanguage) || len(requests) != 2 {
+		t.Errorf("expected error '%v' without a request, got '%v'", ErrUnsupportedLanguage, err)
+	}
+
+	if _, err := NewClient(server.Client(), server.URL, "wrong").Toxicity(ctx, "halo", moderation.LanguageIndonesian); err == nil {
+		t.Error("expected an error for a rejected key")
+	}
+}
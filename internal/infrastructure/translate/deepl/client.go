@@ -0,0 +1,110 @@
+package deepl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/content/translation"
+)
+
+const (
+	DefaultBaseURL = "https://api.deepl.com"
+	FreeBaseURL    = "https://api-free.deepl.com"
+)
+
+// ProviderName is the key the translation service knows this adapter by
+const ProviderName = "deepl"
+
+// maxSegments is DeepL's limit of texts per request
+const maxSegments = 50
+
+// targets DeepL wants with a regional variant
+var targets = map[string]string{"en": "EN-US", "pt": "PT-BR"}
+
+// Client translates with the DeepL API v2. Segments are sent with HTML tag
+// handling, so markup survives and translate="no" spans are left alone.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	authKey    string
+}
+
+func NewClient(httpClient *http.Client, baseURL, authKey string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{httpClient: httpClient, baseURL: strings.TrimRight(baseURL, "/"), authKey: authKey}
+}
+
+var _ translation.Provider = (*Client)(nil)
+
+type translateResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+func (c *Client) Translate(ctx context.Context, segments []string, source, target string) ([]string, error) {
+	translated := make([]string, 0, len(segments))
+	for start := 0; start < len(segments); start += maxSegments {
+		batch := segments[start:min(start+maxSegments, len(segments))]
+		out, err := c.translate(ctx, batch, source, target)
+		if err != nil {
+			return nil, err
+		}
+		translated = append(translated, out...)
+	}
+	return translated, nil
+}
+
+func (c *Client) translate(ctx context.Context, segments []string, source, target string) ([]string, error) {
+	form := url.Values{
+		"source_lang":  {strings.ToUpper(source)},
+		"target_lang":  {targetCode(target)},
+		"tag_handling": {"html"},
+		"text":         segments,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v2/translate", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// 456 is DeepL's "quota exceeded"
+		return nil, fmt.Errorf("failed to translate: DeepL returned %d", resp.StatusCode)
+	}
+
+	var body translateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode translation: %w", err)
+	}
+	if len(body.Translations) != len(segments) {
+		return nil, translation.ErrProviderMismatched
+	}
+	out := make([]string, len(body.Translations))
+	for i, t := range body.Translations {
+		out[i] = t.Text
+	}
+	return out, nil
+}
+
+func targetCode(language string) string {
+	if code, ok := targets[language]; ok {
+		return code
+	}
+	return strings.ToUpper(language)
+}
@@ -0,0 +1,51 @@
+package deepl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClient_Translate(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v2/translate" || r.Header.Get("Authorization") != "DeepL-Auth-Key secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = r.ParseForm()
+		if r.Form.Get("source_lang") != "ID" || r.Form.Get("target_lang") != "EN-US" || r.Form.Get("tag_handling") != "html" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var resp translateResponse
+		for _, text := range r.Form["text"] {
+			resp.Translations = append(resp.Translations, struct {
+				Text string `json:"text"`
+			}{Text: strings.ToUpper(text)})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	segments := make([]string, maxSegments+1)
+	for i := range segments {
+		segments[i] = "<p>halo</p>"
+	}
+	got, err := NewClient(server.Client(), server.URL, "secret").Translate(context.Background(), segments, "id", "en")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 || len(got) != len(segments) || !slices.Contains(got, "<P>HALO</P>") {
+		t.Errorf("expected two batches covering every segment, got %d requests, %d segments", requests, len(got))
+	}
+
+	if _, err := NewClient(server.Client(), server.URL, "wrong").Translate(context.Background(), []string{"<p>halo</p>"}, "id", "en"); err == nil {
+		t.Error("expected an error for a rejected key")
+	}
+}
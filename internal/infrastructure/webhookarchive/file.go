@@ -0,0 +1,68 @@
+package webhookarchive
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jokosaputro95/news-portal-cms/internal/domain/operations/webhook"
+)
+
+var (
+	ErrInvalidKey      = errors.New("invalid archive key")
+	ErrPayloadNotFound = errors.New("archived payload not found")
+)
+
+// FileArchive keeps payloads under a directory, one file per key. Files are
+// created exclusively and read-only, an archived payload is never replaced.
+type FileArchive struct {
+	root string
+}
+
+func NewFileArchive(root string) *FileArchive {
+	return &FileArchive{root: root}
+}
+
+var _ webhook.PayloadArchive = (*FileArchive)(nil)
+
+func (a *FileArchive) Put(ctx context.Context, key string, body []byte) error {
+	path, err := a.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o444)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (a *FileArchive) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := a.path(key)
+	if err != nil {
+		return nil, err
+	}
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrPayloadNotFound
+	}
+	return body, err
+}
+
+// path keeps keys like "payments/2025/09/01/<id>" inside the root
+func (a *FileArchive) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, `\`) || !filepath.IsLocal(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(a.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,33 @@
+package webhookarchive
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFileArchive(t *testing.T) {
+	archive := NewFileArchive(t.TempDir())
+	ctx := context.Background()
+	key := "payments/2025/09/01/d1"
+
+	if err := archive.Put(ctx, key, []byte(`{"id":"evt_1"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, err := archive.Get(ctx, key)
+	if err != nil || string(body) != `{"id":"evt_1"}` {
+		t.Fatalf("expected the archived payload, got %q %v", body, err)
+	}
+
+	if err := archive.Put(ctx, key, []byte(`{}`)); err == nil {
+		t.Error("expected an archived payload to never be replaced")
+	}
+	if _, err := archive.Get(ctx, "payments/2025/09/01/d2"); !errors.Is(err, ErrPayloadNotFound) {
+		t.Errorf("expected error '%v', got '%v'", ErrPayloadNotFound, err)
+	}
+	for _, bad := range []string{"", "../outside", "/etc/passwd", `payments\d1`} {
+		if err := archive.Put(ctx, bad, nil); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("key %q: expected error '%v', got '%v'", bad, ErrInvalidKey, err)
+		}
+	}
+}